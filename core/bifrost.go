@@ -46,12 +46,14 @@ import (
 	"github.com/maximhq/bifrost/core/providers/runway"
 	"github.com/maximhq/bifrost/core/providers/sarvam"
 	"github.com/maximhq/bifrost/core/providers/sgl"
+	"github.com/maximhq/bifrost/core/providers/translated"
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	"github.com/maximhq/bifrost/core/providers/vertex"
 	"github.com/maximhq/bifrost/core/providers/vllm"
 	"github.com/maximhq/bifrost/core/providers/wafer"
 	"github.com/maximhq/bifrost/core/providers/xai"
 	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/valyala/fasthttp"
 )
 
@@ -63,6 +65,7 @@ type ChannelMessage struct {
 	Response       chan *schemas.BifrostResponse
 	ResponseStream chan chan *schemas.BifrostStreamChunk
 	Err            chan schemas.BifrostError
+	queuedAt       time.Time // when the request was handed to its provider queue
 }
 
 // Bifrost manages providers and maintains specified open channels for concurrent processing.
@@ -92,9 +95,21 @@ type Bifrost struct {
 	mcpCredStore        schemas.MCPCredentialStore          // Per-call credential resolver for MCP tool execution (wraps oauth2Provider for OAuth-flavored auth types)
 	mcpInitOnce         sync.Once                           // Ensures MCP manager is initialized only once
 	dropExcessRequests  atomic.Bool                         // If true, in cases where the queue is full, requests will not wait for the queue to be empty and will be dropped instead.
+	shedCounts          sync.Map                            // shedCountKey -> *atomic.Int64, requests shed because a provider queue was saturated
+	inFlight            atomic.Int64                        // requests inside handleRequest/handleStreamRequest, see GetInFlightRequests
 	keySelector         schemas.KeySelector                 // Custom key selector function
+	customKeySelector   bool                                // keySelector was set in BifrostConfig and overrides LoadBalancingStrategy
+	keyLoad             *keyselectors.LoadTracker           // in-flight requests and latency per key, for load-aware strategies
 	keyPoolFilter       schemas.KeyPoolFilter               // optional hook to veto keys before selection (nil = all eligible)
 	kvStore             schemas.KVStore                     // optional KV store for session stickiness (nil = disabled)
+	sessionAffinity     schemas.SessionAffinityStore        // optional store pinning sessions to a provider/model/key (nil = disabled)
+	modelGroups         atomic.Pointer[modelGroupIndex]     // model groups by name, resolved to their targets before routing
+	region              string                              // deployment region used to pick same-region provider endpoints
+	retryBudget         *retryBudget                        // global cap on retries as a share of recent requests (nil = unlimited)
+	imagePreprocessor   *imagePreprocessor                  // normalizes chat images per attempt (nil = images sent as received)
+	confidentialFields  *confidentialFields                 // seals encrypted message fields until dispatch (nil = not recognized)
+	requestFlags        atomic.Pointer[requestFlagSet]      // feature flags evaluated once per request, after PreRequestHooks
+	pluginGuards        *pluginGuards                       // error budgets of degradable plugins, which are bypassed once spent
 }
 
 // ProviderQueue wraps a provider's request channel with lifecycle management
@@ -130,11 +145,37 @@ type Bifrost struct {
 //     In all cases, once no producer goroutine holds a reference to the
 //     ProviderQueue, both the struct and pq.queue are eligible for GC.
 //     No explicit close is needed.
+//
+// Requests are queued in one of two lanes by schemas.RequestPriority: queue holds
+// interactive requests and batchQueue holds batch requests. Workers only take from
+// batchQueue when queue is empty, and each lane has its own BufferSize capacity, so a
+// batch backlog neither delays nor (with DropExcessRequests) crowds out interactive
+// traffic. batchQueue follows the same never-closed rule as queue.
 type ProviderQueue struct {
 	queue      chan *ChannelMessage // the actual request queue channel — never closed, see above
+	batchQueue chan *ChannelMessage // lower-priority lane for batch requests — never closed
 	done       chan struct{}        // closed by signalClosing() to signal shutdown; never written to otherwise
 	closing    uint32               // atomic: 0 = open, 1 = closing
 	signalOnce sync.Once
+	drained    drainMeter // requests taken off either lane and their wait, for Retry-After and scaling stats
+}
+
+// newProviderQueue returns an open ProviderQueue whose lanes each buffer bufferSize requests.
+func newProviderQueue(bufferSize int) *ProviderQueue {
+	return &ProviderQueue{
+		queue:      make(chan *ChannelMessage, bufferSize),
+		batchQueue: make(chan *ChannelMessage, bufferSize),
+		done:       make(chan struct{}),
+		signalOnce: sync.Once{},
+	}
+}
+
+// laneFor returns the lane a request with the given context is queued in.
+func (pq *ProviderQueue) laneFor(ctx context.Context) chan *ChannelMessage {
+	if priority, _ := ctx.Value(schemas.BifrostContextKeyRequestPriority).(schemas.RequestPriority); priority == schemas.RequestPriorityBatch {
+		return pq.batchQueue
+	}
+	return pq.queue
 }
 
 func isLargePayloadPassthrough(ctx *schemas.BifrostContext) bool {
@@ -193,6 +234,12 @@ type PluginPipeline struct {
 
 	// Plugin logging: cached scoped contexts for streaming post-hooks (reused across chunks)
 	streamScopedCtxs map[string]*schemas.BifrostContext
+
+	// guards are the error budgets of degradable plugins; bypassedPlugins are
+	// the plugins skipped in this request's pre-hooks, whose post-hooks are
+	// skipped too.
+	guards          *pluginGuards
+	bypassedPlugins []string
 }
 
 // pluginTimingAccumulator accumulates timing information for a plugin across streaming chunks
@@ -233,18 +280,20 @@ func Init(ctx context.Context, config schemas.BifrostConfig) (*Bifrost, error) {
 
 	bifrostCtx, cancel := schemas.NewBifrostContextWithCancel(ctx)
 	bifrost := &Bifrost{
-		ctx:           bifrostCtx,
-		cancel:        cancel,
-		account:       config.Account,
-		llmPlugins:    atomic.Pointer[[]schemas.LLMPlugin]{},
-		mcpPlugins:    atomic.Pointer[[]schemas.MCPPlugin]{},
-		requestQueues: sync.Map{},
-		waitGroups:    sync.Map{},
-		keySelector:   config.KeySelector,
-		keyPoolFilter: config.KeyPoolFilter,
-		mcpCredStore:  credstore.NewCredStore(config.OAuth2Provider, config.MCPHeadersProvider, config.Logger),
-		logger:        config.Logger,
-		kvStore:       config.KVStore,
+		ctx:             bifrostCtx,
+		cancel:          cancel,
+		account:         config.Account,
+		llmPlugins:      atomic.Pointer[[]schemas.LLMPlugin]{},
+		mcpPlugins:      atomic.Pointer[[]schemas.MCPPlugin]{},
+		requestQueues:   sync.Map{},
+		waitGroups:      sync.Map{},
+		keySelector:     config.KeySelector,
+		keyPoolFilter:   config.KeyPoolFilter,
+		mcpCredStore:    credstore.NewCredStore(config.OAuth2Provider, config.MCPHeadersProvider, config.Logger),
+		logger:          config.Logger,
+		kvStore:         config.KVStore,
+		sessionAffinity: config.SessionAffinityStore,
+		region:          config.Region,
 	}
 	bifrost.tracer.Store(&tracerWrapper{tracer: tracer})
 	if config.LLMPlugins == nil {
@@ -261,9 +310,46 @@ func Init(ctx context.Context, config schemas.BifrostConfig) (*Bifrost, error) {
 
 	bifrost.dropExcessRequests.Store(config.DropExcessRequests)
 
+	if err := bifrost.UpdateModelGroups(config.ModelGroups); err != nil {
+		cancel()
+		return nil, fmt.Errorf("invalid model groups: %w", err)
+	}
+
+	bifrost.pluginGuards = newPluginGuards(bifrost.logger)
+
+	if err := bifrost.UpdateRequestFlags(config.RequestFlags); err != nil {
+		cancel()
+		return nil, fmt.Errorf("invalid request flags: %w", err)
+	}
+
+	if config.RetryBudget != nil {
+		if err := config.RetryBudget.Validate(); err != nil {
+			cancel()
+			return nil, fmt.Errorf("invalid retry budget: %w", err)
+		}
+		bifrost.retryBudget = newRetryBudget(config.RetryBudget)
+	}
+
+	if config.ImagePreprocessing != nil {
+		if err := config.ImagePreprocessing.Validate(); err != nil {
+			cancel()
+			return nil, fmt.Errorf("invalid image preprocessing config: %w", err)
+		}
+		bifrost.imagePreprocessor = newImagePreprocessor(config.ImagePreprocessing)
+	}
+
+	confidential, err := newConfidentialFields(config.ConfidentialFields)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("invalid confidential fields config: %w", err)
+	}
+	bifrost.confidentialFields = confidential
+
+	bifrost.customKeySelector = bifrost.keySelector != nil
 	if bifrost.keySelector == nil {
 		bifrost.keySelector = keyselectors.WeightedRandom
 	}
+	bifrost.keyLoad = keyselectors.NewLoadTracker()
 
 	// Initialize object pools
 	bifrost.channelMessagePool = sync.Pool{
@@ -771,10 +857,38 @@ func (bifrost *Bifrost) ChatCompletionRequest(ctx *schemas.BifrostContext, req *
 		ctx = bifrost.ctx
 	}
 
+	// With x-bf-validate-structured-output, a json_schema response_format is
+	// also checked on the way back. Compile the schema up front so an invalid
+	// one fails before anything is sent.
+	var structuredOutput *jsonschema.Schema
+	if validate, _ := ctx.Value(schemas.BifrostContextKeyValidateStructuredOutput).(bool); validate && req != nil {
+		var schemaErr error
+		if structuredOutput, schemaErr = structuredOutputSchema(req.Params); schemaErr != nil {
+			return nil, &schemas.BifrostError{
+				IsBifrostError: false,
+				StatusCode:     schemas.Ptr(fasthttp.StatusBadRequest),
+				Error: &schemas.ErrorField{
+					Message: schemaErr.Error(),
+					Error:   schemaErr,
+				},
+				ExtraFields: schemas.BifrostErrorExtraFields{
+					RequestType:            schemas.ChatCompletionRequest,
+					Provider:               req.Provider,
+					OriginalModelRequested: req.Model,
+				},
+			}
+		}
+	}
+
 	response, err := bifrost.makeChatCompletionRequest(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	if structuredOutput != nil {
+		if response, err = bifrost.enforceStructuredOutput(ctx, req, structuredOutput, response); err != nil {
+			return nil, err
+		}
+	}
 
 	// Check if we should enter agent mode.
 	if bifrost.MCPManager != nil {
@@ -970,6 +1084,60 @@ func (bifrost *Bifrost) CountTokensRequest(ctx *schemas.BifrostContext, req *sch
 	return response.CountTokensResponse, nil
 }
 
+// CountTokens counts the input tokens of a chat, responses or text completion
+// request without running it, so callers can check that it fits the model's
+// context window (or a budget) before sending it. Chat and text completion
+// requests are counted as their responses-format equivalent. The provider's
+// own counter is used where it has one (OpenAI input_tokens, Anthropic
+// count_tokens, Gemini and Vertex countTokens, Bedrock CountTokens); providers
+// without one get a local estimate with Estimated set.
+func (bifrost *Bifrost) CountTokens(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostCountTokensResponse, *schemas.BifrostError) {
+	var responsesReq *schemas.BifrostResponsesRequest
+	switch {
+	case req == nil:
+	case req.ChatRequest != nil:
+		responsesReq = req.ChatRequest.ToResponsesRequest()
+	case req.ResponsesRequest != nil:
+		responsesReq = req.ResponsesRequest
+	case req.CountTokensRequest != nil:
+		responsesReq = req.CountTokensRequest
+	case req.TextCompletionRequest != nil:
+		if chatReq := req.TextCompletionRequest.ToBifrostChatRequest(); chatReq != nil {
+			responsesReq = chatReq.ToResponsesRequest()
+		}
+	}
+	if responsesReq == nil {
+		return nil, &schemas.BifrostError{
+			IsBifrostError: false,
+			Error: &schemas.ErrorField{
+				Message: "count tokens needs a chat, responses or text completion request",
+			},
+			ExtraFields: schemas.BifrostErrorExtraFields{
+				RequestType: schemas.CountTokensRequest,
+			},
+		}
+	}
+
+	response, bifrostErr := bifrost.CountTokensRequest(ctx, responsesReq)
+	if bifrostErr == nil || bifrostErr.Error == nil || bifrostErr.Error.Code == nil || *bifrostErr.Error.Code != "unsupported_operation" {
+		return response, bifrostErr
+	}
+	inputTokens := schemas.EstimateInputTokens(responsesReq)
+	return &schemas.BifrostCountTokensResponse{
+		Object:      "response.input_tokens",
+		Model:       responsesReq.Model,
+		InputTokens: inputTokens,
+		TotalTokens: &inputTokens,
+		Estimated:   true,
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			RequestType:            schemas.CountTokensRequest,
+			Provider:               responsesReq.Provider,
+			OriginalModelRequested: responsesReq.Model,
+			ResolvedModelUsed:      responsesReq.Model,
+		},
+	}, nil
+}
+
 // CompactionRequest compacts a conversation context window via providers that implement
 // the OpenAI-compatible /v1/responses/compact flow (OpenAI, Azure OpenAI, xAI).
 // Providers without compaction support return an unsupported-operation error.
@@ -2767,6 +2935,102 @@ func (bifrost *Bifrost) CachedContentDeleteRequest(ctx *schemas.BifrostContext,
 	return response.CachedContentDeleteResponse, nil
 }
 
+// FineTuningJobCreateRequest launches a fine-tuning job against a base model.
+func (bifrost *Bifrost) FineTuningJobCreateRequest(ctx *schemas.BifrostContext, req *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	if req == nil {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "fine-tuning job create request is nil"}}
+	}
+	if req.Provider == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "provider is required for fine-tuning job create request"}}
+	}
+	if req.Model == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "model is required for fine-tuning job create request"}}
+	}
+	if req.TrainingFile == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "training_file is required for fine-tuning job create request"}}
+	}
+	if ctx == nil {
+		ctx = bifrost.ctx
+	}
+	bifrostReq := bifrost.getBifrostRequest()
+	bifrostReq.RequestType = schemas.FineTuningJobCreateRequest
+	bifrostReq.FineTuningJobCreateRequest = req
+	response, err := bifrost.handleRequest(ctx, bifrostReq)
+	if err != nil {
+		return nil, err
+	}
+	return response.FineTuningJobCreateResponse, nil
+}
+
+// FineTuningJobListRequest lists fine-tuning jobs.
+func (bifrost *Bifrost) FineTuningJobListRequest(ctx *schemas.BifrostContext, req *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	if req == nil {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "fine-tuning job list request is nil"}}
+	}
+	if req.Provider == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "provider is required for fine-tuning job list request"}}
+	}
+	if ctx == nil {
+		ctx = bifrost.ctx
+	}
+	bifrostReq := bifrost.getBifrostRequest()
+	bifrostReq.RequestType = schemas.FineTuningJobListRequest
+	bifrostReq.FineTuningJobListRequest = req
+	response, err := bifrost.handleRequest(ctx, bifrostReq)
+	if err != nil {
+		return nil, err
+	}
+	return response.FineTuningJobListResponse, nil
+}
+
+// FineTuningJobRetrieveRequest polls a single fine-tuning job by ID.
+func (bifrost *Bifrost) FineTuningJobRetrieveRequest(ctx *schemas.BifrostContext, req *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	if req == nil {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "fine-tuning job retrieve request is nil"}}
+	}
+	if req.Provider == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "provider is required for fine-tuning job retrieve request"}}
+	}
+	if req.JobID == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "job_id is required for fine-tuning job retrieve request"}}
+	}
+	if ctx == nil {
+		ctx = bifrost.ctx
+	}
+	bifrostReq := bifrost.getBifrostRequest()
+	bifrostReq.RequestType = schemas.FineTuningJobRetrieveRequest
+	bifrostReq.FineTuningJobRetrieveRequest = req
+	response, err := bifrost.handleRequest(ctx, bifrostReq)
+	if err != nil {
+		return nil, err
+	}
+	return response.FineTuningJobRetrieveResponse, nil
+}
+
+// FineTuningJobCancelRequest cancels a running fine-tuning job.
+func (bifrost *Bifrost) FineTuningJobCancelRequest(ctx *schemas.BifrostContext, req *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	if req == nil {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "fine-tuning job cancel request is nil"}}
+	}
+	if req.Provider == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "provider is required for fine-tuning job cancel request"}}
+	}
+	if req.JobID == "" {
+		return nil, &schemas.BifrostError{IsBifrostError: false, Error: &schemas.ErrorField{Message: "job_id is required for fine-tuning job cancel request"}}
+	}
+	if ctx == nil {
+		ctx = bifrost.ctx
+	}
+	bifrostReq := bifrost.getBifrostRequest()
+	bifrostReq.RequestType = schemas.FineTuningJobCancelRequest
+	bifrostReq.FineTuningJobCancelRequest = req
+	response, err := bifrost.handleRequest(ctx, bifrostReq)
+	if err != nil {
+		return nil, err
+	}
+	return response.FineTuningJobCancelResponse, nil
+}
+
 func (bifrost *Bifrost) Passthrough(
 	ctx *schemas.BifrostContext,
 	provider schemas.ModelProvider,
@@ -3302,6 +3566,7 @@ func (bifrost *Bifrost) ContainerFileDeleteRequest(ctx *schemas.BifrostContext,
 
 // RemovePlugin removes a plugin from the server.
 func (bifrost *Bifrost) RemovePlugin(name string, pluginTypes []schemas.PluginType) error {
+	bifrost.pluginGuards.forget(name)
 	for _, pluginType := range pluginTypes {
 		switch pluginType {
 		case schemas.PluginTypeLLM:
@@ -3394,6 +3659,7 @@ func (bifrost *Bifrost) removeMCPPlugin(name string) error {
 // ReloadPlugin reloads a plugin with new instance
 // During the reload - it's stop the world phase where we take a global lock on the plugin mutex
 func (bifrost *Bifrost) ReloadPlugin(plugin schemas.BasePlugin, pluginTypes []schemas.PluginType) error {
+	bifrost.pluginGuards.forget(plugin.GetName())
 	for _, pluginType := range pluginTypes {
 		switch pluginType {
 		case schemas.PluginTypeLLM:
@@ -3717,11 +3983,7 @@ func (bifrost *Bifrost) UpdateProvider(providerKey schemas.ModelProvider) error
 	}
 
 	// Step 2: Create new ProviderQueue and wait group with updated settings.
-	newPq := &ProviderQueue{
-		queue:      make(chan *ChannelMessage, providerConfig.ConcurrencyAndBufferSize.BufferSize),
-		done:       make(chan struct{}),
-		signalOnce: sync.Once{},
-	}
+	newPq := newProviderQueue(providerConfig.ConcurrencyAndBufferSize.BufferSize)
 	newWaitGroup := &sync.WaitGroup{}
 
 	// Step 3: Atomically replace the provider in the providers slice before new
@@ -3787,50 +4049,10 @@ func (bifrost *Bifrost) UpdateProvider(providerKey schemas.ModelProvider) error
 	// signalling workers to stop. Old workers are still running and may consume some
 	// items concurrently — that is fine, they process them normally. Since new
 	// workers are already running, successful transfers can be processed immediately.
-	transferredCount := 0
-	cancelledCount := 0
-	for {
-		select {
-		case msg := <-oldPq.queue:
-			select {
-			case newPq.queue <- msg:
-				transferredCount++
-			default:
-				// newPq is full — cancel this message and all remaining in oldPq.
-				cancelMsg := func(r *ChannelMessage) {
-					prov, mod, _ := r.BifrostRequest.GetRequestFields()
-					select {
-					case r.Err <- schemas.BifrostError{
-						IsBifrostError: false,
-						Error:          &schemas.ErrorField{Message: "request failed during provider concurrency update: queue full"},
-						ExtraFields: schemas.BifrostErrorExtraFields{
-							RequestType:            r.RequestType,
-							Provider:               prov,
-							OriginalModelRequested: mod,
-						},
-					}:
-					case <-r.Context.Done():
-					}
-				}
-				cancelMsg(msg)
-				cancelledCount++
-				for {
-					select {
-					case r := <-oldPq.queue:
-						cancelMsg(r)
-						cancelledCount++
-					default:
-						goto transferComplete
-					}
-				}
-			}
-		default:
-			// No more buffered messages
-			goto transferComplete
-		}
-	}
-
-transferComplete:
+	transferredCount, cancelledCount := transferQueuedRequests(oldPq.queue, newPq.queue)
+	batchTransferred, batchCancelled := transferQueuedRequests(oldPq.batchQueue, newPq.batchQueue)
+	transferredCount += batchTransferred
+	cancelledCount += batchCancelled
 	if transferredCount > 0 {
 		bifrost.logger.Info("transferred %d buffered requests to new queue for provider %s", transferredCount, providerKey)
 	}
@@ -4279,9 +4501,18 @@ func (bifrost *Bifrost) UpdateToolManagerConfig(maxAgentDepth int, toolExecution
 
 // createBaseProvider creates a provider based on the base provider type
 func (bifrost *Bifrost) createBaseProvider(providerKey schemas.ModelProvider, config *schemas.ProviderConfig) (schemas.Provider, error) {
+	// Prefer the provider's same-region endpoint when one is configured
+	config = config.ForRegion(bifrost.region)
+
 	// Determine which provider type to create
 	targetProviderKey := providerKey
 
+	if config.CustomProviderConfig != nil && config.CustomProviderConfig.Translator != "" {
+		// Served by a user-registered translator rather than a built-in provider
+		config.CustomProviderConfig.CustomProviderKey = string(providerKey)
+		return translated.NewTranslatedProvider(config, bifrost.logger)
+	}
+
 	if config.CustomProviderConfig != nil {
 		// Validate custom provider config
 		if config.CustomProviderConfig.BaseProviderType == "" {
@@ -4370,11 +4601,7 @@ func (bifrost *Bifrost) createBaseProvider(providerKey schemas.ModelProvider, co
 // Note: This function assumes the caller has already acquired the appropriate mutex for the provider.
 func (bifrost *Bifrost) prepareProvider(providerKey schemas.ModelProvider, config *schemas.ProviderConfig) error {
 	// Create ProviderQueue with lifecycle management
-	pq := &ProviderQueue{
-		queue:      make(chan *ChannelMessage, config.ConcurrencyAndBufferSize.BufferSize),
-		done:       make(chan struct{}),
-		signalOnce: sync.Once{},
-	}
+	pq := newProviderQueue(config.ConcurrencyAndBufferSize.BufferSize)
 
 	bifrost.requestQueues.Store(providerKey, pq)
 
@@ -4414,6 +4641,54 @@ func (bifrost *Bifrost) prepareProvider(providerKey schemas.ModelProvider, confi
 	return nil
 }
 
+// ProviderQueueStats is a point-in-time view of a provider's request queue.
+type ProviderQueueStats struct {
+	Depth      int  `json:"depth"`       // interactive requests currently buffered, waiting for a worker
+	BatchDepth int  `json:"batch_depth"` // batch requests currently buffered; served only while Depth is 0
+	Capacity   int  `json:"capacity"`    // configured buffer size of each priority lane
+	Closing    bool `json:"closing"`     // true while the queue is being drained for an update or removal
+	// DrainRate is the requests per second workers took off the queue over the last 10 seconds.
+	DrainRate float64 `json:"drain_rate"`
+	// AvgWaitMs is how long those requests waited in the queue for a worker, on average.
+	AvgWaitMs float64 `json:"avg_wait_ms"`
+	// Shed counts requests turned away with a 429 because the queue was saturated, by reason.
+	Shed map[schemas.ShedReason]int64 `json:"shed,omitempty"`
+}
+
+// GetProviderQueueStats returns the per-lane depth and capacity of every provider request queue.
+// Values are sampled without locking and are intended for diagnostics only.
+func (bifrost *Bifrost) GetProviderQueueStats() map[schemas.ModelProvider]ProviderQueueStats {
+	providerStats := make(map[schemas.ModelProvider]ProviderQueueStats)
+	bifrost.requestQueues.Range(func(key, value any) bool {
+		providerKey, ok := key.(schemas.ModelProvider)
+		if !ok {
+			return true
+		}
+		pq, ok := value.(*ProviderQueue)
+		if !ok || pq == nil {
+			return true
+		}
+		stats := ProviderQueueStats{
+			Depth:      len(pq.queue),
+			BatchDepth: len(pq.batchQueue),
+			Capacity:   cap(pq.queue),
+			Closing:    atomic.LoadUint32(&pq.closing) == 1,
+			Shed:       bifrost.shedCountsFor(providerKey),
+		}
+		stats.DrainRate, stats.AvgWaitMs = pq.drained.rate(time.Now())
+		providerStats[providerKey] = stats
+		return true
+	})
+	return providerStats
+}
+
+// GetInFlightRequests returns how many requests Bifrost is handling right now,
+// from admission until the response (or, for streams, the stream channel) is
+// returned. Open streams after that are not counted.
+func (bifrost *Bifrost) GetInFlightRequests() int64 {
+	return bifrost.inFlight.Load()
+}
+
 // getProviderQueue returns the ProviderQueue for a given provider key.
 // If the queue doesn't exist, it creates one at runtime and initializes the provider,
 // given the provider config is provided in the account interface implementation.
@@ -4460,6 +4735,22 @@ func (bifrost *Bifrost) getProviderQueue(providerKey schemas.ModelProvider) (*Pr
 	return pq, nil
 }
 
+// keySelectorFor returns the key selector for a provider's load-balancing strategy. A custom
+// KeySelector from BifrostConfig takes precedence over the strategy.
+func (bifrost *Bifrost) keySelectorFor(strategy schemas.LoadBalancingStrategy) schemas.KeySelector {
+	if bifrost.customKeySelector {
+		return bifrost.keySelector
+	}
+	switch strategy {
+	case schemas.LoadBalancingLeastLatency:
+		return keyselectors.LeastLatency(bifrost.keyLoad)
+	case schemas.LoadBalancingLeastPending:
+		return keyselectors.LeastPending(bifrost.keyLoad)
+	default:
+		return bifrost.keySelector
+	}
+}
+
 // GetProviderByKey returns the provider instance for the given provider key.
 // Returns nil if no provider with the given key exists.
 func (bifrost *Bifrost) GetProviderByKey(providerKey schemas.ModelProvider) schemas.Provider {
@@ -4999,6 +5290,8 @@ func (bifrost *Bifrost) shouldContinueWithFallbacks(fallback schemas.Fallback, f
 // It is the wrapper for all non-streaming public API methods.
 func (bifrost *Bifrost) handleRequest(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (resp *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) {
 	defer bifrost.releaseBifrostRequest(req)
+	bifrost.inFlight.Add(1)
+	defer bifrost.inFlight.Add(-1)
 	provider, model, fallbacks := req.GetRequestFields()
 
 	// Handle nil context early to prevent blocking
@@ -5022,6 +5315,13 @@ func (bifrost *Bifrost) handleRequest(ctx *schemas.BifrostContext, req *schemas.
 		ctx.SetValue(schemas.BifrostContextKeyRequestID, requestID)
 	}
 
+	// Encrypted fields become placeholders before any plugin can see them.
+	if err := bifrost.sealConfidentialFields(ctx, req); err != nil {
+		return nil, err
+	}
+
+	bifrost.resolveModelGroup(ctx, req)
+
 	// PreRequestHook: once-per-request phase where plugins decide provider/model/fallbacks
 	// (and may mutate other request fields). Mutations commit to req and are observed by
 	// all downstream phases and fallbacks. Plugin errors are non-blocking (logged + skipped).
@@ -5039,10 +5339,32 @@ func (bifrost *Bifrost) handleRequest(ctx *schemas.BifrostContext, req *schemas.
 		err.PopulateExtraFields(req.RequestType, provider, model, model)
 		return nil, err
 	}
+	applyFallbacksOverride(ctx, req)
+	bifrost.applySessionAffinity(ctx, req)
+	provider, model, fallbacks = req.GetRequestFields()
+	bifrost.evaluateRequestFlags(ctx, req)
+	defer func() {
+		if bifrostErr == nil && resp != nil {
+			bifrost.recordSessionAffinity(ctx, resp.GetExtraFields().RoutingInfo)
+		}
+	}()
 
 	bifrost.logger.Debug(fmt.Sprintf("primary provider %s with model %s and %d fallbacks", provider, model, len(fallbacks)))
 
-	primaryResult, primaryErr := bifrost.tryRequest(ctx, req)
+	// With a hedge delay the first fallback may be raced against the primary; if it was,
+	// the fallback loop below starts after it.
+	var primaryResult *schemas.BifrostResponse
+	var primaryErr *schemas.BifrostError
+	fallbacksTried := 0
+	if hedgeDelay, ok := hedgeDelayFor(ctx, req); ok && bifrost.embeddingFallbackAllowed(req, fallbacks[0]) {
+		var hedged bool
+		primaryResult, primaryErr, hedged = bifrost.tryHedgedRequest(ctx, req, fallbacks[0], hedgeDelay)
+		if hedged {
+			fallbacksTried = 1
+		}
+	} else {
+		primaryResult, primaryErr = bifrost.tryRequest(ctx, req)
+	}
 	if primaryErr != nil {
 		if primaryErr.Error != nil {
 			bifrost.logger.Debug(fmt.Sprintf("primary provider %s with model %s returned error: %s", provider, model, primaryErr.Error.Message))
@@ -5073,6 +5395,13 @@ func (bifrost *Bifrost) handleRequest(ctx *schemas.BifrostContext, req *schemas.
 
 	// Try fallbacks in order
 	for i, fallback := range fallbacks {
+		if i < fallbacksTried {
+			continue
+		}
+		// Fail fast rather than silently serving vectors from another embedding space.
+		if !bifrost.embeddingFallbackAllowed(req, fallback) {
+			return nil, blockEmbeddingFallback(ctx, req, fallback, primaryErr)
+		}
 		ctx.SetValue(schemas.BifrostContextKeyFallbackIndex, i+1)
 		bifrost.logger.Debug(fmt.Sprintf("trying fallback provider %s with model %s", fallback.Provider, fallback.Model))
 		ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelInfo, fmt.Sprintf("Trying fallback %d/%d: %s/%s (previous attempt failed: %s)", i+1, len(fallbacks), fallback.Provider, fallback.Model, routingErrorSummary(lastErr)))
@@ -5135,8 +5464,10 @@ func (bifrost *Bifrost) handleRequest(ctx *schemas.BifrostContext, req *schemas.
 // It handles plugin hooks, request validation, response processing, and fallback providers.
 // If the primary provider fails, it will try each fallback provider in order until one succeeds.
 // It is the wrapper for all streaming public API methods.
-func (bifrost *Bifrost) handleStreamRequest(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+func (bifrost *Bifrost) handleStreamRequest(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (stream chan *schemas.BifrostStreamChunk, bifrostErr *schemas.BifrostError) {
 	defer bifrost.releaseBifrostRequest(req)
+	bifrost.inFlight.Add(1)
+	defer bifrost.inFlight.Add(-1)
 	provider, model, fallbacks := req.GetRequestFields()
 
 	// Handle nil context early to prevent blocking
@@ -5154,6 +5485,13 @@ func (bifrost *Bifrost) handleStreamRequest(ctx *schemas.BifrostContext, req *sc
 		ctx.SetValue(schemas.BifrostContextKeyRequestID, requestID)
 	}
 
+	// Encrypted fields become placeholders before any plugin can see them.
+	if err := bifrost.sealConfidentialFields(ctx, req); err != nil {
+		return nil, err
+	}
+
+	bifrost.resolveModelGroup(ctx, req)
+
 	// PreRequestHook: once-per-request phase. See handleRequest for semantics.
 	preReqPipeline := bifrost.getPluginPipeline()
 	preReqPipeline.RunPreRequestHooks(ctx, req)
@@ -5169,10 +5507,22 @@ func (bifrost *Bifrost) handleStreamRequest(ctx *schemas.BifrostContext, req *sc
 		err.PopulateExtraFields(req.RequestType, provider, model, model)
 		return nil, err
 	}
+	applyFallbacksOverride(ctx, req)
+	bifrost.applySessionAffinity(ctx, req)
+	provider, model, fallbacks = req.GetRequestFields()
+	bifrost.evaluateRequestFlags(ctx, req)
+	// Streams carry RoutingInfo only on chunks; the winning attempt's snapshot is on ctx.
+	defer func() {
+		if bifrostErr == nil && stream != nil {
+			if ri, ok := ctx.Value(schemas.BifrostContextKeyRoutingInfo).(schemas.RoutingInfo); ok {
+				bifrost.recordSessionAffinity(ctx, ri)
+			}
+		}
+	}()
 
 	bifrost.logger.Debug(fmt.Sprintf("primary provider %s with model %s and %d fallbacks", provider, model, len(fallbacks)))
 
-	primaryResult, primaryErr := bifrost.tryStreamRequest(ctx, req)
+	primaryResult, primaryErr := bifrost.tryStreamAttempt(ctx, req, len(fallbacks) > 0)
 	if primaryErr != nil {
 		if primaryErr.Error != nil {
 			bifrost.logger.Debug(fmt.Sprintf("primary provider %s with model %s returned error: %s", provider, model, primaryErr.Error.Message))
@@ -5223,7 +5573,7 @@ func (bifrost *Bifrost) handleStreamRequest(ctx *schemas.BifrostContext, req *sc
 		}
 
 		// Try the fallback provider
-		result, fallbackErr := bifrost.tryStreamRequest(ctx, fallbackReq)
+		result, fallbackErr := bifrost.tryStreamAttempt(ctx, fallbackReq, i < len(fallbacks)-1)
 		// Layer on Primary/IsFallback on errors. For the success case the
 		// result is a chan of stream chunks emitted asynchronously — those
 		// chunks already carry per-attempt RoutingInfo populated upstream,
@@ -5283,6 +5633,15 @@ func (bifrost *Bifrost) tryRequest(ctx *schemas.BifrostContext, req *schemas.Bif
 		return nil, bifrostErr
 	}
 
+	// Apply the parameter overrides of the model group target this attempt is for
+	req = bifrost.applyModelGroupParams(ctx, req)
+
+	// Normalize image inputs to what this attempt's provider accepts
+	req, bifrostErr := bifrost.preprocessImages(ctx, req)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
 	// Add MCP tools to request if MCP is configured and requested
 	if bifrost.MCPManager != nil {
 		req = bifrost.MCPManager.AddToolsToRequest(ctx, req)
@@ -5374,9 +5733,12 @@ func (bifrost *Bifrost) tryRequest(ctx *schemas.BifrostContext, req *schemas.Bif
 		pq = reroutedPq
 	}
 
+	// Queue in the lane for the request's priority; a full lane only affects its own class.
+	lane := pq.laneFor(ctx)
+
 	// Use select with done channel to detect shutdown during send
 	select {
-	case pq.queue <- msg:
+	case lane <- msg:
 		// Message was sent successfully
 	case <-pq.done:
 		bifrost.releaseChannelMessage(msg)
@@ -5392,7 +5754,7 @@ func (bifrost *Bifrost) tryRequest(ctx *schemas.BifrostContext, req *schemas.Bif
 		if bifrost.dropExcessRequests.Load() {
 			bifrost.releaseChannelMessage(msg)
 			bifrost.logger.Warn("request dropped: queue is full, please increase the queue size or set dropExcessRequests to false")
-			bifrostErr := newBifrostQueueFullError()
+			bifrostErr := bifrost.shedRequest(pq, lane, provider, schemas.ShedReasonQueueFull)
 			bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
 			return nil, bifrostErr
 		}
@@ -5404,7 +5766,7 @@ func (bifrost *Bifrost) tryRequest(ctx *schemas.BifrostContext, req *schemas.Bif
 			return nil, bifrostErr
 		}
 		select {
-		case pq.queue <- msg:
+		case lane <- msg:
 			// Message was sent successfully
 		case <-pq.done:
 			bifrost.releaseChannelMessage(msg)
@@ -5413,7 +5775,13 @@ func (bifrost *Bifrost) tryRequest(ctx *schemas.BifrostContext, req *schemas.Bif
 			return nil, bifrostErr
 		case <-ctx.Done():
 			bifrost.releaseChannelMessage(msg)
-			bifrostErr := newBifrostCtxDoneError(ctx, "while waiting for queue space")
+			var bifrostErr *schemas.BifrostError
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// The queue never had room before the deadline: report it as saturation.
+				bifrostErr = bifrost.shedRequest(pq, lane, provider, schemas.ShedReasonQueueWaitTimeout)
+			} else {
+				bifrostErr = newBifrostCtxDoneError(ctx, "while waiting for queue space")
+			}
 			bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
 			return nil, bifrostErr
 		}
@@ -5513,6 +5881,20 @@ func (bifrost *Bifrost) tryStreamRequest(ctx *schemas.BifrostContext, req *schem
 		return nil, bifrostErr
 	}
 
+	// Apply the parameter overrides of the model group target this attempt is for
+	req = bifrost.applyModelGroupParams(ctx, req)
+
+	// Normalize image inputs to what this attempt's provider accepts
+	req, bifrostErr := bifrost.preprocessImages(ctx, req)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	// Reject n > 1 up front for providers that can't stream several choices
+	if bifrostErr := bifrost.checkMultiCandidateStream(req); bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
 	// Add MCP tools to request if MCP is configured and requested
 	if req.RequestType != schemas.SpeechStreamRequest && req.RequestType != schemas.TranscriptionStreamRequest && bifrost.MCPManager != nil {
 		req = bifrost.MCPManager.AddToolsToRequest(ctx, req)
@@ -5708,9 +6090,12 @@ func (bifrost *Bifrost) tryStreamRequest(ctx *schemas.BifrostContext, req *schem
 		pq = reroutedPq
 	}
 
+	// Queue in the lane for the request's priority; a full lane only affects its own class.
+	lane := pq.laneFor(ctx)
+
 	// Use select with done channel to detect shutdown during send
 	select {
-	case pq.queue <- msg:
+	case lane <- msg:
 		// Message was sent successfully
 	case <-pq.done:
 		bifrost.releaseChannelMessage(msg)
@@ -5726,7 +6111,7 @@ func (bifrost *Bifrost) tryStreamRequest(ctx *schemas.BifrostContext, req *schem
 		if bifrost.dropExcessRequests.Load() {
 			bifrost.releaseChannelMessage(msg)
 			bifrost.logger.Warn("request dropped: queue is full, please increase the queue size or set dropExcessRequests to false")
-			bifrostErr := newBifrostQueueFullError()
+			bifrostErr := bifrost.shedRequest(pq, lane, provider, schemas.ShedReasonQueueFull)
 			bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
 			return nil, bifrostErr
 		}
@@ -5738,7 +6123,7 @@ func (bifrost *Bifrost) tryStreamRequest(ctx *schemas.BifrostContext, req *schem
 			return nil, bifrostErr
 		}
 		select {
-		case pq.queue <- msg:
+		case lane <- msg:
 			// Message was sent successfully
 		case <-pq.done:
 			bifrost.releaseChannelMessage(msg)
@@ -5747,7 +6132,13 @@ func (bifrost *Bifrost) tryStreamRequest(ctx *schemas.BifrostContext, req *schem
 			return nil, bifrostErr
 		case <-ctx.Done():
 			bifrost.releaseChannelMessage(msg)
-			bifrostErr := newBifrostCtxDoneError(ctx, "while waiting for queue space")
+			var bifrostErr *schemas.BifrostError
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// The queue never had room before the deadline: report it as saturation.
+				bifrostErr = bifrost.shedRequest(pq, lane, provider, schemas.ShedReasonQueueWaitTimeout)
+			} else {
+				bifrostErr = newBifrostCtxDoneError(ctx, "while waiting for queue space")
+			}
 			bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
 			return nil, bifrostErr
 		}
@@ -5815,9 +6206,13 @@ var errAllKeysFiltered = errors.New("all eligible keys are temporarily suppresse
 //     within a single request — a bad credential will not become valid by waiting.
 //
 // Network/5xx errors reuse the same key since they are transient server issues, not per-key.
+//
+// budget, when non-nil, is the gateway-wide retry budget: every call counts as one request,
+// and each retry must be granted by it or the last error is returned as-is.
 func executeRequestWithRetries[T any](
 	ctx *schemas.BifrostContext,
 	config *schemas.ProviderConfig,
+	budget *retryBudget,
 	requestHandler func(key schemas.Key) (T, *schemas.BifrostError),
 	keyProvider func(usedKeyIDs, deadKeyIDs map[string]bool) (schemas.Key, error),
 	requestType schemas.RequestType,
@@ -5828,6 +6223,14 @@ func executeRequestWithRetries[T any](
 ) (result T, bifrostError *schemas.BifrostError) {
 	var attempts int
 
+	// Bind request correlation fields so structured log lines can be joined with
+	// request logs and traces. No-op unless the logger runs in structured mode.
+	logFields := schemas.LogFieldsFromContext(ctx)
+	logFields.Provider = providerKey
+	logFields.Model = model
+	logFields.Component = "core"
+	logger = schemas.WithLogFields(logger, logFields)
+
 	// Emit the terminal routing-engine entry on every return path — including
 	// early returns from key-selection failures and tracer-missing — so the
 	// audit trail isn't truncated when execution exits before reaching the
@@ -5867,6 +6270,11 @@ func executeRequestWithRetries[T any](
 	// Index in BifrostContextKeyAttemptTrail of an attempt that hit a rate limit and is waiting
 	// to learn whether the *next* key selection actually picks a different key. -1 = no pending.
 	pendingRotationAttemptIdx := -1
+	// Minimum wait before the next attempt requested by the provider's Retry-After
+	// (only tracked when NetworkConfig.RespectRetryAfter is set).
+	var retryAfter time.Duration
+
+	budget.recordRequest()
 
 	for attempts = 0; attempts <= config.NetworkConfig.MaxRetries; attempts++ {
 		ctx.SetValue(schemas.BifrostContextKeyNumberOfRetries, attempts)
@@ -5921,10 +6329,11 @@ func executeRequestWithRetries[T any](
 				var zero T
 				// Clear any selected_key_* set by a *previous* attempt: this early return
 				// skips the terminal cleanup at the end of the function, and the invariant
-				// is that selected_key_id / selected_key_name are populated only on a
+				// is that selected_key_id / selected_key_name / selected_key_byok are populated only on a
 				// successful response. Use attempt_trail for failure attribution.
 				ctx.SetValue(schemas.BifrostContextKeySelectedKeyID, "")
 				ctx.SetValue(schemas.BifrostContextKeySelectedKeyName, "")
+				ctx.SetValue(schemas.BifrostContextKeySelectedKeyBYOK, false)
 				// Only collapse into 502 upstream_credentials_exhausted when keyProvider
 				// explicitly signals "every key is dead" via the errAllKeysDead sentinel.
 				// Any other error (custom selector failure, etc.) propagates unchanged so
@@ -5961,6 +6370,17 @@ func executeRequestWithRetries[T any](
 			currentKey = selectedKey
 			ctx.SetValue(schemas.BifrostContextKeySelectedKeyID, currentKey.ID)
 			ctx.SetValue(schemas.BifrostContextKeySelectedKeyName, currentKey.Name)
+			ctx.SetValue(schemas.BifrostContextKeySelectedKeyBYOK, currentKey.BYOK)
+
+			// Enforce the key's beta allowlist and inject its default beta flags. A rejected
+			// flag is a request problem, not a key problem, so it is not retried on another key.
+			if bifrostErr := applyKeyBetaFeatures(ctx, currentKey); bifrostErr != nil {
+				var zero T
+				ctx.SetValue(schemas.BifrostContextKeySelectedKeyID, "")
+				ctx.SetValue(schemas.BifrostContextKeySelectedKeyName, "")
+				ctx.SetValue(schemas.BifrostContextKeySelectedKeyBYOK, false)
+				return zero, bifrostErr
+			}
 
 			// Resolve any pending rotation marker from the previous failed attempt. Only mark
 			// TriggeredRotation=true if the newly selected key differs from the failed one —
@@ -6031,7 +6451,7 @@ func executeRequestWithRetries[T any](
 			ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelInfo, fmt.Sprintf("Retry %d/%d for %s/%s (previous attempt failed: %s%s)", attempts, config.NetworkConfig.MaxRetries, providerKey, model, routingErrorSummary(bifrostError), keyNote))
 
 			if !(lastWasPermanentKeyFailure && keyChanged) {
-				backoff := calculateBackoff(attempts-1, config)
+				backoff := max(calculateBackoff(attempts-1, config), retryAfter)
 				logger.Debug("sleeping for %s before retry", backoff)
 				time.Sleep(backoff)
 			}
@@ -6287,6 +6707,12 @@ func executeRequestWithRetries[T any](
 				bifrostError.Error.Message == schemas.ErrProviderNetworkError) {
 			shouldRetry = true
 			logger.Debug("detected request HTTP/network error, will retry: %s", errMessage)
+		} else if bifrostError.StatusCode != nil && len(config.NetworkConfig.RetryOnStatus) > 0 {
+			// An explicit retry_on_status list replaces the built-in status classification.
+			shouldRetry = config.NetworkConfig.RetriesStatus(*bifrostError.StatusCode)
+			if shouldRetry {
+				logger.Debug("status %d is listed in retry_on_status, will retry: %s", *bifrostError.StatusCode, errMessage)
+			}
 		} else if (bifrostError.StatusCode != nil && transientServerStatusCodes[*bifrostError.StatusCode]) || isPerKeyFailure {
 			shouldRetry = true
 			logger.Debug("encountered error that should be retried: %s", errMessage)
@@ -6318,6 +6744,30 @@ func executeRequestWithRetries[T any](
 			break
 		}
 
+		// Checks below only matter when another attempt would actually run.
+		if attempts < config.NetworkConfig.MaxRetries {
+			retryAfter = 0
+			if config.NetworkConfig.RespectRetryAfter && bifrostError.StatusCode != nil &&
+				(*bifrostError.StatusCode == 429 || *bifrostError.StatusCode == 503) {
+				if wait, ok := retryAfterFromContext(ctx); ok {
+					if wait > config.NetworkConfig.RetryBackoffMax {
+						// Waiting longer than the configured cap would stall the caller;
+						// return now so fallbacks (if any) can serve the request instead.
+						schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+						ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, fmt.Sprintf("Not retrying %s/%s: provider asked to wait %s, longer than retry_backoff_max %s", providerKey, model, wait, config.NetworkConfig.RetryBackoffMax))
+						break
+					}
+					retryAfter = wait
+				}
+			}
+			if !budget.allowRetry() {
+				schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+				ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, fmt.Sprintf("Not retrying %s/%s: global retry budget exhausted", providerKey, model))
+				logger.Debug("retry budget exhausted, not retrying request for model %s", model)
+				break
+			}
+		}
+
 		// Track key state so the next keyProvider call excludes this key. Permanent
 		// per-key failures (401/402/403) go into deadKeyIDs which is never reset within
 		// this request — a bad credential won't become valid by waiting. Transient
@@ -6372,6 +6822,7 @@ func executeRequestWithRetries[T any](
 	if bifrostError != nil && keyProvider != nil {
 		ctx.SetValue(schemas.BifrostContextKeySelectedKeyID, "")
 		ctx.SetValue(schemas.BifrostContextKeySelectedKeyName, "")
+		ctx.SetValue(schemas.BifrostContextKeySelectedKeyBYOK, false)
 	}
 
 	return result, bifrostError
@@ -6403,37 +6854,33 @@ func clearAnthropicPassthroughForNonNativeProvider(ctx *schemas.BifrostContext,
 func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas.ProviderConfig, pq *ProviderQueue, waitGroup *sync.WaitGroup) {
 	defer waitGroup.Done()
 
+	keySelector := bifrost.keySelectorFor(config.LoadBalancingStrategy)
+	// Load-aware strategies need every attempt's in-flight time and latency per key.
+	trackKeyLoad := !bifrost.customKeySelector &&
+		(config.LoadBalancingStrategy == schemas.LoadBalancingLeastLatency || config.LoadBalancingStrategy == schemas.LoadBalancingLeastPending)
+
 	for {
 		var req *ChannelMessage
+		// Interactive requests always go first: batch requests are only taken when
+		// the interactive lane is empty at the moment this worker becomes free.
 		select {
 		case r := <-pq.queue:
 			req = r
-		case <-pq.done:
-			// Provider is shutting down. Drain any buffered requests and send
-			// back errors so callers are not left blocked on their response channel.
-			for {
-				select {
-				case r := <-pq.queue:
-					provKey, mod, _ := r.GetRequestFields()
-					select {
-					case r.Err <- schemas.BifrostError{
-						IsBifrostError: false,
-						Error: &schemas.ErrorField{
-							Message: "provider is shutting down",
-						},
-						ExtraFields: schemas.BifrostErrorExtraFields{
-							RequestType:            r.RequestType,
-							Provider:               provKey,
-							OriginalModelRequested: mod,
-						},
-					}:
-					case <-r.Context.Done():
-					}
-				default:
-					return
-				}
+		default:
+			select {
+			case r := <-pq.queue:
+				req = r
+			case r := <-pq.batchQueue:
+				req = r
+			case <-pq.done:
+				// Provider is shutting down. Drain any buffered requests and send
+				// back errors so callers are not left blocked on their response channel.
+				bifrost.drainQueueWithErrors(pq)
+				return
 			}
 		}
+		now := time.Now()
+		pq.drained.mark(now, now.Sub(req.queuedAt))
 
 		_, model, _ := req.BifrostRequest.GetRequestFields()
 
@@ -6505,6 +6952,10 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 		// Strip from client response if we captured for storage but not for send-back.
 		dropReq := effectiveStore && !effectiveSendBackReq
 		dropResp := effectiveStore && !effectiveSendBackResp
+		// The raw request of a confidential request holds its decrypted fields.
+		if _, ok := req.Context.Value(schemas.BifrostContextKeyConfidentialFields).(map[string]string); ok {
+			captureReq, dropReq = false, false
+		}
 
 		// Step 3: write all internal signals explicitly (never touch the user override keys).
 		req.Context.SetValue(schemas.BifrostContextKeyCaptureRawRequest, captureReq)
@@ -6572,8 +7023,9 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 				isMultiKeyFileOp := isFileRequestType(req.RequestType) && req.RequestType != schemas.FileUploadRequest
 				isMultiKeyContainerOp := isContainerRequestType(req.RequestType) && req.RequestType != schemas.ContainerCreateRequest && req.RequestType != schemas.ContainerFileCreateRequest
 				isMultiKeyCachedContentOp := isCachedContentRequestType(req.RequestType) && req.RequestType != schemas.CachedContentCreateRequest
+				isMultiKeyFineTuningOp := isFineTuningRequestType(req.RequestType) && req.RequestType != schemas.FineTuningJobCreateRequest
 
-				if isMultiKeyBatchOp || isMultiKeyFileOp || isMultiKeyContainerOp || isMultiKeyCachedContentOp {
+				if isMultiKeyBatchOp || isMultiKeyFileOp || isMultiKeyContainerOp || isMultiKeyCachedContentOp || isMultiKeyFineTuningOp {
 					var modelPtr *string
 					if model != "" {
 						modelPtr = &model
@@ -6635,8 +7087,8 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 							return fixedKey, nil
 						}
 					} else {
-						// Rotating pool: weighted selection with per-cycle exclusion.
-						// Captures supportedKeys, bifrost.keySelector, provider/model by value.
+						// Rotating pool: strategy-based selection with per-cycle exclusion.
+						// Captures supportedKeys, keySelector, provider/model by value.
 						pool := supportedKeys
 						provKey := provider.GetProviderKey()
 						mdl := model
@@ -6684,7 +7136,7 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 									delete(usedKeyIDs, id)
 								}
 							}
-							return bifrost.keySelector(req.Context, available, provKey, mdl)
+							return keySelector(req.Context, available, provKey, mdl)
 						}
 					}
 				}
@@ -6727,7 +7179,7 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 		// pipeline the previous attempt's provider goroutine has already
 		// returned to the pool via its deferred finalizer.
 		if IsStreamRequestType(req.RequestType) {
-			stream, bifrostError = executeRequestWithRetries(req.Context, config, func(k schemas.Key) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+			stream, bifrostError = executeRequestWithRetries(req.Context, config, bifrost.retryBudget, func(k schemas.Key) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
 				if aliasConfig := k.Aliases.ResolveConfig(originalModelRequested); aliasConfig != nil {
 					resolvedModel = aliasConfig.ModelID
 					req.Context.SetValue(schemas.BifrostContextKeyResolvedAlias, &schemas.ResolvedAlias{Key: originalModelRequested, Config: aliasConfig})
@@ -6806,27 +7258,44 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 				// Wrapped in sync.Once so the normal end-of-stream invocation and a deferred
 				// safety-net invocation (e.g. from a provider goroutine's panic path) cannot
 				// double-release the pipeline.
+				// A tracked key stays in flight until the stream ends; its latency is the
+				// time until the stream was established.
+				trackAttempt := trackKeyLoad && k.ID != ""
+				attemptKeyID := k.ID
 				var finalizerOnce sync.Once
 				postHookSpanFinalizer := func(ctx context.Context) {
 					finalizerOnce.Do(func() {
 						pipeline.FinalizeStreamingPostHookSpans(ctx)
 						bifrost.releasePluginPipeline(pipeline)
+						if trackAttempt {
+							bifrost.keyLoad.Release(attemptKeyID)
+						}
 					})
 				}
 				lastAttemptFinalizer = postHookSpanFinalizer
+				if trackAttempt {
+					bifrost.keyLoad.Acquire(attemptKeyID)
+				}
+				attemptStart := time.Now()
 				streamCh, streamErr := bifrost.handleProviderStreamRequest(provider, req, k, postHookRunner, postHookSpanFinalizer)
+				if trackAttempt && streamErr == nil {
+					bifrost.keyLoad.ObserveLatency(attemptKeyID, time.Since(attemptStart))
+				}
 				// If stream setup failed before any provider goroutine started,
 				// no deferred finalizer will run — release the pipeline directly
 				// so a retry doesn't inherit a leaked pool entry.
 				if streamErr != nil && streamCh == nil {
 					finalizerOnce.Do(func() {
 						bifrost.releasePluginPipeline(pipeline)
+						if trackAttempt {
+							bifrost.keyLoad.Release(attemptKeyID)
+						}
 					})
 				}
 				return streamCh, streamErr
 			}, keyProvider, req.RequestType, provider.GetProviderKey(), model, &req.BifrostRequest, bifrost.logger)
 		} else {
-			result, bifrostError = executeRequestWithRetries(req.Context, config, func(k schemas.Key) (*schemas.BifrostResponse, *schemas.BifrostError) {
+			result, bifrostError = executeRequestWithRetries(req.Context, config, bifrost.retryBudget, func(k schemas.Key) (*schemas.BifrostResponse, *schemas.BifrostError) {
 				if aliasConfig := k.Aliases.ResolveConfig(originalModelRequested); aliasConfig != nil {
 					resolvedModel = aliasConfig.ModelID
 					req.Context.SetValue(schemas.BifrostContextKeyResolvedAlias, &schemas.ResolvedAlias{Key: originalModelRequested, Config: aliasConfig})
@@ -6836,7 +7305,17 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 				}
 				req.SetModel(resolvedModel)
 				attemptRoutingInfo = schemas.BuildRoutingInfo(req.Context, provider.GetProviderKey(), originalModelRequested, k)
-				return bifrost.handleProviderRequest(provider, config, req, k, keys)
+				if !trackKeyLoad || k.ID == "" {
+					return bifrost.handleProviderRequest(provider, config, req, k, keys)
+				}
+				bifrost.keyLoad.Acquire(k.ID)
+				attemptStart := time.Now()
+				resp, bifrostErr := bifrost.handleProviderRequest(provider, config, req, k, keys)
+				bifrost.keyLoad.Release(k.ID)
+				if bifrostErr == nil {
+					bifrost.keyLoad.ObserveLatency(k.ID, time.Since(attemptStart))
+				}
+				return resp, bifrostErr
 			}, keyProvider, req.RequestType, provider.GetProviderKey(), model, &req.BifrostRequest, bifrost.logger)
 		}
 
@@ -6944,6 +7423,10 @@ func (bifrost *Bifrost) billAbandonedTerminal(req *ChannelMessage, result *schem
 // handleProviderRequest handles the request to the provider based on the request type
 // key is used for single-key operations, keys is used for batch/file operations that need multiple keys
 func (bifrost *Bifrost) handleProviderRequest(provider schemas.Provider, config *schemas.ProviderConfig, req *ChannelMessage, key schemas.Key, keys []schemas.Key) (*schemas.BifrostResponse, *schemas.BifrostError) {
+	req, bifrostErr := bifrost.revealConfidentialFields(req)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
 	response := &schemas.BifrostResponse{}
 	switch req.RequestType {
 	case schemas.ListModelsRequest:
@@ -6954,7 +7437,7 @@ func (bifrost *Bifrost) handleProviderRequest(provider schemas.Provider, config
 		response.ListModelsResponse = listModelsResponse
 	case schemas.TextCompletionRequest:
 		if changeType, ok := req.Context.Value(schemas.BifrostContextKeyChangeRequestType).(schemas.RequestType); ok && changeType == schemas.ChatCompletionRequest {
-			chatRequest := req.BifrostRequest.TextCompletionRequest.ToBifrostChatRequest()
+			chatRequest := textCompletionToChatRequest(req.Context, req.BifrostRequest.TextCompletionRequest)
 			if chatRequest != nil {
 				chatCompletionResponse, bifrostError := provider.ChatCompletion(req.Context, key, chatRequest)
 				if bifrostError != nil {
@@ -7207,6 +7690,30 @@ func (bifrost *Bifrost) handleProviderRequest(provider schemas.Provider, config
 			return nil, bifrostError
 		}
 		response.CachedContentDeleteResponse = cachedContentDeleteResponse
+	case schemas.FineTuningJobCreateRequest:
+		fineTuningJobCreateResponse, bifrostError := provider.FineTuningJobCreate(req.Context, key, req.BifrostRequest.FineTuningJobCreateRequest)
+		if bifrostError != nil {
+			return nil, bifrostError
+		}
+		response.FineTuningJobCreateResponse = fineTuningJobCreateResponse
+	case schemas.FineTuningJobListRequest:
+		fineTuningJobListResponse, bifrostError := provider.FineTuningJobList(req.Context, keys, req.BifrostRequest.FineTuningJobListRequest)
+		if bifrostError != nil {
+			return nil, bifrostError
+		}
+		response.FineTuningJobListResponse = fineTuningJobListResponse
+	case schemas.FineTuningJobRetrieveRequest:
+		fineTuningJobRetrieveResponse, bifrostError := provider.FineTuningJobRetrieve(req.Context, keys, req.BifrostRequest.FineTuningJobRetrieveRequest)
+		if bifrostError != nil {
+			return nil, bifrostError
+		}
+		response.FineTuningJobRetrieveResponse = fineTuningJobRetrieveResponse
+	case schemas.FineTuningJobCancelRequest:
+		fineTuningJobCancelResponse, bifrostError := provider.FineTuningJobCancel(req.Context, keys, req.BifrostRequest.FineTuningJobCancelRequest)
+		if bifrostError != nil {
+			return nil, bifrostError
+		}
+		response.FineTuningJobCancelResponse = fineTuningJobCancelResponse
 	case schemas.BatchCreateRequest:
 		batchCreateResponse, bifrostError := provider.BatchCreate(req.Context, key, req.BifrostRequest.BatchCreateRequest)
 		if bifrostError != nil {
@@ -7326,10 +7833,14 @@ func (bifrost *Bifrost) handleProviderRequest(provider schemas.Provider, config
 
 // handleProviderStreamRequest handles the stream request to the provider based on the request type
 func (bifrost *Bifrost) handleProviderStreamRequest(provider schemas.Provider, req *ChannelMessage, key schemas.Key, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context)) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	req, bifrostErr := bifrost.revealConfidentialFields(req)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
 	switch req.RequestType {
 	case schemas.TextCompletionStreamRequest:
 		if changeType, ok := req.Context.Value(schemas.BifrostContextKeyChangeRequestType).(schemas.RequestType); ok && changeType == schemas.ChatCompletionRequest {
-			chatRequest := req.BifrostRequest.TextCompletionRequest.ToBifrostChatRequest()
+			chatRequest := textCompletionToChatRequest(req.Context, req.BifrostRequest.TextCompletionRequest)
 			if chatRequest != nil {
 				return provider.ChatCompletionStream(req.Context, wrapConvertedStreamPostHookRunner(postHookRunner, schemas.ChatCompletionRequest), postHookSpanFinalizer, key, chatRequest)
 			}
@@ -7392,6 +7903,13 @@ func (p *PluginPipeline) RunLLMPreHooks(ctx *schemas.BifrostContext, req *schema
 	defer ctx.UnblockRestrictedWrites()
 	for i, plugin := range p.llmPlugins {
 		pluginName := plugin.GetName()
+		if isPluginFlagGated(ctx, pluginName) {
+			continue
+		}
+		guard := p.guards.forPlugin(plugin)
+		if !p.allowPlugin(guard, pluginName) {
+			continue
+		}
 		p.logger.Debug("running pre-hook for plugin %s", pluginName)
 		// Start span for this plugin's PreLLMHook
 		spanCtx, handle := p.tracer.StartSpan(ctx, fmt.Sprintf("plugin.%s.prehook", sanitizeSpanName(pluginName)), schemas.SpanKindPlugin)
@@ -7403,7 +7921,9 @@ func (p *PluginPipeline) RunLLMPreHooks(ctx *schemas.BifrostContext, req *schema
 		}
 
 		pluginCtx := ctx.WithPluginScope(&pluginName)
+		start := time.Now()
 		req, shortCircuit, err = plugin.PreLLMHook(pluginCtx, req)
+		guard.record(err, time.Since(start))
 		pluginCtx.ReleasePluginScope()
 
 		// End span with appropriate status
@@ -7446,6 +7966,10 @@ func (p *PluginPipeline) RunPreRequestHooks(ctx *schemas.BifrostContext, req *sc
 	ctx.BlockRestrictedWrites()
 	for _, plugin := range p.llmPlugins {
 		pluginName := plugin.GetName()
+		guard := p.guards.forPlugin(plugin)
+		if !p.allowPlugin(guard, pluginName) {
+			continue
+		}
 		p.logger.Debug("running pre-request hook for plugin %s", pluginName)
 		spanCtx, handle := p.tracer.StartSpan(ctx, fmt.Sprintf("plugin.%s.prerequesthook", sanitizeSpanName(pluginName)), schemas.SpanKindPlugin)
 		if spanCtx != nil {
@@ -7455,7 +7979,9 @@ func (p *PluginPipeline) RunPreRequestHooks(ctx *schemas.BifrostContext, req *sc
 		}
 
 		pluginCtx := ctx.WithPluginScope(&pluginName)
+		start := time.Now()
 		err := plugin.PreRequestHook(pluginCtx, req)
+		guard.record(err, time.Since(start))
 		pluginCtx.ReleasePluginScope()
 
 		if err != nil {
@@ -7510,6 +8036,10 @@ func (p *PluginPipeline) RunPostLLMHooks(ctx *schemas.BifrostContext, resp *sche
 	for i := runFrom - 1; i >= 0; i-- {
 		plugin := p.llmPlugins[i]
 		pluginName := plugin.GetName()
+		if isPluginFlagGated(ctx, pluginName) || slices.Contains(p.bypassedPlugins, pluginName) {
+			continue
+		}
+		guard := p.guards.forPlugin(plugin)
 		p.logger.Debug("running post-hook for plugin %s", pluginName)
 		if isStreaming {
 			// For streaming: accumulate timing, don't create individual spans per chunk
@@ -7525,6 +8055,7 @@ func (p *PluginPipeline) RunPostLLMHooks(ctx *schemas.BifrostContext, resp *sche
 			start := time.Now()
 			resp, bifrostErr, err = plugin.PostLLMHook(pluginCtx, resp, bifrostErr)
 			duration := time.Since(start)
+			guard.record(err, duration)
 
 			p.accumulatePluginTiming(pluginName, duration, err != nil)
 			if err != nil {
@@ -7541,7 +8072,9 @@ func (p *PluginPipeline) RunPostLLMHooks(ctx *schemas.BifrostContext, resp *sche
 				}
 			}
 			pluginCtx := ctx.WithPluginScope(&pluginName)
+			start := time.Now()
 			resp, bifrostErr, err = plugin.PostLLMHook(pluginCtx, resp, bifrostErr)
+			guard.record(err, time.Since(start))
 			pluginCtx.ReleasePluginScope()
 			// End span with appropriate status
 			if err != nil {
@@ -7841,6 +8374,22 @@ func (p *PluginPipeline) resetPluginPipeline() {
 	}
 	p.streamScopedCtxs = nil
 	p.streamingMu.Unlock()
+	p.guards = nil
+	clear(p.bypassedPlugins)
+	p.bypassedPlugins = p.bypassedPlugins[:0]
+}
+
+// allowPlugin reports whether a plugin's pre-hooks run, remembering the
+// plugins bypassed for this request so their post-hooks are skipped as well.
+func (p *PluginPipeline) allowPlugin(guard *pluginGuard, pluginName string) bool {
+	if slices.Contains(p.bypassedPlugins, pluginName) {
+		return false
+	}
+	if guard.allow() {
+		return true
+	}
+	p.bypassedPlugins = append(p.bypassedPlugins, pluginName)
+	return false
 }
 
 // flushPluginLogs drains accumulated plugin logs from the BifrostContext and
@@ -7991,6 +8540,7 @@ func (bifrost *Bifrost) getPluginPipeline() *PluginPipeline {
 	pipeline.mcpPlugins = *bifrost.mcpPlugins.Load()
 	pipeline.logger = bifrost.logger
 	pipeline.tracer = bifrost.getTracer()
+	pipeline.guards = bifrost.pluginGuards
 	return pipeline
 }
 
@@ -8026,6 +8576,7 @@ func (bifrost *Bifrost) getChannelMessage(req schemas.BifrostRequest) *ChannelMe
 	msg.BifrostRequest = req
 	msg.Response = responseChan
 	msg.Err = errorChan
+	msg.queuedAt = time.Now()
 
 	// Conditionally allocate ResponseStream for streaming requests only
 	if IsStreamRequestType(req.RequestType) {
@@ -8041,6 +8592,53 @@ func (bifrost *Bifrost) getChannelMessage(req schemas.BifrostRequest) *ChannelMe
 	return msg
 }
 
+// transferQueuedRequests moves buffered requests from one queue lane to its
+// replacement during a provider update. Once the new lane is full, the message
+// that did not fit and everything still left in the old lane are cancelled with
+// an error, so no caller waits on a lane that no worker serves.
+func transferQueuedRequests(from, to chan *ChannelMessage) (transferred, cancelled int) {
+	cancelMsg := func(r *ChannelMessage) {
+		prov, mod, _ := r.BifrostRequest.GetRequestFields()
+		select {
+		case r.Err <- schemas.BifrostError{
+			IsBifrostError: false,
+			Error:          &schemas.ErrorField{Message: "request failed during provider concurrency update: queue full"},
+			ExtraFields: schemas.BifrostErrorExtraFields{
+				RequestType:            r.RequestType,
+				Provider:               prov,
+				OriginalModelRequested: mod,
+			},
+		}:
+		case <-r.Context.Done():
+		}
+	}
+	for {
+		select {
+		case msg := <-from:
+			select {
+			case to <- msg:
+				transferred++
+			default:
+				// The new lane is full — cancel this message and all remaining in the old lane.
+				cancelMsg(msg)
+				cancelled++
+				for {
+					select {
+					case r := <-from:
+						cancelMsg(r)
+						cancelled++
+					default:
+						return transferred, cancelled
+					}
+				}
+			}
+		default:
+			// No more buffered messages
+			return transferred, cancelled
+		}
+	}
+}
+
 // drainQueueWithErrors drains all buffered messages from pq and sends each a
 // "provider is shutting down" error. It must be called after all workers for
 // the queue have exited (i.e. after wg.Wait()) to cover the TOCTOU window:
@@ -8058,27 +8656,29 @@ func (bifrost *Bifrost) getChannelMessage(req schemas.BifrostRequest) *ChannelMe
 // it would add per-send atomic overhead on the hot path.
 func (bifrost *Bifrost) drainQueueWithErrors(pq *ProviderQueue) {
 	for {
+		var r *ChannelMessage
 		select {
-		case r := <-pq.queue:
-			provKey, mod, _ := r.GetRequestFields()
-			select {
-			case r.Err <- schemas.BifrostError{
-				IsBifrostError: false,
-				Error:          &schemas.ErrorField{Message: "provider is shutting down"},
-				ExtraFields: schemas.BifrostErrorExtraFields{
-					RequestType:            r.RequestType,
-					Provider:               provKey,
-					OriginalModelRequested: mod,
-				},
-			}:
-			case <-r.Context.Done():
-				// No time.After needed: r.Err is a buffered channel of size 1 freshly
-				// allocated per request, so the send always completes immediately unless
-				// the caller already cancelled. ctx.Done() is the only valid escape.
-			}
+		case r = <-pq.queue:
+		case r = <-pq.batchQueue:
 		default:
 			return
 		}
+		provKey, mod, _ := r.GetRequestFields()
+		select {
+		case r.Err <- schemas.BifrostError{
+			IsBifrostError: false,
+			Error:          &schemas.ErrorField{Message: "provider is shutting down"},
+			ExtraFields: schemas.BifrostErrorExtraFields{
+				RequestType:            r.RequestType,
+				Provider:               provKey,
+				OriginalModelRequested: mod,
+			},
+		}:
+		case <-r.Context.Done():
+			// No time.After needed: r.Err is a buffered channel of size 1 freshly
+			// allocated per request, so the send always completes immediately unless
+			// the caller already cancelled. ctx.Done() is the only valid escape.
+		}
 	}
 }
 
@@ -8164,6 +8764,10 @@ func resetBifrostRequest(req *schemas.BifrostRequest) {
 	req.CachedContentRetrieveRequest = nil
 	req.CachedContentUpdateRequest = nil
 	req.CachedContentDeleteRequest = nil
+	req.FineTuningJobCreateRequest = nil
+	req.FineTuningJobListRequest = nil
+	req.FineTuningJobRetrieveRequest = nil
+	req.FineTuningJobCancelRequest = nil
 	req.BatchCreateRequest = nil
 	req.BatchListRequest = nil
 	req.BatchRetrieveRequest = nil
@@ -8381,7 +8985,7 @@ func (bifrost *Bifrost) selectKeyFromProviderForModelWithPool(ctx *schemas.Bifro
 
 	// Skip model check conditions
 	// We can improve these conditions in the future
-	skipModelCheck := (model == "" && (isFileRequestType(requestType) || isBatchRequestType(requestType) || isContainerRequestType(requestType) || isCachedContentRequestType(requestType) || isModellessVideoRequestType(requestType) || isPassthroughRequestType(requestType))) || requestType == schemas.ListModelsRequest || isResponsesLifecycleRequestType(requestType)
+	skipModelCheck := (model == "" && (isFileRequestType(requestType) || isBatchRequestType(requestType) || isContainerRequestType(requestType) || isCachedContentRequestType(requestType) || isFineTuningRequestType(requestType) || isModellessVideoRequestType(requestType) || isPassthroughRequestType(requestType))) || requestType == schemas.ListModelsRequest || isResponsesLifecycleRequestType(requestType)
 	if skipModelCheck {
 		// When skipping model check: just verify keys are enabled and have values
 		for _, key := range keys {
@@ -8452,6 +9056,17 @@ func (bifrost *Bifrost) selectKeyFromProviderForModelWithPool(ctx *schemas.Bifro
 		}
 	}
 
+	// BYOK keys are tenant-owned and only serve requests that pin them above.
+	supportedKeys = slices.DeleteFunc(supportedKeys, func(key schemas.Key) bool { return key.BYOK })
+	if len(supportedKeys) == 0 {
+		return nil, false, fmt.Errorf("no keys found that support model: %s; BYOK keys must be pinned by id or name", model)
+	}
+
+	// Session affinity: reuse the key that last served the session on this provider/model.
+	if key, ok := sessionAffinityKey(ctx, providerKey, model, supportedKeys); ok {
+		return []schemas.Key{key}, false, nil
+	}
+
 	// Single key: no rotation possible, skip session stickiness (no KV write needed).
 	if len(supportedKeys) == 1 {
 		return []schemas.Key{supportedKeys[0]}, false, nil
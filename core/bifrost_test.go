@@ -3,6 +3,9 @@ package bifrost
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"strings"
 	"sync"
@@ -67,6 +70,7 @@ func TestExecuteRequestWithRetries_SuccessScenarios(t *testing.T) {
 		result, err := executeRequestWithRetries(
 			ctx,
 			config,
+			nil,
 			handler,
 			nil,
 			schemas.ChatCompletionRequest,
@@ -103,6 +107,7 @@ func TestExecuteRequestWithRetries_SuccessScenarios(t *testing.T) {
 		result, err := executeRequestWithRetries(
 			ctx,
 			config,
+			nil,
 			handler,
 			nil,
 			schemas.ChatCompletionRequest,
@@ -141,6 +146,7 @@ func TestExecuteRequestWithRetries_RetryLimits(t *testing.T) {
 		result, err := executeRequestWithRetries(
 			ctx,
 			config,
+			nil,
 			handler,
 			nil,
 			schemas.ChatCompletionRequest,
@@ -207,6 +213,7 @@ func TestExecuteRequestWithRetries_NonRetryableErrors(t *testing.T) {
 			result, err := executeRequestWithRetries(
 				ctx,
 				config,
+				nil,
 				handler,
 				nil,
 				schemas.ChatCompletionRequest,
@@ -284,6 +291,7 @@ func TestExecuteRequestWithRetries_RetryableConditions(t *testing.T) {
 			result, err := executeRequestWithRetries(
 				ctx,
 				config,
+				nil,
 				handler,
 				nil,
 				schemas.ChatCompletionRequest,
@@ -532,6 +540,7 @@ func TestExecuteRequestWithRetries_LoggingAndCounting(t *testing.T) {
 	result, err := executeRequestWithRetries(
 		ctx,
 		config,
+		nil,
 		handler,
 		nil,
 		schemas.ChatCompletionRequest,
@@ -1115,7 +1124,7 @@ func TestSelectKeyFromProviderForModel_SessionStickinessNoRotation(t *testing.T)
 		return "ok", nil
 	}
 
-	result, retryErr := executeRequestWithRetries(bfCtx, config, handler, keyProvider,
+	result, retryErr := executeRequestWithRetries(bfCtx, config, nil, handler, keyProvider,
 		schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, logger)
 
 	if retryErr != nil {
@@ -1191,6 +1200,51 @@ func TestSelectKeyFromProviderForModel_BlacklistedModels(t *testing.T) {
 	})
 }
 
+// Test that BYOK keys stay out of rotation and only serve requests that pin them
+func TestSelectKeyFromProviderForModel_BYOKKeys(t *testing.T) {
+	account := NewMockAccount()
+	account.AddProvider(schemas.OpenAI, 5, 1000)
+
+	ctx := context.Background()
+	bifrost, err := Init(ctx, schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "shared", Name: "Shared", Value: *schemas.NewSecretVar("sk-1"), Weight: 1, Models: []string{"*"}},
+		{ID: "tenant", Name: "Tenant", Value: *schemas.NewSecretVar("sk-2"), Weight: 1, Models: []string{"*"}, BYOK: true},
+	})
+
+	bfCtx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	pool, _, err := bifrost.selectKeyFromProviderForModelWithPool(bfCtx, schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", schemas.OpenAI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pool) != 1 || pool[0].ID != "shared" {
+		t.Fatalf("expected the BYOK key to be left out of rotation, got %v", pool)
+	}
+
+	bfCtx.SetValue(schemas.BifrostContextKeyAPIKeyID, "tenant")
+	pool, canRotate, err := bifrost.selectKeyFromProviderForModelWithPool(bfCtx, schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", schemas.OpenAI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if canRotate || len(pool) != 1 || pool[0].ID != "tenant" {
+		t.Fatalf("expected the pinned BYOK key, got %v (canRotate=%v)", pool, canRotate)
+	}
+
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "tenant", Name: "Tenant", Value: *schemas.NewSecretVar("sk-2"), Weight: 1, Models: []string{"*"}, BYOK: true},
+	})
+	unpinned := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	if _, _, err := bifrost.selectKeyFromProviderForModelWithPool(unpinned, schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", schemas.OpenAI); err == nil {
+		t.Fatal("expected an error when only BYOK keys are configured and none is pinned")
+	}
+}
+
 // Test key rotation in executeRequestWithRetries on rate-limit errors
 func TestExecuteRequestWithRetries_KeyRotation(t *testing.T) {
 	config := createTestConfig(3, 0, 0)
@@ -1228,7 +1282,7 @@ func TestExecuteRequestWithRetries_KeyRotation(t *testing.T) {
 			return "success", nil
 		}
 
-		result, err := executeRequestWithRetries(ctx, config, handler, keyProvider,
+		result, err := executeRequestWithRetries(ctx, config, nil, handler, keyProvider,
 			schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, logger)
 
 		if err != nil {
@@ -1276,7 +1330,7 @@ func TestExecuteRequestWithRetries_KeyRotation(t *testing.T) {
 			return "success", nil
 		}
 
-		result, err := executeRequestWithRetries(ctx, config, handler, keyProvider,
+		result, err := executeRequestWithRetries(ctx, config, nil, handler, keyProvider,
 			schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, logger)
 
 		if err != nil {
@@ -1324,7 +1378,7 @@ func TestExecuteRequestWithRetries_KeyRotation(t *testing.T) {
 			return "", createBifrostError("rate limit exceeded", Ptr(429), nil, false)
 		}
 
-		executeRequestWithRetries(ctx, config6, handler, keyProvider,
+		executeRequestWithRetries(ctx, config6, nil, handler, keyProvider,
 			schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, logger)
 
 		if len(selectedKeyIDs) != 6 {
@@ -1349,7 +1403,7 @@ func TestExecuteRequestWithRetries_KeyRotation(t *testing.T) {
 			return "ok", nil
 		}
 
-		result, err := executeRequestWithRetries(cleanCtx, config, handler, nil,
+		result, err := executeRequestWithRetries(cleanCtx, config, nil, handler, nil,
 			schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, logger)
 
 		if err != nil {
@@ -3025,3 +3079,63 @@ func TestReleaseChannelMessage_ClearsPooledReferences_Streaming(t *testing.T) {
 	default:
 	}
 }
+
+func TestCountTokensUsesProviderCounterOrEstimates(t *testing.T) {
+	paths := make(chan string, 1)
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"object":"response.input_tokens","input_tokens":42}`)
+	}))
+	defer openAI.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, openAI.URL)
+	account.AddProviderWithBaseURL(schemas.Groq, 1, 1, "http://127.0.0.1:1")
+	for _, provider := range []schemas.ModelProvider{schemas.OpenAI, schemas.Groq} {
+		account.SetKeysForProvider(provider, []schemas.Key{
+			{ID: string(provider) + "-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+		})
+	}
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	chatRequest := func(provider schemas.ModelProvider) *schemas.BifrostRequest {
+		return &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{
+			Provider: provider,
+			Model:    "m",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("You are terse.")}},
+				{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("How many tokens is this?")}},
+			},
+		}}
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	counted, bifrostErr := client.CountTokens(ctx, chatRequest(schemas.OpenAI))
+	if bifrostErr != nil {
+		t.Fatalf("count tokens failed: %s", bifrostErr.Error.Message)
+	}
+	if path := <-paths; path != "/v1/responses/input_tokens" {
+		t.Fatalf("expected the provider's counter to be called, got %s", path)
+	}
+	if counted.InputTokens != 42 || counted.Estimated {
+		t.Fatalf("expected the provider's count, got %+v", counted)
+	}
+
+	// Groq has no counter: two messages of 38 bytes of text are estimated locally.
+	ctx = schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	counted, bifrostErr = client.CountTokens(ctx, chatRequest(schemas.Groq))
+	if bifrostErr != nil {
+		t.Fatalf("count tokens failed: %s", bifrostErr.Error.Message)
+	}
+	if counted.InputTokens != 18 || !counted.Estimated || counted.ExtraFields.Provider != schemas.Groq {
+		t.Fatalf("expected an estimate of 18 tokens, got %+v", counted)
+	}
+}
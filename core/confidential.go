@@ -0,0 +1,380 @@
+package bifrost
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// confidentialPlaceholderPrefix starts the placeholder that stands in for a
+// sealed field everywhere but the provider call.
+const confidentialPlaceholderPrefix = "[confidential sha256:"
+
+// errUndecryptable marks a field whose envelope does not open under its data
+// key: the client's fault, unlike a failing key service.
+var errUndecryptable = errors.New("confidential field could not be decrypted")
+
+// confidentialFields seals encrypted message fields on arrival and reveals
+// them for provider dispatch. A nil *confidentialFields leaves requests as is.
+type confidentialFields struct {
+	unwrapper schemas.DataKeyUnwrapper
+	keyIDs    map[string]bool // known key IDs with local KEKs; nil = the unwrapper decides
+}
+
+// newConfidentialFields returns the confidential field handler for config, or
+// nil when config is nil.
+func newConfidentialFields(config *schemas.ConfidentialFieldsConfig) (*confidentialFields, error) {
+	if config == nil {
+		return nil, nil
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Unwrapper != nil {
+		return &confidentialFields{unwrapper: config.Unwrapper}, nil
+	}
+	keys := make(localKeyUnwrapper, len(config.KeyEncryptionKeys))
+	keyIDs := make(map[string]bool, len(config.KeyEncryptionKeys))
+	for keyID, secret := range config.KeyEncryptionKeys {
+		kek, err := base64.StdEncoding.DecodeString(secret.GetValue())
+		if err != nil || len(kek) != 32 {
+			return nil, fmt.Errorf("key encryption key %q must be a base64 32-byte AES-256 key", keyID)
+		}
+		keys[keyID] = kek
+		keyIDs[keyID] = true
+	}
+	return &confidentialFields{unwrapper: keys, keyIDs: keyIDs}, nil
+}
+
+// localKeyUnwrapper unwraps data keys with KEKs held in the gateway config. A
+// wrapped key is the AES-GCM nonce followed by the sealed data key, with the
+// key ID as additional data.
+type localKeyUnwrapper map[string][]byte
+
+func (u localKeyUnwrapper) UnwrapDataKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	kek, ok := u[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", keyID)
+	}
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < gcm.NonceSize() {
+		return nil, errUndecryptable
+	}
+	dataKey, err := gcm.Open(nil, wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, errUndecryptable
+	}
+	return dataKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SealConfidentialField encrypts text as a confidential field under a fresh
+// data key wrapped by kek, the 32-byte local KEK with ID keyID. It is the
+// client side of ConfidentialFieldsConfig.KeyEncryptionKeys; clients whose
+// gateway unwraps with a KMS wrap the data key with the KMS instead.
+func SealConfidentialField(keyID string, kek []byte, text string) (string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	kekGCM, err := newGCM(kek)
+	if err != nil {
+		return "", err
+	}
+	wrapNonce := make([]byte, kekGCM.NonceSize())
+	if _, err := rand.Read(wrapNonce); err != nil {
+		return "", err
+	}
+	dataGCM, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, dataGCM.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	envelope, err := json.Marshal(schemas.ConfidentialEnvelope{
+		KeyID:      keyID,
+		WrappedKey: kekGCM.Seal(wrapNonce, wrapNonce, dataKey, []byte(keyID)),
+		Nonce:      nonce,
+		Ciphertext: dataGCM.Seal(nil, nonce, []byte(text), nil),
+	})
+	if err != nil {
+		return "", err
+	}
+	return schemas.ConfidentialFieldPrefix + base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// parseEnvelope decodes a sealed field and checks its shape.
+func (c *confidentialFields) parseEnvelope(field string) (*schemas.ConfidentialEnvelope, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(field, schemas.ConfidentialFieldPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("envelope is not valid base64: %w", err)
+	}
+	var envelope schemas.ConfidentialEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("envelope is not valid JSON: %w", err)
+	}
+	switch {
+	case envelope.KeyID == "":
+		return nil, fmt.Errorf("envelope has no kid")
+	case c.keyIDs != nil && !c.keyIDs[envelope.KeyID]:
+		return nil, fmt.Errorf("unknown kid %q", envelope.KeyID)
+	case len(envelope.WrappedKey) == 0 || len(envelope.Ciphertext) == 0:
+		return nil, fmt.Errorf("envelope needs wrapped_key and ciphertext")
+	case len(envelope.Nonce) != 12:
+		return nil, fmt.Errorf("envelope nonce must be 12 bytes")
+	}
+	return &envelope, nil
+}
+
+// seal replaces every encrypted message field of req with a placeholder and
+// returns the sealed fields by placeholder. The placeholder carries the
+// SHA-256 of the sealed field, so logs and caches can tell fields apart
+// without holding them. The caller's request is not modified; a request with
+// sealed fields also loses its raw body, which would carry them too.
+func (c *confidentialFields) seal(req *schemas.BifrostRequest) (*schemas.BifrostRequest, map[string]string, error) {
+	if c == nil {
+		return req, nil, nil
+	}
+	var sealed map[string]string
+	out, err := rewriteMessageTexts(req, func(text string) (string, error) {
+		field := strings.TrimSpace(text)
+		if !strings.HasPrefix(field, schemas.ConfidentialFieldPrefix) {
+			return text, nil
+		}
+		if _, err := c.parseEnvelope(field); err != nil {
+			return "", err
+		}
+		placeholder := fmt.Sprintf("%s%x]", confidentialPlaceholderPrefix, sha256.Sum256([]byte(field)))
+		if sealed == nil {
+			sealed = make(map[string]string)
+		}
+		sealed[placeholder] = field
+		return placeholder, nil
+	})
+	if err != nil || sealed == nil {
+		return req, nil, err
+	}
+	out.SetRawRequestBody(nil)
+	return out, sealed, nil
+}
+
+// reveal returns req with every placeholder replaced by its decrypted field.
+// Data keys are unwrapped once per call and nothing is kept afterwards.
+func (c *confidentialFields) reveal(ctx context.Context, req *schemas.BifrostRequest, sealed map[string]string) (*schemas.BifrostRequest, error) {
+	dataKeys := make(map[string][]byte)
+	return rewriteMessageTexts(req, func(text string) (string, error) {
+		if !strings.Contains(text, confidentialPlaceholderPrefix) {
+			return text, nil
+		}
+		for placeholder, field := range sealed {
+			if !strings.Contains(text, placeholder) {
+				continue
+			}
+			envelope, err := c.parseEnvelope(field)
+			if err != nil {
+				return "", err
+			}
+			cacheKey := envelope.KeyID + "\x00" + string(envelope.WrappedKey)
+			dataKey, ok := dataKeys[cacheKey]
+			if !ok {
+				if dataKey, err = c.unwrapper.UnwrapDataKey(ctx, envelope.KeyID, envelope.WrappedKey); err != nil {
+					return "", err
+				}
+				dataKeys[cacheKey] = dataKey
+			}
+			gcm, err := newGCM(dataKey)
+			if err != nil {
+				return "", fmt.Errorf("%w: %v", errUndecryptable, err)
+			}
+			plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+			if err != nil {
+				return "", errUndecryptable
+			}
+			text = strings.ReplaceAll(text, placeholder, string(plaintext))
+		}
+		return text, nil
+	})
+}
+
+// rewriteMessageTexts returns req with fn applied to every text of its chat or
+// responses messages: content strings and text blocks. Messages fn changes
+// are copied, so the caller's request is never modified; req itself is
+// returned when nothing changed.
+func rewriteMessageTexts(req *schemas.BifrostRequest, fn func(text string) (string, error)) (*schemas.BifrostRequest, error) {
+	rewrite := func(text *string) (*string, error) {
+		if text == nil {
+			return nil, nil
+		}
+		rewritten, err := fn(*text)
+		if err != nil || rewritten == *text {
+			return nil, err
+		}
+		return &rewritten, nil
+	}
+	switch {
+	case req.ChatRequest != nil:
+		var messages []schemas.ChatMessage
+		for i, message := range req.ChatRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			var content *schemas.ChatMessageContent
+			text, err := rewrite(message.Content.ContentStr)
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			if text != nil {
+				content = &schemas.ChatMessageContent{ContentStr: text}
+			}
+			for j, block := range message.Content.ContentBlocks {
+				text, err := rewrite(block.Text)
+				if err != nil {
+					return nil, fmt.Errorf("message %d, content block %d: %w", i, j, err)
+				}
+				if text == nil {
+					continue
+				}
+				if content == nil {
+					content = &schemas.ChatMessageContent{ContentBlocks: append([]schemas.ChatContentBlock(nil), message.Content.ContentBlocks...)}
+				}
+				content.ContentBlocks[j].Text = text
+			}
+			if content == nil {
+				continue
+			}
+			if messages == nil {
+				messages = append([]schemas.ChatMessage(nil), req.ChatRequest.Input...)
+			}
+			messages[i].Content = content
+		}
+		if messages == nil {
+			return req, nil
+		}
+		chat := *req.ChatRequest
+		chat.Input = messages
+		out := *req
+		out.ChatRequest = &chat
+		return &out, nil
+	case req.ResponsesRequest != nil:
+		var messages []schemas.ResponsesMessage
+		for i, message := range req.ResponsesRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			var content *schemas.ResponsesMessageContent
+			text, err := rewrite(message.Content.ContentStr)
+			if err != nil {
+				return nil, fmt.Errorf("input item %d: %w", i, err)
+			}
+			if text != nil {
+				content = &schemas.ResponsesMessageContent{ContentStr: text}
+			}
+			for j, block := range message.Content.ContentBlocks {
+				text, err := rewrite(block.Text)
+				if err != nil {
+					return nil, fmt.Errorf("input item %d, content block %d: %w", i, j, err)
+				}
+				if text == nil {
+					continue
+				}
+				if content == nil {
+					content = &schemas.ResponsesMessageContent{ContentBlocks: append([]schemas.ResponsesMessageContentBlock(nil), message.Content.ContentBlocks...)}
+				}
+				content.ContentBlocks[j].Text = text
+			}
+			if content == nil {
+				continue
+			}
+			if messages == nil {
+				messages = append([]schemas.ResponsesMessage(nil), req.ResponsesRequest.Input...)
+			}
+			messages[i].Content = content
+		}
+		if messages == nil {
+			return req, nil
+		}
+		responses := *req.ResponsesRequest
+		responses.Input = messages
+		out := *req
+		out.ResponsesRequest = &responses
+		return &out, nil
+	}
+	return req, nil
+}
+
+// sealConfidentialFields replaces the encrypted fields of an incoming request
+// with placeholders before any plugin sees it, and records them on ctx for
+// dispatch. A malformed field is rejected with a 400.
+func (bifrost *Bifrost) sealConfidentialFields(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) *schemas.BifrostError {
+	out, sealed, err := bifrost.confidentialFields.seal(req)
+	if err != nil {
+		provider, model, _ := req.GetRequestFields()
+		bifrostErr := &schemas.BifrostError{
+			IsBifrostError: false,
+			StatusCode:     schemas.Ptr(fasthttp.StatusBadRequest),
+			Error: &schemas.ErrorField{
+				Message: fmt.Sprintf("invalid confidential field: %v", err),
+				Error:   err,
+			},
+		}
+		bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
+		return bifrostErr
+	}
+	if sealed == nil {
+		return nil
+	}
+	*req = *out
+	ctx.SetValue(schemas.BifrostContextKeyConfidentialFields, sealed)
+	// The raw body still holds the sealed fields; always send the parsed request.
+	ctx.SetValue(schemas.BifrostContextKeyUseRawRequestBody, false)
+	return nil
+}
+
+// revealConfidentialFields returns the message to dispatch to the provider:
+// msg itself, or a copy whose request carries the decrypted fields.
+func (bifrost *Bifrost) revealConfidentialFields(msg *ChannelMessage) (*ChannelMessage, *schemas.BifrostError) {
+	sealed, ok := msg.Context.Value(schemas.BifrostContextKeyConfidentialFields).(map[string]string)
+	if !ok || bifrost.confidentialFields == nil {
+		return msg, nil
+	}
+	revealed, err := bifrost.confidentialFields.reveal(msg.Context, &msg.BifrostRequest, sealed)
+	if err != nil {
+		bifrostErr := &schemas.BifrostError{
+			IsBifrostError: true,
+			Error: &schemas.ErrorField{
+				Message: fmt.Sprintf("failed to decrypt confidential field: %v", err),
+				Error:   err,
+			},
+		}
+		if errors.Is(err, errUndecryptable) {
+			bifrostErr.IsBifrostError = false
+			bifrostErr.StatusCode = schemas.Ptr(fasthttp.StatusBadRequest)
+		}
+		return nil, bifrostErr
+	}
+	out := *msg
+	out.BifrostRequest = *revealed
+	return &out, nil
+}
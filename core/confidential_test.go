@@ -0,0 +1,110 @@
+package bifrost
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// inputRecordingPlugin records the first message text every PreLLMHook sees.
+type inputRecordingPlugin struct {
+	fakeRoutingPlugin
+	seen []string
+}
+
+func (p *inputRecordingPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	p.seen = append(p.seen, *req.ChatRequest.Input[0].Content.ContentStr)
+	return req, nil, nil
+}
+
+func TestConfidentialFieldsAreDecryptedOnlyForDispatch(t *testing.T) {
+	kek := make([]byte, 32)
+	for i := range kek {
+		kek[i] = byte(i)
+	}
+	sealed, err := SealConfidentialField("tenant-a", kek, "my salary is 120k")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bodies := make(chan map[string]any, 1)
+	server := httptest.NewServer(recordingChatHandler(http.StatusOK, bodies))
+	defer server.Close()
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, server.URL)
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "openai-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	plugin := &inputRecordingPlugin{fakeRoutingPlugin: fakeRoutingPlugin{name: "recorder"}}
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account:    account,
+		Logger:     NewDefaultLogger(schemas.LogLevelError),
+		LLMPlugins: []schemas.LLMPlugin{plugin},
+		ConfidentialFields: &schemas.ConfidentialFieldsConfig{
+			KeyEncryptionKeys: map[string]schemas.SecretVar{"tenant-a": *schemas.NewSecretVar(base64.StdEncoding.EncodeToString(kek))},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	input := []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(sealed)}}}
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	_, bifrostErr := client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o", Input: input})
+	if bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+
+	body := <-bodies
+	if got := body["messages"].([]any)[0].(map[string]any)["content"]; got != "my salary is 120k" {
+		t.Fatalf("expected the provider to receive the plaintext, got %v", got)
+	}
+	if len(plugin.seen) != 1 || !strings.HasPrefix(plugin.seen[0], "[confidential sha256:") || strings.Contains(plugin.seen[0], "salary") {
+		t.Fatalf("expected plugins to see only the placeholder, got %v", plugin.seen)
+	}
+	if *input[0].Content.ContentStr != sealed {
+		t.Fatal("the caller's request must not be modified")
+	}
+}
+
+func TestConfidentialFieldsRejectBadEnvelopes(t *testing.T) {
+	kek := make([]byte, 32)
+	fields, err := newConfidentialFields(&schemas.ConfidentialFieldsConfig{
+		KeyEncryptionKeys: map[string]schemas.SecretVar{"tenant-a": *schemas.NewSecretVar(base64.StdEncoding.EncodeToString(kek))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTenant, _ := SealConfidentialField("tenant-b", kek, "hello")
+	for name, text := range map[string]string{
+		"not base64":  schemas.ConfidentialFieldPrefix + "%%%",
+		"unknown kid": otherTenant,
+	} {
+		req := &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+		}}}
+		if _, _, err := fields.seal(req); err == nil {
+			t.Errorf("%s: expected the envelope to be rejected", name)
+		}
+	}
+
+	// A field sealed under another KEK with the same kid parses but does not decrypt.
+	forged, _ := SealConfidentialField("tenant-a", append([]byte{1}, kek[1:]...), "hello")
+	req := &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{Input: []schemas.ChatMessage{
+		{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(forged)}},
+	}}}
+	out, sealedFields, err := fields.seal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fields.reveal(context.Background(), out, sealedFields); err == nil || !strings.Contains(err.Error(), errUndecryptable.Error()) {
+		t.Fatalf("expected an undecryptable error, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package bifrost
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// firstTokenTimeoutType is the error type of a stream attempt abandoned for its first-token timeout.
+const firstTokenTimeoutType = "first_token_timeout"
+
+// firstTokenTimeoutFor returns how long a stream attempt may wait for its first chunk before
+// it is abandoned for the next fallback, or false when streams wait for as long as it takes.
+func firstTokenTimeoutFor(ctx *schemas.BifrostContext) (time.Duration, bool) {
+	timeout, ok := ctx.Value(schemas.BifrostContextKeyFirstTokenTimeout).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
+// streamAttempt is the outcome of one stream attempt.
+type streamAttempt struct {
+	stream chan *schemas.BifrostStreamChunk
+	err    *schemas.BifrostError
+}
+
+// tryStreamAttempt sends one stream attempt. When a first-token timeout is set and another
+// fallback remains, the attempt runs on its own context and is cancelled if its first chunk
+// has not arrived within the timeout. A stream only reaches the client once its first chunk
+// has been checked, so an abandoned attempt has sent nothing and the caller can restart the
+// stream on the next fallback.
+//
+// The attempt works on a copy of req so the caller can release req while the abandoned
+// attempt is still unwinding. The values set on the attempt's context are copied back onto
+// ctx once it produces a stream or fails.
+func (bifrost *Bifrost) tryStreamAttempt(ctx *schemas.BifrostContext, req *schemas.BifrostRequest, hasNextFallback bool) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	timeout, ok := firstTokenTimeoutFor(ctx)
+	if !ok || !hasNextFallback {
+		return bifrost.tryStreamRequest(ctx, req)
+	}
+	provider, model, _ := req.GetRequestFields()
+	attemptReq := bifrost.prepareFallbackRequest(req, schemas.Fallback{Provider: provider, Model: model})
+	if attemptReq == nil {
+		return bifrost.tryStreamRequest(ctx, req)
+	}
+
+	attemptCtx, cancelAttempt := schemas.NewBifrostContextWithCancel(ctx)
+	attempts := make(chan streamAttempt, 1)
+	go func() {
+		stream, err := bifrost.tryStreamRequest(attemptCtx, attemptReq)
+		attempts <- streamAttempt{stream: stream, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case attempt := <-attempts:
+		ctx.AdoptValues(attemptCtx)
+		if attempt.err != nil {
+			cancelAttempt()
+		}
+		return attempt.stream, attempt.err
+	case <-ctx.Done():
+		attempt := <-attempts
+		ctx.AdoptValues(attemptCtx)
+		return attempt.stream, attempt.err
+	case <-timer.C:
+	}
+
+	cancelAttempt()
+	go func() {
+		// Drain a stream that started after all, so the provider goroutine can exit.
+		if attempt := <-attempts; attempt.stream != nil {
+			for range attempt.stream {
+			}
+		}
+	}()
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, fmt.Sprintf("No first token from %s/%s within %s; cancelled the stream to restart it on the next fallback", provider, model, timeout))
+	message := fmt.Sprintf("no first token from %s/%s within %s", provider, model, timeout)
+	errType := firstTokenTimeoutType
+	bifrostErr := &schemas.BifrostError{
+		IsBifrostError: false,
+		StatusCode:     schemas.Ptr(http.StatusGatewayTimeout),
+		Type:           &errType,
+		Error: &schemas.ErrorField{
+			Type:    &errType,
+			Message: message,
+		},
+	}
+	bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
+	return nil, bifrostErr
+}
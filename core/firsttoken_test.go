@@ -0,0 +1,99 @@
+package bifrost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// slowStartHandler serves a chat stream producing "primary" once delay has passed,
+// giving up early when the client disconnects.
+func slowStartHandler(delay time.Duration, hits *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		sseHandler(
+			`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant","content":"primary"}}]}`,
+			`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{},"finish_reason":"stop"}],"usage":{"prompt_tokens":10,"completion_tokens":1,"total_tokens":11}}`,
+		)(w, r)
+	}
+}
+
+func runFirstTokenStream(t *testing.T, primaryDelay time.Duration) (content string, elapsed time.Duration, ctx *schemas.BifrostContext, primaryHits, fallbackHits int32) {
+	t.Helper()
+	var primaryCount, fallbackCount atomic.Int32
+	primary := httptest.NewServer(slowStartHandler(primaryDelay, &primaryCount))
+	t.Cleanup(primary.Close)
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackCount.Add(1)
+		anthropicMessagesHandler()(w, r)
+	}))
+	t.Cleanup(fallback.Close)
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, primary.URL)
+	account.AddProviderWithBaseURL(schemas.Anthropic, 1, 1, fallback.URL)
+	account.configs[schemas.OpenAI].NetworkConfig.MaxRetries = 0
+	account.configs[schemas.Anthropic].NetworkConfig.MaxRetries = 0
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "primary-key", Value: *schemas.NewSecretVar("sk-primary"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	account.SetKeysForProvider(schemas.Anthropic, []schemas.Key{
+		{ID: "fallback-key", Value: *schemas.NewSecretVar("sk-fallback"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	client := newStreamTestClient(t, account)
+
+	ctx = schemas.NewBifrostContext(context.Background(), time.Now().Add(30*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeyFirstTokenTimeout, 200*time.Millisecond)
+	start := time.Now()
+	stream, bifrostErr := client.ChatCompletionStreamRequest(ctx, &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4o-mini",
+		Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}},
+		},
+		Fallbacks: []schemas.Fallback{{Provider: schemas.Anthropic, Model: "claude-3-5-haiku-20241022"}},
+	})
+	if bifrostErr != nil {
+		t.Fatalf("stream failed: %s", bifrostErr.Error.Message)
+	}
+	content, errs := drainChatStream(stream)
+	if len(errs) > 0 {
+		t.Fatalf("stream emitted error chunks: %v", errs)
+	}
+	return content, time.Since(start), ctx, primaryCount.Load(), fallbackCount.Load()
+}
+
+func TestFirstTokenTimeoutSwitchesToFallback(t *testing.T) {
+	content, elapsed, ctx, primaryHits, fallbackHits := runFirstTokenStream(t, 3*time.Second)
+	if content != "hello" || fallbackHits != 1 || primaryHits != 1 {
+		t.Fatalf("expected the fallback to serve the stream, got %q (primary hits %d, fallback hits %d)", content, primaryHits, fallbackHits)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("stream took %s, expected the slow primary to be abandoned", elapsed)
+	}
+	var logged bool
+	for _, entry := range ctx.GetRoutingEngineLogs() {
+		logged = logged || strings.HasPrefix(entry.Message, "No first token from openai/gpt-4o-mini within 200ms")
+	}
+	if !logged {
+		t.Fatalf("expected the switch in the routing logs, got %+v", ctx.GetRoutingEngineLogs())
+	}
+}
+
+func TestFirstTokenWithinTimeoutKeepsPrimary(t *testing.T) {
+	content, _, _, _, fallbackHits := runFirstTokenStream(t, 0)
+	if content != "primary" || fallbackHits != 0 {
+		t.Fatalf("expected the primary to serve the stream, got %q (fallback hits %d)", content, fallbackHits)
+	}
+}
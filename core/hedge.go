@@ -0,0 +1,127 @@
+package bifrost
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// hedgeLeg is the outcome of one side of a hedged request.
+type hedgeLeg struct {
+	ctx       *schemas.BifrostContext
+	resp      *schemas.BifrostResponse
+	err       *schemas.BifrostError
+	secondary bool
+}
+
+// hedgeDelayFor returns the delay after which req is hedged to its first fallback, or false
+// when the request should not be hedged. Only non-streaming inference requests are hedged:
+// a stream is committed to its provider once the first chunk has been sent.
+func hedgeDelayFor(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (time.Duration, bool) {
+	delay, ok := ctx.Value(schemas.BifrostContextKeyHedgeDelay).(time.Duration)
+	if !ok || delay <= 0 {
+		return 0, false
+	}
+	switch req.RequestType {
+	case schemas.TextCompletionRequest, schemas.ChatCompletionRequest, schemas.ResponsesRequest, schemas.EmbeddingRequest:
+	default:
+		return 0, false
+	}
+	_, _, fallbacks := req.GetRequestFields()
+	return delay, len(fallbacks) > 0
+}
+
+// tryHedgedRequest sends req to its primary provider and, if no answer has arrived after
+// delay, also to secondary. The first successful answer is returned and the other attempt is
+// cancelled. If both fail, the primary's error is returned. hedged reports whether the
+// secondary was dispatched, in which case the caller must not try it again as a fallback.
+//
+// Each attempt runs on its own context derived from ctx so that plugin state from the two
+// attempts does not mix; the values set on the returned attempt's context are copied back
+// onto ctx.
+func (bifrost *Bifrost) tryHedgedRequest(ctx *schemas.BifrostContext, req *schemas.BifrostRequest, secondary schemas.Fallback, delay time.Duration) (resp *schemas.BifrostResponse, bifrostErr *schemas.BifrostError, hedged bool) {
+	provider, model, _ := req.GetRequestFields()
+
+	// Both attempts work on copies so the caller can release req as soon as a winner is
+	// known, while the cancelled attempt is still unwinding.
+	primaryReq := bifrost.prepareFallbackRequest(req, schemas.Fallback{Provider: provider, Model: model})
+	secondaryReq := bifrost.prepareFallbackRequest(req, secondary)
+	if primaryReq == nil || secondaryReq == nil {
+		resp, bifrostErr = bifrost.tryRequest(ctx, req)
+		return resp, bifrostErr, false
+	}
+
+	legs := make(chan hedgeLeg, 2)
+	primaryCtx, cancelPrimary := schemas.NewBifrostContextWithCancel(ctx)
+	defer cancelPrimary()
+	go func() {
+		resp, err := bifrost.tryRequest(primaryCtx, primaryReq)
+		legs <- hedgeLeg{ctx: primaryCtx, resp: resp, err: err}
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case leg := <-legs:
+		// The primary answered within the delay; the request was never hedged.
+		ctx.AdoptValues(leg.ctx)
+		return leg.resp, leg.err, false
+	case <-timer.C:
+	case <-ctx.Done():
+		leg := <-legs
+		ctx.AdoptValues(leg.ctx)
+		return leg.resp, leg.err, false
+	}
+
+	secondaryCtx, cancelSecondary := schemas.NewBifrostContextWithCancel(ctx)
+	defer cancelSecondary()
+	secondaryCtx.SetValue(schemas.BifrostContextKeyFallbackIndex, 1)
+	secondaryCtx.SetValue(schemas.BifrostContextKeyFallbackRequestID, uuid.New().String())
+	clearCtxForFallback(secondaryCtx)
+	go func() {
+		resp, err := bifrost.tryRequest(secondaryCtx, secondaryReq)
+		legs <- hedgeLeg{ctx: secondaryCtx, resp: resp, err: err, secondary: true}
+	}()
+
+	var primaryLeg, secondaryLeg hedgeLeg
+	for range 2 {
+		leg := <-legs
+		if leg.err == nil {
+			// Cancel the loser before anything else so it stops spending tokens.
+			if leg.secondary {
+				cancelPrimary()
+			} else {
+				cancelSecondary()
+			}
+			ctx.AdoptValues(leg.ctx)
+			winnerProvider, winnerModel := provider, model
+			if leg.secondary {
+				winnerProvider, winnerModel = secondary.Provider, secondary.Model
+				leg.resp.SetFallbackRoutingInfo(provider, model)
+			}
+			if extraFields := leg.resp.GetExtraFields(); extraFields != nil {
+				extraFields.Hedge = &schemas.HedgeInfo{
+					DelayMs:        delay.Milliseconds(),
+					WinnerProvider: winnerProvider,
+					WinnerModel:    winnerModel,
+					SecondaryWon:   leg.secondary,
+				}
+			}
+			schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+			ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelInfo, fmt.Sprintf("Primary %s/%s did not answer within %s; hedged with %s/%s, %s/%s won", provider, model, delay, secondary.Provider, secondary.Model, winnerProvider, winnerModel))
+			return leg.resp, nil, true
+		}
+		if leg.secondary {
+			secondaryLeg = leg
+		} else {
+			primaryLeg = leg
+		}
+	}
+
+	ctx.AdoptValues(primaryLeg.ctx)
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, fmt.Sprintf("Hedged request failed on both %s/%s (%s) and %s/%s (%s)", provider, model, routingErrorSummary(primaryLeg.err), secondary.Provider, secondary.Model, routingErrorSummary(secondaryLeg.err)))
+	return nil, primaryLeg.err, true
+}
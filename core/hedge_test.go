@@ -0,0 +1,109 @@
+package bifrost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// openAIChatHandler answers chat completions with content after waiting delay, giving up
+// early when the client disconnects.
+func openAIChatHandler(content string, delay time.Duration, hits *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o-mini","choices":[{"index":0,"message":{"role":"assistant","content":%q},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`, content)
+	}
+}
+
+func newHedgeTestClient(t *testing.T, primaryDelay, secondaryDelay time.Duration) (*Bifrost, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var primaryHits, secondaryHits atomic.Int32
+	primary := httptest.NewServer(openAIChatHandler("primary", primaryDelay, &primaryHits))
+	t.Cleanup(primary.Close)
+	secondary := httptest.NewServer(openAIChatHandler("secondary", secondaryDelay, &secondaryHits))
+	t.Cleanup(secondary.Close)
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, primary.URL)
+	account.AddProviderWithBaseURL(schemas.Groq, 1, 1, secondary.URL)
+	account.configs[schemas.OpenAI].NetworkConfig.MaxRetries = 0
+	account.configs[schemas.Groq].NetworkConfig.MaxRetries = 0
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "primary-key", Value: *schemas.NewSecretVar("sk-primary"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	account.SetKeysForProvider(schemas.Groq, []schemas.Key{
+		{ID: "secondary-key", Value: *schemas.NewSecretVar("sk-secondary"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	return newStreamTestClient(t, account), &primaryHits, &secondaryHits
+}
+
+func hedgedChatRequest() *schemas.BifrostChatRequest {
+	return &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4o-mini",
+		Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}},
+		},
+		Fallbacks: []schemas.Fallback{{Provider: schemas.Groq, Model: "llama-3.1-8b-instant"}},
+	}
+}
+
+func TestHedgedRequestSecondaryWins(t *testing.T) {
+	client, primaryHits, secondaryHits := newHedgeTestClient(t, 2*time.Second, 0)
+
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeyHedgeDelay, 50*time.Millisecond)
+	start := time.Now()
+	resp, bifrostErr := client.ChatCompletionRequest(ctx, hedgedChatRequest())
+	if bifrostErr != nil {
+		t.Fatalf("hedged request failed: %s", bifrostErr.Error.Message)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("hedged request took %s, expected the secondary to answer first", elapsed)
+	}
+	if got := *resp.Choices[0].Message.Content.ContentStr; got != "secondary" {
+		t.Fatalf("expected the secondary's answer, got %q", got)
+	}
+	hedge := resp.ExtraFields.Hedge
+	if hedge == nil || !hedge.SecondaryWon || hedge.WinnerProvider != schemas.Groq || hedge.DelayMs != 50 {
+		t.Fatalf("unexpected hedge info %+v", hedge)
+	}
+	if !resp.ExtraFields.RoutingInfo.IsFallback {
+		t.Fatal("a secondary win should be reported as a fallback")
+	}
+	if primaryHits.Load() != 1 || secondaryHits.Load() != 1 {
+		t.Fatalf("expected one call to each provider, got %d and %d", primaryHits.Load(), secondaryHits.Load())
+	}
+}
+
+func TestHedgedRequestFastPrimarySkipsSecondary(t *testing.T) {
+	client, _, secondaryHits := newHedgeTestClient(t, 0, 0)
+
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeyHedgeDelay, time.Second)
+	resp, bifrostErr := client.ChatCompletionRequest(ctx, hedgedChatRequest())
+	if bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+	if got := *resp.Choices[0].Message.Content.ContentStr; got != "primary" {
+		t.Fatalf("expected the primary's answer, got %q", got)
+	}
+	if resp.ExtraFields.Hedge != nil {
+		t.Fatal("a request answered within the delay is not hedged")
+	}
+	if secondaryHits.Load() != 0 {
+		t.Fatal("the secondary must not be called when the primary answers within the delay")
+	}
+}
@@ -0,0 +1,252 @@
+package bifrost
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder for downscaling
+	"image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// imageInputProfile describes how a provider takes images in chat content.
+type imageInputProfile struct {
+	inlineOnly bool // the provider cannot fetch http(s) image URLs itself
+	maxBytes   int  // limit on one image's base64 payload; 0 = no known limit
+}
+
+// imageInputProfiles holds the documented image limits of providers whose
+// limits differ from "URLs accepted, no limit".
+var imageInputProfiles = map[schemas.ModelProvider]imageInputProfile{
+	schemas.OpenAI:    {maxBytes: 20 * 1024 * 1024},
+	schemas.Azure:     {maxBytes: 20 * 1024 * 1024},
+	schemas.Anthropic: {maxBytes: 5 * 1024 * 1024},
+	schemas.Bedrock:   {inlineOnly: true, maxBytes: 3_750_000},
+	schemas.Gemini:    {inlineOnly: true, maxBytes: 20 * 1024 * 1024},
+	schemas.Vertex:    {inlineOnly: true, maxBytes: 20 * 1024 * 1024},
+	schemas.Ollama:    {inlineOnly: true},
+}
+
+// maxDownscaleAttempts bounds how often an image is re-encoded while looking
+// for a size under the limit.
+const maxDownscaleAttempts = 6
+
+// imagePreprocessor normalizes the images of chat requests for the provider
+// each attempt is sent to. A nil *imagePreprocessor leaves requests untouched.
+type imagePreprocessor struct {
+	config schemas.ImagePreprocessingConfig
+	fetch  func(ctx context.Context, url string) (mediaType string, encoded string, err error)
+}
+
+// newImagePreprocessor returns a preprocessor for config, or nil when config is nil.
+func newImagePreprocessor(config *schemas.ImagePreprocessingConfig) *imagePreprocessor {
+	if config == nil {
+		return nil
+	}
+	return &imagePreprocessor{config: *config, fetch: providerUtils.FetchAndEncodeURL}
+}
+
+// profile returns the image profile of provider, with the configured size
+// limit override applied.
+func (p *imagePreprocessor) profile(provider schemas.ModelProvider) imageInputProfile {
+	profile := imageInputProfiles[provider]
+	if limit, ok := p.config.MaxImageBytes[provider]; ok {
+		profile.maxBytes = limit
+	}
+	return profile
+}
+
+// preprocess returns req with its chat images normalized for the request's
+// provider. The caller's request is never modified: when an image changes,
+// the returned request carries copies of the affected messages, so later
+// fallbacks start from the original images.
+func (p *imagePreprocessor) preprocess(ctx context.Context, req *schemas.BifrostRequest) (*schemas.BifrostRequest, error) {
+	if p == nil || req.ChatRequest == nil {
+		return req, nil
+	}
+	provider, _, _ := req.GetRequestFields()
+	profile := p.profile(provider)
+	var messages []schemas.ChatMessage
+	for i, message := range req.ChatRequest.Input {
+		if message.Content == nil {
+			continue
+		}
+		var blocks []schemas.ChatContentBlock
+		for j, block := range message.Content.ContentBlocks {
+			if block.ImageURLStruct == nil {
+				continue
+			}
+			normalized, err := p.normalizeImage(ctx, block.ImageURLStruct.URL, profile)
+			if err != nil {
+				return nil, fmt.Errorf("image in message %d, content block %d: %w", i, j, err)
+			}
+			if normalized == block.ImageURLStruct.URL {
+				continue
+			}
+			if messages == nil {
+				messages = append([]schemas.ChatMessage(nil), req.ChatRequest.Input...)
+			}
+			if blocks == nil {
+				blocks = append([]schemas.ChatContentBlock(nil), message.Content.ContentBlocks...)
+			}
+			img := *block.ImageURLStruct
+			img.URL = normalized
+			blocks[j].ImageURLStruct = &img
+		}
+		if blocks != nil {
+			content := *message.Content
+			content.ContentBlocks = blocks
+			messages[i].Content = &content
+		}
+	}
+	if messages == nil {
+		return req, nil
+	}
+	chat := *req.ChatRequest
+	chat.Input = messages
+	out := *req
+	out.ChatRequest = &chat
+	return &out, nil
+}
+
+// normalizeImage validates one image reference and returns it in a form the
+// provider accepts: http(s) URLs are inlined as data URLs when the provider
+// cannot fetch them (or InlineURLs is set), and inline images above the
+// provider's limit are downscaled when enabled. References with other schemes
+// (e.g. gs://) are provider-specific and returned as is.
+func (p *imagePreprocessor) normalizeImage(ctx context.Context, rawURL string, profile imageInputProfile) (string, error) {
+	trimmed := strings.TrimSpace(rawURL)
+	lower := strings.ToLower(trimmed)
+	isRemote := strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+	if !isRemote && strings.Contains(trimmed, "://") {
+		return rawURL, nil
+	}
+	sanitized, err := schemas.SanitizeImageURL(trimmed)
+	if err != nil {
+		return "", err
+	}
+	info := schemas.ExtractURLTypeInfo(sanitized)
+	if info.Type == schemas.ImageContentTypeURL {
+		if !isRemote || !(profile.inlineOnly || p.config.InlineURLs) {
+			return sanitized, nil
+		}
+		mediaType, encoded, err := p.fetch(ctx, sanitized)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(mediaType, "image/") {
+			return "", fmt.Errorf("%s is not an image (content type %q)", sanitized, mediaType)
+		}
+		sanitized = "data:" + mediaType + ";base64," + encoded
+		info = schemas.ExtractURLTypeInfo(sanitized)
+	}
+	if info.DataURLWithoutPrefix == nil || profile.maxBytes == 0 || len(*info.DataURLWithoutPrefix) <= profile.maxBytes {
+		return sanitized, nil
+	}
+	if !p.config.Downscale {
+		return "", fmt.Errorf("image is %d bytes, above the provider limit of %d bytes", len(*info.DataURLWithoutPrefix), profile.maxBytes)
+	}
+	data, err := base64.StdEncoding.DecodeString(*info.DataURLWithoutPrefix)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 image data: %w", err)
+	}
+	mediaType, encoded, err := downscaleImage(data, profile.maxBytes)
+	if err != nil {
+		return "", err
+	}
+	return "data:" + mediaType + ";base64," + encoded, nil
+}
+
+// downscaleImage re-encodes an image at decreasing resolutions until its
+// base64 encoding fits in maxBytes. PNGs stay PNGs so transparency survives;
+// other formats are re-encoded as JPEG.
+func downscaleImage(data []byte, maxBytes int) (mediaType string, encoded string, err error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", "", fmt.Errorf("image is above the provider limit and cannot be decoded for downscaling: %w", err)
+	}
+	mediaType = "image/jpeg"
+	if format == "png" {
+		mediaType = "image/png"
+	}
+	bounds := src.Bounds()
+	size := base64.StdEncoding.EncodedLen(len(data))
+	width, height := bounds.Dx(), bounds.Dy()
+	for range maxDownscaleAttempts {
+		// Encoded size scales roughly with the pixel count; aim a little below the limit.
+		scale := math.Sqrt(float64(maxBytes)/float64(size)) * 0.9
+		width = max(1, int(float64(width)*scale))
+		height = max(1, int(float64(height)*scale))
+		var buf bytes.Buffer
+		resized := resizeImage(src, width, height)
+		if mediaType == "image/png" {
+			err = png.Encode(&buf, resized)
+		} else {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to re-encode downscaled image: %w", err)
+		}
+		encoded = base64.StdEncoding.EncodeToString(buf.Bytes())
+		if len(encoded) <= maxBytes {
+			return mediaType, encoded, nil
+		}
+		size = len(encoded)
+	}
+	return "", "", fmt.Errorf("image could not be downscaled below the provider limit of %d bytes", maxBytes)
+}
+
+// resizeImage scales src to width x height by averaging the source pixels
+// that fall into each destination pixel.
+func resizeImage(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// preprocessImages applies the image preprocessing stage to one attempt. A
+// failure is the caller's fault (an unreachable or invalid image), so it is
+// reported as a 400 without trying the provider.
+func (bifrost *Bifrost) preprocessImages(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.BifrostError) {
+	out, err := bifrost.imagePreprocessor.preprocess(ctx, req)
+	if err != nil {
+		provider, model, _ := req.GetRequestFields()
+		bifrostErr := &schemas.BifrostError{
+			IsBifrostError: false,
+			StatusCode:     schemas.Ptr(fasthttp.StatusBadRequest),
+			Error: &schemas.ErrorField{
+				Message: fmt.Sprintf("invalid image input: %v", err),
+				Error:   err,
+			},
+		}
+		bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
+		return nil, bifrostErr
+	}
+	return out, nil
+}
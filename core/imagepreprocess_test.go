@@ -0,0 +1,125 @@
+package bifrost
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"strings"
+	"testing"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// noisyPNG returns a PNG that compresses poorly, so its size tracks its resolution.
+func noisyPNG(t *testing.T, width, height int) string {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func imageChatRequest(provider schemas.ModelProvider, url string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: provider,
+			Model:    "m",
+			Input: []schemas.ChatMessage{{
+				Role: schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+					{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("what is this?")},
+					{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: url}},
+				}},
+			}},
+		},
+	}
+}
+
+func imageURLOf(req *schemas.BifrostRequest) string {
+	return req.ChatRequest.Input[0].Content.ContentBlocks[1].ImageURLStruct.URL
+}
+
+func TestImagePreprocessingInlinesURLsForInlineOnlyProviders(t *testing.T) {
+	var fetched []string
+	p := newImagePreprocessor(&schemas.ImagePreprocessingConfig{})
+	p.fetch = func(_ context.Context, url string) (string, string, error) {
+		fetched = append(fetched, url)
+		return "image/png", "iVBORw0KGgo=", nil
+	}
+
+	original := imageChatRequest(schemas.Bedrock, "https://example.com/cat.png")
+	out, err := p.preprocess(context.Background(), original)
+	if err != nil {
+		t.Fatalf("preprocess failed: %v", err)
+	}
+	if got := imageURLOf(out); got != "data:image/png;base64,iVBORw0KGgo=" {
+		t.Fatalf("expected an inlined image, got %q", got)
+	}
+	if imageURLOf(original) != "https://example.com/cat.png" {
+		t.Fatal("the caller's request must not be modified")
+	}
+
+	out, err = p.preprocess(context.Background(), imageChatRequest(schemas.OpenAI, "https://example.com/cat.png"))
+	if err != nil || imageURLOf(out) != "https://example.com/cat.png" {
+		t.Fatalf("providers that fetch URLs should get the URL, got %q (%v)", imageURLOf(out), err)
+	}
+	if len(fetched) != 1 {
+		t.Fatalf("expected one fetch, got %d", len(fetched))
+	}
+
+	out, err = p.preprocess(context.Background(), imageChatRequest(schemas.Vertex, "gs://bucket/cat.png"))
+	if err != nil || imageURLOf(out) != "gs://bucket/cat.png" {
+		t.Fatalf("provider-specific URIs should pass through, got %q (%v)", imageURLOf(out), err)
+	}
+}
+
+func TestImagePreprocessingRejectsNonImages(t *testing.T) {
+	p := newImagePreprocessor(&schemas.ImagePreprocessingConfig{})
+	p.fetch = func(context.Context, string) (string, string, error) {
+		return "text/html", "PGh0bWw+", nil
+	}
+	_, err := p.preprocess(context.Background(), imageChatRequest(schemas.Gemini, "https://example.com/page"))
+	if err == nil || !strings.Contains(err.Error(), "is not an image") {
+		t.Fatalf("expected a non-image error, got %v", err)
+	}
+}
+
+func TestImagePreprocessingDownscalesOversizedImages(t *testing.T) {
+	encoded := noisyPNG(t, 200, 200)
+	limit := len(encoded) / 3
+	overrides := map[schemas.ModelProvider]int{schemas.Anthropic: limit}
+
+	strict := newImagePreprocessor(&schemas.ImagePreprocessingConfig{MaxImageBytes: overrides})
+	_, err := strict.preprocess(context.Background(), imageChatRequest(schemas.Anthropic, "data:image/png;base64,"+encoded))
+	if err == nil || !strings.Contains(err.Error(), "above the provider limit") {
+		t.Fatalf("expected a size limit error without downscaling, got %v", err)
+	}
+
+	p := newImagePreprocessor(&schemas.ImagePreprocessingConfig{Downscale: true, MaxImageBytes: overrides})
+	out, err := p.preprocess(context.Background(), imageChatRequest(schemas.Anthropic, "data:image/png;base64,"+encoded))
+	if err != nil {
+		t.Fatalf("preprocess failed: %v", err)
+	}
+	payload, ok := strings.CutPrefix(imageURLOf(out), "data:image/png;base64,")
+	if !ok || len(payload) > limit {
+		t.Fatalf("expected a PNG within %d bytes, got %d bytes", limit, len(payload))
+	}
+	data, _ := base64.StdEncoding.DecodeString(payload)
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width >= 200 || config.Width != config.Height {
+		t.Fatalf("expected a smaller square image, got %+v (%v)", config, err)
+	}
+}
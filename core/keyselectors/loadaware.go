@@ -0,0 +1,132 @@
+package keyselectors
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// latencyEWMAWeight is the weight of the newest sample in a key's latency average.
+const latencyEWMAWeight = 0.2
+
+// keyLoad is the live load of one key.
+type keyLoad struct {
+	pending atomic.Int64
+	// latencyNs is the exponentially weighted average latency; 0 until the first sample.
+	latencyNs atomic.Int64
+}
+
+// LoadTracker records in-flight requests and recent latency per key ID for the
+// least_pending and least_latency strategies. It is safe for concurrent use.
+type LoadTracker struct {
+	keys sync.Map // key ID -> *keyLoad
+}
+
+// NewLoadTracker returns an empty LoadTracker.
+func NewLoadTracker() *LoadTracker {
+	return &LoadTracker{}
+}
+
+func (t *LoadTracker) load(keyID string) *keyLoad {
+	if v, ok := t.keys.Load(keyID); ok {
+		return v.(*keyLoad)
+	}
+	v, _ := t.keys.LoadOrStore(keyID, &keyLoad{})
+	return v.(*keyLoad)
+}
+
+// Acquire marks a request to keyID as in flight. Every Acquire must be paired with a Release.
+func (t *LoadTracker) Acquire(keyID string) {
+	t.load(keyID).pending.Add(1)
+}
+
+// Release marks a request to keyID as finished.
+func (t *LoadTracker) Release(keyID string) {
+	t.load(keyID).pending.Add(-1)
+}
+
+// ObserveLatency folds a successful request's latency into keyID's average.
+func (t *LoadTracker) ObserveLatency(keyID string, latency time.Duration) {
+	l := t.load(keyID)
+	for {
+		old := l.latencyNs.Load()
+		next := int64(latency)
+		if old > 0 {
+			next = int64(latencyEWMAWeight*float64(latency) + (1-latencyEWMAWeight)*float64(old))
+		}
+		if next <= 0 {
+			next = 1
+		}
+		if l.latencyNs.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+// Pending returns the number of in-flight requests to keyID.
+func (t *LoadTracker) Pending(keyID string) int64 {
+	return t.load(keyID).pending.Load()
+}
+
+// Latency returns keyID's average latency, or 0 if no request has succeeded yet.
+func (t *LoadTracker) Latency(keyID string) time.Duration {
+	return time.Duration(t.load(keyID).latencyNs.Load())
+}
+
+// LeastPending returns a selector that picks the key with the fewest in-flight requests per
+// unit of weight, so a key with twice the weight carries twice the concurrent load. Keys with
+// zero weight are only used when every key has zero weight. Ties are broken at random.
+func LeastPending(tracker *LoadTracker) schemas.KeySelector {
+	return func(ctx *schemas.BifrostContext, keys []schemas.Key, providerKey schemas.ModelProvider, model string) (schemas.Key, error) {
+		return pickLowest(keys, func(key schemas.Key) float64 {
+			weight := key.Weight
+			if weight <= 0 {
+				weight = 1
+			}
+			return float64(tracker.Pending(key.ID)+1) / weight
+		}), nil
+	}
+}
+
+// LeastLatency returns a selector that picks the key with the lowest average latency. Keys
+// without a latency sample yet are tried first so every key gets measured. Keys with zero
+// weight are only used when every key has zero weight. Ties are broken at random.
+func LeastLatency(tracker *LoadTracker) schemas.KeySelector {
+	return func(ctx *schemas.BifrostContext, keys []schemas.Key, providerKey schemas.ModelProvider, model string) (schemas.Key, error) {
+		return pickLowest(keys, func(key schemas.Key) float64 {
+			return float64(tracker.Latency(key.ID))
+		}), nil
+	}
+}
+
+// pickLowest returns the positive-weight key with the lowest score, falling back to all keys
+// when none has a positive weight.
+func pickLowest(keys []schemas.Key, score func(schemas.Key) float64) schemas.Key {
+	candidates := keys
+	weighted := make([]schemas.Key, 0, len(keys))
+	for _, key := range keys {
+		if key.Weight > 0 {
+			weighted = append(weighted, key)
+		}
+	}
+	if len(weighted) > 0 {
+		candidates = weighted
+	}
+
+	best := math.Inf(1)
+	var tied []schemas.Key
+	for _, key := range candidates {
+		switch s := score(key); {
+		case s < best:
+			best = s
+			tied = append(tied[:0], key)
+		case s == best:
+			tied = append(tied, key)
+		}
+	}
+	return tied[rand.Intn(len(tied))]
+}
@@ -0,0 +1,56 @@
+package keyselectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func TestLeastPendingRespectsWeights(t *testing.T) {
+	tracker := NewLoadTracker()
+	selector := LeastPending(tracker)
+	keys := []schemas.Key{{ID: "big", Weight: 3}, {ID: "small", Weight: 1}}
+
+	// Acquiring every pick should settle into a 3:1 split of in-flight requests.
+	for range 8 {
+		key, err := selector(nil, keys, schemas.OpenAI, "gpt-4o")
+		if err != nil {
+			t.Fatalf("selector failed: %v", err)
+		}
+		tracker.Acquire(key.ID)
+	}
+	if tracker.Pending("big") != 6 || tracker.Pending("small") != 2 {
+		t.Fatalf("expected 6/2 in flight, got %d/%d", tracker.Pending("big"), tracker.Pending("small"))
+	}
+
+	tracker.Release("small")
+	tracker.Release("small")
+	if key, _ := selector(nil, keys, schemas.OpenAI, "gpt-4o"); key.ID != "small" {
+		t.Fatalf("expected the idle key to be picked, got %s", key.ID)
+	}
+}
+
+func TestLeastLatencyProbesUnmeasuredKeysFirst(t *testing.T) {
+	tracker := NewLoadTracker()
+	selector := LeastLatency(tracker)
+	keys := []schemas.Key{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}, {ID: "disabled", Weight: 0}}
+
+	tracker.ObserveLatency("a", 100*time.Millisecond)
+	if key, _ := selector(nil, keys, schemas.OpenAI, "gpt-4o"); key.ID != "b" {
+		t.Fatalf("expected the unmeasured key to be probed, got %s", key.ID)
+	}
+
+	tracker.ObserveLatency("b", 400*time.Millisecond)
+	if key, _ := selector(nil, keys, schemas.OpenAI, "gpt-4o"); key.ID != "a" {
+		t.Fatalf("expected the faster key, got %s", key.ID)
+	}
+
+	// A run of fast responses pulls b's average below a's.
+	for range 20 {
+		tracker.ObserveLatency("b", 10*time.Millisecond)
+	}
+	if key, _ := selector(nil, keys, schemas.OpenAI, "gpt-4o"); key.ID != "b" {
+		t.Fatalf("expected b after it sped up, got %s (latency %s)", key.ID, tracker.Latency("b"))
+	}
+}
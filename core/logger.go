@@ -20,6 +20,9 @@ var zerologOnce sync.Once
 type DefaultLogger struct {
 	stderrLogger zerolog.Logger
 	stdoutLogger zerolog.Logger
+	// structured is true when the output type is LoggerOutputTypeStructured; only then
+	// does WithFields attach correlation fields to log lines.
+	structured bool
 }
 
 // toZerologLevel converts a Bifrost log level to a Zerolog level.
@@ -106,10 +109,15 @@ func (logger *DefaultLogger) SetLevel(level schemas.LogLevel) {
 // This determines the format of the log output.
 // If the output type is unknown, it defaults to JSON
 func (logger *DefaultLogger) SetOutputType(outputType schemas.LoggerOutputType) {
+	logger.structured = false
 	switch outputType {
 	case schemas.LoggerOutputTypePretty:
 		logger.stdoutLogger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
 		logger.stderrLogger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
+	case schemas.LoggerOutputTypeStructured:
+		logger.structured = true
+		logger.stdoutLogger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		logger.stderrLogger = zerolog.New(os.Stderr).With().Timestamp().Logger()
 	case schemas.LoggerOutputTypeJSON:
 		logger.stdoutLogger = zerolog.New(os.Stdout).With().Timestamp().Logger()
 		logger.stderrLogger = zerolog.New(os.Stderr).With().Timestamp().Logger()
@@ -121,6 +129,41 @@ func (logger *DefaultLogger) SetOutputType(outputType schemas.LoggerOutputType)
 	}
 }
 
+// WithFields returns a child logger that attaches the non-empty correlation fields
+// to every log line. Outside of structured mode the logger itself is returned so
+// plain JSON and pretty output stay unchanged.
+func (logger *DefaultLogger) WithFields(fields schemas.LogFields) schemas.Logger {
+	if !logger.structured {
+		return logger
+	}
+	return &DefaultLogger{
+		stdoutLogger: withLogFields(logger.stdoutLogger, fields),
+		stderrLogger: withLogFields(logger.stderrLogger, fields),
+		structured:   true,
+	}
+}
+
+// withLogFields returns a copy of l with the non-empty correlation fields added to its context.
+func withLogFields(l zerolog.Logger, fields schemas.LogFields) zerolog.Logger {
+	c := l.With()
+	if fields.RequestID != "" {
+		c = c.Str("request_id", fields.RequestID)
+	}
+	if fields.TraceID != "" {
+		c = c.Str("trace_id", fields.TraceID)
+	}
+	if fields.Provider != "" {
+		c = c.Str("provider", string(fields.Provider))
+	}
+	if fields.Model != "" {
+		c = c.Str("model", fields.Model)
+	}
+	if fields.Component != "" {
+		c = c.Str("component", fields.Component)
+	}
+	return c.Logger()
+}
+
 // NoOpLogger is a no-op implementation of schemas.Logger.
 type NoOpLogger struct{}
 
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// toolOutputSchema returns the output schema an MCP tool declares, or nil when it
+// declares none.
+func toolOutputSchema(mcpTool *mcp.Tool) json.RawMessage {
+	if len(mcpTool.RawOutputSchema) > 0 {
+		return append(json.RawMessage(nil), mcpTool.RawOutputSchema...)
+	}
+	if mcpTool.OutputSchema.Type == "" {
+		return nil
+	}
+	raw, err := json.Marshal(mcp.ToolArgumentsSchema(mcpTool.OutputSchema))
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// validateToolOutput checks a successful tool result against the tool's declared output
+// schema and applies mode. It returns the text to hand the model, which differs from
+// responseText only when the result was coerced or rejected, and the validation record,
+// which is nil when validation is off or the tool declares no schema.
+//
+// The tool's structuredContent is validated; servers that predate structured content
+// are validated on their text result parsed as JSON.
+func validateToolOutput(mode schemas.MCPOutputSchemaValidation, toolName string, outputSchema json.RawMessage, toolResponse *mcp.CallToolResult, responseText string) (string, *schemas.MCPOutputSchemaValidationResult) {
+	if mode == "" || mode == schemas.MCPOutputSchemaValidationOff || len(outputSchema) == 0 || toolResponse == nil || toolResponse.IsError {
+		return responseText, nil
+	}
+	result := &schemas.MCPOutputSchemaValidationResult{Mode: mode}
+
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(outputSchema))
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid output schema: %v", err)
+		return responseText, result
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("output_schema.json", schemaDoc); err != nil {
+		result.Error = fmt.Sprintf("invalid output schema: %v", err)
+		return responseText, result
+	}
+	schema, err := compiler.Compile("output_schema.json")
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid output schema: %v", err)
+		return responseText, result
+	}
+
+	// Round trip structuredContent so numbers reach the validator as json.Number,
+	// the same as a document parsed from text.
+	raw := []byte(responseText)
+	if toolResponse.StructuredContent != nil {
+		if raw, err = json.Marshal(toolResponse.StructuredContent); err != nil {
+			result.Error = fmt.Sprintf("failed to read structured content: %v", err)
+			return rejectToolOutput(mode, toolName, responseText, result)
+		}
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		result.Error = "tool returned no structured content"
+		return rejectToolOutput(mode, toolName, responseText, result)
+	}
+	validationErr := schema.Validate(doc)
+	if validationErr == nil {
+		result.Valid = true
+		return responseText, result
+	}
+	result.Error = validationErr.Error()
+
+	if mode == schemas.MCPOutputSchemaValidationCoerce {
+		if schemaMap, ok := schemaDoc.(map[string]any); ok {
+			coerced := coerceToSchema(doc, schemaMap)
+			if schema.Validate(coerced) == nil {
+				if coercedJSON, err := json.Marshal(coerced); err == nil {
+					result.Valid = true
+					result.Coerced = true
+					return string(coercedJSON), result
+				}
+			}
+		}
+	}
+	return rejectToolOutput(mode, toolName, responseText, result)
+}
+
+// rejectToolOutput applies mode to a result that does not match its schema. Only the
+// error mode changes what the model sees; coerce falls back to passing the result
+// through when coercion could not fix it.
+func rejectToolOutput(mode schemas.MCPOutputSchemaValidation, toolName, responseText string, result *schemas.MCPOutputSchemaValidationResult) (string, *schemas.MCPOutputSchemaValidationResult) {
+	if mode != schemas.MCPOutputSchemaValidationError {
+		return responseText, result
+	}
+	return fmt.Sprintf("Error: the result of tool '%s' does not match its declared output schema: %s", toolName, result.Error), result
+}
+
+// coerceToSchema converts scalars to the types schema declares (strings holding numbers
+// or booleans, numbers and booleans where strings are expected) and drops object fields
+// the schema forbids with additionalProperties: false. $ref and combinators are not
+// followed, so values under them are left as they are.
+func coerceToSchema(value any, schema map[string]any) any {
+	types := schemaTypes(schema["type"])
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		closed := schema["additionalProperties"] == false
+		for key, field := range v {
+			fieldSchema, ok := properties[key].(map[string]any)
+			switch {
+			case ok:
+				v[key] = coerceToSchema(field, fieldSchema)
+			case closed:
+				delete(v, key)
+			}
+		}
+		return v
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				v[i] = coerceToSchema(item, items)
+			}
+		}
+		return v
+	case string:
+		for _, t := range types {
+			switch t {
+			case "string":
+				return v
+			case "integer":
+				if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+					return json.Number(v)
+				}
+			case "number":
+				if _, err := strconv.ParseFloat(v, 64); err == nil {
+					return json.Number(v)
+				}
+			case "boolean":
+				if b, err := strconv.ParseBool(v); err == nil {
+					return b
+				}
+			}
+		}
+	case json.Number:
+		if len(types) > 0 && !slices.Contains(types, "number") && !slices.Contains(types, "integer") && slices.Contains(types, "string") {
+			return v.String()
+		}
+	case bool:
+		if len(types) > 0 && !slices.Contains(types, "boolean") && slices.Contains(types, "string") {
+			return strconv.FormatBool(v)
+		}
+	}
+	return value
+}
+
+// schemaTypes returns the types a schema's "type" keyword allows.
+func schemaTypes(t any) []string {
+	switch t := t.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var weatherOutputSchema = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"city": {"type": "string"},
+		"temperature": {"type": "number"},
+		"raining": {"type": "boolean"}
+	},
+	"required": ["city", "temperature"],
+	"additionalProperties": false
+}`)
+
+func TestConvertMCPToolToBifrostSchema_KeepsOutputSchema(t *testing.T) {
+	tool := convertMCPToolToBifrostSchema(&mcp.Tool{
+		Name:         "weather",
+		InputSchema:  mcp.ToolInputSchema{Type: "object"},
+		OutputSchema: mcp.ToolOutputSchema{Type: "object", Properties: map[string]any{"city": map[string]any{"type": "string"}}, Required: []string{"city"}},
+	}, nil)
+	assert.JSONEq(t, `{"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]}`, string(tool.OutputSchema))
+
+	raw := convertMCPToolToBifrostSchema(&mcp.Tool{Name: "weather", InputSchema: mcp.ToolInputSchema{Type: "object"}, RawOutputSchema: weatherOutputSchema}, nil)
+	assert.JSONEq(t, string(weatherOutputSchema), string(raw.OutputSchema))
+
+	none := convertMCPToolToBifrostSchema(&mcp.Tool{Name: "weather", InputSchema: mcp.ToolInputSchema{Type: "object"}}, nil)
+	assert.Nil(t, none.OutputSchema)
+
+	// The schema is Bifrost-internal and never sent to providers.
+	body, err := json.Marshal(tool)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "required\":[\"city\"]")
+}
+
+func TestValidateToolOutput(t *testing.T) {
+	drifted := &mcp.CallToolResult{StructuredContent: map[string]any{"city": "Paris", "temperature": "21.5", "raining": "false", "humidity": 40}}
+	const driftedText = `{"city":"Paris","temperature":"21.5","raining":"false","humidity":40}`
+
+	t.Run("off", func(t *testing.T) {
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationOff, "weather", weatherOutputSchema, drifted, driftedText)
+		assert.Equal(t, driftedText, text)
+		assert.Nil(t, result)
+	})
+
+	t.Run("valid result", func(t *testing.T) {
+		valid := &mcp.CallToolResult{StructuredContent: map[string]any{"city": "Paris", "temperature": 21.5}}
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationError, "weather", weatherOutputSchema, valid, "ok")
+		assert.Equal(t, "ok", text)
+		require.NotNil(t, result)
+		assert.True(t, result.Valid)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("warn passes the result through", func(t *testing.T) {
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationWarn, "weather", weatherOutputSchema, drifted, driftedText)
+		assert.Equal(t, driftedText, text)
+		require.NotNil(t, result)
+		assert.False(t, result.Valid)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("coerce fixes scalar drift", func(t *testing.T) {
+		content := map[string]any{"city": "Paris", "temperature": "21.5", "raining": "false", "humidity": 40}
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationCoerce, "weather", weatherOutputSchema, &mcp.CallToolResult{StructuredContent: content}, driftedText)
+		assert.JSONEq(t, `{"city": "Paris", "temperature": 21.5, "raining": false}`, text)
+		require.NotNil(t, result)
+		assert.True(t, result.Valid)
+		assert.True(t, result.Coerced)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("coerce passes through what it cannot fix", func(t *testing.T) {
+		missing := &mcp.CallToolResult{StructuredContent: map[string]any{"temperature": 21.5}}
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationCoerce, "weather", weatherOutputSchema, missing, `{"temperature":21.5}`)
+		assert.Equal(t, `{"temperature":21.5}`, text)
+		require.NotNil(t, result)
+		assert.False(t, result.Valid)
+		assert.False(t, result.Coerced)
+	})
+
+	t.Run("error replaces the result", func(t *testing.T) {
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationError, "weather", weatherOutputSchema, drifted, driftedText)
+		assert.Contains(t, text, "does not match its declared output schema")
+		require.NotNil(t, result)
+		assert.False(t, result.Valid)
+	})
+
+	t.Run("text result without structured content", func(t *testing.T) {
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationError, "weather", weatherOutputSchema, &mcp.CallToolResult{}, `{"city": "Paris", "temperature": 21.5}`)
+		assert.Equal(t, `{"city": "Paris", "temperature": 21.5}`, text)
+		assert.True(t, result.Valid)
+
+		_, result = validateToolOutput(schemas.MCPOutputSchemaValidationWarn, "weather", weatherOutputSchema, &mcp.CallToolResult{}, "It is sunny in Paris")
+		assert.Equal(t, "tool returned no structured content", result.Error)
+	})
+
+	t.Run("tool errors are not validated", func(t *testing.T) {
+		text, result := validateToolOutput(schemas.MCPOutputSchemaValidationError, "weather", weatherOutputSchema, &mcp.CallToolResult{IsError: true}, "upstream timeout")
+		assert.Equal(t, "upstream timeout", text)
+		assert.Nil(t, result)
+	})
+}
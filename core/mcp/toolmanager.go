@@ -606,7 +606,7 @@ func (m *ToolsManager) ExecuteTool(
 	now := time.Now()
 
 	// Execute the tool in Chat format (internal execution format)
-	chatResult, clientName, originalToolName, err := m.executeToolInternal(ctx, toolCall, clientConn, executionConfig, toolNameMapping)
+	chatResult, clientName, originalToolName, outputValidation, err := m.executeToolInternal(ctx, toolCall, clientConn, executionConfig, toolNameMapping)
 	if err != nil {
 		return nil, err
 	}
@@ -614,9 +614,10 @@ func (m *ToolsManager) ExecuteTool(
 	latency := time.Since(now).Milliseconds()
 
 	extraFields := schemas.BifrostMCPResponseExtraFields{
-		ClientName: clientName,
-		ToolName:   originalToolName,
-		Latency:    latency,
+		ClientName:             clientName,
+		ToolName:               originalToolName,
+		Latency:                latency,
+		OutputSchemaValidation: outputValidation,
 	}
 
 	// Return result in the appropriate format
@@ -646,20 +647,20 @@ func (m *ToolsManager) ExecuteTool(
 
 // executeToolInternal is the internal tool executor that works with Chat format.
 // This is used internally by ExecuteTool after format conversion.
-// Returns: (message, clientName, originalToolName, error)
+// Returns: (message, clientName, originalToolName, outputSchemaValidation, error)
 func (m *ToolsManager) executeToolInternal(
 	ctx *schemas.BifrostContext,
 	toolCall *schemas.ChatAssistantMessageToolCall,
 	clientConn *client.Client,
 	executionConfig *schemas.MCPClientConfig,
 	toolNameMapping map[string]string,
-) (*schemas.ChatMessage, string, string, error) {
+) (*schemas.ChatMessage, string, string, *schemas.MCPOutputSchemaValidationResult, error) {
 	toolName := *toolCall.Function.Name
 
 	// Check if this is a code mode tool and delegate to CodeMode implementation
 	if m.codeMode != nil && m.codeMode.IsCodeModeTool(toolName) {
 		msg, err := m.codeMode.ExecuteTool(ctx, *toolCall)
-		return msg, "", toolName, err
+		return msg, "", toolName, nil, err
 	}
 
 	// The caller (MCPManager.prepareToolExecution → executeToolWithHooks /
@@ -674,7 +675,7 @@ func (m *ToolsManager) executeToolInternal(
 		arguments = map[string]interface{}{}
 	} else {
 		if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &arguments); err != nil {
-			return nil, "", "", fmt.Errorf("failed to parse tool arguments for '%s': %v", toolName, err)
+			return nil, "", "", nil, fmt.Errorf("failed to parse tool arguments for '%s': %v", toolName, err)
 		}
 	}
 
@@ -714,17 +715,28 @@ func (m *ToolsManager) executeToolInternal(
 	if callErr != nil {
 		// Sentinel-wrapped so the gate can classify error.type (timeout vs tool_error).
 		if toolCtx.Err() == context.DeadlineExceeded {
-			return nil, "", "", fmt.Errorf("MCP tool call timed out after %v: %s: %w", toolExecutionTimeout, toolName, ErrMCPToolTimeout)
+			return nil, "", "", nil, fmt.Errorf("MCP tool call timed out after %v: %s: %w", toolExecutionTimeout, toolName, ErrMCPToolTimeout)
 		}
 		m.logger.Error("%s Tool execution failed for %s via client %s: %v", MCPLogPrefix, toolName, executionConfig.Name, callErr)
-		return nil, "", "", fmt.Errorf("MCP tool call failed for %s: %v: %w", toolName, callErr, ErrMCPToolCallFailed)
+		return nil, "", "", nil, fmt.Errorf("MCP tool call failed for %s: %v: %w", toolName, callErr, ErrMCPToolCallFailed)
 	}
 
 	// Extract text from MCP response
 	responseText := extractTextFromMCPResponse(toolResponse, toolName)
 
+	// Check the result against the tool's declared output schema
+	var outputValidation *schemas.MCPOutputSchemaValidationResult
+	if mode := executionConfig.OutputValidation; mode != "" && mode != schemas.MCPOutputSchemaValidationOff {
+		if state := m.clientManager.GetClientForTool(toolName); state != nil {
+			responseText, outputValidation = validateToolOutput(mode, toolName, state.ToolMap[toolName].OutputSchema, toolResponse, responseText)
+		}
+		if outputValidation != nil && !outputValidation.Valid {
+			m.logger.Warn("%s Result of tool %s via client %s does not match its output schema: %s", MCPLogPrefix, toolName, executionConfig.Name, outputValidation.Error)
+		}
+	}
+
 	// Create tool response message
-	return createToolResponseMessage(*toolCall, responseText), executionConfig.Name, sanitizedToolName, nil
+	return createToolResponseMessage(*toolCall, responseText), executionConfig.Name, sanitizedToolName, outputValidation, nil
 }
 
 // ExecuteAgentForChatRequest executes agent mode for a chat request, handling
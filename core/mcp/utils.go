@@ -585,7 +585,8 @@ func convertMCPToolToBifrostSchema(mcpTool *mcp.Tool, logger schemas.Logger) sch
 				Defs:       defs,
 			},
 		},
-		Annotations: annotations,
+		Annotations:  annotations,
+		OutputSchema: toolOutputSchema(mcpTool),
 	}
 }
 
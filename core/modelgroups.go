@@ -0,0 +1,261 @@
+package bifrost
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// modelGroupIndex holds the configured model groups by name.
+type modelGroupIndex map[string]schemas.ModelGroup
+
+// UpdateModelGroups replaces the configured model groups at runtime. Requests
+// already resolved through a group keep the targets they resolved to.
+func (bifrost *Bifrost) UpdateModelGroups(groups []schemas.ModelGroup) error {
+	byName := make(modelGroupIndex, len(groups))
+	for _, group := range groups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+		name := strings.TrimSpace(group.Name)
+		if _, exists := byName[name]; exists {
+			return fmt.Errorf("duplicate model group %q", name)
+		}
+		group.Name = name
+		byName[name] = group
+	}
+	bifrost.modelGroups.Store(&byName)
+	return nil
+}
+
+// getModelGroup returns the model group with the given name.
+func (bifrost *Bifrost) getModelGroup(name string) (schemas.ModelGroup, bool) {
+	groups := bifrost.modelGroups.Load()
+	if groups == nil {
+		return schemas.ModelGroup{}, false
+	}
+	group, ok := (*groups)[name]
+	return group, ok
+}
+
+// resolveModelGroup rewrites a request for a model group to the group's
+// targets: the first becomes the primary and the rest are tried as fallbacks
+// ahead of the caller's own. Only requests without a provider are resolved, so
+// "openai/prod-chat-large" still reaches OpenAI as a model name. It runs before
+// PreRequestHooks so governance and routing plugins see the concrete targets.
+func (bifrost *Bifrost) resolveModelGroup(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) {
+	provider, model, fallbacks := req.GetRequestFields()
+	if provider != "" || model == "" {
+		return
+	}
+	group, ok := bifrost.getModelGroup(model)
+	if !ok {
+		return
+	}
+	chain := make([]schemas.Fallback, 0, len(group.Targets)-1+len(fallbacks))
+	for _, target := range group.Targets[1:] {
+		chain = append(chain, schemas.Fallback{Provider: target.Provider, Model: target.Model})
+	}
+	chain = append(chain, fallbacks...)
+	req.SetProvider(group.Targets[0].Provider)
+	req.SetModel(group.Targets[0].Model)
+	req.SetFallbacks(chain)
+	ctx.SetValue(schemas.BifrostContextKeyModelGroup, group.Name)
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineModelGroup)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineModelGroup, schemas.LogLevelInfo, fmt.Sprintf("Resolved model group %s to %s/%s with %d group fallback(s)", group.Name, group.Targets[0].Provider, group.Targets[0].Model, len(group.Targets)-1))
+}
+
+// applyModelGroupParams returns req with the parameter overrides of the model
+// group target it is addressed to. The returned request is a copy whenever
+// overrides apply, so the original stays untouched for later fallbacks.
+func (bifrost *Bifrost) applyModelGroupParams(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) *schemas.BifrostRequest {
+	groupName, _ := ctx.Value(schemas.BifrostContextKeyModelGroup).(string)
+	if groupName == "" {
+		return req
+	}
+	group, ok := bifrost.getModelGroup(groupName)
+	if !ok {
+		return req
+	}
+	provider, model, _ := req.GetRequestFields()
+	target := group.Target(provider, model)
+	if target == nil || len(target.Params) == 0 {
+		return req
+	}
+	overridden, err := withParamOverrides(req, target.Params)
+	if err != nil {
+		bifrost.logger.Warn("failed to apply model group %s overrides for %s/%s: %v", groupName, provider, model, err)
+		return req
+	}
+	return overridden
+}
+
+// embeddingFallbackAllowed reports whether fallback may serve req when req is
+// an embedding request. Vectors from different models cannot be mixed in one
+// index, so a fallback is only allowed to the same model (on another provider
+// or deployment) or to a model that shares an embedding-compatible model group
+// with the primary. Other request types are always allowed.
+func (bifrost *Bifrost) embeddingFallbackAllowed(req *schemas.BifrostRequest, fallback schemas.Fallback) bool {
+	if req.RequestType != schemas.EmbeddingRequest {
+		return true
+	}
+	provider, model, _ := req.GetRequestFields()
+	if fallback.Model == model {
+		return true
+	}
+	groups := bifrost.modelGroups.Load()
+	if groups == nil {
+		return false
+	}
+	for _, group := range *groups {
+		if group.EmbeddingCompatible && group.Target(provider, model) != nil && group.Target(fallback.Provider, fallback.Model) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// blockEmbeddingFallback records that fallback was not tried for an embedding
+// request and returns err with the reason appended, so the caller learns why
+// the request was not served by another model.
+func blockEmbeddingFallback(ctx *schemas.BifrostContext, req *schemas.BifrostRequest, fallback schemas.Fallback, err *schemas.BifrostError) *schemas.BifrostError {
+	provider, model, _ := req.GetRequestFields()
+	reason := fmt.Sprintf("embedding fallback from %s/%s to %s/%s blocked: the models are not in an embedding-compatible model group, so their vectors are not interchangeable", provider, model, fallback.Provider, fallback.Model)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, reason)
+	if err.Error == nil {
+		err.Error = &schemas.ErrorField{}
+	}
+	if err.Error.Message == "" {
+		err.Error.Message = reason
+	} else {
+		err.Error.Message = err.Error.Message + " (" + reason + ")"
+	}
+	return err
+}
+
+// withParamOverrides returns a shallow copy of req whose parameters have the
+// overrides applied. Request types without tunable parameters are returned as is.
+func withParamOverrides(req *schemas.BifrostRequest, overrides map[string]any) (*schemas.BifrostRequest, error) {
+	out := *req
+	switch {
+	case req.ChatRequest != nil:
+		chat := *req.ChatRequest
+		var extra map[string]any
+		if chat.Params != nil {
+			extra = chat.Params.ExtraParams
+		}
+		params, extra, err := overrideParams(chat.Params, extra, overrides)
+		if err != nil {
+			return nil, err
+		}
+		params.ExtraParams = extra
+		chat.Params = params
+		out.ChatRequest = &chat
+	case req.ResponsesRequest != nil:
+		responses := *req.ResponsesRequest
+		var extra map[string]any
+		if responses.Params != nil {
+			extra = responses.Params.ExtraParams
+		}
+		params, extra, err := overrideParams(responses.Params, extra, overrides)
+		if err != nil {
+			return nil, err
+		}
+		params.ExtraParams = extra
+		responses.Params = params
+		out.ResponsesRequest = &responses
+	case req.TextCompletionRequest != nil:
+		text := *req.TextCompletionRequest
+		var extra map[string]any
+		if text.Params != nil {
+			extra = text.Params.ExtraParams
+		}
+		params, extra, err := overrideParams(text.Params, extra, overrides)
+		if err != nil {
+			return nil, err
+		}
+		params.ExtraParams = extra
+		text.Params = params
+		out.TextCompletionRequest = &text
+	case req.EmbeddingRequest != nil:
+		embedding := *req.EmbeddingRequest
+		var extra map[string]any
+		if embedding.Params != nil {
+			extra = embedding.Params.ExtraParams
+		}
+		params, extra, err := overrideParams(embedding.Params, extra, overrides)
+		if err != nil {
+			return nil, err
+		}
+		params.ExtraParams = extra
+		embedding.Params = params
+		out.EmbeddingRequest = &embedding
+	default:
+		return req, nil
+	}
+	return &out, nil
+}
+
+// overrideParams merges overrides into a copy of params by JSON field name.
+// Keys that are not fields of T go to a copy of extra instead; a nil value
+// removes the parameter.
+func overrideParams[T any](params *T, extra map[string]any, overrides map[string]any) (*T, map[string]any, error) {
+	fields := jsonFieldNames(reflect.TypeFor[T]())
+	merged := make(map[string]any)
+	if params != nil {
+		data, err := schemas.Marshal(params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := schemas.Unmarshal(data, &merged); err != nil {
+			return nil, nil, err
+		}
+	}
+	extra = maps.Clone(extra)
+	for key, value := range overrides {
+		target := merged
+		if _, known := fields[key]; !known {
+			if extra == nil {
+				extra = make(map[string]any)
+			}
+			target = extra
+		}
+		if value == nil {
+			delete(target, key)
+		} else {
+			target[key] = value
+		}
+	}
+	data, err := schemas.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out T
+	if err := schemas.Unmarshal(data, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, extra, nil
+}
+
+// jsonFieldNames returns the JSON names of a struct type's exported fields.
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+	return names
+}
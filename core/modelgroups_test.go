@@ -0,0 +1,222 @@
+package bifrost
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// recordingChatHandler stores each request body and answers with status; a 200 gets a chat completion.
+func recordingChatHandler(status int, bodies chan<- map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		bodies <- body
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			io.WriteString(w, `{"error":{"message":"upstream unavailable","type":"server_error"}}`)
+			return
+		}
+		io.WriteString(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`)
+	}
+}
+
+func TestModelGroupResolvesFallsBackAndAppliesOverrides(t *testing.T) {
+	openAIBodies := make(chan map[string]any, 4)
+	groqBodies := make(chan map[string]any, 4)
+	openAI := httptest.NewServer(recordingChatHandler(http.StatusServiceUnavailable, openAIBodies))
+	defer openAI.Close()
+	groq := httptest.NewServer(recordingChatHandler(http.StatusOK, groqBodies))
+	defer groq.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, openAI.URL)
+	account.AddProviderWithBaseURL(schemas.Groq, 1, 1, groq.URL)
+	account.configs[schemas.OpenAI].NetworkConfig.MaxRetries = 0
+	for _, provider := range []schemas.ModelProvider{schemas.OpenAI, schemas.Groq} {
+		account.SetKeysForProvider(provider, []schemas.Key{
+			{ID: string(provider) + "-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+		})
+	}
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+		ModelGroups: []schemas.ModelGroup{{
+			Name: "prod-chat-large",
+			Targets: []schemas.ModelGroupTarget{
+				{Provider: schemas.OpenAI, Model: "gpt-4o", Params: map[string]any{"temperature": 0.1, "custom_flag": true}},
+				{Provider: schemas.Groq, Model: "llama-3.3-70b", Params: map[string]any{"top_p": nil, "seed": 7}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	params := &schemas.ChatParameters{TopP: schemas.Ptr(0.9), Temperature: schemas.Ptr(0.7)}
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeyPassthroughExtraParams, true)
+	resp, bifrostErr := client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{
+		Model:  "prod-chat-large",
+		Input:  []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}}},
+		Params: params,
+	})
+	if bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+
+	ri := resp.ExtraFields.RoutingInfo
+	if ri.ModelGroup != "prod-chat-large" || ri.Provider != schemas.Groq || ri.Model != "llama-3.3-70b" || !ri.IsFallback {
+		t.Fatalf("expected the concrete fallback target in routing info, got %+v", ri)
+	}
+
+	primary := <-openAIBodies
+	if primary["model"] != "gpt-4o" || primary["temperature"] != 0.1 || primary["custom_flag"] != true || primary["top_p"] != 0.9 {
+		t.Fatalf("unexpected primary body %v", primary)
+	}
+	fallback := <-groqBodies
+	if fallback["model"] != "llama-3.3-70b" || fallback["temperature"] != 0.7 || fallback["seed"] != float64(7) {
+		t.Fatalf("unexpected fallback body %v", fallback)
+	}
+	if _, ok := fallback["top_p"]; ok {
+		t.Fatal("a null override should remove the parameter")
+	}
+	if _, ok := fallback["custom_flag"]; ok {
+		t.Fatal("the primary's overrides must not leak into the fallback")
+	}
+	if *params.Temperature != 0.7 || params.ExtraParams != nil {
+		t.Fatal("the caller's parameters must not be modified")
+	}
+}
+
+func TestModelGroupValidation(t *testing.T) {
+	for name, group := range map[string]schemas.ModelGroup{
+		"no targets":      {Name: "chat"},
+		"provider prefix": {Name: "openai/chat", Targets: []schemas.ModelGroupTarget{{Provider: schemas.OpenAI, Model: "gpt-4o"}}},
+		"missing model":   {Name: "chat", Targets: []schemas.ModelGroupTarget{{Provider: schemas.OpenAI}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Init(context.Background(), schemas.BifrostConfig{
+				Account:     NewMockAccount(),
+				Logger:      NewDefaultLogger(schemas.LogLevelError),
+				ModelGroups: []schemas.ModelGroup{group},
+			})
+			if err == nil || !strings.Contains(err.Error(), "invalid model groups") {
+				t.Fatalf("expected a model group error, got %v", err)
+			}
+		})
+	}
+}
+
+// embeddingHandler counts requests and answers with status; a 200 gets a one-vector embedding.
+func embeddingHandler(status int, calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			io.WriteString(w, `{"error":{"message":"upstream unavailable","type":"server_error"}}`)
+			return
+		}
+		io.WriteString(w, `{"object":"list","model":"m","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":1,"total_tokens":1}}`)
+	}
+}
+
+func TestEmbeddingFallbackRequiresCompatibleModelGroup(t *testing.T) {
+	for name, tc := range map[string]struct {
+		groups      []schemas.ModelGroup
+		fallback    schemas.Fallback
+		wantServed  bool
+		wantBlocked bool
+	}{
+		"different model without group": {
+			fallback:    schemas.Fallback{Provider: schemas.Mistral, Model: "mistral-embed"},
+			wantBlocked: true,
+		},
+		"group not declared compatible": {
+			groups: []schemas.ModelGroup{{Name: "embed", Targets: []schemas.ModelGroupTarget{
+				{Provider: schemas.OpenAI, Model: "text-embedding-3-small"},
+				{Provider: schemas.Mistral, Model: "mistral-embed"},
+			}}},
+			fallback:    schemas.Fallback{Provider: schemas.Mistral, Model: "mistral-embed"},
+			wantBlocked: true,
+		},
+		"compatible group": {
+			groups: []schemas.ModelGroup{{Name: "embed", EmbeddingCompatible: true, Targets: []schemas.ModelGroupTarget{
+				{Provider: schemas.OpenAI, Model: "text-embedding-3-small"},
+				{Provider: schemas.Mistral, Model: "mistral-embed"},
+			}}},
+			fallback:   schemas.Fallback{Provider: schemas.Mistral, Model: "mistral-embed"},
+			wantServed: true,
+		},
+		"same model elsewhere": {
+			fallback:   schemas.Fallback{Provider: schemas.Mistral, Model: "text-embedding-3-small"},
+			wantServed: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var openAICalls, mistralCalls atomic.Int32
+			openAI := httptest.NewServer(embeddingHandler(http.StatusServiceUnavailable, &openAICalls))
+			defer openAI.Close()
+			mistral := httptest.NewServer(embeddingHandler(http.StatusOK, &mistralCalls))
+			defer mistral.Close()
+
+			account := NewMockAccount()
+			account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, openAI.URL)
+			account.AddProviderWithBaseURL(schemas.Mistral, 1, 1, mistral.URL)
+			account.configs[schemas.OpenAI].NetworkConfig.MaxRetries = 0
+			for _, provider := range []schemas.ModelProvider{schemas.OpenAI, schemas.Mistral} {
+				account.SetKeysForProvider(provider, []schemas.Key{
+					{ID: string(provider) + "-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+				})
+			}
+			client, err := Init(context.Background(), schemas.BifrostConfig{
+				Account:     account,
+				Logger:      NewDefaultLogger(schemas.LogLevelError),
+				ModelGroups: tc.groups,
+			})
+			if err != nil {
+				t.Fatalf("failed to initialize bifrost: %v", err)
+			}
+			defer client.Shutdown()
+
+			ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+			resp, bifrostErr := client.EmbeddingRequest(ctx, &schemas.BifrostEmbeddingRequest{
+				Provider:  schemas.OpenAI,
+				Model:     "text-embedding-3-small",
+				Input:     &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+				Fallbacks: []schemas.Fallback{tc.fallback},
+			})
+			if tc.wantServed {
+				if bifrostErr != nil {
+					t.Fatalf("expected the fallback to serve the request, got %s", bifrostErr.Error.Message)
+				}
+				if resp.ExtraFields.RoutingInfo.Provider != schemas.Mistral {
+					t.Fatalf("expected mistral to serve the request, got %+v", resp.ExtraFields.RoutingInfo)
+				}
+			}
+			if tc.wantBlocked {
+				if bifrostErr == nil || bifrostErr.Error == nil || !strings.Contains(bifrostErr.Error.Message, "embedding fallback from openai/text-embedding-3-small to mistral/mistral-embed blocked") {
+					t.Fatalf("expected a blocked fallback error, got %+v", bifrostErr)
+				}
+				if mistralCalls.Load() != 0 {
+					t.Fatal("an incompatible fallback must not be called")
+				}
+			}
+			if openAICalls.Load() != 1 {
+				t.Fatalf("expected one primary attempt, got %d", openAICalls.Load())
+			}
+		})
+	}
+}
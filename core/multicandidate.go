@@ -0,0 +1,64 @@
+package bifrost
+
+import (
+	"fmt"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// multiCandidateStreamProviders are the providers whose streaming APIs honour
+// n > 1, interleaving the deltas of every choice tagged with its index.
+// Custom providers are checked against their base provider.
+var multiCandidateStreamProviders = map[schemas.ModelProvider]struct{}{
+	schemas.OpenAI: {},
+	schemas.Azure:  {},
+	schemas.VLLM:   {},
+	schemas.SGL:    {},
+}
+
+// requestedCandidates returns the number of choices a chat or text completion
+// request asks for, or 1 when n is unset.
+func requestedCandidates(req *schemas.BifrostRequest) int {
+	var n *int
+	switch {
+	case req.ChatRequest != nil && req.ChatRequest.Params != nil:
+		n = req.ChatRequest.Params.N
+	case req.TextCompletionRequest != nil && req.TextCompletionRequest.Params != nil:
+		n = req.TextCompletionRequest.Params.N
+	}
+	if n == nil || *n < 1 {
+		return 1
+	}
+	return *n
+}
+
+// checkMultiCandidateStream rejects streaming requests with n > 1 for
+// providers that can't stream several choices. Without the check such
+// providers either drop all but one choice or fail with a provider-specific
+// error mid-stream. It runs per attempt, so a fallback to a supporting
+// provider still goes through.
+func (bifrost *Bifrost) checkMultiCandidateStream(req *schemas.BifrostRequest) *schemas.BifrostError {
+	n := requestedCandidates(req)
+	if n <= 1 {
+		return nil
+	}
+	provider, model, _ := req.GetRequestFields()
+	baseProvider := provider
+	if config, err := bifrost.account.GetConfigForProvider(provider); err == nil && config != nil &&
+		config.CustomProviderConfig != nil && config.CustomProviderConfig.BaseProviderType != "" {
+		baseProvider = config.CustomProviderConfig.BaseProviderType
+	}
+	if _, ok := multiCandidateStreamProviders[baseProvider]; ok {
+		return nil
+	}
+	bifrostErr := &schemas.BifrostError{
+		IsBifrostError: false,
+		StatusCode:     schemas.Ptr(fasthttp.StatusBadRequest),
+		Error: &schemas.ErrorField{
+			Message: fmt.Sprintf("provider %s does not support streaming multiple candidates (n=%d); set n to 1 or disable streaming", provider, n),
+		},
+	}
+	bifrostErr.PopulateExtraFields(req.RequestType, provider, model, model)
+	return bifrostErr
+}
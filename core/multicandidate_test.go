@@ -0,0 +1,122 @@
+package bifrost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+func multiCandidateChatRequest(provider schemas.ModelProvider, model string, n int) *schemas.BifrostChatRequest {
+	return &schemas.BifrostChatRequest{
+		Provider: provider,
+		Model:    model,
+		Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}},
+		},
+		Params: &schemas.ChatParameters{N: schemas.Ptr(n)},
+	}
+}
+
+func TestMultiCandidateStreamMultiplexesChoices(t *testing.T) {
+	server := httptest.NewServer(sseHandler(
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant","content":"he"}}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":1,"delta":{"role":"assistant","content":"bon"}}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":1,"delta":{"content":"jour"}}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"llo"}}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":1,"delta":{},"finish_reason":"length"}]}`,
+		`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+	))
+	defer server.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, server.URL)
+	account.configs[schemas.OpenAI].NetworkConfig.MaxRetries = 0
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "n-key", Value: *schemas.NewSecretVar("sk-n"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	client := newStreamTestClient(t, account)
+
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(30*time.Second))
+	stream, bifrostErr := client.ChatCompletionStreamRequest(ctx, multiCandidateChatRequest(schemas.OpenAI, "gpt-4o-mini", 2))
+	if bifrostErr != nil {
+		t.Fatalf("stream failed: %s", bifrostErr.Error.Message)
+	}
+
+	contents := map[int]*strings.Builder{}
+	finishReasons := map[int]string{}
+	for chunk := range stream {
+		if chunk.BifrostError != nil {
+			t.Fatalf("unexpected error chunk: %+v", chunk.BifrostError.Error)
+		}
+		if chunk.BifrostChatResponse == nil {
+			continue
+		}
+		for _, choice := range chunk.BifrostChatResponse.Choices {
+			if choice.FinishReason != nil {
+				if _, dup := finishReasons[choice.Index]; dup {
+					t.Fatalf("finish_reason for choice %d sent twice", choice.Index)
+				}
+				finishReasons[choice.Index] = *choice.FinishReason
+			}
+			if choice.ChatStreamResponseChoice != nil && choice.ChatStreamResponseChoice.Delta != nil && choice.ChatStreamResponseChoice.Delta.Content != nil {
+				if contents[choice.Index] == nil {
+					contents[choice.Index] = &strings.Builder{}
+				}
+				contents[choice.Index].WriteString(*choice.ChatStreamResponseChoice.Delta.Content)
+			}
+		}
+	}
+	if len(contents) != 2 || contents[0].String() != "hello" || contents[1].String() != "bonjour" {
+		t.Fatalf("unexpected per-choice content: %v", contents)
+	}
+	if finishReasons[0] != "stop" || finishReasons[1] != "length" {
+		t.Fatalf("unexpected per-choice finish reasons: %v", finishReasons)
+	}
+}
+
+func TestMultiCandidateStreamRejectedForUnsupportedProvider(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		anthropicMessagesHandler()(w, r)
+	}))
+	defer server.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.Anthropic, 1, 1, server.URL)
+	account.configs[schemas.Anthropic].NetworkConfig.MaxRetries = 0
+	account.SetKeysForProvider(schemas.Anthropic, []schemas.Key{
+		{ID: "n-key", Value: *schemas.NewSecretVar("sk-n"), Models: schemas.WhiteList{"*"}, Weight: 100},
+	})
+	client := newStreamTestClient(t, account)
+
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(30*time.Second))
+	_, bifrostErr := client.ChatCompletionStreamRequest(ctx, multiCandidateChatRequest(schemas.Anthropic, "claude-3-5-haiku-20241022", 3))
+	if bifrostErr == nil {
+		t.Fatal("expected n>1 streaming to be rejected for anthropic")
+	}
+	if bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400, got %v", bifrostErr.StatusCode)
+	}
+	if !strings.Contains(bifrostErr.Error.Message, "n=3") {
+		t.Fatalf("expected the error to name n, got %q", bifrostErr.Error.Message)
+	}
+	if hits.Load() != 0 {
+		t.Fatalf("expected no upstream call, got %d", hits.Load())
+	}
+
+	// A single candidate streams as before.
+	stream, bifrostErr := client.ChatCompletionStreamRequest(ctx, multiCandidateChatRequest(schemas.Anthropic, "claude-3-5-haiku-20241022", 1))
+	if bifrostErr != nil {
+		t.Fatalf("n=1 stream failed: %s", bifrostErr.Error.Message)
+	}
+	if content, errs := drainChatStream(stream); content != "hello" || len(errs) > 0 {
+		t.Fatalf("unexpected n=1 stream: %q %v", content, errs)
+	}
+}
@@ -0,0 +1,230 @@
+package bifrost
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// pluginGuard enforces a degradable plugin's error budget: it counts the
+// plugin's failed hook calls over a fixed window and bypasses the plugin once
+// the budget is spent. A nil *pluginGuard never bypasses.
+type pluginGuard struct {
+	name          string
+	policy        schemas.PluginDegradationPolicy
+	window        time.Duration
+	latencyBudget time.Duration
+	logger        schemas.Logger
+	now           func() time.Time
+
+	// bypassed is read on every hook dispatch; the rest is guarded by mu.
+	bypassed      atomic.Bool
+	mu            sync.Mutex
+	windowStart   time.Time
+	failures      int
+	bypassedAt    time.Time
+	bypassedUntil time.Time
+	reason        string
+	bypasses      int64
+}
+
+func newPluginGuard(name string, policy schemas.PluginDegradationPolicy, logger schemas.Logger) *pluginGuard {
+	window := policy.WindowSeconds
+	if window <= 0 {
+		window = schemas.DefaultPluginDegradationWindowSeconds
+	}
+	return &pluginGuard{
+		name:          name,
+		policy:        policy,
+		window:        time.Duration(window) * time.Second,
+		latencyBudget: time.Duration(policy.LatencyBudgetMs) * time.Millisecond,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// allow reports whether the plugin's hooks run, re-enabling the plugin once
+// its bypass has expired.
+func (g *pluginGuard) allow() bool {
+	if g == nil || !g.bypassed.Load() {
+		return true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.bypassed.Load() {
+		return true
+	}
+	if !g.bypassedUntil.IsZero() && !g.now().Before(g.bypassedUntil) {
+		g.resumeLocked()
+		g.logger.Info("plugin %s re-enabled after its bypass expired", g.name)
+		return true
+	}
+	return false
+}
+
+// record counts a hook call against the budget and bypasses the plugin when
+// the call spends the last of it.
+func (g *pluginGuard) record(err error, duration time.Duration) {
+	if g == nil {
+		return
+	}
+	var reason string
+	switch {
+	case err != nil:
+		reason = err.Error()
+	case g.latencyBudget > 0 && duration > g.latencyBudget:
+		reason = fmt.Sprintf("hook took %s, over its %s latency budget", duration.Round(time.Millisecond), g.latencyBudget)
+	default:
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.bypassed.Load() {
+		return
+	}
+	now := g.now()
+	if g.windowStart.IsZero() || now.Sub(g.windowStart) >= g.window {
+		g.windowStart = now
+		g.failures = 0
+	}
+	g.failures++
+	if g.failures < g.policy.MaxFailures {
+		return
+	}
+
+	g.bypassed.Store(true)
+	g.bypassedAt = now
+	g.bypassedUntil = time.Time{}
+	if g.policy.BypassSeconds > 0 {
+		g.bypassedUntil = now.Add(time.Duration(g.policy.BypassSeconds) * time.Second)
+	}
+	g.reason = reason
+	g.bypasses++
+	until := "until re-enabled"
+	if !g.bypassedUntil.IsZero() {
+		until = "for " + (time.Duration(g.policy.BypassSeconds) * time.Second).String()
+	}
+	g.logger.Error("plugin %s bypassed %s after %d failed hook calls within %s; last failure: %s", g.name, until, g.failures, g.window, reason)
+}
+
+// resume re-enables a bypassed plugin and reports whether it was bypassed.
+func (g *pluginGuard) resume() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	wasBypassed := g.bypassed.Load()
+	g.resumeLocked()
+	return wasBypassed
+}
+
+// resumeLocked clears the bypass and starts a fresh window. Callers must hold g.mu.
+func (g *pluginGuard) resumeLocked() {
+	g.bypassed.Store(false)
+	g.bypassedAt = time.Time{}
+	g.bypassedUntil = time.Time{}
+	g.windowStart = time.Time{}
+	g.failures = 0
+}
+
+func (g *pluginGuard) health() schemas.PluginHealth {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	health := schemas.PluginHealth{
+		Name:     g.name,
+		Policy:   g.policy,
+		Failures: g.failures,
+		Bypassed: g.bypassed.Load(),
+		Reason:   g.reason,
+		Bypasses: g.bypasses,
+	}
+	if g.windowStart.IsZero() || g.now().Sub(g.windowStart) >= g.window {
+		health.Failures = 0
+	}
+	if health.Bypassed {
+		bypassedAt := g.bypassedAt
+		health.BypassedAt = &bypassedAt
+		if !g.bypassedUntil.IsZero() {
+			bypassedUntil := g.bypassedUntil
+			health.BypassedUntil = &bypassedUntil
+		}
+	}
+	return health
+}
+
+// pluginGuards holds the guard of each loaded plugin, created on the plugin's
+// first hook call from its DegradationPolicy. A nil *pluginGuards guards
+// nothing.
+type pluginGuards struct {
+	guards sync.Map // plugin name -> *pluginGuard, nil for plugins that are never bypassed
+	logger schemas.Logger
+}
+
+func newPluginGuards(logger schemas.Logger) *pluginGuards {
+	return &pluginGuards{logger: logger}
+}
+
+// forPlugin returns the plugin's guard, or nil when the plugin is never bypassed.
+func (g *pluginGuards) forPlugin(plugin schemas.BasePlugin) *pluginGuard {
+	if g == nil {
+		return nil
+	}
+	name := plugin.GetName()
+	if guard, ok := g.guards.Load(name); ok {
+		return guard.(*pluginGuard)
+	}
+	var guard *pluginGuard
+	if degradable, ok := plugin.(schemas.DegradablePlugin); ok {
+		if policy := degradable.DegradationPolicy(); policy != nil {
+			if err := policy.Validate(); err != nil {
+				g.logger.Warn("ignoring degradation policy of plugin %s: %v", name, err)
+			} else {
+				guard = newPluginGuard(name, *policy, g.logger)
+			}
+		}
+	}
+	actual, _ := g.guards.LoadOrStore(name, guard)
+	return actual.(*pluginGuard)
+}
+
+// forget drops a plugin's guard when the plugin is reloaded or removed, so a
+// new instance starts with a fresh budget and its own policy.
+func (g *pluginGuards) forget(name string) {
+	if g == nil {
+		return
+	}
+	g.guards.Delete(name)
+}
+
+// GetPluginHealth reports the error budget of every loaded LLM plugin that can
+// be bypassed, in plugin order.
+func (bifrost *Bifrost) GetPluginHealth() []schemas.PluginHealth {
+	var health []schemas.PluginHealth
+	for _, plugin := range *bifrost.llmPlugins.Load() {
+		if guard := bifrost.pluginGuards.forPlugin(plugin); guard != nil {
+			health = append(health, guard.health())
+		}
+	}
+	return health
+}
+
+// ResumePlugin re-enables a bypassed plugin. It returns an error when no loaded
+// plugin by that name can be bypassed.
+func (bifrost *Bifrost) ResumePlugin(name string) error {
+	for _, plugin := range *bifrost.llmPlugins.Load() {
+		if plugin.GetName() != name {
+			continue
+		}
+		guard := bifrost.pluginGuards.forPlugin(plugin)
+		if guard == nil {
+			return fmt.Errorf("plugin %s has no degradation policy", name)
+		}
+		if guard.resume() {
+			bifrost.logger.Info("plugin %s re-enabled", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("plugin %s is not loaded", name)
+}
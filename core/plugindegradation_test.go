@@ -0,0 +1,128 @@
+package bifrost
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// flakyPlugin fails its LLM hooks while failing is set and counts the calls
+// that reach it.
+type flakyPlugin struct {
+	countingPlugin
+	policy  *schemas.PluginDegradationPolicy
+	failing bool
+}
+
+func (f *flakyPlugin) DegradationPolicy() *schemas.PluginDegradationPolicy { return f.policy }
+
+func (f *flakyPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	f.pre++
+	if f.failing {
+		return req, nil, errors.New("exporter unreachable")
+	}
+	return req, nil, nil
+}
+
+func runPluginRequest(t *testing.T, guards *pluginGuards, plugins ...schemas.LLMPlugin) {
+	t.Helper()
+	p := &PluginPipeline{
+		logger:     NewDefaultLogger(schemas.LogLevelError),
+		tracer:     &schemas.NoOpTracer{},
+		llmPlugins: plugins,
+		guards:     guards,
+	}
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(time.Minute))
+	req, _, ran := p.RunLLMPreHooks(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+	if req == nil {
+		t.Fatal("expected the request to pass through the pipeline")
+	}
+	p.RunPostLLMHooks(ctx, &schemas.BifrostResponse{}, nil, ran)
+	p.resetPluginPipeline()
+}
+
+func TestPluginDegradation_BypassesAfterErrorBudget(t *testing.T) {
+	guards := newPluginGuards(NewDefaultLogger(schemas.LogLevelError))
+	observer := &flakyPlugin{countingPlugin: countingPlugin{name: "observer"}, policy: &schemas.PluginDegradationPolicy{MaxFailures: 3}, failing: true}
+	critical := &flakyPlugin{countingPlugin: countingPlugin{name: "critical"}, failing: true}
+
+	for range 5 {
+		runPluginRequest(t, guards, critical, observer)
+	}
+	if observer.pre != 3 || observer.post != 3 {
+		t.Fatalf("expected the observer bypassed after 3 failures (post-hooks skipped with them), got pre=%d post=%d", observer.pre, observer.post)
+	}
+	if critical.pre != 5 || critical.post != 5 {
+		t.Fatalf("expected a plugin without a policy never to be bypassed, got pre=%d post=%d", critical.pre, critical.post)
+	}
+
+	health := guards.forPlugin(observer).health()
+	if !health.Bypassed || health.BypassedUntil != nil || health.Reason != "exporter unreachable" || health.Bypasses != 1 {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+
+	observer.failing = false
+	if !guards.forPlugin(observer).resume() {
+		t.Fatal("expected resume to report the plugin was bypassed")
+	}
+	runPluginRequest(t, guards, critical, observer)
+	if observer.pre != 4 || observer.post != 4 {
+		t.Fatalf("expected the observer to run again once resumed, got pre=%d post=%d", observer.pre, observer.post)
+	}
+}
+
+func TestPluginGuard_WindowLatencyAndExpiry(t *testing.T) {
+	var now atomic.Pointer[time.Time]
+	start := time.Unix(1_700_000_000, 0)
+	now.Store(&start)
+	advance := func(d time.Duration) {
+		next := now.Load().Add(d)
+		now.Store(&next)
+	}
+
+	guard := newPluginGuard("observer", schemas.PluginDegradationPolicy{
+		MaxFailures: 2, WindowSeconds: 10, LatencyBudgetMs: 100, BypassSeconds: 30,
+	}, NewDefaultLogger(schemas.LogLevelError))
+	guard.now = func() time.Time { return *now.Load() }
+
+	guard.record(nil, 50*time.Millisecond)
+	guard.record(nil, 200*time.Millisecond)
+	advance(11 * time.Second)
+	guard.record(errors.New("boom"), 0)
+	if !guard.allow() {
+		t.Fatal("failures in different windows should not bypass the plugin")
+	}
+
+	guard.record(nil, time.Second)
+	if guard.allow() {
+		t.Fatal("expected a slow call to spend the rest of the budget")
+	}
+	if health := guard.health(); health.BypassedUntil == nil || !health.BypassedUntil.Equal(now.Load().Add(30*time.Second)) {
+		t.Fatalf("expected the bypass to expire after 30s, got %+v", health)
+	}
+
+	advance(30 * time.Second)
+	if !guard.allow() {
+		t.Fatal("expected the plugin re-enabled once its bypass expired")
+	}
+	if health := guard.health(); health.Bypassed || health.Failures != 0 || health.Bypasses != 1 {
+		t.Fatalf("unexpected health after expiry: %+v", health)
+	}
+}
+
+func TestPluginGuards_InvalidPolicyNeverBypasses(t *testing.T) {
+	guards := newPluginGuards(NewDefaultLogger(schemas.LogLevelError))
+	plugin := &flakyPlugin{countingPlugin: countingPlugin{name: "observer"}, policy: &schemas.PluginDegradationPolicy{}}
+	if guards.forPlugin(plugin) != nil {
+		t.Fatal("expected a policy without max_failures to be ignored")
+	}
+	guards.forget("observer")
+	plugin.policy = &schemas.PluginDegradationPolicy{MaxFailures: 1}
+	if guards.forPlugin(plugin) == nil {
+		t.Fatal("expected a fresh guard after the plugin was forgotten")
+	}
+}
@@ -0,0 +1,103 @@
+package bifrost
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+func TestProviderQueue_InteractiveRequestsPreemptBatch(t *testing.T) {
+	served := make(chan string, 4)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		served <- body.Model
+		if body.Model == "blocker" {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`)
+	}))
+	defer server.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 4, server.URL)
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "openai-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	send := func(model string, priority schemas.RequestPriority) {
+		ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+		if priority != "" {
+			ctx.SetValue(schemas.BifrostContextKeyRequestPriority, priority)
+		}
+		go client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    model,
+			Input:    []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}}},
+		})
+	}
+	waitFor := func(what string, cond func(ProviderQueueStats) bool) {
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond(client.GetProviderQueueStats()[schemas.OpenAI]) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Occupy the only worker, then queue a batch request ahead of an interactive one.
+	send("blocker", "")
+	if got := <-served; got != "blocker" {
+		t.Fatalf("expected the blocker first, got %s", got)
+	}
+	send("batch-1", schemas.RequestPriorityBatch)
+	waitFor("the batch request to queue", func(s ProviderQueueStats) bool { return s.BatchDepth == 1 })
+	send("interactive-1", schemas.RequestPriorityInteractive)
+	waitFor("the interactive request to queue", func(s ProviderQueueStats) bool { return s.Depth == 1 })
+
+	close(release)
+	for _, want := range []string{"interactive-1", "batch-1"} {
+		select {
+		case got := <-served:
+			if got != want {
+				t.Fatalf("expected %s to be served next, got %s", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestParseRequestPriority(t *testing.T) {
+	for input, want := range map[string]schemas.RequestPriority{
+		"batch":        schemas.RequestPriorityBatch,
+		" Interactive": schemas.RequestPriorityInteractive,
+	} {
+		if got, ok := schemas.ParseRequestPriority(input); !ok || got != want {
+			t.Errorf("ParseRequestPriority(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+	if _, ok := schemas.ParseRequestPriority("urgent"); ok {
+		t.Error("unknown priorities must be rejected")
+	}
+}
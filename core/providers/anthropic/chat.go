@@ -1588,13 +1588,7 @@ func (chunk *AnthropicStreamEvent) ToBifrostChatCompletionStream(ctx *schemas.Bi
 	case AnthropicStreamEventTypeError:
 		if chunk.Error != nil {
 			// Send error through channel before closing
-			bifrostErr := &schemas.BifrostError{
-				IsBifrostError: false,
-				Error: &schemas.ErrorField{
-					Type:    &chunk.Error.Type,
-					Message: chunk.Error.Message,
-				},
-			}
+			bifrostErr := toAnthropicStreamBifrostError(chunk.Error)
 
 			return nil, bifrostErr, true
 		}
@@ -2,6 +2,7 @@ package anthropic
 
 import (
 	"fmt"
+	"net/http"
 
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	schemas "github.com/maximhq/bifrost/core/schemas"
@@ -66,3 +67,33 @@ func parseAnthropicError(resp *fasthttp.Response) *schemas.BifrostError {
 	}
 	return bifrostErr
 }
+
+// anthropicErrorStatusCodes maps Anthropic error types to the HTTP status Anthropic returns
+// for them, so errors delivered as stream events carry the same status as pre-stream ones.
+var anthropicErrorStatusCodes = map[string]int{
+	"invalid_request_error": http.StatusBadRequest,
+	"authentication_error":  http.StatusUnauthorized,
+	"billing_error":         http.StatusPaymentRequired,
+	"permission_error":      http.StatusForbidden,
+	"not_found_error":       http.StatusNotFound,
+	"request_too_large":     http.StatusRequestEntityTooLarge,
+	"rate_limit_error":      http.StatusTooManyRequests,
+	"api_error":             http.StatusInternalServerError,
+	"timeout_error":         http.StatusGatewayTimeout,
+	"overloaded_error":      529,
+}
+
+// toAnthropicStreamBifrostError builds the BifrostError for an error event received mid-stream.
+func toAnthropicStreamBifrostError(streamErr *AnthropicStreamError) *schemas.BifrostError {
+	bifrostErr := &schemas.BifrostError{
+		IsBifrostError: false,
+		Error: &schemas.ErrorField{
+			Type:    &streamErr.Type,
+			Message: streamErr.Message,
+		},
+	}
+	if statusCode, ok := anthropicErrorStatusCodes[streamErr.Type]; ok {
+		bifrostErr.StatusCode = schemas.Ptr(statusCode)
+	}
+	return bifrostErr
+}
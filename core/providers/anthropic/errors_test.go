@@ -94,3 +94,27 @@ func TestToAnthropicChatCompletionError(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamErrorEventKeepsProviderDetail(t *testing.T) {
+	event := &AnthropicStreamEvent{
+		Type:  AnthropicStreamEventTypeError,
+		Error: &AnthropicStreamError{Type: "overloaded_error", Message: "Overloaded"},
+	}
+
+	_, chatErr, last := event.ToBifrostChatCompletionStream(schemas.NewBifrostContext(t.Context(), schemas.NoDeadline), "", NewAnthropicStreamState())
+	_, responsesErr, _ := event.ToBifrostResponsesStream(t.Context(), 0, newFallbackStreamState())
+	for name, bifrostErr := range map[string]*schemas.BifrostError{"chat": chatErr, "responses": responsesErr} {
+		if bifrostErr == nil || bifrostErr.Error == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+		if bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != 529 {
+			t.Errorf("%s: expected status 529, got %v", name, bifrostErr.StatusCode)
+		}
+		if bifrostErr.Error.Type == nil || *bifrostErr.Error.Type != "overloaded_error" || bifrostErr.Error.Message != "Overloaded" {
+			t.Errorf("%s: expected the overloaded_error detail, got %+v", name, bifrostErr.Error)
+		}
+	}
+	if !last {
+		t.Error("expected the chat error event to end the stream")
+	}
+}
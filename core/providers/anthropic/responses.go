@@ -2410,13 +2410,7 @@ func (chunk *AnthropicStreamEvent) ToBifrostResponsesStream(ctx context.Context,
 	case AnthropicStreamEventTypeError:
 		if chunk.Error != nil {
 			// Send error event
-			bifrostErr := &schemas.BifrostError{
-				IsBifrostError: false,
-				Error: &schemas.ErrorField{
-					Type:    &chunk.Error.Type,
-					Message: chunk.Error.Message,
-				},
-			}
+			bifrostErr := toAnthropicStreamBifrostError(chunk.Error)
 
 			return []*schemas.BifrostResponsesStreamResponse{{
 				Type:           schemas.ResponsesStreamResponseTypeError,
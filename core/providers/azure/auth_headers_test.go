@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/maximhq/bifrost/core/schemas"
 )
 
@@ -161,3 +163,87 @@ func TestAzureAuthHeaderForwarding(t *testing.T) {
 		}
 	}
 }
+
+// staticTokenCredential returns a fixed access token, standing in for an Entra ID credential.
+type staticTokenCredential struct{ token string }
+
+func (c staticTokenCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: c.token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// TestAzureExplicitAuthType verifies that an explicit auth_type overrides the inferred order.
+func TestAzureExplicitAuthType(t *testing.T) {
+	t.Parallel()
+
+	provider := &AzureProvider{}
+	provider.credentials.Store("managed_identity:user-assigned-id", staticTokenCredential{token: "mi-token"})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	cases := []struct {
+		name       string
+		key        schemas.Key
+		wantHeader string
+		wantValue  string
+		wantErr    bool
+	}{
+		{
+			name: "managed identity wins over api key value",
+			key: schemas.Key{
+				Value: *schemas.NewSecretVar("static-key"),
+				AzureKeyConfig: &schemas.AzureKeyConfig{
+					AuthType: schemas.AzureAuthTypeManagedIdentity,
+					ClientID: schemas.NewSecretVar("user-assigned-id"),
+				},
+			},
+			wantHeader: "Authorization",
+			wantValue:  "Bearer mi-token",
+		},
+		{
+			name: "api key wins over service principal",
+			key: schemas.Key{
+				Value: *schemas.NewSecretVar("static-key"),
+				AzureKeyConfig: &schemas.AzureKeyConfig{
+					AuthType:     schemas.AzureAuthTypeAPIKey,
+					ClientID:     schemas.NewSecretVar("cid"),
+					ClientSecret: schemas.NewSecretVar("secret"),
+					TenantID:     schemas.NewSecretVar("tenant"),
+				},
+			},
+			wantHeader: "api-key",
+			wantValue:  "static-key",
+		},
+		{
+			name: "client secret without credentials is rejected",
+			key: schemas.Key{
+				Value:          *schemas.NewSecretVar("static-key"),
+				AzureKeyConfig: &schemas.AzureKeyConfig{AuthType: schemas.AzureAuthTypeClientSecret},
+			},
+			wantErr: true,
+		},
+		{
+			name: "api key without value is rejected",
+			key: schemas.Key{
+				AzureKeyConfig: &schemas.AzureKeyConfig{AuthType: schemas.AzureAuthTypeAPIKey},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers, bifrostErr := provider.getAzureAuthHeaders(ctx, tc.key, false)
+			if tc.wantErr {
+				if bifrostErr == nil {
+					t.Fatalf("expected error, got headers %v", headers)
+				}
+				return
+			}
+			if bifrostErr != nil {
+				t.Fatalf("unexpected error: %v", bifrostErr.Error.Message)
+			}
+			if got := headers[tc.wantHeader]; got != tc.wantValue {
+				t.Errorf("expected %s=%q, got headers %v", tc.wantHeader, tc.wantValue, headers)
+			}
+		})
+	}
+}
@@ -92,16 +92,95 @@ func (p *AzureProvider) getOrCreateDefaultAzureCredential() (azcore.TokenCredent
 	return actual.(azcore.TokenCredential), nil
 }
 
-// getAzureAuthHeaders returns authentication headers based on priority:
+// getOrCreateManagedIdentityCredential returns a ManagedIdentityCredential, creating and caching it
+// if needed. An empty clientID selects the system-assigned identity; otherwise the user-assigned
+// identity with that client ID is used.
+func (p *AzureProvider) getOrCreateManagedIdentityCredential(clientID string) (azcore.TokenCredential, error) {
+	cacheKey := "managed_identity:" + clientID
+
+	if val, ok := p.credentials.Load(cacheKey); ok {
+		return val.(azcore.TokenCredential), nil
+	}
+
+	var options *azidentity.ManagedIdentityCredentialOptions
+	if clientID != "" {
+		options = &azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(clientID)}
+	}
+	cred, err := azidentity.NewManagedIdentityCredential(options)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := p.credentials.LoadOrStore(cacheKey, cred)
+	return actual.(azcore.TokenCredential), nil
+}
+
+// hasAzureClientSecretCredentials reports whether client ID, secret and tenant ID are all set.
+func hasAzureClientSecretCredentials(cfg *schemas.AzureKeyConfig) bool {
+	return cfg != nil && cfg.ClientID != nil && cfg.ClientSecret != nil && cfg.TenantID != nil &&
+		cfg.ClientID.GetValue() != "" && cfg.ClientSecret.GetValue() != "" && cfg.TenantID.GetValue() != ""
+}
+
+// getExplicitAzureAuthHeaders resolves authentication headers for keys with an explicit
+// AzureKeyConfig.AuthType. handled is false when the mode should be inferred instead
+// (no auth type, or client_secret with complete credentials, which the inferred path covers).
+func (provider *AzureProvider) getExplicitAzureAuthHeaders(ctx context.Context, key schemas.Key, isAnthropicModel bool) (headers map[string]string, handled bool, bifrostErr *schemas.BifrostError) {
+	if key.AzureKeyConfig == nil {
+		return nil, false, nil
+	}
+	switch key.AzureKeyConfig.AuthType {
+	case schemas.AzureAuthTypeManagedIdentity:
+		clientID := ""
+		if key.AzureKeyConfig.ClientID != nil {
+			clientID = key.AzureKeyConfig.ClientID.GetValue()
+		}
+		cred, err := provider.getOrCreateManagedIdentityCredential(clientID)
+		if err != nil {
+			return nil, true, providerUtils.NewBifrostOperationError("failed to create Azure managed identity credential", err)
+		}
+		token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: getAzureScopes(key.AzureKeyConfig.Scopes)})
+		if err != nil {
+			return nil, true, providerUtils.NewBifrostOperationError("failed to get Azure managed identity access token", err)
+		}
+		if token.Token == "" {
+			return nil, true, providerUtils.NewBifrostOperationError("Azure managed identity access token is empty", errors.New("token is empty"))
+		}
+		return map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token.Token)}, true, nil
+	case schemas.AzureAuthTypeClientSecret:
+		if !hasAzureClientSecretCredentials(key.AzureKeyConfig) {
+			return nil, true, providerUtils.NewBifrostOperationError("Azure auth_type client_secret requires client_id, client_secret and tenant_id", nil)
+		}
+	case schemas.AzureAuthTypeAPIKey:
+		value := key.Value.GetValue()
+		if value == "" {
+			return nil, true, providerUtils.NewBifrostOperationError("Azure auth_type api_key requires a key value", nil)
+		}
+		if isAnthropicModel {
+			return map[string]string{"x-api-key": value}, true, nil
+		}
+		return map[string]string{"api-key": value}, true, nil
+	}
+	return nil, false, nil
+}
+
+// getAzureAuthHeaders returns authentication headers. An explicit AzureKeyConfig.AuthType is
+// honored first; otherwise the mode is inferred in priority order:
 // 1. Service Principal (client ID/secret/tenant ID) - Bearer token
 // 2. Context token - Bearer token
 // 3. API key - api-key or x-api-key header
+// 4. DefaultAzureCredential (no key value) - Bearer token
+//
+// Entra ID credentials are cached per provider and cache their access tokens, refreshing them
+// shortly before expiry, so GetToken only reaches Entra ID when a token needs renewal.
 func (provider *AzureProvider) getAzureAuthHeaders(ctx *schemas.BifrostContext, key schemas.Key, isAnthropicModel bool) (map[string]string, *schemas.BifrostError) {
 	authHeader := make(map[string]string)
 
+	if headers, handled, bifrostErr := provider.getExplicitAzureAuthHeaders(ctx, key, isAnthropicModel); handled {
+		return headers, bifrostErr
+	}
+
 	// Service Principal authentication
-	if key.AzureKeyConfig != nil && key.AzureKeyConfig.ClientID != nil &&
-		key.AzureKeyConfig.ClientSecret != nil && key.AzureKeyConfig.TenantID != nil && key.AzureKeyConfig.ClientID.GetValue() != "" && key.AzureKeyConfig.ClientSecret.GetValue() != "" && key.AzureKeyConfig.TenantID.GetValue() != "" {
+	if hasAzureClientSecretCredentials(key.AzureKeyConfig) {
 		cred, err := provider.getOrCreateAuth(key.AzureKeyConfig.TenantID.GetValue(), key.AzureKeyConfig.ClientID.GetValue(), key.AzureKeyConfig.ClientSecret.GetValue())
 		if err != nil {
 			return nil, providerUtils.NewBifrostOperationError("failed to get or create Azure authentication", err)
@@ -2387,8 +2466,26 @@ func (provider *AzureProvider) getBlobStorageTokenForKey(ctx *schemas.BifrostCon
 	}
 	cfg := key.AzureKeyConfig
 
-	if cfg.ClientID != nil && cfg.ClientSecret != nil && cfg.TenantID != nil &&
-		cfg.ClientID.GetValue() != "" && cfg.ClientSecret.GetValue() != "" && cfg.TenantID.GetValue() != "" {
+	if cfg.AuthType == schemas.AzureAuthTypeManagedIdentity {
+		clientID := ""
+		if cfg.ClientID != nil {
+			clientID = cfg.ClientID.GetValue()
+		}
+		cred, err := provider.getOrCreateManagedIdentityCredential(clientID)
+		if err != nil {
+			return "", providerUtils.NewProviderAPIError("failed to acquire Azure managed identity credentials for blob storage", err, http.StatusUnauthorized, nil, nil)
+		}
+		token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{DefaultAzureStorageScope}})
+		if err != nil {
+			return "", providerUtils.NewProviderAPIError("failed to get Azure managed identity token for blob storage", err, http.StatusUnauthorized, nil, nil)
+		}
+		if token.Token == "" {
+			return "", providerUtils.NewProviderAPIError("Azure managed identity token for blob storage is empty", nil, http.StatusUnauthorized, nil, nil)
+		}
+		return token.Token, nil
+	}
+
+	if hasAzureClientSecretCredentials(cfg) {
 		cred, err := provider.getOrCreateAuth(cfg.TenantID.GetValue(), cfg.ClientID.GetValue(), cfg.ClientSecret.GetValue())
 		if err != nil {
 			return "", providerUtils.NewProviderAPIError("failed to acquire Azure SP credentials for blob storage", err, http.StatusUnauthorized, nil, nil)
@@ -18,10 +18,25 @@ import (
 // 2. Context token - uses Bearer token
 // 3. API key - uses api-key header
 // 4. DefaultAzureCredential auto-detection (managed identity, workload identity, env vars, CLI)
+//
+// An explicit AzureKeyConfig.AuthType takes precedence over this order.
 func (provider *AzureProvider) setAzureAuth(ctx context.Context, req *fasthttp.Request, key schemas.Key) *schemas.BifrostError {
+	if headers, handled, bifrostErr := provider.getExplicitAzureAuthHeaders(ctx, key, false); handled {
+		if bifrostErr != nil {
+			return bifrostErr
+		}
+		if value, ok := headers["Authorization"]; ok {
+			req.Header.Set("Authorization", value)
+			req.Header.Del("api-key")
+		} else {
+			req.Header.Del("Authorization")
+			req.Header.Set("api-key", headers["api-key"])
+		}
+		return nil
+	}
+
 	// Service Principal authentication
-	if key.AzureKeyConfig != nil && key.AzureKeyConfig.ClientID != nil &&
-		key.AzureKeyConfig.ClientSecret != nil && key.AzureKeyConfig.TenantID != nil && key.AzureKeyConfig.ClientID.GetValue() != "" && key.AzureKeyConfig.ClientSecret.GetValue() != "" && key.AzureKeyConfig.TenantID.GetValue() != "" {
+	if hasAzureClientSecretCredentials(key.AzureKeyConfig) {
 		cred, err := provider.getOrCreateAuth(key.AzureKeyConfig.TenantID.GetValue(), key.AzureKeyConfig.ClientID.GetValue(), key.AzureKeyConfig.ClientSecret.GetValue())
 		if err != nil {
 			return providerUtils.NewBifrostOperationError("failed to get or create Azure authentication", err)
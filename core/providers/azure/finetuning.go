@@ -0,0 +1,27 @@
+package azure
+
+import (
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// FineTuningJobCreate is unsupported on AzureProvider. Managed fine-tuning is only
+// exposed through OpenAI, Vertex AI tuning jobs and Bedrock model customization.
+func (provider *AzureProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCreateRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobList is unsupported on AzureProvider (see FineTuningJobCreate).
+func (provider *AzureProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobListRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobRetrieve is unsupported on AzureProvider (see FineTuningJobCreate).
+func (provider *AzureProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobRetrieveRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobCancel is unsupported on AzureProvider (see FineTuningJobCreate).
+func (provider *AzureProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCancelRequest, provider.GetProviderKey())
+}
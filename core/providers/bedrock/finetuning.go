@@ -0,0 +1,470 @@
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// BedrockCustomizationS3Config is an S3 location used by model customization jobs.
+type BedrockCustomizationS3Config struct {
+	S3Uri string `json:"s3Uri"`
+}
+
+// BedrockCustomizationValidationConfig lists the validation datasets of a customization job.
+type BedrockCustomizationValidationConfig struct {
+	Validators []BedrockCustomizationS3Config `json:"validators"`
+}
+
+// BedrockModelCustomizationJobRequest is the body of CreateModelCustomizationJob.
+type BedrockModelCustomizationJobRequest struct {
+	JobName              string                                `json:"jobName"`
+	CustomModelName      string                                `json:"customModelName"`
+	RoleArn              string                                `json:"roleArn"`
+	BaseModelIdentifier  string                                `json:"baseModelIdentifier"`
+	CustomizationType    string                                `json:"customizationType,omitempty"`
+	HyperParameters      map[string]string                     `json:"hyperParameters,omitempty"`
+	TrainingDataConfig   BedrockCustomizationS3Config          `json:"trainingDataConfig"`
+	ValidationDataConfig *BedrockCustomizationValidationConfig `json:"validationDataConfig,omitempty"`
+	OutputDataConfig     BedrockCustomizationS3Config          `json:"outputDataConfig"`
+	JobTags              []BedrockTag                          `json:"jobTags,omitempty"`
+}
+
+// BedrockModelCustomizationJob is the response of GetModelCustomizationJob. List
+// summaries share the same field names, so it is reused for both.
+type BedrockModelCustomizationJob struct {
+	JobArn               string                                `json:"jobArn"`
+	JobName              string                                `json:"jobName,omitempty"`
+	Status               string                                `json:"status,omitempty"`
+	FailureMessage       string                                `json:"failureMessage,omitempty"`
+	BaseModelArn         string                                `json:"baseModelArn,omitempty"`
+	OutputModelArn       string                                `json:"outputModelArn,omitempty"`
+	OutputModelName      string                                `json:"outputModelName,omitempty"`
+	CustomModelArn       string                                `json:"customModelArn,omitempty"`
+	CustomModelName      string                                `json:"customModelName,omitempty"`
+	HyperParameters      map[string]string                     `json:"hyperParameters,omitempty"`
+	TrainingDataConfig   *BedrockCustomizationS3Config         `json:"trainingDataConfig,omitempty"`
+	ValidationDataConfig *BedrockCustomizationValidationConfig `json:"validationDataConfig,omitempty"`
+	CreationTime         *time.Time                            `json:"creationTime,omitempty"`
+	EndTime              *time.Time                            `json:"endTime,omitempty"`
+}
+
+// BedrockModelCustomizationJobListResponse is the response of ListModelCustomizationJobs.
+type BedrockModelCustomizationJobListResponse struct {
+	ModelCustomizationJobSummaries []BedrockModelCustomizationJob `json:"modelCustomizationJobSummaries"`
+	NextToken                      *string                        `json:"nextToken,omitempty"`
+}
+
+// ToBifrostFineTuningJobStatus maps Bedrock model customization statuses to Bifrost statuses.
+func ToBifrostFineTuningJobStatus(status string) schemas.FineTuningJobStatus {
+	switch status {
+	case "InProgress", "Stopping":
+		return schemas.FineTuningJobStatusRunning
+	case "Completed":
+		return schemas.FineTuningJobStatusSucceeded
+	case "Failed":
+		return schemas.FineTuningJobStatusFailed
+	case "Stopped":
+		return schemas.FineTuningJobStatusCancelled
+	default:
+		return schemas.FineTuningJobStatus(status)
+	}
+}
+
+// ToBedrockModelCustomizationJobRequest maps a Bifrost fine-tuning create request to a
+// CreateModelCustomizationJob body. Bedrock takes an absolute learning rate rather than a
+// multiplier, so it is read from extra_params["learning_rate"]; any
+// extra_params["hyper_parameters"] entries are passed through verbatim.
+func ToBedrockModelCustomizationJobRequest(request *schemas.BifrostFineTuningJobCreateRequest, roleArn, outputS3URI string) *BedrockModelCustomizationJobRequest {
+	now := time.Now().Unix()
+	jobName := fmt.Sprintf("bifrost-finetune-%d", now)
+	if name, ok := request.ExtraParams["job_name"].(string); ok && name != "" {
+		jobName = name
+	}
+	customModelName := fmt.Sprintf("bifrost-custom-%d", now)
+	if request.Suffix != nil && *request.Suffix != "" {
+		customModelName = *request.Suffix
+	}
+	customizationType := "FINE_TUNING"
+	if t, ok := request.ExtraParams["customization_type"].(string); ok && t != "" {
+		customizationType = t
+	}
+
+	bedrockReq := &BedrockModelCustomizationJobRequest{
+		JobName:             jobName,
+		CustomModelName:     customModelName,
+		RoleArn:             roleArn,
+		BaseModelIdentifier: request.Model,
+		CustomizationType:   customizationType,
+		TrainingDataConfig:  BedrockCustomizationS3Config{S3Uri: request.TrainingFile},
+		OutputDataConfig:    BedrockCustomizationS3Config{S3Uri: outputS3URI},
+	}
+	if request.ValidationFile != nil && *request.ValidationFile != "" {
+		bedrockReq.ValidationDataConfig = &BedrockCustomizationValidationConfig{
+			Validators: []BedrockCustomizationS3Config{{S3Uri: *request.ValidationFile}},
+		}
+	}
+
+	hyperParameters := make(map[string]string)
+	if hp := request.Hyperparameters; hp != nil {
+		if hp.NEpochs != nil {
+			hyperParameters["epochCount"] = strconv.Itoa(*hp.NEpochs)
+		}
+		if hp.BatchSize != nil {
+			hyperParameters["batchSize"] = strconv.Itoa(*hp.BatchSize)
+		}
+	}
+	if lr, ok := request.ExtraParams["learning_rate"]; ok {
+		hyperParameters["learningRate"] = fmt.Sprintf("%v", lr)
+	}
+	if extra, ok := request.ExtraParams["hyper_parameters"].(map[string]any); ok {
+		for k, v := range extra {
+			hyperParameters[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	if len(hyperParameters) > 0 {
+		bedrockReq.HyperParameters = hyperParameters
+	}
+
+	tagKeys := make([]string, 0, len(request.Metadata))
+	for k := range request.Metadata {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		bedrockReq.JobTags = append(bedrockReq.JobTags, BedrockTag{Key: k, Value: request.Metadata[k]})
+	}
+	return bedrockReq
+}
+
+// ToBifrostFineTuningJob converts a Bedrock model customization job to the Bifrost representation.
+func (job *BedrockModelCustomizationJob) ToBifrostFineTuningJob() schemas.FineTuningJob {
+	status := ToBifrostFineTuningJobStatus(job.Status)
+	result := schemas.FineTuningJob{
+		ID:     job.JobArn,
+		Object: "fine_tuning.job",
+		Model:  job.BaseModelArn,
+		Status: status,
+	}
+	if job.TrainingDataConfig != nil {
+		result.TrainingFile = job.TrainingDataConfig.S3Uri
+	}
+	if job.ValidationDataConfig != nil && len(job.ValidationDataConfig.Validators) > 0 {
+		result.ValidationFile = schemas.Ptr(job.ValidationDataConfig.Validators[0].S3Uri)
+	}
+	if name := job.OutputModelName; name != "" {
+		result.Suffix = schemas.Ptr(name)
+	} else if name := job.CustomModelName; name != "" {
+		result.Suffix = schemas.Ptr(name)
+	}
+	// The custom model only exists once the job has completed.
+	if arn := job.OutputModelArn; arn != "" && status == schemas.FineTuningJobStatusSucceeded {
+		result.FineTunedModel = schemas.Ptr(arn)
+	} else if arn := job.CustomModelArn; arn != "" && status == schemas.FineTuningJobStatusSucceeded {
+		result.FineTunedModel = schemas.Ptr(arn)
+	}
+	if len(job.HyperParameters) > 0 {
+		hp := &schemas.FineTuningHyperparameters{}
+		if n, err := strconv.Atoi(job.HyperParameters["epochCount"]); err == nil {
+			hp.NEpochs = schemas.Ptr(n)
+		}
+		if n, err := strconv.Atoi(job.HyperParameters["batchSize"]); err == nil {
+			hp.BatchSize = schemas.Ptr(n)
+		}
+		result.Hyperparameters = hp
+	}
+	if job.FailureMessage != "" {
+		result.Error = &schemas.FineTuningJobError{Message: job.FailureMessage}
+	}
+	if job.CreationTime != nil {
+		result.CreatedAt = job.CreationTime.Unix()
+	}
+	if job.EndTime != nil {
+		result.FinishedAt = schemas.Ptr(job.EndTime.Unix())
+	}
+	return result
+}
+
+// doModelCustomizationRequest sends a signed request to the Bedrock control plane and
+// returns the response body.
+func (provider *BedrockProvider) doModelCustomizationRequest(ctx *schemas.BifrostContext, key schemas.Key, method, path string, jsonData []byte) ([]byte, time.Duration, *schemas.BifrostError) {
+	if key.BedrockKeyConfig == nil {
+		return nil, 0, providerUtils.NewConfigurationError("bedrock key config is not set")
+	}
+	region := DefaultBedrockRegion
+	if key.BedrockKeyConfig.Region != nil && key.BedrockKeyConfig.Region.GetValue() != "" {
+		region = key.BedrockKeyConfig.Region.GetValue()
+	}
+
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("https://bedrock.%s.amazonaws.com%s", region, path), reqBody)
+	if err != nil {
+		return nil, 0, providerUtils.NewBifrostOperationError("error creating request", err)
+	}
+	if jsonData != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if bifrostErr := signAWSRequest(ctx, httpReq, key.BedrockKeyConfig, region, bedrockSigningService); bifrostErr != nil {
+		return nil, 0, bifrostErr
+	}
+
+	startTime := time.Now()
+	resp, err := providerUtils.DoHTTPRequest(provider.client, httpReq)
+	latency := time.Since(startTime)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, latency, &schemas.BifrostError{
+				IsBifrostError: false,
+				Error: &schemas.ErrorField{
+					Type:    schemas.Ptr(schemas.RequestCancelled),
+					Message: schemas.ErrRequestCancelled,
+					Error:   err,
+				},
+			}
+		}
+		return nil, latency, providerUtils.NewBifrostOperationError(schemas.ErrProviderDoRequest, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, latency, providerUtils.NewBifrostOperationError("error reading response", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, latency, parseBedrockHTTPError(resp.StatusCode, resp.Header, body)
+	}
+	return body, latency, nil
+}
+
+// getModelCustomizationJob fetches a model customization job with a single key.
+func (provider *BedrockProvider) getModelCustomizationJob(ctx *schemas.BifrostContext, key schemas.Key, jobID string) (*BedrockModelCustomizationJob, time.Duration, *schemas.BifrostError) {
+	body, latency, bifrostErr := provider.doModelCustomizationRequest(ctx, key, http.MethodGet, "/model-customization-jobs/"+url.PathEscape(jobID), nil)
+	if bifrostErr != nil {
+		return nil, latency, bifrostErr
+	}
+	var job BedrockModelCustomizationJob
+	if err := sonic.Unmarshal(body, &job); err != nil {
+		return nil, latency, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, err)
+	}
+	return &job, latency, nil
+}
+
+// FineTuningJobCreate starts a Bedrock model customization job. The service role comes
+// from the key's batch_role_arn, extra_params["role_arn"] or the key's role_arn, and the
+// output location from extra_params["output_s3_uri"].
+func (provider *BedrockProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.Bedrock, provider.customProviderConfig, schemas.FineTuningJobCreateRequest); err != nil {
+		return nil, err
+	}
+	if key.BedrockKeyConfig == nil {
+		return nil, providerUtils.NewConfigurationError("bedrock key config is not set")
+	}
+
+	roleArn := ""
+	if key.BedrockKeyConfig.BatchRoleARN != nil {
+		roleArn = key.BedrockKeyConfig.BatchRoleARN.GetValue()
+	}
+	if roleArn == "" {
+		if r, ok := request.ExtraParams["role_arn"].(string); ok {
+			roleArn = r
+		}
+	}
+	if roleArn == "" && key.BedrockKeyConfig.RoleARN != nil {
+		roleArn = key.BedrockKeyConfig.RoleARN.GetValue()
+	}
+	if roleArn == "" {
+		return nil, providerUtils.NewBifrostOperationError("role_arn is required for Bedrock fine-tuning (send it in extra_params or set batch_role_arn in the key config)", nil)
+	}
+
+	outputS3URI, _ := request.ExtraParams["output_s3_uri"].(string)
+	if outputS3URI == "" {
+		return nil, providerUtils.NewBifrostOperationError("output_s3_uri is required for Bedrock fine-tuning (provide in extra_params)", nil)
+	}
+
+	jsonData, err := providerUtils.MarshalSorted(ToBedrockModelCustomizationJobRequest(request, roleArn, outputS3URI))
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderRequestMarshal, err)
+	}
+
+	sendBackRawRequest := providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest)
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	body, latency, bifrostErr := provider.doModelCustomizationRequest(ctx, key, http.MethodPost, "/model-customization-jobs", jsonData)
+	if bifrostErr != nil {
+		return nil, providerUtils.EnrichError(ctx, bifrostErr, jsonData, body, sendBackRawRequest, sendBackRawResponse)
+	}
+
+	var created BedrockModelCustomizationJob
+	rawRequest, rawResponse, bifrostErr := providerUtils.HandleProviderResponse(body, &created, jsonData, sendBackRawRequest, sendBackRawResponse)
+	if bifrostErr != nil {
+		return nil, providerUtils.EnrichError(ctx, bifrostErr, jsonData, body, sendBackRawRequest, sendBackRawResponse)
+	}
+
+	// CreateModelCustomizationJob only returns the job ARN; read the job back for its details.
+	job := &created
+	if retrieved, _, retrieveErr := provider.getModelCustomizationJob(ctx, key, created.JobArn); retrieveErr == nil {
+		job = retrieved
+	} else {
+		job.Status = "InProgress"
+		job.BaseModelArn = request.Model
+		job.TrainingDataConfig = &BedrockCustomizationS3Config{S3Uri: request.TrainingFile}
+	}
+
+	result := &schemas.BifrostFineTuningJobCreateResponse{
+		FineTuningJob: job.ToBifrostFineTuningJob(),
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if sendBackRawRequest {
+		result.ExtraFields.RawRequest = rawRequest
+	}
+	if sendBackRawResponse {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// FineTuningJobList lists model customization jobs using serial pagination across keys.
+func (provider *BedrockProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.Bedrock, provider.customProviderConfig, schemas.FineTuningJobListRequest); err != nil {
+		return nil, err
+	}
+
+	helper, err := providerUtils.NewSerialListHelper(keys, request.After, provider.logger, true)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError("invalid pagination cursor", err)
+	}
+
+	key, nativeCursor, ok := helper.GetCurrentKey()
+	if !ok {
+		return &schemas.BifrostFineTuningJobListResponse{
+			Object: "list",
+			Data:   []schemas.FineTuningJob{},
+		}, nil
+	}
+
+	params := url.Values{}
+	if request.Limit > 0 {
+		params.Set("maxResults", strconv.Itoa(request.Limit))
+	}
+	if nativeCursor != "" {
+		params.Set("nextToken", nativeCursor)
+	}
+	path := "/model-customization-jobs"
+	if encoded := params.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	body, latency, bifrostErr := provider.doModelCustomizationRequest(ctx, key, http.MethodGet, path, nil)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	var listResp BedrockModelCustomizationJobListResponse
+	if err := sonic.Unmarshal(body, &listResp); err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, err)
+	}
+
+	data := make([]schemas.FineTuningJob, 0, len(listResp.ModelCustomizationJobSummaries))
+	for i := range listResp.ModelCustomizationJobSummaries {
+		data = append(data, listResp.ModelCustomizationJobSummaries[i].ToBifrostFineTuningJob())
+	}
+
+	nativeNextCursor := ""
+	if listResp.NextToken != nil {
+		nativeNextCursor = *listResp.NextToken
+	}
+	nextCursor, hasMore := helper.BuildNextCursor(nativeNextCursor != "", nativeNextCursor)
+
+	result := &schemas.BifrostFineTuningJobListResponse{
+		Object:  "list",
+		Data:    data,
+		HasMore: hasMore,
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if nextCursor != "" {
+		result.NextCursor = &nextCursor
+	}
+	return result, nil
+}
+
+// FineTuningJobRetrieve retrieves a model customization job by trying each key until found.
+func (provider *BedrockProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.Bedrock, provider.customProviderConfig, schemas.FineTuningJobRetrieveRequest); err != nil {
+		return nil, err
+	}
+
+	if request.JobID == "" {
+		return nil, providerUtils.NewBifrostOperationError("job_id (job ARN) is required", nil)
+	}
+
+	var lastErr *schemas.BifrostError
+	for _, key := range keys {
+		job, latency, bifrostErr := provider.getModelCustomizationJob(ctx, key, request.JobID)
+		if bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+		return &schemas.BifrostFineTuningJobRetrieveResponse{
+			FineTuningJob: job.ToBifrostFineTuningJob(),
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				Latency: latency.Milliseconds(),
+			},
+		}, nil
+	}
+
+	return nil, lastErr
+}
+
+// FineTuningJobCancel stops a model customization job by trying each key until successful.
+func (provider *BedrockProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.Bedrock, provider.customProviderConfig, schemas.FineTuningJobCancelRequest); err != nil {
+		return nil, err
+	}
+
+	if request.JobID == "" {
+		return nil, providerUtils.NewBifrostOperationError("job_id (job ARN) is required", nil)
+	}
+
+	var lastErr *schemas.BifrostError
+	for _, key := range keys {
+		startTime := time.Now()
+		if _, _, bifrostErr := provider.doModelCustomizationRequest(ctx, key, http.MethodPost, "/model-customization-jobs/"+url.PathEscape(request.JobID)+"/stop", nil); bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+
+		// After stopping, retrieve the job to get its updated status
+		job, _, bifrostErr := provider.getModelCustomizationJob(ctx, key, request.JobID)
+		if bifrostErr != nil {
+			job = &BedrockModelCustomizationJob{JobArn: request.JobID, Status: "Stopping"}
+		}
+		return &schemas.BifrostFineTuningJobCancelResponse{
+			FineTuningJob: job.ToBifrostFineTuningJob(),
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				Latency: time.Since(startTime).Milliseconds(),
+			},
+		}, nil
+	}
+
+	return nil, lastErr
+}
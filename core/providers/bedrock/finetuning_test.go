@@ -0,0 +1,48 @@
+package bedrock
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToBedrockModelCustomizationJobRequest verifies hyperparameters are sent
+// as strings, the absolute learning rate comes from extra_params, and metadata
+// becomes job tags in a stable order.
+func TestToBedrockModelCustomizationJobRequest(t *testing.T) {
+	req := ToBedrockModelCustomizationJobRequest(&schemas.BifrostFineTuningJobCreateRequest{
+		Model:           "amazon.titan-text-express-v1",
+		TrainingFile:    "s3://bucket/train.jsonl",
+		ValidationFile:  schemas.Ptr("s3://bucket/val.jsonl"),
+		Suffix:          schemas.Ptr("support-model"),
+		Hyperparameters: &schemas.FineTuningHyperparameters{NEpochs: schemas.Ptr(2), BatchSize: schemas.Ptr(8)},
+		Metadata:        map[string]string{"team": "platform", "owner": "ml"},
+		ExtraParams:     map[string]any{"learning_rate": 0.00001},
+	}, "arn:aws:iam::123:role/ft", "s3://bucket/out/")
+
+	assert.Equal(t, "support-model", req.CustomModelName)
+	assert.Equal(t, "FINE_TUNING", req.CustomizationType)
+	assert.Equal(t, "arn:aws:iam::123:role/ft", req.RoleArn)
+	assert.Equal(t, "s3://bucket/out/", req.OutputDataConfig.S3Uri)
+	require.NotNil(t, req.ValidationDataConfig)
+	assert.Equal(t, "s3://bucket/val.jsonl", req.ValidationDataConfig.Validators[0].S3Uri)
+	assert.Equal(t, map[string]string{"epochCount": "2", "batchSize": "8", "learningRate": "1e-05"}, req.HyperParameters)
+	assert.Equal(t, []BedrockTag{{Key: "owner", Value: "ml"}, {Key: "team", Value: "platform"}}, req.JobTags)
+}
+
+func TestBedrockModelCustomizationJob_FineTunedModelOnlyWhenCompleted(t *testing.T) {
+	job := &BedrockModelCustomizationJob{
+		JobArn:         "arn:aws:bedrock:us-east-1:123:model-customization-job/abc",
+		Status:         "InProgress",
+		OutputModelArn: "arn:aws:bedrock:us-east-1:123:custom-model/xyz",
+	}
+	assert.Nil(t, job.ToBifrostFineTuningJob().FineTunedModel)
+
+	job.Status = "Completed"
+	result := job.ToBifrostFineTuningJob()
+	assert.Equal(t, schemas.FineTuningJobStatusSucceeded, result.Status)
+	require.NotNil(t, result.FineTunedModel)
+	assert.Equal(t, job.OutputModelArn, *result.FineTunedModel)
+}
@@ -563,6 +563,26 @@ func (provider *BedrockMantleProvider) CachedContentDelete(ctx *schemas.BifrostC
 	return nil, providerUtils.NewUnsupportedOperationError(schemas.CachedContentDeleteRequest, provider.GetProviderKey())
 }
 
+// FineTuningJobCreate is not supported by the Bedrock Mantle provider.
+func (provider *BedrockMantleProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCreateRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobList is not supported by the Bedrock Mantle provider.
+func (provider *BedrockMantleProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobListRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobRetrieve is not supported by the Bedrock Mantle provider.
+func (provider *BedrockMantleProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobRetrieveRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobCancel is not supported by the Bedrock Mantle provider.
+func (provider *BedrockMantleProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCancelRequest, provider.GetProviderKey())
+}
+
 // ContainerCreate is not supported by the Bedrock Mantle provider.
 func (provider *BedrockMantleProvider) ContainerCreate(_ *schemas.BifrostContext, _ schemas.Key, _ *schemas.BifrostContainerCreateRequest) (*schemas.BifrostContainerCreateResponse, *schemas.BifrostError) {
 	return nil, providerUtils.NewUnsupportedOperationError(schemas.ContainerCreateRequest, provider.GetProviderKey())
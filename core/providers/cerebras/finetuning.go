@@ -0,0 +1,27 @@
+package cerebras
+
+import (
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// FineTuningJobCreate is unsupported on CerebrasProvider. Managed fine-tuning is only
+// exposed through OpenAI, Vertex AI tuning jobs and Bedrock model customization.
+func (provider *CerebrasProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCreateRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobList is unsupported on CerebrasProvider (see FineTuningJobCreate).
+func (provider *CerebrasProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobListRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobRetrieve is unsupported on CerebrasProvider (see FineTuningJobCreate).
+func (provider *CerebrasProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobRetrieveRequest, provider.GetProviderKey())
+}
+
+// FineTuningJobCancel is unsupported on CerebrasProvider (see FineTuningJobCreate).
+func (provider *CerebrasProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	return nil, providerUtils.NewUnsupportedOperationError(schemas.FineTuningJobCancelRequest, provider.GetProviderKey())
+}
@@ -117,6 +117,7 @@ func ToCohereChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest) (*Coh
 		cohereReq.StopSequences = bifrostReq.Params.Stop
 		cohereReq.FrequencyPenalty = bifrostReq.Params.FrequencyPenalty
 		cohereReq.PresencePenalty = bifrostReq.Params.PresencePenalty
+		cohereReq.Seed = bifrostReq.Params.Seed
 
 		// Convert reasoning
 		if bifrostReq.Params.Reasoning != nil {
@@ -284,6 +285,9 @@ func (req *CohereChatRequest) ToBifrostChatRequest(ctx *schemas.BifrostContext)
 	if req.PresencePenalty != nil {
 		bifrostReq.Params.PresencePenalty = req.PresencePenalty
 	}
+	if req.Seed != nil {
+		bifrostReq.Params.Seed = req.Seed
+	}
 
 	// Convert reasoning
 	if req.Thinking != nil {
@@ -35,6 +35,7 @@ type CohereChatRequest struct {
 	StopSequences    []string                `json:"stop_sequences,omitempty"`     // Optional: Stop sequences
 	FrequencyPenalty *float64                `json:"frequency_penalty,omitempty"`  // Optional: Frequency penalty
 	PresencePenalty  *float64                `json:"presence_penalty,omitempty"`   // Optional: Presence penalty
+	Seed             *int                    `json:"seed,omitempty"`               // Optional: Seed for deterministic sampling
 	Stream           *bool                   `json:"stream,omitempty"`             // Optional: Enable streaming
 	SafetyMode       *string                 `json:"safety_mode,omitempty"`        // Optional: Safety mode
 	LogProbs         *bool                   `json:"log_probs,omitempty"`          // Optional: Log probabilities
@@ -0,0 +1,51 @@
+package gemini
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Files uploaded through the Files API are referenced by file_id ("files/abc") in
+// chat and responses inputs; they must reach Gemini as fileData parts instead of
+// being dropped, so callers don't have to inline large PDFs/videos as base64.
+
+func TestFileIDToGeminiFileURI(t *testing.T) {
+	assert.Equal(t, testFileURI, fileIDToGeminiFileURI("files/abc"))
+	assert.Equal(t, testFileURI, fileIDToGeminiFileURI("abc"))
+	assert.Equal(t, testFileURI, fileIDToGeminiFileURI(testFileURI))
+	assert.Equal(t, "gs://bucket/doc.pdf", fileIDToGeminiFileURI("gs://bucket/doc.pdf"))
+}
+
+func TestConvertBifrostMessagesToGemini_FileIDBecomesFileData(t *testing.T) {
+	msgs := []schemas.ChatMessage{{
+		Role: schemas.ChatMessageRoleUser,
+		Content: &schemas.ChatMessageContent{
+			ContentBlocks: []schemas.ChatContentBlock{{
+				Type: schemas.ChatContentBlockTypeFile,
+				File: &schemas.ChatInputFile{FileID: sptr("files/abc"), FileType: sptr("video/mp4")},
+			}},
+		},
+	}}
+	contents, _, err := convertBifrostMessagesToGemini(msgs)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	require.Len(t, contents[0].Parts, 1)
+	require.NotNil(t, contents[0].Parts[0].FileData)
+	assert.Equal(t, testFileURI, contents[0].Parts[0].FileData.FileURI)
+	assert.Equal(t, "video/mp4", contents[0].Parts[0].FileData.MIMEType)
+}
+
+func TestConvertContentBlockToGeminiPart_FileIDBecomesFileData(t *testing.T) {
+	part, err := convertContentBlockToGeminiPart(schemas.ResponsesMessageContentBlock{
+		Type:   schemas.ResponsesInputMessageContentBlockTypeFile,
+		FileID: sptr("files/abc"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, part)
+	require.NotNil(t, part.FileData)
+	assert.Equal(t, testFileURI, part.FileData.FileURI)
+	assert.Empty(t, part.FileData.MIMEType)
+}
@@ -561,6 +561,7 @@ func HandleGeminiChatCompletionStream(
 				if strings.Contains(err.Error(), "gemini api error") {
 					// Handle API error
 					bifrostErr := toGeminiStreamBifrostError(err)
+					providerUtils.SetRawError(bifrostErr, eventData)
 					ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 					providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 					return
@@ -1074,6 +1075,7 @@ func HandleGeminiResponsesStream(
 				if strings.Contains(err.Error(), "gemini api error") {
 					// Handle API error
 					bifrostErr := toGeminiStreamBifrostError(err)
+					providerUtils.SetRawError(bifrostErr, eventData)
 					ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 					providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse), responseChan, logger, postHookSpanFinalizer)
 					return
@@ -1532,6 +1534,7 @@ func (provider *GeminiProvider) SpeechStream(ctx *schemas.BifrostContext, postHo
 				if strings.Contains(err.Error(), "gemini api error") {
 					// Handle API error
 					bifrostErr := toGeminiStreamBifrostError(err)
+					providerUtils.SetRawError(bifrostErr, jsonData)
 					ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 					providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, bifrostErr, responseChan, provider.logger, postHookSpanFinalizer)
 					return
@@ -1819,6 +1822,7 @@ func (provider *GeminiProvider) TranscriptionStream(ctx *schemas.BifrostContext,
 			if err != nil {
 				if strings.Contains(err.Error(), "gemini api error") {
 					bifrostErr := toGeminiStreamBifrostError(err)
+					providerUtils.SetRawError(bifrostErr, jsonData)
 					ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 					providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, bifrostErr, responseChan, provider.logger, postHookSpanFinalizer)
 					return
@@ -2013,6 +2013,37 @@ func TestServiceTierMappingResponses(t *testing.T) {
 	}
 }
 
+func TestSeedMapping(t *testing.T) {
+	chatReq := &schemas.BifrostChatRequest{
+		Model: "gemini-2.0-flash",
+		Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hello")}},
+		},
+		Params: &schemas.ChatParameters{Seed: schemas.Ptr(42)},
+	}
+	chatResult, err := gemini.ToGeminiChatCompletionRequest(nil, chatReq)
+	require.NoError(t, err)
+	require.NotNil(t, chatResult.GenerationConfig.Seed)
+	assert.Equal(t, int32(42), *chatResult.GenerationConfig.Seed)
+
+	responsesReq := &schemas.BifrostResponsesRequest{
+		Provider: schemas.Gemini,
+		Model:    "gemini-2.0-flash",
+		Input: []schemas.ResponsesMessage{
+			{
+				Role:    schemas.Ptr(schemas.ResponsesInputMessageRoleUser),
+				Type:    schemas.Ptr(schemas.ResponsesMessageTypeMessage),
+				Content: &schemas.ResponsesMessageContent{ContentStr: schemas.Ptr("hello")},
+			},
+		},
+		Params: &schemas.ResponsesParameters{ExtraParams: map[string]interface{}{"seed": 7}},
+	}
+	responsesResult, err := gemini.ToGeminiResponsesRequest(nil, responsesReq)
+	require.NoError(t, err)
+	require.NotNil(t, responsesResult.GenerationConfig.Seed)
+	assert.Equal(t, int32(7), *responsesResult.GenerationConfig.Seed)
+}
+
 func TestServiceTierReverseMapping(t *testing.T) {
 	tests := []struct {
 		name         string
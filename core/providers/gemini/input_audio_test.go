@@ -0,0 +1,47 @@
+package gemini
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBifrostMessagesToGemini_InputAudio(t *testing.T) {
+	wavBytes := []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	wav := base64.StdEncoding.EncodeToString(wavBytes)
+	for name, tc := range map[string]struct {
+		audio    schemas.ChatInputAudio
+		wantMime string
+	}{
+		"format":             {audio: schemas.ChatInputAudio{Data: wav, Format: schemas.Ptr("mp3")}, wantMime: "audio/mp3"},
+		"data url":           {audio: schemas.ChatInputAudio{Data: "data:audio/ogg;base64," + wav}, wantMime: "audio/ogg"},
+		"detected from data": {audio: schemas.ChatInputAudio{Data: wav}, wantMime: "audio/wav"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			audio := tc.audio
+			contents, _, err := convertBifrostMessagesToGemini([]schemas.ChatMessage{{
+				Role: schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+					{Type: schemas.ChatContentBlockTypeInputAudio, InputAudio: &audio},
+				}},
+			}})
+			require.NoError(t, err)
+			require.Len(t, contents, 1)
+			require.Len(t, contents[0].Parts, 1)
+			blob := contents[0].Parts[0].InlineData
+			require.NotNil(t, blob)
+			require.Equal(t, tc.wantMime, blob.MIMEType)
+			require.Equal(t, wav, blob.Data)
+		})
+	}
+
+	_, _, err := convertBifrostMessagesToGemini([]schemas.ChatMessage{{
+		Role: schemas.ChatMessageRoleUser,
+		Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+			{Type: schemas.ChatContentBlockTypeInputAudio, InputAudio: &schemas.ChatInputAudio{Data: "not base64!"}},
+		}},
+	}})
+	require.ErrorContains(t, err, "failed to parse input audio")
+}
@@ -2943,6 +2943,12 @@ func (r *GeminiGenerationRequest) convertParamsToGenerationConfigResponses(param
 				config.PresencePenalty = schemas.Ptr(val)
 			}
 		}
+		if seed, ok := params.ExtraParams["seed"]; ok {
+			delete(params.ExtraParams, "seed")
+			if val, success := schemas.SafeExtractInt(seed); success {
+				config.Seed = schemas.Ptr(int32(val))
+			}
+		}
 		if stopSequences, ok := params.ExtraParams["stop_sequences"]; ok {
 			delete(params.ExtraParams, "stop_sequences")
 			if val, success := schemas.SafeExtractStringSlice(stopSequences); success {
@@ -3585,6 +3591,15 @@ func convertContentBlockToGeminiPart(block schemas.ResponsesMessageContentBlock,
 				}
 			}
 		}
+
+		// Handle FileID (reference to a file uploaded through the Files API)
+		if block.FileID != nil && *block.FileID != "" {
+			fileData := &FileData{FileURI: fileIDToGeminiFileURI(*block.FileID)}
+			if block.ResponsesInputMessageContentBlockFile != nil && block.ResponsesInputMessageContentBlockFile.FileType != nil {
+				fileData.MIMEType = *block.ResponsesInputMessageContentBlockFile.FileType
+			}
+			return &Part{FileData: fileData}, nil
+		}
 	}
 
 	return nil, nil
@@ -680,6 +680,23 @@ func isImageMimeType(mimeType string) bool {
 	return false
 }
 
+// geminiFilesBaseURI is the prefix of the fileUri Gemini returns for files uploaded
+// through the Files API.
+const geminiFilesBaseURI = "https://generativelanguage.googleapis.com/v1beta/"
+
+// fileIDToGeminiFileURI turns a Files API reference ("files/abc123", or the bare
+// "abc123") into the fileUri Gemini expects in a fileData part. IDs that are
+// already URIs (https://, gs://) pass through unchanged.
+func fileIDToGeminiFileURI(fileID string) string {
+	if strings.Contains(fileID, "://") {
+		return fileID
+	}
+	if !strings.HasPrefix(fileID, "files/") {
+		fileID = "files/" + fileID
+	}
+	return geminiFilesBaseURI + fileID
+}
+
 // convertFileDataToBytes converts file data (data URL or base64) to raw bytes for Gemini API.
 // Returns the bytes and an extracted mime type (if found in data URL).
 func convertFileDataToBytes(fileData string) ([]byte, string) {
@@ -1197,6 +1214,9 @@ func convertParamsToGenerationConfig(params *schemas.ChatParameters, responseMod
 		penalty := float64(*params.FrequencyPenalty)
 		config.FrequencyPenalty = &penalty
 	}
+	if params.Seed != nil {
+		config.Seed = schemas.Ptr(int32(*params.Seed))
+	}
 	// Only set ThinkingConfig if the model actually supports thinking
 	if params.Reasoning != nil && supportsThinkingConfig(model) {
 		config.ThinkingConfig = &GenerationConfigThinkingConfig{
@@ -1997,6 +2017,13 @@ func convertBifrostMessagesToGemini(messages []schemas.ChatMessage, allowedImage
 								fileData.MIMEType = *block.File.FileType
 							}
 							parts = append(parts, &Part{FileData: fileData})
+						} else if block.File.FileID != nil && *block.File.FileID != "" {
+							// Reference to a file uploaded through the Files API
+							fileData := &FileData{FileURI: fileIDToGeminiFileURI(*block.File.FileID)}
+							if block.File.FileType != nil {
+								fileData.MIMEType = *block.File.FileType
+							}
+							parts = append(parts, &Part{FileData: fileData})
 						} else if block.File.FileData != nil {
 							// Inline file data - convert to InlineData (Blob)
 							fileData := *block.File.FileData
@@ -2061,23 +2088,10 @@ func convertBifrostMessagesToGemini(messages []schemas.ChatMessage, allowedImage
 							})
 						}
 					} else if block.InputAudio != nil {
-						// Decode the audio data (handles both standard and URL-safe base64)
-						decodedData, err := decodeBase64StringToBytes(block.InputAudio.Data)
-						if err != nil || len(decodedData) == 0 {
-							continue
-						}
-
-						// Determine MIME type
-						mimeType := "audio/mpeg" // default
-						if block.InputAudio.Format != nil {
-							format := strings.ToLower(strings.TrimSpace(*block.InputAudio.Format))
-							if format != "" {
-								if strings.HasPrefix(format, "audio/") {
-									mimeType = format
-								} else {
-									mimeType = "audio/" + format
-								}
-							}
+						// Accepts bare base64 or a data URL; the MIME type falls back to the audio headers
+						decodedData, mimeType, err := providerUtils.ParseInputAudio(block.InputAudio)
+						if err != nil {
+							return nil, nil, fmt.Errorf("failed to parse input audio: %w", err)
 						}
 
 						parts = append(parts, &Part{
@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -1471,3 +1472,32 @@ func TestOpenAIChatRequest_StripsWebSearchOptionsFilters(t *testing.T) {
 	// Original request must not be mutated
 	require.NotNil(t, req.ChatParameters.WebSearchOptions.Filters)
 }
+
+func TestToOpenAIChatRequest_NormalizesInputAudio(t *testing.T) {
+	wav := base64.StdEncoding.EncodeToString([]byte("RIFF\x24\x00\x00\x00WAVEfmt "))
+	audioMessage := func(audio schemas.ChatInputAudio) schemas.ChatMessage {
+		return schemas.ChatMessage{
+			Role: schemas.ChatMessageRoleUser,
+			Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+				{Type: schemas.ChatContentBlockTypeInputAudio, InputAudio: &audio},
+			}},
+		}
+	}
+	input := []schemas.ChatMessage{
+		audioMessage(schemas.ChatInputAudio{Data: "data:audio/wav;base64," + wav}),
+		audioMessage(schemas.ChatInputAudio{Data: wav}),
+		audioMessage(schemas.ChatInputAudio{Data: wav, Format: schemas.Ptr("audio/mpeg")}),
+		audioMessage(schemas.ChatInputAudio{Data: wav, Format: schemas.Ptr("wav")}),
+	}
+
+	req := ToOpenAIChatRequest(schemas.NewBifrostContext(nil, schemas.NoDeadline), &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o-audio-preview", Input: input})
+	require.NotNil(t, req)
+	for i, want := range []string{"wav", "wav", "mp3", "wav"} {
+		audio := req.Messages[i].Content.ContentBlocks[0].InputAudio
+		require.Equal(t, wav, audio.Data, "message %d", i)
+		require.NotNil(t, audio.Format, "message %d", i)
+		require.Equal(t, want, *audio.Format, "message %d", i)
+	}
+	require.Equal(t, "data:audio/wav;base64,"+wav, input[0].Content.ContentBlocks[0].InputAudio.Data, "the caller's message must not be modified")
+	require.Same(t, input[3].Content, req.Messages[3].Content, "well-formed audio should not be copied")
+}
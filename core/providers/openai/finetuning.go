@@ -0,0 +1,356 @@
+package openai
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// OpenAI Fine-tuning API Types
+
+// OpenAIFineTuningHyperparameters mirrors the OpenAI hyperparameters object.
+// Every field is either a number or the string "auto".
+type OpenAIFineTuningHyperparameters struct {
+	NEpochs                any `json:"n_epochs,omitempty"`
+	BatchSize              any `json:"batch_size,omitempty"`
+	LearningRateMultiplier any `json:"learning_rate_multiplier,omitempty"`
+}
+
+// OpenAIFineTuningJobRequest represents the request body for creating a fine-tuning job.
+type OpenAIFineTuningJobRequest struct {
+	Model           string                           `json:"model"`
+	TrainingFile    string                           `json:"training_file"`
+	ValidationFile  *string                          `json:"validation_file,omitempty"`
+	Suffix          *string                          `json:"suffix,omitempty"`
+	Seed            *int                             `json:"seed,omitempty"`
+	Metadata        map[string]string                `json:"metadata,omitempty"`
+	Hyperparameters *OpenAIFineTuningHyperparameters `json:"hyperparameters,omitempty"`
+}
+
+// OpenAIFineTuningJob represents an OpenAI fine-tuning job object.
+type OpenAIFineTuningJob struct {
+	ID              string                           `json:"id"`
+	Object          string                           `json:"object"`
+	Model           string                           `json:"model"`
+	FineTunedModel  *string                          `json:"fine_tuned_model,omitempty"`
+	Status          string                           `json:"status"`
+	TrainingFile    string                           `json:"training_file"`
+	ValidationFile  *string                          `json:"validation_file,omitempty"`
+	Hyperparameters *OpenAIFineTuningHyperparameters `json:"hyperparameters,omitempty"`
+	TrainedTokens   *int                             `json:"trained_tokens,omitempty"`
+	Error           *schemas.FineTuningJobError      `json:"error,omitempty"`
+	Suffix          *string                          `json:"suffix,omitempty"`
+	Metadata        map[string]string                `json:"metadata,omitempty"`
+	CreatedAt       int64                            `json:"created_at"`
+	FinishedAt      *int64                           `json:"finished_at,omitempty"`
+	EstimatedFinish *int64                           `json:"estimated_finish,omitempty"`
+}
+
+// OpenAIFineTuningJobListResponse represents the response from listing fine-tuning jobs.
+type OpenAIFineTuningJobListResponse struct {
+	Object  string                `json:"object"`
+	Data    []OpenAIFineTuningJob `json:"data"`
+	HasMore bool                  `json:"has_more"`
+}
+
+// ToOpenAIFineTuningJobRequest converts a Bifrost fine-tuning create request to the OpenAI wire format.
+func ToOpenAIFineTuningJobRequest(request *schemas.BifrostFineTuningJobCreateRequest) *OpenAIFineTuningJobRequest {
+	openAIReq := &OpenAIFineTuningJobRequest{
+		Model:          request.Model,
+		TrainingFile:   request.TrainingFile,
+		ValidationFile: request.ValidationFile,
+		Suffix:         request.Suffix,
+		Seed:           request.Seed,
+		Metadata:       request.Metadata,
+	}
+	if hp := request.Hyperparameters; hp != nil {
+		openAIReq.Hyperparameters = &OpenAIFineTuningHyperparameters{}
+		if hp.NEpochs != nil {
+			openAIReq.Hyperparameters.NEpochs = *hp.NEpochs
+		}
+		if hp.BatchSize != nil {
+			openAIReq.Hyperparameters.BatchSize = *hp.BatchSize
+		}
+		if hp.LearningRateMultiplier != nil {
+			openAIReq.Hyperparameters.LearningRateMultiplier = *hp.LearningRateMultiplier
+		}
+	}
+	return openAIReq
+}
+
+// ToBifrostFineTuningJob converts an OpenAI fine-tuning job to the Bifrost representation.
+// Hyperparameters reported as "auto" are left unset.
+func (job *OpenAIFineTuningJob) ToBifrostFineTuningJob() schemas.FineTuningJob {
+	result := schemas.FineTuningJob{
+		ID:              job.ID,
+		Object:          job.Object,
+		Model:           job.Model,
+		FineTunedModel:  job.FineTunedModel,
+		Status:          ToBifrostFineTuningJobStatus(job.Status),
+		TrainingFile:    job.TrainingFile,
+		ValidationFile:  job.ValidationFile,
+		TrainedTokens:   job.TrainedTokens,
+		Error:           job.Error,
+		Suffix:          job.Suffix,
+		Metadata:        job.Metadata,
+		CreatedAt:       job.CreatedAt,
+		FinishedAt:      job.FinishedAt,
+		EstimatedFinish: job.EstimatedFinish,
+	}
+	if hp := job.Hyperparameters; hp != nil {
+		result.Hyperparameters = &schemas.FineTuningHyperparameters{}
+		if v, ok := hp.NEpochs.(float64); ok {
+			result.Hyperparameters.NEpochs = schemas.Ptr(int(v))
+		}
+		if v, ok := hp.BatchSize.(float64); ok {
+			result.Hyperparameters.BatchSize = schemas.Ptr(int(v))
+		}
+		if v, ok := hp.LearningRateMultiplier.(float64); ok {
+			result.Hyperparameters.LearningRateMultiplier = schemas.Ptr(v)
+		}
+	}
+	return result
+}
+
+// ToBifrostFineTuningJobStatus converts OpenAI status to Bifrost status.
+// OpenAI already uses the Bifrost vocabulary; unknown values pass through.
+func ToBifrostFineTuningJobStatus(status string) schemas.FineTuningJobStatus {
+	return schemas.FineTuningJobStatus(status)
+}
+
+// doFineTuningRequest sends a single fine-tuning API call with the given key and
+// returns the decoded body.
+func (provider *OpenAIProvider) doFineTuningRequest(ctx *schemas.BifrostContext, key schemas.Key, method, requestURL string, jsonData []byte) ([]byte, time.Duration, *schemas.BifrostError) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	req.SetRequestURI(requestURL)
+	req.Header.SetMethod(method)
+	req.Header.SetContentType("application/json")
+	if key.Value.GetValue() != "" {
+		req.Header.Set("Authorization", "Bearer "+key.Value.GetValue())
+	}
+	if jsonData != nil {
+		req.SetBody(jsonData)
+	}
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, latency, bifrostErr
+	}
+
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return nil, latency, providerUtils.SetErrorLatency(ParseOpenAIError(resp), latency)
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, latency, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)
+	}
+	// The response is released on return, so hand back a copy.
+	return append([]byte(nil), body...), latency, nil
+}
+
+// FineTuningJobCreate launches a fine-tuning job.
+func (provider *OpenAIProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.OpenAI, provider.customProviderConfig, schemas.FineTuningJobCreateRequest); err != nil {
+		return nil, err
+	}
+
+	if request.TrainingFile == "" {
+		return nil, providerUtils.NewBifrostOperationError("training_file is required for OpenAI fine-tuning API", nil)
+	}
+
+	jsonData, err := providerUtils.MarshalSorted(ToOpenAIFineTuningJobRequest(request))
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderRequestMarshal, err)
+	}
+
+	sendBackRawRequest := providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest)
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	body, latency, bifrostErr := provider.doFineTuningRequest(ctx, key, http.MethodPost, provider.buildRequestURL(ctx, "/v1/fine_tuning/jobs", schemas.FineTuningJobCreateRequest), jsonData)
+	if bifrostErr != nil {
+		return nil, providerUtils.EnrichError(ctx, bifrostErr, jsonData, nil, sendBackRawRequest, sendBackRawResponse, latency)
+	}
+
+	var openAIResp OpenAIFineTuningJob
+	rawRequest, rawResponse, bifrostErr := providerUtils.HandleProviderResponse(body, &openAIResp, jsonData, sendBackRawRequest, sendBackRawResponse)
+	if bifrostErr != nil {
+		return nil, providerUtils.EnrichError(ctx, bifrostErr, jsonData, body, sendBackRawRequest, sendBackRawResponse, latency)
+	}
+
+	result := &schemas.BifrostFineTuningJobCreateResponse{
+		FineTuningJob: openAIResp.ToBifrostFineTuningJob(),
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if sendBackRawRequest {
+		result.ExtraFields.RawRequest = rawRequest
+	}
+	if sendBackRawResponse {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// FineTuningJobList lists fine-tuning jobs using serial pagination across keys.
+// Exhausts all pages from one key before moving to the next.
+func (provider *OpenAIProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.OpenAI, provider.customProviderConfig, schemas.FineTuningJobListRequest); err != nil {
+		return nil, err
+	}
+
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	helper, err := providerUtils.NewSerialListHelper(keys, request.After, provider.logger, true)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError("invalid pagination cursor", err)
+	}
+
+	key, nativeCursor, ok := helper.GetCurrentKey()
+	if !ok {
+		return &schemas.BifrostFineTuningJobListResponse{
+			Object:  "list",
+			Data:    []schemas.FineTuningJob{},
+			HasMore: false,
+		}, nil
+	}
+
+	values := url.Values{}
+	if request.Limit > 0 {
+		values.Set("limit", fmt.Sprintf("%d", request.Limit))
+	}
+	if nativeCursor != "" {
+		values.Set("after", nativeCursor)
+	}
+	requestURL := provider.buildRequestURL(ctx, "/v1/fine_tuning/jobs", schemas.FineTuningJobListRequest)
+	if encodedValues := values.Encode(); encodedValues != "" {
+		requestURL += "?" + encodedValues
+	}
+
+	body, latency, bifrostErr := provider.doFineTuningRequest(ctx, key, http.MethodGet, requestURL, nil)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	var openAIResp OpenAIFineTuningJobListResponse
+	_, rawResponse, bifrostErr := providerUtils.HandleProviderResponse(body, &openAIResp, nil, false, sendBackRawResponse)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	jobs := make([]schemas.FineTuningJob, 0, len(openAIResp.Data))
+	var lastJobID string
+	for _, job := range openAIResp.Data {
+		jobs = append(jobs, job.ToBifrostFineTuningJob())
+		lastJobID = job.ID
+	}
+
+	nextCursor, hasMore := helper.BuildNextCursor(openAIResp.HasMore, lastJobID)
+
+	result := &schemas.BifrostFineTuningJobListResponse{
+		Object:  "list",
+		Data:    jobs,
+		HasMore: hasMore,
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if nextCursor != "" {
+		result.NextCursor = &nextCursor
+	}
+	if sendBackRawResponse {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// fineTuningJobAcrossKeys runs a per-job call with each key until one succeeds.
+func (provider *OpenAIProvider) fineTuningJobAcrossKeys(ctx *schemas.BifrostContext, keys []schemas.Key, method, requestURL string) (*OpenAIFineTuningJob, any, time.Duration, *schemas.BifrostError) {
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	var lastErr *schemas.BifrostError
+	for _, key := range keys {
+		body, latency, bifrostErr := provider.doFineTuningRequest(ctx, key, method, requestURL, nil)
+		if bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+
+		var openAIResp OpenAIFineTuningJob
+		_, rawResponse, bifrostErr := providerUtils.HandleProviderResponse(body, &openAIResp, nil, false, sendBackRawResponse)
+		if bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+		return &openAIResp, rawResponse, latency, nil
+	}
+	return nil, nil, 0, lastErr
+}
+
+// FineTuningJobRetrieve retrieves a fine-tuning job by trying each key until found.
+func (provider *OpenAIProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.OpenAI, provider.customProviderConfig, schemas.FineTuningJobRetrieveRequest); err != nil {
+		return nil, err
+	}
+
+	if request.JobID == "" {
+		return nil, providerUtils.NewBifrostOperationError("job_id is required", nil)
+	}
+
+	requestURL := provider.buildRequestURL(ctx, "/v1/fine_tuning/jobs/"+url.PathEscape(request.JobID), schemas.FineTuningJobRetrieveRequest)
+	job, rawResponse, latency, bifrostErr := provider.fineTuningJobAcrossKeys(ctx, keys, http.MethodGet, requestURL)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	result := &schemas.BifrostFineTuningJobRetrieveResponse{
+		FineTuningJob: job.ToBifrostFineTuningJob(),
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// FineTuningJobCancel cancels a fine-tuning job by trying each key until successful.
+func (provider *OpenAIProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.OpenAI, provider.customProviderConfig, schemas.FineTuningJobCancelRequest); err != nil {
+		return nil, err
+	}
+
+	if request.JobID == "" {
+		return nil, providerUtils.NewBifrostOperationError("job_id is required", nil)
+	}
+
+	requestURL := provider.buildRequestURL(ctx, "/v1/fine_tuning/jobs/"+url.PathEscape(request.JobID)+"/cancel", schemas.FineTuningJobCancelRequest)
+	job, rawResponse, latency, bifrostErr := provider.fineTuningJobAcrossKeys(ctx, keys, http.MethodPost, requestURL)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	result := &schemas.BifrostFineTuningJobCancelResponse{
+		FineTuningJob: job.ToBifrostFineTuningJob(),
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
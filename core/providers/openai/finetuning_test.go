@@ -0,0 +1,51 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAIFineTuningJob_AutoHyperparametersStayUnset verifies that
+// hyperparameters reported as "auto" do not turn into zero values while
+// numeric ones are carried over.
+func TestOpenAIFineTuningJob_AutoHyperparametersStayUnset(t *testing.T) {
+	body := []byte(`{
+		"id": "ftjob-abc",
+		"object": "fine_tuning.job",
+		"model": "gpt-4o-mini-2024-07-18",
+		"status": "running",
+		"training_file": "file-train",
+		"created_at": 1721764800,
+		"hyperparameters": {"n_epochs": 3, "batch_size": "auto", "learning_rate_multiplier": 1.8}
+	}`)
+
+	var job OpenAIFineTuningJob
+	require.NoError(t, sonic.Unmarshal(body, &job))
+	result := job.ToBifrostFineTuningJob()
+
+	assert.Equal(t, "ftjob-abc", result.ID)
+	assert.Equal(t, schemas.FineTuningJobStatusRunning, result.Status)
+	require.NotNil(t, result.Hyperparameters)
+	require.NotNil(t, result.Hyperparameters.NEpochs)
+	assert.Equal(t, 3, *result.Hyperparameters.NEpochs)
+	assert.Nil(t, result.Hyperparameters.BatchSize)
+	require.NotNil(t, result.Hyperparameters.LearningRateMultiplier)
+	assert.InDelta(t, 1.8, *result.Hyperparameters.LearningRateMultiplier, 1e-9)
+}
+
+func TestToOpenAIFineTuningJobRequest(t *testing.T) {
+	req := ToOpenAIFineTuningJobRequest(&schemas.BifrostFineTuningJobCreateRequest{
+		Model:           "gpt-4o-mini-2024-07-18",
+		TrainingFile:    "file-train",
+		Suffix:          schemas.Ptr("support"),
+		Hyperparameters: &schemas.FineTuningHyperparameters{NEpochs: schemas.Ptr(2)},
+	})
+
+	data, err := sonic.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"gpt-4o-mini-2024-07-18","training_file":"file-train","suffix":"support","hyperparameters":{"n_epochs":2}}`, string(data))
+}
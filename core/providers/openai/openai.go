@@ -597,6 +597,12 @@ func HandleOpenAITextCompletionStreaming(
 		var created int
 		lastChunkTime := startTime
 
+		var n *int
+		if request.Params != nil {
+			n = request.Params.N
+		}
+		candidates := newStreamCandidates(n)
+
 		for {
 			// If context was cancelled/timed out, let defer handle it
 			if ctx.Err() != nil {
@@ -639,6 +645,7 @@ func HandleOpenAITextCompletionStreaming(
 					var bifrostErr schemas.BifrostError
 					if err := sonic.UnmarshalString(jsonData, &bifrostErr); err == nil {
 						if bifrostErr.Error != nil && bifrostErr.Error.Message != "" {
+							providerUtils.SetRawError(&bifrostErr, []byte(jsonData))
 							ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 							providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, &bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 							return
@@ -702,10 +709,14 @@ func HandleOpenAITextCompletionStreaming(
 			// Handle finish reason, usually in the final chunk
 			choice := response.Choices[0]
 			if choice.FinishReason != nil && *choice.FinishReason != "" {
-				// Collect finish reason and send at the end of the stream
+				// Collect finish reason and send at the end of the stream.
+				// With n > 1 every choice keeps its own finish reason instead.
 				finishReason = choice.FinishReason
-				response.Choices[0].FinishReason = nil
+				if candidates == nil {
+					response.Choices[0].FinishReason = nil
+				}
 			}
+			candidates.observe(response.Choices)
 
 			if response.ID != "" && messageID == "" {
 				messageID = response.ID
@@ -714,8 +725,9 @@ func HandleOpenAITextCompletionStreaming(
 				created = response.Created
 			}
 
-			// Handle regular content chunks
-			if choice.TextCompletionResponseChoice != nil && choice.TextCompletionResponseChoice.Text != nil {
+			// Handle regular content chunks. With n > 1 every chunk is forwarded
+			// as is, so each choice's text and finish reason keep their index.
+			if candidates != nil || (choice.TextCompletionResponseChoice != nil && choice.TextCompletionResponseChoice.Text != nil) {
 				chunkIndex++
 
 				response.ExtraFields.ChunkIndex = chunkIndex
@@ -730,12 +742,17 @@ func HandleOpenAITextCompletionStreaming(
 			}
 
 			// For providers that don't send [DONE] marker break on finish_reason
-			if !providerUtils.ProviderSendsDoneMarker(providerName) && finishReason != nil {
+			if !providerUtils.ProviderSendsDoneMarker(providerName) && candidates.done(finishReason) {
 				break
 			}
 		}
 
-		response := providerUtils.CreateBifrostTextCompletionChunkResponse(messageID, usage, finishReason, chunkIndex, schemas.TextCompletionStreamRequest, request.Model, created)
+		finalFinishReason := finishReason
+		if candidates != nil {
+			// Each choice's finish reason was forwarded with its own chunk
+			finalFinishReason = nil
+		}
+		response := providerUtils.CreateBifrostTextCompletionChunkResponse(messageID, usage, finalFinishReason, chunkIndex, schemas.TextCompletionStreamRequest, request.Model, created)
 		if postResponseConverter != nil {
 			response = postResponseConverter(response)
 			if response == nil {
@@ -1181,6 +1198,11 @@ func HandleOpenAIChatCompletionStreaming(
 		// service_tier is echoed on chunks; propagate to the final chunk for priority/flex billing
 		var serviceTier *schemas.BifrostServiceTier
 		forwardedTerminalFinishReason := false
+		var n *int
+		if request.Params != nil {
+			n = request.Params.N
+		}
+		candidates := newStreamCandidates(n)
 		// Defer final completed/incomplete event until usage chunk arrives (fallback path only).
 		var pendingFinalEvent *schemas.BifrostResponsesStreamResponse
 		usageSeen := false
@@ -1211,6 +1233,7 @@ func HandleOpenAIChatCompletionStreaming(
 				var bifrostErr schemas.BifrostError
 				if err := sonic.UnmarshalString(jsonData, &bifrostErr); err == nil {
 					if bifrostErr.Error != nil && bifrostErr.Error.Message != "" {
+						providerUtils.SetRawError(&bifrostErr, []byte(jsonData))
 						ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 						providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, &bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 						return
@@ -1291,6 +1314,7 @@ func HandleOpenAIChatCompletionStreaming(
 							bifrostErr.Error.Code = response.Code
 						}
 
+						providerUtils.SetRawError(bifrostErr, []byte(jsonData))
 						ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 						providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 						return
@@ -1372,6 +1396,7 @@ func HandleOpenAIChatCompletionStreaming(
 					// Collect finish reason and send at the end of the stream
 					finishReason = choice.FinishReason
 				}
+				candidates.observe(response.Choices)
 
 				if response.ID != "" && messageID == "" {
 					messageID = response.ID
@@ -1380,15 +1405,17 @@ func HandleOpenAIChatCompletionStreaming(
 					created = response.Created
 				}
 
-				// Handle regular content chunks, including reasoning
-				if choice.ChatStreamResponseChoice != nil &&
+				// Handle regular content chunks, including reasoning. With n > 1
+				// every chunk is forwarded as is, so each choice's deltas and
+				// finish reason keep their index.
+				if candidates != nil || choice.ChatStreamResponseChoice != nil &&
 					choice.ChatStreamResponseChoice.Delta != nil &&
 					((choice.ChatStreamResponseChoice.Delta.Content != nil && *choice.ChatStreamResponseChoice.Delta.Content != "") ||
 						choice.ChatStreamResponseChoice.Delta.Reasoning != nil ||
 						len(choice.ChatStreamResponseChoice.Delta.ReasoningDetails) > 0 ||
 						choice.ChatStreamResponseChoice.Delta.Audio != nil ||
 						len(choice.ChatStreamResponseChoice.Delta.ToolCalls) > 0) {
-					if choice.FinishReason != nil && *choice.FinishReason != "" {
+					if candidates != nil || (choice.FinishReason != nil && *choice.FinishReason != "") {
 						forwardedTerminalFinishReason = true
 					}
 					chunkIndex++
@@ -1405,7 +1432,7 @@ func HandleOpenAIChatCompletionStreaming(
 				}
 
 				// For providers that don't send [DONE] marker break on finish_reason
-				if !providerUtils.ProviderSendsDoneMarker(providerName) && finishReason != nil {
+				if !providerUtils.ProviderSendsDoneMarker(providerName) && candidates.done(finishReason) {
 					break
 				}
 			}
@@ -1616,6 +1643,9 @@ func HandleOpenAIResponsesRequest(
 		return nil, providerUtils.EnrichError(ctx, bifrostErr, jsonData, body, sendBackRawRequest, sendBackRawResponse, latency)
 	}
 
+	// Built-in tools are billed per call but only appear as output items.
+	response.BackfillServerToolUsage()
+
 	response.ExtraFields.Latency = latency.Milliseconds()
 	response.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
 
@@ -1939,6 +1969,7 @@ func HandleOpenAIResponsesStreaming(
 					}
 				}
 
+				providerUtils.SetRawError(bifrostErr, []byte(jsonData))
 				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 				providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, bifrostErr, jsonBody, []byte(jsonData), sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 				return
@@ -1956,6 +1987,7 @@ func HandleOpenAIResponsesStreaming(
 					bifrostErr.Error.Message = response.Response.Error.Message
 					bifrostErr.Error.Code = &response.Response.Error.Code
 				}
+				providerUtils.SetRawError(bifrostErr, []byte(jsonData))
 				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 				providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, bifrostErr, jsonBody, []byte(jsonData), sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 				return
@@ -1963,6 +1995,7 @@ func HandleOpenAIResponsesStreaming(
 
 			response.ExtraFields.ChunkIndex = response.SequenceNumber
 			if response.Type == schemas.ResponsesStreamResponseTypeCompleted || response.Type == schemas.ResponsesStreamResponseTypeIncomplete {
+				response.Response.BackfillServerToolUsage()
 				// Set raw request if enabled
 				if sendBackRawRequest {
 					providerUtils.ParseAndSetRawRequest(&response.ExtraFields, jsonBody)
@@ -2507,6 +2540,7 @@ func HandleOpenAISpeechStreamRequest(
 				var bifrostErr schemas.BifrostError
 				if err := sonic.UnmarshalString(jsonData, &bifrostErr); err == nil {
 					if bifrostErr.Error != nil && bifrostErr.Error.Message != "" {
+						providerUtils.SetRawError(&bifrostErr, []byte(jsonData))
 						ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 						providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, &bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 						return
@@ -3454,6 +3488,7 @@ func HandleOpenAIImageGenerationStreaming(
 				var bifrostErr schemas.BifrostError
 				if err := sonic.UnmarshalString(jsonData, &bifrostErr); err == nil {
 					if bifrostErr.Error != nil && bifrostErr.Error.Message != "" {
+						providerUtils.SetRawError(&bifrostErr, []byte(jsonData))
 						ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 						providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, &bifrostErr, jsonBody, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 						return
@@ -4940,6 +4975,7 @@ func HandleOpenAIImageEditStreamRequest(
 				var bifrostErr schemas.BifrostError
 				if err := sonic.UnmarshalString(jsonData, &bifrostErr); err == nil {
 					if bifrostErr.Error != nil && bifrostErr.Error.Message != "" {
+						providerUtils.SetRawError(&bifrostErr, []byte(jsonData))
 						ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
 						providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, providerUtils.EnrichError(ctx, &bifrostErr, nil, nil, sendBackRawRequest, sendBackRawResponse, latency), responseChan, logger, postHookSpanFinalizer)
 						return
@@ -0,0 +1,44 @@
+package openai
+
+import (
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// streamCandidates tracks the choices of a streaming request with n > 1.
+// Their deltas arrive interleaved, each tagged with its choice index, and
+// every choice finishes on its own. A nil *streamCandidates is a single-choice
+// stream.
+type streamCandidates struct {
+	n        int
+	finished map[int]struct{}
+}
+
+// newStreamCandidates returns a tracker for n > 1, or nil for a single choice.
+func newStreamCandidates(n *int) *streamCandidates {
+	if n == nil || *n <= 1 {
+		return nil
+	}
+	return &streamCandidates{n: *n, finished: make(map[int]struct{}, *n)}
+}
+
+// observe records the choices in a chunk that carry a finish reason.
+func (c *streamCandidates) observe(choices []schemas.BifrostResponseChoice) {
+	if c == nil {
+		return
+	}
+	for _, choice := range choices {
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			c.finished[choice.Index] = struct{}{}
+		}
+	}
+}
+
+// done reports whether the stream has ended, for providers that don't send a
+// [DONE] marker. A single-choice stream ends with its finish reason; a
+// multi-choice stream once every choice has finished.
+func (c *streamCandidates) done(finishReason *string) bool {
+	if c == nil {
+		return finishReason != nil
+	}
+	return len(c.finished) >= c.n
+}
@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"encoding/base64"
 	"strings"
 
 	"github.com/maximhq/bifrost/core/providers/utils"
@@ -41,6 +42,9 @@ func ConvertBifrostMessagesToOpenAIMessages(messages []schemas.ChatMessage) []Op
 			Content:         message.Content,
 			ChatToolMessage: message.ChatToolMessage,
 		}
+		if content := normalizeInputAudio(message.Content); content != nil {
+			openaiMessages[i].Content = content
+		}
 		// Strip provider reasoning signatures (e.g. Gemini thoughtSignatures embedded in
 		// call_id as "<baseID>_ts_<sig>") from the tool result's tool_call_id, but only when it
 		// exceeds OpenAI's limit — shorter IDs are left intact so distinct upstream IDs are
@@ -87,6 +91,41 @@ func ConvertBifrostMessagesToOpenAIMessages(messages []schemas.ChatMessage) []Op
 	return openaiMessages
 }
 
+// normalizeInputAudio returns a copy of content whose input_audio blocks have
+// the shape OpenAI requires (bare base64 data and a short format such as "wav"
+// or "mp3"), or nil when no block needs changing. Clients written against other
+// providers often send data URLs, MIME types or no format at all. Blocks that
+// cannot be decoded are left for the provider to reject.
+func normalizeInputAudio(content *schemas.ChatMessageContent) *schemas.ChatMessageContent {
+	if content == nil {
+		return nil
+	}
+	var blocks []schemas.ChatContentBlock
+	for j, block := range content.ContentBlocks {
+		audio := block.InputAudio
+		if audio == nil || (audio.Format != nil && *audio.Format != "" && !strings.Contains(*audio.Format, "/") && !strings.HasPrefix(audio.Data, "data:")) {
+			continue
+		}
+		data, mimeType, err := utils.ParseInputAudio(audio)
+		if err != nil {
+			continue
+		}
+		if blocks == nil {
+			blocks = append([]schemas.ChatContentBlock(nil), content.ContentBlocks...)
+		}
+		blocks[j].InputAudio = &schemas.ChatInputAudio{
+			Data:   base64.StdEncoding.EncodeToString(data),
+			Format: schemas.Ptr(utils.AudioFormatFromMimeType(mimeType)),
+		}
+	}
+	if blocks == nil {
+		return nil
+	}
+	normalized := *content
+	normalized.ContentBlocks = blocks
+	return &normalized
+}
+
 // isOpenAIReasoningModel checks if the given model is an OpenAI reasoning model
 // that supports the reasoning.effort parameter.
 // OpenAI reasoning models include o1, o3, o4 series and GPT-5.x variants.
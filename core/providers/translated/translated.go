@@ -0,0 +1,381 @@
+// Package translated implements a provider backed by a user-registered
+// schemas.ProviderTranslator, for bespoke provider APIs that are not built in.
+package translated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// TranslatedProvider implements the Provider interface by handing payload
+// mapping to the schemas.ProviderTranslator named in its custom provider
+// config. It serves list models, text completion, chat, responses (natively
+// or through chat), embedding and rerank; everything else is unsupported.
+type TranslatedProvider struct {
+	logger               schemas.Logger                // Logger for provider operations
+	client               *fasthttp.Client              // HTTP client for unary API requests
+	streamingClient      *fasthttp.Client              // HTTP client for streaming API requests
+	networkConfig        schemas.NetworkConfig         // Network configuration including extra headers
+	customProviderConfig *schemas.CustomProviderConfig // Custom provider config naming the translator
+	sendBackRawRequest   bool                          // Whether to include raw request in BifrostResponse
+	sendBackRawResponse  bool                          // Whether to include raw response in BifrostResponse
+}
+
+// NewTranslatedProvider creates a provider served by the translator named in
+// config.CustomProviderConfig.Translator. The translator must already be
+// registered; it is looked up again on every request so a reloaded plugin's
+// translator takes effect without recreating the provider.
+func NewTranslatedProvider(config *schemas.ProviderConfig, logger schemas.Logger) (*TranslatedProvider, error) {
+	if config.CustomProviderConfig == nil || config.CustomProviderConfig.Translator == "" {
+		return nil, fmt.Errorf("translated provider requires custom_provider_config.translator")
+	}
+	if _, ok := schemas.GetProviderTranslator(config.CustomProviderConfig.Translator); !ok {
+		return nil, fmt.Errorf("provider translator %q is not registered", config.CustomProviderConfig.Translator)
+	}
+	config.CheckAndSetDefaults()
+
+	requestTimeout := time.Second * time.Duration(config.NetworkConfig.DefaultRequestTimeoutInSeconds)
+	client := &fasthttp.Client{
+		ReadTimeout:         requestTimeout,
+		WriteTimeout:        requestTimeout,
+		MaxConnsPerHost:     config.NetworkConfig.MaxConnsPerHost,
+		MaxIdleConnDuration: time.Second * time.Duration(config.NetworkConfig.KeepAliveTimeoutInSeconds),
+		MaxConnWaitTimeout:  requestTimeout,
+		MaxConnDuration:     time.Second * time.Duration(schemas.DefaultMaxConnDurationInSeconds),
+		ConnPoolStrategy:    fasthttp.FIFO,
+	}
+
+	// Configure proxy and retry policy
+	client = providerUtils.ConfigureProxy(client, config.ProxyConfig, logger)
+	client = providerUtils.ConfigureDialer(client, config.NetworkConfig.AllowPrivateNetwork)
+	client = providerUtils.ConfigureTLS(client, config.NetworkConfig, logger)
+	streamingClient := providerUtils.BuildStreamingClient(client)
+	config.NetworkConfig.BaseURL = strings.TrimRight(config.NetworkConfig.BaseURL, "/")
+
+	return &TranslatedProvider{
+		logger:               logger,
+		client:               client,
+		streamingClient:      streamingClient,
+		networkConfig:        config.NetworkConfig,
+		customProviderConfig: config.CustomProviderConfig,
+		sendBackRawRequest:   config.SendBackRawRequest,
+		sendBackRawResponse:  config.SendBackRawResponse,
+	}, nil
+}
+
+// GetProviderKey returns the custom provider's name.
+func (provider *TranslatedProvider) GetProviderKey() schemas.ModelProvider {
+	return schemas.ModelProvider(provider.customProviderConfig.CustomProviderKey)
+}
+
+// translate asks the registered translator for the upstream request. The
+// error is the translator's own, so callers can detect
+// schemas.ErrTranslatorUnsupportedRequest.
+func (provider *TranslatedProvider) translate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostRequest) (schemas.ProviderTranslator, *schemas.TranslatedRequest, error) {
+	translator, ok := schemas.GetProviderTranslator(provider.customProviderConfig.Translator)
+	if !ok {
+		return nil, nil, fmt.Errorf("provider translator %q is not registered", provider.customProviderConfig.Translator)
+	}
+	translated, err := translator.TranslateRequest(ctx, key, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if translated == nil {
+		return nil, nil, fmt.Errorf("provider translator %q returned no request", provider.customProviderConfig.Translator)
+	}
+	return translator, translated, nil
+}
+
+// translateError converts an error from translate into a Bifrost error. An
+// unsupported request keeps schemas.ErrTranslatorUnsupportedRequest as its
+// cause so callers can fall back to another request type.
+func (provider *TranslatedProvider) translateError(requestType schemas.RequestType, err error) *schemas.BifrostError {
+	if errors.Is(err, schemas.ErrTranslatorUnsupportedRequest) {
+		bifrostErr := providerUtils.NewUnsupportedOperationError(requestType, provider.GetProviderKey())
+		bifrostErr.Error.Error = err
+		return bifrostErr
+	}
+	return providerUtils.NewBifrostOperationError(schemas.ErrProviderRequestMarshal, err)
+}
+
+// buildHTTPRequest fills req from the translated request.
+func (provider *TranslatedProvider) buildHTTPRequest(ctx *schemas.BifrostContext, req *fasthttp.Request, translated *schemas.TranslatedRequest) {
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+
+	url := translated.Path
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = provider.networkConfig.BaseURL + "/" + strings.TrimLeft(url, "/")
+	}
+	req.SetRequestURI(url)
+	method := translated.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req.Header.SetMethod(method)
+	if len(translated.Body) > 0 {
+		req.Header.SetContentType("application/json")
+		req.SetBody(translated.Body)
+	}
+	for name, value := range translated.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// upstreamError maps a non-2xx response through the translator, falling back
+// to a generic provider error.
+func (provider *TranslatedProvider) upstreamError(ctx *schemas.BifrostContext, translator schemas.ProviderTranslator, statusCode int, body []byte) *schemas.BifrostError {
+	bifrostErr := translator.TranslateError(ctx, statusCode, body)
+	if bifrostErr == nil {
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			message = http.StatusText(statusCode)
+		}
+		bifrostErr = providerUtils.NewProviderAPIError(message, nil, statusCode, nil, nil)
+	}
+	if bifrostErr.StatusCode == nil {
+		bifrostErr.StatusCode = schemas.Ptr(statusCode)
+	}
+	if bifrostErr.Error == nil {
+		bifrostErr.Error = &schemas.ErrorField{Message: http.StatusText(statusCode)}
+	}
+	return bifrostErr
+}
+
+// complete sends a unary request and translates the response.
+func (provider *TranslatedProvider) complete(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostRequest) (*schemas.BifrostResponse, *schemas.BifrostError) {
+	requestType := request.RequestType
+	if err := providerUtils.CheckOperationAllowed(provider.GetProviderKey(), provider.customProviderConfig, requestType); err != nil {
+		return nil, err
+	}
+	translator, translated, err := provider.translate(ctx, key, request)
+	if err != nil {
+		return nil, provider.translateError(requestType, err)
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+	provider.buildHTTPRequest(ctx, req, translated)
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, providerUtils.EnrichError(ctx, bifrostErr, translated.Body, nil, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	// Extract provider response headers before status check so error responses also forward them
+	providerResponseHeaders := providerUtils.ExtractProviderResponseHeaders(resp)
+	ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
+
+	body, decodeErr := providerUtils.CheckAndDecodeBody(resp)
+	if decodeErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, decodeErr)
+	}
+	// Copy the body before the response is released
+	body = append([]byte(nil), body...)
+
+	if statusCode := resp.StatusCode(); statusCode < 200 || statusCode >= 300 {
+		provider.logger.Debug(fmt.Sprintf("error from %s provider: %s", provider.GetProviderKey(), string(body)))
+		return nil, providerUtils.EnrichError(ctx, provider.upstreamError(ctx, translator, statusCode, body), translated.Body, body, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	response, err := translator.TranslateResponse(ctx, requestType, body)
+	if err != nil {
+		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, err), translated.Body, body, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+	if response == nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, fmt.Errorf("provider translator %q returned no response", provider.customProviderConfig.Translator))
+	}
+
+	extraFields := response.GetExtraFields()
+	extraFields.Latency = latency.Milliseconds()
+	extraFields.ProviderResponseHeaders = providerResponseHeaders
+	if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) {
+		providerUtils.ParseAndSetRawRequest(extraFields, translated.Body)
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		extraFields.RawResponse = rawResponse(body)
+	}
+	return response, nil
+}
+
+// stream sends a streaming request and translates each event. The latest
+// chunk is held back until the next one arrives, so the final chunk can be
+// marked even when the stream ends without a translator-reported done event.
+func (provider *TranslatedProvider) stream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	requestType := request.RequestType
+	if err := providerUtils.CheckOperationAllowed(provider.GetProviderKey(), provider.customProviderConfig, requestType); err != nil {
+		return nil, err
+	}
+	translator, translated, err := provider.translate(ctx, key, request)
+	if err != nil {
+		return nil, provider.translateError(requestType, err)
+	}
+	sendBackRawRequest := provider.sendBackRawRequest
+	sendBackRawResponse := provider.sendBackRawResponse
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	resp.StreamBody = true
+	defer fasthttp.ReleaseRequest(req)
+	provider.buildHTTPRequest(ctx, req, translated)
+	if len(req.Header.Peek("Accept")) == 0 {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	startTime := time.Now()
+	doErr := providerUtils.DoStreamingRequest(ctx, provider.streamingClient, req, resp)
+	latency := time.Since(startTime)
+	if doErr != nil {
+		defer providerUtils.ReleaseStreamingResponse(ctx, resp)
+		if errors.Is(doErr, context.Canceled) {
+			return nil, providerUtils.EnrichError(ctx, &schemas.BifrostError{
+				IsBifrostError: false,
+				Error: &schemas.ErrorField{
+					Type:    schemas.Ptr(schemas.RequestCancelled),
+					Message: schemas.ErrRequestCancelled,
+					Error:   doErr,
+				},
+			}, translated.Body, nil, sendBackRawRequest, sendBackRawResponse, latency)
+		}
+		if errors.Is(doErr, fasthttp.ErrTimeout) || errors.Is(doErr, context.DeadlineExceeded) {
+			return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostTimeoutError(schemas.ErrProviderRequestTimedOut, doErr), translated.Body, nil, sendBackRawRequest, sendBackRawResponse, latency)
+		}
+		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostUpstreamConnectionError(schemas.ErrProviderDoRequest, doErr), translated.Body, nil, sendBackRawRequest, sendBackRawResponse, latency)
+	}
+
+	// Extract provider response headers before status check so error responses also forward them
+	ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerUtils.ExtractProviderResponseHeaders(resp))
+
+	if statusCode := resp.StatusCode(); statusCode < 200 || statusCode >= 300 {
+		defer providerUtils.ReleaseStreamingResponse(ctx, resp)
+		providerUtils.MaterializeStreamErrorBody(ctx, resp)
+		body, _ := providerUtils.CheckAndDecodeBody(resp)
+		return nil, providerUtils.EnrichError(ctx, provider.upstreamError(ctx, translator, statusCode, body), translated.Body, body, sendBackRawRequest, sendBackRawResponse, latency)
+	}
+
+	providerUtils.SetStreamIdleTimeoutIfEmpty(ctx, provider.networkConfig.StreamIdleTimeoutInSeconds)
+
+	responseChan := make(chan *schemas.BifrostStreamChunk, schemas.DefaultStreamBufferSize)
+
+	go func() {
+		defer providerUtils.EnsureStreamFinalizerCalled(ctx, postHookSpanFinalizer)
+		defer func() {
+			if ctx.Err() == context.Canceled {
+				providerUtils.HandleStreamCancellation(ctx, postHookRunner, responseChan, provider.logger, postHookSpanFinalizer, translated.Body)
+			} else if ctx.Err() == context.DeadlineExceeded {
+				providerUtils.HandleStreamTimeout(ctx, postHookRunner, responseChan, provider.logger, postHookSpanFinalizer, translated.Body)
+			}
+			providerUtils.CloseStream(ctx, responseChan)
+		}()
+		defer providerUtils.ReleaseStreamingResponse(ctx, resp)
+		// Decompress gzip-encoded streams transparently (no-op for non-gzip)
+		reader, releaseGzip := providerUtils.DecompressStreamBody(resp)
+		defer releaseGzip()
+
+		// Wrap reader with idle timeout to detect stalled streams.
+		reader, stopIdleTimeout := providerUtils.NewIdleTimeoutReader(reader, resp.BodyStream(), providerUtils.GetStreamIdleTimeout(ctx), ctx)
+		defer stopIdleTimeout()
+
+		// Close the raw network stream on ctx cancellation to unblock in-progress reads.
+		stopCancellation := providerUtils.SetupStreamCancellation(ctx, resp.BodyStream(), provider.logger)
+		defer stopCancellation()
+
+		sseReader := providerUtils.GetSSEDataReader(ctx, reader)
+		chunkIndex := 0
+		lastChunkTime := startTime
+		var pending *schemas.BifrostResponse
+
+		send := func(response *schemas.BifrostResponse, final bool) {
+			if final {
+				extraFields := response.GetExtraFields()
+				extraFields.Latency = time.Since(startTime).Milliseconds()
+				if providerUtils.ShouldSendBackRawRequest(ctx, sendBackRawRequest) {
+					providerUtils.ParseAndSetRawRequest(extraFields, translated.Body)
+				}
+				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+			}
+			providerUtils.ProcessAndSendResponse(ctx, postHookRunner, response, responseChan, postHookSpanFinalizer)
+		}
+
+		for {
+			// If context was cancelled/timed out, let defer handle it
+			if ctx.Err() != nil {
+				return
+			}
+			data, readErr := sseReader.ReadDataLine()
+			if readErr != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if readErr != io.EOF {
+					ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+					provider.logger.Warn("Error reading stream: %v", readErr)
+					providerUtils.ProcessAndSendError(ctx, postHookRunner, readErr, responseChan, provider.logger, postHookSpanFinalizer)
+					return
+				}
+				break
+			}
+
+			response, done, translateErr := translator.TranslateStreamEvent(ctx, requestType, data)
+			if translateErr != nil {
+				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+				providerUtils.ProcessAndSendError(ctx, postHookRunner, translateErr, responseChan, provider.logger, postHookSpanFinalizer)
+				return
+			}
+			if response != nil {
+				extraFields := response.GetExtraFields()
+				extraFields.ChunkIndex = chunkIndex
+				extraFields.Latency = time.Since(lastChunkTime).Milliseconds()
+				if providerUtils.ShouldSendBackRawResponse(ctx, sendBackRawResponse) {
+					extraFields.RawResponse = string(data)
+				}
+				lastChunkTime = time.Now()
+				chunkIndex++
+				if pending != nil {
+					send(pending, false)
+				}
+				pending = response
+			}
+			if done {
+				break
+			}
+		}
+		if pending != nil {
+			send(pending, true)
+		}
+	}()
+
+	return responseChan, nil
+}
+
+// rawResponse returns body as JSON when it is JSON, otherwise as a string.
+func rawResponse(body []byte) interface{} {
+	var raw interface{}
+	if err := sonic.Unmarshal(body, &raw); err != nil {
+		return string(body)
+	}
+	return raw
+}
+
+// missingResponse reports a translator response without the field requestType needs.
+func (provider *TranslatedProvider) missingResponse(requestType schemas.RequestType) *schemas.BifrostError {
+	return providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, fmt.Errorf("provider translator %q returned no %s response", provider.customProviderConfig.Translator, requestType))
+}
+
+// isUnsupportedByTranslator reports whether bifrostErr came from a translator
+// that does not serve the request type.
+func isUnsupportedByTranslator(bifrostErr *schemas.BifrostError) bool {
+	return bifrostErr != nil && bifrostErr.Error != nil && errors.Is(bifrostErr.Error.Error, schemas.ErrTranslatorUnsupportedRequest)
+}
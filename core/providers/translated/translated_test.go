@@ -0,0 +1,260 @@
+package translated_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/providers/translated"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// echoTranslator maps chat requests to a toy {"prompt": ...} API whose
+// responses are {"text": ...} and whose stream events are {"delta": ...}.
+// It does not serve responses requests, so those fall back to chat.
+type echoTranslator struct{}
+
+func (echoTranslator) TranslateRequest(_ *schemas.BifrostContext, key schemas.Key, req *schemas.BifrostRequest) (*schemas.TranslatedRequest, error) {
+	if req.ChatRequest == nil {
+		return nil, schemas.ErrTranslatorUnsupportedRequest
+	}
+	var prompt string
+	for _, message := range req.ChatRequest.Input {
+		if message.Content != nil && message.Content.ContentStr != nil {
+			prompt = *message.Content.ContentStr
+		}
+	}
+	body, _ := json.Marshal(map[string]any{"model": req.ChatRequest.Model, "prompt": prompt})
+	path := "/generate"
+	if req.RequestType == schemas.ChatCompletionStreamRequest {
+		path = "/generate/stream"
+	}
+	return &schemas.TranslatedRequest{
+		Path:    path,
+		Headers: map[string]string{"X-Api-Token": key.Value.GetValue()},
+		Body:    body,
+	}, nil
+}
+
+func (echoTranslator) TranslateResponse(_ *schemas.BifrostContext, requestType schemas.RequestType, body []byte) (*schemas.BifrostResponse, error) {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	return &schemas.BifrostResponse{ChatResponse: chatResponse(payload.Text, requestType)}, nil
+}
+
+func (echoTranslator) TranslateStreamEvent(_ *schemas.BifrostContext, requestType schemas.RequestType, data []byte) (*schemas.BifrostResponse, bool, error) {
+	var payload struct {
+		Delta string `json:"delta"`
+		Done  bool   `json:"done"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, false, err
+	}
+	if payload.Done {
+		return nil, true, nil
+	}
+	return &schemas.BifrostResponse{ChatResponse: chatResponse(payload.Delta, requestType)}, false, nil
+}
+
+func (echoTranslator) TranslateError(_ *schemas.BifrostContext, statusCode int, body []byte) *schemas.BifrostError {
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Reason == "" {
+		return nil
+	}
+	return &schemas.BifrostError{
+		StatusCode: schemas.Ptr(statusCode),
+		Error:      &schemas.ErrorField{Message: "upstream: " + payload.Reason},
+	}
+}
+
+func chatResponse(text string, requestType schemas.RequestType) *schemas.BifrostChatResponse {
+	return &schemas.BifrostChatResponse{
+		Model: "echo-1",
+		Choices: []schemas.BifrostResponseChoice{{
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+				Message: &schemas.ChatMessage{
+					Role:    schemas.ChatMessageRoleAssistant,
+					Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)},
+				},
+			},
+		}},
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: requestType},
+	}
+}
+
+// newTestTranslatedProvider registers echoTranslator under a test-scoped name
+// and creates a provider pointed at baseURL.
+func newTestTranslatedProvider(t *testing.T, baseURL string) *translated.TranslatedProvider {
+	t.Helper()
+	name := "echo-" + t.Name()
+	unregister, err := schemas.RegisterProviderTranslator(name, echoTranslator{})
+	if err != nil {
+		t.Fatalf("failed to register translator: %v", err)
+	}
+	t.Cleanup(unregister)
+
+	provider, err := translated.NewTranslatedProvider(&schemas.ProviderConfig{
+		NetworkConfig: schemas.NetworkConfig{
+			BaseURL:                        baseURL,
+			DefaultRequestTimeoutInSeconds: 30,
+		},
+		CustomProviderConfig: &schemas.CustomProviderConfig{
+			CustomProviderKey: "in-house",
+			Translator:        name,
+		},
+	}, bifrost.NewNoOpLogger())
+	if err != nil {
+		t.Fatalf("failed to create translated provider: %v", err)
+	}
+	return provider
+}
+
+func testChatRequest(prompt string) *schemas.BifrostChatRequest {
+	return &schemas.BifrostChatRequest{
+		Provider: "in-house",
+		Model:    "echo-1",
+		Input: []schemas.ChatMessage{{
+			Role:    schemas.ChatMessageRoleUser,
+			Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(prompt)},
+		}},
+	}
+}
+
+func TestNewTranslatedProviderRequiresRegisteredTranslator(t *testing.T) {
+	_, err := translated.NewTranslatedProvider(&schemas.ProviderConfig{
+		CustomProviderConfig: &schemas.CustomProviderConfig{CustomProviderKey: "in-house", Translator: "missing"},
+	}, bifrost.NewNoOpLogger())
+	if err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("expected unregistered translator error, got %v", err)
+	}
+}
+
+func TestTranslatedChatCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/generate" || r.Header.Get("X-Api-Token") != "secret" {
+			http.Error(w, `{"reason":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload struct {
+			Prompt string `json:"prompt"`
+		}
+		_ = json.Unmarshal(body, &payload)
+		_, _ = fmt.Fprintf(w, `{"text":"echo: %s"}`, payload.Prompt)
+	}))
+	defer server.Close()
+
+	provider := newTestTranslatedProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	key := schemas.Key{Value: *schemas.NewSecretVar("secret")}
+
+	response, bifrostErr := provider.ChatCompletion(ctx, key, testChatRequest("hello"))
+	if bifrostErr != nil {
+		t.Fatalf("ChatCompletion returned error: %s", bifrostErr.Error.Message)
+	}
+	if got := *response.Choices[0].Message.Content.ContentStr; got != "echo: hello" {
+		t.Fatalf("expected translated response text, got %q", got)
+	}
+}
+
+func TestTranslatedChatCompletionMapsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/generate" {
+			http.Error(w, `{"reason":"model overloaded"}`, http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, "plain failure", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	provider := newTestTranslatedProvider(t, server.URL)
+	key := schemas.Key{Value: *schemas.NewSecretVar("secret")}
+
+	_, bifrostErr := provider.ChatCompletion(schemas.NewBifrostContext(context.Background(), schemas.NoDeadline), key, testChatRequest("hello"))
+	if bifrostErr == nil || *bifrostErr.StatusCode != http.StatusServiceUnavailable || bifrostErr.Error.Message != "upstream: model overloaded" {
+		t.Fatalf("expected translated 503 error, got %+v", bifrostErr)
+	}
+
+	// Bodies the translator does not recognise fall back to a generic error
+	_, bifrostErr = provider.ChatCompletionStream(schemas.NewBifrostContext(context.Background(), schemas.NoDeadline), nil, nil, key, testChatRequest("hello"))
+	if bifrostErr == nil || *bifrostErr.StatusCode != http.StatusInternalServerError || bifrostErr.Error.Message != "plain failure" {
+		t.Fatalf("expected generic 500 error, got %+v", bifrostErr)
+	}
+}
+
+func TestTranslatedChatCompletionStreamMarksFinalChunk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{`{"delta":"he"}`, `{"delta":"llo"}`, `{"done":true}`} {
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestTranslatedProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	key := schemas.Key{Value: *schemas.NewSecretVar("secret")}
+	var finalFlags []bool
+	postHookRunner := func(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError) {
+		final, _ := ctx.Value(schemas.BifrostContextKeyStreamEndIndicator).(bool)
+		finalFlags = append(finalFlags, final)
+		return result, err
+	}
+
+	stream, bifrostErr := provider.ChatCompletionStream(ctx, postHookRunner, nil, key, testChatRequest("hello"))
+	if bifrostErr != nil {
+		t.Fatalf("ChatCompletionStream returned error: %s", bifrostErr.Error.Message)
+	}
+	var text strings.Builder
+	for chunk := range stream {
+		if chunk.BifrostError != nil {
+			t.Fatalf("unexpected stream error: %s", chunk.BifrostError.Error.Message)
+		}
+		text.WriteString(*chunk.BifrostChatResponse.Choices[0].Message.Content.ContentStr)
+	}
+
+	if text.String() != "hello" {
+		t.Fatalf("expected streamed text %q, got %q", "hello", text.String())
+	}
+	if len(finalFlags) != 2 || finalFlags[0] || !finalFlags[1] {
+		t.Fatalf("expected only the last chunk to be final, got %v", finalFlags)
+	}
+}
+
+func TestTranslatedResponsesFallsBackToChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"text":"from chat"}`)
+	}))
+	defer server.Close()
+
+	provider := newTestTranslatedProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	key := schemas.Key{Value: *schemas.NewSecretVar("secret")}
+
+	response, bifrostErr := provider.Responses(ctx, key, &schemas.BifrostResponsesRequest{
+		Provider: "in-house",
+		Model:    "echo-1",
+		Input: []schemas.ResponsesMessage{{
+			Role:    schemas.Ptr(schemas.ResponsesInputMessageRoleUser),
+			Content: &schemas.ResponsesMessageContent{ContentStr: schemas.Ptr("hello")},
+		}},
+	})
+	if bifrostErr != nil {
+		t.Fatalf("Responses returned error: %s", bifrostErr.Error.Message)
+	}
+	if len(response.Output) == 0 {
+		t.Fatal("expected the chat response to be converted to responses output")
+	}
+}
@@ -4,7 +4,12 @@ package utils
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
 )
 
 // PCMConfig holds the configuration for PCM audio data
@@ -134,3 +139,56 @@ func AudioFilenameFromBytes(audioData []byte) string {
 	}
 	return "audio.mp3"
 }
+
+// ParseInputAudio decodes the payload of an input_audio content block, which
+// may be bare base64 (standard or URL-safe) or a data URL, and returns the
+// audio bytes with their MIME type. The MIME type comes from the data URL,
+// then from the block's format, then from the audio headers.
+func ParseInputAudio(audio *schemas.ChatInputAudio) ([]byte, string, error) {
+	if audio == nil {
+		return nil, "", fmt.Errorf("input audio is missing")
+	}
+	payload := strings.TrimSpace(audio.Data)
+	mimeType := ""
+	if rest, ok := strings.CutPrefix(payload, "data:"); ok {
+		header, data, found := strings.Cut(rest, ",")
+		if !found || !strings.HasSuffix(header, ";base64") {
+			return nil, "", fmt.Errorf("input audio data URL must be base64 encoded")
+		}
+		mimeType = strings.TrimSuffix(header, ";base64")
+		payload = data
+	}
+	payload = strings.TrimRight(strings.NewReplacer("-", "+", "_", "/").Replace(payload), "=")
+	data, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("input audio is not valid base64: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("input audio is empty")
+	}
+	if mimeType == "" && audio.Format != nil {
+		if format := strings.ToLower(strings.TrimSpace(*audio.Format)); format != "" {
+			mimeType = format
+			if !strings.HasPrefix(format, "audio/") {
+				mimeType = "audio/" + format
+			}
+		}
+	}
+	if mimeType == "" {
+		mimeType = DetectAudioMimeType(data)
+	}
+	return data, mimeType, nil
+}
+
+// AudioFormatFromMimeType returns the short format name OpenAI expects in
+// input_audio.format (e.g. "wav", "mp3") for an audio MIME type.
+func AudioFormatFromMimeType(mimeType string) string {
+	switch format := strings.TrimPrefix(strings.ToLower(mimeType), "audio/"); format {
+	case "mpeg", "mpeg3", "x-mpeg-3", "mp3":
+		return "mp3"
+	case "wave", "x-wav", "vnd.wave", "wav":
+		return "wav"
+	default:
+		return format
+	}
+}
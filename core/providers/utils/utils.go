@@ -1557,7 +1557,7 @@ func HandleProviderAPIError(resp *fasthttp.Response, errorResp any) *schemas.Bif
 	// Try JSON parsing first
 	if err := sonic.Unmarshal(decodedBody, errorResp); err == nil {
 		// JSON parsing succeeded, return success
-		return &schemas.BifrostError{
+		bifrostErr := &schemas.BifrostError{
 			IsBifrostError: false,
 			StatusCode:     &statusCode,
 			Error:          &schemas.ErrorField{},
@@ -1565,6 +1565,8 @@ func HandleProviderAPIError(resp *fasthttp.Response, errorResp any) *schemas.Bif
 				RawResponse: rawErrorResponse,
 			},
 		}
+		SetRawError(bifrostErr, decodedBody)
+		return bifrostErr
 	}
 
 	// JSON parsing failed - now check if it's an HTML response (expensive operation)
@@ -1596,6 +1598,16 @@ func HandleProviderAPIError(resp *fasthttp.Response, errorResp any) *schemas.Bif
 	}
 }
 
+// SetRawError keeps a provider error body or stream error event on the error as
+// ExtraFields.RawError. Bodies that are not JSON, and errors that already carry
+// one, are left alone.
+func SetRawError(bifrostErr *schemas.BifrostError, body []byte) {
+	if bifrostErr == nil || len(bifrostErr.ExtraFields.RawError) > 0 || !json.Valid(body) {
+		return
+	}
+	bifrostErr.ExtraFields.RawError = compactRawJSON(body)
+}
+
 // EnrichError attaches the raw request and response to a BifrostError.
 // Returns the request and response from provider embedded in BifrostError.ExtraFields.
 func EnrichError(
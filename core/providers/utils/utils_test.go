@@ -147,6 +147,34 @@ func TestHandleProviderAPIError_RawResponseIncluded(t *testing.T) {
 	}
 }
 
+// TestHandleProviderAPIError_RawErrorSurvivesEnrichError verifies that the provider's JSON
+// error body stays on RawError when raw responses are not sent back.
+func TestHandleProviderAPIError_RawErrorSurvivesEnrichError(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp := &fasthttp.Response{}
+	resp.SetStatusCode(400)
+	resp.Header.Set("Content-Type", "application/json")
+	resp.SetBody([]byte(`{"error": {"message": "Unknown parameter", "type": "invalid_request_error", "param": "temprature"}}`))
+
+	var errorResp map[string]interface{}
+	bifrostErr := EnrichError(ctx, HandleProviderAPIError(resp, &errorResp), nil, nil, false, false)
+
+	if bifrostErr.ExtraFields.RawResponse != nil {
+		t.Errorf("expected RawResponse to be dropped, got %v", bifrostErr.ExtraFields.RawResponse)
+	}
+	want := `{"error":{"message":"Unknown parameter","type":"invalid_request_error","param":"temprature"}}`
+	if got := string(bifrostErr.ExtraFields.RawError); got != want {
+		t.Errorf("RawError = %s, want %s", got, want)
+	}
+
+	// Non-JSON bodies are not kept.
+	plain := &schemas.BifrostError{}
+	SetRawError(plain, []byte("upstream connect error"))
+	if plain.ExtraFields.RawError != nil {
+		t.Errorf("expected no RawError for a non-JSON body, got %s", plain.ExtraFields.RawError)
+	}
+}
+
 // TestEnrichError_PreservesExistingRawResponse verifies that EnrichError preserves
 // existing RawResponse from the error's ExtraFields when responseBody parameter is nil
 func TestEnrichError_PreservesExistingRawResponse(t *testing.T) {
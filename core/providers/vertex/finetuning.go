@@ -0,0 +1,422 @@
+package vertex
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// VertexTuningHyperParameters mirrors SupervisedHyperParameters. EpochCount is an
+// int64, which the Vertex REST API renders as a JSON string.
+type VertexTuningHyperParameters struct {
+	EpochCount             any      `json:"epochCount,omitempty"`
+	LearningRateMultiplier *float64 `json:"learningRateMultiplier,omitempty"`
+	AdapterSize            string   `json:"adapterSize,omitempty"`
+}
+
+// VertexSupervisedTuningSpec is the supervised tuning configuration of a TuningJob.
+type VertexSupervisedTuningSpec struct {
+	TrainingDatasetURI   string                       `json:"trainingDatasetUri"`
+	ValidationDatasetURI string                       `json:"validationDatasetUri,omitempty"`
+	HyperParameters      *VertexTuningHyperParameters `json:"hyperParameters,omitempty"`
+}
+
+// VertexTunedModel references the model produced by a successful TuningJob.
+type VertexTunedModel struct {
+	Model    string `json:"model,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// VertexTuningJob is the Vertex AI TuningJob resource.
+type VertexTuningJob struct {
+	Name                  string                      `json:"name,omitempty"`
+	BaseModel             string                      `json:"baseModel,omitempty"`
+	TunedModelDisplayName string                      `json:"tunedModelDisplayName,omitempty"`
+	SupervisedTuningSpec  *VertexSupervisedTuningSpec `json:"supervisedTuningSpec,omitempty"`
+	State                 string                      `json:"state,omitempty"`
+	Error                 *VertexBatchJobError        `json:"error,omitempty"`
+	TunedModel            *VertexTunedModel           `json:"tunedModel,omitempty"`
+	Labels                map[string]string           `json:"labels,omitempty"`
+	CreateTime            string                      `json:"createTime,omitempty"` // RFC3339
+	StartTime             string                      `json:"startTime,omitempty"`  // RFC3339
+	EndTime               string                      `json:"endTime,omitempty"`    // RFC3339
+	UpdateTime            string                      `json:"updateTime,omitempty"` // RFC3339
+}
+
+// VertexTuningJobListResponse is the response of tuningJobs.list.
+type VertexTuningJobListResponse struct {
+	TuningJobs    []VertexTuningJob `json:"tuningJobs"`
+	NextPageToken string            `json:"nextPageToken,omitempty"`
+}
+
+// vertexJobStateToFineTuningStatus maps Vertex JOB_STATE_* values to Bifrost fine-tuning statuses.
+// JOB_STATE_CANCELLING is reported as running until Vertex confirms the cancellation.
+func vertexJobStateToFineTuningStatus(state string) schemas.FineTuningJobStatus {
+	switch state {
+	case "JOB_STATE_QUEUED", "JOB_STATE_PENDING":
+		return schemas.FineTuningJobStatusQueued
+	case "JOB_STATE_RUNNING", "JOB_STATE_UPDATING", "JOB_STATE_PAUSED", "JOB_STATE_CANCELLING":
+		return schemas.FineTuningJobStatusRunning
+	case "JOB_STATE_SUCCEEDED":
+		return schemas.FineTuningJobStatusSucceeded
+	case "JOB_STATE_FAILED", "JOB_STATE_EXPIRED":
+		return schemas.FineTuningJobStatusFailed
+	case "JOB_STATE_CANCELLED":
+		return schemas.FineTuningJobStatusCancelled
+	default:
+		return schemas.FineTuningJobStatus(state)
+	}
+}
+
+// vertexTuningJobURL resolves a Bifrost job ID (bare job ID or full resource name)
+// to the tuning job's REST URL.
+func vertexTuningJobURL(key schemas.Key, jobID string) (string, *schemas.BifrostError) {
+	if strings.HasPrefix(jobID, "projects/") {
+		// Full resource name: projects/{p}/locations/{r}/tuningJobs/{id}
+		parts := strings.Split(jobID, "/")
+		if len(parts) >= 6 && parts[2] == "locations" {
+			return getVertexAPIBaseURL(parts[3], "v1") + "/" + jobID, nil
+		}
+		return "", providerUtils.NewBifrostOperationError(fmt.Sprintf("invalid Vertex tuning job ID %q", jobID), nil)
+	}
+	base, cfgErr := vertexBatchJobsBaseURL(key)
+	if cfgErr != nil {
+		return "", cfgErr
+	}
+	return base + "/tuningJobs/" + jobID, nil
+}
+
+// ToVertexTuningJobRequest maps a Bifrost fine-tuning create request to a Vertex
+// TuningJob. Suffix becomes the tuned model display name and metadata becomes labels.
+func ToVertexTuningJobRequest(request *schemas.BifrostFineTuningJobCreateRequest) *VertexTuningJob {
+	job := &VertexTuningJob{
+		BaseModel: request.Model,
+		SupervisedTuningSpec: &VertexSupervisedTuningSpec{
+			TrainingDatasetURI: request.TrainingFile,
+		},
+		Labels: request.Metadata,
+	}
+	if request.ValidationFile != nil {
+		job.SupervisedTuningSpec.ValidationDatasetURI = *request.ValidationFile
+	}
+	if request.Suffix != nil {
+		job.TunedModelDisplayName = *request.Suffix
+	}
+	if hp := request.Hyperparameters; hp != nil {
+		job.SupervisedTuningSpec.HyperParameters = &VertexTuningHyperParameters{
+			LearningRateMultiplier: hp.LearningRateMultiplier,
+		}
+		if hp.NEpochs != nil {
+			job.SupervisedTuningSpec.HyperParameters.EpochCount = strconv.Itoa(*hp.NEpochs)
+		}
+	}
+	if adapterSize, ok := request.ExtraParams["adapter_size"].(string); ok {
+		if job.SupervisedTuningSpec.HyperParameters == nil {
+			job.SupervisedTuningSpec.HyperParameters = &VertexTuningHyperParameters{}
+		}
+		job.SupervisedTuningSpec.HyperParameters.AdapterSize = adapterSize
+	}
+	return job
+}
+
+// vertexTuningJobToBifrost maps a TuningJob resource to the Bifrost job representation.
+func vertexTuningJobToBifrost(job *VertexTuningJob) schemas.FineTuningJob {
+	status := vertexJobStateToFineTuningStatus(job.State)
+	result := schemas.FineTuningJob{
+		ID:        job.Name,
+		Object:    "fine_tuning.job",
+		Model:     job.BaseModel,
+		Status:    status,
+		Metadata:  job.Labels,
+		CreatedAt: gcsParseTime(job.CreateTime),
+	}
+	if job.TunedModelDisplayName != "" {
+		result.Suffix = schemas.Ptr(job.TunedModelDisplayName)
+	}
+	if spec := job.SupervisedTuningSpec; spec != nil {
+		result.TrainingFile = spec.TrainingDatasetURI
+		if spec.ValidationDatasetURI != "" {
+			result.ValidationFile = schemas.Ptr(spec.ValidationDatasetURI)
+		}
+		if hp := spec.HyperParameters; hp != nil {
+			result.Hyperparameters = &schemas.FineTuningHyperparameters{
+				LearningRateMultiplier: hp.LearningRateMultiplier,
+			}
+			switch v := hp.EpochCount.(type) {
+			case string:
+				if n, err := strconv.Atoi(v); err == nil {
+					result.Hyperparameters.NEpochs = schemas.Ptr(n)
+				}
+			case float64:
+				result.Hyperparameters.NEpochs = schemas.Ptr(int(v))
+			}
+		}
+	}
+	if job.TunedModel != nil && job.TunedModel.Model != "" {
+		result.FineTunedModel = schemas.Ptr(job.TunedModel.Model)
+	}
+	if job.EndTime != "" && status.IsTerminal() {
+		result.FinishedAt = schemas.Ptr(gcsParseTime(job.EndTime))
+	}
+	if job.Error != nil && job.Error.Message != "" {
+		result.Error = &schemas.FineTuningJobError{
+			Code:    fmt.Sprintf("%d", job.Error.Code),
+			Message: job.Error.Message,
+		}
+	}
+	return result
+}
+
+// doTuningRequest performs a single authenticated tuning API call and returns the
+// response body. 401/403 responses evict the cached credentials for the key.
+func (provider *VertexProvider) doTuningRequest(ctx *schemas.BifrostContext, key schemas.Key, method, requestURL string, jsonData []byte, op string) ([]byte, time.Duration, *schemas.BifrostError) {
+	authHeader, authErr := gcsGetAuthHeader(key)
+	if authErr != nil {
+		return nil, 0, providerUtils.NewBifrostOperationError(authErr.Error(), nil)
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(requestURL)
+	req.Header.SetMethod(method)
+	req.Header.SetContentType("application/json")
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	req.Header.Set("Authorization", authHeader)
+	if jsonData != nil {
+		req.SetBody(jsonData)
+	}
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, latency, providerUtils.EnrichError(ctx, bifrostErr, jsonData, nil, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	if resp.StatusCode() != fasthttp.StatusOK {
+		if resp.StatusCode() == fasthttp.StatusUnauthorized || resp.StatusCode() == fasthttp.StatusForbidden {
+			removeVertexClient(key.VertexKeyConfig.AuthCredentials.GetValue())
+		}
+		return nil, latency, providerUtils.EnrichError(ctx, parseVertexJobAPIError(resp.Body(), resp.StatusCode(), op), jsonData, resp.Body(), provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	return append([]byte(nil), resp.Body()...), latency, nil
+}
+
+// FineTuningJobCreate launches a Vertex AI supervised tuning job. TrainingFile and
+// ValidationFile must be gs:// URIs of JSONL datasets.
+func (provider *VertexProvider) FineTuningJobCreate(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostFineTuningJobCreateRequest) (*schemas.BifrostFineTuningJobCreateResponse, *schemas.BifrostError) {
+	baseURL, cfgErr := vertexBatchJobsBaseURL(key)
+	if cfgErr != nil {
+		return nil, cfgErr
+	}
+	if !strings.HasPrefix(request.TrainingFile, "gs://") {
+		return nil, providerUtils.NewBifrostOperationError("training_file must be a gs:// URI for Vertex tuning jobs", nil)
+	}
+
+	jsonData, err := providerUtils.MarshalSorted(ToVertexTuningJobRequest(request))
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderRequestMarshal, err)
+	}
+
+	sendBackRawRequest := providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest)
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	body, latency, bifrostErr := provider.doTuningRequest(ctx, key, http.MethodPost, baseURL+"/tuningJobs", jsonData, "tuning job create")
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	var created VertexTuningJob
+	rawRequest, rawResponse, parseErr := providerUtils.HandleProviderResponse(body, &created, jsonData, sendBackRawRequest, sendBackRawResponse)
+	if parseErr != nil {
+		return nil, providerUtils.EnrichError(ctx, parseErr, jsonData, body, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	result := &schemas.BifrostFineTuningJobCreateResponse{
+		FineTuningJob: vertexTuningJobToBifrost(&created),
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if sendBackRawRequest {
+		result.ExtraFields.RawRequest = rawRequest
+	}
+	if sendBackRawResponse {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// FineTuningJobList lists Vertex AI tuning jobs across all keys, paginating one key at
+// a time since tuning jobs are scoped to each key's project/region.
+func (provider *VertexProvider) FineTuningJobList(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobListRequest) (*schemas.BifrostFineTuningJobListResponse, *schemas.BifrostError) {
+	if len(keys) == 0 {
+		return nil, providerUtils.NewBifrostOperationError("no keys provided for Vertex FineTuningJobList", nil)
+	}
+
+	helper, err := providerUtils.NewSerialListHelper(keys, request.After, provider.logger, true)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError("invalid pagination cursor", err)
+	}
+
+	key, nativeCursor, ok := helper.GetCurrentKey()
+	if !ok {
+		return &schemas.BifrostFineTuningJobListResponse{
+			Object: "list",
+			Data:   []schemas.FineTuningJob{},
+		}, nil
+	}
+
+	baseURL, cfgErr := vertexBatchJobsBaseURL(key)
+	if cfgErr != nil {
+		return nil, cfgErr
+	}
+
+	params := url.Values{}
+	pageSize := request.Limit
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+	if nativeCursor != "" {
+		params.Set("pageToken", nativeCursor)
+	}
+
+	body, latency, bifrostErr := provider.doTuningRequest(ctx, key, http.MethodGet, baseURL+"/tuningJobs?"+params.Encode(), nil, "tuning job list")
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	sendBackRawResponse := providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse)
+
+	var listResp VertexTuningJobListResponse
+	_, rawResponse, parseErr := providerUtils.HandleProviderResponse(body, &listResp, nil, false, sendBackRawResponse)
+	if parseErr != nil {
+		return nil, providerUtils.EnrichError(ctx, parseErr, nil, body, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+
+	data := make([]schemas.FineTuningJob, 0, len(listResp.TuningJobs))
+	for i := range listResp.TuningJobs {
+		data = append(data, vertexTuningJobToBifrost(&listResp.TuningJobs[i]))
+	}
+
+	nextCursor, hasMore := helper.BuildNextCursor(listResp.NextPageToken != "", listResp.NextPageToken)
+
+	result := &schemas.BifrostFineTuningJobListResponse{
+		Object:  "list",
+		Data:    data,
+		HasMore: hasMore,
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			Latency: latency.Milliseconds(),
+		},
+	}
+	if nextCursor != "" {
+		result.NextCursor = &nextCursor
+	}
+	if sendBackRawResponse {
+		result.ExtraFields.RawResponse = rawResponse
+	}
+	return result, nil
+}
+
+// vertexGetTuningJob fetches a TuningJob resource with a single key.
+func (provider *VertexProvider) vertexGetTuningJob(ctx *schemas.BifrostContext, key schemas.Key, jobID string) (*VertexTuningJob, interface{}, time.Duration, *schemas.BifrostError) {
+	jobURL, cfgErr := vertexTuningJobURL(key, jobID)
+	if cfgErr != nil {
+		return nil, nil, 0, cfgErr
+	}
+
+	body, latency, bifrostErr := provider.doTuningRequest(ctx, key, http.MethodGet, jobURL, nil, "tuning job retrieve")
+	if bifrostErr != nil {
+		return nil, nil, latency, bifrostErr
+	}
+
+	var job VertexTuningJob
+	_, rawResponse, parseErr := providerUtils.HandleProviderResponse(body, &job, nil, false, providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse))
+	if parseErr != nil {
+		return nil, nil, latency, providerUtils.EnrichError(ctx, parseErr, nil, body, provider.sendBackRawRequest, provider.sendBackRawResponse, latency)
+	}
+	return &job, rawResponse, latency, nil
+}
+
+// FineTuningJobRetrieve fetches a Vertex AI tuning job by ID (bare or full resource name).
+func (provider *VertexProvider) FineTuningJobRetrieve(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobRetrieveRequest) (*schemas.BifrostFineTuningJobRetrieveResponse, *schemas.BifrostError) {
+	if len(keys) == 0 {
+		return nil, providerUtils.NewBifrostOperationError("no keys provided for Vertex FineTuningJobRetrieve", nil)
+	}
+
+	// A job ID is scoped to the project/region of the key that created it, so try each key
+	// until one resolves the job; return the last error only if all keys fail.
+	var lastErr *schemas.BifrostError
+	for _, key := range keys {
+		job, rawResponse, latency, bifrostErr := provider.vertexGetTuningJob(ctx, key, request.JobID)
+		if bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+
+		result := &schemas.BifrostFineTuningJobRetrieveResponse{
+			FineTuningJob: vertexTuningJobToBifrost(job),
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				Latency: latency.Milliseconds(),
+			},
+		}
+		if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+			result.ExtraFields.RawResponse = rawResponse
+		}
+		return result, nil
+	}
+
+	return nil, lastErr
+}
+
+// FineTuningJobCancel cancels a Vertex AI tuning job. Vertex returns an empty body for
+// :cancel, so the job is re-read to report its current state.
+func (provider *VertexProvider) FineTuningJobCancel(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostFineTuningJobCancelRequest) (*schemas.BifrostFineTuningJobCancelResponse, *schemas.BifrostError) {
+	if len(keys) == 0 {
+		return nil, providerUtils.NewBifrostOperationError("no keys provided for Vertex FineTuningJobCancel", nil)
+	}
+
+	var lastErr *schemas.BifrostError
+	for _, key := range keys {
+		jobURL, cfgErr := vertexTuningJobURL(key, request.JobID)
+		if cfgErr != nil {
+			lastErr = cfgErr
+			continue
+		}
+
+		startTime := time.Now()
+		if _, _, bifrostErr := provider.doTuningRequest(ctx, key, http.MethodPost, jobURL+":cancel", nil, "tuning job cancel"); bifrostErr != nil {
+			lastErr = bifrostErr
+			continue
+		}
+
+		job, rawResponse, _, bifrostErr := provider.vertexGetTuningJob(ctx, key, request.JobID)
+		if bifrostErr != nil {
+			return nil, bifrostErr
+		}
+
+		result := &schemas.BifrostFineTuningJobCancelResponse{
+			FineTuningJob: vertexTuningJobToBifrost(job),
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				Latency: time.Since(startTime).Milliseconds(),
+			},
+		}
+		if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+			result.ExtraFields.RawResponse = rawResponse
+		}
+		return result, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,54 @@
+package vertex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVertexTuningJobToBifrost verifies a TuningJob resource is normalized,
+// including the string-encoded int64 epoch count and the tuned model name.
+func TestVertexTuningJobToBifrost(t *testing.T) {
+	body := []byte(`{
+		"name": "projects/p/locations/us-central1/tuningJobs/123",
+		"baseModel": "gemini-2.0-flash-001",
+		"tunedModelDisplayName": "support",
+		"supervisedTuningSpec": {
+			"trainingDatasetUri": "gs://bucket/train.jsonl",
+			"hyperParameters": {"epochCount": "4", "learningRateMultiplier": 0.5}
+		},
+		"state": "JOB_STATE_SUCCEEDED",
+		"tunedModel": {"model": "projects/p/locations/us-central1/models/456"},
+		"createTime": "2025-01-01T00:00:00Z",
+		"endTime": "2025-01-01T01:00:00Z"
+	}`)
+
+	var job VertexTuningJob
+	require.NoError(t, sonic.Unmarshal(body, &job))
+	result := vertexTuningJobToBifrost(&job)
+
+	assert.Equal(t, "projects/p/locations/us-central1/tuningJobs/123", result.ID)
+	assert.Equal(t, schemas.FineTuningJobStatusSucceeded, result.Status)
+	assert.Equal(t, "gs://bucket/train.jsonl", result.TrainingFile)
+	require.NotNil(t, result.FineTunedModel)
+	assert.Equal(t, "projects/p/locations/us-central1/models/456", *result.FineTunedModel)
+	require.NotNil(t, result.Hyperparameters)
+	require.NotNil(t, result.Hyperparameters.NEpochs)
+	assert.Equal(t, 4, *result.Hyperparameters.NEpochs)
+	require.NotNil(t, result.FinishedAt)
+	assert.Equal(t, int64(1735693200), *result.FinishedAt)
+}
+
+func TestVertexTuningJobURL(t *testing.T) {
+	url, err := vertexTuningJobURL(schemas.Key{}, "projects/p/locations/europe-west4/tuningJobs/9")
+	require.Nil(t, err)
+	assert.Contains(t, url, "europe-west4")
+	assert.True(t, strings.HasSuffix(url, "/tuningJobs/9"))
+
+	_, err = vertexTuningJobURL(schemas.Key{}, "projects/p/tuningJobs/9")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,193 @@
+package bifrost
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// requestFlagSet holds the configured request flags and, per gated plugin,
+// the flags that let it run.
+type requestFlagSet struct {
+	flags       []schemas.RequestFlag
+	pluginFlags map[string][]string
+}
+
+// UpdateRequestFlags replaces the configured request flags at runtime.
+// Requests already evaluated keep their results.
+func (bifrost *Bifrost) UpdateRequestFlags(flags []schemas.RequestFlag) error {
+	set := &requestFlagSet{flags: make([]schemas.RequestFlag, 0, len(flags)), pluginFlags: make(map[string][]string)}
+	seen := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		if err := flag.Validate(); err != nil {
+			return err
+		}
+		if seen[flag.Name] {
+			return fmt.Errorf("duplicate request flag %q", flag.Name)
+		}
+		seen[flag.Name] = true
+		// Header names arrive lowercased in BifrostContextKeyRequestHeaders.
+		rules := make([]schemas.RequestFlagRule, len(flag.Rules))
+		for i, rule := range flag.Rules {
+			if len(rule.Headers) > 0 {
+				headers := make(map[string]string, len(rule.Headers))
+				for name, value := range rule.Headers {
+					headers[strings.ToLower(strings.TrimSpace(name))] = value
+				}
+				rule.Headers = headers
+			}
+			if header, ok := strings.CutPrefix(rule.BucketBy, schemas.RequestFlagBucketByHeader); ok {
+				rule.BucketBy = schemas.RequestFlagBucketByHeader + strings.ToLower(strings.TrimSpace(header))
+			}
+			rules[i] = rule
+		}
+		flag.Rules = rules
+		set.flags = append(set.flags, flag)
+		for _, plugin := range flag.Plugins {
+			plugin = strings.TrimSpace(plugin)
+			set.pluginFlags[plugin] = append(set.pluginFlags[plugin], flag.Name)
+		}
+	}
+	bifrost.requestFlags.Store(set)
+	return nil
+}
+
+// evaluateRequestFlags evaluates every request flag for req and stores the
+// results, and the plugins they leave gated off, on ctx. It runs after
+// PreRequestHooks, once governance has resolved the virtual key and routing
+// has settled the model, so every PreLLMHook and PostLLMHook sees the same
+// results, including on fallbacks.
+func (bifrost *Bifrost) evaluateRequestFlags(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) {
+	set := bifrost.requestFlags.Load()
+	if set == nil || len(set.flags) == 0 {
+		return
+	}
+	provider, model, _ := req.GetRequestFields()
+	attrs := requestFlagAttributes{
+		requestID:      GetStringFromContext(ctx, schemas.BifrostContextKeyRequestID),
+		virtualKeyID:   GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceVirtualKeyID),
+		virtualKeyName: GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceVirtualKeyName),
+		model:          model,
+		providerModel:  string(provider) + "/" + model,
+	}
+	attrs.headers, _ = ctx.Value(schemas.BifrostContextKeyRequestHeaders).(map[string]string)
+
+	results := make(map[string]bool, len(set.flags))
+	for _, flag := range set.flags {
+		results[flag.Name] = attrs.evaluate(flag)
+	}
+	ctx.SetValue(schemas.BifrostContextKeyRequestFlags, results)
+
+	var gated map[string]bool
+	for plugin, flags := range set.pluginFlags {
+		enabled := false
+		for _, name := range flags {
+			enabled = enabled || results[name]
+		}
+		if !enabled {
+			if gated == nil {
+				gated = make(map[string]bool)
+			}
+			gated[plugin] = true
+		}
+	}
+	if gated != nil {
+		ctx.SetValue(schemas.BifrostContextKeyFlagGatedPlugins, gated)
+	}
+}
+
+// isPluginFlagGated reports whether the plugin is skipped for the request of
+// ctx because no request flag gating it is on.
+func isPluginFlagGated(ctx *schemas.BifrostContext, pluginName string) bool {
+	gated, _ := ctx.Value(schemas.BifrostContextKeyFlagGatedPlugins).(map[string]bool)
+	return gated[pluginName]
+}
+
+// requestFlagAttributes are the request attributes rules match on.
+type requestFlagAttributes struct {
+	requestID      string
+	virtualKeyID   string
+	virtualKeyName string
+	model          string
+	providerModel  string
+	headers        map[string]string
+}
+
+// evaluate reports whether any rule of flag matches the request.
+func (a requestFlagAttributes) evaluate(flag schemas.RequestFlag) bool {
+	for _, rule := range flag.Rules {
+		if a.matches(rule) && a.inRollout(flag.Name, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a requestFlagAttributes) matches(rule schemas.RequestFlagRule) bool {
+	if len(rule.VirtualKeys) > 0 {
+		matched := false
+		for _, vk := range rule.VirtualKeys {
+			if vk != "" && (vk == a.virtualKeyID || vk == a.virtualKeyName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(rule.Models) > 0 {
+		matched := false
+		for _, pattern := range rule.Models {
+			if matchModelPattern(a.model, pattern) || matchModelPattern(a.providerModel, pattern) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for name, want := range rule.Headers {
+		value, ok := a.headers[name]
+		if !ok || (want != "*" && value != want) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRollout reports whether the request falls in the rule's percentage. The
+// bucket is a stable hash of the flag name and the bucketing key, so a key
+// stays in or out of a rollout as long as its percentage does not shrink.
+func (a requestFlagAttributes) inRollout(flagName string, rule schemas.RequestFlagRule) bool {
+	if rule.Percentage == nil {
+		return true
+	}
+	key := a.requestID
+	switch {
+	case rule.BucketBy == schemas.RequestFlagBucketByVirtualKey:
+		if a.virtualKeyID != "" {
+			key = a.virtualKeyID
+		}
+	case strings.HasPrefix(rule.BucketBy, schemas.RequestFlagBucketByHeader):
+		if value := a.headers[strings.TrimPrefix(rule.BucketBy, schemas.RequestFlagBucketByHeader)]; value != "" {
+			key = value
+		}
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(flagName))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+	return float64(hash.Sum64()%10000) < *rule.Percentage*100
+}
+
+// matchModelPattern matches a model exactly or, with a trailing "*", by prefix.
+func matchModelPattern(model, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(model, prefix)
+	}
+	return model == pattern
+}
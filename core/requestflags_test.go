@@ -0,0 +1,150 @@
+package bifrost
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// countingPlugin counts how often its LLM hooks run.
+type countingPlugin struct {
+	name      string
+	pre, post int
+}
+
+func (c *countingPlugin) GetName() string { return c.name }
+func (c *countingPlugin) Cleanup() error  { return nil }
+func (c *countingPlugin) PreRequestHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) error {
+	return nil
+}
+func (c *countingPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	c.pre++
+	return req, nil, nil
+}
+func (c *countingPlugin) PostLLMHook(ctx *schemas.BifrostContext, resp *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	c.post++
+	return resp, bifrostErr, nil
+}
+
+func flagContext(requestID, vkID string, headers map[string]string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(time.Minute))
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, requestID)
+	if vkID != "" {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, vkID)
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyName, vkID+"-name")
+	}
+	ctx.SetValue(schemas.BifrostContextKeyRequestHeaders, headers)
+	return ctx
+}
+
+func flagRequest(provider schemas.ModelProvider, model string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{RequestType: schemas.ChatCompletionRequest, ChatRequest: &schemas.BifrostChatRequest{Provider: provider, Model: model}}
+}
+
+func TestRequestFlagRules(t *testing.T) {
+	bifrost := &Bifrost{}
+	err := bifrost.UpdateRequestFlags([]schemas.RequestFlag{
+		{Name: "tenant-x", Rules: []schemas.RequestFlagRule{{VirtualKeys: []string{"vk-x-name"}}}},
+		{Name: "claude", Rules: []schemas.RequestFlagRule{{Models: []string{"anthropic/claude-*"}}}},
+		{Name: "beta", Rules: []schemas.RequestFlagRule{{Headers: map[string]string{"X-Beta": "1"}}, {Headers: map[string]string{"x-canary": "*"}}}},
+		{Name: "never", Rules: []schemas.RequestFlagRule{{Percentage: schemas.Ptr(0.0)}}},
+		{Name: "empty"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := flagContext("req-1", "vk-x", map[string]string{"x-beta": "1"})
+	bifrost.evaluateRequestFlags(ctx, flagRequest(schemas.Anthropic, "claude-sonnet-4"))
+	want := map[string]bool{"tenant-x": true, "claude": true, "beta": true, "never": false, "empty": false}
+	for name, enabled := range want {
+		if schemas.RequestFlagEnabled(ctx, name) != enabled {
+			t.Errorf("flag %s: got %v, want %v", name, !enabled, enabled)
+		}
+	}
+
+	ctx = flagContext("req-2", "vk-y", map[string]string{"x-canary": "yes", "x-beta": "2"})
+	bifrost.evaluateRequestFlags(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+	if schemas.RequestFlagEnabled(ctx, "tenant-x") || schemas.RequestFlagEnabled(ctx, "claude") || !schemas.RequestFlagEnabled(ctx, "beta") {
+		t.Errorf("unexpected results %v", ctx.Value(schemas.BifrostContextKeyRequestFlags))
+	}
+}
+
+func TestRequestFlagPercentageRollout(t *testing.T) {
+	bifrost := &Bifrost{}
+	err := bifrost.UpdateRequestFlags([]schemas.RequestFlag{
+		{Name: "per-request", Rules: []schemas.RequestFlagRule{{Percentage: schemas.Ptr(10.0)}}},
+		{Name: "per-user", Rules: []schemas.RequestFlagRule{{Percentage: schemas.Ptr(50.0), BucketBy: "header:X-User-ID"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	on := 0
+	for i := range 2000 {
+		ctx := flagContext(fmt.Sprintf("req-%d", i), "", nil)
+		bifrost.evaluateRequestFlags(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+		if schemas.RequestFlagEnabled(ctx, "per-request") {
+			on++
+		}
+	}
+	if on < 140 || on > 260 {
+		t.Errorf("expected about 10%% of requests in the rollout, got %d of 2000", on)
+	}
+
+	// Bucketing by a header keeps each user on one side of the rollout.
+	for user := range 20 {
+		var first bool
+		for i := range 5 {
+			ctx := flagContext(fmt.Sprintf("req-%d-%d", user, i), "", map[string]string{"x-user-id": fmt.Sprintf("user-%d", user)})
+			bifrost.evaluateRequestFlags(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+			enabled := schemas.RequestFlagEnabled(ctx, "per-user")
+			if i == 0 {
+				first = enabled
+			} else if enabled != first {
+				t.Fatalf("user-%d flipped between requests", user)
+			}
+		}
+	}
+}
+
+func TestRequestFlagsGatePlugins(t *testing.T) {
+	bifrost := &Bifrost{}
+	err := bifrost.UpdateRequestFlags([]schemas.RequestFlag{
+		{Name: "new-guardrail", Rules: []schemas.RequestFlagRule{{VirtualKeys: []string{"vk-x"}}}, Plugins: []string{"guardrail"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guardrail := &countingPlugin{name: "guardrail"}
+	logging := &countingPlugin{name: "logging"}
+	pipeline := newRoutingCommitPipeline(logging, guardrail)
+
+	for _, vk := range []string{"vk-x", "vk-y"} {
+		ctx := flagContext("req-"+vk, vk, nil)
+		bifrost.evaluateRequestFlags(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+		_, _, ran := pipeline.RunLLMPreHooks(ctx, flagRequest(schemas.OpenAI, "gpt-4o"))
+		pipeline.RunPostLLMHooks(ctx, &schemas.BifrostResponse{}, nil, ran)
+	}
+	if logging.pre != 2 || logging.post != 2 {
+		t.Errorf("ungated plugin should run for every request, ran pre %d post %d", logging.pre, logging.post)
+	}
+	if guardrail.pre != 1 || guardrail.post != 1 {
+		t.Errorf("gated plugin should run only where its flag is on, ran pre %d post %d", guardrail.pre, guardrail.post)
+	}
+}
+
+func TestUpdateRequestFlagsRejectsInvalidFlags(t *testing.T) {
+	for name, flags := range map[string][]schemas.RequestFlag{
+		"duplicate":      {{Name: "a"}, {Name: "a"}},
+		"bad name":       {{Name: "a b"}},
+		"bad percentage": {{Name: "a", Rules: []schemas.RequestFlagRule{{Percentage: schemas.Ptr(120.0)}}}},
+		"bad bucket":     {{Name: "a", Rules: []schemas.RequestFlagRule{{BucketBy: "team"}}}},
+	} {
+		if err := (&Bifrost{}).UpdateRequestFlags(flags); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package bifrost
+
+import (
+	"sync"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// retryBudget tracks first attempts and retries in one-second buckets over a
+// sliding window and grants a retry only while retries stay within the
+// configured share of first attempts (or the per-second floor). A nil
+// *retryBudget allows every retry.
+type retryBudget struct {
+	mu         sync.Mutex
+	ratio      float64
+	minRetries float64 // floor for the whole window
+	buckets    []retryBudgetBucket
+	now        func() time.Time
+}
+
+type retryBudgetBucket struct {
+	second   int64 // unix second the counts belong to
+	requests int64
+	retries  int64
+}
+
+// newRetryBudget returns a budget for config, or nil when config is nil.
+func newRetryBudget(config *schemas.RetryBudgetConfig) *retryBudget {
+	if config == nil {
+		return nil
+	}
+	window := config.WindowSeconds
+	if window <= 0 {
+		window = schemas.DefaultRetryBudgetWindowSeconds
+	}
+	return &retryBudget{
+		ratio:      config.RetryRatio,
+		minRetries: float64(config.MinRetriesPerSecond * window),
+		buckets:    make([]retryBudgetBucket, window),
+		now:        time.Now,
+	}
+}
+
+// recordRequest counts a first attempt towards the budget.
+func (b *retryBudget) recordRequest() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket(b.now().Unix()).requests++
+}
+
+// allowRetry reports whether one more retry fits in the budget and, if so,
+// spends it.
+func (b *retryBudget) allowRetry() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	second := b.now().Unix()
+	var requests, retries int64
+	for i := range b.buckets {
+		if second-b.buckets[i].second < int64(len(b.buckets)) {
+			requests += b.buckets[i].requests
+			retries += b.buckets[i].retries
+		}
+	}
+	if float64(retries+1) > max(b.minRetries, b.ratio*float64(requests)) {
+		return false
+	}
+	b.bucket(second).retries++
+	return true
+}
+
+// bucket returns the bucket for second, resetting it if it last held an
+// older second. Callers must hold b.mu.
+func (b *retryBudget) bucket(second int64) *retryBudgetBucket {
+	bucket := &b.buckets[second%int64(len(b.buckets))]
+	if bucket.second != second {
+		*bucket = retryBudgetBucket{second: second}
+	}
+	return bucket
+}
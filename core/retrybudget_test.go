@@ -0,0 +1,162 @@
+package bifrost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryBudget(config schemas.RetryBudgetConfig, now *time.Time) *retryBudget {
+	budget := newRetryBudget(&config)
+	budget.now = func() time.Time { return *now }
+	return budget
+}
+
+func TestRetryBudget_CapsRetriesToRatioOfRequests(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	budget := newTestRetryBudget(schemas.RetryBudgetConfig{RetryRatio: 0.5, WindowSeconds: 10}, &now)
+
+	for range 4 {
+		budget.recordRequest()
+	}
+	assert.True(t, budget.allowRetry())
+	assert.True(t, budget.allowRetry())
+	assert.False(t, budget.allowRetry(), "4 requests at ratio 0.5 leave room for 2 retries")
+
+	// Requests and retries age out of the window together.
+	now = now.Add(10 * time.Second)
+	assert.False(t, budget.allowRetry(), "no recent requests, no floor")
+	budget.recordRequest()
+	budget.recordRequest()
+	assert.True(t, budget.allowRetry())
+}
+
+func TestRetryBudget_FloorAllowsRetriesWithoutTraffic(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	budget := newTestRetryBudget(schemas.RetryBudgetConfig{MinRetriesPerSecond: 1, WindowSeconds: 2}, &now)
+
+	assert.True(t, budget.allowRetry())
+	assert.True(t, budget.allowRetry())
+	assert.False(t, budget.allowRetry())
+
+	now = now.Add(time.Second)
+	assert.False(t, budget.allowRetry(), "both retries are still inside the window")
+	now = now.Add(time.Second)
+	assert.True(t, budget.allowRetry())
+}
+
+func TestRetryBudget_NilAllowsEverything(t *testing.T) {
+	var budget *retryBudget
+	budget.recordRequest()
+	assert.True(t, budget.allowRetry())
+	assert.Nil(t, newRetryBudget(nil))
+}
+
+// retryPolicyContext returns a context with a tracer and, if headers is
+// non-nil, the provider response headers a failed attempt would have left.
+func retryPolicyContext(headers map[string]string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyTracer, &schemas.NoOpTracer{})
+	if headers != nil {
+		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, headers)
+	}
+	return ctx
+}
+
+// routingLogMessages returns the messages of the routing engine log entries on ctx.
+func routingLogMessages(ctx *schemas.BifrostContext) []string {
+	var messages []string
+	for _, entry := range ctx.GetRoutingEngineLogs() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// countCallsFailingWith runs executeRequestWithRetries with a handler that
+// always fails with status and returns how many attempts were made.
+func countCallsFailingWith(t *testing.T, ctx *schemas.BifrostContext, config *schemas.ProviderConfig, budget *retryBudget, status int) int {
+	t.Helper()
+	calls := 0
+	handler := func(_ schemas.Key) (string, *schemas.BifrostError) {
+		calls++
+		return "", createBifrostError("upstream failure", Ptr(status), nil, false)
+	}
+	_, err := executeRequestWithRetries(ctx, config, budget, handler, nil,
+		schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4", nil, NewDefaultLogger(schemas.LogLevelError))
+	require.NotNil(t, err)
+	return calls
+}
+
+func TestExecuteRequestWithRetries_RetryOnStatus(t *testing.T) {
+	config := createTestConfig(2, time.Millisecond, 2*time.Millisecond)
+	config.NetworkConfig.RetryOnStatus = []string{"5xx", "408"}
+
+	assert.Equal(t, 3, countCallsFailingWith(t, retryPolicyContext(nil), config, nil, 500))
+	assert.Equal(t, 3, countCallsFailingWith(t, retryPolicyContext(nil), config, nil, 408))
+	assert.Equal(t, 1, countCallsFailingWith(t, retryPolicyContext(nil), config, nil, 429), "429 is not listed, so it is not retried")
+}
+
+func TestExecuteRequestWithRetries_RespectRetryAfter(t *testing.T) {
+	config := createTestConfig(2, time.Millisecond, 100*time.Millisecond)
+	config.NetworkConfig.RespectRetryAfter = true
+
+	ctx := retryPolicyContext(map[string]string{"Retry-After": "30"})
+	assert.Equal(t, 1, countCallsFailingWith(t, ctx, config, nil, 429), "a wait beyond retry_backoff_max ends retries")
+	assert.Contains(t, routingLogMessages(ctx), "Not retrying openai/gpt-4: provider asked to wait 30s, longer than retry_backoff_max 100ms")
+
+	start := time.Now()
+	ctx = retryPolicyContext(map[string]string{"retry-after-ms": "40"})
+	assert.Equal(t, 3, countCallsFailingWith(t, ctx, config, nil, 429))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond, "each retry waits at least the requested 40ms")
+}
+
+func TestExecuteRequestWithRetries_RetryBudgetExhausted(t *testing.T) {
+	config := createTestConfig(3, time.Millisecond, 2*time.Millisecond)
+	now := time.Now()
+	budget := newTestRetryBudget(schemas.RetryBudgetConfig{MinRetriesPerSecond: 1, WindowSeconds: 1}, &now)
+
+	ctx := retryPolicyContext(nil)
+	assert.Equal(t, 2, countCallsFailingWith(t, ctx, config, budget, 503), "the budget grants a single retry")
+	assert.Contains(t, routingLogMessages(ctx), "Not retrying openai/gpt-4: global retry budget exhausted")
+}
+
+func TestCalculateBackoff_ConfiguredJitter(t *testing.T) {
+	config := createTestConfig(3, 100*time.Millisecond, time.Second)
+	config.NetworkConfig.RetryJitter = Ptr(0.0)
+	for range 20 {
+		assert.Equal(t, 200*time.Millisecond, calculateBackoff(1, config))
+	}
+
+	config.NetworkConfig.RetryJitter = Ptr(0.5)
+	for range 100 {
+		backoff := calculateBackoff(1, config)
+		assert.GreaterOrEqual(t, backoff, 100*time.Millisecond)
+		assert.LessOrEqual(t, backoff, 300*time.Millisecond)
+	}
+}
+
+func TestRetryAfterFromContext(t *testing.T) {
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+		ok      bool
+	}{
+		{"seconds", map[string]string{"Retry-After": "2"}, 2 * time.Second, true},
+		{"milliseconds preferred", map[string]string{"Retry-After": "2", "Retry-After-Ms": "250"}, 250 * time.Millisecond, true},
+		{"past date", map[string]string{"Retry-After": "Wed, 21 Oct 2015 07:28:00 GMT"}, 0, true},
+		{"garbage", map[string]string{"Retry-After": "soon"}, 0, false},
+		{"missing", nil, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := retryAfterFromContext(retryPolicyContext(tc.headers))
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package bifrost
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+const (
+	// drainWindowSeconds is how far back a provider queue's drain rate is measured.
+	drainWindowSeconds = 10
+	// maxRetryAfterSeconds caps the Retry-After of a shed request, and is used
+	// as is when the queue has not drained at all within the window.
+	maxRetryAfterSeconds = 60
+)
+
+// drainMeter counts requests taken off a provider queue, and how long they
+// waited, in one-second buckets so the queue's recent drain rate and wait can
+// be estimated.
+type drainMeter struct {
+	mu      sync.Mutex
+	seconds [drainWindowSeconds]int64 // unix second each bucket counts
+	counts  [drainWindowSeconds]int64
+	waits   [drainWindowSeconds]time.Duration
+}
+
+// mark records one request taken off the queue after waiting in it for wait.
+func (m *drainMeter) mark(now time.Time, wait time.Duration) {
+	second := now.Unix()
+	i := second % drainWindowSeconds
+	m.mu.Lock()
+	if m.seconds[i] != second {
+		m.seconds[i], m.counts[i], m.waits[i] = second, 0, 0
+	}
+	m.counts[i]++
+	m.waits[i] += wait
+	m.mu.Unlock()
+}
+
+// rate returns the requests per second taken off the queue over the window
+// and their average wait in milliseconds.
+func (m *drainMeter) rate(now time.Time) (perSecond float64, avgWaitMs float64) {
+	second := now.Unix()
+	var total int64
+	var waited time.Duration
+	m.mu.Lock()
+	for i := range m.seconds {
+		if second-m.seconds[i] < drainWindowSeconds {
+			total += m.counts[i]
+			waited += m.waits[i]
+		}
+	}
+	m.mu.Unlock()
+	if total == 0 {
+		return 0, 0
+	}
+	return float64(total) / drainWindowSeconds, float64(waited.Microseconds()) / 1000 / float64(total)
+}
+
+// retryAfterSeconds estimates how long until a queue of the given depth has
+// room again at the given drain rate, between 1 and maxRetryAfterSeconds.
+func retryAfterSeconds(depth int, rate float64) int {
+	if rate <= 0 {
+		return maxRetryAfterSeconds
+	}
+	seconds := int(math.Ceil(float64(depth+1) / rate))
+	return min(max(seconds, 1), maxRetryAfterSeconds)
+}
+
+// shedCountKey identifies a shed counter.
+type shedCountKey struct {
+	provider schemas.ModelProvider
+	reason   schemas.ShedReason
+}
+
+// shedRequest counts a request shed from a saturated lane of pq and returns
+// the gateway-origin 429 for it.
+func (bifrost *Bifrost) shedRequest(pq *ProviderQueue, lane chan *ChannelMessage, provider schemas.ModelProvider, reason schemas.ShedReason) *schemas.BifrostError {
+	counter, _ := bifrost.shedCounts.LoadOrStore(shedCountKey{provider: provider, reason: reason}, new(atomic.Int64))
+	counter.(*atomic.Int64).Add(1)
+
+	depth := len(lane)
+	drainRate, _ := pq.drained.rate(time.Now())
+	bifrostErr := newBifrostSaturationError(&schemas.GatewaySaturation{
+		Reason:            reason,
+		RetryAfterSeconds: retryAfterSeconds(depth, drainRate),
+		QueueDepth:        depth,
+	})
+	if reason == schemas.ShedReasonQueueWaitTimeout {
+		// The caller's deadline has passed; a fallback would not have time to run.
+		bifrostErr.AllowFallbacks = new(false)
+	}
+	return bifrostErr
+}
+
+// shedCountsFor returns how many requests were shed for provider, by reason.
+func (bifrost *Bifrost) shedCountsFor(provider schemas.ModelProvider) map[schemas.ShedReason]int64 {
+	var counts map[schemas.ShedReason]int64
+	bifrost.shedCounts.Range(func(key, value any) bool {
+		if k := key.(shedCountKey); k.provider == provider {
+			if counts == nil {
+				counts = make(map[schemas.ShedReason]int64)
+			}
+			counts[k.reason] = value.(*atomic.Int64).Load()
+		}
+		return true
+	})
+	return counts
+}
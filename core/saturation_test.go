@@ -0,0 +1,121 @@
+package bifrost
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+func TestSaturatedQueueShedsWithRetryAfter(t *testing.T) {
+	served := make(chan struct{}, 4)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`)
+	}))
+	defer server.Close()
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, server.URL)
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "openai-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	defer client.Shutdown()
+	defer close(release)
+
+	send := func(timeout time.Duration) *schemas.BifrostError {
+		ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(timeout))
+		_, bifrostErr := client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o",
+			Input:    []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}}},
+		})
+		return bifrostErr
+	}
+
+	// Occupy the only worker and fill the one-slot queue behind it.
+	go send(10 * time.Second)
+	<-served
+	go send(10 * time.Second)
+	deadline := time.Now().Add(5 * time.Second)
+	for client.GetProviderQueueStats()[schemas.OpenAI].Depth != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the queue to fill")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := client.GetInFlightRequests(); n != 2 {
+		t.Fatalf("expected the served and the queued request in flight, got %d", n)
+	}
+
+	// Waiting for space past the deadline is shed, and fallbacks are not tried.
+	bifrostErr := send(50 * time.Millisecond)
+	if bifrostErr == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != 429 {
+		t.Fatalf("expected a 429, got %+v", bifrostErr)
+	}
+	if s := bifrostErr.ExtraFields.Saturation; s == nil || s.Reason != schemas.ShedReasonQueueWaitTimeout || s.QueueDepth != 1 {
+		t.Fatalf("expected a queue wait timeout, got %+v", s)
+	}
+	if bifrostErr.AllowFallbacks == nil || *bifrostErr.AllowFallbacks {
+		t.Fatal("a request past its deadline should not fall back")
+	}
+
+	// With drop_excess_requests on, a full queue is shed at once. One request
+	// drained in the last 10s, so two more need about 20s.
+	client.dropExcessRequests.Store(true)
+	bifrostErr = send(10 * time.Second)
+	if bifrostErr == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != 429 || !bifrostErr.IsBifrostError {
+		t.Fatalf("expected a gateway 429, got %+v", bifrostErr)
+	}
+	if s := bifrostErr.ExtraFields.Saturation; s == nil || s.Reason != schemas.ShedReasonQueueFull || s.RetryAfterSeconds != 20 {
+		t.Fatalf("expected a full queue with a 20s retry, got %+v", s)
+	}
+
+	shed := client.GetProviderQueueStats()[schemas.OpenAI].Shed
+	if shed[schemas.ShedReasonQueueFull] != 1 || shed[schemas.ShedReasonQueueWaitTimeout] != 1 {
+		t.Fatalf("unexpected shed counts: %v", shed)
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	for _, tc := range []struct {
+		depth int
+		rate  float64
+		want  int
+	}{
+		{depth: 9, rate: 5, want: 2},
+		{depth: 0, rate: 100, want: 1},
+		{depth: 1000, rate: 1, want: maxRetryAfterSeconds},
+		{depth: 3, rate: 0, want: maxRetryAfterSeconds},
+	} {
+		if got := retryAfterSeconds(tc.depth, tc.rate); got != tc.want {
+			t.Errorf("retryAfterSeconds(%d, %v) = %d, want %d", tc.depth, tc.rate, got, tc.want)
+		}
+	}
+
+	var m drainMeter
+	now := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		m.mark(now.Add(time.Duration(i)*time.Second/2), time.Duration(i%2)*100*time.Millisecond)
+	}
+	if rate, wait := m.rate(now.Add(9 * time.Second)); rate != 2 || wait != 50 {
+		t.Fatalf("expected 2 requests per second waiting 50ms, got %v, %vms", rate, wait)
+	}
+	if rate, _ := m.rate(now.Add(time.Minute)); rate != 0 {
+		t.Fatalf("expected old buckets to expire, got %v", rate)
+	}
+}
@@ -142,9 +142,11 @@ type Key struct {
 	Enabled                *bool                   `json:"enabled,omitempty"`                   // Whether the key is active (default:true)
 	UseForBatchAPI         *bool                   `json:"use_for_batch_api,omitempty"`         // Whether this key can be used for batch API operations (default:false for new keys, migrated keys default to true)
 	UseAnthropicEndpoints  *bool                   `json:"use_anthropic_endpoints,omitempty"`   // Whether to use anthropic endpoints for this key
+	BYOK                   bool                    `json:"byok,omitempty"`                      // Tenant-owned key: only used when a request names it, and its spend is attributed to the tenant
 	ConfigHash             string                  `json:"config_hash,omitempty"`               // Hash of config.json version, used for change detection
 	Status                 KeyStatusType           `json:"status,omitempty"`                    // Status of key
 	Description            string                  `json:"description,omitempty"`               // Description of key
+	BetaFeatures           *KeyBetaFeatures        `json:"beta_features,omitempty"`             // Beta flags this key allows and injects by default
 }
 
 // ModelFamily is a typed enum identifying the underlying model family of an alias target.
@@ -578,6 +580,9 @@ func BuildRoutingInfo(ctx *BifrostContext, attemptProvider ModelProvider, attemp
 		}
 		info.ResolvedKeyAlias = rka
 	}
+	if ctx != nil {
+		info.ModelGroup, _ = ctx.Value(BifrostContextKeyModelGroup).(string)
+	}
 	return info
 }
 
@@ -639,11 +644,15 @@ func (ka *KeyAliases) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// AzureAuthType selects how an Azure key authenticates. When empty, the mode is inferred:
+// client credentials when client ID/secret/tenant are all set, the API key when a value is
+// set, and DefaultAzureCredential otherwise.
 type AzureAuthType string
 
 const (
-	AzureAuthTypeClientSecret    AzureAuthType = "client_secret"
-	AzureAuthTypeManagedIdentity AzureAuthType = "managed_identity"
+	AzureAuthTypeAPIKey          AzureAuthType = "api_key"          // static api-key header (Value)
+	AzureAuthTypeClientSecret    AzureAuthType = "client_secret"    // Entra ID app registration (client ID, secret, tenant)
+	AzureAuthTypeManagedIdentity AzureAuthType = "managed_identity" // Entra ID managed identity; ClientID selects a user-assigned identity
 )
 
 // AzureKeyConfig represents the Azure-specific configuration.
@@ -655,6 +664,8 @@ type AzureKeyConfig struct {
 	ClientSecret *SecretVar `json:"client_secret,omitempty"` // Azure client secret for authentication
 	TenantID     *SecretVar `json:"tenant_id,omitempty"`     // Azure tenant ID for authentication
 	Scopes       []string   `json:"scopes,omitempty"`
+
+	AuthType AzureAuthType `json:"auth_type,omitempty"` // Explicit authentication mode; inferred when empty
 }
 
 // VertexKeyConfig represents the Vertex-specific configuration.
@@ -0,0 +1,180 @@
+package schemas
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Beta feature headers understood by KeyBetaFeatures. Any header name may be configured;
+// these are the ones providers currently use to gate preview capabilities.
+const (
+	BetaHeaderAnthropic = "anthropic-beta"
+	BetaHeaderOpenAI    = "openai-beta"
+)
+
+// KeyBetaFeatures controls which provider beta flags may be used with a key.
+//
+// Both maps are keyed by beta header name (e.g. "anthropic-beta", "openai-beta"; matched
+// case-insensitively) and hold individual flags. Allowlist entries are exact flags or
+// prefixes ending in "*" (e.g. "computer-use-*"). A header that has no Allowlist entry is
+// unrestricted; an empty list blocks every flag for that header.
+//
+// Defaults are injected into every request served by the key, alongside the flags the
+// caller requested. Requesting a flag that isn't allowed fails the request instead of
+// forwarding it.
+type KeyBetaFeatures struct {
+	Allowlist map[string][]string `json:"allowlist,omitempty"`
+	Defaults  map[string][]string `json:"defaults,omitempty"`
+}
+
+// BetaFeatureNotEnabledError is returned when a request asks for a beta flag that the
+// selected key does not allow.
+type BetaFeatureNotEnabledError struct {
+	Header string
+	Flag   string
+}
+
+func (e *BetaFeatureNotEnabledError) Error() string {
+	return fmt.Sprintf("beta feature %q (%s) is not enabled for the selected key", e.Flag, e.Header)
+}
+
+// Validate checks that header names and flags are non-empty and that every default flag is
+// permitted by the allowlist. A nil config is valid.
+func (b *KeyBetaFeatures) Validate() error {
+	if b == nil {
+		return nil
+	}
+	for header, flags := range b.Allowlist {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("beta_features.allowlist has an empty header name")
+		}
+		for _, flag := range flags {
+			if strings.TrimSpace(flag) == "" || strings.TrimSuffix(flag, "*") == "" {
+				return fmt.Errorf("beta_features.allowlist[%s] has an empty flag", header)
+			}
+		}
+	}
+	for header, flags := range b.Defaults {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("beta_features.defaults has an empty header name")
+		}
+		for _, value := range flags {
+			for flag := range splitBetaFlags(value) {
+				if !b.Allows(header, flag) {
+					return fmt.Errorf("beta_features.defaults[%s] flag %q is not in the allowlist", header, flag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Allows reports whether flag may be sent in the given beta header.
+func (b *KeyBetaFeatures) Allows(header, flag string) bool {
+	if b == nil {
+		return true
+	}
+	allowed, restricted := lookupHeader(b.Allowlist, header)
+	if !restricted {
+		return true
+	}
+	for _, entry := range allowed {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(flag, prefix) {
+				return true
+			}
+		} else if entry == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHeaders returns a *BetaFeatureNotEnabledError for the first flag in headers that the
+// allowlist rejects, or nil. Header values may carry several comma-separated flags.
+func (b *KeyBetaFeatures) CheckHeaders(headers map[string][]string) error {
+	if b == nil || len(b.Allowlist) == 0 {
+		return nil
+	}
+	for header, values := range headers {
+		if _, restricted := lookupHeader(b.Allowlist, header); !restricted {
+			continue
+		}
+		for _, value := range values {
+			for flag := range splitBetaFlags(value) {
+				if !b.Allows(header, flag) {
+					return &BetaFeatureNotEnabledError{Header: strings.ToLower(header), Flag: flag}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyDefaults returns a copy of headers with the default flags appended to their beta
+// headers. Flags the caller already requested are not duplicated. headers is not modified.
+func (b *KeyBetaFeatures) ApplyDefaults(headers map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(headers)+len(b.defaultsOrNil()))
+	for k, v := range headers {
+		out[k] = append([]string(nil), v...)
+	}
+	for header, defaults := range b.defaultsOrNil() {
+		// Reuse the caller's spelling of the header name so values land in a single entry.
+		name := header
+		for k := range out {
+			if strings.EqualFold(k, header) {
+				name = k
+				break
+			}
+		}
+		present := make(map[string]bool)
+		for _, value := range out[name] {
+			for flag := range splitBetaFlags(value) {
+				present[flag] = true
+			}
+		}
+		for _, value := range defaults {
+			for flag := range splitBetaFlags(value) {
+				if !present[flag] {
+					present[flag] = true
+					out[name] = append(out[name], flag)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (b *KeyBetaFeatures) defaultsOrNil() map[string][]string {
+	if b == nil {
+		return nil
+	}
+	return b.Defaults
+}
+
+// lookupHeader finds header in m case-insensitively.
+func lookupHeader(m map[string][]string, header string) ([]string, bool) {
+	if v, ok := m[header]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, header) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// splitBetaFlags yields the trimmed, non-empty flags of a comma-separated header value.
+func splitBetaFlags(value string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for part := range strings.SplitSeq(value, ",") {
+			if flag := strings.TrimSpace(part); flag != "" {
+				if !yield(flag) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestKeyBetaFeaturesCheckHeaders(t *testing.T) {
+	features := &KeyBetaFeatures{
+		Allowlist: map[string][]string{
+			"anthropic-beta": {"prompt-caching-2024-07-31", "computer-use-*"},
+			"openai-beta":    {},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string][]string
+		wantFlag string
+	}{
+		{name: "no headers", headers: nil},
+		{name: "exact flag allowed", headers: map[string][]string{"anthropic-beta": {"prompt-caching-2024-07-31"}}},
+		{name: "prefix allowed", headers: map[string][]string{"Anthropic-Beta": {"computer-use-2025-01-24"}}},
+		{name: "comma separated with one rejected", headers: map[string][]string{"anthropic-beta": {"computer-use-2025-01-24, files-api-2025-04-14"}}, wantFlag: "files-api-2025-04-14"},
+		{name: "empty allowlist blocks header", headers: map[string][]string{"openai-beta": {"assistants=v2"}}, wantFlag: "assistants=v2"},
+		{name: "unlisted header unrestricted", headers: map[string][]string{"x-other": {"anything"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := features.CheckHeaders(tt.headers)
+			if tt.wantFlag == "" {
+				if err != nil {
+					t.Fatalf("CheckHeaders() unexpected error: %v", err)
+				}
+				return
+			}
+			var notEnabled *BetaFeatureNotEnabledError
+			if !errors.As(err, &notEnabled) || notEnabled.Flag != tt.wantFlag {
+				t.Fatalf("CheckHeaders() error = %v, want rejection of %q", err, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestKeyBetaFeaturesApplyDefaults(t *testing.T) {
+	features := &KeyBetaFeatures{
+		Defaults: map[string][]string{"anthropic-beta": {"prompt-caching-2024-07-31,context-1m-2025-08-07"}},
+	}
+	requested := map[string][]string{"Anthropic-Beta": {"prompt-caching-2024-07-31"}}
+
+	got := features.ApplyDefaults(requested)
+	want := map[string][]string{"Anthropic-Beta": {"prompt-caching-2024-07-31", "context-1m-2025-08-07"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ApplyDefaults() = %v, want %v", got, want)
+	}
+	if len(requested["Anthropic-Beta"]) != 1 {
+		t.Fatalf("ApplyDefaults() modified its input: %v", requested)
+	}
+}
+
+func TestKeyBetaFeaturesValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		features *KeyBetaFeatures
+		wantErr  bool
+	}{
+		{name: "nil", features: nil},
+		{name: "default within allowlist", features: &KeyBetaFeatures{
+			Allowlist: map[string][]string{"anthropic-beta": {"computer-use-*"}},
+			Defaults:  map[string][]string{"anthropic-beta": {"computer-use-2025-01-24"}},
+		}},
+		{name: "default outside allowlist", features: &KeyBetaFeatures{
+			Allowlist: map[string][]string{"anthropic-beta": {"computer-use-*"}},
+			Defaults:  map[string][]string{"anthropic-beta": {"files-api-2025-04-14"}},
+		}, wantErr: true},
+		{name: "bare wildcard", features: &KeyBetaFeatures{Allowlist: map[string][]string{"anthropic-beta": {"*"}}}, wantErr: true},
+		{name: "empty header name", features: &KeyBetaFeatures{Defaults: map[string][]string{"": {"x"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.features.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -36,6 +36,26 @@ type BifrostConfig struct {
 	KeySelector        KeySelector   // Custom key selector function
 	KeyPoolFilter      KeyPoolFilter // Optional hook to filter available keys before selection; nil = all keys eligible
 	KVStore            KVStore       // shared KV store for clustering/session stickiness; nil = disabled
+	Region             string        // Deployment region; providers use their same-region endpoint from NetworkConfig.RegionalBaseURLs when one is configured
+	// ModelGroups are logical model names clients can send instead of a concrete
+	// model; see ModelGroup. Update at runtime with Bifrost.UpdateModelGroups.
+	ModelGroups []ModelGroup
+	// SessionAffinityStore persists which provider, model and key served each
+	// x-bf-session-id so later turns of the conversation land on the same
+	// deployment. nil = affinity is kept only for keys, in KVStore.
+	SessionAffinityStore SessionAffinityStore
+	// RetryBudget caps retries across all providers to a share of recent
+	// requests. nil = retries are limited only by each provider's MaxRetries.
+	RetryBudget *RetryBudgetConfig
+	// ImagePreprocessing normalizes images in chat content for each attempt's
+	// provider. nil = images are sent as received.
+	ImagePreprocessing *ImagePreprocessingConfig
+	// ConfidentialFields enables encrypted message fields that are decrypted
+	// only for provider dispatch. nil = encrypted fields are not recognized.
+	ConfidentialFields *ConfidentialFieldsConfig
+	// RequestFlags are feature flags evaluated per request; see RequestFlag.
+	// Update at runtime with Bifrost.UpdateRequestFlags.
+	RequestFlags []RequestFlag
 }
 
 // ModelProvider represents the different AI model providers supported by Bifrost.
@@ -172,6 +192,10 @@ const (
 	CachedContentRetrieveRequest   RequestType = "cached_content_retrieve"
 	CachedContentUpdateRequest     RequestType = "cached_content_update"
 	CachedContentDeleteRequest     RequestType = "cached_content_delete"
+	FineTuningJobCreateRequest     RequestType = "fine_tuning_job_create"
+	FineTuningJobListRequest       RequestType = "fine_tuning_job_list"
+	FineTuningJobRetrieveRequest   RequestType = "fine_tuning_job_retrieve"
+	FineTuningJobCancelRequest     RequestType = "fine_tuning_job_cancel"
 	FileContentRequest             RequestType = "file_content"
 	ContainerCreateRequest         RequestType = "container_create"
 	ContainerListRequest           RequestType = "container_list"
@@ -237,6 +261,7 @@ const (
 
 	BifrostContextKeySelectedKeyID                       BifrostContextKey = "bifrost-selected-key-id"                // string (to store the selected key ID (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
 	BifrostContextKeySelectedKeyName                     BifrostContextKey = "bifrost-selected-key-name"              // string (to store the selected key name (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
+	BifrostContextKeySelectedKeyBYOK                     BifrostContextKey = "bifrost-selected-key-byok"              // bool (whether the selected key is a tenant-owned BYOK key (set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyGovernanceVirtualKeyID              BifrostContextKey = "bifrost-governance-virtual-key-id"      // string (to store the virtual key ID (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyGovernanceVirtualKeyName            BifrostContextKey = "bifrost-governance-virtual-key-name"    // string (to store the virtual key name (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyGovernanceTeamID                    BifrostContextKey = "bifrost-governance-team-id"             // string (to store the team ID (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
@@ -261,6 +286,9 @@ const (
 	BifrostContextKeyGovernanceIncludeOnlyKeys           BifrostContextKey = "bf-governance-include-only-keys"        // []string (to store the include-only key IDs for provider config routing (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyNumberOfRetries                     BifrostContextKey = "bifrost-number-of-retries"              // int (to store the number of retries (set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyFallbackIndex                       BifrostContextKey = "bifrost-fallback-index"                 // int (to store the fallback index (set by bifrost - DO NOT SET THIS MANUALLY)) 0 for primary, 1 for first fallback, etc.
+	BifrostContextKeyHedgeDelay                          BifrostContextKey = "bifrost-hedge-delay"                    // time.Duration (when > 0, a non-streaming request that has not been answered by its primary within this delay is also sent to its first fallback; the first success wins and the other attempt is cancelled)
+	BifrostContextKeyFirstTokenTimeout                   BifrostContextKey = "bifrost-first-token-timeout"            // time.Duration (when > 0, a stream attempt that has not produced its first chunk within this timeout is cancelled and the stream restarts on the next fallback; nothing has been sent to the client yet)
+	BifrostContextKeyMaxCostUSD                          BifrostContextKey = "bifrost-max-cost-usd"                   // float64 (when > 0, the estimated cost of the request must not exceed this many US dollars; cost-based routing picks the cheapest deployment that fits and rejects the request when none does)
 	BifrostContextKeyResolvedAlias                       BifrostContextKey = "bifrost-resolved-alias"                 // *ResolvedAlias (set by bifrost after key-level alias resolution — providers read this for model_family routing and provider-specific overrides; nil/absent when no alias matched)
 	BifrostContextKeyRoutingInfo                         BifrostContextKey = "bifrost-routing-info"                   // RoutingInfo (set by bifrost per stream attempt - DO NOT SET THIS MANUALLY) - streams carry RoutingInfo only on chunks, so the transport reads this snapshot to emit routed-identity response headers before the first chunk
 	BifrostContextKeyStreamEndIndicator                  BifrostContextKey = "bifrost-stream-end-indicator"           // bool (set by bifrost - DO NOT SET THIS MANUALLY)
@@ -268,9 +296,12 @@ const (
 	BifrostContextKeyStreamIdleTimeout                   BifrostContextKey = "bifrost-stream-idle-timeout"            // time.Duration (per-chunk idle timeout for streaming)
 	BifrostContextKeySkipKeySelection                    BifrostContextKey = "bifrost-skip-key-selection"             // bool (will pass an empty key to the provider)
 	BifrostContextKeyExtraHeaders                        BifrostContextKey = "bifrost-extra-headers"                  // map[string][]string
+	BifrostContextKeyRequestedExtraHeaders               BifrostContextKey = "bifrost-requested-extra-headers"        // map[string][]string (set by bifrost - DO NOT SET THIS MANUALLY) - snapshot of the caller's extra headers before per-key beta defaults are merged in, so each key selection starts from the caller's own flags
 	BifrostContextKeyURLPath                             BifrostContextKey = "bifrost-extra-url-path"                 // string
 	BifrostContextKeyUseRawRequestBody                   BifrostContextKey = "bifrost-use-raw-request-body"
 	BifrostContextKeyChangeRequestType                   BifrostContextKey = "bifrost-change-request-type"                      // RequestType (set by plugins to trigger request type conversion in core, e.g. text->chat or chat->responses)
+	BifrostContextKeyChangeRequestSystemPrompt           BifrostContextKey = "bifrost-change-request-system-prompt"             // string (set by plugins with BifrostContextKeyChangeRequestType; system message prepended to a text completion converted to chat)
+	BifrostContextKeySystemPromptInjections              BifrostContextKey = "bifrost-system-prompt-injections"                 // []string (appended by plugins that add a system prompt to the request; recorded in the reproducibility bundle of the request log)
 	BifrostContextKeySendBackRawRequest                  BifrostContextKey = "bifrost-send-back-raw-request"                    // bool (per-request override — read by bifrost.go, never overwritten)
 	BifrostContextKeySendBackRawResponse                 BifrostContextKey = "bifrost-send-back-raw-response"                   // bool (per-request override — read by bifrost.go, never overwritten)
 	BifrostContextKeyIntegrationType                     BifrostContextKey = "bifrost-integration-type"                         // integration used in gateway (e.g. openai, anthropic, bedrock, etc.)
@@ -298,6 +329,15 @@ const (
 	BifrostContextKeyClusterNodeID                       BifrostContextKey = "bifrost-cluster-node-id"                          // string (cluster node ID for log attribution - set by enterprise server)
 	BifrostContextKeyGovernanceBudgetIDs                 BifrostContextKey = "bifrost-governance-budget-ids"                    // []string (budget IDs applicable to this request - set by governance plugin)
 	BifrostContextKeyGovernanceRateLimitIDs              BifrostContextKey = "bifrost-governance-rate-limit-ids"                // []string (rate limit IDs applicable to this request - set by governance plugin)
+	BifrostContextKeyResponseCost                        BifrostContextKey = "bifrost-response-cost"                            // float64 (cost of the final response in dollars - set by governance plugin)
+	BifrostContextKeyResponseTokensIn                    BifrostContextKey = "bifrost-response-tokens-in"                       // int (input tokens of the final response - set by governance plugin)
+	BifrostContextKeyResponseTokensOut                   BifrostContextKey = "bifrost-response-tokens-out"                      // int (output tokens of the final response - set by governance plugin)
+	BifrostContextKeyGovernanceBudgetRemaining           BifrostContextKey = "bifrost-governance-budget-remaining"              // float64 (tightest remaining budget in dollars after this request - set by governance plugin)
+	BifrostContextKeyGovernanceOverrideToken             BifrostContextKey = "x-bf-governance-override"                         // string (governance override token presented via the x-bf-governance-override header; skips the budgets and/or rate limits the token covers)
+	BifrostContextKeyGovernanceOverrideID                BifrostContextKey = "bifrost-governance-override-id"                   // string (ID of the governance override token that let this request skip controls - set by governance plugin)
+	BifrostContextKeyRequestPriority                     BifrostContextKey = "x-bf-priority"                                    // RequestPriority (queueing class from the x-bf-priority header or the virtual key; unset = interactive)
+	BifrostContextKeyValidateStructuredOutput            BifrostContextKey = "x-bf-validate-structured-output"                  // bool (validate json_schema chat responses against the schema and retry once on mismatch)
+	BifrostContextKeyDegradedFrom                        BifrostContextKey = "bifrost-degraded-from"                            // string (provider/model the request was degraded away from because its circuit was open - set by circuit breaker plugin)
 	BifrostContextKeyPromptsPluginName                   BifrostContextKey = "prompts-plugin-name"                              // string (name of the prompts plugin to use - set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyIsEnterprise                        BifrostContextKey = "is-enterprise"                                    // bool (set by bifrost - DO NOT SET THIS MANUALLY)
 	BifrostContextKeyAvailableProviders                  BifrostContextKey = "available-providers"                              // []ModelProvider (set by internal bifrost components - DO NOT SET THIS MANUALLY))
@@ -367,6 +407,9 @@ const (
 	BifrostContextKeySSEReaderFactory                    BifrostContextKey = "bifrost-sse-reader-factory"                 // *providerUtils.SSEReaderFactory (set by enterprise — replaces default bufio.Scanner SSE readers with streaming readers)
 	BifrostContextKeySessionID                           BifrostContextKey = "bifrost-session-id"                         // string session ID for the request (session stickiness)
 	BifrostContextKeySessionTTL                          BifrostContextKey = "bifrost-session-ttl"                        // time.Duration session TTL for the request (session stickiness)
+	BifrostContextKeySessionAffinity                     BifrostContextKey = "bifrost-session-affinity"                   // *SessionAffinity (set by bifrost - DO NOT SET THIS MANUALLY) - deployment the session was last served by, loaded from BifrostConfig.SessionAffinityStore
+	BifrostContextKeyModelGroup                          BifrostContextKey = "bifrost-model-group"                        // string (set by bifrost - DO NOT SET THIS MANUALLY) - name of the model group the requested model resolved through
+	BifrostContextKeyFallbacksOverride                   BifrostContextKey = "bifrost-fallbacks-override"                 // []Fallback (from x-bf-fallbacks) replacing the request's fallback chain once PreRequestHooks have run; governance drops entries the virtual key may not use
 	BifrostContextKeyMCPExtraHeaders                     BifrostContextKey = "bifrost-mcp-extra-headers"                  // map[string][]string (these headers are forwarded only to the MCP while tool execution if they are in the allowlist of the MCP client)
 	BifrostContextKeyMCPLogID                            BifrostContextKey = "bifrost-mcp-log-id"                         // string (unique UUID for each MCP tool log entry - set per goroutine by agent executor - DO NOT SET THIS MANUALLY)
 	BifrostContextKeyMCPHealthCheckRequest               BifrostContextKey = "bifrost-mcp-health-check-request"           // bool (set by bifrost - DO NOT SET THIS MANUALLY) - true when the MCP ping/list-tools request was generated by bifrost itself for health checks rather than originating from a caller
@@ -382,9 +425,13 @@ const (
 	BifrostContextKeyPassthroughOverridesPresent         BifrostContextKey = "passthrough_overrides_present" // bool (set by HTTP transport) - passthrough raw request requested
 	BifrostContextKeyConnectionClosed                    BifrostContextKey = "connection_closed"
 	BifrostContextKeyTempTokenScope                      BifrostContextKey = "bifrost-temp-token-scope"       // string (set by auth middleware when a temp token authorized the request - names the scope from the temptoken registry)
+	BifrostContextKeyAdminUserName                       BifrostContextKey = "bifrost-admin-user-name"        // string (set by auth middleware when the configured admin authenticated the request, by password or session)
 	BifrostContextKeyTempTokenResourceID                 BifrostContextKey = "bifrost-temp-token-resource-id" // string (set by auth middleware alongside the scope - the resource_id the token is bound to, e.g. an OAuth flow ID for mcp_auth)
 	BifrostContextKeyAsyncWebhookEndpoint                BifrostContextKey = "bifrost-async-webhook-endpoint" // string (webhook endpoint name to notify when an async job finishes - carried as-is from the x-bf-async-webhook header; the submit path resolves and validates it before the job is created)
 	BifrostContextKeyUpstreamLatency                     BifrostContextKey = "bifrost-upstream-latency"       // *atomic.Int64 nanoseconds (set by bifrost - DO NOT SET THIS MANUALLY) - cumulative time blocked on provider sockets across every attempt; subtract from total to get Bifrost overhead
+	BifrostContextKeyConfidentialFields                  BifrostContextKey = "bifrost-confidential-fields"    // map[string]string (set by bifrost - DO NOT SET THIS MANUALLY) - sealed confidential fields of the request keyed by the placeholder that replaced them; read back just before provider dispatch
+	BifrostContextKeyRequestFlags                        BifrostContextKey = "bifrost-request-flags"          // map[string]bool (set by bifrost - DO NOT SET THIS MANUALLY) - result of every configured request flag for this request; read with RequestFlagEnabled
+	BifrostContextKeyFlagGatedPlugins                    BifrostContextKey = "bifrost-flag-gated-plugins"     // map[string]bool (set by bifrost - DO NOT SET THIS MANUALLY) - plugins skipped for this request because no request flag gating them is on
 )
 
 const (
@@ -395,11 +442,15 @@ const (
 
 // RoutingEngine constants
 const (
-	RoutingEngineGovernance     = "governance"
-	RoutingEngineRoutingRule    = "routing-rule"
-	RoutingEngineLoadbalancing  = "loadbalancing"
-	RoutingEngineModelCatalog   = "model-catalog"
-	RoutingEngineCircuitBreaker = "circuit-breaker"
+	RoutingEngineGovernance      = "governance"
+	RoutingEngineRoutingRule     = "routing-rule"
+	RoutingEngineLoadbalancing   = "loadbalancing"
+	RoutingEngineModelCatalog    = "model-catalog"
+	RoutingEngineCircuitBreaker  = "circuit-breaker"
+	RoutingEngineLatency         = "latency"
+	RoutingEngineCost            = "cost"
+	RoutingEngineSessionAffinity = "session-affinity"
+	RoutingEngineModelGroup      = "model-group"
 	// RoutingEngineCore represents the Bifrost core orchestrator's own
 	// routing decisions — primarily fallback transitions. Emitted when the
 	// primary attempt fails and core advances through the fallback chain so
@@ -534,6 +585,10 @@ type BifrostRequest struct {
 	CachedContentRetrieveRequest *BifrostCachedContentRetrieveRequest
 	CachedContentUpdateRequest   *BifrostCachedContentUpdateRequest
 	CachedContentDeleteRequest   *BifrostCachedContentDeleteRequest
+	FineTuningJobCreateRequest   *BifrostFineTuningJobCreateRequest
+	FineTuningJobListRequest     *BifrostFineTuningJobListRequest
+	FineTuningJobRetrieveRequest *BifrostFineTuningJobRetrieveRequest
+	FineTuningJobCancelRequest   *BifrostFineTuningJobCancelRequest
 	BatchCreateRequest           *BifrostBatchCreateRequest
 	BatchListRequest             *BifrostBatchListRequest
 	BatchRetrieveRequest         *BifrostBatchRetrieveRequest
@@ -650,6 +705,23 @@ func (br *BifrostRequest) GetRequestFields() (provider ModelProvider, model stri
 			return br.CachedContentDeleteRequest.Provider, *br.CachedContentDeleteRequest.Model, nil
 		}
 		return br.CachedContentDeleteRequest.Provider, "", nil
+	case br.FineTuningJobCreateRequest != nil:
+		return br.FineTuningJobCreateRequest.Provider, br.FineTuningJobCreateRequest.Model, nil
+	case br.FineTuningJobListRequest != nil:
+		if br.FineTuningJobListRequest.Model != nil {
+			return br.FineTuningJobListRequest.Provider, *br.FineTuningJobListRequest.Model, nil
+		}
+		return br.FineTuningJobListRequest.Provider, "", nil
+	case br.FineTuningJobRetrieveRequest != nil:
+		if br.FineTuningJobRetrieveRequest.Model != nil {
+			return br.FineTuningJobRetrieveRequest.Provider, *br.FineTuningJobRetrieveRequest.Model, nil
+		}
+		return br.FineTuningJobRetrieveRequest.Provider, "", nil
+	case br.FineTuningJobCancelRequest != nil:
+		if br.FineTuningJobCancelRequest.Model != nil {
+			return br.FineTuningJobCancelRequest.Provider, *br.FineTuningJobCancelRequest.Model, nil
+		}
+		return br.FineTuningJobCancelRequest.Provider, "", nil
 	case br.BatchCreateRequest != nil:
 		if br.BatchCreateRequest.Model != nil {
 			return br.BatchCreateRequest.Provider, *br.BatchCreateRequest.Model, nil
@@ -764,6 +836,14 @@ func (br *BifrostRequest) SetProvider(provider ModelProvider) {
 		br.CachedContentUpdateRequest.Provider = provider
 	case br.CachedContentDeleteRequest != nil:
 		br.CachedContentDeleteRequest.Provider = provider
+	case br.FineTuningJobCreateRequest != nil:
+		br.FineTuningJobCreateRequest.Provider = provider
+	case br.FineTuningJobListRequest != nil:
+		br.FineTuningJobListRequest.Provider = provider
+	case br.FineTuningJobRetrieveRequest != nil:
+		br.FineTuningJobRetrieveRequest.Provider = provider
+	case br.FineTuningJobCancelRequest != nil:
+		br.FineTuningJobCancelRequest.Provider = provider
 	}
 }
 
@@ -819,6 +899,20 @@ func (br *BifrostRequest) SetModel(model string) {
 		if br.CachedContentDeleteRequest.Model != nil {
 			br.CachedContentDeleteRequest.Model = new(model)
 		}
+	case br.FineTuningJobCreateRequest != nil:
+		br.FineTuningJobCreateRequest.Model = model
+	case br.FineTuningJobListRequest != nil:
+		if br.FineTuningJobListRequest.Model != nil {
+			br.FineTuningJobListRequest.Model = new(model)
+		}
+	case br.FineTuningJobRetrieveRequest != nil:
+		if br.FineTuningJobRetrieveRequest.Model != nil {
+			br.FineTuningJobRetrieveRequest.Model = new(model)
+		}
+	case br.FineTuningJobCancelRequest != nil:
+		if br.FineTuningJobCancelRequest.Model != nil {
+			br.FineTuningJobCancelRequest.Model = new(model)
+		}
 	}
 }
 
@@ -905,6 +999,8 @@ func (br *BifrostRequest) SetRawRequestBody(rawRequestBody []byte) {
 		br.CachedContentUpdateRequest.RawRequestBody = rawRequestBody
 	case br.CachedContentDeleteRequest != nil:
 		br.CachedContentDeleteRequest.RawRequestBody = rawRequestBody
+	case br.FineTuningJobCreateRequest != nil:
+		br.FineTuningJobCreateRequest.RawRequestBody = rawRequestBody
 	}
 }
 
@@ -1073,6 +1169,10 @@ type BifrostResponse struct {
 	CachedContentRetrieveResponse *BifrostCachedContentRetrieveResponse
 	CachedContentUpdateResponse   *BifrostCachedContentUpdateResponse
 	CachedContentDeleteResponse   *BifrostCachedContentDeleteResponse
+	FineTuningJobCreateResponse   *BifrostFineTuningJobCreateResponse
+	FineTuningJobListResponse     *BifrostFineTuningJobListResponse
+	FineTuningJobRetrieveResponse *BifrostFineTuningJobRetrieveResponse
+	FineTuningJobCancelResponse   *BifrostFineTuningJobCancelResponse
 	BatchCreateResponse           *BifrostBatchCreateResponse
 	BatchListResponse             *BifrostBatchListResponse
 	BatchRetrieveResponse         *BifrostBatchRetrieveResponse
@@ -1189,6 +1289,14 @@ func (r *BifrostResponse) GetExtraFields() *BifrostResponseExtraFields {
 		return &r.CachedContentUpdateResponse.ExtraFields
 	case r.CachedContentDeleteResponse != nil:
 		return &r.CachedContentDeleteResponse.ExtraFields
+	case r.FineTuningJobCreateResponse != nil:
+		return &r.FineTuningJobCreateResponse.ExtraFields
+	case r.FineTuningJobListResponse != nil:
+		return &r.FineTuningJobListResponse.ExtraFields
+	case r.FineTuningJobRetrieveResponse != nil:
+		return &r.FineTuningJobRetrieveResponse.ExtraFields
+	case r.FineTuningJobCancelResponse != nil:
+		return &r.FineTuningJobCancelResponse.ExtraFields
 	}
 
 	return &BifrostResponseExtraFields{}
@@ -1568,6 +1676,26 @@ func (r *BifrostResponse) PopulateExtraFields(requestType RequestType, provider
 		r.CachedContentDeleteResponse.ExtraFields.Provider = provider
 		r.CachedContentDeleteResponse.ExtraFields.OriginalModelRequested = originalModelRequested
 		r.CachedContentDeleteResponse.ExtraFields.ResolvedModelUsed = resolvedModel
+	case r.FineTuningJobCreateResponse != nil:
+		r.FineTuningJobCreateResponse.ExtraFields.RequestType = requestType
+		r.FineTuningJobCreateResponse.ExtraFields.Provider = provider
+		r.FineTuningJobCreateResponse.ExtraFields.OriginalModelRequested = originalModelRequested
+		r.FineTuningJobCreateResponse.ExtraFields.ResolvedModelUsed = resolvedModel
+	case r.FineTuningJobListResponse != nil:
+		r.FineTuningJobListResponse.ExtraFields.RequestType = requestType
+		r.FineTuningJobListResponse.ExtraFields.Provider = provider
+		r.FineTuningJobListResponse.ExtraFields.OriginalModelRequested = originalModelRequested
+		r.FineTuningJobListResponse.ExtraFields.ResolvedModelUsed = resolvedModel
+	case r.FineTuningJobRetrieveResponse != nil:
+		r.FineTuningJobRetrieveResponse.ExtraFields.RequestType = requestType
+		r.FineTuningJobRetrieveResponse.ExtraFields.Provider = provider
+		r.FineTuningJobRetrieveResponse.ExtraFields.OriginalModelRequested = originalModelRequested
+		r.FineTuningJobRetrieveResponse.ExtraFields.ResolvedModelUsed = resolvedModel
+	case r.FineTuningJobCancelResponse != nil:
+		r.FineTuningJobCancelResponse.ExtraFields.RequestType = requestType
+		r.FineTuningJobCancelResponse.ExtraFields.Provider = provider
+		r.FineTuningJobCancelResponse.ExtraFields.OriginalModelRequested = originalModelRequested
+		r.FineTuningJobCancelResponse.ExtraFields.ResolvedModelUsed = resolvedModel
 	}
 }
 
@@ -1708,6 +1836,50 @@ type BifrostResponseExtraFields struct {
 	DroppedCompatPluginParams []string           `json:"dropped_compat_plugin_params,omitempty"` // params dropped by the compat plugin based on model catalog
 	ProviderResponseHeaders   map[string]string  `json:"provider_response_headers,omitempty"`    // HTTP response headers from the provider (filtered to exclude transport-level headers)
 	PassthroughPath           string             `json:"passthrough_path,omitempty"`             // Stripped provider path for passthrough requests, e.g. "/v1/chat/completions"
+	Hedge                     *HedgeInfo         `json:"hedge,omitempty"`                        // Set when the request was hedged (see BifrostContextKeyHedgeDelay)
+	Dedup                     *DedupInfo         `json:"dedup,omitempty"`                        // Set when the response was shared from an identical in-flight request (dedup plugin)
+	PolicyViolations          []string           `json:"policy_violations,omitempty"`            // output policies the response still violates (response policy plugin)
+	PIIRedactions             []PIIRedaction     `json:"pii_redactions,omitempty"`               // personal data found in the request or response (guardrails plugin)
+}
+
+// PIIRedaction records one span of personal data the guardrails plugin found in
+// a request message or a response choice. The matched text itself is never
+// recorded; Start and End are byte offsets into the original text of the
+// message, or of its content block when the message has several.
+type PIIRedaction struct {
+	Source       string `json:"source"`                  // "request" or "response"
+	MessageIndex int    `json:"message_index"`           // index of the request message or response choice
+	ContentIndex int    `json:"content_index,omitempty"` // index of the content block, 0 for plain text content
+	Type         string `json:"type"`                    // "email", "phone", "credit_card" or a custom pattern's name
+	Start        int    `json:"start"`
+	End          int    `json:"end"`
+	Action       string `json:"action"` // "redact" or "log-only"
+}
+
+// ModerationResult is the verdict of a content moderation check. Scores are
+// normalized to 0-1 per category; Flagged lists the categories whose score
+// reached their threshold.
+type ModerationResult struct {
+	Source         string             `json:"source"`   // "request" or "response"
+	Provider       string             `json:"provider"` // moderation service, e.g. "openai" or "azure"
+	CategoryScores map[string]float64 `json:"category_scores"`
+	Flagged        []string           `json:"flagged"`
+}
+
+// DedupInfo marks a response that was not fetched upstream but shared from an
+// identical request that was already in flight.
+type DedupInfo struct {
+	LeaderRequestID string `json:"leader_request_id"` // request whose upstream call was shared
+}
+
+// HedgeInfo records the outcome of a hedged request: the primary had not answered
+// within DelayMs, so the request was also sent to the first fallback and the first
+// successful answer was returned.
+type HedgeInfo struct {
+	DelayMs        int64         `json:"delay_ms"`
+	WinnerProvider ModelProvider `json:"winner_provider"`
+	WinnerModel    string        `json:"winner_model"`
+	SecondaryWon   bool          `json:"secondary_won"`
 }
 
 type RoutingInfo struct {
@@ -1719,6 +1891,10 @@ type RoutingInfo struct {
 	// Populated only when Model matched an entry in this key's Aliases map
 	ResolvedKeyAlias *ResolvedKeyAlias `json:"resolved_key_alias,omitempty"`
 
+	// Model group the caller's model resolved through (populated only when the
+	// caller sent a group name); Provider and Model are the concrete target
+	ModelGroup string `json:"model_group,omitempty"`
+
 	IsFallback bool `json:"is_fallback,omitempty"`
 
 	// What the caller asked for, before any fallback resolution (populated only when fallback resolution occurred)
@@ -1748,6 +1924,8 @@ type BifrostMCPResponseExtraFields struct {
 	ClientName     string         `json:"client_name"`
 	ToolName       string         `json:"tool_name"` // empty for all but MCPRequestTypeExecuteTool requests for backwards compat, will be a pointer from next major bump.
 	Latency        int64          `json:"latency"`   // in milliseconds
+
+	OutputSchemaValidation *MCPOutputSchemaValidationResult `json:"output_schema_validation,omitempty"` // set when the tool declares an output schema and validation is on
 }
 
 // BifrostCacheDebug represents debug information about the cache.
@@ -1781,6 +1959,33 @@ const (
 	ProviderConnectionFailed = "provider_connection_failed"
 )
 
+// ShedReason is why Bifrost turned a request away because it was saturated.
+type ShedReason string
+
+const (
+	ShedReasonQueueFull        ShedReason = "queue_full"         // the provider queue was full and drop_excess_requests is on
+	ShedReasonQueueWaitTimeout ShedReason = "queue_wait_timeout" // the request's deadline passed while it waited for queue space
+)
+
+// GatewaySaturation is set on the 429 Bifrost returns when it sheds a request
+// itself, as opposed to passing through a provider's rate limit.
+// RetryAfterSeconds is estimated from the queue depth and how fast the
+// provider's workers have been draining it.
+type GatewaySaturation struct {
+	Reason            ShedReason `json:"reason"`
+	RetryAfterSeconds int        `json:"retry_after_seconds"`
+	QueueDepth        int        `json:"queue_depth"`
+}
+
+// RateLimitExceeded is set on the 429 a rate limiting plugin returns when a
+// request would exceed one of its limits.
+type RateLimitExceeded struct {
+	Scope             string `json:"scope"` // what the limit applies to, e.g. "virtual_key", "model" or "provider"
+	Key               string `json:"key"`   // the virtual key, model or provider that reached its limit
+	Limit             string `json:"limit"` // "rpm" or "tpm"
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+}
+
 // BifrostStreamChunk represents a stream of responses from the Bifrost system.
 // Either BifrostResponse or BifrostError will be non-nil.
 type BifrostStreamChunk struct {
@@ -2010,4 +2215,17 @@ type BifrostErrorExtraFields struct {
 	// the provider actually billed us for. Nil when the failure consumed no
 	// tokens (e.g. 401/403/429 before the model ran).
 	BilledUsage *BifrostLLMUsage `json:"billed_usage,omitempty"`
+	// RawError is the provider's error body or stream error event as received.
+	// Unlike RawResponse it is kept regardless of send_back_raw_response, so
+	// provider detail the normalized ErrorField does not carry is never lost.
+	RawError json.RawMessage `json:"raw_error,omitempty"`
+	// Saturation is set when Bifrost shed the request because a provider
+	// queue was saturated; the HTTP transport turns it into Retry-After.
+	Saturation *GatewaySaturation `json:"saturation,omitempty"`
+	// Moderation is set when a moderation plugin blocked the request or its
+	// response, with the score of every category that was checked.
+	Moderation *ModerationResult `json:"moderation,omitempty"`
+	// RateLimit is set when a rate limiting plugin turned the request away;
+	// the HTTP transport turns it into Retry-After.
+	RateLimit *RateLimitExceeded `json:"rate_limit,omitempty"`
 }
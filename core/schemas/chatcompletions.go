@@ -194,7 +194,7 @@ type ChatParameters struct {
 	Audio                *ChatAudioParameters  `json:"audio,omitempty"`                 // Audio parameters
 	FrequencyPenalty     *float64              `json:"frequency_penalty,omitempty"`     // Penalizes frequent tokens
 	LogitBias            *map[string]float64   `json:"logit_bias,omitempty"`            // Bias for logit values
-	LogProbs             *bool                 `json:"logprobs,omitempty"`              // Number of logprobs to return
+	LogProbs             *bool                 `json:"logprobs,omitempty"`              // Whether to return logprobs of the output tokens
 	MaxCompletionTokens  *int                  `json:"max_completion_tokens,omitempty"` // Maximum number of tokens to generate
 	Metadata             *map[string]any       `json:"metadata,omitempty"`              // Metadata to be returned with the response
 	Modalities           []string              `json:"modalities,omitempty"`            // Modalities to be returned with the response
@@ -394,6 +394,7 @@ type ChatTool struct {
 	Custom       *ChatToolCustom     `json:"custom,omitempty"`        // Custom tool definition (shape 2)
 	CacheControl *CacheControl       `json:"cache_control,omitempty"` // Cache control for the tool
 	Annotations  *MCPToolAnnotations `json:"-"`                       // MCP tool annotations (Bifrost-internal, never forwarded to providers)
+	OutputSchema json.RawMessage     `json:"-"`                       // MCP tool output schema (Bifrost-internal, never forwarded to providers)
 
 	// Anthropic-native tool flags promoted to the neutral layer. All optional;
 	// ignored by providers that don't support them. Gating per ProviderFeatures
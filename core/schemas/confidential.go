@@ -0,0 +1,57 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConfidentialFieldPrefix starts every encrypted message field. The rest of
+// the field is the base64 (standard encoding) JSON of a ConfidentialEnvelope.
+const ConfidentialFieldPrefix = "bifrost-enc:v1:"
+
+// ConfidentialEnvelope is an encrypted message field. The text is sealed with
+// AES-256-GCM under a data key the client generates per request; the data key
+// travels wrapped by a key-encryption key (KEK) the client shares with the
+// gateway's key provider, identified by KeyID.
+type ConfidentialEnvelope struct {
+	KeyID      string `json:"kid"`
+	WrappedKey []byte `json:"wrapped_key"` // data key wrapped by the KEK
+	Nonce      []byte `json:"nonce"`       // 12-byte GCM nonce for Ciphertext
+	Ciphertext []byte `json:"ciphertext"`  // sealed text, GCM tag appended
+}
+
+// DataKeyUnwrapper unwraps the data key of a confidential field. It is the
+// key management hook: implement it over a KMS (AWS KMS Decrypt, GCP KMS
+// decrypt, Vault transit) so KEKs never leave the key service. keyID is the
+// envelope's kid. Returned keys must be 32 bytes and are used only in memory
+// for one dispatch.
+type DataKeyUnwrapper interface {
+	UnwrapDataKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// ConfidentialFieldsConfig enables encrypted message fields for tenants that
+// need prompts opaque to gateway operators. A chat or responses message text
+// (a whole content string or text block) that starts with
+// ConfidentialFieldPrefix is replaced on arrival by a placeholder carrying the
+// SHA-256 of the sealed field; plugins, logs and caches only ever see the
+// placeholder. The field is decrypted in memory just before each provider
+// dispatch and the plaintext is dropped when the call returns.
+//
+// Responses are not encrypted: model output is logged as usual.
+type ConfidentialFieldsConfig struct {
+	// KeyEncryptionKeys are local KEKs by key ID, each a base64 32-byte
+	// AES-256 key (env.* and vault.* references are resolved). Used when
+	// Unwrapper is nil; data keys are wrapped as AES-GCM nonce||ciphertext.
+	KeyEncryptionKeys map[string]SecretVar `json:"key_encryption_keys,omitempty"`
+	// Unwrapper unwraps data keys with an external key service. It takes
+	// precedence over KeyEncryptionKeys.
+	Unwrapper DataKeyUnwrapper `json:"-"`
+}
+
+// Validate checks that the config can unwrap data keys.
+func (c ConfidentialFieldsConfig) Validate() error {
+	if c.Unwrapper == nil && len(c.KeyEncryptionKeys) == 0 {
+		return fmt.Errorf("confidential fields need key_encryption_keys or a data key unwrapper")
+	}
+	return nil
+}
@@ -16,10 +16,12 @@ var reservedKeys = []any{
 	BifrostContextKeyAPIKeyName,
 	BifrostContextKeyAPIKeyID,
 	BifrostContextKeyDirectKey,
+	BifrostContextKeyGovernanceOverrideToken,
 	BifrostContextKeyRequestID,
 	BifrostContextKeyFallbackRequestID,
 	BifrostContextKeySelectedKeyID,
 	BifrostContextKeySelectedKeyName,
+	BifrostContextKeySelectedKeyBYOK,
 	BifrostContextKeyNumberOfRetries,
 	BifrostContextKeyFallbackIndex,
 	BifrostContextKeySkipKeySelection,
@@ -31,6 +33,10 @@ var reservedKeys = []any{
 	BifrostContextKeyMCPHealthCheckRequest,
 	BifrostContextKeyUpstreamLatency,
 	BifrostContextKeyRoutingInfo,
+	BifrostContextKeyModelGroup,
+	BifrostContextKeyConfidentialFields,
+	BifrostContextKeyRequestFlags,
+	BifrostContextKeyFlagGatedPlugins,
 }
 
 // pluginLogStore holds plugin log entries accumulated during request processing.
@@ -446,6 +452,26 @@ func (bc *BifrostContext) GetUserValues() map[any]any {
 	return result
 }
 
+// AdoptValues copies the values set directly on child onto bc, overwriting existing ones.
+// Values child inherits from its parents are not copied. Core uses this to carry the state
+// of an attempt that ran on a context derived from bc back onto the request context.
+func (bc *BifrostContext) AdoptValues(child *BifrostContext) {
+	if child == nil || child == bc {
+		return
+	}
+	child = child.Root()
+	child.valuesMu.RLock()
+	values := make(map[any]any, len(child.userValues))
+	for k, v := range child.userValues {
+		values[k] = v
+	}
+	child.valuesMu.RUnlock()
+
+	for k, v := range values {
+		bc.setReservedValue(k, v)
+	}
+}
+
 // GetParentCtxWithUserValues returns a copy of the parent context with all user-set values merged in.
 func (bc *BifrostContext) GetParentCtxWithUserValues() context.Context {
 	parentCtx := bc.parent
@@ -10,5 +10,99 @@ type BifrostCountTokensResponse struct {
 	TokenStrings       []string                      `json:"token_strings,omitempty"`
 	OutputTokens       *int                          `json:"output_tokens,omitempty"`
 	TotalTokens        *int                          `json:"total_tokens"`
-	ExtraFields        BifrostResponseExtraFields    `json:"extra_fields"`
+	// Estimated is true when the provider has no token counting API and
+	// InputTokens is a local estimate (see EstimateInputTokens).
+	Estimated   bool                       `json:"estimated,omitempty"`
+	ExtraFields BifrostResponseExtraFields `json:"extra_fields"`
+}
+
+const (
+	estimatedTokensPerMessage    = 4  // role and delimiters around every item
+	estimatedTokensPerAttachment = 85 // an image or file, counted like a low-detail image tile
+)
+
+// EstimateInputTokens approximates the input tokens of a responses request at
+// about four bytes per token of text, tool arguments, tool outputs,
+// instructions and tool definitions, plus a fixed cost per message and per
+// image or file. It needs no network call, so it suits pre-flight checks; it
+// is not a tokenizer and can be off by a fair margin for code or non-Latin
+// scripts.
+func EstimateInputTokens(req *BifrostResponsesRequest) int {
+	if req == nil {
+		return 0
+	}
+	size, tokens := 0, 0
+	for _, message := range req.Input {
+		tokens += estimatedTokensPerMessage
+		if message.Content != nil {
+			if message.Content.ContentStr != nil {
+				size += len(*message.Content.ContentStr)
+			}
+			blockSize, blockTokens := estimateContentBlocks(message.Content.ContentBlocks)
+			size += blockSize
+			tokens += blockTokens
+		}
+		if tool := message.ResponsesToolMessage; tool != nil {
+			if tool.Name != nil {
+				size += len(*tool.Name)
+			}
+			if tool.Arguments != nil {
+				size += len(*tool.Arguments)
+			}
+			if tool.Output != nil {
+				if tool.Output.ResponsesToolCallOutputStr != nil {
+					size += len(*tool.Output.ResponsesToolCallOutputStr)
+				}
+				blockSize, blockTokens := estimateContentBlocks(tool.Output.ResponsesFunctionToolCallOutputBlocks)
+				size += blockSize
+				tokens += blockTokens
+			}
+		}
+	}
+	if params := req.Params; params != nil {
+		if params.Instructions != nil {
+			size += len(*params.Instructions)
+		}
+		for _, tool := range params.Tools {
+			if data, err := MarshalSorted(tool); err == nil {
+				size += len(data)
+			}
+		}
+	}
+	return tokens + (size+3)/4
+}
+
+// estimateContentBlocks returns the text bytes and attachment tokens of blocks.
+func estimateContentBlocks(blocks []ResponsesMessageContentBlock) (size, tokens int) {
+	for _, block := range blocks {
+		if block.Text != nil {
+			size += len(*block.Text)
+		}
+		if block.ResponsesInputMessageContentBlockImage != nil || block.ResponsesInputMessageContentBlockFile != nil {
+			tokens += estimatedTokensPerAttachment
+		}
+	}
+	return size, tokens
+}
+
+// EstimateEmbeddingInputTokens approximates the input tokens of an embedding
+// request's longest input at about four bytes per token of text. Pre-tokenized
+// inputs are counted exactly. The longest input is what decides whether a
+// model's context fits, so a batch of short queries counts as short.
+func EstimateEmbeddingInputTokens(input *EmbeddingInput) int {
+	if input == nil {
+		return 0
+	}
+	longest := 0
+	if input.Text != nil {
+		longest = (len(*input.Text) + 3) / 4
+	}
+	for _, text := range input.Texts {
+		longest = max(longest, (len(text)+3)/4)
+	}
+	longest = max(longest, len(input.Embedding))
+	for _, tokens := range input.Embeddings {
+		longest = max(longest, len(tokens))
+	}
+	return longest
 }
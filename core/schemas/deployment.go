@@ -0,0 +1,47 @@
+package schemas
+
+// Response headers identifying the Bifrost instance that served a request.
+const (
+	DeploymentRegionHeader     = "x-bifrost-region"
+	DeploymentInstanceIDHeader = "x-bifrost-instance-id"
+)
+
+// DeploymentMetadata identifies where a Bifrost process runs. It is per-process
+// (not shared through the config store) so that instances running active-active
+// behind geo-DNS each report their own region and instance.
+type DeploymentMetadata struct {
+	Region     string `json:"region,omitempty"`      // Deployment region (e.g. "us-east-1"); selects regional provider endpoints
+	InstanceID string `json:"instance_id,omitempty"` // Unique instance identifier; defaults to the hostname
+}
+
+// IsZero reports whether no deployment metadata is set.
+func (d DeploymentMetadata) IsZero() bool {
+	return d.Region == "" && d.InstanceID == ""
+}
+
+// BaseURLForRegion returns the base URL to use when running in region: the
+// RegionalBaseURLs entry for that region when one exists, BaseURL otherwise.
+func (nc *NetworkConfig) BaseURLForRegion(region string) string {
+	if region != "" {
+		if url := nc.RegionalBaseURLs[region]; url != "" {
+			return url
+		}
+	}
+	return nc.BaseURL
+}
+
+// ForRegion returns the provider config to build a provider with in the given
+// region. When the network config has a same-region endpoint it returns a shallow
+// copy with BaseURL pointing at it; otherwise config itself is returned.
+func (config *ProviderConfig) ForRegion(region string) *ProviderConfig {
+	if config == nil {
+		return nil
+	}
+	url := config.NetworkConfig.BaseURLForRegion(region)
+	if url == config.NetworkConfig.BaseURL {
+		return config
+	}
+	regional := *config
+	regional.NetworkConfig.BaseURL = url
+	return &regional
+}
@@ -0,0 +1,54 @@
+package schemas
+
+import "testing"
+
+func TestProviderConfigForRegion(t *testing.T) {
+	config := &ProviderConfig{
+		NetworkConfig: NetworkConfig{
+			BaseURL: "https://api.example.com",
+			RegionalBaseURLs: map[string]string{
+				"eu-west-1": "https://eu.api.example.com",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		region  string
+		wantURL string
+		wantCpy bool
+	}{
+		{name: "no region", region: "", wantURL: "https://api.example.com"},
+		{name: "region without endpoint", region: "us-east-1", wantURL: "https://api.example.com"},
+		{name: "same-region endpoint", region: "eu-west-1", wantURL: "https://eu.api.example.com", wantCpy: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.ForRegion(tt.region)
+			if got.NetworkConfig.BaseURL != tt.wantURL {
+				t.Fatalf("ForRegion(%q) base URL = %q, want %q", tt.region, got.NetworkConfig.BaseURL, tt.wantURL)
+			}
+			if (got != config) != tt.wantCpy {
+				t.Fatalf("ForRegion(%q) returned copy = %v, want %v", tt.region, got != config, tt.wantCpy)
+			}
+		})
+	}
+	if config.NetworkConfig.BaseURL != "https://api.example.com" {
+		t.Fatalf("ForRegion modified its receiver: %q", config.NetworkConfig.BaseURL)
+	}
+}
+
+func TestNetworkConfigRegionalBaseURLsRoundTrip(t *testing.T) {
+	in := NetworkConfig{RegionalBaseURLs: map[string]string{"ap-south-1": "https://in.api.example.com"}}
+	data, err := in.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	var out NetworkConfig
+	if err := out.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if out.RegionalBaseURLs["ap-south-1"] != "https://in.api.example.com" {
+		t.Fatalf("regional_base_urls did not round-trip: %s", data)
+	}
+}
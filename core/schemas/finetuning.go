@@ -0,0 +1,147 @@
+// Package schemas defines the core schemas and types used by the Bifrost system.
+package schemas
+
+// FineTuningJobStatus is the normalized lifecycle state of a fine-tuning job.
+type FineTuningJobStatus string
+
+const (
+	FineTuningJobStatusValidatingFiles FineTuningJobStatus = "validating_files"
+	FineTuningJobStatusQueued          FineTuningJobStatus = "queued"
+	FineTuningJobStatusRunning         FineTuningJobStatus = "running"
+	FineTuningJobStatusSucceeded       FineTuningJobStatus = "succeeded"
+	FineTuningJobStatusFailed          FineTuningJobStatus = "failed"
+	FineTuningJobStatusCancelled       FineTuningJobStatus = "cancelled"
+)
+
+// IsTerminal reports whether the job will not change state anymore.
+func (s FineTuningJobStatus) IsTerminal() bool {
+	return s == FineTuningJobStatusSucceeded || s == FineTuningJobStatusFailed || s == FineTuningJobStatusCancelled
+}
+
+// FineTuningHyperparameters are the training knobs shared by all providers.
+// Unset fields let the provider pick its own defaults.
+type FineTuningHyperparameters struct {
+	NEpochs                *int     `json:"n_epochs,omitempty"`
+	BatchSize              *int     `json:"batch_size,omitempty"`
+	LearningRateMultiplier *float64 `json:"learning_rate_multiplier,omitempty"`
+}
+
+// FineTuningJobError describes why a job failed.
+type FineTuningJobError struct {
+	Code    string  `json:"code,omitempty"`
+	Message string  `json:"message"`
+	Param   *string `json:"param,omitempty"`
+}
+
+// FineTuningJob is the provider-agnostic view of a fine-tuning job.
+//   - OpenAI:  ID is "ftjob-..."
+//   - Vertex:  ID is "projects/{p}/locations/{l}/tuningJobs/{id}"
+//   - Bedrock: ID is the model customization job ARN
+type FineTuningJob struct {
+	ID              string                     `json:"id"`
+	Object          string                     `json:"object,omitempty"`
+	Model           string                     `json:"model"`
+	FineTunedModel  *string                    `json:"fine_tuned_model,omitempty"`
+	Status          FineTuningJobStatus        `json:"status"`
+	TrainingFile    string                     `json:"training_file,omitempty"`
+	ValidationFile  *string                    `json:"validation_file,omitempty"`
+	Hyperparameters *FineTuningHyperparameters `json:"hyperparameters,omitempty"`
+	TrainedTokens   *int                       `json:"trained_tokens,omitempty"`
+	Error           *FineTuningJobError        `json:"error,omitempty"`
+	Suffix          *string                    `json:"suffix,omitempty"`
+	Metadata        map[string]string          `json:"metadata,omitempty"`
+	CreatedAt       int64                      `json:"created_at"`
+	FinishedAt      *int64                     `json:"finished_at,omitempty"`
+	EstimatedFinish *int64                     `json:"estimated_finish,omitempty"`
+}
+
+// BifrostFineTuningJobCreateRequest launches a fine-tuning job against a base model.
+// TrainingFile is a provider file ID (OpenAI) or a storage URI (gs:// for Vertex,
+// s3:// for Bedrock).
+type BifrostFineTuningJobCreateRequest struct {
+	Provider        ModelProvider              `json:"provider"`
+	Model           string                     `json:"model"`
+	TrainingFile    string                     `json:"training_file"`
+	ValidationFile  *string                    `json:"validation_file,omitempty"`
+	Suffix          *string                    `json:"suffix,omitempty"`
+	Hyperparameters *FineTuningHyperparameters `json:"hyperparameters,omitempty"`
+	Seed            *int                       `json:"seed,omitempty"`
+	Metadata        map[string]string          `json:"metadata,omitempty"`
+
+	RawRequestBody []byte         `json:"-"`
+	ExtraParams    map[string]any `json:"-"`
+}
+
+// GetRawRequestBody returns the raw request body.
+func (r *BifrostFineTuningJobCreateRequest) GetRawRequestBody() []byte { return r.RawRequestBody }
+
+// BifrostFineTuningJobCreateResponse is the response from creating a fine-tuning job.
+type BifrostFineTuningJobCreateResponse struct {
+	FineTuningJob
+
+	ExtraFields BifrostResponseExtraFields `json:"extra_fields"`
+}
+
+// BifrostFineTuningJobListRequest lists fine-tuning jobs.
+type BifrostFineTuningJobListRequest struct {
+	Provider ModelProvider `json:"provider"`
+	Model    *string       `json:"model"`
+
+	// Pagination
+	Limit int     `json:"limit,omitempty"`
+	After *string `json:"after,omitempty"`
+
+	ExtraParams map[string]any `json:"-"`
+}
+
+// BifrostFineTuningJobListResponse is the response from listing fine-tuning jobs.
+type BifrostFineTuningJobListResponse struct {
+	Object     string          `json:"object"`
+	Data       []FineTuningJob `json:"data"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor *string         `json:"next_cursor,omitempty"`
+
+	ExtraFields BifrostResponseExtraFields `json:"extra_fields"`
+}
+
+// BifrostFineTuningJobRetrieveRequest polls a single fine-tuning job.
+type BifrostFineTuningJobRetrieveRequest struct {
+	Provider ModelProvider `json:"provider"`
+	Model    *string       `json:"model"`
+	JobID    string        `json:"job_id"`
+
+	ExtraParams map[string]any `json:"-"`
+}
+
+// BifrostFineTuningJobRetrieveResponse is the response from retrieving a fine-tuning job.
+type BifrostFineTuningJobRetrieveResponse struct {
+	FineTuningJob
+
+	ExtraFields BifrostResponseExtraFields `json:"extra_fields"`
+}
+
+// BifrostFineTuningJobCancelRequest cancels a running fine-tuning job.
+type BifrostFineTuningJobCancelRequest struct {
+	Provider ModelProvider `json:"provider"`
+	Model    *string       `json:"model"`
+	JobID    string        `json:"job_id"`
+
+	ExtraParams map[string]any `json:"-"`
+}
+
+// BifrostFineTuningJobCancelResponse is the response from cancelling a fine-tuning job.
+type BifrostFineTuningJobCancelResponse struct {
+	FineTuningJob
+
+	ExtraFields BifrostResponseExtraFields `json:"extra_fields"`
+}
+
+// FineTuningLogParams is the subset of a fine-tuning request recorded in the
+// request log so training launches can be audited.
+type FineTuningLogParams struct {
+	JobID           string                     `json:"job_id,omitempty"`
+	TrainingFile    string                     `json:"training_file,omitempty"`
+	ValidationFile  *string                    `json:"validation_file,omitempty"`
+	Suffix          *string                    `json:"suffix,omitempty"`
+	Hyperparameters *FineTuningHyperparameters `json:"hyperparameters,omitempty"`
+}
@@ -0,0 +1,33 @@
+package schemas
+
+import "fmt"
+
+// ImagePreprocessingConfig enables normalization of images in chat content
+// blocks before they reach a provider. Each attempt (primary or fallback) is
+// prepared for its own provider: image URLs are fetched and inlined for
+// providers that only accept inline bytes, and inline images above the
+// provider's size limit are downscaled or rejected with a clear error instead
+// of an opaque upstream one.
+type ImagePreprocessingConfig struct {
+	// Downscale re-encodes oversized inline images at a smaller resolution
+	// until they fit the provider's limit. When false, oversized images fail
+	// the attempt.
+	Downscale bool `json:"downscale,omitempty"`
+	// InlineURLs fetches image URLs and inlines them for every provider, not
+	// only for the ones that cannot fetch URLs themselves, so size limits are
+	// also enforced on linked images.
+	InlineURLs bool `json:"inline_urls,omitempty"`
+	// MaxImageBytes overrides the per-provider limit on the base64 payload of
+	// one image. 0 removes the limit for that provider.
+	MaxImageBytes map[ModelProvider]int `json:"max_image_bytes,omitempty"`
+}
+
+// Validate checks that the size limits are usable.
+func (c ImagePreprocessingConfig) Validate() error {
+	for provider, limit := range c.MaxImageBytes {
+		if limit < 0 {
+			return fmt.Errorf("max_image_bytes for %s must not be negative", provider)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 // Package schemas defines the core schemas and types used by the Bifrost system.
 package schemas
 
+import "context"
+
 // LogLevel represents the severity level of a log message.
 // Internally it maps to zerolog.Level for interoperability.
 type LogLevel string
@@ -20,8 +22,55 @@ type LoggerOutputType string
 const (
 	LoggerOutputTypeJSON   LoggerOutputType = "json"
 	LoggerOutputTypePretty LoggerOutputType = "pretty"
+	// LoggerOutputTypeStructured emits JSON lines that additionally carry request
+	// correlation fields (request_id, trace_id, provider, model, component) for
+	// loggers obtained through WithLogFields.
+	LoggerOutputTypeStructured LoggerOutputType = "structured"
 )
 
+// LogFields holds the request correlation fields attached to structured log lines.
+// Empty fields are omitted from the output.
+type LogFields struct {
+	RequestID string
+	TraceID   string
+	Provider  ModelProvider
+	Model     string
+	Component string
+}
+
+// FieldLogger is implemented by loggers that can bind request correlation fields
+// to every line they emit. It is optional: loggers that don't implement it are
+// used as-is by WithLogFields.
+type FieldLogger interface {
+	// WithFields returns a logger that attaches the given fields to every log line.
+	WithFields(fields LogFields) Logger
+}
+
+// LogFieldsFromContext extracts the request ID, trace ID and the routed provider/model
+// (from the RoutingInfo snapshot, when present) from ctx.
+func LogFieldsFromContext(ctx context.Context) LogFields {
+	var fields LogFields
+	if ctx == nil {
+		return fields
+	}
+	fields.RequestID, _ = ctx.Value(BifrostContextKeyRequestID).(string)
+	fields.TraceID, _ = ctx.Value(BifrostContextKeyTraceID).(string)
+	if ri, ok := ctx.Value(BifrostContextKeyRoutingInfo).(RoutingInfo); ok {
+		fields.Provider = ri.Provider
+		fields.Model = ri.Model
+	}
+	return fields
+}
+
+// WithLogFields returns a logger bound to fields if logger implements FieldLogger,
+// otherwise logger is returned unchanged.
+func WithLogFields(logger Logger, fields LogFields) Logger {
+	if fl, ok := logger.(FieldLogger); ok {
+		return fl.WithFields(fields)
+	}
+	return logger
+}
+
 // Logger defines the interface for logging operations in the Bifrost system.
 // Implementations of this interface should provide methods for logging messages
 // at different severity levels.
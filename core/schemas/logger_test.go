@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldRecordingLogger struct {
+	noopLogger
+	fields LogFields
+}
+
+func (l *fieldRecordingLogger) WithFields(fields LogFields) Logger {
+	return &fieldRecordingLogger{fields: fields}
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                            {}
+func (noopLogger) Info(string, ...any)                             {}
+func (noopLogger) Warn(string, ...any)                             {}
+func (noopLogger) Error(string, ...any)                            {}
+func (noopLogger) Fatal(string, ...any)                            {}
+func (noopLogger) SetLevel(LogLevel)                               {}
+func (noopLogger) SetOutputType(LoggerOutputType)                  {}
+func (noopLogger) LogHTTPRequest(LogLevel, string) LogEventBuilder { return NoopLogEvent }
+
+func TestLogFieldsFromContext(t *testing.T) {
+	t.Run("nil context returns empty fields", func(t *testing.T) {
+		assert.Equal(t, LogFields{}, LogFieldsFromContext(nil))
+	})
+
+	t.Run("extracts request, trace and routing fields", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), BifrostContextKeyRequestID, "req-1")
+		ctx = context.WithValue(ctx, BifrostContextKeyTraceID, "trace-1")
+		ctx = context.WithValue(ctx, BifrostContextKeyRoutingInfo, RoutingInfo{Provider: OpenAI, Model: "gpt-4o"})
+
+		assert.Equal(t, LogFields{
+			RequestID: "req-1",
+			TraceID:   "trace-1",
+			Provider:  OpenAI,
+			Model:     "gpt-4o",
+		}, LogFieldsFromContext(ctx))
+	})
+}
+
+func TestWithLogFields(t *testing.T) {
+	fields := LogFields{RequestID: "req-1", Component: "core"}
+
+	t.Run("field logger is bound to fields", func(t *testing.T) {
+		bound := WithLogFields(&fieldRecordingLogger{}, fields)
+		recorder, ok := bound.(*fieldRecordingLogger)
+		assert.True(t, ok)
+		assert.Equal(t, fields, recorder.fields)
+	})
+
+	t.Run("plain logger is returned unchanged", func(t *testing.T) {
+		var plain Logger = noopLogger{}
+		assert.Equal(t, plain, WithLogFields(plain, fields))
+	})
+}
@@ -285,6 +285,35 @@ const (
 	CodeModeBindingLevelTool   CodeModeBindingLevel = "tool"
 )
 
+// MCPOutputSchemaValidation defines what Bifrost does when a tool result does not match
+// the output schema the tool declared.
+type MCPOutputSchemaValidation string
+
+const (
+	MCPOutputSchemaValidationOff    MCPOutputSchemaValidation = "off"    // Results are passed through unchecked (default)
+	MCPOutputSchemaValidationWarn   MCPOutputSchemaValidation = "warn"   // Mismatches are logged and recorded, the result is passed through
+	MCPOutputSchemaValidationCoerce MCPOutputSchemaValidation = "coerce" // Scalars are converted to the declared types and undeclared fields dropped where that makes the result match
+	MCPOutputSchemaValidationError  MCPOutputSchemaValidation = "error"  // A mismatching result is replaced by an error the model sees as the tool result
+)
+
+// IsValid reports whether v is a known validation mode; empty means off.
+func (v MCPOutputSchemaValidation) IsValid() bool {
+	switch v {
+	case "", MCPOutputSchemaValidationOff, MCPOutputSchemaValidationWarn, MCPOutputSchemaValidationCoerce, MCPOutputSchemaValidationError:
+		return true
+	}
+	return false
+}
+
+// MCPOutputSchemaValidationResult records how a tool result fared against the tool's
+// declared output schema. It is only set for tools that declare one.
+type MCPOutputSchemaValidationResult struct {
+	Mode    MCPOutputSchemaValidation `json:"mode"`
+	Valid   bool                      `json:"valid"`             // The result returned to the model matches the schema
+	Coerced bool                      `json:"coerced,omitempty"` // The result was rewritten to match
+	Error   string                    `json:"error,omitempty"`   // Why the tool's original result did not match
+}
+
 // MCPAuthType defines the authentication type for MCP connections
 type MCPAuthType string
 
@@ -342,6 +371,10 @@ type MCPClientConfig struct {
 	ConfigHash            string             `json:"-"`                            // Config hash for reconciliation (not serialized)
 	AllowOnAllVirtualKeys bool               `json:"allow_on_all_virtual_keys"`    // Whether to allow the MCP client to run on all virtual keys
 
+	// OutputValidation checks tool results against the output schema each tool
+	// declares; empty means off.
+	OutputValidation MCPOutputSchemaValidation `json:"output_schema_validation,omitempty"`
+
 	// Discovered tools for per-user OAuth clients (persisted so they survive restart)
 	DiscoveredTools           map[string]ChatTool `json:"-"` // Discovered tool schemas keyed by prefixed name
 	DiscoveredToolNameMapping map[string]string   `json:"-"` // Mapping from sanitized tool names to original MCP names
@@ -0,0 +1,62 @@
+package schemas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModelGroupTarget is one concrete deployment a model group resolves to.
+type ModelGroupTarget struct {
+	Provider ModelProvider `json:"provider"`
+	Model    string        `json:"model"`
+	// Params override request parameters when this target is tried, e.g.
+	// {"temperature": 0.2, "max_completion_tokens": 2048}. A null value removes
+	// the parameter; keys that are not Bifrost parameters go to ExtraParams.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// ModelGroup is a logical model name (e.g. "prod-chat-large") that clients can
+// send instead of a concrete model. It resolves to its targets in order: the
+// first is tried as the primary and the rest as fallbacks, ahead of any
+// fallbacks the caller sent.
+//
+// EmbeddingCompatible declares that the targets' embedding models produce
+// vectors in the same space (same model family and dimensions). Embedding
+// requests only fall back to a different model within such a group, since
+// vectors from another model cannot be mixed into the caller's index.
+type ModelGroup struct {
+	Name                string             `json:"name"`
+	Targets             []ModelGroupTarget `json:"targets"`
+	EmbeddingCompatible bool               `json:"embedding_compatible,omitempty"`
+}
+
+// Validate checks that the group has a name that cannot be mistaken for a
+// provider-prefixed model, and at least one complete target.
+func (g ModelGroup) Validate() error {
+	name := strings.TrimSpace(g.Name)
+	if name == "" {
+		return fmt.Errorf("model group name is required")
+	}
+	if provider, _ := ParseModelString(name, ""); provider != "" {
+		return fmt.Errorf("model group %q: name must not start with a provider prefix", name)
+	}
+	if len(g.Targets) == 0 {
+		return fmt.Errorf("model group %q: at least one target is required", name)
+	}
+	for i, target := range g.Targets {
+		if target.Provider == "" || strings.TrimSpace(target.Model) == "" {
+			return fmt.Errorf("model group %q: target %d needs a provider and a model", name, i)
+		}
+	}
+	return nil
+}
+
+// Target returns the group's target for the given provider and model, or nil.
+func (g ModelGroup) Target(provider ModelProvider, model string) *ModelGroupTarget {
+	for i := range g.Targets {
+		if g.Targets[i].Provider == provider && g.Targets[i].Model == model {
+			return &g.Targets[i]
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package schemas
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -1092,6 +1093,11 @@ func (cr *BifrostChatRequest) ToResponsesRequest() *BifrostResponsesRequest {
 			Metadata:        cr.Params.Metadata,
 		}
 
+		// The Responses API asks for logprobs through include rather than a flag
+		if cr.Params.LogProbs != nil && *cr.Params.LogProbs {
+			brr.Params.Include = []string{ResponsesIncludeOutputTextLogProbs}
+		}
+
 		// Convert StreamOptions
 		if cr.Params.StreamOptions != nil {
 			brr.Params.StreamOptions = &ResponsesStreamOptions{
@@ -1108,6 +1114,10 @@ func (cr *BifrostChatRequest) ToResponsesRequest() *BifrostResponsesRequest {
 			}
 			brr.Params.Tools = responsesTools
 		}
+		// Chat's web_search_options is the Responses web_search tool
+		if cr.Params.WebSearchOptions != nil && !slices.ContainsFunc(brr.Params.Tools, isResponsesWebSearchTool) {
+			brr.Params.Tools = append(brr.Params.Tools, cr.Params.WebSearchOptions.ToResponsesTool())
+		}
 
 		// Convert ToolChoice using existing ChatToolChoice.ToResponsesToolChoice()
 		if cr.Params.ToolChoice != nil {
@@ -1208,6 +1218,11 @@ func (brr *BifrostResponsesRequest) ToChatRequest() *BifrostChatRequest {
 			Metadata:            brr.Params.Metadata,
 		}
 
+		// Chat rejects top_logprobs unless logprobs is set, so either signal turns it on
+		if slices.Contains(brr.Params.Include, ResponsesIncludeOutputTextLogProbs) || (brr.Params.TopLogProbs != nil && *brr.Params.TopLogProbs > 0) {
+			bcr.Params.LogProbs = Ptr(true)
+		}
+
 		// Convert StreamOptions
 		if brr.Params.StreamOptions != nil {
 			bcr.Params.StreamOptions = &ChatStreamOptions{
@@ -1218,6 +1233,10 @@ func (brr *BifrostResponsesRequest) ToChatRequest() *BifrostChatRequest {
 
 		// Responses -> Chat fallback only supports function tools in a valid chat shape.
 		bcr.Params.Tools = sanitizeResponsesToolsForChatFallback(brr.Params.Tools)
+		// except web search, which chat carries as web_search_options.
+		if i := slices.IndexFunc(brr.Params.Tools, isResponsesWebSearchTool); i >= 0 {
+			bcr.Params.WebSearchOptions = brr.Params.Tools[i].toChatWebSearchOptions()
+		}
 
 		// Convert ToolChoice using existing ResponsesToolChoice.ToChatToolChoice()
 		if brr.Params.ToolChoice != nil {
@@ -1267,6 +1286,62 @@ func (brr *BifrostResponsesRequest) ToChatRequest() *BifrostChatRequest {
 	return bcr
 }
 
+func isResponsesWebSearchTool(tool ResponsesTool) bool {
+	return tool.Type == ResponsesToolTypeWebSearch || tool.Type == ResponsesToolTypeWebSearchPreview
+}
+
+// toChatWebSearchOptions converts a web_search tool to Chat's web_search_options.
+func (rt *ResponsesTool) toChatWebSearchOptions() *ChatWebSearchOptions {
+	options := &ChatWebSearchOptions{}
+	if rt.ResponsesToolWebSearch == nil {
+		return options
+	}
+	ws := rt.ResponsesToolWebSearch
+	options.SearchContextSize = ws.SearchContextSize
+	if ws.Filters != nil {
+		options.Filters = &ChatWebSearchOptionsFilters{
+			AllowedDomains:  ws.Filters.AllowedDomains,
+			BlockedDomains:  ws.Filters.BlockedDomains,
+			TimeRangeFilter: ws.Filters.TimeRangeFilter,
+		}
+	}
+	if loc := ws.UserLocation; loc != nil {
+		options.UserLocation = &ChatWebSearchOptionsUserLocation{
+			Type: "approximate",
+			Approximate: &ChatWebSearchOptionsUserLocationApproximate{
+				City:     loc.City,
+				Country:  loc.Country,
+				Region:   loc.Region,
+				Timezone: loc.Timezone,
+			},
+		}
+	}
+	return options
+}
+
+// ToResponsesTool converts web_search_options to the equivalent Responses web_search tool.
+func (o *ChatWebSearchOptions) ToResponsesTool() ResponsesTool {
+	ws := &ResponsesToolWebSearch{SearchContextSize: o.SearchContextSize}
+	if o.Filters != nil {
+		ws.Filters = &ResponsesToolWebSearchFilters{
+			AllowedDomains:  o.Filters.AllowedDomains,
+			BlockedDomains:  o.Filters.BlockedDomains,
+			TimeRangeFilter: o.Filters.TimeRangeFilter,
+		}
+	}
+	if o.UserLocation != nil && o.UserLocation.Approximate != nil {
+		loc := o.UserLocation.Approximate
+		ws.UserLocation = &ResponsesToolWebSearchUserLocation{
+			City:     loc.City,
+			Country:  loc.Country,
+			Region:   loc.Region,
+			Timezone: loc.Timezone,
+			Type:     Ptr("approximate"),
+		}
+	}
+	return ResponsesTool{Type: ResponsesToolTypeWebSearch, ResponsesToolWebSearch: ws}
+}
+
 func sanitizeResponsesToolsForChatFallback(tools []ResponsesTool) []ChatTool {
 	if len(tools) == 0 {
 		return nil
@@ -1384,6 +1459,66 @@ func responsesTerminalFromChatFinishReason(finishReason *string) (eventType Resp
 	return eventType, mappedStatus, mappedIncompleteDetails
 }
 
+// chatLogProbsToResponses converts chat content logprobs to output_text
+// logprobs. Both carry the same per-token fields; nil means none were returned.
+func chatLogProbsToResponses(logProbs *BifrostLogProbs) []ResponsesOutputMessageContentTextLogProb {
+	if logProbs == nil || len(logProbs.Content) == 0 {
+		return nil
+	}
+	converted := make([]ResponsesOutputMessageContentTextLogProb, len(logProbs.Content))
+	for i, logProb := range logProbs.Content {
+		converted[i] = ResponsesOutputMessageContentTextLogProb(logProb)
+	}
+	return converted
+}
+
+// responsesLogProbsToChat is the inverse of chatLogProbsToResponses.
+func responsesLogProbsToChat(logProbs []ResponsesOutputMessageContentTextLogProb) *BifrostLogProbs {
+	if len(logProbs) == 0 {
+		return nil
+	}
+	content := make([]ContentLogProb, len(logProbs))
+	for i, logProb := range logProbs {
+		content[i] = ContentLogProb(logProb)
+	}
+	return &BifrostLogProbs{Content: content}
+}
+
+// attachOutputTextLogProbs sets logProbs on the first output_text block of messages.
+func attachOutputTextLogProbs(messages []ResponsesMessage, logProbs []ResponsesOutputMessageContentTextLogProb) {
+	if len(logProbs) == 0 {
+		return
+	}
+	for _, message := range messages {
+		if message.Content == nil {
+			continue
+		}
+		for i := range message.Content.ContentBlocks {
+			block := &message.Content.ContentBlocks[i]
+			if block.Type == ResponsesOutputMessageContentTypeText && block.ResponsesOutputMessageContentText != nil {
+				block.ResponsesOutputMessageContentText.LogProbs = logProbs
+				return
+			}
+		}
+	}
+}
+
+// outputTextLogProbs collects the logprobs of every output_text block in output, in order.
+func outputTextLogProbs(output []ResponsesMessage) []ResponsesOutputMessageContentTextLogProb {
+	var logProbs []ResponsesOutputMessageContentTextLogProb
+	for _, message := range output {
+		if message.Content == nil {
+			continue
+		}
+		for _, block := range message.Content.ContentBlocks {
+			if block.Type == ResponsesOutputMessageContentTypeText && block.ResponsesOutputMessageContentText != nil {
+				logProbs = append(logProbs, block.ResponsesOutputMessageContentText.LogProbs...)
+			}
+		}
+	}
+	return logProbs
+}
+
 // ToBifrostResponsesResponse converts the BifrostChatResponse to BifrostResponsesResponse format
 // This converts Chat-style fields (Choices) to Responses API format
 func (cr *BifrostChatResponse) ToBifrostResponsesResponse() *BifrostResponsesResponse {
@@ -1408,6 +1543,7 @@ func (cr *BifrostChatResponse) ToBifrostResponsesResponse() *BifrostResponsesRes
 		if choice.ChatNonStreamResponseChoice != nil && choice.ChatNonStreamResponseChoice.Message != nil {
 			// Convert ChatMessage to ResponsesMessages
 			responsesMessages := choice.ChatNonStreamResponseChoice.Message.ToResponsesMessages()
+			attachOutputTextLogProbs(responsesMessages, chatLogProbsToResponses(choice.LogProbs))
 			outputMessages = append(outputMessages, responsesMessages...)
 		}
 	}
@@ -1491,6 +1627,15 @@ func (responsesResp *BifrostResponsesResponse) ToBifrostChatResponse() *BifrostC
 			}
 			choices = append(choices, choice)
 		}
+		// Logprobs belong to the assistant text, which the first choice with content carries
+		if logProbs := responsesLogProbsToChat(outputTextLogProbs(responsesResp.Output)); logProbs != nil {
+			for i := range choices {
+				if choices[i].Message.Content != nil {
+					choices[i].LogProbs = logProbs
+					break
+				}
+			}
+		}
 
 		chatResp.Choices = choices
 	}
@@ -1876,6 +2021,9 @@ func (cr *BifrostChatResponse) ToBifrostResponsesStreamResponse(state *ChatToRes
 			LogProbs:       []ResponsesOutputMessageContentTextLogProb{},
 			ExtraFields:    cr.ExtraFields,
 		}
+		if logProbs := chatLogProbsToResponses(choice.LogProbs); logProbs != nil {
+			response.LogProbs = logProbs
+		}
 		if itemID != "" {
 			response.ItemID = &itemID
 		}
@@ -2370,7 +2518,8 @@ func (rsr *BifrostResponsesStreamResponse) ToBifrostChatResponse() *BifrostChatR
 	case ResponsesStreamResponseTypeOutputTextDelta:
 		resp.Choices = []BifrostResponseChoice{
 			{
-				Index: 0,
+				Index:    0,
+				LogProbs: responsesLogProbsToChat(rsr.LogProbs),
 				ChatStreamResponseChoice: &ChatStreamResponseChoice{
 					Delta: &ChatStreamResponseChoiceDelta{
 						Content: rsr.Delta,
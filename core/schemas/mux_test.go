@@ -1336,3 +1336,105 @@ func TestToBifrostResponsesStreamResponse_ReasoningOpensThinkingBlock(t *testing
 		t.Fatalf("text output index %d must be greater than reasoning output index %d", textOutputIndex, reasoningOutputIndex)
 	}
 }
+
+func TestLogProbs_SurviveChatResponsesRoundTrip(t *testing.T) {
+	logProbs := &BifrostLogProbs{Content: []ContentLogProb{
+		{Token: "Hi", LogProb: -0.1, Bytes: []int{72, 105}, TopLogProbs: []LogProb{{Token: "Hi", LogProb: -0.1}, {Token: "Hey", LogProb: -2.3}}},
+		{Token: "!", LogProb: -0.5, Bytes: []int{33}, TopLogProbs: []LogProb{}},
+	}}
+	chatResp := &BifrostChatResponse{
+		ID:    "chatcmpl-1",
+		Model: "gpt-4o",
+		Choices: []BifrostResponseChoice{{
+			FinishReason: Ptr("stop"),
+			LogProbs:     logProbs,
+			ChatNonStreamResponseChoice: &ChatNonStreamResponseChoice{
+				Message: &ChatMessage{Role: ChatMessageRoleAssistant, Content: &ChatMessageContent{ContentStr: Ptr("Hi!")}},
+			},
+		}},
+	}
+
+	responsesResp := chatResp.ToBifrostResponsesResponse()
+	if len(responsesResp.Output) != 1 || responsesResp.Output[0].Content == nil {
+		t.Fatalf("expected one output message, got %+v", responsesResp.Output)
+	}
+	text := responsesResp.Output[0].Content.ContentBlocks[0].ResponsesOutputMessageContentText
+	if len(text.LogProbs) != 2 || text.LogProbs[1].Token != "!" || len(text.LogProbs[0].TopLogProbs) != 2 {
+		t.Fatalf("output_text logprobs not carried over: %+v", text.LogProbs)
+	}
+
+	roundTripped := responsesResp.ToBifrostChatResponse()
+	got, _ := json.Marshal(roundTripped.Choices[0].LogProbs)
+	want, _ := json.Marshal(logProbs)
+	if string(got) != string(want) {
+		t.Fatalf("logprobs changed in the round trip:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestLogProbs_StreamDeltasCarryLogProbs(t *testing.T) {
+	state := AcquireChatToResponsesStreamState()
+	defer ReleaseChatToResponsesStreamState(state)
+	chunk := &BifrostChatResponse{
+		ID: "chatcmpl-1",
+		Choices: []BifrostResponseChoice{{
+			LogProbs:                 &BifrostLogProbs{Content: []ContentLogProb{{Token: "Hi", LogProb: -0.1}}},
+			ChatStreamResponseChoice: &ChatStreamResponseChoice{Delta: &ChatStreamResponseChoiceDelta{Content: Ptr("Hi")}},
+		}},
+	}
+	var delta *BifrostResponsesStreamResponse
+	for _, event := range chunk.ToBifrostResponsesStreamResponse(state) {
+		if event.Type == ResponsesStreamResponseTypeOutputTextDelta {
+			delta = event
+		}
+	}
+	if delta == nil || len(delta.LogProbs) != 1 || delta.LogProbs[0].Token != "Hi" {
+		t.Fatalf("expected an output_text.delta carrying the chunk's logprobs, got %+v", delta)
+	}
+
+	chatChunk := delta.ToBifrostChatResponse()
+	if chatChunk.Choices[0].LogProbs == nil || chatChunk.Choices[0].LogProbs.Content[0].LogProb != -0.1 {
+		t.Fatalf("logprobs lost converting the delta back to chat: %+v", chatChunk.Choices[0].LogProbs)
+	}
+}
+
+func TestLogProbs_RequestFlagMapsToInclude(t *testing.T) {
+	chatReq := &BifrostChatRequest{Params: &ChatParameters{LogProbs: Ptr(true), TopLogProbs: Ptr(3)}}
+	responsesReq := chatReq.ToResponsesRequest()
+	if len(responsesReq.Params.Include) != 1 || responsesReq.Params.Include[0] != ResponsesIncludeOutputTextLogProbs {
+		t.Fatalf("expected include %q, got %v", ResponsesIncludeOutputTextLogProbs, responsesReq.Params.Include)
+	}
+
+	back := (&BifrostResponsesRequest{Params: &ResponsesParameters{TopLogProbs: Ptr(3)}}).ToChatRequest()
+	if back.Params.LogProbs == nil || !*back.Params.LogProbs {
+		t.Fatal("top_logprobs without logprobs is rejected by chat providers; logprobs must be turned on")
+	}
+}
+
+func TestWebSearchToolSurvivesChatResponsesConversion(t *testing.T) {
+	responsesReq := &BifrostResponsesRequest{
+		Params: &ResponsesParameters{Tools: []ResponsesTool{
+			{Type: ResponsesToolTypeFunction, Name: Ptr("lookup"), ResponsesToolFunction: &ResponsesToolFunction{}},
+			{Type: ResponsesToolTypeWebSearch, ResponsesToolWebSearch: &ResponsesToolWebSearch{
+				SearchContextSize: Ptr("low"),
+				Filters:           &ResponsesToolWebSearchFilters{AllowedDomains: []string{"example.com"}},
+				UserLocation:      &ResponsesToolWebSearchUserLocation{Country: Ptr("US"), Type: Ptr("approximate")},
+			}},
+		}},
+	}
+
+	chatReq := responsesReq.ToChatRequest()
+	options := chatReq.Params.WebSearchOptions
+	if options == nil || *options.SearchContextSize != "low" || options.Filters.AllowedDomains[0] != "example.com" || *options.UserLocation.Approximate.Country != "US" {
+		t.Fatalf("expected web_search to become web_search_options, got %+v", options)
+	}
+	if len(chatReq.Params.Tools) != 1 || chatReq.Params.Tools[0].Function.Name != "lookup" {
+		t.Fatalf("expected only the function tool in chat tools, got %+v", chatReq.Params.Tools)
+	}
+
+	back := chatReq.ToResponsesRequest()
+	if len(back.Params.Tools) != 2 || back.Params.Tools[1].Type != ResponsesToolTypeWebSearch ||
+		*back.Params.Tools[1].ResponsesToolWebSearch.SearchContextSize != "low" ||
+		*back.Params.Tools[1].ResponsesToolWebSearch.UserLocation.Country != "US" {
+		t.Fatalf("expected web_search_options to become a web_search tool, got %+v", back.Params.Tools)
+	}
+}
@@ -388,6 +388,20 @@ type ConfigMarshallerPlugin interface {
 	RedactConfig(config map[string]any) (map[string]any, error)
 }
 
+// ConfigSchemaPlugin is optionally implemented by plugins that describe their config
+// with a JSON Schema. The server serves the schema at GET /api/plugins/{name}/schema so
+// the UI can render a config form without plugin-specific code, and validates configs
+// written through the plugins API against it. Like ConfigMarshallerPlugin, it is
+// detected with a type assertion on the loaded plugin.
+type ConfigSchemaPlugin interface {
+	BasePlugin
+
+	// ConfigSchema returns the JSON Schema document (draft 2020-12) for the config map
+	// accepted by the plugins API. Fields that take a SecretVar should accept both the
+	// plain string form and the object form.
+	ConfigSchema() []byte
+}
+
 // ObservabilityPlugin is an interface for plugins that receive completed traces
 // for forwarding to observability backends (e.g., OTEL collectors, Datadog, etc.)
 //
@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultPluginDegradationWindowSeconds is the window failures are counted
+// over when a policy leaves WindowSeconds unset.
+const DefaultPluginDegradationWindowSeconds = 60
+
+// PluginDegradationPolicy is a plugin's error budget. When its LLM hooks fail
+// MaxFailures times within the window, Bifrost bypasses the plugin (skips its
+// hooks) instead of degrading every request, and logs an error. A hook call
+// fails when it returns an error or, with a latency budget, when it runs
+// longer than the budget.
+type PluginDegradationPolicy struct {
+	MaxFailures     int `json:"max_failures"`                // Failed hook calls within the window that bypass the plugin
+	WindowSeconds   int `json:"window_seconds,omitempty"`    // Window failures are counted over (default: 60)
+	LatencyBudgetMs int `json:"latency_budget_ms,omitempty"` // Hook calls slower than this count as failures (0 = no latency budget)
+	BypassSeconds   int `json:"bypass_seconds,omitempty"`    // How long the plugin stays bypassed (0 = until re-enabled)
+}
+
+// Validate checks that the policy's thresholds are usable.
+func (p PluginDegradationPolicy) Validate() error {
+	if p.MaxFailures <= 0 {
+		return fmt.Errorf("max_failures must be positive")
+	}
+	if p.WindowSeconds < 0 || p.LatencyBudgetMs < 0 || p.BypassSeconds < 0 {
+		return fmt.Errorf("window_seconds, latency_budget_ms and bypass_seconds must not be negative")
+	}
+	return nil
+}
+
+// DefaultObservabilityDegradationPolicy is the policy of Bifrost's
+// observability plugins: bypassed for five minutes after 100 failed hook calls,
+// or calls slower than a second, within a minute.
+func DefaultObservabilityDegradationPolicy() *PluginDegradationPolicy {
+	return &PluginDegradationPolicy{MaxFailures: 100, WindowSeconds: 60, LatencyBudgetMs: 1000, BypassSeconds: 300}
+}
+
+// DegradablePlugin is optionally implemented by plugins that can safely be
+// bypassed when they keep failing, such as observability plugins: requests
+// served without them lose telemetry, not correctness. Plugins that enforce
+// policy (governance, guardrails) must not implement it, and are never
+// bypassed. A nil policy opts out.
+type DegradablePlugin interface {
+	DegradationPolicy() *PluginDegradationPolicy
+}
+
+// PluginHealth reports a degradable plugin's error budget.
+type PluginHealth struct {
+	Name     string                  `json:"name"`
+	Policy   PluginDegradationPolicy `json:"policy"`
+	Failures int                     `json:"failures"` // Failed hook calls in the current window
+	Bypassed bool                    `json:"bypassed"`
+	// BypassedAt and BypassedUntil are set while the plugin is bypassed;
+	// BypassedUntil is nil when only an admin can re-enable it.
+	BypassedAt    *time.Time `json:"bypassed_at,omitempty"`
+	BypassedUntil *time.Time `json:"bypassed_until,omitempty"`
+	Reason        string     `json:"reason,omitempty"` // Last failure that bypassed the plugin
+	Bypasses      int64      `json:"bypasses"`         // Times the plugin was bypassed since it was loaded
+}
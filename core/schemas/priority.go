@@ -0,0 +1,22 @@
+package schemas
+
+import "strings"
+
+// RequestPriority is the queueing class of a request. When a provider's
+// workers are saturated, queued interactive requests are always handed to a
+// worker before queued batch requests.
+type RequestPriority string
+
+const (
+	RequestPriorityInteractive RequestPriority = "interactive"
+	RequestPriorityBatch       RequestPriority = "batch"
+)
+
+// ParseRequestPriority parses a priority class name, case-insensitively.
+func ParseRequestPriority(value string) (RequestPriority, bool) {
+	switch priority := RequestPriority(strings.ToLower(strings.TrimSpace(value))); priority {
+	case RequestPriorityInteractive, RequestPriorityBatch:
+		return priority, true
+	}
+	return "", false
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +15,7 @@ const (
 	DefaultMaxRetries                 = 0
 	DefaultRetryBackoffInitial        = 500 * time.Millisecond
 	DefaultRetryBackoffMax            = 5 * time.Second
+	DefaultRetryJitter                = 0.2 // Fraction of each retry backoff that is randomised when NetworkConfig.RetryJitter is unset
 	DefaultRequestTimeoutInSeconds    = 300
 	DefaultMaxConnDurationInSeconds   = 300 // 5 minutes — forces connection recycling to prevent stale connections from NAT/LB silent drops
 	DefaultBufferSize                 = 5000
@@ -53,11 +56,15 @@ const (
 type NetworkConfig struct {
 	// BaseURL is supported for OpenAI, Anthropic, Cohere, Mistral, and Ollama providers (required for Ollama)
 	BaseURL                        string            `json:"base_url,omitempty"`                       // Base URL for the provider (optional)
+	RegionalBaseURLs               map[string]string `json:"regional_base_urls,omitempty"`             // Per-region base URLs keyed by deployment region; the entry for the gateway's region replaces BaseURL (optional)
 	ExtraHeaders                   map[string]string `json:"extra_headers,omitempty"`                  // Additional headers to include in requests (optional)
 	DefaultRequestTimeoutInSeconds int               `json:"default_request_timeout_in_seconds"`       // Default timeout for requests
 	MaxRetries                     int               `json:"max_retries"`                              // Maximum number of retries
 	RetryBackoffInitial            time.Duration     `json:"retry_backoff_initial"`                    // Initial backoff duration (stored as nanoseconds, JSON as milliseconds)
 	RetryBackoffMax                time.Duration     `json:"retry_backoff_max"`                        // Maximum backoff duration (stored as nanoseconds, JSON as milliseconds)
+	RetryJitter                    *float64          `json:"retry_jitter,omitempty"`                   // Fraction (0-1) of each backoff that is randomised; nil = DefaultRetryJitter, 0 = no jitter
+	RetryOnStatus                  []string          `json:"retry_on_status,omitempty"`                // Status classes ("5xx") or codes ("429") to retry; empty = 429/401/402/403 with key rotation and transient 5xx. Network errors are always retried
+	RespectRetryAfter              bool              `json:"respect_retry_after,omitempty"`            // Wait at least the provider's Retry-After on 429/503; a wait longer than RetryBackoffMax ends retries so fallbacks can run
 	InsecureSkipVerify             bool              `json:"insecure_skip_verify,omitempty"`           // Disables TLS certificate verification for provider connections
 	CACertPEM                      *SecretVar        `json:"ca_cert_pem,omitempty"`                    // PEM-encoded CA certificate to trust for provider endpoint connections (supports env.*)
 	StreamIdleTimeoutInSeconds     int               `json:"stream_idle_timeout_in_seconds,omitempty"` // Idle timeout per stream chunk (0 = use default 60s)
@@ -78,11 +85,15 @@ func (nc *NetworkConfig) UnmarshalJSON(data []byte) error {
 	// Use an alias type to avoid infinite recursion
 	type NetworkConfigAlias struct {
 		BaseURL                        string            `json:"base_url,omitempty"`
+		RegionalBaseURLs               map[string]string `json:"regional_base_urls,omitempty"`
 		ExtraHeaders                   map[string]string `json:"extra_headers,omitempty"`
 		DefaultRequestTimeoutInSeconds int               `json:"default_request_timeout_in_seconds"`
 		MaxRetries                     int               `json:"max_retries"`
 		RetryBackoffInitial            json.RawMessage   `json:"retry_backoff_initial"` // string ("500ms") or int (milliseconds)
 		RetryBackoffMax                json.RawMessage   `json:"retry_backoff_max"`     // string ("5s") or int (milliseconds)
+		RetryJitter                    *float64          `json:"retry_jitter,omitempty"`
+		RetryOnStatus                  []string          `json:"retry_on_status,omitempty"`
+		RespectRetryAfter              bool              `json:"respect_retry_after,omitempty"`
 		InsecureSkipVerify             bool              `json:"insecure_skip_verify,omitempty"`
 		CACertPEM                      *SecretVar        `json:"ca_cert_pem,omitempty"`
 		StreamIdleTimeoutInSeconds     int               `json:"stream_idle_timeout_in_seconds,omitempty"`
@@ -100,9 +111,13 @@ func (nc *NetworkConfig) UnmarshalJSON(data []byte) error {
 
 	// Copy all non-duration fields
 	nc.BaseURL = alias.BaseURL
+	nc.RegionalBaseURLs = alias.RegionalBaseURLs
 	nc.ExtraHeaders = alias.ExtraHeaders
 	nc.DefaultRequestTimeoutInSeconds = alias.DefaultRequestTimeoutInSeconds
 	nc.MaxRetries = alias.MaxRetries
+	nc.RetryJitter = alias.RetryJitter
+	nc.RetryOnStatus = alias.RetryOnStatus
+	nc.RespectRetryAfter = alias.RespectRetryAfter
 	nc.InsecureSkipVerify = alias.InsecureSkipVerify
 	nc.CACertPEM = alias.CACertPEM
 	nc.StreamIdleTimeoutInSeconds = alias.StreamIdleTimeoutInSeconds
@@ -171,11 +186,15 @@ func (nc NetworkConfig) MarshalJSON() ([]byte, error) {
 	// Use an alias type to avoid infinite recursion
 	type NetworkConfigAlias struct {
 		BaseURL                        string            `json:"base_url,omitempty"`
+		RegionalBaseURLs               map[string]string `json:"regional_base_urls,omitempty"`
 		ExtraHeaders                   map[string]string `json:"extra_headers,omitempty"`
 		DefaultRequestTimeoutInSeconds int               `json:"default_request_timeout_in_seconds"`
 		MaxRetries                     int               `json:"max_retries"`
 		RetryBackoffInitial            int64             `json:"retry_backoff_initial"` // milliseconds in JSON
 		RetryBackoffMax                int64             `json:"retry_backoff_max"`     // milliseconds in JSON
+		RetryJitter                    *float64          `json:"retry_jitter,omitempty"`
+		RetryOnStatus                  []string          `json:"retry_on_status,omitempty"`
+		RespectRetryAfter              bool              `json:"respect_retry_after,omitempty"`
 		InsecureSkipVerify             bool              `json:"insecure_skip_verify,omitempty"`
 		CACertPEM                      string            `json:"ca_cert_pem,omitempty"`
 		StreamIdleTimeoutInSeconds     int               `json:"stream_idle_timeout_in_seconds,omitempty"`
@@ -188,12 +207,16 @@ func (nc NetworkConfig) MarshalJSON() ([]byte, error) {
 
 	alias := NetworkConfigAlias{
 		BaseURL:                        nc.BaseURL,
+		RegionalBaseURLs:               nc.RegionalBaseURLs,
 		ExtraHeaders:                   nc.ExtraHeaders,
 		DefaultRequestTimeoutInSeconds: nc.DefaultRequestTimeoutInSeconds,
 		MaxRetries:                     nc.MaxRetries,
 		// Convert time.Duration (nanoseconds) to milliseconds
 		RetryBackoffInitial:        int64(nc.RetryBackoffInitial / time.Millisecond),
 		RetryBackoffMax:            int64(nc.RetryBackoffMax / time.Millisecond),
+		RetryJitter:                nc.RetryJitter,
+		RetryOnStatus:              nc.RetryOnStatus,
+		RespectRetryAfter:          nc.RespectRetryAfter,
 		InsecureSkipVerify:         nc.InsecureSkipVerify,
 		StreamIdleTimeoutInSeconds: nc.StreamIdleTimeoutInSeconds,
 		KeepAliveTimeoutInSeconds:  nc.KeepAliveTimeoutInSeconds,
@@ -209,6 +232,45 @@ func (nc NetworkConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias)
 }
 
+// ValidateRetryPolicy checks that RetryJitter is a fraction and that every
+// RetryOnStatus entry is a status class or code.
+func (nc *NetworkConfig) ValidateRetryPolicy() error {
+	if nc.RetryJitter != nil && (*nc.RetryJitter < 0 || *nc.RetryJitter > 1) {
+		return fmt.Errorf("retry_jitter must be between 0 and 1")
+	}
+	for _, entry := range nc.RetryOnStatus {
+		if _, _, ok := parseRetryOnStatus(entry); !ok {
+			return fmt.Errorf("invalid retry_on_status entry %q: use a status class like \"5xx\" or a status code like \"429\"", entry)
+		}
+	}
+	return nil
+}
+
+// RetriesStatus reports whether RetryOnStatus lists statusCode, either by its
+// class ("5xx") or exactly ("503"). Invalid entries are ignored.
+func (nc *NetworkConfig) RetriesStatus(statusCode int) bool {
+	for _, entry := range nc.RetryOnStatus {
+		if lo, hi, ok := parseRetryOnStatus(entry); ok && statusCode >= lo && statusCode <= hi {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryOnStatus returns the inclusive status range a RetryOnStatus entry covers.
+func parseRetryOnStatus(entry string) (int, int, bool) {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	if len(entry) == 3 && entry[1:] == "xx" && entry[0] >= '1' && entry[0] <= '5' {
+		lo := int(entry[0]-'0') * 100
+		return lo, lo + 99, true
+	}
+	code, err := strconv.Atoi(entry)
+	if err != nil || code < 100 || code > 599 {
+		return 0, 0, false
+	}
+	return code, code, true
+}
+
 // Redacted returns a redacted copy of the network configuration with CACertPEM masked.
 func (nc *NetworkConfig) Redacted() *NetworkConfig {
 	if nc == nil {
@@ -382,6 +444,10 @@ type AllowedRequests struct {
 	CachedContentRetrieve bool `json:"cached_content_retrieve"`
 	CachedContentUpdate   bool `json:"cached_content_update"`
 	CachedContentDelete   bool `json:"cached_content_delete"`
+	FineTuningJobCreate   bool `json:"fine_tuning_job_create"`
+	FineTuningJobList     bool `json:"fine_tuning_job_list"`
+	FineTuningJobRetrieve bool `json:"fine_tuning_job_retrieve"`
+	FineTuningJobCancel   bool `json:"fine_tuning_job_cancel"`
 }
 
 // IsOperationAllowed checks if a specific operation is allowed
@@ -511,6 +577,14 @@ func (ar *AllowedRequests) IsOperationAllowed(operation RequestType) bool {
 		return ar.CachedContentUpdate
 	case CachedContentDeleteRequest:
 		return ar.CachedContentDelete
+	case FineTuningJobCreateRequest:
+		return ar.FineTuningJobCreate
+	case FineTuningJobListRequest:
+		return ar.FineTuningJobList
+	case FineTuningJobRetrieveRequest:
+		return ar.FineTuningJobRetrieve
+	case FineTuningJobCancelRequest:
+		return ar.FineTuningJobCancel
 	default:
 		return false // Default to not allowed for unknown operations
 	}
@@ -522,6 +596,7 @@ type CustomProviderConfig struct {
 	BaseProviderType     ModelProvider          `json:"base_provider_type"`               // Base provider type
 	AllowedRequests      *AllowedRequests       `json:"allowed_requests,omitempty"`       // Allowed requests for the custom provider
 	RequestPathOverrides map[RequestType]string `json:"request_path_overrides,omitempty"` // Mapping of request type to its custom path which will override the default path of the provider (not allowed for Bedrock)
+	Translator           string                 `json:"translator,omitempty"`             // Name of a registered ProviderTranslator serving this provider; BaseProviderType is not required when set
 }
 
 // IsOperationAllowed checks if a specific operation is allowed for this custom provider
@@ -532,6 +607,28 @@ func (cpc *CustomProviderConfig) IsOperationAllowed(operation RequestType) bool
 	return cpc.AllowedRequests.IsOperationAllowed(operation)
 }
 
+// LoadBalancingStrategy selects how requests are spread across a provider's keys.
+type LoadBalancingStrategy string
+
+const (
+	// LoadBalancingWeighted picks keys at random in proportion to their weights (default).
+	LoadBalancingWeighted LoadBalancingStrategy = "weighted"
+	// LoadBalancingLeastLatency picks the key with the lowest recent latency.
+	LoadBalancingLeastLatency LoadBalancingStrategy = "least_latency"
+	// LoadBalancingLeastPending picks the key with the fewest in-flight requests relative to its weight.
+	LoadBalancingLeastPending LoadBalancingStrategy = "least_pending"
+)
+
+// IsValid reports whether s is empty (the default) or a known strategy.
+func (s LoadBalancingStrategy) IsValid() bool {
+	switch s {
+	case "", LoadBalancingWeighted, LoadBalancingLeastLatency, LoadBalancingLeastPending:
+		return true
+	default:
+		return false
+	}
+}
+
 // ProviderConfig represents the complete configuration for a provider.
 // An array of ProviderConfig needs to be provided in GetConfigForProvider
 // in your account interface implementation.
@@ -546,6 +643,9 @@ type ProviderConfig struct {
 	StoreRawRequestResponse bool                  `json:"store_raw_request_response"` // Capture raw request/response for internal logging only; strip from API responses returned to clients (default: false)
 	CustomProviderConfig    *CustomProviderConfig `json:"custom_provider_config,omitempty"`
 	OpenAIConfig            *OpenAIConfig         `json:"openai_config,omitempty"`
+	// LoadBalancingStrategy selects how requests are spread across keys (default: weighted).
+	// Ignored when a custom KeySelector is set in BifrostConfig.
+	LoadBalancingStrategy LoadBalancingStrategy `json:"load_balancing_strategy,omitempty"`
 }
 
 // OpenAIConfig holds OpenAI-specific provider configuration.
@@ -705,6 +805,14 @@ type Provider interface {
 	CachedContentUpdate(ctx *BifrostContext, keys []Key, request *BifrostCachedContentUpdateRequest) (*BifrostCachedContentUpdateResponse, *BifrostError)
 	// CachedContentDelete deletes a cached content by name
 	CachedContentDelete(ctx *BifrostContext, keys []Key, request *BifrostCachedContentDeleteRequest) (*BifrostCachedContentDeleteResponse, *BifrostError)
+	// FineTuningJobCreate launches a fine-tuning job against a base model
+	FineTuningJobCreate(ctx *BifrostContext, key Key, request *BifrostFineTuningJobCreateRequest) (*BifrostFineTuningJobCreateResponse, *BifrostError)
+	// FineTuningJobList lists fine-tuning jobs
+	FineTuningJobList(ctx *BifrostContext, keys []Key, request *BifrostFineTuningJobListRequest) (*BifrostFineTuningJobListResponse, *BifrostError)
+	// FineTuningJobRetrieve polls a single fine-tuning job
+	FineTuningJobRetrieve(ctx *BifrostContext, keys []Key, request *BifrostFineTuningJobRetrieveRequest) (*BifrostFineTuningJobRetrieveResponse, *BifrostError)
+	// FineTuningJobCancel cancels a running fine-tuning job
+	FineTuningJobCancel(ctx *BifrostContext, keys []Key, request *BifrostFineTuningJobCancelRequest) (*BifrostFineTuningJobCancelResponse, *BifrostError)
 	// ContainerCreate creates a new container
 	ContainerCreate(ctx *BifrostContext, key Key, request *BifrostContainerCreateRequest) (*BifrostContainerCreateResponse, *BifrostError)
 	// ContainerList lists containers
@@ -0,0 +1,88 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Bucketing keys of a percentage rollout. A "header:<name>" bucket key (e.g.
+// "header:x-user-id") buckets by the value of that request header, so every
+// request of one end user gets the same result.
+const (
+	RequestFlagBucketByRequest    = "request"     // each request is bucketed on its own (default)
+	RequestFlagBucketByVirtualKey = "virtual_key" // every request of a virtual key lands in the same bucket
+	RequestFlagBucketByHeader     = "header:"
+)
+
+// requestFlagNamePattern keeps flag names usable as log metadata keys.
+var requestFlagNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// RequestFlagRule turns its flag on for the requests it matches. Every
+// condition that is set must hold; a rule without conditions matches every
+// request.
+type RequestFlagRule struct {
+	// VirtualKeys are the IDs or names of the virtual keys the rule applies to.
+	VirtualKeys []string `json:"virtual_keys,omitempty"`
+	// Models are models ("gpt-4o" or "openai/gpt-4o") the rule applies to. A
+	// trailing "*" matches by prefix, e.g. "claude-*".
+	Models []string `json:"models,omitempty"`
+	// Headers are request header values the rule requires, by header name. A
+	// value of "*" only requires the header to be present.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Percentage rolls the flag out to this share (0-100) of the matching
+	// requests. nil = all of them.
+	Percentage *float64 `json:"percentage,omitempty"`
+	// BucketBy picks what a percentage rollout is sticky to:
+	// RequestFlagBucketByRequest (default), RequestFlagBucketByVirtualKey or
+	// "header:<name>".
+	BucketBy string `json:"bucket_by,omitempty"`
+}
+
+// RequestFlag is a feature flag evaluated for every inference request once
+// its virtual key and model are resolved. A flag is on when any of its rules
+// matches, and off when it has none. The results are available to plugins
+// through RequestFlagEnabled and are recorded in log metadata.
+type RequestFlag struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Rules       []RequestFlagRule `json:"rules,omitempty"`
+	// Plugins only run for requests where the flag is on. A plugin gated by
+	// several flags runs when any of them is on.
+	Plugins []string `json:"plugins,omitempty"`
+}
+
+// Validate checks the flag's name and rules.
+func (f RequestFlag) Validate() error {
+	if !requestFlagNamePattern.MatchString(f.Name) {
+		return fmt.Errorf("request flag name %q must be letters, digits, '.', '_' or '-'", f.Name)
+	}
+	for i, rule := range f.Rules {
+		if rule.Percentage != nil && (*rule.Percentage < 0 || *rule.Percentage > 100) {
+			return fmt.Errorf("request flag %q: rule %d percentage must be between 0 and 100", f.Name, i)
+		}
+		switch {
+		case rule.BucketBy == "", rule.BucketBy == RequestFlagBucketByRequest, rule.BucketBy == RequestFlagBucketByVirtualKey:
+		case strings.HasPrefix(rule.BucketBy, RequestFlagBucketByHeader) && len(rule.BucketBy) > len(RequestFlagBucketByHeader):
+		default:
+			return fmt.Errorf("request flag %q: rule %d has unknown bucket_by %q", f.Name, i, rule.BucketBy)
+		}
+	}
+	for _, plugin := range f.Plugins {
+		if strings.TrimSpace(plugin) == "" {
+			return fmt.Errorf("request flag %q: plugin names must not be empty", f.Name)
+		}
+	}
+	return nil
+}
+
+// RequestFlagEnabled reports whether the request flag name is on for the
+// request of ctx. Unknown flags, and requests that were not evaluated, are off.
+func RequestFlagEnabled(ctx context.Context, name string) bool {
+	if ctx == nil {
+		return false
+	}
+	flags, _ := ctx.Value(BifrostContextKeyRequestFlags).(map[string]bool)
+	return flags[name]
+}
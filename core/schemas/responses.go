@@ -320,6 +320,44 @@ func (resp *BifrostResponsesResponse) BackfillParams(request *BifrostResponsesRe
 	}
 }
 
+// BackfillServerToolUsage counts the provider-side tool calls in Output into Usage for
+// providers that report them only as output items (OpenAI's web_search, file_search and
+// code_interpreter), so logs and cost calculation see them. Counts the provider already
+// reported are kept.
+func (resp *BifrostResponsesResponse) BackfillServerToolUsage() {
+	if resp == nil || resp.Usage == nil {
+		return
+	}
+	var details ResponsesServerSideToolUsageDetails
+	for _, item := range resp.Output {
+		if item.Type == nil {
+			continue
+		}
+		switch *item.Type {
+		case ResponsesMessageTypeWebSearchCall:
+			details.WebSearchCalls++
+		case ResponsesMessageTypeFileSearchCall:
+			details.FileSearchCalls++
+		case ResponsesMessageTypeCodeInterpreterCall:
+			details.CodeInterpreterCalls++
+		}
+	}
+	if details == (ResponsesServerSideToolUsageDetails{}) {
+		return
+	}
+	if resp.Usage.ServerSideToolUsageDetails == nil {
+		resp.Usage.ServerSideToolUsageDetails = &details
+	}
+	if details.WebSearchCalls > 0 {
+		if resp.Usage.OutputTokensDetails == nil {
+			resp.Usage.OutputTokensDetails = &ResponsesResponseOutputTokens{}
+		}
+		if resp.Usage.OutputTokensDetails.NumSearchQueries == nil {
+			resp.Usage.OutputTokensDetails.NumSearchQueries = Ptr(details.WebSearchCalls)
+		}
+	}
+}
+
 func (resp *BifrostResponsesResponse) WithDefaults() *BifrostResponsesResponse {
 	if resp == nil {
 		return nil
@@ -496,6 +534,10 @@ type PromptCacheBreakpoint struct {
 	Mode *string `json:"mode,omitempty"`
 }
 
+// ResponsesIncludeOutputTextLogProbs is the include value that asks for
+// token logprobs on output_text, the Responses counterpart of chat's logprobs flag.
+const ResponsesIncludeOutputTextLogProbs = "message.output_text.logprobs"
+
 type ResponsesParameters struct {
 	Background           *bool                         `json:"background,omitempty"`
 	Conversation         *string                       `json:"conversation,omitempty"`
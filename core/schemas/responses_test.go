@@ -604,3 +604,33 @@ func TestStreamWithDefaultsStripsCodeExecutionCarry(t *testing.T) {
 		}
 	}
 }
+
+func TestBackfillServerToolUsageCountsBuiltInToolCalls(t *testing.T) {
+	item := func(t ResponsesMessageType) ResponsesMessage { return ResponsesMessage{Type: Ptr(t)} }
+	resp := &BifrostResponsesResponse{
+		Usage: &ResponsesResponseUsage{InputTokens: 10, OutputTokens: 5},
+		Output: []ResponsesMessage{
+			item(ResponsesMessageTypeWebSearchCall),
+			item(ResponsesMessageTypeWebSearchCall),
+			item(ResponsesMessageTypeFileSearchCall),
+			item(ResponsesMessageTypeCodeInterpreterCall),
+			item(ResponsesMessageTypeMessage),
+		},
+	}
+	resp.BackfillServerToolUsage()
+
+	details := resp.Usage.ServerSideToolUsageDetails
+	if details == nil || details.WebSearchCalls != 2 || details.FileSearchCalls != 1 || details.CodeInterpreterCalls != 1 {
+		t.Fatalf("unexpected tool usage details: %+v", details)
+	}
+	if got := resp.Usage.OutputTokensDetails.NumSearchQueries; got == nil || *got != 2 {
+		t.Fatalf("expected 2 search queries, got %v", got)
+	}
+
+	// Counts the provider reported win.
+	resp.Usage.OutputTokensDetails.NumSearchQueries = Ptr(3)
+	resp.BackfillServerToolUsage()
+	if *resp.Usage.OutputTokensDetails.NumSearchQueries != 3 {
+		t.Fatal("a reported search count must not be overwritten")
+	}
+}
@@ -0,0 +1,34 @@
+package schemas
+
+import "fmt"
+
+const (
+	DefaultRetryBudgetWindowSeconds = 10
+	MaxRetryBudgetWindowSeconds     = 300
+)
+
+// RetryBudgetConfig caps retries across every provider to a share of recent
+// traffic, so an upstream incident cannot turn each request into
+// 1+max_retries upstream calls. Over a sliding window, retries are allowed
+// while they stay below RetryRatio x first attempts, or below the
+// MinRetriesPerSecond floor that keeps low-traffic gateways able to retry.
+// Once the budget is spent, failed attempts return (or fall back) immediately.
+type RetryBudgetConfig struct {
+	RetryRatio          float64 `json:"retry_ratio"`                      // Retries allowed per first attempt in the window (e.g. 0.2 = 20% extra load)
+	MinRetriesPerSecond int     `json:"min_retries_per_second,omitempty"` // Retries always allowed per second of window regardless of traffic
+	WindowSeconds       int     `json:"window_seconds,omitempty"`         // Sliding window length (default: 10)
+}
+
+// Validate checks that the budget's ratio, floor, and window are usable.
+func (c RetryBudgetConfig) Validate() error {
+	if c.RetryRatio < 0 {
+		return fmt.Errorf("retry_ratio must not be negative")
+	}
+	if c.MinRetriesPerSecond < 0 {
+		return fmt.Errorf("min_retries_per_second must not be negative")
+	}
+	if c.WindowSeconds < 0 || c.WindowSeconds > MaxRetryBudgetWindowSeconds {
+		return fmt.Errorf("window_seconds must be between 1 and %d", MaxRetryBudgetWindowSeconds)
+	}
+	return nil
+}
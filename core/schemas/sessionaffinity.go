@@ -0,0 +1,27 @@
+package schemas
+
+import (
+	"context"
+	"time"
+)
+
+// SessionAffinity is the deployment a session (x-bf-session-id) was last
+// served by. Routing prefers it for later requests of the same session so
+// provider-side state — prompt caches, previous_response_id — stays reachable.
+type SessionAffinity struct {
+	Provider ModelProvider `json:"provider"`
+	Model    string        `json:"model"`
+	KeyID    string        `json:"key_id,omitempty"`
+}
+
+// SessionAffinityStore persists session affinities across requests and
+// restarts. The concrete implementation (e.g. framework/sessionaffinity.Store)
+// is injected through BifrostConfig; nil disables deployment affinity.
+type SessionAffinityStore interface {
+	// GetSessionAffinity returns the affinity recorded for sessionID, or nil
+	// when there is none or it has expired.
+	GetSessionAffinity(ctx context.Context, sessionID string) (*SessionAffinity, error)
+	// SetSessionAffinity records affinity for sessionID, replacing any previous
+	// one, and keeps it for ttl.
+	SetSessionAffinity(ctx context.Context, sessionID string, affinity SessionAffinity, ttl time.Duration) error
+}
@@ -34,6 +34,9 @@ const (
 	// TraceAttrDimensions holds the map[string]string of request dimensions
 	// parsed from x-bf-dim-* headers, keyed by bare dimension name.
 	TraceAttrDimensions = "bifrost.dimensions"
+	// TraceAttrParentSampled holds the bool sampled flag of the incoming W3C
+	// traceparent header. Absent when the request carried no valid traceparent.
+	TraceAttrParentSampled = "bifrost.parent_sampled"
 )
 
 // AddSpan adds a span to the trace in a thread-safe manner
@@ -797,6 +800,11 @@ const (
 	AttrBifrostRoutingEngineUsed   = "bifrost.routing_engine_used" // comma-joined routing engines that handled the request
 	AttrBifrostStopSequencesJoined = "bifrost.request.stop_sequences"
 
+	// Deployment metadata of the instance that served the request, stamped on the
+	// root span so traces from active-active deployments can be told apart.
+	AttrBifrostDeploymentRegion     = "bifrost.deployment.region"
+	AttrBifrostDeploymentInstanceID = "bifrost.deployment.instance_id"
+
 	// OTel general semconv (no gen_ai prefix). Emitted alongside the legacy
 	// gen_ai.error.type from PopulateErrorAttributes.
 	AttrErrorTypeSpec = "error.type"
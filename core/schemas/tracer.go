@@ -33,6 +33,8 @@ type StreamAccumulatorResult struct {
 	FinishReason          *string                         // Finish reason
 	RawResponse           *string                         // Raw response
 	RawRequest            interface{}                     // Raw request
+	SystemFingerprint     string                          // Provider system_fingerprint, for chat and text completion streams
+	ModelRevision         string                          // Model name the provider echoed back, for chat and text completion streams
 }
 
 // Tracer defines the interface for distributed tracing in Bifrost.
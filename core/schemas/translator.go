@@ -0,0 +1,94 @@
+package schemas
+
+import (
+	"errors"
+	"sync"
+)
+
+// ProviderTranslator maps Bifrost requests to the HTTP API of a bespoke
+// provider, and that provider's responses, stream events and errors back to
+// Bifrost types. It lets an in-house inference API be served as a custom
+// provider without changing core: register the translator under a name with
+// RegisterProviderTranslator and set CustomProviderConfig.Translator to it.
+//
+// Bifrost owns the HTTP client, network config, retries, keys and the plugin
+// pipeline; the translator only maps payloads. Implementations must be safe
+// for concurrent use.
+type ProviderTranslator interface {
+	// TranslateRequest maps req to the HTTP request sent upstream. It returns
+	// ErrTranslatorUnsupportedRequest for request types the provider does not serve.
+	TranslateRequest(ctx *BifrostContext, key Key, req *BifrostRequest) (*TranslatedRequest, error)
+	// TranslateResponse maps a 2xx response body to the Bifrost response for requestType.
+	TranslateResponse(ctx *BifrostContext, requestType RequestType, body []byte) (*BifrostResponse, error)
+	// TranslateStreamEvent maps one stream event, the data of an SSE event or
+	// one NDJSON line, to a stream chunk. A nil response skips the event; done
+	// reports that the event ends the stream.
+	TranslateStreamEvent(ctx *BifrostContext, requestType RequestType, data []byte) (response *BifrostResponse, done bool, err error)
+	// TranslateError maps a non-2xx response to a Bifrost error. Returning nil
+	// falls back to a generic error carrying the status code and body.
+	TranslateError(ctx *BifrostContext, statusCode int, body []byte) *BifrostError
+}
+
+// TranslatedRequest is the upstream HTTP request built by a ProviderTranslator.
+type TranslatedRequest struct {
+	Method  string            `json:"method,omitempty"` // Defaults to POST
+	Path    string            `json:"path"`             // Appended to the provider's base URL, or an absolute URL
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+}
+
+// ErrTranslatorUnsupportedRequest is returned by ProviderTranslator.TranslateRequest
+// for request types the provider does not serve.
+var ErrTranslatorUnsupportedRequest = errors.New("request type is not supported by the provider translator")
+
+// ProviderTranslatorPlugin is a plugin that contributes provider translators,
+// keyed by the name custom providers refer to them by. Dynamic plugins export
+// this as a ProviderTranslators symbol.
+type ProviderTranslatorPlugin interface {
+	BasePlugin
+	ProviderTranslators() map[string]ProviderTranslator
+}
+
+type providerTranslatorEntry struct {
+	translator ProviderTranslator
+}
+
+var (
+	providerTranslatorsMu sync.RWMutex
+	providerTranslators   = map[string]*providerTranslatorEntry{}
+)
+
+// RegisterProviderTranslator registers translator under name, replacing any
+// translator already registered there. The returned func unregisters it; it
+// is a no-op once a later registration has replaced it, so a reloaded plugin
+// can register its new translators before the old instance is cleaned up.
+func RegisterProviderTranslator(name string, translator ProviderTranslator) (unregister func(), err error) {
+	if name == "" {
+		return nil, errors.New("provider translator name is required")
+	}
+	if translator == nil {
+		return nil, errors.New("provider translator is nil")
+	}
+	entry := &providerTranslatorEntry{translator: translator}
+	providerTranslatorsMu.Lock()
+	providerTranslators[name] = entry
+	providerTranslatorsMu.Unlock()
+	return func() {
+		providerTranslatorsMu.Lock()
+		defer providerTranslatorsMu.Unlock()
+		if providerTranslators[name] == entry {
+			delete(providerTranslators, name)
+		}
+	}, nil
+}
+
+// GetProviderTranslator returns the translator registered under name.
+func GetProviderTranslator(name string) (ProviderTranslator, bool) {
+	providerTranslatorsMu.RLock()
+	defer providerTranslatorsMu.RUnlock()
+	entry, ok := providerTranslators[name]
+	if !ok {
+		return nil, false
+	}
+	return entry.translator, true
+}
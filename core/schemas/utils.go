@@ -926,6 +926,10 @@ func DeepCopyChatTool(original ChatTool) ChatTool {
 		copyTool.Annotations = copyAnnotations
 	}
 
+	if original.OutputSchema != nil {
+		copyTool.OutputSchema = append(json.RawMessage(nil), original.OutputSchema...)
+	}
+
 	// Deep copy Custom if present
 	if original.Custom != nil {
 		copyTool.Custom = &ChatToolCustom{}
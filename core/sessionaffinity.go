@@ -0,0 +1,106 @@
+package bifrost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// sessionAffinityWriteTimeout bounds the background write that records which
+// deployment served a session, so a slow store never holds a goroutine forever.
+const sessionAffinityWriteTimeout = 5 * time.Second
+
+// applySessionAffinity moves the deployment that last served the request's
+// session to the front of the chain. It runs after PreRequestHooks, so it only
+// reorders deployments that routing already allowed: if the pinned deployment
+// is not the primary or one of the fallbacks, the chain is left as is and the
+// session moves to whatever serves this request. The loaded affinity is also
+// stashed on ctx, where key selection uses it to pin the same key.
+func (bifrost *Bifrost) applySessionAffinity(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) {
+	if bifrost.sessionAffinity == nil {
+		return
+	}
+	sessionID, _ := ctx.Value(schemas.BifrostContextKeySessionID).(string)
+	if sessionID == "" {
+		return
+	}
+	affinity, err := bifrost.sessionAffinity.GetSessionAffinity(ctx, sessionID)
+	if err != nil {
+		bifrost.logger.Warn("failed to load session affinity: %v", err)
+		return
+	}
+	if affinity == nil {
+		return
+	}
+	ctx.SetValue(schemas.BifrostContextKeySessionAffinity, affinity)
+
+	provider, model, fallbacks := req.GetRequestFields()
+	if provider == affinity.Provider && model == affinity.Model {
+		return
+	}
+	for i, fallback := range fallbacks {
+		if fallback.Provider != affinity.Provider || fallback.Model != affinity.Model {
+			continue
+		}
+		reordered := make([]schemas.Fallback, 0, len(fallbacks))
+		reordered = append(reordered, schemas.Fallback{Provider: provider, Model: model})
+		reordered = append(reordered, fallbacks[:i]...)
+		reordered = append(reordered, fallbacks[i+1:]...)
+		req.SetProvider(affinity.Provider)
+		req.SetModel(affinity.Model)
+		req.SetFallbacks(reordered)
+		schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineSessionAffinity)
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineSessionAffinity, schemas.LogLevelInfo, fmt.Sprintf("Session is pinned to %s/%s; moved it ahead of %s/%s", affinity.Provider, affinity.Model, provider, model))
+		return
+	}
+}
+
+// recordSessionAffinity stores the deployment that served a successful request
+// as its session's affinity. Every success refreshes the TTL; the write runs
+// in the background so the response is not held up by the store.
+func (bifrost *Bifrost) recordSessionAffinity(ctx *schemas.BifrostContext, info schemas.RoutingInfo) {
+	if bifrost.sessionAffinity == nil || info.Provider == "" || info.Model == "" {
+		return
+	}
+	sessionID, _ := ctx.Value(schemas.BifrostContextKeySessionID).(string)
+	if sessionID == "" {
+		return
+	}
+	affinity := schemas.SessionAffinity{Provider: info.Provider, Model: info.Model}
+	affinity.KeyID, _ = ctx.Value(schemas.BifrostContextKeySelectedKeyID).(string)
+	ttl, _ := ctx.Value(schemas.BifrostContextKeySessionTTL).(time.Duration)
+	if ttl <= 0 {
+		ttl = schemas.DefaultSessionStickyTTL
+	}
+
+	store := bifrost.sessionAffinity
+	logger := bifrost.logger
+	go func() {
+		writeCtx, cancel := context.WithTimeout(context.Background(), sessionAffinityWriteTimeout)
+		defer cancel()
+		if err := store.SetSessionAffinity(writeCtx, sessionID, affinity, ttl); err != nil {
+			logger.Warn("failed to record session affinity for provider=%s model=%s: %v", affinity.Provider, affinity.Model, err)
+		}
+	}()
+}
+
+// sessionAffinityKey returns the key the session was last served with when
+// selection is for the session's pinned provider and model and that key is
+// still eligible.
+func sessionAffinityKey(ctx *schemas.BifrostContext, providerKey schemas.ModelProvider, model string, supportedKeys []schemas.Key) (schemas.Key, bool) {
+	if ctx == nil {
+		return schemas.Key{}, false
+	}
+	affinity, ok := ctx.Value(schemas.BifrostContextKeySessionAffinity).(*schemas.SessionAffinity)
+	if !ok || affinity == nil || affinity.KeyID == "" || affinity.Provider != providerKey || affinity.Model != model {
+		return schemas.Key{}, false
+	}
+	for _, key := range supportedKeys {
+		if key.ID == affinity.KeyID {
+			return key, true
+		}
+	}
+	return schemas.Key{}, false
+}
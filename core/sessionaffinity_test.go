@@ -0,0 +1,167 @@
+package bifrost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+type memorySessionAffinityStore struct {
+	mu         sync.Mutex
+	affinities map[string]schemas.SessionAffinity
+	ttls       map[string]time.Duration
+	written    chan struct{}
+}
+
+func newMemorySessionAffinityStore() *memorySessionAffinityStore {
+	return &memorySessionAffinityStore{
+		affinities: map[string]schemas.SessionAffinity{},
+		ttls:       map[string]time.Duration{},
+		written:    make(chan struct{}, 8),
+	}
+}
+
+func (s *memorySessionAffinityStore) GetSessionAffinity(_ context.Context, sessionID string) (*schemas.SessionAffinity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	affinity, ok := s.affinities[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	return &affinity, nil
+}
+
+func (s *memorySessionAffinityStore) SetSessionAffinity(_ context.Context, sessionID string, affinity schemas.SessionAffinity, ttl time.Duration) error {
+	s.mu.Lock()
+	s.affinities[sessionID] = affinity
+	s.ttls[sessionID] = ttl
+	s.mu.Unlock()
+	s.written <- struct{}{}
+	return nil
+}
+
+func (s *memorySessionAffinityStore) waitForWrite(t *testing.T) {
+	t.Helper()
+	select {
+	case <-s.written:
+	case <-time.After(2 * time.Second):
+		t.Fatal("session affinity was not recorded")
+	}
+}
+
+// keyEchoHandler answers chat completions with the bearer token the request was sent with.
+func keyEchoHandler(hits *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":%q},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`, r.Header.Get("Authorization"))
+	}
+}
+
+func newSessionAffinityTestClient(t *testing.T, store schemas.SessionAffinityStore) (*Bifrost, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var openAIHits, groqHits atomic.Int32
+	openAI := httptest.NewServer(keyEchoHandler(&openAIHits))
+	t.Cleanup(openAI.Close)
+	groq := httptest.NewServer(keyEchoHandler(&groqHits))
+	t.Cleanup(groq.Close)
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 1, openAI.URL)
+	account.AddProviderWithBaseURL(schemas.Groq, 1, 1, groq.URL)
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "key-a", Value: *schemas.NewSecretVar("sk-a"), Models: schemas.WhiteList{"*"}, Weight: 1},
+		{ID: "key-b", Value: *schemas.NewSecretVar("sk-b"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	account.SetKeysForProvider(schemas.Groq, []schemas.Key{
+		{ID: "groq-key", Value: *schemas.NewSecretVar("sk-groq"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account:              account,
+		Logger:               NewDefaultLogger(schemas.LogLevelError),
+		SessionAffinityStore: store,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize bifrost: %v", err)
+	}
+	t.Cleanup(client.Shutdown)
+	return client, &openAIHits, &groqHits
+}
+
+func sessionContext(sessionID string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeySessionID, sessionID)
+	return ctx
+}
+
+func TestSessionAffinityPromotesPinnedFallback(t *testing.T) {
+	store := newMemorySessionAffinityStore()
+	store.affinities["conv-1"] = schemas.SessionAffinity{Provider: schemas.Groq, Model: "llama-3.1-8b-instant", KeyID: "groq-key"}
+	client, openAIHits, groqHits := newSessionAffinityTestClient(t, store)
+
+	resp, bifrostErr := client.ChatCompletionRequest(sessionContext("conv-1"), hedgedChatRequest())
+	if bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+	if groqHits.Load() != 1 || openAIHits.Load() != 0 {
+		t.Fatalf("expected the pinned fallback to serve the request, got openai=%d groq=%d", openAIHits.Load(), groqHits.Load())
+	}
+	if ri := resp.ExtraFields.RoutingInfo; ri.Provider != schemas.Groq || ri.IsFallback {
+		t.Fatalf("expected groq as the primary attempt, got %+v", ri)
+	}
+	store.waitForWrite(t)
+	if got := store.ttls["conv-1"]; got != schemas.DefaultSessionStickyTTL {
+		t.Fatalf("expected the default TTL to be refreshed, got %s", got)
+	}
+}
+
+func TestSessionAffinityRecordsAndPinsKey(t *testing.T) {
+	store := newMemorySessionAffinityStore()
+	client, _, _ := newSessionAffinityTestClient(t, store)
+
+	resp, bifrostErr := client.ChatCompletionRequest(sessionContext("conv-2"), hedgedChatRequest())
+	if bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+	store.waitForWrite(t)
+	recorded := store.affinities["conv-2"]
+	if recorded.Provider != schemas.OpenAI || recorded.Model != "gpt-4o-mini" || recorded.KeyID == "" {
+		t.Fatalf("unexpected recorded affinity %+v", recorded)
+	}
+	firstKey := *resp.Choices[0].Message.Content.ContentStr
+
+	for range 5 {
+		resp, bifrostErr = client.ChatCompletionRequest(sessionContext("conv-2"), hedgedChatRequest())
+		if bifrostErr != nil {
+			t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+		}
+		store.waitForWrite(t)
+		if got := *resp.Choices[0].Message.Content.ContentStr; got != firstKey {
+			t.Fatalf("expected every turn on %q, got %q", firstKey, got)
+		}
+	}
+}
+
+func TestSessionAffinityIgnoresDeploymentsOutsideChain(t *testing.T) {
+	store := newMemorySessionAffinityStore()
+	store.affinities["conv-3"] = schemas.SessionAffinity{Provider: schemas.Anthropic, Model: "claude-3-5-haiku"}
+	client, openAIHits, _ := newSessionAffinityTestClient(t, store)
+
+	if _, bifrostErr := client.ChatCompletionRequest(sessionContext("conv-3"), hedgedChatRequest()); bifrostErr != nil {
+		t.Fatalf("request failed: %s", bifrostErr.Error.Message)
+	}
+	if openAIHits.Load() != 1 {
+		t.Fatal("expected the caller's primary to serve the request")
+	}
+	store.waitForWrite(t)
+	if got := store.affinities["conv-3"].Provider; got != schemas.OpenAI {
+		t.Fatalf("expected the session to move to openai, got %s", got)
+	}
+}
@@ -0,0 +1,132 @@
+package bifrost
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/valyala/fasthttp"
+)
+
+// structuredOutputSchema compiles the schema a chat request asked for with
+// response_format {"type": "json_schema"}. It returns nil when the request
+// asks for no schema, so there is nothing to validate.
+func structuredOutputSchema(params *schemas.ChatParameters) (*jsonschema.Schema, error) {
+	if params == nil || params.ResponseFormat == nil {
+		return nil, nil
+	}
+	// response_format arrives as a plain map or an OrderedMap depending on the
+	// entry point; a JSON round trip reads both the same way.
+	raw, err := sonic.Marshal(*params.ResponseFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response_format: %w", err)
+	}
+	var format struct {
+		Type       string `json:"type"`
+		JSONSchema *struct {
+			Schema any `json:"schema"`
+		} `json:"json_schema"`
+	}
+	if err := sonic.Unmarshal(raw, &format); err != nil {
+		return nil, fmt.Errorf("failed to read response_format: %w", err)
+	}
+	if format.Type != "json_schema" || format.JSONSchema == nil || format.JSONSchema.Schema == nil {
+		return nil, nil
+	}
+	schemaRaw, err := sonic.Marshal(format.JSONSchema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response_format.json_schema.schema: %w", err)
+	}
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaRaw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response_format.json_schema.schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("response_format.json", schemaDoc); err != nil {
+		return nil, fmt.Errorf("invalid response_format.json_schema.schema: %w", err)
+	}
+	schema, err := compiler.Compile("response_format.json")
+	if err != nil {
+		return nil, fmt.Errorf("invalid response_format.json_schema.schema: %w", err)
+	}
+	return schema, nil
+}
+
+// validateStructuredOutput checks the text of every choice against schema.
+// Choices that refuse or call tools carry no structured output and are skipped.
+func validateStructuredOutput(schema *jsonschema.Schema, response *schemas.BifrostChatResponse) error {
+	if response == nil {
+		return nil
+	}
+	for _, choice := range response.Choices {
+		if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil {
+			continue
+		}
+		message := choice.Message
+		if message.ChatAssistantMessage != nil && (message.Refusal != nil || len(message.ToolCalls) > 0) {
+			continue
+		}
+		text := chatMessageText(message.Content)
+		doc, err := jsonschema.UnmarshalJSON(strings.NewReader(text))
+		if err != nil {
+			return fmt.Errorf("choice %d is not valid JSON: %w", choice.Index, err)
+		}
+		if err := schema.Validate(doc); err != nil {
+			return fmt.Errorf("choice %d does not match the schema: %w", choice.Index, err)
+		}
+	}
+	return nil
+}
+
+// chatMessageText returns the text of a message's content, joining text blocks.
+func chatMessageText(content *schemas.ChatMessageContent) string {
+	if content == nil {
+		return ""
+	}
+	if content.ContentStr != nil {
+		return *content.ContentStr
+	}
+	var sb strings.Builder
+	for _, block := range content.ContentBlocks {
+		if block.Text != nil {
+			sb.WriteString(*block.Text)
+		}
+	}
+	return sb.String()
+}
+
+// enforceStructuredOutput validates a json_schema chat response and, when it
+// does not match, sends the request once more. A second mismatch fails the
+// request rather than handing the caller JSON it asked Bifrost to guarantee.
+func (bifrost *Bifrost) enforceStructuredOutput(ctx *schemas.BifrostContext, req *schemas.BifrostChatRequest, schema *jsonschema.Schema, response *schemas.BifrostChatResponse) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
+	validationErr := validateStructuredOutput(schema, response)
+	if validationErr == nil {
+		return response, nil
+	}
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelWarn, fmt.Sprintf("Retrying %s/%s: structured output rejected: %v", req.Provider, req.Model, validationErr))
+	response, bifrostErr := bifrost.makeChatCompletionRequest(ctx, req)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+	if validationErr = validateStructuredOutput(schema, response); validationErr == nil {
+		return response, nil
+	}
+	return nil, &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(fasthttp.StatusBadGateway),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("structured_output_validation_failed"),
+			Message: fmt.Sprintf("response did not match the requested JSON schema after a retry: %v", validationErr),
+			Error:   validationErr,
+		},
+		ExtraFields: schemas.BifrostErrorExtraFields{
+			RequestType:            schemas.ChatCompletionRequest,
+			Provider:               req.Provider,
+			OriginalModelRequested: req.Model,
+		},
+	}
+}
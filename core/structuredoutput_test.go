@@ -0,0 +1,122 @@
+package bifrost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonSchemaResponseFormat() *interface{} {
+	var format interface{} = map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name": "person",
+			"schema": map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+				"required":             []interface{}{"name"},
+				"additionalProperties": false,
+			},
+		},
+	}
+	return &format
+}
+
+func chatResponseWithContent(content string) *schemas.BifrostChatResponse {
+	return &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+				Message: &schemas.ChatMessage{
+					Role:    schemas.ChatMessageRoleAssistant,
+					Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(content)},
+				},
+			},
+		}},
+	}
+}
+
+func TestStructuredOutputSchema(t *testing.T) {
+	schema, err := structuredOutputSchema(&schemas.ChatParameters{ResponseFormat: jsonSchemaResponseFormat()})
+	require.NoError(t, err)
+	require.NotNil(t, schema)
+
+	assert.NoError(t, validateStructuredOutput(schema, chatResponseWithContent(`{"name":"Ada"}`)))
+	assert.ErrorContains(t, validateStructuredOutput(schema, chatResponseWithContent(`{"age":3}`)), "does not match the schema")
+	assert.ErrorContains(t, validateStructuredOutput(schema, chatResponseWithContent(`Sure! {"name":"Ada"}`)), "not valid JSON")
+
+	var jsonObject interface{} = map[string]interface{}{"type": "json_object"}
+	schema, err = structuredOutputSchema(&schemas.ChatParameters{ResponseFormat: &jsonObject})
+	assert.NoError(t, err)
+	assert.Nil(t, schema, "json_object carries no schema to validate")
+
+	var invalid interface{} = map[string]interface{}{
+		"type":        "json_schema",
+		"json_schema": map[string]interface{}{"schema": map[string]interface{}{"type": "not-a-type"}},
+	}
+	_, err = structuredOutputSchema(&schemas.ChatParameters{ResponseFormat: &invalid})
+	assert.Error(t, err)
+}
+
+// newStructuredOutputClient returns a client whose OpenAI provider answers
+// each chat request with the next entry of contents.
+func newStructuredOutputClient(t *testing.T, contents ...string) (*Bifrost, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		content := contents[min(int(calls.Add(1))-1, len(contents)-1)]
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":%q},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`, content)
+	}))
+	t.Cleanup(server.Close)
+
+	account := NewMockAccount()
+	account.AddProviderWithBaseURL(schemas.OpenAI, 1, 4, server.URL)
+	account.SetKeysForProvider(schemas.OpenAI, []schemas.Key{
+		{ID: "openai-key", Value: *schemas.NewSecretVar("sk-test"), Models: schemas.WhiteList{"*"}, Weight: 1},
+	})
+	client, err := Init(context.Background(), schemas.BifrostConfig{
+		Account: account,
+		Logger:  NewDefaultLogger(schemas.LogLevelError),
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Shutdown)
+	return client, &calls
+}
+
+func structuredOutputRequest(t *testing.T, client *Bifrost) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
+	ctx := schemas.NewBifrostContext(context.Background(), time.Now().Add(10*time.Second))
+	ctx.SetValue(schemas.BifrostContextKeyValidateStructuredOutput, true)
+	return client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4o",
+		Input:    []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("who?")}}},
+		Params:   &schemas.ChatParameters{ResponseFormat: jsonSchemaResponseFormat()},
+	})
+}
+
+func TestChatCompletionRequest_RetriesStructuredOutputOnce(t *testing.T) {
+	client, calls := newStructuredOutputClient(t, `{"nickname":"Ada"}`, `{"name":"Ada"}`)
+	response, bifrostErr := structuredOutputRequest(t, client)
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, `{"name":"Ada"}`, *response.Choices[0].Message.Content.ContentStr)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestChatCompletionRequest_FailsAfterSecondStructuredOutputMismatch(t *testing.T) {
+	client, calls := newStructuredOutputClient(t, `not json`)
+	_, bifrostErr := structuredOutputRequest(t, client)
+	require.NotNil(t, bifrostErr)
+	assert.Equal(t, "structured_output_validation_failed", *bifrostErr.Error.Type)
+	assert.Equal(t, 502, *bifrostErr.StatusCode)
+	assert.Equal(t, int32(2), calls.Load(), "exactly one retry")
+}
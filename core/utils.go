@@ -10,8 +10,10 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -137,13 +139,48 @@ func isKeySkippingAllowed(providerKey schemas.ModelProvider) bool {
 func calculateBackoff(attempt int, config *schemas.ProviderConfig) time.Duration {
 	// Calculate an exponential backoff: initial * 2^attempt
 	backoff := min(config.NetworkConfig.RetryBackoffInitial*time.Duration(1<<uint(attempt)), config.NetworkConfig.RetryBackoffMax)
-	// Add jitter (20%)
-	jitter := float64(backoff) * (0.8 + 0.4*rand.Float64())
+	// Add jitter (±RetryJitter, 20% by default)
+	jitterFraction := schemas.DefaultRetryJitter
+	if config.NetworkConfig.RetryJitter != nil {
+		jitterFraction = min(max(*config.NetworkConfig.RetryJitter, 0), 1)
+	}
+	jitter := float64(backoff) * (1 - jitterFraction + 2*jitterFraction*rand.Float64())
 	result := time.Duration(jitter)
 	// Ensure we never exceed the configured maximum
 	return min(result, config.NetworkConfig.RetryBackoffMax)
 }
 
+// retryAfterFromContext returns the wait requested by the provider's last
+// response, read from the response headers providers store on ctx. It accepts
+// retry-after-ms (milliseconds) and Retry-After as delay-seconds or an HTTP date.
+func retryAfterFromContext(ctx *schemas.BifrostContext) (time.Duration, bool) {
+	headers, _ := ctx.Value(schemas.BifrostContextKeyProviderResponseHeaders).(map[string]string)
+	var retryAfter string
+	for name, value := range headers {
+		if strings.EqualFold(name, "retry-after-ms") {
+			if ms, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && ms >= 0 {
+				return time.Duration(ms * float64(time.Millisecond)), true
+			}
+		}
+		if strings.EqualFold(name, "retry-after") {
+			retryAfter = strings.TrimSpace(value)
+		}
+	}
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(retryAfter, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
 // validateRequestAfterPreRequestHooks validates the provider and model fields of the given request.
 func validateRequestAfterPreRequestHooks(req *schemas.BifrostRequest) *schemas.BifrostError {
 	if req == nil {
@@ -306,18 +343,23 @@ func newBifrostCtxDoneError(ctx *schemas.BifrostContext, stage string) *schemas.
 	}
 }
 
-// newBifrostQueueFullError creates a 503 BifrostError for requests dropped
-// because the provider queue is full and dropExcessRequests is enabled.
-func newBifrostQueueFullError() *schemas.BifrostError {
-	statusCode := 503
+// newBifrostSaturationError creates the 429 BifrostError for a request Bifrost
+// shed itself because a provider queue was saturated.
+func newBifrostSaturationError(saturation *schemas.GatewaySaturation) *schemas.BifrostError {
+	statusCode := 429
 	errorType := schemas.RequestDropped
+	message := "request dropped: queue is full"
+	if saturation.Reason == schemas.ShedReasonQueueWaitTimeout {
+		message = "request dropped: timed out waiting for queue space"
+	}
 	return &schemas.BifrostError{
 		IsBifrostError: true,
 		StatusCode:     &statusCode,
 		Error: &schemas.ErrorField{
 			Type:    &errorType,
-			Message: "request dropped: queue is full",
+			Message: message,
 		},
+		ExtraFields: schemas.BifrostErrorExtraFields{Saturation: saturation},
 	}
 }
 
@@ -340,18 +382,76 @@ func newBifrostMessageChan(message *schemas.BifrostResponse) chan *schemas.Bifro
 	return ch
 }
 
+// applyKeyBetaFeatures validates the caller's beta flags against the selected key's allowlist
+// and merges the key's default flags into the request's extra headers. It starts from the
+// snapshot of the caller's own extra headers (taken on the first key selection) so defaults
+// applied for one key never leak into a retry or fallback served by another key.
+func applyKeyBetaFeatures(ctx *schemas.BifrostContext, key schemas.Key) *schemas.BifrostError {
+	requested, hasSnapshot := ctx.Value(schemas.BifrostContextKeyRequestedExtraHeaders).(map[string][]string)
+	if !hasSnapshot {
+		if key.BetaFeatures == nil {
+			return nil
+		}
+		current, _ := ctx.Value(schemas.BifrostContextKeyExtraHeaders).(map[string][]string)
+		// Deep copy: providers append auto-injected beta flags to the live map.
+		requested = make(map[string][]string, len(current))
+		for name, values := range current {
+			requested[name] = slices.Clone(values)
+		}
+		ctx.SetValue(schemas.BifrostContextKeyRequestedExtraHeaders, requested)
+	}
+	if err := key.BetaFeatures.CheckHeaders(requested); err != nil {
+		statusCode := 400
+		errType := "beta_feature_not_enabled"
+		message := err.Error()
+		if key.Name != "" {
+			message = fmt.Sprintf("%s (key %q)", message, key.Name)
+		}
+		return &schemas.BifrostError{
+			IsBifrostError: true,
+			StatusCode:     &statusCode,
+			Type:           &errType,
+			Error: &schemas.ErrorField{
+				Type:    &errType,
+				Message: message,
+			},
+		}
+	}
+	ctx.SetValue(schemas.BifrostContextKeyExtraHeaders, key.BetaFeatures.ApplyDefaults(requested))
+	return nil
+}
+
 // clearCtxForFallback clears the ctx values which are not applicable for fallback requests.
 func clearCtxForFallback(ctx *schemas.BifrostContext) {
 	ctx.ClearValue(schemas.BifrostContextKeyAPIKeyID)
 	ctx.ClearValue(schemas.BifrostContextKeyAPIKeyName)
 	ctx.ClearValue(schemas.BifrostContextKeyGovernanceIncludeOnlyKeys)
 	ctx.ClearValue(schemas.BifrostContextKeyChangeRequestType)
+	ctx.ClearValue(schemas.BifrostContextKeyChangeRequestSystemPrompt)
 	ctx.ClearValue(schemas.BifrostContextKeyAttemptTrail)
 	ctx.ClearValue(schemas.BifrostContextKeyStreamEndIndicator)
 	ctx.ClearValue(schemas.BifrostContextKeyConnectionClosed)
 	ctx.ClearValue(schemas.BifrostContextKeySupportsAssistantPrefill)
 }
 
+// applyFallbacksOverride replaces the request's fallback chain with the one the
+// caller sent for this request (x-bf-fallbacks). It runs after PreRequestHooks,
+// so the override wins over chains set by routing rules and load balancing,
+// while governance has already dropped entries the virtual key may not use.
+func applyFallbacksOverride(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) {
+	override, ok := ctx.Value(schemas.BifrostContextKeyFallbacksOverride).([]schemas.Fallback)
+	if !ok {
+		return
+	}
+	req.SetFallbacks(slices.Clone(override))
+	chain := make([]string, 0, len(override))
+	for _, fallback := range override {
+		chain = append(chain, string(fallback.Provider)+"/"+fallback.Model)
+	}
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCore)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCore, schemas.LogLevelInfo, fmt.Sprintf("Fallback chain overridden by request: [%s]", strings.Join(chain, ", ")))
+}
+
 // ClearContextForInternalRequest clears context state that is specific to the
 // caller's original request, so a context derived from it can carry an
 // internal sub-request (e.g. a plugin generating an embedding for its own
@@ -392,6 +492,7 @@ func ClearContextForInternalRequest(ctx *schemas.BifrostContext) {
 	ctx.ClearValue(schemas.BifrostContextKeyLargePayloadMode)
 	ctx.ClearValue(schemas.BifrostContextKeyLargeResponseMode)
 	ctx.ClearValue(schemas.BifrostContextKeyExtraHeaders)
+	ctx.ClearValue(schemas.BifrostContextKeyRequestedExtraHeaders)
 	ctx.ClearValue(schemas.BifrostContextKeyURLPath)
 }
 
@@ -458,6 +559,12 @@ func isCachedContentRequestType(reqType schemas.RequestType) bool {
 		reqType == schemas.CachedContentDeleteRequest
 }
 
+// isFineTuningRequestType returns true if the given request type is a fine-tuning job operation.
+func isFineTuningRequestType(reqType schemas.RequestType) bool {
+	return reqType == schemas.FineTuningJobCreateRequest || reqType == schemas.FineTuningJobListRequest ||
+		reqType == schemas.FineTuningJobRetrieveRequest || reqType == schemas.FineTuningJobCancelRequest
+}
+
 // isContainerRequestType returns true if the given request type is a container API operation.
 func isContainerRequestType(reqType schemas.RequestType) bool {
 	return reqType == schemas.ContainerCreateRequest || reqType == schemas.ContainerListRequest ||
@@ -680,6 +787,22 @@ func isPromptOptionalImageEditType(t *string) bool {
 	)
 }
 
+// textCompletionToChatRequest converts a text completion request that a plugin
+// marked for chat conversion, prepending the system prompt the plugin set, if any.
+func textCompletionToChatRequest(ctx *schemas.BifrostContext, req *schemas.BifrostTextCompletionRequest) *schemas.BifrostChatRequest {
+	chatRequest := req.ToBifrostChatRequest()
+	if chatRequest == nil {
+		return nil
+	}
+	if systemPrompt := GetStringFromContext(ctx, schemas.BifrostContextKeyChangeRequestSystemPrompt); systemPrompt != "" {
+		chatRequest.Input = append([]schemas.ChatMessage{{
+			Role:    schemas.ChatMessageRoleSystem,
+			Content: &schemas.ChatMessageContent{ContentStr: &systemPrompt},
+		}}, chatRequest.Input...)
+	}
+	return chatRequest
+}
+
 // wrapConvertedStreamPostHookRunner wraps a PostHookRunner so that streaming
 // responses produced by a type-converted request are converted back to the
 // caller's original type before the post-hook runs.
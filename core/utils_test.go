@@ -1,11 +1,13 @@
 package bifrost
 
 import (
+	"context"
 	"net"
 	"strings"
 	"testing"
 
 	"github.com/maximhq/bifrost/core/network"
+	"github.com/maximhq/bifrost/core/schemas"
 )
 
 func TestValidateExternalURL(t *testing.T) {
@@ -273,3 +275,101 @@ func TestIsPrivateIP(t *testing.T) {
 	}
 }
 
+
+func TestApplyKeyBetaFeatures(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyExtraHeaders, map[string][]string{
+		"anthropic-beta": {"computer-use-2025-01-24"},
+	})
+
+	cachingKey := schemas.Key{
+		Name: "caching",
+		BetaFeatures: &schemas.KeyBetaFeatures{
+			Defaults: map[string][]string{"anthropic-beta": {"prompt-caching-2024-07-31"}},
+		},
+	}
+	if err := applyKeyBetaFeatures(ctx, cachingKey); err != nil {
+		t.Fatalf("unexpected error: %v", err.Error.Message)
+	}
+	headers, _ := ctx.Value(schemas.BifrostContextKeyExtraHeaders).(map[string][]string)
+	if got := strings.Join(headers["anthropic-beta"], ","); got != "computer-use-2025-01-24,prompt-caching-2024-07-31" {
+		t.Fatalf("expected defaults merged after requested flags, got %q", got)
+	}
+
+	// A retry on a plain key must not inherit the previous key's defaults.
+	if err := applyKeyBetaFeatures(ctx, schemas.Key{Name: "plain"}); err != nil {
+		t.Fatalf("unexpected error: %v", err.Error.Message)
+	}
+	headers, _ = ctx.Value(schemas.BifrostContextKeyExtraHeaders).(map[string][]string)
+	if got := strings.Join(headers["anthropic-beta"], ","); got != "computer-use-2025-01-24" {
+		t.Fatalf("expected only the requested flags, got %q", got)
+	}
+
+	restricted := schemas.Key{
+		Name: "restricted",
+		BetaFeatures: &schemas.KeyBetaFeatures{
+			Allowlist: map[string][]string{"anthropic-beta": {"prompt-caching-*"}},
+		},
+	}
+	bifrostErr := applyKeyBetaFeatures(ctx, restricted)
+	if bifrostErr == nil || bifrostErr.Type == nil || *bifrostErr.Type != "beta_feature_not_enabled" {
+		t.Fatalf("expected beta_feature_not_enabled error, got %+v", bifrostErr)
+	}
+	if *bifrostErr.StatusCode != 400 || !strings.Contains(bifrostErr.Error.Message, "computer-use-2025-01-24") || !strings.Contains(bifrostErr.Error.Message, "restricted") {
+		t.Fatalf("expected a 400 naming the flag and key, got %d %q", *bifrostErr.StatusCode, bifrostErr.Error.Message)
+	}
+}
+
+func TestApplyFallbacksOverride(t *testing.T) {
+	req := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider:  schemas.OpenAI,
+			Model:     "gpt-4o",
+			Fallbacks: []schemas.Fallback{{Provider: schemas.Groq, Model: "llama-3.1-8b-instant"}},
+		},
+	}
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	applyFallbacksOverride(ctx, req)
+	if _, _, fallbacks := req.GetRequestFields(); len(fallbacks) != 1 || fallbacks[0].Provider != schemas.Groq {
+		t.Fatalf("expected the configured chain without an override, got %+v", fallbacks)
+	}
+
+	override := []schemas.Fallback{
+		{Provider: schemas.Anthropic, Model: "claude-sonnet-4"},
+		{Provider: schemas.OpenAI, Model: "gpt-4o-mini"},
+	}
+	ctx.SetValue(schemas.BifrostContextKeyFallbacksOverride, override)
+	applyFallbacksOverride(ctx, req)
+	_, _, fallbacks := req.GetRequestFields()
+	if len(fallbacks) != 2 || fallbacks[0] != override[0] || fallbacks[1] != override[1] {
+		t.Fatalf("expected the override chain, got %+v", fallbacks)
+	}
+	logs := ctx.GetRoutingEngineLogs()
+	if len(logs) != 1 || !strings.Contains(logs[0].Message, "anthropic/claude-sonnet-4, openai/gpt-4o-mini") {
+		t.Fatalf("expected the override in the routing log, got %+v", logs)
+	}
+}
+
+func TestTextCompletionToChatRequestSystemPrompt(t *testing.T) {
+	req := &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4o",
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Once upon a time")},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	if chatReq := textCompletionToChatRequest(ctx, req); len(chatReq.Input) != 1 || chatReq.Input[0].Role != schemas.ChatMessageRoleUser {
+		t.Fatalf("without a system prompt the prompt should be the only message, got %+v", chatReq.Input)
+	}
+
+	ctx.SetValue(schemas.BifrostContextKeyChangeRequestSystemPrompt, "Continue the user's text.")
+	chatReq := textCompletionToChatRequest(ctx, req)
+	if len(chatReq.Input) != 2 || chatReq.Input[0].Role != schemas.ChatMessageRoleSystem || *chatReq.Input[0].Content.ContentStr != "Continue the user's text." {
+		t.Fatalf("expected the system prompt before the prompt, got %+v", chatReq.Input)
+	}
+	if *chatReq.Input[1].Content.ContentStr != "Once upon a time" {
+		t.Errorf("prompt not preserved, got %q", *chatReq.Input[1].Content.ContentStr)
+	}
+}
@@ -0,0 +1,401 @@
+// Package chaos provides runtime fault injection for game days against the real
+// request pipeline. Unlike the mocker plugin it never fabricates response
+// content: faults only delay requests, fail them with a provider error class, or
+// cut streams short. Every fault is scoped (provider / model / virtual key),
+// applies to a percentage of matching traffic and expires automatically.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "chaos"
+
+// Fault kinds.
+const (
+	FaultLatency     = "latency"      // Delay the request before it reaches the provider
+	FaultError       = "error"        // Fail the request with a provider error class
+	FaultAbortStream = "abort_stream" // Fail a stream after a number of chunks
+)
+
+// Provider error classes an error fault can return.
+const (
+	ErrorClassRateLimit      = "rate_limit"
+	ErrorClassServerError    = "server_error"
+	ErrorClassOverloaded     = "overloaded"
+	ErrorClassTimeout        = "timeout"
+	ErrorClassAuthentication = "authentication"
+	ErrorClassBadRequest     = "bad_request"
+)
+
+const (
+	// DefaultFaultTTL is how long a fault stays active when no TTL is given.
+	DefaultFaultTTL = 15 * time.Minute
+	// MaxFaultTTL caps fault lifetimes so a forgotten game day cannot linger.
+	MaxFaultTTL = 24 * time.Hour
+)
+
+// errorClasses maps an error class to the status code and error type a
+// provider would return for it.
+var errorClasses = map[string]struct {
+	status    int
+	errorType string
+}{
+	ErrorClassRateLimit:      {http.StatusTooManyRequests, "rate_limit_error"},
+	ErrorClassServerError:    {http.StatusInternalServerError, "server_error"},
+	ErrorClassOverloaded:     {http.StatusServiceUnavailable, "overloaded_error"},
+	ErrorClassTimeout:        {http.StatusGatewayTimeout, "timeout_error"},
+	ErrorClassAuthentication: {http.StatusUnauthorized, "authentication_error"},
+	ErrorClassBadRequest:     {http.StatusBadRequest, "invalid_request_error"},
+}
+
+// streamFaultKey holds the *streamFault of a stream picked for abortion.
+const streamFaultKey schemas.BifrostContextKey = "bifrost-chaos-stream-fault"
+
+// Scope selects the traffic a fault applies to. Empty fields match everything.
+type Scope struct {
+	Provider   schemas.ModelProvider `json:"provider,omitempty"`
+	Model      string                `json:"model,omitempty"`
+	VirtualKey string                `json:"virtual_key,omitempty"` // Virtual key ID or value
+}
+
+// FaultSpec describes a fault to inject.
+type FaultSpec struct {
+	Kind  string `json:"kind"`
+	Scope Scope  `json:"scope"`
+	// Percentage of matching requests affected, in (0, 100]. Defaults to 100.
+	Percentage float64 `json:"percentage,omitempty"`
+	// Latency to add for latency faults (Go duration, e.g. "2s").
+	Latency string `json:"latency,omitempty"`
+	// ErrorClass returned by error faults and by aborted streams (default server_error).
+	ErrorClass string `json:"error_class,omitempty"`
+	// AfterChunks is the number of chunks an abort_stream fault lets through.
+	AfterChunks int `json:"after_chunks,omitempty"`
+	// AllowFallbacks controls whether an injected error may trigger fallbacks (default true).
+	AllowFallbacks *bool `json:"allow_fallbacks,omitempty"`
+	// TTL after which the fault expires (Go duration, default 15m, max 24h).
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Fault is an active fault.
+type Fault struct {
+	FaultSpec
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Hits      int64     `json:"hits"`
+
+	latency time.Duration
+	hits    atomic.Int64
+}
+
+// Config is the chaos plugin configuration. Faults listed here are activated at
+// startup and expire like any other fault.
+type Config struct {
+	Faults []FaultSpec `json:"faults,omitempty"`
+}
+
+// Plugin injects the active faults into matching requests.
+type Plugin struct {
+	mu     sync.RWMutex
+	faults []*Fault
+	logger schemas.Logger
+}
+
+// streamFault tracks the chunks seen on a stream selected by an abort_stream fault.
+type streamFault struct {
+	fault *Fault
+	seen  atomic.Int64
+}
+
+// Init creates the chaos plugin and activates the faults in config.
+func Init(config *Config, logger schemas.Logger) (*Plugin, error) {
+	p := &Plugin{logger: logger}
+	if config != nil {
+		for _, spec := range config.Faults {
+			if _, err := p.AddFault(spec); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// GetName returns the plugin name.
+func (p *Plugin) GetName() string {
+	return PluginName
+}
+
+// Cleanup drops all faults.
+func (p *Plugin) Cleanup() error {
+	p.ClearFaults()
+	return nil
+}
+
+// AddFault validates spec and activates it.
+func (p *Plugin) AddFault(spec FaultSpec) (*Fault, error) {
+	fault, err := newFault(spec, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.faults = append(p.faults, fault)
+	p.mu.Unlock()
+	if p.logger != nil {
+		p.logger.Warn("chaos fault %s activated: %s on %s/%s until %s", fault.ID, fault.Kind, scopeLabel(string(fault.Scope.Provider)), scopeLabel(fault.Scope.Model), fault.ExpiresAt.Format(time.RFC3339))
+	}
+	return fault.snapshot(), nil
+}
+
+// ListFaults returns the active faults, oldest first.
+func (p *Plugin) ListFaults() []*Fault {
+	now := time.Now()
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	faults := make([]*Fault, 0, len(p.faults))
+	for _, fault := range p.faults {
+		if now.Before(fault.ExpiresAt) {
+			faults = append(faults, fault.snapshot())
+		}
+	}
+	return faults
+}
+
+// RemoveFault deactivates a fault. It reports whether the fault existed.
+func (p *Plugin) RemoveFault(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.faults)
+	p.faults = slices.DeleteFunc(p.faults, func(fault *Fault) bool { return fault.ID == id })
+	return len(p.faults) != n
+}
+
+// ClearFaults deactivates every fault.
+func (p *Plugin) ClearFaults() {
+	p.mu.Lock()
+	p.faults = nil
+	p.mu.Unlock()
+}
+
+// PreRequestHook is not used by this plugin.
+func (p *Plugin) PreRequestHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook applies the faults matching the request. It runs for every
+// attempt, so a fault scoped to one provider leaves its fallbacks untouched.
+// Latency faults add up; the first selected error fault ends the attempt.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	faults := p.matchingFaults(ctx, req)
+	if len(faults) == 0 {
+		return req, nil, nil
+	}
+	var delay time.Duration
+	for _, fault := range faults {
+		switch fault.Kind {
+		case FaultLatency:
+			fault.hits.Add(1)
+			delay += fault.latency
+		case FaultError:
+			if err := sleep(ctx, delay); err != nil {
+				return req, nil, nil
+			}
+			fault.hits.Add(1)
+			return req, &schemas.LLMPluginShortCircuit{Error: fault.bifrostError("fault injected")}, nil
+		case FaultAbortStream:
+			if bifrost.IsStreamRequestType(req.RequestType) && ctx.Value(streamFaultKey) == nil {
+				fault.hits.Add(1)
+				ctx.SetValue(streamFaultKey, &streamFault{fault: fault})
+			}
+		}
+	}
+	_ = sleep(ctx, delay)
+	return req, nil, nil
+}
+
+// PostLLMHook cuts a stream selected by an abort_stream fault: chunks up to
+// AfterChunks pass, the next one is replaced by the fault's error and the rest
+// are dropped.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, resp *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	state, ok := ctx.Value(streamFaultKey).(*streamFault)
+	if !ok || resp == nil {
+		return resp, bifrostErr, nil
+	}
+	seen := state.seen.Add(1)
+	switch {
+	case seen <= int64(state.fault.AfterChunks):
+		return resp, bifrostErr, nil
+	case seen == int64(state.fault.AfterChunks)+1:
+		return nil, state.fault.bifrostError(fmt.Sprintf("stream aborted after %d chunks", state.fault.AfterChunks)), nil
+	default:
+		return nil, &schemas.BifrostError{
+			Error:         &schemas.ErrorField{Message: "stream aborted by chaos fault " + state.fault.ID},
+			StreamControl: &schemas.StreamControl{SkipStream: schemas.Ptr(true)},
+		}, nil
+	}
+}
+
+// matchingFaults returns the live faults whose scope matches the request and
+// whose percentage roll succeeds, pruning expired faults along the way.
+func (p *Plugin) matchingFaults(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) []*Fault {
+	now := time.Now()
+	p.mu.RLock()
+	if len(p.faults) == 0 {
+		p.mu.RUnlock()
+		return nil
+	}
+	provider, model, _ := req.GetRequestFields()
+	var matched []*Fault
+	expired := false
+	for _, fault := range p.faults {
+		if !now.Before(fault.ExpiresAt) {
+			expired = true
+			continue
+		}
+		if fault.matches(ctx, provider, model) && rand.Float64()*100 < fault.Percentage {
+			matched = append(matched, fault)
+		}
+	}
+	p.mu.RUnlock()
+	if expired {
+		p.pruneExpired(now)
+	}
+	return matched
+}
+
+func (p *Plugin) pruneExpired(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = slices.DeleteFunc(p.faults, func(fault *Fault) bool {
+		if now.Before(fault.ExpiresAt) {
+			return false
+		}
+		if p.logger != nil {
+			p.logger.Info("chaos fault %s expired after %d hits", fault.ID, fault.hits.Load())
+		}
+		return true
+	})
+}
+
+func newFault(spec FaultSpec, now time.Time) (*Fault, error) {
+	fault := &Fault{FaultSpec: spec, ID: uuid.NewString(), CreatedAt: now}
+	switch spec.Kind {
+	case FaultLatency:
+		latency, err := time.ParseDuration(spec.Latency)
+		if err != nil || latency <= 0 {
+			return nil, fmt.Errorf("latency fault needs a positive latency duration, got %q", spec.Latency)
+		}
+		fault.latency = latency
+	case FaultError:
+	case FaultAbortStream:
+		if spec.AfterChunks < 0 {
+			return nil, errors.New("after_chunks must not be negative")
+		}
+	default:
+		return nil, fmt.Errorf("unknown fault kind %q (expected %s, %s or %s)", spec.Kind, FaultLatency, FaultError, FaultAbortStream)
+	}
+	if spec.Kind != FaultLatency {
+		if fault.ErrorClass == "" {
+			fault.ErrorClass = ErrorClassServerError
+		}
+		if _, ok := errorClasses[fault.ErrorClass]; !ok {
+			return nil, fmt.Errorf("unknown error_class %q", fault.ErrorClass)
+		}
+	}
+	if fault.Percentage == 0 {
+		fault.Percentage = 100
+	}
+	if fault.Percentage < 0 || fault.Percentage > 100 {
+		return nil, fmt.Errorf("percentage must be in (0, 100], got %v", fault.Percentage)
+	}
+	ttl := DefaultFaultTTL
+	if spec.TTL != "" {
+		parsed, err := time.ParseDuration(spec.TTL)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("ttl must be a positive duration, got %q", spec.TTL)
+		}
+		ttl = parsed
+	}
+	if ttl > MaxFaultTTL {
+		return nil, fmt.Errorf("ttl must not exceed %s", MaxFaultTTL)
+	}
+	fault.ExpiresAt = now.Add(ttl)
+	return fault, nil
+}
+
+func (f *Fault) matches(ctx *schemas.BifrostContext, provider schemas.ModelProvider, model string) bool {
+	if f.Scope.Provider != "" && f.Scope.Provider != provider {
+		return false
+	}
+	if f.Scope.Model != "" && f.Scope.Model != model {
+		return false
+	}
+	if f.Scope.VirtualKey != "" {
+		vkID, _ := ctx.Value(schemas.BifrostContextKeyGovernanceVirtualKeyID).(string)
+		vkValue, _ := ctx.Value(schemas.BifrostContextKeyVirtualKey).(string)
+		if f.Scope.VirtualKey != vkID && f.Scope.VirtualKey != vkValue {
+			return false
+		}
+	}
+	return true
+}
+
+// bifrostError builds the provider-shaped error for the fault's error class.
+func (f *Fault) bifrostError(detail string) *schemas.BifrostError {
+	class := errorClasses[f.ErrorClass]
+	return &schemas.BifrostError{
+		StatusCode: schemas.Ptr(class.status),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr(class.errorType),
+			Code:    schemas.Ptr(f.ErrorClass),
+			Message: fmt.Sprintf("%s (chaos fault %s)", detail, f.ID),
+		},
+		AllowFallbacks: f.AllowFallbacks,
+	}
+}
+
+// snapshot returns a copy of f safe to hand out, with Hits filled in.
+func (f *Fault) snapshot() *Fault {
+	return &Fault{
+		FaultSpec: f.FaultSpec,
+		ID:        f.ID,
+		CreatedAt: f.CreatedAt,
+		ExpiresAt: f.ExpiresAt,
+		Hits:      f.hits.Load(),
+		latency:   f.latency,
+	}
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func scopeLabel(value string) string {
+	if value == "" {
+		return "*"
+	}
+	return value
+}
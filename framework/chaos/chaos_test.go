@@ -0,0 +1,145 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func chatRequest(requestType schemas.RequestType, provider schemas.ModelProvider, model string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: requestType,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: provider, Model: model},
+	}
+}
+
+func newContext(t *testing.T) *schemas.BifrostContext {
+	t.Helper()
+	ctx, cancel := schemas.NewBifrostContextWithCancel(context.Background())
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestErrorFaultScopedToProvider(t *testing.T) {
+	p, err := Init(&Config{Faults: []FaultSpec{{
+		Kind:       FaultError,
+		Scope:      Scope{Provider: schemas.OpenAI},
+		ErrorClass: ErrorClassRateLimit,
+	}}}, nil)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	_, shortCircuit, _ := p.PreLLMHook(newContext(t), chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o"))
+	if shortCircuit == nil || shortCircuit.Error == nil {
+		t.Fatal("expected an injected error for the scoped provider")
+	}
+	if got := *shortCircuit.Error.StatusCode; got != 429 {
+		t.Errorf("status = %d, want 429", got)
+	}
+
+	if _, shortCircuit, _ = p.PreLLMHook(newContext(t), chatRequest(schemas.ChatCompletionRequest, schemas.Anthropic, "claude")); shortCircuit != nil {
+		t.Error("fault applied outside its provider scope")
+	}
+	if faults := p.ListFaults(); len(faults) != 1 || faults[0].Hits != 1 {
+		t.Errorf("unexpected faults %+v", faults)
+	}
+}
+
+func TestVirtualKeyScope(t *testing.T) {
+	p, _ := Init(nil, nil)
+	if _, err := p.AddFault(FaultSpec{Kind: FaultError, Scope: Scope{VirtualKey: "vk-1"}}); err != nil {
+		t.Fatalf("AddFault() error = %v", err)
+	}
+	ctx := newContext(t)
+	ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, "vk-1")
+	if _, shortCircuit, _ := p.PreLLMHook(ctx, chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o")); shortCircuit == nil {
+		t.Error("expected the fault to match the virtual key")
+	}
+	if _, shortCircuit, _ := p.PreLLMHook(newContext(t), chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o")); shortCircuit != nil {
+		t.Error("fault applied to a request without the virtual key")
+	}
+}
+
+func TestLatencyFault(t *testing.T) {
+	p, _ := Init(nil, nil)
+	if _, err := p.AddFault(FaultSpec{Kind: FaultLatency, Latency: "50ms"}); err != nil {
+		t.Fatalf("AddFault() error = %v", err)
+	}
+	start := time.Now()
+	if _, shortCircuit, _ := p.PreLLMHook(newContext(t), chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o")); shortCircuit != nil {
+		t.Fatal("latency fault must not short-circuit")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("request delayed %s, want at least 50ms", elapsed)
+	}
+}
+
+func TestAbortStreamFault(t *testing.T) {
+	p, _ := Init(nil, nil)
+	if _, err := p.AddFault(FaultSpec{Kind: FaultAbortStream, AfterChunks: 2}); err != nil {
+		t.Fatalf("AddFault() error = %v", err)
+	}
+
+	// Non-streaming requests are not affected.
+	ctx := newContext(t)
+	p.PreLLMHook(ctx, chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o"))
+	if resp, bifrostErr, _ := p.PostLLMHook(ctx, &schemas.BifrostResponse{}, nil); resp == nil || bifrostErr != nil {
+		t.Fatal("non-streaming response was altered")
+	}
+
+	ctx = newContext(t)
+	p.PreLLMHook(ctx, chatRequest(schemas.ChatCompletionStreamRequest, schemas.OpenAI, "gpt-4o"))
+	for i := 0; i < 2; i++ {
+		if resp, bifrostErr, _ := p.PostLLMHook(ctx, &schemas.BifrostResponse{}, nil); resp == nil || bifrostErr != nil {
+			t.Fatalf("chunk %d should pass through", i+1)
+		}
+	}
+	_, bifrostErr, _ := p.PostLLMHook(ctx, &schemas.BifrostResponse{}, nil)
+	if bifrostErr == nil || bifrostErr.StreamControl != nil || *bifrostErr.StatusCode != 500 {
+		t.Fatalf("third chunk should become the injected error, got %+v", bifrostErr)
+	}
+	_, bifrostErr, _ = p.PostLLMHook(ctx, &schemas.BifrostResponse{}, nil)
+	if bifrostErr == nil || bifrostErr.StreamControl == nil || !*bifrostErr.StreamControl.SkipStream {
+		t.Fatal("chunks after the abort should be skipped")
+	}
+}
+
+func TestFaultExpiry(t *testing.T) {
+	p, _ := Init(nil, nil)
+	fault, err := p.AddFault(FaultSpec{Kind: FaultError, TTL: "1ms"})
+	if err != nil {
+		t.Fatalf("AddFault() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, shortCircuit, _ := p.PreLLMHook(newContext(t), chatRequest(schemas.ChatCompletionRequest, schemas.OpenAI, "gpt-4o")); shortCircuit != nil {
+		t.Error("expired fault was applied")
+	}
+	if len(p.ListFaults()) != 0 || p.RemoveFault(fault.ID) {
+		t.Error("expired fault was not pruned")
+	}
+}
+
+func TestFaultValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec FaultSpec
+	}{
+		{name: "unknown kind", spec: FaultSpec{Kind: "explode"}},
+		{name: "latency without duration", spec: FaultSpec{Kind: FaultLatency}},
+		{name: "unknown error class", spec: FaultSpec{Kind: FaultError, ErrorClass: "teapot"}},
+		{name: "percentage above 100", spec: FaultSpec{Kind: FaultError, Percentage: 150}},
+		{name: "negative after_chunks", spec: FaultSpec{Kind: FaultAbortStream, AfterChunks: -1}},
+		{name: "ttl above max", spec: FaultSpec{Kind: FaultError, TTL: "48h"}},
+	}
+	p, _ := Init(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := p.AddFault(tt.spec); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
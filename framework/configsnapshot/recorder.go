@@ -0,0 +1,172 @@
+// Package configsnapshot keeps a history of the config store's state so a bad
+// change to providers, keys, routing rules or plugins can be rolled back. The
+// recorder captures a snapshot shortly after every config write; identical
+// states share one stored copy, so history stays small however often a config
+// flips back and forth.
+package configsnapshot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+const (
+	// DefaultDebounce is how long the recorder waits after a config write
+	// before capturing, so one API call that writes several rows records a
+	// single snapshot.
+	DefaultDebounce = 2 * time.Second
+	// DefaultRetention is how many snapshots are kept.
+	DefaultRetention = 500
+	// DefaultResyncInterval is how often the recorder captures without being
+	// signalled, which picks up writes made by other replicas sharing the store.
+	DefaultResyncInterval = time.Minute
+)
+
+// Recorder captures a config snapshot after every config store write and
+// prunes history beyond the retention.
+type Recorder struct {
+	store  configstore.ConfigStore
+	logger schemas.Logger
+
+	debounce       time.Duration
+	retention      int
+	resyncInterval time.Duration
+
+	// mu serializes captures, so a capture requested by a rollback does not
+	// race the background loop.
+	mu sync.Mutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	cancel   context.CancelFunc
+}
+
+// NewRecorder constructs a recorder for store. Returns nil when store is nil,
+// so callers can wire it unconditionally and check the result before starting.
+func NewRecorder(store configstore.ConfigStore, logger schemas.Logger) *Recorder {
+	if store == nil {
+		return nil
+	}
+	return &Recorder{
+		store:          store,
+		logger:         logger,
+		debounce:       DefaultDebounce,
+		retention:      DefaultRetention,
+		resyncInterval: DefaultResyncInterval,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// SetDebounce updates how long to wait after a write before capturing. Call
+// before Start.
+func (r *Recorder) SetDebounce(d time.Duration) {
+	r.debounce = d
+}
+
+// SetRetention updates how many snapshots are kept. Values below 1 keep every
+// snapshot. Call before Start.
+func (r *Recorder) SetRetention(n int) {
+	r.retention = n
+}
+
+// SetResyncInterval updates how often to capture without a write signal. Call
+// before Start.
+func (r *Recorder) SetResyncInterval(d time.Duration) {
+	r.resyncInterval = d
+}
+
+// Start begins the capture loop in a background goroutine.
+func (r *Recorder) Start(ctx context.Context) {
+	runCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	go r.run(runCtx)
+	if r.logger != nil {
+		r.logger.Info("config snapshot recorder started (debounce=%s, retention=%d)", r.debounce, r.retention)
+	}
+}
+
+// Stop stops the capture loop. sync.Once guards against double-close panics
+// from redundant shutdown paths.
+func (r *Recorder) Stop() {
+	r.stopOnce.Do(func() {
+		if r.cancel != nil {
+			r.cancel()
+		}
+		close(r.stopCh)
+	})
+}
+
+// Capture records the current config state right away and prunes history. It
+// returns the latest snapshot, which is an existing one when nothing changed.
+// Rollbacks call it first so the state they replace is always in history.
+func (r *Recorder) Capture(ctx context.Context) (*tables.TableConfigSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot, created, err := r.store.CaptureConfigSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if created && r.retention > 0 {
+		if _, err := r.store.DeleteConfigSnapshotsBeyond(ctx, r.retention); err != nil && r.logger != nil {
+			r.logger.Warn("failed to prune config snapshots: %v", err)
+		}
+	}
+	return snapshot, nil
+}
+
+func (r *Recorder) run(ctx context.Context) {
+	ticker := time.NewTicker(r.resyncInterval)
+	defer ticker.Stop()
+
+	// The debounce timer is armed by the first write after a capture and not
+	// pushed back by later ones, so a steady stream of writes is still
+	// captured every debounce period.
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	// Capture right away so history starts with the config the process
+	// booted with.
+	r.capture(ctx)
+
+	for {
+		select {
+		case <-r.store.ConfigMutations():
+			if debounceC == nil {
+				debounce = time.NewTimer(r.debounce)
+				debounceC = debounce.C
+			}
+		case <-debounceC:
+			debounceC = nil
+			r.capture(ctx)
+		case <-ticker.C:
+			r.capture(ctx)
+		case <-r.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Recorder) capture(ctx context.Context) {
+	snapshot, err := r.Capture(ctx)
+	if err != nil {
+		if r.logger != nil && ctx.Err() == nil {
+			r.logger.Warn("failed to capture config snapshot: %v", err)
+		}
+		return
+	}
+	if r.logger != nil {
+		r.logger.Debug("config snapshot %s is current", snapshot.Hash)
+	}
+}
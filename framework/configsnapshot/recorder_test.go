@@ -0,0 +1,121 @@
+package configsnapshot
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// fakeStore implements only the config snapshot subset of ConfigStore.
+type fakeStore struct {
+	configstore.ConfigStore
+
+	mu        sync.Mutex
+	mutations chan struct{}
+	captures  int
+	prunes    []int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{mutations: make(chan struct{}, 1)}
+}
+
+func (f *fakeStore) ConfigMutations() <-chan struct{} {
+	return f.mutations
+}
+
+func (f *fakeStore) CaptureConfigSnapshot(_ context.Context) (*tables.TableConfigSnapshot, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.captures++
+	return &tables.TableConfigSnapshot{ID: uint(f.captures), Hash: "hash"}, true, nil
+}
+
+func (f *fakeStore) DeleteConfigSnapshotsBeyond(_ context.Context, keep int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prunes = append(f.prunes, keep)
+	return 0, nil
+}
+
+func (f *fakeStore) captureCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.captures
+}
+
+// signal sends a write signal the way the real store does: non-blocking, so
+// signals raised while one is pending coalesce.
+func (f *fakeStore) signal() {
+	select {
+	case f.mutations <- struct{}{}:
+	default:
+	}
+}
+
+func waitForCaptures(t *testing.T, store *fakeStore, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for store.captureCount() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d captures, got %d", want, store.captureCount())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNewRecorderNilStore(t *testing.T) {
+	if NewRecorder(nil, nil) != nil {
+		t.Fatal("expected nil recorder without a store")
+	}
+}
+
+func TestRecorderCapturesOnStartAndDebouncesWrites(t *testing.T) {
+	store := newFakeStore()
+	r := NewRecorder(store, nil)
+	r.SetDebounce(50 * time.Millisecond)
+	r.SetRetention(10)
+	r.SetResyncInterval(time.Hour)
+	r.Start(context.Background())
+	defer r.Stop()
+
+	waitForCaptures(t, store, 1)
+
+	// A burst of writes records a single snapshot.
+	for i := 0; i < 5; i++ {
+		store.signal()
+		time.Sleep(5 * time.Millisecond)
+	}
+	waitForCaptures(t, store, 2)
+	time.Sleep(150 * time.Millisecond)
+	if got := store.captureCount(); got != 2 {
+		t.Fatalf("expected one capture for the burst, got %d total", got)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.prunes) != 2 || store.prunes[0] != 10 {
+		t.Fatalf("expected every new snapshot to prune to the retention, got %v", store.prunes)
+	}
+}
+
+func TestRecorderResyncsWithoutSignal(t *testing.T) {
+	store := newFakeStore()
+	r := NewRecorder(store, nil)
+	r.SetResyncInterval(20 * time.Millisecond)
+	r.Start(context.Background())
+	defer r.Stop()
+
+	waitForCaptures(t, store, 3)
+}
+
+func TestRecorderStopIsIdempotent(t *testing.T) {
+	r := NewRecorder(newFakeStore(), nil)
+	r.Start(context.Background())
+	r.Stop()
+	r.Stop()
+}
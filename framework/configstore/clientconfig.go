@@ -8,8 +8,10 @@ import (
 	"hash"
 	"maps"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -43,15 +45,32 @@ type CompatConfig struct {
 	ConvertChatToResponses bool `json:"convert_chat_to_responses"`
 	ShouldDropParams       bool `json:"should_drop_params"`
 	ShouldConvertParams    bool `json:"should_convert_params"`
+	// TextToChatSystemPrompt is the system message of text completion requests
+	// converted to chat.
+	TextToChatSystemPrompt string `json:"text_to_chat_system_prompt,omitempty"`
+	// TextToChatModels are always converted from text completion to chat.
+	TextToChatModels []string `json:"text_to_chat_models,omitempty"`
+}
+
+// Equal reports whether c and other configure the compat plugin the same way.
+func (c CompatConfig) Equal(other CompatConfig) bool {
+	return c.ConvertTextToChat == other.ConvertTextToChat &&
+		c.ConvertChatToResponses == other.ConvertChatToResponses &&
+		c.ShouldDropParams == other.ShouldDropParams &&
+		c.ShouldConvertParams == other.ShouldConvertParams &&
+		c.TextToChatSystemPrompt == other.TextToChatSystemPrompt &&
+		slices.Equal(c.TextToChatModels, other.TextToChatModels)
 }
 
 // UnmarshalJSON defaults all bool fields to true when absent from JSON.
 func (c *CompatConfig) UnmarshalJSON(data []byte) error {
 	type compatConfig struct {
-		ConvertTextToChat      *bool `json:"convert_text_to_chat"`
-		ConvertChatToResponses *bool `json:"convert_chat_to_responses"`
-		ShouldDropParams       *bool `json:"should_drop_params"`
-		ShouldConvertParams    *bool `json:"should_convert_params"`
+		ConvertTextToChat      *bool    `json:"convert_text_to_chat"`
+		ConvertChatToResponses *bool    `json:"convert_chat_to_responses"`
+		ShouldDropParams       *bool    `json:"should_drop_params"`
+		ShouldConvertParams    *bool    `json:"should_convert_params"`
+		TextToChatSystemPrompt string   `json:"text_to_chat_system_prompt"`
+		TextToChatModels       []string `json:"text_to_chat_models"`
 	}
 	var s compatConfig
 	if err := sonic.Unmarshal(data, &s); err != nil {
@@ -61,6 +80,8 @@ func (c *CompatConfig) UnmarshalJSON(data []byte) error {
 	c.ConvertChatToResponses = s.ConvertChatToResponses == nil || *s.ConvertChatToResponses
 	c.ShouldDropParams = s.ShouldDropParams == nil || *s.ShouldDropParams
 	c.ShouldConvertParams = s.ShouldConvertParams == nil || *s.ShouldConvertParams
+	c.TextToChatSystemPrompt = s.TextToChatSystemPrompt
+	c.TextToChatModels = s.TextToChatModels
 	return nil
 }
 
@@ -105,6 +126,7 @@ type ClientConfig struct {
 	ConfigHash                            string                                `json:"-"`                                           // Config hash for reconciliation (not serialized)
 	DumpErrorsInConsoleLogs               bool                                  `json:"dump_errors_in_console_logs"`                 // Dump error details in console logs
 	WebhookConfig                         *tables.WebhookConfig                 `json:"webhook_config,omitempty"`                    // Global webhook delivery settings; nil means all defaults
+	RetentionConfig                       *tables.RetentionConfig               `json:"retention_config,omitempty"`                  // Retention windows per data class and virtual key; nil means all defaults
 }
 
 // IsMCPOAuthDiscoveryEnabled reports whether the well-known OAuth discovery
@@ -185,6 +207,12 @@ func (c *ClientConfig) GenerateClientConfigHash() (string, error) {
 	if c.Compat.ShouldConvertParams {
 		hash.Write([]byte("compatShouldConvertParams:true"))
 	}
+	if c.Compat.TextToChatSystemPrompt != "" {
+		hash.Write([]byte("compatTextToChatSystemPrompt:" + c.Compat.TextToChatSystemPrompt))
+	}
+	if len(c.Compat.TextToChatModels) > 0 {
+		hash.Write([]byte("compatTextToChatModels:" + strings.Join(c.Compat.TextToChatModels, ",")))
+	}
 
 	// Only hash non-default value to avoid legacy config hash churn.
 	if c.HideDeletedVirtualKeysInFilters {
@@ -273,6 +301,16 @@ func (c *ClientConfig) GenerateClientConfigHash() (string, error) {
 		hash.Write(data)
 	}
 
+	// Only hash when present to avoid legacy config hash churn on upgrade.
+	if c.RetentionConfig != nil {
+		data, err := sonic.Marshal(c.RetentionConfig)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte("retentionConfig:"))
+		hash.Write(data)
+	}
+
 	// Hash integer fields
 	data, err := sonic.Marshal(c.InitialPoolSize)
 	if err != nil {
@@ -468,6 +506,7 @@ type ProviderConfig struct {
 	StoreRawRequestResponse  bool                              `json:"store_raw_request_response"`            // Capture raw request/response for internal logging only; strip from API responses returned to clients
 	CustomProviderConfig     *schemas.CustomProviderConfig     `json:"custom_provider_config,omitempty"`      // Custom provider configuration
 	OpenAIConfig             *schemas.OpenAIConfig             `json:"openai_config,omitempty"`               // OpenAI-specific configuration
+	LoadBalancingStrategy    schemas.LoadBalancingStrategy     `json:"load_balancing_strategy,omitempty"`     // How requests are spread across keys (default: weighted)
 	ConfigHash               string                            `json:"config_hash,omitempty"`                 // Hash of config.json version, used for change detection
 	Status                   string                            `json:"status,omitempty"`                      // Model discovery status for keyless providers
 	Description              string                            `json:"description,omitempty"`                 // Model discovery error message for keyless providers
@@ -488,6 +527,7 @@ func (p *ProviderConfig) Redacted() *ProviderConfig {
 		StoreRawRequestResponse:  p.StoreRawRequestResponse,
 		CustomProviderConfig:     p.CustomProviderConfig,
 		OpenAIConfig:             p.OpenAIConfig,
+		LoadBalancingStrategy:    p.LoadBalancingStrategy,
 		ConfigHash:               p.ConfigHash,
 		Status:                   p.Status,
 		Description:              p.Description,
@@ -536,6 +576,9 @@ func (p *ProviderConfig) Redacted() *ProviderConfig {
 		} else {
 			redactedConfig.Keys[i].UseAnthropicEndpoints = new(false)
 		}
+		redactedConfig.Keys[i].BYOK = key.BYOK
+		// Beta flags are not secret
+		redactedConfig.Keys[i].BetaFeatures = key.BetaFeatures
 
 		// Add model discovery status and error
 		redactedConfig.Keys[i].Status = key.Status
@@ -561,6 +604,7 @@ func (p *ProviderConfig) Redacted() *ProviderConfig {
 			if len(key.AzureKeyConfig.Scopes) > 0 {
 				azureConfig.Scopes = key.AzureKeyConfig.Scopes
 			}
+			azureConfig.AuthType = key.AzureKeyConfig.AuthType
 			redactedConfig.Keys[i].AzureKeyConfig = azureConfig
 		}
 
@@ -723,6 +767,11 @@ func (p *ProviderConfig) GenerateConfigHash(providerName string) (string, error)
 		hash.Write(data)
 	}
 
+	// Hash LoadBalancingStrategy
+	if p.LoadBalancingStrategy != "" {
+		hash.Write([]byte("loadBalancingStrategy:" + string(p.LoadBalancingStrategy)))
+	}
+
 	// Hash SendBackRawRequest
 	if p.SendBackRawRequest {
 		hash.Write([]byte("sendBackRawRequest"))
@@ -875,6 +924,19 @@ func GenerateKeyHash(key schemas.Key) (string, error) {
 	if useAnthropicEndpoints {
 		hash.Write([]byte("useAnthropicEndpoints:true"))
 	}
+	// Hash BYOK (only true produces different hash)
+	if key.BYOK {
+		hash.Write([]byte("byok:true"))
+	}
+	// Hash BetaFeatures (encoding/json sorts map keys, keeping the hash stable)
+	if key.BetaFeatures != nil {
+		data, err := json.Marshal(key.BetaFeatures)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte("betaFeatures:"))
+		hash.Write(data)
+	}
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
@@ -1034,6 +1096,15 @@ func GenerateVirtualKeyHash(vk tables.TableVirtualKey) (string, error) {
 		}
 		hash.Write(data)
 	}
+	// Hash Defaults only when set, so keys without presets keep their hash
+	if !vk.Defaults.IsEmpty() {
+		data, err := sonic.Marshal(vk.Defaults)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte("defaults:"))
+		hash.Write(data)
+	}
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
@@ -1399,6 +1470,21 @@ func GenerateRoutingRuleHash(r tables.TableRoutingRule) (string, error) {
 		hash.Write(data)
 	}
 
+	// Hash Type and EmbeddingBuckets for embedding length rules only, so CEL rule
+	// hashes are unchanged whether or not the type is spelled out
+	if r.IsEmbeddingLengthRule() {
+		hash.Write([]byte("type:" + r.Type))
+		if r.EmbeddingBuckets != nil {
+			hash.Write([]byte(*r.EmbeddingBuckets))
+		} else if len(r.ParsedEmbeddingBuckets) > 0 {
+			data, err := sonic.Marshal(r.ParsedEmbeddingBuckets)
+			if err != nil {
+				return "", err
+			}
+			hash.Write(data)
+		}
+	}
+
 	// Hash ChainRule
 	if r.ChainRule {
 		hash.Write([]byte("chain_rule:true"))
@@ -1507,6 +1593,11 @@ func GenerateMCPClientHash(m tables.TableMCPClient) (string, error) {
 		}
 	}
 
+	// Hash OutputValidation only when set so existing hashes stay stable
+	if m.OutputValidation != "" {
+		hash.Write([]byte("outputValidation:" + m.OutputValidation))
+	}
+
 	// will enable it in the future with a migration
 	// hash.Write([]byte("disabled:" + strconv.FormatBool(m.Disabled)))
 	return hex.EncodeToString(hash.Sum(nil)), nil
@@ -1695,4 +1786,24 @@ type GovernanceConfig struct {
 	PricingOverrides         []tables.TablePricingOverride `json:"pricing_overrides,omitempty"`
 	AuthConfig               *AuthConfig                   `json:"auth_config,omitempty"`
 	ComplexityAnalyzerConfig *ComplexityAnalyzerConfig     `json:"complexity_analyzer_config,omitempty"`
+	BudgetAlerts             *BudgetAlertsConfig           `json:"budget_alerts,omitempty"`
+}
+
+// BudgetAlertsConfig configures the notifications sent when a virtual key or
+// team budget crosses a share of its limit within the current reset window.
+// It is read from config.json only.
+type BudgetAlertsConfig struct {
+	Thresholds  []float64                `json:"thresholds,omitempty"`   // Fractions of the limit, e.g. 0.8; defaults to 0.8 and 1.0
+	WebhookURLs []string                 `json:"webhook_urls,omitempty"` // Each receives a JSON POST per alert
+	Email       *BudgetAlertsEmailConfig `json:"email,omitempty"`
+}
+
+// BudgetAlertsEmailConfig is the SMTP relay budget alerts are mailed through.
+type BudgetAlertsEmailConfig struct {
+	Host     string             `json:"host"`
+	Port     int                `json:"port"`
+	Username *schemas.SecretVar `json:"username,omitempty"`
+	Password *schemas.SecretVar `json:"password,omitempty"`
+	From     string             `json:"from"`
+	To       []string           `json:"to"`
 }
@@ -0,0 +1,364 @@
+package configstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// configSnapshotVersion is the version of the snapshot content format.
+const configSnapshotVersion = 1
+
+// configSnapshotModels are the tables a config snapshot covers, parents before
+// children so rows can be restored without breaking foreign keys.
+var configSnapshotModels = []any{
+	&tables.TableProvider{},
+	&tables.TableKey{},
+	&tables.TableModel{},
+	&tables.TableRoutingRule{},
+	&tables.TableRoutingTarget{},
+	&tables.TablePlugin{},
+}
+
+// configSnapshotTables holds the names of the tables in configSnapshotModels.
+var configSnapshotTables = func() map[string]struct{} {
+	names := make(map[string]struct{}, len(configSnapshotModels))
+	for _, model := range configSnapshotModels {
+		names[model.(schema.Tabler).TableName()] = struct{}{}
+	}
+	return names
+}()
+
+// configSnapshotContent is the stored form of a snapshot. Rows are kept as read
+// from the database, keyed by column name, so secrets stay encrypted and no
+// model hook runs on capture or restore.
+type configSnapshotContent struct {
+	Version int                         `json:"version"`
+	Tables  map[string][]map[string]any `json:"tables"`
+}
+
+// ConfigMutations returns a channel that receives a value after writes to the
+// providers, keys, routing rules or plugins tables. Writes are coalesced: the
+// channel holds at most one pending value.
+func (s *RDBConfigStore) ConfigMutations() <-chan struct{} {
+	return s.configMutations
+}
+
+// registerConfigMutationCallbacks installs the GORM callbacks that feed
+// ConfigMutations. Writes through raw SQL are not observed.
+func (s *RDBConfigStore) registerConfigMutationCallbacks(db *gorm.DB) {
+	notify := func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement == nil {
+			return
+		}
+		if _, ok := configSnapshotTables[tx.Statement.Table]; !ok {
+			return
+		}
+		select {
+		case s.configMutations <- struct{}{}:
+		default:
+		}
+	}
+	db.Callback().Create().After("gorm:after_create").Register("bifrost:config_mutation", notify)
+	db.Callback().Update().After("gorm:after_update").Register("bifrost:config_mutation", notify)
+	db.Callback().Delete().After("gorm:after_delete").Register("bifrost:config_mutation", notify)
+}
+
+// CaptureConfigSnapshot records the current providers, keys, routing rules and
+// plugins. The content is stored once per distinct hash, and nothing is recorded
+// when the state matches the latest snapshot. Returns the latest snapshot and
+// whether it was created by this call.
+func (s *RDBConfigStore) CaptureConfigSnapshot(ctx context.Context) (*tables.TableConfigSnapshot, bool, error) {
+	var snapshot *tables.TableConfigSnapshot
+	created := false
+	err := s.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		content, err := readConfigSnapshotContent(tx)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("failed to encode config snapshot: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		var latest []tables.TableConfigSnapshot
+		if err := tx.Order("id DESC").Limit(1).Find(&latest).Error; err != nil {
+			return err
+		}
+		if len(latest) > 0 && latest[0].Hash == hash {
+			snapshot = &latest[0]
+			return nil
+		}
+
+		now := time.Now().UTC()
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tables.TableConfigSnapshotContent{
+			Hash:      hash,
+			Content:   string(data),
+			CreatedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		snapshot = &tables.TableConfigSnapshot{
+			Hash:         hash,
+			Providers:    len(content.Tables[tables.TableProvider{}.TableName()]),
+			Keys:         len(content.Tables[tables.TableKey{}.TableName()]),
+			RoutingRules: len(content.Tables[tables.TableRoutingRule{}.TableName()]),
+			Plugins:      len(content.Tables[tables.TablePlugin{}.TableName()]),
+			CreatedAt:    now,
+		}
+		if err := tx.Create(snapshot).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return snapshot, created, nil
+}
+
+// GetConfigSnapshots lists recorded snapshots, newest first. A limit of zero
+// or less returns every snapshot.
+func (s *RDBConfigStore) GetConfigSnapshots(ctx context.Context, limit int) ([]tables.TableConfigSnapshot, error) {
+	query := s.DB().WithContext(ctx).Order("id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var snapshots []tables.TableConfigSnapshot
+	if err := query.Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// RestoreConfigSnapshot replaces the providers, keys, routing rules and plugins
+// with the content of the snapshot with the given hash, in a single
+// transaction. Rows the snapshot does not hold are deleted and the others are
+// written back as they were captured. Returns ErrNotFound for an unknown hash.
+func (s *RDBConfigStore) RestoreConfigSnapshot(ctx context.Context, hash string) error {
+	db := s.DB().WithContext(ctx)
+	var stored tables.TableConfigSnapshotContent
+	if err := db.Where("hash = ?", hash).First(&stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	var content configSnapshotContent
+	decoder := json.NewDecoder(bytes.NewReader([]byte(stored.Content)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&content); err != nil {
+		return fmt.Errorf("failed to decode config snapshot %s: %w", hash, err)
+	}
+	if content.Version != configSnapshotVersion {
+		return fmt.Errorf("config snapshot %s has unsupported version %d", hash, content.Version)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		schemas := make([]*schema.Schema, 0, len(configSnapshotModels))
+		for _, model := range configSnapshotModels {
+			sch, err := parseConfigSnapshotSchema(tx, model)
+			if err != nil {
+				return err
+			}
+			schemas = append(schemas, sch)
+		}
+
+		// Delete the rows the snapshot does not hold, children first. Tables
+		// without a primary key are replaced wholesale.
+		for i := len(schemas) - 1; i >= 0; i-- {
+			sch := schemas[i]
+			table := clause.Table{Name: sch.Table}
+			pk := sch.PrioritizedPrimaryField
+			rows := content.Tables[sch.Table]
+			if pk == nil || len(rows) == 0 {
+				if err := tx.Exec("DELETE FROM ?", table).Error; err != nil {
+					return fmt.Errorf("failed to clear %s: %w", sch.Table, err)
+				}
+				continue
+			}
+			ids := make([]any, 0, len(rows))
+			for _, row := range rows {
+				ids = append(ids, decodeConfigSnapshotValue(pk, row[pk.DBName]))
+			}
+			if err := tx.Exec("DELETE FROM ? WHERE ? NOT IN ?", table, clause.Column{Name: pk.DBName}, ids).Error; err != nil {
+				return fmt.Errorf("failed to prune %s: %w", sch.Table, err)
+			}
+		}
+
+		// Write the snapshot rows back, parents first.
+		for _, sch := range schemas {
+			pk := sch.PrioritizedPrimaryField
+			for _, row := range content.Tables[sch.Table] {
+				values := make(map[string]any, len(row))
+				updates := make([]string, 0, len(row))
+				for _, field := range sch.Fields {
+					if field.DBName == "" {
+						continue
+					}
+					value, ok := row[field.DBName]
+					if !ok {
+						continue // column added after the snapshot was taken
+					}
+					values[field.DBName] = decodeConfigSnapshotValue(field, value)
+					if pk == nil || field.DBName != pk.DBName {
+						updates = append(updates, field.DBName)
+					}
+				}
+				query := tx.Table(sch.Table)
+				if pk != nil && len(updates) > 0 {
+					sort.Strings(updates)
+					query = query.Clauses(clause.OnConflict{
+						Columns:   []clause.Column{{Name: pk.DBName}},
+						DoUpdates: clause.AssignmentColumns(updates),
+					})
+				}
+				if err := query.Create(values).Error; err != nil {
+					return fmt.Errorf("failed to restore %s: %w", sch.Table, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteConfigSnapshotsBeyond keeps the newest keep snapshots, deletes the
+// older ones and the contents no remaining snapshot refers to. Returns the
+// number of snapshots deleted.
+func (s *RDBConfigStore) DeleteConfigSnapshotsBeyond(ctx context.Context, keep int) (int64, error) {
+	var deleted int64
+	err := s.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cutoff []uint
+		if err := tx.Model(&tables.TableConfigSnapshot{}).Order("id DESC").Offset(keep).Limit(1).Pluck("id", &cutoff).Error; err != nil {
+			return err
+		}
+		if len(cutoff) == 0 {
+			return nil
+		}
+		res := tx.Where("id <= ?", cutoff[0]).Delete(&tables.TableConfigSnapshot{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		return tx.Where("hash NOT IN (?)", tx.Model(&tables.TableConfigSnapshot{}).Select("hash")).
+			Delete(&tables.TableConfigSnapshotContent{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// readConfigSnapshotContent reads the rows of every snapshotted table in
+// primary key order, normalized so equal states encode to equal bytes.
+func readConfigSnapshotContent(tx *gorm.DB) (*configSnapshotContent, error) {
+	content := &configSnapshotContent{
+		Version: configSnapshotVersion,
+		Tables:  make(map[string][]map[string]any, len(configSnapshotModels)),
+	}
+	for _, model := range configSnapshotModels {
+		sch, err := parseConfigSnapshotSchema(tx, model)
+		if err != nil {
+			return nil, err
+		}
+		query := tx.Table(sch.Table)
+		if pk := sch.PrioritizedPrimaryField; pk != nil {
+			query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: pk.DBName}})
+		} else {
+			for _, name := range sch.DBNames {
+				query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: name}})
+			}
+		}
+		var rows []map[string]any
+		if err := query.Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", sch.Table, err)
+		}
+		normalized := make([]map[string]any, 0, len(rows))
+		for _, row := range rows {
+			values := make(map[string]any, len(row))
+			for _, field := range sch.Fields {
+				if value, ok := row[field.DBName]; ok && field.DBName != "" {
+					values[field.DBName] = encodeConfigSnapshotValue(field, value)
+				}
+			}
+			normalized = append(normalized, values)
+		}
+		content.Tables[sch.Table] = normalized
+	}
+	return content, nil
+}
+
+func parseConfigSnapshotSchema(tx *gorm.DB, model any) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return nil, fmt.Errorf("failed to parse schema of %T: %w", model, err)
+	}
+	return stmt.Schema, nil
+}
+
+// encodeConfigSnapshotValue normalizes a column value read from the database
+// so SQLite and Postgres, which return booleans and times differently, encode
+// the same state identically.
+func encodeConfigSnapshotValue(field *schema.Field, value any) any {
+	if raw, ok := value.([]byte); ok {
+		value = string(raw)
+	}
+	switch field.DataType {
+	case schema.Bool:
+		switch v := value.(type) {
+		case int64:
+			return v != 0
+		case int:
+			return v != 0
+		}
+	case schema.Time:
+		if v, ok := value.(time.Time); ok {
+			return v.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return value
+}
+
+// decodeConfigSnapshotValue converts a value decoded from snapshot JSON back to
+// the Go type the column is written with.
+func decodeConfigSnapshotValue(field *schema.Field, value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		switch field.DataType {
+		case schema.Float:
+			if f, err := v.Float64(); err == nil {
+				return f
+			}
+		case schema.Bool:
+			return v.String() != "0"
+		}
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case string:
+		if field.DataType == schema.Time {
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t
+			}
+		}
+	}
+	return value
+}
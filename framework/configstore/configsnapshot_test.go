@@ -0,0 +1,103 @@
+package configstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConfigSnapshotTestStore returns an RDB test store with the snapshot
+// tables migrated and mutation callbacks wired as the real constructors do.
+func setupConfigSnapshotTestStore(t *testing.T) *RDBConfigStore {
+	store := setupRDBTestStore(t)
+	require.NoError(t, store.DB().AutoMigrate(&tables.TableModel{}, &tables.TableConfigSnapshot{}, &tables.TableConfigSnapshotContent{}))
+	store.configMutations = make(chan struct{}, 1)
+	store.registerConfigMutationCallbacks(store.DB())
+	return store
+}
+
+func TestConfigSnapshotCaptureDedupesAndRestores(t *testing.T) {
+	ctx := context.Background()
+	store := setupConfigSnapshotTestStore(t)
+
+	require.NoError(t, store.AddProvider(ctx, schemas.OpenAI, ProviderConfig{
+		Keys: []schemas.Key{{ID: "key-a", Name: "openai-a", Value: *schemas.NewSecretVar("sk-a"), Weight: 1.0}},
+	}))
+	require.NoError(t, store.CreateRoutingRule(ctx, routingRuleFixture("rule-1", 1, "openai")))
+	plugin := &tables.TablePlugin{Name: "test-plugin", Enabled: true, Version: 1, Config: map[string]any{"mode": "a"}}
+	require.NoError(t, store.CreatePlugin(ctx, plugin))
+	select {
+	case <-store.ConfigMutations():
+	default:
+		t.Fatal("expected config writes to signal ConfigMutations")
+	}
+
+	first, created, err := store.CaptureConfigSnapshot(ctx)
+	require.NoError(t, err)
+	require.True(t, created)
+	assert.Equal(t, 1, first.Providers)
+	assert.Equal(t, 1, first.Keys)
+	assert.Equal(t, 1, first.RoutingRules)
+	assert.Equal(t, 1, first.Plugins)
+
+	again, created, err := store.CaptureConfigSnapshot(ctx)
+	require.NoError(t, err)
+	assert.False(t, created, "an unchanged config should not record a new snapshot")
+	assert.Equal(t, first.ID, again.ID)
+
+	// Mutate every snapshotted area.
+	require.NoError(t, store.AddProvider(ctx, schemas.Anthropic, ProviderConfig{
+		Keys: []schemas.Key{{ID: "key-b", Name: "anthropic-b", Value: *schemas.NewSecretVar("sk-b"), Weight: 1.0}},
+	}))
+	require.NoError(t, store.DeleteRoutingRule(ctx, "rule-1"))
+	plugin.Enabled = false
+	plugin.Config = map[string]any{"mode": "b"}
+	require.NoError(t, store.UpdatePlugin(ctx, plugin))
+	second, created, err := store.CaptureConfigSnapshot(ctx)
+	require.NoError(t, err)
+	require.True(t, created)
+	assert.NotEqual(t, first.Hash, second.Hash)
+	assert.Equal(t, 2, second.Providers)
+	assert.Equal(t, 0, second.RoutingRules)
+
+	require.NoError(t, store.RestoreConfigSnapshot(ctx, first.Hash))
+
+	providers, err := store.GetProvidersConfig(ctx)
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	require.Len(t, providers[schemas.OpenAI].Keys, 1)
+	assert.Equal(t, "sk-a", providers[schemas.OpenAI].Keys[0].Value.GetValue())
+	rules, err := store.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Len(t, rules[0].Targets, 1)
+	restoredPlugin, err := store.GetPlugin(ctx, "test-plugin")
+	require.NoError(t, err)
+	assert.True(t, restoredPlugin.Enabled)
+	assert.Equal(t, map[string]any{"mode": "a"}, restoredPlugin.Config)
+
+	// The restored state is byte-for-byte the snapshotted one, and is recorded
+	// as the newest point in history.
+	third, created, err := store.CaptureConfigSnapshot(ctx)
+	require.NoError(t, err)
+	require.True(t, created)
+	assert.Equal(t, first.Hash, third.Hash)
+
+	snapshots, err := store.GetConfigSnapshots(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	assert.Equal(t, third.ID, snapshots[0].ID)
+
+	deleted, err := store.DeleteConfigSnapshotsBeyond(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	var contents int64
+	require.NoError(t, store.DB().Model(&tables.TableConfigSnapshotContent{}).Count(&contents).Error)
+	assert.Equal(t, int64(1), contents, "only the content of the kept snapshot should remain")
+
+	assert.ErrorIs(t, store.RestoreConfigSnapshot(ctx, second.Hash), ErrNotFound)
+}
@@ -454,7 +454,25 @@ var configstoreMigrationSteps = []migrationStep{
 	{IDs: []string{"add_oauth_config_resource_column"}, run: migrationAddOauthConfigResourceColumn},
 	{IDs: []string{"add_use_anthropic_endpoints_column"}, run: migrationAddUseAnthropicEndpointsColumn},
 	{IDs: []string{"add_bedrock_batch_role_arn_column"}, run: migrationAddBedrockBatchRoleARNColumn},
+	{IDs: []string{"add_azure_auth_type_column"}, run: migrationAddAzureAuthTypeColumn},
+	{IDs: []string{"add_key_beta_features_json_column"}, run: migrationAddKeyBetaFeaturesJSONColumn},
   {IDs: []string{"add_budget_override_columns"}, run: migrationAddBudgetOverrideColumns},
+	{IDs: []string{"add_virtual_key_defaults_json_column"}, run: migrationAddVirtualKeyDefaultsJSONColumn},
+	{IDs: []string{"add_provider_load_balancing_strategy_column"}, run: migrationAddProviderLoadBalancingStrategyColumn},
+	{IDs: []string{"add_session_affinities_table"}, run: migrationAddSessionAffinitiesTable},
+	{IDs: []string{"add_governance_overrides_table"}, run: migrationAddGovernanceOverridesTable},
+	{IDs: []string{"add_virtual_key_priority_column"}, run: migrationAddVirtualKeyPriorityColumn},
+	{IDs: []string{"add_budget_proration_columns"}, run: migrationAddBudgetProrationColumns},
+	{IDs: []string{"add_bulk_operations_table"}, run: migrationAddBulkOperationsTable},
+	{IDs: []string{"add_retention_config_client_column"}, run: migrationAddRetentionConfigClientColumn},
+	{IDs: []string{"add_compat_text_to_chat_client_columns"}, run: migrationAddCompatTextToChatClientColumns},
+	{IDs: []string{"add_virtual_key_max_concurrent_requests_column"}, run: migrationAddVirtualKeyMaxConcurrentRequestsColumn},
+	{IDs: []string{"add_log_access_events_table"}, run: migrationAddLogAccessEventsTable},
+	{IDs: []string{"add_routing_rule_type_columns"}, run: migrationAddRoutingRuleTypeColumns},
+	{IDs: []string{"add_mcp_client_output_schema_validation_column"}, run: migrationAddMCPClientOutputSchemaValidationColumn},
+	{IDs: []string{"add_key_byok_column"}, run: migrationAddKeyBYOKColumn},
+	{IDs: []string{"add_replica_config_versions_table"}, run: migrationAddReplicaConfigVersionsTable},
+	{IDs: []string{"add_config_snapshots_tables"}, run: migrationAddConfigSnapshotsTables},
 }
 
 // quoteSQLiteIdentifier quotes a SQLite identifier, escaping any double quotes.
@@ -10632,6 +10650,27 @@ func migrationAddMCPClientToolExecutionTimeoutColumn(ctx context.Context, db *go
 	return nil
 }
 
+func migrationAddMCPClientOutputSchemaValidationColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_mcp_client_output_schema_validation_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableMCPClient{}, "output_schema_validation")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableMCPClient{}, "output_schema_validation")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
 // migrationAddVirtualKeyExpiresAtColumn adds nullable expires_at to governance_virtual_keys.
 // No index: expiry is checked in-memory from the already-loaded VK, never queried by column.
 func migrationAddVirtualKeyExpiresAtColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
@@ -10943,3 +10982,549 @@ func migrationAddBedrockBatchRoleARNColumn(ctx context.Context, db *gorm.DB, log
 	}
 	return nil
 }
+
+// migrationAddAzureAuthTypeColumn adds the azure_auth_type column to the config_keys table.
+// Existing keys keep a NULL value, so their authentication mode is still inferred from the
+// configured credentials.
+func migrationAddAzureAuthTypeColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_azure_auth_type_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableKey{}, "azure_auth_type")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableKey{}, "azure_auth_type")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddKeyBetaFeaturesJSONColumn adds the beta_features_json column to the config_keys
+// table. Existing keys keep a NULL value, so they forward caller beta flags unrestricted.
+func migrationAddKeyBetaFeaturesJSONColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_key_beta_features_json_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableKey{}, "beta_features_json")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableKey{}, "beta_features_json")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddVirtualKeyDefaultsJSONColumn adds the defaults_json column to the
+// governance_virtual_keys table for per-key model and parameter presets.
+func migrationAddVirtualKeyDefaultsJSONColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_virtual_key_defaults_json_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableVirtualKey{}, "defaults_json")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableVirtualKey{}, "defaults_json")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddProviderLoadBalancingStrategyColumn adds the load_balancing_strategy column to the
+// config_providers table. Existing providers keep an empty value, which selects weighted keys.
+func migrationAddProviderLoadBalancingStrategyColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_provider_load_balancing_strategy_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableProvider{}, "load_balancing_strategy")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableProvider{}, "load_balancing_strategy")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddSessionAffinitiesTable creates the session_affinities table that
+// pins x-bf-session-id conversations to the deployment that served them.
+func migrationAddSessionAffinitiesTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_session_affinities_table"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableSessionAffinity{}) {
+				logger.Info("[configstore] %s: creating table TableSessionAffinity", migrationName)
+				if err := mig.CreateTable(&tables.TableSessionAffinity{}); err != nil {
+					return fmt.Errorf("failed to create session_affinities table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableSessionAffinity{}) {
+				logger.Info("[configstore] %s: dropping table TableSessionAffinity", migrationName)
+				if err := mig.DropTable(&tables.TableSessionAffinity{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddGovernanceOverridesTable creates the governance_overrides table
+// that holds admin-minted tokens for bypassing budgets and rate limits.
+func migrationAddGovernanceOverridesTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_governance_overrides_table"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableGovernanceOverride{}) {
+				logger.Info("[configstore] %s: creating table TableGovernanceOverride", migrationName)
+				if err := mig.CreateTable(&tables.TableGovernanceOverride{}); err != nil {
+					return fmt.Errorf("failed to create governance_overrides table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableGovernanceOverride{}) {
+				logger.Info("[configstore] %s: dropping table TableGovernanceOverride", migrationName)
+				if err := mig.DropTable(&tables.TableGovernanceOverride{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddVirtualKeyPriorityColumn adds the priority column to the
+// governance_virtual_keys table for per-key request queueing classes.
+func migrationAddVirtualKeyPriorityColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_virtual_key_priority_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableVirtualKey{}, "priority")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableVirtualKey{}, "priority")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddBudgetProrationColumns adds the prorated_limit and prorated_cycle_start
+// columns to the governance_budgets table for mid-cycle limit changes.
+func migrationAddBudgetProrationColumns(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_budget_proration_columns"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := addColumnIfNotExists(tx, logger, &tables.TableBudget{}, "prorated_limit"); err != nil {
+				return fmt.Errorf("failed to add prorated_limit column: %w", err)
+			}
+			if err := addColumnIfNotExists(tx, logger, &tables.TableBudget{}, "prorated_cycle_start"); err != nil {
+				return fmt.Errorf("failed to add prorated_cycle_start column: %w", err)
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := dropColumnIfExists(tx, logger, &tables.TableBudget{}, "prorated_cycle_start"); err != nil {
+				return err
+			}
+			return dropColumnIfExists(tx, logger, &tables.TableBudget{}, "prorated_limit")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddBulkOperationsTable creates the bulk_operations table that holds
+// the audit record of every bulk administrative change.
+func migrationAddBulkOperationsTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_bulk_operations_table"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableBulkOperation{}) {
+				logger.Info("[configstore] %s: creating table TableBulkOperation", migrationName)
+				if err := mig.CreateTable(&tables.TableBulkOperation{}); err != nil {
+					return fmt.Errorf("failed to create bulk_operations table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableBulkOperation{}) {
+				logger.Info("[configstore] %s: dropping table TableBulkOperation", migrationName)
+				if err := mig.DropTable(&tables.TableBulkOperation{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddRetentionConfigClientColumn adds the retention_config_json column
+// to config_client.
+func migrationAddRetentionConfigClientColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_retention_config_client_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mg := tx.Migrator()
+			if !mg.HasColumn(&tables.TableClientConfig{}, "retention_config_json") {
+				if err := mg.AddColumn(&tables.TableClientConfig{}, "RetentionConfigJSON"); err != nil {
+					return fmt.Errorf("add retention_config_json column: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mg := tx.Migrator()
+			if mg.HasColumn(&tables.TableClientConfig{}, "retention_config_json") {
+				if err := mg.DropColumn(&tables.TableClientConfig{}, "RetentionConfigJSON"); err != nil {
+					return fmt.Errorf("drop retention_config_json column: %w", err)
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddCompatTextToChatClientColumns adds the compat_text_to_chat_system_prompt
+// and compat_text_to_chat_models_json columns to config_client.
+func migrationAddCompatTextToChatClientColumns(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_compat_text_to_chat_client_columns"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	columns := []struct{ column, field string }{
+		{"compat_text_to_chat_system_prompt", "CompatTextToChatSystemPrompt"},
+		{"compat_text_to_chat_models_json", "CompatTextToChatModelsJSON"},
+	}
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mg := tx.Migrator()
+			for _, c := range columns {
+				if !mg.HasColumn(&tables.TableClientConfig{}, c.column) {
+					if err := mg.AddColumn(&tables.TableClientConfig{}, c.field); err != nil {
+						return fmt.Errorf("add %s column: %w", c.column, err)
+					}
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mg := tx.Migrator()
+			for _, c := range columns {
+				if mg.HasColumn(&tables.TableClientConfig{}, c.column) {
+					if err := mg.DropColumn(&tables.TableClientConfig{}, c.field); err != nil {
+						return fmt.Errorf("drop %s column: %w", c.column, err)
+					}
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddVirtualKeyMaxConcurrentRequestsColumn adds the max_concurrent_requests
+// column to the governance_virtual_keys table.
+func migrationAddVirtualKeyMaxConcurrentRequestsColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_virtual_key_max_concurrent_requests_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return addColumnIfNotExists(tx, logger, &tables.TableVirtualKey{}, "max_concurrent_requests")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			return dropColumnIfExists(tx, logger, &tables.TableVirtualKey{}, "max_concurrent_requests")
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddLogAccessEventsTable creates the log_access_events table that holds
+// the audit record of every log viewed through the API.
+func migrationAddLogAccessEventsTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_log_access_events_table"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableLogAccessEvent{}) {
+				logger.Info("[configstore] %s: creating table TableLogAccessEvent", migrationName)
+				if err := mig.CreateTable(&tables.TableLogAccessEvent{}); err != nil {
+					return fmt.Errorf("failed to create log_access_events table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableLogAccessEvent{}) {
+				logger.Info("[configstore] %s: dropping table TableLogAccessEvent", migrationName)
+				if err := mig.DropTable(&tables.TableLogAccessEvent{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddRoutingRuleTypeColumns adds the type and embedding_buckets columns
+// to routing_rules. Existing rules become CEL rules; GenerateRoutingRuleHash only
+// covers the new fields for embedding length rules, so their hashes stay valid.
+func migrationAddRoutingRuleTypeColumns(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_routing_rule_type_columns"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"type", "embedding_buckets"} {
+				if err := addColumnIfNotExists(tx, logger, &tables.TableRoutingRule{}, column); err != nil {
+					return fmt.Errorf("failed to add %s column: %w", column, err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"type", "embedding_buckets"} {
+				if err := dropColumnIfExists(tx, logger, &tables.TableRoutingRule{}, column); err != nil {
+					return fmt.Errorf("failed to drop %s column: %w", column, err)
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddKeyBYOKColumn adds the byok column to the config_keys table. Existing
+// keys default to false and stay in key rotation.
+func migrationAddKeyBYOKColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_key_byok_column"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := addColumnIfNotExists(tx, logger, &tables.TableKey{}, "byok"); err != nil {
+				return fmt.Errorf("failed to add byok column: %w", err)
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := dropColumnIfExists(tx, logger, &tables.TableKey{}, "byok"); err != nil {
+				return fmt.Errorf("failed to drop byok column: %w", err)
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddReplicaConfigVersionsTable creates the replica_config_versions
+// table replicas publish their config version to.
+func migrationAddReplicaConfigVersionsTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_replica_config_versions_table"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableReplicaConfigVersion{}) {
+				logger.Info("[configstore] %s: creating table TableReplicaConfigVersion", migrationName)
+				if err := mig.CreateTable(&tables.TableReplicaConfigVersion{}); err != nil {
+					return fmt.Errorf("failed to create replica_config_versions table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableReplicaConfigVersion{}) {
+				logger.Info("[configstore] %s: dropping table TableReplicaConfigVersion", migrationName)
+				if err := mig.DropTable(&tables.TableReplicaConfigVersion{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
+
+// migrationAddConfigSnapshotsTables creates the config_snapshots and
+// config_snapshot_contents tables config snapshots are recorded in.
+func migrationAddConfigSnapshotsTables(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "add_config_snapshots_tables"
+	logger.Info("[configstore] starting migration %s", migrationName)
+	defer logger.Info("[configstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if !mig.HasTable(&tables.TableConfigSnapshotContent{}) {
+				logger.Info("[configstore] %s: creating table TableConfigSnapshotContent", migrationName)
+				if err := mig.CreateTable(&tables.TableConfigSnapshotContent{}); err != nil {
+					return fmt.Errorf("failed to create config_snapshot_contents table: %w", err)
+				}
+			}
+			if !mig.HasTable(&tables.TableConfigSnapshot{}) {
+				logger.Info("[configstore] %s: creating table TableConfigSnapshot", migrationName)
+				if err := mig.CreateTable(&tables.TableConfigSnapshot{}); err != nil {
+					return fmt.Errorf("failed to create config_snapshots table: %w", err)
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			mig := tx.Migrator()
+			if mig.HasTable(&tables.TableConfigSnapshot{}) {
+				logger.Info("[configstore] %s: dropping table TableConfigSnapshot", migrationName)
+				if err := mig.DropTable(&tables.TableConfigSnapshot{}); err != nil {
+					return err
+				}
+			}
+			if mig.HasTable(&tables.TableConfigSnapshotContent{}) {
+				logger.Info("[configstore] %s: dropping table TableConfigSnapshotContent", migrationName)
+				if err := mig.DropTable(&tables.TableConfigSnapshotContent{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error running %s migration: %w", migrationName, err)
+	}
+	return nil
+}
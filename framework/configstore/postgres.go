@@ -68,7 +68,8 @@ func newPostgresConfigStore(ctx context.Context, config *PostgresConfig, logger
 	RegisterVaultCallbacks(db)
 	logger.Info("configstore: runtime connection pool ready")
 
-	d := &RDBConfigStore{logger: logger}
+	d := &RDBConfigStore{logger: logger, configMutations: make(chan struct{}, 1)}
+	d.registerConfigMutationCallbacks(db)
 	d.db.Store(db)
 
 	// migrateOnFreshFn: downstream consumers (e.g. bifrost-enterprise) run
@@ -96,6 +97,7 @@ func newPostgresConfigStore(ctx context.Context, config *PostgresConfig, logger
 			return fmt.Errorf("failed to tune fresh runtime pool: %w", err)
 		}
 		RegisterVaultCallbacks(newDB)
+		d.registerConfigMutationCallbacks(newDB)
 		oldDB := d.db.Swap(newDB)
 		if oldDB != nil {
 			postgresconn.Close(oldDB, logger)
@@ -40,6 +40,10 @@ type RDBConfigStore struct {
 	logger           schemas.Logger
 	migrateOnFreshFn func(ctx context.Context, fn func(context.Context, *gorm.DB) error) error
 	refreshPoolFn    func(ctx context.Context) error
+	// stopCheckpoints stops the periodic WAL checkpoints of a SQLite store; nil otherwise.
+	stopCheckpoints func()
+	// configMutations is signaled after writes to the tables config snapshots cover.
+	configMutations chan struct{}
 }
 
 // getWeight safely dereferences a *float64 weight pointer, returning 1.0 as default if nil.
@@ -153,6 +157,8 @@ func schemaKeyFromTableKey(dbKey tables.TableKey) schemas.Key {
 		Enabled:                dbKey.Enabled,
 		UseForBatchAPI:         dbKey.UseForBatchAPI,
 		UseAnthropicEndpoints:  dbKey.UseAnthropicEndpoints,
+		BYOK:                   dbKey.BYOK,
+		BetaFeatures:           dbKey.BetaFeatures,
 		AzureKeyConfig:         dbKey.AzureKeyConfig,
 		VertexKeyConfig:        dbKey.VertexKeyConfig,
 		BedrockKeyConfig:       dbKey.BedrockKeyConfig,
@@ -182,6 +188,8 @@ func tableKeyFromSchemaKey(provider tables.TableProvider, key schemas.Key) (tabl
 		Enabled:                key.Enabled,
 		UseForBatchAPI:         key.UseForBatchAPI,
 		UseAnthropicEndpoints:  key.UseAnthropicEndpoints,
+		BYOK:                   key.BYOK,
+		BetaFeatures:           key.BetaFeatures,
 		AzureKeyConfig:         key.AzureKeyConfig,
 		VertexKeyConfig:        key.VertexKeyConfig,
 		BedrockKeyConfig:       key.BedrockKeyConfig,
@@ -268,6 +276,8 @@ func (s *RDBConfigStore) UpdateClientConfig(ctx context.Context, config *ClientC
 		CompatConvertChatToResponses:          config.Compat.ConvertChatToResponses,
 		CompatShouldDropParams:                config.Compat.ShouldDropParams,
 		CompatShouldConvertParams:             config.Compat.ShouldConvertParams,
+		CompatTextToChatSystemPrompt:          config.Compat.TextToChatSystemPrompt,
+		CompatTextToChatModels:                config.Compat.TextToChatModels,
 		MCPAgentDepth:                         config.MCPAgentDepth,
 		MCPToolExecutionTimeout:               config.MCPToolExecutionTimeout,
 		MCPCodeModeBindingLevel:               config.MCPCodeModeBindingLevel,
@@ -288,6 +298,7 @@ func (s *RDBConfigStore) UpdateClientConfig(ctx context.Context, config *ClientC
 		MCPServerAuthMode:                     config.MCPServerAuthMode,
 		OAuth2ServerConfig:                    config.OAuth2ServerConfig,
 		WebhookConfig:                         config.WebhookConfig,
+		RetentionConfig:                       config.RetentionConfig,
 		ConfigHash:                            config.ConfigHash,
 	}
 	// Delete existing client config and create new one in a transaction.
@@ -537,6 +548,8 @@ func (s *RDBConfigStore) GetClientConfig(ctx context.Context) (*ClientConfig, er
 			ConvertChatToResponses: dbConfig.CompatConvertChatToResponses,
 			ShouldDropParams:       dbConfig.CompatShouldDropParams,
 			ShouldConvertParams:    dbConfig.CompatShouldConvertParams,
+			TextToChatSystemPrompt: dbConfig.CompatTextToChatSystemPrompt,
+			TextToChatModels:       dbConfig.CompatTextToChatModels,
 		},
 		MCPAgentDepth:                         dbConfig.MCPAgentDepth,
 		MCPToolExecutionTimeout:               dbConfig.MCPToolExecutionTimeout,
@@ -558,6 +571,7 @@ func (s *RDBConfigStore) GetClientConfig(ctx context.Context) (*ClientConfig, er
 		MCPServerAuthMode:                     dbConfig.MCPServerAuthMode,
 		OAuth2ServerConfig:                    dbConfig.OAuth2ServerConfig,
 		WebhookConfig:                         dbConfig.WebhookConfig,
+		RetentionConfig:                       dbConfig.RetentionConfig,
 		ConfigHash:                            dbConfig.ConfigHash,
 	}, nil
 }
@@ -674,6 +688,7 @@ func (s *RDBConfigStore) UpdateProvidersConfig(ctx context.Context, providers ma
 			StoreRawRequestResponse:  providerConfig.StoreRawRequestResponse,
 			CustomProviderConfig:     providerConfig.CustomProviderConfig,
 			OpenAIConfig:             providerConfig.OpenAIConfig,
+			LoadBalancingStrategy:    providerConfig.LoadBalancingStrategy,
 			ConfigHash:               providerConfig.ConfigHash,
 			Status:                   providerConfig.Status,
 			Description:              providerConfig.Description,
@@ -723,6 +738,8 @@ func (s *RDBConfigStore) UpdateProvidersConfig(ctx context.Context, providers ma
 				Enabled:                key.Enabled,
 				UseForBatchAPI:         key.UseForBatchAPI,
 				UseAnthropicEndpoints:  key.UseAnthropicEndpoints,
+				BYOK:                   key.BYOK,
+				BetaFeatures:           key.BetaFeatures,
 				AzureKeyConfig:         key.AzureKeyConfig,
 				VertexKeyConfig:        key.VertexKeyConfig,
 				BedrockKeyConfig:       key.BedrockKeyConfig,
@@ -905,6 +922,7 @@ func (s *RDBConfigStore) UpdateProvider(ctx context.Context, provider schemas.Mo
 	dbProvider.StoreRawRequestResponse = configCopy.StoreRawRequestResponse
 	dbProvider.CustomProviderConfig = configCopy.CustomProviderConfig
 	dbProvider.OpenAIConfig = configCopy.OpenAIConfig
+	dbProvider.LoadBalancingStrategy = configCopy.LoadBalancingStrategy
 	dbProvider.ConfigHash = configCopy.ConfigHash
 
 	// Save the updated provider
@@ -955,6 +973,8 @@ func (s *RDBConfigStore) UpdateProvider(ctx context.Context, provider schemas.Mo
 			Enabled:                key.Enabled,
 			UseForBatchAPI:         key.UseForBatchAPI,
 			UseAnthropicEndpoints:  key.UseAnthropicEndpoints,
+			BYOK:                   key.BYOK,
+			BetaFeatures:           key.BetaFeatures,
 			AzureKeyConfig:         key.AzureKeyConfig,
 			VertexKeyConfig:        key.VertexKeyConfig,
 			BedrockKeyConfig:       key.BedrockKeyConfig,
@@ -1078,6 +1098,7 @@ func (s *RDBConfigStore) AddProvider(ctx context.Context, provider schemas.Model
 		StoreRawRequestResponse:  configCopy.StoreRawRequestResponse,
 		CustomProviderConfig:     configCopy.CustomProviderConfig,
 		OpenAIConfig:             configCopy.OpenAIConfig,
+		LoadBalancingStrategy:    configCopy.LoadBalancingStrategy,
 		ConfigHash:               configCopy.ConfigHash,
 	}
 	// Create the provider
@@ -1098,6 +1119,8 @@ func (s *RDBConfigStore) AddProvider(ctx context.Context, provider schemas.Model
 			Enabled:                key.Enabled,
 			UseForBatchAPI:         key.UseForBatchAPI,
 			UseAnthropicEndpoints:  key.UseAnthropicEndpoints,
+			BYOK:                   key.BYOK,
+			BetaFeatures:           key.BetaFeatures,
 			AzureKeyConfig:         key.AzureKeyConfig,
 			VertexKeyConfig:        key.VertexKeyConfig,
 			BedrockKeyConfig:       key.BedrockKeyConfig,
@@ -1239,6 +1262,7 @@ func (s *RDBConfigStore) GetProvidersConfig(ctx context.Context) (map[schemas.Mo
 			StoreRawRequestResponse:  dbProvider.StoreRawRequestResponse,
 			CustomProviderConfig:     dbProvider.CustomProviderConfig,
 			OpenAIConfig:             dbProvider.OpenAIConfig,
+			LoadBalancingStrategy:    dbProvider.LoadBalancingStrategy,
 			ConfigHash:               dbProvider.ConfigHash,
 			Status:                   dbProvider.Status,
 			Description:              dbProvider.Description,
@@ -1272,6 +1296,7 @@ func (s *RDBConfigStore) GetProviderConfig(ctx context.Context, provider schemas
 		StoreRawRequestResponse:  dbProvider.StoreRawRequestResponse,
 		CustomProviderConfig:     dbProvider.CustomProviderConfig,
 		OpenAIConfig:             dbProvider.OpenAIConfig,
+		LoadBalancingStrategy:    dbProvider.LoadBalancingStrategy,
 		ConfigHash:               dbProvider.ConfigHash,
 		Status:                   dbProvider.Status,
 		Description:              dbProvider.Description,
@@ -1556,6 +1581,7 @@ func (s *RDBConfigStore) GetMCPConfig(ctx context.Context) (*schemas.MCPConfig,
 					IsPingAvailable:           dbClient.IsPingAvailable,
 					ToolSyncInterval:          time.Duration(dbClient.ToolSyncInterval) * time.Second,
 					ToolExecutionTimeout:      time.Duration(dbClient.ToolExecutionTimeout) * time.Second,
+					OutputValidation:          schemas.MCPOutputSchemaValidation(dbClient.OutputValidation),
 					ToolPricing:               dbClient.ToolPricing,
 					AllowOnAllVirtualKeys:     dbClient.AllowOnAllVirtualKeys,
 					Disabled:                  dbClient.Disabled,
@@ -1599,6 +1625,7 @@ func (s *RDBConfigStore) GetMCPConfig(ctx context.Context) (*schemas.MCPConfig,
 			IsPingAvailable:           dbClient.IsPingAvailable,
 			ToolSyncInterval:          time.Duration(dbClient.ToolSyncInterval) * time.Second,
 			ToolExecutionTimeout:      time.Duration(dbClient.ToolExecutionTimeout) * time.Second,
+			OutputValidation:          schemas.MCPOutputSchemaValidation(dbClient.OutputValidation),
 			AllowOnAllVirtualKeys:     dbClient.AllowOnAllVirtualKeys,
 			Disabled:                  dbClient.Disabled,
 			ToolPricing:               dbClient.ToolPricing,
@@ -2023,6 +2050,7 @@ func (s *RDBConfigStore) GetMCPClientConfigByID(ctx context.Context, id string)
 		IsPingAvailable:           dbClient.IsPingAvailable,
 		ToolSyncInterval:          time.Duration(dbClient.ToolSyncInterval) * time.Second,
 		ToolExecutionTimeout:      time.Duration(dbClient.ToolExecutionTimeout) * time.Second,
+		OutputValidation:          schemas.MCPOutputSchemaValidation(dbClient.OutputValidation),
 		AllowOnAllVirtualKeys:     dbClient.AllowOnAllVirtualKeys,
 		Disabled:                  dbClient.Disabled,
 		ToolPricing:               dbClient.ToolPricing,
@@ -2079,6 +2107,7 @@ func (s *RDBConfigStore) CreateMCPClientConfig(ctx context.Context, clientConfig
 			IsPingAvailable:       clientConfigCopy.IsPingAvailable,
 			ToolSyncInterval:      toolSyncIntervalSec,
 			ToolExecutionTimeout:  toolExecutionTimeoutSec,
+			OutputValidation:      string(clientConfigCopy.OutputValidation),
 			AllowOnAllVirtualKeys: clientConfigCopy.AllowOnAllVirtualKeys,
 			// DiscoveredTools has json:"-" so deepCopy loses it; use original clientConfig
 			DiscoveredTools:           clientConfig.DiscoveredTools,
@@ -2233,6 +2262,7 @@ func (s *RDBConfigStore) UpdateMCPClientConfig(ctx context.Context, id string, c
 			"tool_pricing_json":          string(toolPricingJSON),
 			"tool_sync_interval":         clientConfigCopy.ToolSyncInterval,
 			"tool_execution_timeout":     clientConfigCopy.ToolExecutionTimeout,
+			"output_schema_validation":   clientConfigCopy.OutputValidation,
 			"allow_on_all_virtual_keys":  clientConfigCopy.AllowOnAllVirtualKeys,
 			"disabled":                   clientConfigCopy.Disabled,
 			"updated_at":                 time.Now(),
@@ -3419,7 +3449,7 @@ func (s *RDBConfigStore) UpdateVirtualKey(ctx context.Context, virtualKey *table
 	} else {
 		virtualKey.ID = existing.ID
 		if err := txDB.WithContext(ctx).
-			Select("name", "description", "value", "is_active", "expires_at", "team_id", "customer_id", "rate_limit_id", "calendar_aligned", "config_hash", "updated_at", "encryption_status", "value_hash").
+			Select("name", "description", "value", "is_active", "expires_at", "team_id", "customer_id", "rate_limit_id", "calendar_aligned", "defaults_json", "config_hash", "updated_at", "encryption_status", "value_hash").
 			Updates(virtualKey).Error; err != nil {
 			return s.parseGormError(err)
 		}
@@ -5649,6 +5679,191 @@ func (s *RDBConfigStore) DeleteExpiredTempTokens(ctx context.Context, before tim
 	return res.RowsAffected, nil
 }
 
+// GetSessionAffinity retrieves the affinity for the SHA-256 hash of a session
+// ID. Returns (nil, nil) when no row matches or the row has expired but not
+// been swept yet.
+func (s *RDBConfigStore) GetSessionAffinity(ctx context.Context, sessionHash string) (*tables.TableSessionAffinity, error) {
+	var affinity tables.TableSessionAffinity
+	err := s.DB().WithContext(ctx).First(&affinity, "session_hash = ? AND expires_at > ?", sessionHash, time.Now()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &affinity, nil
+}
+
+// UpsertSessionAffinity writes or replaces the affinity row for a session hash.
+func (s *RDBConfigStore) UpsertSessionAffinity(ctx context.Context, affinity *tables.TableSessionAffinity) error {
+	return s.DB().WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"provider", "model", "key_id", "expires_at", "updated_at"}),
+	}).Create(affinity).Error
+}
+
+// DeleteExpiredSessionAffinities hard-deletes rows whose expires_at is at or
+// before the given cutoff. Returns the number of rows deleted.
+func (s *RDBConfigStore) DeleteExpiredSessionAffinities(ctx context.Context, before time.Time) (int64, error) {
+	res := s.DB().WithContext(ctx).Where("expires_at <= ?", before).Delete(&tables.TableSessionAffinity{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// UpsertReplicaConfigVersion writes or replaces the row of a replica.
+func (s *RDBConfigStore) UpsertReplicaConfigVersion(ctx context.Context, version *tables.TableReplicaConfigVersion) error {
+	return s.DB().WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "instance_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"config_hash", "bifrost_version", "started_at", "updated_at"}),
+	}).Create(version).Error
+}
+
+// GetReplicaConfigVersions lists the replicas that published a config version
+// at or after seenSince, ordered by instance ID.
+func (s *RDBConfigStore) GetReplicaConfigVersions(ctx context.Context, seenSince time.Time) ([]tables.TableReplicaConfigVersion, error) {
+	var versions []tables.TableReplicaConfigVersion
+	if err := s.DB().WithContext(ctx).Where("updated_at >= ?", seenSince).Order("instance_id ASC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// DeleteStaleReplicaConfigVersions hard-deletes the rows of replicas that have
+// not published since before. Returns the number of rows deleted.
+func (s *RDBConfigStore) DeleteStaleReplicaConfigVersions(ctx context.Context, before time.Time) (int64, error) {
+	res := s.DB().WithContext(ctx).Where("updated_at < ?", before).Delete(&tables.TableReplicaConfigVersion{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// CreateGovernanceOverride persists a new governance override token.
+func (s *RDBConfigStore) CreateGovernanceOverride(ctx context.Context, override *tables.TableGovernanceOverride) error {
+	return s.DB().WithContext(ctx).Create(override).Error
+}
+
+// GetGovernanceOverrides lists every governance override, newest first,
+// including expired and revoked ones.
+func (s *RDBConfigStore) GetGovernanceOverrides(ctx context.Context) ([]tables.TableGovernanceOverride, error) {
+	var overrides []tables.TableGovernanceOverride
+	if err := s.DB().WithContext(ctx).Order("created_at DESC").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// GetGovernanceOverrideByHash retrieves the override whose token hashes to
+// tokenHash. Returns (nil, nil) when no row matches; expiry and revocation are
+// left to the caller so they can be reported distinctly.
+func (s *RDBConfigStore) GetGovernanceOverrideByHash(ctx context.Context, tokenHash string) (*tables.TableGovernanceOverride, error) {
+	var override tables.TableGovernanceOverride
+	err := s.DB().WithContext(ctx).First(&override, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &override, nil
+}
+
+// RevokeGovernanceOverride marks an override as revoked so it stops working
+// before it expires. The row is kept for the audit trail.
+func (s *RDBConfigStore) RevokeGovernanceOverride(ctx context.Context, id string, revokedAt time.Time) error {
+	res := s.DB().WithContext(ctx).Model(&tables.TableGovernanceOverride{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RecordGovernanceOverrideUsage increments an override's usage count and
+// stamps the time it was last used.
+func (s *RDBConfigStore) RecordGovernanceOverrideUsage(ctx context.Context, id string, usedAt time.Time) error {
+	return s.DB().WithContext(ctx).Model(&tables.TableGovernanceOverride{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"usage_count":  gorm.Expr("usage_count + 1"),
+			"last_used_at": usedAt,
+		}).Error
+}
+
+// CreateBulkOperation stores the audit record of a bulk operation.
+func (s *RDBConfigStore) CreateBulkOperation(ctx context.Context, operation *tables.TableBulkOperation) error {
+	return s.DB().WithContext(ctx).Create(operation).Error
+}
+
+// GetBulkOperations lists the most recent bulk operations, newest first. A
+// non-positive limit returns every record.
+func (s *RDBConfigStore) GetBulkOperations(ctx context.Context, limit int) ([]tables.TableBulkOperation, error) {
+	var operations []tables.TableBulkOperation
+	query := s.DB().WithContext(ctx).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&operations).Error; err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+// CreateLogAccessEvents stores the audit records of logs viewed in one request.
+func (s *RDBConfigStore) CreateLogAccessEvents(ctx context.Context, events []tables.TableLogAccessEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.DB().WithContext(ctx).CreateInBatches(events, 100).Error
+}
+
+// GetLogAccessEvents lists who viewed a log, newest first. A non-positive
+// limit returns every record.
+func (s *RDBConfigStore) GetLogAccessEvents(ctx context.Context, logID string, limit int) ([]tables.TableLogAccessEvent, error) {
+	var events []tables.TableLogAccessEvent
+	query := s.DB().WithContext(ctx).Where("log_id = ?", logID).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// DeleteAuditRecordsBatch deletes up to batchSize audit records created before
+// cutoff, oldest first, from each audit table: bulk operations and log access
+// events.
+func (s *RDBConfigStore) DeleteAuditRecordsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	var deleted int64
+	for _, model := range []any{&tables.TableBulkOperation{}, &tables.TableLogAccessEvent{}} {
+		var ids []string
+		if err := s.DB().WithContext(ctx).
+			Model(model).
+			Where("created_at < ?", cutoff).
+			Order("created_at ASC").
+			Limit(batchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return deleted, err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		result := s.DB().WithContext(ctx).Where("id IN ?", ids).Delete(model)
+		deleted += result.RowsAffected
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+	}
+	return deleted, nil
+}
+
 // ExecuteTransaction executes a transaction.
 func (s *RDBConfigStore) ExecuteTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return s.DB().WithContext(ctx).Transaction(fn)
@@ -5729,6 +5944,9 @@ func (s *RDBConfigStore) removeDuplicateKeysAndNullKeys(ctx context.Context) err
 
 // Close closes the SQLite config store.
 func (s *RDBConfigStore) Close(ctx context.Context) error {
+	if s.stopCheckpoints != nil {
+		s.stopCheckpoints()
+	}
 	sqlDB, err := s.DB().DB()
 	if err != nil {
 		return err
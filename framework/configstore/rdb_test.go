@@ -3113,6 +3113,25 @@ func TestClientConfigWebhookConfigRoundTrip(t *testing.T) {
 	assert.Nil(t, loaded.WebhookConfig)
 }
 
+func TestClientConfigCompatTextToChatRoundTrip(t *testing.T) {
+	store := setupRDBTestStore(t)
+	ctx := context.Background()
+
+	compat := CompatConfig{
+		ConvertTextToChat:      true,
+		TextToChatSystemPrompt: "Continue the user's text.",
+		TextToChatModels:       []string{"gpt-4o*", "anthropic/claude-sonnet-4"},
+	}
+	require.NoError(t, store.UpdateClientConfig(ctx, &ClientConfig{Compat: compat}))
+	loaded, err := store.GetClientConfig(ctx)
+	require.NoError(t, err)
+	assert.True(t, loaded.Compat.Equal(compat), "text to chat settings must survive the database round-trip, got %+v", loaded.Compat)
+
+	changed := compat
+	changed.TextToChatModels = []string{"gpt-4o*"}
+	assert.False(t, changed.Equal(compat))
+}
+
 func TestWebhookEndpointTuningRoundTripAndHash(t *testing.T) {
 	store := setupRDBTestStore(t)
 	ctx := context.Background()
@@ -3,34 +3,19 @@ package configstore
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/maximhq/bifrost/core/schemas"
-	"gorm.io/driver/sqlite"
+	"github.com/maximhq/bifrost/framework/sqliteconn"
 	"gorm.io/gorm"
 )
 
 // SQLiteConfig represents the configuration for a SQLite database.
-type SQLiteConfig struct {
-	Path string `json:"path"`
-}
+type SQLiteConfig = sqliteconn.Config
 
 // newSqliteConfigStore creates a new SQLite config store.
 func newSqliteConfigStore(ctx context.Context, config *SQLiteConfig, logger schemas.Logger) (ConfigStore, error) {
-	if _, err := os.Stat(config.Path); os.IsNotExist(err) {
-		// Create DB file
-		f, err := os.Create(config.Path)
-		if err != nil {
-			return nil, err
-		}
-		_ = f.Close()
-	}
-	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=NORMAL&_cache_size=10000&_busy_timeout=60000&_wal_autocheckpoint=1000&_foreign_keys=1", config.Path)
-	logger.Debug("opening DB with dsn: %s", dsn)
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
-		Logger: newGormLogger(logger),
-	})
-
+	logger.Debug("opening DB with dsn: %s", sqliteconn.BuildDSN(config))
+	db, err := sqliteconn.Open(config, newGormLogger(logger))
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +24,8 @@ func newSqliteConfigStore(ctx context.Context, config *SQLiteConfig, logger sche
 	// fields are rewritten to vault refs before persistence and owned vault
 	// secrets are cleaned up on delete.
 	RegisterVaultCallbacks(db)
-	s := &RDBConfigStore{logger: logger}
+	s := &RDBConfigStore{logger: logger, configMutations: make(chan struct{}, 1)}
+	s.registerConfigMutationCallbacks(db)
 	s.db.Store(db)
 	// SQLite has no server-side prepared-plan cache, and opening a second
 	// handle on the same file would contend for the single-writer lock —
@@ -62,5 +48,12 @@ func newSqliteConfigStore(ctx context.Context, config *SQLiteConfig, logger sche
 	if err := s.EncryptPlaintextRows(ctx); err != nil {
 		return nil, fmt.Errorf("failed to encrypt plaintext rows: %w", err)
 	}
+	s.stopCheckpoints = sqliteconn.StartCheckpointer(db, config, logger)
 	return s, nil
 }
+
+// BackupSQLite writes a consistent snapshot of a SQLite config store to destPath while it
+// stays online. Other databases return sqliteconn.ErrNotSQLite.
+func (s *RDBConfigStore) BackupSQLite(ctx context.Context, destPath string) error {
+	return sqliteconn.Backup(ctx, s.DB(), destPath)
+}
@@ -415,6 +415,38 @@ type ConfigStore interface {
 	DeleteTempTokensByResourceID(ctx context.Context, scope, resourceID string, tx ...*gorm.DB) (int64, error)
 	DeleteExpiredTempTokens(ctx context.Context, before time.Time) (int64, error)
 
+	// Session affinity CRUD
+	GetSessionAffinity(ctx context.Context, sessionHash string) (*tables.TableSessionAffinity, error)
+	UpsertSessionAffinity(ctx context.Context, affinity *tables.TableSessionAffinity) error
+	DeleteExpiredSessionAffinities(ctx context.Context, before time.Time) (int64, error)
+
+	// Replica config versions
+	UpsertReplicaConfigVersion(ctx context.Context, version *tables.TableReplicaConfigVersion) error
+	GetReplicaConfigVersions(ctx context.Context, seenSince time.Time) ([]tables.TableReplicaConfigVersion, error)
+	DeleteStaleReplicaConfigVersions(ctx context.Context, before time.Time) (int64, error)
+
+	// Config snapshots
+	CaptureConfigSnapshot(ctx context.Context) (*tables.TableConfigSnapshot, bool, error)
+	GetConfigSnapshots(ctx context.Context, limit int) ([]tables.TableConfigSnapshot, error)
+	RestoreConfigSnapshot(ctx context.Context, hash string) error
+	DeleteConfigSnapshotsBeyond(ctx context.Context, keep int) (int64, error)
+	ConfigMutations() <-chan struct{}
+
+	// Governance override token CRUD
+	CreateGovernanceOverride(ctx context.Context, override *tables.TableGovernanceOverride) error
+	GetGovernanceOverrides(ctx context.Context) ([]tables.TableGovernanceOverride, error)
+	GetGovernanceOverrideByHash(ctx context.Context, tokenHash string) (*tables.TableGovernanceOverride, error)
+	RevokeGovernanceOverride(ctx context.Context, id string, revokedAt time.Time) error
+	RecordGovernanceOverrideUsage(ctx context.Context, id string, usedAt time.Time) error
+
+	// Bulk operation audit records
+	CreateBulkOperation(ctx context.Context, operation *tables.TableBulkOperation) error
+	GetBulkOperations(ctx context.Context, limit int) ([]tables.TableBulkOperation, error)
+
+	// Log access audit records
+	CreateLogAccessEvents(ctx context.Context, events []tables.TableLogAccessEvent) error
+	GetLogAccessEvents(ctx context.Context, logID string, limit int) ([]tables.TableLogAccessEvent, error)
+
 	// Model pricing CRUD
 	GetModelPrices(ctx context.Context) ([]tables.TableModelPricing, error)
 	UpsertModelPrices(ctx context.Context, pricing *tables.TableModelPricing, tx ...*gorm.DB) error
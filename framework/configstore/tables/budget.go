@@ -33,6 +33,12 @@ type TableBudget struct {
 	// OverrideCyclesRemaining includes the current cycle and is positive only for cycle-based overrides.
 	OverrideCyclesRemaining int `gorm:"not null;default:0" json:"override_cycles_remaining,omitempty"`
 
+	// ProratedLimit replaces MaxLimit for the cycle that started at ProratedCycleStart,
+	// after MaxLimit was changed mid-cycle with proration (see ProrateLimitChange). It
+	// lapses on its own once the budget resets and LastReset moves past that cycle.
+	ProratedLimit      *float64   `json:"prorated_limit,omitempty"`
+	ProratedCycleStart *time.Time `json:"prorated_cycle_start,omitempty"`
+
 	// Owner FKs: a budget belongs to at most one Team, VK, ProviderConfig, ModelConfig, or Customer
 	TeamID           *string `gorm:"type:varchar(255);index" json:"team_id,omitempty"`
 	VirtualKeyID     *string `gorm:"type:varchar(255);index" json:"virtual_key_id,omitempty"`
@@ -73,15 +79,78 @@ func (b *TableBudget) HasActiveOverride() bool {
 		(b.OverrideMode == BudgetOverrideModeCycles && b.OverrideCyclesRemaining > 0)
 }
 
-// EffectiveMaxLimit returns the base limit plus any active override amount.
+// CycleMaxLimit returns the limit of the current cycle: the prorated limit if
+// MaxLimit was changed with proration during this cycle, otherwise MaxLimit.
+func (b *TableBudget) CycleMaxLimit() float64 {
+	if b == nil {
+		return 0
+	}
+	if b.ProratedLimit != nil && b.ProratedCycleStart != nil && b.ProratedCycleStart.Equal(b.LastReset) {
+		return *b.ProratedLimit
+	}
+	return b.MaxLimit
+}
+
+// EffectiveMaxLimit returns the cycle limit plus any active override amount.
 func (b *TableBudget) EffectiveMaxLimit() float64 {
 	if b == nil {
 		return 0
 	}
 	if !b.HasActiveOverride() {
-		return b.MaxLimit
+		return b.CycleMaxLimit()
 	}
-	return b.MaxLimit + b.OverrideAmount
+	return b.CycleMaxLimit() + b.OverrideAmount
+}
+
+// CurrentWindow returns the accounting window the budget's usage is counted
+// over: from LastReset until the next reset, which is the next calendar
+// boundary for calendar-aligned budgets and one reset duration later otherwise.
+func (b *TableBudget) CurrentWindow() (start, end time.Time, err error) {
+	start = b.LastReset
+	if b.IsCalendarAligned && IsCalendarAlignableDuration(b.ResetDuration) {
+		return start, GetNextCalendarPeriodStart(b.ResetDuration, start), nil
+	}
+	d, err := ParseDuration(b.ResetDuration)
+	if err != nil {
+		return start, start, err
+	}
+	if d <= 0 {
+		return start, start, fmt.Errorf("reset duration must be > 0: %s", b.ResetDuration)
+	}
+	return start, start.Add(d), nil
+}
+
+// ProrateLimitChange changes MaxLimit to newLimit mid-cycle without resetting
+// usage. The current cycle is held to the old limit for the elapsed share of
+// its window and to newLimit for the remaining share; later cycles get
+// newLimit in full. Repeated changes within a cycle compose.
+func (b *TableBudget) ProrateLimitChange(newLimit float64, now time.Time) error {
+	if b == nil {
+		return fmt.Errorf("budget is required")
+	}
+	start, end, err := b.CurrentWindow()
+	if err != nil {
+		return err
+	}
+	remaining := 0.0
+	if end.After(start) {
+		remaining = min(max(float64(end.Sub(now))/float64(end.Sub(start)), 0), 1)
+	}
+	cycleLimit := b.CycleMaxLimit() + (newLimit-b.MaxLimit)*remaining
+	cycleStart := b.LastReset
+	b.MaxLimit = newLimit
+	b.ProratedLimit = &cycleLimit
+	b.ProratedCycleStart = &cycleStart
+	return nil
+}
+
+// ClearProration drops any prorated limit so MaxLimit applies to the whole cycle.
+func (b *TableBudget) ClearProration() {
+	if b == nil {
+		return
+	}
+	b.ProratedLimit = nil
+	b.ProratedCycleStart = nil
 }
 
 // SetOverride replaces the budget's current override after validating the complete state.
@@ -191,6 +260,9 @@ func (b *TableBudget) BeforeSave(tx *gorm.DB) error {
 	if err := b.validateOverride(); err != nil {
 		return err
 	}
+	if b.ProratedLimit != nil && (*b.ProratedLimit < 0 || math.IsNaN(*b.ProratedLimit) || math.IsInf(*b.ProratedLimit, 0)) {
+		return fmt.Errorf("budget prorated_limit must be a finite non-negative amount")
+	}
 
 	return nil
 }
@@ -3,6 +3,7 @@ package tables
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -137,3 +138,45 @@ func TestTableBudgetValidateOverride(t *testing.T) {
 		})
 	}
 }
+
+// TestTableBudgetProrateLimitChange verifies a mid-cycle change blends the old and new
+// limits by the share of the window left, composes, and lapses at the next reset.
+func TestTableBudgetProrateLimitChange(t *testing.T) {
+	start := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	budget := &TableBudget{MaxLimit: 100, ResetDuration: "30d", LastReset: start, CurrentUsage: 40}
+
+	// A quarter of the way in: 100 for the first quarter, 200 for the rest.
+	require.NoError(t, budget.ProrateLimitChange(200, start.Add(180*time.Hour)))
+	assert.Equal(t, 200.0, budget.MaxLimit)
+	assert.InDelta(t, 175.0, budget.CycleMaxLimit(), 1e-9)
+	assert.Equal(t, 40.0, budget.CurrentUsage, "proration never touches usage")
+
+	// Halfway in: the second change only affects the remaining half.
+	require.NoError(t, budget.ProrateLimitChange(0, start.Add(360*time.Hour)))
+	assert.InDelta(t, 75.0, budget.CycleMaxLimit(), 1e-9)
+
+	require.NoError(t, budget.SetOverride(10, BudgetOverrideModeForever, 0))
+	assert.InDelta(t, 85.0, budget.EffectiveMaxLimit(), 1e-9)
+
+	budget.LastReset = start.AddDate(0, 0, 30)
+	assert.Equal(t, 0.0, budget.CycleMaxLimit(), "the next cycle gets the new limit in full")
+}
+
+// TestTableBudgetCurrentWindow verifies rolling and calendar-aligned windows.
+func TestTableBudgetCurrentWindow(t *testing.T) {
+	lastReset := time.Date(2026, time.January, 31, 15, 0, 0, 0, time.UTC)
+	rolling := &TableBudget{ResetDuration: "1w", LastReset: lastReset}
+	start, end, err := rolling.CurrentWindow()
+	require.NoError(t, err)
+	assert.Equal(t, lastReset, start)
+	assert.Equal(t, lastReset.Add(7*24*time.Hour), end)
+
+	monthStart := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	calendar := &TableBudget{ResetDuration: "1M", LastReset: monthStart, IsCalendarAligned: true}
+	_, end, err = calendar.CurrentWindow()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), end)
+
+	_, _, err = (&TableBudget{ResetDuration: "soon"}).CurrentWindow()
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package tables
+
+import "time"
+
+// Bulk operation outcomes.
+const (
+	BulkOperationStatusApplied  = "applied"  // every item was committed in one transaction
+	BulkOperationStatusRejected = "rejected" // an item failed validation; nothing was written
+	BulkOperationStatusFailed   = "failed"   // the transaction failed and was rolled back
+)
+
+// Bulk operation item outcomes.
+const (
+	BulkItemStatusCreated   = "created"
+	BulkItemStatusUpdated   = "updated"
+	BulkItemStatusUnchanged = "unchanged"
+	BulkItemStatusFailed    = "failed"
+	BulkItemStatusSkipped   = "skipped" // valid, but not applied because another item failed
+)
+
+// BulkOperationItem is the result of a bulk operation for one target.
+type BulkOperationItem struct {
+	ID     string `json:"id"`
+	Status string `json:"status"` // BulkItemStatus* values
+	Error  string `json:"error,omitempty"`
+}
+
+// TableBulkOperation is the audit record of one bulk administrative change,
+// such as disabling every key of a provider. Each bulk call writes exactly one
+// row, whether it was applied or not, holding the per-item results.
+type TableBulkOperation struct {
+	ID        string              `gorm:"type:varchar(255);primaryKey" json:"id"`
+	Operation string              `gorm:"type:varchar(64);index;not null" json:"operation"`
+	Target    string              `gorm:"type:varchar(255)" json:"target,omitempty"` // provider, routing rule ID, etc.
+	Status    string              `gorm:"type:varchar(20);not null" json:"status"`   // BulkOperationStatus* values
+	Items     []BulkOperationItem `gorm:"type:text;serializer:json" json:"items"`
+	CreatedBy string              `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	CreatedAt time.Time           `gorm:"index;not null" json:"created_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableBulkOperation) TableName() string { return "bulk_operations" }
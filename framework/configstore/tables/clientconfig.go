@@ -2,6 +2,8 @@ package tables
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -59,10 +61,12 @@ type TableClientConfig struct {
 	AllowDirectKeys                       bool                           `gorm:"default:false" json:"allow_direct_keys"`                          // Allow callers to bypass the registered key pool via x-bf-direct-key header
 
 	// Compat plugin feature flags
-	CompatConvertTextToChat      bool `gorm:"column:compat_convert_text_to_chat;default:false" json:"-"`
-	CompatConvertChatToResponses bool `gorm:"column:compat_convert_chat_to_responses;default:false" json:"-"`
-	CompatShouldDropParams       bool `gorm:"column:compat_should_drop_params;default:false" json:"-"`
-	CompatShouldConvertParams    bool `gorm:"column:compat_should_convert_params;default:false" json:"-"`
+	CompatConvertTextToChat      bool   `gorm:"column:compat_convert_text_to_chat;default:false" json:"-"`
+	CompatConvertChatToResponses bool   `gorm:"column:compat_convert_chat_to_responses;default:false" json:"-"`
+	CompatShouldDropParams       bool   `gorm:"column:compat_should_drop_params;default:false" json:"-"`
+	CompatShouldConvertParams    bool   `gorm:"column:compat_should_convert_params;default:false" json:"-"`
+	CompatTextToChatSystemPrompt string `gorm:"column:compat_text_to_chat_system_prompt;type:text" json:"-"`
+	CompatTextToChatModelsJSON   string `gorm:"column:compat_text_to_chat_models_json;type:text" json:"-"` // JSON serialized []string
 
 	// MCPServerAuthMode controls how /mcp authenticates inbound clients.
 	// Stored as a plain varchar column so it can be read without JSON parsing.
@@ -76,6 +80,9 @@ type TableClientConfig struct {
 	// WebhookConfigJSON holds the webhook delivery settings as a JSON blob,
 	// deserialized into Webhooks by AfterFind.
 	WebhookConfigJSON string `gorm:"column:webhook_config_json;type:text" json:"-"`
+	// RetentionConfigJSON holds the per data class retention windows as a JSON
+	// blob, deserialized into RetentionConfig by AfterFind.
+	RetentionConfigJSON string `gorm:"column:retention_config_json;type:text" json:"-"`
 
 	// Config hash is used to detect the changes synced from config.json file
 	// Every time we sync the config.json file, we will update the config hash
@@ -95,6 +102,9 @@ type TableClientConfig struct {
 	Metadata           map[string]any            `gorm:"-" json:"metadata,omitempty"`
 	OAuth2ServerConfig *OAuth2ServerConfig       `gorm:"-" json:"oauth2_server_config,omitempty"`
 	WebhookConfig      *WebhookConfig            `gorm:"-" json:"webhook_config,omitempty"`
+	RetentionConfig    *RetentionConfig          `gorm:"-" json:"retention_config,omitempty"`
+
+	CompatTextToChatModels []string `gorm:"-" json:"-"`
 }
 
 // WebhookConfig holds global webhook delivery settings. Delivery
@@ -115,6 +125,46 @@ func (w *WebhookConfig) DeliveryHistoryRetention() time.Duration {
 	return time.Duration(w.DeliveryHistoryRetentionDays) * 24 * time.Hour
 }
 
+// RetentionConfig holds the retention windows of the data classes other than
+// request logs, whose window is LogRetentionDays, and per virtual key overrides
+// for tenants with contractual retention requirements. Zero values fall back
+// to the defaults noted on each field. Analytics rollups are materialized
+// views recomputed from request logs, so they follow the request log window.
+type RetentionConfig struct {
+	MCPToolLogDays  int                                  `json:"mcp_tool_log_days,omitempty"` // MCP tool logs (default: the request log window)
+	RawPayloadDays  int                                  `json:"raw_payload_days,omitempty"`  // Raw request/response and passthrough bodies, cleared from older logs (default: kept with the log)
+	AuditRecordDays int                                  `json:"audit_record_days,omitempty"` // Bulk operation and log access audit records (default: kept forever)
+	VirtualKeys     map[string]VirtualKeyRetentionConfig `json:"virtual_keys,omitempty"`      // Overrides keyed by virtual key ID
+}
+
+// VirtualKeyRetentionConfig overrides the retention windows of the data
+// recorded for one virtual key. Zero values use the global window.
+type VirtualKeyRetentionConfig struct {
+	LogDays        int `json:"log_days,omitempty"`
+	MCPToolLogDays int `json:"mcp_tool_log_days,omitempty"`
+	RawPayloadDays int `json:"raw_payload_days,omitempty"`
+}
+
+// Validate rejects negative windows and empty virtual key IDs. Safe on a nil
+// receiver.
+func (r *RetentionConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MCPToolLogDays < 0 || r.RawPayloadDays < 0 || r.AuditRecordDays < 0 {
+		return fmt.Errorf("retention windows cannot be negative")
+	}
+	for id, override := range r.VirtualKeys {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("retention override needs a virtual key ID")
+		}
+		if override.LogDays < 0 || override.MCPToolLogDays < 0 || override.RawPayloadDays < 0 {
+			return fmt.Errorf("retention windows of virtual key %s cannot be negative", id)
+		}
+	}
+	return nil
+}
+
 // TableName sets the table name for each model
 func (TableClientConfig) TableName() string { return "config_client" }
 
@@ -221,6 +271,26 @@ func (cc *TableClientConfig) BeforeSave(tx *gorm.DB) error {
 		cc.WebhookConfigJSON = ""
 	}
 
+	if cc.RetentionConfig != nil {
+		data, err := json.Marshal(cc.RetentionConfig)
+		if err != nil {
+			return err
+		}
+		cc.RetentionConfigJSON = string(data)
+	} else {
+		cc.RetentionConfigJSON = ""
+	}
+
+	if len(cc.CompatTextToChatModels) > 0 {
+		data, err := json.Marshal(cc.CompatTextToChatModels)
+		if err != nil {
+			return err
+		}
+		cc.CompatTextToChatModelsJSON = string(data)
+	} else {
+		cc.CompatTextToChatModelsJSON = ""
+	}
+
 	return nil
 }
 
@@ -300,5 +370,21 @@ func (cc *TableClientConfig) AfterFind(tx *gorm.DB) error {
 		cc.WebhookConfig = nil
 	}
 
+	if cc.RetentionConfigJSON != "" {
+		var retentionCfg RetentionConfig
+		if err := json.Unmarshal([]byte(cc.RetentionConfigJSON), &retentionCfg); err != nil {
+			return err
+		}
+		cc.RetentionConfig = &retentionCfg
+	} else {
+		cc.RetentionConfig = nil
+	}
+
+	if cc.CompatTextToChatModelsJSON != "" {
+		if err := json.Unmarshal([]byte(cc.CompatTextToChatModelsJSON), &cc.CompatTextToChatModels); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
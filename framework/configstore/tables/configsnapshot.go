@@ -0,0 +1,31 @@
+package tables
+
+import "time"
+
+// TableConfigSnapshot records that the config store held a given state at a
+// point in time. The state itself lives in TableConfigSnapshotContent under its
+// hash, so a config that returns to an earlier state reuses the stored content.
+type TableConfigSnapshot struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Hash         string    `gorm:"type:varchar(64);index;not null" json:"hash"`
+	Providers    int       `gorm:"not null;default:0" json:"providers"`
+	Keys         int       `gorm:"not null;default:0" json:"keys"`
+	RoutingRules int       `gorm:"not null;default:0" json:"routing_rules"`
+	Plugins      int       `gorm:"not null;default:0" json:"plugins"`
+	CreatedAt    time.Time `gorm:"index;not null" json:"created_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableConfigSnapshot) TableName() string { return "config_snapshots" }
+
+// TableConfigSnapshotContent is the content of a config snapshot: the rows of
+// the snapshotted tables as stored, with secrets still encrypted, addressed by
+// the SHA-256 of the content.
+type TableConfigSnapshotContent struct {
+	Hash      string    `gorm:"type:varchar(64);primaryKey" json:"hash"`
+	Content   string    `gorm:"type:text;not null" json:"-"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableConfigSnapshotContent) TableName() string { return "config_snapshot_contents" }
@@ -0,0 +1,58 @@
+package tables
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Governance controls an override token can bypass.
+const (
+	GovernanceOverrideControlBudget    = "budget"
+	GovernanceOverrideControlRateLimit = "rate_limit"
+)
+
+// TableGovernanceOverride is a short-lived, admin-minted token that lets a
+// request presenting it (x-bf-governance-override) skip specific governance
+// controls during an incident. Only the SHA-256 of the token is stored; rows
+// are kept after expiry or revocation as the audit record of who minted the
+// override, why, and how often it was used.
+type TableGovernanceOverride struct {
+	ID           string     `gorm:"type:varchar(255);primaryKey" json:"id"`
+	TokenHash    string     `gorm:"type:varchar(64);uniqueIndex:idx_governance_override_token_hash" json:"-"` // SHA-256 of the plaintext token
+	Controls     []string   `gorm:"type:text;serializer:json;not null" json:"controls"`                       // GovernanceOverrideControl* values
+	VirtualKeyID *string    `gorm:"type:varchar(255);index" json:"virtual_key_id,omitempty"`                  // nil = any virtual key
+	Reason       string     `gorm:"type:text;not null" json:"reason"`
+	CreatedBy    string     `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	ExpiresAt    time.Time  `gorm:"index;not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	UsageCount   int64      `gorm:"default:0;not null" json:"usage_count"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"index;not null" json:"created_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableGovernanceOverride) TableName() string { return "governance_overrides" }
+
+// ValidateGovernanceOverrideControls checks that controls is a non-empty list of known controls.
+func ValidateGovernanceOverrideControls(controls []string) error {
+	if len(controls) == 0 {
+		return fmt.Errorf("at least one control is required")
+	}
+	for _, control := range controls {
+		if control != GovernanceOverrideControlBudget && control != GovernanceOverrideControlRateLimit {
+			return fmt.Errorf("unknown control %q: must be %q or %q", control, GovernanceOverrideControlBudget, GovernanceOverrideControlRateLimit)
+		}
+	}
+	return nil
+}
+
+// IsActiveAt reports whether the override can be used at now.
+func (o *TableGovernanceOverride) IsActiveAt(now time.Time) bool {
+	return o.RevokedAt == nil && o.ExpiresAt.After(now)
+}
+
+// Covers reports whether the override bypasses control.
+func (o *TableGovernanceOverride) Covers(control string) bool {
+	return slices.Contains(o.Controls, control)
+}
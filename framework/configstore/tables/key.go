@@ -38,6 +38,7 @@ type TableKey struct {
 	AzureClientSecret *schemas.SecretVar `gorm:"type:text" json:"azure_client_secret,omitempty"`
 	AzureTenantID     *schemas.SecretVar `gorm:"type:text" json:"azure_tenant_id,omitempty"`
 	AzureScopesJSON   *string            `gorm:"column:azure_scopes;type:text" json:"-"` // JSON serialized []string
+	AzureAuthType     *string            `gorm:"column:azure_auth_type;type:varchar(50)" json:"azure_auth_type,omitempty"`
 
 	// Vertex config fields (embedded)
 	VertexProjectID         *schemas.SecretVar `gorm:"type:text" json:"vertex_project_id,omitempty"`
@@ -89,6 +90,13 @@ type TableKey struct {
 	// endpoints instead of its OpenAI-compatible ones.
 	UseAnthropicEndpoints *bool `gorm:"default:false" json:"use_anthropic_endpoints,omitempty"`
 
+	// BYOK marks a tenant-owned key. It is left out of key rotation and only serves
+	// requests that name it, and the spend on it is logged as tenant-owned.
+	BYOK bool `gorm:"column:byok;default:false" json:"byok,omitempty"`
+
+	// Beta feature flags allowed and injected by default for this key
+	BetaFeaturesJSON *string `gorm:"column:beta_features_json;type:text" json:"-"` // JSON serialized schemas.KeyBetaFeatures
+
 	Status      string `gorm:"type:varchar(50);default:'unknown'" json:"status"`
 	Description string `gorm:"type:text" json:"description,omitempty"`
 
@@ -106,6 +114,7 @@ type TableKey struct {
 	ReplicateKeyConfig     *schemas.ReplicateKeyConfig     `gorm:"-" json:"replicate_key_config,omitempty"`
 	OllamaKeyConfig        *schemas.OllamaKeyConfig        `gorm:"-" json:"ollama_key_config,omitempty"`
 	SGLKeyConfig           *schemas.SGLKeyConfig           `gorm:"-" json:"sgl_key_config,omitempty"`
+	BetaFeatures           *schemas.KeyBetaFeatures        `gorm:"-" json:"beta_features,omitempty"`
 }
 
 // TableName sets the table name for each model
@@ -185,12 +194,19 @@ func (k *TableKey) BeforeSave(tx *gorm.DB) error {
 		} else {
 			k.AzureScopesJSON = nil
 		}
+		if k.AzureKeyConfig.AuthType != "" {
+			authType := string(k.AzureKeyConfig.AuthType)
+			k.AzureAuthType = &authType
+		} else {
+			k.AzureAuthType = nil
+		}
 	} else {
 		k.AzureEndpoint = nil
 		k.AzureClientID = nil
 		k.AzureClientSecret = nil
 		k.AzureTenantID = nil
 		k.AzureScopesJSON = nil
+		k.AzureAuthType = nil
 	}
 	if k.VertexKeyConfig != nil {
 		if k.VertexKeyConfig.ProjectID.IsSet() {
@@ -386,6 +402,20 @@ func (k *TableKey) BeforeSave(tx *gorm.DB) error {
 		k.AliasesJSON = nil
 	}
 
+	if k.BetaFeatures != nil {
+		if err := k.BetaFeatures.Validate(); err != nil {
+			return err
+		}
+		data, err := sonic.Marshal(k.BetaFeatures)
+		if err != nil {
+			return err
+		}
+		s := string(data)
+		k.BetaFeaturesJSON = &s
+	} else {
+		k.BetaFeaturesJSON = nil
+	}
+
 	if k.VLLMKeyConfig != nil {
 		if k.VLLMKeyConfig.URL.IsSet() {
 			u := k.VLLMKeyConfig.URL // Value-copy to prevent shared pointer mutation
@@ -683,7 +713,7 @@ func (k *TableKey) AfterFind(tx *gorm.DB) error {
 		k.UseAnthropicEndpoints = &useAnthropicEndpoints
 	}
 	// Reconstruct Azure config if fields are present
-	if k.AzureEndpoint != nil || k.AzureClientID != nil || k.AzureClientSecret != nil || k.AzureTenantID != nil || (k.AzureScopesJSON != nil && *k.AzureScopesJSON != "") {
+	if k.AzureEndpoint != nil || k.AzureClientID != nil || k.AzureClientSecret != nil || k.AzureTenantID != nil || (k.AzureScopesJSON != nil && *k.AzureScopesJSON != "") || k.AzureAuthType != nil {
 		var scopes []string
 		if k.AzureScopesJSON != nil && *k.AzureScopesJSON != "" {
 			if err := json.Unmarshal([]byte(*k.AzureScopesJSON), &scopes); err != nil {
@@ -701,6 +731,9 @@ func (k *TableKey) AfterFind(tx *gorm.DB) error {
 		if k.AzureEndpoint != nil {
 			azureConfig.Endpoint = *k.AzureEndpoint
 		}
+		if k.AzureAuthType != nil {
+			azureConfig.AuthType = schemas.AzureAuthType(*k.AzureAuthType)
+		}
 
 		k.AzureKeyConfig = azureConfig
 	}
@@ -785,6 +818,16 @@ func (k *TableKey) AfterFind(tx *gorm.DB) error {
 	} else {
 		k.Aliases = nil
 	}
+	// Reconstruct beta features
+	if k.BetaFeaturesJSON != nil && *k.BetaFeaturesJSON != "" {
+		var betaFeatures schemas.KeyBetaFeatures
+		if err := sonic.Unmarshal([]byte(*k.BetaFeaturesJSON), &betaFeatures); err != nil {
+			return err
+		}
+		k.BetaFeatures = &betaFeatures
+	} else {
+		k.BetaFeatures = nil
+	}
 	// Reconstruct VLLM config if fields are present
 	if k.VLLMUrl != nil || (k.VLLMModelName != nil && *k.VLLMModelName != "") {
 		vllmConfig := &schemas.VLLMKeyConfig{}
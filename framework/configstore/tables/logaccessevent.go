@@ -0,0 +1,24 @@
+package tables
+
+import "time"
+
+// Log access levels.
+const (
+	LogAccessContent  = "content"  // the full log, including request and response bodies
+	LogAccessMetadata = "metadata" // a list row: identifiers, usage and truncated previews
+)
+
+// TableLogAccessEvent is the audit record of one operator viewing one log
+// through the API or UI. It only holds identifiers, never log content, so the
+// access history can be kept and shared for privacy reviews.
+type TableLogAccessEvent struct {
+	ID        string    `gorm:"type:varchar(255);primaryKey" json:"id"`
+	LogID     string    `gorm:"type:varchar(255);index;not null" json:"log_id"`
+	Access    string    `gorm:"type:varchar(20);not null" json:"access"` // LogAccess* values
+	Actor     string    `gorm:"type:varchar(255)" json:"actor"`
+	Endpoint  string    `gorm:"type:varchar(255)" json:"endpoint"`
+	CreatedAt time.Time `gorm:"index;not null" json:"created_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableLogAccessEvent) TableName() string { return "log_access_events" }
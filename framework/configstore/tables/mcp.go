@@ -29,6 +29,8 @@ type TableMCPClient struct {
 	ToolPricingJSON         string             `gorm:"type:text" json:"-"`                              // JSON serialized map[string]float64
 	ToolSyncInterval        int                `gorm:"default:0" json:"tool_sync_interval"`             // Per-client tool sync interval in seconds (0 = use global, negative = disabled)
 	ToolExecutionTimeout    int                `gorm:"default:0" json:"tool_execution_timeout"`         // Per-client tool execution timeout in seconds (0 = use global from tool_manager_config)
+	// schemas.MCPOutputSchemaValidation applied to tool results (empty = off)
+	OutputValidation string `gorm:"column:output_schema_validation;type:varchar(20)" json:"output_schema_validation,omitempty"`
 
 	// Per-user OAuth: discovered tools persisted so they survive restart
 	DiscoveredToolsJSON string `gorm:"type:text" json:"-"` // JSON serialized map[string]schemas.ChatTool
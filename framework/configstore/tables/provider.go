@@ -15,18 +15,19 @@ import (
 // NOTE: Any changes to the provider configuration should be reflected in the GenerateConfigHash function
 // That helps us detect changes between config file and database config
 type TableProvider struct {
-	ID                       uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name                     string    `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"` // ModelProvider as string
-	NetworkConfigJSON        string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.NetworkConfig
-	ConcurrencyBufferJSON    string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.ConcurrencyAndBufferSize
-	ProxyConfigJSON          string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.ProxyConfig
-	CustomProviderConfigJSON string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.CustomProviderConfig
-	OpenAIConfigJSON         string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.OpenAIConfig
-	SendBackRawRequest       bool      `json:"send_back_raw_request"`
-	SendBackRawResponse      bool      `json:"send_back_raw_response"`
-	StoreRawRequestResponse  bool      `json:"store_raw_request_response"`
-	CreatedAt                time.Time `gorm:"index;not null" json:"created_at"`
-	UpdatedAt                time.Time `gorm:"index;not null" json:"updated_at"`
+	ID                       uint                          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name                     string                        `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"` // ModelProvider as string
+	NetworkConfigJSON        string                        `gorm:"type:text" json:"-"`                                // JSON serialized schemas.NetworkConfig
+	ConcurrencyBufferJSON    string                        `gorm:"type:text" json:"-"`                                // JSON serialized schemas.ConcurrencyAndBufferSize
+	ProxyConfigJSON          string                        `gorm:"type:text" json:"-"`                                // JSON serialized schemas.ProxyConfig
+	CustomProviderConfigJSON string                        `gorm:"type:text" json:"-"`                                // JSON serialized schemas.CustomProviderConfig
+	OpenAIConfigJSON         string                        `gorm:"type:text" json:"-"`                                // JSON serialized schemas.OpenAIConfig
+	SendBackRawRequest       bool                          `json:"send_back_raw_request"`
+	SendBackRawResponse      bool                          `json:"send_back_raw_response"`
+	StoreRawRequestResponse  bool                          `json:"store_raw_request_response"`
+	LoadBalancingStrategy    schemas.LoadBalancingStrategy `gorm:"type:varchar(32)" json:"load_balancing_strategy,omitempty"` // How requests are spread across keys (default: weighted)
+	CreatedAt                time.Time                     `gorm:"index;not null" json:"created_at"`
+	UpdatedAt                time.Time                     `gorm:"index;not null" json:"updated_at"`
 
 	// Relationships
 	Keys []TableKey `gorm:"foreignKey:ProviderID;constraint:OnDelete:CASCADE" json:"keys"`
@@ -90,8 +91,8 @@ func (p *TableProvider) BeforeSave(tx *gorm.DB) error {
 		}
 		p.ProxyConfigJSON = string(data)
 	}
-	if p.CustomProviderConfig != nil && p.CustomProviderConfig.BaseProviderType == "" {
-		return fmt.Errorf("base_provider_type is required when custom_provider_config is set")
+	if p.CustomProviderConfig != nil && p.CustomProviderConfig.BaseProviderType == "" && p.CustomProviderConfig.Translator == "" {
+		return fmt.Errorf("base_provider_type or translator is required when custom_provider_config is set")
 	}
 	if p.CustomProviderConfig != nil {
 		data, err := json.Marshal(p.CustomProviderConfig)
@@ -182,4 +183,3 @@ func (p *TableProvider) AfterFind(tx *gorm.DB) error {
 
 	return nil
 }
-
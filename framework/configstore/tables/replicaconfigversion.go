@@ -0,0 +1,18 @@
+package tables
+
+import "time"
+
+// TableReplicaConfigVersion is the config version a Bifrost replica last
+// published to the shared config store. Every replica upserts its own row on
+// an interval and reads the others' to notice when they serve different
+// configs, see framework/configversion.
+type TableReplicaConfigVersion struct {
+	InstanceID     string    `gorm:"type:varchar(255);primaryKey" json:"instance_id"`
+	ConfigHash     string    `gorm:"type:varchar(64);not null" json:"config_hash"`
+	BifrostVersion string    `gorm:"type:varchar(64)" json:"bifrost_version,omitempty"`
+	StartedAt      time.Time `gorm:"not null" json:"started_at"`
+	UpdatedAt      time.Time `gorm:"index;not null" json:"updated_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableReplicaConfigVersion) TableName() string { return "replica_config_versions" }
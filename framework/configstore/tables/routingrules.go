@@ -9,6 +9,24 @@ import (
 	"gorm.io/gorm"
 )
 
+// Routing rule types. A CEL rule routes to one of its weighted targets; an
+// embedding length rule applies to embedding requests only and routes by the
+// length of the input to one of its EmbeddingBuckets.
+const (
+	RoutingRuleTypeCEL             = "cel"
+	RoutingRuleTypeEmbeddingLength = "embedding_length"
+)
+
+// RoutingEmbeddingBucket routes embedding requests whose longest input is at
+// most MaxInputTokens (estimated) to Provider/Model. Buckets are ordered by
+// MaxInputTokens; a MaxInputTokens of 0 makes the bucket unbounded and is only
+// valid for the last one.
+type RoutingEmbeddingBucket struct {
+	MaxInputTokens int     `json:"max_input_tokens,omitempty"`
+	Provider       *string `json:"provider,omitempty"` // nil = use incoming provider
+	Model          string  `json:"model"`
+}
+
 // TableRoutingRule represents a routing rule in the database
 type TableRoutingRule struct {
 	ID            string `gorm:"primaryKey;type:varchar(255)" json:"id"`
@@ -17,6 +35,7 @@ type TableRoutingRule struct {
 	Description   string `gorm:"type:text" json:"description"`
 	Enabled       *bool  `gorm:"not null;default:true" json:"enabled,omitempty"` // nil = DB default (true); use EnabledValue() to read
 	CelExpression string `gorm:"type:text;not null" json:"cel_expression"`
+	Type          string `gorm:"type:varchar(50);not null;default:'cel'" json:"type,omitempty"` // "cel" (default) | "embedding_length"
 
 	// Routing Targets (output) — 1:many relationship; weights must sum to 1
 	Targets []TableRoutingTarget `gorm:"foreignKey:RuleID;constraint:OnDelete:CASCADE" json:"targets"`
@@ -27,6 +46,11 @@ type TableRoutingRule struct {
 	Query       *string        `gorm:"type:text" json:"-"`
 	ParsedQuery map[string]any `gorm:"-" json:"query,omitempty"`
 
+	// Embedding length buckets (type "embedding_length" only). Inputs longer than
+	// every bucket fall through to Targets.
+	EmbeddingBuckets       *string                  `gorm:"type:text" json:"-"`
+	ParsedEmbeddingBuckets []RoutingEmbeddingBucket `gorm:"-" json:"embedding_buckets,omitempty"`
+
 	// Scope: where this rule applies
 	Scope   string  `gorm:"type:varchar(50);not null;uniqueIndex:idx_routing_rule_scope_name" json:"scope"` // "global" | "team" | "customer" | "virtual_key"
 	ScopeID *string `gorm:"type:varchar(255);uniqueIndex:idx_routing_rule_scope_name" json:"scope_id"`      // nil for global, otherwise entity ID
@@ -56,6 +80,11 @@ func (r *TableRoutingRule) EnabledValue() bool {
 	return *r.Enabled
 }
 
+// IsEmbeddingLengthRule reports whether the rule routes embeddings by input length.
+func (r *TableRoutingRule) IsEmbeddingLengthRule() bool {
+	return r != nil && r.Type == RoutingRuleTypeEmbeddingLength
+}
+
 // BeforeSave hook for TableRoutingRule to serialize JSON fields
 func (r *TableRoutingRule) BeforeSave(tx *gorm.DB) error {
 	if len(r.ParsedFallbacks) > 0 {
@@ -76,6 +105,18 @@ func (r *TableRoutingRule) BeforeSave(tx *gorm.DB) error {
 	} else {
 		r.Query = nil
 	}
+	if r.Type == "" {
+		r.Type = RoutingRuleTypeCEL
+	}
+	if len(r.ParsedEmbeddingBuckets) > 0 {
+		data, err := sonic.Marshal(r.ParsedEmbeddingBuckets)
+		if err != nil {
+			return err
+		}
+		r.EmbeddingBuckets = bifrost.Ptr(string(data))
+	} else {
+		r.EmbeddingBuckets = nil
+	}
 	return nil
 }
 
@@ -91,6 +132,11 @@ func (r *TableRoutingRule) AfterFind(tx *gorm.DB) error {
 			return err
 		}
 	}
+	if r.EmbeddingBuckets != nil && strings.TrimSpace(*r.EmbeddingBuckets) != "" {
+		if err := sonic.Unmarshal([]byte(*r.EmbeddingBuckets), &r.ParsedEmbeddingBuckets); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,19 @@
+package tables
+
+import "time"
+
+// TableSessionAffinity records which provider, model and key served a session
+// (x-bf-session-id), so later turns of the conversation are routed to the same
+// deployment. The session ID is stored as its SHA-256 hash; rows expire at
+// ExpiresAt and are swept by framework/sessionaffinity.
+type TableSessionAffinity struct {
+	SessionHash string    `gorm:"type:varchar(64);primaryKey" json:"-"` // SHA-256 of the session ID
+	Provider    string    `gorm:"type:varchar(255);not null" json:"provider"`
+	Model       string    `gorm:"type:varchar(255);not null" json:"model"`
+	KeyID       string    `gorm:"type:varchar(255)" json:"key_id,omitempty"`
+	ExpiresAt   time.Time `gorm:"index;not null" json:"expires_at"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// TableName sets the table name for the model.
+func (TableSessionAffinity) TableName() string { return "session_affinities" }
@@ -53,6 +53,28 @@ func GetCalendarPeriodStart(duration string, t time.Time) time.Time {
 	}
 }
 
+// GetNextCalendarPeriodStart returns the calendar boundary that ends the period
+// containing t, i.e. when a calendar-aligned counter with the given duration
+// next resets. Like the reset path, it snaps to every boundary of the unit, so
+// the multiplier in durations like "2w" is not applied. Durations without a
+// calendar boundary return t unchanged.
+func GetNextCalendarPeriodStart(duration string, t time.Time) time.Time {
+	if !IsCalendarAlignableDuration(duration) {
+		return t
+	}
+	start := GetCalendarPeriodStart(duration, t)
+	switch duration[len(duration)-1] {
+	case 'd':
+		return start.AddDate(0, 0, 1)
+	case 'w':
+		return start.AddDate(0, 0, 7)
+	case 'M':
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
 // ParseDuration function to parse duration strings
 func ParseDuration(duration string) (time.Duration, error) {
 	if duration == "" {
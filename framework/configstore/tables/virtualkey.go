@@ -232,6 +232,18 @@ type TableVirtualKey struct {
 
 	CalendarAligned bool `gorm:"default:false" json:"calendar_aligned"`
 
+	// Priority is the queueing class of this key's requests (schemas.RequestPriority).
+	// Empty leaves it to the x-bf-priority header; when set, it wins over the header.
+	Priority string `gorm:"type:varchar(32)" json:"priority,omitempty"`
+
+	// MaxConcurrentRequests caps the requests in flight with this key at once
+	// across all providers. Nil or 0 means no cap.
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"`
+
+	// Defaults are model and parameter presets applied to inference requests made with this key.
+	DefaultsJSON *string             `gorm:"column:defaults_json;type:text" json:"-"` // JSON serialized VirtualKeyDefaults
+	Defaults     *VirtualKeyDefaults `gorm:"-" json:"defaults,omitempty"`
+
 	// Relationships
 	Team      *TableTeam      `gorm:"foreignKey:TeamID" json:"team,omitempty"`
 	Customer  *TableCustomer  `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
@@ -251,6 +263,53 @@ type TableVirtualKey struct {
 	UpdatedAt time.Time `gorm:"index;not null" json:"updated_at"`
 }
 
+// Virtual key default modes.
+const (
+	// VirtualKeyDefaultsModeFill applies a preset only when the request leaves the field unset.
+	VirtualKeyDefaultsModeFill = "fill"
+	// VirtualKeyDefaultsModeForce applies every preset, overriding what the request sent.
+	VirtualKeyDefaultsModeForce = "force"
+)
+
+// VirtualKeyDefaults holds the model and parameter presets of a virtual key, so
+// lightweight clients can send only messages and get centrally managed behavior.
+type VirtualKeyDefaults struct {
+	Mode         string   `json:"mode,omitempty"`          // "fill" (default) or "force"
+	Model        string   `json:"model,omitempty"`         // "provider/model" or a bare model name
+	Temperature  *float64 `json:"temperature,omitempty"`   // Sampling temperature
+	MaxTokens    *int     `json:"max_tokens,omitempty"`    // Maximum output tokens
+	SystemPrompt string   `json:"system_prompt,omitempty"` // System message (chat) or instructions (responses)
+}
+
+// IsEmpty reports whether no preset is configured.
+func (d *VirtualKeyDefaults) IsEmpty() bool {
+	return d == nil || (d.Model == "" && d.Temperature == nil && d.MaxTokens == nil && d.SystemPrompt == "")
+}
+
+// IsForced reports whether presets override values sent by the client.
+func (d *VirtualKeyDefaults) IsForced() bool {
+	return d != nil && d.Mode == VirtualKeyDefaultsModeForce
+}
+
+// Validate checks the mode and parameter ranges.
+func (d *VirtualKeyDefaults) Validate() error {
+	if d == nil {
+		return nil
+	}
+	switch d.Mode {
+	case "", VirtualKeyDefaultsModeFill, VirtualKeyDefaultsModeForce:
+	default:
+		return fmt.Errorf("invalid defaults mode %q (expected %s or %s)", d.Mode, VirtualKeyDefaultsModeFill, VirtualKeyDefaultsModeForce)
+	}
+	if d.Temperature != nil && (*d.Temperature < 0 || *d.Temperature > 2) {
+		return fmt.Errorf("defaults temperature must be between 0 and 2, got %v", *d.Temperature)
+	}
+	if d.MaxTokens != nil && *d.MaxTokens <= 0 {
+		return fmt.Errorf("defaults max_tokens must be positive, got %d", *d.MaxTokens)
+	}
+	return nil
+}
+
 // TableName sets the table name for each model
 func (TableVirtualKey) TableName() string { return "governance_virtual_keys" }
 
@@ -313,6 +372,19 @@ func (vk *TableVirtualKey) BeforeSave(tx *gorm.DB) error {
 		}
 		vk.ValueHash = encrypt.HashSHA256(resolved)
 	}
+	if vk.Defaults.IsEmpty() {
+		vk.DefaultsJSON = nil
+	} else {
+		if err := vk.Defaults.Validate(); err != nil {
+			return fmt.Errorf("virtual key %s: %w", vk.Name, err)
+		}
+		data, err := json.Marshal(vk.Defaults)
+		if err != nil {
+			return err
+		}
+		defaultsJSON := string(data)
+		vk.DefaultsJSON = &defaultsJSON
+	}
 	// Store plaintext SecretVar into vault and rewrite to vault ref before encrypting.
 	if schemas.VaultStoreWriteEnabled() {
 		base := schemas.VaultBasePath(vk.TableName(), vk.VaultPathKey())
@@ -341,6 +413,15 @@ func (vk *TableVirtualKey) AfterFind(tx *gorm.DB) error {
 			return fmt.Errorf("failed to decrypt virtual key value: %w", err)
 		}
 	}
+	if vk.DefaultsJSON != nil && *vk.DefaultsJSON != "" {
+		var defaults VirtualKeyDefaults
+		if err := json.Unmarshal([]byte(*vk.DefaultsJSON), &defaults); err != nil {
+			return fmt.Errorf("failed to unmarshal virtual key defaults: %w", err)
+		}
+		vk.Defaults = &defaults
+	} else {
+		vk.Defaults = nil
+	}
 	for i := range vk.Budgets {
 		vk.Budgets[i].IsCalendarAligned = vk.CalendarAligned
 	}
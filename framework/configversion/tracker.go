@@ -0,0 +1,301 @@
+// Package configversion lets Bifrost replicas that share a config store notice
+// when they serve different configs, for example after a partial rollout or an
+// update that only reached some pods. Every replica publishes a hash of its
+// effective config to the config store on an interval and compares it with the
+// hashes the other live replicas published.
+package configversion
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+const (
+	// DefaultPublishInterval is how often a replica publishes its config hash
+	// and re-reads the others'.
+	DefaultPublishInterval = 15 * time.Second
+	// DefaultGracePeriod is how long replicas may serve different configs, for
+	// example while a rollout is in progress, before the divergence is reported
+	// as a warning.
+	DefaultGracePeriod = 5 * time.Minute
+
+	// liveIntervals is how many publish intervals a replica may miss before it
+	// is no longer counted as live.
+	liveIntervals = 3
+	// staleRowAge is how long the row of a replica that stopped publishing is
+	// kept before it is deleted.
+	staleRowAge = time.Hour
+)
+
+// State is how this replica's config compares with the other live replicas'.
+type State string
+
+const (
+	// StateUnknown means no comparison has completed yet, or the last one failed.
+	StateUnknown State = "unknown"
+	// StateInSync means every live replica serves the same config.
+	StateInSync State = "in_sync"
+	// StateConverging means live replicas serve different configs, but for less
+	// than the grace period.
+	StateConverging State = "converging"
+	// StateDiverged means live replicas have served different configs for longer
+	// than the grace period.
+	StateDiverged State = "diverged"
+)
+
+// Replica is the config version one live replica last published.
+type Replica struct {
+	InstanceID     string    `json:"instance_id"`
+	ConfigHash     string    `json:"config_hash"`
+	BifrostVersion string    `json:"bifrost_version,omitempty"`
+	StartedAt      time.Time `json:"started_at"`
+	LastSeen       time.Time `json:"last_seen"`
+	Self           bool      `json:"self,omitempty"`
+}
+
+// Status is the result of the last comparison.
+type Status struct {
+	InstanceID         string     `json:"instance_id"`
+	ConfigHash         string     `json:"config_hash,omitempty"`
+	State              State      `json:"state"`
+	DivergedSince      *time.Time `json:"diverged_since,omitempty"`
+	GracePeriodSeconds int64      `json:"grace_period_seconds"`
+	Replicas           []Replica  `json:"replicas"`
+	CheckedAt          *time.Time `json:"checked_at,omitempty"`
+	Error              string     `json:"error,omitempty"`
+}
+
+// Tracker publishes this replica's config hash and compares it with the other
+// replicas'. All replicas run one; a divergence is reported by every replica
+// involved, since none of them can tell which config is the intended one.
+type Tracker struct {
+	store          configstore.ConfigStore
+	instanceID     string
+	bifrostVersion string
+	hash           func() (string, error)
+	logger         schemas.Logger
+	startedAt      time.Time
+	now            func() time.Time // injectable for tests
+
+	interval    time.Duration
+	gracePeriod time.Duration
+
+	mu            sync.RWMutex
+	status        Status
+	divergedSince time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	cancel   context.CancelFunc
+}
+
+// NewTracker constructs a tracker for the replica instanceID whose config hash
+// is computed by hash. Returns nil when store is nil, since a replica without
+// a shared store has nobody to compare with, so callers can wire it
+// unconditionally and check the result before starting.
+func NewTracker(store configstore.ConfigStore, instanceID, bifrostVersion string, hash func() (string, error), logger schemas.Logger) *Tracker {
+	if store == nil {
+		return nil
+	}
+	t := &Tracker{
+		store:          store,
+		instanceID:     instanceID,
+		bifrostVersion: bifrostVersion,
+		hash:           hash,
+		logger:         logger,
+		startedAt:      time.Now().UTC(),
+		now:            time.Now,
+		interval:       DefaultPublishInterval,
+		gracePeriod:    DefaultGracePeriod,
+		stopCh:         make(chan struct{}),
+	}
+	t.status = Status{InstanceID: instanceID, State: StateUnknown, GracePeriodSeconds: int64(t.gracePeriod / time.Second), Replicas: []Replica{}}
+	return t
+}
+
+// SetPublishInterval updates the publish cadence. Call before Start.
+func (t *Tracker) SetPublishInterval(d time.Duration) {
+	t.interval = d
+}
+
+// SetGracePeriod updates how long replicas may differ before the divergence is
+// reported as a warning. Call before Start.
+func (t *Tracker) SetGracePeriod(d time.Duration) {
+	t.gracePeriod = d
+	t.mu.Lock()
+	t.status.GracePeriodSeconds = int64(d / time.Second)
+	t.mu.Unlock()
+}
+
+// Start begins the publish loop in a background goroutine.
+func (t *Tracker) Start(ctx context.Context) {
+	runCtx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+	go t.run(runCtx)
+	if t.logger != nil {
+		t.logger.Info("config version tracker started (instance=%s, interval=%s, grace period=%s)", t.instanceID, t.interval, t.gracePeriod)
+	}
+}
+
+// Stop stops the publish loop. sync.Once guards against double-close panics
+// from redundant shutdown paths.
+func (t *Tracker) Stop() {
+	t.stopOnce.Do(func() {
+		if t.cancel != nil {
+			t.cancel()
+		}
+		close(t.stopCh)
+	})
+}
+
+// Status returns the result of the last comparison.
+func (t *Tracker) Status() Status {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	status := t.status
+	status.Replicas = append([]Replica(nil), t.status.Replicas...)
+	return status
+}
+
+func (t *Tracker) run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	// Publish right away so a new replica shows up before its first tick.
+	t.check(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			t.check(ctx)
+		case <-t.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check publishes this replica's config hash, reads the live replicas' and
+// updates the status.
+func (t *Tracker) check(ctx context.Context) {
+	now := t.now().UTC()
+	configHash, err := t.hash()
+	if err != nil {
+		t.fail(now, "failed to hash config: "+err.Error())
+		return
+	}
+	if err := t.store.UpsertReplicaConfigVersion(ctx, &tables.TableReplicaConfigVersion{
+		InstanceID:     t.instanceID,
+		ConfigHash:     configHash,
+		BifrostVersion: t.bifrostVersion,
+		StartedAt:      t.startedAt,
+		UpdatedAt:      now,
+	}); err != nil {
+		t.fail(now, "failed to publish config version: "+err.Error())
+		return
+	}
+	rows, err := t.store.GetReplicaConfigVersions(ctx, now.Add(-liveIntervals*t.interval))
+	if err != nil {
+		t.fail(now, "failed to read replica config versions: "+err.Error())
+		return
+	}
+	if _, err := t.store.DeleteStaleReplicaConfigVersions(ctx, now.Add(-staleRowAge)); err != nil && t.logger != nil {
+		t.logger.Debug("failed to delete stale replica config versions: %v", err)
+	}
+
+	replicas := make([]Replica, 0, len(rows))
+	diverged := false
+	for _, row := range rows {
+		replicas = append(replicas, Replica{
+			InstanceID:     row.InstanceID,
+			ConfigHash:     row.ConfigHash,
+			BifrostVersion: row.BifrostVersion,
+			StartedAt:      row.StartedAt,
+			LastSeen:       row.UpdatedAt,
+			Self:           row.InstanceID == t.instanceID,
+		})
+		if row.ConfigHash != configHash {
+			diverged = true
+		}
+	}
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].InstanceID < replicas[j].InstanceID })
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.status.State
+	state := StateInSync
+	if diverged {
+		if t.divergedSince.IsZero() {
+			t.divergedSince = now
+		}
+		state = StateConverging
+		if now.Sub(t.divergedSince) >= t.gracePeriod {
+			state = StateDiverged
+		}
+	} else {
+		t.divergedSince = time.Time{}
+	}
+	t.status.ConfigHash = configHash
+	t.status.State = state
+	t.status.Replicas = replicas
+	t.status.CheckedAt = &now
+	t.status.Error = ""
+	t.status.DivergedSince = nil
+	if !t.divergedSince.IsZero() {
+		since := t.divergedSince
+		t.status.DivergedSince = &since
+	}
+
+	if t.logger == nil || state == previous {
+		return
+	}
+	switch {
+	case state == StateDiverged:
+		t.logger.Warn("replicas have served different configs since %s: %s", t.divergedSince.Format(time.RFC3339), describeReplicas(replicas))
+	case state == StateInSync && (previous == StateDiverged || previous == StateConverging):
+		t.logger.Info("replicas serve the same config again (config hash %s)", configHash)
+	}
+}
+
+// fail records a comparison that could not complete. The divergence clock keeps
+// running, so a store outage does not reset the grace period.
+func (t *Tracker) fail(now time.Time, message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status.Error == "" && t.logger != nil {
+		t.logger.Warn("config version check failed: %s", message)
+	}
+	t.status.State = StateUnknown
+	t.status.CheckedAt = &now
+	t.status.Error = message
+}
+
+// describeReplicas lists the instances serving each config hash, for logs.
+func describeReplicas(replicas []Replica) string {
+	byHash := make(map[string][]string)
+	hashes := make([]string, 0)
+	for _, replica := range replicas {
+		if _, ok := byHash[replica.ConfigHash]; !ok {
+			hashes = append(hashes, replica.ConfigHash)
+		}
+		byHash[replica.ConfigHash] = append(byHash[replica.ConfigHash], replica.InstanceID)
+	}
+	sort.Strings(hashes)
+	parts := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		short := hash
+		if len(short) > 12 {
+			short = short[:12]
+		}
+		parts = append(parts, short+" on "+strings.Join(byHash[hash], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,164 @@
+package configversion
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// fakeStore implements only the replica config version subset of ConfigStore.
+type fakeStore struct {
+	configstore.ConfigStore
+
+	mu      sync.Mutex
+	rows    map[string]tables.TableReplicaConfigVersion
+	readErr error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{rows: make(map[string]tables.TableReplicaConfigVersion)}
+}
+
+func (f *fakeStore) UpsertReplicaConfigVersion(_ context.Context, version *tables.TableReplicaConfigVersion) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rows[version.InstanceID] = *version
+	return nil
+}
+
+func (f *fakeStore) GetReplicaConfigVersions(_ context.Context, seenSince time.Time) ([]tables.TableReplicaConfigVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.readErr != nil {
+		return nil, f.readErr
+	}
+	var rows []tables.TableReplicaConfigVersion
+	for _, row := range f.rows {
+		if !row.UpdatedAt.Before(seenSince) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) DeleteStaleReplicaConfigVersions(_ context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deleted int64
+	for id, row := range f.rows {
+		if row.UpdatedAt.Before(before) {
+			delete(f.rows, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time          { return c.t }
+func (c *testClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTracker(store *fakeStore, clock *testClock, instanceID string, hash *string) *Tracker {
+	tracker := NewTracker(store, instanceID, "v1.0.0", func() (string, error) { return *hash, nil }, nil)
+	tracker.now = clock.now
+	return tracker
+}
+
+func TestNewTrackerWithoutStoreIsNil(t *testing.T) {
+	if tracker := NewTracker(nil, "a", "", nil, nil); tracker != nil {
+		t.Fatalf("expected a nil tracker without a config store")
+	}
+}
+
+func TestTrackerReportsDivergenceAfterGracePeriod(t *testing.T) {
+	store := newFakeStore()
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hashA, hashB := "hash-1", "hash-1"
+	a := newTestTracker(store, clock, "replica-a", &hashA)
+	b := newTestTracker(store, clock, "replica-b", &hashB)
+	ctx := context.Background()
+
+	a.check(ctx)
+	b.check(ctx)
+	a.check(ctx)
+	if status := a.Status(); status.State != StateInSync || len(status.Replicas) != 2 {
+		t.Fatalf("expected both replicas in sync, got %+v", status)
+	}
+
+	// replica-b picks up a new config; replica-a has not yet.
+	hashB = "hash-2"
+	b.check(ctx)
+	a.check(ctx)
+	status := a.Status()
+	if status.State != StateConverging || status.DivergedSince == nil {
+		t.Fatalf("expected converging inside the grace period, got %+v", status)
+	}
+
+	clock.advance(DefaultGracePeriod)
+	b.check(ctx)
+	a.check(ctx)
+	if status := a.Status(); status.State != StateDiverged {
+		t.Fatalf("expected diverged past the grace period, got %+v", status)
+	}
+
+	hashA = "hash-2"
+	a.check(ctx)
+	if status := a.Status(); status.State != StateInSync || status.DivergedSince != nil {
+		t.Fatalf("expected in sync once the configs match again, got %+v", status)
+	}
+}
+
+func TestTrackerIgnoresReplicasThatStoppedPublishing(t *testing.T) {
+	store := newFakeStore()
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hashA, hashGone := "hash-1", "hash-old"
+	a := newTestTracker(store, clock, "replica-a", &hashA)
+	gone := newTestTracker(store, clock, "replica-gone", &hashGone)
+	ctx := context.Background()
+
+	gone.check(ctx)
+	clock.advance(liveIntervals*DefaultPublishInterval + time.Second)
+	a.check(ctx)
+	status := a.Status()
+	if status.State != StateInSync || len(status.Replicas) != 1 || !status.Replicas[0].Self {
+		t.Fatalf("expected only this replica to count as live, got %+v", status)
+	}
+
+	clock.advance(staleRowAge)
+	a.check(ctx)
+	if _, ok := store.rows["replica-gone"]; ok {
+		t.Fatalf("expected the stale replica row to be deleted")
+	}
+}
+
+func TestTrackerKeepsDivergenceClockAcrossStoreErrors(t *testing.T) {
+	store := newFakeStore()
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hashA, hashB := "hash-1", "hash-2"
+	a := newTestTracker(store, clock, "replica-a", &hashA)
+	b := newTestTracker(store, clock, "replica-b", &hashB)
+	ctx := context.Background()
+
+	b.check(ctx)
+	a.check(ctx)
+	store.readErr = errors.New("connection refused")
+	clock.advance(DefaultPublishInterval)
+	a.check(ctx)
+	if status := a.Status(); status.State != StateUnknown || status.Error == "" {
+		t.Fatalf("expected unknown with an error while the store is unreachable, got %+v", status)
+	}
+
+	store.readErr = nil
+	clock.advance(DefaultGracePeriod)
+	b.check(ctx)
+	a.check(ctx)
+	if status := a.Status(); status.State != StateDiverged || status.Error != "" {
+		t.Fatalf("expected the grace period to keep running across the outage, got %+v", status)
+	}
+}
@@ -0,0 +1,242 @@
+package logstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// DefaultBackfillBatchSize is the number of rows Backfill copies per batch.
+const DefaultBackfillBatchSize = 500
+
+// BackfillCursor is the position of a backfill in one table. Rows are copied
+// in (timestamp, id) order, so the cursor is the last row copied.
+type BackfillCursor struct {
+	Timestamp time.Time `json:"timestamp"`
+	ID        string    `json:"id"`
+	Copied    int64     `json:"copied"`
+	Done      bool      `json:"done"`
+}
+
+// BackfillCheckpoint is the progress of a backfill, saved after every batch
+// so an interrupted backfill resumes where it stopped.
+type BackfillCheckpoint struct {
+	Logs        BackfillCursor `json:"logs"`
+	MCPToolLogs BackfillCursor `json:"mcp_tool_logs"`
+}
+
+// BackfillCheckpointStore persists backfill progress.
+type BackfillCheckpointStore interface {
+	// Load returns the saved checkpoint, or nil when the backfill has not started.
+	Load(ctx context.Context) (*BackfillCheckpoint, error)
+	Save(ctx context.Context, checkpoint *BackfillCheckpoint) error
+}
+
+// FileBackfillCheckpoint stores backfill progress as JSON in a local file.
+type FileBackfillCheckpoint struct {
+	Path string
+}
+
+// Load reads the checkpoint file. A missing file means no progress yet.
+func (f *FileBackfillCheckpoint) Load(_ context.Context) (*BackfillCheckpoint, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backfill checkpoint: %w", err)
+	}
+	var checkpoint BackfillCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse backfill checkpoint %s: %w", f.Path, err)
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint file atomically, so a crash never leaves a torn file.
+func (f *FileBackfillCheckpoint) Save(_ context.Context, checkpoint *BackfillCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write backfill checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write backfill checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write backfill checkpoint: %w", err)
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// BackfillOptions controls a Backfill run.
+type BackfillOptions struct {
+	// BatchSize is the number of rows copied per batch (default DefaultBackfillBatchSize).
+	BatchSize int
+	// Before limits the copy to rows older than it, typically the moment dual
+	// writes started. Zero copies every row; rows already present are skipped.
+	Before time.Time
+	// Pause is slept between batches to limit the load on the source.
+	Pause time.Duration
+	// Checkpoint persists progress after every batch. Nil keeps it in memory only.
+	Checkpoint BackfillCheckpointStore
+}
+
+// Backfill copies the logs and MCP tool logs of source into target in
+// batches, oldest first. It is meant for migrating between backends next to a
+// DualWriteLogStore: writes are idempotent, so rows the dual writer already
+// copied are skipped, and a rerun with the same checkpoint resumes after the
+// last batch saved. Session rollups of the copied logs are refreshed in target.
+//
+// Source must be SQL-backed (SQLite, Postgres or ClickHouse, optionally with
+// object storage). Payloads offloaded to object storage are read back, so
+// target receives full logs.
+func Backfill(ctx context.Context, source, target LogStore, opts BackfillOptions, logger schemas.Logger) (*BackfillCheckpoint, error) {
+	scoped, ok := source.(scopedDBLogStore)
+	if !ok || scoped.ScopedDB(ctx) == nil {
+		return nil, fmt.Errorf("backfill source must be a SQL-backed log store")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBackfillBatchSize
+	}
+	checkpoint := &BackfillCheckpoint{}
+	if opts.Checkpoint != nil {
+		saved, err := opts.Checkpoint.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if saved != nil {
+			checkpoint = saved
+			logger.Info("logstore: resuming backfill after %d logs and %d MCP tool logs", checkpoint.Logs.Copied, checkpoint.MCPToolLogs.Copied)
+		}
+	}
+	save := func() error {
+		if opts.Checkpoint == nil {
+			return nil
+		}
+		if err := opts.Checkpoint.Save(ctx, checkpoint); err != nil {
+			return fmt.Errorf("failed to save backfill checkpoint: %w", err)
+		}
+		return nil
+	}
+
+	for !checkpoint.Logs.Done {
+		var batch []*Log
+		if err := backfillPage(ctx, scoped, &Log{}, &checkpoint.Logs, opts, &batch); err != nil {
+			return checkpoint, fmt.Errorf("failed to read logs: %w", err)
+		}
+		sessions := make(map[string]struct{})
+		for i, log := range batch {
+			if log.HasObject {
+				hydrated, err := source.FindByID(ctx, log.ID)
+				if err != nil {
+					return checkpoint, fmt.Errorf("failed to read log %s: %w", log.ID, err)
+				}
+				batch[i] = hydrated
+			}
+			// The target offloads again when it has object storage of its own
+			batch[i].HasObject = false
+			batch[i].IncNumber = nil
+			if log.ParentRequestID != nil && *log.ParentRequestID != "" {
+				sessions[*log.ParentRequestID] = struct{}{}
+			}
+		}
+		if err := target.BatchCreateIfNotExists(ctx, batch); err != nil {
+			return checkpoint, fmt.Errorf("failed to write logs: %w", err)
+		}
+		if len(sessions) > 0 {
+			ids := make([]string, 0, len(sessions))
+			for id := range sessions {
+				ids = append(ids, id)
+			}
+			if err := target.RefreshSessionRollups(ctx, ids); err != nil {
+				logger.Warn("logstore: backfill failed to refresh %d session rollups: %v", len(ids), err)
+			}
+		}
+		if len(batch) > 0 {
+			last := batch[len(batch)-1]
+			checkpoint.Logs.Timestamp, checkpoint.Logs.ID = last.Timestamp, last.ID
+			checkpoint.Logs.Copied += int64(len(batch))
+		}
+		checkpoint.Logs.Done = len(batch) < opts.BatchSize
+		if err := save(); err != nil {
+			return checkpoint, err
+		}
+		logger.Debug("logstore: backfilled %d logs", checkpoint.Logs.Copied)
+		if err := backfillPause(ctx, opts.Pause, checkpoint.Logs.Done); err != nil {
+			return checkpoint, err
+		}
+	}
+
+	for !checkpoint.MCPToolLogs.Done {
+		var batch []*MCPToolLog
+		if err := backfillPage(ctx, scoped, &MCPToolLog{}, &checkpoint.MCPToolLogs, opts, &batch); err != nil {
+			return checkpoint, fmt.Errorf("failed to read MCP tool logs: %w", err)
+		}
+		for i, log := range batch {
+			if log.HasObject {
+				hydrated, err := source.FindMCPToolLog(ctx, log.ID)
+				if err != nil {
+					return checkpoint, fmt.Errorf("failed to read MCP tool log %s: %w", log.ID, err)
+				}
+				batch[i] = hydrated
+			}
+			batch[i].HasObject = false
+		}
+		if err := target.BatchCreateMCPToolLogsIfNotExists(ctx, batch); err != nil {
+			return checkpoint, fmt.Errorf("failed to write MCP tool logs: %w", err)
+		}
+		if len(batch) > 0 {
+			last := batch[len(batch)-1]
+			checkpoint.MCPToolLogs.Timestamp, checkpoint.MCPToolLogs.ID = last.Timestamp, last.ID
+			checkpoint.MCPToolLogs.Copied += int64(len(batch))
+		}
+		checkpoint.MCPToolLogs.Done = len(batch) < opts.BatchSize
+		if err := save(); err != nil {
+			return checkpoint, err
+		}
+		logger.Debug("logstore: backfilled %d MCP tool logs", checkpoint.MCPToolLogs.Copied)
+		if err := backfillPause(ctx, opts.Pause, checkpoint.MCPToolLogs.Done); err != nil {
+			return checkpoint, err
+		}
+	}
+
+	logger.Info("logstore: backfill complete: %d logs and %d MCP tool logs copied", checkpoint.Logs.Copied, checkpoint.MCPToolLogs.Copied)
+	return checkpoint, nil
+}
+
+// backfillPage reads the next batch of model rows after cursor into dest.
+func backfillPage(ctx context.Context, source scopedDBLogStore, model any, cursor *BackfillCursor, opts BackfillOptions, dest any) error {
+	query := source.ScopedDB(ctx).Model(model)
+	if cursor.ID != "" {
+		query = query.Where("timestamp > ? OR (timestamp = ? AND id > ?)", cursor.Timestamp, cursor.Timestamp, cursor.ID)
+	}
+	if !opts.Before.IsZero() {
+		query = query.Where("timestamp < ?", opts.Before)
+	}
+	return query.Order("timestamp ASC").Order("id ASC").Limit(opts.BatchSize).Find(dest).Error
+}
+
+// backfillPause waits between batches unless the table is done.
+func backfillPause(ctx context.Context, pause time.Duration, done bool) error {
+	if pause <= 0 || done {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(pause):
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package logstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchLogsBYOKFilter verifies spend on tenant-owned BYOK keys can be told
+// apart from spend on Bifrost's own keys, and that the filter skips the matview.
+func TestSearchLogsBYOKFilter(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	cost := 0.01
+
+	for i, byok := range []bool{false, true, true} {
+		entry := &Log{
+			ID:        []string{"shared", "tenant-1", "tenant-2"}[i],
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			Object:    "chat.completion",
+			Provider:  "openai",
+			Model:     "gpt-4o-mini",
+			Status:    "success",
+			Cost:      &cost,
+			BYOK:      byok,
+		}
+		require.NoError(t, store.Create(ctx, entry))
+	}
+
+	tenantOwned := true
+	result, err := store.SearchLogs(ctx, SearchFilters{BYOK: &tenantOwned}, PaginationOptions{Limit: 10, SortBy: "timestamp", Order: "asc"})
+	require.NoError(t, err)
+	require.Len(t, result.Logs, 2)
+	assert.Equal(t, "tenant-1", result.Logs[0].ID)
+	assert.True(t, result.Logs[0].BYOK, "the list projection must carry byok")
+
+	ownKeys := false
+	result, err = store.SearchLogs(ctx, SearchFilters{BYOK: &ownKeys}, PaginationOptions{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Logs, 1)
+	assert.Equal(t, "shared", result.Logs[0].ID)
+
+	assert.False(t, canUseMatViewFilters(SearchFilters{BYOK: &tenantOwned}))
+}
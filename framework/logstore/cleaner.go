@@ -2,7 +2,9 @@ package logstore
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -22,14 +24,106 @@ type LogRetentionManager interface {
 	DeleteLogsBatch(ctx context.Context, cutoff time.Time, batchSize int) (deletedCount int64, err error)
 }
 
+// RetentionClass is a class of logged data with its own retention window.
+type RetentionClass string
+
+const (
+	RetentionClassLogs        RetentionClass = "logs"          // Request log rows
+	RetentionClassMCPToolLogs RetentionClass = "mcp_tool_logs" // MCP tool log rows
+	RetentionClassRawPayloads RetentionClass = "raw_payloads"  // Raw request/response and passthrough bodies of request logs
+)
+
+// RetentionScope selects the rows a retention batch applies to: those of one
+// virtual key when VirtualKeyID is set, otherwise every row except those of
+// ExcludeVirtualKeyIDs, which have windows of their own.
+type RetentionScope struct {
+	VirtualKeyID         string
+	ExcludeVirtualKeyIDs []string
+}
+
+// ClassRetentionManager is implemented by log stores that apply retention per
+// data class and virtual key. ApplyRetentionBatch deletes, or for raw payloads
+// clears, up to batchSize rows of class created before cutoff and returns how
+// many it processed.
+type ClassRetentionManager interface {
+	ApplyRetentionBatch(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) (int64, error)
+}
+
+// SessionRollupRetentionManager is implemented by log stores that keep
+// per-session rollups. Rollups are deleted once their latest activity is past
+// the longest request logs window.
+type SessionRollupRetentionManager interface {
+	DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
+// AuditRetentionManager deletes audit records older than a cutoff in batches.
+// It is implemented by the config store, which holds the audit records.
+type AuditRetentionManager interface {
+	DeleteAuditRecordsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
 // CleanerConfig holds configuration for the log cleaner
 type CleanerConfig struct {
-	RetentionDays int
+	RetentionDays           int                            // Request logs
+	MCPToolLogRetentionDays int                            // MCP tool logs; 0 uses RetentionDays
+	RawPayloadRetentionDays int                            // Raw payloads of request logs; 0 keeps them with the log
+	AuditRetentionDays      int                            // Audit records; 0 keeps them forever
+	VirtualKeyOverrides     map[string]VirtualKeyRetention // Per virtual key ID windows
+}
+
+// VirtualKeyRetention overrides the retention windows of one virtual key's
+// data. Zero values use the global window.
+type VirtualKeyRetention struct {
+	RetentionDays           int
+	MCPToolLogRetentionDays int
+	RawPayloadRetentionDays int
+}
+
+// retentionWindows are the resolved windows, in days, of one scope. Zero
+// means the class is not cleaned.
+type retentionWindows map[RetentionClass]int
+
+// globalWindows resolves the windows of rows without a virtual key override.
+func (c CleanerConfig) globalWindows() retentionWindows {
+	logDays := c.RetentionDays
+	if logDays < 1 {
+		logDays = defaultRetentionDays
+	}
+	mcpDays := c.MCPToolLogRetentionDays
+	if mcpDays < 1 {
+		mcpDays = logDays
+	}
+	return retentionWindows{
+		RetentionClassLogs:        logDays,
+		RetentionClassMCPToolLogs: mcpDays,
+		RetentionClassRawPayloads: max(c.RawPayloadRetentionDays, 0),
+	}
+}
+
+// virtualKeyWindows resolves the windows of one virtual key: its own windows
+// where set, otherwise the global ones. MCP tool logs without a window of
+// their own follow the key's log window.
+func (c CleanerConfig) virtualKeyWindows(override VirtualKeyRetention) retentionWindows {
+	windows := c.globalWindows()
+	if override.RetentionDays > 0 {
+		windows[RetentionClassLogs] = override.RetentionDays
+		if c.MCPToolLogRetentionDays < 1 {
+			windows[RetentionClassMCPToolLogs] = override.RetentionDays
+		}
+	}
+	if override.MCPToolLogRetentionDays > 0 {
+		windows[RetentionClassMCPToolLogs] = override.MCPToolLogRetentionDays
+	}
+	if override.RawPayloadRetentionDays > 0 {
+		windows[RetentionClassRawPayloads] = override.RawPayloadRetentionDays
+	}
+	return windows
 }
 
 // LogsCleaner manages the cleanup of old logs
 type LogsCleaner struct {
 	manager     LogRetentionManager
+	audit       AuditRetentionManager
 	config      CleanerConfig
 	logger      schemas.Logger
 	stopCleanup chan struct{}
@@ -45,6 +139,12 @@ func NewLogsCleaner(manager LogRetentionManager, config CleanerConfig, logger sc
 	}
 }
 
+// SetAuditRetentionManager sets the store whose audit records are cleaned when
+// AuditRetentionDays is set. Call it before StartCleanupRoutine.
+func (c *LogsCleaner) SetAuditRetentionManager(audit AuditRetentionManager) {
+	c.audit = audit
+}
+
 // StartCleanupRoutine starts a goroutine that periodically cleans up old logs
 func (c *LogsCleaner) StartCleanupRoutine() {
 	c.mu.Lock()
@@ -102,55 +202,109 @@ func (c *LogsCleaner) StopCleanupRoutine() {
 	c.stopCleanup = nil
 }
 
-// cleanupOldLogs deletes logs older than the retention period in batches
+// cleanupOldLogs applies the retention window of every data class in batches.
+// Stores that cannot scope retention by class only have their request logs
+// cleaned.
 func (c *LogsCleaner) cleanupOldLogs(ctx context.Context) {
-	retentionDays := c.config.RetentionDays
-	if retentionDays < 1 {
-		retentionDays = defaultRetentionDays
+	now := time.Now().UTC()
+	global := c.config.globalWindows()
+	classManager, scoped := c.manager.(ClassRetentionManager)
+	if !scoped {
+		if len(c.config.VirtualKeyOverrides) > 0 || c.config.MCPToolLogRetentionDays > 0 || c.config.RawPayloadRetentionDays > 0 {
+			c.logger.Warn("log store does not support retention per data class; only request logs are cleaned")
+		}
+		retentionDays := global[RetentionClassLogs]
+		cutoff := now.AddDate(0, 0, -retentionDays)
+		c.logger.Info("starting log cleanup: deleting logs older than %s (retention: %d days)", cutoff.Format(time.RFC3339), retentionDays)
+		c.drain(ctx, "logs", func() (int64, error) {
+			return c.manager.DeleteLogsBatch(ctx, cutoff, batchSize)
+		})
+	} else {
+		overridden := make([]string, 0, len(c.config.VirtualKeyOverrides))
+		for id := range c.config.VirtualKeyOverrides {
+			overridden = append(overridden, id)
+		}
+		sort.Strings(overridden)
+		c.logger.Info("starting log cleanup: retention %d days for logs, %d days for MCP tool logs, %d days for raw payloads (0 = kept with the log), %d virtual key overrides",
+			global[RetentionClassLogs], global[RetentionClassMCPToolLogs], global[RetentionClassRawPayloads], len(overridden))
+		c.cleanScope(ctx, classManager, now, global, RetentionScope{ExcludeVirtualKeyIDs: overridden})
+		for _, id := range overridden {
+			c.cleanScope(ctx, classManager, now, c.config.virtualKeyWindows(c.config.VirtualKeyOverrides[id]), RetentionScope{VirtualKeyID: id})
+		}
 	}
+	if rollups, ok := c.manager.(SessionRollupRetentionManager); ok {
+		// A session can span virtual keys, so rollups follow the longest logs window
+		days := global[RetentionClassLogs]
+		for _, override := range c.config.VirtualKeyOverrides {
+			days = max(days, c.config.virtualKeyWindows(override)[RetentionClassLogs])
+		}
+		cutoff := now.AddDate(0, 0, -days)
+		c.drain(ctx, "session rollups", func() (int64, error) {
+			return rollups.DeleteSessionRollupsBatch(ctx, cutoff, batchSize)
+		})
+	}
+	if c.audit != nil && c.config.AuditRetentionDays > 0 {
+		cutoff := now.AddDate(0, 0, -c.config.AuditRetentionDays)
+		c.drain(ctx, "audit records", func() (int64, error) {
+			return c.audit.DeleteAuditRecordsBatch(ctx, cutoff, batchSize)
+		})
+	}
+}
 
-	// Calculate cutoff time
-	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
-	c.logger.Info("starting log cleanup: deleting logs older than %s (retention: %d days)", cutoff.Format(time.RFC3339), retentionDays)
+// cleanScope applies windows to every data class of scope.
+func (c *LogsCleaner) cleanScope(ctx context.Context, manager ClassRetentionManager, now time.Time, windows retentionWindows, scope RetentionScope) {
+	for _, class := range []RetentionClass{RetentionClassLogs, RetentionClassMCPToolLogs, RetentionClassRawPayloads} {
+		days := windows[class]
+		if days < 1 {
+			continue
+		}
+		cutoff := now.AddDate(0, 0, -days)
+		label := string(class)
+		if scope.VirtualKeyID != "" {
+			label = fmt.Sprintf("%s of virtual key %s", class, scope.VirtualKeyID)
+		}
+		c.drain(ctx, label, func() (int64, error) {
+			return manager.ApplyRetentionBatch(ctx, class, cutoff, scope, batchSize)
+		})
+	}
+}
 
-	totalDeleted := int64(0)
+// drain runs batch until it processes fewer than batchSize rows, fails, or ctx
+// is cancelled.
+func (c *LogsCleaner) drain(ctx context.Context, label string, batch func() (int64, error)) {
+	total := int64(0)
 	batchCount := 0
-
 	for {
 		// Check if context is cancelled
 		select {
 		case <-ctx.Done():
-			c.logger.Warn("log cleanup cancelled: %v", ctx.Err())
+			c.logger.Warn("log cleanup of %s cancelled: %v", label, ctx.Err())
 			return
 		default:
 		}
 
-		// Delete logs in batches using the manager
-		deleted, err := c.manager.DeleteLogsBatch(ctx, cutoff, batchSize)
+		processed, err := batch()
 		if err != nil {
-			c.logger.Error("failed to delete old logs: %v", err)
+			c.logger.Error("failed to clean up old %s: %v", label, err)
 			return
 		}
-
-		if deleted == 0 {
-			// No more logs to delete
+		if processed == 0 {
 			break
 		}
-
-		totalDeleted += deleted
+		total += processed
 		batchCount++
-		c.logger.Debug("deleted batch %d: %d logs", batchCount, deleted)
+		c.logger.Debug("cleaned up batch %d of %s: %d rows", batchCount, label, processed)
 
-		// If we deleted fewer than the batch size, we're done
-		if deleted < int64(batchSize) {
+		// If we processed fewer than the batch size, we're done
+		if processed < int64(batchSize) {
 			break
 		}
 	}
 
-	if totalDeleted > 0 {
-		c.logger.Info("log cleanup completed: deleted %d logs in %d batches", totalDeleted, batchCount)
+	if total > 0 {
+		c.logger.Info("log cleanup of %s completed: %d rows in %d batches", label, total, batchCount)
 	} else {
-		c.logger.Debug("log cleanup completed: no old logs to delete")
+		c.logger.Debug("log cleanup of %s completed: nothing to clean up", label)
 	}
 }
 
@@ -0,0 +1,91 @@
+package logstore
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuditStore struct {
+	cutoffs []time.Time
+}
+
+func (f *fakeAuditStore) DeleteAuditRecordsBatch(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+	f.cutoffs = append(f.cutoffs, cutoff)
+	return 0, nil
+}
+
+func TestCleanerAppliesRetentionPerClassAndVirtualKey(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+	daysAgo := func(days int) time.Time { return time.Now().UTC().AddDate(0, 0, -days) }
+
+	for _, entry := range []*Log{
+		{ID: "default-old", CreatedAt: daysAgo(40)},
+		{ID: "default-raw", CreatedAt: daysAgo(10), RawRequest: `{"a":1}`, RawResponse: `{"b":2}`, PassthroughRequestBody: "body"},
+		{ID: "default-fresh", CreatedAt: daysAgo(1), RawRequest: `{"a":1}`},
+		{ID: "tenant-old", VirtualKeyID: strPtr("vk-tenant"), CreatedAt: daysAgo(40), RawRequest: `{"a":1}`},
+		{ID: "tenant-older", VirtualKeyID: strPtr("vk-tenant"), CreatedAt: daysAgo(400)},
+		{ID: "short-old", VirtualKeyID: strPtr("vk-short"), CreatedAt: daysAgo(5)},
+	} {
+		entry.Timestamp, entry.Object, entry.Provider, entry.Model, entry.Status = entry.CreatedAt, "chat.completion", "openai", "gpt-4o", "success"
+		require.NoError(t, store.Create(ctx, entry))
+	}
+	for _, entry := range []*MCPToolLog{
+		{ID: "mcp-old", CreatedAt: daysAgo(20)},
+		{ID: "mcp-fresh", CreatedAt: daysAgo(5)},
+		{ID: "mcp-tenant", VirtualKeyID: strPtr("vk-tenant"), CreatedAt: daysAgo(20)},
+	} {
+		entry.ToolName, entry.Status = "search_web", "success"
+		require.NoError(t, store.CreateMCPToolLog(ctx, entry))
+	}
+
+	audit := &fakeAuditStore{}
+	cleaner := NewLogsCleaner(store, CleanerConfig{
+		RetentionDays:           30,
+		MCPToolLogRetentionDays: 14,
+		RawPayloadRetentionDays: 7,
+		AuditRetentionDays:      90,
+		VirtualKeyOverrides: map[string]VirtualKeyRetention{
+			"vk-tenant": {RetentionDays: 365, MCPToolLogRetentionDays: 60},
+			"vk-short":  {RetentionDays: 3},
+		},
+	}, testLogger{})
+	cleaner.SetAuditRetentionManager(audit)
+	cleaner.cleanupOldLogs(ctx)
+
+	var logIDs []string
+	require.NoError(t, store.db.Model(&Log{}).Order("id").Pluck("id", &logIDs).Error)
+	assert.Equal(t, []string{"default-fresh", "default-raw", "tenant-old"}, logIDs)
+
+	var mcpIDs []string
+	require.NoError(t, store.db.Model(&MCPToolLog{}).Pluck("id", &mcpIDs).Error)
+	sort.Strings(mcpIDs)
+	assert.Equal(t, []string{"mcp-fresh", "mcp-tenant"}, mcpIDs, "tenant MCP tool logs use their own window")
+
+	raw := func(id string) Log {
+		var log Log
+		require.NoError(t, store.db.Select("raw_request", "raw_response", "passthrough_request_body").Where("id = ?", id).First(&log).Error)
+		return log
+	}
+	assert.Empty(t, raw("default-raw").RawRequest)
+	assert.Empty(t, raw("default-raw").RawResponse)
+	assert.Empty(t, raw("default-raw").PassthroughRequestBody)
+	assert.Empty(t, raw("tenant-old").RawRequest, "raw payload window applies to overridden keys without one")
+	assert.NotEmpty(t, raw("default-fresh").RawRequest)
+
+	require.Len(t, audit.cutoffs, 1)
+	assert.WithinDuration(t, daysAgo(90), audit.cutoffs[0], time.Minute)
+}
+
+func TestCleanerConfigWindows(t *testing.T) {
+	config := CleanerConfig{RetentionDays: 30}
+	assert.Equal(t, retentionWindows{RetentionClassLogs: 30, RetentionClassMCPToolLogs: 30, RetentionClassRawPayloads: 0}, config.globalWindows())
+	assert.Equal(t, retentionWindows{RetentionClassLogs: 90, RetentionClassMCPToolLogs: 90, RetentionClassRawPayloads: 2},
+		config.virtualKeyWindows(VirtualKeyRetention{RetentionDays: 90, RawPayloadRetentionDays: 2}))
+	assert.Equal(t, defaultRetentionDays, CleanerConfig{}.globalWindows()[RetentionClassLogs])
+}
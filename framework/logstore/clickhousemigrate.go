@@ -314,6 +314,21 @@ func migrationClickHouseWebhookDeliveriesTable(ctx context.Context, db *gorm.DB,
 	return clickhouseReconcileColumns(ctx, db, &WebhookDelivery{}, "webhook_deliveries", cluster, logger)
 }
 
+// migrationClickHouseSessionRollupsTable creates the session_rollups table and
+// reconciles it with the SessionRollup struct. Ordering by session_id makes a
+// re-insert of a session's totals replace the previous row; the LogsCleaner
+// prunes rollups whose latest activity is past the logs retention window.
+func migrationClickHouseSessionRollupsTable(ctx context.Context, db *gorm.DB, cluster string, _ int, logger schemas.Logger) error {
+	logger.Info("[logstore] clickhouse: creating table session_rollups")
+	if err := clickhouseCreateTable(ctx, db, &SessionRollup{}, chTableOpts{
+		table:   "session_rollups",
+		orderBy: "session_id",
+	}, cluster); err != nil {
+		return fmt.Errorf("clickhouse: create session_rollups table: %w", err)
+	}
+	return clickhouseReconcileColumns(ctx, db, &SessionRollup{}, "session_rollups", cluster, logger)
+}
+
 // clickhouseMigrationSteps lists the per-table migrations in execution order,
 // mirroring logstoreMigrationSteps for the SQL stores.
 var clickhouseMigrationSteps = []clickhouseMigrationStep{
@@ -321,6 +336,7 @@ var clickhouseMigrationSteps = []clickhouseMigrationStep{
 	migrationClickHouseMCPToolLogsTable,
 	migrationClickHouseAsyncJobsTable,
 	migrationClickHouseWebhookDeliveriesTable,
+	migrationClickHouseSessionRollupsTable,
 }
 
 // triggerClickHouseMigrations runs all registered ClickHouse table migrations
@@ -447,6 +447,37 @@ func (s *ClickHouseLogStore) DeleteLogsBatch(ctx context.Context, cutoff time.Ti
 	return int64(len(ids)), nil
 }
 
+// ApplyRetentionBatch applies retention to one batch. Overridden for the same
+// reason as DeleteLogsBatch, and because clearing raw payloads is an update,
+// which ClickHouse applies as a re-insert of each row.
+func (s *ClickHouseLogStore) ApplyRetentionBatch(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) (int64, error) {
+	ids, err := s.retentionBatchIDs(ctx, class, cutoff, scope, batchSize)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	switch class {
+	case RetentionClassLogs:
+		err = s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Log{}).Error
+	case RetentionClassMCPToolLogs:
+		err = s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&MCPToolLog{}).Error
+	default:
+		for _, id := range ids {
+			cleared := make(map[string]interface{}, len(rawPayloadColumns))
+			for _, column := range rawPayloadColumns {
+				cleared[column] = ""
+			}
+			if err = s.Update(ctx, id, cleared); err != nil && !errors.Is(err, ErrNotFound) {
+				return 0, err
+			}
+		}
+		err = nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
 // DeleteExpiredAsyncJobs deletes async jobs whose expiry has passed.
 // Overridden for the same reason as DeleteLogsBatch: mutation deletes report
 // 0 rows affected, so ids are selected first and their count returned.
@@ -499,6 +530,38 @@ func (s *ClickHouseLogStore) DeleteStaleAsyncJobs(ctx context.Context, staleSinc
 	}
 }
 
+// RefreshSessionRollups recomputes the rollups of sessionIDs and re-inserts
+// them. session_rollups is ordered by session_id, so the newer insert replaces
+// the previous totals (immediately under `final = 1` reads) where the SQL
+// stores need ON CONFLICT DO UPDATE.
+func (s *ClickHouseLogStore) RefreshSessionRollups(ctx context.Context, sessionIDs []string) error {
+	rollups, err := s.computeSessionRollups(ctx, sessionIDs)
+	if err != nil || len(rollups) == 0 {
+		return err
+	}
+	return s.db.WithContext(ctx).Create(&rollups).Error
+}
+
+// DeleteSessionRollupsBatch deletes up to batchSize rollups with no activity
+// since cutoff. Overridden for the same reason as DeleteLogsBatch: mutation
+// deletes report 0 rows affected, so ids are selected first and their count
+// returned.
+func (s *ClickHouseLogStore) DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	var ids []string
+	if err := s.db.WithContext(ctx).Model(&SessionRollup{}).
+		Where("latest_at < ?", cutoff).
+		Limit(batchSize).Pluck("session_id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := s.db.WithContext(ctx).Where("session_id IN ?", ids).Delete(&SessionRollup{}).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
 // DeleteExpiredWebhookDeliveries deletes webhook delivery history whose
 // expiry has passed. Overridden for the same reason as DeleteLogsBatch:
 // mutation deletes report 0 rows affected, so ids are selected first and
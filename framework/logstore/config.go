@@ -19,6 +19,44 @@ type Config struct {
 	// ObjectStorageExcludeFields lists payload field names (DB column names) that
 	// should NOT be offloaded to object storage and instead remain in the database.
 	ObjectStorageExcludeFields []string `json:"object_storage_exclude_fields,omitempty"`
+	// ScrubRules remove secrets from log payloads before the logging plugin persists them.
+	ScrubRules []ScrubRule `json:"scrub_rules,omitempty"`
+	// Shadow mirrors every write to a second backend while migrating to it.
+	Shadow *ShadowConfig `json:"shadow,omitempty"`
+}
+
+// ShadowConfig is a second log store that receives a copy of every write
+// while reads keep coming from the primary. It is used to move between
+// backends, e.g. SQLite to Postgres, together with Backfill for history.
+type ShadowConfig struct {
+	Type   LogStoreType `json:"type"`
+	Config any          `json:"config"`
+	// CompareSampleRate is the fraction (0-1) of reads by ID that are also
+	// read from the shadow and compared. 0 disables the comparison.
+	CompareSampleRate float64 `json:"compare_sample_rate,omitempty"`
+}
+
+// UnmarshalJSON is the custom unmarshal logic for ShadowConfig
+func (c *ShadowConfig) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Type              LogStoreType    `json:"type"`
+		Config            json.RawMessage `json:"config"`
+		CompareSampleRate float64         `json:"compare_sample_rate,omitempty"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return fmt.Errorf("failed to unmarshal shadow logs config: %w", err)
+	}
+	if temp.CompareSampleRate < 0 || temp.CompareSampleRate > 1 {
+		return fmt.Errorf("shadow compare_sample_rate must be between 0 and 1")
+	}
+	config, err := unmarshalBackendConfig(temp.Type, temp.Config)
+	if err != nil {
+		return fmt.Errorf("shadow: %w", err)
+	}
+	c.Type = temp.Type
+	c.Config = config
+	c.CompareSampleRate = temp.CompareSampleRate
+	return nil
 }
 
 const (
@@ -27,6 +65,15 @@ const (
 	DefaultWriterMaxBatchBytes            = 300 * 1024 * 1024
 	DefaultWriterQueueCapacity            = 10000
 	DefaultWriterDeferredUsageConcurrency = 5
+	DefaultWriterBlockTimeout             = "1s"
+)
+
+// Write queue overflow policies, applied when the logging plugin's write queue is full.
+const (
+	WriterOverflowDropNewest = "drop_newest" // drop the entry being enqueued (default; never blocks requests)
+	WriterOverflowDropOldest = "drop_oldest" // evict the oldest queued entry to make room
+	WriterOverflowBlock      = "block"       // wait up to BlockTimeout for room, then drop
+	WriterOverflowSpill      = "spill"       // append to a write-ahead log on disk, replayed when the queue drains
 )
 
 // WriterConfig controls the async logging plugin writer queue and batch flush behavior.
@@ -36,6 +83,9 @@ type WriterConfig struct {
 	MaxBatchBytes            int    `json:"max_batch_bytes,omitempty"`
 	WriteQueueCapacity       int    `json:"write_queue_capacity,omitempty"`
 	DeferredUsageConcurrency int    `json:"deferred_usage_concurrency,omitempty"`
+	OverflowPolicy           string `json:"overflow_policy,omitempty"` // One of the WriterOverflow* policies (default drop_newest)
+	BlockTimeout             string `json:"block_timeout,omitempty"`   // Max wait for queue room under the block policy (default 1s)
+	SpillDir                 string `json:"spill_dir,omitempty"`       // Directory holding the spill write-ahead log; required by the spill policy
 }
 
 // WithDefaults returns a copy of WriterConfig with zero-value fields filled.
@@ -59,9 +109,42 @@ func (c *WriterConfig) WithDefaults() WriterConfig {
 	if out.DeferredUsageConcurrency == 0 {
 		out.DeferredUsageConcurrency = DefaultWriterDeferredUsageConcurrency
 	}
+	if out.OverflowPolicy == "" {
+		out.OverflowPolicy = WriterOverflowDropNewest
+	}
+	if out.BlockTimeout == "" {
+		out.BlockTimeout = DefaultWriterBlockTimeout
+	}
 	return out
 }
 
+// Log payload fields scrub rules apply to.
+const (
+	ScrubFieldInputHistory = "input_history" // the chat and responses input history
+	ScrubFieldRawRequest   = "raw_request"
+	ScrubFieldRawResponse  = "raw_response"
+)
+
+// DefaultScrubReplacement replaces scrubbed values when a rule sets no replacement.
+const DefaultScrubReplacement = "[REDACTED]"
+
+// ScrubRule removes secrets, such as API keys or bearer tokens pasted into a prompt,
+// from log payloads before they are written. A rule with only a Pattern replaces its
+// matches in every string of the payload. A rule with JSONPaths replaces the values at
+// those paths, or only the Pattern matches within them when a Pattern is also set.
+type ScrubRule struct {
+	Name string `json:"name,omitempty"`
+	// Pattern is a Go regular expression, e.g. "AKIA[0-9A-Z]{16}".
+	Pattern string `json:"pattern,omitempty"`
+	// JSONPaths select values of JSON payloads: $, .key, ['key'], [n], [*], .* and
+	// recursive descent (..key), e.g. "$..authorization" or "$[*].content".
+	JSONPaths []string `json:"json_paths,omitempty"`
+	// Replacement defaults to DefaultScrubReplacement.
+	Replacement string `json:"replacement,omitempty"`
+	// Fields are the ScrubField* payloads the rule applies to. Empty = all of them.
+	Fields []string `json:"fields,omitempty"`
+}
+
 // UnmarshalJSON is the custom unmarshal logic for Config
 func (c *Config) UnmarshalJSON(data []byte) error {
 	// First, unmarshal into a temporary struct to get the basic fields
@@ -73,6 +156,8 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 		Writer                     *WriterConfig       `json:"writer,omitempty"`
 		ObjectStorage              *objectstore.Config `json:"object_storage,omitempty"`
 		ObjectStorageExcludeFields []string            `json:"object_storage_exclude_fields,omitempty"`
+		ScrubRules                 []ScrubRule         `json:"scrub_rules,omitempty"`
+		Shadow                     *ShadowConfig       `json:"shadow,omitempty"`
 	}
 
 	var temp TempConfig
@@ -87,37 +172,46 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	c.Writer = temp.Writer
 	c.ObjectStorage = temp.ObjectStorage
 	c.ObjectStorageExcludeFields = temp.ObjectStorageExcludeFields
+	c.ScrubRules = temp.ScrubRules
+	c.Shadow = temp.Shadow
 	if !temp.Enabled {
 		c.Config = nil
 		return nil
 	}
 
-	// Parse the config field based on type
-	switch temp.Type {
+	config, err := unmarshalBackendConfig(temp.Type, temp.Config)
+	if err != nil {
+		return err
+	}
+	c.Config = config
+	return nil
+}
+
+// unmarshalBackendConfig parses the config payload of a log store backend.
+func unmarshalBackendConfig(storeType LogStoreType, data json.RawMessage) (any, error) {
+	switch storeType {
 	case LogStoreTypeSQLite:
-		if len(temp.Config) == 0 {
-			return fmt.Errorf("missing sqlite config payload")
+		if len(data) == 0 {
+			return nil, fmt.Errorf("missing sqlite config payload")
 		}
 		var sqliteConfig SQLiteConfig
-		if err := json.Unmarshal(temp.Config, &sqliteConfig); err != nil {
-			return fmt.Errorf("failed to unmarshal sqlite config: %w", err)
+		if err := json.Unmarshal(data, &sqliteConfig); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sqlite config: %w", err)
 		}
-		c.Config = &sqliteConfig
+		return &sqliteConfig, nil
 	case LogStoreTypePostgres:
 		var postgresConfig PostgresConfig
-		var err error
-		if err = json.Unmarshal(temp.Config, &postgresConfig); err != nil {
-			return fmt.Errorf("failed to unmarshal postgres config: %w", err)
+		if err := json.Unmarshal(data, &postgresConfig); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal postgres config: %w", err)
 		}
-		c.Config = &postgresConfig
+		return &postgresConfig, nil
 	case LogStoreTypeClickHouse:
 		var clickhouseConfig ClickHouseConfig
-		if err := json.Unmarshal(temp.Config, &clickhouseConfig); err != nil {
-			return fmt.Errorf("failed to unmarshal clickhouse config: %w", err)
+		if err := json.Unmarshal(data, &clickhouseConfig); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal clickhouse config: %w", err)
 		}
-		c.Config = &clickhouseConfig
+		return &clickhouseConfig, nil
 	default:
-		return fmt.Errorf("unknown log store type: %s", temp.Type)
+		return nil, fmt.Errorf("unknown log store type: %s", storeType)
 	}
-	return nil
 }
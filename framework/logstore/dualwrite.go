@@ -0,0 +1,375 @@
+package logstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"gorm.io/gorm"
+)
+
+// dualWriteCompareTimeout bounds a sampled read of the shadow store.
+const dualWriteCompareTimeout = 10 * time.Second
+
+// DualWriteStats reports how far the shadow store of a DualWriteLogStore has
+// drifted from the primary.
+type DualWriteStats struct {
+	PrimaryType LogStoreType `json:"primary_type"`
+	ShadowType  LogStoreType `json:"shadow_type"`
+	// ShadowWrites counts writes mirrored to the shadow store.
+	ShadowWrites int64 `json:"shadow_writes"`
+	// ShadowWriteErrors counts failed shadow writes by operation. Each one is a
+	// write the shadow is missing until a backfill copies it.
+	ShadowWriteErrors map[string]int64 `json:"shadow_write_errors"`
+	// Compared counts sampled reads checked against the shadow store.
+	Compared int64 `json:"compared"`
+	// Missing counts sampled logs the shadow store did not have.
+	Missing int64 `json:"missing"`
+	// Mismatched counts sampled logs whose status, usage or cost differed.
+	Mismatched int64 `json:"mismatched"`
+}
+
+// DualWriteLogStore mirrors every write to a shadow store while serving all
+// reads from the primary, so a deployment can move to another backend without
+// losing logs: run both until Backfill has copied the history and the
+// divergence counters stay flat, then make the shadow the primary.
+//
+// Shadow writes run after the primary write succeeds. Their failures are
+// counted and logged but never returned, so the shadow cannot affect requests.
+// A sample of reads by ID is compared against the shadow in the background.
+type DualWriteLogStore struct {
+	LogStore // primary; serves every read
+	shadow   LogStore
+	logger   schemas.Logger
+
+	primaryType       LogStoreType
+	shadowType        LogStoreType
+	compareSampleRate float64
+
+	shadowWrites atomic.Int64
+	compared     atomic.Int64
+	missing      atomic.Int64
+	mismatched   atomic.Int64
+	errorsMu     sync.Mutex
+	shadowErrors map[string]int64
+}
+
+// newDualWriteLogStore creates a DualWriteLogStore. compareSampleRate is the
+// fraction of FindByID reads compared against the shadow; 0 disables it.
+func newDualWriteLogStore(primary, shadow LogStore, primaryType, shadowType LogStoreType, compareSampleRate float64, logger schemas.Logger) *DualWriteLogStore {
+	return &DualWriteLogStore{
+		LogStore:          primary,
+		shadow:            shadow,
+		logger:            logger,
+		primaryType:       primaryType,
+		shadowType:        shadowType,
+		compareSampleRate: compareSampleRate,
+		shadowErrors:      make(map[string]int64),
+	}
+}
+
+// Shadow returns the store writes are mirrored to.
+func (d *DualWriteLogStore) Shadow() LogStore {
+	return d.shadow
+}
+
+// Stats returns the divergence counters since startup.
+func (d *DualWriteLogStore) Stats() DualWriteStats {
+	d.errorsMu.Lock()
+	shadowErrors := make(map[string]int64, len(d.shadowErrors))
+	for op, count := range d.shadowErrors {
+		shadowErrors[op] = count
+	}
+	d.errorsMu.Unlock()
+	return DualWriteStats{
+		PrimaryType:       d.primaryType,
+		ShadowType:        d.shadowType,
+		ShadowWrites:      d.shadowWrites.Load(),
+		ShadowWriteErrors: shadowErrors,
+		Compared:          d.compared.Load(),
+		Missing:           d.missing.Load(),
+		Mismatched:        d.mismatched.Load(),
+	}
+}
+
+// mirror runs a write against the shadow store once the primary has applied
+// it, and returns the primary's error unchanged.
+func (d *DualWriteLogStore) mirror(op string, primaryErr error, write func() error) error {
+	if primaryErr != nil {
+		return primaryErr
+	}
+	d.shadowWrites.Add(1)
+	if err := write(); err != nil {
+		d.errorsMu.Lock()
+		d.shadowErrors[op]++
+		d.errorsMu.Unlock()
+		d.logger.Warn("logstore: shadow %s write %s failed: %v", d.shadowType, op, err)
+	}
+	return nil
+}
+
+// mirrorCount is mirror for writes that report how many rows they affected.
+// The primary's count is returned.
+func (d *DualWriteLogStore) mirrorCount(op string, count int64, primaryErr error, write func() (int64, error)) (int64, error) {
+	return count, d.mirror(op, primaryErr, func() error {
+		_, err := write()
+		return err
+	})
+}
+
+// FindByID reads from the primary and compares a sample of reads against the shadow.
+func (d *DualWriteLogStore) FindByID(ctx context.Context, id string) (*Log, error) {
+	log, err := d.LogStore.FindByID(ctx, id)
+	if err == nil && log != nil && d.compareSampleRate > 0 && rand.Float64() < d.compareSampleRate {
+		go d.compare(log)
+	}
+	return log, err
+}
+
+// compare checks one primary log against its shadow copy.
+func (d *DualWriteLogStore) compare(primary *Log) {
+	ctx, cancel := context.WithTimeout(context.Background(), dualWriteCompareTimeout)
+	defer cancel()
+	shadow, err := d.shadow.FindByID(ctx, primary.ID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		d.logger.Debug("logstore: failed to read log %s from shadow %s: %v", primary.ID, d.shadowType, err)
+		return
+	}
+	d.compared.Add(1)
+	if shadow == nil {
+		d.missing.Add(1)
+		d.logger.Warn("logstore: log %s is missing from shadow %s", primary.ID, d.shadowType)
+		return
+	}
+	if fields := divergentLogFields(primary, shadow); len(fields) > 0 {
+		d.mismatched.Add(1)
+		d.logger.Warn("logstore: log %s differs in shadow %s: %v", primary.ID, d.shadowType, fields)
+	}
+}
+
+// divergentLogFields names the fields that matter for accounting which differ
+// between two copies of a log.
+func divergentLogFields(a, b *Log) []string {
+	var fields []string
+	if a.Status != b.Status {
+		fields = append(fields, "status")
+	}
+	if a.Provider != b.Provider || a.Model != b.Model {
+		fields = append(fields, "model")
+	}
+	if a.TotalTokens != b.TotalTokens {
+		fields = append(fields, "total_tokens")
+	}
+	costA, costB := 0.0, 0.0
+	if a.Cost != nil {
+		costA = *a.Cost
+	}
+	if b.Cost != nil {
+		costB = *b.Cost
+	}
+	if math.Abs(costA-costB) > 1e-9 {
+		fields = append(fields, "cost")
+	}
+	// Backends store timestamps at different precisions
+	if a.Timestamp.UnixMilli() != b.Timestamp.UnixMilli() {
+		fields = append(fields, "timestamp")
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ScopedDB returns the primary's scoped query builder, or nil when the
+// primary is not SQL-backed.
+func (d *DualWriteLogStore) ScopedDB(ctx context.Context) *gorm.DB {
+	if scoped, ok := d.LogStore.(scopedDBLogStore); ok {
+		return scoped.ScopedDB(ctx)
+	}
+	return nil
+}
+
+// ApplyRetentionBatch applies a retention window to both stores independently.
+func (d *DualWriteLogStore) ApplyRetentionBatch(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) (int64, error) {
+	primary, ok := d.LogStore.(ClassRetentionManager)
+	if !ok {
+		return 0, fmt.Errorf("primary log store does not support retention per data class")
+	}
+	count, err := primary.ApplyRetentionBatch(ctx, class, cutoff, scope, batchSize)
+	return d.mirrorCount("apply_retention_batch", count, err, func() (int64, error) {
+		shadow, ok := d.shadow.(ClassRetentionManager)
+		if !ok {
+			return d.shadow.DeleteLogsBatch(ctx, cutoff, batchSize)
+		}
+		return shadow.ApplyRetentionBatch(ctx, class, cutoff, scope, batchSize)
+	})
+}
+
+// Close closes both stores.
+func (d *DualWriteLogStore) Close(ctx context.Context) error {
+	return errors.Join(d.LogStore.Close(ctx), d.shadow.Close(ctx))
+}
+
+// Create writes a log to both stores.
+func (d *DualWriteLogStore) Create(ctx context.Context, entry *Log) error {
+	return d.mirror("create", d.LogStore.Create(ctx, entry), func() error {
+		return d.shadow.Create(ctx, entry)
+	})
+}
+
+// CreateIfNotExists writes a log to both stores unless it already exists.
+func (d *DualWriteLogStore) CreateIfNotExists(ctx context.Context, entry *Log) error {
+	return d.mirror("create_if_not_exists", d.LogStore.CreateIfNotExists(ctx, entry), func() error {
+		return d.shadow.CreateIfNotExists(ctx, entry)
+	})
+}
+
+// BatchCreateIfNotExists writes a batch of logs to both stores.
+func (d *DualWriteLogStore) BatchCreateIfNotExists(ctx context.Context, entries []*Log) error {
+	return d.mirror("batch_create_if_not_exists", d.LogStore.BatchCreateIfNotExists(ctx, entries), func() error {
+		return d.shadow.BatchCreateIfNotExists(ctx, entries)
+	})
+}
+
+// Update updates a log in both stores.
+func (d *DualWriteLogStore) Update(ctx context.Context, id string, entry any) error {
+	return d.mirror("update", d.LogStore.Update(ctx, id, entry), func() error {
+		return d.shadow.Update(ctx, id, entry)
+	})
+}
+
+// BulkUpdateCost updates log costs in both stores.
+func (d *DualWriteLogStore) BulkUpdateCost(ctx context.Context, updates map[string]float64) error {
+	return d.mirror("bulk_update_cost", d.LogStore.BulkUpdateCost(ctx, updates), func() error {
+		return d.shadow.BulkUpdateCost(ctx, updates)
+	})
+}
+
+// Flush removes stale processing logs from both stores.
+func (d *DualWriteLogStore) Flush(ctx context.Context, since time.Time) error {
+	return d.mirror("flush", d.LogStore.Flush(ctx, since), func() error {
+		return d.shadow.Flush(ctx, since)
+	})
+}
+
+// DeleteLog deletes a log from both stores.
+func (d *DualWriteLogStore) DeleteLog(ctx context.Context, id string) error {
+	return d.mirror("delete_log", d.LogStore.DeleteLog(ctx, id), func() error {
+		return d.shadow.DeleteLog(ctx, id)
+	})
+}
+
+// DeleteLogs deletes logs from both stores.
+func (d *DualWriteLogStore) DeleteLogs(ctx context.Context, ids []string) error {
+	return d.mirror("delete_logs", d.LogStore.DeleteLogs(ctx, ids), func() error {
+		return d.shadow.DeleteLogs(ctx, ids)
+	})
+}
+
+// DeleteLogsBatch applies the logs retention cutoff to both stores independently.
+func (d *DualWriteLogStore) DeleteLogsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	count, err := d.LogStore.DeleteLogsBatch(ctx, cutoff, batchSize)
+	return d.mirrorCount("delete_logs_batch", count, err, func() (int64, error) {
+		return d.shadow.DeleteLogsBatch(ctx, cutoff, batchSize)
+	})
+}
+
+// RefreshSessionRollups recomputes session rollups in both stores.
+func (d *DualWriteLogStore) RefreshSessionRollups(ctx context.Context, sessionIDs []string) error {
+	return d.mirror("refresh_session_rollups", d.LogStore.RefreshSessionRollups(ctx, sessionIDs), func() error {
+		return d.shadow.RefreshSessionRollups(ctx, sessionIDs)
+	})
+}
+
+// DeleteSessionRollupsBatch deletes inactive session rollups from both stores.
+func (d *DualWriteLogStore) DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	count, err := d.LogStore.DeleteSessionRollupsBatch(ctx, cutoff, batchSize)
+	return d.mirrorCount("delete_session_rollups_batch", count, err, func() (int64, error) {
+		return d.shadow.DeleteSessionRollupsBatch(ctx, cutoff, batchSize)
+	})
+}
+
+// CreateMCPToolLog writes an MCP tool log to both stores.
+func (d *DualWriteLogStore) CreateMCPToolLog(ctx context.Context, entry *MCPToolLog) error {
+	return d.mirror("create_mcp_tool_log", d.LogStore.CreateMCPToolLog(ctx, entry), func() error {
+		return d.shadow.CreateMCPToolLog(ctx, entry)
+	})
+}
+
+// BatchCreateMCPToolLogsIfNotExists writes a batch of MCP tool logs to both stores.
+func (d *DualWriteLogStore) BatchCreateMCPToolLogsIfNotExists(ctx context.Context, entries []*MCPToolLog) error {
+	return d.mirror("batch_create_mcp_tool_logs", d.LogStore.BatchCreateMCPToolLogsIfNotExists(ctx, entries), func() error {
+		return d.shadow.BatchCreateMCPToolLogsIfNotExists(ctx, entries)
+	})
+}
+
+// UpdateMCPToolLog updates an MCP tool log in both stores.
+func (d *DualWriteLogStore) UpdateMCPToolLog(ctx context.Context, id string, entry any) error {
+	return d.mirror("update_mcp_tool_log", d.LogStore.UpdateMCPToolLog(ctx, id, entry), func() error {
+		return d.shadow.UpdateMCPToolLog(ctx, id, entry)
+	})
+}
+
+// DeleteMCPToolLogs deletes MCP tool logs from both stores.
+func (d *DualWriteLogStore) DeleteMCPToolLogs(ctx context.Context, ids []string) error {
+	return d.mirror("delete_mcp_tool_logs", d.LogStore.DeleteMCPToolLogs(ctx, ids), func() error {
+		return d.shadow.DeleteMCPToolLogs(ctx, ids)
+	})
+}
+
+// FlushMCPToolLogs removes stale processing MCP tool logs from both stores.
+func (d *DualWriteLogStore) FlushMCPToolLogs(ctx context.Context, since time.Time) error {
+	return d.mirror("flush_mcp_tool_logs", d.LogStore.FlushMCPToolLogs(ctx, since), func() error {
+		return d.shadow.FlushMCPToolLogs(ctx, since)
+	})
+}
+
+// CreateAsyncJob writes an async job to both stores.
+func (d *DualWriteLogStore) CreateAsyncJob(ctx context.Context, job *AsyncJob) error {
+	return d.mirror("create_async_job", d.LogStore.CreateAsyncJob(ctx, job), func() error {
+		return d.shadow.CreateAsyncJob(ctx, job)
+	})
+}
+
+// UpdateAsyncJob updates an async job in both stores.
+func (d *DualWriteLogStore) UpdateAsyncJob(ctx context.Context, id string, updates map[string]interface{}) error {
+	return d.mirror("update_async_job", d.LogStore.UpdateAsyncJob(ctx, id, updates), func() error {
+		return d.shadow.UpdateAsyncJob(ctx, id, updates)
+	})
+}
+
+// DeleteExpiredAsyncJobs deletes expired async jobs from both stores.
+func (d *DualWriteLogStore) DeleteExpiredAsyncJobs(ctx context.Context) (int64, error) {
+	count, err := d.LogStore.DeleteExpiredAsyncJobs(ctx)
+	return d.mirrorCount("delete_expired_async_jobs", count, err, func() (int64, error) {
+		return d.shadow.DeleteExpiredAsyncJobs(ctx)
+	})
+}
+
+// DeleteStaleAsyncJobs deletes stale async jobs from both stores.
+func (d *DualWriteLogStore) DeleteStaleAsyncJobs(ctx context.Context, staleSince time.Time) (int64, error) {
+	count, err := d.LogStore.DeleteStaleAsyncJobs(ctx, staleSince)
+	return d.mirrorCount("delete_stale_async_jobs", count, err, func() (int64, error) {
+		return d.shadow.DeleteStaleAsyncJobs(ctx, staleSince)
+	})
+}
+
+// CreateWebhookDelivery writes a webhook delivery to both stores.
+func (d *DualWriteLogStore) CreateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	return d.mirror("create_webhook_delivery", d.LogStore.CreateWebhookDelivery(ctx, delivery), func() error {
+		return d.shadow.CreateWebhookDelivery(ctx, delivery)
+	})
+}
+
+// DeleteExpiredWebhookDeliveries deletes expired webhook deliveries from both stores.
+func (d *DualWriteLogStore) DeleteExpiredWebhookDeliveries(ctx context.Context) (int64, error) {
+	count, err := d.LogStore.DeleteExpiredWebhookDeliveries(ctx)
+	return d.mirrorCount("delete_expired_webhook_deliveries", count, err, func() (int64, error) {
+		return d.shadow.DeleteExpiredWebhookDeliveries(ctx)
+	})
+}
@@ -0,0 +1,174 @@
+package logstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSqliteStore(t *testing.T, name string) LogStore {
+	t.Helper()
+	store, err := newSqliteLogStore(context.Background(), &SQLiteConfig{Path: filepath.Join(t.TempDir(), name)}, hybridTestLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close(context.Background()) })
+	return store
+}
+
+func newTestDualWriteLog(id string, ts time.Time) *Log {
+	return &Log{
+		ID:        id,
+		Timestamp: ts,
+		Provider:  "openai",
+		Model:     "gpt-4o",
+		Status:    "success",
+		Object:    "chat.completion",
+	}
+}
+
+// failingShadowStore rejects every log write.
+type failingShadowStore struct {
+	LogStore
+}
+
+func (failingShadowStore) CreateIfNotExists(context.Context, *Log) error {
+	return errors.New("shadow unavailable")
+}
+
+// cancelOnSave interrupts a backfill once its first checkpoint is saved.
+type cancelOnSave struct {
+	*FileBackfillCheckpoint
+	cancel context.CancelFunc
+}
+
+func (c cancelOnSave) Save(ctx context.Context, checkpoint *BackfillCheckpoint) error {
+	defer c.cancel()
+	return c.FileBackfillCheckpoint.Save(ctx, checkpoint)
+}
+
+func TestDualWrite_MirrorsWritesToShadow(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestSqliteStore(t, "primary.db")
+	shadow := newTestSqliteStore(t, "shadow.db")
+	store := newDualWriteLogStore(primary, shadow, LogStoreTypeSQLite, LogStoreTypeSQLite, 0, hybridTestLogger{})
+
+	require.NoError(t, store.CreateIfNotExists(ctx, newTestDualWriteLog("log-1", time.Now().UTC())))
+	require.NoError(t, store.Update(ctx, "log-1", map[string]any{"status": "error"}))
+
+	for _, s := range []LogStore{primary, shadow} {
+		found, err := s.FindByID(ctx, "log-1")
+		require.NoError(t, err)
+		assert.Equal(t, "error", found.Status)
+	}
+
+	require.NoError(t, store.DeleteLog(ctx, "log-1"))
+	_, err := shadow.FindByID(ctx, "log-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	stats := store.Stats()
+	assert.EqualValues(t, 3, stats.ShadowWrites)
+	assert.Empty(t, stats.ShadowWriteErrors)
+}
+
+func TestDualWrite_ShadowFailureDoesNotFailPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestSqliteStore(t, "primary.db")
+	shadow := failingShadowStore{newTestSqliteStore(t, "shadow.db")}
+	store := newDualWriteLogStore(primary, shadow, LogStoreTypeSQLite, LogStoreTypePostgres, 0, hybridTestLogger{})
+
+	require.NoError(t, store.CreateIfNotExists(ctx, newTestDualWriteLog("log-1", time.Now().UTC())))
+	_, err := primary.FindByID(ctx, "log-1")
+	require.NoError(t, err)
+
+	stats := store.Stats()
+	assert.Equal(t, LogStoreTypePostgres, stats.ShadowType)
+	assert.EqualValues(t, 1, stats.ShadowWrites)
+	assert.EqualValues(t, map[string]int64{"create_if_not_exists": 1}, stats.ShadowWriteErrors)
+}
+
+func TestDualWrite_CompareCountsDivergence(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestSqliteStore(t, "primary.db")
+	shadow := newTestSqliteStore(t, "shadow.db")
+	store := newDualWriteLogStore(primary, shadow, LogStoreTypeSQLite, LogStoreTypeSQLite, 1, hybridTestLogger{})
+
+	now := time.Now().UTC()
+	require.NoError(t, primary.CreateIfNotExists(ctx, newTestDualWriteLog("missing", now)))
+	require.NoError(t, store.CreateIfNotExists(ctx, newTestDualWriteLog("mismatched", now)))
+	require.NoError(t, shadow.Update(ctx, "mismatched", map[string]any{"status": "error"}))
+	require.NoError(t, store.CreateIfNotExists(ctx, newTestDualWriteLog("same", now)))
+
+	found, err := store.FindByID(ctx, "missing")
+	require.NoError(t, err)
+	store.compare(found)
+	found, err = store.FindByID(ctx, "mismatched")
+	require.NoError(t, err)
+	store.compare(found)
+	found, err = store.FindByID(ctx, "same")
+	require.NoError(t, err)
+	store.compare(found)
+
+	// FindByID also compares in the background with a sample rate of 1.
+	require.Eventually(t, func() bool { return store.Stats().Compared == 6 }, 5*time.Second, 10*time.Millisecond)
+	stats := store.Stats()
+	assert.EqualValues(t, 2, stats.Missing)
+	assert.EqualValues(t, 2, stats.Mismatched)
+}
+
+func TestBackfill_ResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	source := newTestSqliteStore(t, "source.db")
+	target := newTestSqliteStore(t, "target.db")
+
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	for i := 0; i < 7; i++ {
+		// Pairs of logs share a timestamp so the cursor has to break ties by ID.
+		require.NoError(t, source.CreateIfNotExists(ctx, newTestDualWriteLog(fmt.Sprintf("log-%d", i), start.Add(time.Duration(i/2)*time.Second))))
+	}
+	// Already copied by the dual writer.
+	require.NoError(t, target.CreateIfNotExists(ctx, newTestDualWriteLog("log-0", start)))
+
+	checkpoints := &FileBackfillCheckpoint{Path: filepath.Join(t.TempDir(), "backfill.json")}
+	interrupted, cancel := context.WithCancel(ctx)
+	// The first run is interrupted after its first batch, leaving a checkpoint behind.
+	_, err := Backfill(interrupted, source, target, BackfillOptions{BatchSize: 3, Pause: time.Minute, Checkpoint: cancelOnSave{checkpoints, cancel}}, hybridTestLogger{})
+	require.ErrorIs(t, err, context.Canceled)
+	saved, err := checkpoints.Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.EqualValues(t, 3, saved.Logs.Copied)
+	assert.Equal(t, "log-2", saved.Logs.ID)
+
+	checkpoint, err := Backfill(ctx, source, target, BackfillOptions{BatchSize: 3, Checkpoint: checkpoints}, hybridTestLogger{})
+	require.NoError(t, err)
+	assert.True(t, checkpoint.Logs.Done)
+	assert.True(t, checkpoint.MCPToolLogs.Done)
+	assert.EqualValues(t, 7, checkpoint.Logs.Copied)
+	for i := 0; i < 7; i++ {
+		_, err := target.FindByID(ctx, fmt.Sprintf("log-%d", i))
+		assert.NoError(t, err, "log-%d", i)
+	}
+}
+
+func TestShadowConfig_Unmarshal(t *testing.T) {
+	var config Config
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"enabled": true,
+		"type": "sqlite",
+		"config": {"path": "logs.db"},
+		"shadow": {"type": "postgres", "config": {"host": "db", "port": "5432", "user": "u", "password": "p", "db_name": "logs", "ssl_mode": "disable"}, "compare_sample_rate": 0.1}
+	}`), &config))
+	require.NotNil(t, config.Shadow)
+	assert.Equal(t, LogStoreTypePostgres, config.Shadow.Type)
+	assert.IsType(t, &PostgresConfig{}, config.Shadow.Config)
+	assert.Equal(t, 0.1, config.Shadow.CompareSampleRate)
+
+	err := json.Unmarshal([]byte(`{"type": "sqlite", "config": {"path": "x.db"}, "compare_sample_rate": 2}`), &ShadowConfig{})
+	assert.Error(t, err)
+}
@@ -529,6 +529,18 @@ func (h *HybridLogStore) DeleteLogsBatch(ctx context.Context, cutoff time.Time,
 	return h.inner.DeleteLogsBatch(ctx, cutoff, batchSize)
 }
 
+// ApplyRetentionBatch delegates to the inner store when it supports retention
+// per data class. As with DeleteLogsBatch, object-store entries are left to the
+// bucket's lifecycle policy, so raw payloads offloaded to object storage are
+// not cleared here.
+func (h *HybridLogStore) ApplyRetentionBatch(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) (int64, error) {
+	inner, ok := h.inner.(ClassRetentionManager)
+	if !ok {
+		return 0, fmt.Errorf("inner log store does not support retention per data class")
+	}
+	return inner.ApplyRetentionBatch(ctx, class, cutoff, scope, batchSize)
+}
+
 // Close shuts the store down cleanly: marks the store closed (so further
 // enqueues are dropped), closes the upload queue, waits for workers to drain
 // any in-flight uploads, then closes the object store and the inner store.
@@ -648,6 +660,22 @@ func (h *HybridLogStore) GetSessionSummary(ctx context.Context, sessionID string
 	return h.inner.GetSessionSummary(ctx, sessionID)
 }
 
+// RefreshSessionRollups delegates to the inner store; rollups carry totals
+// only, never offloadable payloads.
+func (h *HybridLogStore) RefreshSessionRollups(ctx context.Context, sessionIDs []string) error {
+	return h.inner.RefreshSessionRollups(ctx, sessionIDs)
+}
+
+// SearchSessionRollups delegates to the inner store.
+func (h *HybridLogStore) SearchSessionRollups(ctx context.Context, filters SessionRollupSearchFilters, pagination PaginationOptions) (*SessionRollupSearchResult, error) {
+	return h.inner.SearchSessionRollups(ctx, filters, pagination)
+}
+
+// DeleteSessionRollupsBatch delegates to the inner store.
+func (h *HybridLogStore) DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	return h.inner.DeleteSessionRollupsBatch(ctx, cutoff, batchSize)
+}
+
 // GetStats delegates to the inner store and returns aggregate statistics for
 // the matching log rows.
 func (h *HybridLogStore) GetStats(ctx context.Context, filters SearchFilters) (*SearchStats, error) {
@@ -720,6 +748,12 @@ func (h *HybridLogStore) GetModelRankings(ctx context.Context, filters SearchFil
 	return h.inner.GetModelRankings(ctx, filters)
 }
 
+// GetModelUsageProfiles delegates to the inner store and returns per-model
+// usage profiles for the matching log rows.
+func (h *HybridLogStore) GetModelUsageProfiles(ctx context.Context, filters SearchFilters, shortPromptTokens int) (*ModelUsageProfileResult, error) {
+	return h.inner.GetModelUsageProfiles(ctx, filters, shortPromptTokens)
+}
+
 // GetUserRankings delegates to the inner store and returns ranked usage
 // aggregates per user for the matching log rows.
 func (h *HybridLogStore) GetUserRankings(ctx context.Context, filters SearchFilters) (*UserRankingResult, error) {
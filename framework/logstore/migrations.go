@@ -280,6 +280,10 @@ var logstoreMigrationSteps = []migrationStep{
 	{IDs: []string{"webhook_deliveries_add_request_id_column"}, run: migrationAddWebhookDeliveryRequestIDColumn},
 	{IDs: []string{"logs_add_content_hidden_column"}, run: migrationAddContentHiddenColumn},
 	{IDs: []string{"logs_add_server_side_fallback_model_column"}, run: migrationAddServerSideFallbackModelColumn},
+	{IDs: []string{"logs_add_deployment_metadata_columns"}, run: migrationAddDeploymentMetadataColumns},
+	{IDs: []string{"session_rollups_init"}, run: migrationCreateSessionRollupsTable},
+	{IDs: []string{"logs_add_reproducibility_columns"}, run: migrationAddReproducibilityColumns},
+	{IDs: []string{"logs_add_byok_column"}, run: migrationAddBYOKColumn},
 }
 
 // areThereAnyPendingMigrations returns true if there are any pending migrations to be applied.
@@ -3793,3 +3797,150 @@ func migrationAddServerSideFallbackModelColumn(ctx context.Context, db *gorm.DB,
 	}
 	return nil
 }
+
+// migrationAddDeploymentMetadataColumns adds the region and instance_id columns
+// to the logs table, recording which deployment served each request.
+func migrationAddDeploymentMetadataColumns(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "logs_add_deployment_metadata_columns"
+	logger.Info("[logstore] starting migration %s", migrationName)
+	defer logger.Info("[logstore] finished migration %s", migrationName)
+	opts := *migrator.DefaultOptions
+	opts.UseTransaction = true
+	m := migrator.New(db, &opts, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"region", "instance_id"} {
+				if err := addColumnIfNotExists(tx, logger, &Log{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"region", "instance_id"} {
+				if err := dropColumnIfExists(tx, logger, &Log{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error while adding deployment metadata columns: %s", err.Error())
+	}
+	return nil
+}
+
+// migrationCreateSessionRollupsTable creates the session_rollups table and its
+// indexes if missing. Rollups are filled as session logs are written, so
+// sessions logged before this migration appear once they receive new entries.
+func migrationCreateSessionRollupsTable(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "session_rollups_init"
+	logger.Info("[logstore] starting migration %s", migrationName)
+	defer logger.Info("[logstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			dbMigrator := tx.Migrator()
+			if !dbMigrator.HasTable(&SessionRollup{}) {
+				logger.Info("[logstore] %s: creating table SessionRollup", migrationName)
+				if err := dbMigrator.CreateTable(&SessionRollup{}); err != nil {
+					return err
+				}
+			}
+
+			// Explicitly create indexes as declared in struct tags
+			for _, index := range []string{
+				"idx_session_rollups_total_tokens",
+				"idx_session_rollups_total_cost",
+				"idx_session_rollups_latest_at",
+			} {
+				if !dbMigrator.HasIndex(&SessionRollup{}, index) {
+					logger.Info("[logstore] %s: creating index %s on SessionRollup", migrationName, index)
+					if err := dbMigrator.CreateIndex(&SessionRollup{}, index); err != nil {
+						return fmt.Errorf("failed to create index %s: %w", index, err)
+					}
+				}
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			logger.Info("[logstore] %s: dropping table SessionRollup", migrationName)
+			return tx.Migrator().DropTable(&SessionRollup{})
+		},
+	}})
+	err := m.Migrate()
+	if err != nil {
+		return fmt.Errorf("error while creating session_rollups table: %s", err.Error())
+	}
+	return nil
+}
+
+// migrationAddReproducibilityColumns adds the system_fingerprint, model_revision
+// and reproducibility columns to the logs table.
+func migrationAddReproducibilityColumns(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "logs_add_reproducibility_columns"
+	logger.Info("[logstore] starting migration %s", migrationName)
+	defer logger.Info("[logstore] finished migration %s", migrationName)
+	opts := *migrator.DefaultOptions
+	opts.UseTransaction = true
+	m := migrator.New(db, &opts, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"system_fingerprint", "model_revision", "reproducibility"} {
+				if err := addColumnIfNotExists(tx, logger, &Log{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			for _, column := range []string{"system_fingerprint", "model_revision", "reproducibility"} {
+				if err := dropColumnIfExists(tx, logger, &Log{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error while adding reproducibility columns: %s", err.Error())
+	}
+	return nil
+}
+
+// migrationAddBYOKColumn adds the byok boolean column to the logs table. Existing
+// rows default to false, i.e. spend on Bifrost's own keys.
+func migrationAddBYOKColumn(ctx context.Context, db *gorm.DB, logger schemas.Logger) error {
+	migrationName := "logs_add_byok_column"
+	logger.Info("[logstore] starting migration %s", migrationName)
+	defer logger.Info("[logstore] finished migration %s", migrationName)
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: migrationName,
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := addColumnIfNotExists(tx, logger, &Log{}, "byok"); err != nil {
+				return fmt.Errorf("failed to add byok column: %w", err)
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			if err := dropColumnIfExists(tx, logger, &Log{}, "byok"); err != nil {
+				return fmt.Errorf("failed to drop byok column: %w", err)
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error while adding byok column: %w", err)
+	}
+	return nil
+}
@@ -50,6 +50,7 @@ var payloadFields = []string{
 	"passthrough_request_body",
 	"passthrough_response_body",
 	"routing_engine_logs",
+	"reproducibility",
 }
 
 // ExtractPayload reads the serialized TEXT payload fields from a Log into a map.
@@ -90,6 +91,7 @@ func ExtractPayload(l *Log) map[string]string {
 	m["passthrough_request_body"] = l.PassthroughRequestBody
 	m["passthrough_response_body"] = l.PassthroughResponseBody
 	m["routing_engine_logs"] = l.RoutingEngineLogs
+	m["reproducibility"] = l.Reproducibility
 	// Metadata is written to the snapshot so consumers reading objects
 	// directly see custom attributes, but it is deliberately NOT part of
 	// payloadFields: it must always stay DB-resident as well (filters,
@@ -143,6 +145,7 @@ func ClearPayload(l *Log) {
 	l.PassthroughRequestBody = ""
 	l.PassthroughResponseBody = ""
 	l.RoutingEngineLogs = ""
+	l.Reproducibility = ""
 
 	// Clear Parsed virtual fields so GORM's SerializeFields won't re-serialize them.
 	l.InputHistoryParsed = nil
@@ -174,6 +177,7 @@ func ClearPayload(l *Log) {
 	l.CacheDebugParsed = nil
 	l.TokenUsageParsed = nil
 	l.ErrorDetailsParsed = nil
+	l.ReproducibilityParsed = nil
 }
 
 // MergePayloadFromJSON takes a JSON payload (as marshaled by MarshalPayload)
@@ -286,6 +290,9 @@ func MergePayloadFromJSON(l *Log, data []byte) error {
 	if v, ok := m["routing_engine_logs"]; ok && v != "" {
 		l.RoutingEngineLogs = v
 	}
+	if v, ok := m["reproducibility"]; ok && v != "" {
+		l.Reproducibility = v
+	}
 	// Metadata is intentionally NOT restored from the snapshot: the copy
 	// written there (see ExtractPayload) is for external object consumers
 	// only, and the DB row stays authoritative.
@@ -834,6 +841,9 @@ func clearPayloadField(l *Log, name string) {
 		l.PassthroughResponseBody = ""
 	case "routing_engine_logs":
 		l.RoutingEngineLogs = ""
+	case "reproducibility":
+		l.Reproducibility = ""
+		l.ReproducibilityParsed = nil
 	}
 }
 
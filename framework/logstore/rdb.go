@@ -46,11 +46,13 @@ const (
 
 var terminalLogStatuses = []string{"success", "error", "cancelled"}
 
-// nonTerminalLogStatuses are the in-flight statuses structurally absent from
-// mv_logs_hourly (its DDL filters to terminalLogStatuses). Matview-backed
-// counts add these back from the raw table so totals match the row list and
-// the raw aggregate paths, which do not exclude in-flight rows.
-var nonTerminalLogStatuses = []string{"processing"}
+// nonTerminalLogStatuses are the statuses structurally absent from
+// mv_logs_hourly (its DDL filters to terminalLogStatuses): in-flight rows and
+// "aborted" rows written for requests that never reached PostLLMHook, which
+// carry no output, tokens or cost. Matview-backed counts add these back from
+// the raw table so totals match the row list and the raw aggregate paths,
+// which do not exclude them.
+var nonTerminalLogStatuses = []string{"processing", "aborted"}
 
 // RDBLogStore represents a log store that uses a SQLite database.
 type RDBLogStore struct {
@@ -60,6 +62,8 @@ type RDBLogStore struct {
 	// Self-heal state for the matview read path (see matviewheal.go).
 	matViewHealInFlight    atomic.Bool
 	matViewHealLastAttempt atomic.Int64 // unix nanos of the last repair attempt
+	// stopCheckpoints stops the periodic WAL checkpoints of a SQLite store; nil otherwise.
+	stopCheckpoints func()
 }
 
 // generateBucketTimestamps generates all bucket timestamps for a time range.
@@ -332,6 +336,9 @@ func (s *RDBLogStore) applyFilters(baseQuery *gorm.DB, filters SearchFilters) *g
 		// cost is null and status is not error
 		baseQuery = baseQuery.Where("(cost IS NULL OR cost <= 0) AND status NOT IN ('error')")
 	}
+	if filters.BYOK != nil {
+		baseQuery = baseQuery.Where("byok = ?", *filters.BYOK)
+	}
 	if len(filters.CacheHitTypes) > 0 {
 		// Only keep allowed values to avoid passing arbitrary input into the JSON path expression.
 		valid := make([]string, 0, len(filters.CacheHitTypes))
@@ -897,6 +904,180 @@ func (s *RDBLogStore) GetSessionSummary(ctx context.Context, sessionID string) (
 	}, nil
 }
 
+// sessionRollupSortColumns maps the sort_by values accepted for session
+// rollups to their columns.
+var sessionRollupSortColumns = map[string]string{
+	"cost":            "total_cost",
+	"tokens":          "total_tokens",
+	"turns":           "turn_count",
+	"tool_executions": "tool_executions",
+	"started_at":      "started_at",
+	"latest_at":       "latest_at",
+}
+
+// computeSessionRollups aggregates the current totals of sessionIDs from the
+// logs and MCP tool logs. Sessions without logs are omitted.
+func (s *RDBLogStore) computeSessionRollups(ctx context.Context, sessionIDs []string) ([]SessionRollup, error) {
+	ids := make([]string, 0, len(sessionIDs))
+	seen := make(map[string]struct{}, len(sessionIDs))
+	for _, id := range sessionIDs {
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.WithContext(ctx).Model(&Log{}).
+		Select("parent_request_id, COUNT(*), SUM(CASE WHEN fallback_index = 0 THEN 1 ELSE 0 END), COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(total_tokens), 0), COALESCE(SUM(cost), 0), MIN(timestamp), MAX(timestamp)").
+		Where("parent_request_id IN ?", ids).
+		Group("parent_request_id").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	rollups := make([]SessionRollup, 0, len(ids))
+	index := make(map[string]int, len(ids))
+	for rows.Next() {
+		var (
+			rollup     SessionRollup
+			startedRaw any
+			latestRaw  any
+		)
+		if err := rows.Scan(&rollup.SessionID, &rollup.RequestCount, &rollup.TurnCount, &rollup.PromptTokens, &rollup.CompletionTokens, &rollup.TotalTokens, &rollup.TotalCost, &startedRaw, &latestRaw); err != nil {
+			return nil, err
+		}
+		if startedAt, err := time.Parse(time.RFC3339Nano, normalizeAggregateTimestamp(startedRaw)); err == nil {
+			rollup.StartedAt = startedAt
+		}
+		if latestAt, err := time.Parse(time.RFC3339Nano, normalizeAggregateTimestamp(latestRaw)); err == nil {
+			rollup.LatestAt = latestAt
+		}
+		rollup.UpdatedAt = now
+		index[rollup.SessionID] = len(rollups)
+		rollups = append(rollups, rollup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(rollups) == 0 {
+		return nil, nil
+	}
+
+	var toolCounts []struct {
+		SessionID string
+		Count     int64
+	}
+	if err := s.db.WithContext(ctx).Table("mcp_tool_logs").
+		Select("logs.parent_request_id AS session_id, COUNT(*) AS count").
+		Joins("JOIN logs ON logs.id = mcp_tool_logs.llm_request_id").
+		Where("logs.parent_request_id IN ?", ids).
+		Group("logs.parent_request_id").
+		Scan(&toolCounts).Error; err != nil {
+		return nil, err
+	}
+	for _, toolCount := range toolCounts {
+		if i, ok := index[toolCount.SessionID]; ok {
+			rollups[i].ToolExecutions = toolCount.Count
+		}
+	}
+	return rollups, nil
+}
+
+// RefreshSessionRollups recomputes the rollups of sessionIDs from their logs
+// and upserts them. Recomputing rather than incrementing keeps the totals
+// right when a log is written more than once or updated after insert.
+func (s *RDBLogStore) RefreshSessionRollups(ctx context.Context, sessionIDs []string) error {
+	rollups, err := s.computeSessionRollups(ctx, sessionIDs)
+	if err != nil || len(rollups) == 0 {
+		return err
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}},
+		UpdateAll: true,
+	}).Create(&rollups).Error
+}
+
+// SearchSessionRollups returns one page of session rollups whose latest
+// activity falls in the filter window. pagination.SortBy is one of cost,
+// tokens, turns, tool_executions, started_at or latest_at (the default).
+// When a query scope is set, only sessions with logs visible in it are listed.
+func (s *RDBLogStore) SearchSessionRollups(ctx context.Context, filters SessionRollupSearchFilters, pagination PaginationOptions) (*SessionRollupSearchResult, error) {
+	limit := pagination.Limit
+	if limit <= 0 || limit > defaultMaxSearchLimit {
+		limit = defaultMaxSearchLimit
+	}
+	sortColumn, ok := sessionRollupSortColumns[pagination.SortBy]
+	if !ok {
+		sortColumn = "latest_at"
+		pagination.SortBy = "latest_at"
+	}
+	order := "DESC"
+	if pagination.Order == "asc" {
+		order = "ASC"
+	} else {
+		pagination.Order = "desc"
+	}
+
+	query := s.db.WithContext(ctx).Model(&SessionRollup{})
+	if queryscope.FromContext(ctx) != nil {
+		query = query.Where("session_id IN (?)", s.ScopedDB(ctx).Model(&Log{}).Select("parent_request_id").Where("parent_request_id IS NOT NULL"))
+	}
+	if filters.StartTime != nil {
+		query = query.Where("latest_at >= ?", *filters.StartTime)
+	}
+	if filters.EndTime != nil {
+		query = query.Where("latest_at <= ?", *filters.EndTime)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	sessions := []SessionRollup{}
+	pageQuery := query.Order(fmt.Sprintf("%s %s, session_id %s", sortColumn, order, order)).Limit(limit)
+	if pagination.Offset > 0 {
+		pageQuery = pageQuery.Offset(pagination.Offset)
+	}
+	if err := pageQuery.Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	result := &SessionRollupSearchResult{
+		Sessions:   sessions,
+		Pagination: pagination,
+	}
+	result.Pagination.Limit = limit
+	result.Pagination.TotalCount = total
+	return result, nil
+}
+
+// DeleteSessionRollupsBatch deletes up to batchSize rollups of sessions with
+// no activity since cutoff.
+func (s *RDBLogStore) DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	var ids []string
+	if err := s.db.WithContext(ctx).
+		Model(&SessionRollup{}).
+		Where("latest_at < ?", cutoff).
+		Limit(batchSize).
+		Pluck("session_id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := s.db.WithContext(ctx).Where("session_id IN ?", ids).Delete(&SessionRollup{})
+	return result.RowsAffected, result.Error
+}
+
 func normalizeAggregateTimestamp(value any) string {
 	switch v := value.(type) {
 	case nil:
@@ -942,7 +1123,7 @@ func (s *RDBLogStore) listSelectColumns() string {
 		"id", "parent_request_id", "timestamp", "object_type", "provider", "model", "alias",
 		"canonical_model_name", "alias_model_family", "server_side_fallback_model",
 		"number_of_retries", "fallback_index",
-		"selected_key_id", "selected_key_name",
+		"selected_key_id", "selected_key_name", "byok",
 		"virtual_key_id", "virtual_key_name",
 		"routing_engines_used", "routing_rule_id", "routing_rule_name",
 		"user_id", "user_name", "team_id", "team_name", "customer_id", "customer_name",
@@ -2288,6 +2469,40 @@ func (s *RDBLogStore) GetModelRankings(ctx context.Context, filters SearchFilter
 	return &ModelRankingResult{Rankings: rankings}, nil
 }
 
+// GetModelUsageProfiles returns, per (model, provider), the prompt-size and stop-reason
+// shape of successful requests, ordered by spend. Always reads the raw table: the hourly
+// matview does not carry per-row prompt sizes.
+func (s *RDBLogStore) GetModelUsageProfiles(ctx context.Context, filters SearchFilters, shortPromptTokens int) (*ModelUsageProfileResult, error) {
+	query := s.ScopedDB(ctx).Model(&Log{})
+	query = s.applyFilters(query, filters)
+	query = query.Where("status = ?", "success")
+	query = query.Where("model IS NOT NULL AND model != ''")
+
+	var rows []ModelUsageProfile
+	if err := query.
+		Select(`
+			model,
+			provider,
+			COUNT(*) as total_requests,
+			COALESCE(SUM(cost), 0) as total_cost,
+			COALESCE(SUM(prompt_tokens), 0) as prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) as completion_tokens,
+			SUM(CASE WHEN prompt_tokens <= ? THEN 1 ELSE 0 END) as short_prompt_requests,
+			COALESCE(SUM(CASE WHEN prompt_tokens <= ? THEN cost ELSE 0 END), 0) as short_prompt_cost,
+			COALESCE(SUM(CASE WHEN prompt_tokens <= ? THEN prompt_tokens ELSE 0 END), 0) as short_prompt_tokens,
+			COALESCE(SUM(CASE WHEN prompt_tokens <= ? THEN completion_tokens ELSE 0 END), 0) as short_completion_tokens,
+			SUM(CASE WHEN stop_reason = 'length' THEN 1 ELSE 0 END) as length_stopped_requests,
+			SUM(CASE WHEN stop_reason = 'tool_calls' THEN 1 ELSE 0 END) as tool_call_stopped_requests
+		`, shortPromptTokens, shortPromptTokens, shortPromptTokens, shortPromptTokens).
+		Group("model, provider").
+		Order("total_cost DESC, model ASC, provider ASC").
+		Limit(defaultMaxRankingsLimit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get model usage profiles: %w", err)
+	}
+	return &ModelUsageProfileResult{Profiles: rows}, nil
+}
+
 // GetUserRankings returns users ranked by usage with trend comparison to the previous period.
 // Uses the same fresh-aggregate matview gate as GetStats: short windows go to
 // the raw table because mv_logs_hourly rounds the window out to full hour
@@ -3904,8 +4119,70 @@ func (s *RDBLogStore) DeleteLogsBatch(ctx context.Context, cutoff time.Time, bat
 	return result.RowsAffected, nil
 }
 
+// rawPayloadColumns are the log columns cleared by raw payload retention.
+var rawPayloadColumns = []string{"raw_request", "raw_response", "passthrough_request_body", "passthrough_response_body"}
+
+// retentionBatchIDs selects the ids of up to batchSize rows of class in scope
+// created before cutoff, oldest first. Raw payload batches only select logs
+// that still hold a raw payload.
+func (s *RDBLogStore) retentionBatchIDs(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) ([]string, error) {
+	var model any
+	switch class {
+	case RetentionClassLogs, RetentionClassRawPayloads:
+		model = &Log{}
+	case RetentionClassMCPToolLogs:
+		model = &MCPToolLog{}
+	default:
+		return nil, fmt.Errorf("unknown retention class %q", class)
+	}
+	query := s.db.WithContext(ctx).Model(model).Select("id").Where("created_at < ?", cutoff)
+	if scope.VirtualKeyID != "" {
+		query = query.Where("virtual_key_id = ?", scope.VirtualKeyID)
+	} else if len(scope.ExcludeVirtualKeyIDs) > 0 {
+		query = query.Where("(virtual_key_id IS NULL OR virtual_key_id NOT IN ?)", scope.ExcludeVirtualKeyIDs)
+	}
+	if class == RetentionClassRawPayloads {
+		conditions := make([]string, len(rawPayloadColumns))
+		for i, column := range rawPayloadColumns {
+			conditions[i] = column + " <> ''"
+		}
+		query = query.Where("(" + strings.Join(conditions, " OR ") + ")")
+	}
+	var ids []string
+	if err := query.Order("created_at ASC").Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ApplyRetentionBatch deletes up to batchSize request or MCP tool logs in scope
+// created before cutoff, or clears the raw payloads of as many request logs.
+func (s *RDBLogStore) ApplyRetentionBatch(ctx context.Context, class RetentionClass, cutoff time.Time, scope RetentionScope, batchSize int) (int64, error) {
+	ids, err := s.retentionBatchIDs(ctx, class, cutoff, scope, batchSize)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	var result *gorm.DB
+	switch class {
+	case RetentionClassLogs:
+		result = s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Log{})
+	case RetentionClassMCPToolLogs:
+		result = s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&MCPToolLog{})
+	default:
+		cleared := make(map[string]any, len(rawPayloadColumns))
+		for _, column := range rawPayloadColumns {
+			cleared[column] = ""
+		}
+		result = s.db.WithContext(ctx).Model(&Log{}).Where("id IN ?", ids).UpdateColumns(cleared)
+	}
+	return result.RowsAffected, result.Error
+}
+
 // Close closes the log store.
 func (s *RDBLogStore) Close(ctx context.Context) error {
+	if s.stopCheckpoints != nil {
+		s.stopCheckpoints()
+	}
 	sqlDB, err := s.db.WithContext(ctx).DB()
 	if err != nil {
 		return err
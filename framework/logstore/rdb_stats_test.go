@@ -56,3 +56,60 @@ func TestGetStatsTokenSplit(t *testing.T) {
 	require.Equal(t, int64(70), stats.CompletionTokens, "completion = 10+20+40")
 	require.Equal(t, stats.TotalTokens, stats.PromptTokens+stats.CompletionTokens, "split sums to total")
 }
+
+// GetModelUsageProfiles splits each route's successful traffic into short and
+// long prompts and counts how generations ended.
+func TestGetModelUsageProfiles(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Log{}))
+
+	s := &RDBLogStore{db: db, logger: bifrost.NewDefaultLogger(schemas.LogLevelInfo)}
+	now := time.Now()
+	length, toolCalls := "length", "tool_calls"
+
+	seed := []struct {
+		id, model          string
+		prompt, completion int
+		cost               float64
+		status             string
+		stopReason         *string
+	}{
+		{"a", "gpt-4o", 100, 10, 0.5, "success", nil},
+		{"b", "gpt-4o", 200, 20, 1.0, "success", &length},
+		{"c", "gpt-4o", 5000, 50, 4.0, "success", &toolCalls},
+		{"d", "gpt-4o", 100, 10, 9.0, "error", nil}, // failed requests are not profiled
+		{"e", "gpt-4o-mini", 100, 10, 0.1, "success", nil},
+	}
+	for _, sd := range seed {
+		cost := sd.cost
+		require.NoError(t, db.Create(&Log{
+			ID:               sd.id,
+			Timestamp:        now,
+			Provider:         "openai",
+			Model:            sd.model,
+			Status:           sd.status,
+			PromptTokens:     sd.prompt,
+			CompletionTokens: sd.completion,
+			Cost:             &cost,
+			StopReason:       sd.stopReason,
+		}).Error)
+	}
+
+	result, err := s.GetModelUsageProfiles(context.Background(), SearchFilters{}, 1000)
+	require.NoError(t, err)
+	require.Len(t, result.Profiles, 2)
+
+	profile := result.Profiles[0] // ordered by spend
+	require.Equal(t, "gpt-4o", profile.Model)
+	require.Equal(t, int64(3), profile.TotalRequests)
+	require.InDelta(t, 5.5, profile.TotalCost, 1e-9)
+	require.Equal(t, int64(2), profile.ShortPromptRequests)
+	require.InDelta(t, 1.5, profile.ShortPromptCost, 1e-9)
+	require.Equal(t, int64(300), profile.ShortPromptTokens)
+	require.Equal(t, int64(30), profile.ShortCompletionTokens)
+	require.Equal(t, int64(1), profile.LengthStoppedRequests)
+	require.Equal(t, int64(1), profile.ToolCallStoppedRequests)
+}
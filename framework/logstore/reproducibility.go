@@ -0,0 +1,32 @@
+package logstore
+
+// ReproducibilityBundle is the configuration a request ran with, recorded by
+// the logging plugin so the exact request can be rerun later. It is stored as
+// a payload field, so it is offloaded and hidden together with the rest of the
+// request content.
+type ReproducibilityBundle struct {
+	Provider       string `json:"provider"`
+	RequestedModel string `json:"requested_model,omitempty"` // Model the caller asked for, when an alias resolved it to another
+	ResolvedModel  string `json:"resolved_model"`            // Model the request was sent to
+	// ModelRevision and SystemFingerprint identify the backend that served the
+	// request, as reported by the provider. Reruns match only while they hold.
+	ModelRevision     string `json:"model_revision,omitempty"`
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+	FallbackIndex     int    `json:"fallback_index"`
+
+	Seed   *int `json:"seed,omitempty"`
+	Params any  `json:"params,omitempty"` // Request parameters as sent, after presets were applied
+
+	// SystemPromptInjections are the system prompts plugins added to the request,
+	// in the order they were applied.
+	SystemPromptInjections []string                       `json:"system_prompt_injections,omitempty"`
+	PromptTemplate         *ReproducibilityPromptTemplate `json:"prompt_template,omitempty"`
+}
+
+// ReproducibilityPromptTemplate identifies the prompt template version a
+// request was rendered from.
+type ReproducibilityPromptTemplate struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
@@ -0,0 +1,128 @@
+package logstore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSessionRollupTestStore(t *testing.T) LogStore {
+	t.Helper()
+	store, err := newSqliteLogStore(context.Background(), &SQLiteConfig{
+		Path: filepath.Join(t.TempDir(), "sessionrollups.db"),
+	}, testLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close(context.Background()) })
+	return store
+}
+
+func testSessionLog(id, sessionID string, ts time.Time, fallbackIndex int, cost float64, tokens int) *Log {
+	return &Log{
+		ID:               id,
+		ParentRequestID:  &sessionID,
+		Timestamp:        ts,
+		Object:           "chat.completion",
+		Provider:         "openai",
+		Model:            "gpt-4o",
+		Status:           "success",
+		FallbackIndex:    fallbackIndex,
+		Cost:             &cost,
+		PromptTokens:     tokens / 2,
+		CompletionTokens: tokens - tokens/2,
+		TotalTokens:      tokens,
+		CreatedAt:        ts,
+	}
+}
+
+func TestRefreshSessionRollups(t *testing.T) {
+	store := setupSessionRollupTestStore(t)
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, store.BatchCreateIfNotExists(ctx, []*Log{
+		testSessionLog("a1", "sess-a", base, 0, 0.5, 100),
+		testSessionLog("a2", "sess-a", base.Add(time.Minute), 0, 0.25, 40),
+		testSessionLog("a3", "sess-a", base.Add(time.Minute), 1, 0.1, 10), // fallback of a2
+		testSessionLog("b1", "sess-b", base.Add(2*time.Minute), 0, 2, 300),
+	}))
+	llmRequestID := "a1"
+	require.NoError(t, store.BatchCreateMCPToolLogsIfNotExists(ctx, []*MCPToolLog{
+		{ID: "t1", LLMRequestID: &llmRequestID, Timestamp: base, ToolName: "search", Status: "success", CreatedAt: base},
+		{ID: "t2", LLMRequestID: &llmRequestID, Timestamp: base, ToolName: "fetch", Status: "success", CreatedAt: base},
+	}))
+	require.NoError(t, store.RefreshSessionRollups(ctx, []string{"sess-a", "sess-b", "sess-a", "", "sess-missing"}))
+
+	result, err := store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{SortBy: "cost"})
+	require.NoError(t, err)
+	require.Len(t, result.Sessions, 2, "sessions without logs are not materialized")
+	assert.EqualValues(t, 2, result.Pagination.TotalCount)
+	assert.Equal(t, "sess-b", result.Sessions[0].SessionID, "the most expensive session is listed first")
+
+	a := result.Sessions[1]
+	assert.EqualValues(t, 2, a.TurnCount, "fallback attempts are not turns")
+	assert.EqualValues(t, 3, a.RequestCount)
+	assert.EqualValues(t, 150, a.TotalTokens)
+	assert.InDelta(t, 0.85, a.TotalCost, 1e-9)
+	assert.EqualValues(t, 2, a.ToolExecutions)
+	assert.True(t, a.StartedAt.Equal(base))
+	assert.True(t, a.LatestAt.Equal(base.Add(time.Minute)))
+
+	// A later entry and an updated cost are folded in on the next refresh
+	require.NoError(t, store.BatchCreateIfNotExists(ctx, []*Log{testSessionLog("a4", "sess-a", base.Add(3*time.Minute), 0, 5, 20)}))
+	require.NoError(t, store.BulkUpdateCost(ctx, map[string]float64{"a1": 1}))
+	require.NoError(t, store.RefreshSessionRollups(ctx, []string{"sess-a"}))
+
+	result, err = store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{SortBy: "cost", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Sessions, 1)
+	assert.EqualValues(t, 2, result.Pagination.TotalCount)
+	assert.Equal(t, "sess-a", result.Sessions[0].SessionID)
+	assert.EqualValues(t, 3, result.Sessions[0].TurnCount)
+	assert.InDelta(t, 6.35, result.Sessions[0].TotalCost, 1e-9)
+}
+
+func TestSearchSessionRollupsSortingAndFilters(t *testing.T) {
+	store := setupSessionRollupTestStore(t)
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, store.BatchCreateIfNotExists(ctx, []*Log{
+		testSessionLog("a1", "sess-a", base.Add(-2*time.Hour), 0, 1, 500),
+		testSessionLog("b1", "sess-b", base, 0, 3, 100),
+		testSessionLog("b2", "sess-b", base, 0, 0, 0),
+	}))
+	require.NoError(t, store.RefreshSessionRollups(ctx, []string{"sess-a", "sess-b"}))
+
+	result, err := store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{SortBy: "tokens"})
+	require.NoError(t, err)
+	require.Len(t, result.Sessions, 2)
+	assert.Equal(t, "sess-a", result.Sessions[0].SessionID)
+
+	result, err = store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{SortBy: "turns", Order: "asc"})
+	require.NoError(t, err)
+	assert.Equal(t, "sess-a", result.Sessions[0].SessionID)
+	assert.Equal(t, "asc", result.Pagination.Order)
+
+	result, err = store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{SortBy: "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, "latest_at", result.Pagination.SortBy, "unknown sort fields fall back to latest activity")
+	assert.Equal(t, "sess-b", result.Sessions[0].SessionID)
+
+	since := base.Add(-time.Hour)
+	result, err = store.SearchSessionRollups(ctx, SessionRollupSearchFilters{StartTime: &since}, PaginationOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Sessions, 1)
+	assert.Equal(t, "sess-b", result.Sessions[0].SessionID)
+
+	deleted, err := store.DeleteSessionRollupsBatch(ctx, since, 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+	result, err = store.SearchSessionRollups(ctx, SessionRollupSearchFilters{}, PaginationOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Sessions, 1)
+	assert.Equal(t, "sess-b", result.Sessions[0].SessionID)
+}
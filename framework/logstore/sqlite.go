@@ -2,36 +2,18 @@ package logstore
 
 import (
 	"context"
-	"fmt"
-	"os"
 
 	"github.com/maximhq/bifrost/core/schemas"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
+	"github.com/maximhq/bifrost/framework/sqliteconn"
 )
 
 // SQLiteConfig represents the configuration for a SQLite database.
-type SQLiteConfig struct {
-	Path string `json:"path"`
-}
+type SQLiteConfig = sqliteconn.Config
 
 // newSqliteLogStore creates a new SQLite log store.
 func newSqliteLogStore(ctx context.Context, config *SQLiteConfig, logger schemas.Logger) (*RDBLogStore, error) {
-	if _, err := os.Stat(config.Path); os.IsNotExist(err) {
-		// Create DB file
-		f, err := os.Create(config.Path)
-		if err != nil {
-			return nil, err
-		}
-		_ = f.Close()
-	}
-	// Configure SQLite with proper settings to handle concurrent access
-	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=NORMAL&_cache_size=10000&_busy_timeout=60000&_wal_autocheckpoint=1000&_foreign_keys=1", config.Path)
-	logger.Debug("opening DB with dsn: %s", dsn)
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
-		Logger: newGormLogger(logger),
-	})
-
+	logger.Debug("opening DB with dsn: %s", sqliteconn.BuildDSN(config))
+	db, err := sqliteconn.Open(config, newGormLogger(logger))
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +24,13 @@ func newSqliteLogStore(ctx context.Context, config *SQLiteConfig, logger schemas
 	if err := triggerMigrations(ctx, db, logger); err != nil {
 		return nil, err
 	}
+	s.stopCheckpoints = sqliteconn.StartCheckpointer(db, config, logger)
 
 	return s, nil
 }
+
+// BackupSQLite writes a consistent snapshot of a SQLite log store to destPath while it
+// stays online. Other databases return sqliteconn.ErrNotSQLite.
+func (s *RDBLogStore) BackupSQLite(ctx context.Context, destPath string) error {
+	return sqliteconn.Backup(ctx, s.db, destPath)
+}
@@ -34,6 +34,12 @@ type LogStore interface {
 	SearchLogs(ctx context.Context, filters SearchFilters, pagination PaginationOptions) (*SearchResult, error)
 	GetSessionLogs(ctx context.Context, sessionID string, pagination PaginationOptions) (*SessionDetailResult, error)
 	GetSessionSummary(ctx context.Context, sessionID string) (*SessionSummaryResult, error)
+	// RefreshSessionRollups recomputes the totals of the given sessions from their logs.
+	RefreshSessionRollups(ctx context.Context, sessionIDs []string) error
+	// SearchSessionRollups returns one page of per-session totals.
+	SearchSessionRollups(ctx context.Context, filters SessionRollupSearchFilters, pagination PaginationOptions) (*SessionRollupSearchResult, error)
+	// DeleteSessionRollupsBatch deletes up to batchSize rollups with no activity since cutoff.
+	DeleteSessionRollupsBatch(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
 	GetStats(ctx context.Context, filters SearchFilters) (*SearchStats, error)
 	GetHistogram(ctx context.Context, filters SearchFilters, bucketSizeSeconds int64) (*HistogramResult, error)
 	GetTokenHistogram(ctx context.Context, filters SearchFilters, bucketSizeSeconds int64) (*TokenHistogramResult, error)
@@ -48,6 +54,9 @@ type LogStore interface {
 	// GetProviderThroughputHistogram returns time-bucketed tokens/sec with provider breakdown.
 	GetProviderThroughputHistogram(ctx context.Context, filters SearchFilters, bucketSizeSeconds int64) (*ProviderThroughputHistogramResult, error)
 	GetModelRankings(ctx context.Context, filters SearchFilters) (*ModelRankingResult, error)
+	// GetModelUsageProfiles returns per-(provider, model) usage shape over successful requests;
+	// prompts of at most shortPromptTokens tokens count as short.
+	GetModelUsageProfiles(ctx context.Context, filters SearchFilters, shortPromptTokens int) (*ModelUsageProfileResult, error)
 	GetUserRankings(ctx context.Context, filters SearchFilters) (*UserRankingResult, error)
 	GetDimensionRankings(ctx context.Context, filters SearchFilters, dimension RankingDimension) (*DimensionRankingResult, error)
 	// GetDimensionCostHistogram returns time-bucketed cost data grouped by the specified dimension (e.g., team_id, customer_id).
@@ -112,53 +121,74 @@ type LogStore interface {
 // NewLogStore creates a new log store based on the configuration.
 // When ObjectStorage is configured, the returned store is wrapped with a
 // HybridLogStore that offloads payloads to S3-compatible object storage.
+// When Shadow is configured, it is wrapped with a DualWriteLogStore that
+// mirrors every write to the shadow backend.
 func NewLogStore(ctx context.Context, config *Config, logger schemas.Logger) (LogStore, error) {
 	if config == nil {
 		return nil, fmt.Errorf("logstore: config is nil")
 	}
 
-	var inner LogStore
-	var err error
-
-	switch config.Type {
-	case LogStoreTypeSQLite:
-		if sqliteConfig, ok := config.Config.(*SQLiteConfig); ok {
-			inner, err = newSqliteLogStore(ctx, sqliteConfig, logger)
-		} else {
-			return nil, fmt.Errorf("invalid sqlite config: %T", config.Config)
-		}
-	case LogStoreTypePostgres:
-		if postgresConfig, ok := config.Config.(*PostgresConfig); ok {
-			inner, err = newPostgresLogStore(ctx, postgresConfig, logger)
-		} else {
-			return nil, fmt.Errorf("invalid postgres config: %T", config.Config)
-		}
-	case LogStoreTypeClickHouse:
-		if clickhouseConfig, ok := config.Config.(*ClickHouseConfig); ok {
-			inner, err = newClickHouseLogStore(ctx, clickhouseConfig, config.RetentionDays, logger)
-		} else {
-			return nil, fmt.Errorf("invalid clickhouse config: %T", config.Config)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported log store type: %s", config.Type)
-	}
+	inner, err := newBackendLogStore(ctx, config.Type, config.Config, config.RetentionDays, logger)
 	if err != nil {
 		return nil, err
 	}
+	var shadow LogStore
+	if config.Shadow != nil {
+		shadow, err = newBackendLogStore(ctx, config.Shadow.Type, config.Shadow.Config, config.RetentionDays, logger)
+		if err != nil {
+			_ = inner.Close(ctx)
+			return nil, fmt.Errorf("failed to create shadow log store: %w", err)
+		}
+		logger.Info("logstore: mirroring writes to a shadow %s log store", config.Shadow.Type)
+	}
+	primary := inner
 
 	// Optionally wrap with hybrid decorator for object storage offloading.
 	if config.ObjectStorage != nil {
 		objStore, objErr := objectstore.NewObjectStore(ctx, config.ObjectStorage, logger)
 		if objErr != nil {
 			_ = inner.Close(ctx)
+			if shadow != nil {
+				_ = shadow.Close(ctx)
+			}
 			return nil, fmt.Errorf("failed to create object store: %w", objErr)
 		}
 		if err := objStore.Ping(ctx); err != nil {
 			_ = objStore.Close()
 			_ = inner.Close(ctx)
+			if shadow != nil {
+				_ = shadow.Close(ctx)
+			}
 			return nil, fmt.Errorf("failed to ping object store: %w", err)
 		}
-		return newHybridLogStore(inner, objStore, config.ObjectStorage.GetPrefix(), logger, config.ObjectStorageExcludeFields), nil
+		primary = newHybridLogStore(inner, objStore, config.ObjectStorage.GetPrefix(), logger, config.ObjectStorageExcludeFields)
+	}
+	// The shadow receives full payloads; object storage offloading only applies to the primary.
+	if shadow != nil {
+		return newDualWriteLogStore(primary, shadow, config.Type, config.Shadow.Type, config.Shadow.CompareSampleRate, logger), nil
+	}
+	return primary, nil
+}
+
+// newBackendLogStore opens the log store backend of the given type.
+func newBackendLogStore(ctx context.Context, storeType LogStoreType, config any, retentionDays int, logger schemas.Logger) (LogStore, error) {
+	switch storeType {
+	case LogStoreTypeSQLite:
+		if sqliteConfig, ok := config.(*SQLiteConfig); ok {
+			return newSqliteLogStore(ctx, sqliteConfig, logger)
+		}
+		return nil, fmt.Errorf("invalid sqlite config: %T", config)
+	case LogStoreTypePostgres:
+		if postgresConfig, ok := config.(*PostgresConfig); ok {
+			return newPostgresLogStore(ctx, postgresConfig, logger)
+		}
+		return nil, fmt.Errorf("invalid postgres config: %T", config)
+	case LogStoreTypeClickHouse:
+		if clickhouseConfig, ok := config.(*ClickHouseConfig); ok {
+			return newClickHouseLogStore(ctx, clickhouseConfig, retentionDays, logger)
+		}
+		return nil, fmt.Errorf("invalid clickhouse config: %T", config)
+	default:
+		return nil, fmt.Errorf("unsupported log store type: %s", storeType)
 	}
-	return inner, nil
 }
@@ -69,6 +69,7 @@ type SearchFilters struct {
 	MinCost           *float64          `json:"min_cost,omitempty"`
 	MaxCost           *float64          `json:"max_cost,omitempty"`
 	MissingCostOnly   bool              `json:"missing_cost_only,omitempty"`
+	BYOK              *bool             `json:"byok,omitempty"`
 	CacheHitTypes     []string          `json:"cache_hit_types,omitempty"` // For filtering by local-cache hit type ("direct", "semantic")
 	ContentSearch     string            `json:"content_search,omitempty"`
 	MetadataFilters   map[string]string `json:"metadata_filters,omitempty"` // key=metadataKey, value=metadataValue for filtering by metadata
@@ -110,6 +111,40 @@ type SessionSummaryResult struct {
 	DurationMs  int64   `json:"duration_ms"`
 }
 
+// SessionRollup holds the running totals of one session, the logs sharing a
+// parent_request_id. The row is recomputed from the logs whenever entries of
+// the session are written, so listing sessions never aggregates raw logs.
+type SessionRollup struct {
+	SessionID        string    `gorm:"primaryKey;type:varchar(255)" json:"session_id"`
+	TurnCount        int64     `gorm:"not null;default:0" json:"turn_count"`    // User-facing requests; fallback attempts are not counted
+	RequestCount     int64     `gorm:"not null;default:0" json:"request_count"` // Every logged attempt, fallbacks included
+	PromptTokens     int64     `gorm:"not null;default:0" json:"prompt_tokens"`
+	CompletionTokens int64     `gorm:"not null;default:0" json:"completion_tokens"`
+	TotalTokens      int64     `gorm:"not null;default:0;index:idx_session_rollups_total_tokens" json:"total_tokens"`
+	TotalCost        float64   `gorm:"not null;default:0;index:idx_session_rollups_total_cost" json:"total_cost"`
+	ToolExecutions   int64     `gorm:"not null;default:0" json:"tool_executions"` // MCP tool calls made by the session's requests
+	StartedAt        time.Time `gorm:"not null" json:"started_at"`
+	LatestAt         time.Time `gorm:"index:idx_session_rollups_latest_at;not null" json:"latest_at"`
+	UpdatedAt        time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// TableName sets the table name for GORM
+func (SessionRollup) TableName() string {
+	return "session_rollups"
+}
+
+// SessionRollupSearchFilters selects sessions by their latest activity.
+type SessionRollupSearchFilters struct {
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+// SessionRollupSearchResult represents one page of session rollups.
+type SessionRollupSearchResult struct {
+	Sessions   []SessionRollup   `json:"sessions"`
+	Pagination PaginationOptions `json:"pagination"`
+}
+
 type SearchStats struct {
 	TotalRequests             int64   `json:"total_requests"`
 	SuccessRate               float64 `json:"success_rate"`                            // Percentage of individual attempts that succeeded
@@ -143,6 +178,7 @@ type Log struct {
 	FallbackIndex           int       `gorm:"default:0" json:"fallback_index"`
 	SelectedKeyID           string    `gorm:"type:varchar(255);index:idx_logs_selected_key_id" json:"selected_key_id"`
 	SelectedKeyName         string    `gorm:"type:varchar(255)" json:"selected_key_name"`
+	BYOK                    bool      `gorm:"column:byok;default:false" json:"byok"`
 	AttemptTrail            string    `gorm:"type:text" json:"-"` // JSON serialized []schemas.KeyAttemptRecord
 	VirtualKeyID            *string   `gorm:"type:varchar(255);index:idx_logs_virtual_key_id" json:"virtual_key_id"`
 	VirtualKeyName          *string   `gorm:"type:varchar(255)" json:"virtual_key_name"`
@@ -196,7 +232,7 @@ type Log struct {
 	Latency                 *float64  `gorm:"index:idx_logs_latency" json:"latency,omitempty"`
 	TokenUsage              string    `gorm:"type:text" json:"-"`                                                                         // JSON serialized *schemas.LLMUsage
 	Cost                    *float64  `gorm:"index" json:"cost,omitempty"`                                                                // Cost in dollars (total cost of the request - includes cache lookup cost)
-	Status                  string    `gorm:"type:varchar(50);index;index:idx_logs_ts_provider_status,priority:3;not null" json:"status"` // "processing", "success", or "error"
+	Status                  string    `gorm:"type:varchar(50);index;index:idx_logs_ts_provider_status,priority:3;not null" json:"status"` // "processing", "success", "error", "cancelled" or "aborted"
 	StopReason              *string   `gorm:"type:varchar(50);index:idx_logs_stop_reason" json:"stop_reason,omitempty"`                   // Why the model stopped: "stop", "length", "content_filter", "tool_calls", etc.
 	ErrorDetails            string    `gorm:"type:text" json:"-"`                                                                         // JSON serialized *schemas.BifrostError
 	Stream                  bool      `gorm:"default:false" json:"stream"`                                                                // true if this was a streaming response
@@ -223,6 +259,17 @@ type Log struct {
 	BudgetIDs     *string `gorm:"type:text" json:"-"` // JSON serialized []string of budget IDs applicable to this request
 	RateLimitIDs  *string `gorm:"type:text" json:"-"` // JSON serialized []string of rate limit IDs applicable to this request
 
+	// Deployment metadata - the region and instance that served the request, attached
+	// by the logging plugin so active-active deployments stay debuggable.
+	Region     *string `gorm:"type:varchar(255)" json:"region,omitempty"`
+	InstanceID *string `gorm:"type:varchar(255)" json:"instance_id,omitempty"`
+
+	// Reproducibility metadata - what the provider reported about the model that
+	// served the request, and the configuration the gateway ran it with.
+	SystemFingerprint *string `gorm:"type:varchar(255)" json:"system_fingerprint,omitempty"` // Provider system_fingerprint, when reported
+	ModelRevision     *string `gorm:"type:varchar(255)" json:"model_revision,omitempty"`     // Model name the provider echoed back, e.g. a dated snapshot of the requested model
+	Reproducibility   string  `gorm:"type:text" json:"-"`                                    // JSON serialized *ReproducibilityBundle
+
 	// Denormalized token fields for easier querying
 	PromptTokens     int `gorm:"default:0" json:"-"`
 	CompletionTokens int `gorm:"default:0" json:"-"`
@@ -255,6 +302,7 @@ type Log struct {
 	TranscriptionOutputParsed   *schemas.BifrostTranscriptionResponse   `gorm:"-" json:"transcription_output,omitempty"`
 	ImageGenerationOutputParsed *schemas.BifrostImageGenerationResponse `gorm:"-" json:"image_generation_output,omitempty"`
 	CacheDebugParsed            *schemas.BifrostCacheDebug              `gorm:"-" json:"cache_debug,omitempty"`
+	ReproducibilityParsed       *ReproducibilityBundle                  `gorm:"-" json:"reproducibility,omitempty"`
 	ListModelsOutputParsed      []schemas.Model                         `gorm:"-" json:"list_models_output,omitempty"`
 	MetadataParsed              map[string]interface{}                  `gorm:"-" json:"metadata,omitempty"`
 	VideoGenerationInputParsed  *schemas.VideoGenerationInput           `gorm:"-" json:"video_generation_input,omitempty"`
@@ -560,6 +608,14 @@ func (l *Log) SerializeFields() error {
 		}
 	}
 
+	if l.ReproducibilityParsed != nil {
+		if data, err := sonic.Marshal(l.ReproducibilityParsed); err != nil {
+			return err
+		} else {
+			l.Reproducibility = string(data)
+		}
+	}
+
 	if len(l.AttemptTrailParsed) > 0 {
 		if data, err := sonic.Marshal(l.AttemptTrailParsed); err != nil {
 			return err
@@ -861,6 +917,13 @@ func (l *Log) DeserializeFields() error {
 		}
 	}
 
+	if l.Reproducibility != "" {
+		if err := sonic.Unmarshal([]byte(l.Reproducibility), &l.ReproducibilityParsed); err != nil {
+			// Log error but don't fail the operation - initialize as nil
+			l.ReproducibilityParsed = nil
+		}
+	}
+
 	if l.AttemptTrail != "" {
 		if err := sonic.Unmarshal([]byte(l.AttemptTrail), &l.AttemptTrailParsed); err != nil {
 			l.AttemptTrailParsed = nil
@@ -1771,6 +1834,29 @@ type ModelRankingResult struct {
 	Rankings []ModelRankingWithTrend `json:"rankings"`
 }
 
+// ModelUsageProfile summarizes how a (provider, model) route is used: how much of its
+// traffic is short prompts and how its generations end. It backs the model
+// recommendations endpoint, which looks for routes spending on oversized models.
+type ModelUsageProfile struct {
+	Model                   string  `json:"model"`
+	Provider                string  `json:"provider"`
+	TotalRequests           int64   `json:"total_requests"` // Successful requests
+	TotalCost               float64 `json:"total_cost"`
+	PromptTokens            int64   `json:"prompt_tokens"`
+	CompletionTokens        int64   `json:"completion_tokens"`
+	ShortPromptRequests     int64   `json:"short_prompt_requests"` // Requests with at most the requested number of prompt tokens
+	ShortPromptCost         float64 `json:"short_prompt_cost"`
+	ShortPromptTokens       int64   `json:"short_prompt_tokens"`
+	ShortCompletionTokens   int64   `json:"short_completion_tokens"`
+	LengthStoppedRequests   int64   `json:"length_stopped_requests"`    // Generations cut off by max tokens
+	ToolCallStoppedRequests int64   `json:"tool_call_stopped_requests"` // Generations that ended in a tool call
+}
+
+// ModelUsageProfileResult is the result of GetModelUsageProfiles.
+type ModelUsageProfileResult struct {
+	Profiles []ModelUsageProfile `json:"profiles"`
+}
+
 // UserRankingEntry represents a single user's usage statistics.
 type UserRankingEntry struct {
 	UserID        string  `json:"user_id"`
@@ -146,6 +146,11 @@ func (s *Store) calculateBaseCost(result *schemas.BifrostResponse, scopes Lookup
 	}
 	requestType := extraFields.RequestType
 
+	// Batch results carry one completion per item, each with its own model and usage.
+	if result.BatchResultsResponse != nil {
+		return s.calculateBatchResultsCost(result.BatchResultsResponse, routingInfo.Provider, scopes)
+	}
+
 	// Extract usage data from the response (passthrough and native paths unified)
 	input := extractCostInput(result)
 
@@ -179,6 +184,81 @@ func (s *Store) calculateBaseCost(result *schemas.BifrostResponse, scopes Lookup
 	return s.computeCostFromInput(input, routingInfo, requestType, scopes)
 }
 
+// calculateBatchResultsCost prices a downloaded batch results file by summing the
+// usage of every successful item. Batch jobs bill at the datasheet's discounted
+// batch rates; models without batch rates fall back to the standard per-token rates.
+func (s *Store) calculateBatchResultsCost(results *schemas.BifrostBatchResultsResponse, provider schemas.ModelProvider, scopes LookupScopes) float64 {
+	total := 0.0
+	for _, item := range results.Results {
+		model, usage := batchResultItemUsage(item)
+		if model == "" || usage == nil {
+			continue
+		}
+		pricing := s.resolvePricing(schemas.RoutingInfo{Provider: provider, Model: model}, schemas.ChatCompletionRequest, scopes)
+		if pricing == nil {
+			continue
+		}
+		inputRate := 0.0
+		if pricing.InputCostPerToken != nil {
+			inputRate = *pricing.InputCostPerToken
+		}
+		if pricing.InputCostPerTokenBatches != nil {
+			inputRate = *pricing.InputCostPerTokenBatches
+		}
+		outputRate := 0.0
+		if pricing.OutputCostPerToken != nil {
+			outputRate = *pricing.OutputCostPerToken
+		}
+		if pricing.OutputCostPerTokenBatches != nil {
+			outputRate = *pricing.OutputCostPerTokenBatches
+		}
+		total += float64(usage.PromptTokens)*inputRate + float64(usage.CompletionTokens)*outputRate
+	}
+	return total
+}
+
+// batchResultItemUsage extracts the served model and token usage from a single
+// batch result item. OpenAI items carry the completion body under Response,
+// Anthropic items carry the message under Result. Failed items return nil usage.
+func batchResultItemUsage(item schemas.BatchResultItem) (string, *schemas.BifrostLLMUsage) {
+	var body map[string]interface{}
+	switch {
+	case item.Response != nil && item.Response.StatusCode < 300:
+		body = item.Response.Body
+	case item.Result != nil && item.Result.Type == "succeeded":
+		body = item.Result.Message
+	}
+	if body == nil {
+		return "", nil
+	}
+	model, _ := body["model"].(string)
+	rawUsage, ok := body["usage"].(map[string]interface{})
+	if !ok {
+		return model, nil
+	}
+	// Chat completions report prompt/completion tokens; responses and Anthropic
+	// messages report input/output tokens.
+	usage := &schemas.BifrostLLMUsage{
+		PromptTokens:     jsonInt(rawUsage["prompt_tokens"]) + jsonInt(rawUsage["input_tokens"]),
+		CompletionTokens: jsonInt(rawUsage["completion_tokens"]) + jsonInt(rawUsage["output_tokens"]),
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return model, usage
+}
+
+// jsonInt converts a decoded JSON number to an int, returning 0 for anything else.
+func jsonInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case int64:
+		return int(n)
+	}
+	return 0
+}
+
 // calculateAzureModelRouterCost bills the Model Router deployment's own
 // pricing row (the flat per-input-token surcharge) plus the real cost of the
 // model it actually routed to, looked up fresh under the served model name so
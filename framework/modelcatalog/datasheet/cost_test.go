@@ -3824,3 +3824,51 @@ func TestCalculateCostForUsage_NoServerSideFallback_Unchanged(t *testing.T) {
 	assert.InDelta(t, 38*(10.0/1_000_000)+345*(50.0/1_000_000),
 		s.CalculateCostForUsage(usage, schemas.Anthropic, "claude-fable-5", schemas.ResponsesRequest, nil), 1e-12)
 }
+
+func TestCalculateCost_BatchResultsUsesBatchRates(t *testing.T) {
+	s := testStoreWithPricing(map[string]configstoreTables.TableModelPricing{
+		makeKey("gpt-4o-mini", "openai", "chat"): {
+			Model: "gpt-4o-mini", Provider: "openai", Mode: "chat",
+			InputCostPerToken:         new(0.0000002),
+			OutputCostPerToken:        new(0.0000008),
+			InputCostPerTokenBatches:  new(0.0000001),
+			OutputCostPerTokenBatches: new(0.0000004),
+		},
+		makeKey("gpt-4o", "openai", "chat"): {
+			Model: "gpt-4o", Provider: "openai", Mode: "chat",
+			InputCostPerToken:  new(0.000002),
+			OutputCostPerToken: new(0.000008),
+		},
+	})
+
+	resp := &schemas.BifrostResponse{
+		BatchResultsResponse: &schemas.BifrostBatchResultsResponse{
+			BatchID: "batch_123",
+			Results: []schemas.BatchResultItem{
+				{CustomID: "a", Response: &schemas.BatchResultResponse{StatusCode: 200, Body: map[string]interface{}{
+					"model": "gpt-4o-mini",
+					"usage": map[string]interface{}{"prompt_tokens": float64(1000), "completion_tokens": float64(500)},
+				}}},
+				{CustomID: "b", Response: &schemas.BatchResultResponse{StatusCode: 200, Body: map[string]interface{}{
+					"model": "gpt-4o",
+					"usage": map[string]interface{}{"input_tokens": float64(100), "output_tokens": float64(10)},
+				}}},
+				{CustomID: "c", Response: &schemas.BatchResultResponse{StatusCode: 500, Body: map[string]interface{}{
+					"model": "gpt-4o",
+					"usage": map[string]interface{}{"prompt_tokens": float64(1000000)},
+				}}},
+				{CustomID: "d", Error: &schemas.BatchResultError{Code: "invalid_request"}},
+			},
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				RequestType: schemas.BatchResultsRequest,
+				RoutingInfo: schemas.RoutingInfo{Provider: schemas.OpenAI},
+			},
+		},
+	}
+
+	cost := s.CalculateCost(resp, nil)
+	// a (batch rates):    1000 * 0.0000001 + 500 * 0.0000004 = 0.0001 + 0.0002 = 0.0003
+	// b (standard rates): 100 * 0.000002 + 10 * 0.000008     = 0.0002 + 0.00008 = 0.00028
+	// c and d failed and are not billed.
+	assert.InDelta(t, 0.00058, cost, 1e-12)
+}
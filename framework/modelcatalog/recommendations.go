@@ -0,0 +1,79 @@
+package modelcatalog
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// ModelAlternative is a chat model priced below the model it can stand in for.
+type ModelAlternative struct {
+	Provider           schemas.ModelProvider `json:"provider"`
+	Model              string                `json:"model"`
+	InputCostPerToken  float64               `json:"input_cost_per_token"`
+	OutputCostPerToken float64               `json:"output_cost_per_token"`
+}
+
+// GetCheaperAlternatives returns the non-deprecated chat models of provider that are priced
+// below model on both input and output tokens and accept at least its input modalities.
+// With requiresTools, only models known to support function calling qualify. The most
+// expensive alternative comes first, since it is usually the closest in capability.
+// Returns nil when model has no chat pricing.
+func (mc *ModelCatalog) GetCheaperAlternatives(model string, provider schemas.ModelProvider, requiresTools bool) []ModelAlternative {
+	current := mc.datasheet.Get(model, provider, schemas.ChatCompletionRequest)
+	if current == nil || current.InputCostPerToken == nil || current.OutputCostPerToken == nil {
+		return nil
+	}
+	var requiredModalities []string
+	if current.Architecture != nil {
+		requiredModalities = current.Architecture.InputModalities
+	}
+
+	var alternatives []ModelAlternative
+	for _, candidate := range mc.datasheet.DatasheetModelsForProvider(provider) {
+		if candidate == model {
+			continue
+		}
+		pricing := mc.datasheet.Get(candidate, provider, schemas.ChatCompletionRequest)
+		if pricing == nil || pricing.IsDeprecated || pricing.InputCostPerToken == nil || pricing.OutputCostPerToken == nil {
+			continue
+		}
+		if *pricing.InputCostPerToken >= *current.InputCostPerToken || *pricing.OutputCostPerToken > *current.OutputCostPerToken {
+			continue
+		}
+		if len(requiredModalities) > 0 {
+			if pricing.Architecture == nil || !containsAll(pricing.Architecture.InputModalities, requiredModalities) {
+				continue
+			}
+		}
+		if requiresTools && !slices.Contains(mc.datasheet.GetSupportedParameters(candidate), "tools") {
+			continue
+		}
+		alternatives = append(alternatives, ModelAlternative{
+			Provider:           provider,
+			Model:              candidate,
+			InputCostPerToken:  *pricing.InputCostPerToken,
+			OutputCostPerToken: *pricing.OutputCostPerToken,
+		})
+	}
+	sort.Slice(alternatives, func(i, j int) bool {
+		ci := alternatives[i].InputCostPerToken + alternatives[i].OutputCostPerToken
+		cj := alternatives[j].InputCostPerToken + alternatives[j].OutputCostPerToken
+		if ci != cj {
+			return ci > cj
+		}
+		return alternatives[i].Model < alternatives[j].Model
+	})
+	return alternatives
+}
+
+// containsAll reports whether set contains every element of subset.
+func containsAll(set, subset []string) bool {
+	for _, s := range subset {
+		if !slices.Contains(set, s) {
+			return false
+		}
+	}
+	return true
+}
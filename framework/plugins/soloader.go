@@ -165,9 +165,40 @@ func (l *SharedObjectPluginLoader) LoadPlugin(path string, config any) (schemas.
 		}
 	}
 
+	// Optional: ProviderTranslators (ProviderTranslatorPlugin). Each translator is
+	// registered under its name so custom providers can select it with
+	// custom_provider_config.translator.
+	if sym, err := pluginObj.Lookup("ProviderTranslators"); err == nil {
+		providerTranslators, ok := sym.(func() map[string]schemas.ProviderTranslator)
+		if !ok {
+			return nil, fmt.Errorf("failed to cast ProviderTranslators to expected signature")
+		}
+		dp.providerTranslators = providerTranslators()
+		if err := registerProviderTranslators(dp); err != nil {
+			return nil, err
+		}
+	}
+
 	return dp, nil
 }
 
+// registerProviderTranslators registers the plugin's translators. On failure
+// the translators already registered are unregistered again.
+func registerProviderTranslators(dp *DynamicPlugin) error {
+	for name, translator := range dp.providerTranslators {
+		unregister, err := schemas.RegisterProviderTranslator(name, translator)
+		if err != nil {
+			for _, undo := range dp.unregisterTranslators {
+				undo()
+			}
+			dp.unregisterTranslators = nil
+			return fmt.Errorf("failed to register provider translator %q: %w", name, err)
+		}
+		dp.unregisterTranslators = append(dp.unregisterTranslators, unregister)
+	}
+	return nil
+}
+
 // VerifyBasePlugin verifies a plugin at the given path
 // Returns the name of the plugin or an empty string if the plugin is invalid
 // Returns an error if the plugin is invalid
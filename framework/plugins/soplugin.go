@@ -47,6 +47,11 @@ type DynamicPlugin struct {
 
 	// ObservabilityPlugin (optional)
 	inject func(ctx context.Context, trace *schemas.Trace) error
+
+	// ProviderTranslatorPlugin (optional). Translators are registered when the
+	// plugin loads and unregistered on Cleanup.
+	providerTranslators   map[string]schemas.ProviderTranslator
+	unregisterTranslators []func()
 }
 
 // GetName returns the name of the plugin (BasePlugin interface)
@@ -56,9 +61,18 @@ func (dp *DynamicPlugin) GetName() string {
 
 // Cleanup is invoked by core/bifrost.go during plugin unload, reload, and shutdown (BasePlugin interface)
 func (dp *DynamicPlugin) Cleanup() error {
+	for _, unregister := range dp.unregisterTranslators {
+		unregister()
+	}
+	dp.unregisterTranslators = nil
 	return dp.cleanup()
 }
 
+// ProviderTranslators returns the provider translators exported by the plugin (ProviderTranslatorPlugin interface)
+func (dp *DynamicPlugin) ProviderTranslators() map[string]schemas.ProviderTranslator {
+	return dp.providerTranslators
+}
+
 // HTTPTransportPreHook intercepts HTTP requests at the transport layer before entering Bifrost core (HTTPTransportPlugin interface)
 func (dp *DynamicPlugin) HTTPTransportPreHook(ctx *schemas.BifrostContext, req *schemas.HTTPRequest) (*schemas.HTTPResponse, error) {
 	if dp.httpTransportPreHook == nil {
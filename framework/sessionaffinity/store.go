@@ -0,0 +1,113 @@
+// Package sessionaffinity persists the deployment that served each session
+// (x-bf-session-id) in the config store, so multi-turn conversations keep
+// hitting the same provider, model and key across requests, restarts and
+// nodes. Store implements schemas.SessionAffinityStore for bifrost core.
+package sessionaffinity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/encrypt"
+)
+
+// cachedAffinity is the last affinity read from or written to the config
+// store for one session, with the expiry that was persisted alongside it.
+type cachedAffinity struct {
+	affinity  schemas.SessionAffinity
+	expiresAt time.Time
+}
+
+// Store keeps session affinities in the config store. Session IDs are stored
+// only as SHA-256 hashes.
+//
+// Every turn of a conversation refreshes its affinity, so Store keeps a local
+// copy of each one and skips the write while the deployment is unchanged and
+// more than half of the TTL is left. Reads are served from the same copy; a
+// node only sees another node's change to a session once its copy expires.
+type Store struct {
+	store configstore.ConfigStore
+	cache sync.Map         // session hash -> cachedAffinity
+	now   func() time.Time // injectable for tests
+}
+
+// NewStore constructs a Store backed by the given config store. Returns nil
+// when store is nil so callers can wire it unconditionally.
+func NewStore(store configstore.ConfigStore) *Store {
+	if store == nil {
+		return nil
+	}
+	return &Store{store: store, now: time.Now}
+}
+
+// GetSessionAffinity returns the affinity recorded for sessionID, or nil when
+// there is none or it has expired.
+func (s *Store) GetSessionAffinity(ctx context.Context, sessionID string) (*schemas.SessionAffinity, error) {
+	hash := encrypt.HashSHA256(sessionID)
+	if cached, ok := s.cached(hash); ok {
+		affinity := cached.affinity
+		return &affinity, nil
+	}
+	row, err := s.store.GetSessionAffinity(ctx, hash)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	affinity := schemas.SessionAffinity{Provider: schemas.ModelProvider(row.Provider), Model: row.Model, KeyID: row.KeyID}
+	s.cache.Store(hash, cachedAffinity{affinity: affinity, expiresAt: row.ExpiresAt})
+	return &affinity, nil
+}
+
+// SetSessionAffinity records affinity for sessionID and keeps it for ttl.
+func (s *Store) SetSessionAffinity(ctx context.Context, sessionID string, affinity schemas.SessionAffinity, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = schemas.DefaultSessionStickyTTL
+	}
+	hash := encrypt.HashSHA256(sessionID)
+	now := s.now()
+	if cached, ok := s.cached(hash); ok && cached.affinity == affinity && cached.expiresAt.Sub(now) > ttl/2 {
+		return nil
+	}
+	expiresAt := now.Add(ttl)
+	if err := s.store.UpsertSessionAffinity(ctx, &tables.TableSessionAffinity{
+		SessionHash: hash,
+		Provider:    string(affinity.Provider),
+		Model:       affinity.Model,
+		KeyID:       affinity.KeyID,
+		ExpiresAt:   expiresAt,
+		UpdatedAt:   now,
+	}); err != nil {
+		return err
+	}
+	s.cache.Store(hash, cachedAffinity{affinity: affinity, expiresAt: expiresAt})
+	return nil
+}
+
+// DeleteExpired removes affinities that expired at or before the cutoff, from
+// the config store and from the local copy.
+func (s *Store) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	s.cache.Range(func(key, value any) bool {
+		if !value.(cachedAffinity).expiresAt.After(before) {
+			s.cache.Delete(key)
+		}
+		return true
+	})
+	return s.store.DeleteExpiredSessionAffinities(ctx, before)
+}
+
+// cached returns the local copy for a session hash if it has not expired.
+func (s *Store) cached(hash string) (cachedAffinity, bool) {
+	value, ok := s.cache.Load(hash)
+	if !ok {
+		return cachedAffinity{}, false
+	}
+	cached := value.(cachedAffinity)
+	if !s.now().Before(cached.expiresAt) {
+		s.cache.Delete(hash)
+		return cachedAffinity{}, false
+	}
+	return cached, true
+}
@@ -0,0 +1,148 @@
+package sessionaffinity
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// fakeStore implements only the session affinity subset of ConfigStore.
+type fakeStore struct {
+	configstore.ConfigStore
+
+	mu     sync.Mutex
+	rows   map[string]tables.TableSessionAffinity
+	writes int
+	now    func() time.Time
+}
+
+func newFakeStore(now func() time.Time) *fakeStore {
+	return &fakeStore{rows: make(map[string]tables.TableSessionAffinity), now: now}
+}
+
+func (f *fakeStore) GetSessionAffinity(_ context.Context, sessionHash string) (*tables.TableSessionAffinity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	row, ok := f.rows[sessionHash]
+	if !ok || !row.ExpiresAt.After(f.now()) {
+		return nil, nil
+	}
+	return &row, nil
+}
+
+func (f *fakeStore) UpsertSessionAffinity(_ context.Context, affinity *tables.TableSessionAffinity) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rows[affinity.SessionHash] = *affinity
+	f.writes++
+	return nil
+}
+
+func (f *fakeStore) DeleteExpiredSessionAffinities(_ context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deleted int64
+	for hash, row := range f.rows {
+		if !row.ExpiresAt.After(before) {
+			delete(f.rows, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time          { return c.t }
+func (c *testClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestStore() (*Store, *fakeStore, *testClock) {
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	backing := newFakeStore(clock.now)
+	store := NewStore(backing)
+	store.now = clock.now
+	return store, backing, clock
+}
+
+var openAI = schemas.SessionAffinity{Provider: schemas.OpenAI, Model: "gpt-4o", KeyID: "key-a"}
+
+func TestSetPersistsHashedSessionAndSkipsRedundantWrites(t *testing.T) {
+	store, backing, clock := newTestStore()
+	ctx := context.Background()
+
+	if err := store.SetSessionAffinity(ctx, "conv-1", openAI, time.Hour); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if _, ok := backing.rows["conv-1"]; ok {
+		t.Fatal("session ID must not be stored in plaintext")
+	}
+	clock.advance(10 * time.Minute)
+	if err := store.SetSessionAffinity(ctx, "conv-1", openAI, time.Hour); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if backing.writes != 1 {
+		t.Fatalf("expected an unchanged, fresh affinity to skip the write, got %d writes", backing.writes)
+	}
+
+	// Past half the TTL the same affinity is written again to extend it.
+	clock.advance(25 * time.Minute)
+	store.SetSessionAffinity(ctx, "conv-1", openAI, time.Hour)
+	if backing.writes != 2 {
+		t.Fatalf("expected the TTL to be refreshed, got %d writes", backing.writes)
+	}
+
+	// A different deployment is always written.
+	moved := schemas.SessionAffinity{Provider: schemas.Anthropic, Model: "claude-sonnet-4"}
+	store.SetSessionAffinity(ctx, "conv-1", moved, time.Hour)
+	if backing.writes != 3 {
+		t.Fatalf("expected a changed affinity to be written, got %d writes", backing.writes)
+	}
+}
+
+func TestGetReadsThroughAndHonoursExpiry(t *testing.T) {
+	store, backing, clock := newTestStore()
+	ctx := context.Background()
+	store.SetSessionAffinity(ctx, "conv-1", openAI, time.Hour)
+
+	// A fresh Store (another node, or after a restart) reads it from the config store.
+	other := NewStore(backing)
+	other.now = clock.now
+	got, err := other.GetSessionAffinity(ctx, "conv-1")
+	if err != nil || got == nil || *got != openAI {
+		t.Fatalf("expected %+v from the config store, got %+v (err %v)", openAI, got, err)
+	}
+	if got, _ := other.GetSessionAffinity(ctx, "conv-2"); got != nil {
+		t.Fatalf("expected no affinity for an unknown session, got %+v", got)
+	}
+
+	clock.advance(time.Hour)
+	if got, _ := store.GetSessionAffinity(ctx, "conv-1"); got != nil {
+		t.Fatalf("expected the affinity to expire, got %+v", got)
+	}
+}
+
+func TestDeleteExpiredPrunesStoreAndCache(t *testing.T) {
+	store, backing, clock := newTestStore()
+	ctx := context.Background()
+	store.SetSessionAffinity(ctx, "short", openAI, time.Minute)
+	store.SetSessionAffinity(ctx, "long", openAI, time.Hour)
+
+	clock.advance(2 * time.Minute)
+	n, err := store.DeleteExpired(ctx, clock.now())
+	if err != nil || n != 1 {
+		t.Fatalf("expected one expired row deleted, got %d (err %v)", n, err)
+	}
+	if len(backing.rows) != 1 {
+		t.Fatalf("expected one row left, got %d", len(backing.rows))
+	}
+	var cached int
+	store.cache.Range(func(_, _ any) bool { cached++; return true })
+	if cached != 1 {
+		t.Fatalf("expected one cached affinity left, got %d", cached)
+	}
+}
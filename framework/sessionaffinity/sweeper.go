@@ -0,0 +1,104 @@
+package sessionaffinity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// SweepWorker periodically deletes session_affinities rows whose expires_at is
+// in the past, mirroring temptoken.SweepWorker. Expired rows are already
+// ignored on read; sweeping keeps the table and Store's local copy from
+// growing with every session ever seen.
+type SweepWorker struct {
+	store         *Store
+	sweepInterval time.Duration
+	stopCh        chan struct{}
+	stopOnce      sync.Once
+	cancel        context.CancelFunc
+	logger        schemas.Logger
+}
+
+// NewSweepWorker constructs a worker bound to the given store. Returns nil
+// when store is nil so callers can wire it unconditionally and check the
+// result before starting.
+func NewSweepWorker(store *Store, logger schemas.Logger) *SweepWorker {
+	if store == nil {
+		if logger != nil {
+			logger.Warn("session affinity sweep worker not started: store is nil")
+		}
+		return nil
+	}
+	return &SweepWorker{
+		store:         store,
+		sweepInterval: 5 * time.Minute,
+		stopCh:        make(chan struct{}),
+		logger:        logger,
+	}
+}
+
+// Start begins the sweep loop in a background goroutine.
+func (w *SweepWorker) Start(ctx context.Context) {
+	runCtx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	go w.run(runCtx)
+	if w.logger != nil {
+		w.logger.Info("session affinity sweep worker started (interval=%s)", w.sweepInterval)
+	}
+}
+
+// Stop gracefully stops the sweep worker. sync.Once guards against double-close
+// panics from redundant shutdown paths.
+func (w *SweepWorker) Stop() {
+	w.stopOnce.Do(func() {
+		// Cancel any in-flight sweep so a blocked DB call unwinds promptly,
+		// then signal run() to exit its ticker loop.
+		if w.cancel != nil {
+			w.cancel()
+		}
+		close(w.stopCh)
+		if w.logger != nil {
+			w.logger.Info("session affinity sweep worker stopped")
+		}
+	})
+}
+
+func (w *SweepWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.sweepInterval)
+	defer ticker.Stop()
+
+	// Run once on start so a deploy doesn't have to wait a full interval to
+	// reap rows that expired while the process was down.
+	w.sweepExpired(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweepExpired(ctx)
+		case <-w.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *SweepWorker) sweepExpired(ctx context.Context) {
+	n, err := w.store.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		if w.logger != nil {
+			w.logger.Error("session affinity sweep failed: %v", err)
+		}
+		return
+	}
+	if n > 0 && w.logger != nil {
+		w.logger.Debug("session affinity sweep removed %d expired rows", n)
+	}
+}
+
+// SetSweepInterval updates the sweep cadence (for testing).
+func (w *SweepWorker) SetSweepInterval(d time.Duration) {
+	w.sweepInterval = d
+}
@@ -0,0 +1,209 @@
+// Package sqliteconn is the SQLite connection setup shared by framework stores: WAL
+// journaling with a tunable busy timeout and checkpointing, and online backups of a live
+// database.
+package sqliteconn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/maximhq/bifrost/core/schemas"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	// DefaultBusyTimeoutMs is how long a connection waits on a lock by default.
+	DefaultBusyTimeoutMs = 60000
+	// DefaultWALAutocheckpoint is the WAL size, in pages, that triggers a checkpoint by default.
+	DefaultWALAutocheckpoint = 1000
+)
+
+// ErrNotSQLite is returned when a SQLite-only operation is asked of another database.
+var ErrNotSQLite = errors.New("database is not sqlite")
+
+// Config is the shared SQLite configuration used by framework stores.
+type Config struct {
+	Path string `json:"path"`
+	// BusyTimeoutMs is how long a connection waits for a lock held by another one before
+	// failing with SQLITE_BUSY. 0 uses DefaultBusyTimeoutMs.
+	BusyTimeoutMs int `json:"busy_timeout_ms,omitempty"`
+	// WALAutocheckpoint is the WAL size, in pages, at which a commit copies the WAL back
+	// into the database file. 0 uses DefaultWALAutocheckpoint; -1 turns automatic
+	// checkpoints off, leaving them to CheckpointInterval.
+	WALAutocheckpoint int `json:"wal_autocheckpoint,omitempty"`
+	// CheckpointInterval (e.g. "5m") additionally runs a TRUNCATE checkpoint on a timer,
+	// which also shrinks the WAL file back to zero bytes. Empty disables it.
+	CheckpointInterval string `json:"checkpoint_interval,omitempty"`
+}
+
+// Validate checks the tuning fields of config.
+func Validate(config *Config) error {
+	if config == nil {
+		return fmt.Errorf("config is required")
+	}
+	if config.Path == "" {
+		return fmt.Errorf("sqlite path is required")
+	}
+	if config.BusyTimeoutMs < 0 {
+		return fmt.Errorf("busy_timeout_ms must not be negative")
+	}
+	if config.WALAutocheckpoint < -1 {
+		return fmt.Errorf("wal_autocheckpoint must be -1, 0 or a page count")
+	}
+	if _, err := checkpointInterval(config); err != nil {
+		return err
+	}
+	return nil
+}
+
+func checkpointInterval(config *Config) (time.Duration, error) {
+	if config.CheckpointInterval == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(config.CheckpointInterval)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid checkpoint_interval %q", config.CheckpointInterval)
+	}
+	return interval, nil
+}
+
+// BuildDSN returns the go-sqlite3 DSN for config.
+func BuildDSN(config *Config) string {
+	busyTimeout := config.BusyTimeoutMs
+	if busyTimeout == 0 {
+		busyTimeout = DefaultBusyTimeoutMs
+	}
+	return fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=NORMAL&_cache_size=10000&_busy_timeout=%d&_foreign_keys=1", config.Path, busyTimeout)
+}
+
+// connector opens go-sqlite3 connections with a connect hook.
+type connector struct {
+	driver *sqlite3.SQLiteDriver
+	dsn    string
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open(c.dsn) }
+func (c connector) Driver() driver.Driver                        { return c.driver }
+
+// Open creates the database file if needed and opens it in WAL mode. wal_autocheckpoint
+// is not a go-sqlite3 DSN parameter, so it is set on every new connection instead.
+func Open(config *Config, logger gormlogger.Interface) (*gorm.DB, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(config.Path); os.IsNotExist(err) {
+		f, err := os.Create(config.Path)
+		if err != nil {
+			return nil, err
+		}
+		_ = f.Close()
+	}
+	autocheckpoint := config.WALAutocheckpoint
+	switch autocheckpoint {
+	case 0:
+		autocheckpoint = DefaultWALAutocheckpoint
+	case -1:
+		autocheckpoint = 0
+	}
+	conn := sql.OpenDB(connector{
+		driver: &sqlite3.SQLiteDriver{ConnectHook: func(c *sqlite3.SQLiteConn) error {
+			_, err := c.Exec(fmt.Sprintf("PRAGMA wal_autocheckpoint = %d", autocheckpoint), nil)
+			return err
+		}},
+		dsn: BuildDSN(config),
+	})
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: conn}), &gorm.Config{Logger: logger})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// StartCheckpointer runs a TRUNCATE checkpoint every config.CheckpointInterval until the
+// returned stop function is called. It does nothing when no interval is configured.
+func StartCheckpointer(db *gorm.DB, config *Config, logger schemas.Logger) (stop func()) {
+	interval, _ := checkpointInterval(config)
+	if interval == 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := Checkpoint(context.Background(), db); err != nil {
+					logger.Warn("sqlite checkpoint of %s failed: %v", config.Path, err)
+				}
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Checkpoint copies the WAL into the database file and truncates it. Readers still using
+// the WAL keep the checkpoint from completing; it then copies what it can.
+func Checkpoint(ctx context.Context, db *gorm.DB) error {
+	return db.WithContext(ctx).Exec("PRAGMA wal_checkpoint(TRUNCATE)").Error
+}
+
+// Backup writes a consistent snapshot of db to a new database file at destPath, using
+// SQLite's online backup API. Under WAL the backup reads one snapshot while writers carry
+// on, so the database stays available throughout.
+func Backup(ctx context.Context, db *gorm.DB, destPath string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	src, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return src.Raw(func(driverConn any) error {
+		srcConn, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return ErrNotSQLite
+		}
+		dest, err := (&sqlite3.SQLiteDriver{}).Open(destPath)
+		if err != nil {
+			return fmt.Errorf("failed to open backup file: %w", err)
+		}
+		defer dest.Close()
+		backup, err := dest.(*sqlite3.SQLiteConn).Backup("main", srcConn, "main")
+		if err != nil {
+			return fmt.Errorf("failed to start backup: %w", err)
+		}
+		for {
+			// Step reports neither done nor an error while the source is busy or locked.
+			done, err := backup.Step(-1)
+			if err != nil {
+				_ = backup.Finish()
+				return fmt.Errorf("backup failed: %w", err)
+			}
+			if done {
+				return backup.Finish()
+			}
+			select {
+			case <-ctx.Done():
+				_ = backup.Finish()
+				return ctx.Err()
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package sqliteconn
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm/logger"
+)
+
+func TestOpenAppliesWALTuning(t *testing.T) {
+	config := &Config{Path: filepath.Join(t.TempDir(), "tuned.db"), BusyTimeoutMs: 1500, WALAutocheckpoint: 250}
+	db, err := Open(config, logger.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	var journalMode string
+	var busyTimeout, autocheckpoint int
+	db.Raw("PRAGMA journal_mode").Scan(&journalMode)
+	db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout)
+	db.Raw("PRAGMA wal_autocheckpoint").Scan(&autocheckpoint)
+	if journalMode != "wal" || busyTimeout != 1500 || autocheckpoint != 250 {
+		t.Fatalf("expected wal/1500/250, got %s/%d/%d", journalMode, busyTimeout, autocheckpoint)
+	}
+	if err := Checkpoint(context.Background(), db); err != nil {
+		t.Fatalf("checkpoint failed: %v", err)
+	}
+}
+
+func TestBackupSnapshotsLiveDatabase(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(&Config{Path: filepath.Join(dir, "live.db")}, logger.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	if err := db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)").Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		db.Exec("INSERT INTO items (name) VALUES (?)", "item")
+	}
+
+	destPath := filepath.Join(dir, "backup.db")
+	if err := Backup(context.Background(), db, destPath); err != nil {
+		t.Fatalf("backup failed: %v", err)
+	}
+	// The source keeps taking writes after the snapshot.
+	db.Exec("INSERT INTO items (name) VALUES (?)", "later")
+
+	restored, err := Open(&Config{Path: destPath}, logger.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restoredDB, _ := restored.DB()
+	defer restoredDB.Close()
+	var count int
+	if err := restored.Raw("SELECT COUNT(*) FROM items").Scan(&count).Error; err != nil || count != 100 {
+		t.Fatalf("expected 100 rows in the backup, got %d (%v)", count, err)
+	}
+}
+
+func TestValidateRejectsBadTuning(t *testing.T) {
+	for name, config := range map[string]*Config{
+		"no path":             {},
+		"negative timeout":    {Path: "x.db", BusyTimeoutMs: -1},
+		"bad autocheckpoint":  {Path: "x.db", WALAutocheckpoint: -2},
+		"bad interval":        {Path: "x.db", CheckpointInterval: "soon"},
+		"non-positive period": {Path: "x.db", CheckpointInterval: "0s"},
+	} {
+		if err := Validate(config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -55,6 +55,10 @@ func (a *Accumulator) putChatStreamChunk(chunk *ChatStreamChunk) {
 	chunk.FinishReason = nil
 	chunk.TokenUsage = nil
 	chunk.RawResponse = nil
+	chunk.ChoiceIndex = 0
+	chunk.AdditionalChoices = nil
+	chunk.SystemFingerprint = ""
+	chunk.Model = ""
 	a.chatStreamChunkPool.Put(chunk)
 }
 
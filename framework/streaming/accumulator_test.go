@@ -1011,3 +1011,73 @@ func TestChatStreamingFinishReasonOnTerminalChunk(t *testing.T) {
 		t.Fatalf("accumulated finish_reason = %q, want %q", *processed.Data.FinishReason, "stop")
 	}
 }
+
+// TestChatStreamingAccumulatesEachChoice covers n > 1: the deltas of two
+// choices arrive interleaved, once sharing a chunk, and each choice finishes
+// on its own chunk before the synthetic tail.
+func TestChatStreamingAccumulatesEachChoice(t *testing.T) {
+	logger := bifrost.NewDefaultLogger(schemas.LogLevelError)
+	accumulator := NewAccumulator(nil, logger)
+
+	requestID := "multi-choice-request"
+	ctx := schemas.NewBifrostContext(context.Background(), time.Time{})
+	ctx.SetValue(schemas.BifrostContextKeyAccumulatorID, requestID)
+
+	stop, length := "stop", "length"
+	withChoice := func(resp *schemas.BifrostResponse, index int) *schemas.BifrostResponse {
+		resp.ChatResponse.Choices[0].Index = index
+		return resp
+	}
+
+	if _, err := accumulator.processChatStreamingResponse(ctx, withChoice(newChatChunkResponse(0, "Hel", nil, nil), 0), nil); err != nil {
+		t.Fatalf("chunk 0: %v", err)
+	}
+	// One chunk carrying a delta for each choice.
+	shared := withChoice(newChatChunkResponse(1, "Bon", nil, nil), 1)
+	shared.ChatResponse.Choices = append(shared.ChatResponse.Choices, schemas.BifrostResponseChoice{
+		Index:                    0,
+		ChatStreamResponseChoice: &schemas.ChatStreamResponseChoice{Delta: &schemas.ChatStreamResponseChoiceDelta{Content: bifrost.Ptr("lo")}},
+	})
+	if _, err := accumulator.processChatStreamingResponse(ctx, shared, nil); err != nil {
+		t.Fatalf("chunk 1: %v", err)
+	}
+	if _, err := accumulator.processChatStreamingResponse(ctx, withChoice(newChatChunkResponse(2, "jour", &length, nil), 1), nil); err != nil {
+		t.Fatalf("chunk 2: %v", err)
+	}
+	if _, err := accumulator.processChatStreamingResponse(ctx, withChoice(newChatChunkResponse(3, "", &stop, nil), 0), nil); err != nil {
+		t.Fatalf("chunk 3: %v", err)
+	}
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+	processed, err := accumulator.processChatStreamingResponse(ctx, newChatChunkResponse(4, "", nil, &schemas.BifrostLLMUsage{TotalTokens: 12}), nil)
+	if err != nil {
+		t.Fatalf("final chunk: %v", err)
+	}
+	if processed == nil || processed.Data == nil {
+		t.Fatal("expected accumulated data on final chunk")
+	}
+	data := processed.Data
+	if len(data.Choices) != 2 {
+		t.Fatalf("expected 2 accumulated choices, got %d", len(data.Choices))
+	}
+	for i, want := range []struct{ content, finishReason string }{{"Hello", "stop"}, {"Bonjour", "length"}} {
+		choice := data.Choices[i]
+		if choice.Index != i || choice.Message == nil || choice.Message.Content.ContentStr == nil || *choice.Message.Content.ContentStr != want.content {
+			t.Fatalf("choice %d: unexpected message %+v", i, choice.Message)
+		}
+		if choice.FinishReason == nil || *choice.FinishReason != want.finishReason {
+			t.Fatalf("choice %d: finish_reason = %v, want %q", i, choice.FinishReason, want.finishReason)
+		}
+	}
+	// The single-choice fields mirror choice 0.
+	if *data.OutputMessage.Content.ContentStr != "Hello" || *data.FinishReason != "stop" {
+		t.Fatalf("expected choice 0 in OutputMessage and FinishReason, got %q / %q", *data.OutputMessage.Content.ContentStr, *data.FinishReason)
+	}
+
+	resp := processed.ToBifrostResponse()
+	if resp == nil || resp.ChatResponse == nil || len(resp.ChatResponse.Choices) != 2 {
+		t.Fatalf("expected a chat response with 2 choices, got %+v", resp)
+	}
+	if resp.ChatResponse.Choices[1].Index != 1 || *resp.ChatResponse.Choices[1].Message.Content.ContentStr != "Bonjour" {
+		t.Fatalf("unexpected second choice: %+v", resp.ChatResponse.Choices[1])
+	}
+}
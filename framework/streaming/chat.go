@@ -165,15 +165,8 @@ func (a *Accumulator) buildCompleteMessageFromChatStreamChunks(chunks []*ChatStr
 	}
 	var rdAccums map[int]*rdAccum
 
-	// Tool call argument builders keyed by delta index
-	type tcAccum struct {
-		id           *string
-		typ          *string
-		name         *string
-		args         strings.Builder
-		extraContent json.RawMessage
-	}
-	var tcAccums map[uint16]*tcAccum
+	// Tool calls are matched across deltas by ID and index
+	var toolCallAccum *ToolCallAccumulator
 
 	for _, chunk := range chunks {
 		if chunk == nil || chunk.Delta == nil {
@@ -255,36 +248,12 @@ func (a *Accumulator) buildCompleteMessageFromChatStreamChunks(chunks []*ChatStr
 				completeMessage.ChatAssistantMessage.Audio.ExpiresAt = chunk.Delta.Audio.ExpiresAt
 			}
 		}
-		// Accumulate tool calls by index
+		// Accumulate tool calls
 		for _, deltaToolCall := range chunk.Delta.ToolCalls {
-			if tcAccums == nil {
-				tcAccums = make(map[uint16]*tcAccum)
-			}
-			idx := deltaToolCall.Index
-			acc, ok := tcAccums[idx]
-			if !ok {
-				acc = &tcAccum{}
-				tcAccums[idx] = acc
-			}
-			if deltaToolCall.ID != nil {
-				v := *deltaToolCall.ID
-				acc.id = &v
-			}
-			if deltaToolCall.Type != nil {
-				t := *deltaToolCall.Type
-				acc.typ = &t
-			}
-			if deltaToolCall.Function.Name != nil {
-				n := *deltaToolCall.Function.Name
-				acc.name = &n
-			}
-			if args := deltaToolCall.Function.Arguments; args != "" {
-				acc.args.WriteString(args)
-			}
-			if len(deltaToolCall.ExtraContent) > 0 {
-				acc.extraContent = make(json.RawMessage, len(deltaToolCall.ExtraContent))
-				copy(acc.extraContent, deltaToolCall.ExtraContent)
+			if toolCallAccum == nil {
+				toolCallAccum = NewToolCallAccumulator()
 			}
+			toolCallAccum.Add(deltaToolCall)
 		}
 	}
 
@@ -362,34 +331,12 @@ func (a *Accumulator) buildCompleteMessageFromChatStreamChunks(chunks []*ChatStr
 		completeMessage.ChatAssistantMessage.Audio.Transcript = audioTranscriptBuilder.String()
 	}
 
-	// Finalize tool calls — sort by original index for deterministic output
-	if len(tcAccums) > 0 {
+	// Finalize tool calls
+	if toolCallAccum != nil && toolCallAccum.Len() > 0 {
 		if completeMessage.ChatAssistantMessage == nil {
 			completeMessage.ChatAssistantMessage = &schemas.ChatAssistantMessage{}
 		}
-		tcIndices := make([]int, 0, len(tcAccums))
-		for idx := range tcAccums {
-			tcIndices = append(tcIndices, int(idx))
-		}
-		sort.Ints(tcIndices)
-		toolCalls := make([]schemas.ChatAssistantMessageToolCall, 0, len(tcIndices))
-		for _, idx := range tcIndices {
-			acc := tcAccums[uint16(idx)]
-			tc := schemas.ChatAssistantMessageToolCall{
-				Index: uint16(idx),
-				ID:    acc.id,
-				Type:  acc.typ,
-				Function: schemas.ChatAssistantMessageToolCallFunction{
-					Name:      acc.name,
-					Arguments: acc.args.String(),
-				},
-			}
-			if len(acc.extraContent) > 0 {
-				tc.ExtraContent = acc.extraContent
-			}
-			toolCalls = append(toolCalls, tc)
-		}
-		completeMessage.ChatAssistantMessage.ToolCalls = toolCalls
+		completeMessage.ChatAssistantMessage.ToolCalls = toolCallAccum.ToolCalls()
 	}
 
 	return completeMessage
@@ -426,8 +373,14 @@ func (a *Accumulator) processAccumulatedChatStreamingChunks(requestID string, re
 		CacheDebug:       nil,
 		Cost:             nil,
 	}
-	// Build complete message from accumulated chunks
-	completeMessage := a.buildCompleteMessageFromChatStreamChunks(accumulator.ChatStreamChunks)
+	// Build complete message from accumulated chunks. With n > 1 the deltas of
+	// every choice are interleaved, so choice 0 is built from its own chunks.
+	chunksByChoice := splitChatStreamChunksByChoice(accumulator.ChatStreamChunks)
+	messageChunks := accumulator.ChatStreamChunks
+	if chunksByChoice != nil {
+		messageChunks = chunksByChoice[0]
+	}
+	completeMessage := a.buildCompleteMessageFromChatStreamChunks(messageChunks)
 	if !isFinalChunk {
 		data.OutputMessage = completeMessage
 		return data, nil
@@ -445,7 +398,13 @@ func (a *Accumulator) processAccumulatedChatStreamingChunks(requestID string, re
 	data.EndTimestamp = accumulator.FinalTimestamp
 	data.OutputMessage = completeMessage
 	if data.OutputMessage.ChatAssistantMessage != nil && data.OutputMessage.ChatAssistantMessage.ToolCalls != nil {
-		data.ToolCalls = data.OutputMessage.ChatAssistantMessage.ToolCalls
+		// Only calls with complete arguments reach plugins, as in a
+		// non-streaming response; truncated ones are set aside.
+		data.ToolCalls, data.InvalidToolCalls = SplitCompleteToolCalls(data.OutputMessage.ChatAssistantMessage.ToolCalls)
+		data.OutputMessage.ChatAssistantMessage.ToolCalls = data.ToolCalls
+		for _, invalid := range data.InvalidToolCalls {
+			a.logger.Warn("[streaming] dropping incomplete tool call %s for request %s: %s", toolCallLabel(invalid.ToolCall), requestID, invalid.Error)
+		}
 	}
 	data.ErrorDetails = respErr
 	// Update metadata from the chunk with highest index (contains TokenUsage, Cost, FinishReason)
@@ -470,21 +429,19 @@ func (a *Accumulator) processAccumulatedChatStreamingChunks(requestID string, re
 		data.FinishReason = accumulator.getChatFinishReasonLocked()
 	}
 	// Merge LogProbs from all chunks
-	if len(accumulator.ChatStreamChunks) > 0 {
-		var mergedLogProbs *schemas.BifrostLogProbs
-		for _, chunk := range accumulator.ChatStreamChunks {
-			if chunk.LogProbs != nil {
-				if mergedLogProbs == nil {
-					mergedLogProbs = &schemas.BifrostLogProbs{}
-				}
-				mergedLogProbs.Content = append(mergedLogProbs.Content, chunk.LogProbs.Content...)
-				mergedLogProbs.Refusal = append(mergedLogProbs.Refusal, chunk.LogProbs.Refusal...)
-				if chunk.LogProbs.TextCompletionLogProb != nil {
-					mergedLogProbs.TextCompletionLogProb = chunk.LogProbs.TextCompletionLogProb
-				}
-			}
+	data.LogProbs = mergeChatStreamLogProbs(messageChunks)
+	// The first provider-reported values win: the synthetic final chunk may
+	// carry the requested model rather than the one the provider echoed.
+	for _, chunk := range accumulator.ChatStreamChunks {
+		if data.SystemFingerprint == "" && chunk.SystemFingerprint != "" {
+			data.SystemFingerprint = chunk.SystemFingerprint
 		}
-		data.LogProbs = mergedLogProbs
+		if data.ModelRevision == "" && chunk.Model != "" {
+			data.ModelRevision = chunk.Model
+		}
+	}
+	if chunksByChoice != nil {
+		a.accumulateChatChoices(data, chunksByChoice)
 	}
 	// Accumulate raw response using strings.Builder to avoid O(n^2) string concatenation
 	if len(accumulator.ChatStreamChunks) > 0 {
@@ -509,6 +466,80 @@ func (a *Accumulator) processAccumulatedChatStreamingChunks(requestID string, re
 	return data, nil
 }
 
+// mergeChatStreamLogProbs merges the LogProbs of chunks in order.
+func mergeChatStreamLogProbs(chunks []*ChatStreamChunk) *schemas.BifrostLogProbs {
+	var mergedLogProbs *schemas.BifrostLogProbs
+	for _, chunk := range chunks {
+		if chunk.LogProbs != nil {
+			if mergedLogProbs == nil {
+				mergedLogProbs = &schemas.BifrostLogProbs{}
+			}
+			mergedLogProbs.Content = append(mergedLogProbs.Content, chunk.LogProbs.Content...)
+			mergedLogProbs.Refusal = append(mergedLogProbs.Refusal, chunk.LogProbs.Refusal...)
+			if chunk.LogProbs.TextCompletionLogProb != nil {
+				mergedLogProbs.TextCompletionLogProb = chunk.LogProbs.TextCompletionLogProb
+			}
+		}
+	}
+	return mergedLogProbs
+}
+
+// splitChatStreamChunksByChoice groups the chunks of a stream with n > 1 by
+// choice index, flattening choices that shared a chunk. It returns nil when
+// every delta belongs to choice 0, the common case.
+func splitChatStreamChunksByChoice(chunks []*ChatStreamChunk) map[int][]*ChatStreamChunk {
+	multi := false
+	for _, chunk := range chunks {
+		if chunk.ChoiceIndex != 0 || len(chunk.AdditionalChoices) > 0 {
+			multi = true
+			break
+		}
+	}
+	if !multi {
+		return nil
+	}
+	byChoice := make(map[int][]*ChatStreamChunk)
+	for _, chunk := range chunks {
+		byChoice[chunk.ChoiceIndex] = append(byChoice[chunk.ChoiceIndex], chunk)
+		for _, additional := range chunk.AdditionalChoices {
+			additional.ChunkIndex = chunk.ChunkIndex
+			byChoice[additional.ChoiceIndex] = append(byChoice[additional.ChoiceIndex], additional)
+		}
+	}
+	return byChoice
+}
+
+// accumulateChatChoices fills data.Choices from chunks grouped by choice, and
+// points the choice-0 fields of data at choice 0.
+func (a *Accumulator) accumulateChatChoices(data *AccumulatedData, chunksByChoice map[int][]*ChatStreamChunk) {
+	indices := make([]int, 0, len(chunksByChoice))
+	for index := range chunksByChoice {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	data.Choices = make([]AccumulatedChoice, 0, len(indices))
+	for _, index := range indices {
+		chunks := chunksByChoice[index]
+		choice := AccumulatedChoice{Index: index, LogProbs: mergeChatStreamLogProbs(chunks)}
+		if index == 0 {
+			choice.Message = data.OutputMessage
+		} else {
+			choice.Message = a.buildCompleteMessageFromChatStreamChunks(chunks)
+		}
+		// Chunks are sorted by the message builder; the last finish reason wins.
+		for _, chunk := range chunks {
+			if chunk.FinishReason != nil {
+				choice.FinishReason = chunk.FinishReason
+			}
+		}
+		data.Choices = append(data.Choices, choice)
+	}
+	// The stream-level finish reason may belong to any choice; report choice 0's.
+	if data.Choices[0].Index == 0 && data.Choices[0].FinishReason != nil {
+		data.FinishReason = data.Choices[0].FinishReason
+	}
+}
+
 // processChatStreamingResponse processes a chat streaming response
 func (a *Accumulator) processChatStreamingResponse(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*ProcessedStreamResponse, error) {
 	a.logger.Debug("[streaming] processing chat streaming response")
@@ -533,23 +564,34 @@ func (a *Accumulator) processChatStreamingResponse(ctx *schemas.BifrostContext,
 		chunk.FinishReason = bifrost.Ptr("error")
 	} else if result != nil && result.TextCompletionResponse != nil {
 		// Handle text completion response directly
-		if len(result.TextCompletionResponse.Choices) > 0 {
-			choice := result.TextCompletionResponse.Choices[0]
-
-			if choice.TextCompletionResponseChoice != nil {
-				deltaCopy := choice.TextCompletionResponseChoice.Text
-				chunk.Delta = &schemas.ChatStreamResponseChoiceDelta{
-					Content: deltaCopy,
-				}
-				chunk.FinishReason = choice.FinishReason
-				chunk.LogProbs = choice.LogProbs
-			}
+		// Streams with n > 1 interleave the text of every choice; a chunk
+		// carrying several choices keeps the rest in AdditionalChoices.
+		filled := false
+		for _, choice := range result.TextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice == nil {
+				continue
+			}
+			target := chunk
+			if filled {
+				target = &ChatStreamChunk{Timestamp: chunk.Timestamp}
+				chunk.AdditionalChoices = append(chunk.AdditionalChoices, target)
+			}
+			filled = true
+			deltaCopy := choice.TextCompletionResponseChoice.Text
+			target.Delta = &schemas.ChatStreamResponseChoiceDelta{
+				Content: deltaCopy,
+			}
+			target.ChoiceIndex = choice.Index
+			target.FinishReason = choice.FinishReason
+			target.LogProbs = choice.LogProbs
 		}
 		// Extract token usage
 		if result.TextCompletionResponse.Usage != nil && result.TextCompletionResponse.Usage.TotalTokens > 0 {
 			chunk.TokenUsage = result.TextCompletionResponse.Usage
 		}
 		chunk.ChunkIndex = result.TextCompletionResponse.ExtraFields.ChunkIndex
+		chunk.SystemFingerprint = result.TextCompletionResponse.SystemFingerprint
+		chunk.Model = result.TextCompletionResponse.Model
 		if result.TextCompletionResponse.ExtraFields.RawResponse != nil {
 			chunk.RawResponse = bifrost.Ptr(fmt.Sprintf("%v", result.TextCompletionResponse.ExtraFields.RawResponse))
 		}
@@ -562,20 +604,32 @@ func (a *Accumulator) processChatStreamingResponse(ctx *schemas.BifrostContext,
 		}
 	} else if result != nil && result.ChatResponse != nil {
 		// Extract delta and other information
-		if len(result.ChatResponse.Choices) > 0 {
-			choice := result.ChatResponse.Choices[0]
-			if choice.ChatStreamResponseChoice != nil {
-				// Deep copy delta to prevent shared data mutation between chunks
-				chunk.Delta = deepCopyChatStreamDelta(choice.ChatStreamResponseChoice.Delta)
-				chunk.FinishReason = choice.FinishReason
-				chunk.LogProbs = choice.LogProbs
-			}
+		// Streams with n > 1 interleave the deltas of every choice; a chunk
+		// carrying several choices keeps the rest in AdditionalChoices.
+		filled := false
+		for _, choice := range result.ChatResponse.Choices {
+			if choice.ChatStreamResponseChoice == nil {
+				continue
+			}
+			target := chunk
+			if filled {
+				target = &ChatStreamChunk{Timestamp: chunk.Timestamp}
+				chunk.AdditionalChoices = append(chunk.AdditionalChoices, target)
+			}
+			filled = true
+			target.ChoiceIndex = choice.Index
+			// Deep copy delta to prevent shared data mutation between chunks
+			target.Delta = deepCopyChatStreamDelta(choice.ChatStreamResponseChoice.Delta)
+			target.FinishReason = choice.FinishReason
+			target.LogProbs = choice.LogProbs
 		}
 		// Extract token usage
 		if result.ChatResponse.Usage != nil && result.ChatResponse.Usage.TotalTokens > 0 {
 			chunk.TokenUsage = result.ChatResponse.Usage
 		}
 		chunk.ChunkIndex = result.ChatResponse.ExtraFields.ChunkIndex
+		chunk.SystemFingerprint = result.ChatResponse.SystemFingerprint
+		chunk.Model = result.ChatResponse.Model
 		if result.ChatResponse.ExtraFields.RawResponse != nil {
 			chunk.RawResponse = bifrost.Ptr(fmt.Sprintf("%v", result.ChatResponse.ExtraFields.RawResponse))
 		}
@@ -0,0 +1,185 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// ToolCallAccumulator reassembles streamed tool call deltas into complete tool
+// calls. Deltas are matched to a call by ID when they carry one and by index
+// otherwise, so providers that interleave several calls, reuse an index for
+// distinct calls, or send the ID after the first fragment are all handled.
+type ToolCallAccumulator struct {
+	calls   []*toolCallState
+	byID    map[string]*toolCallState
+	byIndex map[uint16]*toolCallState // the latest call seen at each index
+}
+
+type toolCallState struct {
+	index        uint16
+	id           *string
+	typ          *string
+	name         *string
+	args         strings.Builder
+	extraContent json.RawMessage
+}
+
+// InvalidToolCall is a streamed tool call whose arguments are not a complete
+// JSON value, typically because the stream ended mid-call.
+type InvalidToolCall struct {
+	ToolCall schemas.ChatAssistantMessageToolCall
+	Error    string
+}
+
+// NewToolCallAccumulator returns an empty ToolCallAccumulator.
+func NewToolCallAccumulator() *ToolCallAccumulator {
+	return &ToolCallAccumulator{
+		byID:    make(map[string]*toolCallState),
+		byIndex: make(map[uint16]*toolCallState),
+	}
+}
+
+// Add folds one tool call delta into the accumulated calls.
+func (t *ToolCallAccumulator) Add(delta schemas.ChatAssistantMessageToolCall) {
+	call := t.callFor(delta)
+	if delta.Type != nil {
+		typ := *delta.Type
+		call.typ = &typ
+	}
+	if delta.Function.Name != nil {
+		name := *delta.Function.Name
+		call.name = &name
+	}
+	if args := delta.Function.Arguments; args != "" {
+		// Some providers repeat the full arguments once the call is done;
+		// a complete value after a complete value replaces rather than appends.
+		if call.args.Len() > 0 && looksLikeObject(args) && looksLikeObject(call.args.String()) &&
+			validateToolCallArguments(args) == nil && validateToolCallArguments(call.args.String()) == nil {
+			call.args.Reset()
+		}
+		call.args.WriteString(args)
+	}
+	if len(delta.ExtraContent) > 0 {
+		call.extraContent = make(json.RawMessage, len(delta.ExtraContent))
+		copy(call.extraContent, delta.ExtraContent)
+	}
+}
+
+// callFor returns the call delta belongs to, starting a new one when needed.
+func (t *ToolCallAccumulator) callFor(delta schemas.ChatAssistantMessageToolCall) *toolCallState {
+	id := ""
+	if delta.ID != nil {
+		id = *delta.ID
+	}
+	if id != "" {
+		if call, ok := t.byID[id]; ok {
+			return call
+		}
+		// An ID arriving after the first fragment of the call at this index.
+		if call, ok := t.byIndex[delta.Index]; ok && call.id == nil {
+			call.id = &id
+			t.byID[id] = call
+			return call
+		}
+	} else if call, ok := t.byIndex[delta.Index]; ok {
+		return call
+	}
+	call := &toolCallState{index: delta.Index}
+	if id != "" {
+		call.id = &id
+		t.byID[id] = call
+	}
+	t.calls = append(t.calls, call)
+	t.byIndex[delta.Index] = call
+	return call
+}
+
+// Len returns how many tool calls have been seen.
+func (t *ToolCallAccumulator) Len() int {
+	return len(t.calls)
+}
+
+// ToolCalls returns the accumulated calls ordered by index, then by arrival,
+// and re-indexed from zero as in a non-streaming response. Calls without
+// arguments get "{}".
+func (t *ToolCallAccumulator) ToolCalls() []schemas.ChatAssistantMessageToolCall {
+	if len(t.calls) == 0 {
+		return nil
+	}
+	ordered := slices.Clone(t.calls)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].index < ordered[j].index
+	})
+	toolCalls := make([]schemas.ChatAssistantMessageToolCall, 0, len(ordered))
+	for i, call := range ordered {
+		args := call.args.String()
+		if strings.TrimSpace(args) == "" {
+			args = "{}"
+		}
+		tc := schemas.ChatAssistantMessageToolCall{
+			Index: uint16(i),
+			ID:    call.id,
+			Type:  call.typ,
+			Function: schemas.ChatAssistantMessageToolCallFunction{
+				Name:      call.name,
+				Arguments: args,
+			},
+		}
+		if len(call.extraContent) > 0 {
+			tc.ExtraContent = call.extraContent
+		}
+		toolCalls = append(toolCalls, tc)
+	}
+	return toolCalls
+}
+
+// SplitCompleteToolCalls separates tool calls whose arguments are a complete
+// JSON object from those that are not, so consumers never act on truncated
+// arguments.
+func SplitCompleteToolCalls(toolCalls []schemas.ChatAssistantMessageToolCall) ([]schemas.ChatAssistantMessageToolCall, []InvalidToolCall) {
+	var complete []schemas.ChatAssistantMessageToolCall
+	var invalid []InvalidToolCall
+	for _, tc := range toolCalls {
+		if err := validateToolCallArguments(tc.Function.Arguments); err != nil {
+			invalid = append(invalid, InvalidToolCall{ToolCall: tc, Error: err.Error()})
+			continue
+		}
+		complete = append(complete, tc)
+	}
+	return complete, invalid
+}
+
+// looksLikeObject is a cheap check that s may be a whole JSON object, so that
+// fragments are not parsed on every delta.
+func looksLikeObject(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")
+}
+
+func validateToolCallArguments(args string) error {
+	var value any
+	if err := json.Unmarshal([]byte(args), &value); err != nil {
+		return fmt.Errorf("arguments are not valid JSON: %w", err)
+	}
+	if _, ok := value.(map[string]any); !ok {
+		return fmt.Errorf("arguments must be a JSON object")
+	}
+	return nil
+}
+
+// toolCallLabel names a tool call in log messages.
+func toolCallLabel(tc schemas.ChatAssistantMessageToolCall) string {
+	name := "<unnamed>"
+	if tc.Function.Name != nil {
+		name = *tc.Function.Name
+	}
+	if tc.ID != nil {
+		return name + " (" + *tc.ID + ")"
+	}
+	return name
+}
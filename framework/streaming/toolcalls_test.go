@@ -0,0 +1,94 @@
+package streaming
+
+import (
+	"testing"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func toolCallDelta(index uint16, id, name *string, args string) schemas.ChatAssistantMessageToolCall {
+	return schemas.ChatAssistantMessageToolCall{
+		Index: index,
+		ID:    id,
+		Function: schemas.ChatAssistantMessageToolCallFunction{
+			Name:      name,
+			Arguments: args,
+		},
+	}
+}
+
+// TestToolCallAccumulatorMatchesByID covers providers that stream several
+// calls at the same index, distinguished only by ID.
+func TestToolCallAccumulatorMatchesByID(t *testing.T) {
+	acc := NewToolCallAccumulator()
+	acc.Add(toolCallDelta(0, bifrost.Ptr("call_a"), bifrost.Ptr("get_weather"), `{"city":`))
+	acc.Add(toolCallDelta(0, bifrost.Ptr("call_b"), bifrost.Ptr("get_time"), `{"tz":`))
+	acc.Add(toolCallDelta(0, bifrost.Ptr("call_a"), nil, `"Paris"}`))
+	acc.Add(toolCallDelta(0, bifrost.Ptr("call_b"), nil, `"CET"}`))
+
+	calls := acc.ToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+	if *calls[0].ID != "call_a" || calls[0].Function.Arguments != `{"city":"Paris"}` || calls[0].Index != 0 {
+		t.Fatalf("unexpected first call: %+v", calls[0])
+	}
+	if *calls[1].ID != "call_b" || calls[1].Function.Arguments != `{"tz":"CET"}` || calls[1].Index != 1 {
+		t.Fatalf("unexpected second call: %+v", calls[1])
+	}
+}
+
+// TestToolCallAccumulatorLateIDAndRepeatedArguments covers an ID that arrives
+// after the first fragment, a provider that repeats the full arguments at the
+// end of the call, and a call without arguments.
+func TestToolCallAccumulatorLateIDAndRepeatedArguments(t *testing.T) {
+	acc := NewToolCallAccumulator()
+	acc.Add(toolCallDelta(1, nil, bifrost.Ptr("search"), `{"q": "go`))
+	acc.Add(toolCallDelta(1, bifrost.Ptr("call_1"), nil, `lang"}`))
+	acc.Add(toolCallDelta(1, bifrost.Ptr("call_1"), nil, `{"q": "golang"}`))
+	acc.Add(toolCallDelta(0, bifrost.Ptr("call_0"), bifrost.Ptr("list_files"), ""))
+
+	calls := acc.ToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+	if *calls[0].Function.Name != "list_files" || calls[0].Function.Arguments != "{}" {
+		t.Fatalf("calls are ordered by index and empty arguments become {}: %+v", calls[0])
+	}
+	if *calls[1].ID != "call_1" || calls[1].Function.Arguments != `{"q": "golang"}` {
+		t.Fatalf("unexpected search call: id=%v args=%s", calls[1].ID, calls[1].Function.Arguments)
+	}
+}
+
+// TestChatStreamingDropsTruncatedToolCalls verifies plugins only see tool
+// calls with complete arguments once the stream ends.
+func TestChatStreamingDropsTruncatedToolCalls(t *testing.T) {
+	accumulator := NewAccumulator(nil, bifrost.NewDefaultLogger(schemas.LogLevelError))
+	requestID := "req-truncated-tool-call"
+	deltas := [][]schemas.ChatAssistantMessageToolCall{
+		{toolCallDelta(0, bifrost.Ptr("call_0"), bifrost.Ptr("add"), `{"a": 1, `)},
+		{toolCallDelta(1, bifrost.Ptr("call_1"), bifrost.Ptr("delete_file"), `{"path": "/tmp/`)},
+		{toolCallDelta(0, nil, nil, `"b": 2}`)},
+	}
+	for i, delta := range deltas {
+		chunk := &ChatStreamChunk{ChunkIndex: i, Delta: &schemas.ChatStreamResponseChoiceDelta{ToolCalls: delta}}
+		if err := accumulator.addChatStreamChunk(requestID, StreamTypeChat, chunk, i == len(deltas)-1); err != nil {
+			t.Fatalf("failed to add chunk %d: %v", i, err)
+		}
+	}
+
+	data, err := accumulator.processAccumulatedChatStreamingChunks(requestID, nil, true)
+	if err != nil {
+		t.Fatalf("processAccumulatedChatStreamingChunks: %v", err)
+	}
+	if len(data.ToolCalls) != 1 || *data.ToolCalls[0].Function.Name != "add" || data.ToolCalls[0].Function.Arguments != `{"a": 1, "b": 2}` {
+		t.Fatalf("expected only the complete add call, got %+v", data.ToolCalls)
+	}
+	if got := data.OutputMessage.ChatAssistantMessage.ToolCalls; len(got) != 1 {
+		t.Fatalf("output message should carry only complete calls, got %d", len(got))
+	}
+	if len(data.InvalidToolCalls) != 1 || *data.InvalidToolCalls[0].ToolCall.Function.Name != "delete_file" {
+		t.Fatalf("expected the truncated delete_file call to be set aside, got %+v", data.InvalidToolCalls)
+	}
+}
@@ -33,6 +33,7 @@ type AccumulatedData struct {
 	OutputMessage         *schemas.ChatMessage
 	OutputMessages        []schemas.ResponsesMessage // For responses API
 	ToolCalls             []schemas.ChatAssistantMessageToolCall
+	InvalidToolCalls      []InvalidToolCall // Streamed tool calls left out of ToolCalls because their arguments are incomplete
 	ErrorDetails          *schemas.BifrostError
 	TokenUsage            *schemas.BifrostLLMUsage
 	CacheDebug            *schemas.BifrostCacheDebug
@@ -44,6 +45,20 @@ type AccumulatedData struct {
 	FinishReason          *string
 	LogProbs              *schemas.BifrostLogProbs
 	RawResponse           *string
+	SystemFingerprint     string // Provider system_fingerprint, for chat and text completion streams
+	ModelRevision         string // Model name the provider echoed back, for chat and text completion streams
+	// Choices holds every choice of a chat or text completion stream with
+	// n > 1, ordered by index. OutputMessage, FinishReason and LogProbs mirror
+	// choice 0. Nil for single-choice streams.
+	Choices []AccumulatedChoice
+}
+
+// AccumulatedChoice is one choice of a chat or text completion stream with n > 1.
+type AccumulatedChoice struct {
+	Index        int
+	Message      *schemas.ChatMessage
+	FinishReason *string
+	LogProbs     *schemas.BifrostLogProbs
 }
 
 // AudioStreamChunk represents a single streaming chunk
@@ -84,6 +99,10 @@ type ChatStreamChunk struct {
 	ErrorDetails       *schemas.BifrostError                  // Error if any
 	ChunkIndex         int                                    // Index of the chunk in the stream
 	RawResponse        *string                                // Raw response if available
+	ChoiceIndex        int                                    // Index of the choice the delta belongs to (n > 1)
+	AdditionalChoices  []*ChatStreamChunk                     // Deltas of further choices carried by the same chunk
+	SystemFingerprint  string                                 // Provider system_fingerprint if reported on this chunk
+	Model              string                                 // Model name the provider echoed on this chunk
 }
 
 // ResponsesStreamChunk represents a single responses streaming chunk
@@ -340,6 +359,23 @@ func (p *ProcessedStreamResponse) ToBifrostResponse() *schemas.BifrostResponse {
 			},
 			Usage: p.Data.TokenUsage,
 		}
+		if len(p.Data.Choices) > 0 {
+			textResp.Choices = make([]schemas.BifrostResponseChoice, 0, len(p.Data.Choices))
+			for _, choice := range p.Data.Choices {
+				text := ""
+				if choice.Message != nil && choice.Message.Content != nil && choice.Message.Content.ContentStr != nil {
+					text = *choice.Message.Content.ContentStr
+				}
+				textResp.Choices = append(textResp.Choices, schemas.BifrostResponseChoice{
+					Index:        choice.Index,
+					FinishReason: choice.FinishReason,
+					LogProbs:     choice.LogProbs,
+					TextCompletionResponseChoice: &schemas.TextCompletionResponseChoice{
+						Text: &text,
+					},
+				})
+			}
+		}
 
 		resp.TextCompletionResponse = textResp
 		resp.TextCompletionResponse.ExtraFields = schemas.BifrostResponseExtraFields{
@@ -392,6 +428,19 @@ func (p *ProcessedStreamResponse) ToBifrostResponse() *schemas.BifrostResponse {
 			},
 			Usage: usage,
 		}
+		if len(p.Data.Choices) > 0 {
+			chatResp.Choices = make([]schemas.BifrostResponseChoice, 0, len(p.Data.Choices))
+			for _, choice := range p.Data.Choices {
+				chatResp.Choices = append(chatResp.Choices, schemas.BifrostResponseChoice{
+					Index:        choice.Index,
+					FinishReason: choice.FinishReason,
+					LogProbs:     choice.LogProbs,
+					ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+						Message: choice.Message,
+					},
+				})
+			}
+		}
 
 		resp.ChatResponse = chatResp
 		resp.ChatResponse.ExtraFields = schemas.BifrostResponseExtraFields{
@@ -2,6 +2,7 @@
 package tracing
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/valyala/fasthttp"
@@ -69,6 +70,15 @@ type W3CTraceContext struct {
 	TraceState string // Optional vendor-specific trace state
 }
 
+// Sampled reports whether the upstream service sampled the trace (trace-flags bit 0).
+func (c *W3CTraceContext) Sampled() bool {
+	if c == nil {
+		return false
+	}
+	flags, err := strconv.ParseUint(c.TraceFlags, 16, 8)
+	return err == nil && flags&0x01 == 0x01
+}
+
 // ExtractParentID extracts the trace ID from W3C traceparent header.
 // This returns the trace ID (32 hex chars) which should be used to continue
 // the distributed trace from the upstream service.
@@ -57,6 +57,28 @@ func TestParseTraceparent_ValidHeader(t *testing.T) {
 	}
 }
 
+func TestW3CTraceContext_Sampled(t *testing.T) {
+	tests := []struct {
+		flags string
+		want  bool
+	}{
+		{flags: "01", want: true},
+		{flags: "00", want: false},
+		{flags: "03", want: true},
+		{flags: "02", want: false},
+	}
+	for _, tt := range tests {
+		ctx := ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-" + tt.flags)
+		if got := ctx.Sampled(); got != tt.want {
+			t.Errorf("Sampled() with flags %s = %v, want %v", tt.flags, got, tt.want)
+		}
+	}
+	var nilCtx *W3CTraceContext
+	if nilCtx.Sampled() {
+		t.Error("Sampled() on nil context should be false")
+	}
+}
+
 func TestParseTraceparent_InvalidVersion(t *testing.T) {
 	// Only version 00 is supported
 	tests := []struct {
@@ -661,6 +661,8 @@ func (t *Tracer) ProcessStreamingChunk(ctx *schemas.BifrostContext, traceID stri
 		accResult.PassthroughOutput = processedResp.Data.PassthroughOutput
 		accResult.FinishReason = processedResp.Data.FinishReason
 		accResult.RawResponse = processedResp.Data.RawResponse
+		accResult.SystemFingerprint = processedResp.Data.SystemFingerprint
+		accResult.ModelRevision = processedResp.Data.ModelRevision
 
 		if (accResult.Cost == nil || *accResult.Cost == 0.0) && accResult.TokenUsage != nil && accResult.TokenUsage.Cost != nil {
 			accResult.Cost = &accResult.TokenUsage.Cost.TotalCost
@@ -0,0 +1,469 @@
+package vectorstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/postgresconn"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// pgvectorMaxIndexedDimension is the largest vector(n) pgvector can build an
+// HNSW index on. Larger embeddings are stored and searched exactly.
+const pgvectorMaxIndexedDimension = 2000
+
+// PgVectorConfig represents the configuration for the pgvector vector store.
+// Each namespace is a table in the configured database, which needs the
+// vector extension available (it is created if missing).
+type PgVectorConfig struct {
+	postgresconn.Config
+}
+
+// PgVectorStore represents the pgvector vector store. Entries are rows of
+// (id, embedding, metadata) with the metadata kept as JSONB, so namespaces
+// need no schema for their properties.
+type PgVectorStore struct {
+	gormDB *gorm.DB
+	db     *sql.DB
+	logger schemas.Logger
+}
+
+// Ping checks if the database is reachable.
+func (s *PgVectorStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// CreateNamespace creates the table of a namespace with a cosine HNSW index on
+// its embeddings and a GIN index on its metadata. Properties need no columns;
+// every metadata field is filterable through the GIN index.
+func (s *PgVectorStore) CreateNamespace(ctx context.Context, namespace string, dimension int, properties map[string]VectorStoreProperties) error {
+	if dimension <= 0 {
+		return fmt.Errorf("dimension must be positive")
+	}
+	if _, err := s.db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS vector"); err != nil {
+		return fmt.Errorf("failed to create vector extension: %w", err)
+	}
+
+	table := pgvectorTable(namespace)
+	var existingDim sql.NullInt64
+	err := s.db.QueryRowContext(ctx, "SELECT atttypmod FROM pg_attribute WHERE attrelid = to_regclass($1) AND attname = 'embedding'", table).Scan(&existingDim)
+	switch {
+	case err == nil:
+		if existingDim.Valid && int(existingDim.Int64) != dimension {
+			return fmt.Errorf("namespace %q already exists with dimension %d but config requires %d — update vector_store_namespace to a new name or drop the existing table manually", namespace, existingDim.Int64, dimension)
+		}
+	case err != sql.ErrNoRows:
+		return fmt.Errorf("failed to check table existence: %w", err)
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, embedding vector(%d), metadata JSONB NOT NULL DEFAULT '{}'::jsonb)", table, dimension),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING gin (metadata jsonb_path_ops)", pgx.Identifier{namespace + "_metadata_idx"}.Sanitize(), table),
+	}
+	if dimension <= pgvectorMaxIndexedDimension {
+		statements = append(statements, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING hnsw (embedding vector_cosine_ops)", pgx.Identifier{namespace + "_embedding_idx"}.Sanitize(), table))
+	} else {
+		s.logger.Warn(fmt.Sprintf("pgvector cannot index %d-dimensional vectors (max %d); nearest-neighbour search on %q will scan the table", dimension, pgvectorMaxIndexedDimension, namespace))
+	}
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create namespace: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteNamespace drops the table of a namespace.
+func (s *PgVectorStore) DeleteNamespace(ctx context.Context, namespace string) error {
+	_, err := s.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+pgvectorTable(namespace))
+	return err
+}
+
+// GetChunk retrieves a single entry from the pgvector vector store.
+func (s *PgVectorStore) GetChunk(ctx context.Context, namespace string, id string) (SearchResult, error) {
+	if strings.TrimSpace(id) == "" {
+		return SearchResult{}, fmt.Errorf("id is required")
+	}
+	var metadata []byte
+	err := s.db.QueryRowContext(ctx, "SELECT metadata FROM "+pgvectorTable(namespace)+" WHERE id = $1", id).Scan(&metadata)
+	if err == sql.ErrNoRows {
+		return SearchResult{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("failed to get entry: %w", err)
+	}
+	properties, err := decodePgVectorMetadata(metadata)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	return SearchResult{ID: id, Properties: properties}, nil
+}
+
+// GetChunks retrieves multiple entries from the pgvector vector store.
+func (s *PgVectorStore) GetChunks(ctx context.Context, namespace string, ids []string) ([]SearchResult, error) {
+	if len(ids) == 0 {
+		return []SearchResult{}, nil
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT id, metadata FROM "+pgvectorTable(namespace)+" WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+	return scanPgVectorResults(rows, nil, false)
+}
+
+// GetAll retrieves all entries with optional filtering and pagination. The
+// cursor is the last ID of the previous page.
+func (s *PgVectorStore) GetAll(ctx context.Context, namespace string, queries []Query, selectFields []string, cursor *string, limit int64) ([]SearchResult, *string, error) {
+	var args pgvectorArgs
+	conditions, err := buildPgVectorFilter(queries, &args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cursor != nil && *cursor != "" {
+		conditions = append(conditions, "id > "+args.add(*cursor))
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	query := "SELECT id, metadata FROM " + pgvectorTable(namespace) + pgvectorWhere(conditions) + " ORDER BY id LIMIT " + args.add(limit)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list entries: %w", err)
+	}
+	results, err := scanPgVectorResults(rows, selectFields, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(results)) >= limit {
+		lastID := results[len(results)-1].ID
+		return results, &lastID, nil
+	}
+	return results, nil, nil
+}
+
+// GetNearest retrieves the entries most similar to vector by cosine
+// similarity, keeping those at or above threshold.
+func (s *PgVectorStore) GetNearest(ctx context.Context, namespace string, vector []float32, queries []Query, selectFields []string, threshold float64, limit int64) ([]SearchResult, error) {
+	if len(vector) == 0 {
+		return nil, fmt.Errorf("vector is required")
+	}
+	var args pgvectorArgs
+	embedding := args.add(formatPgVector(vector))
+	conditions, err := buildPgVectorFilter(queries, &args)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions,
+		"embedding IS NOT NULL",
+		fmt.Sprintf("1 - (embedding <=> %s::vector) >= %s", embedding, args.add(threshold)),
+	)
+	if limit <= 0 {
+		limit = 10
+	}
+	query := fmt.Sprintf("SELECT id, metadata, 1 - (embedding <=> %s::vector) AS score FROM %s%s ORDER BY embedding <=> %s::vector LIMIT %s",
+		embedding, pgvectorTable(namespace), pgvectorWhere(conditions), embedding, args.add(limit))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search entries: %w", err)
+	}
+	return scanPgVectorResults(rows, selectFields, true)
+}
+
+// Add stores an entry, replacing any entry with the same id.
+func (s *PgVectorStore) Add(ctx context.Context, namespace string, id string, embedding []float32, metadata map[string]interface{}) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("id is required")
+	}
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	var vector any
+	if len(embedding) > 0 {
+		vector = formatPgVector(embedding)
+	}
+	_, err = s.db.ExecContext(ctx,
+		"INSERT INTO "+pgvectorTable(namespace)+" (id, embedding, metadata) VALUES ($1, $2::vector, $3::jsonb) "+
+			"ON CONFLICT (id) DO UPDATE SET embedding = EXCLUDED.embedding, metadata = EXCLUDED.metadata",
+		id, vector, string(metadataJSON))
+	if err != nil {
+		return fmt.Errorf("failed to upsert entry: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an entry from the pgvector vector store.
+func (s *PgVectorStore) Delete(ctx context.Context, namespace string, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("id is required")
+	}
+	_, err := s.db.ExecContext(ctx, "DELETE FROM "+pgvectorTable(namespace)+" WHERE id = $1", id)
+	return err
+}
+
+// DeleteAll removes the entries matching the filter, or every entry without one.
+func (s *PgVectorStore) DeleteAll(ctx context.Context, namespace string, queries []Query) ([]DeleteResult, error) {
+	var args pgvectorArgs
+	conditions, err := buildPgVectorFilter(queries, &args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, "DELETE FROM "+pgvectorTable(namespace)+pgvectorWhere(conditions)+" RETURNING id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete entries: %w", err)
+	}
+	defer rows.Close()
+	results := []DeleteResult{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read deleted entry: %w", err)
+		}
+		results = append(results, DeleteResult{ID: id, Status: DeleteStatusSuccess})
+	}
+	return results, rows.Err()
+}
+
+// Close closes the database connection.
+func (s *PgVectorStore) Close(ctx context.Context, namespace string) error {
+	postgresconn.Close(s.gormDB, s.logger)
+	return nil
+}
+
+// RequiresVectors returns false: the embedding column is nullable, so
+// metadata-only entries can be stored.
+func (s *PgVectorStore) RequiresVectors() bool {
+	return false
+}
+
+// newPgVectorStore creates a new pgvector vector store.
+func newPgVectorStore(ctx context.Context, config *PgVectorConfig, logger schemas.Logger) (*PgVectorStore, error) {
+	if err := postgresconn.Validate(&config.Config, false); err != nil {
+		return nil, err
+	}
+	gormDB, err := postgresconn.Open(postgresconn.BuildDSN(&config.Config), &config.Config, gormlogger.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pgvector database: %w", err)
+	}
+	if err := postgresconn.ApplyPoolTuning(gormDB, &config.Config); err != nil {
+		postgresconn.Close(gormDB, logger)
+		return nil, err
+	}
+	db, err := gormDB.DB()
+	if err != nil {
+		postgresconn.Close(gormDB, logger)
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		postgresconn.Close(gormDB, logger)
+		return nil, fmt.Errorf("failed to connect to pgvector database: %w", err)
+	}
+	return &PgVectorStore{gormDB: gormDB, db: db, logger: logger}, nil
+}
+
+// pgvectorArgs collects positional query arguments.
+type pgvectorArgs []any
+
+// add appends v and returns its placeholder.
+func (a *pgvectorArgs) add(v any) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+func pgvectorTable(namespace string) string {
+	return pgx.Identifier{namespace}.Sanitize()
+}
+
+func pgvectorWhere(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// formatPgVector renders a vector in pgvector's text format, e.g. "[0.1,0.2]".
+func formatPgVector(vector []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func decodePgVectorMetadata(data []byte) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	if len(data) == 0 {
+		return properties, nil
+	}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return properties, nil
+}
+
+// scanPgVectorResults reads (id, metadata[, score]) rows and closes them.
+func scanPgVectorResults(rows *sql.Rows, selectFields []string, withScore bool) ([]SearchResult, error) {
+	defer rows.Close()
+	results := []SearchResult{}
+	for rows.Next() {
+		var (
+			id       string
+			metadata []byte
+			score    float64
+			err      error
+		)
+		if withScore {
+			err = rows.Scan(&id, &metadata, &score)
+		} else {
+			err = rows.Scan(&id, &metadata)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read entry: %w", err)
+		}
+		properties, err := decodePgVectorMetadata(metadata)
+		if err != nil {
+			return nil, err
+		}
+		result := SearchResult{ID: id, Properties: filterProperties(properties, selectFields)}
+		if withScore {
+			result.Score = &score
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// buildPgVectorFilter turns queries into conditions on the metadata column,
+// ANDed together by the caller. Equality and containment use JSONB
+// containment so the GIN index serves them.
+func buildPgVectorFilter(queries []Query, args *pgvectorArgs) ([]string, error) {
+	conditions := make([]string, 0, len(queries))
+	for _, q := range queries {
+		condition, err := buildPgVectorCondition(q, args)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func buildPgVectorCondition(q Query, args *pgvectorArgs) (string, error) {
+	if q.Field == "" {
+		return "", fmt.Errorf("%w: query field is required", ErrQuerySyntax)
+	}
+	contains := func(value any) (string, error) {
+		data, err := json.Marshal(map[string]any{q.Field: value})
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrQuerySyntax, err)
+		}
+		return "metadata @> " + args.add(string(data)) + "::jsonb", nil
+	}
+
+	switch q.Operator {
+	case QueryOperatorEqual:
+		return contains(q.Value)
+	case QueryOperatorNotEqual:
+		condition, err := contains(q.Value)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + condition + ")", nil
+	case QueryOperatorGreaterThan, QueryOperatorGreaterThanOrEqual, QueryOperatorLessThan, QueryOperatorLessThanOrEqual:
+		value, ok := pgvectorNumber(q.Value)
+		if !ok {
+			return "", fmt.Errorf("%w: %s needs a numeric value for %s", ErrQuerySyntax, q.Operator, q.Field)
+		}
+		op := map[QueryOperator]string{
+			QueryOperatorGreaterThan:        ">",
+			QueryOperatorGreaterThanOrEqual: ">=",
+			QueryOperatorLessThan:           "<",
+			QueryOperatorLessThanOrEqual:    "<=",
+		}[q.Operator]
+		field := args.add(q.Field) + "::text"
+		// Only numbers are compared, so a string in the field never fails the cast
+		return fmt.Sprintf("(CASE WHEN jsonb_typeof(metadata->%s) = 'number' THEN (metadata->>%s)::double precision END) %s %s", field, field, op, args.add(value)), nil
+	case QueryOperatorLike:
+		pattern, ok := q.Value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: %s needs a string value for %s", ErrQuerySyntax, q.Operator, q.Field)
+		}
+		// Weaviate-style wildcards
+		pattern = strings.NewReplacer("*", "%", "?", "_").Replace(pattern)
+		return fmt.Sprintf("metadata->>%s::text LIKE %s", args.add(q.Field), args.add(pattern)), nil
+	case QueryOperatorContainsAny:
+		values := pgvectorValues(q.Value)
+		if len(values) == 0 {
+			return "FALSE", nil
+		}
+		// A match is an array field holding the value or a scalar field equal to it
+		alternatives := make([]string, 0, 2*len(values))
+		for _, value := range values {
+			for _, candidate := range []any{[]any{value}, value} {
+				condition, err := contains(candidate)
+				if err != nil {
+					return "", err
+				}
+				alternatives = append(alternatives, condition)
+			}
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", nil
+	case QueryOperatorContainsAll:
+		return contains(pgvectorValues(q.Value))
+	case QueryOperatorIsNull:
+		return fmt.Sprintf("COALESCE(metadata->%s::text, 'null'::jsonb) = 'null'::jsonb", args.add(q.Field)), nil
+	case QueryOperatorIsNotNull:
+		return fmt.Sprintf("COALESCE(metadata->%s::text, 'null'::jsonb) <> 'null'::jsonb", args.add(q.Field)), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported operator %q", ErrQuerySyntax, q.Operator)
+	}
+}
+
+// pgvectorValues returns the elements of a slice value, or the value itself.
+func pgvectorValues(value any) []any {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []any{value}
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+func pgvectorNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
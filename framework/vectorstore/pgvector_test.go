@@ -0,0 +1,121 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/postgresconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	PgVectorTestTimeout   = 30 * time.Second
+	PgVectorTestNamespace = "bifrost_test_pgvector"
+)
+
+func getPgVectorTestConfig() PgVectorConfig {
+	return PgVectorConfig{Config: postgresconn.Config{
+		Host:     schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_HOST", "localhost")),
+		Port:     schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_PORT", "5432")),
+		User:     schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_USER", "bifrost")),
+		Password: schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_PASSWORD", "bifrost_password")),
+		DBName:   schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_DB", "bifrost")),
+		SSLMode:  schemas.NewSecretVar(getEnvWithDefault("PGVECTOR_SSL_MODE", "disable")),
+	}}
+}
+
+func TestBuildPgVectorFilter(t *testing.T) {
+	var args pgvectorArgs
+	conditions, err := buildPgVectorFilter([]Query{
+		{Field: "cache_key", Operator: QueryOperatorEqual, Value: "key-a"},
+		{Field: "expires_at", Operator: QueryOperatorGreaterThan, Value: int64(100)},
+		{Field: "tags", Operator: QueryOperatorContainsAny, Value: []string{"x", "y"}},
+		{Field: "stream_chunks", Operator: QueryOperatorIsNull},
+	}, &args)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"metadata @> $1::jsonb",
+		"(CASE WHEN jsonb_typeof(metadata->$2::text) = 'number' THEN (metadata->>$2::text)::double precision END) > $3",
+		"(metadata @> $4::jsonb OR metadata @> $5::jsonb OR metadata @> $6::jsonb OR metadata @> $7::jsonb)",
+		"COALESCE(metadata->$8::text, 'null'::jsonb) = 'null'::jsonb",
+	}, conditions)
+	assert.Equal(t, pgvectorArgs{
+		`{"cache_key":"key-a"}`,
+		"expires_at", float64(100),
+		`{"tags":["x"]}`, `{"tags":"x"}`, `{"tags":["y"]}`, `{"tags":"y"}`,
+		"stream_chunks",
+	}, args)
+
+	_, err = buildPgVectorFilter([]Query{{Field: "expires_at", Operator: QueryOperatorLessThan, Value: "soon"}}, &args)
+	assert.ErrorIs(t, err, ErrQuerySyntax)
+	_, err = buildPgVectorFilter([]Query{{Field: "a", Operator: "Near"}}, &args)
+	assert.ErrorIs(t, err, ErrQuerySyntax)
+}
+
+func TestFormatPgVector(t *testing.T) {
+	assert.Equal(t, "[0.5,-1,0.25]", formatPgVector([]float32{0.5, -1, 0.25}))
+}
+
+func TestPgVectorConfigUnmarshal(t *testing.T) {
+	var config Config
+	require.NoError(t, config.UnmarshalJSON([]byte(`{"enabled":true,"type":"pgvector","config":{"host":"db","port":"5432","user":"u","password":"p","db_name":"vectors","ssl_mode":"disable"}}`)))
+	pgConfig, ok := config.Config.(PgVectorConfig)
+	require.True(t, ok)
+	assert.Equal(t, "vectors", pgConfig.DBName.GetValue())
+}
+
+func TestPgVectorStore_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), PgVectorTestTimeout)
+	defer cancel()
+	config := getPgVectorTestConfig()
+	store, err := newPgVectorStore(ctx, &config, bifrost.NewDefaultLogger(schemas.LogLevelInfo))
+	if err != nil {
+		t.Skipf("Could not connect to pgvector database: %v", err)
+	}
+	defer store.Close(ctx, PgVectorTestNamespace)
+
+	if err := store.CreateNamespace(ctx, PgVectorTestNamespace, 3, nil); err != nil {
+		t.Skipf("pgvector extension not available: %v", err)
+	}
+	defer store.DeleteNamespace(ctx, PgVectorTestNamespace)
+	require.Error(t, store.CreateNamespace(ctx, PgVectorTestNamespace, 4, nil), "dimension changes must be rejected")
+
+	near, far, metadataOnly := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	require.NoError(t, store.Add(ctx, PgVectorTestNamespace, near, []float32{1, 0, 0}, map[string]interface{}{"cache_key": "a", "expires_at": 200}))
+	require.NoError(t, store.Add(ctx, PgVectorTestNamespace, far, []float32{0, 1, 0}, map[string]interface{}{"cache_key": "a", "expires_at": 50}))
+	require.NoError(t, store.Add(ctx, PgVectorTestNamespace, metadataOnly, nil, map[string]interface{}{"cache_key": "b"}))
+
+	chunk, err := store.GetChunk(ctx, PgVectorTestNamespace, near)
+	require.NoError(t, err)
+	assert.Equal(t, "a", chunk.Properties["cache_key"])
+	_, err = store.GetChunk(ctx, PgVectorTestNamespace, uuid.NewString())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	results, err := store.GetNearest(ctx, PgVectorTestNamespace, []float32{0.9, 0.1, 0}, []Query{{Field: "cache_key", Operator: QueryOperatorEqual, Value: "a"}}, nil, 0.8, 5)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, near, results[0].ID)
+	assert.Greater(t, *results[0].Score, 0.8)
+
+	results, cursor, err := store.GetAll(ctx, PgVectorTestNamespace, []Query{{Field: "expires_at", Operator: QueryOperatorLessThan, Value: 100}}, []string{"cache_key"}, nil, 10)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+	require.Len(t, results, 1)
+	assert.Equal(t, far, results[0].ID)
+
+	deleted, err := store.DeleteAll(ctx, PgVectorTestNamespace, []Query{{Field: "cache_key", Operator: QueryOperatorEqual, Value: "a"}})
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+	results, _, err = store.GetAll(ctx, PgVectorTestNamespace, nil, nil, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, metadataOnly, results[0].ID)
+}
@@ -16,6 +16,7 @@ const (
 	VectorStoreTypeRedis    VectorStoreType = "redis"
 	VectorStoreTypeQdrant   VectorStoreType = "qdrant"
 	VectorStoreTypePinecone VectorStoreType = "pinecone"
+	VectorStoreTypePgVector VectorStoreType = "pgvector"
 )
 
 // Query represents a query to the vector store.
@@ -96,7 +97,7 @@ type VectorStore interface {
 	GetNearest(ctx context.Context, namespace string, vector []float32, queries []Query, selectFields []string, threshold float64, limit int64) ([]SearchResult, error)
 	// RequiresVectors returns true if the vector store requires vectors for all entries.
 	// Dedicated vector databases like Qdrant and Pinecone require vectors, while
-	// more flexible stores like Weaviate and Redis can store metadata-only entries.
+	// more flexible stores like Weaviate, Redis and pgvector can store metadata-only entries.
 	RequiresVectors() bool
 	// Add stores a new vector in the vector store.
 	Add(ctx context.Context, namespace string, id string, embedding []float32, metadata map[string]interface{}) error
@@ -179,6 +180,12 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			return fmt.Errorf("failed to unmarshal pinecone config: %w", err)
 		}
 		c.Config = pineconeConfig
+	case VectorStoreTypePgVector:
+		var pgVectorConfig PgVectorConfig
+		if err := json.Unmarshal(temp.Config, &pgVectorConfig); err != nil {
+			return fmt.Errorf("failed to unmarshal pgvector config: %w", err)
+		}
+		c.Config = pgVectorConfig
 	default:
 		return fmt.Errorf("unknown vector store type: %s", temp.Type)
 	}
@@ -233,6 +240,15 @@ func NewVectorStore(ctx context.Context, config *Config, logger schemas.Logger)
 			return nil, fmt.Errorf("invalid pinecone config")
 		}
 		return newPineconeStore(ctx, &pineconeConfig, logger)
+	case VectorStoreTypePgVector:
+		if config.Config == nil {
+			return nil, fmt.Errorf("pgvector config is required")
+		}
+		pgVectorConfig, ok := config.Config.(PgVectorConfig)
+		if !ok {
+			return nil, fmt.Errorf("invalid pgvector config")
+		}
+		return newPgVectorStore(ctx, &pgVectorConfig, logger)
 	}
 	return nil, fmt.Errorf("invalid vector store type: %s", config.Type)
 }
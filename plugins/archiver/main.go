@@ -0,0 +1,325 @@
+// Package archiver provides an LLM plugin that archives a random sample of full
+// request/response pairs to object storage as gzip-compressed JSONL, partitioned by
+// date and model. The archive is independent of the log store, so teams can build
+// offline debugging and evaluation datasets without access to the logs database.
+// Guardrail literal redactions are applied before anything is written.
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/objectstore"
+)
+
+const PluginName = "archiver"
+
+const (
+	DefaultKeyPrefix       = "archive"
+	DefaultFlushInterval   = time.Minute
+	DefaultMaxBatchRecords = 1000
+
+	// uploadTimeout bounds a single batch upload.
+	uploadTimeout = 30 * time.Second
+)
+
+// sampleKey carries the *pendingRecord of a sampled request from PreLLMHook to PostLLMHook.
+const sampleKey schemas.BifrostContextKey = "archiver-sample"
+
+// Config configures the archiver.
+type Config struct {
+	SamplePercent   float64             `json:"sample_percent"`              // Percentage of requests archived, 0-100
+	Storage         *objectstore.Config `json:"storage"`                     // Object storage destination; objects are always gzip-compressed
+	KeyPrefix       string              `json:"key_prefix,omitempty"`        // Prefix under the storage prefix (default: "archive")
+	FlushInterval   time.Duration       `json:"flush_interval,omitempty"`    // How often buffered records are uploaded (default: 1m)
+	MaxBatchRecords int                 `json:"max_batch_records,omitempty"` // Records per object before an early upload (default: 1000)
+}
+
+// Record is one archived request/response pair, written as a single JSONL line.
+type Record struct {
+	RequestID   string                 `json:"request_id"`
+	Timestamp   time.Time              `json:"timestamp"`
+	RequestType schemas.RequestType    `json:"request_type"`
+	Provider    string                 `json:"provider"`
+	Model       string                 `json:"model"`
+	Request     sonic.NoCopyRawMessage `json:"request"`
+	Response    sonic.NoCopyRawMessage `json:"response"`
+}
+
+// pendingRecord is a sampled request waiting for its response.
+type pendingRecord struct {
+	timestamp time.Time
+	request   []byte
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	samplePercent   float64
+	store           objectstore.ObjectStore
+	keyPrefix       string
+	maxBatchRecords int
+	logger          schemas.Logger
+
+	mu      sync.Mutex
+	batches map[string][][]byte // partition -> encoded records
+
+	sample func() float64 // returns a value in [0, 100)
+	now    func() time.Time
+
+	uploads   sync.WaitGroup
+	stopFlush chan struct{}
+	stopOnce  sync.Once
+}
+
+// Init creates the object store and starts the periodic flush.
+func Init(ctx context.Context, config Config, logger schemas.Logger) (*Plugin, error) {
+	if config.Storage == nil {
+		return nil, fmt.Errorf("archiver: storage is required")
+	}
+	storage := *config.Storage
+	storage.Compress = true
+	store, err := objectstore.NewObjectStore(ctx, &storage, logger)
+	if err != nil {
+		return nil, fmt.Errorf("archiver: %w", err)
+	}
+	keyPrefix := config.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = DefaultKeyPrefix
+	}
+	return newPlugin(config, store, storage.GetPrefix()+"/"+keyPrefix, logger)
+}
+
+// newPlugin validates config and returns a plugin writing to store under keyPrefix.
+func newPlugin(config Config, store objectstore.ObjectStore, keyPrefix string, logger schemas.Logger) (*Plugin, error) {
+	if config.SamplePercent < 0 || config.SamplePercent > 100 {
+		return nil, fmt.Errorf("archiver: sample_percent must be between 0 and 100, got %v", config.SamplePercent)
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	if config.MaxBatchRecords <= 0 {
+		config.MaxBatchRecords = DefaultMaxBatchRecords
+	}
+	plugin := &Plugin{
+		samplePercent:   config.SamplePercent,
+		store:           store,
+		keyPrefix:       keyPrefix,
+		maxBatchRecords: config.MaxBatchRecords,
+		logger:          logger,
+		batches:         make(map[string][][]byte),
+		sample:          func() float64 { return rand.Float64() * 100 },
+		now:             time.Now,
+		stopFlush:       make(chan struct{}),
+	}
+	go plugin.flushLoop(config.FlushInterval)
+	return plugin, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup uploads everything still buffered and closes the object store.
+func (p *Plugin) Cleanup() error {
+	p.stopOnce.Do(func() { close(p.stopFlush) })
+	p.Flush()
+	p.uploads.Wait()
+	return p.store.Close()
+}
+
+// PreRequestHook implements schemas.LLMPlugin. Sampling happens in PreLLMHook so the
+// archived request is the one actually sent, after routing and request-mutating plugins.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook decides whether the request is archived and snapshots its body.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	if p.samplePercent <= 0 {
+		return req, nil, nil
+	}
+	if disabled, _ := ctx.Value(schemas.BifrostContextKeyDisableContentLogging).(bool); disabled {
+		return req, nil, nil
+	}
+	body := archivedRequestBody(req)
+	if body == nil {
+		return req, nil, nil
+	}
+	// Every attempt of a sampled request is archived, so later attempts skip the draw.
+	if _, sampled := ctx.Value(sampleKey).(*pendingRecord); !sampled && p.sample() >= p.samplePercent {
+		return req, nil, nil
+	}
+	encoded, err := sonic.Marshal(body)
+	if err != nil {
+		p.logger.Warn("archiver: failed to encode request: %v", err)
+		return req, nil, nil
+	}
+	ctx.SetValue(sampleKey, &pendingRecord{timestamp: p.now(), request: encoded})
+	return req, nil, nil
+}
+
+// PostLLMHook buffers the sampled request together with its successful response. Streams
+// are archived once, on the final chunk, from the accumulated response.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	pending, ok := ctx.Value(sampleKey).(*pendingRecord)
+	if !ok || bifrostErr != nil || result == nil {
+		return result, bifrostErr, nil
+	}
+	extraFields := result.GetExtraFields()
+	response := result
+	if bifrost.IsStreamRequestType(extraFields.RequestType) {
+		if !bifrost.IsFinalChunk(ctx) {
+			return result, bifrostErr, nil
+		}
+		response = ctx.GetAccumulatedResponse()
+	}
+	body := archivedResponseBody(response)
+	if body == nil {
+		return result, bifrostErr, nil
+	}
+	encoded, err := sonic.Marshal(body)
+	if err != nil {
+		p.logger.Warn("archiver: failed to encode response: %v", err)
+		return result, bifrostErr, nil
+	}
+
+	requestJSON, responseJSON := string(pending.request), string(encoded)
+	if redaction, ok := schemas.RedactionDataFromContext(ctx); ok {
+		requestJSON = schemas.ApplyLiteralReplacements(requestJSON, redaction.LiteralReplacements.Input)
+		responseJSON = schemas.ApplyLiteralReplacements(responseJSON, redaction.LiteralReplacements.Output)
+	}
+	ctx.ClearValue(sampleKey)
+	requestID, _ := ctx.Value(schemas.BifrostContextKeyRequestID).(string)
+	model := extraFields.RoutingInfo.Model
+	if model == "" {
+		model = extraFields.ResolvedModelUsed
+	}
+	p.add(Record{
+		RequestID:   requestID,
+		Timestamp:   pending.timestamp,
+		RequestType: extraFields.RequestType,
+		Provider:    string(extraFields.Provider),
+		Model:       model,
+		Request:     sonic.NoCopyRawMessage(requestJSON),
+		Response:    sonic.NoCopyRawMessage(responseJSON),
+	})
+	return result, bifrostErr, nil
+}
+
+// add buffers record in its date/model partition, uploading the partition once it is full.
+func (p *Plugin) add(record Record) {
+	line, err := sonic.Marshal(record)
+	if err != nil {
+		p.logger.Warn("archiver: failed to encode record: %v", err)
+		return
+	}
+	partition := fmt.Sprintf("dt=%s/model=%s", record.Timestamp.UTC().Format("2006-01-02"), url.PathEscape(record.Model))
+
+	p.mu.Lock()
+	p.batches[partition] = append(p.batches[partition], line)
+	var full [][]byte
+	if len(p.batches[partition]) >= p.maxBatchRecords {
+		full = p.batches[partition]
+		delete(p.batches, partition)
+	}
+	p.mu.Unlock()
+
+	if full != nil {
+		p.upload(partition, full)
+	}
+}
+
+// Flush uploads every buffered partition.
+func (p *Plugin) Flush() {
+	p.mu.Lock()
+	batches := p.batches
+	p.batches = make(map[string][][]byte)
+	p.mu.Unlock()
+
+	for partition, lines := range batches {
+		p.upload(partition, lines)
+	}
+}
+
+// upload writes lines as one JSONL object in the background.
+func (p *Plugin) upload(partition string, lines [][]byte) {
+	size := 0
+	for _, line := range lines {
+		size += len(line) + 1
+	}
+	data := make([]byte, 0, size)
+	for _, line := range lines {
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+	now := p.now()
+	key := fmt.Sprintf("%s/%s/%d-%08x.jsonl", p.keyPrefix, partition, now.UnixNano(), rand.Uint32())
+
+	p.uploads.Add(1)
+	go func() {
+		defer p.uploads.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+		defer cancel()
+		if err := p.store.Put(ctx, key, data, map[string]string{"source": PluginName}); err != nil {
+			p.logger.Warn("archiver: failed to upload %d records to %s: %v", len(lines), key, err)
+		}
+	}()
+}
+
+func (p *Plugin) flushLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.Flush()
+		case <-p.stopFlush:
+			return
+		}
+	}
+}
+
+// archivedRequestBody returns the inference request to archive, or nil for request types
+// that are not archived.
+func archivedRequestBody(req *schemas.BifrostRequest) any {
+	switch {
+	case req.TextCompletionRequest != nil:
+		return req.TextCompletionRequest
+	case req.ChatRequest != nil:
+		return req.ChatRequest
+	case req.ResponsesRequest != nil:
+		return req.ResponsesRequest
+	case req.EmbeddingRequest != nil:
+		return req.EmbeddingRequest
+	case req.RerankRequest != nil:
+		return req.RerankRequest
+	default:
+		return nil
+	}
+}
+
+// archivedResponseBody returns the response to archive alongside archivedRequestBody.
+func archivedResponseBody(resp *schemas.BifrostResponse) any {
+	switch {
+	case resp == nil:
+		return nil
+	case resp.TextCompletionResponse != nil:
+		return resp.TextCompletionResponse
+	case resp.ChatResponse != nil:
+		return resp.ChatResponse
+	case resp.ResponsesResponse != nil:
+		return resp.ResponsesResponse
+	case resp.EmbeddingResponse != nil:
+		return resp.EmbeddingResponse
+	case resp.RerankResponse != nil:
+		return resp.RerankResponse
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package archiver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/objectstore"
+)
+
+func newTestPlugin(t *testing.T, samplePercent float64) (*Plugin, *objectstore.InMemoryObjectStore) {
+	t.Helper()
+	store := objectstore.NewInMemoryObjectStore()
+	plugin, err := newPlugin(Config{SamplePercent: samplePercent, FlushInterval: time.Hour}, store, "bifrost/archive", nil)
+	if err != nil {
+		t.Fatalf("newPlugin: %v", err)
+	}
+	plugin.now = func() time.Time { return time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC) }
+	return plugin, store
+}
+
+func chatRoundTrip(t *testing.T, plugin *Plugin, ctx *schemas.BifrostContext, prompt string) {
+	t.Helper()
+	req := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(prompt)}},
+			},
+		},
+	}
+	if _, _, err := plugin.PreLLMHook(ctx, req); err != nil {
+		t.Fatalf("PreLLMHook: %v", err)
+	}
+	resp := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		ID:    "chatcmpl-1",
+		Model: "gpt-4o",
+		ExtraFields: schemas.BifrostResponseExtraFields{
+			RequestType: schemas.ChatCompletionRequest,
+			Provider:    schemas.OpenAI,
+			RoutingInfo: schemas.RoutingInfo{Provider: schemas.OpenAI, Model: "gpt-4o"},
+		},
+	}}
+	plugin.PostLLMHook(ctx, resp, nil)
+}
+
+func TestArchiverWritesRedactedPartitionedJSONL(t *testing.T) {
+	plugin, store := newTestPlugin(t, 100)
+
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-1")
+	schemas.SetRedactionDataOnContext(ctx, schemas.RedactionData{
+		LiteralReplacements: schemas.RedactionMapsByPhase{Input: map[string]string{"jane@example.com": "[EMAIL]"}},
+	})
+	chatRoundTrip(t, plugin, ctx, "email jane@example.com")
+	chatRoundTrip(t, plugin, schemas.NewBifrostContext(nil, schemas.NoDeadline), "second")
+
+	if err := plugin.Cleanup(); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	keys := store.Keys()
+	if len(keys) != 1 || !strings.HasPrefix(keys[0], "bifrost/archive/dt=2026-03-14/model=gpt-4o/") || !strings.HasSuffix(keys[0], ".jsonl") {
+		t.Fatalf("unexpected object keys %v", keys)
+	}
+	data, _ := store.Get(t.Context(), keys[0])
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records in one object, got %d", len(lines))
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("record is not valid JSON: %v", err)
+	}
+	if record.RequestID != "req-1" || record.Provider != "openai" || record.Model != "gpt-4o" {
+		t.Fatalf("unexpected record metadata %+v", record)
+	}
+	if strings.Contains(lines[0], "jane@example.com") || !strings.Contains(string(record.Request), "[EMAIL]") {
+		t.Fatalf("request was not redacted: %s", record.Request)
+	}
+	if !strings.Contains(string(record.Response), "chatcmpl-1") {
+		t.Fatalf("response missing from record: %s", record.Response)
+	}
+}
+
+func TestArchiverSamplingAndOptOut(t *testing.T) {
+	plugin, store := newTestPlugin(t, 10)
+	draws := []float64{42, 3}
+	plugin.sample = func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+
+	chatRoundTrip(t, plugin, schemas.NewBifrostContext(nil, schemas.NoDeadline), "not sampled")
+	chatRoundTrip(t, plugin, schemas.NewBifrostContext(nil, schemas.NoDeadline), "sampled")
+
+	optOut := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	optOut.SetValue(schemas.BifrostContextKeyDisableContentLogging, true)
+	chatRoundTrip(t, plugin, optOut, "content logging disabled")
+
+	plugin.Cleanup()
+	keys := store.Keys()
+	if len(keys) != 1 {
+		t.Fatalf("expected one object, got %v", keys)
+	}
+	data, _ := store.Get(t.Context(), keys[0])
+	if got := strings.Count(string(data), "\n"); got != 1 || !strings.Contains(string(data), `"sampled"`) {
+		t.Fatalf("expected only the sampled request to be archived, got %s", data)
+	}
+}
+
+func TestArchiverUploadsFullBatchesEarly(t *testing.T) {
+	store := objectstore.NewInMemoryObjectStore()
+	plugin, _ := newPlugin(Config{SamplePercent: 100, FlushInterval: time.Hour, MaxBatchRecords: 2}, store, "archive", nil)
+	for range 2 {
+		chatRoundTrip(t, plugin, schemas.NewBifrostContext(nil, schemas.NoDeadline), "hi")
+	}
+	plugin.uploads.Wait()
+	if store.Len() != 1 {
+		t.Fatalf("expected a full batch to upload without a flush, got %d objects", store.Len())
+	}
+	if _, err := newPlugin(Config{SamplePercent: 150}, store, "archive", nil); err == nil {
+		t.Fatal("expected an error for sample_percent above 100")
+	}
+}
@@ -0,0 +1,162 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxCircuitHistory is the number of most recent transitions kept per circuit.
+const maxCircuitHistory = 20
+
+// ErrCircuitNotFound is returned when an operation names a circuit that has
+// seen no traffic and was never forced.
+var ErrCircuitNotFound = errors.New("circuit not found")
+
+// CircuitTransition is one state change of a circuit.
+type CircuitTransition struct {
+	From   CircuitState `json:"from"`
+	To     CircuitState `json:"to"`
+	At     time.Time    `json:"at"`
+	Reason string       `json:"reason"`
+}
+
+// TransitionCount is how often a circuit moved from one state to another.
+type TransitionCount struct {
+	From  CircuitState `json:"from"`
+	To    CircuitState `json:"to"`
+	Count int64        `json:"count"`
+}
+
+// CircuitSnapshot is the state of one circuit at a point in time.
+type CircuitSnapshot struct {
+	CircuitKey
+	State CircuitState `json:"state"`
+	// Forced is set while an operator's ForceOpen or ForceClose holds the state.
+	Forced bool `json:"forced"`
+	// Requests and Failures are the outcomes in the current window.
+	Requests    int        `json:"requests"`
+	Failures    int        `json:"failures"`
+	FailureRate float64    `json:"failure_rate"`
+	OpenedAt    *time.Time `json:"opened_at,omitempty"`
+	// FailureThreshold is the failure rate the circuit opens at, which moves with the
+	// circuit's baseline when adaptive thresholds are on.
+	FailureThreshold float64 `json:"failure_threshold"`
+	// TrafficFraction is the fraction of requests admitted: below 1 during slow start.
+	TrafficFraction float64 `json:"traffic_fraction"`
+	// TransitionCounts are cumulative; Transitions are the most recent ones, oldest first.
+	TransitionCounts []TransitionCount   `json:"transition_counts"`
+	Transitions      []CircuitTransition `json:"transitions"`
+}
+
+// Snapshot returns every circuit that has seen traffic or been forced, ordered by key.
+func (cb *CircuitBreaker) Snapshot() []CircuitSnapshot {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	snapshots := make([]CircuitSnapshot, 0, len(cb.circuits))
+	for key, c := range cb.circuits {
+		snapshot := CircuitSnapshot{
+			CircuitKey:       key,
+			State:            c.state,
+			Forced:           c.forced,
+			Requests:         c.count,
+			Failures:         c.failures,
+			FailureThreshold: cb.failureThreshold(c),
+			TrafficFraction:  cb.trafficFraction(c),
+			TransitionCounts: make([]TransitionCount, 0, len(c.transitions)),
+			Transitions:      slices.Clone(c.history),
+		}
+		if c.count > 0 {
+			snapshot.FailureRate = float64(c.failures) / float64(c.count)
+		}
+		if c.state != StateClosed {
+			openedAt := c.openedAt
+			snapshot.OpenedAt = &openedAt
+		}
+		for states, count := range c.transitions {
+			snapshot.TransitionCounts = append(snapshot.TransitionCounts, TransitionCount{From: states[0], To: states[1], Count: count})
+		}
+		slices.SortFunc(snapshot.TransitionCounts, func(a, b TransitionCount) int {
+			return strings.Compare(string(a.From)+"/"+string(a.To), string(b.From)+"/"+string(b.To))
+		})
+		snapshots = append(snapshots, snapshot)
+	}
+	slices.SortFunc(snapshots, func(a, b CircuitSnapshot) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return snapshots
+}
+
+// ForceOpen opens key's circuit and holds it open, without probes, until Reset.
+func (cb *CircuitBreaker) ForceOpen(key CircuitKey) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err := cb.checkKey(key); err != nil {
+		return err
+	}
+
+	c := cb.getCircuit(key)
+	c.forced = true
+	c.probeInFlight = false
+	cb.transition(key, c, StateOpen, "forced open")
+	return nil
+}
+
+// ForceClose closes key's circuit with an empty window and holds it closed, however
+// many requests fail, until Reset.
+func (cb *CircuitBreaker) ForceClose(key CircuitKey) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err := cb.checkKey(key); err != nil {
+		return err
+	}
+
+	c := cb.getCircuit(key)
+	c.forced = true
+	c.reset()
+	cb.transition(key, c, StateClosed, "forced closed")
+	return nil
+}
+
+// Reset releases a forced state and closes key's circuit with an empty window.
+func (cb *CircuitBreaker) Reset(key CircuitKey) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCircuitNotFound, key)
+	}
+	c.forced = false
+	c.reset()
+	cb.transition(key, c, StateClosed, "reset")
+	return nil
+}
+
+// checkKey reports whether key names a circuit requests are judged against under the
+// configured granularity. Caller holds mu.
+func (cb *CircuitBreaker) checkKey(key CircuitKey) error {
+	if key.Provider == "" {
+		return fmt.Errorf("provider is required")
+	}
+	switch cb.config.Granularity {
+	case GranularityProviderModel:
+		if key.Model == "" || key.KeyID != "" {
+			return fmt.Errorf("%s circuits need a provider and model", cb.config.Granularity)
+		}
+	case GranularityProviderKey:
+		if key.KeyID == "" || key.Model != "" {
+			return fmt.Errorf("%s circuits need a provider and key_id", cb.config.Granularity)
+		}
+	default:
+		if key.Model != "" || key.KeyID != "" {
+			return fmt.Errorf("%s circuits need only a provider", cb.config.Granularity)
+		}
+	}
+	return nil
+}
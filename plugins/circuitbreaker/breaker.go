@@ -0,0 +1,511 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// CircuitState is the state of a single circuit.
+type CircuitState string
+
+const (
+	// StateClosed lets all traffic through and tracks outcomes in the window.
+	StateClosed CircuitState = "closed"
+	// StateOpen rejects (or degrades) traffic until the cooldown elapses.
+	StateOpen CircuitState = "open"
+	// StateHalfOpen lets a single probe request through to test recovery.
+	StateHalfOpen CircuitState = "half_open"
+)
+
+const (
+	DefaultFailureRateThreshold = 0.5
+	DefaultMinimumRequests      = 10
+	DefaultWindowSize           = 50
+	DefaultCooldownPeriod       = 30 * time.Second
+
+	DefaultSlowStartDuration        = time.Minute
+	DefaultAdaptiveMultiplier       = 3.0
+	DefaultAdaptiveMinimumThreshold = 0.1
+	DefaultAdaptiveBaselineRequests = 1000
+)
+
+// DefaultSlowStartSteps ramps a recovered circuit to 10% then 50% of traffic before
+// restoring all of it.
+var DefaultSlowStartSteps = []float64{0.1, 0.5}
+
+// CircuitGranularity is what a circuit tracks: a whole provider, or each of its
+// models or keys, so one broken deployment or key does not open the circuit for
+// everything else on the provider.
+type CircuitGranularity string
+
+const (
+	GranularityProvider      CircuitGranularity = "provider"
+	GranularityProviderModel CircuitGranularity = "provider+model"
+	GranularityProviderKey   CircuitGranularity = "provider+key"
+)
+
+// CircuitKey identifies a circuit. Model is set only with provider+model
+// granularity and KeyID only with provider+key granularity.
+type CircuitKey struct {
+	Provider schemas.ModelProvider `json:"provider"`
+	Model    string                `json:"model,omitempty"`
+	KeyID    string                `json:"key_id,omitempty"`
+}
+
+// String returns the circuit's name as used in logs and errors.
+func (k CircuitKey) String() string {
+	switch {
+	case k.Model != "":
+		return string(k.Provider) + "/" + k.Model
+	case k.KeyID != "":
+		return fmt.Sprintf("%s key %s", k.Provider, k.KeyID)
+	default:
+		return string(k.Provider)
+	}
+}
+
+// CircuitBreakerConfig controls when a circuit opens and how long it stays open.
+type CircuitBreakerConfig struct {
+	// Granularity is what each circuit tracks (default: provider).
+	Granularity CircuitGranularity `json:"granularity,omitempty"`
+	// FailureRateThreshold is the fraction (0-1] of failed requests in the window that opens the circuit.
+	FailureRateThreshold float64 `json:"failure_rate_threshold,omitempty"`
+	// MinimumRequests is the number of outcomes the window must hold before the rate is evaluated.
+	MinimumRequests int `json:"minimum_requests,omitempty"`
+	// WindowSize is the number of most recent outcomes tracked per circuit.
+	WindowSize int `json:"window_size,omitempty"`
+	// CooldownPeriod is how long an open circuit waits before letting a probe through.
+	CooldownPeriod time.Duration `json:"cooldown_period,omitempty"`
+	// SlowStart, when set, ramps traffic back up after a probe closes a circuit
+	// instead of restoring all of it at once.
+	SlowStart *SlowStartConfig `json:"slow_start,omitempty"`
+	// AdaptiveThreshold, when set, opens each circuit relative to its own historical
+	// failure rate instead of at FailureRateThreshold.
+	AdaptiveThreshold *AdaptiveThresholdConfig `json:"adaptive_threshold,omitempty"`
+}
+
+// SlowStartConfig controls how a recovered circuit's traffic is ramped back up.
+type SlowStartConfig struct {
+	// Steps are the fractions (0-1) of requests admitted, in order, while ramping (default: 0.1, 0.5).
+	Steps []float64 `json:"steps,omitempty"`
+	// Duration is how long the ramp takes, split evenly across the steps (default: 1m).
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// AdaptiveThresholdConfig controls thresholds derived from each circuit's baseline
+// failure rate, so a provider that always fails a little is not judged like one that
+// never fails.
+type AdaptiveThresholdConfig struct {
+	// Multiplier is how many times its baseline failure rate a circuit tolerates (default: 3).
+	Multiplier float64 `json:"multiplier,omitempty"`
+	// MinimumThreshold is the lowest threshold the baseline can produce (default: 0.1).
+	MinimumThreshold float64 `json:"minimum_threshold,omitempty"`
+	// BaselineRequests is how many outcomes the baseline averages over. Until a circuit
+	// has seen this many, FailureRateThreshold applies (default: 1000).
+	BaselineRequests int `json:"baseline_requests,omitempty"`
+}
+
+// withDefaults fills unset fields with the package defaults.
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.Granularity == "" {
+		c.Granularity = GranularityProvider
+	}
+	if c.FailureRateThreshold <= 0 || c.FailureRateThreshold > 1 {
+		c.FailureRateThreshold = DefaultFailureRateThreshold
+	}
+	if c.MinimumRequests <= 0 {
+		c.MinimumRequests = DefaultMinimumRequests
+	}
+	if c.WindowSize <= 0 {
+		c.WindowSize = DefaultWindowSize
+	}
+	if c.MinimumRequests > c.WindowSize {
+		c.MinimumRequests = c.WindowSize
+	}
+	if c.CooldownPeriod <= 0 {
+		c.CooldownPeriod = DefaultCooldownPeriod
+	}
+	if c.SlowStart != nil {
+		slowStart := *c.SlowStart
+		if len(slowStart.Steps) == 0 {
+			slowStart.Steps = DefaultSlowStartSteps
+		}
+		if slowStart.Duration <= 0 {
+			slowStart.Duration = DefaultSlowStartDuration
+		}
+		c.SlowStart = &slowStart
+	}
+	if c.AdaptiveThreshold != nil {
+		adaptive := *c.AdaptiveThreshold
+		if adaptive.Multiplier < 1 {
+			adaptive.Multiplier = DefaultAdaptiveMultiplier
+		}
+		if adaptive.MinimumThreshold <= 0 || adaptive.MinimumThreshold > 1 {
+			adaptive.MinimumThreshold = DefaultAdaptiveMinimumThreshold
+		}
+		if adaptive.BaselineRequests <= 0 {
+			adaptive.BaselineRequests = DefaultAdaptiveBaselineRequests
+		}
+		c.AdaptiveThreshold = &adaptive
+	}
+	return c
+}
+
+// circuit holds the rolling outcome window and state for one circuit key.
+type circuit struct {
+	state         CircuitState
+	outcomes      []bool // ring buffer, true = failure
+	next          int
+	count         int
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+	forced        bool // state was forced by an operator and holds until Reset
+	history       []CircuitTransition
+	transitions   map[[2]CircuitState]int64 // from, to -> count
+
+	// Slow start: recoveredAt is when a probe last closed the circuit, zero once the ramp
+	// is over. rampSeen and rampAdmitted count requests during the current rampStep.
+	recoveredAt  time.Time
+	rampStep     int
+	rampSeen     int
+	rampAdmitted int
+
+	// Adaptive threshold: baseline is the failure rate averaged over baselineSamples outcomes.
+	baseline        float64
+	baselineSamples int
+}
+
+// record pushes an outcome into the ring buffer, evicting the oldest once full.
+func (c *circuit) record(failed bool) {
+	if c.count == len(c.outcomes) {
+		if c.outcomes[c.next] {
+			c.failures--
+		}
+	} else {
+		c.count++
+	}
+	c.outcomes[c.next] = failed
+	if failed {
+		c.failures++
+	}
+	c.next = (c.next + 1) % len(c.outcomes)
+}
+
+// resize changes the window to size outcomes, keeping the most recent ones.
+func (c *circuit) resize(size int) {
+	recent := make([]bool, 0, c.count)
+	oldest := (c.next - c.count + len(c.outcomes)) % len(c.outcomes)
+	for i := range c.count {
+		recent = append(recent, c.outcomes[(oldest+i)%len(c.outcomes)])
+	}
+	if len(recent) > size {
+		recent = recent[len(recent)-size:]
+	}
+	c.outcomes = make([]bool, size)
+	c.next, c.count, c.failures = 0, 0, 0
+	for _, failed := range recent {
+		c.record(failed)
+	}
+}
+
+// reset clears the window, the probe slot and any slow start ramp.
+func (c *circuit) reset() {
+	clear(c.outcomes)
+	c.next, c.count, c.failures = 0, 0, 0
+	c.probeInFlight = false
+	c.recoveredAt = time.Time{}
+}
+
+// CircuitBreaker tracks one circuit per provider, model or key, depending on
+// the configured granularity.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	config   CircuitBreakerConfig
+	circuits map[CircuitKey]*circuit
+	now      func() time.Time
+	onChange []StateChangeFunc
+}
+
+// StateChangeFunc is called when the circuit for key moves from one state to another.
+type StateChangeFunc func(key CircuitKey, from, to CircuitState)
+
+// NewCircuitBreaker returns a breaker with every circuit closed.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		config:   config.withDefaults(),
+		circuits: make(map[CircuitKey]*circuit),
+		now:      time.Now,
+	}
+}
+
+// Key returns the key of the circuit a request for provider/model sent with
+// keyID is judged against, given the configured granularity.
+func (cb *CircuitBreaker) Key(provider schemas.ModelProvider, model string, keyID string) CircuitKey {
+	switch cb.granularity() {
+	case GranularityProviderModel:
+		return CircuitKey{Provider: provider, Model: model}
+	case GranularityProviderKey:
+		return CircuitKey{Provider: provider, KeyID: keyID}
+	default:
+		return CircuitKey{Provider: provider}
+	}
+}
+
+// granularity returns the configured granularity.
+func (cb *CircuitBreaker) granularity() CircuitGranularity {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.config.Granularity
+}
+
+// ReloadConfig applies config without losing circuit state: windows are resized
+// keeping their most recent outcomes, and open circuits keep their cooldown.
+// A new granularity changes what each circuit tracks, so it drops every circuit.
+func (cb *CircuitBreaker) ReloadConfig(config CircuitBreakerConfig) {
+	config = config.withDefaults()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if config.Granularity != cb.config.Granularity {
+		cb.circuits = make(map[CircuitKey]*circuit)
+	}
+	for _, c := range cb.circuits {
+		if len(c.outcomes) != config.WindowSize {
+			c.resize(config.WindowSize)
+		}
+		if config.AdaptiveThreshold != nil {
+			c.baselineSamples = min(c.baselineSamples, config.AdaptiveThreshold.BaselineRequests)
+		}
+	}
+	cb.config = config
+}
+
+// OnStateChange registers fn to be called on every state transition of every circuit,
+// including operator overrides, in the order the transitions happen. fn runs while the
+// breaker is locked: it must return quickly and must not call back into the breaker, so
+// slow work such as posting to Slack or PagerDuty belongs in a goroutine.
+func (cb *CircuitBreaker) OnStateChange(fn StateChangeFunc) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.onChange = append(cb.onChange, fn)
+}
+
+// getCircuit returns the circuit for key, creating it closed. Caller holds mu.
+func (cb *CircuitBreaker) getCircuit(key CircuitKey) *circuit {
+	c, ok := cb.circuits[key]
+	if !ok {
+		c = &circuit{state: StateClosed, outcomes: make([]bool, cb.config.WindowSize), transitions: make(map[[2]CircuitState]int64)}
+		cb.circuits[key] = c
+	}
+	return c
+}
+
+// transition moves key's circuit c to state to, records why and notifies the
+// OnStateChange callbacks. Caller holds mu.
+func (cb *CircuitBreaker) transition(key CircuitKey, c *circuit, to CircuitState, reason string) {
+	from := c.state
+	if from == to {
+		return
+	}
+	now := cb.now()
+	c.state = to
+	if to == StateOpen {
+		c.openedAt = now
+	}
+	c.transitions[[2]CircuitState{from, to}]++
+	if len(c.history) == maxCircuitHistory {
+		c.history = slices.Delete(c.history, 0, 1)
+	}
+	c.history = append(c.history, CircuitTransition{From: from, To: to, At: now, Reason: reason})
+	for _, fn := range cb.onChange {
+		fn(key, from, to)
+	}
+}
+
+// Allow reports whether a request may be sent through key's circuit. An open
+// circuit whose cooldown has elapsed moves to half-open and admits exactly one
+// probe; further requests are rejected until that probe's outcome is recorded.
+func (cb *CircuitBreaker) Allow(key CircuitKey) bool {
+	allowed, _ := cb.acquire(key)
+	return allowed
+}
+
+// acquire is Allow that also reports whether the admitted request is the half-open probe.
+func (cb *CircuitBreaker) acquire(key CircuitKey) (allowed bool, probe bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.getCircuit(key)
+	switch c.state {
+	case StateOpen:
+		if c.forced || cb.now().Sub(c.openedAt) < cb.config.CooldownPeriod {
+			return false, false
+		}
+		cb.transition(key, c, StateHalfOpen, "cooldown elapsed")
+		c.probeInFlight = true
+		return true, true
+	case StateHalfOpen:
+		if c.probeInFlight {
+			return false, false
+		}
+		c.probeInFlight = true
+		return true, true
+	default:
+		return cb.admitRamped(c), false
+	}
+}
+
+// trafficFraction returns the fraction of requests c admits: the current slow start
+// step while c ramps up after recovering, otherwise 1. Caller holds mu.
+func (cb *CircuitBreaker) trafficFraction(c *circuit) float64 {
+	slowStart := cb.config.SlowStart
+	if slowStart == nil || c.state != StateClosed || c.recoveredAt.IsZero() {
+		return 1
+	}
+	step := int(cb.now().Sub(c.recoveredAt) / (slowStart.Duration / time.Duration(len(slowStart.Steps))))
+	if step >= len(slowStart.Steps) {
+		c.recoveredAt = time.Time{}
+		return 1
+	}
+	if step != c.rampStep {
+		c.rampStep, c.rampSeen, c.rampAdmitted = step, 0, 0
+	}
+	return slowStart.Steps[step]
+}
+
+// admitRamped reports whether c lets a request through. While c ramps up it admits the
+// step's fraction of requests, spread evenly rather than at random. Caller holds mu.
+func (cb *CircuitBreaker) admitRamped(c *circuit) bool {
+	fraction := cb.trafficFraction(c)
+	if fraction >= 1 {
+		return true
+	}
+	c.rampSeen++
+	if float64(c.rampAdmitted) >= fraction*float64(c.rampSeen) {
+		return false
+	}
+	c.rampAdmitted++
+	return true
+}
+
+// failureThreshold returns the window failure rate at which c opens. Caller holds mu.
+func (cb *CircuitBreaker) failureThreshold(c *circuit) float64 {
+	adaptive := cb.config.AdaptiveThreshold
+	if adaptive == nil || c.baselineSamples < adaptive.BaselineRequests {
+		return cb.config.FailureRateThreshold
+	}
+	return min(max(c.baseline*adaptive.Multiplier, adaptive.MinimumThreshold), 1)
+}
+
+// learn folds an outcome into c's baseline failure rate: a plain mean over the first
+// BaselineRequests outcomes, then a moving average over about as many. Caller holds mu.
+func (cb *CircuitBreaker) learn(c *circuit, failed bool) {
+	adaptive := cb.config.AdaptiveThreshold
+	if adaptive == nil {
+		return
+	}
+	if c.baselineSamples < adaptive.BaselineRequests {
+		c.baselineSamples++
+	}
+	outcome := 0.0
+	if failed {
+		outcome = 1
+	}
+	c.baseline += (outcome - c.baseline) / float64(c.baselineSamples)
+}
+
+// admitKeys returns the keys of provider whose circuits let a request through.
+// When a key's circuit is ready for its half-open probe, that key alone is
+// returned so the request becomes the probe, and probe is its ID.
+func (cb *CircuitBreaker) admitKeys(provider schemas.ModelProvider, keys []schemas.Key) (admitted []schemas.Key, probe string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	admitted = make([]schemas.Key, 0, len(keys))
+	for _, key := range keys {
+		circuitKey := CircuitKey{Provider: provider, KeyID: key.ID}
+		c, ok := cb.circuits[circuitKey]
+		if !ok || c.state == StateClosed {
+			if !ok || cb.admitRamped(c) {
+				admitted = append(admitted, key)
+			}
+			continue
+		}
+		if c.state == StateOpen && !c.forced && cb.now().Sub(c.openedAt) >= cb.config.CooldownPeriod {
+			cb.transition(circuitKey, c, StateHalfOpen, "cooldown elapsed")
+		}
+		if c.state == StateHalfOpen && !c.probeInFlight {
+			c.probeInFlight = true
+			return []schemas.Key{key}, key.ID
+		}
+	}
+	return admitted, ""
+}
+
+// releaseProbe frees the half-open probe slot without recording an outcome, for probes
+// that ended before the provider could be judged (e.g. the client cancelled).
+func (cb *CircuitBreaker) releaseProbe(key CircuitKey) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if c, ok := cb.circuits[key]; ok && c.state == StateHalfOpen {
+		c.probeInFlight = false
+	}
+}
+
+// RecordSuccess records a successful request. A successful probe closes the circuit.
+func (cb *CircuitBreaker) RecordSuccess(key CircuitKey) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.getCircuit(key)
+	switch c.state {
+	case StateHalfOpen:
+		c.reset()
+		cb.transition(key, c, StateClosed, "probe succeeded")
+		if cb.config.SlowStart != nil {
+			c.recoveredAt, c.rampStep, c.rampSeen, c.rampAdmitted = cb.now(), 0, 0, 0
+		}
+	case StateClosed:
+		c.record(false)
+		cb.learn(c, false)
+	}
+}
+
+// RecordFailure records a failed request. A failed probe reopens the circuit; in the
+// closed state the circuit opens once the window's failure rate crosses the threshold.
+func (cb *CircuitBreaker) RecordFailure(key CircuitKey) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.getCircuit(key)
+	switch c.state {
+	case StateHalfOpen:
+		c.probeInFlight = false
+		cb.transition(key, c, StateOpen, "probe failed")
+	case StateClosed:
+		c.record(true)
+		cb.learn(c, true)
+		// A circuit forced closed keeps counting but never opens.
+		if !c.forced && c.count >= cb.config.MinimumRequests && float64(c.failures)/float64(c.count) >= cb.failureThreshold(c) {
+			cb.transition(key, c, StateOpen, fmt.Sprintf("%d of the last %d requests failed", c.failures, c.count))
+		}
+	}
+}
+
+// State returns the current state of key's circuit.
+func (cb *CircuitBreaker) State(key CircuitKey) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		return StateClosed
+	}
+	return c.state
+}
@@ -0,0 +1,295 @@
+// Package circuitbreaker provides an LLM plugin that tracks provider health with a
+// circuit breaker per provider, or per model or key of a provider (see
+// CircuitGranularity). While a circuit is open, requests either
+// degrade to a designated fallback model (typically a self-hosted Ollama/vLLM model)
+// when a degradation rule matches, or fail fast with a 503 so core moves on to the
+// request's fallbacks. After the cooldown a single probe request goes to the primary;
+// if it succeeds the circuit closes and traffic is restored automatically.
+package circuitbreaker
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "circuit-breaker"
+
+// attemptKey stores the attemptInfo of the current attempt so PostLLMHook can attribute its outcome.
+// With provider+key granularity, KeyPoolFilter updates it when it sends the attempt as a key's probe.
+const attemptKey schemas.BifrostContextKey = "circuit-breaker-attempt"
+
+// DegradationRule reroutes requests for a provider to a designated model while that
+// provider's circuit is open.
+type DegradationRule struct {
+	// Provider is the primary provider whose open circuit triggers the rule.
+	Provider schemas.ModelProvider `json:"provider"`
+	// Models limits the rule to these primary models. Empty matches every model.
+	Models []string `json:"models,omitempty"`
+	// TargetProvider and TargetModel name the model requests degrade to.
+	TargetProvider schemas.ModelProvider `json:"target_provider"`
+	TargetModel    string                `json:"target_model"`
+}
+
+// matches reports whether the rule applies to a request for provider/model.
+func (r DegradationRule) matches(provider schemas.ModelProvider, model string) bool {
+	return r.Provider == provider && (len(r.Models) == 0 || slices.Contains(r.Models, model))
+}
+
+// Config configures the circuit breaker plugin.
+type Config struct {
+	CircuitBreakerConfig
+	// Degradation lists the rules applied, first match wins, while a circuit is open.
+	Degradation []DegradationRule `json:"degradation,omitempty"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	breaker     *CircuitBreaker
+	degradation atomic.Pointer[[]DegradationRule]
+	logger      schemas.Logger
+}
+
+// attemptInfo records how PreLLMHook handled one attempt.
+type attemptInfo struct {
+	circuit  CircuitKey // circuit the attempt is judged against; KeyID is empty until a key is picked
+	probe    bool       // the attempt holds the half-open probe slot
+	rejected bool       // the attempt was short-circuited by this plugin
+}
+
+// Init validates the degradation rules and returns a plugin with every circuit closed.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	p := &Plugin{
+		breaker: NewCircuitBreaker(config.CircuitBreakerConfig),
+		logger:  logger,
+	}
+	p.degradation.Store(&config.Degradation)
+	return p, nil
+}
+
+// ReloadConfig applies config to the running plugin. Circuit state survives the reload,
+// see CircuitBreaker.ReloadConfig; an invalid config is rejected and changes nothing.
+func (p *Plugin) ReloadConfig(config Config) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	p.breaker.ReloadConfig(config.CircuitBreakerConfig)
+	p.degradation.Store(&config.Degradation)
+	return nil
+}
+
+// validate checks the granularity, slow start, adaptive threshold and degradation rules.
+func (config Config) validate() error {
+	switch config.Granularity {
+	case "", GranularityProvider, GranularityProviderModel, GranularityProviderKey:
+	default:
+		return fmt.Errorf("circuit-breaker: unknown granularity %q", config.Granularity)
+	}
+	if slowStart := config.SlowStart; slowStart != nil {
+		for _, step := range slowStart.Steps {
+			if step <= 0 || step >= 1 {
+				return fmt.Errorf("circuit-breaker: slow_start steps must be between 0 and 1, got %v", step)
+			}
+		}
+	}
+	if adaptive := config.AdaptiveThreshold; adaptive != nil && adaptive.Multiplier != 0 && adaptive.Multiplier < 1 {
+		return fmt.Errorf("circuit-breaker: adaptive_threshold multiplier must be at least 1, got %v", adaptive.Multiplier)
+	}
+	for i, rule := range config.Degradation {
+		if rule.Provider == "" || rule.TargetProvider == "" || rule.TargetModel == "" {
+			return fmt.Errorf("circuit-breaker: degradation rule %d needs provider, target_provider and target_model", i)
+		}
+		if rule.TargetProvider == rule.Provider {
+			return fmt.Errorf("circuit-breaker: degradation rule %d targets its own provider %s", i, rule.Provider)
+		}
+	}
+	return nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// Breaker returns the underlying circuit breaker.
+func (p *Plugin) Breaker() *CircuitBreaker { return p.breaker }
+
+// PreRequestHook implements schemas.LLMPlugin. Circuits are checked per attempt in PreLLMHook
+// so each fallback is judged against its own provider.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook lets the attempt through when its circuit allows it. Otherwise the attempt is
+// rerouted by the first matching degradation rule, or short-circuited with a 503 that allows
+// fallbacks. With provider+key granularity the key is usually picked after this hook, so
+// unless the request pins a key its circuit is checked by KeyPoolFilter instead.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	provider, model, _ := req.GetRequestFields()
+	if provider == "" {
+		return req, nil, nil
+	}
+
+	var keyID string
+	if p.breaker.granularity() == GranularityProviderKey {
+		keyID, _ = ctx.Value(schemas.BifrostContextKeyAPIKeyID).(string)
+		if keyID == "" {
+			ctx.SetValue(attemptKey, attemptInfo{circuit: CircuitKey{Provider: provider}})
+			ctx.ClearValue(schemas.BifrostContextKeyDegradedFrom)
+			return req, nil, nil
+		}
+	}
+	circuit := p.breaker.Key(provider, model, keyID)
+
+	allowed, probe := p.breaker.acquire(circuit)
+	if allowed {
+		ctx.SetValue(attemptKey, attemptInfo{circuit: circuit, probe: probe})
+		// A fallback attempt that reaches a healthy provider is no longer degraded.
+		ctx.ClearValue(schemas.BifrostContextKeyDegradedFrom)
+		if probe {
+			ctx.AppendRoutingEngineLog(schemas.RoutingEngineCircuitBreaker, schemas.LogLevelInfo, fmt.Sprintf("Circuit for %s is half-open, sending probe request", circuit))
+		}
+		return req, nil, nil
+	}
+
+	// A closed circuit that rejects the attempt is ramping up after recovering.
+	status := "open"
+	if p.breaker.State(circuit) == StateClosed {
+		status = "recovering"
+	}
+	for _, rule := range *p.degradation.Load() {
+		if !rule.matches(provider, model) {
+			continue
+		}
+		req.SetProvider(rule.TargetProvider)
+		req.SetModel(rule.TargetModel)
+		ctx.SetValue(attemptKey, attemptInfo{circuit: p.breaker.Key(rule.TargetProvider, rule.TargetModel, "")})
+		ctx.SetValue(schemas.BifrostContextKeyDegradedFrom, string(provider)+"/"+model)
+		schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCircuitBreaker)
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineCircuitBreaker, schemas.LogLevelWarn, fmt.Sprintf("Circuit for %s is %s, degrading %s/%s to %s/%s", circuit, status, provider, model, rule.TargetProvider, rule.TargetModel))
+		return req, nil, nil
+	}
+
+	ctx.SetValue(attemptKey, attemptInfo{circuit: circuit, rejected: true})
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCircuitBreaker)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCircuitBreaker, schemas.LogLevelWarn, fmt.Sprintf("Circuit for %s is %s, rejecting request", circuit, status))
+	return req, &schemas.LLMPluginShortCircuit{
+		Error: &schemas.BifrostError{
+			IsBifrostError: true,
+			StatusCode:     schemas.Ptr(http.StatusServiceUnavailable),
+			Error: &schemas.ErrorField{
+				Type:    schemas.Ptr("circuit_open"),
+				Message: fmt.Sprintf("circuit breaker is %s for %s", status, circuit),
+			},
+			AllowFallbacks: schemas.Ptr(true),
+		},
+	}, nil
+}
+
+// KeyPoolFilter implements schemas.KeyPoolFilter for provider+key granularity; pass it as
+// BifrostConfig.KeyPoolFilter. It leaves keys whose circuits are open out of the pool, and
+// when a key is ready for its half-open probe it narrows the pool to that key. Core only
+// filters pools it can rotate through, so a provider's only key is never left out.
+func (p *Plugin) KeyPoolFilter(ctx *schemas.BifrostContext, provider schemas.ModelProvider, _ string, keys []schemas.Key) ([]schemas.Key, error) {
+	if p.breaker.granularity() != GranularityProviderKey {
+		return keys, nil
+	}
+	attempt, _ := ctx.Value(attemptKey).(attemptInfo)
+	if attempt.probe && attempt.circuit.KeyID != "" {
+		// Core only picks another key after a per-key error, so the probe failed.
+		p.breaker.RecordFailure(attempt.circuit)
+		attempt = attemptInfo{circuit: CircuitKey{Provider: provider}}
+		ctx.SetValue(attemptKey, attempt)
+	}
+
+	admitted, probe := p.breaker.admitKeys(provider, keys)
+	if probe != "" {
+		circuit := CircuitKey{Provider: provider, KeyID: probe}
+		ctx.SetValue(attemptKey, attemptInfo{circuit: circuit, probe: true})
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineCircuitBreaker, schemas.LogLevelInfo, fmt.Sprintf("Circuit for %s is half-open, sending probe request", circuit))
+	} else if len(admitted) < len(keys) {
+		schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCircuitBreaker)
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineCircuitBreaker, schemas.LogLevelWarn, fmt.Sprintf("Circuits for %d of %d %s keys are open, leaving them out", len(keys)-len(admitted), len(keys), provider))
+	}
+	return admitted, nil
+}
+
+// PostLLMHook records the attempt's outcome against its circuit. Server errors, rate limits
+// and transport failures count as failures; any other provider response proves the provider
+// is reachable and counts as a success. Streams are judged on their first error or final chunk.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	attempt, ok := ctx.Value(attemptKey).(attemptInfo)
+	if !ok || attempt.rejected {
+		return result, bifrostErr, nil
+	}
+	if p.breaker.granularity() == GranularityProviderKey {
+		// The last key tried served the outcome; a probe key core moved away from failed.
+		servedBy := lastAttemptKeyID(ctx)
+		if attempt.probe && servedBy != attempt.circuit.KeyID {
+			p.breaker.RecordFailure(attempt.circuit)
+			attempt = attemptInfo{circuit: CircuitKey{Provider: attempt.circuit.Provider}}
+		}
+		if attempt.circuit.KeyID == "" {
+			if servedBy == "" {
+				// Keyless and direct-key requests have no key circuit.
+				ctx.ClearValue(attemptKey)
+				return result, bifrostErr, nil
+			}
+			attempt.circuit.KeyID = servedBy
+			// Later chunks of a stream are judged against the same key.
+			ctx.SetValue(attemptKey, attempt)
+		}
+	}
+
+	switch {
+	case bifrostErr != nil:
+		// Clear the attempt so the stream's final chunk is not counted again.
+		ctx.ClearValue(attemptKey)
+		switch {
+		case isProviderFailure(bifrostErr):
+			p.breaker.RecordFailure(attempt.circuit)
+		case bifrostErr.IsBifrostError:
+			if attempt.probe {
+				p.breaker.releaseProbe(attempt.circuit)
+			}
+		default:
+			p.breaker.RecordSuccess(attempt.circuit)
+		}
+	case result != nil:
+		requestType, _, _, _ := bifrost.GetResponseFields(result, nil)
+		if bifrost.IsStreamRequestType(requestType) && !bifrost.IsFinalChunk(ctx) {
+			return result, bifrostErr, nil
+		}
+		p.breaker.RecordSuccess(attempt.circuit)
+	}
+	return result, bifrostErr, nil
+}
+
+// lastAttemptKeyID returns the ID of the key the attempt's last try was sent with, or "" when
+// the request used no configured key.
+func lastAttemptKeyID(ctx *schemas.BifrostContext) string {
+	trail, _ := ctx.Value(schemas.BifrostContextKeyAttemptTrail).([]schemas.KeyAttemptRecord)
+	if len(trail) == 0 {
+		return ""
+	}
+	return trail[len(trail)-1].KeyID
+}
+
+// isProviderFailure reports whether err reflects an unhealthy provider rather than a bad request.
+func isProviderFailure(err *schemas.BifrostError) bool {
+	if err.IsBifrostError {
+		return false
+	}
+	if err.StatusCode == nil {
+		return true
+	}
+	return *err.StatusCode >= http.StatusInternalServerError || *err.StatusCode == http.StatusTooManyRequests
+}
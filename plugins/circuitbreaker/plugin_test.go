@@ -0,0 +1,525 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// fakeClock lets tests step through the cooldown without sleeping.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+var openAI = CircuitKey{Provider: schemas.OpenAI}
+
+func newTestBreaker(clock *fakeClock, granularity CircuitGranularity) *CircuitBreaker {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		Granularity:          granularity,
+		FailureRateThreshold: 0.5,
+		MinimumRequests:      4,
+		WindowSize:           4,
+		CooldownPeriod:       10 * time.Second,
+	})
+	cb.now = clock.now
+	return cb
+}
+
+func chatRequest(provider schemas.ModelProvider, model string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: provider, Model: model},
+	}
+}
+
+func providerError(status int) *schemas.BifrostError {
+	return &schemas.BifrostError{StatusCode: schemas.Ptr(status), Error: &schemas.ErrorField{Message: "upstream error"}}
+}
+
+func chatResponse() *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionRequest},
+	}}
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	cb := newTestBreaker(clock, GranularityProvider)
+
+	cb.RecordSuccess(openAI)
+	cb.RecordFailure(openAI)
+	cb.RecordFailure(openAI)
+	if got := cb.State(openAI); got != StateClosed {
+		t.Fatalf("circuit opened before minimum requests: %s", got)
+	}
+	cb.RecordSuccess(openAI)
+	cb.RecordFailure(openAI) // window is now S F S F after evicting the first success
+	if got := cb.State(openAI); got != StateOpen {
+		t.Fatalf("expected open circuit at 50%% failures, got %s", got)
+	}
+	if cb.Allow(openAI) {
+		t.Fatal("open circuit allowed a request during cooldown")
+	}
+	if !cb.Allow(CircuitKey{Provider: schemas.Anthropic}) {
+		t.Fatal("circuits must be tracked per provider")
+	}
+
+	clock.t = clock.t.Add(10 * time.Second)
+	if !cb.Allow(openAI) {
+		t.Fatal("expected a probe after the cooldown")
+	}
+	if cb.Allow(openAI) {
+		t.Fatal("only one probe may be in flight")
+	}
+	cb.RecordFailure(openAI)
+	if got := cb.State(openAI); got != StateOpen {
+		t.Fatalf("failed probe should reopen the circuit, got %s", got)
+	}
+
+	clock.t = clock.t.Add(10 * time.Second)
+	if !cb.Allow(openAI) {
+		t.Fatal("expected a second probe after the cooldown")
+	}
+	cb.RecordSuccess(openAI)
+	if got := cb.State(openAI); got != StateClosed {
+		t.Fatalf("successful probe should close the circuit, got %s", got)
+	}
+}
+
+func TestPluginDegradesWhileCircuitIsOpen(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	plugin, err := Init(Config{
+		Degradation: []DegradationRule{{
+			Provider:       schemas.OpenAI,
+			Models:         []string{"gpt-4o"},
+			TargetProvider: schemas.Ollama,
+			TargetModel:    "llama3.1:8b",
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	plugin.breaker = newTestBreaker(clock, GranularityProvider)
+
+	// Trip the openai circuit through the hooks.
+	for range 4 {
+		ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+		if _, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o")); sc != nil {
+			t.Fatal("closed circuit short-circuited a request")
+		}
+		plugin.PostLLMHook(ctx, nil, providerError(502))
+	}
+	if got := plugin.breaker.State(openAI); got != StateOpen {
+		t.Fatalf("expected open circuit, got %s", got)
+	}
+
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	req, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o"))
+	if sc != nil {
+		t.Fatal("matching degradation rule should reroute, not short-circuit")
+	}
+	if req.ChatRequest.Provider != schemas.Ollama || req.ChatRequest.Model != "llama3.1:8b" {
+		t.Fatalf("expected degraded target, got %s/%s", req.ChatRequest.Provider, req.ChatRequest.Model)
+	}
+	if got, _ := ctx.Value(schemas.BifrostContextKeyDegradedFrom).(string); got != "openai/gpt-4o" {
+		t.Fatalf("expected degradation marker, got %q", got)
+	}
+
+	// Models outside the rule fail fast with a 503 that allows fallbacks.
+	ctx = schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	_, sc, _ = plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o-mini"))
+	if sc == nil || sc.Error == nil || *sc.Error.StatusCode != 503 || !*sc.Error.AllowFallbacks {
+		t.Fatal("expected a 503 short-circuit for an unmatched model")
+	}
+	plugin.PostLLMHook(ctx, nil, sc.Error)
+
+	// After the cooldown the probe goes to the primary and its success restores traffic.
+	clock.t = clock.t.Add(10 * time.Second)
+	ctx = schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	req, sc, _ = plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o"))
+	if sc != nil || req.ChatRequest.Provider != schemas.OpenAI {
+		t.Fatal("expected the probe to reach the primary provider")
+	}
+	plugin.PostLLMHook(ctx, chatResponse(), nil)
+	if got := plugin.breaker.State(openAI); got != StateClosed {
+		t.Fatalf("expected closed circuit after a successful probe, got %s", got)
+	}
+}
+
+func TestPluginIgnoresClientErrors(t *testing.T) {
+	plugin, err := Init(Config{CircuitBreakerConfig: CircuitBreakerConfig{MinimumRequests: 2, WindowSize: 2}}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for range 4 {
+		ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+		plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o"))
+		plugin.PostLLMHook(ctx, nil, providerError(400))
+	}
+	if got := plugin.breaker.State(openAI); got != StateClosed {
+		t.Fatalf("client errors must not open the circuit, got %s", got)
+	}
+}
+
+func TestInitRejectsIncompleteDegradationRule(t *testing.T) {
+	if _, err := Init(Config{Degradation: []DegradationRule{{Provider: schemas.OpenAI, TargetProvider: schemas.Ollama}}}, nil); err == nil {
+		t.Fatal("expected an error for a rule without target_model")
+	}
+	if _, err := Init(Config{Degradation: []DegradationRule{{Provider: schemas.OpenAI, TargetProvider: schemas.OpenAI, TargetModel: "gpt-4o-mini"}}}, nil); err == nil {
+		t.Fatal("expected an error for a rule targeting its own provider")
+	}
+}
+
+func TestModelCircuitsAreIndependent(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	plugin, err := Init(Config{}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	plugin.breaker = newTestBreaker(clock, GranularityProviderModel)
+
+	for range 4 {
+		ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+		plugin.PreLLMHook(ctx, chatRequest(schemas.Azure, "gpt-4o-deployment"))
+		plugin.PostLLMHook(ctx, nil, providerError(500))
+	}
+	if got := plugin.breaker.State(CircuitKey{Provider: schemas.Azure, Model: "gpt-4o-deployment"}); got != StateOpen {
+		t.Fatalf("expected the broken deployment's circuit open, got %s", got)
+	}
+
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	if _, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.Azure, "gpt-4o-mini-deployment")); sc != nil {
+		t.Fatal("an open model circuit must not reject other models on the provider")
+	}
+	ctx = schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	_, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.Azure, "gpt-4o-deployment"))
+	if sc == nil || sc.Error.Error.Message != "circuit breaker is open for azure/gpt-4o-deployment" {
+		t.Fatalf("expected the broken deployment rejected, got %+v", sc)
+	}
+}
+
+// keyAttempt runs one attempt through the hooks as core would with provider+key
+// granularity, sending it with the first key the filter leaves in the pool.
+func keyAttempt(t *testing.T, plugin *Plugin, keys []schemas.Key, bifrostErr *schemas.BifrostError) string {
+	t.Helper()
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	if _, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o")); sc != nil {
+		t.Fatal("an unpinned request must not be rejected before its key is picked")
+	}
+	admitted, err := plugin.KeyPoolFilter(ctx, schemas.OpenAI, "gpt-4o", keys)
+	if err != nil || len(admitted) == 0 {
+		return ""
+	}
+	ctx.SetValue(schemas.BifrostContextKeyAttemptTrail, []schemas.KeyAttemptRecord{{KeyID: admitted[0].ID}})
+	if bifrostErr != nil {
+		plugin.PostLLMHook(ctx, nil, bifrostErr)
+	} else {
+		plugin.PostLLMHook(ctx, chatResponse(), nil)
+	}
+	return admitted[0].ID
+}
+
+func TestKeyCircuitsFilterTheKeyPool(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	plugin, err := Init(Config{}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	plugin.breaker = newTestBreaker(clock, GranularityProviderKey)
+	broken, healthy := schemas.Key{ID: "key-a"}, schemas.Key{ID: "key-b"}
+
+	for range 4 {
+		if got := keyAttempt(t, plugin, []schemas.Key{broken}, providerError(429)); got != "key-a" {
+			t.Fatalf("expected key-a while its circuit is closed, got %q", got)
+		}
+	}
+	if got := plugin.breaker.State(CircuitKey{Provider: schemas.OpenAI, KeyID: "key-a"}); got != StateOpen {
+		t.Fatalf("expected key-a's circuit open, got %s", got)
+	}
+	if got := keyAttempt(t, plugin, []schemas.Key{broken, healthy}, nil); got != "key-b" {
+		t.Fatalf("expected the pool filtered down to key-b, got %q", got)
+	}
+
+	// A request pinned to the broken key is checked before key selection.
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyAPIKeyID, "key-a")
+	if _, sc, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o")); sc == nil {
+		t.Fatal("expected a request pinned to an open key circuit to be rejected")
+	}
+
+	// After the cooldown key-a is the probe, and its success closes its circuit.
+	clock.t = clock.t.Add(10 * time.Second)
+	if got := keyAttempt(t, plugin, []schemas.Key{broken, healthy}, nil); got != "key-a" {
+		t.Fatalf("expected the probe sent with key-a, got %q", got)
+	}
+	if got := plugin.breaker.State(CircuitKey{Provider: schemas.OpenAI, KeyID: "key-a"}); got != StateClosed {
+		t.Fatalf("expected key-a's circuit closed after its probe, got %s", got)
+	}
+	if got := plugin.breaker.State(openAI); got != StateClosed {
+		t.Fatalf("key failures must not open the provider circuit, got %s", got)
+	}
+}
+
+func TestInitRejectsUnknownGranularity(t *testing.T) {
+	if _, err := Init(Config{CircuitBreakerConfig: CircuitBreakerConfig{Granularity: "provider+region"}}, nil); err == nil {
+		t.Fatal("expected an error for an unknown granularity")
+	}
+}
+
+func TestSnapshotAndForcedStates(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	cb := newTestBreaker(clock, GranularityProvider)
+
+	for range 4 {
+		cb.RecordFailure(openAI)
+	}
+	snapshots := cb.Snapshot()
+	if len(snapshots) != 1 || snapshots[0].State != StateOpen || snapshots[0].FailureRate != 1 || snapshots[0].OpenedAt == nil {
+		t.Fatalf("unexpected snapshot: %+v", snapshots)
+	}
+	if got := snapshots[0].Transitions; len(got) != 1 || got[0].From != StateClosed || got[0].To != StateOpen || got[0].Reason != "4 of the last 4 requests failed" {
+		t.Fatalf("unexpected transitions: %+v", got)
+	}
+
+	// A forced-open circuit sends no probe after the cooldown.
+	if err := cb.ForceOpen(openAI); err != nil {
+		t.Fatalf("ForceOpen: %v", err)
+	}
+	clock.t = clock.t.Add(time.Minute)
+	if cb.Allow(openAI) {
+		t.Fatal("a forced-open circuit must not admit a probe")
+	}
+
+	// A forced-closed circuit does not open however many requests fail.
+	if err := cb.ForceClose(openAI); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+	for range 4 {
+		cb.RecordFailure(openAI)
+	}
+	if got := cb.State(openAI); got != StateClosed {
+		t.Fatalf("a forced-closed circuit opened: %s", got)
+	}
+
+	if err := cb.Reset(openAI); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	for range 4 {
+		cb.RecordFailure(openAI)
+	}
+	if got := cb.State(openAI); got != StateOpen {
+		t.Fatalf("expected the circuit to open again after Reset, got %s", got)
+	}
+
+	snapshot := cb.Snapshot()[0]
+	var closedToOpen int64
+	for _, count := range snapshot.TransitionCounts {
+		if count.From == StateClosed && count.To == StateOpen {
+			closedToOpen = count.Count
+		}
+	}
+	if closedToOpen != 2 || snapshot.Forced {
+		t.Fatalf("unexpected snapshot after reset: %+v", snapshot)
+	}
+
+	if err := cb.ForceOpen(CircuitKey{Provider: schemas.OpenAI, Model: "gpt-4o"}); err == nil {
+		t.Fatal("expected a model circuit to be rejected under provider granularity")
+	}
+	if err := cb.Reset(CircuitKey{Provider: schemas.Anthropic}); !errors.Is(err, ErrCircuitNotFound) {
+		t.Fatalf("expected ErrCircuitNotFound, got %v", err)
+	}
+}
+
+func TestSlowStartRampsTrafficAfterRecovery(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRateThreshold: 0.5,
+		MinimumRequests:      4,
+		WindowSize:           4,
+		CooldownPeriod:       10 * time.Second,
+		SlowStart:            &SlowStartConfig{Duration: 20 * time.Second},
+	})
+	cb.now = clock.now
+	admitted := func(n int) int {
+		count := 0
+		for range n {
+			if cb.Allow(openAI) {
+				count++
+			}
+		}
+		return count
+	}
+
+	for range 4 {
+		cb.RecordFailure(openAI)
+	}
+	clock.t = clock.t.Add(10 * time.Second)
+	if !cb.Allow(openAI) {
+		t.Fatal("expected the probe to be admitted after the cooldown")
+	}
+	cb.RecordSuccess(openAI)
+	if cb.State(openAI) != StateClosed {
+		t.Fatalf("expected the probe to close the circuit, got %s", cb.State(openAI))
+	}
+
+	if got := admitted(100); got != 10 {
+		t.Fatalf("expected 10%% of traffic in the first step, admitted %d of 100", got)
+	}
+	clock.t = clock.t.Add(10 * time.Second)
+	if got := admitted(100); got != 50 {
+		t.Fatalf("expected 50%% of traffic in the second step, admitted %d of 100", got)
+	}
+	clock.t = clock.t.Add(10 * time.Second)
+	if got := admitted(100); got != 100 {
+		t.Fatalf("expected full traffic after the ramp, admitted %d of 100", got)
+	}
+	if snapshot := cb.Snapshot()[0]; snapshot.TrafficFraction != 1 {
+		t.Fatalf("expected the snapshot to report full traffic, got %v", snapshot.TrafficFraction)
+	}
+}
+
+func TestAdaptiveThresholdFollowsBaseline(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRateThreshold: 0.5,
+		MinimumRequests:      10,
+		WindowSize:           10,
+		AdaptiveThreshold:    &AdaptiveThresholdConfig{Multiplier: 2, BaselineRequests: 100},
+	})
+	noisy := CircuitKey{Provider: schemas.Gemini}
+
+	// Learn baselines: OpenAI never fails, the noisy provider fails 30% of the time.
+	for i := range 100 {
+		cb.RecordSuccess(openAI)
+		if i%10 < 3 {
+			cb.RecordFailure(noisy)
+		} else {
+			cb.RecordSuccess(noisy)
+		}
+	}
+	if cb.State(noisy) != StateClosed {
+		t.Fatal("a 30% failure rate is below the static 50% threshold while the baseline is learned")
+	}
+	snapshots := cb.Snapshot()
+	for _, snapshot := range snapshots {
+		want := 0.1 // minimum threshold
+		if snapshot.Provider == schemas.Gemini {
+			want = 0.6
+		}
+		if diff := snapshot.FailureThreshold - want; diff > 0.01 || diff < -0.01 {
+			t.Fatalf("expected %s to open at %v, got %v", snapshot.Provider, want, snapshot.FailureThreshold)
+		}
+	}
+
+	// Two failures in ten are normal for the noisy provider but not for OpenAI.
+	for i := range 10 {
+		if i < 2 {
+			cb.RecordFailure(openAI)
+			cb.RecordFailure(noisy)
+		} else {
+			cb.RecordSuccess(openAI)
+			cb.RecordSuccess(noisy)
+		}
+	}
+	if cb.State(openAI) != StateOpen {
+		t.Fatal("expected OpenAI to open at twice its near-zero baseline")
+	}
+	if cb.State(noisy) != StateClosed {
+		t.Fatal("expected the noisy provider to stay closed within its baseline")
+	}
+}
+
+func TestInitRejectsInvalidSlowStartAndAdaptiveConfig(t *testing.T) {
+	if _, err := Init(Config{CircuitBreakerConfig: CircuitBreakerConfig{SlowStart: &SlowStartConfig{Steps: []float64{0.5, 1}}}}, nil); err == nil {
+		t.Fatal("expected a slow start step of 1 to be rejected")
+	}
+	if _, err := Init(Config{CircuitBreakerConfig: CircuitBreakerConfig{AdaptiveThreshold: &AdaptiveThresholdConfig{Multiplier: 0.5}}}, nil); err == nil {
+		t.Fatal("expected an adaptive multiplier below 1 to be rejected")
+	}
+}
+
+func TestReloadConfigKeepsCircuitState(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	plugin, err := Init(Config{}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	cb := plugin.breaker
+	cb.now = clock.now
+	anthropic := CircuitKey{Provider: schemas.Anthropic}
+	for range 20 {
+		cb.RecordFailure(openAI)
+	}
+	cb.RecordFailure(anthropic)
+	cb.RecordFailure(anthropic)
+	cb.RecordSuccess(anthropic)
+	cb.RecordSuccess(anthropic)
+
+	reloaded := Config{CircuitBreakerConfig: CircuitBreakerConfig{
+		FailureRateThreshold: 0.5,
+		MinimumRequests:      2,
+		WindowSize:           2,
+		CooldownPeriod:       time.Minute,
+	}}
+	if err := plugin.ReloadConfig(reloaded); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if cb.State(openAI) != StateOpen {
+		t.Fatal("an open circuit must stay open across a reload")
+	}
+	snapshots := cb.Snapshot()
+	if len(snapshots) != 2 || snapshots[0].Provider != schemas.Anthropic || snapshots[0].Requests != 2 || snapshots[0].Failures != 0 {
+		t.Fatalf("expected the shrunk window to keep the two most recent successes, got %+v", snapshots)
+	}
+	cb.RecordFailure(anthropic)
+	if cb.State(anthropic) != StateOpen {
+		t.Fatal("expected the reloaded threshold and minimum to apply")
+	}
+
+	reloaded.Granularity = GranularityProviderModel
+	if err := plugin.ReloadConfig(reloaded); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if len(cb.Snapshot()) != 0 {
+		t.Fatal("a granularity change must drop circuits keyed the old way")
+	}
+
+	reloaded.Degradation = []DegradationRule{{Provider: schemas.OpenAI}}
+	if err := plugin.ReloadConfig(reloaded); err == nil {
+		t.Fatal("expected an invalid degradation rule to be rejected")
+	}
+	if cb.granularity() != GranularityProviderModel || len(*plugin.degradation.Load()) != 0 {
+		t.Fatal("a rejected reload must leave the running config unchanged")
+	}
+}
+
+func TestOnStateChangeReportsTransitions(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	cb := newTestBreaker(clock, GranularityProvider)
+	var got []string
+	cb.OnStateChange(func(key CircuitKey, from, to CircuitState) {
+		got = append(got, string(key.Provider)+":"+string(from)+"->"+string(to))
+	})
+
+	for range 4 {
+		cb.RecordFailure(openAI)
+	}
+	clock.t = clock.t.Add(10 * time.Second)
+	if !cb.Allow(openAI) {
+		t.Fatal("expected the probe to be admitted")
+	}
+	cb.RecordSuccess(openAI)
+	if err := cb.ForceOpen(openAI); err != nil {
+		t.Fatalf("ForceOpen: %v", err)
+	}
+	if err := cb.ForceOpen(openAI); err != nil {
+		t.Fatalf("ForceOpen: %v", err)
+	}
+
+	want := []string{"openai:closed->open", "openai:open->half_open", "openai:half_open->closed", "openai:closed->open"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected transitions %v, got %v", want, got)
+	}
+}
@@ -5,6 +5,8 @@
 package compat
 
 import (
+	"strings"
+
 	"github.com/bytedance/sonic"
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/maximhq/bifrost/framework/modelcatalog"
@@ -18,15 +20,25 @@ type Config struct {
 	ConvertChatToResponses bool `json:"convert_chat_to_responses"`
 	ShouldDropParams       bool `json:"should_drop_params"`
 	ShouldConvertParams    bool `json:"should_convert_params"`
+	// TextToChatSystemPrompt is sent as the system message of text completion
+	// requests converted to chat, e.g. to tell the model to continue the prompt.
+	TextToChatSystemPrompt string `json:"text_to_chat_system_prompt,omitempty"`
+	// TextToChatModels are always converted from text completion to chat, whatever
+	// the model catalog says, for models whose provider is retiring its completions
+	// endpoint. Entries match the model or provider/model; a trailing "*" matches by
+	// prefix.
+	TextToChatModels []string `json:"text_to_chat_models,omitempty"`
 }
 
 // UnmarshalJSON defaults all bool fields to true when absent from JSON.
 func (c *Config) UnmarshalJSON(data []byte) error {
 	type config struct {
-		ConvertTextToChat      *bool `json:"convert_text_to_chat"`
-		ConvertChatToResponses *bool `json:"convert_chat_to_responses"`
-		ShouldDropParams       *bool `json:"should_drop_params"`
-		ShouldConvertParams    *bool `json:"should_convert_params"`
+		ConvertTextToChat      *bool    `json:"convert_text_to_chat"`
+		ConvertChatToResponses *bool    `json:"convert_chat_to_responses"`
+		ShouldDropParams       *bool    `json:"should_drop_params"`
+		ShouldConvertParams    *bool    `json:"should_convert_params"`
+		TextToChatSystemPrompt string   `json:"text_to_chat_system_prompt"`
+		TextToChatModels       []string `json:"text_to_chat_models"`
 	}
 	var s config
 	if err := sonic.Unmarshal(data, &s); err != nil {
@@ -36,6 +48,8 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	c.ConvertChatToResponses = s.ConvertChatToResponses == nil || *s.ConvertChatToResponses
 	c.ShouldDropParams = s.ShouldDropParams == nil || *s.ShouldDropParams
 	c.ShouldConvertParams = s.ShouldConvertParams == nil || *s.ShouldConvertParams
+	c.TextToChatSystemPrompt = s.TextToChatSystemPrompt
+	c.TextToChatModels = s.TextToChatModels
 	return nil
 }
 
@@ -114,7 +128,16 @@ func (p *CompatPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 	// Text completion → chat conversion
 	if (convertTextToChatOverrideEnabled && convertTextToChatOverride) || p.config.ConvertTextToChat {
 		if (modifiedReq.RequestType == schemas.TextCompletionRequest || modifiedReq.RequestType == schemas.TextCompletionStreamRequest) && modifiedReq.TextCompletionRequest != nil {
-			p.markForConversion(ctx, modifiedReq.TextCompletionRequest.Provider, modifiedReq.TextCompletionRequest.Model, schemas.TextCompletionRequest, schemas.ChatCompletionRequest)
+			provider, model := modifiedReq.TextCompletionRequest.Provider, modifiedReq.TextCompletionRequest.Model
+			if p.alwaysConvertTextToChat(provider, model) {
+				ctx.SetValue(schemas.BifrostContextKeyChangeRequestType, schemas.ChatCompletionRequest)
+			} else {
+				p.markForConversion(ctx, provider, model, schemas.TextCompletionRequest, schemas.ChatCompletionRequest)
+			}
+			if changeType, _ := ctx.Value(schemas.BifrostContextKeyChangeRequestType).(schemas.RequestType); changeType == schemas.ChatCompletionRequest && p.config.TextToChatSystemPrompt != "" {
+				ctx.SetValue(schemas.BifrostContextKeyChangeRequestSystemPrompt, p.config.TextToChatSystemPrompt)
+				schemas.AppendToContextList(ctx, schemas.BifrostContextKeySystemPromptInjections, p.config.TextToChatSystemPrompt)
+			}
 		}
 	}
 
@@ -177,6 +200,22 @@ func (p *CompatPlugin) Cleanup() error {
 	return nil
 }
 
+// alwaysConvertTextToChat reports whether the model is configured to always be
+// converted from text completion to chat.
+func (p *CompatPlugin) alwaysConvertTextToChat(provider schemas.ModelProvider, model string) bool {
+	providerModel := string(provider) + "/" + model
+	for _, pattern := range p.config.TextToChatModels {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(model, prefix) || strings.HasPrefix(providerModel, prefix) {
+				return true
+			}
+		} else if pattern == model || pattern == providerModel {
+			return true
+		}
+	}
+	return false
+}
+
 // markForConversion checks if the model supports the current request type; if not, mark for conversion
 func (p *CompatPlugin) markForConversion(ctx *schemas.BifrostContext, provider schemas.ModelProvider, model string, currentType schemas.RequestType, targetType schemas.RequestType) {
 	shouldConvert := false
@@ -0,0 +1,53 @@
+package compat
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func newTextCompletionRequest(provider schemas.ModelProvider, model string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.TextCompletionRequest,
+		TextCompletionRequest: &schemas.BifrostTextCompletionRequest{
+			Provider: provider,
+			Model:    model,
+			Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("def fib(n):")},
+		},
+	}
+}
+
+// TestTextToChatModelsAlwaysConvert verifies configured models are adapted to chat
+// without a model catalog, with the configured system prompt.
+func TestTextToChatModelsAlwaysConvert(t *testing.T) {
+	var config Config
+	if err := sonic.Unmarshal([]byte(`{"text_to_chat_system_prompt":"Continue the text.","text_to_chat_models":["gpt-4o*","anthropic/claude-sonnet-4"]}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	plugin, _ := Init(config, bifrost.NewDefaultLogger(schemas.LogLevelError), nil)
+
+	for _, tc := range []struct {
+		provider schemas.ModelProvider
+		model    string
+		convert  bool
+	}{
+		{schemas.OpenAI, "gpt-4o-mini", true},
+		{schemas.Anthropic, "claude-sonnet-4", true},
+		{schemas.Bedrock, "claude-sonnet-4", false},
+	} {
+		ctx := newTestContext()
+		if _, _, err := plugin.PreLLMHook(ctx, newTextCompletionRequest(tc.provider, tc.model)); err != nil {
+			t.Fatal(err)
+		}
+		changeType, _ := ctx.Value(schemas.BifrostContextKeyChangeRequestType).(schemas.RequestType)
+		systemPrompt, _ := ctx.Value(schemas.BifrostContextKeyChangeRequestSystemPrompt).(string)
+		if tc.convert && (changeType != schemas.ChatCompletionRequest || systemPrompt != "Continue the text.") {
+			t.Errorf("%s/%s: expected conversion with the system prompt, got %q %q", tc.provider, tc.model, changeType, systemPrompt)
+		}
+		if !tc.convert && (changeType != "" || systemPrompt != "") {
+			t.Errorf("%s/%s: expected no conversion, got %q %q", tc.provider, tc.model, changeType, systemPrompt)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+// Package costrouter provides an LLM plugin that enforces a per-request cost cap. A request
+// carries the cap in the x-bf-max-cost-usd header or the max_cost_usd parameter; its cost is
+// estimated from the prompt size and output token limit with model catalog pricing. When the
+// requested model belongs to a configured equivalence group, the request is routed to the
+// cheapest member that fits the cap, with the other fitting members as fallbacks. Requests
+// that no deployment can serve within the cap are rejected with a 400.
+package costrouter
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+)
+
+const PluginName = "cost-router"
+
+// MaxCostParam is the request parameter that carries the cap when the header is not set.
+const MaxCostParam = "max_cost_usd"
+
+const (
+	// DefaultCompletionTokens is the output estimate for requests without an output token limit.
+	DefaultCompletionTokens = 1024
+	// bytesPerToken approximates the tokenizer on serialized prompts, rounding up.
+	bytesPerToken = 4
+)
+
+// ErrCodeCostCapExceeded is returned in BifrostError.Error.Code when a request does not fit its cap.
+const ErrCodeCostCapExceeded = "cost_cap_exceeded"
+
+// Context keys carrying the PreRequestHook estimate to each attempt's PreLLMHook.
+const (
+	estimateKey  schemas.BifrostContextKey = "cost-router-estimate"
+	rejectionKey schemas.BifrostContextKey = "cost-router-rejection"
+)
+
+// Deployment is a provider/model pair in an equivalence group.
+type Deployment struct {
+	Provider schemas.ModelProvider `json:"provider"`
+	Model    string                `json:"model"`
+}
+
+func (d Deployment) String() string { return string(d.Provider) + "/" + d.Model }
+
+// Group lists deployments that can serve each other's requests.
+type Group struct {
+	Name        string       `json:"name"`
+	Deployments []Deployment `json:"deployments"`
+}
+
+// Config configures the cost router.
+type Config struct {
+	Groups []Group `json:"groups"`
+	// DefaultCompletionTokens is the output estimate for requests that do not set an output
+	// token limit (default: 1024). Requests that set one are estimated at that limit.
+	DefaultCompletionTokens int `json:"default_completion_tokens,omitempty"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	config  Config
+	groups  map[Deployment]*Group
+	catalog *modelcatalog.ModelCatalog
+	logger  schemas.Logger
+}
+
+// estimate is a request's cap and token estimate.
+type estimate struct {
+	maxCost          float64
+	promptTokens     int
+	completionTokens int
+	requestType      schemas.RequestType
+}
+
+// Init validates the groups and returns a cost router. The catalog is required.
+func Init(config Config, catalog *modelcatalog.ModelCatalog, logger schemas.Logger) (*Plugin, error) {
+	if catalog == nil {
+		return nil, fmt.Errorf("cost-router: model catalog is required")
+	}
+	if config.DefaultCompletionTokens == 0 {
+		config.DefaultCompletionTokens = DefaultCompletionTokens
+	}
+	if config.DefaultCompletionTokens < 0 {
+		return nil, fmt.Errorf("cost-router: default_completion_tokens must not be negative")
+	}
+	p := &Plugin{config: config, groups: make(map[Deployment]*Group), catalog: catalog, logger: logger}
+	for i := range config.Groups {
+		group := &config.Groups[i]
+		if group.Name == "" || len(group.Deployments) == 0 {
+			return nil, fmt.Errorf("cost-router: group %d needs a name and at least one deployment", i)
+		}
+		for _, deployment := range group.Deployments {
+			if deployment.Provider == "" || deployment.Model == "" {
+				return nil, fmt.Errorf("cost-router: deployments of group %s need a provider and model", group.Name)
+			}
+			if other, ok := p.groups[deployment]; ok {
+				return nil, fmt.Errorf("cost-router: %s is in both group %s and group %s", deployment, other.Name, group.Name)
+			}
+			p.groups[deployment] = group
+		}
+	}
+	return p, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook estimates the request and, when its model is in a group, routes it to the
+// cheapest member within the cap. Fallbacks become the other fitting members, cheapest
+// first, followed by the caller's own fallbacks.
+func (p *Plugin) PreRequestHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) error {
+	est, ok := p.estimate(ctx, req)
+	if !ok {
+		return nil
+	}
+	ctx.SetValue(estimateKey, est)
+
+	provider, model, fallbacks := req.GetRequestFields()
+	group := p.groups[Deployment{Provider: provider, Model: model}]
+	if group == nil && provider == "" {
+		group = p.groupForBareModel(model)
+	}
+	if group == nil {
+		return nil
+	}
+
+	type candidate struct {
+		Deployment
+		cost float64
+	}
+	var fitting []candidate
+	cheapest := candidate{cost: -1}
+	for _, deployment := range group.Deployments {
+		cost, priced := p.cost(deployment, est)
+		if !priced {
+			continue
+		}
+		if cheapest.cost < 0 || cost < cheapest.cost {
+			cheapest = candidate{deployment, cost}
+		}
+		if cost <= est.maxCost {
+			fitting = append(fitting, candidate{deployment, cost})
+		}
+	}
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineCost)
+	if len(fitting) == 0 {
+		message := fmt.Sprintf("no deployment in group %s fits the cost cap of $%g", group.Name, est.maxCost)
+		if cheapest.cost >= 0 {
+			message += fmt.Sprintf("; the cheapest is %s at an estimated $%.6f", cheapest.Deployment, cheapest.cost)
+		}
+		ctx.SetValue(rejectionKey, message)
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineCost, schemas.LogLevelWarn, message)
+		return nil
+	}
+	sort.SliceStable(fitting, func(i, j int) bool { return fitting[i].cost < fitting[j].cost })
+
+	best := fitting[0]
+	req.SetProvider(best.Provider)
+	req.SetModel(best.Model)
+	routed := make([]schemas.Fallback, 0, len(fitting)-1+len(fallbacks))
+	seen := make(map[Deployment]bool, len(fitting))
+	for _, c := range fitting {
+		seen[c.Deployment] = true
+		if c.Deployment != best.Deployment {
+			routed = append(routed, schemas.Fallback{Provider: c.Provider, Model: c.Model})
+		}
+	}
+	for _, fallback := range fallbacks {
+		if !seen[Deployment(fallback)] {
+			routed = append(routed, fallback)
+		}
+	}
+	req.SetFallbacks(routed)
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineCost, schemas.LogLevelInfo, fmt.Sprintf("Routed %s to %s at an estimated $%.6f (cap $%g, %d of %d deployments fit)", model, best.Deployment, best.cost, est.maxCost, len(fitting), len(group.Deployments)))
+	return nil
+}
+
+// PreLLMHook rejects attempts that do not fit the cap. A deployment without pricing cannot be
+// checked and is rejected too. Fallbacks stay allowed unless the group had no fitting member.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	est, ok := ctx.Value(estimateKey).(estimate)
+	if !ok {
+		return req, nil, nil
+	}
+	if message, ok := ctx.Value(rejectionKey).(string); ok {
+		return req, &schemas.LLMPluginShortCircuit{Error: capError(message, false)}, nil
+	}
+	provider, model, _ := req.GetRequestFields()
+	deployment := Deployment{Provider: provider, Model: model}
+	cost, priced := p.cost(deployment, est)
+	switch {
+	case !priced:
+		return req, &schemas.LLMPluginShortCircuit{Error: capError(fmt.Sprintf("%s has no pricing, so the cost cap of $%g cannot be enforced", deployment, est.maxCost), true)}, nil
+	case cost > est.maxCost:
+		return req, &schemas.LLMPluginShortCircuit{Error: capError(fmt.Sprintf("%s is estimated at $%.6f, above the cost cap of $%g", deployment, cost, est.maxCost), true)}, nil
+	}
+	return req, nil, nil
+}
+
+// PostLLMHook implements schemas.LLMPlugin.
+func (p *Plugin) PostLLMHook(_ *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	return result, bifrostErr, nil
+}
+
+func capError(message string, allowFallbacks bool) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(http.StatusBadRequest),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("invalid_request_error"),
+			Code:    schemas.Ptr(ErrCodeCostCapExceeded),
+			Message: message,
+		},
+		AllowFallbacks: schemas.Ptr(allowFallbacks),
+	}
+}
+
+// groupForBareModel returns the group of the first deployment whose model matches an
+// unprefixed model name.
+func (p *Plugin) groupForBareModel(model string) *Group {
+	for _, group := range p.config.Groups {
+		for _, deployment := range group.Deployments {
+			if deployment.Model == model {
+				return p.groups[deployment]
+			}
+		}
+	}
+	return nil
+}
+
+// cost returns the estimated cost of the request on deployment, and false when the
+// deployment has no pricing.
+func (p *Plugin) cost(deployment Deployment, est estimate) (float64, bool) {
+	if p.catalog.GetPricingEntryForModel(deployment.Model, deployment.Provider) == nil {
+		return 0, false
+	}
+	usage := &schemas.BifrostLLMUsage{
+		PromptTokens:     est.promptTokens,
+		CompletionTokens: est.completionTokens,
+		TotalTokens:      est.promptTokens + est.completionTokens,
+	}
+	return p.catalog.CalculateCostForUsage(usage, deployment.Provider, deployment.Model, est.requestType, nil), true
+}
+
+// estimate reads the request's cost cap and estimates its tokens. The max_cost_usd parameter
+// is removed so it is not forwarded to the provider. ok is false when the request has no cap
+// or is not a chat or responses request.
+func (p *Plugin) estimate(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (estimate, bool) {
+	var (
+		est         estimate
+		extraParams map[string]interface{}
+		input       interface{}
+		maxOutput   *int
+	)
+	switch {
+	case req.ChatRequest != nil:
+		est.requestType = schemas.ChatCompletionRequest
+		input = req.ChatRequest.Input
+		if params := req.ChatRequest.Params; params != nil {
+			extraParams = params.ExtraParams
+			maxOutput = params.MaxCompletionTokens
+		}
+	case req.ResponsesRequest != nil:
+		est.requestType = schemas.ResponsesRequest
+		input = req.ResponsesRequest.Input
+		if params := req.ResponsesRequest.Params; params != nil {
+			extraParams = params.ExtraParams
+			maxOutput = params.MaxOutputTokens
+		}
+	default:
+		return est, false
+	}
+
+	if raw, ok := extraParams[MaxCostParam]; ok {
+		delete(extraParams, MaxCostParam)
+		if maxCost, ok := parseMaxCost(raw); ok {
+			est.maxCost = maxCost
+		}
+	}
+	// The header wins over the parameter.
+	if maxCost, ok := ctx.Value(schemas.BifrostContextKeyMaxCostUSD).(float64); ok && maxCost > 0 {
+		est.maxCost = maxCost
+	}
+	if est.maxCost <= 0 {
+		return est, false
+	}
+
+	if body, err := sonic.Marshal(input); err == nil {
+		est.promptTokens = (len(body) + bytesPerToken - 1) / bytesPerToken
+	}
+	est.completionTokens = p.config.DefaultCompletionTokens
+	if maxOutput != nil && *maxOutput > 0 {
+		est.completionTokens = *maxOutput
+	}
+	return est, true
+}
+
+// parseMaxCost accepts the cap as a JSON number or numeric string.
+func parseMaxCost(raw interface{}) (float64, bool) {
+	var maxCost float64
+	switch v := raw.(type) {
+	case float64:
+		maxCost = v
+	case int:
+		maxCost = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		maxCost = parsed
+	default:
+		return 0, false
+	}
+	return maxCost, maxCost > 0
+}
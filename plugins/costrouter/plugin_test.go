@@ -0,0 +1,116 @@
+package costrouter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+	"github.com/maximhq/bifrost/framework/modelcatalog/datasheet"
+)
+
+var (
+	gpt4o     = Deployment{Provider: schemas.OpenAI, Model: "gpt-4o"}
+	gpt4oMini = Deployment{Provider: schemas.OpenAI, Model: "gpt-4o-mini"}
+	haiku     = Deployment{Provider: schemas.Anthropic, Model: "claude-3-5-haiku"}
+)
+
+func newTestRouter(t *testing.T) *Plugin {
+	t.Helper()
+	pricingPath := filepath.Join(t.TempDir(), "pricing.json")
+	if err := os.WriteFile(pricingPath, []byte(`{
+		"gpt-4o": {"provider":"openai","mode":"chat","input_cost_per_token":0.0000025,"output_cost_per_token":0.00001},
+		"gpt-4o-mini": {"provider":"openai","mode":"chat","input_cost_per_token":0.00000015,"output_cost_per_token":0.0000006},
+		"claude-3-5-haiku": {"provider":"anthropic","mode":"chat","input_cost_per_token":0.0000008,"output_cost_per_token":0.000004}
+	}`), 0o600); err != nil {
+		t.Fatalf("write pricing testdata: %v", err)
+	}
+	ds := datasheet.New(nil, bifrost.NewDefaultLogger(schemas.LogLevelError), datasheet.Config{URL: "file://" + pricingPath})
+	if err := ds.LoadFromURLIntoMemory(t.Context()); err != nil {
+		t.Fatalf("load pricing testdata: %v", err)
+	}
+	p, err := Init(Config{Groups: []Group{{Name: "general", Deployments: []Deployment{gpt4o, haiku, gpt4oMini}}}}, modelcatalog.NewTestCatalogWithDatasheet(ds), nil)
+	if err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	return p
+}
+
+func chatRequest(deployment Deployment, maxTokens int, extraParams map[string]interface{}) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: deployment.Provider,
+			Model:    deployment.Model,
+			Input:    []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hello")}}},
+			Params:   &schemas.ChatParameters{MaxCompletionTokens: schemas.Ptr(maxTokens), ExtraParams: extraParams},
+		},
+	}
+}
+
+func TestRoutesToCheapestDeploymentWithinCap(t *testing.T) {
+	p := newTestRouter(t)
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	// 1000 output tokens cost about $0.01 on gpt-4o, $0.004 on haiku and $0.0006 on mini.
+	req := chatRequest(gpt4o, 1000, map[string]interface{}{MaxCostParam: "0.005"})
+
+	if err := p.PreRequestHook(ctx, req); err != nil {
+		t.Fatalf("PreRequestHook failed: %v", err)
+	}
+	provider, model, fallbacks := req.GetRequestFields()
+	if provider != schemas.OpenAI || model != "gpt-4o-mini" {
+		t.Fatalf("expected the cheapest deployment, got %s/%s", provider, model)
+	}
+	if len(fallbacks) != 1 || fallbacks[0] != schemas.Fallback(haiku) {
+		t.Fatalf("expected haiku as the only fitting fallback, got %+v", fallbacks)
+	}
+	if _, ok := req.ChatRequest.Params.ExtraParams[MaxCostParam]; ok {
+		t.Fatal("expected max_cost_usd to be stripped from the forwarded params")
+	}
+	if _, sc, _ := p.PreLLMHook(ctx, req); sc != nil {
+		t.Fatalf("expected the routed attempt to pass, got %s", sc.Error.Error.Message)
+	}
+
+	// A fallback above the cap is rejected but lets core move on.
+	req.SetProvider(gpt4o.Provider)
+	req.SetModel(gpt4o.Model)
+	_, sc, _ := p.PreLLMHook(ctx, req)
+	if sc == nil || !*sc.Error.AllowFallbacks || *sc.Error.Error.Code != ErrCodeCostCapExceeded {
+		t.Fatalf("expected a fallback-allowing cap error, got %+v", sc)
+	}
+}
+
+func TestRejectsWhenNoDeploymentFits(t *testing.T) {
+	p := newTestRouter(t)
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyMaxCostUSD, 0.0001)
+	req := chatRequest(gpt4o, 1000, nil)
+
+	if err := p.PreRequestHook(ctx, req); err != nil {
+		t.Fatalf("PreRequestHook failed: %v", err)
+	}
+	_, sc, _ := p.PreLLMHook(ctx, req)
+	if sc == nil || *sc.Error.StatusCode != 400 || *sc.Error.AllowFallbacks {
+		t.Fatalf("expected a final 400, got %+v", sc)
+	}
+	if !strings.Contains(sc.Error.Error.Message, "cheapest is openai/gpt-4o-mini") {
+		t.Fatalf("expected the cheapest option in the message, got %q", sc.Error.Error.Message)
+	}
+}
+
+func TestRequestsWithoutCapAreUntouched(t *testing.T) {
+	p := newTestRouter(t)
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	req := chatRequest(gpt4o, 1000, nil)
+
+	p.PreRequestHook(ctx, req)
+	if provider, model, _ := req.GetRequestFields(); provider != schemas.OpenAI || model != "gpt-4o" {
+		t.Fatalf("expected the request to be left alone, got %s/%s", provider, model)
+	}
+	if _, sc, _ := p.PreLLMHook(ctx, req); sc != nil {
+		t.Fatalf("expected no cap check, got %s", sc.Error.Error.Message)
+	}
+}
@@ -0,0 +1,396 @@
+// Package dedup provides an LLM plugin that collapses identical requests while
+// they are in flight. The first request goes upstream; an identical one arriving
+// before it finishes waits and is answered with a copy of the first one's
+// response, or of its chunks as they stream in. Requests are identical when
+// they go to the same provider and model, have the same request type and hash
+// alike the way the semantic cache matches them.
+package dedup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/plugins/semanticcache"
+)
+
+const PluginName = "dedup"
+
+// DefaultMaxWait is how long a duplicate waits on the first request when
+// Config.MaxWaitSeconds is not set.
+const DefaultMaxWait = 2 * time.Minute
+
+// stateKey holds the *flight a leading attempt publishes to, or the *follower of
+// a streaming attempt that joined one, between PreLLMHook and PostLLMHook.
+const stateKey schemas.BifrostContextKey = "dedup-state"
+
+var (
+	errFlightEnded   = errors.New("the request this one was collapsed into ended without a response")
+	errFlightTimeout = errors.New("timed out waiting on the request this one was collapsed into")
+)
+
+// Config configures the dedup plugin.
+type Config struct {
+	// MaxWaitSeconds bounds how long a duplicate waits on the first request for
+	// its response, or for its next chunk when streaming. A duplicate that
+	// gives up on a non-streaming request goes upstream itself. Default: 120.
+	MaxWaitSeconds int `json:"max_wait_seconds,omitempty"`
+	// ShareAcrossVirtualKeys also collapses identical requests made with
+	// different virtual keys. By default a request only joins requests of its
+	// own virtual key.
+	ShareAcrossVirtualKeys bool `json:"share_across_virtual_keys,omitempty"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	maxWait                time.Duration
+	shareAcrossVirtualKeys bool
+	logger                 schemas.Logger
+
+	mu      sync.Mutex
+	flights map[string]*flight // by flight key, while the leader is in flight
+}
+
+// outcome is one response or error the leader got, marshalled so every
+// follower decodes its own copy that later plugins are free to modify.
+type outcome struct {
+	response []byte
+	err      []byte
+	failed   error // set when the leader's outcome could not be marshalled
+}
+
+// flight is the upstream call of a leading request that identical requests
+// attach to. A non-streaming flight publishes one outcome, a streaming one an
+// outcome per chunk.
+type flight struct {
+	key             string
+	leaderRequestID string
+	stream          bool
+
+	mu         sync.Mutex
+	outcomes   []outcome
+	done       bool
+	lastActive time.Time
+	changed    chan struct{} // closed and replaced whenever outcomes or done change
+}
+
+// follower tracks a streaming duplicate, so PostLLMHook can tell which chunk
+// is its last one.
+type follower struct {
+	received atomic.Int64 // chunks PostLLMHook has seen
+	last     atomic.Int64 // 1-based index of the final chunk, 0 until it is sent
+}
+
+// Init validates config and returns the plugin.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	if config.MaxWaitSeconds < 0 {
+		return nil, fmt.Errorf("dedup: max_wait_seconds cannot be negative")
+	}
+	maxWait := DefaultMaxWait
+	if config.MaxWaitSeconds > 0 {
+		maxWait = time.Duration(config.MaxWaitSeconds) * time.Second
+	}
+	return &Plugin{
+		maxWait:                maxWait,
+		shareAcrossVirtualKeys: config.ShareAcrossVirtualKeys,
+		logger:                 logger,
+		flights:                make(map[string]*flight),
+	}, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook implements schemas.LLMPlugin. Requests are collapsed per
+// attempt in PreLLMHook, so fallbacks are matched on their own provider and model.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook lets the first of a set of identical requests through and attaches
+// the rest to it. A non-streaming duplicate blocks until the leader's response
+// arrives; a streaming one is answered with a stream that replays the leader's
+// chunks as they come in.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	ctx.ClearValue(stateKey)
+	key, ok := p.flightKey(ctx, req)
+	if !ok {
+		return req, nil, nil
+	}
+
+	p.mu.Lock()
+	f, found := p.flights[key]
+	if found && f.idle() > p.maxWait {
+		// The leader stopped publishing without landing, e.g. its client went
+		// away mid-stream. Start over rather than join a flight that never ends.
+		found = false
+	}
+	if !found {
+		requestID, _ := ctx.Value(schemas.BifrostContextKeyRequestID).(string)
+		f = &flight{
+			key:             key,
+			leaderRequestID: requestID,
+			stream:          bifrost.IsStreamRequestType(req.RequestType),
+			lastActive:      time.Now(),
+			changed:         make(chan struct{}),
+		}
+		p.flights[key] = f
+	}
+	p.mu.Unlock()
+
+	if !found {
+		ctx.SetValue(stateKey, f)
+		return req, nil, nil
+	}
+	if f.stream {
+		state := &follower{}
+		ctx.SetValue(stateKey, state)
+		return req, &schemas.LLMPluginShortCircuit{Stream: p.replay(ctx, f, state, req.RequestType)}, nil
+	}
+
+	o, _, err := f.next(ctx, 0, p.maxWait)
+	if err != nil {
+		// Go upstream rather than fail a request that has not been answered yet
+		ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("dedup: %v, sending the request upstream", err))
+		return req, nil, nil
+	}
+	response, bifrostErr, err := f.decode(o, req.RequestType)
+	if err != nil {
+		ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("dedup: %v, sending the request upstream", err))
+		return req, nil, nil
+	}
+	if bifrostErr != nil {
+		return req, &schemas.LLMPluginShortCircuit{Error: bifrostErr}, nil
+	}
+	return req, &schemas.LLMPluginShortCircuit{Response: response}, nil
+}
+
+// PostLLMHook publishes a leader's responses and chunks to its followers, and
+// marks the final chunk of a streaming follower.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	switch state := ctx.Value(stateKey).(type) {
+	case *flight:
+		final := bifrostErr != nil || !state.stream || bifrost.IsFinalChunk(ctx)
+		if result != nil || bifrostErr != nil {
+			state.publish(p.encode(result, bifrostErr), final)
+		}
+		if final {
+			state.land()
+			p.land(state)
+			ctx.ClearValue(stateKey)
+		}
+	case *follower:
+		// Set synchronously, like the semantic cache does for replays, so the
+		// plugins after this one read the right value for this chunk.
+		if n := state.received.Add(1); n == state.last.Load() {
+			ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+		}
+	}
+	return result, bifrostErr, nil
+}
+
+// flightKey returns the key identical requests share, and false for requests
+// that cannot be collapsed.
+func (p *Plugin) flightKey(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (string, bool) {
+	hash, err := semanticcache.RequestHash(req, false, p.logger)
+	if err != nil {
+		return "", false
+	}
+	provider, model, _ := req.GetRequestFields()
+	key := string(req.RequestType) + "|" + string(provider) + "|" + model + "|" + hash
+	if !p.shareAcrossVirtualKeys {
+		vkID, _ := ctx.Value(schemas.BifrostContextKeyGovernanceVirtualKeyID).(string)
+		key += "|" + vkID
+	}
+	return key, true
+}
+
+// land forgets f so the next identical request goes upstream. A newer flight
+// that replaced an idle f is left in place.
+func (p *Plugin) land(f *flight) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.flights[f.key] == f {
+		delete(p.flights, f.key)
+	}
+}
+
+// encode marshals a leader's response or error into an outcome.
+func (p *Plugin) encode(result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) outcome {
+	var o outcome
+	var err error
+	if bifrostErr != nil {
+		o.err, err = json.Marshal(bifrostErr)
+	} else {
+		o.response, err = json.Marshal(result)
+	}
+	if err != nil {
+		p.logger.Warn("dedup: failed to copy a response for duplicate requests: %v", err)
+		return outcome{failed: fmt.Errorf("failed to copy the shared response: %w", err)}
+	}
+	return o
+}
+
+// replay returns the stream a streaming follower is answered with. It sends
+// the leader's chunks as they are published and ends with an error chunk when
+// the leader stops without finishing.
+func (p *Plugin) replay(ctx *schemas.BifrostContext, f *flight, state *follower, requestType schemas.RequestType) chan *schemas.BifrostStreamChunk {
+	stream := make(chan *schemas.BifrostStreamChunk)
+	go func() {
+		defer close(stream)
+		for i := 0; ; i++ {
+			chunk, last := f.nextChunk(ctx, i, p.maxWait, requestType)
+			if chunk == nil {
+				return
+			}
+			if last {
+				state.last.Store(int64(i + 1))
+			}
+			select {
+			case stream <- chunk:
+			case <-ctx.Done():
+				return
+			}
+			if last {
+				return
+			}
+		}
+	}()
+	return stream
+}
+
+// nextChunk returns the i-th chunk of a streaming flight for a follower and
+// whether it is the last. It returns nil when the follower's context ends.
+func (f *flight) nextChunk(ctx *schemas.BifrostContext, i int, maxWait time.Duration, requestType schemas.RequestType) (*schemas.BifrostStreamChunk, bool) {
+	o, last, err := f.next(ctx, i, maxWait)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		return &schemas.BifrostStreamChunk{BifrostError: flightError(err)}, true
+	}
+	response, bifrostErr, err := f.decode(o, requestType)
+	if err != nil {
+		return &schemas.BifrostStreamChunk{BifrostError: flightError(err)}, true
+	}
+	if bifrostErr != nil {
+		return &schemas.BifrostStreamChunk{BifrostError: bifrostErr}, true
+	}
+	return &schemas.BifrostStreamChunk{
+		BifrostTextCompletionResponse:        response.TextCompletionResponse,
+		BifrostChatResponse:                  response.ChatResponse,
+		BifrostResponsesStreamResponse:       response.ResponsesStreamResponse,
+		BifrostSpeechStreamResponse:          response.SpeechStreamResponse,
+		BifrostTranscriptionStreamResponse:   response.TranscriptionStreamResponse,
+		BifrostImageGenerationStreamResponse: response.ImageGenerationStreamResponse,
+	}, last
+}
+
+// decode returns a follower's own copy of o, stamped with the leader it came from.
+func (f *flight) decode(o outcome, requestType schemas.RequestType) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	if o.failed != nil {
+		return nil, nil, o.failed
+	}
+	if o.err != nil {
+		var bifrostErr schemas.BifrostError
+		if err := json.Unmarshal(o.err, &bifrostErr); err != nil {
+			return nil, nil, fmt.Errorf("failed to copy the shared error: %w", err)
+		}
+		return nil, &bifrostErr, nil
+	}
+	var response schemas.BifrostResponse
+	if err := json.Unmarshal(o.response, &response); err != nil {
+		return nil, nil, fmt.Errorf("failed to copy the shared response: %w", err)
+	}
+	if extraFields := response.GetExtraFields(); extraFields != nil {
+		if extraFields.RequestType == "" {
+			extraFields.RequestType = requestType
+		}
+		extraFields.Dedup = &schemas.DedupInfo{LeaderRequestID: f.leaderRequestID}
+	}
+	return &response, nil, nil
+}
+
+// publish appends the leader's next outcome, ending the flight when it is final.
+func (f *flight) publish(o outcome, final bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.done {
+		return
+	}
+	f.outcomes = append(f.outcomes, o)
+	f.done = final
+	f.notifyLocked()
+}
+
+// land ends the flight. Followers still waiting on an outcome that was never
+// published get errFlightEnded.
+func (f *flight) land() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.done {
+		f.done = true
+		f.notifyLocked()
+	}
+}
+
+func (f *flight) notifyLocked() {
+	f.lastActive = time.Now()
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// idle returns how long ago the leader last published.
+func (f *flight) idle() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return time.Since(f.lastActive)
+}
+
+// next blocks until the leader published its i-th outcome, returning it with
+// whether it is the last one. It fails when ctx ends, the flight ends without
+// it, or nothing is published for maxWait.
+func (f *flight) next(ctx *schemas.BifrostContext, i int, maxWait time.Duration) (outcome, bool, error) {
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+	for {
+		f.mu.Lock()
+		if i < len(f.outcomes) {
+			o, last := f.outcomes[i], f.done && i == len(f.outcomes)-1
+			f.mu.Unlock()
+			return o, last, nil
+		}
+		done, changed := f.done, f.changed
+		f.mu.Unlock()
+		if done {
+			return outcome{}, true, errFlightEnded
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return outcome{}, false, ctx.Err()
+		case <-timer.C:
+			return outcome{}, false, errFlightTimeout
+		}
+	}
+}
+
+// flightError is the error a follower gets when the request it was collapsed
+// into cannot answer it. Fallbacks are allowed: the follower has not been
+// sent anywhere yet.
+func flightError(err error) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(http.StatusGatewayTimeout),
+		Error:          &schemas.ErrorField{Message: err.Error()},
+	}
+}
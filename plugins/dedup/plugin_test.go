@@ -0,0 +1,229 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func chatRequest(requestType schemas.RequestType, model, prompt string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: requestType,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    model,
+			Input: []schemas.ChatMessage{{
+				Role:    schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(prompt)},
+			}},
+		},
+	}
+}
+
+func chatResponse(text string) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+				Message: &schemas.ChatMessage{
+					Role:    schemas.ChatMessageRoleAssistant,
+					Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)},
+				},
+			},
+		}},
+	}}
+}
+
+func responseText(response *schemas.BifrostResponse) string {
+	return *response.ChatResponse.Choices[0].Message.Content.ContentStr
+}
+
+func requestContext(requestID, vkID string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, requestID)
+	if vkID != "" {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, vkID)
+	}
+	return ctx
+}
+
+func newPlugin(t *testing.T, config Config) *Plugin {
+	t.Helper()
+	plugin, err := Init(config, bifrost.NewNoOpLogger())
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	return plugin
+}
+
+// follow runs PreLLMHook for a duplicate in the background and returns its short circuit.
+func follow(plugin *Plugin, ctx *schemas.BifrostContext, req *schemas.BifrostRequest) <-chan *schemas.LLMPluginShortCircuit {
+	result := make(chan *schemas.LLMPluginShortCircuit, 1)
+	go func() {
+		_, shortCircuit, _ := plugin.PreLLMHook(ctx, req)
+		result <- shortCircuit
+	}()
+	return result
+}
+
+func TestDuplicateWaitsForLeaderResponse(t *testing.T) {
+	plugin := newPlugin(t, Config{})
+	leaderCtx := requestContext("req-1", "vk-a")
+	if _, shortCircuit, _ := plugin.PreLLMHook(leaderCtx, chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello")); shortCircuit != nil {
+		t.Fatal("the first request must go upstream")
+	}
+
+	// Normalized like the semantic cache: case and surrounding space do not matter
+	waiting := follow(plugin, requestContext("req-2", "vk-a"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "  hello "))
+	select {
+	case <-waiting:
+		t.Fatal("the duplicate must wait for the leader")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	leaderResponse := chatResponse("hi there")
+	plugin.PostLLMHook(leaderCtx, leaderResponse, nil)
+	shortCircuit := <-waiting
+	if shortCircuit == nil || shortCircuit.Response == nil {
+		t.Fatalf("expected the duplicate to be answered with the leader's response, got %+v", shortCircuit)
+	}
+	if shortCircuit.Response == leaderResponse || shortCircuit.Response.ChatResponse == leaderResponse.ChatResponse {
+		t.Fatal("the duplicate must get its own copy of the response")
+	}
+	if got := responseText(shortCircuit.Response); got != "hi there" {
+		t.Fatalf("expected the leader's text, got %q", got)
+	}
+	if dedup := shortCircuit.Response.ChatResponse.ExtraFields.Dedup; dedup == nil || dedup.LeaderRequestID != "req-1" {
+		t.Fatalf("expected the response to name its leader, got %+v", dedup)
+	}
+
+	// Once the leader has landed the next identical request goes upstream again
+	if _, shortCircuit, _ := plugin.PreLLMHook(requestContext("req-3", "vk-a"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello")); shortCircuit != nil {
+		t.Fatal("a request after the leader finished must go upstream")
+	}
+}
+
+func TestDuplicateSharesLeaderError(t *testing.T) {
+	plugin := newPlugin(t, Config{})
+	leaderCtx := requestContext("req-1", "")
+	plugin.PreLLMHook(leaderCtx, chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+	waiting := follow(plugin, requestContext("req-2", ""), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+
+	time.Sleep(20 * time.Millisecond)
+	plugin.PostLLMHook(leaderCtx, nil, &schemas.BifrostError{StatusCode: schemas.Ptr(503), Error: &schemas.ErrorField{Message: "overloaded"}})
+	shortCircuit := <-waiting
+	if shortCircuit == nil || shortCircuit.Error == nil || shortCircuit.Error.Error.Message != "overloaded" || *shortCircuit.Error.StatusCode != 503 {
+		t.Fatalf("expected the leader's error, got %+v", shortCircuit)
+	}
+}
+
+func TestDifferentRequestsAreNotCollapsed(t *testing.T) {
+	plugin := newPlugin(t, Config{})
+	plugin.PreLLMHook(requestContext("req-1", "vk-a"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+
+	for name, attempt := range map[string]struct {
+		ctx *schemas.BifrostContext
+		req *schemas.BifrostRequest
+	}{
+		"other prompt":      {requestContext("req-2", "vk-a"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Goodbye")},
+		"other model":       {requestContext("req-3", "vk-a"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o-mini", "Hello")},
+		"streaming":         {requestContext("req-4", "vk-a"), chatRequest(schemas.ChatCompletionStreamRequest, "gpt-4o", "Hello")},
+		"other virtual key": {requestContext("req-5", "vk-b"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello")},
+	} {
+		if _, shortCircuit, _ := plugin.PreLLMHook(attempt.ctx, attempt.req); shortCircuit != nil {
+			t.Errorf("%s: expected the request to go upstream", name)
+		}
+	}
+
+	shared := newPlugin(t, Config{ShareAcrossVirtualKeys: true})
+	leaderCtx := requestContext("req-1", "vk-a")
+	shared.PreLLMHook(leaderCtx, chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+	waiting := follow(shared, requestContext("req-2", "vk-b"), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+	time.Sleep(20 * time.Millisecond)
+	shared.PostLLMHook(leaderCtx, chatResponse("hi"), nil)
+	if shortCircuit := <-waiting; shortCircuit == nil || shortCircuit.Response == nil {
+		t.Fatal("expected virtual keys to share requests with share_across_virtual_keys")
+	}
+}
+
+func TestDuplicateGoesUpstreamWhenLeaderStalls(t *testing.T) {
+	plugin := newPlugin(t, Config{MaxWaitSeconds: 1})
+	plugin.PreLLMHook(requestContext("req-1", ""), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+
+	start := time.Now()
+	_, shortCircuit, _ := plugin.PreLLMHook(requestContext("req-2", ""), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello"))
+	if shortCircuit != nil {
+		t.Fatal("expected the duplicate to go upstream after waiting max_wait_seconds")
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Fatalf("expected the duplicate to wait for the leader, waited %s", waited)
+	}
+
+	// The stalled flight is replaced, so the next request leads a new one
+	if _, shortCircuit, _ := plugin.PreLLMHook(requestContext("req-3", ""), chatRequest(schemas.ChatCompletionRequest, "gpt-4o", "Hello")); shortCircuit != nil {
+		t.Fatal("expected a stalled flight to be replaced")
+	}
+}
+
+func TestStreamingDuplicateReplaysLeaderChunks(t *testing.T) {
+	plugin := newPlugin(t, Config{})
+	leaderCtx := requestContext("req-1", "")
+	plugin.PreLLMHook(leaderCtx, chatRequest(schemas.ChatCompletionStreamRequest, "gpt-4o", "Hello"))
+
+	followerCtx := requestContext("req-2", "")
+	_, shortCircuit, _ := plugin.PreLLMHook(followerCtx, chatRequest(schemas.ChatCompletionStreamRequest, "gpt-4o", "Hello"))
+	if shortCircuit == nil || shortCircuit.Stream == nil {
+		t.Fatalf("expected a streaming duplicate to be answered with a stream, got %+v", shortCircuit)
+	}
+
+	go func() {
+		plugin.PostLLMHook(leaderCtx, chatResponse("hel"), nil)
+		time.Sleep(20 * time.Millisecond)
+		leaderCtx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+		plugin.PostLLMHook(leaderCtx, chatResponse("lo"), nil)
+	}()
+
+	var text string
+	var finals []bool
+	for chunk := range shortCircuit.Stream {
+		if chunk.BifrostError != nil {
+			t.Fatalf("unexpected stream error: %s", chunk.BifrostError.Error.Message)
+		}
+		response := &schemas.BifrostResponse{ChatResponse: chunk.BifrostChatResponse}
+		plugin.PostLLMHook(followerCtx, response, nil)
+		text += responseText(response)
+		finals = append(finals, bifrost.IsFinalChunk(followerCtx))
+		if chunk.BifrostChatResponse.ExtraFields.RequestType != schemas.ChatCompletionStreamRequest {
+			t.Fatalf("expected replayed chunks to carry the request type, got %q", chunk.BifrostChatResponse.ExtraFields.RequestType)
+		}
+	}
+	if text != "hello" {
+		t.Fatalf("expected the leader's chunks, got %q", text)
+	}
+	if len(finals) != 2 || finals[0] || !finals[1] {
+		t.Fatalf("expected only the last replayed chunk to be final, got %v", finals)
+	}
+}
+
+func TestStreamingDuplicateJoiningLateGetsEarlierChunks(t *testing.T) {
+	plugin := newPlugin(t, Config{})
+	leaderCtx := requestContext("req-1", "")
+	plugin.PreLLMHook(leaderCtx, chatRequest(schemas.ChatCompletionStreamRequest, "gpt-4o", "Hello"))
+	plugin.PostLLMHook(leaderCtx, chatResponse("hel"), nil)
+
+	_, shortCircuit, _ := plugin.PreLLMHook(requestContext("req-2", ""), chatRequest(schemas.ChatCompletionStreamRequest, "gpt-4o", "Hello"))
+	if shortCircuit == nil || shortCircuit.Stream == nil {
+		t.Fatal("expected to join the stream in flight")
+	}
+	plugin.PostLLMHook(leaderCtx, nil, &schemas.BifrostError{Error: &schemas.ErrorField{Message: "connection reset"}})
+
+	var chunks []*schemas.BifrostStreamChunk
+	for chunk := range shortCircuit.Stream {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 2 || chunks[0].BifrostChatResponse == nil || chunks[1].BifrostError == nil || chunks[1].BifrostError.Error.Message != "connection reset" {
+		t.Fatalf("expected the earlier chunk followed by the leader's error, got %+v", chunks)
+	}
+}
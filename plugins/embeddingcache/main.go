@@ -0,0 +1,307 @@
+// Package embeddingcache provides an LLM plugin that caches embedding vectors keyed
+// by the exact input text. Unlike the semantic cache, which stores whole responses,
+// it caches per input string: a request whose texts are all cached is answered
+// without calling the provider, and a partially cached request only sends the
+// missing texts upstream. Vectors live in an in-memory LRU with an optional shared
+// Redis tier.
+package embeddingcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/redis/go-redis/v9"
+)
+
+const PluginName = "embedding-cache"
+
+// CacheHitType is reported as CacheDebug.HitType on responses served entirely from the cache.
+const CacheHitType = "embedding"
+
+const (
+	DefaultTTL        = 24 * time.Hour
+	DefaultMaxEntries = 10000
+	DefaultKeyPrefix  = "bifrost:embeddingcache:"
+
+	// tierTimeout bounds each Redis round trip so a slow tier degrades to a cache miss.
+	tierTimeout = 200 * time.Millisecond
+)
+
+// stateKey carries the per-attempt cacheState from PreLLMHook to PostLLMHook.
+const stateKey schemas.BifrostContextKey = "embedding-cache-state"
+
+// Config configures the embedding cache.
+type Config struct {
+	TTL        time.Duration `json:"ttl,omitempty"`         // Time-to-live for cached vectors (default: 24h)
+	MaxEntries int           `json:"max_entries,omitempty"` // Maximum vectors held in memory (default: 10000)
+	Redis      *RedisConfig  `json:"redis,omitempty"`       // Optional shared Redis tier
+}
+
+// RedisConfig configures the optional Redis tier.
+type RedisConfig struct {
+	Addr      string `json:"addr"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	DB        int    `json:"db,omitempty"`
+	KeyPrefix string `json:"key_prefix,omitempty"` // default: "bifrost:embeddingcache:"
+}
+
+// Stats reports cache effectiveness since the plugin started. Hits and misses count input texts.
+type Stats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Entries int     `json:"entries"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	ttl    time.Duration
+	memory *memoryStore
+	tier   Tier
+	logger schemas.Logger
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheState records which input texts were served from the cache for one attempt.
+type cacheState struct {
+	keys   []string          // cache key per original input text
+	hits   map[int][]float64 // original index -> cached vector
+	misses []int             // original indices sent upstream, in request order
+}
+
+// Init returns an embedding cache plugin. When config.Redis is set, a Redis client is
+// created for the shared tier.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultMaxEntries
+	}
+	plugin := &Plugin{
+		ttl:    config.TTL,
+		memory: newMemoryStore(config.TTL, config.MaxEntries),
+		logger: logger,
+	}
+	if config.Redis != nil {
+		if config.Redis.Addr == "" {
+			return nil, fmt.Errorf("embedding-cache: redis.addr is required when redis is configured")
+		}
+		keyPrefix := config.Redis.KeyPrefix
+		if keyPrefix == "" {
+			keyPrefix = DefaultKeyPrefix
+		}
+		client := redis.NewClient(&redis.Options{
+			Addr:     config.Redis.Addr,
+			Username: config.Redis.Username,
+			Password: config.Redis.Password,
+			DB:       config.Redis.DB,
+		})
+		plugin.tier = NewRedisTier(client, keyPrefix)
+	}
+	return plugin, nil
+}
+
+// SetTier replaces the shared second-level store. Passing nil disables it.
+func (p *Plugin) SetTier(tier Tier) { p.tier = tier }
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error {
+	if closer, ok := p.tier.(*redisTier); ok {
+		return closer.client.Close()
+	}
+	return nil
+}
+
+// Stats returns the plugin's hit/miss counters and current in-memory size.
+func (p *Plugin) Stats() Stats {
+	stats := Stats{Hits: p.hits.Load(), Misses: p.misses.Load(), Entries: p.memory.len()}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// PreRequestHook implements schemas.LLMPlugin. Lookups happen per attempt in PreLLMHook
+// because the cache key includes the provider and model.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook looks up every input text. A full hit short-circuits with the cached vectors;
+// a partial hit forwards only the missing texts and PostLLMHook merges the rest back in.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	if req.RequestType != schemas.EmbeddingRequest || req.EmbeddingRequest == nil {
+		return req, nil, nil
+	}
+	if useRaw, _ := ctx.Value(schemas.BifrostContextKeyUseRawRequestBody).(bool); useRaw {
+		return req, nil, nil
+	}
+	embeddingReq := req.EmbeddingRequest
+	texts := inputTexts(embeddingReq.Input)
+	if len(texts) == 0 || !cacheableParams(embeddingReq.Params) {
+		return req, nil, nil
+	}
+
+	state := &cacheState{keys: make([]string, len(texts)), hits: make(map[int][]float64)}
+	var missTexts []string
+	for i, text := range texts {
+		state.keys[i] = cacheKey(embeddingReq.Provider, embeddingReq.Model, embeddingReq.Params, text)
+		if vector, ok := p.lookup(ctx, state.keys[i]); ok {
+			state.hits[i] = vector
+			continue
+		}
+		state.misses = append(state.misses, i)
+		missTexts = append(missTexts, text)
+	}
+	p.hits.Add(int64(len(state.hits)))
+	p.misses.Add(int64(len(state.misses)))
+
+	if len(state.misses) == 0 {
+		return req, &schemas.LLMPluginShortCircuit{Response: &schemas.BifrostResponse{
+			EmbeddingResponse: &schemas.BifrostEmbeddingResponse{
+				Data:   appendCachedVectors(nil, state.hits),
+				Model:  embeddingReq.Model,
+				Object: "list",
+				Usage:  &schemas.BifrostLLMUsage{},
+				ExtraFields: schemas.BifrostResponseExtraFields{
+					CacheDebug: &schemas.BifrostCacheDebug{CacheHit: true, HitType: schemas.Ptr(CacheHitType)},
+				},
+			},
+		}}, nil
+	}
+
+	ctx.SetValue(stateKey, state)
+	if len(state.hits) == 0 {
+		return req, nil, nil
+	}
+
+	// Copy rather than mutate so a fallback attempt starts from the full input again.
+	trimmedEmbeddingReq := *embeddingReq
+	trimmedEmbeddingReq.Input = &schemas.EmbeddingInput{Texts: missTexts}
+	trimmedReq := *req
+	trimmedReq.EmbeddingRequest = &trimmedEmbeddingReq
+	return &trimmedReq, nil, nil
+}
+
+// PostLLMHook stores the newly embedded vectors and, after a partial hit, merges the cached
+// vectors back in so the response lines up with the caller's original input order.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	state, ok := ctx.Value(stateKey).(*cacheState)
+	if !ok {
+		return result, bifrostErr, nil
+	}
+	ctx.ClearValue(stateKey)
+	if bifrostErr != nil || result == nil || result.EmbeddingResponse == nil {
+		return result, bifrostErr, nil
+	}
+
+	response := result.EmbeddingResponse
+	merged := make([]schemas.EmbeddingData, 0, len(response.Data)+len(state.hits))
+	for _, data := range response.Data {
+		if data.Index < 0 || data.Index >= len(state.misses) {
+			merged = append(merged, data)
+			continue
+		}
+		originalIndex := state.misses[data.Index]
+		if data.Embedding.EmbeddingArray != nil {
+			p.store(ctx, state.keys[originalIndex], slices.Clone(data.Embedding.EmbeddingArray))
+		}
+		data.Index = originalIndex
+		merged = append(merged, data)
+	}
+	if len(state.hits) > 0 {
+		merged = appendCachedVectors(merged, state.hits)
+		slices.SortFunc(merged, func(a, b schemas.EmbeddingData) int { return a.Index - b.Index })
+	}
+	response.Data = merged
+	return result, bifrostErr, nil
+}
+
+// lookup checks memory first, then the shared tier, promoting tier hits into memory.
+func (p *Plugin) lookup(ctx context.Context, key string) ([]float64, bool) {
+	if vector, ok := p.memory.get(key); ok {
+		return vector, true
+	}
+	if p.tier == nil {
+		return nil, false
+	}
+	tierCtx, cancel := context.WithTimeout(ctx, tierTimeout)
+	defer cancel()
+	vector, ok, err := p.tier.Get(tierCtx, key)
+	if err != nil {
+		p.logger.Warn("embedding-cache: tier lookup failed: %v", err)
+		return nil, false
+	}
+	if ok {
+		p.memory.set(key, vector)
+	}
+	return vector, ok
+}
+
+// store writes a vector to memory and, when configured, the shared tier.
+func (p *Plugin) store(ctx context.Context, key string, vector []float64) {
+	p.memory.set(key, vector)
+	if p.tier == nil {
+		return
+	}
+	tierCtx, cancel := context.WithTimeout(ctx, tierTimeout)
+	defer cancel()
+	if err := p.tier.Set(tierCtx, key, vector, p.ttl); err != nil {
+		p.logger.Warn("embedding-cache: tier write failed: %v", err)
+	}
+}
+
+// inputTexts returns the request's text inputs, or nil for token-array inputs.
+func inputTexts(input *schemas.EmbeddingInput) []string {
+	switch {
+	case input == nil:
+		return nil
+	case input.Text != nil:
+		return []string{*input.Text}
+	default:
+		return input.Texts
+	}
+}
+
+// cacheableParams reports whether the request asks for float vectors, the only format cached.
+func cacheableParams(params *schemas.EmbeddingParameters) bool {
+	return params == nil || params.EncodingFormat == nil || *params.EncodingFormat == "float"
+}
+
+// cacheKey hashes everything that determines the vector for text.
+func cacheKey(provider schemas.ModelProvider, model string, params *schemas.EmbeddingParameters, text string) string {
+	dimensions := ""
+	if params != nil && params.Dimensions != nil {
+		dimensions = strconv.Itoa(*params.Dimensions)
+	}
+	sum := sha256.Sum256([]byte(string(provider) + "\x00" + model + "\x00" + dimensions + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// appendCachedVectors appends the cached vectors to data at their original indices.
+func appendCachedVectors(data []schemas.EmbeddingData, hits map[int][]float64) []schemas.EmbeddingData {
+	start := len(data)
+	for index, vector := range hits {
+		data = append(data, schemas.EmbeddingData{
+			Index:     index,
+			Object:    "embedding",
+			Embedding: schemas.EmbeddingStruct{EmbeddingArray: slices.Clone(vector)},
+		})
+	}
+	slices.SortFunc(data[start:], func(a, b schemas.EmbeddingData) int { return a.Index - b.Index })
+	return data
+}
@@ -0,0 +1,166 @@
+package embeddingcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// mapTier is an in-process Tier standing in for Redis.
+type mapTier map[string][]float64
+
+func (t mapTier) Get(_ context.Context, key string) ([]float64, bool, error) {
+	vector, ok := t[key]
+	return vector, ok, nil
+}
+
+func (t mapTier) Set(_ context.Context, key string, vector []float64, _ time.Duration) error {
+	t[key] = vector
+	return nil
+}
+
+func embeddingRequest(texts ...string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.EmbeddingRequest,
+		EmbeddingRequest: &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.OpenAI,
+			Model:    "text-embedding-3-small",
+			Input:    &schemas.EmbeddingInput{Texts: texts},
+		},
+	}
+}
+
+// fakeProvider answers an embedding request with one vector per text, [len(text)].
+func fakeProvider(req *schemas.BifrostRequest) *schemas.BifrostResponse {
+	texts := inputTexts(req.EmbeddingRequest.Input)
+	data := make([]schemas.EmbeddingData, len(texts))
+	for i, text := range texts {
+		data[i] = schemas.EmbeddingData{Index: i, Object: "embedding", Embedding: schemas.EmbeddingStruct{EmbeddingArray: []float64{float64(len(text))}}}
+	}
+	return &schemas.BifrostResponse{EmbeddingResponse: &schemas.BifrostEmbeddingResponse{Data: data, Object: "list"}}
+}
+
+// roundTrip runs a request through the hooks, calling fakeProvider unless short-circuited.
+// It returns the response and the request that reached the provider (nil on a full hit).
+func roundTrip(t *testing.T, plugin *Plugin, req *schemas.BifrostRequest) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostRequest) {
+	t.Helper()
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	sent, shortCircuit, err := plugin.PreLLMHook(ctx, req)
+	if err != nil {
+		t.Fatalf("PreLLMHook: %v", err)
+	}
+	if shortCircuit != nil {
+		return shortCircuit.Response.EmbeddingResponse, nil
+	}
+	result, _, _ := plugin.PostLLMHook(ctx, fakeProvider(sent), nil)
+	return result.EmbeddingResponse, sent
+}
+
+func vectors(resp *schemas.BifrostEmbeddingResponse) []float64 {
+	out := make([]float64, len(resp.Data))
+	for i, data := range resp.Data {
+		if data.Index != i {
+			return nil
+		}
+		out[i] = data.Embedding.EmbeddingArray[0]
+	}
+	return out
+}
+
+func TestEmbeddingCacheFullAndPartialHits(t *testing.T) {
+	plugin, err := Init(Config{}, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	resp, sent := roundTrip(t, plugin, embeddingRequest("a", "bb"))
+	if sent == nil || len(resp.Data) != 2 {
+		t.Fatal("first request should reach the provider")
+	}
+
+	resp, sent = roundTrip(t, plugin, embeddingRequest("a", "bb"))
+	if sent != nil {
+		t.Fatal("fully cached request should be short-circuited")
+	}
+	if resp.ExtraFields.CacheDebug == nil || !resp.ExtraFields.CacheDebug.CacheHit || *resp.ExtraFields.CacheDebug.HitType != CacheHitType {
+		t.Fatal("full hit should carry embedding cache debug")
+	}
+	if got := vectors(resp); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("unexpected cached vectors %v", got)
+	}
+
+	req := embeddingRequest("ccc", "a", "dddd")
+	resp, sent = roundTrip(t, plugin, req)
+	if got := sent.EmbeddingRequest.Input.Texts; len(got) != 2 || got[0] != "ccc" || got[1] != "dddd" {
+		t.Fatalf("only missing texts should reach the provider, got %v", got)
+	}
+	if len(req.EmbeddingRequest.Input.Texts) != 3 {
+		t.Fatal("the caller's request must not be mutated")
+	}
+	if got := vectors(resp); len(got) != 3 || got[0] != 3 || got[1] != 1 || got[2] != 4 {
+		t.Fatalf("merged vectors out of order: %v", got)
+	}
+
+	stats := plugin.Stats()
+	if stats.Hits != 3 || stats.Misses != 4 || stats.Entries != 4 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestEmbeddingCacheKeyIncludesModelAndDimensions(t *testing.T) {
+	plugin, _ := Init(Config{}, nil)
+	roundTrip(t, plugin, embeddingRequest("a"))
+
+	other := embeddingRequest("a")
+	other.EmbeddingRequest.Model = "text-embedding-3-large"
+	if _, sent := roundTrip(t, plugin, other); sent == nil {
+		t.Fatal("a different model must not share cache entries")
+	}
+
+	withDims := embeddingRequest("a")
+	withDims.EmbeddingRequest.Params = &schemas.EmbeddingParameters{Dimensions: schemas.Ptr(256)}
+	if _, sent := roundTrip(t, plugin, withDims); sent == nil {
+		t.Fatal("different dimensions must not share cache entries")
+	}
+
+	base64 := embeddingRequest("a")
+	base64.EmbeddingRequest.Params = &schemas.EmbeddingParameters{EncodingFormat: schemas.Ptr("base64")}
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	if _, sc, _ := plugin.PreLLMHook(ctx, base64); sc != nil {
+		t.Fatal("non-float encodings are not cached")
+	}
+}
+
+func TestEmbeddingCacheEvictionAndTier(t *testing.T) {
+	tier := mapTier{}
+	plugin, _ := Init(Config{MaxEntries: 1}, nil)
+	plugin.SetTier(tier)
+
+	roundTrip(t, plugin, embeddingRequest("a"))
+	roundTrip(t, plugin, embeddingRequest("bb"))
+	if plugin.memory.len() != 1 || len(tier) != 2 {
+		t.Fatalf("expected 1 entry in memory and 2 in the tier, got %d and %d", plugin.memory.len(), len(tier))
+	}
+
+	// "a" was evicted from memory but is still served from the tier.
+	if _, sent := roundTrip(t, plugin, embeddingRequest("a")); sent != nil {
+		t.Fatal("tier hit should short-circuit")
+	}
+}
+
+func TestMemoryStoreExpiresEntries(t *testing.T) {
+	now := time.Unix(0, 0)
+	store := newMemoryStore(time.Minute, 10)
+	store.now = func() time.Time { return now }
+
+	store.set("k", []float64{1})
+	if _, ok := store.get("k"); !ok {
+		t.Fatal("expected a fresh entry")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := store.get("k"); ok {
+		t.Fatal("expected the entry to expire after the TTL")
+	}
+}
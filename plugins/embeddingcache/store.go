@@ -0,0 +1,125 @@
+package embeddingcache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryStore is a size-bounded LRU of embedding vectors with a per-entry TTL.
+type memoryStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List // front = most recently used
+	entries    map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	vector    []float64
+	expiresAt time.Time
+}
+
+func newMemoryStore(ttl time.Duration, maxEntries int) *memoryStore {
+	return &memoryStore{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// get returns the vector for key, dropping it if it has expired.
+func (s *memoryStore) get(key string) ([]float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !s.now().Before(entry.expiresAt) {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return entry.vector, true
+}
+
+// set stores vector under key, evicting the least recently used entries past maxEntries.
+func (s *memoryStore) set(key string, vector []float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := s.now().Add(s.ttl)
+	if elem, ok := s.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.vector, entry.expiresAt = vector, expiresAt
+		s.order.MoveToFront(elem)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, vector: vector, expiresAt: expiresAt})
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// len returns the number of entries currently held, including expired ones not yet evicted.
+func (s *memoryStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// Tier is a shared second-level store consulted on in-memory misses, so every
+// Bifrost node benefits from vectors embedded by any other node.
+type Tier interface {
+	Get(ctx context.Context, key string) ([]float64, bool, error)
+	Set(ctx context.Context, key string, vector []float64, ttl time.Duration) error
+}
+
+// redisTier stores vectors as JSON arrays in Redis.
+type redisTier struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisTier returns a Tier backed by client. Keys are namespaced with keyPrefix.
+func NewRedisTier(client redis.UniversalClient, keyPrefix string) Tier {
+	return &redisTier{client: client, keyPrefix: keyPrefix}
+}
+
+func (t *redisTier) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	data, err := t.client.Get(ctx, t.keyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var vector []float64
+	if err := json.Unmarshal(data, &vector); err != nil {
+		return nil, false, err
+	}
+	return vector, true, nil
+}
+
+func (t *redisTier) Set(ctx context.Context, key string, vector []float64, ttl time.Duration) error {
+	data, err := json.Marshal(vector)
+	if err != nil {
+		return err
+	}
+	return t.client.Set(ctx, t.keyPrefix+key, data, ttl).Err()
+}
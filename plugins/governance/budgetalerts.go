@@ -0,0 +1,289 @@
+package governance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// BudgetAlertEvent is the event name carried by every budget alert.
+const BudgetAlertEvent = "budget.threshold_crossed"
+
+// Budget alert entity types.
+const (
+	BudgetAlertEntityVirtualKey = "virtual_key"
+	BudgetAlertEntityTeam       = "team"
+)
+
+// DefaultBudgetAlertThresholds are used when budget alerts leave thresholds unset.
+var DefaultBudgetAlertThresholds = []float64{0.8, 1.0}
+
+const (
+	budgetAlertQueueSize   = 256
+	budgetAlertSendTimeout = 10 * time.Second
+)
+
+// BudgetAlert is the payload of a budget alert. Webhooks receive it as JSON.
+type BudgetAlert struct {
+	Event         string    `json:"event"`
+	EntityType    string    `json:"entity_type"`
+	EntityID      string    `json:"entity_id"`
+	EntityName    string    `json:"entity_name,omitempty"`
+	BudgetID      string    `json:"budget_id"`
+	ResetDuration string    `json:"reset_duration"`
+	Threshold     float64   `json:"threshold"`
+	CurrentUsage  float64   `json:"current_usage"`
+	MaxLimit      float64   `json:"max_limit"`
+	WindowStart   time.Time `json:"window_start"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// budgetAlerter notifies webhooks and email recipients when a budget crosses
+// one of the configured thresholds. Each threshold alerts at most once per
+// reset window of a budget. Usage is this node's view of the budget.
+type budgetAlerter struct {
+	thresholds  []float64
+	webhookURLs []string
+	email       *configstore.BudgetAlertsEmailConfig
+	httpClient  *http.Client
+	logger      schemas.Logger
+
+	// deliver sends one alert to every destination; tests replace it.
+	deliver func(alert BudgetAlert)
+
+	mu    sync.Mutex
+	fired map[string]firedBudgetAlert // budget ID -> highest threshold alerted this window
+
+	queue chan BudgetAlert
+	done  chan struct{}
+	once  sync.Once
+}
+
+type firedBudgetAlert struct {
+	window    time.Time
+	threshold float64
+}
+
+// newBudgetAlerter validates config. It returns nil when config has no
+// destinations. Call start to launch the delivery worker.
+func newBudgetAlerter(config *configstore.BudgetAlertsConfig, logger schemas.Logger) (*budgetAlerter, error) {
+	if config == nil || (len(config.WebhookURLs) == 0 && config.Email == nil) {
+		return nil, nil
+	}
+	thresholds := slices.Clone(config.Thresholds)
+	if len(thresholds) == 0 {
+		thresholds = slices.Clone(DefaultBudgetAlertThresholds)
+	}
+	for _, threshold := range thresholds {
+		if threshold <= 0 {
+			return nil, fmt.Errorf("budget alert threshold must be positive, got %v", threshold)
+		}
+	}
+	slices.Sort(thresholds)
+	thresholds = slices.Compact(thresholds)
+	for _, url := range config.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("budget alert webhook url must be http(s): %q", url)
+		}
+	}
+	if email := config.Email; email != nil {
+		if email.Host == "" || email.Port <= 0 || email.From == "" || len(email.To) == 0 {
+			return nil, fmt.Errorf("budget alert email needs host, port, from and to")
+		}
+	}
+	a := &budgetAlerter{
+		thresholds:  thresholds,
+		webhookURLs: config.WebhookURLs,
+		email:       config.Email,
+		httpClient:  &http.Client{Timeout: budgetAlertSendTimeout},
+		logger:      logger,
+		fired:       make(map[string]firedBudgetAlert),
+		queue:       make(chan BudgetAlert, budgetAlertQueueSize),
+		done:        make(chan struct{}),
+	}
+	a.deliver = a.send
+	return a, nil
+}
+
+// observe raises an alert when budget has crossed a threshold it has not yet
+// alerted on in its current window. Only the highest newly crossed threshold
+// alerts, so a single large request does not send 80% and 100% together.
+func (a *budgetAlerter) observe(entityType, entityID, entityName string, budget *configstoreTables.TableBudget) {
+	if a == nil || budget == nil {
+		return
+	}
+	limit := budget.EffectiveMaxLimit()
+	if limit <= 0 {
+		return
+	}
+	crossed := 0.0
+	for _, threshold := range a.thresholds {
+		if budget.CurrentUsage >= threshold*limit {
+			crossed = threshold
+		}
+	}
+	if crossed == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	previous, ok := a.fired[budget.ID]
+	if ok && previous.window.Equal(budget.LastReset) && previous.threshold >= crossed {
+		a.mu.Unlock()
+		return
+	}
+	a.fired[budget.ID] = firedBudgetAlert{window: budget.LastReset, threshold: crossed}
+	a.mu.Unlock()
+
+	alert := BudgetAlert{
+		Event:         BudgetAlertEvent,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		EntityName:    entityName,
+		BudgetID:      budget.ID,
+		ResetDuration: budget.ResetDuration,
+		Threshold:     crossed,
+		CurrentUsage:  budget.CurrentUsage,
+		MaxLimit:      limit,
+		WindowStart:   budget.LastReset,
+		Timestamp:     time.Now().UTC(),
+	}
+	select {
+	case a.queue <- alert:
+	default:
+		a.logger.Warn("budget alert queue is full, dropping %s alert for budget %s", formatThreshold(crossed), budget.ID)
+	}
+}
+
+// start launches the delivery worker.
+func (a *budgetAlerter) start() {
+	if a != nil {
+		go a.run()
+	}
+}
+
+func (a *budgetAlerter) run() {
+	for {
+		select {
+		case alert := <-a.queue:
+			a.deliver(alert)
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// stop ends the delivery worker. Alerts still queued are dropped.
+func (a *budgetAlerter) stop() {
+	if a == nil {
+		return
+	}
+	a.once.Do(func() { close(a.done) })
+}
+
+func (a *budgetAlerter) send(alert BudgetAlert) {
+	if len(a.webhookURLs) > 0 {
+		body, err := sonic.Marshal(alert)
+		if err != nil {
+			a.logger.Error("failed to marshal budget alert for budget %s: %v", alert.BudgetID, err)
+		} else {
+			for _, url := range a.webhookURLs {
+				if err := a.postWebhook(url, body); err != nil {
+					a.logger.Warn("failed to send budget alert for budget %s to %s: %v", alert.BudgetID, url, err)
+				}
+			}
+		}
+	}
+	if a.email != nil {
+		if err := a.sendEmail(alert); err != nil {
+			a.logger.Warn("failed to email budget alert for budget %s: %v", alert.BudgetID, err)
+		}
+	}
+}
+
+func (a *budgetAlerter) postWebhook(url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), budgetAlertSendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (a *budgetAlerter) sendEmail(alert BudgetAlert) error {
+	var auth smtp.Auth
+	if a.email.Username != nil && a.email.Username.Val != "" {
+		password := ""
+		if a.email.Password != nil {
+			password = a.email.Password.Val
+		}
+		auth = smtp.PlainAuth("", a.email.Username.Val, password, a.email.Host)
+	}
+	addr := net.JoinHostPort(a.email.Host, strconv.Itoa(a.email.Port))
+	return smtp.SendMail(addr, auth, a.email.From, a.email.To, budgetAlertEmail(a.email.From, a.email.To, alert))
+}
+
+// budgetAlertEmail renders alert as a plain-text email message.
+func budgetAlertEmail(from string, to []string, alert BudgetAlert) []byte {
+	entity := alert.EntityID
+	if alert.EntityName != "" {
+		entity = alert.EntityName
+	}
+	entityType := strings.ReplaceAll(alert.EntityType, "_", " ")
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: [Bifrost] %s %s reached %s of its %s budget\r\n", entityType, entity, formatThreshold(alert.Threshold), alert.ResetDuration)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "The %s budget of %s %s (%s) has reached %s of its limit.\r\n\r\n", alert.ResetDuration, entityType, entity, alert.EntityID, formatThreshold(alert.Threshold))
+	fmt.Fprintf(&msg, "Usage: $%.4f of $%.4f\r\n", alert.CurrentUsage, alert.MaxLimit)
+	fmt.Fprintf(&msg, "Window start: %s\r\n", alert.WindowStart.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&msg, "Budget ID: %s\r\n", alert.BudgetID)
+	return []byte(msg.String())
+}
+
+func formatThreshold(threshold float64) string {
+	return strconv.FormatFloat(threshold*100, 'f', -1, 64) + "%"
+}
+
+// checkBudgetAlerts runs the alerter over the budgets of vk and its team.
+func (t *UsageTracker) checkBudgetAlerts(ctx context.Context, vk *configstoreTables.TableVirtualKey) {
+	if t.alerter == nil || vk == nil {
+		return
+	}
+	for i := range vk.Budgets {
+		t.alerter.observe(BudgetAlertEntityVirtualKey, vk.ID, vk.Name, t.store.LoadBudget(ctx, vk.Budgets[i].ID))
+	}
+	if vk.TeamID != nil {
+		teamName := ""
+		if vk.Team != nil {
+			teamName = vk.Team.Name
+		}
+		for _, budget := range t.store.CollectTeamBudgets(ctx, *vk.TeamID) {
+			t.alerter.observe(BudgetAlertEntityTeam, *vk.TeamID, teamName, budget)
+		}
+	}
+}
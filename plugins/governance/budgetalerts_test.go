@@ -0,0 +1,152 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBudgetAlerts_WebhookOnThresholds verifies VK and team budgets alert once
+// per threshold per window, and that only the highest newly crossed threshold alerts.
+func TestBudgetAlerts_WebhookOnThresholds(t *testing.T) {
+	logger := NewMockLogger()
+	received := make(chan BudgetAlert, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert BudgetAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		received <- alert
+	}))
+	defer server.Close()
+
+	vkBudget := buildBudgetWithUsage("vk-budget", 100.0, 0.0, "1d")
+	teamBudget := buildBudgetWithUsage("team-budget", 1000.0, 0.0, "1M")
+	team := buildTeam("team1", "Platform", teamBudget)
+	vk := buildVirtualKeyWithBudget("vk1", "sk-bf-test", "Test VK", vkBudget)
+	vk.TeamID = &team.ID
+
+	store, err := NewLocalGovernanceStore(context.Background(), logger, nil, &configstore.GovernanceConfig{
+		VirtualKeys: []configstoreTables.TableVirtualKey{*vk},
+		Teams:       []configstoreTables.TableTeam{*team},
+		Budgets:     []configstoreTables.TableBudget{*vkBudget, *teamBudget},
+	}, nil)
+	require.NoError(t, err)
+
+	tracker := NewUsageTracker(context.Background(), store, NewBudgetResolver(store, nil, logger, nil), nil, logger)
+	defer tracker.Cleanup()
+	tracker.alerter, err = newBudgetAlerter(&configstore.BudgetAlertsConfig{WebhookURLs: []string{server.URL}}, logger)
+	require.NoError(t, err)
+	tracker.alerter.start()
+
+	spend := func(requestID string, cost float64) {
+		tracker.UpdateUsage(context.Background(), &UsageUpdate{
+			VirtualKey: "sk-bf-test", Provider: schemas.OpenAI, Model: "gpt-4o",
+			Success: true, Cost: cost, RequestID: requestID,
+		})
+	}
+	next := func() BudgetAlert {
+		t.Helper()
+		select {
+		case alert := <-received:
+			return alert
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a budget alert")
+			return BudgetAlert{}
+		}
+	}
+
+	spend("req-1", 50) // 50% of the VK budget: no alert
+	spend("req-2", 35) // 85%
+	alert := next()
+	assert.Equal(t, BudgetAlertEvent, alert.Event)
+	assert.Equal(t, BudgetAlertEntityVirtualKey, alert.EntityType)
+	assert.Equal(t, "vk1", alert.EntityID)
+	assert.Equal(t, "vk-budget", alert.BudgetID)
+	assert.Equal(t, 0.8, alert.Threshold)
+	assert.InDelta(t, 85.0, alert.CurrentUsage, 1e-9)
+
+	spend("req-3", 5)   // 90%: 80% already alerted
+	spend("req-4", 800) // VK over 100%, team jumps straight to 89%
+	alerts := map[string]BudgetAlert{}
+	for range 2 {
+		alert := next()
+		alerts[alert.BudgetID] = alert
+	}
+	assert.Equal(t, 1.0, alerts["vk-budget"].Threshold)
+	assert.Equal(t, BudgetAlertEntityTeam, alerts["team-budget"].EntityType)
+	assert.Equal(t, "team1", alerts["team-budget"].EntityID)
+	assert.Equal(t, 0.8, alerts["team-budget"].Threshold)
+
+	spend("req-5", 1)
+	select {
+	case alert := <-received:
+		t.Fatalf("no threshold was newly crossed, got %+v", alert)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// TestBudgetAlerts_RearmAfterReset verifies a new reset window alerts again.
+func TestBudgetAlerts_RearmAfterReset(t *testing.T) {
+	alerter, err := newBudgetAlerter(&configstore.BudgetAlertsConfig{Thresholds: []float64{0.5}, WebhookURLs: []string{"http://localhost"}}, NewMockLogger())
+	require.NoError(t, err)
+	var delivered []BudgetAlert
+	alerter.deliver = func(alert BudgetAlert) { delivered = append(delivered, alert) }
+
+	budget := buildBudgetWithUsage("b1", 10, 6, "1h")
+	alerter.observe(BudgetAlertEntityVirtualKey, "vk1", "", budget)
+	alerter.observe(BudgetAlertEntityVirtualKey, "vk1", "", budget)
+	require.Len(t, alerter.queue, 1)
+
+	budget.LastReset = budget.LastReset.Add(time.Hour)
+	alerter.observe(BudgetAlertEntityVirtualKey, "vk1", "", budget)
+	require.Len(t, alerter.queue, 2)
+
+	// The worker is not started, so drain the queue by hand.
+	for len(alerter.queue) > 0 {
+		alerter.deliver(<-alerter.queue)
+	}
+	require.Len(t, delivered, 2)
+	assert.True(t, delivered[1].WindowStart.After(delivered[0].WindowStart))
+}
+
+// TestBudgetAlerts_Config verifies validation and that alerts are off without destinations.
+func TestBudgetAlerts_Config(t *testing.T) {
+	logger := NewMockLogger()
+	alerter, err := newBudgetAlerter(&configstore.BudgetAlertsConfig{Thresholds: []float64{0.5}}, logger)
+	require.NoError(t, err)
+	assert.Nil(t, alerter)
+
+	for name, config := range map[string]*configstore.BudgetAlertsConfig{
+		"zero threshold": {Thresholds: []float64{0}, WebhookURLs: []string{"https://example.com"}},
+		"bad url":        {WebhookURLs: []string{"example.com/hook"}},
+		"email no to":    {Email: &configstore.BudgetAlertsEmailConfig{Host: "smtp.example.com", Port: 587, From: "bifrost@example.com"}},
+	} {
+		_, err := newBudgetAlerter(config, logger)
+		assert.Error(t, err, name)
+	}
+
+	alerter, err = newBudgetAlerter(&configstore.BudgetAlertsConfig{Thresholds: []float64{1, 0.5, 1}, WebhookURLs: []string{"https://example.com"}}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.5, 1}, alerter.thresholds)
+}
+
+// TestBudgetAlerts_EmailMessage verifies the rendered email.
+func TestBudgetAlerts_EmailMessage(t *testing.T) {
+	msg := string(budgetAlertEmail("bifrost@example.com", []string{"a@example.com", "b@example.com"}, BudgetAlert{
+		EntityType: BudgetAlertEntityTeam, EntityID: "team1", EntityName: "Platform", BudgetID: "b1",
+		ResetDuration: "1M", Threshold: 0.8, CurrentUsage: 812.5, MaxLimit: 1000,
+	}))
+	assert.Contains(t, msg, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, msg, "Subject: [Bifrost] team Platform reached 80% of its 1M budget\r\n")
+	assert.Contains(t, msg, "Usage: $812.5000 of $1000.0000")
+	assert.True(t, strings.Contains(msg, "\r\n\r\n"), "headers must be separated from the body")
+}
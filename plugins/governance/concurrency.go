@@ -0,0 +1,149 @@
+package governance
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// governanceConcurrencySlotContextKey holds the ID of the virtual key whose
+// in-flight slot the request holds, from PreLLMHook until the final PostLLMHook.
+const governanceConcurrencySlotContextKey schemas.BifrostContextKey = "bf-governance-concurrency-slot"
+
+// VirtualKeyInFlight is the number of requests in flight with a virtual key.
+type VirtualKeyInFlight struct {
+	VirtualKeyID   string
+	VirtualKeyName string
+	InFlight       int64
+	MaxConcurrent  int // 0 when the key has no cap
+}
+
+// inFlightTracker counts the requests in flight per virtual key on this node.
+// Counts are kept for every key so they can be exported; the cap is only
+// enforced for keys with MaxConcurrentRequests set.
+type inFlightTracker struct {
+	counters sync.Map // vk ID -> *inFlightCounter
+}
+
+type inFlightCounter struct {
+	count atomic.Int64
+	name  atomic.Pointer[string]
+	max   atomic.Int64
+}
+
+func (t *inFlightTracker) counter(vk *configstoreTables.TableVirtualKey) *inFlightCounter {
+	value, ok := t.counters.Load(vk.ID)
+	if !ok {
+		value, _ = t.counters.LoadOrStore(vk.ID, &inFlightCounter{})
+	}
+	c := value.(*inFlightCounter)
+	if name := c.name.Load(); name == nil || *name != vk.Name {
+		c.name.Store(&vk.Name)
+	}
+	limit := 0
+	if vk.MaxConcurrentRequests != nil {
+		limit = *vk.MaxConcurrentRequests
+	}
+	c.max.Store(int64(limit))
+	return c
+}
+
+// tryAcquire takes an in-flight slot for vk. It returns false when the key is
+// already at its cap.
+func (t *inFlightTracker) tryAcquire(vk *configstoreTables.TableVirtualKey) bool {
+	c := t.counter(vk)
+	limit := c.max.Load()
+	for {
+		current := c.count.Load()
+		if limit > 0 && current >= limit {
+			return false
+		}
+		if c.count.CompareAndSwap(current, current+1) {
+			return true
+		}
+	}
+}
+
+// release frees a slot taken by tryAcquire.
+func (t *inFlightTracker) release(vkID string) {
+	if value, ok := t.counters.Load(vkID); ok {
+		value.(*inFlightCounter).count.Add(-1)
+	}
+}
+
+// snapshot returns the in-flight count of every key seen since startup,
+// ordered by virtual key ID.
+func (t *inFlightTracker) snapshot() []VirtualKeyInFlight {
+	var result []VirtualKeyInFlight
+	t.counters.Range(func(key, value any) bool {
+		c := value.(*inFlightCounter)
+		entry := VirtualKeyInFlight{
+			VirtualKeyID:  key.(string),
+			InFlight:      c.count.Load(),
+			MaxConcurrent: int(c.max.Load()),
+		}
+		if name := c.name.Load(); name != nil {
+			entry.VirtualKeyName = *name
+		}
+		result = append(result, entry)
+		return true
+	})
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].VirtualKeyID < result[j].VirtualKeyID
+	})
+	return result
+}
+
+// acquireConcurrencySlot takes an in-flight slot for the request's virtual key,
+// or returns a 429 when the key is at its MaxConcurrentRequests cap. A request
+// that already holds a slot (a fallback attempt) keeps it, and read-only
+// metadata calls that skip budgets and rate limits take none.
+func (p *GovernancePlugin) acquireConcurrencySlot(ctx *schemas.BifrostContext, virtualKeyValue string) *schemas.BifrostError {
+	if virtualKeyValue == "" || bifrost.GetBoolFromContext(ctx, schemas.BifrostContextKeySkipBudgetAndRateLimits) {
+		return nil
+	}
+	vk, ok := p.store.GetVirtualKey(ctx, virtualKeyValue)
+	if !ok || vk == nil {
+		return nil
+	}
+	if held, ok := ctx.Value(governanceConcurrencySlotContextKey).(string); ok && held == vk.ID {
+		return nil
+	}
+	if !p.inFlight.tryAcquire(vk) {
+		ctx.SetValue(governanceRejectedContextKey, true)
+		return &schemas.BifrostError{
+			Type:       new(string(DecisionConcurrencyLimited)),
+			StatusCode: new(429),
+			Error: &schemas.ErrorField{
+				Message: fmt.Sprintf("virtual key '%s' has reached its limit of %d concurrent requests", vk.Name, *vk.MaxConcurrentRequests),
+			},
+		}
+	}
+	ctx.SetValue(governanceConcurrencySlotContextKey, vk.ID)
+	return nil
+}
+
+// releaseConcurrencySlot frees the request's in-flight slot once the response
+// is complete: on the only PostLLMHook call, or the final chunk of a stream.
+func (p *GovernancePlugin) releaseConcurrencySlot(ctx *schemas.BifrostContext, requestType schemas.RequestType) {
+	vkID, ok := ctx.Value(governanceConcurrencySlotContextKey).(string)
+	if !ok {
+		return
+	}
+	if bifrost.IsStreamRequestType(requestType) && !bifrost.IsFinalChunk(ctx) {
+		return
+	}
+	ctx.ClearValue(governanceConcurrencySlotContextKey)
+	p.inFlight.release(vkID)
+}
+
+// InFlightRequestsByVirtualKey returns the requests in flight on this node for
+// every virtual key that has served a request since startup.
+func (p *GovernancePlugin) InFlightRequestsByVirtualKey() []VirtualKeyInFlight {
+	return p.inFlight.snapshot()
+}
@@ -0,0 +1,108 @@
+package governance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreLLMHook_VirtualKeyConcurrencyCap verifies requests beyond a key's
+// MaxConcurrentRequests are rejected with 429 until an earlier one completes.
+func TestPreLLMHook_VirtualKeyConcurrencyCap(t *testing.T) {
+	logger := NewMockLogger()
+	vk := buildVirtualKey("vk1", "sk-bf-test", "Capped VK", true)
+	vk.ProviderConfigs = []configstoreTables.TableVirtualKeyProviderConfig{buildProviderConfig("openai", []string{"*"})}
+	vk.MaxConcurrentRequests = new(2)
+	store, err := NewLocalGovernanceStore(context.Background(), logger, nil, &configstore.GovernanceConfig{
+		VirtualKeys: []configstoreTables.TableVirtualKey{*vk},
+	}, nil)
+	require.NoError(t, err)
+
+	plugin, err := InitFromStore(context.Background(), &Config{IsVkMandatory: boolPtr(true)}, logger, store, nil, nil, nil, nil)
+	require.NoError(t, err)
+	defer plugin.Cleanup()
+
+	newRequest := func() (*schemas.BifrostContext, *schemas.BifrostRequest) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		ctx.SetValue(schemas.BifrostContextKeyVirtualKey, "sk-bf-test")
+		return ctx, &schemas.BifrostRequest{
+			RequestType: schemas.ChatCompletionRequest,
+			ChatRequest: &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o"},
+		}
+	}
+	complete := func(ctx *schemas.BifrostContext) {
+		result := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+			ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionRequest, Provider: schemas.OpenAI},
+		}}
+		_, _, err := plugin.PostLLMHook(ctx, result, nil)
+		require.NoError(t, err)
+	}
+
+	var admitted []*schemas.BifrostContext
+	for range 2 {
+		ctx, req := newRequest()
+		_, shortCircuit, err := plugin.PreLLMHook(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, shortCircuit, "requests within the cap are admitted")
+		admitted = append(admitted, ctx)
+	}
+
+	ctx, req := newRequest()
+	_, shortCircuit, _ := plugin.PreLLMHook(ctx, req)
+	require.NotNil(t, shortCircuit, "the third concurrent request exceeds the cap")
+	assert.Equal(t, 429, *shortCircuit.Error.StatusCode)
+	assert.Equal(t, string(DecisionConcurrencyLimited), *shortCircuit.Error.Type)
+	complete(ctx) // the rejected request holds no slot
+
+	inFlight := plugin.InFlightRequestsByVirtualKey()
+	require.Len(t, inFlight, 1)
+	assert.Equal(t, VirtualKeyInFlight{VirtualKeyID: "vk1", VirtualKeyName: "Capped VK", InFlight: 2, MaxConcurrent: 2}, inFlight[0])
+
+	complete(admitted[0])
+	complete(admitted[0]) // a repeated post hook does not release twice
+	ctx, req = newRequest()
+	_, shortCircuit, _ = plugin.PreLLMHook(ctx, req)
+	assert.Nil(t, shortCircuit, "a completed request frees its slot")
+	assert.EqualValues(t, 2, plugin.InFlightRequestsByVirtualKey()[0].InFlight)
+}
+
+// TestPreLLMHook_StreamHoldsSlotUntilFinalChunk verifies a streaming request
+// keeps its slot until the final chunk.
+func TestPreLLMHook_StreamHoldsSlotUntilFinalChunk(t *testing.T) {
+	logger := NewMockLogger()
+	vk := buildVirtualKey("vk1", "sk-bf-test", "Capped VK", true)
+	vk.ProviderConfigs = []configstoreTables.TableVirtualKeyProviderConfig{buildProviderConfig("openai", []string{"*"})}
+	vk.MaxConcurrentRequests = new(1)
+	store, err := NewLocalGovernanceStore(context.Background(), logger, nil, &configstore.GovernanceConfig{
+		VirtualKeys: []configstoreTables.TableVirtualKey{*vk},
+	}, nil)
+	require.NoError(t, err)
+
+	plugin, err := InitFromStore(context.Background(), &Config{IsVkMandatory: boolPtr(true)}, logger, store, nil, nil, nil, nil)
+	require.NoError(t, err)
+	defer plugin.Cleanup()
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyVirtualKey, "sk-bf-test")
+	req := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionStreamRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o"},
+	}
+	_, shortCircuit, _ := plugin.PreLLMHook(ctx, req)
+	require.Nil(t, shortCircuit)
+
+	chunk := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionStreamRequest, Provider: schemas.OpenAI},
+	}}
+	_, _, _ = plugin.PostLLMHook(ctx, chunk, nil)
+	assert.EqualValues(t, 1, plugin.InFlightRequestsByVirtualKey()[0].InFlight, "intermediate chunks keep the slot")
+
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+	_, _, _ = plugin.PostLLMHook(ctx, chunk, nil)
+	assert.EqualValues(t, 0, plugin.InFlightRequestsByVirtualKey()[0].InFlight, "the final chunk releases the slot")
+}
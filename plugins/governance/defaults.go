@@ -0,0 +1,97 @@
+package governance
+
+import (
+	"github.com/maximhq/bifrost/core/schemas"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+)
+
+// applyVirtualKeyDefaults applies the virtual key's model and parameter presets
+// to chat, text completion and responses requests. In fill mode a preset is used
+// only when the request leaves the field unset; in force mode every preset
+// replaces what the client sent. The system prompt becomes a leading system
+// message for chat and the instructions for responses. It reports whether the
+// preset system prompt was applied.
+func applyVirtualKeyDefaults(req *schemas.BifrostRequest, defaults *configstoreTables.VirtualKeyDefaults) (systemPromptApplied bool) {
+	if req == nil || defaults.IsEmpty() {
+		return false
+	}
+	force := defaults.IsForced()
+
+	if defaults.Model != "" {
+		if _, model, _ := req.GetRequestFields(); model == "" || force {
+			provider, modelName := schemas.ParseModelString(defaults.Model, "")
+			// A bare preset model keeps whatever provider the client chose.
+			if provider != "" {
+				req.SetProvider(provider)
+			}
+			req.SetModel(modelName)
+		}
+	}
+
+	switch {
+	case req.ChatRequest != nil:
+		chat := req.ChatRequest
+		if chat.Params == nil {
+			chat.Params = &schemas.ChatParameters{}
+		}
+		chat.Params.Temperature = presetValue(chat.Params.Temperature, defaults.Temperature, force)
+		chat.Params.MaxCompletionTokens = presetValue(chat.Params.MaxCompletionTokens, defaults.MaxTokens, force)
+		if defaults.SystemPrompt != "" {
+			chat.Input, systemPromptApplied = applySystemPrompt(chat.Input, defaults.SystemPrompt, force)
+		}
+	case req.TextCompletionRequest != nil:
+		text := req.TextCompletionRequest
+		if text.Params == nil {
+			text.Params = &schemas.TextCompletionParameters{}
+		}
+		text.Params.Temperature = presetValue(text.Params.Temperature, defaults.Temperature, force)
+		text.Params.MaxTokens = presetValue(text.Params.MaxTokens, defaults.MaxTokens, force)
+	case req.ResponsesRequest != nil:
+		responses := req.ResponsesRequest
+		if responses.Params == nil {
+			responses.Params = &schemas.ResponsesParameters{}
+		}
+		responses.Params.Temperature = presetValue(responses.Params.Temperature, defaults.Temperature, force)
+		responses.Params.MaxOutputTokens = presetValue(responses.Params.MaxOutputTokens, defaults.MaxTokens, force)
+		if defaults.SystemPrompt != "" {
+			var instructions *string
+			if responses.Params.Instructions != nil && *responses.Params.Instructions != "" {
+				instructions = responses.Params.Instructions
+			}
+			systemPrompt := defaults.SystemPrompt
+			responses.Params.Instructions = presetValue(instructions, &systemPrompt, force)
+			systemPromptApplied = instructions == nil || force
+		}
+	}
+	return systemPromptApplied
+}
+
+// presetValue returns preset when it is set and either force is on or current is unset.
+func presetValue[T any](current, preset *T, force bool) *T {
+	if preset == nil || (current != nil && !force) {
+		return current
+	}
+	value := *preset
+	return &value
+}
+
+// applySystemPrompt prepends the preset system message when the conversation has
+// none. In force mode any client system messages are replaced by the preset.
+// It reports whether the preset was added.
+func applySystemPrompt(messages []schemas.ChatMessage, systemPrompt string, force bool) ([]schemas.ChatMessage, bool) {
+	result := make([]schemas.ChatMessage, 0, len(messages)+1)
+	for _, message := range messages {
+		if message.Role == schemas.ChatMessageRoleSystem {
+			if !force {
+				return messages, false
+			}
+			continue
+		}
+		result = append(result, message)
+	}
+	system := schemas.ChatMessage{
+		Role:    schemas.ChatMessageRoleSystem,
+		Content: &schemas.ChatMessageContent{ContentStr: &systemPrompt},
+	}
+	return append([]schemas.ChatMessage{system}, result...), true
+}
@@ -0,0 +1,121 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userMessage(text string) schemas.ChatMessage {
+	return schemas.ChatMessage{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: &text}}
+}
+
+func TestApplyVirtualKeyDefaultsFillsMissingFields(t *testing.T) {
+	defaults := &configstoreTables.VirtualKeyDefaults{
+		Model:        "openai/gpt-4o-mini",
+		Temperature:  schemas.Ptr(0.2),
+		MaxTokens:    schemas.Ptr(256),
+		SystemPrompt: "Be brief.",
+	}
+	req := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Input: []schemas.ChatMessage{userMessage("hi")}},
+	}
+
+	assert.True(t, applyVirtualKeyDefaults(req, defaults))
+
+	chat := req.ChatRequest
+	assert.Equal(t, schemas.OpenAI, chat.Provider)
+	assert.Equal(t, "gpt-4o-mini", chat.Model)
+	require.NotNil(t, chat.Params)
+	assert.Equal(t, 0.2, *chat.Params.Temperature)
+	assert.Equal(t, 256, *chat.Params.MaxCompletionTokens)
+	require.Len(t, chat.Input, 2)
+	assert.Equal(t, schemas.ChatMessageRoleSystem, chat.Input[0].Role)
+	assert.Equal(t, "Be brief.", *chat.Input[0].Content.ContentStr)
+}
+
+func TestApplyVirtualKeyDefaultsKeepsClientValuesInFillMode(t *testing.T) {
+	defaults := &configstoreTables.VirtualKeyDefaults{
+		Model:        "openai/gpt-4o-mini",
+		Temperature:  schemas.Ptr(0.2),
+		SystemPrompt: "Be brief.",
+	}
+	req := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.Anthropic,
+			Model:    "claude-sonnet-4-5",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("Client prompt")}},
+				userMessage("hi"),
+			},
+			Params: &schemas.ChatParameters{Temperature: schemas.Ptr(0.9)},
+		},
+	}
+
+	assert.False(t, applyVirtualKeyDefaults(req, defaults), "the client's system prompt is kept in fill mode")
+
+	chat := req.ChatRequest
+	assert.Equal(t, schemas.Anthropic, chat.Provider)
+	assert.Equal(t, "claude-sonnet-4-5", chat.Model)
+	assert.Equal(t, 0.9, *chat.Params.Temperature)
+	require.Len(t, chat.Input, 2)
+	assert.Equal(t, "Client prompt", *chat.Input[0].Content.ContentStr)
+}
+
+func TestApplyVirtualKeyDefaultsForceMode(t *testing.T) {
+	defaults := &configstoreTables.VirtualKeyDefaults{
+		Mode:         configstoreTables.VirtualKeyDefaultsModeForce,
+		Model:        "gpt-4o-mini",
+		MaxTokens:    schemas.Ptr(128),
+		SystemPrompt: "Central prompt",
+	}
+	chatReq := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("Client prompt")}},
+				userMessage("hi"),
+			},
+			Params: &schemas.ChatParameters{MaxCompletionTokens: schemas.Ptr(4096)},
+		},
+	}
+
+	assert.True(t, applyVirtualKeyDefaults(chatReq, defaults))
+
+	chat := chatReq.ChatRequest
+	// A bare preset model keeps the client's provider.
+	assert.Equal(t, schemas.OpenAI, chat.Provider)
+	assert.Equal(t, "gpt-4o-mini", chat.Model)
+	assert.Equal(t, 128, *chat.Params.MaxCompletionTokens)
+	require.Len(t, chat.Input, 2)
+	assert.Equal(t, "Central prompt", *chat.Input[0].Content.ContentStr)
+	assert.Equal(t, schemas.ChatMessageRoleUser, chat.Input[1].Role)
+
+	responsesReq := &schemas.BifrostRequest{
+		RequestType: schemas.ResponsesRequest,
+		ResponsesRequest: &schemas.BifrostResponsesRequest{
+			Model:  "gpt-4o",
+			Params: &schemas.ResponsesParameters{Instructions: schemas.Ptr("Client instructions")},
+		},
+	}
+
+	assert.True(t, applyVirtualKeyDefaults(responsesReq, defaults))
+
+	assert.Equal(t, "gpt-4o-mini", responsesReq.ResponsesRequest.Model)
+	assert.Equal(t, "Central prompt", *responsesReq.ResponsesRequest.Params.Instructions)
+	assert.Equal(t, 128, *responsesReq.ResponsesRequest.Params.MaxOutputTokens)
+}
+
+func TestVirtualKeyDefaultsValidate(t *testing.T) {
+	assert.NoError(t, (&configstoreTables.VirtualKeyDefaults{Mode: "fill", Temperature: schemas.Ptr(1.0)}).Validate())
+	assert.Error(t, (&configstoreTables.VirtualKeyDefaults{Mode: "merge"}).Validate())
+	assert.Error(t, (&configstoreTables.VirtualKeyDefaults{Temperature: schemas.Ptr(2.5)}).Validate())
+	assert.Error(t, (&configstoreTables.VirtualKeyDefaults{MaxTokens: schemas.Ptr(0)}).Validate())
+}
@@ -40,6 +40,8 @@ type Config struct {
 	IsEnterprise          bool      `json:"is_enterprise"`
 	DisableAutoToolInject *bool     `json:"disable_auto_tool_inject"`
 	RoutingChainMaxDepth  *int      `json:"routing_chain_max_depth"` // Pointer to live config value; changes are reflected immediately without restart
+
+	BudgetAlerts *configstore.BudgetAlertsConfig `json:"budget_alerts,omitempty"` // Threshold alerts for virtual key and team budgets
 }
 
 type InMemoryStore interface {
@@ -90,6 +92,8 @@ type GovernancePlugin struct {
 	disableAutoToolInject *bool
 
 	complexityAnalyzer atomic.Pointer[complexity.ComplexityAnalyzer]
+
+	inFlight inFlightTracker // Requests in flight per virtual key, for MaxConcurrentRequests
 }
 
 // Init initializes and returns a governance plugin instance.
@@ -179,6 +183,15 @@ func Init(
 
 	// 3. Tracker (business logic owner, depends on store and resolver)
 	tracker := NewUsageTracker(ctx, governanceStore, resolver, configStore, logger)
+	if config != nil {
+		alerter, err := newBudgetAlerter(config.BudgetAlerts, logger)
+		if err != nil {
+			tracker.Cleanup()
+			return nil, fmt.Errorf("failed to initialize budget alerts: %w", err)
+		}
+		alerter.start()
+		tracker.alerter = alerter
+	}
 
 	// 4. Perform startup reset check for any expired limits from downtime
 	// Use distributed lock to prevent race condition when multiple instances boot simultaneously
@@ -293,6 +306,15 @@ func InitFromStore(
 	}
 	resolver := NewBudgetResolver(governanceStore, modelCatalog, logger, inMemoryStore)
 	tracker := NewUsageTracker(ctx, governanceStore, resolver, configStore, logger)
+	if config != nil {
+		alerter, err := newBudgetAlerter(config.BudgetAlerts, logger)
+		if err != nil {
+			tracker.Cleanup()
+			return nil, fmt.Errorf("failed to initialize budget alerts: %w", err)
+		}
+		alerter.start()
+		tracker.alerter = alerter
+	}
 	engine, err := NewRoutingEngine(governanceStore, logger, routingChainMaxDepth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize routing engine: %w", err)
@@ -648,6 +670,28 @@ func (p *GovernancePlugin) loadBalanceProvider(ctx *schemas.BifrostContext, req
 	return nil
 }
 
+// filterFallbacksOverride drops entries of a caller-supplied fallback chain
+// (x-bf-fallbacks) that the virtual key may not use, so the header cannot reach
+// providers or models the key forbids. Core applies what is left once
+// PreRequestHooks have run.
+func (p *GovernancePlugin) filterFallbacksOverride(ctx *schemas.BifrostContext, virtualKey *configstoreTables.TableVirtualKey) {
+	override, ok := ctx.Value(schemas.BifrostContextKeyFallbacksOverride).([]schemas.Fallback)
+	if !ok || len(override) == 0 {
+		return
+	}
+	allowed := make([]schemas.Fallback, 0, len(override))
+	for _, fallback := range override {
+		if p.resolver.isModelAllowed(virtualKey, fallback.Provider, fallback.Model) {
+			allowed = append(allowed, fallback)
+			continue
+		}
+		ctx.AppendRoutingEngineLog(schemas.RoutingEngineGovernance, schemas.LogLevelWarn, fmt.Sprintf("Requested fallback %s/%s dropped: not allowed for this virtual key", fallback.Provider, fallback.Model))
+	}
+	if len(allowed) != len(override) {
+		ctx.SetValue(schemas.BifrostContextKeyFallbacksOverride, allowed)
+	}
+}
+
 // publishRoutingAllowlist records, for downstream routing layers, which of the VK's configured
 // providers permit modelStr according to the VK's own allowed_models / blocked_models. It is a
 // coarse provider gate (BifrostContextKeyRoutingAllowedProviders) layered on top of the model
@@ -736,6 +780,11 @@ func (p *GovernancePlugin) applyRoutingRules(ctx *schemas.BifrostContext, req *s
 		BudgetAndRateLimitStatus: p.store.GetBudgetAndRateLimitStatus(ctx, model, provider, virtualKey, nil, nil, nil),
 		computeComplexity:        computeComplexity,
 	}
+	if req.EmbeddingRequest != nil {
+		routingCtx.estimateEmbeddingTokens = func() int {
+			return schemas.EstimateEmbeddingInputTokens(req.EmbeddingRequest.Input)
+		}
+	}
 
 	p.logger.Debug("[PreRequestHook] Built routing context: provider=%s, model=%s, requestType=%s, vk=%v",
 		provider, model, requestType, virtualKey != nil)
@@ -959,9 +1008,6 @@ func (p *GovernancePlugin) EvaluateGovernanceRequest(ctx *schemas.BifrostContext
 	}
 	p.cfgMutex.RUnlock()
 
-	// First evaluate model and provider checks (applies even when virtual keys are disabled or not present)
-	result := p.resolver.EvaluateModelAndProviderRequest(ctx, evaluationRequest.Provider, evaluationRequest.Model)
-
 	// The flow for governance checks is:
 	//   VK (identity + VK-level budget/rate-limit) -> Customer -> Team -> User
 	// VK identity runs FIRST so that revoked, provider-disallowed, or model-disallowed
@@ -978,6 +1024,16 @@ func (p *GovernancePlugin) EvaluateGovernanceRequest(ctx *schemas.BifrostContext
 		}
 	}
 
+	// An override token (x-bf-governance-override) lifts the budgets and/or rate
+	// limits it covers for every check below; identity and provider/model
+	// filtering are still enforced.
+	if err := p.resolveGovernanceOverride(ctx, hierarchyVK); err != nil {
+		return nil, err
+	}
+
+	// First evaluate model and provider checks (applies even when virtual keys are disabled or not present)
+	result := p.resolver.EvaluateModelAndProviderRequest(ctx, evaluationRequest.Provider, evaluationRequest.Model)
+
 	// Read-only metadata calls (e.g. list models) set this flag to skip budget/rate-limit
 	// checks while still enforcing VK identity (existence, active status, provider/model filtering).
 	skipBudgetsAndRateLimits := bifrost.GetBoolFromContext(ctx, schemas.BifrostContextKeySkipBudgetAndRateLimits)
@@ -1211,6 +1267,12 @@ func (p *GovernancePlugin) PreRequestHook(ctx *schemas.BifrostContext, req *sche
 
 	stampGovernanceCtxFromVK(ctx, virtualKey)
 
+	// Presets go in before routing so a defaulted model is routed and load balanced
+	// like one the client sent.
+	if virtualKey != nil && applyVirtualKeyDefaults(req, virtualKey.Defaults) {
+		schemas.AppendToContextList(ctx, schemas.BifrostContextKeySystemPromptInjections, virtualKey.Defaults.SystemPrompt)
+	}
+
 	// Large-payload mode: the body streams to the provider unparsed, so req.Model is
 	// empty for routes where the model lives in the body (OpenAI/Anthropic chat,
 	// responses, etc.). Route on LargePayloadMetadata.Model — the provider's
@@ -1246,6 +1308,7 @@ func (p *GovernancePlugin) PreRequestHook(ctx *schemas.BifrostContext, req *sche
 		if err := p.loadBalanceProvider(ctx, req, virtualKey); err != nil {
 			return err
 		}
+		p.filterFallbacksOverride(ctx, virtualKey)
 
 		// A caller-provided include-tools list can only narrow the virtual key's
 		// tool grant, never expand it — prune entries the key does not allow.
@@ -1305,6 +1368,12 @@ func (p *GovernancePlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.
 			Error: bifrostError,
 		}, nil
 	}
+	// Hold an in-flight slot for the virtual key until the response completes
+	if concurrencyErr := p.acquireConcurrencySlot(ctx, virtualKeyValue); concurrencyErr != nil {
+		return req, &schemas.LLMPluginShortCircuit{
+			Error: concurrencyErr,
+		}, nil
+	}
 
 	return req, nil, nil
 }
@@ -1320,13 +1389,15 @@ func (p *GovernancePlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.
 //   - *schemas.BifrostError: The processed error
 //   - error: Any error that occurred during processing
 func (p *GovernancePlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	// Extract request type, provider, and model
+	requestType, provider, requestedModel, _ := bifrost.GetResponseFields(result, err)
+
+	p.releaseConcurrencySlot(ctx, requestType)
+
 	if _, ok := ctx.Value(governanceRejectedContextKey).(bool); ok {
 		return result, err, nil
 	}
 
-	// Extract request type, provider, and model
-	requestType, provider, requestedModel, _ := bifrost.GetResponseFields(result, err)
-
 	// Extract governance information
 	virtualKey := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyVirtualKey)
 	requestID := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyRequestID)
@@ -1364,6 +1435,9 @@ func (p *GovernancePlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		if len(rateLimitIDs) > 0 {
 			ctx.SetValue(schemas.BifrostContextKeyGovernanceRateLimitIDs, rateLimitIDs)
 		}
+		if result != nil && (isFinalChunk || !bifrost.IsStreamRequestType(requestType)) {
+			p.recordResponseSpend(ctx, result, budgetIDs, pricingScopes)
+		}
 
 		// Attempt number distinguishes physical provider calls within one
 		// logical request so each token-consuming attempt bills exactly once.
@@ -0,0 +1,118 @@
+package governance
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/encrypt"
+)
+
+const (
+	// GovernanceOverrideTokenPrefix marks override tokens so they are recognisable in headers and logs.
+	GovernanceOverrideTokenPrefix = "bf-ovr-"
+	// DefaultGovernanceOverrideTTL is how long an override token lives when the minting request sets no TTL.
+	DefaultGovernanceOverrideTTL = time.Hour
+	// MaxGovernanceOverrideTTL caps how long an override token can live; overrides are for incidents, not standing exceptions.
+	MaxGovernanceOverrideTTL = 24 * time.Hour
+)
+
+// governanceOverrideContextKey holds the validated *TableGovernanceOverride for
+// a request, so fallback attempts reuse the first lookup instead of hitting the
+// config store (and counting the usage) again.
+const governanceOverrideContextKey schemas.BifrostContextKey = "bf-governance-override"
+
+// governanceOverrideUsageTimeout bounds the background write that records an
+// override's usage.
+const governanceOverrideUsageTimeout = 5 * time.Second
+
+// resolveGovernanceOverride validates the override token presented with the
+// request (x-bf-governance-override) and, when it is active and scoped to the
+// request's virtual key (if any), stashes it on ctx so the resolver skips the
+// controls it covers. A token that is presented but unusable rejects the
+// request rather than silently enforcing the limits it was meant to lift.
+func (p *GovernancePlugin) resolveGovernanceOverride(ctx *schemas.BifrostContext, vk *configstoreTables.TableVirtualKey) *schemas.BifrostError {
+	token := strings.TrimSpace(bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceOverrideToken))
+	if token == "" {
+		return nil
+	}
+	if _, ok := ctx.Value(governanceOverrideContextKey).(*configstoreTables.TableGovernanceOverride); ok {
+		return nil
+	}
+	if p.configStore == nil {
+		return governanceOverrideError(403, "governance override tokens require a config store")
+	}
+	override, err := p.configStore.GetGovernanceOverrideByHash(ctx, encrypt.HashSHA256(token))
+	if err != nil {
+		p.logger.Error("failed to look up governance override token: %v", err)
+		return governanceOverrideError(500, "failed to validate governance override token")
+	}
+	now := time.Now()
+	if override == nil || !override.IsActiveAt(now) {
+		return governanceOverrideError(403, "governance override token is invalid, expired, or revoked")
+	}
+	if override.VirtualKeyID != nil && (vk == nil || vk.ID != *override.VirtualKeyID) {
+		return governanceOverrideError(403, "governance override token is not valid for this virtual key")
+	}
+
+	ctx.SetValue(governanceOverrideContextKey, override)
+	ctx.SetValue(schemas.BifrostContextKeyGovernanceOverrideID, override.ID)
+
+	vkName := ""
+	if vk != nil {
+		vkName = vk.Name
+	}
+	requestID, _ := ctx.Value(schemas.BifrostContextKeyRequestID).(string)
+	p.logger.Info("[governance] override %s used by request %s (virtual key %q) to bypass %s; reason: %q, created by: %q, expires at %s",
+		override.ID, requestID, vkName, strings.Join(override.Controls, ","), override.Reason, override.CreatedBy, override.ExpiresAt.Format(time.RFC3339))
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		writeCtx, cancel := context.WithTimeout(p.ctx, governanceOverrideUsageTimeout)
+		defer cancel()
+		if err := p.configStore.RecordGovernanceOverrideUsage(writeCtx, override.ID, now); err != nil {
+			p.logger.Warn("failed to record usage of governance override %s: %v", override.ID, err)
+		}
+	}()
+	return nil
+}
+
+// GenerateGovernanceOverrideToken returns a new random override token.
+func GenerateGovernanceOverrideToken() string {
+	return GovernanceOverrideTokenPrefix + rand.Text()
+}
+
+// governanceOverrideError builds the error returned for an unusable override token.
+func governanceOverrideError(status int, message string) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		Type:       new("governance_override_invalid"),
+		StatusCode: new(status),
+		Error: &schemas.ErrorField{
+			Message: message,
+		},
+	}
+}
+
+// overrideBypasses reports whether the request carries a governance override
+// that skips control.
+func overrideBypasses(ctx context.Context, control string) bool {
+	override, ok := ctx.Value(governanceOverrideContextKey).(*configstoreTables.TableGovernanceOverride)
+	return ok && override != nil && override.Covers(control)
+}
+
+// rateLimitBlocks reports whether a rate limit check result rejects the
+// request, unless a governance override lifts rate limits for it.
+func rateLimitBlocks(ctx context.Context, decision Decision, err error) bool {
+	return (err != nil || isRateLimitViolation(decision)) && !overrideBypasses(ctx, configstoreTables.GovernanceOverrideControlRateLimit)
+}
+
+// budgetBlocks reports whether a budget check result rejects the request,
+// unless a governance override lifts budgets for it.
+func budgetBlocks(ctx context.Context, decision Decision, err error) bool {
+	return (err != nil || isBudgetViolation(decision)) && !overrideBypasses(ctx, configstoreTables.GovernanceOverrideControlBudget)
+}
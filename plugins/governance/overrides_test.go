@@ -0,0 +1,132 @@
+package governance
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// overrideConfigStore implements only the governance override subset of ConfigStore.
+type overrideConfigStore struct {
+	configstore.ConfigStore
+
+	mu        sync.Mutex
+	overrides map[string]*configstoreTables.TableGovernanceOverride
+	used      chan string
+}
+
+func (s *overrideConfigStore) GetGovernanceOverrideByHash(_ context.Context, tokenHash string) (*configstoreTables.TableGovernanceOverride, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	override, ok := s.overrides[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	copied := *override
+	return &copied, nil
+}
+
+func (s *overrideConfigStore) RecordGovernanceOverrideUsage(_ context.Context, id string, _ time.Time) error {
+	s.used <- id
+	return nil
+}
+
+// newOverridePlugin returns a plugin whose virtual key "sk-bf-test" is over
+// both its budget and its request rate limit.
+func newOverridePlugin(t *testing.T, overrides map[string]*configstoreTables.TableGovernanceOverride) (*GovernancePlugin, *overrideConfigStore) {
+	t.Helper()
+	logger := NewMockLogger()
+	budget := buildBudgetWithUsage("budget1", 100.0, 100.0, "1d")
+	rateLimit := buildRateLimitWithUsage("rl1", 10000, 0, 100, 100)
+	vk := buildVirtualKeyWithBudget("vk1", "sk-bf-test", "Test VK", budget)
+	vk.RateLimit = rateLimit
+	vk.RateLimitID = &rateLimit.ID
+
+	store, err := NewLocalGovernanceStore(context.Background(), logger, nil, &configstore.GovernanceConfig{
+		VirtualKeys: []configstoreTables.TableVirtualKey{*vk},
+		Budgets:     []configstoreTables.TableBudget{*budget},
+		RateLimits:  []configstoreTables.TableRateLimit{*rateLimit},
+	}, nil)
+	require.NoError(t, err)
+
+	byHash := make(map[string]*configstoreTables.TableGovernanceOverride, len(overrides))
+	for token, override := range overrides {
+		byHash[encrypt.HashSHA256(token)] = override
+	}
+	configStore := &overrideConfigStore{overrides: byHash, used: make(chan string, 4)}
+	return &GovernancePlugin{
+		ctx:         context.Background(),
+		logger:      logger,
+		store:       store,
+		resolver:    NewBudgetResolver(store, nil, logger, nil),
+		configStore: configStore,
+	}, configStore
+}
+
+func evaluateWithOverride(p *GovernancePlugin, token string) (*schemas.BifrostContext, *EvaluationResult, *schemas.BifrostError) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	if token != "" {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceOverrideToken, token)
+	}
+	result, err := p.EvaluateGovernanceRequest(ctx, &EvaluationRequest{
+		VirtualKey: "sk-bf-test",
+		Provider:   schemas.OpenAI,
+		Model:      "gpt-4",
+	}, schemas.ChatCompletionRequest)
+	return ctx, result, err
+}
+
+func TestGovernanceOverride_SkipsOnlyCoveredControls(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	p, configStore := newOverridePlugin(t, map[string]*configstoreTables.TableGovernanceOverride{
+		"both":        {ID: "ov-both", Controls: []string{configstoreTables.GovernanceOverrideControlBudget, configstoreTables.GovernanceOverrideControlRateLimit}, ExpiresAt: expiresAt},
+		"budget-only": {ID: "ov-budget", Controls: []string{configstoreTables.GovernanceOverrideControlBudget}, ExpiresAt: expiresAt},
+	})
+
+	_, _, err := evaluateWithOverride(p, "")
+	require.NotNil(t, err, "the virtual key is over its limits without an override")
+
+	ctx, result, err := evaluateWithOverride(p, "both")
+	require.Nil(t, err)
+	assert.Equal(t, DecisionAllow, result.Decision)
+	assert.Equal(t, "ov-both", ctx.Value(schemas.BifrostContextKeyGovernanceOverrideID))
+	select {
+	case id := <-configStore.used:
+		assert.Equal(t, "ov-both", id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("override usage was not recorded")
+	}
+
+	_, _, err = evaluateWithOverride(p, "budget-only")
+	require.NotNil(t, err, "a budget override must not lift the rate limit")
+	assert.Equal(t, 429, *err.StatusCode)
+}
+
+func TestGovernanceOverride_RejectsUnusableTokens(t *testing.T) {
+	now := time.Now()
+	otherVK := "vk-other"
+	controls := []string{configstoreTables.GovernanceOverrideControlBudget, configstoreTables.GovernanceOverrideControlRateLimit}
+	p, configStore := newOverridePlugin(t, map[string]*configstoreTables.TableGovernanceOverride{
+		"expired": {ID: "ov-expired", Controls: controls, ExpiresAt: now.Add(-time.Minute)},
+		"revoked": {ID: "ov-revoked", Controls: controls, ExpiresAt: now.Add(time.Hour), RevokedAt: &now},
+		"scoped":  {ID: "ov-scoped", Controls: controls, ExpiresAt: now.Add(time.Hour), VirtualKeyID: &otherVK},
+	})
+
+	for _, token := range []string{"unknown", "expired", "revoked", "scoped"} {
+		t.Run(token, func(t *testing.T) {
+			_, _, err := evaluateWithOverride(p, token)
+			require.NotNil(t, err)
+			assert.Equal(t, 403, *err.StatusCode)
+			assert.Equal(t, "governance_override_invalid", *err.Type)
+		})
+	}
+	assert.Empty(t, configStore.used, "rejected tokens must not count as used")
+}
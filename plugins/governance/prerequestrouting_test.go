@@ -72,3 +72,28 @@ func TestRunPreRequestRouting_UnknownPrefixIsTreatedAsModelNamespace(t *testing.
 	require.NoError(t, err)
 	assert.Equal(t, "groq/meta-llama/llama-3.1-8b-instant", got)
 }
+
+// TestFilterFallbacksOverride_DropsEntriesTheVirtualKeyForbids verifies that an
+// x-bf-fallbacks chain is narrowed to the providers and models the VK allows.
+func TestFilterFallbacksOverride_DropsEntriesTheVirtualKeyForbids(t *testing.T) {
+	vk := buildVirtualKeyWithProviders("vk1", "sk-bf-fb", "Fallback VK", []configstoreTables.TableVirtualKeyProviderConfig{
+		buildProviderConfig("openai", []string{"gpt-4o-mini"}),
+		buildProviderConfig("anthropic", []string{"*"}),
+	})
+	p := newPreRequestRoutingPlugin(t, vk)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyFallbacksOverride, []schemas.Fallback{
+		{Provider: schemas.Anthropic, Model: "claude-sonnet-4"},
+		{Provider: schemas.OpenAI, Model: "gpt-4o"},
+		{Provider: schemas.Groq, Model: "llama-3.1-8b-instant"},
+		{Provider: schemas.OpenAI, Model: "gpt-4o-mini"},
+	})
+
+	p.filterFallbacksOverride(ctx, vk)
+
+	got, _ := ctx.Value(schemas.BifrostContextKeyFallbacksOverride).([]schemas.Fallback)
+	assert.Equal(t, []schemas.Fallback{
+		{Provider: schemas.Anthropic, Model: "claude-sonnet-4"},
+		{Provider: schemas.OpenAI, Model: "gpt-4o-mini"},
+	}, got)
+}
@@ -25,6 +25,7 @@ const (
 	DecisionModelBlocked       Decision = "model_blocked"
 	DecisionProviderBlocked    Decision = "provider_blocked"
 	DecisionMCPToolBlocked     Decision = "mcp_tool_blocked"
+	DecisionConcurrencyLimited Decision = "concurrency_limited"
 )
 
 // EvaluationRequest contains the context for evaluating a request
@@ -89,14 +90,14 @@ func (r *BudgetResolver) EvaluateModelAndProviderRequest(ctx *schemas.BifrostCon
 	}
 	// 1. Check provider-level rate limits FIRST (before model-level checks)
 	if provider != "" {
-		if decision, err := r.store.CheckProviderRateLimit(ctx, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+		if decision, err := r.store.CheckProviderRateLimit(ctx, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("Provider-level rate limit check failed: %s", reasonFromErr(err, decision)),
 			}
 		}
 		// 2. Check provider-level budgets FIRST (before model-level checks)
-		if decision, err := r.store.CheckProviderBudget(ctx, request, nil); err != nil || isBudgetViolation(decision) {
+		if decision, err := r.store.CheckProviderBudget(ctx, request, nil); budgetBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("Provider-level budget exceeded: %s", reasonFromErr(err, decision)),
@@ -105,7 +106,7 @@ func (r *BudgetResolver) EvaluateModelAndProviderRequest(ctx *schemas.BifrostCon
 	}
 	// 3. Check model-level rate limits (after provider-level checks)
 	if model != "" {
-		if decision, err := r.store.CheckModelRateLimit(ctx, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+		if decision, err := r.store.CheckModelRateLimit(ctx, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("Model-level rate limit check failed: %s", reasonFromErr(err, decision)),
@@ -113,7 +114,7 @@ func (r *BudgetResolver) EvaluateModelAndProviderRequest(ctx *schemas.BifrostCon
 		}
 
 		// 4. Check model-level budgets (after provider-level checks)
-		if decision, err := r.store.CheckModelBudget(ctx, request, nil); err != nil || isBudgetViolation(decision) {
+		if decision, err := r.store.CheckModelBudget(ctx, request, nil); budgetBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("Model-level budget exceeded: %s", reasonFromErr(err, decision)),
@@ -136,7 +137,7 @@ func (r *BudgetResolver) EvaluateCustomerRequest(ctx *schemas.BifrostContext, cu
 		}
 	}
 	// Check customer-level rate limits
-	if decision, err := r.store.CheckCustomerRateLimit(ctx, customerID, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+	if decision, err := r.store.CheckCustomerRateLimit(ctx, customerID, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("Customer-level rate limit exceeded: %s", reasonFromErr(err, decision)),
@@ -144,7 +145,7 @@ func (r *BudgetResolver) EvaluateCustomerRequest(ctx *schemas.BifrostContext, cu
 	}
 
 	// Check customer-level budget
-	if decision, err := r.store.CheckCustomerBudget(ctx, customerID, request, nil); err != nil || isBudgetViolation(decision) {
+	if decision, err := r.store.CheckCustomerBudget(ctx, customerID, request, nil); budgetBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("Customer-level budget exceeded: %s", reasonFromErr(err, decision)),
@@ -166,7 +167,7 @@ func (r *BudgetResolver) EvaluateTeamRequest(ctx *schemas.BifrostContext, teamID
 		}
 	}
 	// Check team-level rate limits
-	if decision, err := r.store.CheckTeamRateLimit(ctx, teamID, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+	if decision, err := r.store.CheckTeamRateLimit(ctx, teamID, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("Team-level rate limit exceeded: %s", reasonFromErr(err, decision)),
@@ -174,7 +175,7 @@ func (r *BudgetResolver) EvaluateTeamRequest(ctx *schemas.BifrostContext, teamID
 	}
 
 	// Check team-level budget
-	if decision, err := r.store.CheckTeamBudget(ctx, teamID, request, nil); err != nil || isBudgetViolation(decision) {
+	if decision, err := r.store.CheckTeamBudget(ctx, teamID, request, nil); budgetBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("Team-level budget exceeded: %s", reasonFromErr(err, decision)),
@@ -201,7 +202,7 @@ func (r *BudgetResolver) EvaluateUserRequest(ctx *schemas.BifrostContext, userID
 	}
 
 	// Check user-level rate limits
-	if decision, err := r.store.CheckUserRateLimit(ctx, userID, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+	if decision, err := r.store.CheckUserRateLimit(ctx, userID, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("User-level rate limit exceeded: %s", reasonFromErr(err, decision)),
@@ -209,7 +210,7 @@ func (r *BudgetResolver) EvaluateUserRequest(ctx *schemas.BifrostContext, userID
 	}
 
 	// Check user-level budget
-	if decision, err := r.store.CheckUserBudget(ctx, userID, request, nil); err != nil || isBudgetViolation(decision) {
+	if decision, err := r.store.CheckUserBudget(ctx, userID, request, nil); budgetBlocks(ctx, decision, err) {
 		return &EvaluationResult{
 			Decision: decision,
 			Reason:   fmt.Sprintf("User-level budget exceeded: %s", reasonFromErr(err, decision)),
@@ -220,13 +221,13 @@ func (r *BudgetResolver) EvaluateUserRequest(ctx *schemas.BifrostContext, userID
 	// VK-scoped block in EvaluateVirtualKeyRequest. Gated on model being present —
 	// MCP tool execution (no model) is excluded naturally by this guard.
 	if request.Model != "" {
-		if decision, err := r.store.CheckScopedModelRateLimit(ctx, configstoreTables.ModelConfigScopeUser, userID, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+		if decision, err := r.store.CheckScopedModelRateLimit(ctx, configstoreTables.ModelConfigScopeUser, userID, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("User-level model rate limit exceeded: %s", reasonFromErr(err, decision)),
 			}
 		}
-		if decision, err := r.store.CheckScopedModelBudget(ctx, configstoreTables.ModelConfigScopeUser, userID, request, nil); err != nil || isBudgetViolation(decision) {
+		if decision, err := r.store.CheckScopedModelBudget(ctx, configstoreTables.ModelConfigScopeUser, userID, request, nil); budgetBlocks(ctx, decision, err) {
 			return &EvaluationResult{
 				Decision: decision,
 				Reason:   fmt.Sprintf("User-level model budget exceeded: %s", reasonFromErr(err, decision)),
@@ -254,6 +255,10 @@ func (r *BudgetResolver) EvaluateVirtualKeyRequest(ctx *schemas.BifrostContext,
 	// Set virtual key id and name in context
 	ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, vk.ID)
 	ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyName, vk.Name)
+	// The key's priority is admin-controlled, so it overrides whatever x-bf-priority asked for.
+	if priority, ok := schemas.ParseRequestPriority(vk.Priority); ok {
+		ctx.SetValue(schemas.BifrostContextKeyRequestPriority, priority)
+	}
 	if vk.Team != nil {
 		ctx.SetValue(schemas.BifrostContextKeyGovernanceTeamID, vk.Team.ID)
 		ctx.SetValue(schemas.BifrostContextKeyGovernanceTeamName, vk.Team.Name)
@@ -319,14 +324,14 @@ func (r *BudgetResolver) EvaluateVirtualKeyRequest(ctx *schemas.BifrostContext,
 		// request must satisfy both (most-restrictive wins). Gated on a model being present,
 		// mirroring the global model checks.
 		if model != "" {
-			if decision, err := r.store.CheckScopedModelRateLimit(ctx, configstoreTables.ModelConfigScopeVirtualKey, vk.ID, evaluationRequest, nil, nil); err != nil || isRateLimitViolation(decision) {
+			if decision, err := r.store.CheckScopedModelRateLimit(ctx, configstoreTables.ModelConfigScopeVirtualKey, vk.ID, evaluationRequest, nil, nil); rateLimitBlocks(ctx, decision, err) {
 				return &EvaluationResult{
 					Decision:   decision,
 					Reason:     fmt.Sprintf("Model-level rate limit check failed (virtual key scope): %s", reasonFromErr(err, decision)),
 					VirtualKey: vk,
 				}
 			}
-			if decision, err := r.store.CheckScopedModelBudget(ctx, configstoreTables.ModelConfigScopeVirtualKey, vk.ID, evaluationRequest, nil); err != nil || isBudgetViolation(decision) {
+			if decision, err := r.store.CheckScopedModelBudget(ctx, configstoreTables.ModelConfigScopeVirtualKey, vk.ID, evaluationRequest, nil); budgetBlocks(ctx, decision, err) {
 				return &EvaluationResult{
 					Decision:   decision,
 					Reason:     fmt.Sprintf("Model-level budget exceeded (virtual key scope): %s", reasonFromErr(err, decision)),
@@ -414,7 +419,7 @@ func (r *BudgetResolver) isProviderAllowed(vk *configstoreTables.TableVirtualKey
 
 // checkRateLimitHierarchy checks provider-level rate limits first, then VK rate limits using flexible approach
 func (r *BudgetResolver) checkRateLimitHierarchy(ctx context.Context, vk *configstoreTables.TableVirtualKey, request *EvaluationRequest) *EvaluationResult {
-	if decision, err := r.store.CheckVirtualKeyRateLimit(ctx, vk, request, nil, nil); err != nil || isRateLimitViolation(decision) {
+	if decision, err := r.store.CheckVirtualKeyRateLimit(ctx, vk, request, nil, nil); rateLimitBlocks(ctx, decision, err) {
 		// Check provider-level first (matching check order), then VK-level.
 		// Resolve by ID from the canonical rate-limit map because embedded
 		// references can intentionally remain stale after request-time resets.
@@ -442,7 +447,7 @@ func (r *BudgetResolver) checkRateLimitHierarchy(ctx context.Context, vk *config
 // checkBudgetHierarchy checks the budget hierarchy atomically (VK → Team → Customer)
 func (r *BudgetResolver) checkBudgetHierarchy(ctx context.Context, vk *configstoreTables.TableVirtualKey, request *EvaluationRequest) *EvaluationResult {
 	// Use atomic budget checking to prevent race conditions
-	if decision, err := r.store.CheckVirtualKeyBudget(ctx, vk, request, nil); err != nil || isBudgetViolation(decision) {
+	if decision, err := r.store.CheckVirtualKeyBudget(ctx, vk, request, nil); budgetBlocks(ctx, decision, err) {
 		r.logger.Debug(fmt.Sprintf("Atomic budget exceeded for VK %s: %s", vk.ID, reasonFromErr(err, decision)))
 		return &EvaluationResult{
 			Decision:   decision,
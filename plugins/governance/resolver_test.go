@@ -533,6 +533,30 @@ func TestBudgetResolver_ContextPopulation(t *testing.T) {
 	assert.Equal(t, "cust1", customerID)
 }
 
+// TestBudgetResolver_VirtualKeyPriorityOverridesHeader verifies that a VK's
+// priority replaces the one the client asked for with x-bf-priority.
+func TestBudgetResolver_VirtualKeyPriorityOverridesHeader(t *testing.T) {
+	logger := NewMockLogger()
+	vk := buildVirtualKey("vk1", "sk-bf-test", "Test VK", true)
+	vk.Priority = "batch"
+	vk.ProviderConfigs = []configstoreTables.TableVirtualKeyProviderConfig{
+		buildProviderConfig("openai", []string{"*"}),
+	}
+
+	store, err := NewLocalGovernanceStore(context.Background(), logger, nil, &configstore.GovernanceConfig{
+		VirtualKeys: []configstoreTables.TableVirtualKey{*vk},
+	}, nil)
+	require.NoError(t, err)
+
+	resolver := NewBudgetResolver(store, nil, logger, nil)
+	ctx := &schemas.BifrostContext{}
+	ctx.SetValue(schemas.BifrostContextKeyRequestPriority, schemas.RequestPriorityInteractive)
+
+	result := resolver.EvaluateVirtualKeyRequest(ctx, "sk-bf-test", schemas.OpenAI, "gpt-4", schemas.ChatCompletionRequest, false)
+	assertDecision(t, DecisionAllow, result)
+	assert.Equal(t, schemas.RequestPriorityBatch, ctx.Value(schemas.BifrostContextKeyRequestPriority))
+}
+
 // TestBudgetResolver_EvaluateRequest_PassthroughModelFiltering verifies that passthrough requests
 // enforce the VK's model allowlist only when a model is resolved: a disallowed model is blocked, an
 // allowed model passes, and an absent model imposes no model restriction. Non-passthrough
@@ -33,6 +33,9 @@ type RoutingDecision struct {
 	Fallbacks       []string // Fallback chain: ["provider/model", ...]
 	MatchedRuleID   string   // ID of the rule that matched
 	MatchedRuleName string   // Name of the rule that matched
+	// EmbeddingInputTokens is the estimated length of the longest input when an
+	// embedding length rule matched, 0 otherwise.
+	EmbeddingInputTokens int
 }
 
 // RoutingContext holds all data needed for routing rule evaluation
@@ -47,6 +50,7 @@ type RoutingContext struct {
 	QueryParams              map[string]string                   // Query parameters for dynamic routing
 	BudgetAndRateLimitStatus *BudgetAndRateLimitStatus           // Budget and rate limit status by provider/model
 	computeComplexity        func() *complexity.ComplexityResult // Lazy complexity computation; called at most once when a rule references "complexity_tier"
+	estimateEmbeddingTokens  func() int                          // Lazy estimate of the longest embedding input; nil for non-embedding requests
 }
 
 type RoutingEngine struct {
@@ -128,6 +132,7 @@ func (re *RoutingEngine) EvaluateRoutingRules(ctx *schemas.BifrostContext, routi
 	var finalDecision *RoutingDecision
 	var complexityResult *complexity.ComplexityResult
 	computeComplexity := routingCtx.computeComplexity
+	embeddingTokens := -1 // estimated at most once, on the first embedding length rule that matches
 
 	for chainStep := 0; ; chainStep++ {
 		// TERMINATION 4: Chain exceeded configured max depth.
@@ -187,6 +192,10 @@ func (re *RoutingEngine) EvaluateRoutingRules(ctx *schemas.BifrostContext, routi
 					ctx.AppendRoutingEngineLog(schemas.RoutingEngineRoutingRule, schemas.LogLevelInfo, fmt.Sprintf("Rule '%s' skipped: already fired in this chain", rule.Name))
 					continue
 				}
+				if rule.IsEmbeddingLengthRule() && routingCtx.estimateEmbeddingTokens == nil {
+					ctx.AppendRoutingEngineLog(schemas.RoutingEngineRoutingRule, schemas.LogLevelInfo, fmt.Sprintf("Rule '%s' skipped: embedding length rules only apply to embedding requests", rule.Name))
+					continue
+				}
 				re.logger.Debug("[RoutingEngine] Evaluating rule: name=%s, expression=%s", rule.Name, rule.CelExpression)
 
 				referencesComplexity := celExpressionReferencesIdentifier(rule.CelExpression, "complexity_tier")
@@ -227,7 +236,23 @@ func (re *RoutingEngine) EvaluateRoutingRules(ctx *schemas.BifrostContext, routi
 					continue
 				}
 
-				target, ok := selectWeightedTarget(rule.Targets)
+				var target configstoreTables.TableRoutingTarget
+				var ok bool
+				if rule.IsEmbeddingLengthRule() {
+					if embeddingTokens < 0 {
+						embeddingTokens = routingCtx.estimateEmbeddingTokens()
+					}
+					if bucket, found := selectEmbeddingBucket(rule.ParsedEmbeddingBuckets, embeddingTokens); found {
+						target = configstoreTables.TableRoutingTarget{Provider: bucket.Provider, Model: &bucket.Model, Weight: 1}
+						ok = true
+						ctx.AppendRoutingEngineLog(schemas.RoutingEngineRoutingRule, schemas.LogLevelInfo, fmt.Sprintf("Rule '%s': embedding input ~%d tokens → bucket %s", rule.Name, embeddingTokens, describeEmbeddingBucket(bucket)))
+					} else {
+						ctx.AppendRoutingEngineLog(schemas.RoutingEngineRoutingRule, schemas.LogLevelInfo, fmt.Sprintf("Rule '%s': embedding input ~%d tokens exceeds every bucket, using targets", rule.Name, embeddingTokens))
+					}
+				}
+				if !ok {
+					target, ok = selectWeightedTarget(rule.Targets)
+				}
 				if !ok {
 					re.logger.Debug("[RoutingEngine] Rule %s matched but has no valid targets (empty list or all-negative weights), skipping — note: all-zero weights use uniform selection and would not reach here", rule.Name)
 					ctx.AppendRoutingEngineLog(schemas.RoutingEngineRoutingRule, schemas.LogLevelError, fmt.Sprintf("Rule '%s' [%s] → matched but no valid targets (empty or all-negative weights), skipping", rule.Name, rule.CelExpression))
@@ -257,6 +282,9 @@ func (re *RoutingEngine) EvaluateRoutingRules(ctx *schemas.BifrostContext, routi
 					MatchedRuleID:   rule.ID,
 					MatchedRuleName: rule.Name,
 				}
+				if rule.IsEmbeddingLengthRule() {
+					stepDecision.EmbeddingInputTokens = embeddingTokens
+				}
 				matchedRule = rule
 				matchedTargetWeight = target.Weight
 				break outerLoop
@@ -299,6 +327,59 @@ func (re *RoutingEngine) EvaluateRoutingRules(ctx *schemas.BifrostContext, routi
 	return finalDecision, nil
 }
 
+// selectEmbeddingBucket returns the first bucket whose bound covers tokens.
+// Buckets are ordered by MaxInputTokens; an unbounded (0) bucket covers all.
+func selectEmbeddingBucket(buckets []configstoreTables.RoutingEmbeddingBucket, tokens int) (configstoreTables.RoutingEmbeddingBucket, bool) {
+	for _, bucket := range buckets {
+		if bucket.MaxInputTokens == 0 || tokens <= bucket.MaxInputTokens {
+			return bucket, true
+		}
+	}
+	return configstoreTables.RoutingEmbeddingBucket{}, false
+}
+
+// describeEmbeddingBucket renders a bucket for routing engine logs, e.g. "≤512 → openai/text-embedding-3-small".
+func describeEmbeddingBucket(bucket configstoreTables.RoutingEmbeddingBucket) string {
+	bound := "unbounded"
+	if bucket.MaxInputTokens > 0 {
+		bound = fmt.Sprintf("≤%d", bucket.MaxInputTokens)
+	}
+	target := bucket.Model
+	if bucket.Provider != nil && *bucket.Provider != "" {
+		target = *bucket.Provider + "/" + bucket.Model
+	}
+	return bound + " → " + target
+}
+
+// ValidateRoutingEmbeddingBuckets checks the buckets of an embedding length
+// rule: at least one, each with a model, bounds strictly increasing, and only
+// the last one unbounded.
+func ValidateRoutingEmbeddingBuckets(buckets []configstoreTables.RoutingEmbeddingBucket) error {
+	if len(buckets) == 0 {
+		return fmt.Errorf("embedding length rules require at least one embedding bucket")
+	}
+	previous := 0
+	for i, bucket := range buckets {
+		if strings.TrimSpace(bucket.Model) == "" {
+			return fmt.Errorf("embedding bucket %d: model is required", i)
+		}
+		if bucket.MaxInputTokens < 0 {
+			return fmt.Errorf("embedding bucket %d: max_input_tokens must be non-negative", i)
+		}
+		if bucket.MaxInputTokens == 0 {
+			if i != len(buckets)-1 {
+				return fmt.Errorf("embedding bucket %d: only the last bucket may be unbounded (max_input_tokens 0)", i)
+			}
+			continue
+		}
+		if bucket.MaxInputTokens <= previous {
+			return fmt.Errorf("embedding bucket %d: max_input_tokens must be greater than the previous bucket's (%d)", i, previous)
+		}
+		previous = bucket.MaxInputTokens
+	}
+	return nil
+}
+
 // selectWeightedTarget picks one target from the slice using weighted random selection.
 // Each target's Weight contributes proportionally to its probability of being chosen.
 // Weights do not need to be normalised to 100; the function normalises internally.
@@ -3,6 +3,7 @@ package governance
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -1932,3 +1933,87 @@ func getDefaultRouting(ctx *RoutingContext) *RoutingDecision {
 		MatchedRuleID: "0",
 	}
 }
+
+// TestEvaluateRoutingRules_EmbeddingLengthBuckets tests that an embedding length
+// rule routes by the estimated input length, falls back to its targets past the
+// last bounded bucket, and never applies to non-embedding requests.
+func TestEvaluateRoutingRules_EmbeddingLengthBuckets(t *testing.T) {
+	store, err := NewLocalGovernanceStore(context.Background(), NewMockLogger(), nil, &configstore.GovernanceConfig{}, nil)
+	require.NoError(t, err)
+	engine, err := NewRoutingEngine(store, NewMockLogger(), schemas.Ptr(10))
+	require.NoError(t, err)
+
+	rule := &configstoreTables.TableRoutingRule{
+		ID:   "embed",
+		Name: "Embedding By Length",
+		Type: configstoreTables.RoutingRuleTypeEmbeddingLength,
+		ParsedEmbeddingBuckets: []configstoreTables.RoutingEmbeddingBucket{
+			{MaxInputTokens: 16, Model: "text-embedding-3-small"},
+			{MaxInputTokens: 512, Provider: bifrost.Ptr("cohere"), Model: "embed-english-v3.0"},
+		},
+		Targets: []configstoreTables.TableRoutingTarget{
+			{Model: bifrost.Ptr("text-embedding-3-large"), Weight: 1.0},
+		},
+		Enabled: bifrost.Ptr(true),
+		Scope:   "global",
+	}
+	require.NoError(t, store.UpdateRoutingRuleInMemory(context.Background(), rule))
+
+	route := func(text string) *RoutingDecision {
+		input := &schemas.EmbeddingInput{Text: &text}
+		decision, err := engine.EvaluateRoutingRules(schemas.NewBifrostContext(context.Background(), time.Now()), &RoutingContext{
+			Provider:    schemas.OpenAI,
+			Model:       "text-embedding-3-large",
+			RequestType: string(schemas.EmbeddingRequest),
+			estimateEmbeddingTokens: func() int {
+				return schemas.EstimateEmbeddingInputTokens(input)
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, decision)
+		return decision
+	}
+
+	short := route("five word search query")
+	assert.Equal(t, "openai", short.Provider)
+	assert.Equal(t, "text-embedding-3-small", short.Model)
+	assert.Equal(t, 6, short.EmbeddingInputTokens)
+
+	medium := route(strings.Repeat("a", 400))
+	assert.Equal(t, "cohere", medium.Provider)
+	assert.Equal(t, "embed-english-v3.0", medium.Model)
+	assert.Equal(t, 100, medium.EmbeddingInputTokens)
+
+	long := route(strings.Repeat("a", 4000))
+	assert.Equal(t, "openai", long.Provider)
+	assert.Equal(t, "text-embedding-3-large", long.Model, "inputs past every bucket use the rule's targets")
+
+	bgCtx := schemas.NewBifrostContext(context.Background(), time.Now())
+	decision, err := engine.EvaluateRoutingRules(bgCtx, &RoutingContext{
+		Provider:    schemas.OpenAI,
+		Model:       "gpt-4o",
+		RequestType: string(schemas.ChatCompletionRequest),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, decision, "embedding length rules must not route chat requests")
+}
+
+func TestValidateRoutingEmbeddingBuckets(t *testing.T) {
+	valid := []configstoreTables.RoutingEmbeddingBucket{
+		{MaxInputTokens: 64, Model: "small"},
+		{MaxInputTokens: 1024, Model: "medium"},
+		{Model: "large"},
+	}
+	require.NoError(t, ValidateRoutingEmbeddingBuckets(valid))
+
+	invalid := map[string][]configstoreTables.RoutingEmbeddingBucket{
+		"empty":               nil,
+		"missing model":       {{MaxInputTokens: 64}},
+		"unordered":           {{MaxInputTokens: 1024, Model: "a"}, {MaxInputTokens: 64, Model: "b"}},
+		"unbounded not last":  {{Model: "a"}, {MaxInputTokens: 64, Model: "b"}},
+		"negative max tokens": {{MaxInputTokens: -1, Model: "a"}},
+	}
+	for name, buckets := range invalid {
+		assert.Error(t, ValidateRoutingEmbeddingBuckets(buckets), name)
+	}
+}
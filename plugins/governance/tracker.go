@@ -60,6 +60,9 @@ type UsageTracker struct {
 	// by a TTL sweep on the existing resetWorker tick (no extra goroutine).
 	billedMu sync.Mutex
 	billed   map[string]time.Time
+
+	// alerter sends budget threshold alerts; nil when none are configured.
+	alerter *budgetAlerter
 }
 
 const (
@@ -211,6 +214,7 @@ func (t *UsageTracker) UpdateUsage(ctx context.Context, update *UsageUpdate) {
 		if err := t.store.UpdateVirtualKeyBudgetUsageInMemory(ctx, vk, update.Provider, update.Cost); err != nil {
 			t.logger.Error("failed to update budget hierarchy atomically for VK %s: %v", vk.ID, err)
 		}
+		t.checkBudgetAlerts(ctx, vk)
 	}
 }
 
@@ -392,6 +396,7 @@ func (t *UsageTracker) Cleanup() error {
 	}
 	// Wait for workers to finish
 	t.wg.Wait()
+	t.alerter.stop()
 
 	t.logger.Debug("usage tracker cleanup completed")
 	return nil
@@ -9,6 +9,7 @@ import (
 	bifrost "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
 	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
 	"github.com/valyala/fasthttp"
 )
 
@@ -55,7 +56,8 @@ func IsModelRequiredForRequest(requestType schemas.RequestType) bool {
 	// Cached content list/retrieve/update/delete target a resource name (cachedContents/{id}),
 	// not a model, so they carry no model to filter on; only create binds a cache to a model.
 	// Responses retrieve/delete/cancel/input_items target a response_id, not a model.
-	if requestType == schemas.ListModelsRequest || requestType == schemas.MCPToolExecutionRequest || requestType == schemas.BatchCreateRequest || requestType == schemas.BatchListRequest || requestType == schemas.BatchRetrieveRequest || requestType == schemas.BatchCancelRequest || requestType == schemas.BatchResultsRequest || requestType == schemas.FileUploadRequest || requestType == schemas.FileListRequest || requestType == schemas.FileRetrieveRequest || requestType == schemas.FileDeleteRequest || requestType == schemas.FileContentRequest || requestType == schemas.ContainerCreateRequest || requestType == schemas.ContainerListRequest || requestType == schemas.ContainerRetrieveRequest || requestType == schemas.ContainerDeleteRequest || requestType == schemas.ContainerFileCreateRequest || requestType == schemas.ContainerFileListRequest || requestType == schemas.ContainerFileRetrieveRequest || requestType == schemas.ContainerFileContentRequest || requestType == schemas.ContainerFileDeleteRequest || requestType == schemas.CachedContentListRequest || requestType == schemas.CachedContentRetrieveRequest || requestType == schemas.CachedContentUpdateRequest || requestType == schemas.CachedContentDeleteRequest || requestType == schemas.ResponsesRetrieveRequest || requestType == schemas.ResponsesRetrieveStreamRequest || requestType == schemas.ResponsesDeleteRequest || requestType == schemas.ResponsesCancelRequest || requestType == schemas.ResponsesInputItemsRequest || requestType == schemas.VideoRetrieveRequest || requestType == schemas.VideoDownloadRequest || requestType == schemas.VideoListRequest || requestType == schemas.VideoDeleteRequest || requestType == schemas.VideoRemixRequest || requestType == schemas.PassthroughRequest || requestType == schemas.PassthroughStreamRequest {
+	// Fine-tuning list/retrieve/cancel target a job ID; only create names a base model.
+	if requestType == schemas.ListModelsRequest || requestType == schemas.MCPToolExecutionRequest || requestType == schemas.BatchCreateRequest || requestType == schemas.BatchListRequest || requestType == schemas.BatchRetrieveRequest || requestType == schemas.BatchCancelRequest || requestType == schemas.BatchResultsRequest || requestType == schemas.FileUploadRequest || requestType == schemas.FileListRequest || requestType == schemas.FileRetrieveRequest || requestType == schemas.FileDeleteRequest || requestType == schemas.FileContentRequest || requestType == schemas.ContainerCreateRequest || requestType == schemas.ContainerListRequest || requestType == schemas.ContainerRetrieveRequest || requestType == schemas.ContainerDeleteRequest || requestType == schemas.ContainerFileCreateRequest || requestType == schemas.ContainerFileListRequest || requestType == schemas.ContainerFileRetrieveRequest || requestType == schemas.ContainerFileContentRequest || requestType == schemas.ContainerFileDeleteRequest || requestType == schemas.CachedContentListRequest || requestType == schemas.CachedContentRetrieveRequest || requestType == schemas.CachedContentUpdateRequest || requestType == schemas.CachedContentDeleteRequest || requestType == schemas.FineTuningJobListRequest || requestType == schemas.FineTuningJobRetrieveRequest || requestType == schemas.FineTuningJobCancelRequest || requestType == schemas.ResponsesRetrieveRequest || requestType == schemas.ResponsesRetrieveStreamRequest || requestType == schemas.ResponsesDeleteRequest || requestType == schemas.ResponsesCancelRequest || requestType == schemas.ResponsesInputItemsRequest || requestType == schemas.VideoRetrieveRequest || requestType == schemas.VideoDownloadRequest || requestType == schemas.VideoListRequest || requestType == schemas.VideoDeleteRequest || requestType == schemas.VideoRemixRequest || requestType == schemas.PassthroughRequest || requestType == schemas.PassthroughStreamRequest {
 		return false
 	}
 	return true
@@ -70,12 +72,15 @@ func getWeight(w *float64) float64 {
 	return *w
 }
 
-// stampGovernanceCtxFromVK copies team/customer identifiers from the VK onto ctx so
-// downstream plugins (logging, observability) see the governance scope.
+// stampGovernanceCtxFromVK copies the VK and its team/customer identifiers onto ctx so
+// downstream plugins (logging, observability) and request flag evaluation see the
+// governance scope.
 func stampGovernanceCtxFromVK(ctx *schemas.BifrostContext, vk *configstoreTables.TableVirtualKey) {
 	if vk == nil {
 		return
 	}
+	ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, vk.ID)
+	ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyName, vk.Name)
 	if vk.TeamID != nil {
 		ctx.SetValue(schemas.BifrostContextKeyGovernanceTeamID, *vk.TeamID)
 	}
@@ -192,3 +197,59 @@ func (p *GovernancePlugin) validateRequiredHeaders(ctx *schemas.BifrostContext)
 	}
 	return nil
 }
+
+// responseTokenCounts returns the input and output token counts reported on a
+// final response, and false when the response carries no token usage.
+func responseTokenCounts(result *schemas.BifrostResponse) (int, int, bool) {
+	if result == nil {
+		return 0, 0, false
+	}
+	switch {
+	case result.TextCompletionResponse != nil && result.TextCompletionResponse.Usage != nil:
+		return result.TextCompletionResponse.Usage.PromptTokens, result.TextCompletionResponse.Usage.CompletionTokens, true
+	case result.ChatResponse != nil && result.ChatResponse.Usage != nil:
+		return result.ChatResponse.Usage.PromptTokens, result.ChatResponse.Usage.CompletionTokens, true
+	case result.ResponsesResponse != nil && result.ResponsesResponse.Usage != nil:
+		return result.ResponsesResponse.Usage.InputTokens, result.ResponsesResponse.Usage.OutputTokens, true
+	case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil && result.ResponsesStreamResponse.Response.Usage != nil:
+		usage := result.ResponsesStreamResponse.Response.Usage
+		return usage.InputTokens, usage.OutputTokens, true
+	case result.EmbeddingResponse != nil && result.EmbeddingResponse.Usage != nil:
+		return result.EmbeddingResponse.Usage.PromptTokens, 0, true
+	case result.PassthroughResponse != nil && result.PassthroughResponse.PassthroughUsage != nil && result.PassthroughResponse.PassthroughUsage.LLMUsage != nil:
+		usage := result.PassthroughResponse.PassthroughUsage.LLMUsage
+		return usage.PromptTokens, usage.CompletionTokens, true
+	}
+	return 0, 0, false
+}
+
+// recordResponseSpend stores the cost and token counts of a final response on the
+// context, together with the tightest remaining budget across budgetIDs once this
+// request is charged. The transport surfaces these as x-bf-* response headers.
+// Budget usage is read before the async tracker applies this request, so the
+// request's own cost is subtracted here.
+func (p *GovernancePlugin) recordResponseSpend(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, budgetIDs []string, pricingScopes *modelcatalog.PricingLookupScopes) {
+	var cost float64
+	if p.modelCatalog != nil {
+		cost = p.modelCatalog.CalculateCost(result, pricingScopes)
+		ctx.SetValue(schemas.BifrostContextKeyResponseCost, cost)
+	}
+	if tokensIn, tokensOut, ok := responseTokenCounts(result); ok {
+		ctx.SetValue(schemas.BifrostContextKeyResponseTokensIn, tokensIn)
+		ctx.SetValue(schemas.BifrostContextKeyResponseTokensOut, tokensOut)
+	}
+	remaining, found := 0.0, false
+	for _, id := range budgetIDs {
+		budget := p.store.LoadBudget(ctx, id)
+		if budget == nil {
+			continue
+		}
+		headroom := budget.EffectiveMaxLimit() - budget.CurrentUsage - cost
+		if !found || headroom < remaining {
+			remaining, found = headroom, true
+		}
+	}
+	if found {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceBudgetRemaining, max(remaining, 0))
+	}
+}
@@ -1,8 +1,12 @@
 package governance
 
 import (
+	"context"
 	"testing"
 
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
 	"github.com/valyala/fasthttp"
 )
 
@@ -29,3 +33,35 @@ func TestParseVirtualKeyFromFastHTTPRequest_APIKeyHeaderNonVirtualKeyIgnored(t *
 		t.Fatalf("virtual key should not be set from a non-VK api-key value, got %#v", *vk)
 	}
 }
+
+func TestRecordResponseSpend(t *testing.T) {
+	vkBudget := buildBudgetWithUsage("vk-budget", 10, 3, "1d")
+	teamBudget := buildBudgetWithUsage("team-budget", 5, 4.25, "1d")
+	store, err := NewLocalGovernanceStore(context.Background(), NewMockLogger(), nil, &configstore.GovernanceConfig{
+		Budgets: []configstoreTables.TableBudget{*vkBudget, *teamBudget},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewLocalGovernanceStore() error = %v", err)
+	}
+	p := &GovernancePlugin{store: store}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	result := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Usage: &schemas.BifrostLLMUsage{PromptTokens: 12, CompletionTokens: 30, TotalTokens: 42},
+	}}
+	p.recordResponseSpend(ctx, result, []string{"vk-budget", "team-budget", "missing"}, nil)
+
+	if got, _ := ctx.Value(schemas.BifrostContextKeyResponseTokensIn).(int); got != 12 {
+		t.Errorf("tokens in = %d, want 12", got)
+	}
+	if got, _ := ctx.Value(schemas.BifrostContextKeyResponseTokensOut).(int); got != 30 {
+		t.Errorf("tokens out = %d, want 30", got)
+	}
+	if got, ok := ctx.Value(schemas.BifrostContextKeyGovernanceBudgetRemaining).(float64); !ok || got != 0.75 {
+		t.Errorf("budget remaining = %v (set=%v), want the tightest budget's 0.75", got, ok)
+	}
+	// Without a model catalog there is no price to report.
+	if _, ok := ctx.Value(schemas.BifrostContextKeyResponseCost).(float64); ok {
+		t.Error("cost recorded without a model catalog")
+	}
+}
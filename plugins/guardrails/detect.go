@@ -0,0 +1,134 @@
+package guardrails
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detector is a built-in kind of personal data the plugin recognizes.
+type Detector string
+
+const (
+	DetectorEmail      Detector = "email"
+	DetectorPhone      Detector = "phone"
+	DetectorCreditCard Detector = "credit_card"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	// phonePattern matches international (+CC ...) and North American style numbers;
+	// isPhoneNumber then checks the digit count.
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s.\-]?)?(?:\(\d{2,4}\)|\d{2,4})(?:[\s.\-]?\d{2,4}){2,3}`)
+	// cardPattern matches 13 to 19 digits, optionally grouped by spaces or dashes;
+	// isCardNumber then applies the Luhn check.
+	cardPattern = regexp.MustCompile(`\d(?:[ \-]?\d){12,18}`)
+)
+
+// matcher finds one kind of personal data.
+type matcher struct {
+	name    string // the PIIRedaction type
+	pattern *regexp.Regexp
+	valid   func(match string) bool // nil accepts every match
+}
+
+// builtinMatchers holds the matcher of each built-in detector.
+var builtinMatchers = map[Detector]matcher{
+	DetectorCreditCard: {name: string(DetectorCreditCard), pattern: cardPattern, valid: isCardNumber},
+	DetectorEmail:      {name: string(DetectorEmail), pattern: emailPattern},
+	DetectorPhone:      {name: string(DetectorPhone), pattern: phonePattern, valid: isPhoneNumber},
+}
+
+// builtinOrder is the order built-in detectors run in. An earlier matcher wins a span
+// two matchers both claim, so a card number is not also reported as a phone number.
+var builtinOrder = []Detector{DetectorCreditCard, DetectorEmail, DetectorPhone}
+
+// span is one match in a text.
+type span struct {
+	kind       string
+	start, end int
+}
+
+// findSpans returns the non-overlapping matches of matchers in text, in text order.
+func findSpans(matchers []matcher, text string) []span {
+	var spans []span
+	for _, m := range matchers {
+		for _, loc := range m.pattern.FindAllStringIndex(text, -1) {
+			if !atBoundary(text, loc[0], loc[1]) || (m.valid != nil && !m.valid(text[loc[0]:loc[1]])) {
+				continue
+			}
+			if overlapsAny(spans, loc[0], loc[1]) {
+				continue
+			}
+			spans = append(spans, span{kind: m.name, start: loc[0], end: loc[1]})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	return spans
+}
+
+func overlapsAny(spans []span, start, end int) bool {
+	for _, s := range spans {
+		if start < s.end && s.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// atBoundary reports whether a match is not glued to surrounding letters or digits,
+// so part of a longer number or identifier is not reported.
+func atBoundary(text string, start, end int) bool {
+	isWord := func(b byte) bool {
+		return b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+	}
+	return (start == 0 || !isWord(text[start-1])) && (end == len(text) || !isWord(text[end]))
+}
+
+// redact replaces each span of text with a [REDACTED_<TYPE>] marker.
+func redact(text string, spans []span) string {
+	var b strings.Builder
+	last := 0
+	for _, s := range spans {
+		b.WriteString(text[last:s.start])
+		b.WriteString("[REDACTED_" + strings.ToUpper(s.kind) + "]")
+		last = s.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func digitsOf(s string) []int {
+	digits := make([]int, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, int(s[i]-'0'))
+		}
+	}
+	return digits
+}
+
+// isCardNumber applies the Luhn checksum card numbers carry.
+func isCardNumber(match string) bool {
+	digits := digitsOf(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// isPhoneNumber accepts 10 to 15 digits, the range of E.164 numbers with an area code.
+func isPhoneNumber(match string) bool {
+	n := len(digitsOf(match))
+	return n >= 10 && n <= 15
+}
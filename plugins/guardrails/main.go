@@ -0,0 +1,447 @@
+// Package guardrails provides an LLM plugin that scans request messages and responses for
+// personal data (email addresses, phone numbers, credit card numbers and custom regular
+// expressions). Depending on the rules of the request's virtual key, a match blocks the
+// request, is redacted before the text reaches the provider or the caller, or is only logged.
+// Every match that is let through is recorded in extra_fields.pii_redactions for audit, by
+// position and type but never by value.
+package guardrails
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "guardrails"
+
+// ErrCodePIIDetected is the error code of a request or response blocked for personal data.
+const ErrCodePIIDetected = "pii_detected"
+
+// Action is what the plugin does with a text that contains personal data.
+type Action string
+
+const (
+	// ActionBlock rejects the request, or the response, with a 400.
+	ActionBlock Action = "block"
+	// ActionRedact replaces each match with a [REDACTED_<TYPE>] marker.
+	ActionRedact Action = "redact"
+	// ActionLogOnly lets the text through unchanged and only records the matches.
+	ActionLogOnly Action = "log-only"
+)
+
+// CustomPattern is a regular expression reported under its own type name.
+type CustomPattern struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// Rules select what counts as personal data and what to do with it.
+type Rules struct {
+	// Detectors are the built-in detectors to run. Empty runs all of them.
+	Detectors      []Detector      `json:"detectors,omitempty"`
+	CustomPatterns []CustomPattern `json:"custom_patterns,omitempty"`
+	// Action defaults to ActionRedact.
+	Action Action `json:"action,omitempty"`
+}
+
+// Config configures the guardrails plugin.
+type Config struct {
+	// Default applies to requests without a virtual key or whose virtual key has no
+	// rules of its own. Nil leaves those requests unscanned.
+	Default *Rules `json:"default,omitempty"`
+	// VirtualKeys holds rules per virtual key, by ID or name.
+	VirtualKeys map[string]Rules `json:"virtual_keys,omitempty"`
+}
+
+// Context keys private to the plugin.
+const (
+	contextKeyRequestRedactions schemas.BifrostContextKey = "guardrails-request-redactions" // []schemas.PIIRedaction found in the request
+)
+
+// ruleSet is a compiled Rules.
+type ruleSet struct {
+	action   Action
+	matchers []matcher
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	defaultRules *ruleSet
+	virtualKeys  map[string]*ruleSet
+	logger       schemas.Logger
+}
+
+// Init validates config, compiles its patterns and returns the plugin.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	p := &Plugin{virtualKeys: make(map[string]*ruleSet, len(config.VirtualKeys)), logger: logger}
+	if config.Default != nil {
+		rules, err := compileRules(*config.Default)
+		if err != nil {
+			return nil, fmt.Errorf("guardrails: default rules: %w", err)
+		}
+		p.defaultRules = rules
+	}
+	for key, rules := range config.VirtualKeys {
+		compiled, err := compileRules(rules)
+		if err != nil {
+			return nil, fmt.Errorf("guardrails: virtual key %q: %w", key, err)
+		}
+		p.virtualKeys[key] = compiled
+	}
+	return p, nil
+}
+
+func compileRules(rules Rules) (*ruleSet, error) {
+	compiled := &ruleSet{action: rules.Action}
+	switch rules.Action {
+	case "":
+		compiled.action = ActionRedact
+	case ActionBlock, ActionRedact, ActionLogOnly:
+	default:
+		return nil, fmt.Errorf("unknown action %q", rules.Action)
+	}
+	detectors := rules.Detectors
+	if len(detectors) == 0 {
+		detectors = builtinOrder
+	}
+	for _, detector := range builtinOrder {
+		if slices.Contains(detectors, detector) {
+			compiled.matchers = append(compiled.matchers, builtinMatchers[detector])
+		}
+	}
+	for _, detector := range detectors {
+		if _, ok := builtinMatchers[detector]; !ok {
+			return nil, fmt.Errorf("unknown detector %q", detector)
+		}
+	}
+	for _, custom := range rules.CustomPatterns {
+		if custom.Name == "" {
+			return nil, fmt.Errorf("custom pattern %q needs a name", custom.Pattern)
+		}
+		if _, ok := builtinMatchers[Detector(custom.Name)]; ok {
+			return nil, fmt.Errorf("custom pattern %q shadows a built-in detector", custom.Name)
+		}
+		pattern, err := regexp.Compile(custom.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("custom pattern %q: %w", custom.Name, err)
+		}
+		compiled.matchers = append(compiled.matchers, matcher{name: custom.Name, pattern: pattern})
+	}
+	return compiled, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook implements schemas.LLMPlugin. Requests are scanned per attempt in PreLLMHook.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// rulesFor returns the rules of the request's virtual key, falling back to the default
+// rules, or nil when the request is not scanned.
+func (p *Plugin) rulesFor(ctx *schemas.BifrostContext) *ruleSet {
+	for _, key := range []schemas.BifrostContextKey{schemas.BifrostContextKeyGovernanceVirtualKeyID, schemas.BifrostContextKeyGovernanceVirtualKeyName} {
+		if vk, _ := ctx.Value(key).(string); vk != "" {
+			if rules, ok := p.virtualKeys[vk]; ok {
+				return rules
+			}
+		}
+	}
+	return p.defaultRules
+}
+
+// PreLLMHook scans the request's messages. A blocked request is short-circuited with a 400;
+// a redacted request is sent on as a copy, so the caller's request is never modified.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	rules := p.rulesFor(ctx)
+	if rules == nil {
+		return req, nil, nil
+	}
+	scanned, found := rules.scanRequest(req)
+	if len(found) == 0 {
+		return req, nil, nil
+	}
+	types := typesOf(found)
+	switch rules.action {
+	case ActionBlock:
+		ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("blocked the request: it contains %s", types))
+		return req, &schemas.LLMPluginShortCircuit{Error: piiError(fmt.Sprintf("request blocked: it contains personal data (%s)", types))}, nil
+	case ActionRedact:
+		ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("redacted %d spans of %s from the request", len(found), types))
+	default:
+		ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("request contains %s", types))
+	}
+	ctx.SetValue(contextKeyRequestRedactions, found)
+	return scanned, nil, nil
+}
+
+// PostLLMHook scans non-streaming chat, text completion and responses output, applies the
+// rules' action to it and records what was found in the request and the response. Streamed
+// chunks are not scanned, since a match may span chunks; the request's matches are recorded
+// on the final chunk.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	if bifrostErr != nil || result == nil {
+		return result, bifrostErr, nil
+	}
+	rules := p.rulesFor(ctx)
+	extra := result.GetExtraFields()
+	if rules == nil || extra == nil {
+		return result, bifrostErr, nil
+	}
+	requestFound, _ := ctx.Value(contextKeyRequestRedactions).([]schemas.PIIRedaction)
+	switch extra.RequestType {
+	case schemas.ChatCompletionStreamRequest, schemas.TextCompletionStreamRequest, schemas.ResponsesStreamRequest:
+		if final, _ := ctx.Value(schemas.BifrostContextKeyStreamEndIndicator).(bool); final {
+			extra.PIIRedactions = append(extra.PIIRedactions, requestFound...)
+		}
+		return result, bifrostErr, nil
+	}
+
+	found := rules.scanResponse(result)
+	if len(found) > 0 {
+		types := typesOf(found)
+		switch rules.action {
+		case ActionBlock:
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("blocked the response: it contains %s", types))
+			return nil, piiError(fmt.Sprintf("response blocked: it contains personal data (%s)", types)), nil
+		case ActionRedact:
+			ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("redacted %d spans of %s from the response", len(found), types))
+		default:
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("response contains %s", types))
+		}
+	}
+	extra.PIIRedactions = append(extra.PIIRedactions, requestFound...)
+	extra.PIIRedactions = append(extra.PIIRedactions, found...)
+	return result, bifrostErr, nil
+}
+
+// scanText finds personal data in text and returns the text to send on: redacted when the
+// action is ActionRedact, text itself otherwise.
+func (r *ruleSet) scanText(text *string, contentIndex int) (*string, []schemas.PIIRedaction) {
+	if text == nil {
+		return nil, nil
+	}
+	spans := findSpans(r.matchers, *text)
+	if len(spans) == 0 {
+		return text, nil
+	}
+	found := make([]schemas.PIIRedaction, len(spans))
+	for i, s := range spans {
+		found[i] = schemas.PIIRedaction{ContentIndex: contentIndex, Type: s.kind, Start: s.start, End: s.end, Action: string(r.action)}
+	}
+	if r.action == ActionRedact {
+		return schemas.Ptr(redact(*text, spans)), found
+	}
+	return text, found
+}
+
+// scanChatContent scans content and returns it, or a redacted copy of it.
+func (r *ruleSet) scanChatContent(content *schemas.ChatMessageContent) (*schemas.ChatMessageContent, []schemas.PIIRedaction) {
+	if content == nil {
+		return nil, nil
+	}
+	if content.ContentStr != nil {
+		text, found := r.scanText(content.ContentStr, 0)
+		if text == content.ContentStr {
+			return content, found
+		}
+		return &schemas.ChatMessageContent{ContentStr: text}, found
+	}
+	var all []schemas.PIIRedaction
+	var blocks []schemas.ChatContentBlock
+	for j, block := range content.ContentBlocks {
+		text, found := r.scanText(block.Text, j)
+		all = append(all, found...)
+		if text != block.Text {
+			if blocks == nil {
+				blocks = slices.Clone(content.ContentBlocks)
+			}
+			blocks[j].Text = text
+		}
+	}
+	if blocks == nil {
+		return content, all
+	}
+	return &schemas.ChatMessageContent{ContentBlocks: blocks}, all
+}
+
+// scanResponsesContent scans content and returns it, or a redacted copy of it.
+func (r *ruleSet) scanResponsesContent(content *schemas.ResponsesMessageContent) (*schemas.ResponsesMessageContent, []schemas.PIIRedaction) {
+	if content == nil {
+		return nil, nil
+	}
+	if content.ContentStr != nil {
+		text, found := r.scanText(content.ContentStr, 0)
+		if text == content.ContentStr {
+			return content, found
+		}
+		return &schemas.ResponsesMessageContent{ContentStr: text}, found
+	}
+	var all []schemas.PIIRedaction
+	var blocks []schemas.ResponsesMessageContentBlock
+	for j, block := range content.ContentBlocks {
+		text, found := r.scanText(block.Text, j)
+		all = append(all, found...)
+		if text != block.Text {
+			if blocks == nil {
+				blocks = slices.Clone(content.ContentBlocks)
+			}
+			blocks[j].Text = text
+		}
+	}
+	if blocks == nil {
+		return content, all
+	}
+	return &schemas.ResponsesMessageContent{ContentBlocks: blocks}, all
+}
+
+// scanRequest scans the messages of a chat, responses or text completion request and
+// returns the request to send on, with what was found. Redaction copies every level of
+// the request it changes.
+func (r *ruleSet) scanRequest(req *schemas.BifrostRequest) (*schemas.BifrostRequest, []schemas.PIIRedaction) {
+	var all []schemas.PIIRedaction
+	record := func(index int, found []schemas.PIIRedaction) {
+		for _, f := range found {
+			f.Source, f.MessageIndex = "request", index
+			all = append(all, f)
+		}
+	}
+	out := *req
+	changed := false
+	switch {
+	case req.ChatRequest != nil:
+		var input []schemas.ChatMessage
+		for i, msg := range req.ChatRequest.Input {
+			content, found := r.scanChatContent(msg.Content)
+			record(i, found)
+			if content != msg.Content {
+				if input == nil {
+					input = slices.Clone(req.ChatRequest.Input)
+				}
+				input[i].Content = content
+			}
+		}
+		if input != nil {
+			chat := *req.ChatRequest
+			chat.Input = input
+			out.ChatRequest, changed = &chat, true
+		}
+	case req.ResponsesRequest != nil:
+		var input []schemas.ResponsesMessage
+		for i, msg := range req.ResponsesRequest.Input {
+			content, found := r.scanResponsesContent(msg.Content)
+			record(i, found)
+			if content != msg.Content {
+				if input == nil {
+					input = slices.Clone(req.ResponsesRequest.Input)
+				}
+				input[i].Content = content
+			}
+		}
+		if input != nil {
+			responses := *req.ResponsesRequest
+			responses.Input = input
+			out.ResponsesRequest, changed = &responses, true
+		}
+	case req.TextCompletionRequest != nil && req.TextCompletionRequest.Input != nil:
+		prompt := req.TextCompletionRequest.Input
+		scanned := *prompt
+		redacted := false
+		if prompt.PromptStr != nil {
+			text, found := r.scanText(prompt.PromptStr, 0)
+			record(0, found)
+			scanned.PromptStr, redacted = text, text != prompt.PromptStr
+		}
+		for i := range prompt.PromptArray {
+			text, found := r.scanText(&prompt.PromptArray[i], 0)
+			record(i, found)
+			if text != &prompt.PromptArray[i] {
+				if !redacted {
+					scanned.PromptArray, redacted = slices.Clone(prompt.PromptArray), true
+				}
+				scanned.PromptArray[i] = *text
+			}
+		}
+		if redacted {
+			text := *req.TextCompletionRequest
+			text.Input = &scanned
+			out.TextCompletionRequest, changed = &text, true
+		}
+	}
+	if !changed {
+		return req, all
+	}
+	return &out, all
+}
+
+// scanResponse scans the choices of a chat or text completion response, or the output
+// messages of a responses response, redacting them in place when the action is ActionRedact.
+func (r *ruleSet) scanResponse(result *schemas.BifrostResponse) []schemas.PIIRedaction {
+	var all []schemas.PIIRedaction
+	record := func(index int, found []schemas.PIIRedaction) {
+		for _, f := range found {
+			f.Source, f.MessageIndex = "response", index
+			all = append(all, f)
+		}
+	}
+	switch {
+	case result.ChatResponse != nil:
+		for _, choice := range result.ChatResponse.Choices {
+			if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil {
+				continue
+			}
+			content, found := r.scanChatContent(choice.Message.Content)
+			record(choice.Index, found)
+			choice.Message.Content = content
+		}
+	case result.TextCompletionResponse != nil:
+		for _, choice := range result.TextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice == nil {
+				continue
+			}
+			text, found := r.scanText(choice.Text, 0)
+			record(choice.Index, found)
+			choice.Text = text
+		}
+	case result.ResponsesResponse != nil:
+		for i := range result.ResponsesResponse.Output {
+			msg := &result.ResponsesResponse.Output[i]
+			content, found := r.scanResponsesContent(msg.Content)
+			record(i, found)
+			msg.Content = content
+		}
+	}
+	return all
+}
+
+// typesOf lists the distinct types found, in order of first appearance.
+func typesOf(found []schemas.PIIRedaction) string {
+	var types []string
+	for _, f := range found {
+		if !slices.Contains(types, f.Type) {
+			types = append(types, f.Type)
+		}
+	}
+	return strings.Join(types, ", ")
+}
+
+func piiError(message string) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(http.StatusBadRequest),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("invalid_request_error"),
+			Code:    schemas.Ptr(ErrCodePIIDetected),
+			Message: message,
+		},
+		AllowFallbacks: schemas.Ptr(false),
+	}
+}
@@ -0,0 +1,243 @@
+package guardrails
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const contactText = "mail me at jane@example.com or call +1 415-555-0132"
+
+func chatRequest(text string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("be brief")}},
+				{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+			},
+		},
+	}
+}
+
+func chatResult(text string) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{
+			FinishReason: schemas.Ptr("stop"),
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+				Message: &schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+			},
+		}},
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionRequest},
+	}}
+}
+
+func vkContext(id string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	if id != "" {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, id)
+	}
+	return ctx
+}
+
+func TestRedactsRequestAndResponse(t *testing.T) {
+	p, err := Init(Config{Default: &Rules{}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := vkContext("")
+	original := chatRequest(contactText)
+	req, shortCircuit, _ := p.PreLLMHook(ctx, original)
+	if shortCircuit != nil {
+		t.Fatal("a redacted request should not be short-circuited")
+	}
+	if got := *req.ChatRequest.Input[1].Content.ContentStr; got != "mail me at [REDACTED_EMAIL] or call [REDACTED_PHONE]" {
+		t.Fatalf("unexpected redaction: %q", got)
+	}
+	if *original.ChatRequest.Input[1].Content.ContentStr != contactText {
+		t.Fatal("the caller's request must not be modified")
+	}
+	if req.ChatRequest.Input[0].Content != original.ChatRequest.Input[0].Content {
+		t.Fatal("messages without personal data should be shared, not copied")
+	}
+
+	result, bifrostErr, _ := p.PostLLMHook(ctx, chatResult("card 4111 1111 1111 1111 on file"), nil)
+	if bifrostErr != nil {
+		t.Fatalf("unexpected error: %+v", bifrostErr)
+	}
+	if got := *result.ChatResponse.Choices[0].Message.Content.ContentStr; got != "card [REDACTED_CREDIT_CARD] on file" {
+		t.Fatalf("unexpected response redaction: %q", got)
+	}
+
+	email := strings.Index(contactText, "jane@")
+	want := []schemas.PIIRedaction{
+		{Source: "request", MessageIndex: 1, Type: "email", Start: email, End: email + len("jane@example.com"), Action: "redact"},
+		{Source: "request", MessageIndex: 1, Type: "phone", Start: strings.Index(contactText, "+1"), End: len(contactText), Action: "redact"},
+		{Source: "response", MessageIndex: 0, Type: "credit_card", Start: 5, End: 24, Action: "redact"},
+	}
+	got := result.ChatResponse.ExtraFields.PIIRedactions
+	if len(got) != len(want) {
+		t.Fatalf("expected %d redactions, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("redaction %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRulesPerVirtualKey(t *testing.T) {
+	p, err := Init(Config{
+		Default: &Rules{Action: ActionLogOnly},
+		VirtualKeys: map[string]Rules{
+			"vk-strict": {Action: ActionBlock, Detectors: []Detector{DetectorEmail}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, shortCircuit, _ := p.PreLLMHook(vkContext("vk-strict"), chatRequest(contactText))
+	if shortCircuit == nil || shortCircuit.Error == nil || *shortCircuit.Error.StatusCode != 400 || *shortCircuit.Error.Error.Code != ErrCodePIIDetected {
+		t.Fatalf("expected the request to be blocked, got %+v", shortCircuit)
+	}
+	if msg := shortCircuit.Error.Error.Message; !strings.Contains(msg, "email") || strings.Contains(msg, "phone") || strings.Contains(msg, "jane") {
+		t.Fatalf("expected the block to name only the email detector, got %q", msg)
+	}
+
+	// Phone numbers are not among vk-strict's detectors.
+	_, shortCircuit, _ = p.PreLLMHook(vkContext("vk-strict"), chatRequest("call +1 415-555-0132"))
+	if shortCircuit != nil {
+		t.Fatal("a detector the virtual key does not run should not block")
+	}
+
+	// Other keys fall back to the default, which only records.
+	ctx := vkContext("vk-other")
+	original := chatRequest(contactText)
+	req, shortCircuit, _ := p.PreLLMHook(ctx, original)
+	if shortCircuit != nil || req != original {
+		t.Fatal("log-only should pass the request through unchanged")
+	}
+	result, _, _ := p.PostLLMHook(ctx, chatResult("done"), nil)
+	if got := result.ChatResponse.ExtraFields.PIIRedactions; len(got) != 2 || got[0].Action != "log-only" {
+		t.Fatalf("expected two log-only findings, got %+v", got)
+	}
+
+	// A response with personal data is blocked too.
+	_, bifrostErr, _ := p.PostLLMHook(vkContext("vk-strict"), chatResult("reach me at a.b@corp.io"), nil)
+	if bifrostErr == nil || *bifrostErr.Error.Code != ErrCodePIIDetected {
+		t.Fatalf("expected the response to be blocked, got %+v", bifrostErr)
+	}
+}
+
+func TestStreamRecordsRequestFindingsOnFinalChunk(t *testing.T) {
+	p, err := Init(Config{Default: &Rules{}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := vkContext("")
+	p.PreLLMHook(ctx, chatRequest(contactText))
+
+	chunk := func() *schemas.BifrostResponse {
+		return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+			ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionStreamRequest},
+		}}
+	}
+	result, _, _ := p.PostLLMHook(ctx, chunk(), nil)
+	if len(result.ChatResponse.ExtraFields.PIIRedactions) != 0 {
+		t.Fatal("intermediate chunks should not carry findings")
+	}
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+	result, _, _ = p.PostLLMHook(ctx, chunk(), nil)
+	if got := result.ChatResponse.ExtraFields.PIIRedactions; len(got) != 2 {
+		t.Fatalf("expected the request findings on the final chunk, got %+v", got)
+	}
+}
+
+func TestScansContentBlocksResponsesAndPrompts(t *testing.T) {
+	p, err := Init(Config{Default: &Rules{
+		Detectors:      []Detector{DetectorEmail},
+		CustomPatterns: []CustomPattern{{Name: "employee_id", Pattern: `EMP-\d{6}`}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := []schemas.ChatContentBlock{
+		{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("hello")},
+		{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("my id is EMP-123456")},
+	}
+	req := &schemas.BifrostRequest{RequestType: schemas.ChatCompletionRequest, ChatRequest: &schemas.BifrostChatRequest{
+		Input: []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentBlocks: blocks}}},
+	}}
+	ctx := vkContext("")
+	out, _, _ := p.PreLLMHook(ctx, req)
+	if got := *out.ChatRequest.Input[0].Content.ContentBlocks[1].Text; got != "my id is [REDACTED_EMPLOYEE_ID]" {
+		t.Fatalf("unexpected block redaction: %q", got)
+	}
+	if *blocks[1].Text != "my id is EMP-123456" {
+		t.Fatal("the caller's content blocks must not be modified")
+	}
+	if found := ctx.Value(contextKeyRequestRedactions).([]schemas.PIIRedaction); len(found) != 1 || found[0].ContentIndex != 1 || found[0].Type != "employee_id" {
+		t.Fatalf("unexpected findings: %+v", found)
+	}
+
+	responses := &schemas.BifrostRequest{RequestType: schemas.ResponsesRequest, ResponsesRequest: &schemas.BifrostResponsesRequest{
+		Input: []schemas.ResponsesMessage{{Role: schemas.Ptr(schemas.ResponsesInputMessageRoleUser), Content: &schemas.ResponsesMessageContent{ContentStr: schemas.Ptr("ping x@y.dev")}}},
+	}}
+	out, _, _ = p.PreLLMHook(vkContext(""), responses)
+	if got := *out.ResponsesRequest.Input[0].Content.ContentStr; got != "ping [REDACTED_EMAIL]" {
+		t.Fatalf("unexpected responses redaction: %q", got)
+	}
+
+	prompts := []string{"fine", "to x@y.dev"}
+	text := &schemas.BifrostRequest{RequestType: schemas.TextCompletionRequest, TextCompletionRequest: &schemas.BifrostTextCompletionRequest{
+		Input: &schemas.TextCompletionInput{PromptArray: prompts},
+	}}
+	out, _, _ = p.PreLLMHook(vkContext(""), text)
+	if got := out.TextCompletionRequest.Input.PromptArray; got[0] != "fine" || got[1] != "to [REDACTED_EMAIL]" || prompts[1] != "to x@y.dev" {
+		t.Fatalf("unexpected prompt redaction: %q (caller's %q)", got, prompts)
+	}
+}
+
+func TestDetectors(t *testing.T) {
+	matchers := []matcher{builtinMatchers[DetectorCreditCard], builtinMatchers[DetectorEmail], builtinMatchers[DetectorPhone]}
+	for _, tc := range []struct {
+		text string
+		want []string
+	}{
+		{text: "visa 4111-1111-1111-1111", want: []string{"credit_card"}},
+		{text: "amex 378282246310005", want: []string{"credit_card"}},
+		{text: "not a card 4111 1111 1111 1112", want: nil},
+		{text: "order 1234567890123456789012", want: nil},
+		{text: "call (415) 555-0132 or +44 20 7946 0958", want: []string{"phone", "phone"}},
+		{text: "version 1.2.3 released 2024-01-02", want: nil},
+		{text: "first.last+tag@sub.example.co.uk", want: []string{"email"}},
+	} {
+		var got []string
+		for _, s := range findSpans(matchers, tc.text) {
+			got = append(got, s.kind)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%q: got %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestInitRejectsInvalidRules(t *testing.T) {
+	for name, config := range map[string]Config{
+		"unknown action":   {Default: &Rules{Action: "mask"}},
+		"unknown detector": {VirtualKeys: map[string]Rules{"vk": {Detectors: []Detector{"ssn"}}}},
+		"unnamed pattern":  {Default: &Rules{CustomPatterns: []CustomPattern{{Pattern: `\d+`}}}},
+		"shadowing name":   {Default: &Rules{CustomPatterns: []CustomPattern{{Name: "email", Pattern: `\d+`}}}},
+		"bad regex":        {Default: &Rules{CustomPatterns: []CustomPattern{{Name: "id", Pattern: `(`}}}},
+	} {
+		if _, err := Init(config, nil); err == nil || !strings.HasPrefix(err.Error(), "guardrails:") {
+			t.Errorf("%s: expected a guardrails error, got %v", name, err)
+		}
+	}
+}
@@ -30,6 +30,8 @@ type AccumulatedContent struct {
 // It handles partial JSON chunks by accumulating them and making the accumulated content valid JSON
 type JsonParserPlugin struct {
 	usage Usage
+	// validation is set when JSON-mode streams are validated instead of repaired
+	validation *ValidationConfig
 	// State management for accumulating chunks
 	accumulatedContent map[string]*AccumulatedContent // requestID -> accumulated content with timestamp
 	mutex              sync.RWMutex
@@ -45,6 +47,8 @@ type PluginConfig struct {
 	Usage           Usage
 	CleanupInterval time.Duration
 	MaxAge          time.Duration
+	// Validation, when set, validates JSON-mode streams instead of repairing them
+	Validation *ValidationConfig
 }
 
 const (
@@ -63,9 +67,15 @@ func Init(config PluginConfig) (*JsonParserPlugin, error) {
 	if config.Usage == "" {
 		config.Usage = PerRequest
 	}
+	if config.Validation != nil && config.Validation.HoldBackBytes <= 0 {
+		validation := *config.Validation
+		validation.HoldBackBytes = DefaultHoldBackBytes
+		config.Validation = &validation
+	}
 
 	plugin := &JsonParserPlugin{
 		usage:              config.Usage,
+		validation:         config.Validation,
 		accumulatedContent: make(map[string]*AccumulatedContent),
 		cleanupInterval:    config.CleanupInterval,
 		maxAge:             config.MaxAge,
@@ -103,7 +113,7 @@ func (p *JsonParserPlugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.
 	return nil
 }
 
-// PreLLMHook is not used for this plugin as we only process responses
+// PreLLMHook records whether the attempt asked for JSON output when validation is enabled
 // Parameters:
 //   - ctx: The Bifrost context
 //   - req: The Bifrost request
@@ -113,6 +123,11 @@ func (p *JsonParserPlugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.
 //   - *schemas.LLMPluginShortCircuit: The plugin short circuit if the request is not allowed
 //   - error: Any error that occurred during processing
 func (p *JsonParserPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	if p.validation != nil {
+		ctx.SetValue(jsonOutputRequestedKey, isJSONOutputRequest(req))
+		// Each fallback starts a new stream with its own validation state
+		ctx.ClearValue(validationStateKey)
+	}
 	return req, nil, nil
 }
 
@@ -148,6 +163,10 @@ func (p *JsonParserPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		return result, err, nil
 	}
 
+	if p.validation != nil {
+		return p.validateStream(ctx, result, extraFields.RequestType)
+	}
+
 	// Get request ID for state management, if it's not set, return as is
 	requestID := p.getRequestID(ctx, result)
 	if requestID == "" {
@@ -581,3 +581,149 @@ func TestParsePartialJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONStreamValidator(t *testing.T) {
+	cases := []struct {
+		chunks   []string
+		complete bool
+		failAt   int // byte offset of the expected syntax error, -1 for none
+	}{
+		{[]string{`{"a": [1, -2.5e3, `, `true, null, "xé"]}`}, true, -1},
+		{[]string{` 42 `}, true, -1},
+		{[]string{`{"a": `, `1`}, false, -1},
+		{[]string{`{"a" 1}`}, false, 5},
+		{[]string{`Sure! {"a": 1}`}, false, 0},
+		{[]string{`[1, 2}`}, false, 5},
+		{[]string{`{"a": 01}`}, false, 7},
+		{[]string{`{"a": 1}`, ` {"b": 2}`}, false, 9},
+		{[]string{`["tr`, `ue", tru`, `x]`}, false, 12},
+	}
+	for i, tc := range cases {
+		var v jsonStreamValidator
+		var err error
+		for _, chunk := range tc.chunks {
+			if err = v.Write(chunk); err != nil {
+				break
+			}
+		}
+		if tc.failAt < 0 && err != nil {
+			t.Errorf("case %d: unexpected error %v", i, err)
+		}
+		if tc.failAt >= 0 {
+			syntaxErr, ok := err.(*jsonSyntaxError)
+			if !ok || syntaxErr.Offset != tc.failAt {
+				t.Errorf("case %d: expected syntax error at byte %d, got %v", i, tc.failAt, err)
+			}
+		}
+		if v.Complete() != tc.complete {
+			t.Errorf("case %d: expected Complete() == %v", i, tc.complete)
+		}
+	}
+}
+
+// newValidationContext returns a context for a JSON mode chat stream attempt that has gone
+// through the plugin's PreLLMHook.
+func newValidationContext(t *testing.T, plugin *JsonParserPlugin) *schemas.BifrostContext {
+	t.Helper()
+	var responseFormat interface{} = map[string]interface{}{"type": "json_object"}
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, _, err := plugin.PreLLMHook(ctx, &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionStreamRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o-mini",
+			Params:   &schemas.ChatParameters{ResponseFormat: &responseFormat},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PreLLMHook: %v", err)
+	}
+	return ctx
+}
+
+// newChatStreamChunk builds a chat completion stream chunk carrying content.
+func newChatStreamChunk(content string) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{
+		ChatResponse: &schemas.BifrostChatResponse{
+			ID: "chatcmpl-test",
+			Choices: []schemas.BifrostResponseChoice{{
+				ChatStreamResponseChoice: &schemas.ChatStreamResponseChoice{
+					Delta: &schemas.ChatStreamResponseChoiceDelta{Content: bifrost.Ptr(content)},
+				},
+			}},
+			ExtraFields: schemas.BifrostResponseExtraFields{
+				RequestType: schemas.ChatCompletionStreamRequest,
+			},
+		},
+	}
+}
+
+func TestValidationRetriesMalformedOutputOnce(t *testing.T) {
+	plugin, err := Init(PluginConfig{Usage: AllRequests, Validation: &ValidationConfig{RetryOnInvalid: true}})
+	if err != nil {
+		t.Fatalf("failed to init plugin: %v", err)
+	}
+	defer plugin.Cleanup()
+	ctx := newValidationContext(t, plugin)
+
+	// The malformed first attempt is still held back, so it fails with a retryable error.
+	result, bifrostErr, _ := plugin.PostLLMHook(ctx, newChatStreamChunk(`{"name": `), nil)
+	if result != nil || bifrostErr == nil || bifrostErr.StreamControl == nil {
+		t.Fatal("expected the first chunk to be held back")
+	}
+	_, bifrostErr, _ = plugin.PostLLMHook(ctx, newChatStreamChunk(`John}`), nil)
+	if bifrostErr == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != 502 {
+		t.Fatalf("expected a retryable 502, got %+v", bifrostErr)
+	}
+	if *bifrostErr.Error.Type != InvalidJSONOutputErrorType {
+		t.Fatalf("unexpected error type %q", *bifrostErr.Error.Type)
+	}
+
+	// Core's retry starts a new attempt; valid output is released in one piece at the end.
+	ctx.SetValue(schemas.BifrostContextKeyNumberOfRetries, 1)
+	if _, bifrostErr, _ = plugin.PostLLMHook(ctx, newChatStreamChunk(`{"name": `), nil); bifrostErr == nil || bifrostErr.StreamControl == nil {
+		t.Fatal("expected the retry's first chunk to be held back")
+	}
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+	result, bifrostErr, _ = plugin.PostLLMHook(ctx, newChatStreamChunk(`"John"}`), nil)
+	if bifrostErr != nil {
+		t.Fatalf("unexpected error on valid output: %+v", bifrostErr)
+	}
+	if got := *result.ChatResponse.Choices[0].ChatStreamResponseChoice.Delta.Content; got != `{"name": "John"}` {
+		t.Fatalf("expected held content to be released, got %q", got)
+	}
+
+	// A second malformed attempt is not retried again.
+	ctx.SetValue(schemas.BifrostContextKeyNumberOfRetries, 2)
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, false)
+	_, bifrostErr, _ = plugin.PostLLMHook(ctx, newChatStreamChunk(`oops`), nil)
+	if bifrostErr == nil || *bifrostErr.StatusCode != 422 {
+		t.Fatalf("expected a terminal 422 after the retry was used, got %+v", bifrostErr)
+	}
+}
+
+func TestValidationRejectsTruncatedStream(t *testing.T) {
+	plugin, err := Init(PluginConfig{Usage: AllRequests, Validation: &ValidationConfig{}})
+	if err != nil {
+		t.Fatalf("failed to init plugin: %v", err)
+	}
+	defer plugin.Cleanup()
+	ctx := newValidationContext(t, plugin)
+
+	result, bifrostErr, _ := plugin.PostLLMHook(ctx, newChatStreamChunk(`{"items": [1, `), nil)
+	if bifrostErr != nil || *result.ChatResponse.Choices[0].ChatStreamResponseChoice.Delta.Content != `{"items": [1, ` {
+		t.Fatal("valid partial output should pass through unchanged")
+	}
+	ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+	_, bifrostErr, _ = plugin.PostLLMHook(ctx, newChatStreamChunk(`2`), nil)
+	if bifrostErr == nil || bifrostErr.StreamControl != nil || *bifrostErr.StatusCode != 422 {
+		t.Fatalf("expected the final chunk to be replaced by a validation error, got %+v", bifrostErr)
+	}
+
+	// Requests that did not ask for JSON output are left alone.
+	plain := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	plugin.PreLLMHook(plain, &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{}})
+	if _, bifrostErr, _ = plugin.PostLLMHook(plain, newChatStreamChunk(`not json`), nil); bifrostErr != nil {
+		t.Fatalf("non-JSON requests must not be validated, got %+v", bifrostErr)
+	}
+}
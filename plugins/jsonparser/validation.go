@@ -0,0 +1,199 @@
+package jsonparser
+
+import (
+	"net/http"
+	"strings"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const (
+	// DefaultHoldBackBytes is how much output is held back for a possible retry when
+	// ValidationConfig.HoldBackBytes is not set.
+	DefaultHoldBackBytes = 256
+
+	// InvalidJSONOutputErrorType is the error type reported when streamed output fails validation.
+	InvalidJSONOutputErrorType = "invalid_json_output"
+)
+
+const (
+	// jsonOutputRequestedKey marks attempts whose request asked for JSON mode or structured outputs.
+	jsonOutputRequestedKey schemas.BifrostContextKey = "json-parser-json-output-requested"
+	// validationStateKey holds the *validationState of the current stream attempt.
+	validationStateKey schemas.BifrostContextKey = "json-parser-validation-state"
+	// validationRetriedKey is set once the plugin has aborted an attempt for a retry.
+	validationRetriedKey schemas.BifrostContextKey = "json-parser-validation-retried"
+)
+
+// ValidationConfig switches the plugin from repairing partial JSON to validating it. Streams
+// of requests that ask for JSON mode (response_format json_object/json_schema, or the
+// Responses API text.format equivalent) are checked incrementally: malformed output ends the
+// stream with an invalid_json_output error as soon as it appears, and a stream that finishes
+// before its JSON value is complete gets that error in place of its final chunk. Deltas of
+// valid output are passed through unchanged.
+type ValidationConfig struct {
+	// RetryOnInvalid holds back the first HoldBackBytes of chat completion output. If the
+	// output turns out to be malformed before anything was released to the client, the
+	// attempt is aborted with a retryable 502 so core retries it. At most one attempt per
+	// request is aborted this way; the provider needs max_retries of at least 1 for the
+	// retry to happen, otherwise the error goes on to the request's fallbacks.
+	RetryOnInvalid bool
+	// HoldBackBytes is how much output is held back while RetryOnInvalid is set
+	// (default: 256). Output shorter than this is released with the final chunk.
+	HoldBackBytes int
+}
+
+// validationState tracks one stream attempt.
+type validationState struct {
+	attempt   int // core retry number the state belongs to
+	validator jsonStreamValidator
+	held      strings.Builder // content held back for a possible retry
+	heldRole  *string
+	released  bool // content has been sent on to the client
+	failed    bool // validation failed; remaining chunks of the attempt are dropped
+}
+
+// isJSONOutputRequest reports whether req asks the model for JSON output.
+func isJSONOutputRequest(req *schemas.BifrostRequest) bool {
+	switch {
+	case req.ChatRequest != nil && req.ChatRequest.Params != nil && req.ChatRequest.Params.ResponseFormat != nil:
+		format, ok := (*req.ChatRequest.Params.ResponseFormat).(map[string]interface{})
+		if !ok {
+			return false
+		}
+		formatType, _ := format["type"].(string)
+		return formatType == "json_object" || formatType == "json_schema"
+	case req.ResponsesRequest != nil && req.ResponsesRequest.Params != nil && req.ResponsesRequest.Params.Text != nil && req.ResponsesRequest.Params.Text.Format != nil:
+		formatType := req.ResponsesRequest.Params.Text.Format.Type
+		return formatType == "json_object" || formatType == "json_schema"
+	default:
+		return false
+	}
+}
+
+// getValidationState returns the state of the current attempt, starting a fresh one when
+// core has moved on to a retry.
+func getValidationState(ctx *schemas.BifrostContext) *validationState {
+	attempt, _ := ctx.Value(schemas.BifrostContextKeyNumberOfRetries).(int)
+	state, ok := ctx.Value(validationStateKey).(*validationState)
+	if !ok || state.attempt != attempt {
+		state = &validationState{attempt: attempt}
+		ctx.SetValue(validationStateKey, state)
+	}
+	return state
+}
+
+// validateStream is PostLLMHook in validation mode.
+func (p *JsonParserPlugin) validateStream(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, requestType schemas.RequestType) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	if requested, _ := ctx.Value(jsonOutputRequestedKey).(bool); !requested {
+		return result, nil, nil
+	}
+	state := getValidationState(ctx)
+	if state.failed {
+		return nil, skipChunkError(), nil
+	}
+	isFinalChunk := bifrost.IsFinalChunk(ctx)
+
+	if requestType == schemas.ResponsesStreamRequest {
+		resp := result.ResponsesStreamResponse
+		if resp == nil {
+			return result, nil, nil
+		}
+		if resp.Type == schemas.ResponsesStreamResponseTypeOutputTextDelta && resp.Delta != nil {
+			if err := state.validator.Write(*resp.Delta); err != nil {
+				return nil, p.invalidOutput(ctx, state, err.Error()), nil
+			}
+		}
+		if isFinalChunk && !state.validator.Complete() {
+			return nil, p.invalidOutput(ctx, state, "stream ended before the JSON value was complete"), nil
+		}
+		return result, nil, nil
+	}
+
+	if result.ChatResponse == nil || len(result.ChatResponse.Choices) == 0 {
+		return result, nil, nil
+	}
+	// JSON mode output is validated on the first choice.
+	choice := result.ChatResponse.Choices[0]
+	var delta *schemas.ChatStreamResponseChoiceDelta
+	if choice.ChatStreamResponseChoice != nil {
+		delta = choice.ChatStreamResponseChoice.Delta
+	}
+	if delta != nil && delta.Content != nil {
+		if err := state.validator.Write(*delta.Content); err != nil {
+			return nil, p.invalidOutput(ctx, state, err.Error()), nil
+		}
+	}
+	if isFinalChunk && !state.validator.Complete() {
+		return nil, p.invalidOutput(ctx, state, "stream ended before the JSON value was complete"), nil
+	}
+
+	if !p.validation.RetryOnInvalid || state.released {
+		return result, nil, nil
+	}
+
+	// Hold back content until enough has been validated to commit to this attempt.
+	if delta != nil {
+		if delta.Role != nil && state.heldRole == nil {
+			state.heldRole = delta.Role
+		}
+		if delta.Content != nil {
+			state.held.WriteString(*delta.Content)
+		}
+	}
+	if !isFinalChunk && state.held.Len() < p.validation.HoldBackBytes {
+		return nil, skipChunkError(), nil
+	}
+
+	state.released = true
+	resultCopy := p.deepCopyBifrostResponse(result)
+	releaseChoice := &resultCopy.ChatResponse.Choices[0]
+	if releaseChoice.ChatStreamResponseChoice == nil {
+		releaseChoice.ChatStreamResponseChoice = &schemas.ChatStreamResponseChoice{}
+	}
+	if releaseChoice.ChatStreamResponseChoice.Delta == nil {
+		releaseChoice.ChatStreamResponseChoice.Delta = &schemas.ChatStreamResponseChoiceDelta{}
+	}
+	releaseDelta := releaseChoice.ChatStreamResponseChoice.Delta
+	if releaseDelta.Role == nil {
+		releaseDelta.Role = state.heldRole
+	}
+	if state.held.Len() > 0 {
+		content := state.held.String()
+		releaseDelta.Content = &content
+	}
+	return resultCopy, nil, nil
+}
+
+// invalidOutput fails the current attempt. While nothing has been released and the request
+// has not been retried yet, the error is retryable so core transparently retries the attempt.
+func (p *JsonParserPlugin) invalidOutput(ctx *schemas.BifrostContext, state *validationState, reason string) *schemas.BifrostError {
+	state.failed = true
+	statusCode := http.StatusUnprocessableEntity
+	if p.validation.RetryOnInvalid && !state.released {
+		if retried, _ := ctx.Value(validationRetriedKey).(bool); !retried {
+			ctx.SetValue(validationRetriedKey, true)
+			statusCode = http.StatusBadGateway
+		}
+	}
+	return &schemas.BifrostError{
+		StatusCode: schemas.Ptr(statusCode),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr(InvalidJSONOutputErrorType),
+			Message: "model output is not valid JSON: " + reason,
+		},
+	}
+}
+
+// skipChunkError drops a chunk from the stream without surfacing an error to the client.
+func skipChunkError() *schemas.BifrostError {
+	return &schemas.BifrostError{
+		Error: &schemas.ErrorField{
+			Message: "chunk held back by JSON validation",
+		},
+		StreamControl: &schemas.StreamControl{
+			SkipStream: bifrost.Ptr(true),
+		},
+	}
+}
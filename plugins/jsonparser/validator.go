@@ -0,0 +1,302 @@
+package jsonparser
+
+import "fmt"
+
+// validatorState is the position of a jsonStreamValidator inside the JSON grammar.
+type validatorState int
+
+const (
+	stateValue        validatorState = iota // expecting a value
+	stateValueOrClose                       // after '[': a value or ']'
+	stateKeyOrClose                         // after '{': a key or '}'
+	stateKey                                // after ',' in an object: a key
+	stateColon                              // after an object key
+	stateCommaOrClose                       // after a value inside a container
+	stateString
+	stateStringEscape
+	stateStringHex
+	stateNumMinus
+	stateNumZero
+	stateNumInt
+	stateNumDot
+	stateNumFrac
+	stateNumExp
+	stateNumExpSign
+	stateNumExpDigits
+	stateLiteral
+	stateDone // the top-level value is complete; only whitespace may follow
+)
+
+// jsonSyntaxError reports the first byte at which streamed output stopped being JSON.
+type jsonSyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *jsonSyntaxError) Error() string {
+	return fmt.Sprintf("invalid JSON at byte %d: %s", e.Offset, e.Msg)
+}
+
+// jsonStreamValidator checks JSON text incrementally as it arrives. Each Write only scans
+// the new bytes, so malformed output is caught at the first offending byte without
+// re-parsing the accumulated content on every chunk.
+type jsonStreamValidator struct {
+	stack   []byte // open containers, '{' or '['
+	state   validatorState
+	inKey   bool   // the current string is an object key
+	literal string // remaining bytes of true/false/null
+	hex     int    // remaining hex digits of a \u escape
+	offset  int    // bytes consumed so far
+	err     *jsonSyntaxError
+}
+
+// Write feeds the next piece of output to the validator. Once an error is returned every
+// later call returns the same error.
+func (v *jsonStreamValidator) Write(s string) error {
+	if v.err != nil {
+		return v.err
+	}
+	for i := 0; i < len(s); i++ {
+		if err := v.step(s[i]); err != nil {
+			v.err = err
+			return err
+		}
+		v.offset++
+	}
+	return nil
+}
+
+// Complete reports whether the bytes written so far form exactly one valid JSON value.
+func (v *jsonStreamValidator) Complete() bool {
+	if v.err != nil {
+		return false
+	}
+	switch v.state {
+	case stateDone:
+		return true
+	case stateNumZero, stateNumInt, stateNumFrac, stateNumExpDigits:
+		// A top-level number has no terminator, so the end of output completes it.
+		return len(v.stack) == 0
+	default:
+		return false
+	}
+}
+
+func (v *jsonStreamValidator) step(c byte) *jsonSyntaxError {
+	switch v.state {
+	case stateString:
+		switch {
+		case c == '"':
+			if v.inKey {
+				v.inKey = false
+				v.state = stateColon
+			} else {
+				v.endValue()
+			}
+		case c == '\\':
+			v.state = stateStringEscape
+		case c < 0x20:
+			return v.fail("control character in string")
+		}
+		return nil
+	case stateStringEscape:
+		switch c {
+		case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			v.state = stateString
+		case 'u':
+			v.state, v.hex = stateStringHex, 4
+		default:
+			return v.fail(fmt.Sprintf("invalid escape character %q", c))
+		}
+		return nil
+	case stateStringHex:
+		if !isHexDigit(c) {
+			return v.fail(fmt.Sprintf("invalid hex digit %q in unicode escape", c))
+		}
+		if v.hex--; v.hex == 0 {
+			v.state = stateString
+		}
+		return nil
+	case stateLiteral:
+		if c != v.literal[0] {
+			return v.fail(fmt.Sprintf("unexpected character %q in literal", c))
+		}
+		if v.literal = v.literal[1:]; v.literal == "" {
+			v.endValue()
+		}
+		return nil
+	case stateNumMinus, stateNumZero, stateNumInt, stateNumDot, stateNumFrac, stateNumExp, stateNumExpSign, stateNumExpDigits:
+		consumed, err := v.stepNumber(c)
+		if err != nil || consumed {
+			return err
+		}
+		// The number ended at c; c belongs to whatever follows the value.
+		v.endValue()
+		return v.step(c)
+	}
+
+	if isSpace(c) {
+		return nil
+	}
+	switch v.state {
+	case stateValue:
+		return v.startValue(c)
+	case stateValueOrClose:
+		if c == ']' {
+			return v.close(c)
+		}
+		return v.startValue(c)
+	case stateKeyOrClose, stateKey:
+		if c == '}' && v.state == stateKeyOrClose {
+			return v.close(c)
+		}
+		if c != '"' {
+			return v.fail(fmt.Sprintf("expected object key, found %q", c))
+		}
+		v.state, v.inKey = stateString, true
+	case stateColon:
+		if c != ':' {
+			return v.fail(fmt.Sprintf("expected ':' after object key, found %q", c))
+		}
+		v.state = stateValue
+	case stateCommaOrClose:
+		switch c {
+		case ',':
+			if v.stack[len(v.stack)-1] == '{' {
+				v.state = stateKey
+			} else {
+				v.state = stateValue
+			}
+		case '}', ']':
+			return v.close(c)
+		default:
+			return v.fail(fmt.Sprintf("expected ',' or closing bracket, found %q", c))
+		}
+	case stateDone:
+		return v.fail(fmt.Sprintf("unexpected %q after top-level value", c))
+	}
+	return nil
+}
+
+// startValue begins the value whose first byte is c.
+func (v *jsonStreamValidator) startValue(c byte) *jsonSyntaxError {
+	switch {
+	case c == '{' || c == '[':
+		v.stack = append(v.stack, c)
+		if c == '{' {
+			v.state = stateKeyOrClose
+		} else {
+			v.state = stateValueOrClose
+		}
+	case c == '"':
+		v.state = stateString
+	case c == '-':
+		v.state = stateNumMinus
+	case c == '0':
+		v.state = stateNumZero
+	case c >= '1' && c <= '9':
+		v.state = stateNumInt
+	case c == 't':
+		v.state, v.literal = stateLiteral, "rue"
+	case c == 'f':
+		v.state, v.literal = stateLiteral, "alse"
+	case c == 'n':
+		v.state, v.literal = stateLiteral, "ull"
+	default:
+		return v.fail(fmt.Sprintf("unexpected %q where a value was expected", c))
+	}
+	return nil
+}
+
+// stepNumber advances a number by c. It returns false without an error when c cannot
+// extend the number but the number is already complete.
+func (v *jsonStreamValidator) stepNumber(c byte) (bool, *jsonSyntaxError) {
+	digit := c >= '0' && c <= '9'
+	switch v.state {
+	case stateNumMinus:
+		switch {
+		case c == '0':
+			v.state = stateNumZero
+		case digit:
+			v.state = stateNumInt
+		default:
+			return false, v.fail("expected digit after '-'")
+		}
+	case stateNumZero, stateNumInt:
+		switch {
+		case digit && v.state == stateNumZero:
+			return false, v.fail("leading zero in number")
+		case digit:
+		case c == '.':
+			v.state = stateNumDot
+		case c == 'e' || c == 'E':
+			v.state = stateNumExp
+		default:
+			return false, nil
+		}
+	case stateNumDot:
+		if !digit {
+			return false, v.fail("expected digit after decimal point")
+		}
+		v.state = stateNumFrac
+	case stateNumFrac:
+		switch {
+		case digit:
+		case c == 'e' || c == 'E':
+			v.state = stateNumExp
+		default:
+			return false, nil
+		}
+	case stateNumExp:
+		switch {
+		case c == '+' || c == '-':
+			v.state = stateNumExpSign
+		case digit:
+			v.state = stateNumExpDigits
+		default:
+			return false, v.fail("expected digit in exponent")
+		}
+	case stateNumExpSign:
+		if !digit {
+			return false, v.fail("expected digit in exponent")
+		}
+		v.state = stateNumExpDigits
+	case stateNumExpDigits:
+		if !digit {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// close pops the innermost container, which must match the closing bracket c.
+func (v *jsonStreamValidator) close(c byte) *jsonSyntaxError {
+	open := v.stack[len(v.stack)-1]
+	if (open == '{') != (c == '}') {
+		return v.fail(fmt.Sprintf("mismatched closing %q", c))
+	}
+	v.stack = v.stack[:len(v.stack)-1]
+	v.endValue()
+	return nil
+}
+
+// endValue moves past a completed value.
+func (v *jsonStreamValidator) endValue() {
+	if len(v.stack) == 0 {
+		v.state = stateDone
+	} else {
+		v.state = stateCommaOrClose
+	}
+}
+
+func (v *jsonStreamValidator) fail(msg string) *jsonSyntaxError {
+	return &jsonSyntaxError{Offset: v.offset, Msg: msg}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,322 @@
+// Package latencyrouter provides an LLM plugin that routes each request for a configured model
+// to the fastest healthy deployment that serves it. It keeps an exponentially weighted average
+// of latency and error rate per provider/model, sends the request to the best ranked
+// deployment and queues the others as fallbacks in rank order. Every decision is recorded in
+// the request's routing engine logs.
+package latencyrouter
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "latency-router"
+
+const (
+	DefaultSmoothingFactor = 0.2
+	DefaultMaxErrorRate    = 0.5
+	DefaultMinSamples      = 5
+	DefaultCooldownPeriod  = 30 * time.Second
+)
+
+// attemptKey stores the attempt of the current deployment so PostLLMHook can record its outcome.
+const attemptKey schemas.BifrostContextKey = "latency-router-attempt"
+
+// Deployment is a provider/model pair that can serve a route.
+type Deployment struct {
+	Provider schemas.ModelProvider `json:"provider"`
+	Model    string                `json:"model"`
+}
+
+func (d Deployment) String() string { return string(d.Provider) + "/" + d.Model }
+
+// Route lists the interchangeable deployments for a requested model.
+type Route struct {
+	// Model is the requested model the route applies to, either bare ("gpt-4o") or
+	// provider-qualified ("openai/gpt-4o").
+	Model       string       `json:"model"`
+	Deployments []Deployment `json:"deployments"`
+}
+
+// Config configures the latency router.
+type Config struct {
+	Routes []Route `json:"routes"`
+	// SmoothingFactor is the weight of the newest sample in the latency and error rate averages (default: 0.2).
+	SmoothingFactor float64 `json:"smoothing_factor,omitempty"`
+	// MaxErrorRate is the average error rate above which a deployment is unhealthy (default: 0.5).
+	MaxErrorRate float64 `json:"max_error_rate,omitempty"`
+	// MinSamples is the number of outcomes before a deployment can be marked unhealthy (default: 5).
+	MinSamples int `json:"min_samples,omitempty"`
+	// CooldownPeriod is how long an unhealthy deployment is ranked last before it is tried again (default: 30s).
+	CooldownPeriod time.Duration `json:"cooldown_period,omitempty"`
+}
+
+// DeploymentStats is a snapshot of one deployment's measurements.
+type DeploymentStats struct {
+	Deployment
+	LatencyMs float64 `json:"latency_ms"` // average latency of successful requests; 0 until the first success
+	ErrorRate float64 `json:"error_rate"`
+	Samples   int     `json:"samples"`
+	Healthy   bool    `json:"healthy"`
+}
+
+// deploymentStats holds the running averages of one deployment.
+type deploymentStats struct {
+	mu          sync.Mutex
+	latencyMs   float64
+	errorRate   float64
+	samples     int
+	lastFailure time.Time
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	config Config
+	routes map[string]*Route
+	stats  map[Deployment]*deploymentStats
+	logger schemas.Logger
+	now    func() time.Time
+}
+
+// attempt is one request sent to a tracked deployment.
+type attempt struct {
+	deployment Deployment
+	start      time.Time
+}
+
+// Init validates the routes and returns a router with no measurements.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	if config.SmoothingFactor == 0 {
+		config.SmoothingFactor = DefaultSmoothingFactor
+	}
+	if config.MaxErrorRate == 0 {
+		config.MaxErrorRate = DefaultMaxErrorRate
+	}
+	if config.MinSamples == 0 {
+		config.MinSamples = DefaultMinSamples
+	}
+	if config.CooldownPeriod == 0 {
+		config.CooldownPeriod = DefaultCooldownPeriod
+	}
+	if config.SmoothingFactor < 0 || config.SmoothingFactor > 1 {
+		return nil, fmt.Errorf("latency-router: smoothing_factor must be between 0 and 1")
+	}
+	if config.MaxErrorRate < 0 || config.MaxErrorRate > 1 {
+		return nil, fmt.Errorf("latency-router: max_error_rate must be between 0 and 1")
+	}
+
+	p := &Plugin{
+		config: config,
+		routes: make(map[string]*Route, len(config.Routes)),
+		stats:  make(map[Deployment]*deploymentStats),
+		logger: logger,
+		now:    time.Now,
+	}
+	for i := range config.Routes {
+		route := &config.Routes[i]
+		if route.Model == "" || len(route.Deployments) == 0 {
+			return nil, fmt.Errorf("latency-router: route %d needs a model and at least one deployment", i)
+		}
+		if _, ok := p.routes[route.Model]; ok {
+			return nil, fmt.Errorf("latency-router: duplicate route for model %s", route.Model)
+		}
+		for j, deployment := range route.Deployments {
+			if deployment.Provider == "" || deployment.Model == "" {
+				return nil, fmt.Errorf("latency-router: deployment %d of route %s needs a provider and model", j, route.Model)
+			}
+			if _, ok := p.stats[deployment]; !ok {
+				p.stats[deployment] = &deploymentStats{}
+			}
+		}
+		p.routes[route.Model] = route
+	}
+	return p, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook routes requests for a configured model to its best ranked deployment and
+// queues the remaining deployments, then any caller fallbacks, as fallbacks.
+func (p *Plugin) PreRequestHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) error {
+	provider, model, fallbacks := req.GetRequestFields()
+	route := p.routes[model]
+	if route == nil && provider != "" {
+		route = p.routes[string(provider)+"/"+model]
+	}
+	if route == nil {
+		return nil
+	}
+
+	ranked := p.rank(route.Deployments)
+	best := ranked[0]
+	req.SetProvider(best.Provider)
+	req.SetModel(best.Model)
+
+	routed := make([]schemas.Fallback, 0, len(ranked)-1+len(fallbacks))
+	seen := map[Deployment]bool{best.Deployment: true}
+	for _, deployment := range ranked[1:] {
+		seen[deployment.Deployment] = true
+		routed = append(routed, schemas.Fallback{Provider: deployment.Provider, Model: deployment.Model})
+	}
+	for _, fallback := range fallbacks {
+		if !seen[Deployment(fallback)] {
+			routed = append(routed, fallback)
+		}
+	}
+	req.SetFallbacks(routed)
+
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeyRoutingEnginesUsed, schemas.RoutingEngineLatency)
+	descriptions := make([]string, len(ranked))
+	for i, deployment := range ranked {
+		descriptions[i] = describe(deployment)
+	}
+	ctx.AppendRoutingEngineLog(schemas.RoutingEngineLatency, schemas.LogLevelInfo, fmt.Sprintf("Routed %s to %s; ranking: %s", model, best.Deployment, strings.Join(descriptions, ", ")))
+	return nil
+}
+
+// PreLLMHook starts timing attempts sent to a tracked deployment.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	provider, model, _ := req.GetRequestFields()
+	deployment := Deployment{Provider: provider, Model: model}
+	if _, ok := p.stats[deployment]; ok {
+		ctx.SetValue(attemptKey, attempt{deployment: deployment, start: p.now()})
+	} else {
+		ctx.ClearValue(attemptKey)
+	}
+	return req, nil, nil
+}
+
+// PostLLMHook records the attempt's outcome. Successes record their latency, streams on their
+// final chunk; server errors, rate limits and transport failures raise the error rate; other
+// errors are the caller's and are not recorded.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	current, ok := ctx.Value(attemptKey).(attempt)
+	if !ok {
+		return result, bifrostErr, nil
+	}
+	switch {
+	case bifrostErr != nil:
+		// Clear the attempt so the stream's final chunk is not counted again.
+		ctx.ClearValue(attemptKey)
+		if isProviderFailure(bifrostErr) {
+			p.record(current.deployment, 0, false)
+		}
+	case result != nil:
+		requestType, _, _, _ := bifrost.GetResponseFields(result, nil)
+		if bifrost.IsStreamRequestType(requestType) && !bifrost.IsFinalChunk(ctx) {
+			return result, bifrostErr, nil
+		}
+		ctx.ClearValue(attemptKey)
+		p.record(current.deployment, p.now().Sub(current.start), true)
+	}
+	return result, bifrostErr, nil
+}
+
+// Stats returns the measurements of every tracked deployment.
+func (p *Plugin) Stats() []DeploymentStats {
+	out := make([]DeploymentStats, 0, len(p.stats))
+	for deployment := range p.stats {
+		out = append(out, p.snapshot(deployment))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Deployment.String() < out[j].Deployment.String() })
+	return out
+}
+
+// record folds one outcome into a deployment's averages.
+func (p *Plugin) record(deployment Deployment, latency time.Duration, success bool) {
+	stats := p.stats[deployment]
+	alpha := p.config.SmoothingFactor
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	failure := 1.0
+	if success {
+		failure = 0
+		latencyMs := float64(latency) / float64(time.Millisecond)
+		if stats.latencyMs == 0 {
+			stats.latencyMs = latencyMs
+		} else {
+			stats.latencyMs = alpha*latencyMs + (1-alpha)*stats.latencyMs
+		}
+	} else {
+		stats.lastFailure = p.now()
+	}
+	if stats.samples == 0 {
+		stats.errorRate = failure
+	} else {
+		stats.errorRate = alpha*failure + (1-alpha)*stats.errorRate
+	}
+	stats.samples++
+}
+
+func (p *Plugin) snapshot(deployment Deployment) DeploymentStats {
+	stats := p.stats[deployment]
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return DeploymentStats{
+		Deployment: deployment,
+		LatencyMs:  stats.latencyMs,
+		ErrorRate:  stats.errorRate,
+		Samples:    stats.samples,
+		Healthy: stats.samples < p.config.MinSamples ||
+			stats.errorRate <= p.config.MaxErrorRate ||
+			p.now().Sub(stats.lastFailure) >= p.config.CooldownPeriod,
+	}
+}
+
+// rank orders deployments best first: healthy before unhealthy, then among healthy ones
+// unmeasured before measured (so every deployment gets a latency sample) and faster before
+// slower, and among unhealthy ones by error rate. Ties keep the configured order.
+func (p *Plugin) rank(deployments []Deployment) []DeploymentStats {
+	ranked := make([]DeploymentStats, len(deployments))
+	for i, deployment := range deployments {
+		ranked[i] = p.snapshot(deployment)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		if !a.Healthy {
+			return a.ErrorRate < b.ErrorRate
+		}
+		if (a.LatencyMs == 0) != (b.LatencyMs == 0) {
+			return a.LatencyMs == 0
+		}
+		return a.LatencyMs < b.LatencyMs
+	})
+	return ranked
+}
+
+func describe(stats DeploymentStats) string {
+	switch {
+	case !stats.Healthy:
+		return fmt.Sprintf("%s (unhealthy, %.0f%% errors)", stats.Deployment, stats.ErrorRate*100)
+	case stats.LatencyMs == 0:
+		return fmt.Sprintf("%s (unmeasured)", stats.Deployment)
+	default:
+		return fmt.Sprintf("%s (%.0fms, %.0f%% errors)", stats.Deployment, stats.LatencyMs, stats.ErrorRate*100)
+	}
+}
+
+// isProviderFailure reports whether err reflects an unhealthy deployment rather than a bad request.
+func isProviderFailure(err *schemas.BifrostError) bool {
+	if err.IsBifrostError {
+		return false
+	}
+	if err.StatusCode == nil {
+		return true
+	}
+	return *err.StatusCode >= http.StatusInternalServerError || *err.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,120 @@
+package latencyrouter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// fakeClock lets tests control measured latency and the cooldown without sleeping.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+var (
+	openaiGPT4o = Deployment{Provider: schemas.OpenAI, Model: "gpt-4o"}
+	azureGPT4o  = Deployment{Provider: schemas.Azure, Model: "gpt-4o"}
+)
+
+func newTestRouter(t *testing.T, clock *fakeClock) *Plugin {
+	t.Helper()
+	p, err := Init(Config{
+		Routes:     []Route{{Model: "gpt-4o", Deployments: []Deployment{openaiGPT4o, azureGPT4o}}},
+		MinSamples: 2,
+	}, nil)
+	if err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	p.now = clock.now
+	return p
+}
+
+func chatRequest(model string, fallbacks ...schemas.Fallback) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Model: model, Fallbacks: fallbacks},
+	}
+}
+
+func chatResponse() *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: schemas.ChatCompletionRequest},
+	}}
+}
+
+// send runs one attempt against deployment that takes latency and ends with err, if any.
+func send(p *Plugin, clock *fakeClock, deployment Deployment, latency time.Duration, err *schemas.BifrostError) {
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	req := chatRequest(deployment.Model)
+	req.SetProvider(deployment.Provider)
+	p.PreLLMHook(ctx, req)
+	clock.t = clock.t.Add(latency)
+	if err != nil {
+		p.PostLLMHook(ctx, nil, err)
+	} else {
+		p.PostLLMHook(ctx, chatResponse(), nil)
+	}
+}
+
+// route runs PreRequestHook for a gpt-4o request and returns the routed request and its routing logs.
+func route(t *testing.T, p *Plugin, fallbacks ...schemas.Fallback) (*schemas.BifrostRequest, []schemas.RoutingEngineLogEntry) {
+	t.Helper()
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	req := chatRequest("gpt-4o", fallbacks...)
+	if err := p.PreRequestHook(ctx, req); err != nil {
+		t.Fatalf("PreRequestHook failed: %v", err)
+	}
+	return req, ctx.GetRoutingEngineLogs()
+}
+
+func TestRoutesToFastestDeployment(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	p := newTestRouter(t, clock)
+
+	// Unmeasured deployments are tried before measured ones.
+	send(p, clock, openaiGPT4o, 100*time.Millisecond, nil)
+	req, logs := route(t, p)
+	if provider, _, _ := req.GetRequestFields(); provider != schemas.Azure {
+		t.Fatalf("expected the unmeasured deployment, got %s", provider)
+	}
+	if len(logs) != 1 || logs[0].Engine != schemas.RoutingEngineLatency {
+		t.Fatalf("expected one latency routing log, got %+v", logs)
+	}
+
+	send(p, clock, azureGPT4o, 400*time.Millisecond, nil)
+	anthropic := schemas.Fallback{Provider: schemas.Anthropic, Model: "claude-sonnet-4"}
+	req, _ = route(t, p, schemas.Fallback(azureGPT4o), anthropic)
+	provider, model, fallbacks := req.GetRequestFields()
+	if provider != schemas.OpenAI || model != "gpt-4o" {
+		t.Fatalf("expected the faster openai deployment, got %s/%s", provider, model)
+	}
+	if len(fallbacks) != 2 || fallbacks[0] != schemas.Fallback(azureGPT4o) || fallbacks[1] != anthropic {
+		t.Fatalf("expected azure then the caller's anthropic fallback, got %+v", fallbacks)
+	}
+}
+
+func TestUnhealthyDeploymentIsRankedLastUntilCooldown(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	p := newTestRouter(t, clock)
+	send(p, clock, azureGPT4o, 400*time.Millisecond, nil)
+	send(p, clock, openaiGPT4o, 100*time.Millisecond, nil)
+
+	serverError := &schemas.BifrostError{StatusCode: schemas.Ptr(503), Error: &schemas.ErrorField{Message: "unavailable"}}
+	for range 4 {
+		send(p, clock, openaiGPT4o, 10*time.Millisecond, serverError)
+	}
+	// Client errors do not count against the deployment.
+	send(p, clock, azureGPT4o, 10*time.Millisecond, &schemas.BifrostError{StatusCode: schemas.Ptr(400), Error: &schemas.ErrorField{Message: "bad request"}})
+
+	req, _ := route(t, p)
+	if provider, _, _ := req.GetRequestFields(); provider != schemas.Azure {
+		t.Fatalf("expected the healthy azure deployment, got %s", provider)
+	}
+
+	clock.t = clock.t.Add(DefaultCooldownPeriod)
+	req, _ = route(t, p)
+	if provider, _, _ := req.GetRequestFields(); provider != schemas.OpenAI {
+		t.Fatalf("expected openai to be retried after the cooldown, got %s", provider)
+	}
+}
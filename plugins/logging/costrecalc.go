@@ -191,6 +191,7 @@ func (p *LoggerPlugin) RunCostRecalcJob(ctx context.Context, metaJSON string, ch
 				return snapshot(), fmt.Errorf("failed to bulk update costs: %w", err)
 			}
 			meta.Updated += len(costUpdates)
+			p.refreshSessionRollups(ctx, recalculatedLogs(batch, costUpdates), nil)
 		}
 		// Merge the skip count only once the batch is durably committed, so a retry
 		// after a BulkUpdateCost failure cannot double-count the same skipped rows.
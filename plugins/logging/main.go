@@ -303,6 +303,11 @@ func (p *LoggerPlugin) scheduleDeferredUsageUpdate(ctx *schemas.BifrostContext,
 		}
 		if updErr := p.store.Update(p.ctx, requestID, usageUpdates); updErr != nil {
 			p.logger.Warn("failed to update deferred usage for request %s: %v", requestID, updErr)
+			return
+		}
+		// The usage arrived after the log was written, so its session's rollup is stale
+		if updated, err := p.store.FindAll(p.ctx, map[string]interface{}{"id": requestID}, "id", "parent_request_id"); err == nil {
+			p.refreshSessionRollups(p.ctx, updated, nil)
 		}
 	}()
 }
@@ -369,6 +374,7 @@ type InitialLogData struct {
 	RoutingEngineUsed      []string
 	Metadata               map[string]any
 	PassthroughRequestBody string // Raw body for passthrough requests (UTF-8)
+	Seed                   *int   // Request seed, kept for the reproducibility bundle even when content logging is off
 }
 
 // LogCallback is a function that gets called when a new log entry is created
@@ -382,7 +388,8 @@ type Config struct {
 	RetainContentInObjectStorage *bool                  `json:"retain_content_in_object_storage"` // Pointer to live config value; when true, content-disabled requests are offloaded to object storage as hidden instead of dropped
 	LoggingHeaders               *[]string              `json:"logging_headers"`                  // Pointer to live config slice; changes are reflected immediately without restart
 	Writer                       *logstore.WriterConfig `json:"writer,omitempty"`
-	ObjectStorageEnabled         bool                   `json:"-"` // Set by the server from the logstore config; required for retain_content_in_object_storage to take effect
+	ObjectStorageEnabled         bool                   `json:"-"`                     // Set by the server from the logstore config; required for retain_content_in_object_storage to take effect
+	ScrubRules                   []logstore.ScrubRule   `json:"scrub_rules,omitempty"` // Secrets scrubbed from input history and raw payloads before persistence
 }
 
 func validateWriterConfig(config logstore.WriterConfig) error {
@@ -408,6 +415,24 @@ func validateWriterConfig(config logstore.WriterConfig) error {
 	if config.DeferredUsageConcurrency <= 0 {
 		return fmt.Errorf("writer deferred_usage_concurrency must be greater than 0")
 	}
+	switch config.OverflowPolicy {
+	case logstore.WriterOverflowDropNewest, logstore.WriterOverflowDropOldest:
+	case logstore.WriterOverflowBlock:
+		blockTimeout, err := time.ParseDuration(config.BlockTimeout)
+		if err != nil {
+			return fmt.Errorf("writer block_timeout must be a valid Go duration: %w", err)
+		}
+		if blockTimeout <= 0 {
+			return fmt.Errorf("writer block_timeout must be greater than 0")
+		}
+	case logstore.WriterOverflowSpill:
+		if config.SpillDir == "" {
+			return fmt.Errorf("writer spill_dir is required when overflow_policy is %q", logstore.WriterOverflowSpill)
+		}
+	default:
+		return fmt.Errorf("writer overflow_policy must be one of %q, %q, %q or %q",
+			logstore.WriterOverflowDropNewest, logstore.WriterOverflowDropOldest, logstore.WriterOverflowBlock, logstore.WriterOverflowSpill)
+	}
 	return nil
 }
 
@@ -430,6 +455,7 @@ type LoggerPlugin struct {
 	logCallback                  LogCallback
 	mcpToolLogCallback           MCPToolLogCallback // Callback for MCP tool log entries
 	droppedRequests              atomic.Int64
+	abortedRequests              atomic.Int64          // Stale pending LLM logs persisted with status "aborted"
 	cleanupTicker                *time.Ticker          // Ticker for cleaning up old processing logs
 	logMsgPool                   sync.Pool             // Pool for reusing LogMessage structs
 	updateDataPool               sync.Pool             // Pool for reusing UpdateLogData structs
@@ -438,13 +464,23 @@ type LoggerPlugin struct {
 	pendingMCPLogsToInject       sync.Map              // Maps mcpLogID -> *logstore.MCPToolLog (PreMCPHook input data awaiting PostMCPHook)
 	writerConfig                 logstore.WriterConfig // Resolved async writer queue and batch settings
 	writeQueue                   chan *writeQueueEntry // Buffered channel for batch write queue
+	blockTimeout                 time.Duration         // Max wait for queue room under the block overflow policy
+	spill                        *spillWAL             // On-disk overflow log; nil unless the overflow policy is spill
+	scrubber                     *scrubber             // Secret scrubbing applied before entries are queued; nil without scrub rules
+	spillReplayerDone            chan struct{}         // Closed when spillReplayer exits; nil without a spill WAL
+	overflowEvicted              atomic.Int64          // Queued entries evicted to make room (drop_oldest)
+	overflowBlocked              atomic.Int64          // Enqueues that had to wait for room (block)
+	overflowSpilled              atomic.Int64          // Entries written to the spill WAL (spill)
+	overflowReplayed             atomic.Int64          // Spilled entries fed back into the write queue
 	closed                       atomic.Bool           // Set during cleanup to prevent sends on closed writeQueue
 	deferredUsageSem             chan struct{}         // Limits concurrent deferred usage DB updates
 	clusterNodeID                atomic.Value          // Cluster node ID (string) for log attribution in clustered deployments
+	deployment                   atomic.Value          // Deployment metadata (schemas.DeploymentMetadata) stamped onto log entries
 	batchCtx                     context.Context       // Cancelled by Cleanup to stop the batchWriter goroutine before any further DB work
 	batchCancel                  context.CancelFunc    // Cancels batchCtx
 	batchWriterDone              chan struct{}         // Closed by batchWriter on exit; receiving from it transfers writeQueue ownership to Cleanup
 	recoveredBatch               []*writeQueueEntry    // batchWriter parks its in-memory batch here before exiting; safe to read after batchWriterDone closes (happens-before)
+	prefixCache                  prefixCacheTracker    // Prompt prefix reuse per route and virtual key
 }
 
 // Init creates new logger plugin with given log store
@@ -466,13 +502,27 @@ func Init(ctx context.Context, config *Config, logger schemas.Logger, logsStore
 	if err := validateWriterConfig(writerConfig); err != nil {
 		return nil, err
 	}
-	logger.Info("initializing logging writer settings: max_batch_size=%d batch_interval=%s max_batch_bytes=%d write_queue_capacity=%d deferred_usage_concurrency=%d",
+	logger.Info("initializing logging writer settings: max_batch_size=%d batch_interval=%s max_batch_bytes=%d write_queue_capacity=%d deferred_usage_concurrency=%d overflow_policy=%s",
 		writerConfig.MaxBatchSize,
 		writerConfig.BatchInterval,
 		writerConfig.MaxBatchBytes,
 		writerConfig.WriteQueueCapacity,
 		writerConfig.DeferredUsageConcurrency,
+		writerConfig.OverflowPolicy,
 	)
+	scrubber, err := newScrubber(config.ScrubRules)
+	if err != nil {
+		return nil, err
+	}
+	// Validated above; only consulted by the block policy.
+	blockTimeout, _ := time.ParseDuration(writerConfig.BlockTimeout)
+	var spill *spillWAL
+	if writerConfig.OverflowPolicy == logstore.WriterOverflowSpill {
+		var err error
+		if spill, err = openSpillWAL(writerConfig.SpillDir); err != nil {
+			return nil, fmt.Errorf("failed to open logging spill WAL: %w", err)
+		}
+	}
 
 	batchCtx, batchCancel := context.WithCancel(ctx)
 	plugin := &LoggerPlugin{
@@ -488,6 +538,9 @@ func Init(ctx context.Context, config *Config, logger schemas.Logger, logsStore
 		logger:                       logger,
 		writerConfig:                 writerConfig,
 		writeQueue:                   make(chan *writeQueueEntry, writerConfig.WriteQueueCapacity),
+		blockTimeout:                 blockTimeout,
+		spill:                        spill,
+		scrubber:                     scrubber,
 		deferredUsageSem:             make(chan struct{}, writerConfig.DeferredUsageConcurrency),
 		batchCtx:                     batchCtx,
 		batchCancel:                  batchCancel,
@@ -519,6 +572,12 @@ func Init(ctx context.Context, config *Config, logger schemas.Logger, logsStore
 	plugin.wg.Add(1)
 	go plugin.batchWriter()
 
+	// Feed spilled entries (including any left by a previous process) back into the queue
+	if spill != nil {
+		plugin.spillReplayerDone = make(chan struct{})
+		go plugin.spillReplayer()
+	}
+
 	return plugin, nil
 }
 
@@ -530,6 +589,24 @@ func (p *LoggerPlugin) SetClusterNodeID(nodeID string) {
 	p.clusterNodeID.Store(nodeID)
 }
 
+// SetDeploymentMetadata sets the region and instance ID attached to all log entries,
+// so requests served by different instances of an active-active deployment can be
+// told apart.
+func (p *LoggerPlugin) SetDeploymentMetadata(deployment schemas.DeploymentMetadata) {
+	p.deployment.Store(deployment)
+}
+
+// applyDeploymentMetadata stamps the configured region and instance ID onto entry.
+func (p *LoggerPlugin) applyDeploymentMetadata(entry *logstore.Log) {
+	deployment, _ := p.deployment.Load().(schemas.DeploymentMetadata)
+	if deployment.Region != "" {
+		entry.Region = &deployment.Region
+	}
+	if deployment.InstanceID != "" {
+		entry.InstanceID = &deployment.InstanceID
+	}
+}
+
 // cleanupWorker periodically removes old processing logs
 func (p *LoggerPlugin) cleanupWorker() {
 	defer p.wg.Done()
@@ -575,6 +652,12 @@ func (p *LoggerPlugin) GetName() string {
 	return PluginName
 }
 
+// DegradationPolicy implements schemas.DegradablePlugin. Requests served while
+// the plugin is bypassed are not logged.
+func (p *LoggerPlugin) DegradationPolicy() *schemas.PluginDegradationPolicy {
+	return schemas.DefaultObservabilityDegradationPolicy()
+}
+
 // HTTPTransportPreHook is not used for this plugin
 func (p *LoggerPlugin) HTTPTransportPreHook(ctx *schemas.BifrostContext, req *schemas.HTTPRequest) (*schemas.HTTPResponse, error) {
 	return nil, nil
@@ -684,6 +767,10 @@ func (p *LoggerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 
 	provider, model, _ := req.GetRequestFields()
 
+	if prefix := extractPromptPrefix(req); prefix != nil {
+		ctx.SetValue(promptPrefixKey, prefix)
+	}
+
 	initialData := &InitialLogData{
 		Provider: string(provider),
 		Model:    model,
@@ -692,6 +779,12 @@ func (p *LoggerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 	if req.RequestType == schemas.RealtimeRequest {
 		initialData.Object = "realtime.turn"
 	}
+	switch {
+	case req.ChatRequest != nil && req.ChatRequest.Params != nil:
+		initialData.Seed = req.ChatRequest.Params.Seed
+	case req.TextCompletionRequest != nil && req.TextCompletionRequest.Params != nil:
+		initialData.Seed = req.TextCompletionRequest.Params.Seed
+	}
 
 	if p.contentLoggingEnabled(ctx) {
 		inputHistory, responsesInputHistory := p.extractInputHistory(req)
@@ -815,6 +908,21 @@ func (p *LoggerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 			initialData.Params = &schemas.VideoLogParams{
 				VideoID: req.VideoDeleteRequest.ID,
 			}
+		case schemas.FineTuningJobCreateRequest:
+			initialData.Params = &schemas.FineTuningLogParams{
+				TrainingFile:    req.FineTuningJobCreateRequest.TrainingFile,
+				ValidationFile:  req.FineTuningJobCreateRequest.ValidationFile,
+				Suffix:          req.FineTuningJobCreateRequest.Suffix,
+				Hyperparameters: req.FineTuningJobCreateRequest.Hyperparameters,
+			}
+		case schemas.FineTuningJobRetrieveRequest:
+			initialData.Params = &schemas.FineTuningLogParams{
+				JobID: req.FineTuningJobRetrieveRequest.JobID,
+			}
+		case schemas.FineTuningJobCancelRequest:
+			initialData.Params = &schemas.FineTuningLogParams{
+				JobID: req.FineTuningJobCancelRequest.JobID,
+			}
 		case schemas.PassthroughRequest, schemas.PassthroughStreamRequest:
 			initialData.Params = &schemas.PassthroughLogParams{
 				Method:   req.PassthroughRequest.Method,
@@ -832,7 +940,7 @@ func (p *LoggerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 	}
 
 	// Capture configured logging headers and x-bf-lh-* headers into metadata first
-	initialData.Metadata = mergeRealtimeMetadata(p.captureLoggingHeaders(ctx), ctx)
+	initialData.Metadata = mergeRequestFlagMetadata(mergeRealtimeMetadata(p.captureLoggingHeaders(ctx), ctx), ctx)
 
 	// System entries are set after so they take precedence over dynamic header values
 	if isAsync, ok := ctx.Value(schemas.BifrostIsAsyncRequest).(bool); ok && isAsync {
@@ -949,6 +1057,10 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 
 	p.logger.Debug("PostLLMHook: request %s type=%q isFinalChunk=%v hasError=%v", requestID, requestType, isFinalChunk, bifrostErr != nil)
 
+	if bifrostErr == nil && (!bifrost.IsStreamRequestType(requestType) || isFinalChunk) {
+		p.recordPromptPrefix(ctx, result)
+	}
+
 	// Retrieve pending input data from PreLLMHook
 	var pendingVal any
 	var hasPending bool
@@ -975,7 +1087,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 				Timestamp: time.Now().UTC(),
 				CreatedAt: time.Now().UTC(),
 			}
-			entry.MetadataParsed = mergeRealtimeMetadata(p.captureLoggingHeaders(ctx), ctx)
+			entry.MetadataParsed = mergeAdaptedRequestMetadata(mergeRequestFlagMetadata(mergeRealtimeMetadata(p.captureLoggingHeaders(ctx), ctx), ctx), ctx)
 			if isAsync, ok := ctx.Value(schemas.BifrostIsAsyncRequest).(bool); ok && isAsync {
 				if entry.MetadataParsed == nil {
 					entry.MetadataParsed = make(map[string]interface{})
@@ -988,6 +1100,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 			if nodeID, _ := p.clusterNodeID.Load().(string); nodeID != "" {
 				entry.ClusterNodeID = &nodeID
 			}
+			p.applyDeploymentMetadata(entry)
 			applyLargePayloadPreviewsToEntry(ctx, entry, contentLoggingEnabled)
 			p.storeOrEnqueueEntry(ctx, entry, p.makePostWriteCallback(nil))
 		} else {
@@ -1075,11 +1188,14 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 		}
 	}
 	applyOutputFieldsToEntry(entry, selectedKeyID, selectedKeyName, virtualKeyID, virtualKeyName, routingRuleID, routingRuleName, selectedPromptID, selectedPromptName, selectedPromptVersion, teamID, teamName, customerID, customerName, userID, userName, businessUnitID, businessUnitName, numberOfRetries, latency, attemptTrail)
+	// Cost on a tenant's own key is tenant spend, see schemas.Key.BYOK.
+	entry.BYOK, _ = ctx.Value(schemas.BifrostContextKeySelectedKeyBYOK).(bool)
 	applyResolvedAliasInfo(entry, resolvedKeyAlias)
 	// Attach cluster governance metadata for disconnected node usage recovery
 	if nodeID, _ := p.clusterNodeID.Load().(string); nodeID != "" {
 		entry.ClusterNodeID = &nodeID
 	}
+	p.applyDeploymentMetadata(entry)
 	if budgetIDs, ok := ctx.Value(schemas.BifrostContextKeyGovernanceBudgetIDs).([]string); ok && len(budgetIDs) > 0 {
 		entry.BudgetIDsParsed = budgetIDs
 	}
@@ -1105,7 +1221,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 		entry.CustomerNamesParsed = customerNames
 	}
 	entry.MetadataParsed = pending.InitialData.Metadata
-	entry.MetadataParsed = mergeRealtimeMetadata(entry.MetadataParsed, ctx)
+	entry.MetadataParsed = mergeAdaptedRequestMetadata(mergeRealtimeMetadata(entry.MetadataParsed, ctx), ctx)
 	entry.RoutingEngineLogs = routingEngineLogs
 
 	// Branch based on response type to populate output-specific fields
@@ -1152,6 +1268,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 		// logs DB reflects what we were actually billed, mirroring the governance
 		// budget.
 		p.applyErrorBillingFromBilledUsage(ctx, entry, bifrostErr.ExtraFields.BilledUsage, requestType)
+		applyReproducibilityToEntry(ctx, entry, pending.InitialData.Seed, nil, nil, contentLoggingEnabled)
 		applyLargePayloadPreviewsToEntry(ctx, entry, contentLoggingEnabled)
 		p.storeOrEnqueueEntry(ctx, entry, p.makePostWriteCallback(nil))
 		p.scheduleDeferredUsageUpdate(ctx, requestID, entry.TokenUsageParsed != nil)
@@ -1238,6 +1355,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 				}
 			}
 		}
+		applyReproducibilityToEntry(ctx, entry, pending.InitialData.Seed, nil, streamResponse, contentLoggingEnabled)
 		applyLargePayloadPreviewsToEntry(ctx, entry, contentLoggingEnabled)
 		if tracer != nil && traceID != "" {
 			tracer.CleanupStreamAccumulator(traceID)
@@ -1271,6 +1389,7 @@ func (p *LoggerPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.
 			entry.Status = logStatusError
 		}
 	}
+	applyReproducibilityToEntry(ctx, entry, pending.InitialData.Seed, result, nil, contentLoggingEnabled)
 	applyLargePayloadPreviewsToEntry(ctx, entry, contentLoggingEnabled)
 
 	// Calculate cost
@@ -1334,9 +1453,19 @@ func (p *LoggerPlugin) Cleanup() error {
 		// point, no other goroutine reads from p.writeQueue, so we can drain
 		// it ourselves. This wait is microseconds (no DB work involved).
 		<-p.batchWriterDone
+		// The spill replayer also sends to p.writeQueue; wait for it (it exits
+		// on p.done) so nothing but us touches the queue from here on.
+		if p.spillReplayerDone != nil {
+			<-p.spillReplayerDone
+		}
 		// Drain p.recoveredBatch and whatever is still buffered in
 		// p.writeQueue under a bounded deadline.
 		p.drainPending()
+		if p.spill != nil {
+			if err := p.spill.close(); err != nil {
+				p.logger.Warn("failed to close logging spill WAL: %v", err)
+			}
+		}
 		// Close the channel as hygiene. The defer/recover in enqueueLogEntry
 		// (writer.go:254-259) absorbs any racing producer send.
 		close(p.writeQueue)
@@ -1374,6 +1503,14 @@ drainQueue:
 	// budget and starve later chunks.
 	for len(batch) > 0 {
 		if time.Now().After(deadline) {
+			// Under the spill policy, persist the remainder to disk so the next
+			// process replays it instead of losing it.
+			if p.spill != nil {
+				batch = p.spillRemaining(batch)
+				if len(batch) == 0 {
+					return
+				}
+			}
 			p.droppedRequests.Add(int64(len(batch)))
 			p.logger.Warn("logging plugin cleanup deadline reached; dropping %d entries", len(batch))
 			return
@@ -1668,6 +1805,14 @@ func (p *LoggerPlugin) PostMCPHook(ctx *schemas.BifrostContext, resp *schemas.Bi
 		entry.ErrorDetailsParsed = sanitizeErrorForLogging(bifrostErr, p.resolveContentPolicy(ctx).visible(), shouldStoreRaw)
 	} else if resp != nil {
 		entry.Status = "success"
+		// Record output schema drift even when content logging is off; the record
+		// carries no tool output.
+		if validation := resp.ExtraFields.OutputSchemaValidation; validation != nil {
+			if entry.MetadataParsed == nil {
+				entry.MetadataParsed = make(map[string]interface{})
+			}
+			entry.MetadataParsed["output_schema_validation"] = validation
+		}
 		// MCP tool logs have no hidden-content mode, so content is only
 		// stored when it is also visible.
 		if p.resolveContentPolicy(ctx).visible() {
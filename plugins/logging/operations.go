@@ -21,6 +21,9 @@ const (
 	logStatusSuccess    = "success"
 	logStatusError      = "error"
 	logStatusCancelled  = "cancelled"
+	// logStatusAborted marks requests whose PostLLMHook never ran (e.g. the process
+	// or connection died mid-request). The row carries input data only.
+	logStatusAborted = "aborted"
 )
 
 func logStatusForError(err *schemas.BifrostError) string {
@@ -1017,6 +1020,23 @@ func (p *LoggerPlugin) GetSessionSummary(ctx context.Context, sessionID string)
 	return p.store.GetSessionSummary(ctx, sessionID)
 }
 
+// SearchSessions returns one page of per-session token, cost, turn and tool
+// execution rollups.
+func (p *LoggerPlugin) SearchSessions(ctx context.Context, filters logstore.SessionRollupSearchFilters, pagination logstore.PaginationOptions) (*logstore.SessionRollupSearchResult, error) {
+	return p.store.SearchSessionRollups(ctx, filters, pagination)
+}
+
+// recalculatedLogs returns the logs of batch whose cost was updated.
+func recalculatedLogs(batch []logstore.Log, costUpdates map[string]float64) []*logstore.Log {
+	updated := make([]*logstore.Log, 0, len(costUpdates))
+	for i := range batch {
+		if _, ok := costUpdates[batch[i].ID]; ok {
+			updated = append(updated, &batch[i])
+		}
+	}
+	return updated
+}
+
 // GetLog retrieves a single log entry by ID including all fields (raw_request, raw_response).
 func (p *LoggerPlugin) GetLog(ctx context.Context, id string) (*logstore.Log, error) {
 	return p.store.FindByID(ctx, id)
@@ -1086,6 +1106,11 @@ func (p *LoggerPlugin) GetModelRankings(ctx context.Context, filters logstore.Se
 	return p.store.GetModelRankings(ctx, filters)
 }
 
+// GetModelUsageProfiles returns per-model prompt-size and stop-reason profiles for the given filters
+func (p *LoggerPlugin) GetModelUsageProfiles(ctx context.Context, filters logstore.SearchFilters, shortPromptTokens int) (*logstore.ModelUsageProfileResult, error) {
+	return p.store.GetModelUsageProfiles(ctx, filters, shortPromptTokens)
+}
+
 func (p *LoggerPlugin) GetDimensionRankings(ctx context.Context, filters logstore.SearchFilters, dimension logstore.RankingDimension) (*logstore.DimensionRankingResult, error) {
 	return p.store.GetDimensionRankings(ctx, filters, dimension)
 }
@@ -1337,6 +1362,7 @@ func (p *LoggerPlugin) RecalculateCostsWithProgress(ctx context.Context, filters
 				return nil, fmt.Errorf("failed to bulk update costs: %w", err)
 			}
 			result.Updated += len(costUpdates)
+			p.refreshSessionRollups(ctx, recalculatedLogs(searchResult.Logs, costUpdates), nil)
 		}
 
 		if filters.MissingCostOnly {
@@ -3,6 +3,7 @@ package logging
 import (
 	"context"
 	"errors"
+	"math"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -905,6 +906,57 @@ func TestCleanupStalePendingMCPLogsPersistsErrorFallback(t *testing.T) {
 	}
 }
 
+// TestCleanupStalePendingLogsPersistsAborted verifies stale pending LLM logs are
+// written as "aborted" rows with their input data instead of being dropped.
+func TestCleanupStalePendingLogsPersistsAborted(t *testing.T) {
+	store := newTestStore(t)
+	plugin, err := Init(context.Background(), &Config{}, testLogger{}, store, nil, nil)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	stale := time.Now().Add(-pendingLogTTL - time.Minute)
+	plugin.pendingLogsEntries.Store("req-aborted", &PendingLogData{
+		RequestID: "req-aborted",
+		Timestamp: stale,
+		Status:    "processing",
+		InitialData: &InitialLogData{
+			Object:   "chat.completion",
+			Provider: "openai",
+			Model:    "gpt-4o",
+			Params:   &schemas.ChatParameters{User: schemas.Ptr("billing-user")},
+		},
+		CreatedAt: stale,
+	})
+
+	plugin.cleanupStalePendingLogs()
+
+	if _, ok := plugin.pendingLogsEntries.Load("req-aborted"); ok {
+		t.Fatal("expected stale pending log to be removed from memory")
+	}
+	if got := plugin.GetWriteQueueStats().Aborted; got != 1 {
+		t.Fatalf("expected 1 aborted request, got %d", got)
+	}
+	if err := plugin.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	logEntry, err := store.FindByID(context.Background(), "req-aborted")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if logEntry.Status != logStatusAborted {
+		t.Fatalf("expected status %q, got %q", logStatusAborted, logEntry.Status)
+	}
+	if logEntry.Provider != "openai" || logEntry.Model != "gpt-4o" {
+		t.Fatalf("expected input data to be persisted, got provider=%q model=%q", logEntry.Provider, logEntry.Model)
+	}
+	if logEntry.ErrorDetailsParsed == nil || logEntry.ErrorDetailsParsed.Error == nil ||
+		!strings.Contains(logEntry.ErrorDetailsParsed.Error.Message, "pending log TTL") {
+		t.Fatalf("expected stale request error details, got %#v", logEntry.ErrorDetailsParsed)
+	}
+}
+
 // TestActiveStreamSurvivesCleanup is the regression test for the prod issue where
 // streaming requests running longer than the pending TTL had their in-memory
 // pending entry evicted mid-flight (causing the final log row to be lost and a
@@ -1830,3 +1882,51 @@ func TestApplyNonStreamingOutputToEntryContentLoggingEnabled(t *testing.T) {
 		t.Error("expected OutputMessageParsed to be set when contentLoggingEnabled=true")
 	}
 }
+
+func TestProcessBatchRefreshesSessionRollups(t *testing.T) {
+	store := newTestStore(t)
+	plugin, err := Init(context.Background(), &Config{}, testLogger{}, store, nil, nil)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	defer plugin.Cleanup()
+
+	sessionID := "sess-rollup"
+	now := time.Now().UTC()
+	newLog := func(id string, cost float64, tokens int) *logstore.Log {
+		return &logstore.Log{
+			ID:              id,
+			ParentRequestID: &sessionID,
+			Timestamp:       now,
+			Object:          "chat.completion",
+			Provider:        "openai",
+			Model:           "gpt-4o",
+			Status:          "success",
+			Cost:            &cost,
+			TotalTokens:     tokens,
+			CreatedAt:       now,
+		}
+	}
+	plugin.processBatch([]*writeQueueEntry{{log: newLog("turn-1", 0.5, 100)}})
+	// The tool log arrives in a later batch than the request that triggered it
+	llmRequestID := "turn-1"
+	plugin.processBatch([]*writeQueueEntry{
+		{log: newLog("turn-2", 0.25, 50)},
+		{mcpLog: &logstore.MCPToolLog{ID: "tool-1", LLMRequestID: &llmRequestID, Timestamp: now, ToolName: "search", Status: "success", CreatedAt: now}},
+	})
+
+	result, err := plugin.SearchSessions(context.Background(), logstore.SessionRollupSearchFilters{}, logstore.PaginationOptions{SortBy: "cost"})
+	if err != nil {
+		t.Fatalf("SearchSessions() error = %v", err)
+	}
+	if len(result.Sessions) != 1 {
+		t.Fatalf("expected one session rollup, got %d", len(result.Sessions))
+	}
+	rollup := result.Sessions[0]
+	if rollup.SessionID != sessionID || rollup.TurnCount != 2 || rollup.TotalTokens != 150 || rollup.ToolExecutions != 1 {
+		t.Fatalf("unexpected rollup: %+v", rollup)
+	}
+	if math.Abs(rollup.TotalCost-0.75) > 1e-9 {
+		t.Fatalf("expected total cost 0.75, got %v", rollup.TotalCost)
+	}
+}
@@ -0,0 +1,257 @@
+package logging
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/framework/logstore"
+)
+
+const (
+	// spillFileName is the active spill WAL that overflowing entries are appended to.
+	spillFileName = "logging-spill.wal"
+	// spillReplayFileName holds a rotated WAL while its entries are fed back into
+	// the write queue. A leftover replay file (e.g. after a crash) is replayed on
+	// the next start; inserts are idempotent, so re-replaying entries is harmless.
+	spillReplayFileName = "logging-spill.wal.replay"
+	// spillReplayInterval is how often the replayer checks for spilled entries.
+	spillReplayInterval = time.Second
+)
+
+// spillRecord is one line of the spill WAL. Exactly one field is set.
+type spillRecord struct {
+	Log    *logstore.Log        `json:"log,omitempty"`
+	MCPLog *logstore.MCPToolLog `json:"mcp_log,omitempty"`
+}
+
+// spillWAL is an append-only JSON-lines file holding log entries that did not
+// fit in the write queue under the spill overflow policy.
+type spillWAL struct {
+	mu      sync.Mutex
+	dir     string
+	file    *os.File
+	pending bool // entries were appended since the last rotation
+}
+
+// openSpillWAL opens (creating if needed) the spill WAL in dir. Entries left by a
+// previous process are picked up by the next replay.
+func openSpillWAL(dir string) (*spillWAL, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	w := &spillWAL{dir: dir}
+	if err := w.openActive(); err != nil {
+		return nil, err
+	}
+	if info, err := w.file.Stat(); err == nil && info.Size() > 0 {
+		w.pending = true
+	}
+	return w, nil
+}
+
+func (w *spillWAL) openActive() error {
+	file, err := os.OpenFile(filepath.Join(w.dir, spillFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	return nil
+}
+
+// append writes a queue entry to the WAL.
+func (w *spillWAL) append(entry *writeQueueEntry) error {
+	line, err := sonic.Marshal(spillRecord{Log: entry.log, MCPLog: entry.mcpLog})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return errors.New("spill WAL is closed")
+	}
+	if _, err := w.file.Write(line); err != nil {
+		return err
+	}
+	w.pending = true
+	return nil
+}
+
+// rotate moves the active WAL aside for replay and starts a fresh one. It returns
+// the path of the file to replay, or "" when there is nothing to replay. An
+// existing replay file (left by an interrupted replay) is returned as is.
+func (w *spillWAL) rotate() (string, error) {
+	replayPath := filepath.Join(w.dir, spillReplayFileName)
+	if _, err := os.Stat(replayPath); err == nil {
+		return replayPath, nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.pending || w.file == nil {
+		return "", nil
+	}
+	if err := w.file.Close(); err != nil {
+		return "", err
+	}
+	w.file = nil
+	if err := os.Rename(filepath.Join(w.dir, spillFileName), replayPath); err != nil {
+		return "", errors.Join(err, w.openActive())
+	}
+	w.pending = false
+	return replayPath, w.openActive()
+}
+
+// close flushes and closes the active WAL. Unreplayed entries stay on disk.
+func (w *spillWAL) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := errors.Join(w.file.Sync(), w.file.Close())
+	w.file = nil
+	return err
+}
+
+// pushWriteQueueEntry sends entry to the write queue, applying the configured
+// overflow policy when the queue is full. It reports whether the entry was kept
+// (queued or spilled to disk); the caller accounts for rejected entries.
+func (p *LoggerPlugin) pushWriteQueueEntry(entry *writeQueueEntry) bool {
+	select {
+	case p.writeQueue <- entry:
+		return true
+	default:
+	}
+	switch p.writerConfig.OverflowPolicy {
+	case logstore.WriterOverflowDropOldest:
+		select {
+		case <-p.writeQueue:
+			p.overflowEvicted.Add(1)
+			p.droppedRequests.Add(1)
+		default:
+		}
+		select {
+		case p.writeQueue <- entry:
+			return true
+		default:
+			return false
+		}
+	case logstore.WriterOverflowBlock:
+		p.overflowBlocked.Add(1)
+		timer := time.NewTimer(p.blockTimeout)
+		defer timer.Stop()
+		select {
+		case p.writeQueue <- entry:
+			return true
+		case <-timer.C:
+			return false
+		case <-p.batchCtx.Done():
+			return false
+		}
+	case logstore.WriterOverflowSpill:
+		if err := p.spill.append(entry); err != nil {
+			p.logger.Warn("failed to spill log entry to disk: %v", err)
+			return false
+		}
+		p.overflowSpilled.Add(1)
+		return true
+	default:
+		return false
+	}
+}
+
+// spillReplayer periodically feeds spilled entries back into the write queue
+// once it has drained below half capacity. Replayed entries lose their
+// in-process callbacks and use the plugin-level log callbacks instead.
+func (p *LoggerPlugin) spillReplayer() {
+	defer close(p.spillReplayerDone)
+	ticker := time.NewTicker(spillReplayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if len(p.writeQueue) > cap(p.writeQueue)/2 {
+				continue
+			}
+			replayPath, err := p.spill.rotate()
+			if err != nil {
+				p.logger.Warn("failed to rotate logging spill WAL: %v", err)
+				continue
+			}
+			if replayPath == "" {
+				continue
+			}
+			if err := p.replaySpillFile(replayPath); err != nil {
+				p.logger.Warn("failed to replay logging spill WAL: %v", err)
+			}
+		}
+	}
+}
+
+// replaySpillFile enqueues every record in path, blocking for queue room, and
+// removes the file once all of them are queued. Shutdown leaves the file in
+// place for the next process.
+func (p *LoggerPlugin) replaySpillFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	p.mu.Lock()
+	mcpCallback := p.mcpToolLogCallback
+	p.mu.Unlock()
+	logCallback := p.makePostWriteCallback(nil)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), p.writerConfig.MaxBatchBytes)
+	for scanner.Scan() {
+		var record spillRecord
+		if err := sonic.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// Most likely a torn final line from a crash mid-append.
+			p.logger.Warn("skipping unreadable logging spill record: %v", err)
+			p.droppedRequests.Add(1)
+			continue
+		}
+		entry := &writeQueueEntry{log: record.Log, mcpLog: record.MCPLog}
+		if record.Log != nil {
+			entry.callback = logCallback
+		} else if record.MCPLog != nil {
+			entry.mcpCallback = mcpCallback
+		} else {
+			continue
+		}
+		select {
+		case p.writeQueue <- entry:
+			p.overflowReplayed.Add(1)
+		case <-p.done:
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
+
+// spillRemaining appends entries to the spill WAL during shutdown and returns
+// the ones that could not be written.
+func (p *LoggerPlugin) spillRemaining(entries []*writeQueueEntry) []*writeQueueEntry {
+	for i, entry := range entries {
+		if err := p.spill.append(entry); err != nil {
+			p.logger.Warn("failed to spill log entries during cleanup: %v", err)
+			return entries[i:]
+		}
+		p.overflowSpilled.Add(1)
+	}
+	p.logger.Info("logging plugin cleanup deadline reached; spilled %d entries to disk for replay on restart", len(entries))
+	return nil
+}
@@ -0,0 +1,189 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/framework/logstore"
+)
+
+// newOverflowTestPlugin returns a plugin with a one-slot write queue and no
+// batch writer, so the queue stays full once something is enqueued.
+func newOverflowTestPlugin(t *testing.T, writer logstore.WriterConfig) *LoggerPlugin {
+	t.Helper()
+	writerConfig := writer.WithDefaults()
+	writerConfig.WriteQueueCapacity = 1
+	if err := validateWriterConfig(writerConfig); err != nil {
+		t.Fatalf("validateWriterConfig() error = %v", err)
+	}
+	blockTimeout, _ := time.ParseDuration(writerConfig.BlockTimeout)
+	batchCtx, batchCancel := context.WithCancel(context.Background())
+	t.Cleanup(batchCancel)
+	p := &LoggerPlugin{
+		ctx:          context.Background(),
+		logger:       testLogger{},
+		writerConfig: writerConfig,
+		writeQueue:   make(chan *writeQueueEntry, writerConfig.WriteQueueCapacity),
+		blockTimeout: blockTimeout,
+		batchCtx:     batchCtx,
+		done:         make(chan struct{}),
+	}
+	if writerConfig.OverflowPolicy == logstore.WriterOverflowSpill {
+		spill, err := openSpillWAL(writerConfig.SpillDir)
+		if err != nil {
+			t.Fatalf("openSpillWAL() error = %v", err)
+		}
+		t.Cleanup(func() { _ = spill.close() })
+		p.spill = spill
+	}
+	return p
+}
+
+func queuedLogID(t *testing.T, p *LoggerPlugin) string {
+	t.Helper()
+	select {
+	case entry := <-p.writeQueue:
+		return entry.log.ID
+	default:
+		t.Fatal("expected a queued entry")
+		return ""
+	}
+}
+
+func TestOverflowDropNewest(t *testing.T) {
+	p := newOverflowTestPlugin(t, logstore.WriterConfig{})
+	p.enqueueLogEntry(makeTestLog("first"), nil)
+	p.enqueueLogEntry(makeTestLog("second"), nil)
+
+	if got := queuedLogID(t, p); got != "first" {
+		t.Fatalf("queued entry = %q, want first", got)
+	}
+	if stats := p.GetWriteQueueStats(); stats.Dropped != 1 || stats.OverflowPolicy != logstore.WriterOverflowDropNewest {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	p := newOverflowTestPlugin(t, logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowDropOldest})
+	p.enqueueLogEntry(makeTestLog("first"), nil)
+	p.enqueueLogEntry(makeTestLog("second"), nil)
+
+	if got := queuedLogID(t, p); got != "second" {
+		t.Fatalf("queued entry = %q, want second", got)
+	}
+	if stats := p.GetWriteQueueStats(); stats.Evicted != 1 || stats.Dropped != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestOverflowBlock(t *testing.T) {
+	p := newOverflowTestPlugin(t, logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowBlock, BlockTimeout: "2s"})
+	p.enqueueLogEntry(makeTestLog("first"), nil)
+
+	// Free the slot while the second enqueue is waiting for room.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		<-p.writeQueue
+	}()
+	p.enqueueLogEntry(makeTestLog("second"), nil)
+	if got := queuedLogID(t, p); got != "second" {
+		t.Fatalf("queued entry = %q, want second", got)
+	}
+
+	// With the queue full and nobody draining, the enqueue gives up after the timeout.
+	p.blockTimeout = 20 * time.Millisecond
+	p.enqueueLogEntry(makeTestLog("third"), nil)
+	p.enqueueLogEntry(makeTestLog("fourth"), nil)
+	if stats := p.GetWriteQueueStats(); stats.Blocked != 2 || stats.Dropped != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestOverflowSpillAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	p := newOverflowTestPlugin(t, logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowSpill, SpillDir: dir})
+	p.enqueueLogEntry(makeTestLog("first"), nil)
+	p.enqueueLogEntry(makeTestLog("second"), nil)
+
+	if stats := p.GetWriteQueueStats(); stats.Spilled != 1 || stats.Dropped != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if got := queuedLogID(t, p); got != "first" {
+		t.Fatalf("queued entry = %q, want first", got)
+	}
+
+	replayPath, err := p.spill.rotate()
+	if err != nil || replayPath == "" {
+		t.Fatalf("rotate() = %q, %v", replayPath, err)
+	}
+	if err := p.replaySpillFile(replayPath); err != nil {
+		t.Fatalf("replaySpillFile() error = %v", err)
+	}
+	if got := queuedLogID(t, p); got != "second" {
+		t.Fatalf("replayed entry = %q, want second", got)
+	}
+	if _, err := os.Stat(replayPath); !os.IsNotExist(err) {
+		t.Fatalf("expected replay file to be removed, stat err = %v", err)
+	}
+	if stats := p.GetWriteQueueStats(); stats.Replayed != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// TestSpillReplayedOnStartup verifies entries spilled by a previous process are
+// persisted by the next one.
+func TestSpillReplayedOnStartup(t *testing.T) {
+	dir := t.TempDir()
+	line, err := sonic.Marshal(spillRecord{Log: makeTestLog("from-previous-process")})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, spillFileName), append(line, '\n'), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	rec := &recordingStore{LogStore: newTestStore(t)}
+	plugin, err := Init(context.Background(), &Config{Writer: &logstore.WriterConfig{
+		BatchInterval:  "10ms",
+		OverflowPolicy: logstore.WriterOverflowSpill,
+		SpillDir:       dir,
+	}}, testLogger{}, rec, nil, nil)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	defer plugin.Cleanup()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := rec.uniqueIDs()["from-previous-process"]; ok {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("spilled entry from a previous process was not persisted")
+}
+
+func TestValidateWriterConfigOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		writer  logstore.WriterConfig
+		wantErr bool
+	}{
+		{name: "default", writer: logstore.WriterConfig{}},
+		{name: "block", writer: logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowBlock, BlockTimeout: "250ms"}},
+		{name: "block with invalid timeout", writer: logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowBlock, BlockTimeout: "soon"}, wantErr: true},
+		{name: "spill without dir", writer: logstore.WriterConfig{OverflowPolicy: logstore.WriterOverflowSpill}, wantErr: true},
+		{name: "unknown policy", writer: logstore.WriterConfig{OverflowPolicy: "retry"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateWriterConfig(tt.writer.WithDefaults()); (err != nil) != tt.wantErr {
+				t.Errorf("validateWriterConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
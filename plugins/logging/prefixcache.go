@@ -0,0 +1,252 @@
+package logging
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+)
+
+const (
+	// promptPrefixKey carries the promptPrefix computed in PreLLMHook to PostLLMHook.
+	promptPrefixKey schemas.BifrostContextKey = "logging-prompt-prefix"
+
+	// minCacheablePrefixTokens is the shortest prefix providers cache (1024 tokens for
+	// both OpenAI and Anthropic); shorter prefixes are not cache-eligible.
+	minCacheablePrefixTokens = 1024
+	// prefixReuseWindow is how long a prefix counts as reused after it was last sent,
+	// the longest TTL providers offer for cached prompts.
+	prefixReuseWindow = time.Hour
+	// maxTrackedPrefixes bounds the prefix hashes kept per route and virtual key.
+	maxTrackedPrefixes = 10000
+)
+
+// promptPrefix is the cacheable part of a request: everything but its last input
+// message. Only a hash of it is kept, never the prompt itself.
+type promptPrefix struct {
+	hash        [16]byte
+	prefixBytes int
+	totalBytes  int
+	route       string
+}
+
+// prefixGroupKey identifies the requests prefixes are compared across.
+type prefixGroupKey struct {
+	route        string
+	virtualKeyID string
+}
+
+// prefixGroup is the reuse tracked for one route and virtual key.
+type prefixGroup struct {
+	lastSeen map[[16]byte]time.Time
+	stats    PrefixCacheStats
+}
+
+// PrefixCacheStats reports how often requests on one route (provider/model) and
+// virtual key repeat a cache-eligible prompt prefix sent within the last hour.
+// Token counts and spend are estimates: the prefix's share of the prompt is taken
+// from its share of the serialized input.
+type PrefixCacheStats struct {
+	Route        string `json:"route"`
+	VirtualKeyID string `json:"virtual_key_id,omitempty"`
+	// Requests counts successful chat and responses requests with usage.
+	Requests int64 `json:"requests"`
+	// PrefixRequests had a prefix long enough for providers to cache; ReusedRequests
+	// repeated one of them.
+	PrefixRequests int64   `json:"prefix_requests"`
+	ReusedRequests int64   `json:"reused_requests"`
+	ReuseRate      float64 `json:"reuse_rate"`
+	UniquePrefixes int     `json:"unique_prefixes"`
+	// EstimatedReusedPrefixTokens were sent in a prefix that had been sent before, and
+	// EstimatedCacheEligibleSpend is what they cost at the uncached input price.
+	EstimatedPrefixTokens       int64   `json:"estimated_prefix_tokens"`
+	EstimatedReusedPrefixTokens int64   `json:"estimated_reused_prefix_tokens"`
+	EstimatedCacheEligibleSpend float64 `json:"estimated_cache_eligible_spend"`
+}
+
+// prefixCacheTracker aggregates PrefixCacheStats. The zero value is ready to use.
+type prefixCacheTracker struct {
+	mu     sync.Mutex
+	groups map[prefixGroupKey]*prefixGroup
+	now    func() time.Time
+}
+
+// extractPromptPrefix returns the prefix of a chat or responses request, or nil for
+// other requests and requests with no prefix. Tools and instructions are part of
+// the prefix, as providers cache them with it.
+func extractPromptPrefix(req *schemas.BifrostRequest) *promptPrefix {
+	var prefix, last any
+	switch {
+	case req.ChatRequest != nil && len(req.ChatRequest.Input) > 0:
+		input := req.ChatRequest.Input
+		var tools []schemas.ChatTool
+		if req.ChatRequest.Params != nil {
+			tools = req.ChatRequest.Params.Tools
+		}
+		if len(input) == 1 && len(tools) == 0 {
+			return nil
+		}
+		prefix = struct {
+			Tools    []schemas.ChatTool
+			Messages []schemas.ChatMessage
+		}{tools, input[:len(input)-1]}
+		last = input[len(input)-1]
+	case req.ResponsesRequest != nil && len(req.ResponsesRequest.Input) > 0:
+		input := req.ResponsesRequest.Input
+		var instructions *string
+		var tools []schemas.ResponsesTool
+		if req.ResponsesRequest.Params != nil {
+			instructions = req.ResponsesRequest.Params.Instructions
+			tools = req.ResponsesRequest.Params.Tools
+		}
+		if len(input) == 1 && len(tools) == 0 && instructions == nil {
+			return nil
+		}
+		prefix = struct {
+			Instructions *string
+			Tools        []schemas.ResponsesTool
+			Messages     []schemas.ResponsesMessage
+		}{instructions, tools, input[:len(input)-1]}
+		last = input[len(input)-1]
+	default:
+		return nil
+	}
+	prefixJSON, err := sonic.Marshal(prefix)
+	if err != nil {
+		return nil
+	}
+	lastJSON, err := sonic.Marshal(last)
+	if err != nil {
+		return nil
+	}
+	provider, model, _ := req.GetRequestFields()
+	sum := sha256.Sum256(prefixJSON)
+	p := &promptPrefix{
+		prefixBytes: len(prefixJSON),
+		totalBytes:  len(prefixJSON) + len(lastJSON),
+		route:       string(provider) + "/" + model,
+	}
+	copy(p.hash[:], sum[:])
+	return p
+}
+
+// record counts one successful request. promptTokens is the request's input usage
+// and spend prices a number of input tokens at the model's uncached rate.
+func (t *prefixCacheTracker) record(virtualKeyID string, prefix *promptPrefix, promptTokens int, spend func(tokens int) float64) {
+	if prefix == nil || promptTokens <= 0 || prefix.totalBytes == 0 {
+		return
+	}
+	prefixTokens := promptTokens * prefix.prefixBytes / prefix.totalBytes
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.groups == nil {
+		t.groups = make(map[prefixGroupKey]*prefixGroup)
+	}
+	key := prefixGroupKey{route: prefix.route, virtualKeyID: virtualKeyID}
+	group, ok := t.groups[key]
+	if !ok {
+		group = &prefixGroup{
+			lastSeen: make(map[[16]byte]time.Time),
+			stats:    PrefixCacheStats{Route: prefix.route, VirtualKeyID: virtualKeyID},
+		}
+		t.groups[key] = group
+	}
+	group.stats.Requests++
+	if prefixTokens < minCacheablePrefixTokens {
+		return
+	}
+	now := time.Now()
+	if t.now != nil {
+		now = t.now()
+	}
+	group.stats.PrefixRequests++
+	group.stats.EstimatedPrefixTokens += int64(prefixTokens)
+	if seen, ok := group.lastSeen[prefix.hash]; ok && now.Sub(seen) < prefixReuseWindow {
+		group.stats.ReusedRequests++
+		group.stats.EstimatedReusedPrefixTokens += int64(prefixTokens)
+		if spend != nil {
+			group.stats.EstimatedCacheEligibleSpend += spend(prefixTokens)
+		}
+	}
+	if _, ok := group.lastSeen[prefix.hash]; !ok && len(group.lastSeen) >= maxTrackedPrefixes {
+		for hash, seen := range group.lastSeen {
+			if now.Sub(seen) >= prefixReuseWindow {
+				delete(group.lastSeen, hash)
+			}
+		}
+		if len(group.lastSeen) >= maxTrackedPrefixes {
+			return
+		}
+	}
+	group.lastSeen[prefix.hash] = now
+}
+
+// snapshot returns the stats of every group, highest estimated spend first.
+func (t *prefixCacheTracker) snapshot() []PrefixCacheStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]PrefixCacheStats, 0, len(t.groups))
+	for _, group := range t.groups {
+		s := group.stats
+		s.UniquePrefixes = len(group.lastSeen)
+		if s.PrefixRequests > 0 {
+			s.ReuseRate = float64(s.ReusedRequests) / float64(s.PrefixRequests)
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b PrefixCacheStats) int {
+		if c := cmp.Compare(b.EstimatedCacheEligibleSpend, a.EstimatedCacheEligibleSpend); c != 0 {
+			return c
+		}
+		return cmp.Or(cmp.Compare(a.Route, b.Route), cmp.Compare(a.VirtualKeyID, b.VirtualKeyID))
+	})
+	return stats
+}
+
+// recordPromptPrefix attributes a final successful response to the prefix PreLLMHook
+// computed for its request.
+func (p *LoggerPlugin) recordPromptPrefix(ctx *schemas.BifrostContext, result *schemas.BifrostResponse) {
+	prefix, _ := ctx.Value(promptPrefixKey).(*promptPrefix)
+	if prefix == nil || result == nil {
+		return
+	}
+	var usage *schemas.BifrostLLMUsage
+	switch {
+	case result.ChatResponse != nil:
+		usage = result.ChatResponse.Usage
+	case result.ResponsesResponse != nil && result.ResponsesResponse.Usage != nil:
+		usage = result.ResponsesResponse.Usage.ToBifrostLLMUsage()
+	case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil && result.ResponsesStreamResponse.Response.Usage != nil:
+		usage = result.ResponsesStreamResponse.Response.Usage.ToBifrostLLMUsage()
+	}
+	if usage == nil {
+		return
+	}
+	ctx.ClearValue(promptPrefixKey)
+
+	requestType, provider, _, model := bifrost.GetResponseFields(result, nil)
+	var spend func(tokens int) float64
+	if p.pricingManager != nil {
+		scopes := modelcatalog.PricingLookupScopesFromContext(ctx, string(provider))
+		spend = func(tokens int) float64 {
+			return p.pricingManager.CalculateCostForUsage(&schemas.BifrostLLMUsage{PromptTokens: tokens, TotalTokens: tokens}, provider, model, requestType, scopes)
+		}
+	}
+	virtualKeyID, _ := ctx.Value(schemas.BifrostContextKeyGovernanceVirtualKeyID).(string)
+	p.prefixCache.record(virtualKeyID, prefix, usage.PromptTokens, spend)
+}
+
+// GetPrefixCacheStats returns prompt prefix reuse per route and virtual key since
+// the plugin started.
+func (p *LoggerPlugin) GetPrefixCacheStats() []PrefixCacheStats {
+	return p.prefixCache.snapshot()
+}
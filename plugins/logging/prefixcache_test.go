@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func prefixChatRequest(system, question string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.Anthropic,
+			Model:    "claude-sonnet-4",
+			Input: []schemas.ChatMessage{
+				{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(system)}},
+				{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(question)}},
+			},
+		},
+	}
+}
+
+func TestExtractPromptPrefix(t *testing.T) {
+	system := strings.Repeat("You are a support agent. ", 200)
+	a := extractPromptPrefix(prefixChatRequest(system, "Where is my order?"))
+	b := extractPromptPrefix(prefixChatRequest(system, "How do I reset my password?"))
+	c := extractPromptPrefix(prefixChatRequest(system+"Be brief.", "Where is my order?"))
+	if a == nil || b == nil || c == nil {
+		t.Fatal("expected a prefix for requests with a system prompt")
+	}
+	if a.hash != b.hash {
+		t.Fatal("requests sharing a system prompt must share a prefix hash")
+	}
+	if a.hash == c.hash {
+		t.Fatal("a changed system prompt must change the prefix hash")
+	}
+	if a.route != "anthropic/claude-sonnet-4" || a.prefixBytes >= a.totalBytes {
+		t.Fatalf("unexpected prefix %+v", a)
+	}
+
+	single := &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{
+		Input: []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}}},
+	}}
+	if extractPromptPrefix(single) != nil {
+		t.Fatal("a lone user message has no prefix")
+	}
+}
+
+func TestPrefixCacheTrackerCountsReuse(t *testing.T) {
+	clock := time.Unix(0, 0)
+	tracker := &prefixCacheTracker{now: func() time.Time { return clock }}
+	system := strings.Repeat("You are a support agent. ", 200)
+	prefix := extractPromptPrefix(prefixChatRequest(system, "Where is my order?"))
+	spend := func(tokens int) float64 { return float64(tokens) / 1e6 }
+
+	tracker.record("vk-1", prefix, 2000, spend)
+	tracker.record("vk-1", prefix, 2000, spend)
+	tracker.record("vk-2", prefix, 2000, spend) // other virtual keys are tracked separately
+	tracker.record("vk-1", prefix, 100, spend)  // too short to be cached
+	clock = clock.Add(2 * prefixReuseWindow)
+	tracker.record("vk-1", prefix, 2000, spend) // the provider cache has expired
+
+	stats := tracker.snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("expected one entry per virtual key, got %+v", stats)
+	}
+	vk1 := stats[0]
+	if vk1.VirtualKeyID != "vk-1" || vk1.Requests != 4 || vk1.PrefixRequests != 3 || vk1.ReusedRequests != 1 || vk1.UniquePrefixes != 1 {
+		t.Fatalf("unexpected vk-1 stats %+v", vk1)
+	}
+	if vk1.EstimatedReusedPrefixTokens == 0 || vk1.EstimatedCacheEligibleSpend != float64(vk1.EstimatedReusedPrefixTokens)/1e6 {
+		t.Fatalf("expected the reused prefix tokens to be priced, got %+v", vk1)
+	}
+	if vk2 := stats[1]; vk2.ReusedRequests != 0 || vk2.ReuseRate != 0 {
+		t.Fatalf("unexpected vk-2 stats %+v", vk2)
+	}
+}
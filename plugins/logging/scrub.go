@@ -0,0 +1,342 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/framework/logstore"
+)
+
+// scrubber applies the configured scrub rules to log payloads before they are
+// queued for persistence.
+type scrubber struct {
+	rules []compiledScrubRule
+}
+
+type compiledScrubRule struct {
+	pattern     *regexp.Regexp
+	paths       [][]jsonPathStep
+	replacement string
+	fields      []string // nil = every field
+}
+
+// newScrubber compiles rules. It returns nil when there are none.
+func newScrubber(rules []logstore.ScrubRule) (*scrubber, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	s := &scrubber{rules: make([]compiledScrubRule, 0, len(rules))}
+	for i, rule := range rules {
+		name := rule.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		if rule.Pattern == "" && len(rule.JSONPaths) == 0 {
+			return nil, fmt.Errorf("scrub rule %s needs a pattern or json_paths", name)
+		}
+		compiled := compiledScrubRule{replacement: rule.Replacement}
+		if compiled.replacement == "" {
+			compiled.replacement = logstore.DefaultScrubReplacement
+		}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("scrub rule %s has an invalid pattern: %w", name, err)
+			}
+			compiled.pattern = pattern
+		}
+		for _, path := range rule.JSONPaths {
+			steps, err := parseJSONPath(path)
+			if err != nil {
+				return nil, fmt.Errorf("scrub rule %s has an invalid json path %q: %w", name, path, err)
+			}
+			compiled.paths = append(compiled.paths, steps)
+		}
+		for _, field := range rule.Fields {
+			switch field {
+			case logstore.ScrubFieldInputHistory, logstore.ScrubFieldRawRequest, logstore.ScrubFieldRawResponse:
+				compiled.fields = append(compiled.fields, field)
+			default:
+				return nil, fmt.Errorf("scrub rule %s has unknown field %q", name, field)
+			}
+		}
+		s.rules = append(s.rules, compiled)
+	}
+	return s, nil
+}
+
+// scrubLog scrubs the input history and raw payloads of entry in place. The
+// parsed input history is replaced, never mutated, since it may share memory
+// with the request.
+func (s *scrubber) scrubLog(entry *logstore.Log) {
+	if entry == nil {
+		return
+	}
+	if entry.RawRequest != "" {
+		entry.RawRequest, _ = s.scrub(logstore.ScrubFieldRawRequest, entry.RawRequest)
+	}
+	if entry.RawResponse != "" {
+		entry.RawResponse, _ = s.scrub(logstore.ScrubFieldRawResponse, entry.RawResponse)
+	}
+	if len(entry.InputHistoryParsed) > 0 {
+		entry.InputHistoryParsed = scrubHistory(s, entry.InputHistoryParsed)
+	}
+	if len(entry.ResponsesInputHistoryParsed) > 0 {
+		entry.ResponsesInputHistoryParsed = scrubHistory(s, entry.ResponsesInputHistoryParsed)
+	}
+}
+
+// scrubHistory scrubs the JSON form of history. A history that no longer
+// decodes after scrubbing is dropped rather than persisted unscrubbed.
+func scrubHistory[T any](s *scrubber, history []T) []T {
+	data, err := sonic.Marshal(history)
+	if err != nil {
+		return nil
+	}
+	scrubbed, changed := s.scrub(logstore.ScrubFieldInputHistory, string(data))
+	if !changed {
+		return history
+	}
+	var out []T
+	if err := sonic.UnmarshalString(scrubbed, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// scrub applies the rules for field to text and reports whether it changed.
+// JSON payloads are scrubbed value by value; anything else gets the patterns
+// applied to the raw text.
+func (s *scrubber) scrub(field, text string) (string, bool) {
+	if doc, ok := decodeJSONPayload(text); ok {
+		changed := false
+		for i := range s.rules {
+			rule := &s.rules[i]
+			if rule.appliesTo(field) {
+				doc = rule.scrubJSON(doc, &changed)
+			}
+		}
+		if !changed {
+			return text, false
+		}
+		out, err := encodeJSONPayload(doc)
+		if err != nil {
+			// Never fall back to the unscrubbed payload.
+			return logstore.DefaultScrubReplacement, true
+		}
+		return out, true
+	}
+	changed := false
+	for i := range s.rules {
+		rule := &s.rules[i]
+		if rule.pattern != nil && rule.appliesTo(field) {
+			text = rule.replaceMatches(text, &changed)
+		}
+	}
+	return text, changed
+}
+
+func (r *compiledScrubRule) appliesTo(field string) bool {
+	return r.fields == nil || slices.Contains(r.fields, field)
+}
+
+func (r *compiledScrubRule) scrubJSON(doc any, changed *bool) any {
+	if len(r.paths) == 0 {
+		return r.scrubStrings(doc, changed)
+	}
+	for _, path := range r.paths {
+		doc = walkJSONPath(doc, path, func(value any) any {
+			if r.pattern != nil {
+				return r.scrubStrings(value, changed)
+			}
+			*changed = true
+			return r.replacement
+		})
+	}
+	return doc
+}
+
+// scrubStrings replaces pattern matches in every string under value.
+func (r *compiledScrubRule) scrubStrings(value any, changed *bool) any {
+	switch v := value.(type) {
+	case string:
+		return r.replaceMatches(v, changed)
+	case map[string]any:
+		for key, child := range v {
+			v[key] = r.scrubStrings(child, changed)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.scrubStrings(child, changed)
+		}
+	}
+	return value
+}
+
+func (r *compiledScrubRule) replaceMatches(text string, changed *bool) string {
+	if !r.pattern.MatchString(text) {
+		return text
+	}
+	*changed = true
+	return r.pattern.ReplaceAllLiteralString(text, r.replacement)
+}
+
+// decodeJSONPayload decodes text when it is a JSON object or array, keeping
+// numbers as written.
+func decodeJSONPayload(text string) (any, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return nil, false
+	}
+	return doc, true
+}
+
+func encodeJSONPayload(doc any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// jsonPathStep is one step of the JSONPath subset scrub rules support.
+type jsonPathStep struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool // ..key or ..*
+}
+
+// parseJSONPath parses $ followed by .key, ['key'], [n], [*], .* and ..key steps.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(path), "$")
+	if !ok {
+		return nil, fmt.Errorf("must start with $")
+	}
+	var steps []jsonPathStep
+	for rest != "" {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.recursive = true
+			rest = rest[1:]
+			fallthrough
+		case rest[0] == '.':
+			rest = rest[1:]
+			if rest != "" && rest[0] == '[' {
+				if !step.recursive {
+					return nil, fmt.Errorf("unexpected '[' after '.'")
+				}
+				break
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, fmt.Errorf("empty key")
+			}
+			if name == "*" {
+				step.wildcard = true
+			} else {
+				step.key = name
+			}
+			steps = append(steps, step)
+			continue
+		}
+		if rest == "" || rest[0] != '[' {
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated '['")
+		}
+		inner := rest[1:end]
+		rest = rest[end+1:]
+		switch {
+		case inner == "*":
+			step.wildcard = true
+		case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+			step.key = inner[1 : len(inner)-1]
+		default:
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid subscript [%s]", inner)
+			}
+			step.index, step.isIndex = index, true
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// walkJSONPath replaces every value steps select in node with fn(value) and
+// returns the updated node.
+func walkJSONPath(node any, steps []jsonPathStep, fn func(any) any) any {
+	if len(steps) == 0 {
+		return fn(node)
+	}
+	step, rest := steps[0], steps[1:]
+	if step.recursive {
+		here := step
+		here.recursive = false
+		node = walkJSONPath(node, append([]jsonPathStep{here}, rest...), fn)
+		switch v := node.(type) {
+		case map[string]any:
+			for key, child := range v {
+				v[key] = walkJSONPath(child, steps, fn)
+			}
+		case []any:
+			for i, child := range v {
+				v[i] = walkJSONPath(child, steps, fn)
+			}
+		}
+		return node
+	}
+	switch v := node.(type) {
+	case map[string]any:
+		switch {
+		case step.wildcard:
+			for key, child := range v {
+				v[key] = walkJSONPath(child, rest, fn)
+			}
+		case !step.isIndex:
+			if child, ok := v[step.key]; ok {
+				v[step.key] = walkJSONPath(child, rest, fn)
+			}
+		}
+	case []any:
+		switch {
+		case step.wildcard:
+			for i, child := range v {
+				v[i] = walkJSONPath(child, rest, fn)
+			}
+		case step.isIndex:
+			index := step.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index >= 0 && index < len(v) {
+				v[index] = walkJSONPath(v[index], rest, fn)
+			}
+		}
+	}
+	return node
+}
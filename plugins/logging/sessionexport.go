@@ -0,0 +1,158 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/logstore"
+)
+
+// maxSessionConversationLogs caps how many logs of a session are read to rebuild its
+// conversation; longer sessions are exported up to the cap and marked truncated.
+const maxSessionConversationLogs = 1000
+
+// SessionConversation is the conversation of a session rebuilt from its chat and
+// responses logs, oldest message first, with the scrub rules applied.
+type SessionConversation struct {
+	SessionID string `json:"session_id"`
+	// Provider and Model are the ones that served the latest turn.
+	Provider schemas.ModelProvider `json:"provider"`
+	Model    string                `json:"model"`
+	Messages []schemas.ChatMessage `json:"messages"`
+	// Tools are the tools offered on the latest turn that offered any.
+	Tools []schemas.ChatTool `json:"tools,omitempty"`
+	// LogIDs are the logs the conversation was rebuilt from.
+	LogIDs []string `json:"log_ids"`
+	// HiddenTurns counts logs left out because content logging was off for them.
+	HiddenTurns int  `json:"hidden_turns,omitempty"`
+	Truncated   bool `json:"truncated,omitempty"`
+}
+
+// GetSessionConversation rebuilds the conversation of a parent_request_id session.
+//
+// Chat clients resend the whole history on every turn, so a turn whose input starts
+// with the conversation's first message only contributes the messages past what the
+// conversation already holds, plus its output. Any other input, such as a responses
+// turn chained with previous_response_id, is appended whole. Inline attachments are
+// replaced by a link to the log that carried them, see linkAttachments.
+func (p *LoggerPlugin) GetSessionConversation(ctx context.Context, sessionID string) (*SessionConversation, error) {
+	conversation := &SessionConversation{SessionID: sessionID, Messages: []schemas.ChatMessage{}}
+	pagination := logstore.PaginationOptions{Limit: 50, SortBy: "timestamp", Order: "asc"}
+	for {
+		page, err := p.store.GetSessionLogs(ctx, sessionID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page.Logs {
+			if len(conversation.LogIDs)+conversation.HiddenTurns == maxSessionConversationLogs {
+				conversation.Truncated = true
+				break
+			}
+			// The session page carries no payloads, so every log is read in full.
+			log, err := p.store.FindByID(ctx, summary.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read log %s: %w", summary.ID, err)
+			}
+			if log.ContentHidden {
+				conversation.HiddenTurns++
+				continue
+			}
+			conversation.addTurn(log)
+		}
+		if !page.HasMore || conversation.Truncated || page.ReturnedCount == 0 {
+			break
+		}
+		pagination.Offset += page.ReturnedCount
+	}
+	if len(conversation.LogIDs) == 0 && conversation.HiddenTurns == 0 {
+		return nil, logstore.ErrNotFound
+	}
+
+	if p.scrubber != nil && len(conversation.Messages) > 0 {
+		scrubbed := scrubHistory(p.scrubber, conversation.Messages)
+		if scrubbed == nil {
+			return nil, fmt.Errorf("failed to apply scrub rules to session %s", sessionID)
+		}
+		conversation.Messages = scrubbed
+	}
+	return conversation, nil
+}
+
+// addTurn appends the messages log adds to the conversation. Logs without chat or
+// responses content, such as embeddings, are skipped.
+func (c *SessionConversation) addTurn(log *logstore.Log) {
+	input, output := log.InputHistoryParsed, log.OutputMessageParsed
+	if len(input) == 0 && output == nil {
+		if len(log.ResponsesInputHistoryParsed) == 0 && len(log.ResponsesOutputParsed) == 0 {
+			return
+		}
+		input = schemas.ToChatMessages(log.ResponsesInputHistoryParsed)
+		if outputs := schemas.ToChatMessages(log.ResponsesOutputParsed); len(outputs) > 0 {
+			input = append(input, outputs[:len(outputs)-1]...)
+			output = &outputs[len(outputs)-1]
+		}
+	}
+
+	if len(c.Messages) > 0 && len(input) > 0 && sameMessage(c.Messages[0], input[0]) {
+		input = input[min(len(c.Messages), len(input)):]
+	}
+	for _, message := range input {
+		c.Messages = append(c.Messages, linkAttachments(message, log.ID))
+	}
+	if output != nil {
+		c.Messages = append(c.Messages, linkAttachments(*output, log.ID))
+	}
+
+	c.LogIDs = append(c.LogIDs, log.ID)
+	c.Provider = schemas.ModelProvider(log.Provider)
+	c.Model = log.Model
+	if len(log.ToolsParsed) > 0 {
+		c.Tools = log.ToolsParsed
+	}
+}
+
+// sameMessage reports whether a and b serialize identically.
+func sameMessage(a, b schemas.ChatMessage) bool {
+	aJSON, errA := sonic.Marshal(a)
+	bJSON, errB := sonic.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
+
+// linkAttachments returns message with inline attachments (data URL images, audio and
+// file data) replaced by a text block linking to the log they can be read from.
+// Attachments given by URL or file id are kept as they are.
+func linkAttachments(message schemas.ChatMessage, logID string) schemas.ChatMessage {
+	if message.Content == nil || len(message.Content.ContentBlocks) == 0 {
+		return message
+	}
+	blocks := make([]schemas.ChatContentBlock, 0, len(message.Content.ContentBlocks))
+	for _, block := range message.Content.ContentBlocks {
+		kind := ""
+		switch {
+		case block.ImageURLStruct != nil && strings.HasPrefix(block.ImageURLStruct.URL, "data:"):
+			kind = "image"
+		case block.InputAudio != nil && block.InputAudio.Data != "":
+			kind = "audio"
+		case block.File != nil && block.File.FileData != nil:
+			kind = "file"
+			if block.File.Filename != nil {
+				kind = "file " + *block.File.Filename
+			}
+		}
+		if kind == "" {
+			blocks = append(blocks, block)
+			continue
+		}
+		blocks = append(blocks, schemas.ChatContentBlock{
+			Type: schemas.ChatContentBlockTypeText,
+			Text: schemas.Ptr(fmt.Sprintf("[%s attachment: /api/logs/%s]", kind, logID)),
+		})
+	}
+	content := *message.Content
+	content.ContentBlocks = blocks
+	message.Content = &content
+	return message
+}
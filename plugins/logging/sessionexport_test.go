@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chatText(role schemas.ChatMessageRole, text string) schemas.ChatMessage {
+	return schemas.ChatMessage{Role: role, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}}
+}
+
+// TestGetSessionConversation verifies turns are stitched into one conversation, with
+// scrub rules, attachment links and hidden turns applied.
+func TestGetSessionConversation(t *testing.T) {
+	store := newTestStore(t)
+	p := &LoggerPlugin{store: store, scrubber: testScrubber(t, logstore.ScrubRule{Pattern: `sk-[a-z0-9]+`})}
+	ctx := context.Background()
+	session := "session-1"
+	start := time.Now().UTC()
+
+	system := chatText(schemas.ChatMessageRoleSystem, "You are a support bot.")
+	question := schemas.ChatMessage{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+		{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("My key sk-abc123 fails, see the screenshot")},
+		{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: "data:image/png;base64,iVBORw0KGgo="}},
+		{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: "https://example.com/diagram.png"}},
+	}}}
+	toolCall := schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant, ChatAssistantMessage: &schemas.ChatAssistantMessage{
+		ToolCalls: []schemas.ChatAssistantMessageToolCall{{ID: schemas.Ptr("call-1"), Function: schemas.ChatAssistantMessageToolCallFunction{Name: schemas.Ptr("lookup_key"), Arguments: `{"key":"sk-abc123"}`}}},
+	}}
+	toolResult := schemas.ChatMessage{Role: schemas.ChatMessageRoleTool, ChatToolMessage: &schemas.ChatToolMessage{ToolCallID: schemas.Ptr("call-1")}, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("revoked")}}
+	answer := chatText(schemas.ChatMessageRoleAssistant, "That key was revoked.")
+
+	logs := []*logstore.Log{
+		{ID: "turn-1", InputHistoryParsed: []schemas.ChatMessage{system, question}, OutputMessageParsed: &toolCall, ToolsParsed: []schemas.ChatTool{{Type: schemas.ChatToolTypeFunction, Function: &schemas.ChatToolFunction{Name: "lookup_key"}}}},
+		{ID: "turn-2", InputHistoryParsed: []schemas.ChatMessage{system, question, toolCall, toolResult}, OutputMessageParsed: &answer},
+		{ID: "turn-3", InputHistoryParsed: []schemas.ChatMessage{chatText(schemas.ChatMessageRoleUser, "private")}, ContentHidden: true},
+	}
+	for i, log := range logs {
+		log.ParentRequestID = &session
+		log.Timestamp = start.Add(time.Duration(i) * time.Second)
+		log.Object = "chat.completion"
+		log.Provider = string(schemas.OpenAI)
+		log.Model = "gpt-4o"
+		log.Status = "success"
+		require.NoError(t, store.Create(ctx, log))
+	}
+
+	conversation, err := p.GetSessionConversation(ctx, session)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"turn-1", "turn-2"}, conversation.LogIDs)
+	assert.Equal(t, 1, conversation.HiddenTurns)
+	assert.Equal(t, schemas.OpenAI, conversation.Provider)
+	require.Len(t, conversation.Tools, 1)
+
+	messages := conversation.Messages
+	require.Len(t, messages, 5, "the resent history must not be duplicated")
+	assert.Equal(t, []schemas.ChatMessageRole{"system", "user", "assistant", "tool", "assistant"},
+		[]schemas.ChatMessageRole{messages[0].Role, messages[1].Role, messages[2].Role, messages[3].Role, messages[4].Role})
+
+	blocks := messages[1].Content.ContentBlocks
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "My key [REDACTED] fails, see the screenshot", *blocks[0].Text)
+	assert.Equal(t, "[image attachment: /api/logs/turn-1]", *blocks[1].Text)
+	assert.Equal(t, "https://example.com/diagram.png", blocks[2].ImageURLStruct.URL)
+	assert.Equal(t, `{"key":"[REDACTED]"}`, messages[2].ToolCalls[0].Function.Arguments)
+	assert.Equal(t, "That key was revoked.", *messages[4].Content.ContentStr)
+
+	_, err = p.GetSessionConversation(ctx, "missing")
+	assert.ErrorIs(t, err, logstore.ErrNotFound)
+}
+
+// TestSessionConversationAppendsChainedTurns verifies a turn that does not resend the
+// history, such as a responses turn chained with previous_response_id, is appended whole.
+func TestSessionConversationAppendsChainedTurns(t *testing.T) {
+	first, second := chatText(schemas.ChatMessageRoleAssistant, "Hi!"), chatText(schemas.ChatMessageRoleAssistant, "Paris.")
+	conversation := &SessionConversation{}
+	conversation.addTurn(&logstore.Log{ID: "a", InputHistoryParsed: []schemas.ChatMessage{chatText(schemas.ChatMessageRoleUser, "Hello")}, OutputMessageParsed: &first})
+	conversation.addTurn(&logstore.Log{ID: "b", InputHistoryParsed: []schemas.ChatMessage{chatText(schemas.ChatMessageRoleUser, "Capital of France?")}, OutputMessageParsed: &second})
+	conversation.addTurn(&logstore.Log{ID: "c", EmbeddingOutputParsed: []schemas.EmbeddingData{{}}})
+
+	require.Len(t, conversation.Messages, 4)
+	assert.Equal(t, "Capital of France?", *conversation.Messages[2].Content.ContentStr)
+	assert.Equal(t, []string{"a", "b"}, conversation.LogIDs)
+}
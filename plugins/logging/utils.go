@@ -34,6 +34,12 @@ type LogManager interface {
 	// GetSessionSummary returns aggregate totals for a single parent_request_id session.
 	GetSessionSummary(ctx context.Context, sessionID string) (*logstore.SessionSummaryResult, error)
 
+	// GetSessionConversation rebuilds a session's conversation from its logs, with scrub rules applied.
+	GetSessionConversation(ctx context.Context, sessionID string) (*SessionConversation, error)
+
+	// SearchSessions returns one page of per-session token, cost, turn and tool execution rollups.
+	SearchSessions(ctx context.Context, filters *logstore.SessionRollupSearchFilters, pagination *logstore.PaginationOptions) (*logstore.SessionRollupSearchResult, error)
+
 	// GetStats calculates statistics for logs matching the given filters
 	GetStats(ctx context.Context, filters *logstore.SearchFilters) (*logstore.SearchStats, error)
 
@@ -70,12 +76,18 @@ type LogManager interface {
 	// GetModelRankings returns models ranked by usage with trend comparison
 	GetModelRankings(ctx context.Context, filters *logstore.SearchFilters) (*logstore.ModelRankingResult, error)
 
+	// GetModelUsageProfiles returns per-model prompt-size and stop-reason profiles for the given filters
+	GetModelUsageProfiles(ctx context.Context, filters *logstore.SearchFilters, shortPromptTokens int) (*logstore.ModelUsageProfileResult, error)
+
 	// GetDimensionRankings returns entities ranked by usage grouped by the given dimension
 	GetDimensionRankings(ctx context.Context, filters *logstore.SearchFilters, dimension logstore.RankingDimension) (*logstore.DimensionRankingResult, error)
 
 	// Get the number of dropped requests
 	GetDroppedRequests(ctx context.Context) int64
 
+	// GetPrefixCacheStats returns prompt prefix reuse per route and virtual key
+	GetPrefixCacheStats() []PrefixCacheStats
+
 	// GetAvailableModels returns all unique models from logs
 	GetAvailableModels(ctx context.Context, limit int, query string) ([]string, error)
 
@@ -205,6 +217,20 @@ func (p *PluginLogManager) GetSessionSummary(ctx context.Context, sessionID stri
 	return p.plugin.GetSessionSummary(ctx, sessionID)
 }
 
+func (p *PluginLogManager) GetSessionConversation(ctx context.Context, sessionID string) (*SessionConversation, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, fmt.Errorf("sessionID cannot be empty")
+	}
+	return p.plugin.GetSessionConversation(ctx, sessionID)
+}
+
+func (p *PluginLogManager) SearchSessions(ctx context.Context, filters *logstore.SessionRollupSearchFilters, pagination *logstore.PaginationOptions) (*logstore.SessionRollupSearchResult, error) {
+	if filters == nil || pagination == nil {
+		return nil, fmt.Errorf("filters and pagination cannot be nil")
+	}
+	return p.plugin.SearchSessions(ctx, *filters, *pagination)
+}
+
 func (p *PluginLogManager) GetStats(ctx context.Context, filters *logstore.SearchFilters) (*logstore.SearchStats, error) {
 	if filters == nil {
 		return nil, fmt.Errorf("filters cannot be nil")
@@ -289,6 +315,13 @@ func (p *PluginLogManager) GetModelRankings(ctx context.Context, filters *logsto
 	return p.plugin.GetModelRankings(ctx, *filters)
 }
 
+func (p *PluginLogManager) GetModelUsageProfiles(ctx context.Context, filters *logstore.SearchFilters, shortPromptTokens int) (*logstore.ModelUsageProfileResult, error) {
+	if filters == nil {
+		return nil, fmt.Errorf("filters cannot be nil")
+	}
+	return p.plugin.GetModelUsageProfiles(ctx, *filters, shortPromptTokens)
+}
+
 func (p *PluginLogManager) GetDimensionRankings(ctx context.Context, filters *logstore.SearchFilters, dimension logstore.RankingDimension) (*logstore.DimensionRankingResult, error) {
 	if filters == nil {
 		return nil, fmt.Errorf("filters cannot be nil")
@@ -296,6 +329,10 @@ func (p *PluginLogManager) GetDimensionRankings(ctx context.Context, filters *lo
 	return p.plugin.GetDimensionRankings(ctx, *filters, dimension)
 }
 
+func (p *PluginLogManager) GetPrefixCacheStats() []PrefixCacheStats {
+	return p.plugin.GetPrefixCacheStats()
+}
+
 func (p *PluginLogManager) GetDroppedRequests(ctx context.Context) int64 {
 	return p.plugin.droppedRequests.Load()
 }
@@ -763,6 +800,8 @@ func convertToProcessedStreamResponse(result *schemas.StreamAccumulatorResult, r
 		PassthroughOutput:     result.PassthroughOutput,
 		FinishReason:          result.FinishReason,
 		RawResponse:           result.RawResponse,
+		SystemFingerprint:     result.SystemFingerprint,
+		ModelRevision:         result.ModelRevision,
 	}
 
 	// Handle tool calls if present
@@ -815,6 +854,43 @@ func mergeRealtimeMetadata(metadata map[string]interface{}, ctx *schemas.Bifrost
 	return metadata
 }
 
+// mergeRequestFlagMetadata records the result of every request flag evaluated for the
+// request as "flag.<name>": "on" or "off". String values keep them filterable with
+// metadata filters on every log store dialect.
+func mergeRequestFlagMetadata(metadata map[string]interface{}, ctx *schemas.BifrostContext) map[string]interface{} {
+	if ctx == nil {
+		return metadata
+	}
+	flags, _ := ctx.Value(schemas.BifrostContextKeyRequestFlags).(map[string]bool)
+	for name, enabled := range flags {
+		if metadata == nil {
+			metadata = make(map[string]interface{}, len(flags))
+		}
+		value := "off"
+		if enabled {
+			value = "on"
+		}
+		metadata["flag."+name] = value
+	}
+	return metadata
+}
+
+// mergeAdaptedRequestMetadata records a request that a plugin adapted to another
+// request type before it reached the provider, e.g. a legacy text completion sent
+// as chat, as "adapted_request_type": the type that was sent.
+func mergeAdaptedRequestMetadata(metadata map[string]interface{}, ctx *schemas.BifrostContext) map[string]interface{} {
+	if ctx == nil {
+		return metadata
+	}
+	if changeType, ok := ctx.Value(schemas.BifrostContextKeyChangeRequestType).(schemas.RequestType); ok && changeType != "" {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata["adapted_request_type"] = string(changeType)
+	}
+	return metadata
+}
+
 // formatRoutingEngineLogs formats routing engine logs into a human-readable string.
 // Format: [timestamp] [engine] - message
 // Parameters:
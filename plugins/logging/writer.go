@@ -1,12 +1,14 @@
 package logging
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/framework/streaming"
 )
 
 const (
@@ -187,6 +189,8 @@ func (p *LoggerPlugin) processBatch(batch []*writeQueueEntry) {
 		}
 	}
 
+	p.refreshSessionRollups(p.ctx, logs, mcpLogs)
+
 	// Collect callbacks that need to fire, then run them in a single goroutine.
 	// This avoids blocking the batch writer (synchronous was causing 1+ second stalls
 	// during WebSocket broadcast) without creating a goroutine per entry (which caused
@@ -226,10 +230,58 @@ func (p *LoggerPlugin) processBatch(batch []*writeQueueEntry) {
 	}
 }
 
+// refreshSessionRollups recomputes the rollups of the sessions that logs and
+// mcpLogs belong to. A tool log belongs to the session of the LLM request that
+// triggered it, which is looked up when that request is not in the batch.
+// Failures are logged only: the next write to the session repairs its rollup.
+func (p *LoggerPlugin) refreshSessionRollups(ctx context.Context, logs []*logstore.Log, mcpLogs []*logstore.MCPToolLog) {
+	sessions := make(map[string]struct{})
+	sessionOf := make(map[string]string, len(logs))
+	for _, log := range logs {
+		if log.ParentRequestID != nil && *log.ParentRequestID != "" {
+			sessions[*log.ParentRequestID] = struct{}{}
+			sessionOf[log.ID] = *log.ParentRequestID
+		}
+	}
+	var unresolved []string
+	for _, mcpLog := range mcpLogs {
+		if mcpLog.LLMRequestID == nil || *mcpLog.LLMRequestID == "" {
+			continue
+		}
+		if sessionID, ok := sessionOf[*mcpLog.LLMRequestID]; ok {
+			sessions[sessionID] = struct{}{}
+			continue
+		}
+		unresolved = append(unresolved, *mcpLog.LLMRequestID)
+	}
+	if len(unresolved) > 0 {
+		parents, err := p.store.FindAll(ctx, map[string]interface{}{"id": unresolved}, "id", "parent_request_id")
+		if err != nil {
+			p.logger.Warn("failed to resolve sessions of %d MCP tool logs: %v", len(unresolved), err)
+		}
+		for _, parent := range parents {
+			if parent.ParentRequestID != nil && *parent.ParentRequestID != "" {
+				sessions[*parent.ParentRequestID] = struct{}{}
+			}
+		}
+	}
+	if len(sessions) == 0 {
+		return
+	}
+	sessionIDs := make([]string, 0, len(sessions))
+	for sessionID := range sessions {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	if err := p.store.RefreshSessionRollups(ctx, sessionIDs); err != nil {
+		p.logger.Warn("failed to refresh rollups of %d sessions: %v", len(sessionIDs), err)
+	}
+}
+
 // cleanupStalePendingLogs removes stale in-memory pending log state.
-// Pending LLM entries are dropped to prevent unbounded memory growth. Pending
-// MCP entries are converted into terminal error rows and queued for persistence,
-// because PreMCPHook does not write a processing row to the database.
+// Pending LLM entries are persisted as "aborted" rows carrying their input data,
+// so requests whose PostLLMHook never fired stay visible for billing
+// reconciliation. Pending MCP entries are converted into terminal error rows.
+// Neither hook writes a processing row to the database up front.
 func (p *LoggerPlugin) cleanupStalePendingLogs() {
 	cutoff := time.Now().Add(-pendingLogTTL)
 	p.pendingLogsEntries.Range(func(key, value any) bool {
@@ -243,7 +295,19 @@ func (p *LoggerPlugin) cleanupStalePendingLogs() {
 				lastActive = time.Unix(0, nanos)
 			}
 			if lastActive.Before(cutoff) {
-				p.pendingLogsEntries.Delete(key)
+				actual, loaded := p.pendingLogsEntries.LoadAndDelete(key)
+				if !loaded {
+					return true
+				}
+				stalePending, ok := actual.(*PendingLogData)
+				if !ok || stalePending == nil || stalePending.InitialData == nil {
+					return true
+				}
+				p.abortedRequests.Add(1)
+				p.logger.Warn("request %s did not complete within the pending log TTL, recording it as aborted", stalePending.RequestID)
+				entry := buildStaleLogEntry(stalePending)
+				p.applyDeploymentMetadata(entry)
+				p.enqueueLogEntry(entry, p.makePostWriteCallback(nil))
 			}
 		}
 		return true
@@ -278,9 +342,38 @@ func (p *LoggerPlugin) cleanupStalePendingLogs() {
 	})
 }
 
+// WriteQueueStats is a point-in-time view of the batch write queue.
+type WriteQueueStats struct {
+	Depth          int    `json:"depth"`
+	Capacity       int    `json:"capacity"`
+	Dropped        int64  `json:"dropped"` // entries dropped before reaching the log store
+	Aborted        int64  `json:"aborted"` // requests whose PostLLMHook never ran, recorded with status "aborted"
+	OverflowPolicy string `json:"overflow_policy"`
+	Evicted        int64  `json:"evicted"`  // queued entries discarded to make room (drop_oldest); included in Dropped
+	Blocked        int64  `json:"blocked"`  // enqueues that waited for room (block)
+	Spilled        int64  `json:"spilled"`  // entries written to the spill WAL (spill)
+	Replayed       int64  `json:"replayed"` // spilled entries fed back into the write queue
+}
+
+// GetWriteQueueStats returns the current depth, capacity and drop/overflow counters of the write queue.
+func (p *LoggerPlugin) GetWriteQueueStats() WriteQueueStats {
+	return WriteQueueStats{
+		Depth:          len(p.writeQueue),
+		Capacity:       cap(p.writeQueue),
+		Dropped:        p.droppedRequests.Load(),
+		Aborted:        p.abortedRequests.Load(),
+		OverflowPolicy: p.writerConfig.OverflowPolicy,
+		Evicted:        p.overflowEvicted.Load(),
+		Blocked:        p.overflowBlocked.Load(),
+		Spilled:        p.overflowSpilled.Load(),
+		Replayed:       p.overflowReplayed.Load(),
+	}
+}
+
 // enqueueLogEntry pushes a complete log entry to the write queue.
-// If the queue is full, the entry is dropped to prevent Postgres slowness
-// from cascading into request handling goroutines.
+// When the queue is full the configured overflow policy applies; the default
+// drops the entry to prevent Postgres slowness from cascading into request
+// handling goroutines.
 func (p *LoggerPlugin) enqueueLogEntry(entry *logstore.Log, callback func(entry *logstore.Log)) {
 	if p.closed.Load() {
 		return
@@ -292,10 +385,11 @@ func (p *LoggerPlugin) enqueueLogEntry(entry *logstore.Log, callback func(entry
 			p.droppedRequests.Add(1)
 		}
 	}()
-	select {
-	case p.writeQueue <- &writeQueueEntry{log: entry, callback: callback}:
-		// enqueued successfully
-	default:
+	if p.scrubber != nil {
+		// Scrub before the entry reaches the queue, the spill WAL or the callback's broadcast.
+		p.scrubber.scrubLog(entry)
+	}
+	if !p.pushWriteQueueEntry(&writeQueueEntry{log: entry, callback: callback}) {
 		p.droppedRequests.Add(1)
 		p.logger.Warn("log write queue full, dropping log entry %s", entry.ID)
 	}
@@ -307,9 +401,8 @@ func (p *LoggerPlugin) EnqueueLogEntry(entry *logstore.Log) {
 	p.enqueueLogEntry(entry, p.makePostWriteCallback(nil))
 }
 
-// enqueueMCPToolLogEntry pushes a complete MCP tool log entry to the write queue.
-// If the queue is full, the entry is dropped to prevent store slowness from
-// cascading into request handling goroutines.
+// enqueueMCPToolLogEntry pushes a complete MCP tool log entry to the write queue,
+// applying the configured overflow policy when the queue is full.
 func (p *LoggerPlugin) enqueueMCPToolLogEntry(entry *logstore.MCPToolLog, callback func(entry *logstore.MCPToolLog)) {
 	if p.closed.Load() {
 		return
@@ -319,9 +412,7 @@ func (p *LoggerPlugin) enqueueMCPToolLogEntry(entry *logstore.MCPToolLog, callba
 			p.droppedRequests.Add(1)
 		}
 	}()
-	select {
-	case p.writeQueue <- &writeQueueEntry{mcpLog: entry, mcpCallback: callback}:
-	default:
+	if !p.pushWriteQueueEntry(&writeQueueEntry{mcpLog: entry, mcpCallback: callback}) {
 		p.droppedRequests.Add(1)
 		p.logger.Warn("log write queue full, dropping MCP tool log entry %s", entry.ID)
 	}
@@ -417,6 +508,21 @@ func buildStaleMCPToolLogEntry(pending *logstore.MCPToolLog) *logstore.MCPToolLo
 	return &entry
 }
 
+// buildStaleLogEntry converts a pending LLM log whose PostLLMHook never fired
+// into an "aborted" row. Only input data is available, so output, usage and
+// cost are left empty.
+func buildStaleLogEntry(pending *PendingLogData) *logstore.Log {
+	entry := buildCompleteLogEntryFromPending(pending)
+	entry.Status = logStatusAborted
+	entry.ErrorDetailsParsed = &schemas.BifrostError{
+		IsBifrostError: true,
+		Error: &schemas.ErrorField{
+			Message: "request did not complete before pending log TTL",
+		},
+	}
+	return entry
+}
+
 // buildInitialLogEntry constructs a logstore.Log from PendingLogData (input)
 // without writing to the database. Used for the UI callback in PreLLMHook.
 func buildInitialLogEntry(pending *PendingLogData) *logstore.Log {
@@ -515,6 +621,61 @@ func applyResolvedAliasInfo(entry *logstore.Log, resolvedAlias *schemas.Resolved
 	}
 }
 
+// applyReproducibilityToEntry records the provider's system fingerprint and
+// model revision on the entry and attaches the reproducibility bundle. Output
+// fields, model alias and prompt selection must already be applied. Either
+// result (non-streaming) or streamResponse (streaming) carries the provider's
+// identifiers; both may be nil for errors.
+func applyReproducibilityToEntry(ctx *schemas.BifrostContext, entry *logstore.Log, seed *int, result *schemas.BifrostResponse, streamResponse *streaming.ProcessedStreamResponse, contentLoggingEnabled bool) {
+	var fingerprint, revision string
+	switch {
+	case streamResponse != nil && streamResponse.Data != nil:
+		fingerprint = streamResponse.Data.SystemFingerprint
+		revision = streamResponse.Data.ModelRevision
+	case result != nil && result.ChatResponse != nil:
+		fingerprint = result.ChatResponse.SystemFingerprint
+		revision = result.ChatResponse.Model
+	case result != nil && result.TextCompletionResponse != nil:
+		fingerprint = result.TextCompletionResponse.SystemFingerprint
+		revision = result.TextCompletionResponse.Model
+	}
+	if fingerprint != "" {
+		entry.SystemFingerprint = &fingerprint
+	}
+	if revision != "" {
+		entry.ModelRevision = &revision
+	}
+
+	bundle := &logstore.ReproducibilityBundle{
+		Provider:          entry.Provider,
+		ResolvedModel:     entry.Model,
+		ModelRevision:     revision,
+		SystemFingerprint: fingerprint,
+		FallbackIndex:     entry.FallbackIndex,
+		Seed:              seed,
+		Params:            entry.ParamsParsed,
+	}
+	if entry.Alias != nil {
+		bundle.RequestedModel = *entry.Alias
+	}
+	// System prompts are request content; leave them out when content logging is off.
+	if contentLoggingEnabled {
+		if injections, ok := ctx.Value(schemas.BifrostContextKeySystemPromptInjections).([]string); ok && len(injections) > 0 {
+			bundle.SystemPromptInjections = injections
+		}
+	}
+	if entry.SelectedPromptID != nil {
+		bundle.PromptTemplate = &logstore.ReproducibilityPromptTemplate{ID: *entry.SelectedPromptID}
+		if entry.SelectedPromptName != nil {
+			bundle.PromptTemplate.Name = *entry.SelectedPromptName
+		}
+		if entry.SelectedPromptVersion != nil {
+			bundle.PromptTemplate.Version = *entry.SelectedPromptVersion
+		}
+	}
+	entry.ReproducibilityParsed = bundle
+}
+
 // applyOutputFieldsToEntry sets common output fields on a log entry.
 func applyOutputFieldsToEntry(
 	entry *logstore.Log,
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/framework/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReproducibilityToEntryNonStreaming(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeySystemPromptInjections, "be terse")
+
+	alias := "fast"
+	promptID, promptVersion := "p-1", "3"
+	params := &schemas.ChatParameters{Seed: schemas.Ptr(42)}
+	entry := &logstore.Log{
+		Provider:              "openai",
+		Model:                 "gpt-4o-mini",
+		Alias:                 &alias,
+		FallbackIndex:         1,
+		ParamsParsed:          params,
+		SelectedPromptID:      &promptID,
+		SelectedPromptVersion: &promptVersion,
+	}
+	result := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Model:             "gpt-4o-mini-2024-07-18",
+		SystemFingerprint: "fp_abc",
+	}}
+
+	applyReproducibilityToEntry(ctx, entry, params.Seed, result, nil, true)
+
+	require.NotNil(t, entry.SystemFingerprint)
+	assert.Equal(t, "fp_abc", *entry.SystemFingerprint)
+	require.NotNil(t, entry.ModelRevision)
+	assert.Equal(t, "gpt-4o-mini-2024-07-18", *entry.ModelRevision)
+
+	bundle := entry.ReproducibilityParsed
+	require.NotNil(t, bundle)
+	assert.Equal(t, "openai", bundle.Provider)
+	assert.Equal(t, "fast", bundle.RequestedModel)
+	assert.Equal(t, "gpt-4o-mini", bundle.ResolvedModel)
+	assert.Equal(t, 1, bundle.FallbackIndex)
+	require.NotNil(t, bundle.Seed)
+	assert.Equal(t, 42, *bundle.Seed)
+	assert.Same(t, params, bundle.Params)
+	assert.Equal(t, []string{"be terse"}, bundle.SystemPromptInjections)
+	require.NotNil(t, bundle.PromptTemplate)
+	assert.Equal(t, logstore.ReproducibilityPromptTemplate{ID: "p-1", Version: "3"}, *bundle.PromptTemplate)
+}
+
+func TestApplyReproducibilityToEntryStreamingWithoutContent(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	schemas.AppendToContextList(ctx, schemas.BifrostContextKeySystemPromptInjections, "secret instructions")
+
+	entry := &logstore.Log{Provider: "openai", Model: "gpt-4o"}
+	streamResponse := &streaming.ProcessedStreamResponse{Data: &streaming.AccumulatedData{
+		SystemFingerprint: "fp_stream",
+		ModelRevision:     "gpt-4o-2024-08-06",
+	}}
+
+	applyReproducibilityToEntry(ctx, entry, schemas.Ptr(7), nil, streamResponse, false)
+
+	require.NotNil(t, entry.SystemFingerprint)
+	assert.Equal(t, "fp_stream", *entry.SystemFingerprint)
+	bundle := entry.ReproducibilityParsed
+	require.NotNil(t, bundle)
+	assert.Equal(t, "gpt-4o-2024-08-06", bundle.ModelRevision)
+	assert.Equal(t, 7, *bundle.Seed)
+	assert.Empty(t, bundle.RequestedModel)
+	assert.Nil(t, bundle.SystemPromptInjections, "system prompts are content and must not be recorded with content logging off")
+	assert.Nil(t, bundle.PromptTemplate)
+}
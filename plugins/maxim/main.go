@@ -164,6 +164,12 @@ func (plugin *Plugin) GetName() string {
 	return PluginName
 }
 
+// DegradationPolicy implements schemas.DegradablePlugin, so an unreachable
+// Maxim backend stops costing every request its hook latency.
+func (plugin *Plugin) DegradationPolicy() *schemas.PluginDegradationPolicy {
+	return schemas.DefaultObservabilityDegradationPolicy()
+}
+
 // HTTPTransportPreHook is not used for this plugin
 func (plugin *Plugin) HTTPTransportPreHook(ctx *schemas.BifrostContext, req *schemas.HTTPRequest) (*schemas.HTTPResponse, error) {
 	return nil, nil
@@ -0,0 +1,252 @@
+// Package mediavalidator provides an LLM plugin that validates inline images, audio and files
+// before a request is dispatched. Attachments are checked against a size limit, a MIME type
+// allowlist and a maximum image dimension, using built-in limits for providers that document
+// them, and against the input modalities the model catalog records for the target model.
+// Violations fail with a structured 400 instead of an opaque provider error. Oversized JPEG
+// and PNG images can optionally be downscaled by the gateway instead of rejected.
+package mediavalidator
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+)
+
+const PluginName = "media-validator"
+
+// Error codes returned in BifrostError.Error.Code.
+const (
+	ErrCodeMediaTooLarge        = "media_too_large"
+	ErrCodeUnsupportedMediaType = "unsupported_media_type"
+	ErrCodeImageTooLarge        = "image_dimensions_exceeded"
+	ErrCodeUnsupportedModality  = "unsupported_modality"
+	ErrCodeInvalidMedia         = "invalid_media"
+)
+
+// Limits bounds the media a provider accepts inline. Zero values are unlimited.
+type Limits struct {
+	MaxAttachmentBytes int64    `json:"max_attachment_bytes,omitempty"` // Decoded size of one image, audio clip or file
+	MaxImageDimension  int      `json:"max_image_dimension,omitempty"`  // Longest edge of an image in pixels
+	AllowedMIMETypes   []string `json:"allowed_mime_types,omitempty"`   // Accepted media types; "image/*" matches a whole family
+}
+
+// builtinLimits are the documented inline limits of providers that reject larger payloads.
+var builtinLimits = map[schemas.ModelProvider]Limits{
+	schemas.Anthropic: {
+		MaxAttachmentBytes: 5 << 20,
+		MaxImageDimension:  8000,
+		AllowedMIMETypes:   []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf", "text/plain"},
+	},
+	schemas.Bedrock: {MaxAttachmentBytes: 3_750_000, MaxImageDimension: 8000},
+	schemas.OpenAI:  {MaxAttachmentBytes: 20 << 20},
+	schemas.Azure:   {MaxAttachmentBytes: 20 << 20},
+	schemas.Gemini:  {MaxAttachmentBytes: 20 << 20},
+	schemas.Vertex:  {MaxAttachmentBytes: 20 << 20},
+}
+
+// Config configures the media validator. The top-level limits apply to every provider and
+// combine with the provider's own limits: the stricter size and dimension wins, and a MIME
+// type must be allowed by both lists.
+type Config struct {
+	Limits
+	// ProviderLimits replaces the built-in limits of a provider.
+	ProviderLimits map[schemas.ModelProvider]Limits `json:"provider_limits,omitempty"`
+	// DownscaleImages shrinks JPEG and PNG images that exceed the dimension limit instead of
+	// rejecting them.
+	DownscaleImages bool `json:"downscale_images,omitempty"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	config  Config
+	catalog *modelcatalog.ModelCatalog
+	logger  schemas.Logger
+}
+
+// Init returns a media validator. The catalog is optional; without it no modality checks are made.
+func Init(config Config, catalog *modelcatalog.ModelCatalog, logger schemas.Logger) (*Plugin, error) {
+	if config.MaxAttachmentBytes < 0 || config.MaxImageDimension < 0 {
+		return nil, fmt.Errorf("media-validator: limits must not be negative")
+	}
+	for provider, limits := range config.ProviderLimits {
+		if limits.MaxAttachmentBytes < 0 || limits.MaxImageDimension < 0 {
+			return nil, fmt.Errorf("media-validator: limits for %s must not be negative", provider)
+		}
+	}
+	return &Plugin{config: config, catalog: catalog, logger: logger}, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook implements schemas.LLMPlugin. Media is checked per attempt in PreLLMHook so
+// each fallback is judged against its own provider's limits.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook validates every inline attachment of chat and responses requests, downscaling
+// images in place when enabled, and short-circuits with a 400 on the first violation.
+// Fallbacks stay allowed since another provider may accept the attachment.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	items := collectMedia(req)
+	if len(items) == 0 {
+		return req, nil, nil
+	}
+	provider, model, _ := req.GetRequestFields()
+	limits := p.limitsFor(provider)
+	modalities := p.inputModalities(provider, model)
+
+	for _, item := range items {
+		if violation := p.validate(item, limits, modalities); violation != nil {
+			violation.message = fmt.Sprintf("%s: %s", item.path, violation.message)
+			return req, &schemas.LLMPluginShortCircuit{Error: violation.toBifrostError(item.path)}, nil
+		}
+	}
+	return req, nil, nil
+}
+
+// PostLLMHook implements schemas.LLMPlugin.
+func (p *Plugin) PostLLMHook(_ *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	return result, bifrostErr, nil
+}
+
+// effectiveLimits are the global limits merged with one provider's limits.
+type effectiveLimits struct {
+	maxBytes     int64
+	maxDimension int
+	mimeLists    [][]string // a MIME type must match every list
+}
+
+func (p *Plugin) limitsFor(provider schemas.ModelProvider) effectiveLimits {
+	providerLimits, ok := p.config.ProviderLimits[provider]
+	if !ok {
+		providerLimits = builtinLimits[provider]
+	}
+	limits := effectiveLimits{
+		maxBytes:     minPositive(p.config.MaxAttachmentBytes, providerLimits.MaxAttachmentBytes),
+		maxDimension: int(minPositive(int64(p.config.MaxImageDimension), int64(providerLimits.MaxImageDimension))),
+	}
+	for _, list := range [][]string{p.config.AllowedMIMETypes, providerLimits.AllowedMIMETypes} {
+		if len(list) > 0 {
+			limits.mimeLists = append(limits.mimeLists, list)
+		}
+	}
+	return limits
+}
+
+// inputModalities returns the model's input modalities from the catalog, or nil when unknown.
+func (p *Plugin) inputModalities(provider schemas.ModelProvider, model string) []string {
+	if p.catalog == nil || model == "" {
+		return nil
+	}
+	entry := p.catalog.GetModelCapabilityEntryForModel(model, provider)
+	if entry == nil || entry.Architecture == nil {
+		return nil
+	}
+	return entry.Architecture.InputModalities
+}
+
+// violation is a failed check, reported to the caller as a 400.
+type violation struct {
+	code    string
+	message string
+}
+
+func (v *violation) toBifrostError(param string) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(http.StatusBadRequest),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("invalid_request_error"),
+			Code:    schemas.Ptr(v.code),
+			Message: v.message,
+			Param:   param,
+		},
+		AllowFallbacks: schemas.Ptr(true),
+	}
+}
+
+// validate checks one attachment. Images over the dimension limit are downscaled first when
+// enabled, so the size limit applies to the downscaled image.
+func (p *Plugin) validate(item *mediaItem, limits effectiveLimits, modalities []string) *violation {
+	// The catalog only lists file support inconsistently, so only image and audio are gated.
+	if len(modalities) > 0 && item.kind != mediaKindFile && !slices.Contains(modalities, string(item.kind)) {
+		return &violation{ErrCodeUnsupportedModality, fmt.Sprintf("model does not accept %s input", item.kind)}
+	}
+	if item.mimeType != "" && !mimeAllowed(item.mimeType, limits.mimeLists) {
+		return &violation{ErrCodeUnsupportedMediaType, fmt.Sprintf("media type %s is not allowed", item.mimeType)}
+	}
+	if item.data == "" {
+		// Remote URLs are fetched by the provider; only their inferred type can be checked.
+		return nil
+	}
+
+	if item.kind == mediaKindImage && limits.maxDimension > 0 {
+		width, height, ok := imageDimensions(item.data)
+		if ok && max(width, height) > limits.maxDimension {
+			if !p.config.DownscaleImages || item.replace == nil {
+				return &violation{ErrCodeImageTooLarge, fmt.Sprintf("image is %dx%d pixels, the limit is %d pixels per side", width, height, limits.maxDimension)}
+			}
+			resized, mimeType, err := downscaleImage(item.data, limits.maxDimension)
+			if err != nil {
+				return &violation{ErrCodeImageTooLarge, fmt.Sprintf("image is %dx%d pixels, the limit is %d pixels per side, and it could not be downscaled: %v", width, height, limits.maxDimension, err)}
+			}
+			item.mimeType = mimeType
+			item.data = resized
+			item.replace(resized)
+			if p.logger != nil {
+				p.logger.Debug("media-validator: downscaled %dx%d image at %s to %d pixels", width, height, item.path, limits.maxDimension)
+			}
+		}
+	}
+
+	if limits.maxBytes > 0 {
+		size := decodedSize(item.data)
+		if size < 0 && item.kind == mediaKindFile {
+			// Responses file_data may carry plain text instead of base64.
+			size = int64(len(item.data))
+		}
+		if size < 0 {
+			return &violation{ErrCodeInvalidMedia, "attachment is not valid base64"}
+		}
+		if size > limits.maxBytes {
+			return &violation{ErrCodeMediaTooLarge, fmt.Sprintf("attachment is %d bytes, the limit is %d bytes", size, limits.maxBytes)}
+		}
+	}
+	return nil
+}
+
+// mimeAllowed reports whether mimeType matches every list. A "type/*" entry matches the family.
+func mimeAllowed(mimeType string, lists [][]string) bool {
+	mimeType = strings.ToLower(mimeType)
+	family, _, _ := strings.Cut(mimeType, "/")
+	for _, list := range lists {
+		if !slices.ContainsFunc(list, func(allowed string) bool {
+			allowed = strings.ToLower(allowed)
+			return allowed == mimeType || allowed == family+"/*"
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// minPositive returns the smaller of the positive values, or 0 when neither is positive.
+func minPositive(a, b int64) int64 {
+	switch {
+	case a <= 0:
+		return max(b, 0)
+	case b <= 0:
+		return a
+	default:
+		return min(a, b)
+	}
+}
@@ -0,0 +1,265 @@
+package mediavalidator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif" // registers GIF for dimension checks
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// mediaKind names an attachment by the catalog's input modality.
+type mediaKind string
+
+const (
+	mediaKindImage mediaKind = "image"
+	mediaKindAudio mediaKind = "audio"
+	mediaKindFile  mediaKind = "file"
+)
+
+// downscaleJPEGQuality is the JPEG quality used when re-encoding a downscaled image.
+const downscaleJPEGQuality = 90
+
+// audioFormatMIMETypes maps input_audio formats to media types.
+var audioFormatMIMETypes = map[string]string{
+	"wav":  "audio/wav",
+	"mp3":  "audio/mpeg",
+	"flac": "audio/flac",
+	"ogg":  "audio/ogg",
+	"opus": "audio/ogg",
+	"webm": "audio/webm",
+	"m4a":  "audio/mp4",
+	"aac":  "audio/aac",
+}
+
+// mediaItem is one attachment of a request.
+type mediaItem struct {
+	path     string // location in the request, e.g. messages[0].content[1]
+	kind     mediaKind
+	mimeType string // empty when unknown
+	data     string // base64 payload of inline media; empty for remote URLs
+	// replace writes a re-encoded base64 image back into the request. Nil for non-images.
+	replace func(data string)
+}
+
+// collectMedia returns the attachments of chat and responses requests.
+func collectMedia(req *schemas.BifrostRequest) []*mediaItem {
+	var items []*mediaItem
+	if req.ChatRequest != nil {
+		for i, message := range req.ChatRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			for j := range message.Content.ContentBlocks {
+				block := &message.Content.ContentBlocks[j]
+				path := fmt.Sprintf("messages[%d].content[%d]", i, j)
+				switch {
+				case block.ImageURLStruct != nil && block.ImageURLStruct.URL != "":
+					item := newMediaItem(path, mediaKindImage, block.ImageURLStruct.URL, "")
+					if item.data != "" {
+						item.replace = func(data string) {
+							block.ImageURLStruct.URL = "data:" + item.mimeType + ";base64," + data
+						}
+					}
+					items = append(items, item)
+				case block.InputAudio != nil && block.InputAudio.Data != "":
+					items = append(items, newMediaItem(path, mediaKindAudio, block.InputAudio.Data, audioMIMEType(block.InputAudio.Format)))
+				case block.File != nil:
+					if item := newFileItem(path, block.File.FileData, block.File.FileURL, block.File.FileType); item != nil {
+						items = append(items, item)
+					}
+				}
+			}
+		}
+	}
+	if req.ResponsesRequest != nil {
+		for i, message := range req.ResponsesRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			for j := range message.Content.ContentBlocks {
+				block := &message.Content.ContentBlocks[j]
+				path := fmt.Sprintf("input[%d].content[%d]", i, j)
+				switch {
+				case block.ResponsesInputMessageContentBlockImage != nil && block.ImageURL != nil && *block.ImageURL != "":
+					item := newMediaItem(path, mediaKindImage, *block.ImageURL, "")
+					if item.data != "" {
+						item.replace = func(data string) {
+							block.ImageURL = schemas.Ptr("data:" + item.mimeType + ";base64," + data)
+						}
+					}
+					items = append(items, item)
+				case block.Audio != nil && block.Audio.Data != "":
+					items = append(items, newMediaItem(path, mediaKindAudio, block.Audio.Data, audioMIMEType(&block.Audio.Format)))
+				case block.ResponsesInputMessageContentBlockFile != nil:
+					if item := newFileItem(path, block.FileData, block.FileURL, block.FileType); item != nil {
+						items = append(items, item)
+					}
+				}
+			}
+		}
+	}
+	return items
+}
+
+// newFileItem returns the attachment of a file block, or nil when it only references an uploaded file.
+func newFileItem(path string, fileData, fileURL, fileType *string) *mediaItem {
+	mimeType := ""
+	if fileType != nil && strings.Contains(*fileType, "/") {
+		mimeType = *fileType
+	}
+	switch {
+	case fileData != nil && *fileData != "":
+		return newMediaItem(path, mediaKindFile, *fileData, mimeType)
+	case fileURL != nil && *fileURL != "":
+		return newMediaItem(path, mediaKindFile, *fileURL, mimeType)
+	}
+	return nil
+}
+
+// newMediaItem parses a data URL, remote URL or raw base64 payload. mimeType, when set, takes
+// precedence over the type declared in a data URL or detected from the content.
+func newMediaItem(path string, kind mediaKind, raw string, mimeType string) *mediaItem {
+	item := &mediaItem{path: path, kind: kind, mimeType: mimeType}
+	raw = strings.TrimSpace(raw)
+	switch {
+	case strings.HasPrefix(raw, "data:"):
+		header, payload, _ := strings.Cut(raw[len("data:"):], ",")
+		declared, _, _ := strings.Cut(header, ";")
+		if item.mimeType == "" {
+			item.mimeType = declared
+		}
+		if strings.HasSuffix(header, ";base64") {
+			item.data = payload
+		}
+	case strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://"):
+		if item.mimeType == "" {
+			if info := schemas.ExtractURLTypeInfo(raw); info.MediaType != nil {
+				item.mimeType = *info.MediaType
+			}
+		}
+	default:
+		item.data = raw
+		if item.mimeType == "" {
+			item.mimeType = sniffMIMEType(raw)
+		}
+	}
+	item.mimeType = strings.ToLower(strings.TrimSpace(item.mimeType))
+	return item
+}
+
+func audioMIMEType(format *string) string {
+	if format == nil {
+		return ""
+	}
+	return audioFormatMIMETypes[strings.ToLower(*format)]
+}
+
+// sniffMIMEType detects the media type of a base64 payload, or returns "" when unknown.
+func sniffMIMEType(data string) string {
+	// 684 base64 characters decode to the 512 bytes http.DetectContentType considers.
+	head := data[:min(len(data), 684)]
+	head = head[:len(head)/4*4]
+	decoded, err := base64.StdEncoding.DecodeString(head)
+	if err != nil || len(decoded) == 0 {
+		return ""
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(decoded), ";")
+	if mimeType == "application/octet-stream" {
+		return ""
+	}
+	return mimeType
+}
+
+// decodedSize returns the decoded length of a base64 payload, or -1 when it is not base64.
+func decodedSize(data string) int64 {
+	var chars, padding int64
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '+', c == '/':
+			if padding > 0 {
+				return -1
+			}
+			chars++
+		case c == '=':
+			padding++
+		case c == '\n', c == '\r', c == ' ':
+		default:
+			return -1
+		}
+	}
+	if padding > 2 || (chars+padding)%4 == 1 {
+		return -1
+	}
+	return chars * 3 / 4
+}
+
+// imageDimensions reads the width and height from an image header. ok is false for formats
+// the standard library cannot decode, such as WebP.
+func imageDimensions(data string) (width, height int, ok bool) {
+	config, _, err := image.DecodeConfig(base64.NewDecoder(base64.StdEncoding, strings.NewReader(data)))
+	if err != nil {
+		return 0, 0, false
+	}
+	return config.Width, config.Height, true
+}
+
+// downscaleImage shrinks a base64 JPEG or PNG image so its longest edge is maxDimension pixels,
+// averaging the source pixels under each target pixel, and re-encodes it in its own format.
+// It returns the new payload and its media type.
+func downscaleImage(data string, maxDimension int) (string, string, error) {
+	src, format, err := image.Decode(base64.NewDecoder(base64.StdEncoding, strings.NewReader(data)))
+	if err != nil {
+		return "", "", err
+	}
+	if format != "jpeg" && format != "png" {
+		return "", "", fmt.Errorf("%s images cannot be downscaled", format)
+	}
+
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := maxDimension, maxDimension
+	if srcW >= srcH {
+		dstH = max(1, srcH*maxDimension/srcW)
+	} else {
+		dstW = max(1, srcW*maxDimension/srcH)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := bounds.Min.Y+y*srcH/dstH, bounds.Min.Y+(y+1)*srcH/dstH
+		for x := 0; x < dstW; x++ {
+			x0, x1 := bounds.Min.X+x*srcW/dstW, bounds.Min.X+(x+1)*srcW/dstW
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(b / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: downscaleJPEGQuality})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), "image/" + format, nil
+}
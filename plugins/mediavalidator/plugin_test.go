@@ -0,0 +1,119 @@
+package mediavalidator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+	"github.com/maximhq/bifrost/framework/modelcatalog/datasheet"
+)
+
+func pngDataURL(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func imageRequest(provider schemas.ModelProvider, model, url string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: provider,
+			Model:    model,
+			Input: []schemas.ChatMessage{{
+				Role: schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+					{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("describe")},
+					{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: url}},
+				}},
+			}},
+		},
+	}
+}
+
+func errorCode(t *testing.T, sc *schemas.LLMPluginShortCircuit) string {
+	t.Helper()
+	if sc == nil || sc.Error == nil {
+		t.Fatal("expected the request to be rejected")
+	}
+	if *sc.Error.StatusCode != 400 || sc.Error.Error.Param != "messages[0].content[1]" {
+		t.Fatalf("unexpected error %d %v: %s", *sc.Error.StatusCode, sc.Error.Error.Param, sc.Error.Error.Message)
+	}
+	return *sc.Error.Error.Code
+}
+
+func TestImageDimensionLimitAndDownscaling(t *testing.T) {
+	ctx := schemas.NewBifrostContext(t.Context(), schemas.NoDeadline)
+	config := Config{Limits: Limits{MaxImageDimension: 40}}
+
+	p, _ := Init(config, nil, nil)
+	_, sc, _ := p.PreLLMHook(ctx, imageRequest(schemas.OpenAI, "gpt-4o", pngDataURL(t, 100, 50)))
+	if code := errorCode(t, sc); code != ErrCodeImageTooLarge {
+		t.Fatalf("expected %s, got %s", ErrCodeImageTooLarge, code)
+	}
+
+	config.DownscaleImages = true
+	p, _ = Init(config, nil, nil)
+	req := imageRequest(schemas.OpenAI, "gpt-4o", pngDataURL(t, 100, 50))
+	if _, sc, _ := p.PreLLMHook(ctx, req); sc != nil {
+		t.Fatalf("expected the image to be downscaled, got %s", sc.Error.Error.Message)
+	}
+	url := req.ChatRequest.Input[0].Content.ContentBlocks[1].ImageURLStruct.URL
+	width, height, ok := imageDimensions(strings.TrimPrefix(url, "data:image/png;base64,"))
+	if !ok || width != 40 || height != 20 {
+		t.Fatalf("expected a 40x20 png, got %dx%d (%v)", width, height, ok)
+	}
+}
+
+func TestProviderLimits(t *testing.T) {
+	ctx := schemas.NewBifrostContext(t.Context(), schemas.NoDeadline)
+	p, _ := Init(Config{ProviderLimits: map[schemas.ModelProvider]Limits{schemas.OpenAI: {MaxAttachmentBytes: 10}}}, nil, nil)
+
+	_, sc, _ := p.PreLLMHook(ctx, imageRequest(schemas.OpenAI, "gpt-4o", pngDataURL(t, 8, 8)))
+	if code := errorCode(t, sc); code != ErrCodeMediaTooLarge {
+		t.Fatalf("expected %s, got %s", ErrCodeMediaTooLarge, code)
+	}
+	// Anthropic keeps its built-in MIME allowlist.
+	_, sc, _ = p.PreLLMHook(ctx, imageRequest(schemas.Anthropic, "claude-sonnet-4", "data:image/bmp;base64,Qk0="))
+	if code := errorCode(t, sc); code != ErrCodeUnsupportedMediaType {
+		t.Fatalf("expected %s, got %s", ErrCodeUnsupportedMediaType, code)
+	}
+	// Remote URLs are only checked by their inferred type.
+	if _, sc, _ := p.PreLLMHook(ctx, imageRequest(schemas.Anthropic, "claude-sonnet-4", "https://example.com/cat.png")); sc != nil {
+		t.Fatalf("expected the remote image to pass, got %s", sc.Error.Error.Message)
+	}
+}
+
+func TestModelWithoutImageInputIsRejected(t *testing.T) {
+	pricingPath := filepath.Join(t.TempDir(), "pricing.json")
+	if err := os.WriteFile(pricingPath, []byte(`{
+		"text-only": {"provider":"openai","mode":"chat","input_cost_per_token":0.000001,"output_cost_per_token":0.000002,"architecture":{"input_modalities":["text"]}},
+		"vision": {"provider":"openai","mode":"chat","input_cost_per_token":0.000001,"output_cost_per_token":0.000002,"architecture":{"input_modalities":["text","image"]}}
+	}`), 0o600); err != nil {
+		t.Fatalf("write pricing testdata: %v", err)
+	}
+	ds := datasheet.New(nil, nil, datasheet.Config{URL: "file://" + pricingPath})
+	if err := ds.LoadFromURLIntoMemory(t.Context()); err != nil {
+		t.Fatalf("load pricing testdata: %v", err)
+	}
+	p, _ := Init(Config{}, modelcatalog.NewTestCatalogWithDatasheet(ds), nil)
+	ctx := schemas.NewBifrostContext(t.Context(), schemas.NoDeadline)
+
+	_, sc, _ := p.PreLLMHook(ctx, imageRequest(schemas.OpenAI, "text-only", pngDataURL(t, 8, 8)))
+	if code := errorCode(t, sc); code != ErrCodeUnsupportedModality {
+		t.Fatalf("expected %s, got %s", ErrCodeUnsupportedModality, code)
+	}
+	if _, sc, _ := p.PreLLMHook(ctx, imageRequest(schemas.OpenAI, "vision", pngDataURL(t, 8, 8))); sc != nil {
+		t.Fatalf("expected the vision model to accept the image, got %s", sc.Error.Error.Message)
+	}
+}
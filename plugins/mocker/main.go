@@ -1,6 +1,9 @@
 package mocker
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"math/rand"
@@ -37,18 +40,28 @@ const (
 	LatencyTypeUniform = "uniform"
 )
 
+var (
+	// ErrRuleNotFound is returned by the rule management methods for an unknown rule name
+	ErrRuleNotFound = errors.New("mock rule not found")
+	// ErrRuleExists is returned by AddRule when a rule with the same name is already configured
+	ErrRuleExists = errors.New("mock rule already exists")
+)
+
 // compiledRule represents a rule with pre-compiled regex and normalized weights for performance
 type compiledRule struct {
 	MockRule
 	compiledRegex     *regexp.Regexp // Pre-compiled regex for fast matching
 	normalizedWeights []float64      // Pre-calculated normalized weights for fast response selection
+	// turnCounters counts the requests each response has answered, for scripted
+	// scenarios; indexed like Responses.
+	turnCounters []*atomic.Int64
 }
 
 // MockerPlugin provides comprehensive request/response mocking capabilities
 type MockerPlugin struct {
 	config        MockerConfig
 	rules         []MockRule
-	compiledRules []compiledRule // Pre-compiled rules for performance
+	compiledRules []compiledRule // Pre-compiled rules for performance (replaced, never modified in place, so requests in flight keep a consistent view)
 	mu            sync.RWMutex
 	faker         faker.Faker // Use jaswdr/faker library
 
@@ -103,16 +116,32 @@ type Response struct {
 }
 
 // SuccessResponse defines mock success response content
-// Either Message or MessageTemplate should be set (MessageTemplate takes precedence)
+// At least one of Message, MessageTemplate, JSONContent or ToolCalls should be set
+// (JSONContent takes precedence over MessageTemplate, which takes precedence over Message).
+// Alternatively, Turns scripts a multi-turn scenario: the Nth request the response
+// answers gets Turns[N-1], and the last turn repeats once the script runs out.
+// Turns inherit Model, Usage and CustomFields from the scenario when they leave them unset.
+// The turn counter is shared by every conversation the rule matches, so run scripted
+// scenarios one conversation at a time.
 type SuccessResponse struct {
 	Message         string                 `json:"message"`          // Static response message
 	Model           *string                `json:"model"`            // Override model name in response (optional)
 	Usage           *Usage                 `json:"usage"`            // Token usage info (optional, defaults applied if nil)
-	FinishReason    *string                `json:"finish_reason"`    // Completion reason (optional, defaults to "stop")
+	FinishReason    *string                `json:"finish_reason"`    // Completion reason (optional, defaults to "stop", or "tool_calls" with ToolCalls)
 	MessageTemplate *string                `json:"message_template"` // Template with variables like {{model}}, {{provider}} (overrides Message)
+	JSONContent     interface{}            `json:"json_content"`     // Structured content returned as the message's JSON text (overrides MessageTemplate)
+	ToolCalls       []ToolCall             `json:"tool_calls"`       // Tool calls requested by the assistant message (optional)
+	Turns           []SuccessResponse      `json:"turns"`            // Scripted multi-turn scenario (optional, see above)
 	CustomFields    map[string]interface{} `json:"custom_fields"`    // Additional fields stored in response metadata
 }
 
+// ToolCall defines a function call returned in a mock assistant message
+type ToolCall struct {
+	ID        *string         `json:"id"`        // Tool call ID (optional, generated if nil)
+	Name      string          `json:"name"`      // Function name
+	Arguments json.RawMessage `json:"arguments"` // Arguments as a JSON object, or as a JSON-encoded string (optional, defaults to {})
+}
+
 // ErrorResponse defines mock error response content
 type ErrorResponse struct {
 	Message    string  `json:"message"`     // Error message to return
@@ -207,20 +236,10 @@ func (p *MockerPlugin) compileRules() error {
 	p.compiledRules = make([]compiledRule, 0, len(p.rules))
 
 	for _, rule := range p.rules {
-		compiled := compiledRule{MockRule: rule}
-
-		// Pre-compile regex if present
-		if rule.Conditions.MessageRegex != nil {
-			regex, err := regexp.Compile(*rule.Conditions.MessageRegex)
-			if err != nil {
-				return fmt.Errorf("invalid regex in rule '%s': %w", rule.Name, err)
-			}
-			compiled.compiledRegex = regex
+		compiled, err := p.compileRule(rule)
+		if err != nil {
+			return err
 		}
-
-		// Pre-calculate normalized weights for fast response selection
-		compiled.normalizedWeights = p.calculateNormalizedWeights(rule.Responses)
-
 		p.compiledRules = append(p.compiledRules, compiled)
 	}
 
@@ -230,6 +249,30 @@ func (p *MockerPlugin) compileRules() error {
 	return nil
 }
 
+// compileRule pre-compiles a single rule's regex pattern and response weights
+func (p *MockerPlugin) compileRule(rule MockRule) (compiledRule, error) {
+	compiled := compiledRule{MockRule: rule}
+
+	// Pre-compile regex if present
+	if rule.Conditions.MessageRegex != nil {
+		regex, err := regexp.Compile(*rule.Conditions.MessageRegex)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("invalid regex in rule '%s': %w", rule.Name, err)
+		}
+		compiled.compiledRegex = regex
+	}
+
+	// Pre-calculate normalized weights for fast response selection
+	compiled.normalizedWeights = p.calculateNormalizedWeights(rule.Responses)
+
+	compiled.turnCounters = make([]*atomic.Int64, len(rule.Responses))
+	for i := range compiled.turnCounters {
+		compiled.turnCounters[i] = &atomic.Int64{}
+	}
+
+	return compiled, nil
+}
+
 // calculateNormalizedWeights pre-calculates normalized cumulative weights for fast response selection
 func (p *MockerPlugin) calculateNormalizedWeights(responses []Response) []float64 {
 	if len(responses) == 0 {
@@ -443,9 +486,37 @@ func validateResponse(response Response) error {
 
 // validateSuccessResponse validates success response content
 func validateSuccessResponse(content SuccessResponse) error {
-	// Either Message or MessageTemplate must be provided
-	if content.Message == "" && (content.MessageTemplate == nil || *content.MessageTemplate == "") {
-		return fmt.Errorf("either message or message_template is required")
+	// A scripted scenario is validated turn by turn
+	if len(content.Turns) > 0 {
+		for i, turn := range content.Turns {
+			if len(turn.Turns) > 0 {
+				return fmt.Errorf("turn %d cannot have turns of its own", i)
+			}
+			if err := validateSuccessResponse(turn); err != nil {
+				return fmt.Errorf("invalid turn %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	// Some content must be provided
+	if content.Message == "" && (content.MessageTemplate == nil || *content.MessageTemplate == "") && content.JSONContent == nil && len(content.ToolCalls) == 0 {
+		return fmt.Errorf("one of message, message_template, json_content or tool_calls is required")
+	}
+
+	if content.JSONContent != nil {
+		if _, err := json.Marshal(content.JSONContent); err != nil {
+			return fmt.Errorf("json_content cannot be encoded as JSON: %w", err)
+		}
+	}
+
+	for i, toolCall := range content.ToolCalls {
+		if toolCall.Name == "" {
+			return fmt.Errorf("tool call %d: name is required", i)
+		}
+		if len(bytes.TrimSpace(toolCall.Arguments)) > 0 && !json.Valid(toolCall.Arguments) {
+			return fmt.Errorf("tool call %d: arguments must be valid JSON", i)
+		}
 	}
 
 	// If usage is provided, validate it
@@ -545,7 +616,7 @@ func (p *MockerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 	}
 
 	// Select a response from the rule's possible responses using pre-calculated weights
-	response := p.selectResponse(rule)
+	response, responseIndex := p.selectResponse(rule)
 	if response == nil {
 		// No valid response configuration, continue with normal flow
 		return req, nil, nil
@@ -566,7 +637,7 @@ func (p *MockerPlugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifr
 
 	switch response.Type {
 	case ResponseTypeSuccess:
-		modifiedReq, shortCircuit, err = p.generateSuccessShortCircuit(req, response, startTime)
+		modifiedReq, shortCircuit, err = p.generateSuccessShortCircuit(req, rule.scenarioTurn(responseIndex, response.Content), startTime)
 	case ResponseTypeError:
 		modifiedReq, shortCircuit, err = p.generateErrorShortCircuit(req, response)
 	default:
@@ -615,8 +686,12 @@ func (p *MockerPlugin) Cleanup() error {
 
 // findMatchingCompiledRule finds the first rule that matches the request using pre-compiled rules
 func (p *MockerPlugin) findMatchingCompiledRule(req *schemas.BifrostRequest) *compiledRule {
-	for i := range p.compiledRules {
-		rule := &p.compiledRules[i]
+	p.mu.RLock()
+	compiledRules := p.compiledRules
+	p.mu.RUnlock()
+
+	for i := range compiledRules {
+		rule := &compiledRules[i]
 		if !rule.Enabled {
 			continue
 		}
@@ -794,12 +869,11 @@ func (p *MockerPlugin) calculateRequestSizeFast(req *schemas.BifrostRequest) int
 }
 
 // generateSuccessShortCircuit creates a success response short-circuit with optimized allocations
-func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest, response *Response, startTime time.Time) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
-	if response.Content == nil {
+func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest, content *SuccessResponse, startTime time.Time) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	if content == nil {
 		return req, nil, nil
 	}
 
-	content := response.Content
 	message := content.Message
 
 	// Apply message template if provided
@@ -807,6 +881,15 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 		message = p.applyTemplate(*content.MessageTemplate, req)
 	}
 
+	// Structured content is returned as its JSON text
+	if content.JSONContent != nil {
+		jsonContent, err := json.Marshal(content.JSONContent)
+		if err != nil {
+			return req, nil, fmt.Errorf("failed to encode json_content: %w", err)
+		}
+		message = string(jsonContent)
+	}
+
 	// Apply defaults for token usage if not provided
 	var usage schemas.BifrostLLMUsage
 	if content.Usage != nil {
@@ -828,6 +911,9 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 	var finishReason *string
 	if content.FinishReason != nil {
 		finishReason = content.FinishReason
+	} else if len(content.ToolCalls) > 0 {
+		static := "tool_calls"
+		finishReason = &static
 	} else {
 		// Use a static string to avoid allocation
 		static := "stop"
@@ -840,6 +926,18 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 	mockResponse := &schemas.BifrostResponse{}
 
 	if req.RequestType == schemas.ChatCompletionRequest || req.RequestType == schemas.ChatCompletionStreamRequest {
+		assistantMessage := &schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant}
+		// A message that only calls tools carries no content
+		if message != "" || len(content.ToolCalls) == 0 {
+			assistantMessage.Content = &schemas.ChatMessageContent{
+				ContentStr: &message,
+			}
+		}
+		if len(content.ToolCalls) > 0 {
+			assistantMessage.ChatAssistantMessage = &schemas.ChatAssistantMessage{
+				ToolCalls: p.buildChatToolCalls(content.ToolCalls),
+			}
+		}
 		mockResponse.ChatResponse = &schemas.BifrostChatResponse{
 			Model: model,
 			Usage: &usage,
@@ -847,12 +945,7 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 				{
 					Index: 0,
 					ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
-						Message: &schemas.ChatMessage{
-							Role: schemas.ChatMessageRoleAssistant,
-							Content: &schemas.ChatMessageContent{
-								ContentStr: &message,
-							},
-						},
+						Message: assistantMessage,
 					},
 					FinishReason: finishReason,
 				},
@@ -867,15 +960,7 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 	} else if req.RequestType == schemas.ResponsesRequest {
 		mockResponse.ResponsesResponse = &schemas.BifrostResponsesResponse{
 			CreatedAt: int(time.Now().Unix()),
-			Output: []schemas.ResponsesMessage{
-				{
-					Role: bifrost.Ptr(schemas.ResponsesInputMessageRoleAssistant),
-					Content: &schemas.ResponsesMessageContent{
-						ContentStr: &message,
-					},
-					Type: bifrost.Ptr(schemas.ResponsesMessageTypeMessage),
-				},
-			},
+			Output:    p.buildResponsesOutput(message, content.ToolCalls),
 			Usage: &schemas.ResponsesResponseUsage{
 				InputTokens:  usage.PromptTokens,
 				OutputTokens: usage.CompletionTokens,
@@ -894,15 +979,7 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 			SequenceNumber: 0,
 			Response: &schemas.BifrostResponsesResponse{
 				CreatedAt: int(time.Now().Unix()),
-				Output: []schemas.ResponsesMessage{
-					{
-						Role: bifrost.Ptr(schemas.ResponsesInputMessageRoleAssistant),
-						Content: &schemas.ResponsesMessageContent{
-							ContentStr: &message,
-						},
-						Type: bifrost.Ptr(schemas.ResponsesMessageTypeMessage),
-					},
-				},
+				Output:    p.buildResponsesOutput(message, content.ToolCalls),
 				Usage: &schemas.ResponsesResponseUsage{
 					InputTokens:  usage.PromptTokens,
 					OutputTokens: usage.CompletionTokens,
@@ -919,7 +996,7 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 	}
 
 	// Override model if specified
-	if content.Model != nil {
+	if content.Model != nil && mockResponse.ChatResponse != nil {
 		mockResponse.ChatResponse.Model = *content.Model
 	}
 
@@ -946,6 +1023,74 @@ func (p *MockerPlugin) generateSuccessShortCircuit(req *schemas.BifrostRequest,
 	}, nil
 }
 
+// buildChatToolCalls converts mock tool calls to chat completion tool calls
+func (p *MockerPlugin) buildChatToolCalls(toolCalls []ToolCall) []schemas.ChatAssistantMessageToolCall {
+	chatToolCalls := make([]schemas.ChatAssistantMessageToolCall, 0, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		chatToolCalls = append(chatToolCalls, schemas.ChatAssistantMessageToolCall{
+			Index: uint16(i),
+			Type:  bifrost.Ptr("function"),
+			ID:    p.toolCallID(toolCall),
+			Function: schemas.ChatAssistantMessageToolCallFunction{
+				Name:      bifrost.Ptr(toolCall.Name),
+				Arguments: toolCall.arguments(),
+			},
+		})
+	}
+	return chatToolCalls
+}
+
+// buildResponsesOutput builds responses API output items: the message (unless the
+// assistant only calls tools) followed by one function_call item per tool call
+func (p *MockerPlugin) buildResponsesOutput(message string, toolCalls []ToolCall) []schemas.ResponsesMessage {
+	output := make([]schemas.ResponsesMessage, 0, len(toolCalls)+1)
+	if message != "" || len(toolCalls) == 0 {
+		output = append(output, schemas.ResponsesMessage{
+			Role: bifrost.Ptr(schemas.ResponsesInputMessageRoleAssistant),
+			Content: &schemas.ResponsesMessageContent{
+				ContentStr: &message,
+			},
+			Type: bifrost.Ptr(schemas.ResponsesMessageTypeMessage),
+		})
+	}
+	for _, toolCall := range toolCalls {
+		output = append(output, schemas.ResponsesMessage{
+			Type:   bifrost.Ptr(schemas.ResponsesMessageTypeFunctionCall),
+			Status: bifrost.Ptr("completed"),
+			ResponsesToolMessage: &schemas.ResponsesToolMessage{
+				CallID:    p.toolCallID(toolCall),
+				Name:      bifrost.Ptr(toolCall.Name),
+				Arguments: bifrost.Ptr(toolCall.arguments()),
+			},
+		})
+	}
+	return output
+}
+
+// toolCallID returns the configured tool call ID, or a generated one
+func (p *MockerPlugin) toolCallID(toolCall ToolCall) *string {
+	if toolCall.ID != nil {
+		return toolCall.ID
+	}
+	return bifrost.Ptr("call_" + strings.ReplaceAll(p.faker.UUID().V4(), "-", ""))
+}
+
+// arguments returns the tool call arguments as the JSON text providers send
+func (toolCall ToolCall) arguments() string {
+	arguments := bytes.TrimSpace(toolCall.Arguments)
+	if len(arguments) == 0 {
+		return "{}"
+	}
+	// Arguments given as a JSON-encoded string are sent as that string
+	if arguments[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(arguments, &encoded); err == nil {
+			return encoded
+		}
+	}
+	return string(arguments)
+}
+
 // generateErrorShortCircuit creates an error response short-circuit with optimized performance
 func (p *MockerPlugin) generateErrorShortCircuit(req *schemas.BifrostRequest, response *Response) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
 	if response.Error == nil {
@@ -994,16 +1139,17 @@ func (p *MockerPlugin) generateErrorShortCircuit(req *schemas.BifrostRequest, re
 }
 
 // selectResponse selects a response using pre-calculated normalized weights for optimal performance
-func (p *MockerPlugin) selectResponse(rule *compiledRule) *Response {
+// and returns it along with its index in the rule's responses
+func (p *MockerPlugin) selectResponse(rule *compiledRule) (*Response, int) {
 	responses := rule.Responses
 	normalizedWeights := rule.normalizedWeights
 
 	if len(responses) == 0 {
-		return nil
+		return nil, -1
 	}
 
 	if len(responses) == 1 {
-		return &responses[0]
+		return &responses[0], 0
 	}
 
 	// Fast O(log n) binary search using pre-calculated cumulative weights
@@ -1020,7 +1166,31 @@ func (p *MockerPlugin) selectResponse(rule *compiledRule) *Response {
 		}
 	}
 
-	return &responses[left]
+	return &responses[left], left
+}
+
+// scenarioTurn returns the content a response answers with: for a scripted scenario,
+// the turn matching how many requests the response has answered so far, with unset
+// Model, Usage and CustomFields taken from the scenario; otherwise content itself
+func (rule *compiledRule) scenarioTurn(responseIndex int, content *SuccessResponse) *SuccessResponse {
+	if content == nil || len(content.Turns) == 0 || responseIndex < 0 || responseIndex >= len(rule.turnCounters) {
+		return content
+	}
+	turnIndex := int(rule.turnCounters[responseIndex].Add(1) - 1)
+	if turnIndex >= len(content.Turns) {
+		turnIndex = len(content.Turns) - 1
+	}
+	turn := content.Turns[turnIndex]
+	if turn.Model == nil {
+		turn.Model = content.Model
+	}
+	if turn.Usage == nil {
+		turn.Usage = content.Usage
+	}
+	if turn.CustomFields == nil {
+		turn.CustomFields = content.CustomFields
+	}
+	return &turn
 }
 
 // getLatency returns the applicable latency configuration
@@ -1265,3 +1435,90 @@ func (p *MockerPlugin) GetStats() MockStats {
 
 	return statsCopy
 }
+
+// ResetStats clears all statistics while keeping the rules in place
+func (p *MockerPlugin) ResetStats() {
+	atomic.StoreInt64(&p.totalRequests, 0)
+	atomic.StoreInt64(&p.mockedRequests, 0)
+	atomic.StoreInt64(&p.responsesGenerated, 0)
+	atomic.StoreInt64(&p.errorsGenerated, 0)
+
+	p.ruleHitsMu.Lock()
+	p.ruleHits = make(map[string]int64)
+	p.ruleHitsMu.Unlock()
+}
+
+// The rule management methods below change the rules of a running plugin. Changes are
+// in-memory only: re-initializing the plugin restores the configured rules.
+
+// ListRules returns the current rules in the order they were configured or added
+func (p *MockerPlugin) ListRules() []MockRule {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Clone(p.rules)
+}
+
+// SetRuleEnabled enables or disables the rule with the given name
+// Scripted scenarios keep their position across toggles
+func (p *MockerPlugin) SetRuleEnabled(name string, enabled bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	index := slices.IndexFunc(p.rules, func(rule MockRule) bool { return rule.Name == name })
+	if index < 0 {
+		return ErrRuleNotFound
+	}
+
+	rules := slices.Clone(p.rules)
+	rules[index].Enabled = enabled
+	compiledRules := slices.Clone(p.compiledRules)
+	for i := range compiledRules {
+		if compiledRules[i].Name == name {
+			compiledRules[i].Enabled = enabled
+		}
+	}
+
+	p.rules = rules
+	p.compiledRules = compiledRules
+	return nil
+}
+
+// AddRule validates and adds a rule, which takes effect for the next request
+func (p *MockerPlugin) AddRule(rule MockRule) error {
+	if err := validateRule(rule); err != nil {
+		return fmt.Errorf("invalid rule (%s): %w", rule.Name, err)
+	}
+	compiled, err := p.compileRule(rule)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if slices.ContainsFunc(p.rules, func(existing MockRule) bool { return existing.Name == rule.Name }) {
+		return ErrRuleExists
+	}
+
+	p.rules = append(slices.Clone(p.rules), rule)
+	p.compiledRules = append(slices.Clone(p.compiledRules), compiled)
+	p.sortCompiledRulesByPriority()
+	return nil
+}
+
+// RemoveRule removes the rule with the given name
+// Its hits remain in the statistics until they are reset
+func (p *MockerPlugin) RemoveRule(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rules := slices.DeleteFunc(slices.Clone(p.rules), func(rule MockRule) bool { return rule.Name == name })
+	if len(rules) == len(p.rules) {
+		return ErrRuleNotFound
+	}
+
+	p.rules = rules
+	p.compiledRules = slices.DeleteFunc(slices.Clone(p.compiledRules), func(rule compiledRule) bool { return rule.Name == name })
+	return nil
+}
@@ -2,6 +2,8 @@ package mocker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	bifrost "github.com/maximhq/bifrost/core"
@@ -493,6 +495,50 @@ func TestMockerPlugin_ValidationErrors(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "tool call without name",
+			config: MockerConfig{
+				Enabled: true,
+				Rules: []MockRule{
+					{
+						Name:    "test",
+						Enabled: true,
+						Responses: []Response{
+							{
+								Type: ResponseTypeSuccess,
+								Content: &SuccessResponse{
+									ToolCalls: []ToolCall{{Arguments: json.RawMessage(`{}`)}},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "nested scenario turns",
+			config: MockerConfig{
+				Enabled: true,
+				Rules: []MockRule{
+					{
+						Name:    "test",
+						Enabled: true,
+						Responses: []Response{
+							{
+								Type: ResponseTypeSuccess,
+								Content: &SuccessResponse{
+									Turns: []SuccessResponse{
+										{Turns: []SuccessResponse{{Message: "test"}}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "valid configuration",
 			config: MockerConfig{
@@ -530,3 +576,260 @@ func TestMockerPlugin_ValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+// TestMockerPlugin_ScenarioTurns tests a scripted tool-call scenario: the first request
+// gets a tool call, the second the final answer, and later requests repeat the last turn
+func TestMockerPlugin_ScenarioTurns(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	config := MockerConfig{
+		Enabled: true,
+		Rules: []MockRule{
+			{
+				Name:        "weather-agent",
+				Enabled:     true,
+				Probability: 1.0,
+				Responses: []Response{
+					{
+						Type: ResponseTypeSuccess,
+						Content: &SuccessResponse{
+							Model: bifrost.Ptr("mock-agent"),
+							Turns: []SuccessResponse{
+								{
+									ToolCalls: []ToolCall{
+										{
+											ID:        bifrost.Ptr("call_weather"),
+											Name:      "get_weather",
+											Arguments: json.RawMessage(`{"city": "Paris"}`),
+										},
+									},
+								},
+								{Message: "It is sunny in Paris."},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	plugin, err := Init(config)
+	if err != nil {
+		t.Fatalf("Expected no error creating plugin, got: %v", err)
+	}
+
+	account := BaseAccount{}
+	client, err := bifrost.Init(ctx, schemas.BifrostConfig{
+		Account:    &account,
+		LLMPlugins: []schemas.LLMPlugin{plugin},
+		Logger:     bifrost.NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("Error initializing Bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	request := &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4",
+		Input: []schemas.ChatMessage{
+			{
+				Role: schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{
+					ContentStr: bifrost.Ptr("What is the weather in Paris?"),
+				},
+			},
+		},
+	}
+
+	response, bifrostErr := client.ChatCompletionRequest(ctx, request)
+	if bifrostErr != nil {
+		t.Fatalf("Expected no error, got: %v", bifrostErr)
+	}
+	if response.Model != "mock-agent" {
+		t.Errorf("Expected the scenario model to apply to its turns, got: %s", response.Model)
+	}
+	choice := response.Choices[0]
+	if choice.FinishReason == nil || *choice.FinishReason != "tool_calls" {
+		t.Errorf("Expected finish reason tool_calls, got: %v", choice.FinishReason)
+	}
+	message := choice.ChatNonStreamResponseChoice.Message
+	if message.ChatAssistantMessage == nil || len(message.ChatAssistantMessage.ToolCalls) != 1 {
+		t.Fatal("Expected one tool call in the first turn")
+	}
+	toolCall := message.ChatAssistantMessage.ToolCalls[0]
+	if *toolCall.ID != "call_weather" || *toolCall.Function.Name != "get_weather" || toolCall.Function.Arguments != `{"city": "Paris"}` {
+		t.Errorf("Unexpected tool call: id=%s name=%s arguments=%s", *toolCall.ID, *toolCall.Function.Name, toolCall.Function.Arguments)
+	}
+
+	for i := 0; i < 2; i++ {
+		response, bifrostErr = client.ChatCompletionRequest(ctx, request)
+		if bifrostErr != nil {
+			t.Fatalf("Expected no error, got: %v", bifrostErr)
+		}
+		message = response.Choices[0].ChatNonStreamResponseChoice.Message
+		if message.Content == nil || message.Content.ContentStr == nil || *message.Content.ContentStr != "It is sunny in Paris." {
+			t.Fatalf("Expected the final answer on request %d", i+2)
+		}
+		if message.ChatAssistantMessage != nil && len(message.ChatAssistantMessage.ToolCalls) > 0 {
+			t.Errorf("Expected no tool calls on request %d", i+2)
+		}
+	}
+}
+
+// TestMockerPlugin_JSONContent tests structured content returned as the message's JSON text
+func TestMockerPlugin_JSONContent(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	config := MockerConfig{
+		Enabled: true,
+		Rules: []MockRule{
+			{
+				Name:        "structured",
+				Enabled:     true,
+				Probability: 1.0,
+				Responses: []Response{
+					{
+						Type: ResponseTypeSuccess,
+						Content: &SuccessResponse{
+							Message:     "ignored",
+							JSONContent: map[string]interface{}{"answer": 42},
+						},
+					},
+				},
+			},
+		},
+	}
+	plugin, err := Init(config)
+	if err != nil {
+		t.Fatalf("Expected no error creating plugin, got: %v", err)
+	}
+
+	account := BaseAccount{}
+	client, err := bifrost.Init(ctx, schemas.BifrostConfig{
+		Account:    &account,
+		LLMPlugins: []schemas.LLMPlugin{plugin},
+		Logger:     bifrost.NewDefaultLogger(schemas.LogLevelError),
+	})
+	if err != nil {
+		t.Fatalf("Error initializing Bifrost: %v", err)
+	}
+	defer client.Shutdown()
+
+	response, bifrostErr := client.ChatCompletionRequest(ctx, &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    "gpt-4",
+		Input: []schemas.ChatMessage{
+			{
+				Role: schemas.ChatMessageRoleUser,
+				Content: &schemas.ChatMessageContent{
+					ContentStr: bifrost.Ptr("Answer in JSON"),
+				},
+			},
+		},
+	})
+	if bifrostErr != nil {
+		t.Fatalf("Expected no error, got: %v", bifrostErr)
+	}
+	content := response.Choices[0].ChatNonStreamResponseChoice.Message.Content
+	if content == nil || content.ContentStr == nil || *content.ContentStr != `{"answer":42}` {
+		t.Errorf("Expected JSON content, got: %v", content)
+	}
+}
+
+// TestMockerPlugin_RuleManagement tests adding, toggling and removing rules at runtime
+func TestMockerPlugin_RuleManagement(t *testing.T) {
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	plugin, err := Init(MockerConfig{
+		Enabled:         true,
+		DefaultBehavior: DefaultBehaviorSuccess,
+		Rules: []MockRule{
+			{
+				Name:        "base",
+				Enabled:     true,
+				Priority:    1,
+				Probability: 1.0,
+				Responses: []Response{
+					{Type: ResponseTypeSuccess, Content: &SuccessResponse{Message: "base response"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating plugin, got: %v", err)
+	}
+
+	outage := MockRule{
+		Name:        "outage",
+		Enabled:     true,
+		Priority:    100,
+		Probability: 1.0,
+		Responses: []Response{
+			{
+				Type: ResponseTypeError,
+				Error: &ErrorResponse{
+					Message:    "Service unavailable",
+					StatusCode: bifrost.Ptr(503),
+				},
+			},
+		},
+	}
+	if err := plugin.AddRule(outage); err != nil {
+		t.Fatalf("Expected no error adding rule, got: %v", err)
+	}
+	if err := plugin.AddRule(outage); !errors.Is(err, ErrRuleExists) {
+		t.Errorf("Expected ErrRuleExists for a duplicate rule, got: %v", err)
+	}
+	if err := plugin.AddRule(MockRule{Name: "empty", Enabled: true}); err == nil {
+		t.Error("Expected an invalid rule to be rejected")
+	}
+	if rules := plugin.ListRules(); len(rules) != 2 || rules[1].Name != "outage" {
+		t.Fatalf("Expected the base and outage rules, got: %+v", rules)
+	}
+
+	request := &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4",
+			Input: []schemas.ChatMessage{
+				{
+					Role: schemas.ChatMessageRoleUser,
+					Content: &schemas.ChatMessageContent{
+						ContentStr: bifrost.Ptr("Hello"),
+					},
+				},
+			},
+		},
+	}
+	_, shortCircuit, err := plugin.PreLLMHook(ctx, request)
+	if err != nil || shortCircuit == nil || shortCircuit.Error == nil {
+		t.Fatalf("Expected the outage rule to inject an error, got: %+v, %v", shortCircuit, err)
+	}
+
+	if err := plugin.SetRuleEnabled("outage", false); err != nil {
+		t.Fatalf("Expected no error disabling rule, got: %v", err)
+	}
+	_, shortCircuit, _ = plugin.PreLLMHook(ctx, request)
+	if shortCircuit == nil || shortCircuit.Response == nil {
+		t.Fatal("Expected the base rule to answer once the outage rule is disabled")
+	}
+
+	if err := plugin.RemoveRule("outage"); err != nil {
+		t.Fatalf("Expected no error removing rule, got: %v", err)
+	}
+	if err := plugin.RemoveRule("outage"); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("Expected ErrRuleNotFound removing twice, got: %v", err)
+	}
+	if err := plugin.SetRuleEnabled("outage", true); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("Expected ErrRuleNotFound enabling a removed rule, got: %v", err)
+	}
+
+	if stats := plugin.GetStats(); stats.TotalRequests != 2 || stats.RuleHits["outage"] != 1 {
+		t.Errorf("Expected 2 requests and 1 outage hit, got: %+v", stats)
+	}
+	plugin.ResetStats()
+	if stats := plugin.GetStats(); stats.TotalRequests != 0 || len(stats.RuleHits) != 0 {
+		t.Errorf("Expected empty stats after reset, got: %+v", stats)
+	}
+	if rules := plugin.ListRules(); len(rules) != 1 {
+		t.Errorf("Expected resetting stats to keep the rules, got: %+v", rules)
+	}
+}
@@ -0,0 +1,140 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultOpenAIEndpoint is the base URL of the OpenAI moderations API.
+	DefaultOpenAIEndpoint = "https://api.openai.com"
+	// DefaultOpenAIModel is the OpenAI moderation model used when none is set.
+	DefaultOpenAIModel = "omni-moderation-latest"
+	// azureAPIVersion is the Azure AI Content Safety API version called.
+	azureAPIVersion = "2024-09-01"
+	// azureMaxTextRunes is the longest text Azure Content Safety analyzes in one call;
+	// longer texts are analyzed in chunks and the highest score per category is kept.
+	azureMaxTextRunes = 10000
+	// azureMaxSeverity is the highest severity of the four-level output, used to
+	// normalize severities to scores.
+	azureMaxSeverity = 6
+	// maxErrorBodyBytes is how much of an error response is quoted in the error.
+	maxErrorBodyBytes = 512
+)
+
+// scorer scores a text per moderation category, from 0 to 1.
+type scorer interface {
+	score(ctx context.Context, text string) (map[string]float64, error)
+}
+
+// openAIScorer calls the OpenAI moderations endpoint.
+type openAIScorer struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+	model    string
+}
+
+func (s *openAIScorer) score(ctx context.Context, text string) (map[string]float64, error) {
+	var resp struct {
+		Results []struct {
+			CategoryScores map[string]float64 `json:"category_scores"`
+		} `json:"results"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + s.apiKey}
+	if err := postJSON(ctx, s.client, s.endpoint+"/v1/moderations", headers, map[string]string{"model": s.model, "input": text}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 {
+		return nil, fmt.Errorf("openai moderation returned no results")
+	}
+	return resp.Results[0].CategoryScores, nil
+}
+
+// azureScorer calls the text analysis endpoint of Azure AI Content Safety.
+type azureScorer struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+}
+
+// azureCategories maps Azure category names to the OpenAI names, so thresholds are
+// configured the same way for both services.
+var azureCategories = map[string]string{
+	"Hate":     "hate",
+	"SelfHarm": "self-harm",
+	"Sexual":   "sexual",
+	"Violence": "violence",
+}
+
+func (s *azureScorer) score(ctx context.Context, text string) (map[string]float64, error) {
+	scores := make(map[string]float64, len(azureCategories))
+	headers := map[string]string{"Ocp-Apim-Subscription-Key": s.apiKey}
+	url := s.endpoint + "/contentsafety/text:analyze?api-version=" + azureAPIVersion
+	for _, chunk := range chunkRunes(text, azureMaxTextRunes) {
+		var resp struct {
+			CategoriesAnalysis []struct {
+				Category string `json:"category"`
+				Severity int    `json:"severity"`
+			} `json:"categoriesAnalysis"`
+		}
+		if err := postJSON(ctx, s.client, url, headers, map[string]string{"text": chunk, "outputType": "FourSeverityLevels"}, &resp); err != nil {
+			return nil, err
+		}
+		for _, analysis := range resp.CategoriesAnalysis {
+			category, ok := azureCategories[analysis.Category]
+			if !ok {
+				category = strings.ToLower(analysis.Category)
+			}
+			scores[category] = max(scores[category], min(float64(analysis.Severity)/azureMaxSeverity, 1))
+		}
+	}
+	return scores, nil
+}
+
+// postJSON posts body as JSON to url and decodes a 2xx response into out.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("moderation service returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid moderation response: %w", err)
+	}
+	return nil
+}
+
+// chunkRunes splits text into pieces of at most n runes.
+func chunkRunes(text string, n int) []string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return []string{text}
+	}
+	var chunks []string
+	for start := 0; start < len(runes); start += n {
+		chunks = append(chunks, string(runes[start:min(start+n, len(runes))]))
+	}
+	return chunks
+}
@@ -0,0 +1,326 @@
+// Package moderation provides an LLM plugin that checks request inputs and/or response
+// outputs with a content moderation service, OpenAI's moderations endpoint or Azure AI
+// Content Safety, and blocks any text whose score in a category reaches that category's
+// threshold. A blocked request or response gets a policy error whose
+// extra_fields.moderation holds the score of every category that was checked.
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "moderation"
+
+const (
+	// ErrCodeContentFlagged is the error code of a request or response blocked by moderation.
+	ErrCodeContentFlagged = "content_flagged"
+	// ErrCodeModerationUnavailable is the error code of a request blocked because the
+	// moderation service failed and the plugin fails closed.
+	ErrCodeModerationUnavailable = "moderation_unavailable"
+)
+
+const (
+	// DefaultThreshold is the score at or above which a category is flagged, for categories
+	// without a threshold of their own.
+	DefaultThreshold = 0.5
+	// DefaultTimeout bounds a call to the moderation service.
+	DefaultTimeout = 5 * time.Second
+)
+
+// Service is a content moderation service.
+type Service string
+
+const (
+	ServiceOpenAI Service = "openai"
+	ServiceAzure  Service = "azure"
+)
+
+// Config configures the moderation plugin.
+type Config struct {
+	// Service defaults to ServiceOpenAI.
+	Service Service            `json:"service,omitempty"`
+	APIKey  *schemas.SecretVar `json:"api_key"`
+	// Endpoint is the base URL of the OpenAI API (default DefaultOpenAIEndpoint), or the
+	// endpoint of the Azure Content Safety resource, which is required.
+	Endpoint *schemas.SecretVar `json:"endpoint,omitempty"`
+	// Model is the OpenAI moderation model (default DefaultOpenAIModel).
+	Model string `json:"model,omitempty"`
+	// CheckInput checks the user messages of each request; CheckOutput checks the text of
+	// non-streaming responses. At least one must be set.
+	CheckInput  bool `json:"check_input,omitempty"`
+	CheckOutput bool `json:"check_output,omitempty"`
+	// DefaultThreshold (0 uses DefaultThreshold) applies to categories missing from
+	// Thresholds. A threshold above 1 never flags its category.
+	DefaultThreshold float64            `json:"default_threshold,omitempty"`
+	Thresholds       map[string]float64 `json:"thresholds,omitempty"`
+	// Timeout (e.g. "3s") defaults to DefaultTimeout.
+	Timeout string `json:"timeout,omitempty"`
+	// FailClosed blocks requests when the moderation service fails. By default they are
+	// let through unchecked.
+	FailClosed bool `json:"fail_closed,omitempty"`
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	service          Service
+	scorer           scorer
+	checkInput       bool
+	checkOutput      bool
+	defaultThreshold float64
+	thresholds       map[string]float64
+	timeout          time.Duration
+	failClosed       bool
+	logger           schemas.Logger
+}
+
+// Init validates config and returns the plugin.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	if !config.CheckInput && !config.CheckOutput {
+		return nil, fmt.Errorf("moderation: set check_input and/or check_output")
+	}
+	apiKey := schemas.SecretVarAsString(config.APIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("moderation: api_key is required")
+	}
+	p := &Plugin{
+		service:          config.Service,
+		checkInput:       config.CheckInput,
+		checkOutput:      config.CheckOutput,
+		defaultThreshold: config.DefaultThreshold,
+		thresholds:       config.Thresholds,
+		timeout:          DefaultTimeout,
+		failClosed:       config.FailClosed,
+		logger:           logger,
+	}
+	if p.defaultThreshold == 0 {
+		p.defaultThreshold = DefaultThreshold
+	}
+	if p.defaultThreshold < 0 {
+		return nil, fmt.Errorf("moderation: default_threshold cannot be negative")
+	}
+	for category, threshold := range config.Thresholds {
+		if threshold <= 0 {
+			return nil, fmt.Errorf("moderation: threshold of %q must be positive", category)
+		}
+	}
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("moderation: invalid timeout %q", config.Timeout)
+		}
+		p.timeout = timeout
+	}
+	client := &http.Client{Timeout: p.timeout}
+	endpoint := strings.TrimRight(schemas.SecretVarAsString(config.Endpoint), "/")
+	switch config.Service {
+	case "", ServiceOpenAI:
+		p.service = ServiceOpenAI
+		if endpoint == "" {
+			endpoint = DefaultOpenAIEndpoint
+		}
+		model := config.Model
+		if model == "" {
+			model = DefaultOpenAIModel
+		}
+		p.scorer = &openAIScorer{client: client, endpoint: endpoint, apiKey: apiKey, model: model}
+	case ServiceAzure:
+		if endpoint == "" {
+			return nil, fmt.Errorf("moderation: endpoint is required for azure")
+		}
+		p.scorer = &azureScorer{client: client, endpoint: endpoint, apiKey: apiKey}
+	default:
+		return nil, fmt.Errorf("moderation: unknown service %q", config.Service)
+	}
+	return p, nil
+}
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook implements schemas.LLMPlugin. Inputs are checked per attempt in PreLLMHook.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook checks the user messages of the request and blocks it when a category is
+// flagged.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	if !p.checkInput {
+		return req, nil, nil
+	}
+	text := inputText(req)
+	if text == "" {
+		return req, nil, nil
+	}
+	if bifrostErr := p.check(ctx, "request", text); bifrostErr != nil {
+		return req, &schemas.LLMPluginShortCircuit{Error: bifrostErr}, nil
+	}
+	return req, nil, nil
+}
+
+// PostLLMHook checks the text of a non-streaming response and replaces the response with
+// an error when a category is flagged. Streaming responses are not checked.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	if !p.checkOutput || bifrostErr != nil || result == nil {
+		return result, bifrostErr, nil
+	}
+	if extra := result.GetExtraFields(); extra != nil {
+		switch extra.RequestType {
+		case schemas.ChatCompletionStreamRequest, schemas.TextCompletionStreamRequest, schemas.ResponsesStreamRequest:
+			return result, bifrostErr, nil
+		}
+	}
+	text := outputText(result)
+	if text == "" {
+		return result, bifrostErr, nil
+	}
+	if blocked := p.check(ctx, "response", text); blocked != nil {
+		return nil, blocked, nil
+	}
+	return result, bifrostErr, nil
+}
+
+// check scores text and returns the error to block it with, or nil to let it through.
+func (p *Plugin) check(ctx *schemas.BifrostContext, source, text string) *schemas.BifrostError {
+	callCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	scores, err := p.scorer.score(callCtx, text)
+	if err != nil {
+		if p.failClosed {
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("%s moderation failed (%v), failing closed", source, err))
+			return moderationError(http.StatusServiceUnavailable, ErrCodeModerationUnavailable, fmt.Sprintf("%s blocked: content moderation is unavailable", source), nil)
+		}
+		ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("%s moderation failed (%v), letting the %s through", source, err, source))
+		return nil
+	}
+	result := &schemas.ModerationResult{Source: source, Provider: string(p.service), CategoryScores: scores, Flagged: []string{}}
+	for category, score := range scores {
+		if score >= p.threshold(category) {
+			result.Flagged = append(result.Flagged, category)
+		}
+	}
+	if len(result.Flagged) == 0 {
+		return nil
+	}
+	sort.Strings(result.Flagged)
+	flagged := make([]string, len(result.Flagged))
+	for i, category := range result.Flagged {
+		flagged[i] = fmt.Sprintf("%s %.2f", category, scores[category])
+	}
+	ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("blocked the %s: flagged %s", source, strings.Join(flagged, ", ")))
+	return moderationError(http.StatusBadRequest, ErrCodeContentFlagged, fmt.Sprintf("%s blocked by content moderation: %s", source, strings.Join(flagged, ", ")), result)
+}
+
+// threshold returns the score at or above which category is flagged.
+func (p *Plugin) threshold(category string) float64 {
+	if threshold, ok := p.thresholds[category]; ok {
+		return threshold
+	}
+	return p.defaultThreshold
+}
+
+// inputText joins the text of the request's user messages, or its text completion prompt.
+func inputText(req *schemas.BifrostRequest) string {
+	var parts []string
+	switch {
+	case req.ChatRequest != nil:
+		for _, msg := range req.ChatRequest.Input {
+			if msg.Role != schemas.ChatMessageRoleUser || msg.Content == nil {
+				continue
+			}
+			if msg.Content.ContentStr != nil {
+				parts = append(parts, *msg.Content.ContentStr)
+			}
+			for _, block := range msg.Content.ContentBlocks {
+				if block.Text != nil {
+					parts = append(parts, *block.Text)
+				}
+			}
+		}
+	case req.ResponsesRequest != nil:
+		for _, msg := range req.ResponsesRequest.Input {
+			if msg.Role == nil || *msg.Role != schemas.ResponsesInputMessageRoleUser || msg.Content == nil {
+				continue
+			}
+			parts = append(parts, responsesText(msg.Content)...)
+		}
+	case req.TextCompletionRequest != nil && req.TextCompletionRequest.Input != nil:
+		if prompt := req.TextCompletionRequest.Input.PromptStr; prompt != nil {
+			parts = append(parts, *prompt)
+		}
+		parts = append(parts, req.TextCompletionRequest.Input.PromptArray...)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// outputText joins the text of a chat, text completion or responses response.
+func outputText(result *schemas.BifrostResponse) string {
+	var parts []string
+	switch {
+	case result.ChatResponse != nil:
+		for _, choice := range result.ChatResponse.Choices {
+			if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil || choice.Message.Content == nil {
+				continue
+			}
+			if content := choice.Message.Content; content.ContentStr != nil {
+				parts = append(parts, *content.ContentStr)
+			} else {
+				for _, block := range content.ContentBlocks {
+					if block.Text != nil {
+						parts = append(parts, *block.Text)
+					}
+				}
+			}
+		}
+	case result.TextCompletionResponse != nil:
+		for _, choice := range result.TextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice != nil && choice.Text != nil {
+				parts = append(parts, *choice.Text)
+			}
+		}
+	case result.ResponsesResponse != nil:
+		for _, msg := range result.ResponsesResponse.Output {
+			if msg.Content != nil {
+				parts = append(parts, responsesText(msg.Content)...)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func responsesText(content *schemas.ResponsesMessageContent) []string {
+	if content.ContentStr != nil {
+		return []string{*content.ContentStr}
+	}
+	var parts []string
+	for _, block := range content.ContentBlocks {
+		if block.Text != nil {
+			parts = append(parts, *block.Text)
+		}
+	}
+	return parts
+}
+
+func moderationError(status int, code, message string, result *schemas.ModerationResult) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(status),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("invalid_request_error"),
+			Code:    schemas.Ptr(code),
+			Message: message,
+		},
+		AllowFallbacks: schemas.Ptr(false),
+		ExtraFields:    schemas.BifrostErrorExtraFields{Moderation: result},
+	}
+}
@@ -0,0 +1,203 @@
+package moderation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func chatRequest(text string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o", Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("Be helpful.")}},
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+		}},
+	}
+}
+
+func chatResponse(requestType schemas.RequestType, text string) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+			Message: &schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+		}}},
+		ExtraFields: schemas.BifrostResponseExtraFields{RequestType: requestType},
+	}}
+}
+
+func newContext() *schemas.BifrostContext {
+	return schemas.NewBifrostContext(nil, schemas.NoDeadline)
+}
+
+// openAIServer answers moderation calls with a high "violence" score for inputs that
+// mention a fight, and records the inputs.
+func openAIServer(t *testing.T, inputs *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/moderations" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad request", http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Model string `json:"model"`
+			Input string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Model != DefaultOpenAIModel {
+			http.Error(w, "bad body", http.StatusBadRequest)
+			return
+		}
+		*inputs = append(*inputs, body.Input)
+		violence := 0.01
+		if strings.Contains(body.Input, "fight") {
+			violence = 0.93
+		}
+		json.NewEncoder(w).Encode(map[string]any{"results": []map[string]any{{
+			"category_scores": map[string]float64{"violence": violence, "hate": 0.3},
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestInputModerationBlocksFlaggedCategories(t *testing.T) {
+	var inputs []string
+	server := openAIServer(t, &inputs)
+	p, err := Init(Config{APIKey: schemas.NewSecretVar("sk-test"), Endpoint: schemas.NewSecretVar(server.URL + "/"), CheckInput: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, shortCircuit, _ := p.PreLLMHook(newContext(), chatRequest("how do I win a fight"))
+	if shortCircuit == nil || shortCircuit.Error == nil {
+		t.Fatal("expected the flagged request to be blocked")
+	}
+	bifrostErr := shortCircuit.Error
+	if *bifrostErr.StatusCode != http.StatusBadRequest || *bifrostErr.Error.Code != ErrCodeContentFlagged || *bifrostErr.AllowFallbacks {
+		t.Fatalf("expected a 400 policy error without fallbacks, got %+v", bifrostErr)
+	}
+	result := bifrostErr.ExtraFields.Moderation
+	if result == nil || result.Source != "request" || result.Provider != "openai" || len(result.Flagged) != 1 || result.Flagged[0] != "violence" || result.CategoryScores["hate"] != 0.3 {
+		t.Fatalf("expected the category scores on the error, got %+v", result)
+	}
+	if inputs[0] != "how do I win a fight" {
+		t.Fatalf("expected only the user message to be moderated, got %q", inputs[0])
+	}
+
+	if _, shortCircuit, _ = p.PreLLMHook(newContext(), chatRequest("how do I bake bread")); shortCircuit != nil {
+		t.Fatalf("expected a benign request to pass, got %q", shortCircuit.Error.Error.Message)
+	}
+
+	// Per category thresholds override the default.
+	p.thresholds = map[string]float64{"violence": 1.1, "hate": 0.25}
+	_, shortCircuit, _ = p.PreLLMHook(newContext(), chatRequest("how do I win a fight"))
+	if shortCircuit == nil || len(shortCircuit.Error.ExtraFields.Moderation.Flagged) != 1 || shortCircuit.Error.ExtraFields.Moderation.Flagged[0] != "hate" {
+		t.Fatalf("expected only hate to be flagged, got %+v", shortCircuit)
+	}
+
+	// Responses are not checked unless check_output is set.
+	response := chatResponse(schemas.ChatCompletionRequest, "a fight scene")
+	if out, bifrostErr, _ := p.PostLLMHook(newContext(), response, nil); out != response || bifrostErr != nil {
+		t.Fatal("expected the response to pass unchecked")
+	}
+}
+
+func TestOutputModeration(t *testing.T) {
+	var inputs []string
+	server := openAIServer(t, &inputs)
+	p, err := Init(Config{APIKey: schemas.NewSecretVar("sk-test"), Endpoint: schemas.NewSecretVar(server.URL), CheckOutput: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, shortCircuit, _ := p.PreLLMHook(newContext(), chatRequest("a fight")); shortCircuit != nil || len(inputs) != 0 {
+		t.Fatal("expected the input to pass unchecked")
+	}
+
+	out, bifrostErr, _ := p.PostLLMHook(newContext(), chatResponse(schemas.ChatCompletionRequest, "the fight went on"), nil)
+	if out != nil || bifrostErr == nil || bifrostErr.ExtraFields.Moderation.Source != "response" {
+		t.Fatalf("expected the flagged response to be replaced with an error, got %+v", bifrostErr)
+	}
+	response := chatResponse(schemas.ChatCompletionStreamRequest, "the fight went on")
+	if out, bifrostErr, _ = p.PostLLMHook(newContext(), response, nil); out != response || bifrostErr != nil {
+		t.Fatal("expected stream chunks to pass unchecked")
+	}
+}
+
+func TestAzureContentSafety(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contentsafety/text:analyze" || r.URL.Query().Get("api-version") != azureAPIVersion || r.Header.Get("Ocp-Apim-Subscription-Key") != "azure-key" {
+			http.Error(w, "bad request", http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		texts = append(texts, body.Text)
+		selfHarm := 0
+		if strings.Contains(body.Text, "hurt") {
+			selfHarm = 4
+		}
+		json.NewEncoder(w).Encode(map[string]any{"categoriesAnalysis": []map[string]any{
+			{"category": "Hate", "severity": 0},
+			{"category": "SelfHarm", "severity": selfHarm},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := Init(Config{Service: ServiceAzure, APIKey: schemas.NewSecretVar("azure-key"), Endpoint: schemas.NewSecretVar(server.URL), CheckInput: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A long text is analyzed in chunks and the highest severity wins.
+	text := strings.Repeat("a", azureMaxTextRunes) + " I want to hurt myself"
+	_, shortCircuit, _ := p.PreLLMHook(newContext(), chatRequest(text))
+	if len(texts) != 2 {
+		t.Fatalf("expected the text to be analyzed in 2 chunks, got %d", len(texts))
+	}
+	if shortCircuit == nil {
+		t.Fatal("expected the request to be blocked")
+	}
+	result := shortCircuit.Error.ExtraFields.Moderation
+	if result.Provider != "azure" || result.Flagged[0] != "self-harm" || result.CategoryScores["self-harm"] < 0.66 || result.CategoryScores["hate"] != 0 {
+		t.Fatalf("expected severities normalized under openai category names, got %+v", result)
+	}
+}
+
+func TestServiceFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	p, err := Init(Config{APIKey: schemas.NewSecretVar("sk-test"), Endpoint: schemas.NewSecretVar(server.URL), CheckInput: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, shortCircuit, _ := p.PreLLMHook(newContext(), chatRequest("hello")); shortCircuit != nil {
+		t.Fatal("a moderation failure should fail open by default")
+	}
+	p.failClosed = true
+	_, shortCircuit, _ := p.PreLLMHook(newContext(), chatRequest("hello"))
+	if shortCircuit == nil || *shortCircuit.Error.StatusCode != http.StatusServiceUnavailable || *shortCircuit.Error.Error.Code != ErrCodeModerationUnavailable {
+		t.Fatalf("a moderation failure should block when failing closed, got %+v", shortCircuit)
+	}
+}
+
+func TestInitRejectsInvalidConfig(t *testing.T) {
+	key := schemas.NewSecretVar("sk-test")
+	for name, config := range map[string]Config{
+		"nothing to check":       {APIKey: key},
+		"no api key":             {CheckInput: true},
+		"azure without endpoint": {Service: ServiceAzure, APIKey: key, CheckInput: true},
+		"unknown service":        {Service: "perspective", APIKey: key, CheckInput: true},
+		"zero category":          {APIKey: key, CheckInput: true, Thresholds: map[string]float64{"hate": 0}},
+		"bad timeout":            {APIKey: key, CheckInput: true, Timeout: "soon"},
+	} {
+		if _, err := Init(config, nil); err == nil || !strings.HasPrefix(err.Error(), "moderation:") {
+			t.Errorf("%s: expected a moderation error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxExportBatchSize is the number of traces sent to a collector per export request.
+	DefaultMaxExportBatchSize = 512
+	// DefaultScheduleDelayMs is how long a partially filled batch waits before it is flushed.
+	DefaultScheduleDelayMs = 5000
+	// DefaultExportMaxRetries is the number of retries after the first failed export attempt.
+	DefaultExportMaxRetries = 5
+	// DefaultExportInitialBackoffMs is the delay before the first retry.
+	DefaultExportInitialBackoffMs = 500
+	// DefaultExportMaxBackoffMs caps the exponential backoff between retries.
+	DefaultExportMaxBackoffMs = 30000
+)
+
+// ExportBatchConfig tunes how completed traces are batched and retried on their way to the
+// collectors. Traces are flushed once MaxExportBatchSize are queued or ScheduleDelayMs has
+// elapsed since the oldest one was queued. A batch that fails with a retryable error (the
+// collector is unreachable, overloaded or restarting) is retried with jittered exponential
+// backoff; once MaxRetries is exhausted its traces are dropped and counted.
+type ExportBatchConfig struct {
+	MaxExportBatchSize int `json:"max_export_batch_size,omitempty"` // default 512
+	ScheduleDelayMs    int `json:"schedule_delay_ms,omitempty"`     // default 5000
+	MaxRetries         int `json:"max_retries,omitempty"`           // default 5; -1 disables retries
+	InitialBackoffMs   int `json:"initial_backoff_ms,omitempty"`    // default 500
+	MaxBackoffMs       int `json:"max_backoff_ms,omitempty"`        // default 30000
+}
+
+// withDefaults returns a copy of the config with zero values replaced by defaults.
+// A nil config yields the defaults.
+func (c *ExportBatchConfig) withDefaults() ExportBatchConfig {
+	var out ExportBatchConfig
+	if c != nil {
+		out = *c
+	}
+	if out.MaxExportBatchSize <= 0 {
+		out.MaxExportBatchSize = DefaultMaxExportBatchSize
+	}
+	if out.ScheduleDelayMs <= 0 {
+		out.ScheduleDelayMs = DefaultScheduleDelayMs
+	}
+	if out.MaxRetries == 0 {
+		out.MaxRetries = DefaultExportMaxRetries
+	} else if out.MaxRetries < 0 {
+		out.MaxRetries = 0
+	}
+	if out.InitialBackoffMs <= 0 {
+		out.InitialBackoffMs = DefaultExportInitialBackoffMs
+	}
+	if out.MaxBackoffMs <= 0 {
+		out.MaxBackoffMs = max(DefaultExportMaxBackoffMs, out.InitialBackoffMs)
+	}
+	return out
+}
+
+// Validate checks the batch settings. A nil config is valid (defaults apply).
+func (c *ExportBatchConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxExportBatchSize < 0 || c.ScheduleDelayMs < 0 || c.InitialBackoffMs < 0 || c.MaxBackoffMs < 0 {
+		return fmt.Errorf("export_batch values must not be negative")
+	}
+	if c.MaxRetries < -1 {
+		return fmt.Errorf("export_batch.max_retries must be -1 (disabled) or greater, got %d", c.MaxRetries)
+	}
+	resolved := c.withDefaults()
+	if resolved.MaxBackoffMs < resolved.InitialBackoffMs {
+		return fmt.Errorf("export_batch.max_backoff_ms (%d) must be >= initial_backoff_ms (%d)", resolved.MaxBackoffMs, resolved.InitialBackoffMs)
+	}
+	return nil
+}
+
+// collectorStatusError is returned by the HTTP client when the collector answers with a
+// non-2xx status, so the retry policy can tell throttling and outages from rejections.
+type collectorStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *collectorStatusError) Error() string {
+	return "collector returned " + e.Status
+}
+
+// isRetryableExportError reports whether a failed export may succeed if attempted again.
+// Transport errors (connection refused, reset, timeouts) are retried, as are HTTP 408/429/5xx
+// and the equivalent gRPC codes. Malformed or rejected payloads are not.
+func isRetryableExportError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var statusErr *collectorStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= 500
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		switch s.Code() {
+		case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// exportBackoff returns the jittered delay before the given retry (1-based): the exponential
+// delay capped at max, scaled by a random factor in [0.5, 1.0) so that many instances
+// recovering from the same collector outage don't retry in lockstep.
+func exportBackoff(retry int, initial, max time.Duration) time.Duration {
+	d := initial
+	for i := 1; i < retry && d < max; i++ {
+		d *= 2
+	}
+	d = min(d, max)
+	return d/2 + rand.N(d/2+1)
+}
+
+// emitWithRetry emits rs to the client, retrying retryable failures with backoff. It stops
+// early when ctx is cancelled or stop is closed (plugin shutdown). onRetry is invoked before
+// every retry.
+func emitWithRetry(ctx context.Context, stop <-chan struct{}, client OtelClient, rs []*ResourceSpan, cfg ExportBatchConfig, onRetry func()) error {
+	initial := time.Duration(cfg.InitialBackoffMs) * time.Millisecond
+	maxBackoff := time.Duration(cfg.MaxBackoffMs) * time.Millisecond
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = client.Emit(ctx, rs); err == nil {
+			return nil
+		}
+		if attempt >= cfg.MaxRetries || !isRetryableExportError(err) {
+			return err
+		}
+		timer := time.NewTimer(exportBackoff(attempt+1, initial, maxBackoff))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-stop:
+			timer.Stop()
+			return err
+		}
+		if onRetry != nil {
+			onRetry()
+		}
+	}
+}
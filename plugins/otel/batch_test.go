@@ -0,0 +1,106 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyClient fails the first failures Emit calls with err.
+type flakyClient struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *flakyClient) Emit(context.Context, []*ResourceSpan) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	return nil
+}
+
+func (c *flakyClient) Close() error { return nil }
+
+func TestIsRetryableExportError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "transport error", err: errors.New("connection refused"), want: true},
+		{name: "http 503", err: &collectorStatusError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, want: true},
+		{name: "http 429", err: &collectorStatusError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}, want: true},
+		{name: "http 400", err: &collectorStatusError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}, want: false},
+		{name: "grpc unavailable", err: status.Error(codes.Unavailable, "down"), want: true},
+		{name: "grpc invalid argument", err: status.Error(codes.InvalidArgument, "bad"), want: false},
+		{name: "cancelled", err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableExportError(tt.err); got != tt.want {
+				t.Errorf("isRetryableExportError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmitWithRetry(t *testing.T) {
+	cfg := ExportBatchConfig{MaxRetries: 3, InitialBackoffMs: 1, MaxBackoffMs: 2}
+
+	t.Run("recovers after collector restart", func(t *testing.T) {
+		client := &flakyClient{failures: 2, err: errors.New("connection refused")}
+		retries := 0
+		if err := emitWithRetry(context.Background(), nil, client, nil, cfg, func() { retries++ }); err != nil {
+			t.Fatalf("expected success after retries, got %v", err)
+		}
+		if client.calls != 3 || retries != 2 {
+			t.Errorf("expected 3 calls and 2 retries, got %d and %d", client.calls, retries)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		client := &flakyClient{failures: 10, err: errors.New("connection refused")}
+		if err := emitWithRetry(context.Background(), nil, client, nil, cfg, nil); err == nil {
+			t.Fatal("expected error after exhausting retries")
+		}
+		if client.calls != 4 {
+			t.Errorf("expected 1 attempt + 3 retries, got %d calls", client.calls)
+		}
+	})
+
+	t.Run("does not retry rejected payloads", func(t *testing.T) {
+		client := &flakyClient{failures: 10, err: &collectorStatusError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}}
+		_ = emitWithRetry(context.Background(), nil, client, nil, cfg, nil)
+		if client.calls != 1 {
+			t.Errorf("expected a single attempt, got %d", client.calls)
+		}
+	})
+
+	t.Run("stops backing off on shutdown", func(t *testing.T) {
+		client := &flakyClient{failures: 10, err: errors.New("connection refused")}
+		stop := make(chan struct{})
+		close(stop)
+		slow := ExportBatchConfig{MaxRetries: 5, InitialBackoffMs: 60000, MaxBackoffMs: 60000}
+		start := time.Now()
+		_ = emitWithRetry(context.Background(), stop, client, nil, slow, nil)
+		if client.calls != 1 || time.Since(start) > time.Second {
+			t.Errorf("expected a single attempt without backoff, got %d calls in %s", client.calls, time.Since(start))
+		}
+	})
+}
+
+func TestExportBackoffIsCapped(t *testing.T) {
+	for retry := 1; retry <= 10; retry++ {
+		d := exportBackoff(retry, 100*time.Millisecond, time.Second)
+		if d < 0 || d > time.Second {
+			t.Errorf("retry %d: backoff %s outside [0, 1s]", retry, d)
+		}
+	}
+}
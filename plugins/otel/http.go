@@ -3,7 +3,6 @@ package otel
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -72,7 +71,7 @@ func (c *OtelClientHTTP) Emit(ctx context.Context, rs []*ResourceSpan) error {
 		// Discard the body to avoid leaking memory
 		_, _ = io.Copy(io.Discard, resp.Body)
 		logger.Error("[otel] collector at %s returned status %s", c.endpoint, resp.Status)
-		return fmt.Errorf("collector returned %s", resp.Status)
+		return &collectorStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 	logger.Debug("[otel] successfully sent trace to %s, status: %s", c.endpoint, resp.Status)
 	return nil
@@ -143,6 +143,18 @@ type Config struct {
 	// single-object config it is read from the object; in a profiles wrapper it is read
 	// from the top-level field (or hoisted from the first profile that carries one).
 	PluginSpanFilter *PluginSpanFilter `json:"plugin_span_filter,omitempty"`
+
+	// ExportPool sizes the shared buffer of completed traces awaiting export. Defaults
+	// apply when omitted (see ExportPoolConfig).
+	ExportPool *ExportPoolConfig `json:"export_pool,omitempty"`
+
+	// ExportBatch tunes batching, flush interval and retry/backoff of collector exports.
+	// Defaults apply when omitted (see ExportBatchConfig).
+	ExportBatch *ExportBatchConfig `json:"export_batch,omitempty"`
+
+	// Sampling decides which completed traces are exported; every trace is exported when
+	// omitted (see SamplingConfig).
+	Sampling *SamplingConfig `json:"sampling,omitempty"`
 }
 
 // UnmarshalJSON normalizes both supported config shapes into Profiles. A wrapper object
@@ -172,6 +184,8 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	}
 	c.Profiles = []*Profile{&prof}
 	c.PluginSpanFilter = spanFilterFrom(data)
+	shared := sharedSettingsFrom(data)
+	c.ExportPool, c.ExportBatch, c.Sampling = shared.ExportPool, shared.ExportBatch, shared.Sampling
 	return nil
 }
 
@@ -189,6 +203,23 @@ func spanFilterFrom(data []byte) *PluginSpanFilter {
 	return c.PluginSpanFilter
 }
 
+// sharedSettings captures the plugin-wide export settings carried by a legacy single-profile object.
+type sharedSettings struct {
+	ExportPool  *ExportPoolConfig  `json:"export_pool,omitempty"`
+	ExportBatch *ExportBatchConfig `json:"export_batch,omitempty"`
+	Sampling    *SamplingConfig    `json:"sampling,omitempty"`
+}
+
+// sharedSettingsFrom extracts the top-level export_pool, export_batch and sampling settings
+// from a legacy single-profile object; any of them may be nil.
+func sharedSettingsFrom(data []byte) sharedSettings {
+	var c sharedSettings
+	if err := sonic.Unmarshal(data, &c); err != nil {
+		return sharedSettings{}
+	}
+	return c
+}
+
 // hoistSpanFilter returns the first plugin_span_filter found among the profiles of a
 // wrapper-shaped config, used as a fallback when the top-level field is absent.
 func hoistSpanFilter(data []byte) *PluginSpanFilter {
@@ -231,6 +262,9 @@ type profileForStorage struct {
 type configForStorage struct {
 	Profiles         []profileForStorage `json:"profiles"`
 	PluginSpanFilter *PluginSpanFilter   `json:"plugin_span_filter,omitempty"`
+	ExportPool       *ExportPoolConfig   `json:"export_pool,omitempty"`
+	ExportBatch      *ExportBatchConfig  `json:"export_batch,omitempty"`
+	Sampling         *SamplingConfig     `json:"sampling,omitempty"`
 }
 
 // MarshalForStorage serializes Config to JSON with *SecretVar fields as plain strings
@@ -241,6 +275,9 @@ func (c *Config) MarshalForStorage() ([]byte, error) {
 	out := configForStorage{
 		Profiles:         make([]profileForStorage, 0, len(c.Profiles)),
 		PluginSpanFilter: c.PluginSpanFilter,
+		ExportPool:       c.ExportPool,
+		ExportBatch:      c.ExportBatch,
+		Sampling:         c.Sampling,
 	}
 	for _, p := range c.Profiles {
 		if p == nil {
@@ -277,7 +314,7 @@ func (c *Config) Redacted() *Config {
 	if c == nil {
 		return nil
 	}
-	redacted := &Config{PluginSpanFilter: c.PluginSpanFilter}
+	redacted := &Config{PluginSpanFilter: c.PluginSpanFilter, ExportPool: c.ExportPool, ExportBatch: c.ExportBatch, Sampling: c.Sampling}
 	if c.Profiles != nil {
 		redacted.Profiles = make([]*Profile, 0, len(c.Profiles))
 		for _, p := range c.Profiles {
@@ -355,6 +392,14 @@ type OtelPlugin struct {
 	pricingManager *modelcatalog.ModelCatalog
 
 	pluginSpanFilter *PluginSpanFilter
+
+	// exportPool buffers completed traces so Inject never blocks on a collector. Nil
+	// when the plugin is constructed directly (tests), in which case export is inline.
+	exportPool *exportPool
+	// exportBatch holds the resolved batching and retry settings.
+	exportBatch ExportBatchConfig
+
+	sampling *SamplingConfig
 }
 
 // Init function for the OTEL plugin
@@ -372,6 +417,15 @@ func Init(ctx context.Context, config *Config, _logger schemas.Logger, pricingMa
 	if err := config.PluginSpanFilter.Validate(); err != nil {
 		return nil, err
 	}
+	if err := config.ExportPool.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.ExportBatch.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Sampling.Validate(); err != nil {
+		return nil, err
+	}
 	// Loading attributes from environment
 	attributesFromEnvironment := make([]*commonpb.KeyValue, 0)
 	if attributes, ok := os.LookupEnv(OTELResponseAttributesEnvKey); ok {
@@ -405,6 +459,8 @@ func Init(ctx context.Context, config *Config, _logger schemas.Logger, pricingMa
 		attributesFromEnvironment: attributesFromEnvironment,
 		instanceAttrs:             instanceAttrs,
 		pluginSpanFilter:          config.PluginSpanFilter,
+		exportBatch:               config.ExportBatch.withDefaults(),
+		sampling:                  config.Sampling,
 	}
 	p.ctx, p.cancel = context.WithCancel(ctx)
 
@@ -422,6 +478,7 @@ func Init(ctx context.Context, config *Config, _logger schemas.Logger, pricingMa
 		}
 		p.targets = append(p.targets, target)
 	}
+	p.exportPool = newExportPool(config.ExportPool, config.ExportBatch, p.exportBatchToTargets, p.recordExportPoolDrop, p.recordExportPoolGrowth)
 
 	return p, nil
 }
@@ -514,6 +571,11 @@ func (p *OtelPlugin) GetName() string {
 	return PluginName
 }
 
+// DegradationPolicy implements schemas.DegradablePlugin.
+func (p *OtelPlugin) DegradationPolicy() *schemas.PluginDegradationPolicy {
+	return schemas.DefaultObservabilityDegradationPolicy()
+}
+
 // MarshalConfigForStorage implements schemas.ConfigMarshallerPlugin.
 func (p *OtelPlugin) MarshalConfigForStorage(raw map[string]any) (map[string]any, error) {
 	b, err := sonic.Marshal(raw)
@@ -652,15 +714,44 @@ func (p *OtelPlugin) RecordHTTPMetrics(ctx context.Context, path, method, status
 	}
 }
 
-// Inject receives a completed trace and sends it to the OTEL collector.
-// Implements schemas.ObservabilityPlugin interface.
+// Inject receives a completed trace and queues it on the export pool, from which it is
+// sent to the OTEL collectors. Implements schemas.ObservabilityPlugin interface.
 // This method is called asynchronously by TracingMiddleware after the response
 // has been written to the client.
 func (p *OtelPlugin) Inject(ctx context.Context, trace *schemas.Trace) error {
 	if trace == nil {
 		return nil
 	}
-	// Emit the trace to every configured profile's collector, and record metrics against
+	if p.exportPool == nil {
+		p.exportBatchToTargets([]*schemas.Trace{trace})
+		return nil
+	}
+	if !p.exportPool.offer(trace) {
+		logger.Warn("otel export pool is full, dropping trace %s", trace.TraceID)
+	}
+	return nil
+}
+
+// exportBatchToTargets converts and emits a batch of completed traces to every target,
+// one export request per target. It runs on the export pool's workers, detached from the
+// requests that produced the traces. A batch that still fails after its retries is dropped.
+func (p *OtelPlugin) exportBatchToTargets(batch []*schemas.Trace) {
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var stop <-chan struct{}
+	if p.exportPool != nil {
+		stop = p.exportPool.done
+	}
+	// Sampling only gates span export; request metrics below cover every trace.
+	sampled := make([]*schemas.Trace, 0, len(batch))
+	for _, trace := range batch {
+		if p.sampling.ShouldSample(trace) {
+			sampled = append(sampled, trace)
+		}
+	}
+	// Emit the batch to every configured profile's collector, and record metrics against
 	// each profile's exporter. Conversion is per-target because the resource service name
 	// differs per profile; everything else (filter, instance attrs) is shared.
 	var wg sync.WaitGroup
@@ -668,20 +759,63 @@ func (p *OtelPlugin) Inject(ctx context.Context, trace *schemas.Trace) error {
 		wg.Add(1)
 		go func(t *otelTarget) {
 			defer wg.Done()
-			if t.client != nil {
-				resourceSpan := p.convertTraceToResourceSpan(t.serviceName, trace, t.requestHeaders, t.disableContentLogging, t.groupTracesBySession, t.disableRootSpanContent)
-				if err := t.client.Emit(ctx, []*ResourceSpan{resourceSpan}); err != nil {
-					logger.Error("failed to emit trace %s to %s: %v", trace.TraceID, t.url, err)
+			// Request metrics are recorded before the export so collector retries don't delay them.
+			if t.metricsExporter != nil {
+				for _, trace := range batch {
+					p.recordMetricsFromTrace(context.Background(), t.metricsExporter, trace)
+					p.recordMCPMetricsFromTrace(context.Background(), t.metricsExporter, trace)
 				}
 			}
-			if t.metricsExporter != nil {
-				p.recordMetricsFromTrace(ctx, t.metricsExporter, trace)
-				p.recordMCPMetricsFromTrace(ctx, t.metricsExporter, trace)
+			if t.client != nil && len(sampled) > 0 {
+				resourceSpans := make([]*ResourceSpan, 0, len(sampled))
+				for _, trace := range sampled {
+					resourceSpans = append(resourceSpans, p.convertTraceToResourceSpan(t.serviceName, trace, t.requestHeaders, t.disableContentLogging, t.groupTracesBySession, t.disableRootSpanContent))
+				}
+				err := emitWithRetry(ctx, stop, t.client, resourceSpans, p.exportBatch, func() {
+					if t.metricsExporter != nil {
+						t.metricsExporter.RecordExportRetry(context.Background())
+					}
+				})
+				if err != nil {
+					logger.Error("failed to emit %d traces to %s, dropping batch: %v", len(sampled), t.url, err)
+				}
+				if t.metricsExporter != nil {
+					t.metricsExporter.RecordExportBatch(context.Background(), err == nil)
+					if err != nil {
+						t.metricsExporter.RecordExportPoolDrop(context.Background(), int64(len(sampled)), dropReasonExportFailed)
+					}
+				}
 			}
 		}(t)
 	}
 	wg.Wait()
-	return nil
+}
+
+// recordExportPoolDrop records a trace dropped by the export pool against every metrics exporter.
+func (p *OtelPlugin) recordExportPoolDrop(reason string) {
+	for _, t := range p.targets {
+		if t.metricsExporter != nil {
+			t.metricsExporter.RecordExportPoolDrop(context.Background(), 1, reason)
+		}
+	}
+}
+
+// recordExportPoolGrowth records a pool growth event and the new capacity against every metrics exporter.
+func (p *OtelPlugin) recordExportPoolGrowth(capacity int) {
+	logger.Info("otel export pool saturated, grew capacity to %d", capacity)
+	for _, t := range p.targets {
+		if t.metricsExporter != nil {
+			t.metricsExporter.RecordExportPoolGrowth(context.Background(), int64(capacity))
+		}
+	}
+}
+
+// GetExportPoolStats returns the export pool's saturation statistics.
+func (p *OtelPlugin) GetExportPoolStats() ExportPoolStats {
+	if p.exportPool == nil {
+		return ExportPoolStats{}
+	}
+	return p.exportPool.stats()
 }
 
 // RequestHeaderPatterns returns the deduplicated union of request-header name patterns
@@ -1034,6 +1168,10 @@ func (p *OtelPlugin) recordMetricsFromTrace(ctx context.Context, exporter *Metri
 // Cleanup function for the OTEL plugin. It shuts down every profile's metrics exporter
 // and closes every trace client, returning the first client-close error encountered.
 func (p *OtelPlugin) Cleanup() error {
+	// Flush buffered traces before the clients are closed.
+	if p.exportPool != nil && !p.exportPool.close(exportPoolDrainTimeout) {
+		logger.Warn("otel export pool did not drain within %s, remaining traces are dropped", exportPoolDrainTimeout)
+	}
 	if p.cancel != nil {
 		p.cancel()
 	}
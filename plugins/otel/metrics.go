@@ -69,6 +69,15 @@ type MetricsExporter struct {
 	httpRequestDuration   *syncFloat64Histogram
 	httpRequestSizeBytes  *syncFloat64Histogram
 	httpResponseSizeBytes *syncFloat64Histogram
+
+	// Export pool saturation metrics
+	exportPoolDroppedTotal *syncInt64Counter
+	exportPoolGrowthsTotal *syncInt64Counter
+	exportPoolCapacity     *syncInt64Gauge
+
+	// Batch export metrics
+	exportBatchesTotal *syncInt64Counter
+	exportRetriesTotal *syncInt64Counter
 }
 
 // syncInt64Counter wraps metric.Int64Counter with thread-safe lazy initialization
@@ -97,6 +106,32 @@ func (c *syncInt64Counter) Add(ctx context.Context, value int64, opts ...metric.
 	}
 }
 
+// syncInt64Gauge wraps metric.Int64Gauge with thread-safe lazy initialization
+type syncInt64Gauge struct {
+	gauge metric.Int64Gauge
+	once  sync.Once
+	name  string
+	desc  string
+	unit  string
+	meter metric.Meter
+}
+
+func (g *syncInt64Gauge) Record(ctx context.Context, value int64, opts ...metric.RecordOption) {
+	g.once.Do(func() {
+		var err error
+		g.gauge, err = g.meter.Int64Gauge(g.name,
+			metric.WithDescription(g.desc),
+			metric.WithUnit(g.unit),
+		)
+		if err != nil {
+			logger.Error("failed to create gauge %s: %v", g.name, err)
+		}
+	})
+	if g.gauge != nil {
+		g.gauge.Record(ctx, value, opts...)
+	}
+}
+
 // syncFloat64Counter wraps metric.Float64Counter with thread-safe lazy initialization
 type syncFloat64Counter struct {
 	counter metric.Float64Counter
@@ -476,6 +511,41 @@ func (m *MetricsExporter) initMetrics() {
 		meter:      m.meter,
 		boundaries: httpBodySizeBuckets,
 	}
+
+	m.exportPoolDroppedTotal = &syncInt64Counter{
+		name:  "bifrost_otel_export_pool_dropped_total",
+		desc:  "Total number of traces dropped by the OTEL exporter, by reason (queue_full, export_failed)",
+		unit:  "{trace}",
+		meter: m.meter,
+	}
+
+	m.exportPoolGrowthsTotal = &syncInt64Counter{
+		name:  "bifrost_otel_export_pool_growths_total",
+		desc:  "Total number of times the OTEL export pool grew because it was saturated",
+		unit:  "{event}",
+		meter: m.meter,
+	}
+
+	m.exportPoolCapacity = &syncInt64Gauge{
+		name:  "bifrost_otel_export_pool_capacity",
+		desc:  "Current capacity of the OTEL export pool",
+		unit:  "{trace}",
+		meter: m.meter,
+	}
+
+	m.exportBatchesTotal = &syncInt64Counter{
+		name:  "bifrost_otel_export_batches_total",
+		desc:  "Total number of trace batches sent to the OTEL collector, by outcome (success, failure)",
+		unit:  "{batch}",
+		meter: m.meter,
+	}
+
+	m.exportRetriesTotal = &syncInt64Counter{
+		name:  "bifrost_otel_export_retries_total",
+		desc:  "Total number of retried OTEL collector export attempts",
+		unit:  "{attempt}",
+		meter: m.meter,
+	}
 }
 
 // Shutdown gracefully shuts down the metrics exporter
@@ -486,6 +556,32 @@ func (m *MetricsExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// RecordExportPoolDrop records traces dropped by the exporter, either because the export
+// pool was full (queue_full) or because a batch exhausted its retries (export_failed)
+func (m *MetricsExporter) RecordExportPoolDrop(ctx context.Context, count int64, reason string) {
+	m.exportPoolDroppedTotal.Add(ctx, count, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+// RecordExportBatch records a batch export attempt sequence and its final outcome
+func (m *MetricsExporter) RecordExportBatch(ctx context.Context, success bool) {
+	outcome := "success"
+	if !success {
+		outcome = "failure"
+	}
+	m.exportBatchesTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+// RecordExportRetry records a retried export attempt
+func (m *MetricsExporter) RecordExportRetry(ctx context.Context) {
+	m.exportRetriesTotal.Add(ctx, 1)
+}
+
+// RecordExportPoolGrowth records an export pool growth event and its new capacity
+func (m *MetricsExporter) RecordExportPoolGrowth(ctx context.Context, capacity int64) {
+	m.exportPoolGrowthsTotal.Add(ctx, 1)
+	m.exportPoolCapacity.Record(ctx, capacity)
+}
+
 // RecordUpstreamRequest records an upstream request metric
 func (m *MetricsExporter) RecordUpstreamRequest(ctx context.Context, attrs ...attribute.KeyValue) {
 	m.upstreamRequestsTotal.Add(ctx, 1, metric.WithAttributes(attrs...))
@@ -0,0 +1,328 @@
+package otel
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const (
+	// DefaultExportPoolInitialSize is the number of completed traces buffered for export
+	// before the pool starts growing.
+	DefaultExportPoolInitialSize = 2000
+	// DefaultExportPoolMaxSize caps adaptive growth when max_size is not configured.
+	DefaultExportPoolMaxSize = 16000
+	// MaxExportPoolSize is the hard ceiling for max_size, bounding worst-case memory held
+	// by traces waiting on a slow or unavailable collector.
+	MaxExportPoolSize = 200000
+	// DefaultExportPoolWorkers is the number of goroutines draining the pool.
+	DefaultExportPoolWorkers = 8
+	// exportPoolDrainTimeout bounds how long Cleanup waits for buffered traces to flush.
+	exportPoolDrainTimeout = 5 * time.Second
+)
+
+// DropPolicy decides which trace is discarded when the export pool is full at its maximum size.
+type DropPolicy string
+
+const (
+	DropPolicyNewest DropPolicy = "drop_newest" // reject the incoming trace (default)
+	DropPolicyOldest DropPolicy = "drop_oldest" // evict the oldest queued trace to make room
+)
+
+// Drop reasons attached to the bifrost_otel_export_pool_dropped_total metric.
+const (
+	dropReasonQueueFull    = "queue_full"
+	dropReasonExportFailed = "export_failed"
+)
+
+// ExportPoolConfig sizes the in-memory pool (the export queue) of completed traces awaiting
+// export. The pool starts at InitialSize, doubles (up to MaxSize) when a burst saturates it,
+// and shrinks back towards InitialSize once the backlog clears. When it is full at MaxSize,
+// DropPolicy decides which trace is discarded.
+type ExportPoolConfig struct {
+	InitialSize int        `json:"initial_size,omitempty"` // default 2000
+	MaxSize     int        `json:"max_size,omitempty"`     // maximum queue size; default 16000, at most 200000
+	Workers     int        `json:"workers,omitempty"`      // default 8
+	DropPolicy  DropPolicy `json:"drop_policy,omitempty"`  // default drop_newest
+}
+
+// withDefaults returns a copy of the config with zero values replaced by defaults.
+// A nil config yields the defaults.
+func (c *ExportPoolConfig) withDefaults() ExportPoolConfig {
+	var out ExportPoolConfig
+	if c != nil {
+		out = *c
+	}
+	if out.InitialSize <= 0 {
+		out.InitialSize = DefaultExportPoolInitialSize
+	}
+	if out.MaxSize <= 0 {
+		out.MaxSize = max(DefaultExportPoolMaxSize, out.InitialSize)
+	}
+	if out.Workers <= 0 {
+		out.Workers = DefaultExportPoolWorkers
+	}
+	if out.DropPolicy == "" {
+		out.DropPolicy = DropPolicyNewest
+	}
+	return out
+}
+
+// Validate checks the pool bounds. A nil config is valid (defaults apply).
+func (c *ExportPoolConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.InitialSize < 0 || c.MaxSize < 0 || c.Workers < 0 {
+		return fmt.Errorf("export_pool sizes must not be negative")
+	}
+	if c.DropPolicy != "" && c.DropPolicy != DropPolicyNewest && c.DropPolicy != DropPolicyOldest {
+		return fmt.Errorf("export_pool.drop_policy must be %q or %q, got %q", DropPolicyNewest, DropPolicyOldest, c.DropPolicy)
+	}
+	resolved := c.withDefaults()
+	if resolved.MaxSize < resolved.InitialSize {
+		return fmt.Errorf("export_pool.max_size (%d) must be >= initial_size (%d)", resolved.MaxSize, resolved.InitialSize)
+	}
+	if resolved.MaxSize > MaxExportPoolSize {
+		return fmt.Errorf("export_pool.max_size must be at most %d, got %d", MaxExportPoolSize, resolved.MaxSize)
+	}
+	return nil
+}
+
+// ExportPoolStats is a point-in-time view of the export pool's saturation.
+type ExportPoolStats struct {
+	Depth         int   `json:"depth"`           // traces currently waiting for export
+	Capacity      int   `json:"capacity"`        // current (adaptive) capacity
+	MaxCapacity   int   `json:"max_capacity"`    // growth cap
+	HighWaterMark int   `json:"high_water_mark"` // largest depth observed since start
+	Dropped       int64 `json:"dropped"`         // traces dropped (rejected or evicted) because the pool was full at max capacity
+	Grown         int64 `json:"grown"`           // number of times the pool grew
+}
+
+// queuedTrace is a trace waiting in the pool along with its enqueue time, used to
+// schedule the flush of partially filled batches.
+type queuedTrace struct {
+	trace      *schemas.Trace
+	enqueuedAt time.Time
+}
+
+// exportPool is a bounded FIFO of completed traces drained in batches by a fixed set of workers.
+// A worker flushes as soon as MaxBatchSize traces are queued, or once the oldest queued trace
+// has waited ScheduleDelay.
+type exportPool struct {
+	mu    sync.Mutex
+	items []queuedTrace
+	head  int
+	// wake is signalled (non-blocking) on every enqueue; done is closed on close.
+	wake chan struct{}
+	done chan struct{}
+
+	initialCapacity int
+	capacity        int
+	maxCapacity     int
+	highWater       int
+	dropped         int64
+	grown           int64
+	closed          bool
+	dropOldest      bool
+
+	maxBatchSize  int
+	scheduleDelay time.Duration
+
+	export  func([]*schemas.Trace)
+	onDrop  func(reason string)
+	onGrow  func(capacity int)
+	workers sync.WaitGroup
+}
+
+// newExportPool starts the pool's workers. export is invoked with every dequeued batch;
+// onDrop and onGrow (optional) are invoked outside the pool lock to record saturation metrics.
+func newExportPool(config *ExportPoolConfig, batch *ExportBatchConfig, export func([]*schemas.Trace), onDrop func(reason string), onGrow func(capacity int)) *exportPool {
+	cfg := config.withDefaults()
+	batchCfg := batch.withDefaults()
+	q := &exportPool{
+		items:           make([]queuedTrace, 0, cfg.InitialSize),
+		wake:            make(chan struct{}, 1),
+		done:            make(chan struct{}),
+		initialCapacity: cfg.InitialSize,
+		capacity:        cfg.InitialSize,
+		maxCapacity:     cfg.MaxSize,
+		dropOldest:      cfg.DropPolicy == DropPolicyOldest,
+		maxBatchSize:    batchCfg.MaxExportBatchSize,
+		scheduleDelay:   time.Duration(batchCfg.ScheduleDelayMs) * time.Millisecond,
+		export:          export,
+		onDrop:          onDrop,
+		onGrow:          onGrow,
+	}
+	for range cfg.Workers {
+		q.workers.Go(q.run)
+	}
+	return q
+}
+
+// offer enqueues a trace without blocking. It grows the pool when it is full and below
+// its cap; at the cap the drop policy applies. It returns false when the incoming trace
+// was rejected (with drop_oldest the incoming trace is always accepted).
+func (q *exportPool) offer(trace *schemas.Trace) bool {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return false
+	}
+	grewTo := 0
+	evicted := false
+	if q.depthLocked() >= q.capacity {
+		switch {
+		case q.capacity < q.maxCapacity:
+			q.capacity = min(q.capacity*2, q.maxCapacity)
+			q.grown++
+			grewTo = q.capacity
+		case q.dropOldest:
+			q.items[q.head] = queuedTrace{}
+			q.head++
+			q.dropped++
+			evicted = true
+		default:
+			q.dropped++
+			q.mu.Unlock()
+			if q.onDrop != nil {
+				q.onDrop(dropReasonQueueFull)
+			}
+			return false
+		}
+	}
+	q.items = append(q.items, queuedTrace{trace: trace, enqueuedAt: time.Now()})
+	if depth := q.depthLocked(); depth > q.highWater {
+		q.highWater = depth
+	}
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	if evicted && q.onDrop != nil {
+		q.onDrop(dropReasonQueueFull)
+	}
+	if grewTo > 0 && q.onGrow != nil {
+		q.onGrow(grewTo)
+	}
+	return true
+}
+
+// takeBatch blocks until a batch is ready: MaxBatchSize traces are queued, the oldest
+// queued trace has waited ScheduleDelay, or the pool is closing. It returns false once the
+// pool is closed and drained.
+func (q *exportPool) takeBatch() ([]*schemas.Trace, bool) {
+	for {
+		q.mu.Lock()
+		depth := q.depthLocked()
+		if depth == 0 && q.closed {
+			q.mu.Unlock()
+			return nil, false
+		}
+		var wait time.Duration
+		if depth > 0 {
+			wait = q.scheduleDelay - time.Since(q.items[q.head].enqueuedAt)
+			if depth >= q.maxBatchSize || wait <= 0 || q.closed {
+				batch := q.popLocked(min(depth, q.maxBatchSize))
+				q.mu.Unlock()
+				return batch, true
+			}
+		}
+		q.mu.Unlock()
+
+		if depth == 0 {
+			select {
+			case <-q.wake:
+			case <-q.done:
+			}
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-q.wake:
+		case <-q.done:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// popLocked removes n traces from the head of the queue. Caller must hold q.mu.
+func (q *exportPool) popLocked(n int) []*schemas.Trace {
+	batch := make([]*schemas.Trace, n)
+	for i := range n {
+		batch[i] = q.items[q.head].trace
+		q.items[q.head] = queuedTrace{}
+		q.head++
+	}
+	// Compact once the consumed prefix dominates so the backing array doesn't grow unbounded.
+	if q.head > len(q.items)/2 {
+		m := copy(q.items, q.items[q.head:])
+		clear(q.items[m:])
+		q.items = q.items[:m]
+		q.head = 0
+	}
+	// Shrink back towards the initial size once a burst has drained.
+	if q.capacity > q.initialCapacity && q.depthLocked() < q.capacity/4 {
+		q.capacity = max(q.capacity/2, q.initialCapacity)
+	}
+	return batch
+}
+
+// run is the worker loop.
+func (q *exportPool) run() {
+	for {
+		batch, ok := q.takeBatch()
+		if !ok {
+			return
+		}
+		q.export(batch)
+	}
+}
+
+// depthLocked returns the number of queued traces. Caller must hold q.mu.
+func (q *exportPool) depthLocked() int {
+	return len(q.items) - q.head
+}
+
+// stats returns the current saturation statistics.
+func (q *exportPool) stats() ExportPoolStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return ExportPoolStats{
+		Depth:         q.depthLocked(),
+		Capacity:      q.capacity,
+		MaxCapacity:   q.maxCapacity,
+		HighWaterMark: q.highWater,
+		Dropped:       q.dropped,
+		Grown:         q.grown,
+	}
+}
+
+// close stops accepting traces and waits up to timeout for the workers to flush the backlog.
+// It returns false if the workers were still busy when the timeout elapsed.
+func (q *exportPool) close(timeout time.Duration) bool {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,198 @@
+package otel
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// blockingExporter holds every exported trace until release is closed so tests can
+// saturate the pool deterministically.
+type blockingExporter struct {
+	release  chan struct{}
+	mu       sync.Mutex
+	exported []string
+}
+
+func (e *blockingExporter) export(batch []*schemas.Trace) {
+	<-e.release
+	e.mu.Lock()
+	for _, trace := range batch {
+		e.exported = append(e.exported, trace.TraceID)
+	}
+	e.mu.Unlock()
+}
+
+func TestExportPoolGrowsAndDropsAtCap(t *testing.T) {
+	exp := &blockingExporter{release: make(chan struct{})}
+	var drops, growths int
+	var mu sync.Mutex
+	pool := newExportPool(&ExportPoolConfig{InitialSize: 2, MaxSize: 4, Workers: 1}, &ExportBatchConfig{MaxExportBatchSize: 1}, exp.export,
+		func(string) { mu.Lock(); drops++; mu.Unlock() },
+		func(int) { mu.Lock(); growths++; mu.Unlock() },
+	)
+
+	// The single worker takes the first trace and blocks on it, so the pool holds the rest.
+	if !pool.offer(&schemas.Trace{TraceID: "t0"}) {
+		t.Fatal("expected first trace to be accepted")
+	}
+	waitFor(t, func() bool { return pool.stats().Depth == 0 })
+
+	accepted := 0
+	for i := 1; i <= 6; i++ {
+		if pool.offer(&schemas.Trace{TraceID: "t"}) {
+			accepted++
+		}
+	}
+
+	stats := pool.stats()
+	if accepted != 4 {
+		t.Errorf("expected 4 traces accepted after growth to max size, got %d", accepted)
+	}
+	if stats.Capacity != 4 || stats.Grown != 1 {
+		t.Errorf("expected capacity 4 after one growth, got capacity=%d grown=%d", stats.Capacity, stats.Grown)
+	}
+	if stats.Dropped != 2 || stats.HighWaterMark != 4 {
+		t.Errorf("expected 2 drops and high-water mark 4, got dropped=%d high_water=%d", stats.Dropped, stats.HighWaterMark)
+	}
+	mu.Lock()
+	if drops != 2 || growths != 1 {
+		t.Errorf("expected callbacks for 2 drops and 1 growth, got %d and %d", drops, growths)
+	}
+	mu.Unlock()
+
+	close(exp.release)
+	if !pool.close(time.Second) {
+		t.Fatal("expected pool to drain on close")
+	}
+	if len(exp.exported) != 5 {
+		t.Errorf("expected 5 exported traces, got %d", len(exp.exported))
+	}
+	if stats := pool.stats(); stats.Capacity != 2 {
+		t.Errorf("expected capacity to shrink back to 2 after draining, got %d", stats.Capacity)
+	}
+}
+
+func TestExportPoolRejectsAfterClose(t *testing.T) {
+	pool := newExportPool(nil, nil, func([]*schemas.Trace) {}, nil, nil)
+	pool.close(time.Second)
+	if pool.offer(&schemas.Trace{TraceID: "late"}) {
+		t.Error("expected offer to fail after close")
+	}
+}
+
+func TestExportPoolDropOldest(t *testing.T) {
+	exp := &blockingExporter{release: make(chan struct{})}
+	pool := newExportPool(&ExportPoolConfig{InitialSize: 2, MaxSize: 2, Workers: 1, DropPolicy: DropPolicyOldest}, &ExportBatchConfig{MaxExportBatchSize: 1}, exp.export, nil, nil)
+
+	pool.offer(&schemas.Trace{TraceID: "t0"})
+	waitFor(t, func() bool { return pool.stats().Depth == 0 })
+	for _, id := range []string{"t1", "t2", "t3"} {
+		if !pool.offer(&schemas.Trace{TraceID: id}) {
+			t.Fatalf("expected %s to be accepted under drop_oldest", id)
+		}
+	}
+
+	close(exp.release)
+	pool.close(time.Second)
+	if got := strings.Join(exp.exported, ","); got != "t0,t2,t3" {
+		t.Errorf("expected t1 to be evicted, got exported %s", got)
+	}
+	if stats := pool.stats(); stats.Dropped != 1 {
+		t.Errorf("expected 1 drop, got %d", stats.Dropped)
+	}
+}
+
+func TestExportPoolBatching(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]*schemas.Trace
+	export := func(batch []*schemas.Trace) {
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}
+	batchCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(batches)
+	}
+
+	t.Run("flushes when the batch is full", func(t *testing.T) {
+		batches = nil
+		pool := newExportPool(&ExportPoolConfig{Workers: 1}, &ExportBatchConfig{MaxExportBatchSize: 3, ScheduleDelayMs: 60000}, export, nil, nil)
+		defer pool.close(time.Second)
+		for range 3 {
+			pool.offer(&schemas.Trace{TraceID: "t"})
+		}
+		waitFor(t, func() bool { return batchCount() == 1 })
+		mu.Lock()
+		defer mu.Unlock()
+		if len(batches[0]) != 3 {
+			t.Errorf("expected a batch of 3, got %d", len(batches[0]))
+		}
+	})
+
+	t.Run("flushes a partial batch after the schedule delay", func(t *testing.T) {
+		batches = nil
+		pool := newExportPool(&ExportPoolConfig{Workers: 1}, &ExportBatchConfig{MaxExportBatchSize: 100, ScheduleDelayMs: 20}, export, nil, nil)
+		defer pool.close(time.Second)
+		pool.offer(&schemas.Trace{TraceID: "a"})
+		pool.offer(&schemas.Trace{TraceID: "b"})
+		waitFor(t, func() bool { return batchCount() == 1 })
+		mu.Lock()
+		defer mu.Unlock()
+		if len(batches[0]) != 2 {
+			t.Errorf("expected a batch of 2, got %d", len(batches[0]))
+		}
+	})
+
+	t.Run("close flushes without waiting for the delay", func(t *testing.T) {
+		batches = nil
+		pool := newExportPool(&ExportPoolConfig{Workers: 1}, &ExportBatchConfig{MaxExportBatchSize: 100, ScheduleDelayMs: 60000}, export, nil, nil)
+		pool.offer(&schemas.Trace{TraceID: "a"})
+		if !pool.close(time.Second) {
+			t.Fatal("expected pool to drain on close")
+		}
+		if batchCount() != 1 {
+			t.Errorf("expected the pending trace to be flushed on close, got %d batches", batchCount())
+		}
+	})
+}
+
+func TestExportPoolConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ExportPoolConfig
+		wantErr bool
+	}{
+		{name: "nil uses defaults", config: nil},
+		{name: "initial only", config: &ExportPoolConfig{InitialSize: 500}},
+		{name: "max below initial", config: &ExportPoolConfig{InitialSize: 100, MaxSize: 50}, wantErr: true},
+		{name: "max above ceiling", config: &ExportPoolConfig{MaxSize: MaxExportPoolSize + 1}, wantErr: true},
+		{name: "negative workers", config: &ExportPoolConfig{Workers: -1}, wantErr: true},
+		{name: "drop oldest", config: &ExportPoolConfig{DropPolicy: DropPolicyOldest}},
+		{name: "unknown drop policy", config: &ExportPoolConfig{DropPolicy: "drop_random"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("condition not met within 1s")
+}
@@ -0,0 +1,172 @@
+package otel
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// SamplingConfig decides which completed traces are exported to the collectors. Metrics are
+// recorded for every trace regardless of the sampling decision.
+//
+// The decision is taken once the trace has completed (after the post-hooks have run), so the
+// final status and latency are known. Rules are evaluated in order:
+//  1. Tail rules: traces with an error span (AlwaysSampleErrors) or whose duration reaches
+//     LatencyThresholdMs are always kept.
+//  2. Parent-based: when the request carried a W3C traceparent, its sampled flag is honored
+//     (unless ParentBased is set to false).
+//  3. Ratio: the remaining traces are kept with probability Ratio. The decision is derived
+//     from the trace ID, so every gateway instance agrees on the same trace.
+//
+// VirtualKeyOverrides replaces the ratio and tail rules for requests made with a specific
+// virtual key, keyed by virtual key ID or name.
+type SamplingConfig struct {
+	Ratio               *float64                     `json:"ratio,omitempty"`        // default 1 (keep all)
+	ParentBased         *bool                        `json:"parent_based,omitempty"` // default true
+	AlwaysSampleErrors  bool                         `json:"always_sample_errors,omitempty"`
+	LatencyThresholdMs  int                          `json:"latency_threshold_ms,omitempty"` // 0 disables the latency rule
+	VirtualKeyOverrides map[string]*SamplingOverride `json:"virtual_key_overrides,omitempty"`
+}
+
+// SamplingOverride replaces the sampling rules for a single virtual key. Unset fields
+// inherit the top-level value.
+type SamplingOverride struct {
+	Ratio              *float64 `json:"ratio,omitempty"`
+	AlwaysSampleErrors *bool    `json:"always_sample_errors,omitempty"`
+	LatencyThresholdMs *int     `json:"latency_threshold_ms,omitempty"`
+}
+
+// Validate checks ratios and thresholds. A nil config is valid (every trace is exported).
+func (c *SamplingConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateSamplingRatio("sampling.ratio", c.Ratio); err != nil {
+		return err
+	}
+	if c.LatencyThresholdMs < 0 {
+		return fmt.Errorf("sampling.latency_threshold_ms must not be negative")
+	}
+	for vk, override := range c.VirtualKeyOverrides {
+		if override == nil {
+			return fmt.Errorf("sampling.virtual_key_overrides[%s] is empty", vk)
+		}
+		if err := validateSamplingRatio(fmt.Sprintf("sampling.virtual_key_overrides[%s].ratio", vk), override.Ratio); err != nil {
+			return err
+		}
+		if override.LatencyThresholdMs != nil && *override.LatencyThresholdMs < 0 {
+			return fmt.Errorf("sampling.virtual_key_overrides[%s].latency_threshold_ms must not be negative", vk)
+		}
+	}
+	return nil
+}
+
+func validateSamplingRatio(field string, ratio *float64) error {
+	if ratio != nil && (math.IsNaN(*ratio) || *ratio < 0 || *ratio > 1) {
+		return fmt.Errorf("%s must be between 0 and 1, got %v", field, *ratio)
+	}
+	return nil
+}
+
+// ShouldSample reports whether the completed trace should be exported. A nil config keeps
+// every trace.
+func (c *SamplingConfig) ShouldSample(trace *schemas.Trace) bool {
+	if c == nil || trace == nil {
+		return true
+	}
+	ratio := 1.0
+	if c.Ratio != nil {
+		ratio = *c.Ratio
+	}
+	alwaysErrors := c.AlwaysSampleErrors
+	latencyThreshold := c.LatencyThresholdMs
+	if override := c.overrideFor(trace); override != nil {
+		if override.Ratio != nil {
+			ratio = *override.Ratio
+		}
+		if override.AlwaysSampleErrors != nil {
+			alwaysErrors = *override.AlwaysSampleErrors
+		}
+		if override.LatencyThresholdMs != nil {
+			latencyThreshold = *override.LatencyThresholdMs
+		}
+	}
+
+	if alwaysErrors && traceHasError(trace) {
+		return true
+	}
+	if latencyThreshold > 0 && traceDuration(trace) >= time.Duration(latencyThreshold)*time.Millisecond {
+		return true
+	}
+	if c.ParentBased == nil || *c.ParentBased {
+		if sampled, ok := trace.Attributes[schemas.TraceAttrParentSampled].(bool); ok {
+			return sampled
+		}
+	}
+	return traceIDRatioSampled(trace.TraceID, ratio)
+}
+
+// overrideFor returns the override for the trace's virtual key, matched by ID first and
+// then by name, or nil.
+func (c *SamplingConfig) overrideFor(trace *schemas.Trace) *SamplingOverride {
+	if len(c.VirtualKeyOverrides) == 0 {
+		return nil
+	}
+	for _, span := range trace.Spans {
+		if span == nil {
+			continue
+		}
+		if id := getStringAttr(span.Attributes, schemas.AttrBifrostVirtualKeyID); id != "" {
+			if override, ok := c.VirtualKeyOverrides[id]; ok {
+				return override
+			}
+			return c.VirtualKeyOverrides[getStringAttr(span.Attributes, schemas.AttrBifrostVirtualKeyName)]
+		}
+	}
+	return nil
+}
+
+// traceHasError reports whether any span in the trace ended with an error status.
+func traceHasError(trace *schemas.Trace) bool {
+	for _, span := range trace.Spans {
+		if span != nil && span.Status == schemas.SpanStatusError {
+			return true
+		}
+	}
+	return false
+}
+
+// traceDuration returns the wall-clock duration of the trace, falling back to the root span
+// when the trace boundaries were not recorded.
+func traceDuration(trace *schemas.Trace) time.Duration {
+	if !trace.StartTime.IsZero() && !trace.EndTime.IsZero() {
+		return trace.EndTime.Sub(trace.StartTime)
+	}
+	if root := trace.RootSpan; root != nil && !root.StartTime.IsZero() && !root.EndTime.IsZero() {
+		return root.EndTime.Sub(root.StartTime)
+	}
+	return 0
+}
+
+// traceIDRatioSampled maps the trace ID onto [0, 1) and keeps it when it falls below ratio.
+func traceIDRatioSampled(traceID string, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(traceID))
+	// FNV alone clusters sequential IDs; the splitmix64 finalizer spreads them evenly.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x>>11)/(1<<53) < ratio
+}
@@ -0,0 +1,146 @@
+package otel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func samplingTrace(id string, status schemas.SpanStatus, duration time.Duration, vkID string) *schemas.Trace {
+	start := time.Now()
+	span := &schemas.Span{SpanID: "s1", Status: status, Attributes: map[string]any{}}
+	if vkID != "" {
+		span.Attributes[schemas.AttrBifrostVirtualKeyID] = vkID
+		span.Attributes[schemas.AttrBifrostVirtualKeyName] = vkID + "-name"
+	}
+	return &schemas.Trace{
+		TraceID:    id,
+		StartTime:  start,
+		EndTime:    start.Add(duration),
+		Spans:      []*schemas.Span{span},
+		Attributes: map[string]any{},
+	}
+}
+
+func TestSamplingShouldSample(t *testing.T) {
+	zero := 0.0
+	one := 1.0
+	noParent := false
+
+	tests := []struct {
+		name   string
+		config *SamplingConfig
+		trace  *schemas.Trace
+		want   bool
+	}{
+		{name: "nil config keeps all", config: nil, trace: samplingTrace("t", schemas.SpanStatusOk, 0, ""), want: true},
+		{name: "ratio zero drops", config: &SamplingConfig{Ratio: &zero}, trace: samplingTrace("t", schemas.SpanStatusOk, 0, ""), want: false},
+		{
+			name:   "errors kept at ratio zero",
+			config: &SamplingConfig{Ratio: &zero, AlwaysSampleErrors: true},
+			trace:  samplingTrace("t", schemas.SpanStatusError, 0, ""),
+			want:   true,
+		},
+		{
+			name:   "slow traces kept at ratio zero",
+			config: &SamplingConfig{Ratio: &zero, LatencyThresholdMs: 100},
+			trace:  samplingTrace("t", schemas.SpanStatusOk, 200*time.Millisecond, ""),
+			want:   true,
+		},
+		{
+			name:   "fast traces below threshold follow ratio",
+			config: &SamplingConfig{Ratio: &zero, LatencyThresholdMs: 100},
+			trace:  samplingTrace("t", schemas.SpanStatusOk, 10*time.Millisecond, ""),
+			want:   false,
+		},
+		{
+			name:   "parent sampled wins over ratio",
+			config: &SamplingConfig{Ratio: &zero},
+			trace: func() *schemas.Trace {
+				tr := samplingTrace("t", schemas.SpanStatusOk, 0, "")
+				tr.Attributes[schemas.TraceAttrParentSampled] = true
+				return tr
+			}(),
+			want: true,
+		},
+		{
+			name:   "parent not sampled drops",
+			config: &SamplingConfig{Ratio: &one},
+			trace: func() *schemas.Trace {
+				tr := samplingTrace("t", schemas.SpanStatusOk, 0, "")
+				tr.Attributes[schemas.TraceAttrParentSampled] = false
+				return tr
+			}(),
+			want: false,
+		},
+		{
+			name:   "parent ignored when parent_based is false",
+			config: &SamplingConfig{Ratio: &one, ParentBased: &noParent},
+			trace: func() *schemas.Trace {
+				tr := samplingTrace("t", schemas.SpanStatusOk, 0, "")
+				tr.Attributes[schemas.TraceAttrParentSampled] = false
+				return tr
+			}(),
+			want: true,
+		},
+		{
+			name:   "virtual key override by id",
+			config: &SamplingConfig{Ratio: &zero, VirtualKeyOverrides: map[string]*SamplingOverride{"vk-1": {Ratio: &one}}},
+			trace:  samplingTrace("t", schemas.SpanStatusOk, 0, "vk-1"),
+			want:   true,
+		},
+		{
+			name:   "virtual key override by name",
+			config: &SamplingConfig{Ratio: &one, VirtualKeyOverrides: map[string]*SamplingOverride{"vk-2-name": {Ratio: &zero}}},
+			trace:  samplingTrace("t", schemas.SpanStatusOk, 0, "vk-2"),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ShouldSample(tt.trace); got != tt.want {
+				t.Errorf("ShouldSample() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraceIDRatioSampled(t *testing.T) {
+	kept := 0
+	for i := range 10000 {
+		if traceIDRatioSampled(fmt.Sprintf("trace-%d", i), 0.25) {
+			kept++
+		}
+	}
+	if kept < 2200 || kept > 2800 {
+		t.Errorf("expected roughly 25%% of traces kept, got %d/10000", kept)
+	}
+	if traceIDRatioSampled("same", 0.5) != traceIDRatioSampled("same", 0.5) {
+		t.Error("expected the decision to be deterministic per trace ID")
+	}
+}
+
+func TestSamplingConfigValidate(t *testing.T) {
+	bad := 1.5
+	negative := -1
+	tests := []struct {
+		name    string
+		config  *SamplingConfig
+		wantErr bool
+	}{
+		{name: "nil", config: nil},
+		{name: "ratio out of range", config: &SamplingConfig{Ratio: &bad}, wantErr: true},
+		{name: "negative threshold", config: &SamplingConfig{LatencyThresholdMs: -1}, wantErr: true},
+		{name: "override ratio out of range", config: &SamplingConfig{VirtualKeyOverrides: map[string]*SamplingOverride{"vk": {Ratio: &bad}}}, wantErr: true},
+		{name: "override negative threshold", config: &SamplingConfig{VirtualKeyOverrides: map[string]*SamplingOverride{"vk": {LatencyThresholdMs: &negative}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package promptguard
+
+import (
+	"regexp"
+	"strings"
+)
+
+// heuristic is one weighted signal of an injection or jailbreak attempt.
+type heuristic struct {
+	name    string
+	weight  float64
+	pattern *regexp.Regexp
+}
+
+// heuristics are combined with a noisy-or, so several weak signals add up to a strong one
+// but no single phrase short of an explicit override scores near 1.
+var heuristics = []heuristic{
+	{
+		name:    "ignore_instructions",
+		weight:  0.6,
+		pattern: regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override|bypass)\b.{0,40}\b(previous|prior|above|earlier|all|any|system|your)\b.{0,20}\b(instructions?|prompts?|rules|directions|guidelines)\b`),
+	},
+	{
+		name:    "system_prompt_leak",
+		weight:  0.5,
+		pattern: regexp.MustCompile(`(?i)\b(reveal|show|print|repeat|output|leak|tell me)\b.{0,40}\b(system prompt|initial (instructions|prompt)|hidden (instructions|prompt)|your (instructions|prompt|rules))\b`),
+	},
+	{
+		name:    "jailbreak_persona",
+		weight:  0.45,
+		pattern: regexp.MustCompile(`(?i)\b(DAN|do anything now|developer mode|jailbreak(ed|ing)?|unfiltered|no (restrictions|limits|rules)|without (any )?(restrictions|limitations|filters|censorship))\b`),
+	},
+	{
+		name:    "role_reassignment",
+		weight:  0.3,
+		pattern: regexp.MustCompile(`(?i)\b(you are now|from now on,? you|pretend (to be|you are)|act as (an? )?(unrestricted|evil|uncensored))\b`),
+	},
+	{
+		name:    "fake_role_markers",
+		weight:  0.4,
+		pattern: regexp.MustCompile(`(?im)^\s*(system|assistant)\s*:|<\|?(im_start|im_end|system|endoftext)\|?>|\[/?INST\]|<</?SYS>>`),
+	},
+	{
+		name:    "encoded_payload",
+		weight:  0.2,
+		pattern: regexp.MustCompile(`[A-Za-z0-9+/]{160,}={0,2}`),
+	},
+}
+
+// scoreText returns the heuristic injection score of text, from 0 to 1, and the names of
+// the heuristics that matched.
+func scoreText(text string) (float64, []string) {
+	clean := 1.0
+	var matched []string
+	for _, h := range heuristics {
+		if h.pattern.MatchString(text) {
+			clean *= 1 - h.weight
+			matched = append(matched, h.name)
+		}
+	}
+	return 1 - clean, matched
+}
+
+// tail returns at most n bytes from the end of text, cut at a rune boundary.
+func tail(text string, n int) string {
+	if len(text) <= n {
+		return text
+	}
+	return strings.ToValidUTF8(text[len(text)-n:], "")
+}
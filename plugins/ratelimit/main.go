@@ -0,0 +1,344 @@
+// Package ratelimit provides an LLM plugin that enforces requests-per-minute and
+// tokens-per-minute limits per virtual key, per model and per provider with token
+// buckets. A request over a limit is turned away with a 429 whose
+// extra_fields.rate_limit names the limit it hit and when to retry, which the HTTP
+// transport also sends as Retry-After. Buckets live in memory, or in Redis so every
+// Bifrost replica shares them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/redis/go-redis/v9"
+)
+
+const PluginName = "rate-limit"
+
+// ErrCodeRateLimited is the error code of a request turned away by a limit.
+const ErrCodeRateLimited = "rate_limited"
+
+// Scopes a limit applies to, as reported in schemas.RateLimitExceeded.Scope.
+const (
+	ScopeVirtualKey = "virtual_key"
+	ScopeModel      = "model"
+	ScopeProvider   = "provider"
+)
+
+// Limits, as reported in schemas.RateLimitExceeded.Limit.
+const (
+	LimitRPM = "rpm"
+	LimitTPM = "tpm"
+)
+
+// DefaultKey configures the limit of every virtual key, model or provider without
+// one of its own. Each of them still gets its own bucket.
+const DefaultKey = "*"
+
+const (
+	DefaultKeyPrefix = "bifrost:ratelimit:"
+
+	// storeTimeout bounds each Redis round trip.
+	storeTimeout = 200 * time.Millisecond
+)
+
+// Context keys carrying state between the hooks of one request.
+const (
+	// stateKey holds the limits of the current attempt, charged with its tokens in PostLLMHook.
+	stateKey schemas.BifrostContextKey = "rate-limit-state"
+	// virtualKeyAdmittedKey marks a request whose virtual key limits were already
+	// checked, so fallback attempts do not count against them again.
+	virtualKeyAdmittedKey schemas.BifrostContextKey = "rate-limit-virtual-key-admitted"
+)
+
+// Limit is a pair of per-minute limits. Zero leaves that dimension unlimited. A
+// bucket holds one minute's worth, so a full minute's budget can be spent in a
+// burst, and refills continuously.
+type Limit struct {
+	RPM int `json:"rpm,omitempty"` // requests per minute
+	TPM int `json:"tpm,omitempty"` // input plus output tokens per minute
+}
+
+// Config configures the rate limit plugin. Each map is keyed by what it limits, or
+// DefaultKey.
+type Config struct {
+	// VirtualKeys are keyed by virtual key ID or name, as resolved by the governance plugin.
+	VirtualKeys map[string]Limit `json:"virtual_keys,omitempty"`
+	// Models are keyed by model ("gpt-4o"), shared across providers, or by
+	// provider and model ("openai/gpt-4o").
+	Models map[string]Limit `json:"models,omitempty"`
+	// Providers are keyed by provider ("openai").
+	Providers map[string]Limit `json:"providers,omitempty"`
+	// Redis shares the buckets across Bifrost replicas. Without it they are per node.
+	Redis *RedisConfig `json:"redis,omitempty"`
+	// FailClosed turns requests away when Redis fails. By default they are let through.
+	FailClosed bool `json:"fail_closed,omitempty"`
+}
+
+// RedisConfig configures the shared Redis store.
+type RedisConfig struct {
+	Addr      string `json:"addr"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	DB        int    `json:"db,omitempty"`
+	KeyPrefix string `json:"key_prefix,omitempty"` // default: "bifrost:ratelimit:"
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	virtualKeys map[string]Limit
+	models      map[string]Limit
+	providers   map[string]Limit
+	store       Store
+	redis       *redis.Client
+	failClosed  bool
+	logger      schemas.Logger
+}
+
+// scopedLimit is a limit that applies to an attempt, with the bucket it counts against.
+type scopedLimit struct {
+	scope  string
+	key    string // the virtual key, model or provider limited
+	bucket string // bucket key, without the rpm/tpm suffix
+	limit  Limit
+}
+
+// Init validates config and returns the plugin. When config.Redis is set, a Redis
+// client is created for the shared store.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	for scope, limits := range map[string]map[string]Limit{ScopeVirtualKey: config.VirtualKeys, ScopeModel: config.Models, ScopeProvider: config.Providers} {
+		for key, limit := range limits {
+			if key == "" {
+				return nil, fmt.Errorf("rate-limit: %s limits need a key", scope)
+			}
+			if limit.RPM < 0 || limit.TPM < 0 {
+				return nil, fmt.Errorf("rate-limit: %s %q limits cannot be negative", scope, key)
+			}
+		}
+	}
+	plugin := &Plugin{
+		virtualKeys: config.VirtualKeys,
+		models:      config.Models,
+		providers:   config.Providers,
+		store:       newMemoryStore(),
+		failClosed:  config.FailClosed,
+		logger:      logger,
+	}
+	if config.Redis != nil {
+		if config.Redis.Addr == "" {
+			return nil, fmt.Errorf("rate-limit: redis.addr is required when redis is configured")
+		}
+		keyPrefix := config.Redis.KeyPrefix
+		if keyPrefix == "" {
+			keyPrefix = DefaultKeyPrefix
+		}
+		plugin.redis = redis.NewClient(&redis.Options{
+			Addr:     config.Redis.Addr,
+			Username: config.Redis.Username,
+			Password: config.Redis.Password,
+			DB:       config.Redis.DB,
+		})
+		plugin.store = NewRedisStore(plugin.redis, keyPrefix)
+	}
+	return plugin, nil
+}
+
+// SetStore replaces the bucket store.
+func (p *Plugin) SetStore(store Store) { p.store = store }
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error {
+	if p.redis != nil {
+		return p.redis.Close()
+	}
+	return nil
+}
+
+// PreRequestHook implements schemas.LLMPlugin. Limits are checked per attempt in
+// PreLLMHook, once governance has resolved the virtual key.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// PreLLMHook takes a request from every RPM bucket of the attempt and turns it away
+// when a bucket is empty or a TPM bucket is in debt. Virtual key limits count once
+// per request; a model or provider limit lets the request fall back elsewhere.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	ctx.ClearValue(stateKey)
+	provider, model, _ := req.GetRequestFields()
+	limits := p.limitsFor(ctx, provider, model)
+	if len(limits) == 0 {
+		return req, nil, nil
+	}
+	admitted, _ := ctx.Value(virtualKeyAdmittedKey).(bool)
+	for _, limit := range limits {
+		if limit.scope == ScopeVirtualKey && admitted {
+			continue
+		}
+		if bifrostErr := p.admit(ctx, limit); bifrostErr != nil {
+			return req, &schemas.LLMPluginShortCircuit{Error: bifrostErr}, nil
+		}
+		if limit.scope == ScopeVirtualKey {
+			ctx.SetValue(virtualKeyAdmittedKey, true)
+		}
+	}
+	ctx.SetValue(stateKey, limits)
+	return req, nil, nil
+}
+
+// PostLLMHook charges the tokens the attempt used to its TPM buckets. Streams are
+// charged on the chunk that carries the usage.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	limits, ok := ctx.Value(stateKey).([]scopedLimit)
+	if !ok {
+		return result, bifrostErr, nil
+	}
+	tokens := usedTokens(result)
+	if tokens == 0 {
+		return result, bifrostErr, nil
+	}
+	ctx.ClearValue(stateKey)
+	for _, limit := range limits {
+		if limit.limit.TPM == 0 {
+			continue
+		}
+		if _, err := p.take(ctx, limit.bucket+":"+LimitTPM, limit.limit.TPM, 0, float64(tokens)); err != nil {
+			p.logger.Warn("rate-limit: failed to charge %d tokens to %s %s: %v", tokens, limit.scope, limit.key, err)
+		}
+	}
+	return result, bifrostErr, nil
+}
+
+// admit checks limit for one request, returning the error to turn it away with.
+func (p *Plugin) admit(ctx *schemas.BifrostContext, limit scopedLimit) *schemas.BifrostError {
+	checks := []struct {
+		name       string
+		perMinute  int
+		need, cost float64
+	}{
+		{LimitTPM, limit.limit.TPM, 1, 0}, // admit while the bucket is not in debt
+		{LimitRPM, limit.limit.RPM, 1, 1},
+	}
+	for _, check := range checks {
+		if check.perMinute == 0 {
+			continue
+		}
+		wait, err := p.take(ctx, limit.bucket+":"+check.name, check.perMinute, check.need, check.cost)
+		if err != nil {
+			if p.failClosed {
+				ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("rate limit store failed (%v), failing closed", err))
+				return &schemas.BifrostError{
+					IsBifrostError: true,
+					StatusCode:     schemas.Ptr(http.StatusServiceUnavailable),
+					Error:          &schemas.ErrorField{Message: "rate limits are unavailable"},
+					AllowFallbacks: schemas.Ptr(false),
+				}
+			}
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("rate limit store failed (%v), letting the request through", err))
+			return nil
+		}
+		if wait > 0 {
+			return rateLimitError(&schemas.RateLimitExceeded{
+				Scope:             limit.scope,
+				Key:               limit.key,
+				Limit:             check.name,
+				RetryAfterSeconds: max(1, int(math.Ceil(wait.Seconds()))),
+			})
+		}
+	}
+	return nil
+}
+
+// take runs Store.Take on the bucket of a per-minute limit.
+func (p *Plugin) take(ctx context.Context, key string, perMinute int, need, cost float64) (time.Duration, error) {
+	storeCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	bucket := Bucket{Capacity: float64(perMinute), Rate: float64(perMinute) / 60}
+	return p.store.Take(storeCtx, key, bucket, need, cost)
+}
+
+// limitsFor returns the limits that apply to an attempt on provider and model.
+func (p *Plugin) limitsFor(ctx *schemas.BifrostContext, provider schemas.ModelProvider, model string) []scopedLimit {
+	var limits []scopedLimit
+	if vkID, vkName := virtualKey(ctx); vkID != "" {
+		for _, key := range []string{vkID, vkName, DefaultKey} {
+			if limit, ok := p.virtualKeys[key]; ok && key != "" {
+				limits = append(limits, scopedLimit{scope: ScopeVirtualKey, key: vkName, bucket: "vk:" + vkID, limit: limit})
+				break
+			}
+		}
+	}
+	if model != "" {
+		providerModel := string(provider) + "/" + model
+		for _, key := range []string{providerModel, model, DefaultKey} {
+			if limit, ok := p.models[key]; ok {
+				if key == DefaultKey {
+					key = providerModel
+				}
+				limits = append(limits, scopedLimit{scope: ScopeModel, key: key, bucket: "model:" + key, limit: limit})
+				break
+			}
+		}
+	}
+	if provider != "" {
+		for _, key := range []string{string(provider), DefaultKey} {
+			if limit, ok := p.providers[key]; ok {
+				limits = append(limits, scopedLimit{scope: ScopeProvider, key: string(provider), bucket: "provider:" + string(provider), limit: limit})
+				break
+			}
+		}
+	}
+	return limits
+}
+
+// virtualKey returns the ID and name of the request's virtual key as resolved by
+// governance. Virtual key limits need the governance plugin.
+func virtualKey(ctx *schemas.BifrostContext) (id, name string) {
+	id, _ = ctx.Value(schemas.BifrostContextKeyGovernanceVirtualKeyID).(string)
+	name, _ = ctx.Value(schemas.BifrostContextKeyGovernanceVirtualKeyName).(string)
+	if name == "" {
+		name = id
+	}
+	return id, name
+}
+
+// usedTokens returns the input plus output tokens reported on result.
+func usedTokens(result *schemas.BifrostResponse) int {
+	switch {
+	case result == nil:
+		return 0
+	case result.TextCompletionResponse != nil && result.TextCompletionResponse.Usage != nil:
+		return result.TextCompletionResponse.Usage.TotalTokens
+	case result.ChatResponse != nil && result.ChatResponse.Usage != nil:
+		return result.ChatResponse.Usage.TotalTokens
+	case result.ResponsesResponse != nil && result.ResponsesResponse.Usage != nil:
+		return result.ResponsesResponse.Usage.TotalTokens
+	case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil && result.ResponsesStreamResponse.Response.Usage != nil:
+		return result.ResponsesStreamResponse.Response.Usage.TotalTokens
+	case result.EmbeddingResponse != nil && result.EmbeddingResponse.Usage != nil:
+		return result.EmbeddingResponse.Usage.TotalTokens
+	}
+	return 0
+}
+
+func rateLimitError(exceeded *schemas.RateLimitExceeded) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     schemas.Ptr(http.StatusTooManyRequests),
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr("rate_limit_error"),
+			Code:    schemas.Ptr(ErrCodeRateLimited),
+			Message: fmt.Sprintf("%s %s exceeded its %s limit, retry in %ds", exceeded.Scope, exceeded.Key, exceeded.Limit, exceeded.RetryAfterSeconds),
+		},
+		// Another provider or model may still have capacity; a virtual key does not.
+		AllowFallbacks: schemas.Ptr(exceeded.Scope != ScopeVirtualKey),
+		ExtraFields:    schemas.BifrostErrorExtraFields{RateLimit: exceeded},
+	}
+}
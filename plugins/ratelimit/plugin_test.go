@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func chatRequest(provider schemas.ModelProvider, model string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: provider, Model: model},
+	}
+}
+
+func chatResponse(totalTokens int) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Usage: &schemas.BifrostLLMUsage{TotalTokens: totalTokens},
+	}}
+}
+
+func vkContext(vkID string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	if vkID != "" {
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyID, vkID)
+		ctx.SetValue(schemas.BifrostContextKeyGovernanceVirtualKeyName, vkID+"-name")
+	}
+	return ctx
+}
+
+// newPlugin returns a plugin on an in-memory store whose clock the test controls.
+func newPlugin(t *testing.T, config Config) (*Plugin, *time.Time) {
+	t.Helper()
+	plugin, err := Init(config, nil)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	store := newMemoryStore()
+	store.now = func() time.Time { return now }
+	plugin.SetStore(store)
+	return plugin, &now
+}
+
+// send runs one attempt through the hooks, returning the error it was turned away with.
+func send(plugin *Plugin, ctx *schemas.BifrostContext, req *schemas.BifrostRequest, tokens int) *schemas.BifrostError {
+	_, shortCircuit, _ := plugin.PreLLMHook(ctx, req)
+	if shortCircuit != nil {
+		return shortCircuit.Error
+	}
+	plugin.PostLLMHook(ctx, chatResponse(tokens), nil)
+	return nil
+}
+
+func TestVirtualKeyRPM(t *testing.T) {
+	plugin, now := newPlugin(t, Config{VirtualKeys: map[string]Limit{"vk-a-name": {RPM: 2}, DefaultKey: {RPM: 1}}})
+	req := chatRequest(schemas.OpenAI, "gpt-4o")
+
+	for i := range 2 {
+		if err := send(plugin, vkContext("vk-a"), req, 10); err != nil {
+			t.Fatalf("request %d should be admitted, got %v", i, err.Error.Message)
+		}
+	}
+	err := send(plugin, vkContext("vk-a"), req, 10)
+	if err == nil || *err.StatusCode != 429 {
+		t.Fatalf("third request should be rate limited, got %+v", err)
+	}
+	got := err.ExtraFields.RateLimit
+	if got == nil || got.Scope != ScopeVirtualKey || got.Key != "vk-a-name" || got.Limit != LimitRPM || got.RetryAfterSeconds != 30 {
+		t.Fatalf("unexpected rate limit details %+v", got)
+	}
+	if err.AllowFallbacks == nil || *err.AllowFallbacks {
+		t.Error("a virtual key limit must not fall back")
+	}
+
+	// Other keys get their own bucket from the default.
+	if err := send(plugin, vkContext("vk-b"), req, 10); err != nil {
+		t.Fatalf("vk-b should have its own bucket, got %v", err.Error.Message)
+	}
+	if err := send(plugin, vkContext("vk-c"), req, 10); err != nil {
+		t.Fatalf("vk-c should have its own bucket, got %v", err.Error.Message)
+	}
+
+	// The bucket refills continuously: one request every 30s at 2 RPM.
+	*now = now.Add(30 * time.Second)
+	if err := send(plugin, vkContext("vk-a"), req, 10); err != nil {
+		t.Fatalf("request after refill should be admitted, got %v", err.Error.Message)
+	}
+	if err := send(plugin, vkContext(""), req, 10); err != nil {
+		t.Fatalf("requests without a virtual key are not limited, got %v", err.Error.Message)
+	}
+}
+
+func TestTPMDebt(t *testing.T) {
+	plugin, now := newPlugin(t, Config{Models: map[string]Limit{"gpt-4o": {TPM: 600}}})
+
+	// The first request overspends the minute's budget; the next waits out the debt.
+	if err := send(plugin, vkContext(""), chatRequest(schemas.OpenAI, "gpt-4o"), 900); err != nil {
+		t.Fatalf("first request should be admitted, got %v", err.Error.Message)
+	}
+	err := send(plugin, vkContext(""), chatRequest(schemas.Azure, "gpt-4o"), 10)
+	if err == nil {
+		t.Fatal("a model limit should be shared across providers")
+	}
+	// 301 tokens short at 10 tokens per second.
+	if got := err.ExtraFields.RateLimit; got.Scope != ScopeModel || got.Key != "gpt-4o" || got.Limit != LimitTPM || got.RetryAfterSeconds != 31 {
+		t.Fatalf("unexpected rate limit details %+v", got)
+	}
+	if err.AllowFallbacks == nil || !*err.AllowFallbacks {
+		t.Error("a model limit should let the request fall back")
+	}
+	if err := send(plugin, vkContext(""), chatRequest(schemas.OpenAI, "gpt-4o-mini"), 10); err != nil {
+		t.Fatalf("other models are not limited, got %v", err.Error.Message)
+	}
+
+	*now = now.Add(31 * time.Second)
+	if err := send(plugin, vkContext(""), chatRequest(schemas.OpenAI, "gpt-4o"), 10); err != nil {
+		t.Fatalf("request after the debt is repaid should be admitted, got %v", err.Error.Message)
+	}
+}
+
+func TestStreamChargedOnUsageChunk(t *testing.T) {
+	plugin, _ := newPlugin(t, Config{Providers: map[string]Limit{"openai": {TPM: 60}}})
+	ctx := vkContext("")
+	if _, shortCircuit, _ := plugin.PreLLMHook(ctx, chatRequest(schemas.OpenAI, "gpt-4o")); shortCircuit != nil {
+		t.Fatal("first request should be admitted")
+	}
+	plugin.PostLLMHook(ctx, &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{}}, nil)
+	plugin.PostLLMHook(ctx, chatResponse(60), nil)
+	plugin.PostLLMHook(ctx, chatResponse(60), nil) // a repeated usage chunk is not charged twice
+
+	err := send(plugin, vkContext(""), chatRequest(schemas.OpenAI, "gpt-4o"), 0)
+	if err == nil || err.ExtraFields.RateLimit.Scope != ScopeProvider || err.ExtraFields.RateLimit.RetryAfterSeconds != 1 {
+		t.Fatalf("expected the provider bucket to be empty after one charge, got %+v", err)
+	}
+}
+
+func TestFallbackCountsVirtualKeyOnce(t *testing.T) {
+	plugin, _ := newPlugin(t, Config{
+		VirtualKeys: map[string]Limit{"vk-a": {RPM: 1}},
+		Providers:   map[string]Limit{"openai": {RPM: 1}},
+	})
+	if err := send(plugin, vkContext("vk-x"), chatRequest(schemas.OpenAI, "gpt-4o"), 1); err != nil {
+		t.Fatalf("unrelated request should be admitted, got %v", err.Error.Message)
+	}
+
+	ctx := vkContext("vk-a")
+	err := send(plugin, ctx, chatRequest(schemas.OpenAI, "gpt-4o"), 1)
+	if err == nil || err.ExtraFields.RateLimit.Scope != ScopeProvider {
+		t.Fatalf("primary attempt should hit the provider limit, got %+v", err)
+	}
+	if err := send(plugin, ctx, chatRequest(schemas.Anthropic, "claude-sonnet-4"), 1); err != nil {
+		t.Fatalf("fallback attempt should not count against the virtual key again, got %v", err.Error.Message)
+	}
+}
+
+func TestInitRejectsInvalidConfig(t *testing.T) {
+	for name, config := range map[string]Config{
+		"negative rpm":     {VirtualKeys: map[string]Limit{"vk": {RPM: -1}}},
+		"empty key":        {Providers: map[string]Limit{"": {TPM: 10}}},
+		"redis addr empty": {Redis: &RedisConfig{}},
+	} {
+		if _, err := Init(config, nil); err == nil || !strings.HasPrefix(err.Error(), "rate-limit:") {
+			t.Errorf("%s: expected a rate-limit error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Bucket describes a token bucket: it holds up to Capacity tokens and refills
+// at Rate tokens per second. A bucket nobody has used yet is full.
+type Bucket struct {
+	Capacity float64
+	Rate     float64
+}
+
+// Store holds the level of every token bucket. Take must be atomic per key.
+type Store interface {
+	// Take refills the bucket at key and, when it holds at least need tokens,
+	// removes cost tokens and returns 0. Otherwise it leaves the bucket as is
+	// and returns how long until need tokens are available. cost may exceed
+	// the level, leaving the bucket in debt until it refills.
+	Take(ctx context.Context, key string, bucket Bucket, need, cost float64) (time.Duration, error)
+}
+
+// waitFor returns how long bucket takes to refill from level to need.
+func waitFor(bucket Bucket, level, need float64) time.Duration {
+	return time.Duration((need - level) / bucket.Rate * float64(time.Second))
+}
+
+// memoryStore keeps buckets in process memory, so limits are per Bifrost node.
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type memoryBucket struct {
+	level   float64
+	updated time.Time
+	full    time.Time // when the bucket will be full again and can be forgotten
+}
+
+// sweepInterval is how often buckets that have refilled completely are dropped.
+const sweepInterval = time.Minute
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{buckets: make(map[string]*memoryBucket), now: time.Now}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, bucket Bucket, need, cost float64) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{level: bucket.Capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.level = min(bucket.Capacity, b.level+now.Sub(b.updated).Seconds()*bucket.Rate)
+	b.updated = now
+	if b.level < need {
+		return waitFor(bucket, b.level, need), nil
+	}
+	b.level -= cost
+	b.full = now.Add(waitFor(bucket, b.level, bucket.Capacity))
+	return 0, nil
+}
+
+// takeScript is Store.Take as a Redis script. Levels are returned as strings
+// because Redis truncates Lua numbers to integers. The key expires once the
+// bucket is full again.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local need = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'level', 'updated')
+local level = tonumber(state[1]) or capacity
+local updated = tonumber(state[2]) or now
+level = math.min(capacity, level + math.max(0, now - updated) * rate)
+if level < need then
+  return tostring((need - level) / rate)
+end
+level = level - cost
+redis.call('HSET', KEYS[1], 'level', tostring(level), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - level) / rate * 1000) + 1000)
+return '0'
+`)
+
+// redisStore keeps buckets in Redis, so every Bifrost node shares the limits.
+type redisStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisStore returns a Store backed by client. Keys are namespaced with keyPrefix.
+func NewRedisStore(client redis.UniversalClient, keyPrefix string) Store {
+	return &redisStore{client: client, keyPrefix: keyPrefix}
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, bucket Bucket, need, cost float64) (time.Duration, error) {
+	result, err := takeScript.Run(ctx, s.client, []string{s.keyPrefix + key},
+		strconv.FormatFloat(bucket.Capacity, 'f', -1, 64),
+		strconv.FormatFloat(bucket.Rate, 'f', -1, 64),
+		strconv.FormatFloat(need, 'f', -1, 64),
+		strconv.FormatFloat(cost, 'f', -1, 64),
+	).Text()
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := strconv.ParseFloat(result, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
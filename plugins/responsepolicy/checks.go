@@ -0,0 +1,246 @@
+package responsepolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// Format is an output format a policy can forbid.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatCode     Format = "code"
+	FormatJSON     Format = "json"
+	FormatHTML     Format = "html"
+)
+
+var (
+	markdownPattern = regexp.MustCompile(`(?m)^#{1,6}\s|^\s*[-*+]\s+\S|^\s*\d+\.\s+\S|\*\*[^*\n]+\*\*|^\|.*\|\s*$|\[[^\]\n]+\]\([^)\n]+\)`)
+	codePattern     = regexp.MustCompile("```")
+	htmlPattern     = regexp.MustCompile(`(?i)</?(html|body|div|span|p|br|ul|ol|li|h[1-6]|table|tr|td|a|b|i|strong|em|pre|code)(\s[^>]*)?/?>`)
+)
+
+// formatDetectors report whether a text uses a format.
+var formatDetectors = map[Format]func(text string) bool{
+	FormatMarkdown: markdownPattern.MatchString,
+	FormatCode:     codePattern.MatchString,
+	FormatHTML:     htmlPattern.MatchString,
+	FormatJSON: func(text string) bool {
+		text = strings.TrimSpace(text)
+		return (strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")) && json.Valid([]byte(text))
+	},
+}
+
+// formatDescriptions name formats in retry instructions.
+var formatDescriptions = map[Format]string{
+	FormatMarkdown: "Markdown formatting (headings, lists, bold text, tables or links)",
+	FormatCode:     "code blocks",
+	FormatJSON:     "JSON",
+	FormatHTML:     "HTML markup",
+}
+
+// violation is one way a response breaks its policy.
+type violation struct {
+	kind    string // "length", "language" or "format"
+	message string
+}
+
+func violationMessages(violations []violation) []string {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.message
+	}
+	return messages
+}
+
+// checkResponse returns the policy violations of a chat response. Length is judged by
+// the reported completion tokens, or estimated from the text when the provider reports
+// no usage.
+func checkResponse(policy *Policy, response *schemas.BifrostChatResponse) []violation {
+	text := responseText(response)
+	var violations []violation
+	if limit := policy.MaxResponseTokens; limit > 0 {
+		tokens := estimateTokens(text)
+		if response.Usage != nil && response.Usage.CompletionTokens > 0 {
+			tokens = response.Usage.CompletionTokens
+		}
+		if tokens > limit {
+			violations = append(violations, violation{kind: "length", message: fmt.Sprintf("response is %d tokens, above the limit of %d", tokens, limit)})
+		}
+	}
+	if policy.Language != "" && text != "" {
+		if detected, ok := detectLanguage(text); ok && detected != policy.Language {
+			violations = append(violations, violation{kind: "language", message: fmt.Sprintf("response is in %s, not %s", languageNames[detected], languageNames[policy.Language])})
+		}
+	}
+	for _, format := range policy.ForbiddenFormats {
+		if formatDetectors[format](text) {
+			violations = append(violations, violation{kind: "format", message: fmt.Sprintf("response uses forbidden format %s", format)})
+		}
+	}
+	return violations
+}
+
+// responseText joins the text content of every choice.
+func responseText(response *schemas.BifrostChatResponse) string {
+	var parts []string
+	for _, choice := range response.Choices {
+		if text := choiceText(choice); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func choiceText(choice schemas.BifrostResponseChoice) string {
+	if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil || choice.Message.Content == nil {
+		return ""
+	}
+	content := choice.Message.Content
+	if content.ContentStr != nil {
+		return *content.ContentStr
+	}
+	var parts []string
+	for _, block := range content.ContentBlocks {
+		if block.Text != nil {
+			parts = append(parts, *block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// estimateTokens approximates a token count at four characters per token.
+func estimateTokens(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
+
+// truncateResponse cuts every choice's text to about limit tokens at a word boundary and
+// marks it finished for length. It reports whether anything was cut. Usage is left as the
+// provider reported it, since that is what was billed.
+func truncateResponse(response *schemas.BifrostChatResponse, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	maxRunes := limit * 4
+	truncated := false
+	for i := range response.Choices {
+		choice := &response.Choices[i]
+		if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil || choice.Message.Content == nil || choice.Message.Content.ContentStr == nil {
+			continue
+		}
+		runes := []rune(*choice.Message.Content.ContentStr)
+		if len(runes) <= maxRunes {
+			continue
+		}
+		cut := maxRunes
+		for cut > maxRunes/2 && !unicode.IsSpace(runes[cut]) {
+			cut--
+		}
+		if cut == maxRunes/2 {
+			cut = maxRunes
+		}
+		message := *choice.Message
+		content := *message.Content
+		content.ContentStr = schemas.Ptr(strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace))
+		message.Content = &content
+		choice.Message = &message
+		choice.FinishReason = schemas.Ptr(string(schemas.BifrostFinishReasonLength))
+		truncated = true
+	}
+	return truncated
+}
+
+// languageNames are the languages detectLanguage can tell apart.
+var languageNames = map[string]string{
+	"en": "English", "es": "Spanish", "fr": "French", "de": "German", "it": "Italian", "pt": "Portuguese",
+	"ru": "Russian", "ar": "Arabic", "hi": "Hindi", "zh": "Chinese", "ja": "Japanese", "ko": "Korean",
+	"el": "Greek", "he": "Hebrew", "th": "Thai",
+}
+
+// scriptLanguages map a Unicode script to the language it identifies on its own.
+var scriptLanguages = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"}, {unicode.Katakana, "ja"}, {unicode.Hangul, "ko"}, {unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"}, {unicode.Arabic, "ar"}, {unicode.Devanagari, "hi"},
+	{unicode.Greek, "el"}, {unicode.Hebrew, "he"}, {unicode.Thai, "th"},
+}
+
+// stopwords are frequent short words that tell Latin-script languages apart.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "of", "to", "in", "that", "it", "with", "for", "this", "you", "was"},
+	"es": {"el", "la", "los", "las", "de", "que", "y", "en", "es", "por", "con", "para", "una", "del"},
+	"fr": {"le", "la", "les", "de", "des", "et", "est", "que", "en", "un", "une", "pour", "dans", "avec"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "mit", "den", "von", "ich", "sie"},
+	"it": {"il", "lo", "la", "gli", "di", "che", "e", "è", "un", "una", "per", "con", "non", "sono"},
+	"pt": {"o", "a", "os", "as", "de", "que", "e", "é", "um", "uma", "para", "com", "não", "em"},
+}
+
+// detectLanguage guesses the language of text. Non-Latin scripts are identified by their
+// characters (Japanese kana win over the Han characters Japanese also uses); Latin-script
+// text is scored by stopwords. ok is false when the text gives too little to go on.
+func detectLanguage(text string) (language string, ok bool) {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.table, r) {
+				counts[script.language]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return "", false
+	}
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+	best, bestCount := "", 0
+	for language, count := range counts {
+		if count > bestCount {
+			best, bestCount = language, count
+		}
+	}
+	if bestCount*2 > letters {
+		return best, true
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	scores := make(map[string]int)
+	for _, word := range words {
+		for language, list := range stopwords {
+			for _, stopword := range list {
+				if word == stopword {
+					scores[language]++
+					break
+				}
+			}
+		}
+	}
+	best, bestScore, runnerUp := "", 0, 0
+	for language, score := range scores {
+		if score > bestScore {
+			best, bestScore, runnerUp = language, score, bestScore
+		} else if score > runnerUp {
+			runnerUp = score
+		}
+	}
+	// Require a few hits and a clear margin before calling it.
+	if bestScore < 3 || bestScore < runnerUp*3/2 {
+		return "", false
+	}
+	return best, true
+}
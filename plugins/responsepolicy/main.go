@@ -0,0 +1,307 @@
+// Package responsepolicy provides an LLM plugin that enforces lightweight output policies per
+// HTTP route: a maximum response length, a required response language and forbidden output
+// formats. The length limit is applied up front by capping the request's max tokens; every
+// policy is then checked on the response. A violating chat response is annotated, truncated or
+// retried once with an instruction that restates the policy, and every decision is recorded in
+// the plugin log of the request.
+package responsepolicy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+const PluginName = "response-policy"
+
+// Action is what the plugin does with a chat response that violates its policy.
+type Action string
+
+const (
+	// ActionAnnotate returns the response as is and lists the violations in
+	// extra_fields.policy_violations.
+	ActionAnnotate Action = "annotate"
+	// ActionTruncate cuts responses that are too long down to the limit. Other violations
+	// are annotated.
+	ActionTruncate Action = "truncate"
+	// ActionRetry sends the request once more with an instruction that restates the
+	// policy. Violations left after the retry are annotated.
+	ActionRetry Action = "retry"
+)
+
+// Policy constrains the responses of the routes it matches.
+type Policy struct {
+	Name string `json:"name"`
+	// Routes are HTTP route templates such as "/v1/chat/completions"; "*" matches any
+	// path segment (e.g. "/openai/*"). Empty matches every route.
+	Routes []string `json:"routes,omitempty"`
+	// MaxResponseTokens caps max_tokens on the way in and is checked against the
+	// completion tokens on the way out. 0 = no limit.
+	MaxResponseTokens int `json:"max_response_tokens,omitempty"`
+	// Language is the ISO 639-1 code the response must be written in (e.g. "en").
+	Language string `json:"language,omitempty"`
+	// ForbiddenFormats are formats the response must not use.
+	ForbiddenFormats []Format `json:"forbidden_formats,omitempty"`
+	// OnViolation defaults to ActionAnnotate.
+	OnViolation Action `json:"on_violation,omitempty"`
+}
+
+// Config configures the response policy plugin. The first policy whose routes match a
+// request applies to it.
+type Config struct {
+	Policies []Policy `json:"policies"`
+}
+
+// ChatCompleter sends a chat completion request; *bifrost.Bifrost implements it.
+type ChatCompleter interface {
+	ChatCompletionRequest(ctx *schemas.BifrostContext, req *schemas.BifrostChatRequest) (*schemas.BifrostChatResponse, *schemas.BifrostError)
+}
+
+// Context keys private to the plugin.
+const (
+	contextKeyAttempt schemas.BifrostContextKey = "response-policy-attempt" // *policyAttempt for the current attempt
+	contextKeyRetry   schemas.BifrostContextKey = "response-policy-retry"   // bool, set on the context of a policy retry
+)
+
+// policyAttempt is what PreLLMHook hands to PostLLMHook for one attempt.
+type policyAttempt struct {
+	policy *Policy
+	chat   *schemas.BifrostChatRequest
+}
+
+// Plugin implements schemas.LLMPlugin.
+type Plugin struct {
+	policies []Policy
+	client   ChatCompleter
+	logger   schemas.Logger
+}
+
+// Init validates config and returns the plugin. Retries need a client, set with SetClient;
+// until one is set, ActionRetry annotates instead.
+func Init(config Config, logger schemas.Logger) (*Plugin, error) {
+	for i, policy := range config.Policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("response-policy: policy %d needs a name", i)
+		}
+		if policy.MaxResponseTokens < 0 {
+			return nil, fmt.Errorf("response-policy: policy %q: max_response_tokens must not be negative", policy.Name)
+		}
+		if policy.Language != "" {
+			if _, ok := languageNames[policy.Language]; !ok {
+				return nil, fmt.Errorf("response-policy: policy %q: unsupported language %q", policy.Name, policy.Language)
+			}
+		}
+		for _, format := range policy.ForbiddenFormats {
+			if _, ok := formatDetectors[format]; !ok {
+				return nil, fmt.Errorf("response-policy: policy %q: unknown format %q", policy.Name, format)
+			}
+		}
+		switch policy.OnViolation {
+		case "":
+			config.Policies[i].OnViolation = ActionAnnotate
+		case ActionAnnotate, ActionTruncate, ActionRetry:
+		default:
+			return nil, fmt.Errorf("response-policy: policy %q: unknown on_violation %q", policy.Name, policy.OnViolation)
+		}
+		for _, route := range policy.Routes {
+			if _, err := path.Match(route, ""); err != nil {
+				return nil, fmt.Errorf("response-policy: policy %q: invalid route %q", policy.Name, route)
+			}
+		}
+	}
+	return &Plugin{policies: config.Policies, logger: logger}, nil
+}
+
+// SetClient sets the client used to retry violating responses.
+func (p *Plugin) SetClient(client ChatCompleter) { p.client = client }
+
+// GetName implements schemas.BasePlugin.
+func (p *Plugin) GetName() string { return PluginName }
+
+// Cleanup implements schemas.BasePlugin.
+func (p *Plugin) Cleanup() error { return nil }
+
+// PreRequestHook implements schemas.LLMPlugin. Policies are applied per attempt in PreLLMHook.
+func (p *Plugin) PreRequestHook(_ *schemas.BifrostContext, _ *schemas.BifrostRequest) error {
+	return nil
+}
+
+// policyFor returns the first policy matching the request's HTTP route, or nil.
+func (p *Plugin) policyFor(ctx *schemas.BifrostContext) *Policy {
+	route, _ := ctx.Value(schemas.BifrostContextKeyHTTPRoute).(string)
+	for i := range p.policies {
+		if len(p.policies[i].Routes) == 0 {
+			return &p.policies[i]
+		}
+		for _, pattern := range p.policies[i].Routes {
+			if matched, _ := path.Match(pattern, route); matched {
+				return &p.policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// PreLLMHook caps the request's max tokens at the policy's limit and remembers the chat
+// request so a violating response can be retried. The caller's parameters are never
+// modified; a capped request carries a copy.
+func (p *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) (*schemas.BifrostRequest, *schemas.LLMPluginShortCircuit, error) {
+	policy := p.policyFor(ctx)
+	if policy == nil {
+		return req, nil, nil
+	}
+	if limit := policy.MaxResponseTokens; limit > 0 {
+		capped := *req
+		req = &capped
+		switch {
+		case req.ChatRequest != nil:
+			chat := *req.ChatRequest
+			params := schemas.ChatParameters{}
+			if chat.Params != nil {
+				params = *chat.Params
+			}
+			if params.MaxCompletionTokens == nil || *params.MaxCompletionTokens > limit {
+				params.MaxCompletionTokens = schemas.Ptr(limit)
+				chat.Params = &params
+				req.ChatRequest = &chat
+				ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: capped max_completion_tokens at %d", policy.Name, limit))
+			}
+		case req.ResponsesRequest != nil:
+			responses := *req.ResponsesRequest
+			params := schemas.ResponsesParameters{}
+			if responses.Params != nil {
+				params = *responses.Params
+			}
+			if params.MaxOutputTokens == nil || *params.MaxOutputTokens > limit {
+				params.MaxOutputTokens = schemas.Ptr(limit)
+				responses.Params = &params
+				req.ResponsesRequest = &responses
+				ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: capped max_output_tokens at %d", policy.Name, limit))
+			}
+		case req.TextCompletionRequest != nil:
+			text := *req.TextCompletionRequest
+			params := schemas.TextCompletionParameters{}
+			if text.Params != nil {
+				params = *text.Params
+			}
+			if params.MaxTokens == nil || *params.MaxTokens > limit {
+				params.MaxTokens = schemas.Ptr(limit)
+				text.Params = &params
+				req.TextCompletionRequest = &text
+				ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: capped max_tokens at %d", policy.Name, limit))
+			}
+		}
+	}
+	if req.RequestType == schemas.ChatCompletionRequest && req.ChatRequest != nil {
+		ctx.SetValue(contextKeyAttempt, &policyAttempt{policy: policy, chat: req.ChatRequest})
+	}
+	return req, nil, nil
+}
+
+// PostLLMHook checks non-streaming chat responses against the policy and applies the
+// policy's action to violations. A policy retry is checked by the request that issued it,
+// not by its own hooks.
+func (p *Plugin) PostLLMHook(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
+	if bifrostErr != nil || result == nil || result.ChatResponse == nil {
+		return result, bifrostErr, nil
+	}
+	if retry, _ := ctx.Value(contextKeyRetry).(bool); retry {
+		return result, bifrostErr, nil
+	}
+	attempt, _ := ctx.Value(contextKeyAttempt).(*policyAttempt)
+	if attempt == nil {
+		return result, bifrostErr, nil
+	}
+	policy := attempt.policy
+	response := result.ChatResponse
+	violations := checkResponse(policy, response)
+	if len(violations) == 0 {
+		return result, bifrostErr, nil
+	}
+	ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("policy %s: response violates %s", policy.Name, strings.Join(violationMessages(violations), "; ")))
+
+	switch policy.OnViolation {
+	case ActionTruncate:
+		if truncateResponse(response, policy.MaxResponseTokens) {
+			ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: truncated the response to %d tokens", policy.Name, policy.MaxResponseTokens))
+			// Usage still reports the untruncated completion, so only the other checks are redone.
+			remaining := violations[:0]
+			for _, v := range checkResponse(policy, response) {
+				if v.kind != "length" {
+					remaining = append(remaining, v)
+				}
+			}
+			violations = remaining
+		}
+	case ActionRetry:
+		if p.client == nil {
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("policy %s: no client to retry with, annotating instead", policy.Name))
+			break
+		}
+		retried, retryErr := p.retry(ctx, attempt, violations)
+		if retryErr != nil {
+			message := "unknown error"
+			if retryErr.Error != nil {
+				message = retryErr.Error.Message
+			}
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("policy %s: retry failed (%s), keeping the first response", policy.Name, message))
+			break
+		}
+		// The retry is logged and billed as its own request, so the outer request keeps
+		// the usage and routing of the call it made.
+		retried.ExtraFields = response.ExtraFields
+		retried.Usage = response.Usage
+		response = retried
+		result.ChatResponse = retried
+		violations = checkResponse(policy, response)
+		if len(violations) == 0 {
+			ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: retry satisfied the policy", policy.Name))
+		} else {
+			ctx.Log(schemas.LogLevelWarn, fmt.Sprintf("policy %s: retry still violates %s", policy.Name, strings.Join(violationMessages(violations), "; ")))
+		}
+	}
+	if len(violations) > 0 {
+		response.ExtraFields.PolicyViolations = append(response.ExtraFields.PolicyViolations, violationMessages(violations)...)
+	}
+	return result, bifrostErr, nil
+}
+
+// retry sends the attempt's request again to the same provider and model, with an extra
+// system message that names the violations and restates the policy.
+func (p *Plugin) retry(ctx *schemas.BifrostContext, attempt *policyAttempt, violations []violation) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
+	retryCtx := schemas.NewBifrostContext(ctx, schemas.NoDeadline)
+	retryCtx.SetValue(contextKeyRetry, true)
+	if requestID, ok := ctx.Value(schemas.BifrostContextKeyRequestID).(string); ok {
+		retryCtx.SetValue(schemas.BifrostContextKeyRequestID, requestID+"-policy-retry")
+	}
+	defer retryCtx.Cancel()
+
+	req := *attempt.chat
+	req.Fallbacks = nil
+	req.Input = append(append([]schemas.ChatMessage(nil), attempt.chat.Input...), schemas.ChatMessage{
+		Role:    schemas.ChatMessageRoleSystem,
+		Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(retryInstruction(attempt.policy, violations))},
+	})
+	ctx.Log(schemas.LogLevelInfo, fmt.Sprintf("policy %s: retrying %s/%s with the policy restated", attempt.policy.Name, req.Provider, req.Model))
+	return p.client.ChatCompletionRequest(retryCtx, &req)
+}
+
+// retryInstruction tells the model what was wrong with its answer and what the policy asks for.
+func retryInstruction(policy *Policy, violations []violation) string {
+	var b strings.Builder
+	b.WriteString("Your previous answer was rejected: ")
+	b.WriteString(strings.Join(violationMessages(violations), "; "))
+	b.WriteString(". Answer the last request again.")
+	if policy.Language != "" {
+		fmt.Fprintf(&b, " Write the entire answer in %s.", languageNames[policy.Language])
+	}
+	for _, format := range policy.ForbiddenFormats {
+		fmt.Fprintf(&b, " Do not use %s.", formatDescriptions[format])
+	}
+	if policy.MaxResponseTokens > 0 {
+		fmt.Fprintf(&b, " Keep the answer under %d words.", policy.MaxResponseTokens*3/4)
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package responsepolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func chatRequest(maxTokens *int) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{
+			Provider: schemas.OpenAI,
+			Model:    "gpt-4o",
+			Input:    []schemas.ChatMessage{{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hi")}}},
+			Params:   &schemas.ChatParameters{MaxCompletionTokens: maxTokens},
+		},
+	}
+}
+
+func chatResult(text string, completionTokens int) *schemas.BifrostResponse {
+	return &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{
+			FinishReason: schemas.Ptr("stop"),
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{
+				Message: &schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr(text)}},
+			},
+		}},
+		Usage: &schemas.BifrostLLMUsage{CompletionTokens: completionTokens},
+	}}
+}
+
+func routeContext(route string) *schemas.BifrostContext {
+	ctx := schemas.NewBifrostContext(nil, schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyHTTPRoute, route)
+	return ctx
+}
+
+// fakeClient answers every retry with the same text and records the requests.
+type fakeClient struct {
+	text     string
+	requests []*schemas.BifrostChatRequest
+}
+
+func (c *fakeClient) ChatCompletionRequest(ctx *schemas.BifrostContext, req *schemas.BifrostChatRequest) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
+	if retry, _ := ctx.Value(contextKeyRetry).(bool); !retry {
+		panic("a policy retry must be marked on its context")
+	}
+	c.requests = append(c.requests, req)
+	return chatResult(c.text, 5).ChatResponse, nil
+}
+
+func TestPreLLMHookCapsMaxTokensOnMatchingRoutes(t *testing.T) {
+	p, err := Init(Config{Policies: []Policy{{Name: "support", Routes: []string{"/v1/chat/completions"}, MaxResponseTokens: 100}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := chatRequest(schemas.Ptr(500))
+	req, _, _ := p.PreLLMHook(routeContext("/v1/chat/completions"), original)
+	if got := *req.ChatRequest.Params.MaxCompletionTokens; got != 100 {
+		t.Fatalf("expected max tokens capped at 100, got %d", got)
+	}
+	if *original.ChatRequest.Params.MaxCompletionTokens != 500 {
+		t.Fatal("the caller's parameters must not be modified")
+	}
+
+	req, _, _ = p.PreLLMHook(routeContext("/v1/chat/completions"), chatRequest(schemas.Ptr(50)))
+	if got := *req.ChatRequest.Params.MaxCompletionTokens; got != 50 {
+		t.Fatalf("a lower max tokens should be kept, got %d", got)
+	}
+
+	req, _, _ = p.PreLLMHook(routeContext("/v1/embeddings"), chatRequest(nil))
+	if req.ChatRequest.Params.MaxCompletionTokens != nil {
+		t.Fatal("routes outside the policy should not be capped")
+	}
+}
+
+func TestPostLLMHookAnnotatesAndTruncates(t *testing.T) {
+	p, err := Init(Config{Policies: []Policy{
+		{Name: "short", Routes: []string{"/short"}, MaxResponseTokens: 5, OnViolation: ActionTruncate},
+		{Name: "plain", ForbiddenFormats: []Format{FormatMarkdown}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := routeContext("/short")
+	p.PreLLMHook(ctx, chatRequest(nil))
+	result, _, _ := p.PostLLMHook(ctx, chatResult("one two three four five six seven eight nine ten", 12), nil)
+	choice := result.ChatResponse.Choices[0]
+	if text := *choice.Message.Content.ContentStr; text != "one two three four" || *choice.FinishReason != "length" {
+		t.Fatalf("expected a truncated response, got %q (%s)", text, *choice.FinishReason)
+	}
+	if len(result.ChatResponse.ExtraFields.PolicyViolations) != 0 {
+		t.Fatalf("a truncated response should not be annotated, got %v", result.ChatResponse.ExtraFields.PolicyViolations)
+	}
+
+	ctx = routeContext("/v1/chat/completions")
+	p.PreLLMHook(ctx, chatRequest(nil))
+	result, _, _ = p.PostLLMHook(ctx, chatResult("## Answer\n- **yes**", 4), nil)
+	if v := result.ChatResponse.ExtraFields.PolicyViolations; len(v) != 1 || !strings.Contains(v[0], "markdown") {
+		t.Fatalf("expected a markdown violation, got %v", v)
+	}
+}
+
+func TestPostLLMHookRetriesWithPolicyInstruction(t *testing.T) {
+	p, err := Init(Config{Policies: []Policy{{Name: "english", Language: "en", OnViolation: ActionRetry}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{text: "The answer is that it depends on the size of the order."}
+	p.SetClient(client)
+
+	ctx := routeContext("/v1/chat/completions")
+	p.PreLLMHook(ctx, chatRequest(nil))
+	result, _, _ := p.PostLLMHook(ctx, chatResult("La respuesta es que depende del tamaño de la orden y de los productos.", 12), nil)
+
+	if len(client.requests) != 1 {
+		t.Fatalf("expected one retry, got %d", len(client.requests))
+	}
+	retry := client.requests[0]
+	last := retry.Input[len(retry.Input)-1]
+	if last.Role != schemas.ChatMessageRoleSystem || !strings.Contains(*last.Content.ContentStr, "Write the entire answer in English") {
+		t.Fatalf("expected the retry to restate the policy, got %+v", last)
+	}
+	if got := *result.ChatResponse.Choices[0].Message.Content.ContentStr; got != client.text {
+		t.Fatalf("expected the retried answer, got %q", got)
+	}
+	if result.ChatResponse.Usage.CompletionTokens != 12 || len(result.ChatResponse.ExtraFields.PolicyViolations) != 0 {
+		t.Fatalf("expected the first call's usage and no violations, got %+v", result.ChatResponse)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	for text, want := range map[string]string{
+		"The weather is nice and it is warm for this time of the year.": "en",
+		"Der Hund ist nicht mit den Kindern in die Schule gegangen.":    "de",
+		"Il est parti avec les enfants pour une semaine dans le sud.":   "fr",
+		"Привет, как у тебя дела сегодня?":                              "ru",
+		"今日はとても良い天気ですね。":                                                "ja",
+		"今天天气很好。":                                                       "zh",
+	} {
+		if got, ok := detectLanguage(text); !ok || got != want {
+			t.Errorf("detectLanguage(%q) = %q, %v; want %q", text, got, ok, want)
+		}
+	}
+	if _, ok := detectLanguage("OK"); ok {
+		t.Error("short text should not be classified")
+	}
+}
+
+func TestInitRejectsInvalidPolicies(t *testing.T) {
+	for name, policy := range map[string]Policy{
+		"no name":       {Language: "en"},
+		"language":      {Name: "p", Language: "xx"},
+		"format":        {Name: "p", ForbiddenFormats: []Format{"yaml"}},
+		"action":        {Name: "p", OnViolation: "drop"},
+		"negative size": {Name: "p", MaxResponseTokens: -1},
+	} {
+		if _, err := Init(Config{Policies: []Policy{policy}}, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package semanticcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/vectorstore"
+)
+
+// statsPageSize is the page size GetCacheStats scans the namespace with.
+const statsPageSize int64 = 1000
+
+// maxStatsEntries caps how many entries GetCacheStats scans, so inspecting a
+// very large cache can't stall the vector store. CacheStats.Truncated is set
+// when the cap is hit.
+const maxStatsEntries = 100_000
+
+// PurgeFilter selects the cache entries Purge deletes. Every set field must
+// match. At least one field must be set, so an empty filter can never wipe the
+// whole namespace by accident.
+type PurgeFilter struct {
+	CacheKey string
+	Provider schemas.ModelProvider
+	Model    string
+	// OlderThan deletes entries written at least this long ago. Entries only
+	// record their expiry, so age is derived from the plugin TTL; entries
+	// written with a per-request TTL override are aged as if they used it.
+	OlderThan time.Duration
+	// Expired deletes entries past their expiry that the store still holds.
+	Expired bool
+	// Metadata matches further indexed properties exactly, e.g. params_hash.
+	Metadata map[string]any
+}
+
+// CacheStats reports what the cache holds and how often lookups hit it.
+type CacheStats struct {
+	Entries    int64            `json:"entries"`
+	Expired    int64            `json:"expired"`
+	ByCacheKey map[string]int64 `json:"by_cache_key"`
+	ByProvider map[string]int64 `json:"by_provider"`
+	ByModel    map[string]int64 `json:"by_model"`
+	// Truncated is true when the namespace holds more entries than were scanned.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Lookups and Hits count cache lookups since the plugin started; GetStats
+	// breaks them down by outcome and cache key.
+	Lookups int64   `json:"lookups"`
+	Hits    int64   `json:"hits"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// purgeableProperties are the indexed properties a PurgeFilter may match in
+// Metadata. Payload columns are excluded: they aren't filterable everywhere.
+var purgeableProperties = map[string]struct{}{
+	"cache_key":   {},
+	"provider":    {},
+	"model":       {},
+	"params_hash": {},
+}
+
+// queries translates the filter into vector store queries scoped to entries
+// this plugin wrote.
+func (f PurgeFilter) queries(ttl time.Duration, now time.Time) ([]vectorstore.Query, error) {
+	queries := []vectorstore.Query{
+		{Field: "from_bifrost_semantic_cache_plugin", Operator: vectorstore.QueryOperatorEqual, Value: true},
+	}
+	match := func(field string, value any) {
+		queries = append(queries, vectorstore.Query{Field: field, Operator: vectorstore.QueryOperatorEqual, Value: value})
+	}
+	if f.CacheKey != "" {
+		match("cache_key", f.CacheKey)
+	}
+	if f.Provider != "" {
+		match("provider", string(f.Provider))
+	}
+	if f.Model != "" {
+		match("model", f.Model)
+	}
+	for field, value := range f.Metadata {
+		if _, ok := purgeableProperties[field]; !ok {
+			return nil, fmt.Errorf("cannot filter cache entries by %q", field)
+		}
+		match(field, value)
+	}
+	if f.OlderThan < 0 {
+		return nil, fmt.Errorf("older_than must not be negative")
+	}
+	// An entry written at t expires at t+ttl, so written before now-OlderThan
+	// means expiring before now-OlderThan+ttl. Expired narrows to the earlier
+	// of the two bounds.
+	var expiresBefore time.Time
+	if f.OlderThan > 0 {
+		expiresBefore = now.Add(ttl - f.OlderThan)
+	}
+	if f.Expired && (expiresBefore.IsZero() || now.Before(expiresBefore)) {
+		expiresBefore = now
+	}
+	if !expiresBefore.IsZero() {
+		queries = append(queries, vectorstore.Query{Field: "expires_at", Operator: vectorstore.QueryOperatorLessThan, Value: expiresBefore.Unix()})
+	}
+	if len(queries) == 1 {
+		return nil, fmt.Errorf("purge filter must set at least one field")
+	}
+	return queries, nil
+}
+
+// Purge deletes every cache entry matching filter and returns how many were
+// deleted. Use ClearCacheForCacheID to delete a single entry.
+func (plugin *Plugin) Purge(ctx context.Context, filter PurgeFilter) (int, error) {
+	queries, err := filter.queries(plugin.config.TTL, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, CacheSetTimeout)
+	defer cancel()
+	results, err := plugin.store.DeleteAll(ctx, plugin.config.VectorStoreNamespace, queries)
+	if err != nil {
+		plugin.logger.Warn("Failed to purge cache entries: %v", err)
+		return 0, err
+	}
+	deleted := 0
+	for _, result := range results {
+		if result.Status == vectorstore.DeleteStatusError {
+			plugin.logger.Warn("Failed to delete cache entry %s: %s", result.ID, result.Error)
+			continue
+		}
+		deleted++
+	}
+	plugin.logger.Debug("Purged %d cache entries", deleted)
+	return deleted, nil
+}
+
+// GetCacheStats counts the entries in the cache by cache key, provider and
+// model, and reports the hit rate of lookups since startup. It scans the
+// namespace, so it is meant for admin use rather than the request path.
+func (plugin *Plugin) GetCacheStats(ctx context.Context) (*CacheStats, error) {
+	lookups := plugin.stats.total.snapshot()
+	stats := &CacheStats{
+		ByCacheKey: make(map[string]int64),
+		ByProvider: make(map[string]int64),
+		ByModel:    make(map[string]int64),
+		Lookups:    lookups.Lookups,
+		Hits:       lookups.DirectHits + lookups.SemanticHits,
+		HitRate:    lookups.HitRate,
+	}
+
+	queries := []vectorstore.Query{
+		{Field: "from_bifrost_semantic_cache_plugin", Operator: vectorstore.QueryOperatorEqual, Value: true},
+	}
+	selectFields := []string{"cache_key", "provider", "model", "expires_at"}
+	var cursor *string
+	for {
+		results, next, err := plugin.store.GetAll(ctx, plugin.config.VectorStoreNamespace, queries, selectFields, cursor, statsPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cache entries: %w", err)
+		}
+		for _, result := range results {
+			stats.Entries++
+			if key, ok := result.Properties["cache_key"].(string); ok {
+				stats.ByCacheKey[key]++
+			}
+			if provider, ok := result.Properties["provider"].(string); ok {
+				stats.ByProvider[provider]++
+			}
+			if model, ok := result.Properties["model"].(string); ok {
+				stats.ByModel[model]++
+			}
+			if expired, _ := isExpiredEntry(result.Properties); expired {
+				stats.Expired++
+			}
+		}
+		if next == nil || *next == "" || len(results) == 0 {
+			break
+		}
+		if stats.Entries >= maxStatsEntries {
+			stats.Truncated = true
+			break
+		}
+		cursor = next
+	}
+	return stats, nil
+}
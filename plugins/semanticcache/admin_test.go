@@ -0,0 +1,149 @@
+package semanticcache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/vectorstore"
+)
+
+// pagedStore serves its chunks from GetAll two at a time, so GetCacheStats
+// has to follow the cursor.
+type pagedStore struct {
+	*observableStore
+	order []string
+}
+
+func (s *pagedStore) GetAll(ctx context.Context, ns string, q []vectorstore.Query, sf []string, cur *string, lim int64) ([]vectorstore.SearchResult, *string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offset := 0
+	if cur != nil {
+		offset, _ = strconv.Atoi(*cur)
+	}
+	end := min(offset+2, len(s.order))
+	var results []vectorstore.SearchResult
+	for _, id := range s.order[offset:end] {
+		results = append(results, s.chunks[id])
+	}
+	if end == len(s.order) {
+		return results, nil, nil
+	}
+	next := strconv.Itoa(end)
+	return results, &next, nil
+}
+
+func queryFor(queries []vectorstore.Query, field string) *vectorstore.Query {
+	for i := range queries {
+		if queries[i].Field == field {
+			return &queries[i]
+		}
+	}
+	return nil
+}
+
+func TestPurgeFilter_RequiresAField(t *testing.T) {
+	if _, err := (PurgeFilter{}).queries(time.Hour, time.Now()); err == nil {
+		t.Fatal("expected an empty filter to be rejected")
+	}
+	if _, err := (PurgeFilter{Metadata: map[string]any{"response": "x"}}).queries(time.Hour, time.Now()); err == nil {
+		t.Fatal("expected a filter on an unindexed property to be rejected")
+	}
+}
+
+func TestPurgeFilter_Queries(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	queries, err := PurgeFilter{
+		Provider:  "openai",
+		Model:     "gpt-4o",
+		OlderThan: 10 * time.Minute,
+		Metadata:  map[string]any{"params_hash": "abc"},
+	}.queries(time.Hour, now)
+	if err != nil {
+		t.Fatalf("queries failed: %v", err)
+	}
+	if q := queryFor(queries, "from_bifrost_semantic_cache_plugin"); q == nil || q.Value != true {
+		t.Errorf("expected the plugin marker filter, got %+v", queries)
+	}
+	if q := queryFor(queries, "provider"); q == nil || q.Value != "openai" {
+		t.Errorf("expected provider=openai, got %+v", queries)
+	}
+	if q := queryFor(queries, "params_hash"); q == nil || q.Value != "abc" {
+		t.Errorf("expected params_hash=abc, got %+v", queries)
+	}
+	// Written more than 10 minutes ago with a 1h TTL = expiring within 50 minutes.
+	q := queryFor(queries, "expires_at")
+	if q == nil || q.Operator != vectorstore.QueryOperatorLessThan || q.Value != now.Add(50*time.Minute).Unix() {
+		t.Errorf("expected expires_at < now+50m, got %+v", q)
+	}
+
+	queries, err = PurgeFilter{OlderThan: 10 * time.Minute, Expired: true}.queries(time.Hour, now)
+	if err != nil {
+		t.Fatalf("queries failed: %v", err)
+	}
+	if q := queryFor(queries, "expires_at"); q == nil || q.Value != now.Unix() {
+		t.Errorf("expected expired to narrow expires_at to now, got %+v", q)
+	}
+}
+
+func TestPurge_CountsDeletedEntries(t *testing.T) {
+	store := newObservableStore()
+	store.deleteAllResults = []vectorstore.DeleteResult{
+		{ID: "a", Status: vectorstore.DeleteStatusSuccess},
+		{ID: "b", Status: vectorstore.DeleteStatusError, Error: "boom"},
+		{ID: "c", Status: vectorstore.DeleteStatusSuccess},
+	}
+	plugin := newTestPlugin(t, store)
+
+	deleted, err := plugin.Purge(context.Background(), PurgeFilter{Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("expected 2 deleted entries, got %d", deleted)
+	}
+	if len(store.deleteAllQueries) != 1 || queryFor(store.deleteAllQueries[0], "model") == nil {
+		t.Fatalf("expected one DeleteAll filtered by model, got %+v", store.deleteAllQueries)
+	}
+}
+
+func TestGetCacheStats(t *testing.T) {
+	store := &pagedStore{observableStore: newObservableStore()}
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Hour).Unix()
+	for i, entry := range []struct {
+		key, provider, model string
+		expiresAt            int64
+	}{
+		{"tenant-a", "openai", "gpt-4o", future},
+		{"tenant-a", "openai", "gpt-4o-mini", future},
+		{"tenant-b", "anthropic", "claude", past},
+	} {
+		id := strconv.Itoa(i)
+		store.order = append(store.order, id)
+		store.chunks[id] = vectorstore.SearchResult{ID: id, Properties: map[string]any{
+			"cache_key": entry.key, "provider": entry.provider, "model": entry.model, "expires_at": entry.expiresAt,
+		}}
+	}
+	plugin := newTestPlugin(t, store)
+	for range 4 {
+		plugin.stats.recordLookup("tenant-a")
+	}
+	plugin.stats.recordHit("tenant-a", CacheTypeDirect)
+
+	stats, err := plugin.GetCacheStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetCacheStats failed: %v", err)
+	}
+	if stats.Entries != 3 || stats.Expired != 1 {
+		t.Fatalf("expected 3 entries with 1 expired, got %+v", stats)
+	}
+	if stats.ByCacheKey["tenant-a"] != 2 || stats.ByProvider["anthropic"] != 1 || stats.ByModel["gpt-4o-mini"] != 1 {
+		t.Fatalf("unexpected breakdown: %+v", stats)
+	}
+	if stats.HitRate != 0.25 {
+		t.Fatalf("expected hit rate 0.25, got %v", stats.HitRate)
+	}
+}
@@ -0,0 +1,159 @@
+package semanticcache
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// EmbeddingStrategyType selects which part of a chat or responses conversation
+// is embedded for semantic lookups.
+type EmbeddingStrategyType string
+
+const (
+	// EmbeddingStrategyFullConversation embeds every message (the default).
+	EmbeddingStrategyFullConversation EmbeddingStrategyType = "full_conversation"
+	// EmbeddingStrategyLastUserMessage embeds only the latest user message.
+	EmbeddingStrategyLastUserMessage EmbeddingStrategyType = "last_user_message"
+	// EmbeddingStrategyLastNMessages embeds the last LastN messages.
+	EmbeddingStrategyLastNMessages EmbeddingStrategyType = "last_n_messages"
+	// EmbeddingStrategyTemplate renders Template over the conversation.
+	EmbeddingStrategyTemplate EmbeddingStrategyType = "template"
+)
+
+// EmbeddingStrategyConfig controls the text embedded for semantic lookups of
+// chat and responses requests. Other request types always embed their whole
+// input. The direct hash path is unaffected: exact replays still match on the
+// full request.
+//
+// In a long multi-turn chat the latest question is drowned out by the
+// history when the whole conversation is embedded, so two chats on different
+// topics that share an opening match each other. Embedding only the tail of
+// the conversation avoids that.
+type EmbeddingStrategyConfig struct {
+	// Type is the strategy (default: full_conversation).
+	Type EmbeddingStrategyType `json:"type,omitempty"`
+	// LastN is the number of trailing messages embedded by last_n_messages.
+	LastN int `json:"last_n,omitempty"`
+	// Template is a Go text/template rendered by the template strategy. It
+	// sees .Messages (each with .Role, .Type and .Content, oldest first) and
+	// .LastUserMessage.
+	Template string `json:"template,omitempty"`
+	// MinMessages is the conversation length from which the strategy applies.
+	// Shorter conversations are embedded in full, since a single message out
+	// of a two-turn chat carries too little context to match on (default: 0,
+	// always apply).
+	MinMessages int `json:"min_messages,omitempty"`
+
+	tmpl *template.Template
+}
+
+// validate checks the strategy and compiles its template, filling in the
+// default type.
+func (c *EmbeddingStrategyConfig) validate() error {
+	if c.Type == "" {
+		c.Type = EmbeddingStrategyFullConversation
+	}
+	if c.MinMessages < 0 {
+		return fmt.Errorf("embedding_strategy.min_messages must be non-negative, got %d", c.MinMessages)
+	}
+	switch c.Type {
+	case EmbeddingStrategyFullConversation, EmbeddingStrategyLastUserMessage:
+	case EmbeddingStrategyLastNMessages:
+		if c.LastN <= 0 {
+			return fmt.Errorf("embedding_strategy.last_n must be > 0 for %s", c.Type)
+		}
+	case EmbeddingStrategyTemplate:
+		if strings.TrimSpace(c.Template) == "" {
+			return fmt.Errorf("embedding_strategy.template is required for %s", c.Type)
+		}
+		tmpl, err := template.New("embedding").Option("missingkey=error").Parse(c.Template)
+		if err != nil {
+			return fmt.Errorf("failed to parse embedding_strategy.template: %w", err)
+		}
+		c.tmpl = tmpl
+	default:
+		return fmt.Errorf("unknown embedding_strategy.type %q", c.Type)
+	}
+	return nil
+}
+
+// embeddingMessage is one text-bearing message of a conversation, with its
+// content already normalized.
+type embeddingMessage struct {
+	Role    string
+	Type    string
+	Content string
+}
+
+// line serializes the message for embedding: "role: content", or
+// "role: type: content" for responses messages that carry a type.
+func (m embeddingMessage) line() string {
+	if m.Type != "" {
+		return fmt.Sprintf("%s: %s: %s", m.Role, m.Type, m.Content)
+	}
+	return fmt.Sprintf("%s: %s", m.Role, m.Content)
+}
+
+// joinEmbeddingMessages serializes messages one per line.
+func joinEmbeddingMessages(msgs []embeddingMessage) string {
+	lines := make([]string, len(msgs))
+	for i, msg := range msgs {
+		lines[i] = msg.line()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lastUserMessage returns the latest message with the user role.
+func lastUserMessage(msgs []embeddingMessage) (embeddingMessage, bool) {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == "user" {
+			return msgs[i], true
+		}
+	}
+	return embeddingMessage{}, false
+}
+
+// applyEmbeddingStrategy builds the text to embed from a conversation's
+// text-bearing messages. msgs must not be empty.
+func (plugin *Plugin) applyEmbeddingStrategy(msgs []embeddingMessage) (string, error) {
+	strategy := plugin.config.EmbeddingStrategy
+	if strategy == nil || strategy.Type == EmbeddingStrategyFullConversation || len(msgs) < strategy.MinMessages {
+		return joinEmbeddingMessages(msgs), nil
+	}
+	switch strategy.Type {
+	case EmbeddingStrategyLastUserMessage:
+		msg, ok := lastUserMessage(msgs)
+		if !ok {
+			return "", fmt.Errorf("no user message found for embedding")
+		}
+		return msg.line(), nil
+	case EmbeddingStrategyLastNMessages:
+		if len(msgs) > strategy.LastN {
+			msgs = msgs[len(msgs)-strategy.LastN:]
+		}
+		return joinEmbeddingMessages(msgs), nil
+	case EmbeddingStrategyTemplate:
+		if strategy.tmpl == nil {
+			return "", fmt.Errorf("embedding template is not compiled")
+		}
+		data := struct {
+			Messages        []embeddingMessage
+			LastUserMessage string
+		}{Messages: msgs}
+		if msg, ok := lastUserMessage(msgs); ok {
+			data.LastUserMessage = msg.Content
+		}
+		var sb strings.Builder
+		if err := strategy.tmpl.Execute(&sb, data); err != nil {
+			return "", fmt.Errorf("failed to render embedding template: %w", err)
+		}
+		text := strings.TrimSpace(sb.String())
+		if text == "" {
+			return "", fmt.Errorf("embedding template rendered no text")
+		}
+		return text, nil
+	default:
+		return joinEmbeddingMessages(msgs), nil
+	}
+}
@@ -0,0 +1,115 @@
+package semanticcache
+
+import (
+	"testing"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func strategyChatRequest(turns ...string) *schemas.BifrostRequest {
+	roles := []schemas.ChatMessageRole{schemas.ChatMessageRoleUser, schemas.ChatMessageRoleAssistant}
+	input := []schemas.ChatMessage{{
+		Role:    schemas.ChatMessageRoleSystem,
+		Content: &schemas.ChatMessageContent{ContentStr: bifrost.Ptr("You are helpful")},
+	}}
+	for i, turn := range turns {
+		input = append(input, schemas.ChatMessage{
+			Role:    roles[i%2],
+			Content: &schemas.ChatMessageContent{ContentStr: bifrost.Ptr(turn)},
+		})
+	}
+	return &schemas.BifrostRequest{
+		RequestType: schemas.ChatCompletionRequest,
+		ChatRequest: &schemas.BifrostChatRequest{Provider: schemas.OpenAI, Model: "gpt-4o-mini", Input: input},
+	}
+}
+
+func strategyPlugin(t *testing.T, strategy *EmbeddingStrategyConfig) *Plugin {
+	t.Helper()
+	if strategy != nil {
+		if err := strategy.validate(); err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+	}
+	return &Plugin{config: &Config{EmbeddingStrategy: strategy}}
+}
+
+func TestEmbeddingStrategies(t *testing.T) {
+	req := strategyChatRequest("What is Go?", "A language.", "Who made it?")
+
+	tests := []struct {
+		name     string
+		strategy *EmbeddingStrategyConfig
+		want     string
+	}{
+		{
+			name: "default embeds the full conversation",
+			want: "system: you are helpful\nuser: what is go?\nassistant: a language.\nuser: who made it?",
+		},
+		{
+			name:     "last user message",
+			strategy: &EmbeddingStrategyConfig{Type: EmbeddingStrategyLastUserMessage},
+			want:     "user: who made it?",
+		},
+		{
+			name:     "last n messages",
+			strategy: &EmbeddingStrategyConfig{Type: EmbeddingStrategyLastNMessages, LastN: 2},
+			want:     "assistant: a language.\nuser: who made it?",
+		},
+		{
+			name: "template",
+			strategy: &EmbeddingStrategyConfig{
+				Type:     EmbeddingStrategyTemplate,
+				Template: "{{range .Messages}}{{if eq .Role \"user\"}}{{.Content}} {{end}}{{end}}| {{.LastUserMessage}}",
+			},
+			want: "what is go? who made it? | who made it?",
+		},
+		{
+			name:     "conversations shorter than min_messages are embedded in full",
+			strategy: &EmbeddingStrategyConfig{Type: EmbeddingStrategyLastUserMessage, MinMessages: 5},
+			want:     "system: you are helpful\nuser: what is go?\nassistant: a language.\nuser: who made it?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := strategyPlugin(t, tt.strategy).extractTextForEmbedding(nil, req)
+			if err != nil {
+				t.Fatalf("extractTextForEmbedding: %v", err)
+			}
+			if text != tt.want {
+				t.Fatalf("got %q, want %q", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddingStrategyLastUserMessageWithoutUser(t *testing.T) {
+	plugin := strategyPlugin(t, &EmbeddingStrategyConfig{Type: EmbeddingStrategyLastUserMessage})
+	if _, err := plugin.extractTextForEmbedding(nil, strategyChatRequest()); err == nil {
+		t.Fatal("expected an error when the conversation has no user message")
+	}
+}
+
+func TestEmbeddingStrategyValidate(t *testing.T) {
+	invalid := []*EmbeddingStrategyConfig{
+		{Type: "first_message"},
+		{Type: EmbeddingStrategyLastNMessages},
+		{Type: EmbeddingStrategyTemplate},
+		{Type: EmbeddingStrategyTemplate, Template: "{{.Messages"},
+		{Type: EmbeddingStrategyLastUserMessage, MinMessages: -1},
+	}
+	for _, strategy := range invalid {
+		if err := strategy.validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", *strategy)
+		}
+	}
+
+	strategy := &EmbeddingStrategyConfig{}
+	if err := strategy.validate(); err != nil {
+		t.Fatalf("empty strategy: %v", err)
+	}
+	if strategy.Type != EmbeddingStrategyFullConversation {
+		t.Fatalf("expected the default type to be %s, got %s", EmbeddingStrategyFullConversation, strategy.Type)
+	}
+}
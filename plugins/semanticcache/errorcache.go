@@ -0,0 +1,180 @@
+package semanticcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// DefaultErrorCacheTTL is how long a cached upstream error is served when
+// ErrorCachingConfig.TTL is unset. Errors are kept far shorter than responses
+// so a transient refusal doesn't stick.
+const DefaultErrorCacheTTL = time.Minute
+
+// ErrorCachingConfig enables negative caching: upstream errors on the allow
+// list are cached so identical failing requests are answered from cache
+// instead of hitting the provider again.
+//
+// Cached errors are only served to exact replays (direct cache hits), never
+// to semantically similar requests.
+type ErrorCachingConfig struct {
+	// StatusCodes is the allow list of upstream HTTP status codes to cache,
+	// e.g. 400 for content-policy refusals. Required.
+	StatusCodes []int `json:"status_codes"`
+	// ErrorCodes optionally narrows the allow list to errors whose code or
+	// type is listed, e.g. "content_policy_violation".
+	ErrorCodes []string `json:"error_codes,omitempty"`
+	// TTL is how long a cached error is served (default: 1m). Accepts a
+	// duration string ("30s") or a number of seconds.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// UnmarshalJSON lets TTL be a duration string or a number of seconds, like Config.TTL.
+func (c *ErrorCachingConfig) UnmarshalJSON(data []byte) error {
+	type alias ErrorCachingConfig
+	aux := &struct {
+		TTL json.RawMessage `json:"ttl,omitempty"`
+		*alias
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return fmt.Errorf("failed to unmarshal error_caching: %w", err)
+	}
+	ttl, err := parseTTL(aux.TTL)
+	if err != nil {
+		return fmt.Errorf("error_caching: %w", err)
+	}
+	if ttl != 0 {
+		c.TTL = ttl
+	}
+	return nil
+}
+
+// cachedErrorEntry is the envelope a cached error is stored in, in the
+// response property. BifrostResponse has no cached_error field, so the two
+// never collide.
+type cachedErrorEntry struct {
+	CachedError *schemas.BifrostError `json:"cached_error"`
+}
+
+// shouldCacheError reports whether bifrostErr is on the allow list.
+func (c *ErrorCachingConfig) shouldCacheError(bifrostErr *schemas.BifrostError) bool {
+	if c == nil || bifrostErr == nil || bifrostErr.StatusCode == nil {
+		return false
+	}
+	if !slices.Contains(c.StatusCodes, *bifrostErr.StatusCode) {
+		return false
+	}
+	if len(c.ErrorCodes) == 0 {
+		return true
+	}
+	if bifrostErr.Error == nil {
+		return false
+	}
+	if bifrostErr.Error.Code != nil && slices.Contains(c.ErrorCodes, *bifrostErr.Error.Code) {
+		return true
+	}
+	return bifrostErr.Error.Type != nil && slices.Contains(c.ErrorCodes, *bifrostErr.Error.Type)
+}
+
+// cacheableError copies what a replay needs from bifrostErr. Raw payloads,
+// key statuses and billed usage belong to the original call and are dropped.
+func cacheableError(bifrostErr *schemas.BifrostError) *schemas.BifrostError {
+	cached := &schemas.BifrostError{
+		Type:           bifrostErr.Type,
+		IsBifrostError: bifrostErr.IsBifrostError,
+		StatusCode:     bifrostErr.StatusCode,
+	}
+	if bifrostErr.Error != nil {
+		cached.Error = &schemas.ErrorField{
+			Type:    bifrostErr.Error.Type,
+			Code:    bifrostErr.Error.Code,
+			Message: bifrostErr.Error.Message,
+			Param:   bifrostErr.Error.Param,
+		}
+		if cached.Error.Message == "" && bifrostErr.Error.Error != nil {
+			cached.Error.Message = bifrostErr.Error.Error.Error()
+		}
+	}
+	return cached
+}
+
+// decodeCachedError returns the error stored in a cache entry, or nil when the
+// entry holds a response.
+func decodeCachedError(properties map[string]interface{}) *schemas.BifrostError {
+	raw, ok := properties["response"].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	var entry cachedErrorEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return nil
+	}
+	return entry.CachedError
+}
+
+// cacheError writes an allow-listed upstream error under the request's direct
+// cache ID. It runs from PostLLMHook and never alters the error returned.
+func (plugin *Plugin) cacheError(ctx *schemas.BifrostContext, bifrostErr *schemas.BifrostError) {
+	if !plugin.config.ErrorCaching.shouldCacheError(bifrostErr) {
+		return
+	}
+	requestID, ok := ctx.Value(schemas.BifrostContextKeyRequestID).(string)
+	if !ok {
+		return
+	}
+	cacheKey, ok := plugin.resolveCacheKey(ctx)
+	if !ok {
+		return
+	}
+	state := plugin.getCacheState(requestID)
+	if state == nil {
+		return
+	}
+	defer plugin.clearCacheState(requestID)
+	// Only exact replays may be answered with an error, so without a direct
+	// cache ID there is nothing to write. ShortCircuited means this error was
+	// itself served from cache.
+	if state.ParamsHash == "" || state.DirectCacheID == "" || state.ShortCircuited {
+		return
+	}
+	plugin.cleanupStreamAccumulator(requestID)
+	if plugin.shouldSkipCacheWrite(ctx) {
+		return
+	}
+
+	// Without an embedding the entry is invisible to semantic search; stores
+	// that require vectors get the lookup's embedding or placeholder instead.
+	var embedding []float32
+	if plugin.store.RequiresVectors() {
+		if len(state.Embeddings) == 0 {
+			return
+		}
+		embedding = state.Embeddings
+	}
+
+	payload, err := json.Marshal(cachedErrorEntry{CachedError: cacheableError(bifrostErr)})
+	if err != nil {
+		plugin.logger.Warn("Failed to marshal upstream error for caching: %v", err)
+		return
+	}
+	ttl := plugin.config.ErrorCaching.TTL
+	metadata := plugin.buildUnifiedMetadata(bifrostErr.ExtraFields.Provider, bifrostErr.ExtraFields.OriginalModelRequested, state.ParamsHash, cacheKey, ttl)
+	metadata["response"] = string(payload)
+	metadata["stream_chunks"] = []string{}
+	storageID := state.DirectCacheID
+
+	plugin.writersWg.Add(1)
+	go func() {
+		defer plugin.writersWg.Done()
+		cacheCtx, cancel := context.WithTimeout(context.Background(), CacheSetTimeout)
+		defer cancel()
+		if err := plugin.store.Add(cacheCtx, plugin.config.VectorStoreNamespace, storageID, embedding, metadata); err != nil {
+			plugin.stats.recordStoreFailure(cacheKey)
+			plugin.logger.Warn("Failed to cache upstream error (namespace=%s, id=%s): %v", plugin.config.VectorStoreNamespace, storageID, err)
+		}
+	}()
+}
@@ -0,0 +1,145 @@
+package semanticcache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+func newErrorCachingPlugin(errorCaching *ErrorCachingConfig) (*Plugin, *directFastPathStore) {
+	store := newDirectFastPathStore()
+	config := getDefaultTestConfig()
+	config.CacheByProvider = bifrost.Ptr(true)
+	config.CacheByModel = bifrost.Ptr(true)
+	config.TTL = DefaultCacheTTL
+	config.ErrorCaching = errorCaching
+	return &Plugin{
+		store:  store,
+		config: config,
+		logger: bifrost.NewDefaultLogger(schemas.LogLevelDebug),
+	}, store
+}
+
+func refusal(status int, code string) *schemas.BifrostError {
+	return &schemas.BifrostError{
+		StatusCode: bifrost.Ptr(status),
+		Error: &schemas.ErrorField{
+			Code:    bifrost.Ptr(code),
+			Message: "The prompt was flagged by the content filter.",
+		},
+		ExtraFields: schemas.BifrostErrorExtraFields{
+			Provider:               schemas.OpenAI,
+			OriginalModelRequested: "gpt-4o",
+			RequestType:            schemas.ChatCompletionRequest,
+			BilledUsage:            &schemas.BifrostLLMUsage{PromptTokens: 12},
+		},
+	}
+}
+
+// seedError runs a request that misses the cache and fails upstream with bifrostErr.
+func seedError(t *testing.T, plugin *Plugin, cacheKey, prompt string, bifrostErr *schemas.BifrostError) {
+	t.Helper()
+	ctx := CreateContextWithCacheKeyAndType(t, cacheKey, CacheTypeDirect)
+	req := newCrossProviderChatRequest(schemas.OpenAI, "gpt-4o", schemas.ChatCompletionRequest, prompt)
+	_, shortCircuit, err := plugin.PreLLMHook(ctx, req)
+	if err != nil || shortCircuit != nil {
+		t.Fatalf("expected the seed request to miss, got shortCircuit=%v err=%v", shortCircuit, err)
+	}
+	_, returned, err := plugin.PostLLMHook(ctx, nil, bifrostErr)
+	if err != nil || returned != bifrostErr {
+		t.Fatalf("expected PostLLMHook to return the upstream error unchanged, got %v %v", returned, err)
+	}
+	plugin.WaitForPendingOperations()
+}
+
+func replay(t *testing.T, plugin *Plugin, cacheKey, prompt string) *schemas.LLMPluginShortCircuit {
+	t.Helper()
+	ctx := CreateContextWithCacheKeyAndType(t, cacheKey, CacheTypeDirect)
+	req := newCrossProviderChatRequest(schemas.OpenAI, "gpt-4o", schemas.ChatCompletionRequest, prompt)
+	_, shortCircuit, err := plugin.PreLLMHook(ctx, req)
+	if err != nil {
+		t.Fatalf("replay PreLLMHook failed: %v", err)
+	}
+	return shortCircuit
+}
+
+func TestErrorCaching_ReplaysAllowListedError(t *testing.T) {
+	plugin, _ := newErrorCachingPlugin(&ErrorCachingConfig{StatusCodes: []int{400}, ErrorCodes: []string{"content_policy_violation"}, TTL: time.Minute})
+	const prompt = "a prompt the provider refuses"
+
+	seedError(t, plugin, "negative-cache", prompt, refusal(400, "content_policy_violation"))
+
+	shortCircuit := replay(t, plugin, "negative-cache", prompt)
+	if shortCircuit == nil || shortCircuit.Error == nil {
+		t.Fatal("expected the refusal to be served from cache")
+	}
+	cached := shortCircuit.Error
+	if cached.StatusCode == nil || *cached.StatusCode != 400 || cached.Error == nil || *cached.Error.Code != "content_policy_violation" {
+		t.Fatalf("unexpected cached error: %+v", cached)
+	}
+	if cached.ExtraFields.BilledUsage != nil {
+		t.Fatal("expected billed usage of the original call not to be replayed")
+	}
+
+	// The replayed error reaches PostLLMHook too and must not be written again.
+	if shortCircuit := replay(t, plugin, "negative-cache", "a different prompt"); shortCircuit != nil {
+		t.Fatal("expected a different prompt to miss")
+	}
+}
+
+func TestErrorCaching_SkipsErrorsOffTheAllowList(t *testing.T) {
+	for name, bifrostErr := range map[string]*schemas.BifrostError{
+		"status":     refusal(429, "content_policy_violation"),
+		"error code": refusal(400, "invalid_request_error"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			plugin, store := newErrorCachingPlugin(&ErrorCachingConfig{StatusCodes: []int{400}, ErrorCodes: []string{"content_policy_violation"}, TTL: time.Minute})
+			seedError(t, plugin, "negative-cache-"+name, "prompt", bifrostErr)
+			if len(store.chunks) != 0 {
+				t.Fatalf("expected no cache write, got %d entries", len(store.chunks))
+			}
+		})
+	}
+}
+
+func TestErrorCaching_DisabledByDefault(t *testing.T) {
+	plugin, store := newErrorCachingPlugin(nil)
+	seedError(t, plugin, "negative-cache-off", "prompt", refusal(400, "content_policy_violation"))
+	if len(store.chunks) != 0 {
+		t.Fatalf("expected no cache write without error_caching, got %d entries", len(store.chunks))
+	}
+}
+
+func TestErrorCaching_ExpiresAfterTTL(t *testing.T) {
+	plugin, store := newErrorCachingPlugin(&ErrorCachingConfig{StatusCodes: []int{400}, TTL: time.Minute})
+	seedError(t, plugin, "negative-cache-ttl", "prompt", refusal(400, "content_policy_violation"))
+	if len(store.chunks) != 1 {
+		t.Fatalf("expected one cache entry, got %d", len(store.chunks))
+	}
+	for id, chunk := range store.chunks {
+		chunk.Properties["expires_at"] = time.Now().Add(-time.Second).Unix()
+		store.chunks[id] = chunk
+	}
+	if shortCircuit := replay(t, plugin, "negative-cache-ttl", "prompt"); shortCircuit != nil {
+		t.Fatal("expected an expired cached error to miss")
+	}
+}
+
+func TestErrorCachingConfig_Unmarshal(t *testing.T) {
+	var config Config
+	if err := json.Unmarshal([]byte(`{"dimension": 1, "error_caching": {"status_codes": [400, 451], "ttl": "30s"}}`), &config); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if config.ErrorCaching == nil || config.ErrorCaching.TTL != 30*time.Second || len(config.ErrorCaching.StatusCodes) != 2 {
+		t.Fatalf("unexpected error_caching: %+v", config.ErrorCaching)
+	}
+	if err := json.Unmarshal([]byte(`{"error_caching": {"status_codes": [400], "ttl": 15}}`), &config); err != nil || config.ErrorCaching.TTL != 15*time.Second {
+		t.Fatalf("expected a numeric TTL in seconds, got %v (err %v)", config.ErrorCaching.TTL, err)
+	}
+	if err := json.Unmarshal([]byte(`{"error_caching": {"status_codes": [400], "ttl": "soon"}}`), &config); err == nil {
+		t.Fatal("expected an invalid TTL to be rejected")
+	}
+}
@@ -42,6 +42,12 @@ type Config struct {
 	CacheByModel                 *bool  `json:"cache_by_model,omitempty"`                 // Include model in cache key (default: true)
 	CacheByProvider              *bool  `json:"cache_by_provider,omitempty"`              // Include provider in cache key (default: true)
 	ExcludeSystemPrompt          *bool  `json:"exclude_system_prompt,omitempty"`          // Exclude system prompt in cache key (default: false)
+
+	// Which part of a conversation is embedded for semantic lookups (optional, full conversation when nil)
+	EmbeddingStrategy *EmbeddingStrategyConfig `json:"embedding_strategy,omitempty"`
+
+	// Negative caching of upstream errors (optional, disabled when nil)
+	ErrorCaching *ErrorCachingConfig `json:"error_caching,omitempty"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for Config so TTL accepts
@@ -61,29 +67,43 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	if len(aux.TTL) == 0 || string(aux.TTL) == "null" {
-		return nil
+	ttl, err := parseTTL(aux.TTL)
+	if err != nil {
+		return err
+	}
+	if ttl != 0 {
+		c.TTL = ttl
+	}
+	return nil
+}
+
+// parseTTL decodes a TTL given as a duration string ("1m", "1h") or a JSON
+// number of seconds. An absent or null value decodes to 0.
+func parseTTL(raw json.RawMessage) (time.Duration, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
 	}
 
 	// Try string first ("1m"); fall back to a JSON number (seconds).
+	var ttl time.Duration
 	var s string
-	if err := json.Unmarshal(aux.TTL, &s); err == nil {
+	if err := json.Unmarshal(raw, &s); err == nil {
 		d, err := time.ParseDuration(s)
 		if err != nil {
-			return fmt.Errorf("failed to parse TTL duration string '%s': %w", s, err)
+			return 0, fmt.Errorf("failed to parse TTL duration string '%s': %w", s, err)
 		}
-		c.TTL = d
+		ttl = d
 	} else {
 		var seconds float64
-		if err := json.Unmarshal(aux.TTL, &seconds); err != nil {
-			return fmt.Errorf("unsupported TTL value: %s", string(aux.TTL))
+		if err := json.Unmarshal(raw, &seconds); err != nil {
+			return 0, fmt.Errorf("unsupported TTL value: %s", string(raw))
 		}
-		c.TTL = time.Duration(seconds * float64(time.Second))
+		ttl = time.Duration(seconds * float64(time.Second))
 	}
-	if c.TTL < 0 {
-		return fmt.Errorf("TTL must be non-negative, got %v", c.TTL)
+	if ttl < 0 {
+		return 0, fmt.Errorf("TTL must be non-negative, got %v", ttl)
 	}
-	return nil
+	return ttl, nil
 }
 
 // StreamChunk is one chunk from a streaming response, retained until the
@@ -161,6 +181,8 @@ type Plugin struct {
 	// invokes Cleanup more than once (e.g. plugin registered against multiple
 	// interface caches).
 	cleanupOnce sync.Once
+	// stats counts lookup outcomes and store failures, reported by GetStats.
+	stats cacheStats
 }
 
 // Plugin constants
@@ -272,6 +294,22 @@ func Init(ctx context.Context, config *Config, logger schemas.Logger, store vect
 		config.ConversationHistoryThreshold = DefaultConversationHistoryThreshold
 	}
 
+	if config.EmbeddingStrategy != nil {
+		if err := config.EmbeddingStrategy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ErrorCaching != nil {
+		if len(config.ErrorCaching.StatusCodes) == 0 {
+			return nil, fmt.Errorf("error_caching.status_codes must list at least one status code")
+		}
+		if config.ErrorCaching.TTL == 0 {
+			logger.Debug("Error caching TTL is not set, using default of %v", DefaultErrorCacheTTL)
+			config.ErrorCaching.TTL = DefaultErrorCacheTTL
+		}
+	}
+
 	// Set cache behavior defaults
 	if config.CacheByModel == nil {
 		logger.Debug("CacheByModel is not set, defaulting to true")
@@ -410,6 +448,7 @@ func (plugin *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifro
 		return req, nil, nil
 	}
 	state.ParamsHash = paramsHash
+	plugin.stats.recordLookup(cacheKey)
 
 	if performDirectSearch {
 		shortCircuit, err := plugin.performDirectSearch(ctx, state, req, cacheKey, metadata, paramsHash)
@@ -418,6 +457,7 @@ func (plugin *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifro
 			plugin.logger.Warn(msg)
 			ctx.Log(schemas.LogLevelWarn, msg)
 		} else if shortCircuit != nil {
+			plugin.stats.recordHit(cacheKey, CacheTypeDirect)
 			return req, shortCircuit, nil
 		}
 	}
@@ -438,6 +478,7 @@ func (plugin *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifro
 				plugin.logger.Warn(msg)
 				ctx.Log(schemas.LogLevelWarn, msg)
 			} else if shortCircuit != nil {
+				plugin.stats.recordHit(cacheKey, CacheTypeSemantic)
 				return req, shortCircuit, nil
 			}
 		}
@@ -450,6 +491,7 @@ func (plugin *Plugin) PreLLMHook(ctx *schemas.BifrostContext, req *schemas.Bifro
 		plugin.setPlaceholderVectorIfRequired(state)
 	}
 
+	plugin.stats.recordMiss(cacheKey)
 	return req, nil, nil
 }
 
@@ -509,8 +551,10 @@ func (plugin *Plugin) setPlaceholderVectorIfRequired(state *cacheState) {
 func (plugin *Plugin) PostLLMHook(ctx *schemas.BifrostContext, res *schemas.BifrostResponse, bifrostErr *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError, error) {
 	if bifrostErr != nil {
 		// We rely on errors always arriving as the final chunk for streams, so
-		// we abort caching here without further bookkeeping. Any partial
-		// accumulator from a prior chunk gets reaped by the periodic cleanup.
+		// we abort caching here without further bookkeeping unless the error
+		// is on the error_caching allow list. Any partial accumulator from a
+		// prior chunk gets reaped by the periodic cleanup.
+		plugin.cacheError(ctx, bifrostErr)
 		return res, bifrostErr, nil
 	}
 
@@ -625,10 +669,12 @@ func (plugin *Plugin) PostLLMHook(ctx *schemas.BifrostContext, res *schemas.Bifr
 		unifiedMetadata := plugin.buildUnifiedMetadata(provider, model, paramsHash, cacheKey, cacheTTL)
 		if isStream {
 			if err := plugin.addStreamingResponse(cacheCtx, requestID, storageID, res, embeddingToStore, unifiedMetadata, cacheTTL, isFinalChunk); err != nil {
+				plugin.stats.recordStoreFailure(cacheKey)
 				plugin.logger.Warn("Failed to cache streaming response (namespace=%s, id=%s): %v. The cache_id stamped on the response will not resolve on subsequent lookups.", plugin.config.VectorStoreNamespace, storageID, err)
 			}
 		} else {
 			if err := plugin.addNonStreamingResponse(cacheCtx, storageID, res, embeddingToStore, unifiedMetadata, cacheTTL); err != nil {
+				plugin.stats.recordStoreFailure(cacheKey)
 				plugin.logger.Warn("Failed to cache single response (namespace=%s, id=%s): %v. The cache_id stamped on the response will not resolve on subsequent lookups.", plugin.config.VectorStoreNamespace, storageID, err)
 			}
 		}
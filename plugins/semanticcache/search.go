@@ -44,6 +44,7 @@ func (plugin *Plugin) performDirectSearch(ctx *schemas.BifrostContext, state *ca
 		if isMiss {
 			return nil, nil
 		}
+		plugin.stats.recordStoreFailure(cacheKey)
 		return nil, fmt.Errorf("failed to fetch direct cache chunk: %w", err)
 	}
 	return plugin.buildResponseFromResult(ctx, state, req, result, CacheTypeDirect, nil, nil)
@@ -92,6 +93,7 @@ func (plugin *Plugin) performSemanticSearch(ctx *schemas.BifrostContext, state *
 	selectFields := selectFieldsForRequest(req.RequestType)
 	results, err := plugin.store.GetNearest(ctx, plugin.config.VectorStoreNamespace, embedding, strictFilters, selectFields, cacheThreshold, 1)
 	if err != nil {
+		plugin.stats.recordStoreFailure(cacheKey)
 		return nil, fmt.Errorf("failed to search semantic cache: %w", err)
 	}
 	if len(results) == 0 {
@@ -205,6 +207,23 @@ func (plugin *Plugin) generateRequestHash(req *schemas.BifrostRequest, params ma
 	return fmt.Sprintf("%x", xxhash.Sum64(jsonData)), nil
 }
 
+// RequestHash returns the hash the cache matches identical requests on: the
+// normalized input together with every parameter that changes the response.
+// Provider and model are not part of it. It lets other plugins recognise
+// duplicate requests exactly as the direct cache path does, and fails for
+// request types the cache does not support.
+func RequestHash(req *schemas.BifrostRequest, excludeSystemPrompt bool, logger schemas.Logger) (string, error) {
+	plugin := &Plugin{config: &Config{ExcludeSystemPrompt: &excludeSystemPrompt}, logger: logger}
+	if !isSemanticCacheSupportedRequestType(req.RequestType) {
+		return "", fmt.Errorf("unsupported request type %q", req.RequestType)
+	}
+	metadata, err := plugin.buildRequestMetadataForCaching(nil, req)
+	if err != nil {
+		return "", err
+	}
+	return plugin.generateRequestHash(req, metadata)
+}
+
 // generateDirectCacheID returns a deterministic UUIDv5 derived from the cache
 // key, request hash, params hash, and (optionally) provider/model. The same
 // inputs always produce the same ID, which is what makes the direct path an
@@ -266,6 +285,15 @@ func (plugin *Plugin) buildResponseFromResult(ctx *schemas.BifrostContext, state
 		return nil, nil
 	}
 
+	if cachedErr := decodeCachedError(properties); cachedErr != nil {
+		// Cached errors answer exact replays only; a similar prompt may well succeed.
+		if cacheType != CacheTypeDirect {
+			return nil, nil
+		}
+		state.ShortCircuited = true
+		return &schemas.LLMPluginShortCircuit{Error: cachedErr}, nil
+	}
+
 	similarity := 0.0
 	if result.Score != nil {
 		similarity = *result.Score
@@ -0,0 +1,130 @@
+package semanticcache
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maxTrackedCacheKeys caps the cache keys counted individually. Cache keys come
+// from callers, so lookups under further keys are folded into
+// OtherCacheKeysLabel to keep the stats (and metric series) bounded.
+const maxTrackedCacheKeys = 1000
+
+// OtherCacheKeysLabel is the ByCacheKey entry for lookups under cache keys past
+// maxTrackedCacheKeys.
+const OtherCacheKeysLabel = "_other"
+
+// LookupStats counts cache lookups by outcome. A lookup is one PreLLMHook that
+// searched the cache; it ends in a direct hit, a semantic hit or a miss.
+// StoreFailures counts vector store reads and writes that failed, so a
+// degraded store shows up as failures rather than as a falling hit rate alone.
+type LookupStats struct {
+	Lookups       int64   `json:"lookups"`
+	DirectHits    int64   `json:"direct_hits"`
+	SemanticHits  int64   `json:"semantic_hits"`
+	Misses        int64   `json:"misses"`
+	StoreFailures int64   `json:"store_failures"`
+	HitRate       float64 `json:"hit_rate"`
+}
+
+// Stats reports lookup outcomes since the plugin started, in total and per
+// cache key.
+type Stats struct {
+	LookupStats
+	ByCacheKey map[string]LookupStats `json:"by_cache_key"`
+}
+
+// lookupCounters is the live form of LookupStats.
+type lookupCounters struct {
+	lookups       atomic.Int64
+	directHits    atomic.Int64
+	semanticHits  atomic.Int64
+	misses        atomic.Int64
+	storeFailures atomic.Int64
+}
+
+func (c *lookupCounters) snapshot() LookupStats {
+	stats := LookupStats{
+		Lookups:       c.lookups.Load(),
+		DirectHits:    c.directHits.Load(),
+		SemanticHits:  c.semanticHits.Load(),
+		Misses:        c.misses.Load(),
+		StoreFailures: c.storeFailures.Load(),
+	}
+	if stats.Lookups > 0 {
+		stats.HitRate = float64(stats.DirectHits+stats.SemanticHits) / float64(stats.Lookups)
+	}
+	return stats
+}
+
+// cacheStats holds the lookup counters, in total and per cache key.
+type cacheStats struct {
+	total  lookupCounters
+	byKey  sync.Map // cache key -> *lookupCounters
+	keys   atomic.Int64
+	other  lookupCounters
+	create sync.Mutex
+}
+
+// forKey returns the counters of a cache key, creating them while fewer than
+// maxTrackedCacheKeys keys are tracked.
+func (s *cacheStats) forKey(cacheKey string) *lookupCounters {
+	if counters, ok := s.byKey.Load(cacheKey); ok {
+		return counters.(*lookupCounters)
+	}
+	s.create.Lock()
+	defer s.create.Unlock()
+	if counters, ok := s.byKey.Load(cacheKey); ok {
+		return counters.(*lookupCounters)
+	}
+	if s.keys.Load() >= maxTrackedCacheKeys {
+		return &s.other
+	}
+	counters := &lookupCounters{}
+	s.byKey.Store(cacheKey, counters)
+	s.keys.Add(1)
+	return counters
+}
+
+func (s *cacheStats) recordLookup(cacheKey string) {
+	s.total.lookups.Add(1)
+	s.forKey(cacheKey).lookups.Add(1)
+}
+
+func (s *cacheStats) recordHit(cacheKey string, cacheType CacheType) {
+	if cacheType == CacheTypeSemantic {
+		s.total.semanticHits.Add(1)
+		s.forKey(cacheKey).semanticHits.Add(1)
+		return
+	}
+	s.total.directHits.Add(1)
+	s.forKey(cacheKey).directHits.Add(1)
+}
+
+func (s *cacheStats) recordMiss(cacheKey string) {
+	s.total.misses.Add(1)
+	s.forKey(cacheKey).misses.Add(1)
+}
+
+func (s *cacheStats) recordStoreFailure(cacheKey string) {
+	s.total.storeFailures.Add(1)
+	s.forKey(cacheKey).storeFailures.Add(1)
+}
+
+// GetStats returns the lookup counters since the plugin started. Unlike
+// GetCacheStats it doesn't touch the vector store, so it is cheap enough to
+// call on every metrics scrape.
+func (plugin *Plugin) GetStats() Stats {
+	stats := Stats{
+		LookupStats: plugin.stats.total.snapshot(),
+		ByCacheKey:  make(map[string]LookupStats),
+	}
+	plugin.stats.byKey.Range(func(key, value any) bool {
+		stats.ByCacheKey[key.(string)] = value.(*lookupCounters).snapshot()
+		return true
+	})
+	if other := plugin.stats.other.snapshot(); other.Lookups > 0 || other.StoreFailures > 0 {
+		stats.ByCacheKey[OtherCacheKeysLabel] = other
+	}
+	return stats
+}
@@ -0,0 +1,68 @@
+package semanticcache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/vectorstore"
+)
+
+// unreachableStore fails every read, as a vector store that is down would.
+type unreachableStore struct {
+	*directFastPathStore
+}
+
+func (s *unreachableStore) GetChunk(ctx context.Context, namespace string, id string) (vectorstore.SearchResult, error) {
+	return vectorstore.SearchResult{}, errors.New("dial tcp: connection refused")
+}
+
+func TestGetStats_CountsLookupOutcomes(t *testing.T) {
+	plugin, store := newErrorCachingPlugin(&ErrorCachingConfig{StatusCodes: []int{400}, TTL: time.Minute})
+	const prompt = "a prompt the provider refuses"
+
+	seedError(t, plugin, "tenant-a", prompt, refusal(400, "content_filter"))
+	if replay(t, plugin, "tenant-a", prompt) == nil {
+		t.Fatal("expected the replay to hit the cache")
+	}
+	plugin.store = &unreachableStore{store}
+	if replay(t, plugin, "tenant-b", prompt) != nil {
+		t.Fatal("expected no cache hit while the store is unreachable")
+	}
+
+	stats := plugin.GetStats()
+	want := LookupStats{Lookups: 3, DirectHits: 1, Misses: 2, StoreFailures: 1, HitRate: 1.0 / 3}
+	if stats.LookupStats != want {
+		t.Fatalf("got totals %+v, want %+v", stats.LookupStats, want)
+	}
+	if got := stats.ByCacheKey[keyForTest(t, "tenant-a")]; got.Lookups != 2 || got.DirectHits != 1 || got.Misses != 1 || got.HitRate != 0.5 {
+		t.Fatalf("unexpected tenant-a stats: %+v", got)
+	}
+	if got := stats.ByCacheKey[keyForTest(t, "tenant-b")]; got.Lookups != 1 || got.Misses != 1 || got.StoreFailures != 1 {
+		t.Fatalf("unexpected tenant-b stats: %+v", got)
+	}
+}
+
+func TestGetStats_CapsTrackedCacheKeys(t *testing.T) {
+	plugin := &Plugin{}
+	for i := range maxTrackedCacheKeys + 5 {
+		plugin.stats.recordLookup("key-" + strconv.Itoa(i))
+	}
+	plugin.stats.recordHit("key-0", CacheTypeSemantic)
+
+	stats := plugin.GetStats()
+	if stats.Lookups != maxTrackedCacheKeys+5 || stats.SemanticHits != 1 {
+		t.Fatalf("unexpected totals: %+v", stats.LookupStats)
+	}
+	if len(stats.ByCacheKey) != maxTrackedCacheKeys+1 {
+		t.Fatalf("expected %d tracked keys plus %s, got %d entries", maxTrackedCacheKeys, OtherCacheKeysLabel, len(stats.ByCacheKey))
+	}
+	if got := stats.ByCacheKey[OtherCacheKeysLabel]; got.Lookups != 5 {
+		t.Fatalf("expected the overflow keys folded into %s, got %+v", OtherCacheKeysLabel, got)
+	}
+	if got := stats.ByCacheKey["key-0"]; got.SemanticHits != 1 {
+		t.Fatalf("expected key-0 to keep its own counters, got %+v", got)
+	}
+}
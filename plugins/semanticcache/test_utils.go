@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	bifrost "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/postgresconn"
 	"github.com/maximhq/bifrost/framework/vectorstore"
 	mocker "github.com/maximhq/bifrost/plugins/mocker"
 )
@@ -195,6 +196,25 @@ func getPineconeConfigFromEnv() vectorstore.PineconeConfig {
 	}
 }
 
+// getPgVectorConfigFromEnv retrieves pgvector configuration from environment
+// variables, defaulting to the framework docker-compose Postgres.
+func getPgVectorConfigFromEnv() vectorstore.PgVectorConfig {
+	fromEnv := func(name, fallback string) *schemas.SecretVar {
+		if value := schemas.NewSecretVar("env." + name); value.GetValue() != "" {
+			return value
+		}
+		return schemas.NewSecretVar(fallback)
+	}
+	return vectorstore.PgVectorConfig{Config: postgresconn.Config{
+		Host:     fromEnv("PGVECTOR_HOST", "localhost"),
+		Port:     fromEnv("PGVECTOR_PORT", "5432"),
+		User:     fromEnv("PGVECTOR_USER", "bifrost"),
+		Password: fromEnv("PGVECTOR_PASSWORD", "bifrost_password"),
+		DBName:   fromEnv("PGVECTOR_DB", "bifrost"),
+		SSLMode:  fromEnv("PGVECTOR_SSL_MODE", "disable"),
+	}}
+}
+
 // storeConfigForType returns the env-derived connection config for a vector
 // store type. The bool is false for an unrecognized type. Shared by test setup
 // and the TestMain namespace sweep so both stay in lockstep when a new backend
@@ -209,6 +229,8 @@ func storeConfigForType(storeType vectorstore.VectorStoreType) (interface{}, boo
 		return getQdrantConfigFromEnv(), true
 	case vectorstore.VectorStoreTypePinecone:
 		return getPineconeConfigFromEnv(), true
+	case vectorstore.VectorStoreTypePgVector:
+		return getPgVectorConfigFromEnv(), true
 	default:
 		return nil, false
 	}
@@ -357,6 +357,9 @@ func extractResponsesMessageContent(msg schemas.ResponsesMessage) string {
 // Text serialization format (for cache consistency):
 //   - Chat API: "role: content"
 //   - Responses API: "role: msgType: content" (when msgType is present), "role: content" (when msgType is empty)
+//
+// For chat and responses requests, Config.EmbeddingStrategy picks which of
+// the serialized messages are embedded.
 func (plugin *Plugin) extractTextForEmbedding(state *cacheState, req *schemas.BifrostRequest) (string, error) {
 	switch {
 	case req.TextCompletionRequest != nil:
@@ -373,49 +376,43 @@ func (plugin *Plugin) extractTextForEmbedding(state *cacheState, req *schemas.Bi
 		if !ok {
 			return "", fmt.Errorf("failed to cast request input to chat messages")
 		}
-		var textParts []string
+		var msgs []embeddingMessage
 		for _, msg := range reqInput {
 			content := extractChatMessageContent(msg)
 			if content == "" {
 				continue
 			}
-			textParts = append(textParts, fmt.Sprintf("%s: %s", msg.Role, normalizeText(content)))
+			msgs = append(msgs, embeddingMessage{Role: string(msg.Role), Content: normalizeText(content)})
 		}
-		if len(textParts) == 0 {
+		if len(msgs) == 0 {
 			return "", fmt.Errorf("no text content found in chat messages")
 		}
-		return strings.Join(textParts, "\n"), nil
+		return plugin.applyEmbeddingStrategy(msgs)
 
 	case req.ResponsesRequest != nil:
 		reqInput, ok := plugin.getInputForCaching(state, req).([]schemas.ResponsesMessage)
 		if !ok {
 			return "", fmt.Errorf("failed to cast request input to responses messages")
 		}
-		var textParts []string
+		var msgs []embeddingMessage
 		for _, msg := range reqInput {
 			content := extractResponsesMessageContent(msg)
 			if content == "" {
 				continue
 			}
-			content = normalizeText(content)
-			role := ""
-			msgType := ""
+			embeddingMsg := embeddingMessage{Content: normalizeText(content)}
 			if msg.Role != nil {
-				role = string(*msg.Role)
+				embeddingMsg.Role = string(*msg.Role)
 			}
 			if msg.Type != nil {
-				msgType = string(*msg.Type)
-			}
-			if msgType != "" {
-				textParts = append(textParts, fmt.Sprintf("%s: %s: %s", role, msgType, content))
-			} else {
-				textParts = append(textParts, fmt.Sprintf("%s: %s", role, content))
+				embeddingMsg.Type = string(*msg.Type)
 			}
+			msgs = append(msgs, embeddingMsg)
 		}
-		if len(textParts) == 0 {
+		if len(msgs) == 0 {
 			return "", fmt.Errorf("no text content found in responses messages")
 		}
-		return strings.Join(textParts, "\n"), nil
+		return plugin.applyEmbeddingStrategy(msgs)
 
 	case req.SpeechRequest != nil:
 		if req.SpeechRequest.Input.Input == "" {
@@ -0,0 +1,283 @@
+package telemetry
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DerivedMetricType is the Prometheus type of a derived metric.
+type DerivedMetricType string
+
+const (
+	DerivedMetricCounter   DerivedMetricType = "counter"
+	DerivedMetricHistogram DerivedMetricType = "histogram"
+)
+
+// DerivedMetricConfig defines a metric computed from the fields of every
+// completed request, so one-off metrics don't need a plugin change.
+//
+// For example, responses cut off by the token limit per model:
+//
+//	{"name": "bifrost_truncated_responses_total", "type": "counter",
+//	 "labels": ["provider", "model"], "match": {"finish_reason": "length"}}
+//
+// and tool calls per request:
+//
+//	{"name": "bifrost_tool_calls_per_request", "type": "histogram",
+//	 "value": "tool_calls", "labels": ["model"], "buckets": [0, 1, 2, 4, 8]}
+type DerivedMetricConfig struct {
+	Name string            `json:"name"`
+	Help string            `json:"help,omitempty"`
+	Type DerivedMetricType `json:"type"`
+	// Value is the numeric field a histogram observes or a counter adds. A
+	// counter without one counts matching requests.
+	Value string `json:"value,omitempty"`
+	// Labels are the string fields the metric is labelled by.
+	Labels []string `json:"labels,omitempty"`
+	// Match restricts the metric to requests whose string fields equal the
+	// given values.
+	Match map[string]string `json:"match,omitempty"`
+	// Buckets are the histogram buckets (default: prometheus.DefBuckets).
+	Buckets []float64 `json:"buckets,omitempty"`
+}
+
+// derivedStringFields are the string fields a derived metric can be labelled
+// by or matched on, besides the default bifrost labels and custom labels.
+var derivedStringFields = []string{
+	"status",        // success or error
+	"status_code",   // upstream status code of an error, empty on success
+	"finish_reason", // first choice's finish reason; incomplete reason or status for responses
+	"cache_hit",     // true when served from the semantic cache
+	"cache_type",    // direct or semantic on a cache hit
+}
+
+// derivedNumericFields are the fields a derived metric can observe.
+var derivedNumericFields = []string{
+	"input_tokens",
+	"output_tokens",
+	"total_tokens",
+	"tool_calls",
+	"latency_seconds",
+	"cost",
+	"retries",
+}
+
+// derivedStreamStateKey holds the *derivedStreamState of a streaming request.
+const derivedStreamStateKey schemas.BifrostContextKey = "bf-prom-derived-stream-state"
+
+// derivedStreamState carries what a derived metric needs across the chunks of
+// a chat stream: tool calls and the finish reason arrive before the final
+// chunk.
+type derivedStreamState struct {
+	toolCalls    int
+	finishReason string
+}
+
+// derivedSample is the fields of one completed request.
+type derivedSample struct {
+	strings map[string]string
+	numbers map[string]float64
+}
+
+type derivedMetric struct {
+	config    DerivedMetricConfig
+	counter   *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+}
+
+// newDerivedMetrics validates the configured derived metrics and registers
+// them. stringFields are the default and custom label names, which derived
+// metrics can use as well.
+func newDerivedMetrics(configs []DerivedMetricConfig, stringFields []string, registry *prometheus.Registry) ([]*derivedMetric, error) {
+	stringFields = append(slices.Clone(stringFields), derivedStringFields...)
+	metrics := make([]*derivedMetric, 0, len(configs))
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, fmt.Errorf("derived metric name is required")
+		}
+		for _, label := range config.Labels {
+			if !slices.Contains(stringFields, label) {
+				return nil, fmt.Errorf("derived metric %s: unknown label field %q", config.Name, label)
+			}
+		}
+		for field := range config.Match {
+			if !slices.Contains(stringFields, field) {
+				return nil, fmt.Errorf("derived metric %s: unknown match field %q", config.Name, field)
+			}
+		}
+		if config.Value != "" && !slices.Contains(derivedNumericFields, config.Value) {
+			return nil, fmt.Errorf("derived metric %s: unknown value field %q", config.Name, config.Value)
+		}
+		help := config.Help
+		if help == "" {
+			help = "Derived metric " + config.Name + "."
+		}
+
+		metric := &derivedMetric{config: config}
+		var collector prometheus.Collector
+		switch config.Type {
+		case DerivedMetricCounter:
+			metric.counter = prometheus.NewCounterVec(prometheus.CounterOpts{Name: config.Name, Help: help}, config.Labels)
+			collector = metric.counter
+		case DerivedMetricHistogram:
+			if config.Value == "" {
+				return nil, fmt.Errorf("derived metric %s: histograms need a value field", config.Name)
+			}
+			buckets := config.Buckets
+			if len(buckets) == 0 {
+				buckets = prometheus.DefBuckets
+			}
+			metric.histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: config.Name, Help: help, Buckets: buckets}, config.Labels)
+			collector = metric.histogram
+		default:
+			return nil, fmt.Errorf("derived metric %s: unknown type %q", config.Name, config.Type)
+		}
+		if err := registry.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register derived metric %s: %v", config.Name, err)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+// record updates the metric from a request, if it matches.
+func (m *derivedMetric) record(sample derivedSample) {
+	for field, want := range m.config.Match {
+		if sample.strings[field] != want {
+			return
+		}
+	}
+	labelValues := getPrometheusLabelValues(m.config.Labels, sample.strings)
+	if m.histogram != nil {
+		m.histogram.WithLabelValues(labelValues...).Observe(sample.numbers[m.config.Value])
+		return
+	}
+	if m.config.Value == "" {
+		m.counter.WithLabelValues(labelValues...).Inc()
+		return
+	}
+	if value := sample.numbers[m.config.Value]; value > 0 {
+		m.counter.WithLabelValues(labelValues...).Add(value)
+	}
+}
+
+// trackDerivedStreamChunk folds a chat stream chunk into the stream's derived
+// state. Called for every chunk, before the final one is recorded.
+func trackDerivedStreamChunk(ctx *schemas.BifrostContext, result *schemas.BifrostResponse) *derivedStreamState {
+	state, _ := ctx.Value(derivedStreamStateKey).(*derivedStreamState)
+	if state == nil {
+		state = &derivedStreamState{}
+		ctx.SetValue(derivedStreamStateKey, state)
+	}
+	if result == nil || result.ChatResponse == nil {
+		return state
+	}
+	for _, choice := range result.ChatResponse.Choices {
+		if choice.FinishReason != nil && *choice.FinishReason != "" && state.finishReason == "" {
+			state.finishReason = *choice.FinishReason
+		}
+		if choice.ChatStreamResponseChoice == nil || choice.ChatStreamResponseChoice.Delta == nil {
+			continue
+		}
+		// A tool call's first delta carries its ID; later deltas only append arguments.
+		for _, toolCall := range choice.ChatStreamResponseChoice.Delta.ToolCalls {
+			if toolCall.ID != nil && *toolCall.ID != "" {
+				state.toolCalls++
+			}
+		}
+	}
+	return state
+}
+
+// buildDerivedSample collects the fields of a completed request. labelValues
+// are its default and custom label values; stream is the chat stream state,
+// nil for other requests.
+func buildDerivedSample(labelValues map[string]string, result *schemas.BifrostResponse, bifrostErr *schemas.BifrostError, stream *derivedStreamState, inputTokens, outputTokens, retries int, latencySeconds, cost float64) derivedSample {
+	sample := derivedSample{
+		strings: maps.Clone(labelValues),
+		numbers: map[string]float64{
+			"input_tokens":    float64(inputTokens),
+			"output_tokens":   float64(outputTokens),
+			"total_tokens":    float64(inputTokens + outputTokens),
+			"latency_seconds": latencySeconds,
+			"cost":            cost,
+			"retries":         float64(retries),
+		},
+	}
+	sample.strings["status"] = "success"
+	sample.strings["cache_hit"] = "false"
+	if bifrostErr != nil {
+		sample.strings["status"] = "error"
+		if bifrostErr.StatusCode != nil {
+			sample.strings["status_code"] = strconv.Itoa(*bifrostErr.StatusCode)
+		}
+	}
+
+	finishReason, toolCalls := responseCompletion(result)
+	if stream != nil {
+		if finishReason == "" {
+			finishReason = stream.finishReason
+		}
+		toolCalls = stream.toolCalls
+	}
+	sample.strings["finish_reason"] = finishReason
+	sample.numbers["tool_calls"] = float64(toolCalls)
+
+	if result != nil {
+		if cacheDebug := result.GetExtraFields().CacheDebug; cacheDebug != nil && cacheDebug.CacheHit {
+			sample.strings["cache_hit"] = "true"
+			if cacheDebug.HitType != nil {
+				sample.strings["cache_type"] = *cacheDebug.HitType
+			}
+		}
+	}
+	return sample
+}
+
+// responseCompletion returns why a response stopped and how many tool calls it
+// made. Streams other than responses streams carry neither in their final chunk.
+func responseCompletion(result *schemas.BifrostResponse) (finishReason string, toolCalls int) {
+	if result == nil {
+		return "", 0
+	}
+	var choices []schemas.BifrostResponseChoice
+	switch {
+	case result.ChatResponse != nil:
+		choices = result.ChatResponse.Choices
+	case result.TextCompletionResponse != nil:
+		choices = result.TextCompletionResponse.Choices
+	case result.ResponsesResponse != nil:
+		return responsesCompletion(result.ResponsesResponse)
+	case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil:
+		return responsesCompletion(result.ResponsesStreamResponse.Response)
+	}
+	for _, choice := range choices {
+		if finishReason == "" && choice.FinishReason != nil {
+			finishReason = *choice.FinishReason
+		}
+		if choice.ChatNonStreamResponseChoice != nil && choice.ChatNonStreamResponseChoice.Message != nil &&
+			choice.ChatNonStreamResponseChoice.Message.ChatAssistantMessage != nil {
+			toolCalls += len(choice.ChatNonStreamResponseChoice.Message.ChatAssistantMessage.ToolCalls)
+		}
+	}
+	return finishReason, toolCalls
+}
+
+func responsesCompletion(resp *schemas.BifrostResponsesResponse) (finishReason string, toolCalls int) {
+	if resp.IncompleteDetails != nil && resp.IncompleteDetails.Reason != "" {
+		finishReason = resp.IncompleteDetails.Reason
+	} else if resp.Status != nil {
+		finishReason = *resp.Status
+	}
+	for _, item := range resp.Output {
+		if item.Type != nil && *item.Type == schemas.ResponsesMessageTypeFunctionCall {
+			toolCalls++
+		}
+	}
+	return finishReason, toolCalls
+}
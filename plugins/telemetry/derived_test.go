@@ -0,0 +1,155 @@
+package telemetry
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var testDerivedMetrics = []DerivedMetricConfig{
+	{
+		Name:   "bifrost_truncated_responses_total",
+		Type:   DerivedMetricCounter,
+		Labels: []string{"model"},
+		Match:  map[string]string{"finish_reason": "length"},
+	},
+	{
+		Name:    "bifrost_tool_calls_per_request",
+		Type:    DerivedMetricHistogram,
+		Value:   "tool_calls",
+		Buckets: []float64{0, 1, 2, 4},
+	},
+}
+
+func newDerivedTestPlugin(t *testing.T) *PrometheusPlugin {
+	t.Helper()
+	p, err := Init(&Config{DerivedMetrics: testDerivedMetrics}, nil, bifrost.NewDefaultLogger(schemas.LogLevelError))
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	return p
+}
+
+// waitForHistogram polls until the named histogram has observed want samples
+// and returns their sum.
+func waitForHistogram(t *testing.T, reg *prometheus.Registry, name string, want uint64) float64 {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		fams, err := reg.Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		for _, mf := range fams {
+			if mf.GetName() != name {
+				continue
+			}
+			var count uint64
+			var sum float64
+			for _, m := range mf.GetMetric() {
+				count += m.GetHistogram().GetSampleCount()
+				sum += m.GetHistogram().GetSampleSum()
+			}
+			if count == want {
+				return sum
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("histogram %s did not reach %d samples", name, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func chatResponse(finishReason string, toolCalls int) *schemas.BifrostResponse {
+	message := &schemas.ChatMessage{Role: schemas.ChatMessageRoleAssistant, ChatAssistantMessage: &schemas.ChatAssistantMessage{}}
+	for i := range toolCalls {
+		message.ChatAssistantMessage.ToolCalls = append(message.ChatAssistantMessage.ToolCalls, schemas.ChatAssistantMessageToolCall{
+			ID:       schemas.Ptr("call_" + strconv.Itoa(i)),
+			Function: schemas.ChatAssistantMessageToolCallFunction{Name: schemas.Ptr("lookup")},
+		})
+	}
+	resp := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Choices: []schemas.BifrostResponseChoice{{
+			FinishReason:                &finishReason,
+			ChatNonStreamResponseChoice: &schemas.ChatNonStreamResponseChoice{Message: message},
+		}},
+	}}
+	resp.PopulateExtraFields(schemas.ChatCompletionRequest, "openai", "gpt-4o", "gpt-4o")
+	return resp
+}
+
+func TestDerivedMetrics_RecordFromResponses(t *testing.T) {
+	p := newDerivedTestPlugin(t)
+
+	for _, resp := range []*schemas.BifrostResponse{chatResponse("length", 0), chatResponse("tool_calls", 2), chatResponse("stop", 0)} {
+		if _, _, err := p.PostLLMHook(newHookContext(schemas.ChatCompletionRequest), resp, nil); err != nil {
+			t.Fatalf("PostLLMHook: %v", err)
+		}
+	}
+
+	if sum := waitForHistogram(t, p.registry, "bifrost_tool_calls_per_request", 3); sum != 2 {
+		t.Fatalf("expected 2 tool calls observed, got %v", sum)
+	}
+	waitForCounter(t, p.registry, "bifrost_truncated_responses_total", 1)
+}
+
+func TestDerivedMetrics_ChatStream(t *testing.T) {
+	p := newDerivedTestPlugin(t)
+	ctx := newHookContext(schemas.ChatCompletionStreamRequest)
+
+	chunk := func(index int, choice schemas.BifrostResponseChoice) *schemas.BifrostResponse {
+		resp := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{Choices: []schemas.BifrostResponseChoice{choice}}}
+		resp.PopulateExtraFields(schemas.ChatCompletionStreamRequest, "openai", "gpt-4o", "gpt-4o")
+		resp.ChatResponse.ExtraFields.ChunkIndex = index
+		return resp
+	}
+	toolCallDelta := func(id *string) schemas.BifrostResponseChoice {
+		return schemas.BifrostResponseChoice{ChatStreamResponseChoice: &schemas.ChatStreamResponseChoice{
+			Delta: &schemas.ChatStreamResponseChoiceDelta{ToolCalls: []schemas.ChatAssistantMessageToolCall{{ID: id}}},
+		}}
+	}
+	chunks := []*schemas.BifrostResponse{
+		chunk(0, toolCallDelta(schemas.Ptr("call_a"))),
+		chunk(1, toolCallDelta(nil)),
+		chunk(2, toolCallDelta(schemas.Ptr("call_b"))),
+		chunk(3, schemas.BifrostResponseChoice{FinishReason: schemas.Ptr("length")}),
+		chunk(4, schemas.BifrostResponseChoice{}),
+	}
+	for i, resp := range chunks {
+		if i == len(chunks)-1 {
+			ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+		}
+		if _, _, err := p.PostLLMHook(ctx, resp, nil); err != nil {
+			t.Fatalf("PostLLMHook: %v", err)
+		}
+	}
+
+	if sum := waitForHistogram(t, p.registry, "bifrost_tool_calls_per_request", 1); sum != 2 {
+		t.Fatalf("expected the stream's 2 tool calls observed once, got %v", sum)
+	}
+	waitForCounter(t, p.registry, "bifrost_truncated_responses_total", 1)
+}
+
+func TestDerivedMetrics_InvalidConfig(t *testing.T) {
+	invalid := map[string]DerivedMetricConfig{
+		"missing name":            {Type: DerivedMetricCounter},
+		"unknown type":            {Name: "m", Type: "gauge"},
+		"unknown label":           {Name: "m", Type: DerivedMetricCounter, Labels: []string{"prompt"}},
+		"unknown match field":     {Name: "m", Type: DerivedMetricCounter, Match: map[string]string{"user": "x"}},
+		"unknown value field":     {Name: "m", Type: DerivedMetricCounter, Value: "finish_reason"},
+		"histogram without value": {Name: "m", Type: DerivedMetricHistogram},
+		"clashes with a built-in": {Name: "bifrost_upstream_requests_total", Type: DerivedMetricCounter},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Init(&Config{DerivedMetrics: []DerivedMetricConfig{config}}, nil, bifrost.NewDefaultLogger(schemas.LogLevelError)); err == nil {
+				t.Fatal("expected Init to reject the derived metric")
+			}
+		})
+	}
+}
@@ -83,13 +83,17 @@ func (c *Config) MarshalForStorage() ([]byte, error) {
 		BasicAuth      *basicAuthStorage `json:"basic_auth,omitempty"`
 	}
 	type configStorage struct {
-		CustomLabels   []string            `json:"custom_labels,omitempty"`
-		MetricsEnabled *bool               `json:"metrics_enabled,omitempty"`
-		PushGateway    *pushGatewayStorage `json:"push_gateway,omitempty"`
+		CustomLabels                  []string              `json:"custom_labels,omitempty"`
+		MetricsEnabled                *bool                 `json:"metrics_enabled,omitempty"`
+		DisableHighCardinalityMetrics bool                  `json:"disable_high_cardinality_metrics,omitempty"`
+		DerivedMetrics                []DerivedMetricConfig `json:"derived_metrics,omitempty"`
+		PushGateway                   *pushGatewayStorage   `json:"push_gateway,omitempty"`
 	}
 	storage := configStorage{
-		CustomLabels:   c.CustomLabels,
-		MetricsEnabled: c.MetricsEnabled,
+		CustomLabels:                  c.CustomLabels,
+		MetricsEnabled:                c.MetricsEnabled,
+		DisableHighCardinalityMetrics: c.DisableHighCardinalityMetrics,
+		DerivedMetrics:                c.DerivedMetrics,
 	}
 	if c.PushGateway != nil {
 		pgw := &pushGatewayStorage{
@@ -176,6 +180,8 @@ type PrometheusPlugin struct {
 	CacheWriteInputTokensTotal     *prometheus.CounterVec
 	CacheWriteInputTokens5mTotal   *prometheus.CounterVec
 	CacheWriteInputTokens1hTotal   *prometheus.CounterVec
+	DegradedRequestsTotal          *prometheus.CounterVec
+	GovernanceOverridesTotal       *prometheus.CounterVec
 	CostTotal                      *prometheus.CounterVec
 	StreamInterTokenLatencySeconds *prometheus.HistogramVec
 	StreamFirstTokenLatencySeconds *prometheus.HistogramVec
@@ -186,6 +192,28 @@ type PrometheusPlugin struct {
 	MCPToolDuration                *prometheus.HistogramVec
 	customLabels                   []string
 
+	// rollup holds the per-provider aggregates, recorded for every request.
+	rollup *providerRollup
+	// highCardinality gates the detailed upstream and MCP series.
+	highCardinality bool
+
+	// queueDepth exports provider queue depths once SetProviderQueueStatsSource is called.
+	queueDepth *queueDepthCollector
+	// gatewayLoad exports in-flight requests and SSE connections once SetGatewayLoadSource is called.
+	gatewayLoad *gatewayLoadCollector
+	// virtualKeyInFlight exports per virtual key in-flight requests once SetVirtualKeyInFlightSource is called.
+	virtualKeyInFlight *virtualKeyInFlightCollector
+	// logStoreDualWrite exports log store shadow divergence once SetLogStoreDualWriteSource is called.
+	logStoreDualWrite *logStoreDualWriteCollector
+	// semanticCache exports semantic cache lookup outcomes once SetSemanticCacheStatsSource is called.
+	semanticCache *semanticCacheCollector
+	// circuitBreaker exports circuit breaker state once SetCircuitBreakerStatsSource is called.
+	circuitBreaker *circuitBreakerCollector
+	// configVersion exports config divergence between replicas once SetConfigVersionSource is called.
+	configVersion *configVersionCollector
+	// derived are the operator-defined metrics from Config.DerivedMetrics.
+	derived []*derivedMetric
+
 	defaultHTTPLabels    []string
 	defaultBifrostLabels []string
 	defaultMCPLabels     []string
@@ -209,6 +237,15 @@ type Config struct {
 	PushGateway  *PushGatewayConfig `json:"push_gateway"`
 	// MetricsEnabled controls whether the /metrics scrape endpoint is served.
 	MetricsEnabled *bool `json:"metrics_enabled,omitempty"`
+	// DisableHighCardinalityMetrics stops recording the upstream and MCP series
+	// labelled by model, virtual key, team and custom labels, for fleets large
+	// enough that their cardinality hurts. The per-provider bifrost_provider_*
+	// aggregates are always recorded.
+	DisableHighCardinalityMetrics bool `json:"disable_high_cardinality_metrics,omitempty"`
+	// DerivedMetrics are extra metrics computed from the fields of each
+	// completed request. They are recorded regardless of
+	// DisableHighCardinalityMetrics, so mind the cardinality of their labels.
+	DerivedMetrics []DerivedMetricConfig `json:"derived_metrics,omitempty"`
 }
 
 // Keep in sync with plugins/otel/metrics.go's identical arrays so the Prometheus
@@ -491,6 +528,27 @@ func Init(config *Config, pricingManager *modelcatalog.ModelCatalog, logger sche
 		append(defaultBifrostLabels, filteredCustomLabels...),
 	)
 
+	// Requests the circuit breaker degraded to a designated fallback model because the
+	// primary provider's circuit was open. The provider/model labels are the degraded
+	// target; degraded_from carries the original "provider/model".
+	bifrostDegradedRequestsTotal := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bifrost_degraded_requests_total",
+			Help: "Total number of requests served by a degradation target while the primary provider's circuit was open.",
+		},
+		append(append(defaultBifrostLabels, "degraded_from"), filteredCustomLabels...),
+	)
+
+	// Requests that skipped a budget and/or rate limit by presenting a governance
+	// override token. override_id identifies the token so its usage can be audited.
+	bifrostGovernanceOverridesTotal := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bifrost_governance_overrides_total",
+			Help: "Total number of requests that presented a governance override token to bypass budgets or rate limits.",
+		},
+		append(append(defaultBifrostLabels, "override_id"), filteredCustomLabels...),
+	)
+
 	bifrostCostTotal := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "bifrost_cost_total",
@@ -567,7 +625,42 @@ func Init(config *Config, pricingManager *modelcatalog.ModelCatalog, logger sche
 		append(defaultMCPLabels, filteredCustomLabels...),
 	)
 
+	queueDepth := newQueueDepthCollector()
+	if err := registry.Register(queueDepth); err != nil {
+		return nil, fmt.Errorf("failed to register provider queue depth collector: %v", err)
+	}
+	gatewayLoad := newGatewayLoadCollector()
+	if err := registry.Register(gatewayLoad); err != nil {
+		return nil, fmt.Errorf("failed to register gateway load collector: %v", err)
+	}
+	virtualKeyInFlight := newVirtualKeyInFlightCollector()
+	if err := registry.Register(virtualKeyInFlight); err != nil {
+		return nil, fmt.Errorf("failed to register virtual key in-flight collector: %v", err)
+	}
+	logStoreDualWrite := newLogStoreDualWriteCollector()
+	if err := registry.Register(logStoreDualWrite); err != nil {
+		return nil, fmt.Errorf("failed to register log store dual-write collector: %v", err)
+	}
+	semanticCache := newSemanticCacheCollector()
+	if err := registry.Register(semanticCache); err != nil {
+		return nil, fmt.Errorf("failed to register semantic cache collector: %v", err)
+	}
+	circuitBreaker := newCircuitBreakerCollector()
+	if err := registry.Register(circuitBreaker); err != nil {
+		return nil, fmt.Errorf("failed to register circuit breaker collector: %v", err)
+	}
+	configVersion := newConfigVersionCollector()
+	if err := registry.Register(configVersion); err != nil {
+		return nil, fmt.Errorf("failed to register config version collector: %v", err)
+	}
+	derived, err := newDerivedMetrics(config.DerivedMetrics, append(slices.Clone(defaultBifrostLabels), filteredCustomLabels...), registry)
+	if err != nil {
+		return nil, err
+	}
+
 	plugin := &PrometheusPlugin{
+		rollup:                         newProviderRollup(factory),
+		highCardinality:                !config.DisableHighCardinalityMetrics,
 		logger:                         logger,
 		pricingManager:                 pricingManager,
 		registry:                       registry,
@@ -590,6 +683,8 @@ func Init(config *Config, pricingManager *modelcatalog.ModelCatalog, logger sche
 		CacheWriteInputTokensTotal:     bifrostCacheWriteInputTokensTotal,
 		CacheWriteInputTokens5mTotal:   bifrostCacheWriteInputTokens5mTotal,
 		CacheWriteInputTokens1hTotal:   bifrostCacheWriteInputTokens1hTotal,
+		DegradedRequestsTotal:          bifrostDegradedRequestsTotal,
+		GovernanceOverridesTotal:       bifrostGovernanceOverridesTotal,
 		CostTotal:                      bifrostCostTotal,
 		StreamInterTokenLatencySeconds: bifrostStreamInterTokenLatencySeconds,
 		StreamFirstTokenLatencySeconds: bifrostStreamFirstTokenLatencySeconds,
@@ -602,6 +697,14 @@ func Init(config *Config, pricingManager *modelcatalog.ModelCatalog, logger sche
 		defaultHTTPLabels:              defaultHTTPLabels,
 		defaultBifrostLabels:           defaultBifrostLabels,
 		defaultMCPLabels:               defaultMCPLabels,
+		queueDepth:                     queueDepth,
+		gatewayLoad:                    gatewayLoad,
+		virtualKeyInFlight:             virtualKeyInFlight,
+		logStoreDualWrite:              logStoreDualWrite,
+		semanticCache:                  semanticCache,
+		circuitBreaker:                 circuitBreaker,
+		configVersion:                  configVersion,
+		derived:                        derived,
 	}
 
 	// Default /metrics scraping to on when the config omits the field — preserves
@@ -643,6 +746,106 @@ func (p *PrometheusPlugin) GetName() string {
 	return PluginName
 }
 
+// DegradationPolicy implements schemas.DegradablePlugin: a bypassed plugin
+// leaves gaps in the bifrost_* request metrics but not in the HTTP ones.
+func (p *PrometheusPlugin) DegradationPolicy() *schemas.PluginDegradationPolicy {
+	return schemas.DefaultObservabilityDegradationPolicy()
+}
+
+// configSchema is the JSON Schema of the plugin's config map, served to the UI for form generation.
+var configSchema = []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Telemetry",
+  "type": "object",
+  "properties": {
+    "custom_labels": {
+      "type": "array",
+      "title": "Custom labels",
+      "description": "Extra Prometheus labels, read from x-bf-dim-<label> request headers",
+      "items": {"type": "string", "pattern": "^[a-zA-Z_][a-zA-Z0-9_]*$"},
+      "uniqueItems": true
+    },
+    "metrics_enabled": {
+      "type": "boolean",
+      "title": "Serve /metrics",
+      "default": true
+    },
+    "disable_high_cardinality_metrics": {
+      "type": "boolean",
+      "title": "Disable high-cardinality metrics",
+      "description": "Only record the per-provider bifrost_provider_* aggregates, not the series labelled by model, virtual key and custom labels",
+      "default": false
+    },
+    "derived_metrics": {
+      "type": "array",
+      "title": "Derived metrics",
+      "description": "Extra metrics computed from the fields of each completed request",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string", "title": "Name", "pattern": "^[a-zA-Z_:][a-zA-Z0-9_:]*$"},
+          "help": {"type": "string", "title": "Help"},
+          "type": {"type": "string", "title": "Type", "enum": ["counter", "histogram"]},
+          "value": {
+            "type": "string",
+            "title": "Value field",
+            "description": "Numeric field a histogram observes or a counter adds; a counter without one counts requests",
+            "enum": ["input_tokens", "output_tokens", "total_tokens", "tool_calls", "latency_seconds", "cost", "retries"]
+          },
+          "labels": {
+            "type": "array",
+            "title": "Labels",
+            "description": "Default or custom label names, or status, status_code, finish_reason, cache_hit, cache_type",
+            "items": {"type": "string"}
+          },
+          "match": {
+            "type": "object",
+            "title": "Match",
+            "description": "Only record requests whose fields equal these values",
+            "additionalProperties": {"type": "string"}
+          },
+          "buckets": {"type": "array", "title": "Histogram buckets", "items": {"type": "number"}}
+        },
+        "required": ["name", "type"]
+      }
+    },
+    "push_gateway": {
+      "type": ["object", "null"],
+      "title": "Push Gateway",
+      "properties": {
+        "enabled": {"type": "boolean", "title": "Enabled"},
+        "push_gateway_url": {"$ref": "#/$defs/secret", "title": "Push Gateway URL"},
+        "job_name": {"type": "string", "title": "Job name", "default": "bifrost"},
+        "instance_id": {"type": "string", "title": "Instance ID", "description": "Defaults to the hostname"},
+        "push_interval": {"type": "integer", "title": "Push interval (seconds)", "minimum": 0, "default": 15},
+        "basic_auth": {
+          "type": ["object", "null"],
+          "title": "Basic auth",
+          "properties": {
+            "username": {"$ref": "#/$defs/secret", "title": "Username"},
+            "password": {"$ref": "#/$defs/secret", "title": "Password", "writeOnly": true}
+          }
+        }
+      }
+    }
+  },
+  "$defs": {
+    "secret": {
+      "description": "A literal value or env.VAR_NAME reference",
+      "anyOf": [
+        {"type": "null"},
+        {"type": "string"},
+        {"type": "object", "properties": {"value": {"type": "string"}}, "required": ["value"]}
+      ]
+    }
+  }
+}`)
+
+// ConfigSchema implements schemas.ConfigSchemaPlugin.
+func (p *PrometheusPlugin) ConfigSchema() []byte {
+	return configSchema
+}
+
 // MarshalConfigForStorage implements schemas.ConfigMarshallerPlugin.
 func (p *PrometheusPlugin) MarshalConfigForStorage(raw map[string]any) (map[string]any, error) {
 	b, err := sonic.Marshal(raw)
@@ -715,6 +918,9 @@ func (p *PrometheusPlugin) HTTPTransportPostHook(ctx *schemas.BifrostContext, re
 // resolved. Silent when either is missing: no accumulator means upstream was
 // never measured, and reporting the full duration as overhead would be wrong.
 func (p *PrometheusPlugin) recordOverhead(ctx *schemas.BifrostContext, total time.Duration) {
+	if !p.highCardinality {
+		return
+	}
 	labels, ok := ctx.Value(overheadLabelsKey).([]string)
 	if !ok || len(labels) == 0 {
 		return
@@ -804,7 +1010,7 @@ func (p *PrometheusPlugin) PostMCPHook(ctx *schemas.BifrostContext, resp *schema
 		clientName = bifrost.GetStringFromContext(ctx, mcpClientNameKey)
 		toolName = bifrost.GetStringFromContext(ctx, mcpToolNameKey)
 	}
-	if !mcpReqType.IsExecuteTool() || bifrost.IsCodemodeTool(toolName) {
+	if !p.highCardinality || !mcpReqType.IsExecuteTool() || bifrost.IsCodemodeTool(toolName) {
 		return resp, bifrostErr, nil
 	}
 
@@ -888,6 +1094,61 @@ func extractProviderCacheTokens(result *schemas.BifrostResponse) (read, write, w
 	return
 }
 
+// extractTokens returns the input and output tokens of a response's usage, zero
+// when it has none. Its cases are the contract with the logging plugin checked by
+// TestTokenExtractionParityWithLogging.
+func extractTokens(result *schemas.BifrostResponse) (inputTokens, outputTokens int) {
+	if result == nil {
+		return 0, 0
+	}
+	switch {
+	case result.TextCompletionResponse != nil && result.TextCompletionResponse.Usage != nil:
+		inputTokens = result.TextCompletionResponse.Usage.PromptTokens
+		outputTokens = result.TextCompletionResponse.Usage.CompletionTokens
+	case result.ChatResponse != nil && result.ChatResponse.Usage != nil:
+		inputTokens = result.ChatResponse.Usage.PromptTokens
+		outputTokens = result.ChatResponse.Usage.CompletionTokens
+	case result.ResponsesResponse != nil && result.ResponsesResponse.Usage != nil:
+		inputTokens = result.ResponsesResponse.Usage.InputTokens
+		outputTokens = result.ResponsesResponse.Usage.OutputTokens
+	case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil && result.ResponsesStreamResponse.Response.Usage != nil:
+		inputTokens = result.ResponsesStreamResponse.Response.Usage.InputTokens
+		outputTokens = result.ResponsesStreamResponse.Response.Usage.OutputTokens
+	case result.EmbeddingResponse != nil && result.EmbeddingResponse.Usage != nil:
+		inputTokens = result.EmbeddingResponse.Usage.PromptTokens
+		outputTokens = result.EmbeddingResponse.Usage.CompletionTokens
+	case result.SpeechStreamResponse != nil && result.SpeechStreamResponse.Usage != nil:
+		inputTokens = result.SpeechStreamResponse.Usage.InputTokens
+		outputTokens = result.SpeechStreamResponse.Usage.OutputTokens
+	case result.TranscriptionResponse != nil && result.TranscriptionResponse.Usage != nil:
+		if result.TranscriptionResponse.Usage.InputTokens != nil {
+			inputTokens = *result.TranscriptionResponse.Usage.InputTokens
+		}
+		if result.TranscriptionResponse.Usage.OutputTokens != nil {
+			outputTokens = *result.TranscriptionResponse.Usage.OutputTokens
+		}
+	case result.TranscriptionStreamResponse != nil && result.TranscriptionStreamResponse.Usage != nil:
+		if result.TranscriptionStreamResponse.Usage.InputTokens != nil {
+			inputTokens = *result.TranscriptionStreamResponse.Usage.InputTokens
+		}
+		if result.TranscriptionStreamResponse.Usage.OutputTokens != nil {
+			outputTokens = *result.TranscriptionStreamResponse.Usage.OutputTokens
+		}
+	case result.CompactionResponse != nil && result.CompactionResponse.Usage != nil:
+		if u := result.CompactionResponse.Usage.ToBifrostLLMUsage(); u != nil {
+			inputTokens = u.PromptTokens
+			outputTokens = u.CompletionTokens
+		}
+	case result.ImageGenerationResponse != nil && result.ImageGenerationResponse.Usage != nil:
+		inputTokens = result.ImageGenerationResponse.Usage.InputTokens
+		outputTokens = result.ImageGenerationResponse.Usage.OutputTokens
+	case result.PassthroughResponse != nil && result.PassthroughResponse.PassthroughUsage != nil && result.PassthroughResponse.PassthroughUsage.LLMUsage != nil:
+		inputTokens = result.PassthroughResponse.PassthroughUsage.LLMUsage.PromptTokens
+		outputTokens = result.PassthroughResponse.PassthroughUsage.LLMUsage.CompletionTokens
+	}
+	return inputTokens, outputTokens
+}
+
 // PostLLMHook calculates duration and records upstream metrics for successful requests.
 // It records:
 //   - Request latency
@@ -928,6 +1189,8 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		routingEngines = engines
 	}
 	routingEngineUsed := strings.Join(routingEngines, ",")
+	degradedFrom := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyDegradedFrom)
+	governanceOverrideID := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceOverrideID)
 
 	teamID := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceTeamID)
 	teamName := bifrost.GetStringFromContext(ctx, schemas.BifrostContextKeyGovernanceTeamName)
@@ -985,6 +1248,13 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		ctx.SetValue(overheadLabelsKey, slices.Clone(promLabelValues))
 	}
 
+	// A chat stream's tool calls and finish reason arrive before its final
+	// chunk, so derived metrics track them chunk by chunk.
+	var derivedStream *derivedStreamState
+	if len(p.derived) > 0 && requestType == schemas.ChatCompletionStreamRequest {
+		derivedStream = trackDerivedStreamChunk(ctx, result)
+	}
+
 	// Calculate cost and record metrics in a separate goroutine to avoid blocking the main thread
 	go func() {
 		// For streaming requests, handle per-token metrics for intermediate chunks
@@ -996,8 +1266,11 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 				if result != nil {
 					extraFields := result.GetExtraFields()
 					if extraFields.ChunkIndex == 0 {
-						p.StreamFirstTokenLatencySeconds.WithLabelValues(promLabelValues...).Observe(float64(extraFields.Latency) / 1000.0)
-					} else {
+						p.rollup.firstTokenLatencySeconds.WithLabelValues(string(provider)).Observe(float64(extraFields.Latency) / 1000.0)
+						if p.highCardinality {
+							p.StreamFirstTokenLatencySeconds.WithLabelValues(promLabelValues...).Observe(float64(extraFields.Latency) / 1000.0)
+						}
+					} else if p.highCardinality {
 						p.StreamInterTokenLatencySeconds.WithLabelValues(promLabelValues...).Observe(float64(extraFields.Latency) / 1000.0)
 					}
 				}
@@ -1014,7 +1287,7 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		// next try (per-key failure — rate-limit/auth/billing/permission — with retries remaining).
 		// Mark the key unhealthy on any failure, since key health is per-failure not per-rotation.
 		for _, record := range attemptTrail {
-			if record.TriggeredRotation && record.FailReason != nil {
+			if p.highCardinality && record.TriggeredRotation && record.FailReason != nil {
 				p.KeyRotationEventsTotal.WithLabelValues(
 					string(provider), originalModel, record.KeyID, record.KeyName, *record.FailReason,
 				).Inc()
@@ -1028,15 +1301,35 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 			p.ProviderKeyUp.WithLabelValues(string(provider), selectedKeyID, selectedKeyName).Set(1)
 		}
 
+		duration := time.Since(startTime).Seconds()
+		inputTokens, outputTokens := extractTokens(result)
+		p.rollup.record(string(provider), bifrostErr == nil, duration, inputTokens, outputTokens, cost)
+		if len(p.derived) > 0 {
+			sample := buildDerivedSample(labelValues, result, bifrostErr, derivedStream, inputTokens, outputTokens, numberOfRetries, duration, cost)
+			for _, metric := range p.derived {
+				metric.record(sample)
+			}
+		}
+		if !p.highCardinality {
+			return
+		}
+
 		p.UpstreamRequestsTotal.WithLabelValues(promLabelValues...).Inc()
 
+		if governanceOverrideID != "" {
+			overrideLabelValues := make([]string, 0, len(promLabelValues)+1)
+			overrideLabelValues = append(overrideLabelValues, promLabelValues[:len(p.defaultBifrostLabels)]...)
+			overrideLabelValues = append(overrideLabelValues, governanceOverrideID)
+			overrideLabelValues = append(overrideLabelValues, promLabelValues[len(p.defaultBifrostLabels):]...)
+			p.GovernanceOverridesTotal.WithLabelValues(overrideLabelValues...).Inc()
+		}
+
 		// Record retries used for this request. Observed once per request (per the goroutine
 		// guarding around isStreamFinal), so .Sum/.Count map cleanly to "total retry attempts"
 		// and "total requests"; bucket le="0" gives "requests that succeeded on the first try".
 		p.RequestRetries.WithLabelValues(promLabelValues...).Observe(float64(numberOfRetries))
 
 		// Record latency
-		duration := time.Since(startTime).Seconds()
 		latencyLabelValues := make([]string, 0, len(promLabelValues)+1)
 		latencyLabelValues = append(latencyLabelValues, promLabelValues[:len(p.defaultBifrostLabels)]...) // all default labels
 		latencyLabelValues = append(latencyLabelValues, strconv.FormatBool(bifrostErr == nil))            // is_success
@@ -1071,55 +1364,6 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 		}
 
 		if result != nil {
-			// Record input and output tokens
-			var inputTokens, outputTokens int
-
-			switch {
-			case result.TextCompletionResponse != nil && result.TextCompletionResponse.Usage != nil:
-				inputTokens = result.TextCompletionResponse.Usage.PromptTokens
-				outputTokens = result.TextCompletionResponse.Usage.CompletionTokens
-			case result.ChatResponse != nil && result.ChatResponse.Usage != nil:
-				inputTokens = result.ChatResponse.Usage.PromptTokens
-				outputTokens = result.ChatResponse.Usage.CompletionTokens
-			case result.ResponsesResponse != nil && result.ResponsesResponse.Usage != nil:
-				inputTokens = result.ResponsesResponse.Usage.InputTokens
-				outputTokens = result.ResponsesResponse.Usage.OutputTokens
-			case result.ResponsesStreamResponse != nil && result.ResponsesStreamResponse.Response != nil && result.ResponsesStreamResponse.Response.Usage != nil:
-				inputTokens = result.ResponsesStreamResponse.Response.Usage.InputTokens
-				outputTokens = result.ResponsesStreamResponse.Response.Usage.OutputTokens
-			case result.EmbeddingResponse != nil && result.EmbeddingResponse.Usage != nil:
-				inputTokens = result.EmbeddingResponse.Usage.PromptTokens
-				outputTokens = result.EmbeddingResponse.Usage.CompletionTokens
-			case result.SpeechStreamResponse != nil && result.SpeechStreamResponse.Usage != nil:
-				inputTokens = result.SpeechStreamResponse.Usage.InputTokens
-				outputTokens = result.SpeechStreamResponse.Usage.OutputTokens
-			case result.TranscriptionResponse != nil && result.TranscriptionResponse.Usage != nil:
-				if result.TranscriptionResponse.Usage.InputTokens != nil {
-					inputTokens = *result.TranscriptionResponse.Usage.InputTokens
-				}
-				if result.TranscriptionResponse.Usage.OutputTokens != nil {
-					outputTokens = *result.TranscriptionResponse.Usage.OutputTokens
-				}
-			case result.TranscriptionStreamResponse != nil && result.TranscriptionStreamResponse.Usage != nil:
-				if result.TranscriptionStreamResponse.Usage.InputTokens != nil {
-					inputTokens = *result.TranscriptionStreamResponse.Usage.InputTokens
-				}
-				if result.TranscriptionStreamResponse.Usage.OutputTokens != nil {
-					outputTokens = *result.TranscriptionStreamResponse.Usage.OutputTokens
-				}
-			case result.CompactionResponse != nil && result.CompactionResponse.Usage != nil:
-				if u := result.CompactionResponse.Usage.ToBifrostLLMUsage(); u != nil {
-					inputTokens = u.PromptTokens
-					outputTokens = u.CompletionTokens
-				}
-			case result.ImageGenerationResponse != nil && result.ImageGenerationResponse.Usage != nil:
-				inputTokens = result.ImageGenerationResponse.Usage.InputTokens
-				outputTokens = result.ImageGenerationResponse.Usage.OutputTokens
-			case result.PassthroughResponse != nil && result.PassthroughResponse.PassthroughUsage != nil && result.PassthroughResponse.PassthroughUsage.LLMUsage != nil:
-				inputTokens = result.PassthroughResponse.PassthroughUsage.LLMUsage.PromptTokens
-				outputTokens = result.PassthroughResponse.PassthroughUsage.LLMUsage.CompletionTokens
-			}
-
 			p.InputTokensTotal.WithLabelValues(promLabelValues...).Add(float64(inputTokens))
 			p.OutputTokensTotal.WithLabelValues(promLabelValues...).Add(float64(outputTokens))
 
@@ -1156,6 +1400,14 @@ func (p *PrometheusPlugin) PostLLMHook(ctx *schemas.BifrostContext, result *sche
 
 				p.CacheHitsTotal.WithLabelValues(cacheHitLabelValues...).Inc()
 			}
+
+			if degradedFrom != "" {
+				degradedLabelValues := make([]string, 0, len(promLabelValues)+1)
+				degradedLabelValues = append(degradedLabelValues, promLabelValues[:len(p.defaultBifrostLabels)]...)
+				degradedLabelValues = append(degradedLabelValues, degradedFrom)
+				degradedLabelValues = append(degradedLabelValues, promLabelValues[len(p.defaultBifrostLabels):]...)
+				p.DegradedRequestsTotal.WithLabelValues(degradedLabelValues...).Inc()
+			}
 		}
 	}()
 
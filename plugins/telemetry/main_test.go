@@ -3,9 +3,12 @@ package telemetry
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,7 +91,7 @@ type usageCase struct {
 // reaches the Prometheus counters (and therefore Grafana) — the Grafana-vs-logs mismatch.
 //
 // When logging learns a new usage-bearing response type, add it here AND to the switch in
-// PostLLMHook; this list is the contract between the two plugins.
+// extractTokens; this list is the contract between the two plugins.
 func tokenUsageCases() []usageCase {
 	return []usageCase{
 		{
@@ -157,7 +160,7 @@ func tokenUsageCases() []usageCase {
 // Grafana-vs-Bifrost-logs usage mismatch: it drives PostLLMHook with one response per
 // usage-bearing type that logging records, and asserts bifrost_input_tokens_total /
 // bifrost_output_tokens_total reflect the exact token counts. A response type that logging
-// records but PostLLMHook's switch omits records zero here and fails the test.
+// records but extractTokens omits records zero here and fails the test.
 func TestTokenExtractionParityWithLogging(t *testing.T) {
 	for _, tc := range tokenUsageCases() {
 		t.Run(tc.name, func(t *testing.T) {
@@ -171,10 +174,37 @@ func TestTokenExtractionParityWithLogging(t *testing.T) {
 
 			waitForCounter(t, p.registry, "bifrost_input_tokens_total", tc.wantIn)
 			waitForCounter(t, p.registry, "bifrost_output_tokens_total", tc.wantOut)
+			waitForCounter(t, p.registry, "bifrost_provider_input_tokens_total", tc.wantIn)
+			waitForCounter(t, p.registry, "bifrost_provider_output_tokens_total", tc.wantOut)
 		})
 	}
 }
 
+// TestDisableHighCardinalityMetrics asserts that with high-cardinality series disabled only the
+// per-provider rollups are recorded for an upstream request.
+func TestDisableHighCardinalityMetrics(t *testing.T) {
+	p, err := Init(&Config{DisableHighCardinalityMetrics: true}, nil, bifrost.NewDefaultLogger(schemas.LogLevelError))
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	resp := &schemas.BifrostResponse{ChatResponse: &schemas.BifrostChatResponse{
+		Usage: &schemas.BifrostLLMUsage{PromptTokens: 11, CompletionTokens: 7, TotalTokens: 18},
+	}}
+	resp.PopulateExtraFields(schemas.ChatCompletionRequest, "openai", "m", "m")
+	if _, _, err := p.PostLLMHook(newHookContext(schemas.ChatCompletionRequest), resp, nil); err != nil {
+		t.Fatalf("PostLLMHook: %v", err)
+	}
+
+	waitForCounter(t, p.registry, "bifrost_provider_requests_total", 1)
+	waitForCounter(t, p.registry, "bifrost_provider_input_tokens_total", 11)
+	waitForCounter(t, p.registry, "bifrost_provider_output_tokens_total", 7)
+	for _, name := range []string{"bifrost_upstream_requests_total", "bifrost_input_tokens_total", "bifrost_output_tokens_total"} {
+		if got := counterTotal(t, p.registry, name); got != 0 {
+			t.Errorf("%s = %v, want 0 with high-cardinality metrics disabled", name, got)
+		}
+	}
+}
+
 // TestPostLLMHookRequiresStartTime asserts the documented early-return: without startTimeKey in
 // context (PreLLMHook never ran) PostLLMHook records nothing rather than panicking or recording
 // with a bogus latency.
@@ -350,3 +380,348 @@ func TestPushGatewayPushesBifrostButNotRuntimeCollectors(t *testing.T) {
 }
 
 func boolPtr(b bool) *bool { return &b }
+
+func TestProviderQueueDepthCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	queueDepth := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		depths := map[string]float64{}
+		for _, mf := range fams {
+			if mf.GetName() != "bifrost_provider_queue_depth" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				var provider, priority string
+				for _, label := range m.GetLabel() {
+					switch label.GetName() {
+					case "provider":
+						provider = label.GetValue()
+					case "priority":
+						priority = label.GetValue()
+					}
+				}
+				depths[provider+"/"+priority] = m.GetGauge().GetValue()
+			}
+		}
+		return depths
+	}
+
+	if got := queueDepth(); len(got) != 0 {
+		t.Fatalf("expected no queue depth series before a source is set, got %v", got)
+	}
+	p.SetProviderQueueStatsSource(func() map[schemas.ModelProvider]bifrost.ProviderQueueStats {
+		return map[schemas.ModelProvider]bifrost.ProviderQueueStats{
+			schemas.OpenAI: {Depth: 3, BatchDepth: 7, DrainRate: 1.5, AvgWaitMs: 250, Shed: map[schemas.ShedReason]int64{schemas.ShedReasonQueueFull: 4}},
+		}
+	})
+	got := queueDepth()
+	if got["openai/interactive"] != 3 || got["openai/batch"] != 7 {
+		t.Fatalf("unexpected queue depths: %v", got)
+	}
+
+	fams, err := p.GetRegistry().Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	values := map[string]float64{}
+	for _, mf := range fams {
+		for _, m := range mf.GetMetric() {
+			name := mf.GetName()
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "reason" {
+					name += "/" + label.GetValue()
+				}
+			}
+			values[name] = m.GetGauge().GetValue()
+		}
+	}
+	if values["bifrost_provider_queue_drain_rate"] != 1.5 || values["bifrost_provider_queue_wait_seconds"] != 0.25 || values["bifrost_requests_shed/queue_full"] != 4 {
+		t.Fatalf("unexpected drain rate, wait or shed counts: %v", values)
+	}
+}
+
+func TestGatewayLoadCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			if name := mf.GetName(); name == "bifrost_in_flight_requests" || name == "bifrost_sse_connections" {
+				values[name] = mf.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no gateway load series before a source is set, got %v", got)
+	}
+	p.SetGatewayLoadSource(func() GatewayLoad { return GatewayLoad{InFlightRequests: 12, SSEConnections: 5} })
+	if got := gather(); got["bifrost_in_flight_requests"] != 12 || got["bifrost_sse_connections"] != 5 {
+		t.Fatalf("unexpected gateway load: %v", got)
+	}
+}
+
+func TestVirtualKeyInFlightCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			name := mf.GetName()
+			if name != "bifrost_virtual_key_in_flight_requests" && name != "bifrost_virtual_key_max_concurrent_requests" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, lp := range m.GetLabel() {
+					if lp.GetName() == "virtual_key_id" {
+						values[name+"/"+lp.GetValue()] = m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return values
+	}
+
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no virtual key in-flight series before a source is set, got %v", got)
+	}
+	p.SetVirtualKeyInFlightSource(func() []VirtualKeyInFlight {
+		return []VirtualKeyInFlight{
+			{VirtualKeyID: "vk1", VirtualKeyName: "capped", InFlight: 7, MaxConcurrent: 20},
+			{VirtualKeyID: "vk2", VirtualKeyName: "uncapped", InFlight: 3},
+		}
+	})
+	got := gather()
+	if got["bifrost_virtual_key_in_flight_requests/vk1"] != 7 || got["bifrost_virtual_key_in_flight_requests/vk2"] != 3 {
+		t.Fatalf("unexpected in-flight counts: %v", got)
+	}
+	if got["bifrost_virtual_key_max_concurrent_requests/vk1"] != 20 {
+		t.Fatalf("unexpected cap: %v", got)
+	}
+	if _, ok := got["bifrost_virtual_key_max_concurrent_requests/vk2"]; ok {
+		t.Fatalf("keys without a cap should not export one: %v", got)
+	}
+}
+
+func TestLogStoreDualWriteCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			if !strings.HasPrefix(mf.GetName(), "bifrost_logstore_shadow_") {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				key := mf.GetName()
+				for _, lp := range m.GetLabel() {
+					if lp.GetName() == "operation" || lp.GetName() == "kind" {
+						key += "/" + lp.GetValue()
+					}
+				}
+				values[key] = m.GetCounter().GetValue()
+			}
+		}
+		return values
+	}
+
+	p.SetLogStoreDualWriteSource(func() *LogStoreDualWrite { return nil })
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no shadow series when writes are not mirrored, got %v", got)
+	}
+	p.SetLogStoreDualWriteSource(func() *LogStoreDualWrite {
+		return &LogStoreDualWrite{
+			PrimaryType: "sqlite", ShadowType: "postgres",
+			ShadowWrites: 40, ShadowWriteErrors: map[string]int64{"update": 2},
+			Compared: 10, Missing: 1, Mismatched: 3,
+		}
+	})
+	got := gather()
+	if got["bifrost_logstore_shadow_writes_total"] != 40 || got["bifrost_logstore_shadow_write_errors_total/update"] != 2 || got["bifrost_logstore_shadow_compared_total"] != 10 {
+		t.Fatalf("unexpected shadow write counters: %v", got)
+	}
+	if got["bifrost_logstore_shadow_divergence_total/missing"] != 1 || got["bifrost_logstore_shadow_divergence_total/mismatched"] != 3 {
+		t.Fatalf("unexpected divergence counters: %v", got)
+	}
+}
+
+func TestSemanticCacheCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			if !strings.HasPrefix(mf.GetName(), "bifrost_semantic_cache_") {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				key := mf.GetName()
+				for _, lp := range m.GetLabel() {
+					if lp.GetName() == "cache_key" || lp.GetName() == "cache_type" {
+						key += "/" + lp.GetValue()
+					}
+				}
+				values[key] = m.GetCounter().GetValue()
+			}
+		}
+		return values
+	}
+
+	p.SetSemanticCacheStatsSource(func() *SemanticCacheStats { return nil })
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no semantic cache series without the plugin, got %v", got)
+	}
+	p.SetSemanticCacheStatsSource(func() *SemanticCacheStats {
+		return &SemanticCacheStats{
+			SemanticCacheLookups: SemanticCacheLookups{Lookups: 10, DirectHits: 2, SemanticHits: 3, Misses: 5, StoreFailures: 1},
+			ByCacheKey: map[string]SemanticCacheLookups{
+				"tenant-a": {Lookups: 10, DirectHits: 2, SemanticHits: 3, Misses: 5, StoreFailures: 1},
+			},
+		}
+	})
+	got := gather()
+	if got["bifrost_semantic_cache_lookups_total/tenant-a"] != 10 || got["bifrost_semantic_cache_misses_total/tenant-a"] != 5 || got["bifrost_semantic_cache_store_failures_total/tenant-a"] != 1 {
+		t.Fatalf("unexpected lookup counters: %v", got)
+	}
+	if got["bifrost_semantic_cache_hits_total/tenant-a/direct"] != 2 || got["bifrost_semantic_cache_hits_total/tenant-a/semantic"] != 3 {
+		t.Fatalf("unexpected hit counters: %v", got)
+	}
+}
+
+func TestCircuitBreakerCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			if !strings.HasPrefix(mf.GetName(), "bifrost_circuit_breaker_") {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				key := mf.GetName()
+				// Labels come back sorted by name: from, key_id, model, provider, state, to.
+				for _, lp := range m.GetLabel() {
+					if lp.GetValue() != "" {
+						key += "/" + lp.GetValue()
+					}
+				}
+				if m.GetCounter() != nil {
+					values[key] = m.GetCounter().GetValue()
+				} else {
+					values[key] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		return values
+	}
+
+	p.SetCircuitBreakerStatsSource(func() []CircuitBreakerCircuit { return nil })
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no circuit breaker series without the plugin, got %v", got)
+	}
+	p.SetCircuitBreakerStatsSource(func() []CircuitBreakerCircuit {
+		return []CircuitBreakerCircuit{{
+			Provider:    "openai",
+			Model:       "gpt-4o",
+			State:       "open",
+			FailureRate: 0.75,
+			Transitions: []CircuitBreakerTransitions{{From: "closed", To: "open", Count: 2}},
+		}}
+	})
+	got := gather()
+	if got["bifrost_circuit_breaker_state/gpt-4o/openai/open"] != 1 || got["bifrost_circuit_breaker_state/gpt-4o/openai/closed"] != 0 {
+		t.Fatalf("unexpected state gauges: %v", got)
+	}
+	if got["bifrost_circuit_breaker_failure_rate/gpt-4o/openai"] != 0.75 {
+		t.Fatalf("unexpected failure rate: %v", got)
+	}
+	if got["bifrost_circuit_breaker_transitions_total/closed/gpt-4o/openai/open"] != 2 {
+		t.Fatalf("unexpected transition counter: %v", got)
+	}
+}
+
+func TestConfigVersionCollector(t *testing.T) {
+	p := newTestPlugin(t)
+	gather := func() map[string]float64 {
+		fams, err := p.GetRegistry().Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		values := map[string]float64{}
+		for _, mf := range fams {
+			if !strings.HasPrefix(mf.GetName(), "bifrost_config_version_") {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				key := mf.GetName()
+				for _, lp := range m.GetLabel() {
+					key += "/" + lp.GetValue()
+				}
+				values[key] = m.GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+
+	p.SetConfigVersionSource(func() *ConfigVersionStats { return nil })
+	if got := gather(); len(got) != 0 {
+		t.Fatalf("expected no config version series without a config store, got %v", got)
+	}
+	p.SetConfigVersionSource(func() *ConfigVersionStats {
+		return &ConfigVersionStats{State: "diverged", ReplicasByHash: map[string]int{"hash-1": 2, "hash-2": 1}}
+	})
+	got := gather()
+	if got["bifrost_config_version_state/diverged"] != 1 || got["bifrost_config_version_state/in_sync"] != 0 {
+		t.Fatalf("unexpected state gauges: %v", got)
+	}
+	if got["bifrost_config_version_replicas/hash-1"] != 2 || got["bifrost_config_version_replicas/hash-2"] != 1 {
+		t.Fatalf("unexpected replica gauges: %v", got)
+	}
+}
+
+func TestConfigSchemaCoversConfigFields(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal((&PrometheusPlugin{}).ConfigSchema(), &schema); err != nil {
+		t.Fatalf("config schema is not valid JSON: %v", err)
+	}
+	assertCovered := func(typ reflect.Type, properties map[string]json.RawMessage, where string) {
+		for i := 0; i < typ.NumField(); i++ {
+			tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			if tag == "" || tag == "-" {
+				continue
+			}
+			if _, ok := properties[tag]; !ok {
+				t.Errorf("config schema has no %s property %q", where, tag)
+			}
+		}
+	}
+	top := make(map[string]json.RawMessage, len(schema.Properties))
+	for name := range schema.Properties {
+		top[name] = nil
+	}
+	assertCovered(reflect.TypeOf(Config{}), top, "top-level")
+	assertCovered(reflect.TypeOf(PushGatewayConfig{}), schema.Properties["push_gateway"].Properties, "push_gateway")
+}
@@ -0,0 +1,547 @@
+package telemetry
+
+import (
+	"slices"
+	"sync/atomic"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ProviderQueueStatsSource reports the current depth of every provider queue,
+// typically (*bifrost.Bifrost).GetProviderQueueStats.
+type ProviderQueueStatsSource func() map[schemas.ModelProvider]bifrost.ProviderQueueStats
+
+// queueDepthCollector exports bifrost_provider_queue_depth, the queue drain
+// rate and wait, and shed counts at scrape time from the configured source, so they are
+// read when Prometheus asks for them rather than sampled on every request. It
+// exports nothing until a source is set.
+type queueDepthCollector struct {
+	desc      *prometheus.Desc
+	drainDesc *prometheus.Desc
+	waitDesc  *prometheus.Desc
+	shedDesc  *prometheus.Desc
+	source    atomic.Pointer[ProviderQueueStatsSource]
+}
+
+func newQueueDepthCollector() *queueDepthCollector {
+	return &queueDepthCollector{
+		desc: prometheus.NewDesc(
+			"bifrost_provider_queue_depth",
+			"Number of requests waiting for a provider worker, by priority lane. Interactive requests are always dequeued before batch requests.",
+			[]string{"provider", "priority"},
+			nil,
+		),
+		drainDesc: prometheus.NewDesc(
+			"bifrost_provider_queue_drain_rate",
+			"Requests per second provider workers took off the queue over the last 10 seconds. Retry-After on shed requests is estimated from it.",
+			[]string{"provider"},
+			nil,
+		),
+		waitDesc: prometheus.NewDesc(
+			"bifrost_provider_queue_wait_seconds",
+			"Average time requests taken off the queue over the last 10 seconds waited for a provider worker.",
+			[]string{"provider"},
+			nil,
+		),
+		shedDesc: prometheus.NewDesc(
+			"bifrost_requests_shed",
+			"Requests Bifrost turned away with a 429 because a provider queue was saturated, by reason.",
+			[]string{"provider", "reason"},
+			nil,
+		),
+	}
+}
+
+func (c *queueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+	ch <- c.drainDesc
+	ch <- c.waitDesc
+	ch <- c.shedDesc
+}
+
+func (c *queueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	for provider, stats := range (*source)() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(stats.Depth), string(provider), string(schemas.RequestPriorityInteractive))
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(stats.BatchDepth), string(provider), string(schemas.RequestPriorityBatch))
+		ch <- prometheus.MustNewConstMetric(c.drainDesc, prometheus.GaugeValue, stats.DrainRate, string(provider))
+		ch <- prometheus.MustNewConstMetric(c.waitDesc, prometheus.GaugeValue, stats.AvgWaitMs/1000, string(provider))
+		for reason, count := range stats.Shed {
+			ch <- prometheus.MustNewConstMetric(c.shedDesc, prometheus.GaugeValue, float64(count), string(provider), string(reason))
+		}
+	}
+}
+
+// SetProviderQueueStatsSource sets where bifrost_provider_queue_depth reads
+// provider queue depths from. The Bifrost client is created after its plugins,
+// so the transport wires this once the client exists.
+func (p *PrometheusPlugin) SetProviderQueueStatsSource(source ProviderQueueStatsSource) {
+	p.queueDepth.source.Store(&source)
+}
+
+// GatewayLoad is the gateway-wide load exported for autoscaling.
+type GatewayLoad struct {
+	InFlightRequests int64 // requests Bifrost is handling, see (*bifrost.Bifrost).GetInFlightRequests
+	SSEConnections   int64 // SSE responses currently streaming to clients
+}
+
+// GatewayLoadSource reports the current gateway load.
+type GatewayLoadSource func() GatewayLoad
+
+// gatewayLoadCollector exports bifrost_in_flight_requests and
+// bifrost_sse_connections at scrape time from the configured source. Together
+// with the queue gauges they are meant as HPA/KEDA external metrics, so
+// replicas scale on gateway saturation rather than CPU. It exports nothing
+// until a source is set.
+type gatewayLoadCollector struct {
+	inFlightDesc *prometheus.Desc
+	sseDesc      *prometheus.Desc
+	source       atomic.Pointer[GatewayLoadSource]
+}
+
+func newGatewayLoadCollector() *gatewayLoadCollector {
+	return &gatewayLoadCollector{
+		inFlightDesc: prometheus.NewDesc(
+			"bifrost_in_flight_requests",
+			"Requests Bifrost is handling, from admission until the response or stream is returned.",
+			nil,
+			nil,
+		),
+		sseDesc: prometheus.NewDesc(
+			"bifrost_sse_connections",
+			"SSE responses currently streaming to clients.",
+			nil,
+			nil,
+		),
+	}
+}
+
+func (c *gatewayLoadCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.inFlightDesc
+	ch <- c.sseDesc
+}
+
+func (c *gatewayLoadCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	load := (*source)()
+	ch <- prometheus.MustNewConstMetric(c.inFlightDesc, prometheus.GaugeValue, float64(load.InFlightRequests))
+	ch <- prometheus.MustNewConstMetric(c.sseDesc, prometheus.GaugeValue, float64(load.SSEConnections))
+}
+
+// SetGatewayLoadSource sets where bifrost_in_flight_requests and
+// bifrost_sse_connections read from. Like the queue stats source, the
+// transport wires this once the client exists.
+func (p *PrometheusPlugin) SetGatewayLoadSource(source GatewayLoadSource) {
+	p.gatewayLoad.source.Store(&source)
+}
+
+// VirtualKeyInFlight is the number of requests in flight with a virtual key.
+type VirtualKeyInFlight struct {
+	VirtualKeyID   string
+	VirtualKeyName string
+	InFlight       int64
+	MaxConcurrent  int // 0 when the key has no cap
+}
+
+// VirtualKeyInFlightSource reports the requests in flight per virtual key,
+// typically from the governance plugin.
+type VirtualKeyInFlightSource func() []VirtualKeyInFlight
+
+// virtualKeyInFlightCollector exports bifrost_virtual_key_in_flight_requests
+// and bifrost_virtual_key_max_concurrent_requests at scrape time from the
+// configured source. It exports nothing until a source is set.
+type virtualKeyInFlightCollector struct {
+	inFlightDesc *prometheus.Desc
+	maxDesc      *prometheus.Desc
+	source       atomic.Pointer[VirtualKeyInFlightSource]
+}
+
+func newVirtualKeyInFlightCollector() *virtualKeyInFlightCollector {
+	return &virtualKeyInFlightCollector{
+		inFlightDesc: prometheus.NewDesc(
+			"bifrost_virtual_key_in_flight_requests",
+			"Requests in flight with a virtual key on this node, counted against its max_concurrent_requests cap.",
+			[]string{"virtual_key_id", "virtual_key_name"},
+			nil,
+		),
+		maxDesc: prometheus.NewDesc(
+			"bifrost_virtual_key_max_concurrent_requests",
+			"The max_concurrent_requests cap of a virtual key. Only keys with a cap are exported.",
+			[]string{"virtual_key_id", "virtual_key_name"},
+			nil,
+		),
+	}
+}
+
+func (c *virtualKeyInFlightCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.inFlightDesc
+	ch <- c.maxDesc
+}
+
+func (c *virtualKeyInFlightCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	for _, vk := range (*source)() {
+		ch <- prometheus.MustNewConstMetric(c.inFlightDesc, prometheus.GaugeValue, float64(vk.InFlight), vk.VirtualKeyID, vk.VirtualKeyName)
+		if vk.MaxConcurrent > 0 {
+			ch <- prometheus.MustNewConstMetric(c.maxDesc, prometheus.GaugeValue, float64(vk.MaxConcurrent), vk.VirtualKeyID, vk.VirtualKeyName)
+		}
+	}
+}
+
+// SetVirtualKeyInFlightSource sets where bifrost_virtual_key_in_flight_requests
+// reads per virtual key in-flight counts from. The transport wires this to the
+// governance plugin.
+func (p *PrometheusPlugin) SetVirtualKeyInFlightSource(source VirtualKeyInFlightSource) {
+	p.virtualKeyInFlight.source.Store(&source)
+}
+
+// LogStoreDualWrite is the divergence of a shadow log store from the primary
+// while log writes are mirrored during a backend migration.
+type LogStoreDualWrite struct {
+	PrimaryType       string
+	ShadowType        string
+	ShadowWrites      int64
+	ShadowWriteErrors map[string]int64 // by operation
+	Compared          int64            // sampled reads checked against the shadow
+	Missing           int64            // sampled logs absent from the shadow
+	Mismatched        int64            // sampled logs that differ in the shadow
+}
+
+// LogStoreDualWriteSource reports the dual-write divergence, or nil when
+// writes are not mirrored.
+type LogStoreDualWriteSource func() *LogStoreDualWrite
+
+// logStoreDualWriteCollector exports the bifrost_logstore_shadow_* counters at
+// scrape time from the configured source. It exports nothing until a source is
+// set and the log store mirrors writes.
+type logStoreDualWriteCollector struct {
+	writesDesc     *prometheus.Desc
+	errorsDesc     *prometheus.Desc
+	comparedDesc   *prometheus.Desc
+	divergenceDesc *prometheus.Desc
+	source         atomic.Pointer[LogStoreDualWriteSource]
+}
+
+func newLogStoreDualWriteCollector() *logStoreDualWriteCollector {
+	return &logStoreDualWriteCollector{
+		writesDesc: prometheus.NewDesc(
+			"bifrost_logstore_shadow_writes_total",
+			"Log store writes mirrored to the shadow store during a backend migration.",
+			[]string{"primary", "shadow"},
+			nil,
+		),
+		errorsDesc: prometheus.NewDesc(
+			"bifrost_logstore_shadow_write_errors_total",
+			"Mirrored log store writes the shadow store failed, by operation. The shadow lacks these until a backfill copies them.",
+			[]string{"primary", "shadow", "operation"},
+			nil,
+		),
+		comparedDesc: prometheus.NewDesc(
+			"bifrost_logstore_shadow_compared_total",
+			"Sampled log reads compared between the primary and the shadow store.",
+			[]string{"primary", "shadow"},
+			nil,
+		),
+		divergenceDesc: prometheus.NewDesc(
+			"bifrost_logstore_shadow_divergence_total",
+			"Sampled logs that were missing from the shadow store or differed in status, usage or cost, by kind.",
+			[]string{"primary", "shadow", "kind"},
+			nil,
+		),
+	}
+}
+
+func (c *logStoreDualWriteCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.writesDesc
+	ch <- c.errorsDesc
+	ch <- c.comparedDesc
+	ch <- c.divergenceDesc
+}
+
+func (c *logStoreDualWriteCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	stats := (*source)()
+	if stats == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.writesDesc, prometheus.CounterValue, float64(stats.ShadowWrites), stats.PrimaryType, stats.ShadowType)
+	for operation, count := range stats.ShadowWriteErrors {
+		ch <- prometheus.MustNewConstMetric(c.errorsDesc, prometheus.CounterValue, float64(count), stats.PrimaryType, stats.ShadowType, operation)
+	}
+	ch <- prometheus.MustNewConstMetric(c.comparedDesc, prometheus.CounterValue, float64(stats.Compared), stats.PrimaryType, stats.ShadowType)
+	ch <- prometheus.MustNewConstMetric(c.divergenceDesc, prometheus.CounterValue, float64(stats.Missing), stats.PrimaryType, stats.ShadowType, "missing")
+	ch <- prometheus.MustNewConstMetric(c.divergenceDesc, prometheus.CounterValue, float64(stats.Mismatched), stats.PrimaryType, stats.ShadowType, "mismatched")
+}
+
+// SetLogStoreDualWriteSource sets where the bifrost_logstore_shadow_* counters
+// read from. The transport wires this when the log store mirrors writes.
+func (p *PrometheusPlugin) SetLogStoreDualWriteSource(source LogStoreDualWriteSource) {
+	p.logStoreDualWrite.source.Store(&source)
+}
+
+// SemanticCacheLookups counts semantic cache lookups by outcome.
+type SemanticCacheLookups struct {
+	Lookups       int64
+	DirectHits    int64
+	SemanticHits  int64
+	Misses        int64
+	StoreFailures int64
+}
+
+// SemanticCacheStats is the semantic cache's lookup counters, in total and by
+// cache key.
+type SemanticCacheStats struct {
+	SemanticCacheLookups
+	ByCacheKey map[string]SemanticCacheLookups
+}
+
+// SemanticCacheStatsSource reports the semantic cache's lookup counters, or
+// nil when the semantic cache plugin is not loaded.
+type SemanticCacheStatsSource func() *SemanticCacheStats
+
+// semanticCacheCollector exports the bifrost_semantic_cache_* counters at
+// scrape time from the configured source. Series carry the cache key, which
+// the semantic cache caps at a fixed number of distinct keys.
+type semanticCacheCollector struct {
+	lookupsDesc       *prometheus.Desc
+	hitsDesc          *prometheus.Desc
+	missesDesc        *prometheus.Desc
+	storeFailuresDesc *prometheus.Desc
+	source            atomic.Pointer[SemanticCacheStatsSource]
+}
+
+func newSemanticCacheCollector() *semanticCacheCollector {
+	return &semanticCacheCollector{
+		lookupsDesc: prometheus.NewDesc(
+			"bifrost_semantic_cache_lookups_total",
+			"Requests looked up in the semantic cache, by cache key.",
+			[]string{"cache_key"},
+			nil,
+		),
+		hitsDesc: prometheus.NewDesc(
+			"bifrost_semantic_cache_hits_total",
+			"Semantic cache lookups served from cache, by cache key and cache type (direct or semantic).",
+			[]string{"cache_key", "cache_type"},
+			nil,
+		),
+		missesDesc: prometheus.NewDesc(
+			"bifrost_semantic_cache_misses_total",
+			"Semantic cache lookups that fell through to the provider, by cache key.",
+			[]string{"cache_key"},
+			nil,
+		),
+		storeFailuresDesc: prometheus.NewDesc(
+			"bifrost_semantic_cache_store_failures_total",
+			"Semantic cache reads and writes the vector store failed, by cache key.",
+			[]string{"cache_key"},
+			nil,
+		),
+	}
+}
+
+func (c *semanticCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lookupsDesc
+	ch <- c.hitsDesc
+	ch <- c.missesDesc
+	ch <- c.storeFailuresDesc
+}
+
+func (c *semanticCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	stats := (*source)()
+	if stats == nil {
+		return
+	}
+	for cacheKey, lookups := range stats.ByCacheKey {
+		ch <- prometheus.MustNewConstMetric(c.lookupsDesc, prometheus.CounterValue, float64(lookups.Lookups), cacheKey)
+		ch <- prometheus.MustNewConstMetric(c.hitsDesc, prometheus.CounterValue, float64(lookups.DirectHits), cacheKey, "direct")
+		ch <- prometheus.MustNewConstMetric(c.hitsDesc, prometheus.CounterValue, float64(lookups.SemanticHits), cacheKey, "semantic")
+		ch <- prometheus.MustNewConstMetric(c.missesDesc, prometheus.CounterValue, float64(lookups.Misses), cacheKey)
+		ch <- prometheus.MustNewConstMetric(c.storeFailuresDesc, prometheus.CounterValue, float64(lookups.StoreFailures), cacheKey)
+	}
+}
+
+// SetSemanticCacheStatsSource sets where the bifrost_semantic_cache_* counters
+// read from. The transport wires this to the semantic cache plugin.
+func (p *PrometheusPlugin) SetSemanticCacheStatsSource(source SemanticCacheStatsSource) {
+	p.semanticCache.source.Store(&source)
+}
+
+// circuitStates are the states bifrost_circuit_breaker_state has a series for.
+var circuitStates = []string{"closed", "open", "half_open"}
+
+// CircuitBreakerTransitions counts one kind of state change of a circuit.
+type CircuitBreakerTransitions struct {
+	From  string
+	To    string
+	Count int64
+}
+
+// CircuitBreakerCircuit is the state of one circuit breaker circuit. Model and
+// KeyID are empty unless the breaker tracks circuits at that granularity.
+type CircuitBreakerCircuit struct {
+	Provider    string
+	Model       string
+	KeyID       string
+	State       string
+	FailureRate float64
+	Transitions []CircuitBreakerTransitions
+}
+
+// CircuitBreakerStatsSource reports the circuit breaker's circuits, or nil
+// when the circuit breaker plugin is not loaded.
+type CircuitBreakerStatsSource func() []CircuitBreakerCircuit
+
+// circuitBreakerCollector exports the bifrost_circuit_breaker_* metrics at
+// scrape time from the configured source.
+type circuitBreakerCollector struct {
+	stateDesc       *prometheus.Desc
+	failureRateDesc *prometheus.Desc
+	transitionsDesc *prometheus.Desc
+	source          atomic.Pointer[CircuitBreakerStatsSource]
+}
+
+func newCircuitBreakerCollector() *circuitBreakerCollector {
+	circuitLabels := []string{"provider", "model", "key_id"}
+	return &circuitBreakerCollector{
+		stateDesc: prometheus.NewDesc(
+			"bifrost_circuit_breaker_state",
+			"1 for the state each circuit is in (closed, open or half_open), 0 for the others.",
+			append(slices.Clone(circuitLabels), "state"),
+			nil,
+		),
+		failureRateDesc: prometheus.NewDesc(
+			"bifrost_circuit_breaker_failure_rate",
+			"Fraction of the requests in each circuit's window that failed.",
+			circuitLabels,
+			nil,
+		),
+		transitionsDesc: prometheus.NewDesc(
+			"bifrost_circuit_breaker_transitions_total",
+			"Circuit state changes, by circuit and from/to state.",
+			append(slices.Clone(circuitLabels), "from", "to"),
+			nil,
+		),
+	}
+}
+
+func (c *circuitBreakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.stateDesc
+	ch <- c.failureRateDesc
+	ch <- c.transitionsDesc
+}
+
+func (c *circuitBreakerCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	for _, circuit := range (*source)() {
+		for _, state := range circuitStates {
+			value := 0.0
+			if circuit.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.stateDesc, prometheus.GaugeValue, value, circuit.Provider, circuit.Model, circuit.KeyID, state)
+		}
+		ch <- prometheus.MustNewConstMetric(c.failureRateDesc, prometheus.GaugeValue, circuit.FailureRate, circuit.Provider, circuit.Model, circuit.KeyID)
+		for _, transitions := range circuit.Transitions {
+			ch <- prometheus.MustNewConstMetric(c.transitionsDesc, prometheus.CounterValue, float64(transitions.Count), circuit.Provider, circuit.Model, circuit.KeyID, transitions.From, transitions.To)
+		}
+	}
+}
+
+// SetCircuitBreakerStatsSource sets where the bifrost_circuit_breaker_*
+// metrics read from. The transport wires this to the circuit breaker plugin.
+func (p *PrometheusPlugin) SetCircuitBreakerStatsSource(source CircuitBreakerStatsSource) {
+	p.circuitBreaker.source.Store(&source)
+}
+
+// configVersionStates are the states bifrost_config_version_state has a series for.
+var configVersionStates = []string{"unknown", "in_sync", "converging", "diverged"}
+
+// ConfigVersionStats is how this replica's config compares with the other
+// replicas sharing its config store.
+type ConfigVersionStats struct {
+	State string
+	// ReplicasByHash counts the live replicas serving each config hash.
+	ReplicasByHash map[string]int
+}
+
+// ConfigVersionStatsSource reports the config version comparison, or nil when
+// there is no config store to compare through.
+type ConfigVersionStatsSource func() *ConfigVersionStats
+
+// configVersionCollector exports the bifrost_config_version_* metrics at
+// scrape time from the configured source.
+type configVersionCollector struct {
+	stateDesc    *prometheus.Desc
+	replicasDesc *prometheus.Desc
+	source       atomic.Pointer[ConfigVersionStatsSource]
+}
+
+func newConfigVersionCollector() *configVersionCollector {
+	return &configVersionCollector{
+		stateDesc: prometheus.NewDesc(
+			"bifrost_config_version_state",
+			"1 for how this replica's config compares with the other replicas' (unknown, in_sync, converging or diverged), 0 for the others.",
+			[]string{"state"},
+			nil,
+		),
+		replicasDesc: prometheus.NewDesc(
+			"bifrost_config_version_replicas",
+			"Live replicas serving each config hash, as seen by this replica.",
+			[]string{"config_hash"},
+			nil,
+		),
+	}
+}
+
+func (c *configVersionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.stateDesc
+	ch <- c.replicasDesc
+}
+
+func (c *configVersionCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil || *source == nil {
+		return
+	}
+	stats := (*source)()
+	if stats == nil {
+		return
+	}
+	for _, state := range configVersionStates {
+		value := 0.0
+		if stats.State == state {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.stateDesc, prometheus.GaugeValue, value, state)
+	}
+	for hash, count := range stats.ReplicasByHash {
+		ch <- prometheus.MustNewConstMetric(c.replicasDesc, prometheus.GaugeValue, float64(count), hash)
+	}
+}
+
+// SetConfigVersionSource sets where the bifrost_config_version_* metrics read
+// from. The transport wires this to the config version tracker.
+func (p *PrometheusPlugin) SetConfigVersionSource(source ConfigVersionStatsSource) {
+	p.configVersion.source.Store(&source)
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// providerRollup holds the low-cardinality aggregates of the upstream metrics,
+// labelled by provider only. They are recorded alongside the detailed
+// bifrost_* series, whose model, virtual key and custom labels can multiply
+// into a very large number of series, and are the only upstream series left
+// when those are disabled. They keep long-term dashboards and downsampled
+// storage cheap regardless of how many models and virtual keys a fleet has.
+type providerRollup struct {
+	requestsTotal            *prometheus.CounterVec
+	errorsTotal              *prometheus.CounterVec
+	latencySeconds           *prometheus.HistogramVec
+	firstTokenLatencySeconds *prometheus.HistogramVec
+	inputTokensTotal         *prometheus.CounterVec
+	outputTokensTotal        *prometheus.CounterVec
+	costTotal                *prometheus.CounterVec
+}
+
+func newProviderRollup(factory promauto.Factory) *providerRollup {
+	labels := []string{"provider"}
+	return &providerRollup{
+		requestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "bifrost_provider_requests_total",
+			Help: "Total number of requests forwarded to upstream providers, by provider only. Aggregate of bifrost_upstream_requests_total.",
+		}, labels),
+		errorsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "bifrost_provider_errors_total",
+			Help: "Total number of failed upstream requests, by provider only. Aggregate of bifrost_error_requests_total.",
+		}, labels),
+		latencySeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bifrost_provider_latency_seconds",
+			Help:    "Latency of requests forwarded to upstream providers, by provider only. Aggregate of bifrost_upstream_latency_seconds.",
+			Buckets: upstreamLatencyBuckets,
+		}, labels),
+		firstTokenLatencySeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bifrost_provider_first_token_latency_seconds",
+			Help:    "Latency of the first token of a stream response, by provider only. Aggregate of bifrost_stream_first_token_latency_seconds.",
+			Buckets: firstTokenLatencyBuckets,
+		}, labels),
+		inputTokensTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "bifrost_provider_input_tokens_total",
+			Help: "Total number of input tokens, by provider only. Aggregate of bifrost_input_tokens_total.",
+		}, labels),
+		outputTokensTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "bifrost_provider_output_tokens_total",
+			Help: "Total number of output tokens, by provider only. Aggregate of bifrost_output_tokens_total.",
+		}, labels),
+		costTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "bifrost_provider_cost_total",
+			Help: "Total cost in USD of upstream requests, by provider only. Aggregate of bifrost_cost_total.",
+		}, labels),
+	}
+}
+
+// record rolls up one completed request.
+func (r *providerRollup) record(provider string, success bool, latencySeconds float64, inputTokens, outputTokens int, cost float64) {
+	r.requestsTotal.WithLabelValues(provider).Inc()
+	if !success {
+		r.errorsTotal.WithLabelValues(provider).Inc()
+	}
+	r.latencySeconds.WithLabelValues(provider).Observe(latencySeconds)
+	r.inputTokensTotal.WithLabelValues(provider).Add(float64(inputTokens))
+	r.outputTokensTotal.WithLabelValues(provider).Add(float64(outputTokens))
+	if cost > 0 {
+		r.costTotal.WithLabelValues(provider).Add(cost)
+	}
+}
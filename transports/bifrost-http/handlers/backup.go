@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maximhq/bifrost/framework/sqliteconn"
+	"github.com/valyala/fasthttp"
+)
+
+// sqliteBackuper is implemented by the config and log stores; only SQLite-backed stores
+// can be backed up.
+type sqliteBackuper interface {
+	BackupSQLite(ctx context.Context, destPath string) error
+}
+
+// backupFile is a finished backup being streamed to the client; it is deleted once
+// fasthttp closes the body stream.
+type backupFile struct {
+	*os.File
+}
+
+func (f backupFile) Close() error {
+	err := f.File.Close()
+	_ = os.Remove(f.Name())
+	return err
+}
+
+// backupDatabase handles POST /api/admin/backup?store=config|logs - Stream a consistent
+// snapshot of a SQLite store. The snapshot is taken with SQLite's online backup API while
+// the gateway keeps serving, so single-node installs no longer need to stop the process to
+// copy database files. store defaults to config.
+func (h *RuntimeHandler) backupDatabase(ctx *fasthttp.RequestCtx) {
+	storeName := string(ctx.QueryArgs().Peek("store"))
+	var store any
+	switch storeName {
+	case "", "config":
+		storeName = "config"
+		store = h.config.ConfigStore
+	case "logs":
+		store = h.config.LogsStore
+	default:
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("unknown store %q: expected config or logs", storeName))
+		return
+	}
+	backuper, ok := store.(sqliteBackuper)
+	if !ok {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("the %s store is not a sqlite store", storeName))
+		return
+	}
+
+	tmp, err := os.CreateTemp("", "bifrost-backup-*.db")
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to create backup file: %v", err))
+		return
+	}
+	path := tmp.Name()
+	_ = tmp.Close()
+	if err := backuper.BackupSQLite(ctx, path); err != nil {
+		_ = os.Remove(path)
+		if errors.Is(err, sqliteconn.ErrNotSQLite) {
+			SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("the %s store is not a sqlite store", storeName))
+			return
+		}
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to back up the %s store: %v", storeName, err))
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		_ = os.Remove(path)
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to read backup: %v", err))
+		return
+	}
+	info, err := file.Stat()
+	if err != nil {
+		backupFile{file}.Close()
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to read backup: %v", err))
+		return
+	}
+
+	filename := fmt.Sprintf("bifrost-%s-%s.db", storeName, time.Now().UTC().Format("20060102T150405Z"))
+	ctx.Response.Header.Set("Content-Type", "application/vnd.sqlite3")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.SetBodyStream(backupFile{file}, int(info.Size()))
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestBackupStreamsSQLiteSnapshot(t *testing.T) {
+	ctx := context.Background()
+	logsStore, err := logstore.NewLogStore(ctx, &logstore.Config{
+		Enabled: true,
+		Type:    logstore.LogStoreTypeSQLite,
+		Config:  &logstore.SQLiteConfig{Path: filepath.Join(t.TempDir(), "logs.db")},
+	}, testLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { logsStore.Close(context.Background()) })
+	h := NewRuntimeHandler(&lib.Config{LogsStore: logsStore}, nil, nil)
+
+	backup := func(uri string) *fasthttp.RequestCtx {
+		var req fasthttp.Request
+		req.SetRequestURI(uri)
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Init(&req, nil, nil)
+		h.backupDatabase(reqCtx)
+		return reqCtx
+	}
+
+	reqCtx := backup("/api/admin/backup?store=logs")
+	require.Equal(t, fasthttp.StatusOK, reqCtx.Response.StatusCode(), string(reqCtx.Response.Body()))
+	require.Contains(t, string(reqCtx.Response.Header.Peek("Content-Disposition")), "bifrost-logs-")
+	require.True(t, bytes.HasPrefix(reqCtx.Response.Body(), []byte("SQLite format 3\x00")), "expected a sqlite database file")
+
+	// No config store is configured, and unknown stores are rejected.
+	for _, uri := range []string{"/api/admin/backup", "/api/admin/backup?store=vectors"} {
+		require.Equal(t, fasthttp.StatusBadRequest, backup(uri).Response.StatusCode(), uri)
+	}
+}
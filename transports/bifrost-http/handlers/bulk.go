@@ -0,0 +1,464 @@
+// Package handlers provides HTTP request handlers for the Bifrost HTTP transport.
+// This file contains the bulk administrative operations API.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/fasthttp/router"
+	"github.com/google/uuid"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/plugins"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+	"gorm.io/gorm"
+)
+
+// Bulk operation names recorded in the audit log.
+const (
+	BulkOperationDisableProviderKeys = "disable_provider_keys"
+	BulkOperationEnableProviderKeys  = "enable_provider_keys"
+	BulkOperationApplyRoutingRule    = "apply_routing_rule"
+	BulkOperationUpdatePlugins       = "update_plugins"
+)
+
+// BulkManager applies committed bulk changes to the running gateway.
+type BulkManager interface {
+	PluginsLoader
+	OnKeyUpdated(ctx context.Context, provider schemas.ModelProvider, key schemas.Key) error
+	ReloadRoutingRule(ctx context.Context, id string) error
+}
+
+// BulkHandler serves batch endpoints for administrative changes that scripts
+// would otherwise make with hundreds of single-item calls. Each call validates
+// every item first, writes all of them in one transaction, and stores a single
+// audit record with a result per item.
+type BulkHandler struct {
+	inMemoryStore *lib.Config
+	configStore   configstore.ConfigStore
+	manager       BulkManager
+}
+
+// NewBulkHandler creates a new BulkHandler. It requires a config store.
+func NewBulkHandler(manager BulkManager, inMemoryStore *lib.Config, configStore configstore.ConfigStore) (*BulkHandler, error) {
+	if configStore == nil {
+		return nil, fmt.Errorf("config store is required")
+	}
+	return &BulkHandler{
+		inMemoryStore: inMemoryStore,
+		configStore:   configStore,
+		manager:       manager,
+	}, nil
+}
+
+// BulkProviderKeysRequest is the optional request body for enabling or
+// disabling every key of a provider.
+type BulkProviderKeysRequest struct {
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// BulkApplyRoutingRuleRequest is the request body for applying a routing rule
+// to a list of virtual keys.
+type BulkApplyRoutingRuleRequest struct {
+	VirtualKeyIDs []string `json:"virtual_key_ids"`
+	CreatedBy     string   `json:"created_by,omitempty"`
+}
+
+// BulkUpdatePluginsRequest is the request body for updating several plugins at once.
+type BulkUpdatePluginsRequest struct {
+	Plugins []struct {
+		Name    string         `json:"name"`
+		Enabled *bool          `json:"enabled,omitempty"` // nil keeps the current state
+		Config  map[string]any `json:"config"`            // merged over the stored config
+	} `json:"plugins"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// RegisterRoutes registers the bulk operation routes
+func (h *BulkHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/bulk/operations", lib.ChainMiddlewares(h.listBulkOperations, middlewares...))
+	r.POST("/api/bulk/providers/{provider}/keys/disable", lib.ChainMiddlewares(h.setProviderKeysEnabled(false), middlewares...))
+	r.POST("/api/bulk/providers/{provider}/keys/enable", lib.ChainMiddlewares(h.setProviderKeysEnabled(true), middlewares...))
+	r.POST("/api/bulk/routing-rules/{rule_id}/apply", lib.ChainMiddlewares(h.applyRoutingRule, middlewares...))
+	r.POST("/api/bulk/plugins", lib.ChainMiddlewares(h.updatePlugins, middlewares...))
+}
+
+// listBulkOperations returns the audit records of past bulk operations, newest first.
+func (h *BulkHandler) listBulkOperations(ctx *fasthttp.RequestCtx) {
+	limit := 50
+	if raw := string(ctx.QueryArgs().Peek("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > 1000 {
+			SendError(ctx, fasthttp.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	operations, err := h.configStore.GetBulkOperations(ctx, limit)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to list bulk operations: %v", err))
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"operations": operations,
+		"count":      len(operations),
+	})
+}
+
+// finishBulkOperation stores the audit record and sends it as the response:
+// 200 when applied, 400 when rejected by validation and 500 when the
+// transaction failed. A failed audit write is logged rather than reported,
+// since the change itself has already been committed or rolled back.
+func (h *BulkHandler) finishBulkOperation(ctx *fasthttp.RequestCtx, operation *configstoreTables.TableBulkOperation) {
+	operation.ID = uuid.NewString()
+	operation.CreatedBy = strings.TrimSpace(operation.CreatedBy)
+	operation.CreatedAt = time.Now().UTC()
+	if err := h.configStore.CreateBulkOperation(ctx, operation); err != nil {
+		logger.Error("failed to store audit record for bulk operation %s on %s: %v", operation.Operation, operation.Target, err)
+	}
+	logger.Info("bulk operation %s on %s by %q: %s (%d items)", operation.Operation, operation.Target, operation.CreatedBy, operation.Status, len(operation.Items))
+	status := fasthttp.StatusOK
+	switch operation.Status {
+	case configstoreTables.BulkOperationStatusRejected:
+		status = fasthttp.StatusBadRequest
+	case configstoreTables.BulkOperationStatusFailed:
+		status = fasthttp.StatusInternalServerError
+	}
+	SendJSONWithStatus(ctx, operation, status)
+}
+
+// rejectOrFail settles a bulk operation that did not apply: items that were
+// not themselves the problem are marked skipped, since nothing was written.
+func rejectOrFail(operation *configstoreTables.TableBulkOperation, status string) {
+	operation.Status = status
+	for i := range operation.Items {
+		if operation.Items[i].Status != configstoreTables.BulkItemStatusFailed {
+			operation.Items[i].Status = configstoreTables.BulkItemStatusSkipped
+		}
+	}
+}
+
+// hasFailedItems reports whether any item of a bulk operation failed validation.
+func hasFailedItems(items []configstoreTables.BulkOperationItem) bool {
+	return slices.ContainsFunc(items, func(item configstoreTables.BulkOperationItem) bool {
+		return item.Status == configstoreTables.BulkItemStatusFailed
+	})
+}
+
+// setProviderKeysEnabled returns the handler that disables (or re-enables)
+// every key of a provider, e.g. when its credentials leak or it is being retired.
+func (h *BulkHandler) setProviderKeysEnabled(enabled bool) fasthttp.RequestHandler {
+	operationName := BulkOperationDisableProviderKeys
+	if enabled {
+		operationName = BulkOperationEnableProviderKeys
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		provider, err := getProviderFromCtx(ctx)
+		if err != nil {
+			SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid provider: %v", err))
+			return
+		}
+		var req BulkProviderKeysRequest
+		if len(ctx.PostBody()) > 0 {
+			if err := sonic.Unmarshal(ctx.PostBody(), &req); err != nil {
+				SendError(ctx, fasthttp.StatusBadRequest, "Invalid request payload")
+				return
+			}
+		}
+		providerConfig, err := h.inMemoryStore.GetProviderConfigRaw(provider)
+		if err != nil {
+			if errors.Is(err, lib.ErrNotFound) {
+				SendError(ctx, fasthttp.StatusNotFound, fmt.Sprintf("Provider not found: %v", err))
+				return
+			}
+			SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to get provider config: %v", err))
+			return
+		}
+		if providerConfig.CustomProviderConfig != nil && providerConfig.CustomProviderConfig.IsKeyLess {
+			SendError(ctx, fasthttp.StatusBadRequest, "Cannot update keys on a keyless provider")
+			return
+		}
+
+		operation := &configstoreTables.TableBulkOperation{
+			Operation: operationName,
+			Target:    string(provider),
+			CreatedBy: req.CreatedBy,
+		}
+		changed, unchanged, err := h.inMemoryStore.SetProviderKeysEnabled(ctx, provider, enabled)
+		if err != nil {
+			for _, key := range providerConfig.Keys {
+				operation.Items = append(operation.Items, configstoreTables.BulkOperationItem{ID: key.ID})
+			}
+			rejectOrFail(operation, configstoreTables.BulkOperationStatusFailed)
+			logger.Warn("bulk %s for provider %s failed: %v", operationName, provider, err)
+			h.finishBulkOperation(ctx, operation)
+			return
+		}
+		for _, key := range changed {
+			operation.Items = append(operation.Items, configstoreTables.BulkOperationItem{ID: key.ID, Status: configstoreTables.BulkItemStatusUpdated})
+			if err := h.manager.OnKeyUpdated(ctx, provider, key); err != nil {
+				logger.Warn("Catalog refresh failed for provider %s after key update: %v", provider, err)
+			}
+		}
+		for _, id := range unchanged {
+			operation.Items = append(operation.Items, configstoreTables.BulkOperationItem{ID: id, Status: configstoreTables.BulkItemStatusUnchanged})
+		}
+		operation.Status = configstoreTables.BulkOperationStatusApplied
+		h.finishBulkOperation(ctx, operation)
+	}
+}
+
+// applyRoutingRule copies a routing rule onto each listed virtual key as a
+// virtual_key-scoped rule with the same name. A virtual key that already has a
+// rule by that name gets it updated, so re-running the call is safe.
+func (h *BulkHandler) applyRoutingRule(ctx *fasthttp.RequestCtx) {
+	ruleID := ctx.UserValue("rule_id").(string)
+	var req BulkApplyRoutingRuleRequest
+	if err := sonic.Unmarshal(ctx.PostBody(), &req); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if len(req.VirtualKeyIDs) == 0 {
+		SendError(ctx, fasthttp.StatusBadRequest, "virtual_key_ids must not be empty")
+		return
+	}
+	source, err := h.configStore.GetRoutingRule(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, configstore.ErrNotFound) {
+			SendError(ctx, fasthttp.StatusNotFound, "Routing rule not found")
+			return
+		}
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to get routing rule: %v", err))
+		return
+	}
+	existingRules, err := h.configStore.GetRoutingRules(ctx)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to list routing rules: %v", err))
+		return
+	}
+
+	operation := &configstoreTables.TableBulkOperation{
+		Operation: BulkOperationApplyRoutingRule,
+		Target:    source.ID,
+		CreatedBy: req.CreatedBy,
+	}
+	var rules []*configstoreTables.TableRoutingRule
+	for _, vkID := range req.VirtualKeyIDs {
+		vkID = strings.TrimSpace(vkID)
+		if vkID == "" || slices.ContainsFunc(operation.Items, func(item configstoreTables.BulkOperationItem) bool { return item.ID == vkID }) {
+			continue
+		}
+		rule, itemErr := h.routingRuleForVirtualKey(ctx, source, vkID, existingRules)
+		item := configstoreTables.BulkOperationItem{ID: vkID}
+		switch {
+		case itemErr != nil:
+			item.Status = configstoreTables.BulkItemStatusFailed
+			item.Error = itemErr.Error()
+		case rule.CreatedAt.IsZero():
+			item.Status = configstoreTables.BulkItemStatusCreated
+		default:
+			item.Status = configstoreTables.BulkItemStatusUpdated
+		}
+		operation.Items = append(operation.Items, item)
+		rules = append(rules, rule)
+	}
+	if hasFailedItems(operation.Items) {
+		rejectOrFail(operation, configstoreTables.BulkOperationStatusRejected)
+		h.finishBulkOperation(ctx, operation)
+		return
+	}
+
+	if err := h.configStore.ExecuteTransaction(ctx, func(tx *gorm.DB) error {
+		for i, rule := range rules {
+			var err error
+			if operation.Items[i].Status == configstoreTables.BulkItemStatusCreated {
+				err = h.configStore.CreateRoutingRule(ctx, rule, tx)
+			} else {
+				err = h.configStore.UpdateRoutingRule(ctx, rule, tx)
+			}
+			if err != nil {
+				operation.Items[i].Status = configstoreTables.BulkItemStatusFailed
+				operation.Items[i].Error = err.Error()
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		rejectOrFail(operation, configstoreTables.BulkOperationStatusFailed)
+		h.finishBulkOperation(ctx, operation)
+		return
+	}
+	for i, rule := range rules {
+		if err := h.manager.ReloadRoutingRule(ctx, rule.ID); err != nil {
+			operation.Items[i].Error = fmt.Sprintf("saved but failed to reload in memory: %v, please restart bifrost to sync with the database", err)
+		}
+	}
+	operation.Status = configstoreTables.BulkOperationStatusApplied
+	h.finishBulkOperation(ctx, operation)
+}
+
+// routingRuleForVirtualKey builds the copy of source scoped to vkID, reusing
+// the ID of a rule with the same name already on that virtual key. The copy
+// has a zero CreatedAt when it is new.
+func (h *BulkHandler) routingRuleForVirtualKey(ctx context.Context, source *configstoreTables.TableRoutingRule, vkID string, existingRules []configstoreTables.TableRoutingRule) (*configstoreTables.TableRoutingRule, error) {
+	if _, err := h.configStore.GetVirtualKey(ctx, vkID); err != nil {
+		if errors.Is(err, configstore.ErrNotFound) {
+			return nil, fmt.Errorf("virtual key not found")
+		}
+		return nil, fmt.Errorf("failed to verify virtual key: %w", err)
+	}
+	rule := &configstoreTables.TableRoutingRule{
+		ID:              uuid.NewString(),
+		Name:            source.Name,
+		Description:     source.Description,
+		Enabled:         source.Enabled,
+		CelExpression:   source.CelExpression,
+		ParsedFallbacks: source.ParsedFallbacks,
+		ParsedQuery:     source.ParsedQuery,
+		Scope:           "virtual_key",
+		ScopeID:         &vkID,
+		ChainRule:       source.ChainRule,
+		Priority:        source.Priority,
+
+		Type:                   source.Type,
+		ParsedEmbeddingBuckets: source.ParsedEmbeddingBuckets,
+	}
+	for _, target := range source.Targets {
+		target.RuleID = ""
+		rule.Targets = append(rule.Targets, target)
+	}
+	for _, existing := range existingRules {
+		if existing.Scope != "virtual_key" || existing.ScopeID == nil || *existing.ScopeID != vkID {
+			continue
+		}
+		if existing.Name == source.Name {
+			rule.ID = existing.ID
+			rule.CreatedAt = existing.CreatedAt
+		}
+	}
+	for _, existing := range existingRules {
+		if existing.Scope == "virtual_key" && existing.ScopeID != nil && *existing.ScopeID == vkID &&
+			existing.ID != rule.ID && existing.Priority == rule.Priority {
+			return nil, fmt.Errorf("routing rule %q already uses priority %d on this virtual key", existing.Name, rule.Priority)
+		}
+	}
+	return rule, nil
+}
+
+// updatePlugins merges new config into several plugins in one transaction,
+// then reloads each enabled plugin and stops each disabled one.
+func (h *BulkHandler) updatePlugins(ctx *fasthttp.RequestCtx) {
+	var req BulkUpdatePluginsRequest
+	if err := sonic.Unmarshal(ctx.PostBody(), &req); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if len(req.Plugins) == 0 {
+		SendError(ctx, fasthttp.StatusBadRequest, "plugins must not be empty")
+		return
+	}
+
+	operation := &configstoreTables.TableBulkOperation{
+		Operation: BulkOperationUpdatePlugins,
+		Target:    "plugins",
+		CreatedBy: req.CreatedBy,
+	}
+	var updated []*configstoreTables.TablePlugin
+	for _, update := range req.Plugins {
+		item := configstoreTables.BulkOperationItem{ID: update.Name, Status: configstoreTables.BulkItemStatusUpdated}
+		plugin, err := h.mergedPlugin(ctx, update.Name, update.Enabled, update.Config)
+		if err != nil {
+			item.Status = configstoreTables.BulkItemStatusFailed
+			item.Error = err.Error()
+		} else if slices.ContainsFunc(updated, func(p *configstoreTables.TablePlugin) bool { return p != nil && p.Name == update.Name }) {
+			item.Status = configstoreTables.BulkItemStatusFailed
+			item.Error = "plugin is listed more than once"
+		}
+		operation.Items = append(operation.Items, item)
+		updated = append(updated, plugin)
+	}
+	if hasFailedItems(operation.Items) {
+		rejectOrFail(operation, configstoreTables.BulkOperationStatusRejected)
+		h.finishBulkOperation(ctx, operation)
+		return
+	}
+
+	if err := h.configStore.ExecuteTransaction(ctx, func(tx *gorm.DB) error {
+		for i, plugin := range updated {
+			if err := h.configStore.UpdatePlugin(ctx, plugin, tx); err != nil {
+				operation.Items[i].Status = configstoreTables.BulkItemStatusFailed
+				operation.Items[i].Error = err.Error()
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		rejectOrFail(operation, configstoreTables.BulkOperationStatusFailed)
+		h.finishBulkOperation(ctx, operation)
+		return
+	}
+	for i, plugin := range updated {
+		var err error
+		if plugin.Enabled {
+			err = h.manager.ReloadPlugin(ctx, plugin.Name, plugin.Path, plugin.Config, plugin.Placement, plugin.Order)
+		} else if err = h.manager.RemovePlugin(ctx, plugin.Name); errors.Is(err, plugins.ErrPluginNotFound) {
+			err = nil
+		}
+		if err != nil {
+			operation.Items[i].Error = fmt.Sprintf("saved but failed to apply: %v", err)
+		}
+	}
+	operation.Status = configstoreTables.BulkOperationStatusApplied
+	h.finishBulkOperation(ctx, operation)
+}
+
+// mergedPlugin returns the stored plugin with config merged over its current
+// config the way PUT /api/plugins/{name} does, and enabled applied if set.
+func (h *BulkHandler) mergedPlugin(ctx context.Context, name string, enabled *bool, config map[string]any) (*configstoreTables.TablePlugin, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	existing, err := h.configStore.GetPlugin(ctx, name)
+	if err != nil {
+		if errors.Is(err, configstore.ErrNotFound) {
+			return nil, fmt.Errorf("plugin not found")
+		}
+		return nil, fmt.Errorf("failed to get plugin: %w", err)
+	}
+	merged := config
+	if existingConfig, ok := existing.Config.(map[string]any); ok && len(existingConfig) > 0 {
+		merged = make(map[string]any, len(existingConfig)+len(config))
+		maps.Copy(merged, existingConfig)
+		maps.Copy(merged, restoreRedactedFromExisting(config, existingConfig))
+	}
+	violations, err := validatePluginConfig(h.manager, name, merged)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return nil, fmt.Errorf("invalid plugin configuration: %s: %s", violations[0].Path, violations[0].Message)
+	}
+	normalized, err := h.manager.NormalizePluginConfig(name, merged)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin configuration: %w", err)
+	}
+	if normalized != nil {
+		merged = normalized
+	}
+	plugin := *existing
+	plugin.Config = merged
+	if enabled != nil {
+		plugin.Enabled = *enabled
+	}
+	return &plugin, nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// bulkTestManager records which routing rules a bulk operation reloaded.
+type bulkTestManager struct {
+	BulkManager
+	reloadedRules []string
+}
+
+func (m *bulkTestManager) ReloadRoutingRule(_ context.Context, id string) error {
+	m.reloadedRules = append(m.reloadedRules, id)
+	return nil
+}
+
+func applyRoutingRuleRequest(t *testing.T, h *BulkHandler, ruleID string, vkIDs ...string) (int, tables.TableBulkOperation) {
+	t.Helper()
+	body, err := sonic.Marshal(BulkApplyRoutingRuleRequest{VirtualKeyIDs: vkIDs, CreatedBy: "ops@example.com"})
+	require.NoError(t, err)
+	var req fasthttp.Request
+	req.SetBody(body)
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, nil, nil)
+	ctx.SetUserValue("rule_id", ruleID)
+	h.applyRoutingRule(ctx)
+	var operation tables.TableBulkOperation
+	require.NoError(t, sonic.Unmarshal(ctx.Response.Body(), &operation))
+	return ctx.Response.StatusCode(), operation
+}
+
+func vkScopedRules(t *testing.T, store configstore.ConfigStore) map[string]tables.TableRoutingRule {
+	t.Helper()
+	rules, err := store.GetRoutingRules(context.Background())
+	require.NoError(t, err)
+	byVK := map[string]tables.TableRoutingRule{}
+	for _, rule := range rules {
+		if rule.Scope == "virtual_key" {
+			byVK[*rule.ScopeID] = rule
+		}
+	}
+	return byVK
+}
+
+func TestBulkApplyRoutingRule(t *testing.T) {
+	SetLogger(&mockLogger{})
+	ctx := context.Background()
+	store := newTestConfigStore(t)
+	now := time.Now()
+	for _, id := range []string{"vk-1", "vk-2"} {
+		require.NoError(t, store.CreateVirtualKey(ctx, &tables.TableVirtualKey{
+			ID: id, Name: id, Value: *schemas.NewSecretVar(id + "-secret"),
+			IsActive: schemas.Ptr(true), CreatedAt: now, UpdatedAt: now,
+		}))
+	}
+	require.NoError(t, store.CreateRoutingRule(ctx, &tables.TableRoutingRule{
+		ID:            "rule-1",
+		Name:          "prefer-mini",
+		CelExpression: "true",
+		Scope:         "global",
+		Priority:      5,
+		Targets:       []tables.TableRoutingTarget{{Model: schemas.Ptr("gpt-4o-mini"), Weight: 1}},
+	}))
+	manager := &bulkTestManager{}
+	h, err := NewBulkHandler(manager, nil, store)
+	require.NoError(t, err)
+
+	// An unknown virtual key rejects the whole batch and writes nothing.
+	status, operation := applyRoutingRuleRequest(t, h, "rule-1", "vk-1", "vk-missing")
+	assert.Equal(t, fasthttp.StatusBadRequest, status)
+	assert.Equal(t, tables.BulkOperationStatusRejected, operation.Status)
+	assert.Equal(t, []tables.BulkOperationItem{
+		{ID: "vk-1", Status: tables.BulkItemStatusSkipped},
+		{ID: "vk-missing", Status: tables.BulkItemStatusFailed, Error: "virtual key not found"},
+	}, operation.Items)
+	assert.Empty(t, vkScopedRules(t, store))
+
+	status, operation = applyRoutingRuleRequest(t, h, "rule-1", "vk-1", "vk-2", "vk-1")
+	require.Equal(t, fasthttp.StatusOK, status)
+	assert.Equal(t, tables.BulkOperationStatusApplied, operation.Status)
+	assert.Equal(t, []tables.BulkOperationItem{
+		{ID: "vk-1", Status: tables.BulkItemStatusCreated},
+		{ID: "vk-2", Status: tables.BulkItemStatusCreated},
+	}, operation.Items, "duplicate virtual keys are applied once")
+	rules := vkScopedRules(t, store)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "prefer-mini", rules["vk-1"].Name)
+	require.Len(t, rules["vk-1"].Targets, 1)
+	assert.Equal(t, "gpt-4o-mini", *rules["vk-1"].Targets[0].Model)
+	assert.Len(t, manager.reloadedRules, 2)
+
+	// Re-running updates the copies in place instead of failing on the name.
+	status, operation = applyRoutingRuleRequest(t, h, "rule-1", "vk-1")
+	require.Equal(t, fasthttp.StatusOK, status)
+	assert.Equal(t, tables.BulkItemStatusUpdated, operation.Items[0].Status)
+	assert.Equal(t, rules["vk-1"].ID, vkScopedRules(t, store)["vk-1"].ID)
+
+	audit, err := store.GetBulkOperations(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, audit, 3, "one audit record per call, applied or not")
+	assert.Equal(t, BulkOperationApplyRoutingRule, audit[0].Operation)
+	assert.Equal(t, "ops@example.com", audit[0].CreatedBy)
+}
@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
 	"github.com/fasthttp/router"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/plugins/semanticcache"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
 	"github.com/valyala/fasthttp"
 )
@@ -13,6 +19,21 @@ import (
 type CacheClearer interface {
 	ClearCacheForCacheID(cacheID string) error
 	ClearCacheForKey(cacheKey string) error
+	Purge(ctx context.Context, filter semanticcache.PurgeFilter) (int, error)
+	GetCacheStats(ctx context.Context) (*semanticcache.CacheStats, error)
+	GetStats() semanticcache.Stats
+}
+
+// PurgeCacheRequest is the body of POST /api/cache/purge. Every set field must
+// match; at least one is required.
+type PurgeCacheRequest struct {
+	CacheKey string                `json:"cache_key,omitempty"`
+	Provider schemas.ModelProvider `json:"provider,omitempty"`
+	Model    string                `json:"model,omitempty"`
+	// OlderThan is a Go duration string ("30m", "24h").
+	OlderThan string         `json:"older_than,omitempty"`
+	Expired   bool           `json:"expired,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
 }
 
 // CacheClearerResolver returns the currently-loaded cache plugin or nil if
@@ -38,6 +59,9 @@ func NewCacheHandler(resolve CacheClearerResolver) *CacheHandler {
 func (h *CacheHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
 	r.DELETE("/api/cache/clear/{cacheId}", lib.ChainMiddlewares(h.clearCache, middlewares...))
 	r.DELETE("/api/cache/clear-by-key/{cacheKey}", lib.ChainMiddlewares(h.clearCacheByKey, middlewares...))
+	r.POST("/api/cache/purge", lib.ChainMiddlewares(h.purgeCache, middlewares...))
+	r.GET("/api/cache/stats", lib.ChainMiddlewares(h.getCacheStats, middlewares...))
+	r.GET("/api/cache/stats/lookups", lib.ChainMiddlewares(h.getLookupStats, middlewares...))
 }
 
 func (h *CacheHandler) clearCache(ctx *fasthttp.RequestCtx) {
@@ -81,3 +105,71 @@ func (h *CacheHandler) clearCacheByKey(ctx *fasthttp.RequestCtx) {
 		"message": "Cache cleared successfully",
 	})
 }
+
+// purgeCache handles POST /api/cache/purge - Delete cache entries by cache key, provider/model, age or metadata
+func (h *CacheHandler) purgeCache(ctx *fasthttp.RequestCtx) {
+	plugin := h.resolve()
+	if plugin == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "semantic_cache plugin is not loaded")
+		return
+	}
+	var req PurgeCacheRequest
+	if err := sonic.Unmarshal(ctx.PostBody(), &req); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+		return
+	}
+	filter := semanticcache.PurgeFilter{
+		CacheKey: req.CacheKey,
+		Provider: req.Provider,
+		Model:    req.Model,
+		Expired:  req.Expired,
+		Metadata: req.Metadata,
+	}
+	if req.OlderThan != "" {
+		olderThan, err := time.ParseDuration(req.OlderThan)
+		if err != nil || olderThan <= 0 {
+			SendError(ctx, fasthttp.StatusBadRequest, "older_than must be a positive duration such as 30m or 24h")
+			return
+		}
+		filter.OlderThan = olderThan
+	}
+	if filter.CacheKey == "" && filter.Provider == "" && filter.Model == "" && filter.OlderThan == 0 && !filter.Expired && len(filter.Metadata) == 0 {
+		SendError(ctx, fasthttp.StatusBadRequest, "At least one of cache_key, provider, model, older_than, expired or metadata is required")
+		return
+	}
+	deleted, err := plugin.Purge(ctx, filter)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to purge cache: %v", err))
+		return
+	}
+
+	SendJSON(ctx, map[string]any{
+		"message": "Cache purged successfully",
+		"deleted": deleted,
+	})
+}
+
+// getCacheStats handles GET /api/cache/stats - Entry counts and hit rate of the semantic cache
+func (h *CacheHandler) getCacheStats(ctx *fasthttp.RequestCtx) {
+	plugin := h.resolve()
+	if plugin == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "semantic_cache plugin is not loaded")
+		return
+	}
+	stats, err := plugin.GetCacheStats(ctx)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to get cache stats: %v", err))
+		return
+	}
+	SendJSON(ctx, stats)
+}
+
+// getLookupStats handles GET /api/cache/stats/lookups - Lookup outcomes of the semantic cache, in total and per cache key
+func (h *CacheHandler) getLookupStats(ctx *fasthttp.RequestCtx) {
+	plugin := h.resolve()
+	if plugin == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "semantic_cache plugin is not loaded")
+		return
+	}
+	SendJSON(ctx, plugin.GetStats())
+}
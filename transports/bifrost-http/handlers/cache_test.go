@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/maximhq/bifrost/plugins/semanticcache"
 	"github.com/valyala/fasthttp"
 )
 
@@ -15,6 +18,7 @@ type fakeCacheClearer struct {
 	clearByKey func(string) error
 	idCalls    []string
 	keyCalls   []string
+	purges     []semanticcache.PurgeFilter
 }
 
 func (f *fakeCacheClearer) ClearCacheForCacheID(id string) error {
@@ -33,6 +37,20 @@ func (f *fakeCacheClearer) ClearCacheForKey(key string) error {
 	return nil
 }
 
+func (f *fakeCacheClearer) Purge(_ context.Context, filter semanticcache.PurgeFilter) (int, error) {
+	f.purges = append(f.purges, filter)
+	return 3, nil
+}
+
+func (f *fakeCacheClearer) GetCacheStats(context.Context) (*semanticcache.CacheStats, error) {
+	return &semanticcache.CacheStats{Entries: 5, Lookups: 4, Hits: 2, HitRate: 0.5}, nil
+}
+
+func (f *fakeCacheClearer) GetStats() semanticcache.Stats {
+	lookups := semanticcache.LookupStats{Lookups: 4, DirectHits: 1, SemanticHits: 1, Misses: 2, HitRate: 0.5}
+	return semanticcache.Stats{LookupStats: lookups, ByCacheKey: map[string]semanticcache.LookupStats{"tenant-a": lookups}}
+}
+
 func newCacheCtx(userKey, userVal string) *fasthttp.RequestCtx {
 	ctx := &fasthttp.RequestCtx{}
 	if userKey != "" {
@@ -174,3 +192,92 @@ func TestClearCacheByKey_PluginNotLoaded(t *testing.T) {
 		t.Fatalf("expected plugin-not-loaded message, got %s", ctx.Response.Body())
 	}
 }
+
+// -----------------------------------------------------------------------------
+// purgeCache (POST /api/cache/purge) and getCacheStats (GET /api/cache/stats)
+// -----------------------------------------------------------------------------
+
+func newPurgeCtx(body string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBodyString(body)
+	return ctx
+}
+
+func TestPurgeCache_OK(t *testing.T) {
+	clearer := &fakeCacheClearer{}
+	h := newCacheHandler(clearer)
+
+	ctx := newPurgeCtx(`{"provider":"openai","model":"gpt-4o","older_than":"2h","metadata":{"params_hash":"abc"}}`)
+	h.purgeCache(ctx)
+
+	if got := ctx.Response.StatusCode(); got != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", got, ctx.Response.Body())
+	}
+	if len(clearer.purges) != 1 {
+		t.Fatalf("expected one Purge call, got %v", clearer.purges)
+	}
+	filter := clearer.purges[0]
+	if filter.Provider != "openai" || filter.Model != "gpt-4o" || filter.OlderThan != 2*time.Hour || filter.Metadata["params_hash"] != "abc" {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+	if !strings.Contains(string(ctx.Response.Body()), `"deleted":3`) {
+		t.Fatalf("expected deleted count in body, got %s", ctx.Response.Body())
+	}
+}
+
+func TestPurgeCache_RejectsBadFilters(t *testing.T) {
+	for _, body := range []string{`{}`, `{"older_than":"soon"}`, `{"older_than":"-1h"}`, `not json`} {
+		clearer := &fakeCacheClearer{}
+		h := newCacheHandler(clearer)
+
+		ctx := newPurgeCtx(body)
+		h.purgeCache(ctx)
+
+		if got := ctx.Response.StatusCode(); got != fasthttp.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", body, got)
+		}
+		if len(clearer.purges) != 0 {
+			t.Fatalf("expected no Purge calls for %s, got %v", body, clearer.purges)
+		}
+	}
+}
+
+func TestGetCacheStats_OK(t *testing.T) {
+	h := newCacheHandler(&fakeCacheClearer{})
+
+	ctx := &fasthttp.RequestCtx{}
+	h.getCacheStats(ctx)
+
+	if got := ctx.Response.StatusCode(); got != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", got, ctx.Response.Body())
+	}
+	if !strings.Contains(string(ctx.Response.Body()), `"hit_rate":0.5`) {
+		t.Fatalf("expected hit rate in body, got %s", ctx.Response.Body())
+	}
+}
+
+func TestGetCacheStats_PluginNotLoaded(t *testing.T) {
+	h := NewCacheHandler(func() CacheClearer { return nil })
+
+	ctx := &fasthttp.RequestCtx{}
+	h.getCacheStats(ctx)
+
+	if got := ctx.Response.StatusCode(); got != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 when plugin not loaded, got %d", got)
+	}
+}
+
+func TestGetLookupStats(t *testing.T) {
+	h := newCacheHandler(&fakeCacheClearer{})
+
+	ctx := &fasthttp.RequestCtx{}
+	h.getLookupStats(ctx)
+
+	if got := ctx.Response.StatusCode(); got != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", got, ctx.Response.Body())
+	}
+	body := string(ctx.Response.Body())
+	if !strings.Contains(body, `"semantic_hits":1`) || !strings.Contains(body, `"tenant-a":{`) {
+		t.Fatalf("expected totals and the per-key breakdown in body, got %s", body)
+	}
+}
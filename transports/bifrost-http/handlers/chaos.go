@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"github.com/bytedance/sonic"
+	"github.com/fasthttp/router"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/chaos"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// FaultInjector is the contract the handler needs from the chaos plugin.
+type FaultInjector interface {
+	AddFault(spec chaos.FaultSpec) (*chaos.Fault, error)
+	ListFaults() []*chaos.Fault
+	RemoveFault(id string) bool
+	ClearFaults()
+}
+
+// FaultInjectorResolver returns the currently-loaded chaos plugin or nil if
+// none is loaded. Resolved per request so plugin reloads are honored.
+type FaultInjectorResolver func() FaultInjector
+
+// ChaosHandler manages runtime fault injection for game days.
+type ChaosHandler struct {
+	resolve FaultInjectorResolver
+}
+
+// NewChaosHandler returns a ChaosHandler that resolves the chaos plugin at
+// request time. When the plugin is not loaded every route returns 400.
+func NewChaosHandler(resolve FaultInjectorResolver) *ChaosHandler {
+	return &ChaosHandler{resolve: resolve}
+}
+
+// RegisterRoutes registers the fault management routes.
+func (h *ChaosHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/chaos/faults", lib.ChainMiddlewares(h.listFaults, middlewares...))
+	r.POST("/api/chaos/faults", lib.ChainMiddlewares(h.addFault, middlewares...))
+	r.DELETE("/api/chaos/faults", lib.ChainMiddlewares(h.clearFaults, middlewares...))
+	r.DELETE("/api/chaos/faults/{id}", lib.ChainMiddlewares(h.removeFault, middlewares...))
+}
+
+func (h *ChaosHandler) injector(ctx *fasthttp.RequestCtx) FaultInjector {
+	injector := h.resolve()
+	if injector == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "chaos plugin is not loaded")
+	}
+	return injector
+}
+
+// listFaults handles GET /api/chaos/faults - List the active faults.
+func (h *ChaosHandler) listFaults(ctx *fasthttp.RequestCtx) {
+	injector := h.injector(ctx)
+	if injector == nil {
+		return
+	}
+	faults := injector.ListFaults()
+	SendJSON(ctx, map[string]any{
+		"faults": faults,
+		"count":  len(faults),
+	})
+}
+
+// addFault handles POST /api/chaos/faults - Activate a fault.
+func (h *ChaosHandler) addFault(ctx *fasthttp.RequestCtx) {
+	injector := h.injector(ctx)
+	if injector == nil {
+		return
+	}
+	var spec chaos.FaultSpec
+	if err := sonic.Unmarshal(ctx.PostBody(), &spec); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	fault, err := injector.AddFault(spec)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+	SendJSONWithStatus(ctx, fault, fasthttp.StatusCreated)
+}
+
+// removeFault handles DELETE /api/chaos/faults/{id} - Deactivate a fault.
+func (h *ChaosHandler) removeFault(ctx *fasthttp.RequestCtx) {
+	injector := h.injector(ctx)
+	if injector == nil {
+		return
+	}
+	id, ok := ctx.UserValue("id").(string)
+	if !ok || id == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid fault ID")
+		return
+	}
+	if !injector.RemoveFault(id) {
+		SendError(ctx, fasthttp.StatusNotFound, "Fault not found")
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"message": "Fault removed successfully",
+	})
+}
+
+// clearFaults handles DELETE /api/chaos/faults - Deactivate every fault.
+func (h *ChaosHandler) clearFaults(ctx *fasthttp.RequestCtx) {
+	injector := h.injector(ctx)
+	if injector == nil {
+		return
+	}
+	injector.ClearFaults()
+	SendJSON(ctx, map[string]any{
+		"message": "Faults cleared successfully",
+	})
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bytedance/sonic"
+	"github.com/fasthttp/router"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/plugins/circuitbreaker"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// CircuitBreakerAdmin is the contract the handler needs from the circuit breaker plugin.
+type CircuitBreakerAdmin interface {
+	Snapshot() []circuitbreaker.CircuitSnapshot
+	ForceOpen(key circuitbreaker.CircuitKey) error
+	ForceClose(key circuitbreaker.CircuitKey) error
+	Reset(key circuitbreaker.CircuitKey) error
+}
+
+// CircuitBreakerAdminResolver returns the currently-loaded circuit breaker or nil
+// if none is loaded. Resolved per request so plugin reloads are honored.
+type CircuitBreakerAdminResolver func() CircuitBreakerAdmin
+
+// CircuitBreakerHandler lists circuits and lets operators override their state.
+type CircuitBreakerHandler struct {
+	resolve CircuitBreakerAdminResolver
+}
+
+// NewCircuitBreakerHandler returns a CircuitBreakerHandler that resolves the circuit
+// breaker at request time. When the plugin is not loaded every route returns 400.
+func NewCircuitBreakerHandler(resolve CircuitBreakerAdminResolver) *CircuitBreakerHandler {
+	return &CircuitBreakerHandler{resolve: resolve}
+}
+
+// RegisterRoutes registers the circuit breaker admin routes.
+func (h *CircuitBreakerHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/circuit-breaker/circuits", lib.ChainMiddlewares(h.listCircuits, middlewares...))
+	r.POST("/api/circuit-breaker/circuits/force-open", lib.ChainMiddlewares(h.forceOpen, middlewares...))
+	r.POST("/api/circuit-breaker/circuits/force-close", lib.ChainMiddlewares(h.forceClose, middlewares...))
+	r.POST("/api/circuit-breaker/circuits/reset", lib.ChainMiddlewares(h.reset, middlewares...))
+}
+
+func (h *CircuitBreakerHandler) admin(ctx *fasthttp.RequestCtx) CircuitBreakerAdmin {
+	admin := h.resolve()
+	if admin == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "circuit breaker plugin is not loaded")
+	}
+	return admin
+}
+
+// listCircuits handles GET /api/circuit-breaker/circuits - List every circuit with its
+// state, failure rate and recent transitions.
+func (h *CircuitBreakerHandler) listCircuits(ctx *fasthttp.RequestCtx) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	circuits := admin.Snapshot()
+	SendJSON(ctx, map[string]any{
+		"circuits": circuits,
+		"count":    len(circuits),
+	})
+}
+
+// forceOpen handles POST /api/circuit-breaker/circuits/force-open - Hold a circuit open.
+func (h *CircuitBreakerHandler) forceOpen(ctx *fasthttp.RequestCtx) {
+	h.apply(ctx, CircuitBreakerAdmin.ForceOpen, "Circuit forced open")
+}
+
+// forceClose handles POST /api/circuit-breaker/circuits/force-close - Hold a circuit closed.
+func (h *CircuitBreakerHandler) forceClose(ctx *fasthttp.RequestCtx) {
+	h.apply(ctx, CircuitBreakerAdmin.ForceClose, "Circuit forced closed")
+}
+
+// reset handles POST /api/circuit-breaker/circuits/reset - Release a forced state and
+// close a circuit with an empty window.
+func (h *CircuitBreakerHandler) reset(ctx *fasthttp.RequestCtx) {
+	h.apply(ctx, CircuitBreakerAdmin.Reset, "Circuit reset")
+}
+
+// apply runs op against the circuit named by the request body.
+func (h *CircuitBreakerHandler) apply(ctx *fasthttp.RequestCtx, op func(CircuitBreakerAdmin, circuitbreaker.CircuitKey) error, message string) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	var key circuitbreaker.CircuitKey
+	if err := sonic.Unmarshal(ctx.PostBody(), &key); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if err := op(admin, key); err != nil {
+		status := fasthttp.StatusBadRequest
+		if errors.Is(err, circuitbreaker.ErrCircuitNotFound) {
+			status = fasthttp.StatusNotFound
+		}
+		SendError(ctx, status, err.Error())
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"message": message,
+		"circuit": key.String(),
+	})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/plugins/circuitbreaker"
+	"github.com/valyala/fasthttp"
+)
+
+func newCircuitBreakerCtx(body string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBody([]byte(body))
+	return ctx
+}
+
+func TestCircuitBreakerHandlerNotLoaded(t *testing.T) {
+	h := NewCircuitBreakerHandler(func() CircuitBreakerAdmin { return nil })
+	ctx := newCircuitBreakerCtx("")
+	h.listCircuits(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 without the plugin, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestCircuitBreakerHandlerForceAndReset(t *testing.T) {
+	breaker := circuitbreaker.NewCircuitBreaker(circuitbreaker.CircuitBreakerConfig{})
+	h := NewCircuitBreakerHandler(func() CircuitBreakerAdmin { return breaker })
+
+	ctx := newCircuitBreakerCtx(`{"provider": "openai"}`)
+	h.reset(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 resetting an unknown circuit, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = newCircuitBreakerCtx(`{"provider": "openai", "model": "gpt-4o"}`)
+	h.forceOpen(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 for a model circuit under provider granularity, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = newCircuitBreakerCtx(`{"provider": "openai"}`)
+	h.forceOpen(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200 forcing open, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	ctx = newCircuitBreakerCtx("")
+	h.listCircuits(ctx)
+	var listed struct {
+		Circuits []circuitbreaker.CircuitSnapshot `json:"circuits"`
+		Count    int                              `json:"count"`
+	}
+	if err := sonic.Unmarshal(ctx.Response.Body(), &listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if listed.Count != 1 || listed.Circuits[0].State != circuitbreaker.StateOpen || !listed.Circuits[0].Forced {
+		t.Fatalf("expected one forced open circuit, got %+v", listed)
+	}
+
+	ctx = newCircuitBreakerCtx(`{"provider": "openai"}`)
+	h.reset(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200 resetting, got %d", ctx.Response.StatusCode())
+	}
+	if state := breaker.Snapshot()[0].State; state != circuitbreaker.StateClosed {
+		t.Fatalf("expected the circuit closed after reset, got %s", state)
+	}
+}
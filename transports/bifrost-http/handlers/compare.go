@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/google/uuid"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	minCompareTargets = 2
+	maxCompareTargets = 4
+)
+
+// CompareTargetResult is one target's outcome in a comparison. Exactly one of
+// Response and Error is set.
+type CompareTargetResult struct {
+	Target    string                       `json:"target"`
+	RequestID string                       `json:"request_id"`
+	LatencyMs int64                        `json:"latency_ms"`
+	Cost      *float64                     `json:"cost,omitempty"`
+	Usage     *schemas.BifrostLLMUsage     `json:"usage,omitempty"`
+	Response  *schemas.BifrostChatResponse `json:"response,omitempty"`
+	Error     *schemas.BifrostError        `json:"error,omitempty"`
+}
+
+// CompareResponse is returned by POST /v1/compare. ComparisonID is the parent
+// request ID every target's log is recorded under.
+type CompareResponse struct {
+	ComparisonID string                `json:"comparison_id"`
+	Results      []CompareTargetResult `json:"results"`
+}
+
+// prepareCompareRequest parses a chat completion body whose "targets" list replaces
+// "model". It returns the targets and the request every target is sent.
+func prepareCompareRequest(ctx *fasthttp.RequestCtx, config *lib.Config) ([]string, *schemas.BifrostChatRequest, error) {
+	var body struct {
+		Targets []string `json:"targets"`
+	}
+	if err := sonic.Unmarshal(ctx.PostBody(), &body); err != nil {
+		return nil, nil, fmt.Errorf("Invalid request payload")
+	}
+	if len(body.Targets) < minCompareTargets || len(body.Targets) > maxCompareTargets {
+		return nil, nil, fmt.Errorf("targets must list between %d and %d provider/model pairs", minCompareTargets, maxCompareTargets)
+	}
+	for _, target := range body.Targets {
+		if provider, model := schemas.ParseModelString(target, ""); provider == "" || model == "" {
+			return nil, nil, fmt.Errorf("target %q must be in provider/model format", target)
+		}
+	}
+	req, bifrostChatReq, err := prepareChatCompletionRequest(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case req.Model != "":
+		return nil, nil, fmt.Errorf("model is not supported for comparisons, list the models in targets")
+	case len(req.Fallbacks) > 0:
+		return nil, nil, fmt.Errorf("fallbacks are not supported for comparisons")
+	case effectiveStream(req.Stream):
+		return nil, nil, fmt.Errorf("streaming is not supported for comparisons")
+	}
+	delete(bifrostChatReq.Params.ExtraParams, "targets")
+	return body.Targets, bifrostChatReq, nil
+}
+
+// compare handles POST /v1/compare - Send one chat completion request to 2-4 targets
+// in parallel and return every response side by side. Each target runs through the
+// full plugin pipeline, so governance applies, and is logged with the comparison ID
+// as its parent request ID.
+func (h *CompletionHandler) compare(ctx *fasthttp.RequestCtx) {
+	targets, bifrostChatReq, err := prepareCompareRequest(ctx, h.config)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+	defer cancel()
+	comparisonID, _ := bifrostCtx.Value(schemas.BifrostContextKeyRequestID).(string)
+
+	results := make([]CompareTargetResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.compareTarget(bifrostCtx, comparisonID, target, bifrostChatReq)
+		}()
+	}
+	wg.Wait()
+
+	SendJSON(ctx, CompareResponse{
+		ComparisonID: comparisonID,
+		Results:      results,
+	})
+}
+
+// compareTarget sends base to target on its own child context so the target gets its
+// own request ID and log entry.
+func (h *CompletionHandler) compareTarget(parent *schemas.BifrostContext, comparisonID, target string, base *schemas.BifrostChatRequest) CompareTargetResult {
+	requestCtx, cancel := schemas.NewBifrostContextWithCancel(parent)
+	defer cancel()
+	result := CompareTargetResult{Target: target, RequestID: uuid.New().String()}
+	requestCtx.SetValue(schemas.BifrostContextKeyRequestID, result.RequestID)
+	requestCtx.SetValue(schemas.BifrostContextKeyParentRequestID, comparisonID)
+
+	req := *base
+	req.Provider, req.Model = schemas.ParseModelString(target, "")
+	req.Input = slices.Clone(base.Input)
+	params := *base.Params
+	req.Params = &params
+
+	start := time.Now()
+	resp, bifrostErr := h.client.ChatCompletionRequest(requestCtx, &req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if cost, ok := requestCtx.Value(schemas.BifrostContextKeyResponseCost).(float64); ok {
+		result.Cost = &cost
+	}
+	if bifrostErr != nil {
+		result.Error = lib.SanitizeBifrostErrorForClient(bifrostErr)
+		return result
+	}
+	result.Response = resp
+	if resp != nil {
+		result.Usage = resp.Usage
+	}
+	return result
+}
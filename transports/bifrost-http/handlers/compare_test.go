@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+func TestPrepareCompareRequest(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBodyString(`{"targets": ["openai/gpt-4o", "anthropic/claude-sonnet-4"], "messages": [{"role": "user", "content": "hi"}], "temperature": 0.2, "top_k": 5}`)
+
+	targets, req, err := prepareCompareRequest(ctx, nil)
+	if err != nil {
+		t.Fatalf("prepareCompareRequest: %v", err)
+	}
+	if len(targets) != 2 || targets[1] != "anthropic/claude-sonnet-4" {
+		t.Fatalf("unexpected targets: %v", targets)
+	}
+	if len(req.Input) != 1 || req.Params.Temperature == nil || *req.Params.Temperature != 0.2 {
+		t.Fatalf("expected the chat body to be parsed, got %+v", req)
+	}
+	if _, ok := req.Params.ExtraParams["targets"]; ok {
+		t.Fatal("targets must not be forwarded to providers as an extra param")
+	}
+	if req.Params.ExtraParams["top_k"] == nil {
+		t.Fatalf("expected unknown fields to stay extra params, got %v", req.Params.ExtraParams)
+	}
+	if provider, model := schemas.ParseModelString(targets[0], ""); provider != schemas.OpenAI || model != "gpt-4o" {
+		t.Fatalf("unexpected target parse: %s/%s", provider, model)
+	}
+}
+
+func TestPrepareCompareRequestRejectsInvalidBodies(t *testing.T) {
+	messages := `"messages": [{"role": "user", "content": "hi"}]`
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "one target", body: `{"targets": ["openai/gpt-4o"], ` + messages + `}`, wantErr: "between 2 and 4"},
+		{name: "five targets", body: `{"targets": ["openai/a", "openai/b", "openai/c", "openai/d", "openai/e"], ` + messages + `}`, wantErr: "between 2 and 4"},
+		{name: "target without provider", body: `{"targets": ["openai/gpt-4o", "gpt-4o"], ` + messages + `}`, wantErr: "provider/model format"},
+		{name: "model set", body: `{"model": "openai/gpt-4o", "targets": ["openai/gpt-4o", "openai/gpt-4o-mini"], ` + messages + `}`, wantErr: "model is not supported"},
+		{name: "fallbacks set", body: `{"fallbacks": ["openai/gpt-4o"], "targets": ["openai/gpt-4o", "openai/gpt-4o-mini"], ` + messages + `}`, wantErr: "fallbacks are not supported"},
+		{name: "streaming", body: `{"stream": true, "targets": ["openai/gpt-4o", "openai/gpt-4o-mini"], ` + messages + `}`, wantErr: "streaming is not supported"},
+		{name: "no messages", body: `{"targets": ["openai/gpt-4o", "openai/gpt-4o-mini"]}`, wantErr: "messages is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.SetBodyString(tt.body)
+			_, _, err := prepareCompareRequest(ctx, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ var securityHeaders = []string{
 	"x-goog-api-key",
 	"x-bf-api-key",
 	"x-bf-vk",
+	"x-bf-governance-override",
 }
 
 func getPasswordPolicyFailures(password string) []string {
@@ -523,7 +525,7 @@ func (h *ConfigHandler) updateConfig(ctx *fasthttp.RequestCtx) {
 	// Handle compat plugin toggle
 	newCompat := payload.ClientConfig.Compat
 	oldCompat := currentConfig.Compat
-	if newCompat != oldCompat {
+	if !newCompat.Equal(oldCompat) {
 		newEnabled := newCompat.ConvertTextToChat || newCompat.ConvertChatToResponses || newCompat.ShouldDropParams || newCompat.ShouldConvertParams
 		if newEnabled {
 			compatCfg := &compat.Config{
@@ -531,6 +533,8 @@ func (h *ConfigHandler) updateConfig(ctx *fasthttp.RequestCtx) {
 				ConvertChatToResponses: newCompat.ConvertChatToResponses,
 				ShouldDropParams:       newCompat.ShouldDropParams,
 				ShouldConvertParams:    newCompat.ShouldConvertParams,
+				TextToChatSystemPrompt: newCompat.TextToChatSystemPrompt,
+				TextToChatModels:       newCompat.TextToChatModels,
 			}
 			if err := h.configManager.ReloadPlugin(ctx, compat.PluginName, nil, compatCfg, nil, nil); err != nil {
 				logger.Warn("failed to load compat plugin: %v", err)
@@ -634,6 +638,19 @@ func (h *ConfigHandler) updateConfig(ctx *fasthttp.RequestCtx) {
 	}
 	updatedConfig.LogRetentionDays = payload.ClientConfig.LogRetentionDays
 
+	// Only update RetentionConfig if provided; the log cleaner reads it at startup
+	if payload.ClientConfig.RetentionConfig != nil {
+		if err := payload.ClientConfig.RetentionConfig.Validate(); err != nil {
+			logger.Warn("invalid retention config: %v", err)
+			SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+			return
+		}
+		if !reflect.DeepEqual(payload.ClientConfig.RetentionConfig, currentConfig.RetentionConfig) {
+			restartReasons = append(restartReasons, "Retention")
+		}
+		updatedConfig.RetentionConfig = payload.ClientConfig.RetentionConfig
+	}
+
 	if err := h.store.ConfigStore.UpdateClientConfig(ctx, updatedConfig); err != nil {
 		logger.Warn("failed to save configuration: %v", err)
 		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to save configuration: %v", err))
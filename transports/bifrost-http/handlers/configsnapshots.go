@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fasthttp/router"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// ConfigRollbacker restores a config snapshot and applies it to the running
+// gateway.
+type ConfigRollbacker interface {
+	RollbackConfig(ctx context.Context, hash string) error
+}
+
+// ConfigSnapshotHandler lists the recorded config snapshots and rolls the
+// config back to one of them.
+type ConfigSnapshotHandler struct {
+	configStore configstore.ConfigStore
+	rollbacker  ConfigRollbacker
+}
+
+// NewConfigSnapshotHandler creates a new ConfigSnapshotHandler.
+func NewConfigSnapshotHandler(configStore configstore.ConfigStore, rollbacker ConfigRollbacker) *ConfigSnapshotHandler {
+	return &ConfigSnapshotHandler{
+		configStore: configStore,
+		rollbacker:  rollbacker,
+	}
+}
+
+// RegisterRoutes registers the config snapshot routes.
+func (h *ConfigSnapshotHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/config/snapshots", lib.ChainMiddlewares(h.listSnapshots, middlewares...))
+	r.POST("/api/config/rollback/{snapshot}", lib.ChainMiddlewares(h.rollback, middlewares...))
+}
+
+// listSnapshots handles GET /api/config/snapshots - List config snapshots, newest first.
+func (h *ConfigSnapshotHandler) listSnapshots(ctx *fasthttp.RequestCtx) {
+	limit := 100
+	if raw := string(ctx.QueryArgs().Peek("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > 1000 {
+			SendError(ctx, fasthttp.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	snapshots, err := h.configStore.GetConfigSnapshots(ctx, limit)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to list config snapshots: %v", err))
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"snapshots": snapshots,
+		"count":     len(snapshots),
+	})
+}
+
+// rollback handles POST /api/config/rollback/{snapshot} - Restore the providers,
+// keys, routing rules and plugins of the snapshot with the given hash.
+func (h *ConfigSnapshotHandler) rollback(ctx *fasthttp.RequestCtx) {
+	hash, _ := ctx.UserValue("snapshot").(string)
+	if hash == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "snapshot hash is required")
+		return
+	}
+	if err := h.rollbacker.RollbackConfig(ctx, hash); err != nil {
+		if errors.Is(err, configstore.ErrNotFound) {
+			SendError(ctx, fasthttp.StatusNotFound, fmt.Sprintf("config snapshot %s not found", hash))
+			return
+		}
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to roll back config: %v", err))
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"status":   "success",
+		"message":  "Config rolled back",
+		"snapshot": hash,
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/valyala/fasthttp"
+)
+
+// mockConfigSnapshotStore embeds the interface so unimplemented methods panic.
+type mockConfigSnapshotStore struct {
+	configstore.ConfigStore
+	snapshots []tables.TableConfigSnapshot
+	limit     int
+}
+
+func (m *mockConfigSnapshotStore) GetConfigSnapshots(_ context.Context, limit int) ([]tables.TableConfigSnapshot, error) {
+	m.limit = limit
+	return m.snapshots, nil
+}
+
+type mockConfigRollbacker struct {
+	hash string
+	err  error
+}
+
+func (m *mockConfigRollbacker) RollbackConfig(_ context.Context, hash string) error {
+	m.hash = hash
+	return m.err
+}
+
+func TestConfigSnapshotHandlerListsSnapshots(t *testing.T) {
+	store := &mockConfigSnapshotStore{snapshots: []tables.TableConfigSnapshot{{ID: 2, Hash: "b"}, {ID: 1, Hash: "a"}}}
+	h := NewConfigSnapshotHandler(store, &mockConfigRollbacker{})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/config/snapshots?limit=10")
+	h.listSnapshots(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	var listed struct {
+		Snapshots []tables.TableConfigSnapshot `json:"snapshots"`
+		Count     int                          `json:"count"`
+	}
+	if err := sonic.Unmarshal(ctx.Response.Body(), &listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if listed.Count != 2 || listed.Snapshots[0].Hash != "b" || store.limit != 10 {
+		t.Fatalf("expected both snapshots newest first with limit 10, got %+v (limit %d)", listed, store.limit)
+	}
+
+	ctx = &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/config/snapshots?limit=0")
+	h.listSnapshots(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid limit, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestConfigSnapshotHandlerRollback(t *testing.T) {
+	rollbacker := &mockConfigRollbacker{}
+	h := NewConfigSnapshotHandler(&mockConfigSnapshotStore{}, rollbacker)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.SetUserValue("snapshot", "abc")
+	h.rollback(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK || rollbacker.hash != "abc" {
+		t.Fatalf("expected 200 rolling back to abc, got %d (hash %q)", ctx.Response.StatusCode(), rollbacker.hash)
+	}
+
+	rollbacker.err = configstore.ErrNotFound
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue("snapshot", "missing")
+	h.rollback(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown snapshot, got %d", ctx.Response.StatusCode())
+	}
+
+	rollbacker.err = errors.New("boom")
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue("snapshot", "abc")
+	h.rollback(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusInternalServerError {
+		t.Fatalf("expected 500 when the rollback fails, got %d", ctx.Response.StatusCode())
+	}
+}
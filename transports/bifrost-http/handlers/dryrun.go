@@ -0,0 +1,91 @@
+// Package handlers provides HTTP request handlers for the Bifrost HTTP transport.
+// This file contains the shared helpers for validation-only (dry run) config mutations.
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// keyLivenessTimeout bounds the upstream call made to check a key during a dry run.
+const keyLivenessTimeout = 15 * time.Second
+
+// DryRunResponse is returned by config mutation endpoints called with ?dry_run=true.
+// The request went through the same validation as a real mutation, but nothing was
+// persisted or applied. Result holds the state the mutation would have produced
+// (secrets redacted), or for deletions the resource that would have been removed.
+type DryRunResponse struct {
+	DryRun    bool                `json:"dry_run"`
+	Valid     bool                `json:"valid"`
+	Result    any                 `json:"result,omitempty"`
+	KeyChecks []KeyLivenessResult `json:"key_checks,omitempty"`
+}
+
+// KeyLivenessResult reports whether the provider accepted a key during a dry run.
+type KeyLivenessResult struct {
+	KeyID   string `json:"key_id"`
+	KeyName string `json:"key_name,omitempty"`
+	Live    bool   `json:"live"`
+	Error   string `json:"error,omitempty"`
+}
+
+// isDryRun reports whether the request asked for validation only (?dry_run=true).
+func isDryRun(ctx *fasthttp.RequestCtx) bool {
+	return queryBool(ctx, "dry_run")
+}
+
+// shouldCheckKeys reports whether a dry run should also verify keys against the
+// provider (?check_keys=true). Ignored outside dry runs.
+func shouldCheckKeys(ctx *fasthttp.RequestCtx) bool {
+	return queryBool(ctx, "check_keys")
+}
+
+func queryBool(ctx *fasthttp.RequestCtx, name string) bool {
+	v, err := strconv.ParseBool(string(ctx.QueryArgs().Peek(name)))
+	return err == nil && v
+}
+
+// sendDryRun writes the dry run outcome. A failed key liveness check makes the
+// result invalid and is reported with 422 so CI pipelines fail on it.
+func sendDryRun(ctx *fasthttp.RequestCtx, result any, keyChecks ...KeyLivenessResult) {
+	response := DryRunResponse{
+		DryRun:    true,
+		Valid:     true,
+		Result:    result,
+		KeyChecks: keyChecks,
+	}
+	for _, check := range keyChecks {
+		if !check.Live {
+			response.Valid = false
+		}
+	}
+	if !response.Valid {
+		SendJSONWithStatus(ctx, response, fasthttp.StatusUnprocessableEntity)
+		return
+	}
+	SendJSON(ctx, response)
+}
+
+// checkKeyLiveness lists the provider's models with the given key, without adding
+// the key to the provider's pool. The provider must already be registered.
+func checkKeyLiveness(client *bifrost.Bifrost, provider schemas.ModelProvider, key schemas.Key) KeyLivenessResult {
+	result := KeyLivenessResult{KeyID: key.ID, KeyName: key.Name}
+	if client == nil {
+		result.Error = "bifrost client is not available"
+		return result
+	}
+	bifrostCtx, cancel := schemas.NewBifrostContextWithTimeout(context.Background(), keyLivenessTimeout)
+	defer cancel()
+	bifrostCtx.SetValue(schemas.BifrostContextKeyDirectKey, key)
+	if _, bifrostErr := client.ListModelsRequest(bifrostCtx, &schemas.BifrostListModelsRequest{Provider: provider}); bifrostErr != nil {
+		result.Error = bifrost.GetErrorMessage(bifrostErr)
+		return result
+	}
+	result.Live = true
+	return result
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/maximhq/bifrost/framework/configstore"
 	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/encrypt"
 	"github.com/maximhq/bifrost/framework/logstore"
 	"github.com/maximhq/bifrost/framework/modelcatalog"
 	"github.com/maximhq/bifrost/plugins/governance"
@@ -181,13 +182,17 @@ type CreateVirtualKeyRequest struct {
 		MCPClientName  string            `json:"mcp_client_name" validate:"required"`
 		ToolsToExecute schemas.WhiteList `json:"tools_to_execute,omitempty"`
 	} `json:"mcp_configs,omitempty"` // Empty means no MCP clients allowed (deny-by-default)
-	TeamID          *string                 `json:"team_id,omitempty"`     // Mutually exclusive with CustomerID
-	CustomerID      *string                 `json:"customer_id,omitempty"` // Mutually exclusive with TeamID
-	Budgets         []CreateBudgetRequest   `json:"budgets,omitempty"`     // Multi-budget: each must have a unique reset_duration
-	RateLimit       *CreateRateLimitRequest `json:"rate_limit,omitempty"`
-	IsActive        *bool                   `json:"is_active,omitempty"`
-	CalendarAligned bool                    `json:"calendar_aligned,omitempty"` // When true, all budgets reset at clean calendar boundaries
-	ExpiresAt       *time.Time              `json:"expires_at,omitempty"`       // Optional expiry; nil means never expires
+	TeamID          *string                               `json:"team_id,omitempty"`     // Mutually exclusive with CustomerID
+	CustomerID      *string                               `json:"customer_id,omitempty"` // Mutually exclusive with TeamID
+	Budgets         []CreateBudgetRequest                 `json:"budgets,omitempty"`     // Multi-budget: each must have a unique reset_duration
+	RateLimit       *CreateRateLimitRequest               `json:"rate_limit,omitempty"`
+	IsActive        *bool                                 `json:"is_active,omitempty"`
+	CalendarAligned bool                                  `json:"calendar_aligned,omitempty"` // When true, all budgets reset at clean calendar boundaries
+	ExpiresAt       *time.Time                            `json:"expires_at,omitempty"`       // Optional expiry; nil means never expires
+	Defaults        *configstoreTables.VirtualKeyDefaults `json:"defaults,omitempty"`         // Model and parameter presets
+	Priority        string                                `json:"priority,omitempty"`         // Queueing class: "interactive" or "batch"; empty defers to x-bf-priority
+
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"` // Cap on requests in flight with this key; nil or 0 means no cap
 }
 
 // UpdateVirtualKeyRequest represents the request body for updating a virtual key
@@ -209,18 +214,48 @@ type UpdateVirtualKeyRequest struct {
 		MCPClientName  string            `json:"mcp_client_name" validate:"required"`
 		ToolsToExecute schemas.WhiteList `json:"tools_to_execute,omitempty"`
 	} `json:"mcp_configs,omitempty"`
-	TeamID           schemas.OptionalJSON[string] `json:"team_id,omitempty"`
-	CustomerID       schemas.OptionalJSON[string] `json:"customer_id,omitempty"`
-	Budgets          []CreateBudgetRequest        `json:"budgets,omitempty"` // Multi-budget: replaces all VK-level budgets
-	RateLimit        *UpdateRateLimitRequest      `json:"rate_limit,omitempty"`
-	IsActive         *bool                        `json:"is_active,omitempty"`
-	CalendarAligned  *bool                        `json:"calendar_aligned,omitempty"` // When true, all budgets reset at clean calendar boundaries
-	ResetBudgetUsage *bool                        `json:"reset_budget_usage,omitempty"`
-	ExpiresAt        *string                      `json:"expires_at,omitempty"` // RFC3339 timestamp sets a new expiry, "" clears it, omitted leaves it unchanged
+	TeamID           schemas.OptionalJSON[string]          `json:"team_id,omitempty"`
+	CustomerID       schemas.OptionalJSON[string]          `json:"customer_id,omitempty"`
+	Budgets          []CreateBudgetRequest                 `json:"budgets,omitempty"` // Multi-budget: replaces all VK-level budgets
+	RateLimit        *UpdateRateLimitRequest               `json:"rate_limit,omitempty"`
+	IsActive         *bool                                 `json:"is_active,omitempty"`
+	CalendarAligned  *bool                                 `json:"calendar_aligned,omitempty"` // When true, all budgets reset at clean calendar boundaries
+	ResetBudgetUsage *bool                                 `json:"reset_budget_usage,omitempty"`
+	ExpiresAt        *string                               `json:"expires_at,omitempty"` // RFC3339 timestamp sets a new expiry, "" clears it, omitted leaves it unchanged
+	Defaults         *configstoreTables.VirtualKeyDefaults `json:"defaults,omitempty"`   // Replaces the presets; {} clears them, omitted leaves them unchanged
+	Priority         *string                               `json:"priority,omitempty"`   // "interactive" or "batch" sets the queueing class, "" clears it, omitted leaves it unchanged
+
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"` // Sets the in-flight cap, 0 clears it, omitted leaves it unchanged
 }
 
 var errVirtualKeyDualAssociation = errors.New("VirtualKey cannot be attached to both Team and Customer")
 
+// normalizeVirtualKeyMaxConcurrentRequests validates a virtual key's in-flight
+// cap. Nil and 0 both mean no cap and are stored as nil.
+func normalizeVirtualKeyMaxConcurrentRequests(max *int) (*int, error) {
+	if max == nil || *max == 0 {
+		return nil, nil
+	}
+	if *max < 0 {
+		return nil, fmt.Errorf("max_concurrent_requests must be 0 or greater")
+	}
+	value := *max
+	return &value, nil
+}
+
+// normalizeVirtualKeyPriority validates a virtual key's queueing priority and
+// returns it in canonical form; empty means the key sets no priority.
+func normalizeVirtualKeyPriority(priority string) (string, error) {
+	if strings.TrimSpace(priority) == "" {
+		return "", nil
+	}
+	parsed, ok := schemas.ParseRequestPriority(priority)
+	if !ok {
+		return "", fmt.Errorf("priority must be %q or %q", schemas.RequestPriorityInteractive, schemas.RequestPriorityBatch)
+	}
+	return string(parsed), nil
+}
+
 // optionalJSONStringHasValue reports whether a presence-aware string contains a non-empty value.
 func optionalJSONStringHasValue(value schemas.OptionalJSON[string]) bool {
 	return value.Set && !value.Null && value.Value != ""
@@ -257,12 +292,29 @@ type CreateBudgetRequest struct {
 	ID            string  `json:"id,omitempty"`
 	MaxLimit      float64 `json:"max_limit" validate:"required"`      // Maximum budget in dollars
 	ResetDuration string  `json:"reset_duration" validate:"required"` // e.g., "30s", "5m", "1h", "1d", "1w", "1M"
+	Prorate       bool    `json:"prorate,omitempty"`                  // Apply a changed max_limit only to the rest of the current cycle
 }
 
 // UpdateBudgetRequest represents the request body for updating a budget
 type UpdateBudgetRequest struct {
 	MaxLimit      *float64 `json:"max_limit,omitempty"`
 	ResetDuration *string  `json:"reset_duration,omitempty"`
+	Prorate       bool     `json:"prorate,omitempty"` // Apply a changed max_limit only to the rest of the current cycle
+}
+
+// BudgetWindowResponse describes the accounting window a budget's usage is
+// currently counted over and the limit that applies to it.
+type BudgetWindowResponse struct {
+	BudgetID          string    `json:"budget_id"`
+	ResetDuration     string    `json:"reset_duration"`
+	Schedule          string    `json:"schedule"` // "calendar" or "rolling"
+	WindowStart       time.Time `json:"window_start"`
+	WindowEnd         time.Time `json:"window_end"` // When the budget next resets
+	MaxLimit          float64   `json:"max_limit"`
+	CycleMaxLimit     float64   `json:"cycle_max_limit"` // Limit for this window; differs from max_limit after a prorated change
+	Prorated          bool      `json:"prorated"`
+	EffectiveMaxLimit float64   `json:"effective_max_limit"` // Cycle limit plus any active override
+	CurrentUsage      float64   `json:"current_usage"`
 }
 
 // BudgetOverrideRequest replaces the active override on one budget.
@@ -278,6 +330,23 @@ type BudgetOverrideResponse struct {
 	EffectiveMaxLimit float64                        `json:"effective_max_limit"`
 }
 
+// CreateGovernanceOverrideRequest mints a short-lived token that lets requests
+// presenting it (x-bf-governance-override) skip budgets and/or rate limits.
+type CreateGovernanceOverrideRequest struct {
+	Controls     []string `json:"controls"`                 // "budget" and/or "rate_limit"
+	VirtualKeyID *string  `json:"virtual_key_id,omitempty"` // nil = usable with any virtual key
+	Reason       string   `json:"reason"`
+	CreatedBy    string   `json:"created_by,omitempty"`
+	TTLSeconds   int64    `json:"ttl_seconds,omitempty"` // defaults to 1h, capped at 24h
+}
+
+// CreateGovernanceOverrideResponse returns the minted override. The plaintext
+// token is only ever returned here; the store keeps its hash.
+type CreateGovernanceOverrideResponse struct {
+	Token    string                                     `json:"token"`
+	Override *configstoreTables.TableGovernanceOverride `json:"override"`
+}
+
 // RoutingTarget represents a single weighted routing target within a rule.
 // All fields except Weight are optional; nil means "use the incoming request's value".
 // Weights across all targets in a rule must sum to 1 (e.g. 0.7 + 0.3 = 1.0).
@@ -295,12 +364,15 @@ type CreateRoutingRuleRequest struct {
 	Enabled       *bool           `json:"enabled,omitempty"`    // nil = use DB default (true)
 	ChainRule     *bool           `json:"chain_rule,omitempty"` // nil = use DB default (false)
 	CelExpression string          `json:"cel_expression"`
-	Targets       []RoutingTarget `json:"targets"` // Required; weights must sum to 1
+	Type          string          `json:"type,omitempty"` // "cel" (default) or "embedding_length"
+	Targets       []RoutingTarget `json:"targets"`        // Required; weights must sum to 1
 	Fallbacks     []string        `json:"fallbacks,omitempty"`
 	Scope         string          `json:"scope,omitempty"` // Defaults to "global" if not provided
 	ScopeID       *string         `json:"scope_id,omitempty"`
 	Query         map[string]any  `json:"query,omitempty"`
 	Priority      int             `json:"priority,omitempty"` // Defaults to 0 if not provided
+
+	EmbeddingBuckets []configstoreTables.RoutingEmbeddingBucket `json:"embedding_buckets,omitempty"` // Required for embedding_length rules
 }
 
 // UpdateRoutingRuleRequest represents the request body for updating a routing rule
@@ -310,12 +382,15 @@ type UpdateRoutingRuleRequest struct {
 	Enabled       *bool           `json:"enabled,omitempty"`
 	ChainRule     *bool           `json:"chain_rule,omitempty"`
 	CelExpression *string         `json:"cel_expression,omitempty"`
+	Type          *string         `json:"type,omitempty"`
 	Targets       []RoutingTarget `json:"targets,omitempty"` // If provided, replaces all existing targets; weights must sum to 1
 	Fallbacks     []string        `json:"fallbacks,omitempty"`
 	Query         map[string]any  `json:"query,omitempty"`
 	Priority      *int            `json:"priority,omitempty"`
 	Scope         *string         `json:"scope,omitempty"`
 	ScopeID       *string         `json:"scope_id,omitempty"`
+
+	EmbeddingBuckets []configstoreTables.RoutingEmbeddingBucket `json:"embedding_buckets,omitempty"` // If provided, replaces all existing buckets
 }
 
 // CreateRateLimitRequest represents the request body for creating a rate limit using flexible approach
@@ -462,6 +537,7 @@ func coerceLegacyBudget(req *UpdateBudgetRequest, existing *configstoreTables.Ta
 	if req.ResetDuration != nil {
 		b.ResetDuration = *req.ResetDuration
 	}
+	b.Prorate = req.Prorate
 	if b.MaxLimit == 0 || b.ResetDuration == "" {
 		return nil
 	}
@@ -469,6 +545,32 @@ func coerceLegacyBudget(req *UpdateBudgetRequest, existing *configstoreTables.Ta
 	return &result
 }
 
+// applyBudgetRequest updates a matched budget from its request. With prorate, a
+// changed limit applies only to the rest of the current cycle; without it, the
+// new limit applies to the whole cycle and drops any earlier proration. An
+// unchanged limit keeps the current cycle's proration, since clients resend
+// every budget on each update.
+func applyBudgetRequest(existing *configstoreTables.TableBudget, req CreateBudgetRequest, now time.Time) error {
+	if existing.ResetDuration != req.ResetDuration {
+		existing.ResetDuration = req.ResetDuration
+		existing.MaxLimit = req.MaxLimit
+		existing.ClearProration()
+		return nil
+	}
+	if existing.MaxLimit == req.MaxLimit {
+		return nil
+	}
+	if !req.Prorate {
+		existing.MaxLimit = req.MaxLimit
+		existing.ClearProration()
+		return nil
+	}
+	if err := existing.ProrateLimitChange(req.MaxLimit, now); err != nil {
+		return &badRequestError{err: fmt.Errorf("cannot prorate budget %s: %w", existing.ID, err)}
+	}
+	return nil
+}
+
 func isRateLimitRemovalRequest(req *UpdateRateLimitRequest) bool {
 	return req != nil && req.TokenMaxLimit == nil && req.RequestMaxLimit == nil &&
 		req.TokenResetDuration == nil && req.RequestResetDuration == nil
@@ -502,8 +604,9 @@ func (h *GovernanceHandler) reconcileModelConfigBudgets(ctx context.Context, tx
 			return err
 		}
 		if found {
-			existing.MaxLimit = b.MaxLimit
-			existing.ResetDuration = b.ResetDuration
+			if err := applyBudgetRequest(&existing, b, time.Now()); err != nil {
+				return err
+			}
 			if err := validateBudget(&existing); err != nil {
 				return err
 			}
@@ -570,8 +673,9 @@ func (h *GovernanceHandler) reconcileCustomerBudgets(ctx context.Context, tx *go
 			return err
 		}
 		if found {
-			existing.MaxLimit = b.MaxLimit
-			existing.ResetDuration = b.ResetDuration
+			if err := applyBudgetRequest(&existing, b, time.Now()); err != nil {
+				return err
+			}
 			if err := validateBudget(&existing); err != nil {
 				return err
 			}
@@ -1014,6 +1118,11 @@ func (h *GovernanceHandler) RegisterRoutes(r *router.Router, middlewares ...sche
 	r.DELETE("/api/governance/virtual-keys/{vk_id}/budgets/{budget_id}/override", lib.ChainMiddlewares(h.deleteVirtualKeyBudgetOverride, middlewares...))
 	r.DELETE("/api/governance/virtual-keys/{vk_id}", lib.ChainMiddlewares(h.deleteVirtualKey, middlewares...))
 
+	// Governance override tokens (emergency bypass of budgets/rate limits)
+	r.GET("/api/governance/overrides", lib.ChainMiddlewares(h.getGovernanceOverrides, middlewares...))
+	r.POST("/api/governance/overrides", lib.ChainMiddlewares(h.createGovernanceOverride, middlewares...))
+	r.DELETE("/api/governance/overrides/{override_id}", lib.ChainMiddlewares(h.revokeGovernanceOverride, middlewares...))
+
 	// Team CRUD operations
 	r.GET("/api/governance/teams", lib.ChainMiddlewares(h.getTeams, middlewares...))
 	r.POST("/api/governance/teams", lib.ChainMiddlewares(h.createTeam, middlewares...))
@@ -1030,6 +1139,7 @@ func (h *GovernanceHandler) RegisterRoutes(r *router.Router, middlewares ...sche
 
 	// Budget and Rate Limit GET operations
 	r.GET("/api/governance/budgets", lib.ChainMiddlewares(h.getBudgets, middlewares...))
+	r.GET("/api/governance/budgets/{budget_id}/window", lib.ChainMiddlewares(h.getBudgetWindow, middlewares...))
 	r.GET("/api/governance/rate-limits", lib.ChainMiddlewares(h.getRateLimits, middlewares...))
 
 	// Routing Rules CRUD operations
@@ -1318,6 +1428,20 @@ func (h *GovernanceHandler) createVirtualKey(ctx *fasthttp.RequestCtx) {
 			return
 		}
 	}
+	if err := req.Defaults.Validate(); err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
+	priority, err := normalizeVirtualKeyPriority(req.Priority)
+	if err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
+	maxConcurrentRequests, err := normalizeVirtualKeyMaxConcurrentRequests(req.MaxConcurrentRequests)
+	if err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
 	// Set defaults: nil means "use DB default (true)"
 	isActive := req.IsActive
 	if isActive == nil {
@@ -1345,6 +1469,12 @@ func (h *GovernanceHandler) createVirtualKey(ctx *fasthttp.RequestCtx) {
 			IsActive:        isActive,
 			CalendarAligned: req.CalendarAligned,
 			ExpiresAt:       req.ExpiresAt,
+			Priority:        priority,
+
+			MaxConcurrentRequests: maxConcurrentRequests,
+		}
+		if !req.Defaults.IsEmpty() {
+			vk.Defaults = req.Defaults
 		}
 		if err := h.configStore.CreateVirtualKey(ctx, &vk, tx); err != nil {
 			return err
@@ -1645,6 +1775,23 @@ func (h *GovernanceHandler) updateVirtualKey(ctx *fasthttp.RequestCtx) {
 		}
 		newExpiresAt = &parsed
 	}
+	if err := req.Defaults.Validate(); err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
+	var newPriority string
+	if req.Priority != nil {
+		var err error
+		if newPriority, err = normalizeVirtualKeyPriority(*req.Priority); err != nil {
+			SendError(ctx, 400, err.Error())
+			return
+		}
+	}
+	newMaxConcurrentRequests, err := normalizeVirtualKeyMaxConcurrentRequests(req.MaxConcurrentRequests)
+	if err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
 	vk, err := h.configStore.GetVirtualKey(ctx, vkID)
 	if err != nil {
 		if errors.Is(err, configstore.ErrNotFound) {
@@ -1707,6 +1854,18 @@ func (h *GovernanceHandler) updateVirtualKey(ctx *fasthttp.RequestCtx) {
 		if req.CalendarAligned != nil {
 			vk.CalendarAligned = *req.CalendarAligned
 		}
+		if req.Defaults != nil {
+			vk.Defaults = req.Defaults
+			if vk.Defaults.IsEmpty() {
+				vk.Defaults = nil
+			}
+		}
+		if req.Priority != nil {
+			vk.Priority = newPriority
+		}
+		if req.MaxConcurrentRequests != nil {
+			vk.MaxConcurrentRequests = newMaxConcurrentRequests
+		}
 		// VK top-level and per-provider budgets/rate-limits are stored in VK-scoped model
 		// configs (the single source of truth), written by syncVKGovernanceToModelConfigs
 		// below. Per-provider desired state is accumulated while reconciling provider config rows.
@@ -2184,6 +2343,104 @@ func (h *GovernanceHandler) deleteVirtualKey(ctx *fasthttp.RequestCtx) {
 	})
 }
 
+// Governance Override Tokens
+
+// getGovernanceOverrides handles GET /api/governance/overrides - List every override, including expired and revoked ones
+func (h *GovernanceHandler) getGovernanceOverrides(ctx *fasthttp.RequestCtx) {
+	overrides, err := h.configStore.GetGovernanceOverrides(ctx)
+	if err != nil {
+		logger.Error("failed to retrieve governance overrides: %v", err)
+		SendError(ctx, 500, "Failed to retrieve governance overrides")
+		return
+	}
+	SendJSON(ctx, map[string]interface{}{
+		"overrides": overrides,
+		"count":     len(overrides),
+	})
+}
+
+// createGovernanceOverride handles POST /api/governance/overrides - Mint an override token
+func (h *GovernanceHandler) createGovernanceOverride(ctx *fasthttp.RequestCtx) {
+	var req CreateGovernanceOverrideRequest
+	if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+		SendError(ctx, 400, "Invalid JSON")
+		return
+	}
+	if err := configstoreTables.ValidateGovernanceOverrideControls(req.Controls); err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		SendError(ctx, 400, "reason is required")
+		return
+	}
+	if req.TTLSeconds < 0 {
+		SendError(ctx, 400, "ttl_seconds must be positive")
+		return
+	}
+	ttl := governance.DefaultGovernanceOverrideTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if ttl > governance.MaxGovernanceOverrideTTL {
+		SendError(ctx, 400, fmt.Sprintf("ttl_seconds must not exceed %d", int64(governance.MaxGovernanceOverrideTTL/time.Second)))
+		return
+	}
+	if req.VirtualKeyID != nil {
+		if _, err := h.configStore.GetVirtualKey(ctx, *req.VirtualKeyID); err != nil {
+			if errors.Is(err, configstore.ErrNotFound) {
+				SendError(ctx, 404, "Virtual key not found")
+				return
+			}
+			SendError(ctx, 500, "Failed to retrieve virtual key")
+			return
+		}
+	}
+
+	token := governance.GenerateGovernanceOverrideToken()
+	now := time.Now()
+	override := &configstoreTables.TableGovernanceOverride{
+		ID:           uuid.NewString(),
+		TokenHash:    encrypt.HashSHA256(token),
+		Controls:     req.Controls,
+		VirtualKeyID: req.VirtualKeyID,
+		Reason:       reason,
+		CreatedBy:    strings.TrimSpace(req.CreatedBy),
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}
+	if err := h.configStore.CreateGovernanceOverride(ctx, override); err != nil {
+		logger.Error("failed to create governance override: %v", err)
+		SendError(ctx, 500, "Failed to create governance override")
+		return
+	}
+	logger.Info("[governance] override %s minted by %q to bypass %s until %s; reason: %q",
+		override.ID, override.CreatedBy, strings.Join(override.Controls, ","), override.ExpiresAt.Format(time.RFC3339), override.Reason)
+	SendJSON(ctx, CreateGovernanceOverrideResponse{
+		Token:    token,
+		Override: override,
+	})
+}
+
+// revokeGovernanceOverride handles DELETE /api/governance/overrides/{override_id} - Revoke an override before it expires
+func (h *GovernanceHandler) revokeGovernanceOverride(ctx *fasthttp.RequestCtx) {
+	overrideID := ctx.UserValue("override_id").(string)
+	if err := h.configStore.RevokeGovernanceOverride(ctx, overrideID, time.Now()); err != nil {
+		if errors.Is(err, configstore.ErrNotFound) {
+			SendError(ctx, 404, "Governance override not found or already revoked")
+			return
+		}
+		logger.Error("failed to revoke governance override: %v", err)
+		SendError(ctx, 500, "Failed to revoke governance override")
+		return
+	}
+	logger.Info("[governance] override %s revoked", overrideID)
+	SendJSON(ctx, map[string]interface{}{
+		"message": "Governance override revoked successfully",
+	})
+}
+
 // Team CRUD Operations
 
 // getTeams handles GET /api/governance/teams - Get all teams
@@ -2449,7 +2706,9 @@ func (h *GovernanceHandler) updateTeam(ctx *fasthttp.RequestCtx) {
 			matchedIDs := make(map[string]bool)
 			for _, b := range req.Budgets {
 				if existing, found := existingByDuration[b.ResetDuration]; found {
-					existing.MaxLimit = b.MaxLimit
+					if err := applyBudgetRequest(&existing, b, time.Now()); err != nil {
+						return err
+					}
 					// LastReset / CurrentUsage are preserved on update; if calendar
 					// alignment was just enabled in this request, the post-reconciliation
 					// snap block below resets them.
@@ -3028,6 +3287,51 @@ func (h *GovernanceHandler) getBudgets(ctx *fasthttp.RequestCtx) {
 	})
 }
 
+// getBudgetWindow handles GET /api/governance/budgets/{budget_id}/window - Get the
+// accounting window a budget is currently counted over. It reads the in-memory
+// governance state, which carries live usage and the owner's calendar alignment.
+func (h *GovernanceHandler) getBudgetWindow(ctx *fasthttp.RequestCtx) {
+	budgetID := ctx.UserValue("budget_id").(string)
+	if h.governanceManager == nil {
+		SendError(ctx, 503, "Governance data is not available")
+		return
+	}
+	data := h.governanceManager.GetGovernanceData(ctx)
+	if data == nil {
+		SendError(ctx, 500, "Governance data is not available")
+		return
+	}
+	budget, ok := data.Budgets[budgetID]
+	if !ok || budget == nil {
+		SendError(ctx, 404, "Budget not found")
+		return
+	}
+	SendJSON(ctx, buildBudgetWindow(budget))
+}
+
+// buildBudgetWindow describes budget's current accounting window. A budget
+// with an unparseable reset duration reports an empty window.
+func buildBudgetWindow(budget *configstoreTables.TableBudget) BudgetWindowResponse {
+	schedule := "rolling"
+	if budget.IsCalendarAligned && configstoreTables.IsCalendarAlignableDuration(budget.ResetDuration) {
+		schedule = "calendar"
+	}
+	start, end, _ := budget.CurrentWindow()
+	cycleMaxLimit := budget.CycleMaxLimit()
+	return BudgetWindowResponse{
+		BudgetID:          budget.ID,
+		ResetDuration:     budget.ResetDuration,
+		Schedule:          schedule,
+		WindowStart:       start,
+		WindowEnd:         end,
+		MaxLimit:          budget.MaxLimit,
+		CycleMaxLimit:     cycleMaxLimit,
+		Prorated:          cycleMaxLimit != budget.MaxLimit,
+		EffectiveMaxLimit: budget.EffectiveMaxLimit(),
+		CurrentUsage:      budget.CurrentUsage,
+	}
+}
+
 // getRateLimits handles GET /api/governance/rate-limits - Get all rate limits
 func (h *GovernanceHandler) getRateLimits(ctx *fasthttp.RequestCtx) {
 	rateLimits, err := h.configStore.GetRateLimits(ctx)
@@ -4120,6 +4424,14 @@ func (h *GovernanceHandler) createRoutingRule(ctx *fasthttp.RequestCtx) {
 		SendError(ctx, 400, fmt.Sprintf("invalid CEL expression: %s", err.Error()))
 		return
 	}
+	ruleType := req.Type
+	if ruleType == "" {
+		ruleType = configstoreTables.RoutingRuleTypeCEL
+	}
+	if err := validateRoutingRuleType(ruleType, req.EmbeddingBuckets); err != nil {
+		SendError(ctx, 400, err.Error())
+		return
+	}
 
 	// Set defaults and normalize scope/scope_id
 	scope := req.Scope
@@ -4173,12 +4485,20 @@ func (h *GovernanceHandler) createRoutingRule(ctx *fasthttp.RequestCtx) {
 		Enabled:         enabled,
 		ChainRule:       chainRule,
 		CelExpression:   req.CelExpression,
+		Type:            ruleType,
 		Targets:         targets,
 		Scope:           scope,
 		ScopeID:         req.ScopeID,
 		Priority:        req.Priority,
 		ParsedFallbacks: req.Fallbacks,
 		ParsedQuery:     req.Query,
+
+		ParsedEmbeddingBuckets: req.EmbeddingBuckets,
+	}
+
+	if isDryRun(ctx) {
+		sendDryRun(ctx, rule)
+		return
 	}
 
 	// Create in database
@@ -4269,6 +4589,25 @@ func (h *GovernanceHandler) updateRoutingRule(ctx *fasthttp.RequestCtx) {
 	if req.Query != nil {
 		rule.ParsedQuery = req.Query
 	}
+	if req.Type != nil || req.EmbeddingBuckets != nil {
+		if req.Type != nil {
+			rule.Type = *req.Type
+		}
+		if rule.Type == "" {
+			rule.Type = configstoreTables.RoutingRuleTypeCEL
+		}
+		if req.EmbeddingBuckets != nil {
+			rule.ParsedEmbeddingBuckets = req.EmbeddingBuckets
+		}
+		// Switching a rule back to CEL drops its buckets.
+		if !rule.IsEmbeddingLengthRule() && req.EmbeddingBuckets == nil {
+			rule.ParsedEmbeddingBuckets = nil
+		}
+		if err := validateRoutingRuleType(rule.Type, rule.ParsedEmbeddingBuckets); err != nil {
+			SendError(ctx, 400, err.Error())
+			return
+		}
+	}
 	if req.Fallbacks != nil {
 		if err := validateRoutingFallbacks(req.Fallbacks); err != nil {
 			SendError(ctx, 400, err.Error())
@@ -4303,6 +4642,11 @@ func (h *GovernanceHandler) updateRoutingRule(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
+	if isDryRun(ctx) {
+		sendDryRun(ctx, rule)
+		return
+	}
+
 	// Update in database
 	if err := h.configStore.UpdateRoutingRule(ctx, rule); err != nil {
 		SendError(ctx, 500, fmt.Sprintf("Failed to update routing rule in database: %v", err))
@@ -4325,6 +4669,21 @@ func (h *GovernanceHandler) updateRoutingRule(ctx *fasthttp.RequestCtx) {
 func (h *GovernanceHandler) deleteRoutingRule(ctx *fasthttp.RequestCtx) {
 	ruleID := ctx.UserValue("rule_id").(string)
 
+	if isDryRun(ctx) {
+		rule, err := h.configStore.GetRoutingRule(ctx, ruleID)
+		if err != nil {
+			if errors.Is(err, configstore.ErrNotFound) {
+				SendError(ctx, 404, "Routing rule not found")
+				return
+			}
+			logger.Error("failed to get routing rule: %v", err)
+			SendError(ctx, 500, "Failed to retrieve routing rule")
+			return
+		}
+		sendDryRun(ctx, rule)
+		return
+	}
+
 	// Delete from database
 	if err := h.configStore.DeleteRoutingRule(ctx, ruleID); err != nil {
 		if errors.Is(err, configstore.ErrNotFound) {
@@ -4824,6 +5183,22 @@ func validateRoutingTargets(targets []RoutingTarget) error {
 	return nil
 }
 
+// validateRoutingRuleType checks the rule type and that embedding buckets are
+// set exactly when the rule routes embeddings by length.
+func validateRoutingRuleType(ruleType string, buckets []configstoreTables.RoutingEmbeddingBucket) error {
+	switch ruleType {
+	case configstoreTables.RoutingRuleTypeCEL:
+		if len(buckets) > 0 {
+			return fmt.Errorf("embedding_buckets require type %q", configstoreTables.RoutingRuleTypeEmbeddingLength)
+		}
+		return nil
+	case configstoreTables.RoutingRuleTypeEmbeddingLength:
+		return governance.ValidateRoutingEmbeddingBuckets(buckets)
+	default:
+		return fmt.Errorf("unknown routing rule type %q", ruleType)
+	}
+}
+
 // validateRoutingFallbacks ensures each fallback parses to a non-empty known provider via
 // schemas.ParseModelString (e.g. "openai/gpt-4o", or "azure/" to use the incoming model).
 func validateRoutingFallbacks(fallbacks []string) error {
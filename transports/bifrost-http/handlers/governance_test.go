@@ -542,6 +542,77 @@ func assertStringPtrEqual(t *testing.T, label string, got *string, want *string)
 	}
 }
 
+// TestApplyBudgetRequestProration verifies that prorate only blends a changed limit,
+// a resent unchanged limit keeps the cycle's proration, and a plain change drops it.
+func TestApplyBudgetRequestProration(t *testing.T) {
+	lastReset := time.Now().Add(-10 * 24 * time.Hour)
+	budget := configstoreTables.TableBudget{ID: "b1", MaxLimit: 100, ResetDuration: "20d", LastReset: lastReset}
+
+	if err := applyBudgetRequest(&budget, CreateBudgetRequest{MaxLimit: 300, ResetDuration: "20d", Prorate: true}, lastReset.Add(10*24*time.Hour)); err != nil {
+		t.Fatalf("prorate: %v", err)
+	}
+	if budget.MaxLimit != 300 || budget.CycleMaxLimit() != 200 {
+		t.Fatalf("expected max 300 and cycle limit 200, got %v and %v", budget.MaxLimit, budget.CycleMaxLimit())
+	}
+	if err := applyBudgetRequest(&budget, CreateBudgetRequest{MaxLimit: 300, ResetDuration: "20d"}, time.Now()); err != nil {
+		t.Fatalf("resend: %v", err)
+	}
+	if budget.CycleMaxLimit() != 200 {
+		t.Fatalf("resending the same limit must keep the proration, got cycle limit %v", budget.CycleMaxLimit())
+	}
+	if err := applyBudgetRequest(&budget, CreateBudgetRequest{MaxLimit: 50, ResetDuration: "20d"}, time.Now()); err != nil {
+		t.Fatalf("plain change: %v", err)
+	}
+	if budget.CycleMaxLimit() != 50 || budget.ProratedLimit != nil {
+		t.Fatalf("a change without prorate applies to the whole cycle, got %+v", budget)
+	}
+}
+
+func TestGetBudgetWindow(t *testing.T) {
+	SetLogger(&mockLogger{})
+	monthStart := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	prorated := 150.0
+	manager := &mockGovernanceManagerForVK{data: &governance.GovernanceData{
+		Budgets: map[string]*configstoreTables.TableBudget{
+			"b1": {
+				ID:                 "b1",
+				MaxLimit:           200,
+				ResetDuration:      "1M",
+				LastReset:          monthStart,
+				CurrentUsage:       42,
+				IsCalendarAligned:  true,
+				ProratedLimit:      &prorated,
+				ProratedCycleStart: &monthStart,
+			},
+		},
+	}}
+	handler := &GovernanceHandler{governanceManager: manager}
+
+	ctx := newTestRequestCtx("")
+	ctx.SetUserValue("budget_id", "b1")
+	handler.getBudgetWindow(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("status=%d body=%s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	var window BudgetWindowResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &window); err != nil {
+		t.Fatalf("decode window: %v", err)
+	}
+	if window.Schedule != "calendar" || !window.WindowEnd.Equal(time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected window: %+v", window)
+	}
+	if !window.Prorated || window.CycleMaxLimit != 150 || window.EffectiveMaxLimit != 150 || window.CurrentUsage != 42 {
+		t.Fatalf("unexpected limits: %+v", window)
+	}
+
+	missing := newTestRequestCtx("")
+	missing.SetUserValue("budget_id", "nope")
+	handler.getBudgetWindow(missing)
+	if missing.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 for unknown budget, got %d", missing.Response.StatusCode())
+	}
+}
+
 func TestFindExistingBudgetPrefersIDOverResetDuration(t *testing.T) {
 	monthlyBudget := configstoreTables.TableBudget{
 		ID:            "budget-monthly",
@@ -2806,6 +2877,33 @@ func TestValidateRoutingFallbacks(t *testing.T) {
 	}
 }
 
+func TestValidateRoutingRuleType(t *testing.T) {
+	buckets := []configstoreTables.RoutingEmbeddingBucket{{MaxInputTokens: 64, Model: "text-embedding-3-small"}}
+	tests := []struct {
+		name     string
+		ruleType string
+		buckets  []configstoreTables.RoutingEmbeddingBucket
+		wantErr  bool
+	}{
+		{name: "cel", ruleType: configstoreTables.RoutingRuleTypeCEL, wantErr: false},
+		{name: "cel with buckets rejected", ruleType: configstoreTables.RoutingRuleTypeCEL, buckets: buckets, wantErr: true},
+		{name: "embedding length", ruleType: configstoreTables.RoutingRuleTypeEmbeddingLength, buckets: buckets, wantErr: false},
+		{name: "embedding length without buckets rejected", ruleType: configstoreTables.RoutingRuleTypeEmbeddingLength, wantErr: true},
+		{name: "unknown type rejected", ruleType: "latency", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRoutingRuleType(tt.ruleType, tt.buckets)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // --- customer calendar_aligned handler tests ---
 
 type mockCustomerStore struct {
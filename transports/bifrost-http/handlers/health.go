@@ -7,25 +7,47 @@ import (
 
 	"github.com/fasthttp/router"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configversion"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
 	"github.com/valyala/fasthttp"
 )
 
 // HealthHandler manages HTTP requests for health checks.
 type HealthHandler struct {
-	config *lib.Config
+	config         *lib.Config
+	configVersions *configversion.Tracker
 }
 
-// NewHealthHandler creates a new health handler instance.
-func NewHealthHandler(config *lib.Config) *HealthHandler {
+// NewHealthHandler creates a new health handler instance. configVersions may be
+// nil when there is no config store to compare replicas' configs through.
+func NewHealthHandler(config *lib.Config, configVersions *configversion.Tracker) *HealthHandler {
 	return &HealthHandler{
-		config: config,
+		config:         config,
+		configVersions: configVersions,
 	}
 }
 
 // RegisterRoutes registers the health-related routes.
 func (h *HealthHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
 	r.GET("/health", lib.ChainMiddlewares(h.getHealth, middlewares...))
+	r.GET("/readyz", lib.ChainMiddlewares(h.getReadiness, middlewares...))
+}
+
+// getReadiness handles GET /readyz - Get whether the server is ready to serve,
+// along with how its config compares with the other replicas'. A divergence
+// past the grace period reports status "warning" but stays 200, so a rollout
+// that is itself the cause of the divergence is not blocked by it.
+func (h *HealthHandler) getReadiness(ctx *fasthttp.RequestCtx) {
+	if h.configVersions == nil {
+		SendJSON(ctx, map[string]any{"status": "ok", "components": map[string]any{"config_version": "disabled"}})
+		return
+	}
+	status := h.configVersions.Status()
+	readiness := "ok"
+	if status.State == configversion.StateDiverged {
+		readiness = "warning"
+	}
+	SendJSON(ctx, map[string]any{"status": readiness, "components": map[string]any{"config_version": status}})
 }
 
 // getHealth handles GET /api/health - Get the health status of the server.
@@ -350,6 +350,16 @@ var batchCreateParamsKnownFields = map[string]bool{
 	"metadata":          true,
 }
 
+var fineTuningJobCreateParamsKnownFields = map[string]bool{
+	"model":           true,
+	"training_file":   true,
+	"validation_file": true,
+	"suffix":          true,
+	"hyperparameters": true,
+	"seed":            true,
+	"metadata":        true,
+}
+
 var containerCreateParamsKnownFields = map[string]bool{
 	"provider":      true,
 	"name":          true,
@@ -592,6 +602,17 @@ type BatchListRequest struct {
 	Before   *string `json:"before,omitempty"` // Cursor for pagination
 }
 
+// FineTuningJobCreateRequest is a bifrost fine-tuning job create request
+type FineTuningJobCreateRequest struct {
+	Model           string                             `json:"model"`                     // Base model in "provider/model" format
+	TrainingFile    string                             `json:"training_file"`             // File ID (OpenAI) or gs:// / s3:// URI
+	ValidationFile  *string                            `json:"validation_file,omitempty"` // Optional validation dataset
+	Suffix          *string                            `json:"suffix,omitempty"`          // Name suffix for the tuned model
+	Hyperparameters *schemas.FineTuningHyperparameters `json:"hyperparameters,omitempty"`
+	Seed            *int                               `json:"seed,omitempty"`
+	Metadata        map[string]string                  `json:"metadata,omitempty"`
+}
+
 // ContainerCreateRequest is a bifrost container create request
 type ContainerCreateRequest struct {
 	Provider     string                         `json:"provider"`                // Provider name
@@ -678,6 +699,7 @@ const (
 var PathToTypeMapping = map[string]schemas.RequestType{
 	"/v1/completions":            schemas.TextCompletionRequest,
 	"/v1/chat/completions":       schemas.ChatCompletionRequest,
+	"/v1/compare":                schemas.ChatCompletionRequest,
 	"/v1/responses":              schemas.ResponsesRequest,
 	"/v1/embeddings":             schemas.EmbeddingRequest,
 	"/v1/rerank":                 schemas.RerankRequest,
@@ -686,6 +708,7 @@ var PathToTypeMapping = map[string]schemas.RequestType{
 	"/v1/audio/transcriptions":   schemas.TranscriptionRequest,
 	"/v1/images/generations":     schemas.ImageGenerationRequest,
 	"/v1/responses/input_tokens": schemas.CountTokensRequest,
+	"/v1/tokens/count":           schemas.CountTokensRequest,
 	"/v1/responses/compact":      schemas.CompactionRequest,
 	"/v1/images/edits":           schemas.ImageEditRequest,
 	"/v1/images/variations":      schemas.ImageVariationRequest,
@@ -724,6 +747,7 @@ func (h *CompletionHandler) RegisterRoutes(r *router.Router, middlewares ...sche
 	// Completion endpoints (non-parameterized)
 	r.POST("/v1/completions", lib.ChainMiddlewares(h.textCompletion, baseMiddlewares...))
 	r.POST("/v1/chat/completions", lib.ChainMiddlewares(h.chatCompletion, baseMiddlewares...))
+	r.POST("/v1/compare", lib.ChainMiddlewares(h.compare, baseMiddlewares...))
 	r.POST("/v1/responses", lib.ChainMiddlewares(h.responses, baseMiddlewares...))
 	responsesRetrieveMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.ResponsesRetrieveRequest)}, middlewares...)
 	responsesDeleteMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.ResponsesDeleteRequest)}, middlewares...)
@@ -743,10 +767,13 @@ func (h *CompletionHandler) RegisterRoutes(r *router.Router, middlewares ...sche
 	r.POST("/v1/audio/transcriptions", lib.ChainMiddlewares(h.transcription, baseMiddlewares...))
 	r.POST("/v1/images/generations", lib.ChainMiddlewares(h.imageGeneration, baseMiddlewares...))
 	r.POST("/v1/responses/input_tokens", lib.ChainMiddlewares(h.countTokens, baseMiddlewares...))
+	r.POST("/v1/tokens/count", lib.ChainMiddlewares(h.tokensCount, baseMiddlewares...))
 	r.POST("/v1/responses/compact", lib.ChainMiddlewares(h.compaction, baseMiddlewares...))
 	r.POST("/v1/images/edits", lib.ChainMiddlewares(h.imageEdit, baseMiddlewares...))
 	r.POST("/v1/images/variations", lib.ChainMiddlewares(h.imageVariation, baseMiddlewares...))
 	r.POST("/v1/videos", lib.ChainMiddlewares(h.videoGeneration, baseMiddlewares...))
+	// Broadcast stream subscriptions (see x-bf-broadcast)
+	r.GET("/v1/streams/{request_id}", lib.ChainMiddlewares(h.subscribeStream, middlewares...))
 
 	// Video API endpoints (parameterized routes need explicit request type middleware)
 	videoListMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.VideoListRequest)}, middlewares...)
@@ -773,6 +800,17 @@ func (h *CompletionHandler) RegisterRoutes(r *router.Router, middlewares ...sche
 	r.POST("/v1/batches/{batch_id}/cancel", lib.ChainMiddlewares(h.batchCancel, batchCancelMW...))
 	r.GET("/v1/batches/{batch_id}/results", lib.ChainMiddlewares(h.batchResults, batchResultsMW...))
 
+	// Fine-tuning API endpoints (parameterized routes need explicit request type middleware)
+	fineTuningJobCreateMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FineTuningJobCreateRequest)}, middlewares...)
+	fineTuningJobListMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FineTuningJobListRequest)}, middlewares...)
+	fineTuningJobRetrieveMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FineTuningJobRetrieveRequest)}, middlewares...)
+	fineTuningJobCancelMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FineTuningJobCancelRequest)}, middlewares...)
+
+	r.POST("/v1/fine_tuning/jobs", lib.ChainMiddlewares(h.fineTuningJobCreate, fineTuningJobCreateMW...))
+	r.GET("/v1/fine_tuning/jobs", lib.ChainMiddlewares(h.fineTuningJobList, fineTuningJobListMW...))
+	r.GET("/v1/fine_tuning/jobs/{job_id}", lib.ChainMiddlewares(h.fineTuningJobRetrieve, fineTuningJobRetrieveMW...))
+	r.POST("/v1/fine_tuning/jobs/{job_id}/cancel", lib.ChainMiddlewares(h.fineTuningJobCancel, fineTuningJobCancelMW...))
+
 	// File API endpoints (parameterized routes need explicit request type middleware)
 	fileUploadMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FileUploadRequest)}, middlewares...)
 	fileListMW := append([]schemas.BifrostHTTPMiddleware{createRequestTypeMiddleware(schemas.FileListRequest)}, middlewares...)
@@ -1611,6 +1649,52 @@ func (h *CompletionHandler) countTokens(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, response)
 }
 
+// tokensCount handles POST /v1/tokens/count - Counts the input tokens of a chat
+// (messages), text completion (prompt) or responses (input) body without running it.
+// Providers without a token counting API get a local estimate marked "estimated".
+func (h *CompletionHandler) tokensCount(ctx *fasthttp.RequestCtx) {
+	var shape struct {
+		Messages json.RawMessage `json:"messages"`
+		Prompt   json.RawMessage `json:"prompt"`
+	}
+	if err := sonic.Unmarshal(ctx.PostBody(), &shape); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	bifrostReq := &schemas.BifrostRequest{RequestType: schemas.CountTokensRequest}
+	var err error
+	switch {
+	case len(shape.Messages) > 0:
+		_, bifrostReq.ChatRequest, err = prepareChatCompletionRequest(ctx, h.config)
+	case len(shape.Prompt) > 0:
+		_, bifrostReq.TextCompletionRequest, err = prepareTextCompletionRequest(ctx, h.config)
+	default:
+		_, bifrostReq.ResponsesRequest, err = prepareResponsesRequest(ctx, h.config)
+	}
+	if err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+	defer cancel()
+
+	response, bifrostErr := h.client.CountTokens(bifrostCtx, bifrostReq)
+	if bifrostErr != nil {
+		forwardProviderHeadersFromContext(ctx, bifrostCtx)
+		SendBifrostError(ctx, bifrostErr)
+		return
+	}
+
+	lib.ApplyBifrostResponseHeaders(ctx, bifrostCtx, response.ExtraFields)
+	SendJSON(ctx, response)
+}
+
 func responsesLifecycleProviderFromQuery(ctx *fasthttp.RequestCtx) schemas.ModelProvider {
 	p := schemas.ModelProvider(string(ctx.QueryArgs().Peek("provider")))
 	if p == "" {
@@ -1992,6 +2076,9 @@ func (h *CompletionHandler) handleStreamingResponse(ctx *fasthttp.RequestCtx, bi
 	// which batches multiple SSE events into single TCP segments.
 	// Each event is delivered individually via a channel, ensuring one HTTP chunk per event.
 	reader := lib.NewSSEStreamReader()
+	reader.SetPacing(h.config.StreamPacingFor(string(ctx.Path())))
+	// Clients that sent x-bf-broadcast can be joined by others on GET /v1/streams/{request_id}.
+	reader.SetBroadcast(lib.StartStreamBroadcast(ctx, bifrostCtx, h.config.GetStreamBroadcasts()))
 	ctx.Response.SetBodyStream(reader, -1)
 
 	// Producer goroutine: processes the stream channel, formats SSE events, sends to reader
@@ -2134,6 +2221,7 @@ func (h *CompletionHandler) handleStreamingResponse(ctx *fasthttp.RequestCtx, bi
 				}
 			}
 
+			reader.Pace(lib.EstimateStreamChunkTokens(chunk))
 			if !reader.SendEvent(eventType, chunkJSON) {
 				cancel() // Client disconnected, cancel upstream stream
 				// Drain remaining chunks so the provider goroutine's defer
@@ -2152,6 +2240,15 @@ func (h *CompletionHandler) handleStreamingResponse(ctx *fasthttp.RequestCtx, bi
 		// once they see [DONE], so they'd be silently dropped.
 		runCompleter(true)
 
+		// Headers are long gone by now, so the spend summary (x-bf-cost etc. on
+		// buffered responses) travels as a final named event.
+		if spend, ok := lib.GetResponseSpend(bifrostCtx); ok {
+			if spendJSON, err := sonic.Marshal(spend); err == nil && !reader.SendEvent(lib.StreamSpendEventType, spendJSON) {
+				cancel()
+				return
+			}
+		}
+
 		if !includeEventType && !skipDoneMarker {
 			// Send the [DONE] marker to indicate the end of the stream (only for non-responses/image-gen APIs)
 			if !reader.SendDone() {
@@ -3323,6 +3420,205 @@ func (h *CompletionHandler) batchResults(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, resp)
 }
 
+// fineTuningJobCreate handles POST /v1/fine_tuning/jobs - Launch a fine-tuning job
+func (h *CompletionHandler) fineTuningJobCreate(ctx *fasthttp.RequestCtx) {
+	var req FineTuningJobCreateRequest
+	if err := sonic.Unmarshal(ctx.PostBody(), &req); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if req.Model == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "model is required")
+		return
+	}
+	if req.TrainingFile == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "training_file is required")
+		return
+	}
+
+	provider, modelName, err := resolveModelAndProvider(ctx, h.config, req.Model)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Extract extra params (e.g. Bedrock role_arn / output_s3_uri)
+	extraParams, err := extractExtraParams(ctx.PostBody(), fineTuningJobCreateParamsKnownFields)
+	if err != nil {
+		logger.Warn("Failed to extract extra params: %v", err)
+	}
+
+	bifrostFineTuningReq := &schemas.BifrostFineTuningJobCreateRequest{
+		Provider:        provider,
+		Model:           modelName,
+		TrainingFile:    req.TrainingFile,
+		ValidationFile:  req.ValidationFile,
+		Suffix:          req.Suffix,
+		Hyperparameters: req.Hyperparameters,
+		Seed:            req.Seed,
+		Metadata:        req.Metadata,
+		ExtraParams:     extraParams,
+	}
+
+	// Convert context
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	defer cancel()
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+
+	resp, bifrostErr := h.client.FineTuningJobCreateRequest(bifrostCtx, bifrostFineTuningReq)
+	if bifrostErr != nil {
+		forwardProviderHeadersFromContext(ctx, bifrostCtx)
+		SendBifrostError(ctx, bifrostErr)
+		return
+	}
+
+	if resp != nil {
+		lib.ApplyBifrostResponseHeaders(ctx, bifrostCtx, resp.ExtraFields)
+	}
+	SendJSON(ctx, resp)
+}
+
+// fineTuningJobList handles GET /v1/fine_tuning/jobs - List fine-tuning jobs
+func (h *CompletionHandler) fineTuningJobList(ctx *fasthttp.RequestCtx) {
+	// Get provider from query parameters
+	provider := string(ctx.QueryArgs().Peek("provider"))
+	if provider == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "provider query parameter is required")
+		return
+	}
+
+	// Parse limit parameter
+	limit := 0
+	if limitStr := ctx.QueryArgs().Peek("limit"); len(limitStr) > 0 {
+		if n, err := strconv.Atoi(string(limitStr)); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	var after *string
+	if afterStr := ctx.QueryArgs().Peek("after"); len(afterStr) > 0 {
+		s := string(afterStr)
+		after = &s
+	}
+
+	bifrostFineTuningReq := &schemas.BifrostFineTuningJobListRequest{
+		Provider: schemas.ModelProvider(provider),
+		Limit:    limit,
+		After:    after,
+	}
+
+	// Convert context
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	defer cancel()
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+
+	resp, bifrostErr := h.client.FineTuningJobListRequest(bifrostCtx, bifrostFineTuningReq)
+	if bifrostErr != nil {
+		forwardProviderHeadersFromContext(ctx, bifrostCtx)
+		SendBifrostError(ctx, bifrostErr)
+		return
+	}
+
+	if resp != nil {
+		lib.ApplyBifrostResponseHeaders(ctx, bifrostCtx, resp.ExtraFields)
+	}
+	SendJSON(ctx, resp)
+}
+
+// fineTuningJobPathParams reads the job ID and provider shared by the per-job routes.
+func fineTuningJobPathParams(ctx *fasthttp.RequestCtx) (jobID string, provider string, ok bool) {
+	jobID, _ = ctx.UserValue("job_id").(string)
+	if jobID == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "job_id is required")
+		return "", "", false
+	}
+	// Decode percent-encoding so Vertex resource names and Bedrock job ARNs
+	// sent with %2F reach the provider raw, not double-encoded.
+	if decoded, err := url.PathUnescape(jobID); err == nil {
+		jobID = decoded
+	}
+
+	provider = string(ctx.QueryArgs().Peek("provider"))
+	if provider == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "provider query parameter is required")
+		return "", "", false
+	}
+	return jobID, provider, true
+}
+
+// fineTuningJobRetrieve handles GET /v1/fine_tuning/jobs/{job_id} - Poll a fine-tuning job
+func (h *CompletionHandler) fineTuningJobRetrieve(ctx *fasthttp.RequestCtx) {
+	jobID, provider, ok := fineTuningJobPathParams(ctx)
+	if !ok {
+		return
+	}
+
+	bifrostFineTuningReq := &schemas.BifrostFineTuningJobRetrieveRequest{
+		Provider: schemas.ModelProvider(provider),
+		JobID:    jobID,
+	}
+
+	// Convert context
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	defer cancel()
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+
+	resp, bifrostErr := h.client.FineTuningJobRetrieveRequest(bifrostCtx, bifrostFineTuningReq)
+	if bifrostErr != nil {
+		forwardProviderHeadersFromContext(ctx, bifrostCtx)
+		SendBifrostError(ctx, bifrostErr)
+		return
+	}
+
+	if resp != nil {
+		lib.ApplyBifrostResponseHeaders(ctx, bifrostCtx, resp.ExtraFields)
+	}
+	SendJSON(ctx, resp)
+}
+
+// fineTuningJobCancel handles POST /v1/fine_tuning/jobs/{job_id}/cancel - Cancel a fine-tuning job
+func (h *CompletionHandler) fineTuningJobCancel(ctx *fasthttp.RequestCtx) {
+	jobID, provider, ok := fineTuningJobPathParams(ctx)
+	if !ok {
+		return
+	}
+
+	bifrostFineTuningReq := &schemas.BifrostFineTuningJobCancelRequest{
+		Provider: schemas.ModelProvider(provider),
+		JobID:    jobID,
+	}
+
+	// Convert context
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	defer cancel()
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+
+	resp, bifrostErr := h.client.FineTuningJobCancelRequest(bifrostCtx, bifrostFineTuningReq)
+	if bifrostErr != nil {
+		forwardProviderHeadersFromContext(ctx, bifrostCtx)
+		SendBifrostError(ctx, bifrostErr)
+		return
+	}
+
+	if resp != nil {
+		lib.ApplyBifrostResponseHeaders(ctx, bifrostCtx, resp.ExtraFields)
+	}
+	SendJSON(ctx, resp)
+}
+
 // encodeStorageFileID makes a storage-URI file id (gs://...) opaque and path-safe so
 // callers can use it in retrieve/delete/content without percent-encoding slashes.
 // Non-URI ids (OpenAI/Gemini/Anthropic) pass through unchanged. The response's
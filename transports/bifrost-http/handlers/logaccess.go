@@ -0,0 +1,100 @@
+// Package handlers provides HTTP request handlers for the Bifrost HTTP transport.
+// This file contains the audit trail of who viewed which logs.
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/valyala/fasthttp"
+)
+
+// unauthenticatedActor names the viewer of a log when auth is disabled.
+const unauthenticatedActor = "unauthenticated"
+
+// LogAccessAuditStore is the narrow surface the logging endpoints need to
+// record and list log views. configstore.ConfigStore satisfies this.
+type LogAccessAuditStore interface {
+	CreateLogAccessEvents(ctx context.Context, events []tables.TableLogAccessEvent) error
+	GetLogAccessEvents(ctx context.Context, logID string, limit int) ([]tables.TableLogAccessEvent, error)
+}
+
+// SetLogAccessAuditStore wires the store that records who viewed which logs.
+// Until it is set no views are recorded and GET /api/logs/{id}/access returns 503.
+func (h *LoggingHandler) SetLogAccessAuditStore(store LogAccessAuditStore) {
+	h.accessAudit = store
+}
+
+// logAccessActor names who is viewing logs: the enterprise user when one is
+// signed in, otherwise the configured admin.
+func logAccessActor(ctx *fasthttp.RequestCtx) string {
+	for _, key := range []schemas.BifrostContextKey{schemas.BifrostContextKeyUserName, schemas.BifrostContextKeyUserID, schemas.BifrostContextKeyAdminUserName} {
+		if actor, ok := ctx.UserValue(key).(string); ok && actor != "" {
+			return actor
+		}
+	}
+	return unauthenticatedActor
+}
+
+// recordLogAccess stores one audit record per log served by the request. Only
+// identifiers are stored, never log content. A failed write is logged rather
+// than reported, since the logs have already been read.
+func (h *LoggingHandler) recordLogAccess(ctx *fasthttp.RequestCtx, access string, logIDs []string) {
+	if h.accessAudit == nil || len(logIDs) == 0 {
+		return
+	}
+	actor := logAccessActor(ctx)
+	endpoint := string(ctx.Path())
+	now := time.Now().UTC()
+	events := make([]tables.TableLogAccessEvent, 0, len(logIDs))
+	for _, id := range logIDs {
+		events = append(events, tables.TableLogAccessEvent{
+			ID:        uuid.NewString(),
+			LogID:     id,
+			Access:    access,
+			Actor:     actor,
+			Endpoint:  endpoint,
+			CreatedAt: now,
+		})
+	}
+	if err := h.accessAudit.CreateLogAccessEvents(ctx, events); err != nil {
+		logger.Error("failed to store access records for %d logs viewed by %q: %v", len(logIDs), actor, err)
+	}
+}
+
+// getLogAccess handles GET /api/logs/{id}/access - List who viewed a log, newest first
+func (h *LoggingHandler) getLogAccess(ctx *fasthttp.RequestCtx) {
+	if h.accessAudit == nil {
+		SendError(ctx, fasthttp.StatusServiceUnavailable, "Log access audit is not available")
+		return
+	}
+	id, ok := ctx.UserValue("id").(string)
+	if !ok || id == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "log id is required")
+		return
+	}
+	limit := 100
+	if raw := string(ctx.QueryArgs().Peek("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > 1000 {
+			SendError(ctx, fasthttp.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	events, err := h.accessAudit.GetLogAccessEvents(ctx, id, limit)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to list log access: %v", err))
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"log_id": id,
+		"events": events,
+		"count":  len(events),
+	})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// accessLogManager serves fixed logs to the list and detail endpoints.
+type accessLogManager struct {
+	dashboardLogManager
+}
+
+func (m *accessLogManager) GetLog(ctx context.Context, id string) (*logstore.Log, error) {
+	return &logstore.Log{ID: id}, nil
+}
+
+func (m *accessLogManager) Search(ctx context.Context, filters *logstore.SearchFilters, pagination *logstore.PaginationOptions) (*logstore.SearchResult, error) {
+	return &logstore.SearchResult{Logs: []logstore.Log{{ID: "log-1"}, {ID: "log-2"}}}, nil
+}
+
+// noRedactedKeys resolves no keys, virtual keys or routing rules.
+type noRedactedKeys struct{}
+
+func (noRedactedKeys) GetAllRedactedKeys(context.Context, []string) []schemas.Key { return nil }
+func (noRedactedKeys) GetAllRedactedVirtualKeys(context.Context, []string) []tables.TableVirtualKey {
+	return nil
+}
+func (noRedactedKeys) GetAllRedactedRoutingRules(context.Context, []string) []tables.TableRoutingRule {
+	return nil
+}
+
+func logRequest(path string, userValues map[any]any) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.SetRequestURI(path)
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, nil, nil)
+	for key, value := range userValues {
+		ctx.SetUserValue(key, value)
+	}
+	return ctx
+}
+
+func TestLogAccessIsAudited(t *testing.T) {
+	SetLogger(&mockLogger{})
+	store := newTestConfigStore(t)
+	h := NewLoggingHandler(&accessLogManager{}, noRedactedKeys{}, nil)
+	h.SetLogAccessAuditStore(store)
+
+	h.getLogs(logRequest("/api/logs", map[any]any{schemas.BifrostContextKeyAdminUserName: "admin"}))
+	time.Sleep(time.Millisecond) // order the two views by time
+	h.getLogByID(logRequest("/api/logs/log-1", map[any]any{
+		"id":                                   "log-1",
+		schemas.BifrostContextKeyAdminUserName: "admin",
+		schemas.BifrostContextKeyUserName:      "jane@example.com",
+	}))
+
+	ctx := logRequest("/api/logs/log-1/access", map[any]any{"id": "log-1"})
+	h.getLogAccess(ctx)
+	require.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode(), string(ctx.Response.Body()))
+	var response struct {
+		Events []tables.TableLogAccessEvent `json:"events"`
+		Count  int                          `json:"count"`
+	}
+	require.NoError(t, sonic.Unmarshal(ctx.Response.Body(), &response))
+	require.Equal(t, 2, response.Count)
+	assert.Equal(t, tables.LogAccessContent, response.Events[0].Access)
+	assert.Equal(t, "jane@example.com", response.Events[0].Actor, "the signed-in user wins over the admin")
+	assert.Equal(t, "/api/logs/log-1", response.Events[0].Endpoint)
+	assert.Equal(t, tables.LogAccessMetadata, response.Events[1].Access)
+	assert.Equal(t, "admin", response.Events[1].Actor)
+
+	events, err := store.GetLogAccessEvents(context.Background(), "log-2", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, tables.LogAccessMetadata, events[0].Access)
+
+	// Access records expire with the other audit records.
+	retention, ok := store.(logstore.AuditRetentionManager)
+	require.True(t, ok)
+	deleted, err := retention.DeleteAuditRecordsBatch(context.Background(), time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, deleted)
+}
+
+func TestLogAccessWithoutAuditStore(t *testing.T) {
+	h := NewLoggingHandler(&accessLogManager{}, noRedactedKeys{}, nil)
+	h.getLogByID(logRequest("/api/logs/log-1", map[any]any{"id": "log-1"}))
+
+	ctx := logRequest("/api/logs/log-1/access", map[any]any{"id": "log-1"})
+	h.getLogAccess(ctx)
+	assert.Equal(t, fasthttp.StatusServiceUnavailable, ctx.Response.StatusCode())
+	assert.Equal(t, unauthenticatedActor, logAccessActor(ctx))
+}
+
+// reproducibilityLogManager serves logs keyed by ID; unknown IDs are not found.
+type reproducibilityLogManager struct {
+	dashboardLogManager
+	logs map[string]*logstore.Log
+}
+
+func (m *reproducibilityLogManager) GetLog(ctx context.Context, id string) (*logstore.Log, error) {
+	if log, ok := m.logs[id]; ok {
+		return log, nil
+	}
+	return nil, logstore.ErrNotFound
+}
+
+func TestGetLogReproducibility(t *testing.T) {
+	SetLogger(&mockLogger{})
+	h := NewLoggingHandler(&reproducibilityLogManager{logs: map[string]*logstore.Log{
+		"log-1": {ID: "log-1", ReproducibilityParsed: &logstore.ReproducibilityBundle{
+			Provider:          "openai",
+			ResolvedModel:     "gpt-4o-mini",
+			SystemFingerprint: "fp_abc",
+			Seed:              schemas.Ptr(42),
+		}},
+		"hidden": {ID: "hidden", ContentHidden: true, ReproducibilityParsed: &logstore.ReproducibilityBundle{Provider: "openai"}},
+		"legacy": {ID: "legacy"},
+	}}, noRedactedKeys{}, nil)
+
+	ctx := logRequest("/api/logs/log-1/reproducibility", map[any]any{"id": "log-1"})
+	h.getLogReproducibility(ctx)
+	require.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode(), string(ctx.Response.Body()))
+	var response struct {
+		LogID           string                         `json:"log_id"`
+		Reproducibility logstore.ReproducibilityBundle `json:"reproducibility"`
+	}
+	require.NoError(t, sonic.Unmarshal(ctx.Response.Body(), &response))
+	assert.Equal(t, "log-1", response.LogID)
+	assert.Equal(t, "fp_abc", response.Reproducibility.SystemFingerprint)
+	require.NotNil(t, response.Reproducibility.Seed)
+	assert.Equal(t, 42, *response.Reproducibility.Seed)
+
+	for _, id := range []string{"hidden", "legacy", "missing"} {
+		ctx := logRequest("/api/logs/"+id+"/reproducibility", map[any]any{"id": id})
+		h.getLogReproducibility(ctx)
+		assert.Equal(t, fasthttp.StatusNotFound, ctx.Response.StatusCode(), id)
+	}
+}
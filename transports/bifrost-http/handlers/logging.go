@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -45,6 +46,10 @@ type LoggingHandler struct {
 	// in which case the recalculate-cost endpoints return 503.
 	sidekiqRunner *sidekiq.Runner
 	sidekiqStore  SidekiqJobStore
+
+	// accessAudit records who viewed which logs. Nil until SetLogAccessAuditStore
+	// wires it, in which case views are not recorded.
+	accessAudit LogAccessAuditStore
 }
 
 // SidekiqJobStore is the narrow read surface the recalculate-cost endpoints need
@@ -266,9 +271,13 @@ func (h *LoggingHandler) shouldHideDeletedVirtualKeysInFilters() bool {
 func (h *LoggingHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
 	// LLM Log retrieval with filtering, search, and pagination
 	r.GET("/api/logs", lib.ChainMiddlewares(h.getLogs, middlewares...))
+	r.GET("/api/sessions", lib.ChainMiddlewares(h.getSessions, middlewares...))
+	r.GET("/api/sessions/{id}/export", lib.ChainMiddlewares(h.exportSession, middlewares...))
 	r.GET("/api/logs/sessions/{session_id}/summary", lib.ChainMiddlewares(h.getLogSessionSummaryByID, middlewares...))
 	r.GET("/api/logs/sessions/{session_id}", lib.ChainMiddlewares(h.getLogSessionByID, middlewares...))
 	r.GET("/api/logs/{id}", lib.ChainMiddlewares(h.getLogByID, middlewares...))
+	r.GET("/api/logs/{id}/access", lib.ChainMiddlewares(h.getLogAccess, middlewares...))
+	r.GET("/api/logs/{id}/reproducibility", lib.ChainMiddlewares(h.getLogReproducibility, middlewares...))
 	r.GET("/api/logs/stats", lib.ChainMiddlewares(h.getLogsStats, middlewares...))
 	r.GET("/api/logs/histogram", lib.ChainMiddlewares(h.getLogsHistogram, middlewares...))
 	r.GET("/api/logs/histogram/tokens", lib.ChainMiddlewares(h.getLogsTokenHistogram, middlewares...))
@@ -284,9 +293,11 @@ func (h *LoggingHandler) RegisterRoutes(r *router.Router, middlewares ...schemas
 	r.GET("/api/logs/histogram/tokens/by-dimension", lib.ChainMiddlewares(h.getLogsDimensionTokenHistogram, middlewares...))
 	r.GET("/api/logs/histogram/latency/by-dimension", lib.ChainMiddlewares(h.getLogsDimensionLatencyHistogram, middlewares...))
 	r.GET("/api/logs/dropped", lib.ChainMiddlewares(h.getDroppedRequests, middlewares...))
+	r.GET("/api/logs/prefix-cache", lib.ChainMiddlewares(h.getPrefixCacheStats, middlewares...))
 	r.GET("/api/logs/filterdata", lib.ChainMiddlewares(h.getAvailableFilterData, middlewares...))
 	r.GET("/api/logs/rankings", lib.ChainMiddlewares(h.getModelRankings, middlewares...))
 	r.GET("/api/logs/rankings/by-dimension", lib.ChainMiddlewares(h.getDimensionRankings, middlewares...))
+	r.GET("/api/recommendations", lib.ChainMiddlewares(h.getModelRecommendations, middlewares...))
 	// Consolidated, public-facing dashboard payload (all of the above in one call)
 	r.GET("/api/logs/dashboard", lib.ChainMiddlewares(h.getDashboard, middlewares...))
 	r.DELETE("/api/logs", lib.ChainMiddlewares(h.deleteLogs, middlewares...))
@@ -403,6 +414,12 @@ func (h *LoggingHandler) getLogSessionByID(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
+	logIDs := make([]string, 0, len(result.Logs))
+	for _, log := range result.Logs {
+		logIDs = append(logIDs, log.ID)
+	}
+	h.recordLogAccess(ctx, tables.LogAccessMetadata, logIDs)
+
 	SendJSON(ctx, result)
 }
 
@@ -424,6 +441,67 @@ func (h *LoggingHandler) getLogSessionSummaryByID(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, result)
 }
 
+// getSessions handles GET /api/sessions - List per-session token, cost, turn and tool execution rollups.
+// order_by is one of cost, tokens, turns, tool_executions, started_at or latest_at (default).
+func (h *LoggingHandler) getSessions(ctx *fasthttp.RequestCtx) {
+	filters := &logstore.SessionRollupSearchFilters{}
+	if startTime := string(ctx.QueryArgs().Peek("start_time")); startTime != "" {
+		if t, err := time.Parse(time.RFC3339Nano, startTime); err == nil {
+			filters.StartTime = &t
+		}
+	}
+	if endTime := string(ctx.QueryArgs().Peek("end_time")); endTime != "" {
+		if t, err := time.Parse(time.RFC3339Nano, endTime); err == nil {
+			filters.EndTime = &t
+		}
+	}
+
+	pagination := &logstore.PaginationOptions{Limit: 50, SortBy: "latest_at", Order: "desc"}
+	if limit := string(ctx.QueryArgs().Peek("limit")); limit != "" {
+		if i, err := strconv.Atoi(limit); err == nil {
+			if i <= 0 {
+				SendError(ctx, fasthttp.StatusBadRequest, "limit must be greater than 0")
+				return
+			}
+			if i > 1000 {
+				SendError(ctx, fasthttp.StatusBadRequest, "limit cannot exceed 1000")
+				return
+			}
+			pagination.Limit = i
+		}
+	}
+	if offset := string(ctx.QueryArgs().Peek("offset")); offset != "" {
+		if i, err := strconv.Atoi(offset); err == nil {
+			if i < 0 {
+				SendError(ctx, fasthttp.StatusBadRequest, "offset cannot be negative")
+				return
+			}
+			pagination.Offset = i
+		}
+	}
+	if orderBy := string(ctx.QueryArgs().Peek("order_by")); orderBy != "" {
+		switch orderBy {
+		case "cost", "tokens", "turns", "tool_executions", "started_at", "latest_at":
+			pagination.SortBy = orderBy
+		default:
+			SendError(ctx, fasthttp.StatusBadRequest, "invalid order_by: must be 'cost', 'tokens', 'turns', 'tool_executions', 'started_at' or 'latest_at'")
+			return
+		}
+	}
+	if order := string(ctx.QueryArgs().Peek("order")); order == "asc" || order == "desc" {
+		pagination.Order = order
+	}
+
+	result, err := h.logManager.SearchSessions(ctx, filters, pagination)
+	if err != nil {
+		logger.Error("failed to search sessions: %v", err)
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Session search failed: %v", err))
+		return
+	}
+
+	SendJSON(ctx, result)
+}
+
 // getLogs handles GET /api/logs - Get logs with filtering, search, and pagination via query parameters
 func (h *LoggingHandler) getLogs(ctx *fasthttp.RequestCtx) {
 	// Parse query parameters into filters
@@ -527,6 +605,11 @@ func (h *LoggingHandler) getLogs(ctx *fasthttp.RequestCtx) {
 			filters.MissingCostOnly = val
 		}
 	}
+	if byok := string(ctx.QueryArgs().Peek("byok")); byok != "" {
+		if val, err := strconv.ParseBool(byok); err == nil {
+			filters.BYOK = &val
+		}
+	}
 	if cacheHitTypes := string(ctx.QueryArgs().Peek("cache_hit_types")); cacheHitTypes != "" {
 		filters.CacheHitTypes = parseCommaSeparated(cacheHitTypes)
 	}
@@ -627,6 +710,12 @@ func (h *LoggingHandler) getLogs(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
+	logIDs := make([]string, 0, len(result.Logs))
+	for _, log := range result.Logs {
+		logIDs = append(logIDs, log.ID)
+	}
+	h.recordLogAccess(ctx, tables.LogAccessMetadata, logIDs)
+
 	SendJSON(ctx, result)
 }
 
@@ -672,9 +761,42 @@ func (h *LoggingHandler) getLogByID(ctx *fasthttp.RequestCtx) {
 		log.RoutingRule = findRedactedRoutingRule(redactedRoutingRules, *log.RoutingRuleID, *log.RoutingRuleName)
 	}
 
+	h.recordLogAccess(ctx, tables.LogAccessContent, []string{log.ID})
+
 	SendJSON(ctx, log)
 }
 
+// getLogReproducibility handles GET /api/logs/{id}/reproducibility - Get the configuration a request ran with, for rerunning it
+func (h *LoggingHandler) getLogReproducibility(ctx *fasthttp.RequestCtx) {
+	id, ok := ctx.UserValue("id").(string)
+	if !ok || id == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "log id is required")
+		return
+	}
+
+	log, err := h.logManager.GetLog(ctx, id)
+	if err != nil {
+		if errors.Is(err, logstore.ErrNotFound) {
+			SendError(ctx, fasthttp.StatusNotFound, "log not found")
+			return
+		}
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to get log: %v", err))
+		return
+	}
+	// The bundle is stored with the request payload, so it is hidden with it.
+	if log.ContentHidden || log.ReproducibilityParsed == nil {
+		SendError(ctx, fasthttp.StatusNotFound, "no reproducibility data recorded for this log")
+		return
+	}
+
+	h.recordLogAccess(ctx, tables.LogAccessContent, []string{log.ID})
+
+	SendJSON(ctx, map[string]any{
+		"log_id":          log.ID,
+		"reproducibility": log.ReproducibilityParsed,
+	})
+}
+
 // getLogsStats handles GET /api/logs/stats - Get statistics for logs with filtering
 func (h *LoggingHandler) getLogsStats(ctx *fasthttp.RequestCtx) {
 	// Parse query parameters into filters (same as getLogs)
@@ -777,6 +899,11 @@ func (h *LoggingHandler) getLogsStats(ctx *fasthttp.RequestCtx) {
 			filters.MissingCostOnly = val
 		}
 	}
+	if byok := string(ctx.QueryArgs().Peek("byok")); byok != "" {
+		if val, err := strconv.ParseBool(byok); err == nil {
+			filters.BYOK = &val
+		}
+	}
 	if cacheHitTypes := string(ctx.QueryArgs().Peek("cache_hit_types")); cacheHitTypes != "" {
 		filters.CacheHitTypes = parseCommaSeparated(cacheHitTypes)
 	}
@@ -936,6 +1063,11 @@ func parseHistogramFilters(ctx *fasthttp.RequestCtx) *logstore.SearchFilters {
 			filters.MissingCostOnly = val
 		}
 	}
+	if byok := string(ctx.QueryArgs().Peek("byok")); byok != "" {
+		if val, err := strconv.ParseBool(byok); err == nil {
+			filters.BYOK = &val
+		}
+	}
 	if cacheHitTypes := string(ctx.QueryArgs().Peek("cache_hit_types")); cacheHitTypes != "" {
 		filters.CacheHitTypes = parseCommaSeparated(cacheHitTypes)
 	}
@@ -1160,6 +1292,25 @@ func (h *LoggingHandler) getDroppedRequests(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, map[string]int64{"dropped_requests": droppedRequests})
 }
 
+// getPrefixCacheStats handles GET /api/logs/prefix-cache - Get prompt prefix reuse rates and
+// estimated cache-eligible spend per route and virtual key, optionally narrowed by the
+// comma-separated routes (provider/model) and virtual_key_ids query params.
+func (h *LoggingHandler) getPrefixCacheStats(ctx *fasthttp.RequestCtx) {
+	routes := parseCommaSeparated(string(ctx.QueryArgs().Peek("routes")))
+	virtualKeyIDs := parseCommaSeparated(string(ctx.QueryArgs().Peek("virtual_key_ids")))
+	stats := make([]logging.PrefixCacheStats, 0)
+	for _, s := range h.logManager.GetPrefixCacheStats() {
+		if len(routes) > 0 && !slices.Contains(routes, s.Route) {
+			continue
+		}
+		if len(virtualKeyIDs) > 0 && !slices.Contains(virtualKeyIDs, s.VirtualKeyID) {
+			continue
+		}
+		stats = append(stats, s)
+	}
+	SendJSON(ctx, map[string]any{"prefixes": stats})
+}
+
 // getModelRankings handles GET /api/logs/rankings - Get models ranked by usage with trends
 func (h *LoggingHandler) getModelRankings(ctx *fasthttp.RequestCtx) {
 	filters := parseHistogramFilters(ctx)
@@ -2304,6 +2455,8 @@ func (h *LoggingHandler) getMCPLogByID(ctx *fasthttp.RequestCtx) {
 		log.VirtualKey = findRedactedVirtualKey(redactedVirtualKeys, *log.VirtualKeyID, *log.VirtualKeyName)
 	}
 
+	h.recordLogAccess(ctx, tables.LogAccessContent, []string{log.ID})
+
 	SendJSON(ctx, log)
 }
 
@@ -293,6 +293,7 @@ func (s *fakeSidekiqStore) ListClaimableSidekiqJobs(ctx context.Context, staleBe
 
 type dashboardLogManager struct {
 	failStats              bool
+	conversation           *loggingplugin.SessionConversation
 	lastLLMFilters         logstore.SearchFilters
 	lastMCPFilters         logstore.MCPToolLogSearchFilters
 	lastRecalculateFilters logstore.SearchFilters
@@ -311,6 +312,15 @@ func (m *dashboardLogManager) GetSessionLogs(ctx context.Context, sessionID stri
 func (m *dashboardLogManager) GetSessionSummary(ctx context.Context, sessionID string) (*logstore.SessionSummaryResult, error) {
 	return nil, nil
 }
+func (m *dashboardLogManager) GetSessionConversation(ctx context.Context, sessionID string) (*loggingplugin.SessionConversation, error) {
+	if m.conversation == nil || m.conversation.SessionID != sessionID {
+		return nil, logstore.ErrNotFound
+	}
+	return m.conversation, nil
+}
+func (m *dashboardLogManager) SearchSessions(ctx context.Context, filters *logstore.SessionRollupSearchFilters, pagination *logstore.PaginationOptions) (*logstore.SessionRollupSearchResult, error) {
+	return nil, nil
+}
 func (m *dashboardLogManager) GetStats(ctx context.Context, filters *logstore.SearchFilters) (*logstore.SearchStats, error) {
 	m.lastLLMFilters = *filters
 	if m.failStats {
@@ -351,10 +361,19 @@ func (m *dashboardLogManager) GetProviderThroughputHistogram(ctx context.Context
 func (m *dashboardLogManager) GetModelRankings(ctx context.Context, filters *logstore.SearchFilters) (*logstore.ModelRankingResult, error) {
 	return &logstore.ModelRankingResult{}, nil
 }
+func (m *dashboardLogManager) GetModelUsageProfiles(ctx context.Context, filters *logstore.SearchFilters, shortPromptTokens int) (*logstore.ModelUsageProfileResult, error) {
+	return &logstore.ModelUsageProfileResult{}, nil
+}
 func (m *dashboardLogManager) GetDimensionRankings(ctx context.Context, filters *logstore.SearchFilters, dimension logstore.RankingDimension) (*logstore.DimensionRankingResult, error) {
 	return &logstore.DimensionRankingResult{Dimension: dimension}, nil
 }
 func (m *dashboardLogManager) GetDroppedRequests(ctx context.Context) int64 { return 0 }
+func (m *dashboardLogManager) GetPrefixCacheStats() []loggingplugin.PrefixCacheStats {
+	return []loggingplugin.PrefixCacheStats{
+		{Route: "anthropic/claude-sonnet-4", VirtualKeyID: "vk-1", PrefixRequests: 4, ReusedRequests: 3, ReuseRate: 0.75},
+		{Route: "openai/gpt-4o", VirtualKeyID: "vk-2", PrefixRequests: 2},
+	}
+}
 func (m *dashboardLogManager) GetAvailableModels(ctx context.Context, limit int, query string) ([]string, error) {
 	return nil, nil
 }
@@ -449,3 +468,20 @@ func (m *dashboardLogManager) GetMCPTopTools(ctx context.Context, filters logsto
 	return &logstore.MCPTopToolsResult{}, nil
 }
 func (m *dashboardLogManager) DeleteMCPToolLogs(ctx context.Context, ids []string) error { return nil }
+
+func TestGetPrefixCacheStatsFilters(t *testing.T) {
+	h := &LoggingHandler{logManager: &dashboardLogManager{}}
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/logs/prefix-cache?virtual_key_ids=vk-1")
+	h.getPrefixCacheStats(ctx)
+
+	var body struct {
+		Prefixes []loggingplugin.PrefixCacheStats `json:"prefixes"`
+	}
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Prefixes) != 1 || body.Prefixes[0].Route != "anthropic/claude-sonnet-4" || body.Prefixes[0].ReuseRate != 0.75 {
+		t.Fatalf("expected only vk-1's prefix stats, got %+v", body.Prefixes)
+	}
+}
@@ -445,6 +445,7 @@ func (h *MCPHandler) getMCPClientsPaginated(ctx *fasthttp.RequestCtx, params con
 			IsPingAvailable:       &isPingAvailable,
 			ToolSyncInterval:      time.Duration(dbClient.ToolSyncInterval) * time.Second,
 			ToolExecutionTimeout:  time.Duration(dbClient.ToolExecutionTimeout) * time.Second,
+			OutputValidation:      schemas.MCPOutputSchemaValidation(dbClient.OutputValidation),
 			ToolPricing:           dbClient.ToolPricing,
 			AllowOnAllVirtualKeys: dbClient.AllowOnAllVirtualKeys,
 			Disabled:              dbClient.Disabled,
@@ -590,6 +591,7 @@ type MCPClientUpdateRequest struct {
 	IsPingAvailable       *bool                        `json:"is_ping_available,omitempty"`
 	ToolSyncInterval      *int                         `json:"tool_sync_interval,omitempty"`
 	ToolExecutionTimeout  *int                         `json:"tool_execution_timeout,omitempty"`
+	OutputValidation      *string                      `json:"output_schema_validation,omitempty"`
 	Headers               map[string]schemas.SecretVar `json:"headers,omitempty"`
 	AllowedExtraHeaders   *schemas.WhiteList           `json:"allowed_extra_headers,omitempty"`
 	ToolPricing           map[string]float64           `json:"tool_pricing,omitempty"`
@@ -642,6 +644,10 @@ func (h *MCPHandler) addMCPClient(ctx *fasthttp.RequestCtx) {
 		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid allowed_extra_headers: %v", err))
 		return
 	}
+	if !schemas.MCPOutputSchemaValidation(req.OutputValidation).IsValid() {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid output_schema_validation %q: must be off, warn, coerce or error", req.OutputValidation))
+		return
+	}
 
 	// Handle per-user headers: admin declares the required key names (schema)
 	// AND supplies a sample set of values inline so the server can verify
@@ -718,6 +724,7 @@ func (h *MCPHandler) addMCPClient(ctx *fasthttp.RequestCtx) {
 			Headers:               req.Headers,
 			AllowedExtraHeaders:   req.AllowedExtraHeaders,
 			AllowOnAllVirtualKeys: req.AllowOnAllVirtualKeys,
+			OutputValidation:      schemas.MCPOutputSchemaValidation(req.OutputValidation),
 		}
 
 		// Verify connection and discover tools using the admin's sample
@@ -821,6 +828,7 @@ func (h *MCPHandler) addMCPClient(ctx *fasthttp.RequestCtx) {
 			Headers:               req.Headers,
 			AllowedExtraHeaders:   req.AllowedExtraHeaders,
 			AllowOnAllVirtualKeys: req.AllowOnAllVirtualKeys,
+			OutputValidation:      schemas.MCPOutputSchemaValidation(req.OutputValidation),
 		}
 
 		if err := h.oauthHandler.StorePendingMCPClient(flowInitiation.OauthConfigID, pendingConfig); err != nil {
@@ -915,6 +923,7 @@ func (h *MCPHandler) addMCPClient(ctx *fasthttp.RequestCtx) {
 			AllowedExtraHeaders:   req.AllowedExtraHeaders,
 			ToolPricing:           req.ToolPricing,
 			AllowOnAllVirtualKeys: req.AllowOnAllVirtualKeys,
+			OutputValidation:      schemas.MCPOutputSchemaValidation(req.OutputValidation),
 		}
 
 		// Store pending config in database (associated with oauth_config_id for multi-instance support)
@@ -979,6 +988,7 @@ func (h *MCPHandler) addMCPClient(ctx *fasthttp.RequestCtx) {
 		ToolSyncInterval:      toolSyncInterval,
 		ToolPricing:           req.ToolPricing,
 		AllowOnAllVirtualKeys: req.AllowOnAllVirtualKeys,
+		OutputValidation:      schemas.MCPOutputSchemaValidation(req.OutputValidation),
 	}
 
 	// Creating MCP client config in config store
@@ -1126,6 +1136,14 @@ func (h *MCPHandler) updateMCPClient(ctx *fasthttp.RequestCtx) {
 		}
 		resolvedToolExecutionTimeout = time.Duration(*req.ToolExecutionTimeout) * time.Second
 	}
+	resolvedOutputValidation := existingConfig.OutputValidation
+	if req.OutputValidation != nil {
+		if !schemas.MCPOutputSchemaValidation(*req.OutputValidation).IsValid() {
+			SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid output_schema_validation %q: must be off, warn, coerce or error", *req.OutputValidation))
+			return
+		}
+		resolvedOutputValidation = schemas.MCPOutputSchemaValidation(*req.OutputValidation)
+	}
 
 	// Resolve tools_to_execute and tools_to_auto_execute.
 	resolvedToolsToExecute := existingConfig.ToolsToExecute
@@ -1297,6 +1315,7 @@ func (h *MCPHandler) updateMCPClient(ctx *fasthttp.RequestCtx) {
 		ToolPricing:           toolPricing,
 		ToolSyncInterval:      int(resolvedToolSyncInterval / time.Second),
 		ToolExecutionTimeout:  int(resolvedToolExecutionTimeout / time.Second),
+		OutputValidation:      string(resolvedOutputValidation),
 		AuthType:              string(existingConfig.AuthType),
 		OauthConfigID:         existingConfig.OauthConfigID,
 		AllowOnAllVirtualKeys: allowOnAllVKs,
@@ -1361,6 +1380,7 @@ func (h *MCPHandler) updateMCPClient(ctx *fasthttp.RequestCtx) {
 		IsPingAvailable:       isPingAvailable,
 		ToolSyncInterval:      toolSyncInterval,
 		ToolExecutionTimeout:  resolvedToolExecutionTimeout,
+		OutputValidation:      resolvedOutputValidation,
 		ToolPricing:           toolPricing,
 		AllowOnAllVirtualKeys: allowOnAllVKs,
 		Disabled:              disabled,
@@ -25,7 +25,7 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
-var loggingSkipPaths = []string{"/health", "/_next", "/api/dev"}
+var loggingSkipPaths = []string{"/health", "/readyz", "/_next", "/api/dev"}
 var realtimeTransportPaths = buildRealtimeTransportPathSet()
 
 // SecurityHeadersMiddleware sets security-related HTTP headers on every response.
@@ -47,6 +47,82 @@ func SecurityHeadersMiddleware() schemas.BifrostHTTPMiddleware {
 	}
 }
 
+// DeploymentHeadersMiddleware stamps the region and instance ID of this Bifrost
+// instance onto every response, so callers behind geo-DNS can tell which
+// deployment served them. Empty values are not sent.
+func DeploymentHeadersMiddleware(deployment schemas.DeploymentMetadata) schemas.BifrostHTTPMiddleware {
+	return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+		if deployment.IsZero() {
+			return next
+		}
+		return func(ctx *fasthttp.RequestCtx) {
+			if deployment.Region != "" {
+				ctx.Response.Header.Set(schemas.DeploymentRegionHeader, deployment.Region)
+			}
+			if deployment.InstanceID != "" {
+				ctx.Response.Header.Set(schemas.DeploymentInstanceIDHeader, deployment.InstanceID)
+			}
+			next(ctx)
+		}
+	}
+}
+
+// ResponseSigningMiddleware attaches a signed attestation to buffered inference
+// responses (those carrying the x-bifrost-request-type header) with a 2xx status.
+// Streaming responses are not signed: their body is not known when headers are
+// written. A nil signer disables the middleware.
+func ResponseSigningMiddleware(signer *lib.ResponseSigner) schemas.BifrostHTTPMiddleware {
+	return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+		if signer == nil {
+			return next
+		}
+		return func(ctx *fasthttp.RequestCtx) {
+			next(ctx)
+			status := ctx.Response.StatusCode()
+			if status < 200 || status >= 300 || ctx.Response.IsBodyStream() ||
+				len(ctx.Response.Header.Peek(lib.HeaderBifrostRequestType)) == 0 {
+				return
+			}
+			requestID := responseRequestID(ctx)
+			model := string(ctx.Response.Header.Peek(lib.HeaderBifrostResolvedModel))
+			if model == "" {
+				model = string(ctx.Response.Header.Peek(lib.HeaderBifrostRoutingInfoModel))
+			}
+			attestation, signature, err := signer.Sign(signer.Attest(requestID, model, ctx.Response.Body()))
+			if err != nil {
+				logger.Warn("failed to sign response %s: %v", requestID, err)
+				return
+			}
+			key := signer.Key()
+			ctx.Response.Header.Set(lib.HeaderBifrostAttestation, attestation)
+			ctx.Response.Header.Set(lib.HeaderBifrostSignature, signature)
+			ctx.Response.Header.Set(lib.HeaderBifrostSignatureKeyID, key.KeyID)
+			ctx.Response.Header.Set(lib.HeaderBifrostSignatureAlgorithm, key.Algorithm)
+		}
+	}
+}
+
+// responseRequestID returns the request ID to attest for a finished request: the
+// one recorded on the Bifrost context, then the x-request-id response or request
+// header. When none exists a new ID is generated and echoed in x-request-id so
+// the attestation can still be matched to the response.
+func responseRequestID(ctx *fasthttp.RequestCtx) string {
+	if bifrostCtx, ok := ctx.UserValue(lib.FastHTTPUserValueBifrostContext).(*schemas.BifrostContext); ok && bifrostCtx != nil {
+		if requestID, ok := bifrostCtx.Value(schemas.BifrostContextKeyRequestID).(string); ok && requestID != "" {
+			return requestID
+		}
+	}
+	if requestID := string(ctx.Response.Header.Peek("x-request-id")); requestID != "" {
+		return requestID
+	}
+	requestID := string(ctx.Request.Header.Peek("x-request-id"))
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	ctx.Response.Header.Set("x-request-id", requestID)
+	return requestID
+}
+
 // clientForwardedIP returns the client-supplied originating IP from reverse-proxy
 // headers, or "" if none are present. X-Forwarded-For may be a comma-separated list
 // (client, proxy1, proxy2); the leftmost entry is the original client.
@@ -728,6 +804,15 @@ func fasthttpResponseToHTTPResponse(ctx *fasthttp.RequestCtx, resp *schemas.HTTP
 }
 
 // validateSession checks if a session token is valid
+// setAdminUserName records the configured admin username on a request the admin
+// authenticated, so handlers can attribute audit records to it. Sessions are only
+// issued to the admin, so a valid session resolves to the same name.
+func setAdminUserName(ctx *fasthttp.RequestCtx, authConfig *configstore.AuthConfig) {
+	if authConfig != nil && authConfig.AdminUserName != nil {
+		ctx.SetUserValue(schemas.BifrostContextKeyAdminUserName, authConfig.AdminUserName.GetValue())
+	}
+}
+
 func validateSession(_ *fasthttp.RequestCtx, store configstore.ConfigStore, token string) bool {
 	session, err := store.GetSession(context.Background(), token)
 	if err != nil || session == nil {
@@ -905,6 +990,7 @@ func (m *AuthMiddleware) APIMiddleware() schemas.BifrostHTTPMiddleware {
 		"/api/scim/oauth/refresh",
 		"/api/scim/oauth/logout",
 		"/health",
+		"/readyz",
 		"/api/version",
 	}
 	whitelistedPrefixes := []string{
@@ -998,6 +1084,7 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 							sessionToken := m.wsTicketStore.Consume(ticket)
 							if sessionToken != "" && validateSession(ctx, m.store, sessionToken) {
 								ctx.SetUserValue(schemas.BifrostContextKeySessionToken, sessionToken)
+								setAdminUserName(ctx, authConfig)
 								next(ctx)
 								return
 							}
@@ -1009,6 +1096,7 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 						if token != "" {
 							if validateSession(ctx, m.store, token) {
 								ctx.SetUserValue(schemas.BifrostContextKeySessionToken, token)
+								setAdminUserName(ctx, authConfig)
 								next(ctx)
 								return
 							}
@@ -1019,6 +1107,7 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 						cookieToken := string(ctx.Request.Header.Cookie("token"))
 						if cookieToken != "" && validateSession(ctx, m.store, cookieToken) {
 							ctx.SetUserValue(schemas.BifrostContextKeySessionToken, cookieToken)
+							setAdminUserName(ctx, authConfig)
 							next(ctx)
 							return
 						}
@@ -1031,6 +1120,7 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 				cookieToken := string(ctx.Request.Header.Cookie("token"))
 				if cookieToken != "" && validateSession(ctx, m.store, cookieToken) {
 					ctx.SetUserValue(schemas.BifrostContextKeySessionToken, cookieToken)
+					setAdminUserName(ctx, authConfig)
 					ctx.SetUserValue(schemas.IsLocalAdminContextKey, true)
 					next(ctx)
 					return
@@ -1079,6 +1169,7 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 					SendError(ctx, fasthttp.StatusUnauthorized, "Unauthorized")
 					return
 				}
+				setAdminUserName(ctx, authConfig)
 				// Continue with the next handler
 				next(ctx)
 				return
@@ -1121,12 +1212,14 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 					}
 					// Mark as local admin for RBAC bypass
 					ctx.SetUserValue(schemas.IsLocalAdminContextKey, true)
+					setAdminUserName(ctx, authConfig)
 					// Continue with the next handler
 					next(ctx)
 					return
 				}
 				// setting up session in the request
 				ctx.SetUserValue(schemas.BifrostContextKeySessionToken, token)
+				setAdminUserName(ctx, authConfig)
 				ctx.SetUserValue(schemas.IsLocalAdminContextKey, true)
 				// Continue with the next handler
 				next(ctx)
@@ -1148,7 +1241,8 @@ func (m *AuthMiddleware) middleware(shouldSkip func(*configstore.AuthConfig, str
 //
 // This middleware should be placed early in the middleware chain to capture the full request lifecycle.
 type TracingMiddleware struct {
-	tracer atomic.Pointer[tracing.Tracer]
+	tracer     atomic.Pointer[tracing.Tracer]
+	deployment atomic.Pointer[schemas.DeploymentMetadata]
 }
 
 // collectDimensionHeaders gathers x-bf-dim-* request headers into a map keyed by
@@ -1188,6 +1282,11 @@ func (m *TracingMiddleware) SetObservabilityPlugins(obsPlugins []schemas.Observa
 	}
 }
 
+// SetDeploymentMetadata sets the region and instance ID recorded on every root span
+func (m *TracingMiddleware) SetDeploymentMetadata(deployment schemas.DeploymentMetadata) {
+	m.deployment.Store(&deployment)
+}
+
 // SetTracer sets the tracer for the tracing middleware
 func (m *TracingMiddleware) SetTracer(tracer *tracing.Tracer) {
 	m.tracer.Store(tracer)
@@ -1231,6 +1330,12 @@ func (m *TracingMiddleware) Middleware() schemas.BifrostHTTPMiddleware {
 			if sessionID := strings.TrimSpace(string(ctx.Request.Header.Peek("x-bf-session-id"))); sessionID != "" {
 				tracer.SetTraceAttribute(traceID, schemas.TraceAttrSessionID, sessionID)
 			}
+			// Record the upstream sampling decision so exporters can honor it (parent-based sampling).
+			if inheritedTraceID != "" {
+				if traceContext := tracing.ExtractTraceContext(&ctx.Request.Header); traceContext != nil {
+					tracer.SetTraceAttribute(traceID, schemas.TraceAttrParentSampled, traceContext.Sampled())
+				}
+			}
 			// Only trace ID goes into context (lightweight, no bloat)
 			ctx.SetUserValue(schemas.BifrostContextKeyTraceID, traceID)
 			// Extract parent span ID from W3C traceparent header (if present)
@@ -1280,6 +1385,14 @@ func (m *TracingMiddleware) Middleware() schemas.BifrostHTTPMiddleware {
 				tracer.SetAttribute(rootSpan, "http.method", string(ctx.Method()))
 				tracer.SetAttribute(rootSpan, "http.url", string(ctx.RequestURI()))
 				tracer.SetAttribute(rootSpan, "http.user_agent", string(ctx.Request.Header.UserAgent()))
+				if deployment := m.deployment.Load(); deployment != nil {
+					if deployment.Region != "" {
+						tracer.SetAttribute(rootSpan, schemas.AttrBifrostDeploymentRegion, deployment.Region)
+					}
+					if deployment.InstanceID != "" {
+						tracer.SetAttribute(rootSpan, schemas.AttrBifrostDeploymentInstanceID, deployment.InstanceID)
+					}
+				}
 				// Set root span ID in context for child span creation
 				if spanID, ok := spanCtx.Value(schemas.BifrostContextKeySpanID).(string); ok {
 					ctx.SetUserValue(schemas.BifrostContextKeySpanID, spanID)
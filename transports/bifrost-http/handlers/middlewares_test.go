@@ -6,6 +6,9 @@ import (
 	"compress/zlib"
 	"context"
 	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"strings"
@@ -2355,3 +2358,98 @@ func TestTracingMiddleware_AccessLogIncludesRequestID(t *testing.T) {
 		t.Error("expected access log to include a non-empty trace_id")
 	}
 }
+
+// TestDeploymentHeadersMiddleware verifies the region and instance ID are stamped
+// onto responses, and that unset values are not sent.
+func TestDeploymentHeadersMiddleware(t *testing.T) {
+	next := func(ctx *fasthttp.RequestCtx) { ctx.SetStatusCode(fasthttp.StatusOK) }
+
+	ctx := &fasthttp.RequestCtx{}
+	DeploymentHeadersMiddleware(schemas.DeploymentMetadata{Region: "eu-west-1", InstanceID: "bifrost-eu-1"})(next)(ctx)
+	if got := string(ctx.Response.Header.Peek(schemas.DeploymentRegionHeader)); got != "eu-west-1" {
+		t.Errorf("region header = %q, want %q", got, "eu-west-1")
+	}
+	if got := string(ctx.Response.Header.Peek(schemas.DeploymentInstanceIDHeader)); got != "bifrost-eu-1" {
+		t.Errorf("instance header = %q, want %q", got, "bifrost-eu-1")
+	}
+
+	ctx = &fasthttp.RequestCtx{}
+	DeploymentHeadersMiddleware(schemas.DeploymentMetadata{InstanceID: "bifrost-1"})(next)(ctx)
+	if ctx.Response.Header.Peek(schemas.DeploymentRegionHeader) != nil {
+		t.Errorf("expected no region header when region is unset")
+	}
+}
+
+func TestResponseSigningMiddleware(t *testing.T) {
+	body := []byte(`{"id":"chatcmpl-1","usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`)
+	next := func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set(lib.HeaderBifrostRequestType, string(schemas.ChatCompletionRequest))
+		ctx.Response.Header.Set(lib.HeaderBifrostResolvedModel, "gpt-4o")
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		ctx.SetBody(body)
+	}
+
+	for _, algorithm := range []string{lib.ResponseSigningAlgorithmEd25519, lib.ResponseSigningAlgorithmHMACSHA256} {
+		t.Run(algorithm, func(t *testing.T) {
+			signer, err := lib.NewResponseSigner(&lib.ResponseSigningConfig{
+				Enabled:   true,
+				Algorithm: algorithm,
+				KeyID:     "test-key",
+				Key:       schemas.NewSecretVar("shared-secret"),
+			})
+			if algorithm == lib.ResponseSigningAlgorithmEd25519 {
+				// Ed25519 keys must be base64; an unset key generates an ephemeral pair.
+				if err == nil {
+					t.Fatal("expected an error for a non-base64 ed25519 key")
+				}
+				signer, err = lib.NewResponseSigner(&lib.ResponseSigningConfig{Enabled: true, KeyID: "test-key"})
+			}
+			if err != nil {
+				t.Fatalf("NewResponseSigner() error = %v", err)
+			}
+
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.Set("x-request-id", "req-123")
+			ResponseSigningMiddleware(signer)(next)(ctx)
+
+			attestation := string(ctx.Response.Header.Peek(lib.HeaderBifrostAttestation))
+			signature := string(ctx.Response.Header.Peek(lib.HeaderBifrostSignature))
+			if !signer.Verify(attestation, signature) {
+				t.Fatal("signature does not verify")
+			}
+			if got := string(ctx.Response.Header.Peek(lib.HeaderBifrostSignatureKeyID)); got != "test-key" {
+				t.Errorf("key id header = %q, want test-key", got)
+			}
+			if got := string(ctx.Response.Header.Peek(lib.HeaderBifrostSignatureAlgorithm)); got != algorithm {
+				t.Errorf("algorithm header = %q, want %q", got, algorithm)
+			}
+			if signer.Verify(attestation+"x", signature) {
+				t.Error("tampered attestation verified")
+			}
+
+			payload, err := base64.RawURLEncoding.DecodeString(attestation)
+			if err != nil {
+				t.Fatalf("attestation is not base64url: %v", err)
+			}
+			var got lib.ResponseAttestation
+			if err := json.Unmarshal(payload, &got); err != nil {
+				t.Fatalf("attestation is not JSON: %v", err)
+			}
+			digest := sha256.Sum256(body)
+			if got.RequestID != "req-123" || got.Model != "gpt-4o" || got.ContentSHA256 != hex.EncodeToString(digest[:]) {
+				t.Errorf("unexpected attestation %+v", got)
+			}
+			if usage, ok := got.Usage.(map[string]any); !ok || usage["total_tokens"] != float64(8) {
+				t.Errorf("usage = %#v, want total_tokens 8", got.Usage)
+			}
+		})
+	}
+
+	// Non-inference responses are left unsigned.
+	signer, _ := lib.NewResponseSigner(&lib.ResponseSigningConfig{Enabled: true})
+	ctx := &fasthttp.RequestCtx{}
+	ResponseSigningMiddleware(signer)(func(ctx *fasthttp.RequestCtx) { ctx.SetBodyString(`{}`) })(ctx)
+	if ctx.Response.Header.Peek(lib.HeaderBifrostSignature) != nil {
+		t.Error("expected no signature on a non-inference response")
+	}
+}
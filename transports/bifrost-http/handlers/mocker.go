@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bytedance/sonic"
+	"github.com/fasthttp/router"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/plugins/mocker"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// MockerAdmin is the contract the handler needs from the mocker plugin.
+type MockerAdmin interface {
+	ListRules() []mocker.MockRule
+	AddRule(rule mocker.MockRule) error
+	SetRuleEnabled(name string, enabled bool) error
+	RemoveRule(name string) error
+	GetStats() mocker.MockStats
+	ResetStats()
+}
+
+// MockerAdminResolver returns the currently-loaded mocker plugin or nil if none
+// is loaded. Resolved per request so plugin reloads are honored.
+type MockerAdminResolver func() MockerAdmin
+
+// MockerHandler manages mock rules at runtime, so failure-injection rules can be
+// toggled against a running gateway. Changes are not persisted: reloading the
+// plugin restores the rules in its config.
+type MockerHandler struct {
+	resolve MockerAdminResolver
+}
+
+// NewMockerHandler returns a MockerHandler that resolves the mocker plugin at
+// request time. When the plugin is not loaded every route returns 400.
+func NewMockerHandler(resolve MockerAdminResolver) *MockerHandler {
+	return &MockerHandler{resolve: resolve}
+}
+
+// RegisterRoutes registers the mock rule management routes.
+func (h *MockerHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/mocker/rules", lib.ChainMiddlewares(h.listRules, middlewares...))
+	r.POST("/api/mocker/rules", lib.ChainMiddlewares(h.addRule, middlewares...))
+	r.DELETE("/api/mocker/rules/{name}", lib.ChainMiddlewares(h.removeRule, middlewares...))
+	r.POST("/api/mocker/rules/{name}/enable", lib.ChainMiddlewares(h.enableRule, middlewares...))
+	r.POST("/api/mocker/rules/{name}/disable", lib.ChainMiddlewares(h.disableRule, middlewares...))
+	r.GET("/api/mocker/stats", lib.ChainMiddlewares(h.getStats, middlewares...))
+	r.POST("/api/mocker/stats/reset", lib.ChainMiddlewares(h.resetStats, middlewares...))
+}
+
+func (h *MockerHandler) admin(ctx *fasthttp.RequestCtx) MockerAdmin {
+	admin := h.resolve()
+	if admin == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "mocker plugin is not loaded")
+	}
+	return admin
+}
+
+// listRules handles GET /api/mocker/rules - List the mock rules.
+func (h *MockerHandler) listRules(ctx *fasthttp.RequestCtx) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	rules := admin.ListRules()
+	SendJSON(ctx, map[string]any{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// addRule handles POST /api/mocker/rules - Add a mock rule.
+func (h *MockerHandler) addRule(ctx *fasthttp.RequestCtx) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	var rule mocker.MockRule
+	if err := sonic.Unmarshal(ctx.PostBody(), &rule); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if err := admin.AddRule(rule); err != nil {
+		if errors.Is(err, mocker.ErrRuleExists) {
+			SendError(ctx, fasthttp.StatusConflict, err.Error())
+			return
+		}
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+	SendJSONWithStatus(ctx, rule, fasthttp.StatusCreated)
+}
+
+// removeRule handles DELETE /api/mocker/rules/{name} - Remove a mock rule.
+func (h *MockerHandler) removeRule(ctx *fasthttp.RequestCtx) {
+	h.applyToRule(ctx, MockerAdmin.RemoveRule, "Mock rule removed successfully")
+}
+
+// enableRule handles POST /api/mocker/rules/{name}/enable - Enable a mock rule.
+func (h *MockerHandler) enableRule(ctx *fasthttp.RequestCtx) {
+	h.applyToRule(ctx, func(admin MockerAdmin, name string) error {
+		return admin.SetRuleEnabled(name, true)
+	}, "Mock rule enabled")
+}
+
+// disableRule handles POST /api/mocker/rules/{name}/disable - Disable a mock rule.
+func (h *MockerHandler) disableRule(ctx *fasthttp.RequestCtx) {
+	h.applyToRule(ctx, func(admin MockerAdmin, name string) error {
+		return admin.SetRuleEnabled(name, false)
+	}, "Mock rule disabled")
+}
+
+func (h *MockerHandler) applyToRule(ctx *fasthttp.RequestCtx, op func(MockerAdmin, string) error, message string) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	name, ok := ctx.UserValue("name").(string)
+	if !ok || name == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "Invalid rule name")
+		return
+	}
+	if err := op(admin, name); err != nil {
+		if errors.Is(err, mocker.ErrRuleNotFound) {
+			SendError(ctx, fasthttp.StatusNotFound, "Mock rule not found")
+			return
+		}
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"message": message,
+	})
+}
+
+// getStats handles GET /api/mocker/stats - Get request and per-rule hit counts.
+func (h *MockerHandler) getStats(ctx *fasthttp.RequestCtx) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	SendJSON(ctx, admin.GetStats())
+}
+
+// resetStats handles POST /api/mocker/stats/reset - Zero the statistics, keeping the rules.
+func (h *MockerHandler) resetStats(ctx *fasthttp.RequestCtx) {
+	admin := h.admin(ctx)
+	if admin == nil {
+		return
+	}
+	admin.ResetStats()
+	SendJSON(ctx, map[string]any{
+		"message": "Mocker statistics reset",
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/plugins/mocker"
+	"github.com/valyala/fasthttp"
+)
+
+func newMockerCtx(name, body string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBody([]byte(body))
+	if name != "" {
+		ctx.SetUserValue("name", name)
+	}
+	return ctx
+}
+
+func TestMockerHandlerNotLoaded(t *testing.T) {
+	h := NewMockerHandler(func() MockerAdmin { return nil })
+	ctx := newMockerCtx("", "")
+	h.listRules(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 without the plugin, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestMockerHandlerManagesRules(t *testing.T) {
+	plugin, err := mocker.Init(mocker.MockerConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("init mocker: %v", err)
+	}
+	h := NewMockerHandler(func() MockerAdmin { return plugin })
+
+	rule := `{"name": "rate-limited", "enabled": true, "priority": 10, "probability": 1,
+		"responses": [{"type": "error", "error": {"message": "slow down", "status_code": 429}}]}`
+	ctx := newMockerCtx("", rule)
+	h.addRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusCreated {
+		t.Fatalf("expected 201 adding a rule, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	ctx = newMockerCtx("", rule)
+	h.addRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusConflict {
+		t.Fatalf("expected 409 adding a duplicate rule, got %d", ctx.Response.StatusCode())
+	}
+	ctx = newMockerCtx("", `{"name": "no-responses"}`)
+	h.addRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid rule, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = newMockerCtx("rate-limited", "")
+	h.disableRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200 disabling, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = newMockerCtx("", "")
+	h.listRules(ctx)
+	var listed struct {
+		Rules []mocker.MockRule `json:"rules"`
+		Count int               `json:"count"`
+	}
+	if err := sonic.Unmarshal(ctx.Response.Body(), &listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if listed.Count != 2 || listed.Rules[1].Name != "rate-limited" || listed.Rules[1].Enabled {
+		t.Fatalf("expected the default rule and the disabled rate-limited rule, got %+v", listed)
+	}
+
+	ctx = newMockerCtx("rate-limited", "")
+	h.removeRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200 removing, got %d", ctx.Response.StatusCode())
+	}
+	ctx = newMockerCtx("rate-limited", "")
+	h.enableRule(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 enabling a removed rule, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = newMockerCtx("", "")
+	h.resetStats(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200 resetting stats, got %d", ctx.Response.StatusCode())
+	}
+}
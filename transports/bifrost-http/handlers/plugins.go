@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
 	"github.com/maximhq/bifrost/framework/plugins"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/valyala/fasthttp"
 )
 
@@ -29,6 +31,14 @@ type PluginsLoader interface {
 	// using the loaded plugin instance if it implements ConfigMarshallerPlugin.
 	// Returns nil, nil when the plugin is not loaded or does not implement the interface.
 	ExpandPluginConfigForAPI(name string, config map[string]any) (map[string]any, error)
+	// GetPluginConfigSchema returns the config JSON Schema of a plugin implementing
+	// ConfigSchemaPlugin, or nil when the plugin is not loaded or exposes no schema.
+	GetPluginConfigSchema(name string) []byte
+	// GetPluginHealth returns the error budget of every loaded plugin that can be
+	// bypassed when it keeps failing.
+	GetPluginHealth() []schemas.PluginHealth
+	// ResumePlugin re-enables a bypassed plugin.
+	ResumePlugin(name string) error
 }
 
 // PluginsHandler is the handler for the plugins API
@@ -92,13 +102,105 @@ func (h *PluginsHandler) expandPluginConfigForAPI(name string, config map[string
 	return config, nil
 }
 
+// PluginConfigValidationError is a single violation of a plugin's config schema.
+type PluginConfigValidationError struct {
+	// Path is the JSON pointer of the offending value in the config, e.g. "/push_gateway/push_interval".
+	Path string `json:"path"`
+	// SchemaPath is the JSON pointer of the failing schema keyword, e.g. "/properties/push_gateway/properties/push_interval/minimum".
+	SchemaPath string `json:"schema_path"`
+	Message    string `json:"message"`
+}
+
+// validatePluginConfig validates config against the plugin's config schema. Plugins that do
+// not implement ConfigSchemaPlugin are not validated. The error is non-nil only when the
+// schema itself is unusable.
+func validatePluginConfig(loader PluginsLoader, name string, config map[string]any) ([]PluginConfigValidationError, error) {
+	schema := loader.GetPluginConfigSchema(name)
+	if schema == nil {
+		return nil, nil
+	}
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config schema of plugin %s: %w", name, err)
+	}
+	compiler := jsonschema.NewCompiler()
+	schemaURL := name + ".schema.json"
+	if err := compiler.AddResource(schemaURL, schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to add config schema of plugin %s: %w", name, err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile config schema of plugin %s: %w", name, err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	// Round-trip through JSON so numbers reach the validator as json.Number.
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	var validationErr *jsonschema.ValidationError
+	if err := compiled.Validate(instance); !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	var violations []PluginConfigValidationError
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		violations = append(violations, PluginConfigValidationError{
+			Path:       unit.InstanceLocation,
+			SchemaPath: unit.KeywordLocation,
+			Message:    unit.Error.String(),
+		})
+	}
+	return violations, nil
+}
+
+// checkPluginConfig validates config against the plugin's schema and writes a 400 response
+// listing the violations when it does not match. Returns false if a response was written.
+func (h *PluginsHandler) checkPluginConfig(ctx *fasthttp.RequestCtx, name string, config map[string]any) bool {
+	violations, err := validatePluginConfig(h.pluginsLoader, name, config)
+	if err != nil {
+		logger.Error("failed to validate config of plugin %s: %v", name, err)
+		SendError(ctx, fasthttp.StatusInternalServerError, "Failed to validate plugin configuration")
+		return false
+	}
+	if len(violations) > 0 {
+		sendPluginConfigViolations(ctx, violations)
+		return false
+	}
+	return true
+}
+
+// sendPluginConfigViolations responds 400 in the BifrostError shape, with the schema
+// violations listed under validation_errors so the UI can attach them to form fields.
+func sendPluginConfigViolations(ctx *fasthttp.RequestCtx, violations []PluginConfigValidationError) {
+	SendJSONWithStatus(ctx, map[string]any{
+		"is_bifrost_error": false,
+		"status_code":      fasthttp.StatusBadRequest,
+		"error": map[string]any{
+			"message": fmt.Sprintf("Invalid plugin configuration: %s: %s", violations[0].Path, violations[0].Message),
+		},
+		"validation_errors": violations,
+	}, fasthttp.StatusBadRequest)
+}
+
 // RegisterRoutes registers the routes for the PluginsHandler
 func (h *PluginsHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
 	r.GET("/api/plugins", lib.ChainMiddlewares(h.getPlugins, middlewares...))
 	r.GET("/api/plugins/builtins", lib.ChainMiddlewares(h.getBuiltinPlugins, middlewares...))
 	r.GET("/api/plugins/loaded", lib.ChainMiddlewares(h.getLoadedPlugins, middlewares...))
+	r.GET("/api/plugins/health", lib.ChainMiddlewares(h.getPluginHealth, middlewares...))
 	r.GET("/api/plugins/{name}", lib.ChainMiddlewares(h.getPlugin, middlewares...))
+	r.GET("/api/plugins/{name}/schema", lib.ChainMiddlewares(h.getPluginSchema, middlewares...))
 	r.POST("/api/plugins", lib.ChainMiddlewares(h.createPlugin, middlewares...))
+	r.POST("/api/plugins/{name}/resume", lib.ChainMiddlewares(h.resumePlugin, middlewares...))
 	r.PUT("/api/plugins/{name}", lib.ChainMiddlewares(h.updatePlugin, middlewares...))
 	r.DELETE("/api/plugins/{name}", lib.ChainMiddlewares(h.deletePlugin, middlewares...))
 }
@@ -176,6 +278,34 @@ func (h *PluginsHandler) getLoadedPlugins(ctx *fasthttp.RequestCtx) {
 	})
 }
 
+// getPluginHealth returns the error budget of every loaded degradable plugin,
+// including the ones currently bypassed.
+func (h *PluginsHandler) getPluginHealth(ctx *fasthttp.RequestCtx) {
+	health := h.pluginsLoader.GetPluginHealth()
+	if health == nil {
+		health = []schemas.PluginHealth{}
+	}
+	SendJSON(ctx, map[string]any{
+		"plugins": health,
+	})
+}
+
+// resumePlugin re-enables a plugin bypassed after spending its error budget.
+func (h *PluginsHandler) resumePlugin(ctx *fasthttp.RequestCtx) {
+	name, ok := ctx.UserValue("name").(string)
+	if !ok || name == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "Missing required 'name' parameter")
+		return
+	}
+	if err := h.pluginsLoader.ResumePlugin(name); err != nil {
+		SendError(ctx, fasthttp.StatusNotFound, err.Error())
+		return
+	}
+	SendJSON(ctx, map[string]any{
+		"message": "Plugin resumed successfully",
+	})
+}
+
 // getPlugins gets all plugins
 func (h *PluginsHandler) getPlugins(ctx *fasthttp.RequestCtx) {
 	if h.configStore == nil {
@@ -275,6 +405,22 @@ func (h *PluginsHandler) getPlugin(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, h.buildPluginResponse(ctx, plugin))
 }
 
+// getPluginSchema returns the JSON Schema of a plugin's config, for generic form rendering.
+func (h *PluginsHandler) getPluginSchema(ctx *fasthttp.RequestCtx) {
+	name, ok := ctx.UserValue("name").(string)
+	if !ok || name == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "Missing required 'name' parameter")
+		return
+	}
+	schema := h.pluginsLoader.GetPluginConfigSchema(name)
+	if schema == nil {
+		SendError(ctx, fasthttp.StatusNotFound, "Plugin is not loaded or does not expose a config schema")
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(schema)
+}
+
 // createPlugin creates a new plugin
 func (h *PluginsHandler) createPlugin(ctx *fasthttp.RequestCtx) {
 	if h.configStore == nil {
@@ -318,14 +464,16 @@ func (h *PluginsHandler) createPlugin(ctx *fasthttp.RequestCtx) {
 	if isBuiltin && request.Path != nil {
 		request.Path = nil
 	}
+	if !h.checkPluginConfig(ctx, request.Name, request.Config) {
+		return
+	}
 	// Normalize before DB write so SecretVar fields are stored as plain strings.
 	normalizedConfig, err := h.normalizePluginConfig(request.Name, request.Config)
 	if err != nil {
 		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid plugin configuration: %v", err))
 		return
 	}
-	// Create DB entry first to avoid orphaned in-memory state if DB write fails
-	if err := h.configStore.CreatePlugin(ctx, &configstoreTables.TablePlugin{
+	newPlugin := &configstoreTables.TablePlugin{
 		Name:      request.Name,
 		Enabled:   request.Enabled,
 		Config:    normalizedConfig,
@@ -333,7 +481,13 @@ func (h *PluginsHandler) createPlugin(ctx *fasthttp.RequestCtx) {
 		IsCustom:  !isBuiltin,
 		Placement: request.Placement,
 		Order:     request.Order,
-	}); err != nil {
+	}
+	if isDryRun(ctx) {
+		sendDryRun(ctx, h.buildPluginResponse(ctx, newPlugin))
+		return
+	}
+	// Create DB entry first to avoid orphaned in-memory state if DB write fails
+	if err := h.configStore.CreatePlugin(ctx, newPlugin); err != nil {
 		logger.Error("failed to create plugin: %v", err)
 		SendError(ctx, 500, "Failed to create plugin")
 		return
@@ -397,7 +551,7 @@ func (h *PluginsHandler) updatePlugin(ctx *fasthttp.RequestCtx) {
 	var existingPlugin *configstoreTables.TablePlugin
 	existingPlugin, err = h.configStore.GetPlugin(ctx, name)
 	if err != nil {
-		// If doesn't exist, create it
+		// If doesn't exist, create it (a dry run only validates the would-be plugin)
 		if errors.Is(err, configstore.ErrNotFound) {
 			plugin = &configstoreTables.TablePlugin{
 				Name:     name,
@@ -406,10 +560,12 @@ func (h *PluginsHandler) updatePlugin(ctx *fasthttp.RequestCtx) {
 				Path:     nil,
 				IsCustom: false,
 			}
-			if err := h.configStore.CreatePlugin(ctx, plugin); err != nil {
-				logger.Error("failed to create plugin: %v", err)
-				SendError(ctx, 500, "Failed to create plugin")
-				return
+			if !isDryRun(ctx) {
+				if err := h.configStore.CreatePlugin(ctx, plugin); err != nil {
+					logger.Error("failed to create plugin: %v", err)
+					SendError(ctx, 500, "Failed to create plugin")
+					return
+				}
 			}
 		} else {
 			logger.Error("failed to get plugin: %v", err)
@@ -459,14 +615,16 @@ func (h *PluginsHandler) updatePlugin(ctx *fasthttp.RequestCtx) {
 			maps.Copy(mergedConfig, incoming)
 		}
 	}
+	if !h.checkPluginConfig(ctx, name, mergedConfig) {
+		return
+	}
 	// Normalize through the typed plugin config so custom MarshalJSON (e.g. SecretVar → string) runs.
 	mergedConfig, err = h.normalizePluginConfig(name, mergedConfig)
 	if err != nil {
 		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid plugin configuration: %v", err))
 		return
 	}
-	// Updating the plugin
-	if err := h.configStore.UpdatePlugin(ctx, &configstoreTables.TablePlugin{
+	updatedPlugin := &configstoreTables.TablePlugin{
 		Name:      name,
 		Enabled:   request.Enabled,
 		Config:    mergedConfig,
@@ -474,7 +632,13 @@ func (h *PluginsHandler) updatePlugin(ctx *fasthttp.RequestCtx) {
 		IsCustom:  !isBuiltin,
 		Placement: request.Placement,
 		Order:     request.Order,
-	}); err != nil {
+	}
+	if isDryRun(ctx) {
+		sendDryRun(ctx, h.buildPluginResponse(ctx, updatedPlugin))
+		return
+	}
+	// Updating the plugin
+	if err := h.configStore.UpdatePlugin(ctx, updatedPlugin); err != nil {
 		logger.Error("failed to update plugin: %v", err)
 		SendError(ctx, 500, "Failed to update plugin")
 		return
@@ -540,6 +704,21 @@ func (h *PluginsHandler) deletePlugin(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	if isDryRun(ctx) {
+		plugin, err := h.configStore.GetPlugin(ctx, name)
+		if err != nil {
+			if errors.Is(err, configstore.ErrNotFound) {
+				SendError(ctx, fasthttp.StatusNotFound, "Plugin not found")
+				return
+			}
+			logger.Error("failed to get plugin: %v", err)
+			SendError(ctx, 500, "Failed to retrieve plugin")
+			return
+		}
+		sendDryRun(ctx, h.buildPluginResponse(ctx, plugin))
+		return
+	}
+
 	if err := h.configStore.DeletePlugin(ctx, name); err != nil {
 		if errors.Is(err, configstore.ErrNotFound) {
 			SendError(ctx, fasthttp.StatusNotFound, "Plugin not found")
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/maximhq/bifrost/core/schemas"
@@ -60,6 +61,14 @@ func (noopPluginsLoader) ExpandPluginConfigForAPI(_ string, _ map[string]any) (m
 	return nil, nil
 }
 
+func (noopPluginsLoader) GetPluginConfigSchema(_ string) []byte { return nil }
+
+func (noopPluginsLoader) GetPluginHealth() []schemas.PluginHealth { return nil }
+
+func (noopPluginsLoader) ResumePlugin(name string) error {
+	return fmt.Errorf("plugin %s is not loaded", name)
+}
+
 // buildUpdateRequest creates a PUT /api/plugins/{name} fasthttp context.
 func buildUpdateRequest(t *testing.T, body any) *fasthttp.RequestCtx {
 	t.Helper()
@@ -368,3 +377,129 @@ func TestGetLoadedPlugins(t *testing.T) {
 		}
 	}
 }
+
+// schemaPluginsLoader is a noopPluginsLoader whose plugins all expose schema.
+type schemaPluginsLoader struct {
+	noopPluginsLoader
+	schema []byte
+}
+
+func (l schemaPluginsLoader) GetPluginConfigSchema(_ string) []byte { return l.schema }
+
+const testPluginSchema = `{
+	"type": "object",
+	"properties": {
+		"push_gateway": {
+			"type": "object",
+			"properties": {"push_interval": {"type": "integer", "minimum": 1}}
+		},
+		"custom_labels": {"type": "array", "items": {"type": "string"}}
+	}
+}`
+
+// TestValidatePluginConfig verifies that schema violations point at the offending
+// config value and at the schema keyword that rejected it.
+func TestValidatePluginConfig(t *testing.T) {
+	loader := schemaPluginsLoader{schema: []byte(testPluginSchema)}
+
+	violations, err := validatePluginConfig(loader, "telemetry", map[string]any{
+		"push_gateway":  map[string]any{"push_interval": 15},
+		"custom_labels": []any{"team"},
+	})
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("expected valid config, got %v, %v", violations, err)
+	}
+
+	violations, err = validatePluginConfig(loader, "telemetry", map[string]any{
+		"push_gateway": map[string]any{"push_interval": 0},
+	})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("expected one violation, got %+v", violations)
+	}
+	if violations[0].Path != "/push_gateway/push_interval" {
+		t.Errorf("expected path /push_gateway/push_interval, got %q", violations[0].Path)
+	}
+	if violations[0].SchemaPath != "/properties/push_gateway/properties/push_interval/minimum" {
+		t.Errorf("unexpected schema path %q", violations[0].SchemaPath)
+	}
+
+	// Plugins without a schema accept any config.
+	violations, err = validatePluginConfig(noopPluginsLoader{}, "telemetry", map[string]any{"push_gateway": "x"})
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("expected no validation without a schema, got %v, %v", violations, err)
+	}
+}
+
+// TestGetPluginSchema verifies the schema is served as-is and that plugins
+// without one return 404.
+func TestGetPluginSchema(t *testing.T) {
+	h := &PluginsHandler{pluginsLoader: schemaPluginsLoader{schema: []byte(testPluginSchema)}}
+	ctx := &fasthttp.RequestCtx{}
+	ctx.SetUserValue("name", "telemetry")
+	h.getPluginSchema(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK || string(ctx.Response.Body()) != testPluginSchema {
+		t.Fatalf("expected the schema, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	h = &PluginsHandler{pluginsLoader: noopPluginsLoader{}}
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue("name", "telemetry")
+	h.getPluginSchema(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 without a schema, got %d", ctx.Response.StatusCode())
+	}
+}
+
+// healthPluginsLoader is a noopPluginsLoader with one bypassed plugin.
+type healthPluginsLoader struct {
+	noopPluginsLoader
+	resumed *string
+}
+
+func (healthPluginsLoader) GetPluginHealth() []schemas.PluginHealth {
+	return []schemas.PluginHealth{{Name: "otel", Policy: schemas.PluginDegradationPolicy{MaxFailures: 3}, Bypassed: true, Bypasses: 1}}
+}
+
+func (l healthPluginsLoader) ResumePlugin(name string) error {
+	if name != "otel" {
+		return l.noopPluginsLoader.ResumePlugin(name)
+	}
+	*l.resumed = name
+	return nil
+}
+
+// TestPluginHealthAndResume verifies bypassed plugins are reported and that
+// resuming an unknown plugin returns 404.
+func TestPluginHealthAndResume(t *testing.T) {
+	var resumed string
+	h := &PluginsHandler{pluginsLoader: healthPluginsLoader{resumed: &resumed}}
+
+	ctx := &fasthttp.RequestCtx{}
+	h.getPluginHealth(ctx)
+	var body struct {
+		Plugins []schemas.PluginHealth `json:"plugins"`
+	}
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(body.Plugins) != 1 || !body.Plugins[0].Bypassed || body.Plugins[0].Name != "otel" {
+		t.Fatalf("unexpected health: %s", ctx.Response.Body())
+	}
+
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue("name", "otel")
+	h.resumePlugin(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusOK || resumed != "otel" {
+		t.Fatalf("expected otel resumed, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue("name", "governance")
+	h.resumePlugin(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 for a plugin that cannot be resumed, got %d", ctx.Response.StatusCode())
+	}
+}
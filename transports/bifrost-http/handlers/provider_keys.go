@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	bifrost "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
 	"github.com/valyala/fasthttp"
 )
@@ -118,6 +119,11 @@ func (h *ProviderHandler) createProviderKey(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	if err := key.BetaFeatures.Validate(); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid beta_features: %v", err))
+		return
+	}
+
 	if key.ID == "" {
 		key.ID = uuid.NewString()
 	}
@@ -125,6 +131,11 @@ func (h *ProviderHandler) createProviderKey(ctx *fasthttp.RequestCtx) {
 		key.Enabled = bifrost.Ptr(true)
 	}
 
+	if isDryRun(ctx) {
+		h.sendKeyDryRun(ctx, provider, key)
+		return
+	}
+
 	if err := h.inMemoryStore.AddProviderKey(ctx, provider, key); err != nil {
 		logger.Warn("Failed to create key for provider %s: %v", provider, err)
 		if errors.Is(err, lib.ErrNotFound) {
@@ -230,6 +241,16 @@ func (h *ProviderHandler) updateProviderKey(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	if err := mergedKey.BetaFeatures.Validate(); err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid beta_features: %v", err))
+		return
+	}
+
+	if isDryRun(ctx) {
+		h.sendKeyDryRun(ctx, provider, mergedKey)
+		return
+	}
+
 	if err := h.inMemoryStore.UpdateProviderKey(ctx, provider, keyID, mergedKey); err != nil {
 		logger.Warn("Failed to update key %s for provider %s: %v", keyID, provider, err)
 		if errors.Is(err, lib.ErrNotFound) {
@@ -297,6 +318,11 @@ func (h *ProviderHandler) deleteProviderKey(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	if isDryRun(ctx) {
+		sendDryRun(ctx, redactedKey)
+		return
+	}
+
 	if err := h.inMemoryStore.RemoveProviderKey(ctx, provider, keyID); err != nil {
 		logger.Warn("Failed to delete key %s for provider %s: %v", keyID, provider, err)
 		if errors.Is(err, lib.ErrNotFound) {
@@ -314,6 +340,29 @@ func (h *ProviderHandler) deleteProviderKey(ctx *fasthttp.RequestCtx) {
 	SendJSON(ctx, redactedKey)
 }
 
+// sendKeyDryRun completes a validation-only key create or update: it rejects names
+// already used by another key (enforced by the store on a real write), optionally
+// checks the key against the provider, and returns the redacted key that would be stored.
+func (h *ProviderHandler) sendKeyDryRun(ctx *fasthttp.RequestCtx, provider schemas.ModelProvider, key schemas.Key) {
+	existingKeys, err := h.inMemoryStore.GetAllKeys()
+	if err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Failed to get provider keys: %v", err))
+		return
+	}
+	for _, existing := range existingKeys {
+		if existing.Name == key.Name && existing.KeyID != key.ID {
+			SendError(ctx, fasthttp.StatusConflict, "API key names must be unique across providers. Choose a different name")
+			return
+		}
+	}
+	redacted := (&configstore.ProviderConfig{Keys: []schemas.Key{key}}).Redacted().Keys[0]
+	if !shouldCheckKeys(ctx) {
+		sendDryRun(ctx, redacted)
+		return
+	}
+	sendDryRun(ctx, redacted, checkKeyLiveness(h.client, provider, key))
+}
+
 // mergeUpdatedKey merges an updated key with the old raw version, preserving
 // stored values for masked placeholders. A placeholder without a stored
 // counterpart is rejected so it can never reach persistence.
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bytedance/sonic"
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/maximhq/bifrost/framework/configstore"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
@@ -318,3 +319,101 @@ func TestCreateProviderKey_CustomBedrockRequiresRegion(t *testing.T) {
 		t.Fatalf("expected bedrock_key_config.region error, got %s", body)
 	}
 }
+
+func TestProviderKeyDryRun(t *testing.T) {
+	SetLogger(&mockLogger{})
+	lib.SetLogger(&mockLogger{})
+
+	newHandler := func() *ProviderHandler {
+		return &ProviderHandler{
+			inMemoryStore: &lib.Config{
+				Providers: map[schemas.ModelProvider]configstore.ProviderConfig{
+					schemas.OpenAI: {
+						Keys: []schemas.Key{{ID: "existing", Name: "prod", Value: *schemas.NewSecretVar("sk-existing1234567890"), Weight: 1}},
+					},
+				},
+			},
+			modelsManager: &mockModelsManager{},
+		}
+	}
+
+	t.Run("create validates without persisting", func(t *testing.T) {
+		h := newHandler()
+		ctx := newTestRequestCtx(`{"name":"ci","value":"sk-ci-test-1234567890","weight":1.0,"models":["*"]}`)
+		ctx.Request.SetRequestURI("/api/providers/openai/keys?dry_run=true")
+		ctx.SetUserValue("provider", "openai")
+
+		h.createProviderKey(ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusOK {
+			t.Fatalf("status got %d, want 200; body=%s", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+		var resp DryRunResponse
+		if err := sonic.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !resp.DryRun || !resp.Valid {
+			t.Fatalf("expected a valid dry run, got %+v", resp)
+		}
+		if body := string(ctx.Response.Body()); strings.Contains(body, "sk-ci-test-1234567890") {
+			t.Fatalf("dry run result leaked the raw key value: %s", body)
+		}
+		if keys := h.inMemoryStore.Providers[schemas.OpenAI].Keys; len(keys) != 1 {
+			t.Fatalf("dry run must not add the key, provider has %d keys", len(keys))
+		}
+	})
+
+	t.Run("create rejects duplicate name", func(t *testing.T) {
+		h := newHandler()
+		ctx := newTestRequestCtx(`{"name":"prod","value":"sk-ci-test-1234567890","weight":1.0,"models":["*"]}`)
+		ctx.Request.SetRequestURI("/api/providers/openai/keys?dry_run=true")
+		ctx.SetUserValue("provider", "openai")
+
+		h.createProviderKey(ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusConflict {
+			t.Fatalf("status got %d, want 409; body=%s", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	t.Run("create reports validation errors", func(t *testing.T) {
+		h := newHandler()
+		ctx := newTestRequestCtx(`{"name":"ci","value":"sk-ci-test-1234567890","weight":1.0,"beta_features":{"allowlist":{"anthropic-beta":["a"]},"defaults":{"anthropic-beta":["b"]}}}`)
+		ctx.Request.SetRequestURI("/api/providers/openai/keys?dry_run=true")
+		ctx.SetUserValue("provider", "openai")
+
+		h.createProviderKey(ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+			t.Fatalf("status got %d, want 400; body=%s", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	t.Run("delete returns the key without removing it", func(t *testing.T) {
+		h := newHandler()
+		ctx := newTestRequestCtx("")
+		ctx.Request.SetRequestURI("/api/providers/openai/keys/existing?dry_run=true")
+		ctx.SetUserValue("provider", "openai")
+		ctx.SetUserValue("key_id", "existing")
+
+		h.deleteProviderKey(ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusOK {
+			t.Fatalf("status got %d, want 200; body=%s", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+		if keys := h.inMemoryStore.Providers[schemas.OpenAI].Keys; len(keys) != 1 {
+			t.Fatalf("dry run must not remove the key, provider has %d keys", len(keys))
+		}
+	})
+}
+
+func TestSendDryRunFailedKeyCheck(t *testing.T) {
+	ctx := newTestRequestCtx("")
+	sendDryRun(ctx, nil, KeyLivenessResult{KeyID: "k", Live: false, Error: "invalid api key"})
+	if ctx.Response.StatusCode() != fasthttp.StatusUnprocessableEntity {
+		t.Fatalf("status got %d, want 422", ctx.Response.StatusCode())
+	}
+	var resp DryRunResponse
+	if err := sonic.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Valid || len(resp.KeyChecks) != 1 {
+		t.Fatalf("expected an invalid result with the key check, got %+v", resp)
+	}
+}
@@ -73,18 +73,19 @@ const (
 // ProviderResponse represents the response for provider operations
 type ProviderResponse struct {
 	Name                     schemas.ModelProvider            `json:"name"`
-	NetworkConfig            schemas.NetworkConfig            `json:"network_config"`                   // Network-related settings
-	ConcurrencyAndBufferSize schemas.ConcurrencyAndBufferSize `json:"concurrency_and_buffer_size"`      // Concurrency settings
-	ProxyConfig              *schemas.ProxyConfig             `json:"proxy_config"`                     // Proxy configuration
-	SendBackRawRequest       bool                             `json:"send_back_raw_request"`            // Include raw request in BifrostResponse
-	SendBackRawResponse      bool                             `json:"send_back_raw_response"`           // Include raw response in BifrostResponse
-	StoreRawRequestResponse  bool                             `json:"store_raw_request_response"`       // Capture raw request/response for internal logging only
-	CustomProviderConfig     *schemas.CustomProviderConfig    `json:"custom_provider_config,omitempty"` // Custom provider configuration
-	OpenAIConfig             *schemas.OpenAIConfig            `json:"openai_config,omitempty"`          // OpenAI-specific configuration
-	ProviderStatus           ProviderStatus                   `json:"provider_status"`                  // Health/initialization status of the provider
-	Status                   string                           `json:"status,omitempty"`                 // Operational status (e.g., list_models_failed)
-	Description              string                           `json:"description,omitempty"`            // Error/status description
-	ConfigHash               string                           `json:"config_hash,omitempty"`            // Hash of config.json version, used for change detection
+	NetworkConfig            schemas.NetworkConfig            `json:"network_config"`                    // Network-related settings
+	ConcurrencyAndBufferSize schemas.ConcurrencyAndBufferSize `json:"concurrency_and_buffer_size"`       // Concurrency settings
+	ProxyConfig              *schemas.ProxyConfig             `json:"proxy_config"`                      // Proxy configuration
+	SendBackRawRequest       bool                             `json:"send_back_raw_request"`             // Include raw request in BifrostResponse
+	SendBackRawResponse      bool                             `json:"send_back_raw_response"`            // Include raw response in BifrostResponse
+	StoreRawRequestResponse  bool                             `json:"store_raw_request_response"`        // Capture raw request/response for internal logging only
+	CustomProviderConfig     *schemas.CustomProviderConfig    `json:"custom_provider_config,omitempty"`  // Custom provider configuration
+	OpenAIConfig             *schemas.OpenAIConfig            `json:"openai_config,omitempty"`           // OpenAI-specific configuration
+	LoadBalancingStrategy    schemas.LoadBalancingStrategy    `json:"load_balancing_strategy,omitempty"` // Key selection strategy
+	ProviderStatus           ProviderStatus                   `json:"provider_status"`                   // Health/initialization status of the provider
+	Status                   string                           `json:"status,omitempty"`                  // Operational status (e.g., list_models_failed)
+	Description              string                           `json:"description,omitempty"`             // Error/status description
+	ConfigHash               string                           `json:"config_hash,omitempty"`             // Hash of config.json version, used for change detection
 }
 
 // ListProvidersResponse represents the response for listing all providers
@@ -109,6 +110,7 @@ type providerCreatePayload struct {
 	StoreRawRequestResponse  *bool                             `json:"store_raw_request_response,omitempty"`
 	CustomProviderConfig     *schemas.CustomProviderConfig     `json:"custom_provider_config,omitempty"`
 	OpenAIConfig             *schemas.OpenAIConfig             `json:"openai_config,omitempty"` // OpenAI-specific configuration
+	LoadBalancingStrategy    schemas.LoadBalancingStrategy     `json:"load_balancing_strategy,omitempty"`
 }
 
 type providerUpdatePayload struct {
@@ -120,6 +122,7 @@ type providerUpdatePayload struct {
 	StoreRawRequestResponse  *bool                            `json:"store_raw_request_response,omitempty"`
 	CustomProviderConfig     *schemas.CustomProviderConfig    `json:"custom_provider_config,omitempty"`
 	OpenAIConfig             *schemas.OpenAIConfig            `json:"openai_config,omitempty"` // OpenAI-specific configuration
+	LoadBalancingStrategy    schemas.LoadBalancingStrategy    `json:"load_balancing_strategy,omitempty"`
 }
 
 // RegisterRoutes registers all provider management routes
@@ -254,12 +257,13 @@ func (h *ProviderHandler) addProvider(ctx *fasthttp.RequestCtx) {
 			SendError(ctx, fasthttp.StatusBadRequest, "Custom provider cannot be same as a standard provider")
 			return
 		}
-		if payload.CustomProviderConfig.BaseProviderType == "" {
+		// providers served by a translator need no base provider
+		if payload.CustomProviderConfig.BaseProviderType == "" && payload.CustomProviderConfig.Translator == "" {
 			SendError(ctx, fasthttp.StatusBadRequest, "BaseProviderType is required when CustomProviderConfig is provided")
 			return
 		}
 		// check if base provider is a supported base provider
-		if !bifrost.IsSupportedBaseProvider(payload.CustomProviderConfig.BaseProviderType) {
+		if payload.CustomProviderConfig.BaseProviderType != "" && !bifrost.IsSupportedBaseProvider(payload.CustomProviderConfig.BaseProviderType) {
 			SendError(ctx, fasthttp.StatusBadRequest, "BaseProviderType must be a standard provider")
 			return
 		}
@@ -312,12 +316,21 @@ func (h *ProviderHandler) addProvider(ctx *fasthttp.RequestCtx) {
 		StoreRawRequestResponse:  payload.StoreRawRequestResponse != nil && *payload.StoreRawRequestResponse,
 		CustomProviderConfig:     payload.CustomProviderConfig,
 		OpenAIConfig:             payload.OpenAIConfig,
+		LoadBalancingStrategy:    payload.LoadBalancingStrategy,
+	}
+	if !config.LoadBalancingStrategy.IsValid() {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid load balancing strategy: %s", config.LoadBalancingStrategy))
+		return
 	}
 	// Validate custom provider configuration before persisting
 	if err := lib.ValidateCustomProvider(config, payload.Provider); err != nil {
 		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid custom provider config: %v", err))
 		return
 	}
+	if isDryRun(ctx) {
+		sendDryRun(ctx, h.getProviderResponseFromConfig(payload.Provider, *config.Redacted(), ProviderStatusActive))
+		return
+	}
 	// Add provider to store (env vars will be processed by store)
 	if err := h.inMemoryStore.AddProvider(ctx, payload.Provider, config); err != nil {
 		logger.Warn("Failed to add provider %s: %v", payload.Provider, err)
@@ -507,6 +520,11 @@ func (h *ProviderHandler) updateProvider(ctx *fasthttp.RequestCtx) {
 	config.ProxyConfig = payload.ProxyConfig
 	config.CustomProviderConfig = payload.CustomProviderConfig
 	config.OpenAIConfig = payload.OpenAIConfig
+	if !payload.LoadBalancingStrategy.IsValid() {
+		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Invalid load balancing strategy: %s", payload.LoadBalancingStrategy))
+		return
+	}
+	config.LoadBalancingStrategy = payload.LoadBalancingStrategy
 	if payload.SendBackRawRequest != nil {
 		config.SendBackRawRequest = *payload.SendBackRawRequest
 	}
@@ -517,6 +535,11 @@ func (h *ProviderHandler) updateProvider(ctx *fasthttp.RequestCtx) {
 		config.StoreRawRequestResponse = *payload.StoreRawRequestResponse
 	}
 
+	if isDryRun(ctx) {
+		sendDryRun(ctx, h.getProviderResponseFromConfig(provider, *config.Redacted(), ProviderStatusActive))
+		return
+	}
+
 	// Add provider to store if it doesn't exist (upsert behavior)
 	if _, err := h.inMemoryStore.GetProviderConfigRaw(provider); err != nil {
 		if !errors.Is(err, lib.ErrNotFound) {
@@ -593,11 +616,21 @@ func (h *ProviderHandler) deleteProvider(ctx *fasthttp.RequestCtx) {
 	}
 
 	// Check if provider exists
-	if _, err := h.inMemoryStore.GetProviderConfigRedacted(provider); err != nil && !errors.Is(err, lib.ErrNotFound) {
+	redactedConfig, err := h.inMemoryStore.GetProviderConfigRedacted(provider)
+	if err != nil && !errors.Is(err, lib.ErrNotFound) {
 		SendError(ctx, fasthttp.StatusBadRequest, fmt.Sprintf("Failed to get provider: %v", err))
 		return
 	}
 
+	if isDryRun(ctx) {
+		if redactedConfig == nil {
+			SendError(ctx, fasthttp.StatusNotFound, fmt.Sprintf("Provider %s not found", provider))
+			return
+		}
+		sendDryRun(ctx, h.getProviderResponseFromConfig(provider, *redactedConfig, ProviderStatusDeleted))
+		return
+	}
+
 	if err := h.modelsManager.RemoveProvider(ctx, provider); err != nil {
 		logger.Warn("Failed to delete models for provider %s: %v", provider, err)
 	}
@@ -1234,6 +1267,7 @@ func (h *ProviderHandler) getProviderResponseFromConfig(provider schemas.ModelPr
 		StoreRawRequestResponse:  config.StoreRawRequestResponse,
 		CustomProviderConfig:     config.CustomProviderConfig,
 		OpenAIConfig:             config.OpenAIConfig,
+		LoadBalancingStrategy:    config.LoadBalancingStrategy,
 		ProviderStatus:           status,
 		Status:                   config.Status,
 		Description:              config.Description,
@@ -1282,6 +1316,9 @@ func validateRetryBackoff(networkConfig *schemas.NetworkConfig) error {
 				return fmt.Errorf("retry backoff initial must be less than or equal to retry backoff max")
 			}
 		}
+		if err := networkConfig.ValidateRetryPolicy(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
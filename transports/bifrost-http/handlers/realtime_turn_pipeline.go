@@ -76,6 +76,9 @@ func newRealtimeTurnContext(
 		if strings.TrimSpace(key.Name) != "" {
 			ctx.SetValue(schemas.BifrostContextKeySelectedKeyName, key.Name)
 		}
+		if key.BYOK {
+			ctx.SetValue(schemas.BifrostContextKeySelectedKeyBYOK, true)
+		}
 	}
 	return ctx
 }
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/framework/modelcatalog"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// defaultRecommendationShortPromptTokens is the prompt size at or below which a request
+	// counts as short when the short_prompt_tokens query parameter is not set.
+	defaultRecommendationShortPromptTokens = 1000
+	// defaultRecommendationMinRequests is the traffic a route needs before it is considered.
+	defaultRecommendationMinRequests = 50
+	// recommendationMinShortPromptShare is the share of short prompts that marks a route as
+	// mostly simple traffic.
+	recommendationMinShortPromptShare = 0.5
+	// recommendationMaxLengthStopRate is the share of generations cut off by max tokens above
+	// which a route is left alone: its outputs are long, not simple.
+	recommendationMaxLengthStopRate = 0.05
+	// recommendationMaxAlternatives caps the alternatives suggested per route.
+	recommendationMaxAlternatives = 3
+)
+
+// RecommendedAlternative is a cheaper model suggested for a route, with the cost of its
+// short-prompt traffic projected onto the alternative's pricing.
+type RecommendedAlternative struct {
+	modelcatalog.ModelAlternative
+	ProjectedCost    float64 `json:"projected_cost"`
+	ProjectedSavings float64 `json:"projected_savings"`
+}
+
+// ModelRecommendation flags a (provider, model) route that spends on a large model for
+// mostly short prompts.
+type ModelRecommendation struct {
+	Provider         string                   `json:"provider"`
+	Model            string                   `json:"model"`
+	TotalRequests    int64                    `json:"total_requests"`
+	TotalCost        float64                  `json:"total_cost"`
+	ShortPromptShare float64                  `json:"short_prompt_share"`
+	ShortPromptCost  float64                  `json:"short_prompt_cost"`
+	LengthStopRate   float64                  `json:"length_stop_rate"`
+	UsesTools        bool                     `json:"uses_tools"`
+	Reasons          []string                 `json:"reasons"`
+	Alternatives     []RecommendedAlternative `json:"alternatives"`
+}
+
+// ModelRecommendationsResponse is the response of GET /api/recommendations.
+type ModelRecommendationsResponse struct {
+	ShortPromptTokens int                   `json:"short_prompt_tokens"`
+	Recommendations   []ModelRecommendation `json:"recommendations"`
+}
+
+// getModelRecommendations handles GET /api/recommendations - Suggest cheaper models for routes
+// whose traffic is mostly short prompts. Accepts the same filters as the histogram endpoints,
+// plus short_prompt_tokens and min_requests.
+func (h *LoggingHandler) getModelRecommendations(ctx *fasthttp.RequestCtx) {
+	if h.config == nil || h.config.ModelCatalog == nil {
+		SendError(ctx, fasthttp.StatusServiceUnavailable, "Model catalog is not available")
+		return
+	}
+	shortPromptTokens := defaultRecommendationShortPromptTokens
+	if raw := string(ctx.QueryArgs().Peek("short_prompt_tokens")); raw != "" {
+		i, err := strconv.Atoi(raw)
+		if err != nil || i <= 0 {
+			SendError(ctx, fasthttp.StatusBadRequest, "short_prompt_tokens must be a positive integer")
+			return
+		}
+		shortPromptTokens = i
+	}
+	minRequests := int64(defaultRecommendationMinRequests)
+	if raw := string(ctx.QueryArgs().Peek("min_requests")); raw != "" {
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || i < 1 {
+			SendError(ctx, fasthttp.StatusBadRequest, "min_requests must be a positive integer")
+			return
+		}
+		minRequests = i
+	}
+
+	filters := parseHistogramFilters(ctx)
+	profiles, err := h.logManager.GetModelUsageProfiles(ctx, filters, shortPromptTokens)
+	if err != nil {
+		logger.Error("failed to get model usage profiles: %v", err)
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Model recommendations calculation failed: %v", err))
+		return
+	}
+
+	SendJSON(ctx, ModelRecommendationsResponse{
+		ShortPromptTokens: shortPromptTokens,
+		Recommendations:   buildModelRecommendations(profiles.Profiles, h.config.ModelCatalog, shortPromptTokens, minRequests),
+	})
+}
+
+// buildModelRecommendations applies the oversized-model heuristics to each route profile and
+// prices its short-prompt traffic on the catalog's cheaper alternatives. Recommendations are
+// ordered by the best projected savings.
+func buildModelRecommendations(profiles []logstore.ModelUsageProfile, catalog *modelcatalog.ModelCatalog, shortPromptTokens int, minRequests int64) []ModelRecommendation {
+	recommendations := []ModelRecommendation{}
+	for _, profile := range profiles {
+		if profile.TotalRequests < minRequests || profile.ShortPromptRequests == 0 || profile.ShortPromptCost <= 0 {
+			continue
+		}
+		shortShare := float64(profile.ShortPromptRequests) / float64(profile.TotalRequests)
+		lengthStopRate := float64(profile.LengthStoppedRequests) / float64(profile.TotalRequests)
+		if shortShare < recommendationMinShortPromptShare || lengthStopRate > recommendationMaxLengthStopRate {
+			continue
+		}
+		usesTools := profile.ToolCallStoppedRequests > 0
+
+		var alternatives []RecommendedAlternative
+		for _, alternative := range catalog.GetCheaperAlternatives(profile.Model, schemas.ModelProvider(profile.Provider), usesTools) {
+			projected := float64(profile.ShortPromptTokens)*alternative.InputCostPerToken + float64(profile.ShortCompletionTokens)*alternative.OutputCostPerToken
+			if savings := profile.ShortPromptCost - projected; savings > 0 {
+				alternatives = append(alternatives, RecommendedAlternative{
+					ModelAlternative: alternative,
+					ProjectedCost:    projected,
+					ProjectedSavings: savings,
+				})
+			}
+			if len(alternatives) == recommendationMaxAlternatives {
+				break
+			}
+		}
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		reasons := []string{
+			fmt.Sprintf("%.0f%% of requests have at most %d prompt tokens", shortShare*100, shortPromptTokens),
+			fmt.Sprintf("%.1f%% of generations were cut off by max tokens", lengthStopRate*100),
+		}
+		if usesTools {
+			reasons = append(reasons, "requests use tools; only alternatives with function calling are suggested")
+		}
+		recommendations = append(recommendations, ModelRecommendation{
+			Provider:         profile.Provider,
+			Model:            profile.Model,
+			TotalRequests:    profile.TotalRequests,
+			TotalCost:        profile.TotalCost,
+			ShortPromptShare: shortShare,
+			ShortPromptCost:  profile.ShortPromptCost,
+			LengthStopRate:   lengthStopRate,
+			UsesTools:        usesTools,
+			Reasons:          reasons,
+			Alternatives:     alternatives,
+		})
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return bestSavings(recommendations[i]) > bestSavings(recommendations[j])
+	})
+	return recommendations
+}
+
+func bestSavings(recommendation ModelRecommendation) float64 {
+	best := 0.0
+	for _, alternative := range recommendation.Alternatives {
+		best = max(best, alternative.ProjectedSavings)
+	}
+	return best
+}
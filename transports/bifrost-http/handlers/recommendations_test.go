@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/plugins/logging"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// mockProfileLogManager embeds the interface so unimplemented methods panic; it serves
+// fixed usage profiles and records the short-prompt threshold it was asked for.
+type mockProfileLogManager struct {
+	logging.LogManager
+	profiles          []logstore.ModelUsageProfile
+	shortPromptTokens int
+}
+
+func (m *mockProfileLogManager) GetModelUsageProfiles(_ context.Context, _ *logstore.SearchFilters, shortPromptTokens int) (*logstore.ModelUsageProfileResult, error) {
+	m.shortPromptTokens = shortPromptTokens
+	return &logstore.ModelUsageProfileResult{Profiles: m.profiles}, nil
+}
+
+func TestGetModelRecommendations(t *testing.T) {
+	SetLogger(&mockLogger{})
+	catalog := modelCatalogForPricingJSON(t, []byte(`{
+		"gpt-4o": {"provider":"openai","mode":"chat","input_cost_per_token":0.0000025,"output_cost_per_token":0.00001},
+		"gpt-4o-mini": {"provider":"openai","mode":"chat","input_cost_per_token":0.00000015,"output_cost_per_token":0.0000006},
+		"gpt-3.5-turbo": {"provider":"openai","mode":"chat","input_cost_per_token":0.0000005,"output_cost_per_token":0.0000015,"is_deprecated":true},
+		"o1": {"provider":"openai","mode":"chat","input_cost_per_token":0.000015,"output_cost_per_token":0.00006}
+	}`))
+	mgr := &mockProfileLogManager{profiles: []logstore.ModelUsageProfile{
+		{ // Mostly short prompts on an expensive model: recommended.
+			Provider: "openai", Model: "gpt-4o", TotalRequests: 100, TotalCost: 2,
+			ShortPromptRequests: 80, ShortPromptCost: 1, ShortPromptTokens: 40000, ShortCompletionTokens: 8000,
+		},
+		{ // Frequently truncated outputs: left alone.
+			Provider: "openai", Model: "o1", TotalRequests: 100, TotalCost: 20,
+			ShortPromptRequests: 90, ShortPromptCost: 10, ShortPromptTokens: 45000, ShortCompletionTokens: 90000,
+			LengthStoppedRequests: 20,
+		},
+		{ // Too little traffic to judge.
+			Provider: "openai", Model: "gpt-4o", TotalRequests: 3, TotalCost: 1,
+			ShortPromptRequests: 3, ShortPromptCost: 1, ShortPromptTokens: 300, ShortCompletionTokens: 30,
+		},
+	}}
+	h := &LoggingHandler{logManager: mgr, config: &lib.Config{ModelCatalog: catalog}}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/recommendations?short_prompt_tokens=2000&min_requests=10")
+	h.getModelRecommendations(ctx)
+
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if mgr.shortPromptTokens != 2000 {
+		t.Fatalf("expected short_prompt_tokens to be passed through, got %d", mgr.shortPromptTokens)
+	}
+	var resp ModelRecommendationsResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Recommendations) != 1 {
+		t.Fatalf("expected one recommendation, got %+v", resp.Recommendations)
+	}
+	rec := resp.Recommendations[0]
+	if rec.Model != "gpt-4o" || rec.ShortPromptShare != 0.8 || len(rec.Alternatives) != 1 {
+		t.Fatalf("unexpected recommendation %+v", rec)
+	}
+	alt := rec.Alternatives[0]
+	if alt.Model != "gpt-4o-mini" {
+		t.Fatalf("expected gpt-4o-mini (the deprecated and pricier models are excluded), got %s", alt.Model)
+	}
+	wantProjected := 40000*0.00000015 + 8000*0.0000006
+	if diff := alt.ProjectedCost - wantProjected; diff > 1e-12 || diff < -1e-12 {
+		t.Fatalf("expected projected cost %v, got %v", wantProjected, alt.ProjectedCost)
+	}
+	if diff := alt.ProjectedSavings - (1 - wantProjected); diff > 1e-12 || diff < -1e-12 {
+		t.Fatalf("expected projected savings %v, got %v", 1-wantProjected, alt.ProjectedSavings)
+	}
+}
+
+func TestGetModelRecommendationsRejectsInvalidThreshold(t *testing.T) {
+	SetLogger(&mockLogger{})
+	h := &LoggingHandler{logManager: &mockProfileLogManager{}, config: &lib.Config{ModelCatalog: modelCatalogForPricingJSON(t, []byte(`{}`))}}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/recommendations?short_prompt_tokens=0")
+	h.getModelRecommendations(ctx)
+
+	if ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", ctx.Response.StatusCode())
+	}
+}
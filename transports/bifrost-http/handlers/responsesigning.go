@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/fasthttp/router"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// ResponseSigningHandler publishes the key used to verify signed response
+// attestations (see ResponseSigningMiddleware).
+type ResponseSigningHandler struct {
+	signer *lib.ResponseSigner
+}
+
+// NewResponseSigningHandler creates a response signing handler. signer may be nil,
+// in which case the key endpoint reports 404.
+func NewResponseSigningHandler(signer *lib.ResponseSigner) *ResponseSigningHandler {
+	return &ResponseSigningHandler{signer: signer}
+}
+
+// RegisterRoutes registers the verification key route. It lives under
+// /.well-known/ so that verifiers can fetch it without credentials.
+func (h *ResponseSigningHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/.well-known/bifrost-response-signing-key", lib.ChainMiddlewares(h.getKey, middlewares...))
+}
+
+// getKey handles GET /.well-known/bifrost-response-signing-key - Get the algorithm,
+// key ID and (for ed25519) public key used to sign responses.
+func (h *ResponseSigningHandler) getKey(ctx *fasthttp.RequestCtx) {
+	if h.signer == nil {
+		SendError(ctx, fasthttp.StatusNotFound, "response signing is not enabled")
+		return
+	}
+	SendJSON(ctx, h.signer.Key())
+}
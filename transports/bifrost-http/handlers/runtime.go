@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fasthttp/router"
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/plugins/logging"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// maxCPUProfileSeconds bounds how long a /debug/pprof/profile request holds its connection.
+	maxCPUProfileSeconds = 60
+	// maxTraceSeconds bounds how long a /debug/pprof/trace request holds its connection.
+	maxTraceSeconds = 30
+)
+
+// WriteQueueStatsProvider is the minimal contract the runtime handler needs from
+// the logging plugin.
+type WriteQueueStatsProvider interface {
+	GetWriteQueueStats() logging.WriteQueueStats
+}
+
+// WriteQueueStatsResolver returns the currently-loaded logging plugin or nil if
+// none is loaded. Called per request so plugin reloads are honored.
+type WriteQueueStatsResolver func() WriteQueueStatsProvider
+
+// RuntimeMemoryStats is the heap/memory section of the runtime snapshot.
+type RuntimeMemoryStats struct {
+	HeapAlloc    uint64 `json:"heap_alloc"`
+	HeapInuse    uint64 `json:"heap_inuse"`
+	HeapIdle     uint64 `json:"heap_idle"`
+	HeapReleased uint64 `json:"heap_released"`
+	HeapObjects  uint64 `json:"heap_objects"`
+	TotalAlloc   uint64 `json:"total_alloc"`
+	Sys          uint64 `json:"sys"`
+}
+
+// RuntimeGCStats is the garbage collector section of the runtime snapshot.
+type RuntimeGCStats struct {
+	NumGC         uint32     `json:"num_gc"`
+	PauseTotalNs  uint64     `json:"pause_total_ns"`
+	LastPauseNs   uint64     `json:"last_pause_ns"`
+	LastGC        *time.Time `json:"last_gc,omitempty"`
+	NextGC        uint64     `json:"next_gc"`
+	GCCPUFraction float64    `json:"gc_cpu_fraction"`
+}
+
+// RuntimeSnapshot is the response body of GET /api/admin/runtime.
+type RuntimeSnapshot struct {
+	Timestamp      time.Time                                            `json:"timestamp"`
+	GoVersion      string                                               `json:"go_version"`
+	NumCPU         int                                                  `json:"num_cpu"`
+	GOMAXPROCS     int                                                  `json:"gomaxprocs"`
+	Goroutines     int                                                  `json:"goroutines"`
+	Memory         RuntimeMemoryStats                                   `json:"memory"`
+	GC             RuntimeGCStats                                       `json:"gc"`
+	ProviderQueues map[schemas.ModelProvider]bifrost.ProviderQueueStats `json:"provider_queues"`
+	LogWriteQueue  *logging.WriteQueueStats                             `json:"log_write_queue,omitempty"`
+}
+
+// ScalingMetrics is the response body of GET /api/scaling/metrics: gateway
+// saturation in a flat shape for KEDA's metrics-api scaler (valueLocation
+// "in_flight_requests", "queue_depth", ...) or an HPA external metrics
+// adapter. The same values are exported as Prometheus gauges by the telemetry
+// plugin.
+type ScalingMetrics struct {
+	Timestamp         time.Time `json:"timestamp"`
+	InFlightRequests  int64     `json:"in_flight_requests"`   // requests being handled, until the response or stream is returned
+	SSEConnections    int64     `json:"sse_connections"`      // SSE responses currently streaming to clients
+	QueueDepth        int       `json:"queue_depth"`          // requests waiting for a worker across every provider queue and lane
+	QueueUtilization  float64   `json:"queue_utilization"`    // depth over capacity of the fullest provider lane, 0 to 1
+	AvgProviderWaitMs float64   `json:"avg_provider_wait_ms"` // queue wait over the last 10 seconds, weighted by requests served
+	ShedRequests      int64     `json:"shed_requests"`        // requests shed with a 429 since startup, all providers and reasons
+}
+
+// RuntimeHandler serves runtime diagnostics (snapshot, goroutine dumps and pprof profiles)
+// and online backups of SQLite stores.
+type RuntimeHandler struct {
+	config            *lib.Config
+	client            *bifrost.Bifrost
+	resolveWriteQueue WriteQueueStatsResolver
+	pprofEnabled      bool
+}
+
+// NewRuntimeHandler creates a new runtime diagnostics handler.
+// pprof routes are only registered when BIFROST_ADMIN_PPROF is set to "true".
+func NewRuntimeHandler(config *lib.Config, client *bifrost.Bifrost, resolveWriteQueue WriteQueueStatsResolver) *RuntimeHandler {
+	enabled, _ := strconv.ParseBool(os.Getenv("BIFROST_ADMIN_PPROF"))
+	return &RuntimeHandler{
+		config:            config,
+		client:            client,
+		resolveWriteQueue: resolveWriteQueue,
+		pprofEnabled:      enabled,
+	}
+}
+
+// RegisterRoutes registers the runtime diagnostics routes.
+func (h *RuntimeHandler) RegisterRoutes(r *router.Router, middlewares ...schemas.BifrostHTTPMiddleware) {
+	r.GET("/api/admin/runtime", lib.ChainMiddlewares(h.getRuntimeSnapshot, middlewares...))
+	r.GET("/api/scaling/metrics", lib.ChainMiddlewares(h.getScalingMetrics, middlewares...))
+	r.GET("/api/admin/runtime/goroutines", lib.ChainMiddlewares(h.requireDashboardAuth(h.getGoroutineDump), middlewares...))
+	r.POST("/api/admin/backup", lib.ChainMiddlewares(h.requireDashboardAuth(h.backupDatabase), middlewares...))
+	if !h.pprofEnabled {
+		return
+	}
+	r.GET("/debug/pprof/", lib.ChainMiddlewares(h.requireDashboardAuth(h.getPprofIndex), middlewares...))
+	r.GET("/debug/pprof/{name}", lib.ChainMiddlewares(h.requireDashboardAuth(h.getPprofProfile), middlewares...))
+}
+
+// requireDashboardAuth rejects the request unless dashboard authentication is enabled.
+// Goroutine stacks, profiles and backups can expose request data, so these routes fail
+// closed instead of being served on an unauthenticated gateway.
+func (h *RuntimeHandler) requireDashboardAuth(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if h.config == nil || h.config.ConfigStore == nil {
+			SendError(ctx, fasthttp.StatusForbidden, "runtime diagnostics require dashboard authentication to be enabled")
+			return
+		}
+		authConfig, err := h.config.ConfigStore.GetAuthConfig(ctx)
+		if err != nil {
+			SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to get auth config: %v", err))
+			return
+		}
+		if authConfig == nil || !authConfig.IsEnabled {
+			SendError(ctx, fasthttp.StatusForbidden, "runtime diagnostics require dashboard authentication to be enabled")
+			return
+		}
+		next(ctx)
+	}
+}
+
+// getRuntimeSnapshot handles GET /api/admin/runtime - Get a snapshot of runtime statistics.
+func (h *RuntimeHandler) getRuntimeSnapshot(ctx *fasthttp.RequestCtx) {
+	SendJSON(ctx, h.snapshot())
+}
+
+// snapshot collects the current runtime statistics.
+func (h *RuntimeHandler) snapshot() RuntimeSnapshot {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	snapshot := RuntimeSnapshot{
+		Timestamp:  time.Now().UTC(),
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		Memory: RuntimeMemoryStats{
+			HeapAlloc:    mem.HeapAlloc,
+			HeapInuse:    mem.HeapInuse,
+			HeapIdle:     mem.HeapIdle,
+			HeapReleased: mem.HeapReleased,
+			HeapObjects:  mem.HeapObjects,
+			TotalAlloc:   mem.TotalAlloc,
+			Sys:          mem.Sys,
+		},
+		GC: RuntimeGCStats{
+			NumGC:         mem.NumGC,
+			PauseTotalNs:  mem.PauseTotalNs,
+			NextGC:        mem.NextGC,
+			GCCPUFraction: mem.GCCPUFraction,
+		},
+		ProviderQueues: map[schemas.ModelProvider]bifrost.ProviderQueueStats{},
+	}
+	if mem.NumGC > 0 {
+		snapshot.GC.LastPauseNs = mem.PauseNs[(mem.NumGC+255)%256]
+		lastGC := time.Unix(0, int64(mem.LastGC)).UTC()
+		snapshot.GC.LastGC = &lastGC
+	}
+	if h.client != nil {
+		snapshot.ProviderQueues = h.client.GetProviderQueueStats()
+	}
+	if h.resolveWriteQueue != nil {
+		if provider := h.resolveWriteQueue(); provider != nil {
+			stats := provider.GetWriteQueueStats()
+			snapshot.LogWriteQueue = &stats
+		}
+	}
+	return snapshot
+}
+
+// getScalingMetrics handles GET /api/scaling/metrics - Get the gateway saturation signals for autoscaling.
+func (h *RuntimeHandler) getScalingMetrics(ctx *fasthttp.RequestCtx) {
+	SendJSON(ctx, h.scalingMetrics())
+}
+
+// scalingMetrics collects the current gateway saturation signals.
+func (h *RuntimeHandler) scalingMetrics() ScalingMetrics {
+	metrics := ScalingMetrics{
+		Timestamp:      time.Now().UTC(),
+		SSEConnections: lib.OpenSSEStreams(),
+	}
+	if h.client == nil {
+		return metrics
+	}
+	metrics.InFlightRequests = h.client.GetInFlightRequests()
+	var served, waited float64
+	for _, stats := range h.client.GetProviderQueueStats() {
+		metrics.QueueDepth += stats.Depth + stats.BatchDepth
+		if stats.Capacity > 0 {
+			metrics.QueueUtilization = max(metrics.QueueUtilization, float64(max(stats.Depth, stats.BatchDepth))/float64(stats.Capacity))
+		}
+		served += stats.DrainRate
+		waited += stats.DrainRate * stats.AvgWaitMs
+		for _, count := range stats.Shed {
+			metrics.ShedRequests += count
+		}
+	}
+	if served > 0 {
+		metrics.AvgProviderWaitMs = waited / served
+	}
+	return metrics
+}
+
+// getGoroutineDump handles GET /api/admin/runtime/goroutines - Dump all goroutine stacks as text.
+func (h *RuntimeHandler) getGoroutineDump(ctx *fasthttp.RequestCtx) {
+	ctx.SetContentType("text/plain; charset=utf-8")
+	if err := pprof.Lookup("goroutine").WriteTo(ctx, 2); err != nil {
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to write goroutine dump: %v", err))
+	}
+}
+
+// getPprofIndex handles GET /debug/pprof/ - List the available profiles.
+func (h *RuntimeHandler) getPprofIndex(ctx *fasthttp.RequestCtx) {
+	names := make([]string, 0, len(pprof.Profiles()))
+	for _, p := range pprof.Profiles() {
+		names = append(names, p.Name())
+	}
+	ctx.SetContentType("text/plain; charset=utf-8")
+	ctx.SetBodyString("profiles: " + strings.Join(names, " ") + "; also /profile /trace\n")
+}
+
+// getPprofProfile handles GET /debug/pprof/{name} - Write the named profile, a CPU
+// profile (profile) or an execution trace (trace).
+func (h *RuntimeHandler) getPprofProfile(ctx *fasthttp.RequestCtx) {
+	name, _ := ctx.UserValue("name").(string)
+	switch name {
+	case "profile":
+		seconds := boundedSeconds(ctx, 30, maxCPUProfileSeconds)
+		ctx.SetContentType("application/octet-stream")
+		if err := pprof.StartCPUProfile(ctx); err != nil {
+			SendError(ctx, fasthttp.StatusConflict, fmt.Sprintf("failed to start CPU profile: %v", err))
+			return
+		}
+		waitOrDone(ctx, seconds)
+		pprof.StopCPUProfile()
+	case "trace":
+		seconds := boundedSeconds(ctx, 1, maxTraceSeconds)
+		ctx.SetContentType("application/octet-stream")
+		if err := trace.Start(ctx); err != nil {
+			SendError(ctx, fasthttp.StatusConflict, fmt.Sprintf("failed to start trace: %v", err))
+			return
+		}
+		waitOrDone(ctx, seconds)
+		trace.Stop()
+	default:
+		p := pprof.Lookup(name)
+		if p == nil {
+			SendError(ctx, fasthttp.StatusNotFound, "unknown profile: "+name)
+			return
+		}
+		debug, _ := strconv.Atoi(string(ctx.QueryArgs().Peek("debug")))
+		if debug > 0 {
+			ctx.SetContentType("text/plain; charset=utf-8")
+		} else {
+			ctx.SetContentType("application/octet-stream")
+		}
+		if err := p.WriteTo(ctx, debug); err != nil {
+			SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("failed to write profile: %v", err))
+		}
+	}
+}
+
+// boundedSeconds reads the "seconds" query parameter, falling back to def and capping at max.
+func boundedSeconds(ctx *fasthttp.RequestCtx, def, max int) int {
+	seconds, _ := strconv.Atoi(string(ctx.QueryArgs().Peek("seconds")))
+	if seconds <= 0 {
+		seconds = def
+	}
+	if seconds > max {
+		seconds = max
+	}
+	return seconds
+}
+
+// waitOrDone blocks for the given duration or until the server shuts down.
+// CPU profiling and tracing are process-wide, so they must stop promptly on shutdown.
+func waitOrDone(ctx *fasthttp.RequestCtx, seconds int) {
+	timer := time.NewTimer(time.Duration(seconds) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
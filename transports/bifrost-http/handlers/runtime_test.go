@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/maximhq/bifrost/plugins/logging"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeWriteQueueStats struct {
+	stats logging.WriteQueueStats
+}
+
+func (f *fakeWriteQueueStats) GetWriteQueueStats() logging.WriteQueueStats {
+	return f.stats
+}
+
+func TestRuntimeSnapshotIncludesWriteQueue(t *testing.T) {
+	fake := &fakeWriteQueueStats{stats: logging.WriteQueueStats{Depth: 3, Capacity: 100, Dropped: 2}}
+	h := NewRuntimeHandler(nil, nil, func() WriteQueueStatsProvider { return fake })
+
+	ctx := &fasthttp.RequestCtx{}
+	h.getRuntimeSnapshot(ctx)
+
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d", ctx.Response.StatusCode())
+	}
+	var snapshot RuntimeSnapshot
+	if err := json.Unmarshal(ctx.Response.Body(), &snapshot); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if snapshot.Goroutines <= 0 {
+		t.Errorf("expected goroutine count > 0, got %d", snapshot.Goroutines)
+	}
+	if snapshot.LogWriteQueue == nil || *snapshot.LogWriteQueue != fake.stats {
+		t.Errorf("expected write queue stats %+v, got %+v", fake.stats, snapshot.LogWriteQueue)
+	}
+}
+
+func TestRuntimeSnapshotWithoutLoggingPlugin(t *testing.T) {
+	h := NewRuntimeHandler(nil, nil, func() WriteQueueStatsProvider { return nil })
+	if snapshot := h.snapshot(); snapshot.LogWriteQueue != nil {
+		t.Errorf("expected no write queue stats, got %+v", snapshot.LogWriteQueue)
+	}
+}
+
+func TestRuntimeDiagnosticsRequireDashboardAuth(t *testing.T) {
+	h := NewRuntimeHandler(nil, nil, nil)
+	called := false
+	handler := h.requireDashboardAuth(func(*fasthttp.RequestCtx) { called = true })
+
+	ctx := &fasthttp.RequestCtx{}
+	handler(ctx)
+
+	if called {
+		t.Error("expected handler not to be called without a config store")
+	}
+	if ctx.Response.StatusCode() != fasthttp.StatusForbidden {
+		t.Errorf("expected 403, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestBoundedSeconds(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{query: "", expected: 30},
+		{query: "seconds=5", expected: 5},
+		{query: "seconds=-1", expected: 30},
+		{query: "seconds=600", expected: maxCPUProfileSeconds},
+	}
+	for _, tt := range tests {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/debug/pprof/profile?" + tt.query)
+		if got := boundedSeconds(ctx, 30, maxCPUProfileSeconds); got != tt.expected {
+			t.Errorf("query %q: expected %d, got %d", tt.query, tt.expected, got)
+		}
+	}
+}
+
+func TestScalingMetricsCountOpenSSEStreams(t *testing.T) {
+	h := NewRuntimeHandler(nil, nil, nil)
+	before := h.scalingMetrics().SSEConnections
+	reader := lib.NewSSEStreamReader()
+	if got := h.scalingMetrics().SSEConnections; got != before+1 {
+		t.Fatalf("expected %d open streams, got %d", before+1, got)
+	}
+	reader.Close()
+	reader.Close()
+	if got := h.scalingMetrics().SSEConnections; got != before {
+		t.Fatalf("expected closing a stream to end its count, got %d", got)
+	}
+}
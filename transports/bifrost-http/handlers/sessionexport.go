@@ -0,0 +1,174 @@
+// Package handlers provides HTTP request handlers for the Bifrost HTTP transport.
+// This file contains the session export handler, which hands a logged conversation
+// back in a provider's message format or as markdown.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/providers/anthropic"
+	"github.com/maximhq/bifrost/core/providers/openai"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/plugins/logging"
+	"github.com/valyala/fasthttp"
+)
+
+// Formats accepted by GET /api/sessions/{id}/export.
+const (
+	sessionExportFormatOpenAI    = "openai"
+	sessionExportFormatAnthropic = "anthropic"
+	sessionExportFormatMarkdown  = "markdown"
+)
+
+// exportSession handles GET /api/sessions/{id}/export?format=openai|anthropic|markdown -
+// Export a session's conversation as an OpenAI chat completions or Anthropic messages
+// request body, or as markdown. Scrub rules are applied and inline attachments are
+// replaced by links to the logs that carried them.
+func (h *LoggingHandler) exportSession(ctx *fasthttp.RequestCtx) {
+	sessionID, ok := ctx.UserValue("id").(string)
+	if !ok || strings.TrimSpace(sessionID) == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "session id is required")
+		return
+	}
+	format := string(ctx.QueryArgs().Peek("format"))
+	if format == "" {
+		format = sessionExportFormatOpenAI
+	}
+	if format != sessionExportFormatOpenAI && format != sessionExportFormatAnthropic && format != sessionExportFormatMarkdown {
+		SendError(ctx, fasthttp.StatusBadRequest, "format must be one of openai, anthropic or markdown")
+		return
+	}
+
+	conversation, err := h.logManager.GetSessionConversation(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, logstore.ErrNotFound) {
+			SendError(ctx, fasthttp.StatusNotFound, "session not found")
+			return
+		}
+		logger.Error("failed to export session %s: %v", sessionID, err)
+		SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Session export failed: %v", err))
+		return
+	}
+	h.recordLogAccess(ctx, tables.LogAccessContent, conversation.LogIDs)
+
+	if format == sessionExportFormatMarkdown {
+		ctx.SetContentType("text/markdown; charset=utf-8")
+		ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "session-"+sessionID+".md"))
+		ctx.SetBodyString(renderSessionMarkdown(conversation))
+		return
+	}
+
+	// The converters only read the context, so it needs nothing from the request.
+	bifrostCtx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	request := &schemas.BifrostChatRequest{
+		Model: conversation.Model,
+		Input: conversation.Messages,
+	}
+	if len(conversation.Tools) > 0 {
+		request.Params = &schemas.ChatParameters{Tools: conversation.Tools}
+	}
+	var body any
+	switch format {
+	case sessionExportFormatAnthropic:
+		request.Provider = schemas.Anthropic
+		body, err = anthropic.ToAnthropicChatRequest(bifrostCtx, request)
+		if err != nil {
+			SendError(ctx, fasthttp.StatusInternalServerError, fmt.Sprintf("Session export failed: %v", err))
+			return
+		}
+	default:
+		request.Provider = schemas.OpenAI
+		body = openai.ToOpenAIChatRequest(bifrostCtx, request)
+	}
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "session-"+sessionID+".json"))
+	SendJSON(ctx, body)
+}
+
+// renderSessionMarkdown renders conversation for people to read: one section per
+// message, tool calls as JSON code blocks and attachments as links.
+func renderSessionMarkdown(conversation *logging.SessionConversation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Session %s\n\n", conversation.SessionID)
+	if conversation.Model != "" {
+		fmt.Fprintf(&b, "Model: %s/%s\n\n", conversation.Provider, conversation.Model)
+	}
+	if conversation.HiddenTurns > 0 {
+		fmt.Fprintf(&b, "_%d turns are left out because content logging was disabled for them._\n\n", conversation.HiddenTurns)
+	}
+	if conversation.Truncated {
+		fmt.Fprintf(&b, "_The session is longer than the export limit; only its first %d turns are included._\n\n", len(conversation.LogIDs)+conversation.HiddenTurns)
+	}
+
+	for _, message := range conversation.Messages {
+		switch {
+		case message.Role == schemas.ChatMessageRoleTool && message.ChatToolMessage != nil && message.ToolCallID != nil:
+			fmt.Fprintf(&b, "## Tool result (`%s`)\n\n", *message.ToolCallID)
+		case message.Role == "":
+			b.WriteString("## Message\n\n")
+		default:
+			role := string(message.Role)
+			fmt.Fprintf(&b, "## %s\n\n", strings.ToUpper(role[:1])+role[1:])
+		}
+		if message.Content != nil {
+			if message.Content.ContentStr != nil {
+				b.WriteString(*message.Content.ContentStr)
+				b.WriteString("\n\n")
+			}
+			for _, block := range message.Content.ContentBlocks {
+				if text := markdownContentBlock(block); text != "" {
+					b.WriteString(text)
+					b.WriteString("\n\n")
+				}
+			}
+		}
+		if message.ChatAssistantMessage == nil {
+			continue
+		}
+		if message.Refusal != nil {
+			fmt.Fprintf(&b, "> Refused: %s\n\n", *message.Refusal)
+		}
+		for _, call := range message.ToolCalls {
+			name, id := "", ""
+			if call.Function.Name != nil {
+				name = *call.Function.Name
+			}
+			if call.ID != nil {
+				id = *call.ID
+			}
+			fmt.Fprintf(&b, "**Tool call** `%s` (`%s`)\n\n```json\n%s\n```\n\n", name, id, call.Function.Arguments)
+		}
+	}
+	return b.String()
+}
+
+// markdownContentBlock renders one content block, or returns "" for blocks with
+// nothing to show such as cache points.
+func markdownContentBlock(block schemas.ChatContentBlock) string {
+	switch {
+	case block.Text != nil:
+		return *block.Text
+	case block.Refusal != nil:
+		return "> Refused: " + *block.Refusal
+	case block.ImageURLStruct != nil && block.ImageURLStruct.URL != "":
+		return fmt.Sprintf("![image](%s)", block.ImageURLStruct.URL)
+	case block.ImageURLStruct != nil && block.ImageURLStruct.FileID != nil:
+		return fmt.Sprintf("Image file `%s`", *block.ImageURLStruct.FileID)
+	case block.File != nil:
+		name := "file"
+		if block.File.Filename != nil {
+			name = *block.File.Filename
+		}
+		switch {
+		case block.File.FileURL != nil:
+			return fmt.Sprintf("[%s](%s)", name, *block.File.FileURL)
+		case block.File.FileID != nil:
+			return fmt.Sprintf("File `%s`", *block.File.FileID)
+		}
+	}
+	return ""
+}
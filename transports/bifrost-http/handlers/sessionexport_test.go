@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	loggingplugin "github.com/maximhq/bifrost/plugins/logging"
+	"github.com/valyala/fasthttp"
+)
+
+func exportTestConversation() *loggingplugin.SessionConversation {
+	return &loggingplugin.SessionConversation{
+		SessionID: "session-1",
+		Provider:  schemas.OpenAI,
+		Model:     "gpt-4o",
+		LogIDs:    []string{"turn-1", "turn-2"},
+		Messages: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleSystem, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("You are a support bot.")}},
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentBlocks: []schemas.ChatContentBlock{
+				{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("Is my key revoked?")},
+				{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: "https://example.com/screenshot.png"}},
+			}}},
+			{Role: schemas.ChatMessageRoleAssistant, ChatAssistantMessage: &schemas.ChatAssistantMessage{ToolCalls: []schemas.ChatAssistantMessageToolCall{
+				{ID: schemas.Ptr("call-1"), Type: schemas.Ptr("function"), Function: schemas.ChatAssistantMessageToolCallFunction{Name: schemas.Ptr("lookup_key"), Arguments: `{"key":"[REDACTED]"}`}},
+			}}},
+			{Role: schemas.ChatMessageRoleTool, ChatToolMessage: &schemas.ChatToolMessage{ToolCallID: schemas.Ptr("call-1")}, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("revoked")}},
+			{Role: schemas.ChatMessageRoleAssistant, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("Yes, it was revoked.")}},
+		},
+	}
+}
+
+func runSessionExport(h *LoggingHandler, sessionID, format string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/sessions/" + sessionID + "/export?format=" + format)
+	ctx.SetUserValue("id", sessionID)
+	h.exportSession(ctx)
+	return ctx
+}
+
+// TestExportSessionFormats verifies each format renders the conversation in the target's shape.
+func TestExportSessionFormats(t *testing.T) {
+	SetLogger(&mockLogger{})
+	h := &LoggingHandler{logManager: &dashboardLogManager{conversation: exportTestConversation()}}
+
+	ctx := runSessionExport(h, "session-1", "openai")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	var openaiBody struct {
+		Model    string           `json:"model"`
+		Messages []map[string]any `json:"messages"`
+	}
+	if err := json.Unmarshal(ctx.Response.Body(), &openaiBody); err != nil {
+		t.Fatalf("decode openai export: %v", err)
+	}
+	if openaiBody.Model != "gpt-4o" || len(openaiBody.Messages) != 5 || openaiBody.Messages[3]["tool_call_id"] != "call-1" {
+		t.Fatalf("unexpected openai export: %s", ctx.Response.Body())
+	}
+
+	ctx = runSessionExport(h, "session-1", "anthropic")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	var anthropicBody struct {
+		System   any `json:"system"`
+		Messages []struct {
+			Role    string           `json:"role"`
+			Content []map[string]any `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(ctx.Response.Body(), &anthropicBody); err != nil {
+		t.Fatalf("decode anthropic export: %v", err)
+	}
+	if anthropicBody.System == nil {
+		t.Fatalf("expected the system prompt to move to the top-level system field: %s", ctx.Response.Body())
+	}
+	var sawToolUse, sawToolResult bool
+	for _, message := range anthropicBody.Messages {
+		for _, block := range message.Content {
+			sawToolUse = sawToolUse || block["type"] == "tool_use"
+			sawToolResult = sawToolResult || block["type"] == "tool_result"
+		}
+	}
+	if !sawToolUse || !sawToolResult {
+		t.Fatalf("expected tool_use and tool_result blocks: %s", ctx.Response.Body())
+	}
+
+	ctx = runSessionExport(h, "session-1", "markdown")
+	markdown := string(ctx.Response.Body())
+	for _, want := range []string{"# Session session-1", "## User", "![image](https://example.com/screenshot.png)", "**Tool call** `lookup_key` (`call-1`)", "## Tool result (`call-1`)", "Yes, it was revoked."} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("expected markdown export to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
+
+// TestExportSessionErrors verifies unknown formats and sessions are rejected.
+func TestExportSessionErrors(t *testing.T) {
+	SetLogger(&mockLogger{})
+	h := &LoggingHandler{logManager: &dashboardLogManager{conversation: exportTestConversation()}}
+
+	if ctx := runSessionExport(h, "session-1", "gemini"); ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown format, got %d", ctx.Response.StatusCode())
+	}
+	if ctx := runSessionExport(h, "missing", "markdown"); ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown session, got %d", ctx.Response.StatusCode())
+	}
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
 	"github.com/valyala/fasthttp"
@@ -125,3 +127,62 @@ func TestSSEStreamReaderNoEventBatching(t *testing.T) {
 		}
 	}
 }
+
+// TestSSEStreamReaderPacing verifies that paced events are released at the
+// configured tokens-per-second rate while Send never blocks the producer.
+func TestSSEStreamReaderPacing(t *testing.T) {
+	reader := lib.NewSSEStreamReader()
+	reader.SetPacing(100) // 10ms per token
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		reader.Pace(5) // 50ms per event
+		if !reader.Send([]byte(fmt.Sprintf("data: %d\n\n", i))) {
+			t.Fatal("Send() returned false on an open reader")
+		}
+	}
+	reader.Done()
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Fatalf("producer blocked for %s, want no backpressure", elapsed)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if got := strings.Count(string(body), "data: "); got != 5 {
+		t.Fatalf("read %d events, want 5", got)
+	}
+	// The first event is released immediately; the remaining four wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("stream delivered in %s, want at least 200ms", elapsed)
+	}
+
+	// After the client goes away, Send reports the disconnect.
+	closed := lib.NewSSEStreamReader()
+	closed.SetPacing(100)
+	closed.Close()
+	if closed.Send([]byte("data: late\n\n")) {
+		t.Fatal("Send() returned true after Close()")
+	}
+}
+
+func TestStreamPacingFor(t *testing.T) {
+	config := &lib.Config{ServerConfig: &lib.ServerConfig{StreamPacing: []lib.StreamPacingRule{
+		{Path: "/openai/*", TokensPerSecond: 20},
+		{Path: "/openai/v1/chat/completions", TokensPerSecond: 50},
+	}}}
+	tests := []struct {
+		path string
+		want float64
+	}{
+		{path: "/openai/v1/chat/completions", want: 50},
+		{path: "/openai/v1/responses", want: 20},
+		{path: "/v1/chat/completions", want: 0},
+	}
+	for _, tt := range tests {
+		if got := config.StreamPacingFor(tt.path); got != tt.want {
+			t.Errorf("StreamPacingFor(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package handlers provides HTTP request handlers for the Bifrost HTTP transport.
+// This file contains the stream subscription handler, which lets more clients
+// watch a streaming generation that opted into broadcast while it is in progress.
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	bifrost "github.com/maximhq/bifrost/core"
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+// subscribeStream handles GET /v1/streams/{request_id} - Attach to a stream whose
+// originating request sent x-bf-broadcast: true. The subscriber gets the events
+// already sent followed by the live ones, byte for byte in the originating route's
+// format, and must present the same virtual key as the originating request.
+// A subscriber that falls too far behind is dropped without slowing the stream.
+func (h *CompletionHandler) subscribeStream(ctx *fasthttp.RequestCtx) {
+	requestID, ok := ctx.UserValue("request_id").(string)
+	if !ok || requestID == "" {
+		SendError(ctx, fasthttp.StatusBadRequest, "request_id is required")
+		return
+	}
+
+	bifrostCtx, cancel := lib.ConvertToBifrostContext(ctx, h.config)
+	if bifrostCtx == nil {
+		SendError(ctx, fasthttp.StatusBadRequest, "Failed to convert context")
+		return
+	}
+	virtualKey := bifrost.GetStringFromContext(bifrostCtx, schemas.BifrostContextKeyVirtualKey)
+	cancel()
+
+	subscription, err := h.config.GetStreamBroadcasts().Subscribe(requestID, virtualKey)
+	if err != nil {
+		if errors.Is(err, lib.ErrStreamBroadcastTooLong) {
+			SendError(ctx, fasthttp.StatusConflict, err.Error())
+			return
+		}
+		SendError(ctx, fasthttp.StatusNotFound, err.Error())
+		return
+	}
+
+	ctx.SetContentType(subscription.ContentType)
+	ctx.Response.Header.Set("Cache-Control", "no-cache")
+	ctx.Response.Header.Set("Connection", "keep-alive")
+	reader := lib.NewSSEStreamReader()
+	ctx.Response.SetBodyStream(reader, -1)
+
+	go func() {
+		defer reader.Done()
+		defer subscription.Close()
+		for event := range subscription.Events() {
+			if !reader.Send(event) {
+				return // Subscriber disconnected
+			}
+		}
+		if subscription.Dropped() && strings.HasPrefix(subscription.ContentType, "text/event-stream") {
+			reader.SendError([]byte(`{"error":"subscriber fell too far behind the stream and was dropped"}`))
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+	"github.com/valyala/fasthttp"
+)
+
+func runStreamSubscription(h *CompletionHandler, requestID, virtualKey string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/v1/streams/" + requestID)
+	if virtualKey != "" {
+		ctx.Request.Header.Set("x-bf-vk", virtualKey)
+	}
+	ctx.SetUserValue("request_id", requestID)
+	h.subscribeStream(ctx)
+	return ctx
+}
+
+// TestSubscribeStreamReplaysBroadcast verifies a subscriber holding the origin's
+// virtual key receives the events already sent followed by the live ones.
+func TestSubscribeStreamReplaysBroadcast(t *testing.T) {
+	SetLogger(&mockLogger{})
+	config := &lib.Config{ClientConfig: &configstore.ClientConfig{}, StreamBroadcasts: lib.NewStreamBroadcasts()}
+	h := &CompletionHandler{config: config}
+
+	// The originating stream, as handleStreamingResponse sets it up.
+	origin := &fasthttp.RequestCtx{}
+	origin.Request.Header.Set(lib.HeaderBifrostBroadcast, "true")
+	origin.SetContentType("text/event-stream")
+	bifrostCtx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	bifrostCtx.SetValue(schemas.BifrostContextKeyRequestID, "req-1")
+	bifrostCtx.SetValue(schemas.BifrostContextKeyVirtualKey, "sk-bf-team")
+	originReader := lib.NewSSEStreamReader()
+	originReader.SetBroadcast(lib.StartStreamBroadcast(origin, bifrostCtx, config.GetStreamBroadcasts()))
+	origin.Response.SetBodyStream(originReader, -1)
+	go func() { _, _ = io.Copy(io.Discard, originReader) }()
+	originReader.SendEvent("", []byte(`{"delta":"Hel"}`))
+
+	if ctx := runStreamSubscription(h, "req-1", "sk-bf-other"); ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("expected 404 for another virtual key, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx := runStreamSubscription(h, "req-1", "sk-bf-team")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK || string(ctx.Response.Header.ContentType()) != "text/event-stream" {
+		t.Fatalf("expected an SSE stream, got %d %q", ctx.Response.StatusCode(), ctx.Response.Header.ContentType())
+	}
+	originReader.SendEvent("", []byte(`{"delta":"lo"}`))
+	originReader.SendDone()
+	originReader.Done()
+
+	body, err := io.ReadAll(ctx.Response.BodyStream())
+	if err != nil {
+		t.Fatalf("read subscription: %v", err)
+	}
+	want := "data: {\"delta\":\"Hel\"}\n\ndata: {\"delta\":\"lo\"}\n\ndata: [DONE]\n\n"
+	if string(body) != want {
+		t.Fatalf("expected %q, got %q", want, body)
+	}
+}
@@ -355,6 +355,7 @@ func (h *WebRTCRealtimeHandler) resolveRealtimeWebRTCKeys(
 		bifrostCtx.ClearValue(schemas.BifrostContextKeyAPIKeyName)
 		bifrostCtx.ClearValue(schemas.BifrostContextKeySelectedKeyID)
 		bifrostCtx.ClearValue(schemas.BifrostContextKeySelectedKeyName)
+		bifrostCtx.ClearValue(schemas.BifrostContextKeySelectedKeyBYOK)
 		authKey := schemas.Key{Value: *schemas.NewSecretVar(inboundToken)}
 		return authKey, nil, nil
 	}
@@ -1116,6 +1117,7 @@ func newRealtimeRelayContext(requestCtx *schemas.BifrostContext) (*schemas.Bifro
 		schemas.BifrostContextKeyGovernancePluginName,
 		schemas.BifrostContextKeySelectedKeyID,
 		schemas.BifrostContextKeySelectedKeyName,
+		schemas.BifrostContextKeySelectedKeyBYOK,
 		schemas.BifrostContextKeyIsEnterprise,
 		schemas.BifrostContextKeyRoutingEnginesUsed,
 		schemas.BifrostContextKeyRoutingEngineLogs,
@@ -31,6 +31,8 @@ func (s testHandlerStore) ShouldAllowPerRequestRawOverride() bool           { re
 func (s testHandlerStore) ShouldAllowDirectKeys() bool                      { return false }
 func (s testHandlerStore) GetMCPExternalServerURL() string                  { return "" }
 func (s testHandlerStore) GetMCPExternalClientURL() string                  { return "" }
+func (s testHandlerStore) StreamPacingFor(string) float64                   { return 0 }
+func (s testHandlerStore) GetStreamBroadcasts() *lib.StreamBroadcasts       { return nil }
 
 func TestResolveRealtimeSDPTarget_BaseRouteRequiresProviderPrefix(t *testing.T) {
 	var ctx fasthttp.RequestCtx
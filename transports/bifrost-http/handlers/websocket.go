@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,14 +16,22 @@ import (
 	"github.com/fasthttp/router"
 	"github.com/fasthttp/websocket"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/maximhq/bifrost/plugins/logging"
 	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
 	"github.com/valyala/fasthttp"
 )
 
+// maxLogBackfill caps how many past log entries a client can ask for on connect.
+const maxLogBackfill = 500
+
 // WebSocketClient represents a connected WebSocket client with its own mutex
 type WebSocketClient struct {
 	conn *websocket.Conn
 	mu   sync.Mutex // Per-connection mutex for thread-safe writes
+	// logFilter narrows the log updates sent to this client. It is set on
+	// connect and never changes; nil means every log update.
+	logFilter *logstore.SearchFilters
 }
 
 // WebSocketHandler manages WebSocket connections for real-time updates
@@ -30,8 +40,9 @@ type WebSocketHandler struct {
 	allowedOrigins []string
 	clients        map[*websocket.Conn]*WebSocketClient
 	mu             sync.RWMutex
-	stopChan       chan struct{} // Channel to signal heartbeat goroutine to stop
-	done           chan struct{} // Channel to signal when heartbeat goroutine has stopped
+	stopChan       chan struct{}      // Channel to signal heartbeat goroutine to stop
+	done           chan struct{}      // Channel to signal when heartbeat goroutine has stopped
+	logManager     logging.LogManager // Source for log backfill; nil disables backfill
 }
 
 // NewWebSocketHandler creates a new WebSocket handler instance
@@ -91,10 +102,75 @@ func isLocalhost(host string) bool {
 	return ip != nil && ip.IsLoopback()
 }
 
+// SetLogManager sets the log store used to backfill log subscriptions.
+func (h *WebSocketHandler) SetLogManager(logManager logging.LogManager) {
+	h.mu.Lock()
+	h.logManager = logManager
+	h.mu.Unlock()
+}
+
+// parseLogSubscription reads the log subscription a client negotiates on
+// connect. It uses the filter names of GET /api/logs:
+//   - virtual_key_ids, models, status, objects: comma-separated; an entry must
+//     match every filter given
+//   - backfill: number of most recent matching entries to send before live
+//     updates (max 500)
+//
+// It returns a nil filter when the client asked for no filtering.
+func parseLogSubscription(ctx *fasthttp.RequestCtx) (*logstore.SearchFilters, int, error) {
+	filters := &logstore.SearchFilters{
+		VirtualKeyIDs: parseCommaSeparated(string(ctx.QueryArgs().Peek("virtual_key_ids"))),
+		Models:        parseCommaSeparated(string(ctx.QueryArgs().Peek("models"))),
+		Status:        parseCommaSeparated(string(ctx.QueryArgs().Peek("status"))),
+		Objects:       parseCommaSeparated(string(ctx.QueryArgs().Peek("objects"))),
+	}
+	backfill := 0
+	if raw := string(ctx.QueryArgs().Peek("backfill")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return nil, 0, fmt.Errorf("backfill must be a non-negative integer")
+		}
+		if n > maxLogBackfill {
+			return nil, 0, fmt.Errorf("backfill cannot exceed %d", maxLogBackfill)
+		}
+		backfill = n
+	}
+	if len(filters.VirtualKeyIDs) == 0 && len(filters.Models) == 0 && len(filters.Status) == 0 && len(filters.Objects) == 0 {
+		filters = nil
+	}
+	return filters, backfill, nil
+}
+
+// logMatchesFilter reports whether a log update should go to a client
+// subscribed with filter.
+func logMatchesFilter(filter *logstore.SearchFilters, entry *logstore.Log) bool {
+	if filter == nil {
+		return true
+	}
+	if len(filter.VirtualKeyIDs) > 0 && (entry.VirtualKeyID == nil || !slices.Contains(filter.VirtualKeyIDs, *entry.VirtualKeyID)) {
+		return false
+	}
+	if len(filter.Models) > 0 && !slices.Contains(filter.Models, entry.Model) {
+		return false
+	}
+	if len(filter.Status) > 0 && !slices.Contains(filter.Status, entry.Status) {
+		return false
+	}
+	if len(filter.Objects) > 0 && !slices.Contains(filter.Objects, entry.Object) {
+		return false
+	}
+	return true
+}
+
 // connectStream handles WebSocket connections for real-time streaming
 func (h *WebSocketHandler) connectStream(ctx *fasthttp.RequestCtx) {
+	logFilter, backfill, err := parseLogSubscription(ctx)
+	if err != nil {
+		SendError(ctx, fasthttp.StatusBadRequest, err.Error())
+		return
+	}
 	upgrader := h.getUpgrader()
-	err := upgrader.Upgrade(ctx, func(ws *websocket.Conn) {
+	err = upgrader.Upgrade(ctx, func(ws *websocket.Conn) {
 		// Read safety & liveness
 		ws.SetReadLimit(50 << 20) // 50 MiB
 		ws.SetReadDeadline(time.Now().Add(60 * time.Second))
@@ -104,7 +180,8 @@ func (h *WebSocketHandler) connectStream(ctx *fasthttp.RequestCtx) {
 		})
 		// Create a new client with its own mutex
 		client := &WebSocketClient{
-			conn: ws,
+			conn:      ws,
+			logFilter: logFilter,
 		}
 
 		// Register new client
@@ -112,6 +189,12 @@ func (h *WebSocketHandler) connectStream(ctx *fasthttp.RequestCtx) {
 		h.clients[ws] = client
 		h.mu.Unlock()
 
+		// Backfill after registering so no update falls between the two. An
+		// entry may then arrive twice; clients upsert log updates by ID.
+		if backfill > 0 {
+			h.sendLogBackfill(client, backfill)
+		}
+
 		// Clean up on disconnect
 		defer func() {
 			h.mu.Lock()
@@ -255,6 +338,82 @@ func (h *WebSocketHandler) BroadcastMarshaledMessage(data []byte) {
 	}
 }
 
+// logUpdateMessage is the WebSocket message for a log entry. Operation is
+// "create" for a request that just started, "update" when it changes, and
+// "backfill" for past entries sent on connect.
+type logUpdateMessage struct {
+	Type      string        `json:"type"`
+	Operation string        `json:"operation"`
+	Payload   *logstore.Log `json:"payload"`
+}
+
+// BroadcastLogUpdate sends a log entry to every client whose log subscription
+// matches it. It has the signature of logging.LogCallback.
+func (h *WebSocketHandler) BroadcastLogUpdate(_ context.Context, logEntry *logstore.Log) {
+	if logEntry == nil {
+		return
+	}
+	operation := "update"
+	if logEntry.Status == "processing" {
+		operation = "create"
+	}
+	data, err := sonic.Marshal(logUpdateMessage{Type: "log", Operation: operation, Payload: logEntry})
+	if err != nil {
+		logger.Error("failed to marshal log update: %v", err)
+		return
+	}
+
+	h.mu.RLock()
+	clients := make([]*WebSocketClient, 0, len(h.clients))
+	for _, client := range h.clients {
+		if logMatchesFilter(client.logFilter, logEntry) {
+			clients = append(clients, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range clients {
+		if err := h.sendMessageSafely(client, websocket.TextMessage, data); err != nil {
+			logger.Error("failed to send log update to client: %v", err)
+		}
+	}
+}
+
+// sendLogBackfill sends a client the last n log entries matching its
+// subscription, oldest first.
+func (h *WebSocketHandler) sendLogBackfill(client *WebSocketClient, n int) {
+	h.mu.RLock()
+	logManager := h.logManager
+	h.mu.RUnlock()
+	if logManager == nil {
+		return
+	}
+	filters := client.logFilter
+	if filters == nil {
+		filters = &logstore.SearchFilters{}
+	}
+	result, err := logManager.Search(h.ctx, filters, &logstore.PaginationOptions{
+		Limit:  n,
+		SortBy: "timestamp",
+		Order:  "desc",
+	})
+	if err != nil {
+		logger.Error("failed to load log backfill: %v", err)
+		return
+	}
+	for i := len(result.Logs) - 1; i >= 0; i-- {
+		data, err := sonic.Marshal(logUpdateMessage{Type: "log", Operation: "backfill", Payload: &result.Logs[i]})
+		if err != nil {
+			logger.Error("failed to marshal log backfill: %v", err)
+			continue
+		}
+		if err := h.sendMessageSafely(client, websocket.TextMessage, data); err != nil {
+			logger.Error("failed to send log backfill to client: %v", err)
+			return
+		}
+	}
+}
+
 // StartHeartbeat starts sending periodic heartbeat messages to keep connections alive
 func (h *WebSocketHandler) StartHeartbeat() {
 	ticker := time.NewTicker(30 * time.Second)
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/framework/logstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func subscriptionRequest(query string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/ws?" + query)
+	return ctx
+}
+
+func TestParseLogSubscription(t *testing.T) {
+	filter, backfill, err := parseLogSubscription(subscriptionRequest("virtual_key_ids=vk-1,%20vk-2&status=error&backfill=25"))
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+	assert.Equal(t, []string{"vk-1", "vk-2"}, filter.VirtualKeyIDs)
+	assert.Equal(t, []string{"error"}, filter.Status)
+	assert.Empty(t, filter.Models)
+	assert.Equal(t, 25, backfill)
+
+	filter, backfill, err = parseLogSubscription(subscriptionRequest(""))
+	require.NoError(t, err)
+	assert.Nil(t, filter, "no filters means every log update")
+	assert.Zero(t, backfill)
+
+	_, _, err = parseLogSubscription(subscriptionRequest("backfill=-1"))
+	assert.Error(t, err)
+	_, _, err = parseLogSubscription(subscriptionRequest("backfill=501"))
+	assert.Error(t, err)
+}
+
+func TestLogMatchesFilter(t *testing.T) {
+	vk := "vk-1"
+	entry := &logstore.Log{VirtualKeyID: &vk, Model: "gpt-4o", Status: "success", Object: "chat.completion"}
+
+	assert.True(t, logMatchesFilter(nil, entry))
+	assert.True(t, logMatchesFilter(&logstore.SearchFilters{VirtualKeyIDs: []string{"vk-1"}, Objects: []string{"chat.completion"}}, entry))
+	assert.False(t, logMatchesFilter(&logstore.SearchFilters{VirtualKeyIDs: []string{"vk-1"}, Status: []string{"error"}}, entry), "every filter must match")
+	assert.False(t, logMatchesFilter(&logstore.SearchFilters{Models: []string{"claude-3-5-sonnet"}}, entry))
+	assert.False(t, logMatchesFilter(&logstore.SearchFilters{VirtualKeyIDs: []string{"vk-1"}}, &logstore.Log{Model: "gpt-4o"}), "entries without a virtual key never match a virtual key filter")
+}
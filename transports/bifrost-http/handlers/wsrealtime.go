@@ -830,6 +830,7 @@ var realtimeMiddlewareKeys = []any{
 	schemas.BifrostContextKeyAPIKeyName,
 	schemas.BifrostContextKeySelectedKeyID,
 	schemas.BifrostContextKeySelectedKeyName,
+	schemas.BifrostContextKeySelectedKeyBYOK,
 	// NOTE: BifrostContextKeyTraceID is intentionally NOT inherited here. The
 	// upgrade request's trace is already ended by the time realtime turns run, so
 	// inheriting it would route each turn's log entry into pendingLogsToInject
@@ -719,7 +719,7 @@ func isSecurityDeniedExtraHeader(name string) bool {
 	}
 	switch name {
 	case "authorization", "proxy-authorization", "cookie", "host", "content-length", "connection", "transfer-encoding",
-		"upgrade", "origin", "x-api-key", "x-goog-api-key", "x-bf-api-key", "x-bf-api-key-id", "x-bf-vk":
+		"upgrade", "origin", "x-api-key", "x-goog-api-key", "x-bf-api-key", "x-bf-api-key-id", "x-bf-vk", "x-bf-governance-override":
 		return true
 	default:
 		return false
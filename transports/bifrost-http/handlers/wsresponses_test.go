@@ -49,6 +49,8 @@ func (s testWSHandlerStore) ShouldAllowPerRequestRawOverride() bool     { return
 func (s testWSHandlerStore) ShouldAllowDirectKeys() bool                { return false }
 func (s testWSHandlerStore) GetMCPExternalServerURL() string            { return "" }
 func (s testWSHandlerStore) GetMCPExternalClientURL() string            { return "" }
+func (s testWSHandlerStore) StreamPacingFor(string) float64             { return 0 }
+func (s testWSHandlerStore) GetStreamBroadcasts() *lib.StreamBroadcasts { return nil }
 
 type timeoutNetError struct{}
 
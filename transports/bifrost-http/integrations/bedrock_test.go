@@ -75,6 +75,14 @@ func (m *mockHandlerStore) GetMCPExternalClientURL() string {
 	return ""
 }
 
+func (m *mockHandlerStore) StreamPacingFor(path string) float64 {
+	return 0
+}
+
+func (m *mockHandlerStore) GetStreamBroadcasts() *lib.StreamBroadcasts {
+	return nil
+}
+
 func (m *mockHandlerStore) GetModelCatalog() *modelcatalog.ModelCatalog {
 	return m.modelCatalog
 }
@@ -0,0 +1,349 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/providers/anthropic"
+	"github.com/maximhq/bifrost/core/providers/gemini"
+	"github.com/maximhq/bifrost/core/providers/openai"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// conformanceFixture is one recorded exchange on an integration surface, stored as
+// testdata/conformance/<surface>/<name>.json. See testdata/conformance/README.md.
+type conformanceFixture struct {
+	Description string `json:"description"`
+	// Model is the model from the request path, for surfaces that carry it there (genai).
+	Model    string          `json:"model,omitempty"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	// Ignore lists dotted paths ("response.usage.cache_creation", "request.messages.*.name")
+	// that are expected to differ after the round trip.
+	Ignore []string `json:"ignore,omitempty"`
+	// KnownIssue marks a fixture that reproduces an open translation bug. Its diffs are
+	// reported as a skip instead of a failure until the bug is fixed.
+	KnownIssue string `json:"known_issue,omitempty"`
+}
+
+// conformanceSurface replays a surface's recorded requests and responses through the
+// integration route and the provider converters that serve the same API.
+type conformanceSurface struct {
+	// roundTripRequest parses a client request with the route, converts it to Bifrost
+	// and back to the provider's wire format.
+	roundTripRequest func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error)
+	// roundTripResponse parses a provider response, converts it to Bifrost and back
+	// to the client's wire format with the route.
+	roundTripResponse func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error)
+	// normalizeRequest rewrites a recorded request into the equivalent form the provider
+	// converter emits, e.g. shorthand string content into content blocks.
+	normalizeRequest func(request interface{})
+	// ignore lists paths that always differ on this surface.
+	ignore []string
+}
+
+func findRoute(routes []RouteConfig, method, path string) RouteConfig {
+	for _, route := range routes {
+		if route.Method == method && route.Path == path {
+			return route
+		}
+	}
+	panic(fmt.Sprintf("no %s %s route", method, path))
+}
+
+func conformanceSurfaces() map[string]conformanceSurface {
+	openaiChat := findRoute(CreateOpenAIRouteConfigs("", nil), "POST", "/v1/chat/completions")
+	anthropicMessages := findRoute(CreateAnthropicRouteConfigs("", nil), "POST", "/v1/messages")
+	genaiGenerate := findRoute(CreateGenAIRouteConfigs(""), "POST", "/v1beta/models/{model:*}")
+
+	return map[string]conformanceSurface{
+		"openai": {
+			roundTripRequest: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				bifrostReq, err := convertRequest(ctx, openaiChat, fixture.Request, nil)
+				if err != nil {
+					return nil, err
+				}
+				return openai.ToOpenAIChatRequest(ctx, bifrostReq.ChatRequest), nil
+			},
+			roundTripResponse: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				var resp schemas.BifrostChatResponse
+				if err := json.Unmarshal(fixture.Response, &resp); err != nil {
+					return nil, err
+				}
+				return openaiChat.ChatResponseConverter(ctx, &resp)
+			},
+		},
+		"anthropic": {
+			roundTripRequest: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				bifrostReq, err := convertRequest(ctx, anthropicMessages, fixture.Request, nil)
+				if err != nil {
+					return nil, err
+				}
+				return anthropic.ToAnthropicResponsesRequest(ctx, bifrostReq.ResponsesRequest)
+			},
+			roundTripResponse: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				var resp anthropic.AnthropicMessageResponse
+				if err := json.Unmarshal(fixture.Response, &resp); err != nil {
+					return nil, err
+				}
+				return anthropicMessages.ResponsesResponseConverter(ctx, resp.ToBifrostResponsesResponse(ctx))
+			},
+			normalizeRequest: func(request interface{}) {
+				req, _ := request.(map[string]interface{})
+				req["system"] = textBlocks(req["system"])
+				messages, _ := req["messages"].([]interface{})
+				for _, message := range messages {
+					if message, ok := message.(map[string]interface{}); ok {
+						message["content"] = textBlocks(message["content"])
+					}
+				}
+			},
+		},
+		"genai": {
+			roundTripRequest: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				bifrostReq, err := convertRequest(ctx, genaiGenerate, fixture.Request, func(req interface{}) {
+					req.(*gemini.GeminiGenerationRequest).Model = fixture.Model
+				})
+				if err != nil {
+					return nil, err
+				}
+				return gemini.ToGeminiResponsesRequest(ctx, bifrostReq.ResponsesRequest)
+			},
+			roundTripResponse: func(ctx *schemas.BifrostContext, fixture conformanceFixture) (interface{}, error) {
+				var resp gemini.GenerateContentResponse
+				if err := json.Unmarshal(fixture.Response, &resp); err != nil {
+					return nil, err
+				}
+				return genaiGenerate.ResponsesResponseConverter(ctx, resp.ToResponsesBifrostResponsesResponse())
+			},
+			// The model travels in the URL, and Bifrost assigns its own response id and creation time.
+			ignore: []string{"request.model", "response.responseId", "response.createTime"},
+		},
+	}
+}
+
+// textBlocks expands Anthropic's shorthand string content into a single text block.
+func textBlocks(content interface{}) interface{} {
+	if text, ok := content.(string); ok {
+		return []interface{}{map[string]interface{}{"type": "text", "text": text}}
+	}
+	return content
+}
+
+// convertRequest parses body into the route's request type, applies fromPath for
+// values the router takes from the URL, and runs the route's request converter.
+func convertRequest(ctx *schemas.BifrostContext, route RouteConfig, body json.RawMessage, fromPath func(req interface{})) (*schemas.BifrostRequest, error) {
+	req := route.GetRequestTypeInstance(ctx)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, err
+	}
+	if fromPath != nil {
+		fromPath(req)
+	}
+	return route.RequestConverter(ctx, req)
+}
+
+// TestIntegrationConformance replays every recorded fixture through the translation
+// layers and fails on any field that is dropped, added or changed on the way.
+func TestIntegrationConformance(t *testing.T) {
+	for name, surface := range conformanceSurfaces() {
+		paths, err := filepath.Glob(filepath.Join("testdata", "conformance", name, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(paths) == 0 {
+			t.Errorf("no conformance fixtures for %s", name)
+		}
+		for _, path := range paths {
+			t.Run(name+"/"+strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var fixture conformanceFixture
+				if err := json.Unmarshal(data, &fixture); err != nil {
+					t.Fatalf("invalid fixture: %v", err)
+				}
+				if len(fixture.Request) == 0 && len(fixture.Response) == 0 {
+					t.Fatal("fixture has neither a request nor a response")
+				}
+				ignore := append(append([]string(nil), surface.ignore...), fixture.Ignore...)
+
+				var diffs []string
+				if len(fixture.Request) > 0 {
+					got, err := surface.roundTripRequest(schemas.NewBifrostContext(t.Context(), schemas.NoDeadline), fixture)
+					if err != nil {
+						t.Fatalf("request conversion failed: %v", err)
+					}
+					diffs = append(diffs, diffJSON(t, "request", fixture.Request, got, surface.normalizeRequest, ignore)...)
+				}
+				if len(fixture.Response) > 0 {
+					got, err := surface.roundTripResponse(schemas.NewBifrostContext(t.Context(), schemas.NoDeadline), fixture)
+					if err != nil {
+						t.Fatalf("response conversion failed: %v", err)
+					}
+					diffs = append(diffs, diffJSON(t, "response", fixture.Response, got, nil, ignore)...)
+				}
+
+				switch {
+				case fixture.KnownIssue != "" && len(diffs) == 0:
+					t.Errorf("fixture passes now; remove its known_issue (%s)", fixture.KnownIssue)
+				case fixture.KnownIssue != "":
+					t.Skipf("known issue %s:\n%s", fixture.KnownIssue, strings.Join(diffs, "\n"))
+				case len(diffs) > 0:
+					t.Errorf("%s: translation changed %d field(s):\n%s", fixture.Description, len(diffs), strings.Join(diffs, "\n"))
+				}
+			})
+		}
+	}
+}
+
+// diffJSON compares the recorded payload with the converted one after normalizing both
+// through JSON, and returns one line per differing leaf. Ignore patterns are rooted at
+// "request" or "response".
+func diffJSON(t *testing.T, root string, want json.RawMessage, got interface{}, normalize func(interface{}), ignore []string) []string {
+	t.Helper()
+	var wantValue, gotValue interface{}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("invalid %s payload: %v", root, err)
+	}
+	if normalize != nil {
+		normalize(wantValue)
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("marshal converted %s: %v", root, err)
+	}
+	if err := json.Unmarshal(gotJSON, &gotValue); err != nil {
+		t.Fatalf("unmarshal converted %s: %v", root, err)
+	}
+	var diffs []string
+	walkDiff(root, wantValue, gotValue, func(path string) bool { return isIgnored(path, ignore) }, &diffs)
+	return diffs
+}
+
+func walkDiff(path string, want, got interface{}, ignored func(string) bool, diffs *[]string) {
+	if ignored(path) {
+		return
+	}
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool, len(w)+len(g))
+		for key := range w {
+			keys[key] = true
+		}
+		for key := range g {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			walkDiff(path+"."+key, w[key], g[key], ignored, diffs)
+		}
+		return
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(w) || i < len(g); i++ {
+			var wi, gi interface{}
+			if i < len(w) {
+				wi = w[i]
+			}
+			if i < len(g) {
+				gi = g[i]
+			}
+			walkDiff(fmt.Sprintf("%s.%d", path, i), wi, gi, ignored, diffs)
+		}
+		return
+	}
+	// A zero value and an absent field mean the same thing to clients.
+	if reflect.DeepEqual(want, got) || (got == nil && isZeroJSON(want)) || (want == nil && isZeroJSON(got)) {
+		return
+	}
+	switch {
+	case got == nil:
+		*diffs = append(*diffs, fmt.Sprintf("  %s: dropped (was %s)", path, compactValue(want)))
+	case want == nil:
+		*diffs = append(*diffs, fmt.Sprintf("  %s: added %s", path, compactValue(got)))
+	default:
+		*diffs = append(*diffs, fmt.Sprintf("  %s: %s -> %s", path, compactValue(want), compactValue(got)))
+	}
+}
+
+// isZeroJSON reports whether v is a zero scalar or a container holding only zero values.
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		for _, item := range v {
+			if !isZeroJSON(item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if !isZeroJSON(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isIgnored reports whether path matches an ignore pattern, where "*" matches one
+// segment and a pattern also covers everything below it.
+func isIgnored(path string, ignore []string) bool {
+	segments := strings.Split(path, ".")
+	for _, pattern := range ignore {
+		parts := strings.Split(pattern, ".")
+		if len(parts) > len(segments) {
+			continue
+		}
+		matched := true
+		for i, part := range parts {
+			if part != "*" && part != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func compactValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(data) > 120 {
+		return string(data[:117]) + "..."
+	}
+	return string(data)
+}
@@ -2733,6 +2733,8 @@ func (g *GenericRouter) handleStreaming(ctx *fasthttp.RequestCtx, bifrostCtx *sc
 	// Use SSEStreamReader to bypass fasthttp's internal pipe (fasthttputil.PipeConns)
 	// which batches multiple SSE events into single TCP segments.
 	reader := lib.NewSSEStreamReader()
+	reader.SetPacing(g.handlerStore.StreamPacingFor(string(ctx.Path())))
+	reader.SetBroadcast(lib.StartStreamBroadcast(ctx, bifrostCtx, g.handlerStore.GetStreamBroadcasts()))
 	ctx.Response.SetBodyStream(reader, -1)
 
 	// Producer goroutine: processes the stream channel, formats events, sends to reader
@@ -2923,6 +2925,9 @@ func (g *GenericRouter) handleStreaming(ctx *fasthttp.RequestCtx, bifrostCtx *sc
 					continue
 				}
 
+				// Weight the next event for output pacing (no-op unless configured for this route)
+				reader.Pace(lib.EstimateStreamChunkTokens(chunk))
+
 				// Handle Bedrock Event Stream format
 				if config.Type == RouteConfigTypeBedrock && eventStreamEncoder != nil {
 					// We need to cast to BedrockStreamEvent to determine event type and structure
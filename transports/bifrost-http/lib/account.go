@@ -60,11 +60,19 @@ func (baseAccount *BaseAccount) GetKeysForProvider(ctx context.Context, provider
 						filtered = append(filtered, key)
 					}
 				}
-				keys = filtered
+				return filtered, nil
 			}
 		}
 	}
-	return keys, nil
+	// BYOK keys belong to a tenant and are only visible to the virtual keys that
+	// list them, so an allow-all virtual key cannot reach another tenant's key.
+	filtered := make([]schemas.Key, 0, len(keys))
+	for _, key := range keys {
+		if !key.BYOK {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
 }
 
 // GetConfigForProvider returns the complete configuration for a specific provider.
@@ -95,6 +103,7 @@ func (baseAccount *BaseAccount) GetConfigForProvider(providerKey schemas.ModelPr
 	providerConfig.SendBackRawRequest = config.SendBackRawRequest
 	providerConfig.SendBackRawResponse = config.SendBackRawResponse
 	providerConfig.StoreRawRequestResponse = config.StoreRawRequestResponse
+	providerConfig.LoadBalancingStrategy = config.LoadBalancingStrategy
 	if config.CustomProviderConfig != nil {
 		providerConfig.CustomProviderConfig = config.CustomProviderConfig
 	}
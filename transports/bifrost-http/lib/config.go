@@ -26,6 +26,7 @@ import (
 	mcputils "github.com/maximhq/bifrost/core/mcp/utils"
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/maximhq/bifrost/framework"
+	"github.com/maximhq/bifrost/framework/chaos"
 	"github.com/maximhq/bifrost/framework/configstore"
 	configstoreTables "github.com/maximhq/bifrost/framework/configstore/tables"
 	"github.com/maximhq/bifrost/framework/encrypt"
@@ -40,11 +41,13 @@ import (
 	"github.com/maximhq/bifrost/framework/objectstore"
 	plugins "github.com/maximhq/bifrost/framework/plugins"
 	"github.com/maximhq/bifrost/framework/vectorstore"
+	"github.com/maximhq/bifrost/plugins/circuitbreaker"
 	"github.com/maximhq/bifrost/plugins/compat"
 	"github.com/maximhq/bifrost/plugins/governance"
 	"github.com/maximhq/bifrost/plugins/governance/complexity"
 	"github.com/maximhq/bifrost/plugins/logging"
 	"github.com/maximhq/bifrost/plugins/maxim"
+	"github.com/maximhq/bifrost/plugins/mocker"
 	"github.com/maximhq/bifrost/plugins/otel"
 	"github.com/maximhq/bifrost/plugins/prompts"
 	"github.com/maximhq/bifrost/plugins/semanticcache"
@@ -90,6 +93,12 @@ type HandlerStore interface {
 	// redirect_uri when acting as an OAuth client to upstream MCP servers, or empty string
 	// if not configured (falls back to dynamic Host-header-based URL).
 	GetMCPExternalClientURL() string
+	// StreamPacingFor returns the SSE output pacing limit in tokens per second for
+	// a request path, or 0 when streams on that path are not paced.
+	StreamPacingFor(path string) float64
+	// GetStreamBroadcasts returns the registry of streams that opted into
+	// broadcast, or nil if broadcast is unavailable.
+	GetStreamBroadcasts() *StreamBroadcasts
 }
 
 // Retry backoff constants for validation
@@ -130,6 +139,9 @@ var builtinPluginNames = []string{
 	semanticcache.PluginName,
 	compat.PluginName,
 	maxim.PluginName,
+	chaos.PluginName,
+	circuitbreaker.PluginName,
+	mocker.PluginName,
 }
 
 func GetBuiltinPluginNames() []string {
@@ -149,6 +161,10 @@ type pluginOrderInfo struct {
 
 type ServerConfig struct {
 	ReadBufferSize int `json:"read_buffer_size,omitempty"`
+	// LogStyle overrides the -log-style flag when set (json, pretty or structured).
+	LogStyle string `json:"log_style,omitempty"`
+	// StreamPacing throttles SSE chunk delivery on matching routes.
+	StreamPacing []StreamPacingRule `json:"stream_pacing,omitempty"`
 }
 
 // ConfigData represents the configuration data for the Bifrost HTTP transport.
@@ -159,25 +175,32 @@ type ConfigData struct {
 	// from config.json. Omitting this field or setting it to 2 uses v1.5.0+ semantics:
 	// empty = deny all, ["*"] = allow all. Setting it to 1 restores v1.4.x semantics:
 	// empty = allow all (equivalent to ["*"]).
-	Version       int                       `json:"version,omitempty"`
-	EnvLabel      string                    `json:"env_label,omitempty"`
-	Server        *ServerConfig             `json:"server,omitempty"`
-	SourceOfTruth string                    `json:"source_of_truth,omitempty"`
-	Client        *configstore.ClientConfig `json:"client"`
-	EncryptionKey *schemas.SecretVar        `json:"encryption_key"`
+	Version       int                         `json:"version,omitempty"`
+	EnvLabel      string                      `json:"env_label,omitempty"`
+	Deployment    *schemas.DeploymentMetadata `json:"deployment,omitempty"`
+	Server        *ServerConfig               `json:"server,omitempty"`
+	SourceOfTruth string                      `json:"source_of_truth,omitempty"`
+	Client        *configstore.ClientConfig   `json:"client"`
+	EncryptionKey *schemas.SecretVar          `json:"encryption_key"`
 	// Deprecated: Use GovernanceConfig.AuthConfig instead
-	AuthConfig        *configstore.AuthConfig               `json:"auth_config,omitempty"`
-	Providers         map[string]configstore.ProviderConfig `json:"providers"`
-	FrameworkConfig   *framework.FrameworkConfig            `json:"framework,omitempty"`
-	MCP               *schemas.MCPConfig                    `json:"mcp,omitempty"`
-	Webhooks          []*WebhookEndpointConfig              `json:"webhooks,omitempty"`
-	Governance        *configstore.GovernanceConfig         `json:"governance,omitempty"`
-	VectorStoreConfig *vectorstore.Config                   `json:"vector_store,omitempty"`
-	ConfigStoreConfig *configstore.Config                   `json:"config_store,omitempty"`
-	LogsStoreConfig   *logstore.Config                      `json:"logs_store,omitempty"`
-	Plugins           []*schemas.PluginConfig               `json:"plugins,omitempty"`
-	WebSocket         *schemas.WebSocketConfig              `json:"websocket,omitempty"`
-	FeatureFlags      *FeatureFlagsFileConfig               `json:"feature_flags,omitempty"`
+	AuthConfig         *configstore.AuthConfig               `json:"auth_config,omitempty"`
+	Providers          map[string]configstore.ProviderConfig `json:"providers"`
+	FrameworkConfig    *framework.FrameworkConfig            `json:"framework,omitempty"`
+	MCP                *schemas.MCPConfig                    `json:"mcp,omitempty"`
+	Webhooks           []*WebhookEndpointConfig              `json:"webhooks,omitempty"`
+	Governance         *configstore.GovernanceConfig         `json:"governance,omitempty"`
+	VectorStoreConfig  *vectorstore.Config                   `json:"vector_store,omitempty"`
+	ConfigStoreConfig  *configstore.Config                   `json:"config_store,omitempty"`
+	LogsStoreConfig    *logstore.Config                      `json:"logs_store,omitempty"`
+	Plugins            []*schemas.PluginConfig               `json:"plugins,omitempty"`
+	WebSocket          *schemas.WebSocketConfig              `json:"websocket,omitempty"`
+	FeatureFlags       *FeatureFlagsFileConfig               `json:"feature_flags,omitempty"`
+	ResponseSigning    *ResponseSigningConfig                `json:"response_signing,omitempty"`
+	ModelGroups        []schemas.ModelGroup                  `json:"model_groups,omitempty"`
+	RetryBudget        *schemas.RetryBudgetConfig            `json:"retry_budget,omitempty"`
+	ImagePreprocessing *schemas.ImagePreprocessingConfig     `json:"image_preprocessing,omitempty"`
+	ConfidentialFields *schemas.ConfidentialFieldsConfig     `json:"confidential_fields,omitempty"`
+	RequestFlags       []schemas.RequestFlag                 `json:"request_flags,omitempty"`
 
 	presentSections           map[string]bool
 	presentGovernanceSections map[string]bool
@@ -417,25 +440,32 @@ func (cd *ConfigData) UnmarshalJSON(data []byte) error {
 
 	// First, unmarshal into a temporary struct to get all fields except the complex configs
 	type TempConfigData struct {
-		Version           int                                   `json:"version,omitempty"`
-		EnvLabel          string                                `json:"env_label,omitempty"`
-		SourceOfTruth     string                                `json:"source_of_truth,omitempty"`
-		FrameworkConfig   json.RawMessage                       `json:"framework,omitempty"`
-		Server            *ServerConfig                         `json:"server,omitempty"`
-		Client            *configstore.ClientConfig             `json:"client"`
-		EncryptionKey     *schemas.SecretVar                    `json:"encryption_key"`
-		AuthConfig        *configstore.AuthConfig               `json:"auth_config,omitempty"`
-		Providers         map[string]configstore.ProviderConfig `json:"providers"`
-		MCP               *schemas.MCPConfig                    `json:"mcp,omitempty"`
-		Webhooks          []*WebhookEndpointConfig              `json:"webhooks,omitempty"`
-		Governance        *configstore.GovernanceConfig         `json:"governance,omitempty"`
-		VectorStoreConfig json.RawMessage                       `json:"vector_store,omitempty"`
-		ConfigStoreConfig json.RawMessage                       `json:"config_store,omitempty"`
-		LogsStoreConfig   json.RawMessage                       `json:"logs_store,omitempty"`
-		Plugins           []*schemas.PluginConfig               `json:"plugins,omitempty"`
-		WebSocket         *schemas.WebSocketConfig              `json:"websocket,omitempty"`
-		FeatureFlags      *FeatureFlagsFileConfig               `json:"feature_flags,omitempty"`
-		SkillsRegistry    *SkillsRegistryConfig                 `json:"skills_registry,omitempty"`
+		Version            int                                   `json:"version,omitempty"`
+		EnvLabel           string                                `json:"env_label,omitempty"`
+		Deployment         *schemas.DeploymentMetadata           `json:"deployment,omitempty"`
+		SourceOfTruth      string                                `json:"source_of_truth,omitempty"`
+		FrameworkConfig    json.RawMessage                       `json:"framework,omitempty"`
+		Server             *ServerConfig                         `json:"server,omitempty"`
+		Client             *configstore.ClientConfig             `json:"client"`
+		EncryptionKey      *schemas.SecretVar                    `json:"encryption_key"`
+		AuthConfig         *configstore.AuthConfig               `json:"auth_config,omitempty"`
+		Providers          map[string]configstore.ProviderConfig `json:"providers"`
+		MCP                *schemas.MCPConfig                    `json:"mcp,omitempty"`
+		Webhooks           []*WebhookEndpointConfig              `json:"webhooks,omitempty"`
+		Governance         *configstore.GovernanceConfig         `json:"governance,omitempty"`
+		VectorStoreConfig  json.RawMessage                       `json:"vector_store,omitempty"`
+		ConfigStoreConfig  json.RawMessage                       `json:"config_store,omitempty"`
+		LogsStoreConfig    json.RawMessage                       `json:"logs_store,omitempty"`
+		Plugins            []*schemas.PluginConfig               `json:"plugins,omitempty"`
+		WebSocket          *schemas.WebSocketConfig              `json:"websocket,omitempty"`
+		FeatureFlags       *FeatureFlagsFileConfig               `json:"feature_flags,omitempty"`
+		ResponseSigning    *ResponseSigningConfig                `json:"response_signing,omitempty"`
+		SkillsRegistry     *SkillsRegistryConfig                 `json:"skills_registry,omitempty"`
+		ModelGroups        []schemas.ModelGroup                  `json:"model_groups,omitempty"`
+		RetryBudget        *schemas.RetryBudgetConfig            `json:"retry_budget,omitempty"`
+		ImagePreprocessing *schemas.ImagePreprocessingConfig     `json:"image_preprocessing,omitempty"`
+		ConfidentialFields *schemas.ConfidentialFieldsConfig     `json:"confidential_fields,omitempty"`
+		RequestFlags       []schemas.RequestFlag                 `json:"request_flags,omitempty"`
 	}
 
 	var temp TempConfigData
@@ -446,6 +476,8 @@ func (cd *ConfigData) UnmarshalJSON(data []byte) error {
 	// Set simple fields
 	cd.Version = temp.Version
 	cd.EnvLabel = temp.EnvLabel
+	cd.Deployment = temp.Deployment
+	cd.ResponseSigning = temp.ResponseSigning
 	cd.SourceOfTruth = normalizeSourceOfTruth(temp.SourceOfTruth)
 	cd.Client = temp.Client
 	cd.Server = temp.Server
@@ -458,6 +490,11 @@ func (cd *ConfigData) UnmarshalJSON(data []byte) error {
 	cd.Plugins = temp.Plugins
 	cd.WebSocket = temp.WebSocket
 	cd.FeatureFlags = temp.FeatureFlags
+	cd.ModelGroups = temp.ModelGroups
+	cd.RetryBudget = temp.RetryBudget
+	cd.ImagePreprocessing = temp.ImagePreprocessing
+	cd.ConfidentialFields = temp.ConfidentialFields
+	cd.RequestFlags = temp.RequestFlags
 	cd.presentGovernanceSections = nil
 	if rawGovernance, ok := raw["governance"]; ok && len(rawGovernance) > 0 {
 		var rawGovernanceFields map[string]json.RawMessage
@@ -600,6 +637,8 @@ type Config struct {
 	AsyncJobExecutor *logstore.AsyncJobExecutor
 	// Shared in-memory kvstore for transport-level protocol coordination.
 	KVStore *kvstore.Store
+	// Streams that opted into broadcast, attachable by request ID.
+	StreamBroadcasts *StreamBroadcasts
 
 	// Process-wide feature flag store. Flags are code-declared via
 	// featureflags.Register; this struct holds the effective state with
@@ -619,6 +658,36 @@ type Config struct {
 	// environment (e.g. "staging", "prod"). Set via config.json env_label or BIFROST_ENV_LABEL env var.
 	EnvLabel string
 
+	// Deployment identifies this instance (region, instance ID) for response headers,
+	// logs and traces, and picks same-region provider endpoints. Set via config.json
+	// deployment or the BIFROST_REGION / BIFROST_INSTANCE_ID env vars; the instance ID
+	// defaults to the hostname.
+	Deployment schemas.DeploymentMetadata
+
+	// ModelGroups are logical model names that resolve to an ordered list of
+	// provider/model targets. Set via config.json model_groups.
+	ModelGroups []schemas.ModelGroup
+
+	// RetryBudget caps retries across all providers to a share of recent
+	// requests. Set via config.json retry_budget; nil = no global cap.
+	RetryBudget *schemas.RetryBudgetConfig
+
+	// ImagePreprocessing normalizes chat images for each provider. Set via
+	// config.json image_preprocessing; nil = images are sent as received.
+	ImagePreprocessing *schemas.ImagePreprocessingConfig
+
+	// ConfidentialFields enables encrypted message fields that only the provider
+	// call sees in plaintext. Set via config.json confidential_fields; nil = off.
+	ConfidentialFields *schemas.ConfidentialFieldsConfig
+
+	// RequestFlags are feature flags evaluated per inference request. Set via
+	// config.json request_flags.
+	RequestFlags []schemas.RequestFlag
+
+	// ResponseSigner signs buffered inference responses for attestation. Nil when
+	// response_signing is not enabled.
+	ResponseSigner *ResponseSigner
+
 	// StreamingDecompressThreshold overrides the default threshold (10MB) for
 	// switching from buffered to streaming request decompression. Set by
 	// enterprise from LargePayloadConfig.RequestThresholdBytes. Zero means
@@ -924,10 +993,11 @@ func LoadConfig(ctx context.Context, configDirPath string) (*Config, error) {
 	if err := initStores(ctx, config, &configData, configDBPath, logsDBPath); err != nil {
 		return nil, err
 	}
-	// 3. KV store
+	// 3. KV store and stream broadcast registry
 	if err := initKVStore(config); err != nil {
 		return nil, err
 	}
+	config.StreamBroadcasts = NewStreamBroadcasts()
 	// 3a. Feature flags (after ConfigStore from initStores, before handlers)
 	if err := initFeatureFlags(ctx, config, &configData); err != nil {
 		return nil, err
@@ -974,6 +1044,22 @@ func LoadConfig(ctx context.Context, configDirPath string) (*Config, error) {
 	} else if label := strings.TrimSpace(os.Getenv("BIFROST_ENV_LABEL")); label != "" {
 		config.EnvLabel = truncateLabel(label)
 	}
+	// 14a. Deployment metadata (config.json takes precedence over env vars)
+	config.Deployment = resolveDeploymentMetadata(configData.Deployment)
+	// Model groups (validated when the bifrost client is initialized)
+	config.ModelGroups = configData.ModelGroups
+	// Retry budget (validated when the bifrost client is initialized)
+	config.RetryBudget = configData.RetryBudget
+	// Image preprocessing (validated when the bifrost client is initialized)
+	config.ImagePreprocessing = configData.ImagePreprocessing
+	// Confidential fields (validated when the bifrost client is initialized)
+	config.ConfidentialFields = configData.ConfidentialFields
+	// Request flags (validated when the bifrost client is initialized)
+	config.RequestFlags = configData.RequestFlags
+	// 14b. Response signing
+	if config.ResponseSigner, err = NewResponseSigner(configData.ResponseSigning); err != nil {
+		return nil, err
+	}
 	// 15. WebSocket defaults
 	if configData.WebSocket != nil {
 		configData.WebSocket.CheckAndSetDefaults()
@@ -994,6 +1080,29 @@ func LoadConfig(ctx context.Context, configDirPath string) (*Config, error) {
 	return config, nil
 }
 
+// resolveDeploymentMetadata fills in the deployment metadata of this instance:
+// values from config.json win, then BIFROST_REGION / BIFROST_INSTANCE_ID, and the
+// instance ID falls back to the hostname.
+func resolveDeploymentMetadata(fileDeployment *schemas.DeploymentMetadata) schemas.DeploymentMetadata {
+	var deployment schemas.DeploymentMetadata
+	if fileDeployment != nil {
+		deployment.Region = strings.TrimSpace(fileDeployment.Region)
+		deployment.InstanceID = strings.TrimSpace(fileDeployment.InstanceID)
+	}
+	if deployment.Region == "" {
+		deployment.Region = strings.TrimSpace(os.Getenv("BIFROST_REGION"))
+	}
+	if deployment.InstanceID == "" {
+		deployment.InstanceID = strings.TrimSpace(os.Getenv("BIFROST_INSTANCE_ID"))
+	}
+	if deployment.InstanceID == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			deployment.InstanceID = hostname
+		}
+	}
+	return deployment
+}
+
 // initStores initializes config, logs, and vector stores.
 // When config data sections are absent (nil), creates default SQLite stores for persistence.
 func initStores(ctx context.Context, config *Config, configData *ConfigData, configDBPath, logsDBPath string) error {
@@ -1558,6 +1667,8 @@ func mergeProviderKeys(provider schemas.ModelProvider, fileKeys, dbKeys []schema
 					Enabled:                dbKey.Enabled,
 					UseForBatchAPI:         dbKey.UseForBatchAPI,
 					UseAnthropicEndpoints:  dbKey.UseAnthropicEndpoints,
+					BYOK:                   dbKey.BYOK,
+					BetaFeatures:           dbKey.BetaFeatures,
 				})
 				if err != nil {
 					logger.Warn("failed to generate key hash for db key %s (%s): %v, falling back to name comparison", dbKey.Name, provider, err)
@@ -1641,6 +1752,8 @@ func reconcileProviderKeys(provider schemas.ModelProvider, fileKeys, dbKeys []sc
 					Enabled:                dbKey.Enabled,
 					UseForBatchAPI:         dbKey.UseForBatchAPI,
 					UseAnthropicEndpoints:  dbKey.UseAnthropicEndpoints,
+					BYOK:                   dbKey.BYOK,
+					BetaFeatures:           dbKey.BetaFeatures,
 				})
 				if err != nil {
 					logger.Warn("failed to generate key hash for db key %s (%s): %v", dbKey.Name, provider, err)
@@ -1890,6 +2003,12 @@ func mcpClientConfigToTable(clientConfig *schemas.MCPClientConfig) (configstoreT
 			clientConfig.ToolExecutionTimeout.String(),
 		)
 	}
+	if !clientConfig.OutputValidation.IsValid() {
+		return configstoreTables.TableMCPClient{}, fmt.Errorf(
+			"output_schema_validation must be off, warn, coerce or error, got %q",
+			clientConfig.OutputValidation,
+		)
+	}
 	authType := string(clientConfig.AuthType)
 	if authType == "" {
 		authType = string(schemas.MCPAuthTypeHeaders)
@@ -1910,6 +2029,7 @@ func mcpClientConfigToTable(clientConfig *schemas.MCPClientConfig) (configstoreT
 		IsPingAvailable:           clientConfig.IsPingAvailable,
 		ToolSyncInterval:          int(clientConfig.ToolSyncInterval / time.Second),
 		ToolExecutionTimeout:      int(math.Ceil(clientConfig.ToolExecutionTimeout.Seconds())),
+		OutputValidation:          string(clientConfig.OutputValidation),
 		ToolPricing:               clientConfig.ToolPricing,
 		AllowOnAllVirtualKeys:     clientConfig.AllowOnAllVirtualKeys,
 		Disabled:                  clientConfig.Disabled,
@@ -2212,6 +2332,10 @@ func loadGovernanceConfig(ctx context.Context, config *Config, configData *Confi
 	if governanceConfig != nil && configData.Governance != nil {
 		mergeGovernanceConfig(ctx, config, configData, governanceConfig)
 	}
+	// Budget alerts are not persisted, so the file is always their source.
+	if config.GovernanceConfig != nil && configData.Governance != nil {
+		config.GovernanceConfig.BudgetAlerts = configData.Governance.BudgetAlerts
+	}
 }
 
 func resolveGovernanceKeyReferences(ctx context.Context, config *Config, governanceConfig *configstore.GovernanceConfig) error {
@@ -4395,20 +4519,20 @@ func ResolveFrameworkPricingConfig(
 	}
 
 	return &configstoreTables.TableFrameworkConfig{
-			ID:                     configID,
-			PricingURL:             resolvedPricingURL,
-			PricingSyncInterval:    resolvedSyncSeconds,
-			ModelParametersURL:     resolvedModelParametersURL,
-			MCPLibraryURL:          resolvedMCPLibraryURL,
-			MCPLibrarySyncInterval: resolvedMCPLibrarySyncInterval,
-			ConfigHash:             persistedHash,
-		}, &modelcatalog.Config{
-			PricingURL:             resolvedPricingURL,
-			PricingSyncInterval:    resolvedSyncSeconds,
-			ModelParametersURL:     resolvedModelParametersURL,
-			MCPLibraryURL:          resolvedMCPLibraryURL,
-			MCPLibrarySyncInterval: resolvedMCPLibrarySyncInterval,
-		}, needsDBUpdate
+		ID:                     configID,
+		PricingURL:             resolvedPricingURL,
+		PricingSyncInterval:    resolvedSyncSeconds,
+		ModelParametersURL:     resolvedModelParametersURL,
+		MCPLibraryURL:          resolvedMCPLibraryURL,
+		MCPLibrarySyncInterval: resolvedMCPLibrarySyncInterval,
+		ConfigHash:             persistedHash,
+	}, &modelcatalog.Config{
+		PricingURL:             resolvedPricingURL,
+		PricingSyncInterval:    resolvedSyncSeconds,
+		ModelParametersURL:     resolvedModelParametersURL,
+		MCPLibraryURL:          resolvedMCPLibraryURL,
+		MCPLibrarySyncInterval: resolvedMCPLibrarySyncInterval,
+	}, needsDBUpdate
 }
 
 // initFrameworkConfig initializes framework config and pricing manager from file
@@ -4910,6 +5034,11 @@ func (c *Config) GetKVStore() *kvstore.Store {
 	return c.KVStore
 }
 
+// GetStreamBroadcasts returns the registry of streams that opted into broadcast.
+func (c *Config) GetStreamBroadcasts() *StreamBroadcasts {
+	return c.StreamBroadcasts
+}
+
 // Close gracefully shuts down all background components associated with the Config.
 // This includes ModelCatalog sync worker, TokenRefreshWorker, KVStore cleanup loop,
 // ConfigStore, LogsStore, and VectorStore. It should be called when the Config is
@@ -5823,6 +5952,87 @@ func (c *Config) UpdateProviderKey(ctx context.Context, provider schemas.ModelPr
 	return nil
 }
 
+// SetProviderKeysEnabled enables or disables every key of a provider. Keys
+// already in the requested state are left alone; the rest are written in a
+// single config store transaction, so either all of them change or none do.
+// It returns the keys that changed and the IDs of those that did not.
+func (c *Config) SetProviderKeysEnabled(ctx context.Context, provider schemas.ModelProvider, enabled bool) ([]schemas.Key, []string, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	existingConfig, exists := c.Providers[provider]
+	if !exists {
+		return nil, nil, ErrNotFound
+	}
+
+	updatedConfig := existingConfig
+	updatedConfig.Keys = append([]schemas.Key(nil), existingConfig.Keys...)
+	var changed []int
+	var unchanged []string
+	for i, key := range updatedConfig.Keys {
+		if (key.Enabled == nil || *key.Enabled) == enabled {
+			unchanged = append(unchanged, key.ID)
+			continue
+		}
+		key.Enabled = schemas.Ptr(enabled)
+		updatedConfig.Keys[i] = key
+		changed = append(changed, i)
+	}
+	if len(changed) == 0 {
+		return nil, unchanged, nil
+	}
+
+	skipDBUpdate := false
+	if ctx.Value(schemas.BifrostContextKeySkipDBUpdate) != nil {
+		if skip, ok := ctx.Value(schemas.BifrostContextKeySkipDBUpdate).(bool); ok {
+			skipDBUpdate = skip
+		}
+	}
+	if c.ConfigStore != nil && !skipDBUpdate {
+		if err := c.ConfigStore.ExecuteTransaction(ctx, func(tx *gorm.DB) error {
+			for _, i := range changed {
+				key := updatedConfig.Keys[i]
+				if err := c.ConfigStore.UpdateProviderKey(ctx, provider, key.ID, key, tx); err != nil {
+					return fmt.Errorf("key %s: %w", key.ID, err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to update provider keys in store: %w", err)
+		}
+		// Re-read for the same reason as UpdateProviderKey: the vault store
+		// callback only rewrites the stored row.
+		for _, i := range changed {
+			storedKey, err := c.ConfigStore.GetProviderKey(ctx, provider, updatedConfig.Keys[i].ID)
+			if err != nil {
+				logger.Error("failed to re-read stored key %s for provider %s after update: %v", updatedConfig.Keys[i].ID, provider, err)
+				return nil, nil, fmt.Errorf("failed to re-read provider key after update: %w", err)
+			}
+			updatedConfig.Keys[i] = *storedKey
+		}
+	}
+
+	c.Providers[provider] = updatedConfig
+
+	c.Mu.Unlock()
+	clientErr := c.client.UpdateProvider(provider)
+	c.Mu.Lock()
+
+	if clientErr != nil {
+		if reflect.DeepEqual(c.Providers[provider], updatedConfig) {
+			c.Providers[provider] = existingConfig
+		}
+		return nil, nil, fmt.Errorf("failed to update provider: %w", clientErr)
+	}
+
+	changedKeys := make([]schemas.Key, 0, len(changed))
+	for _, i := range changed {
+		changedKeys = append(changedKeys, updatedConfig.Keys[i])
+	}
+	logger.Info("Set enabled=%t on %d keys for provider: %s", enabled, len(changedKeys), provider)
+	return changedKeys, unchanged, nil
+}
+
 // RemoveProviderKey removes a single key from an existing provider configuration.
 func (c *Config) RemoveProviderKey(ctx context.Context, provider schemas.ModelProvider, keyID string) error {
 	c.Mu.Lock()
@@ -6151,6 +6361,7 @@ func (c *Config) UpdateMCPClient(ctx context.Context, id string, updatedConfig *
 	c.MCPConfig.ClientConfigs[configIndex].IsPingAvailable = updatedConfig.IsPingAvailable
 	c.MCPConfig.ClientConfigs[configIndex].ToolSyncInterval = updatedConfig.ToolSyncInterval
 	c.MCPConfig.ClientConfigs[configIndex].ToolExecutionTimeout = updatedConfig.ToolExecutionTimeout
+	c.MCPConfig.ClientConfigs[configIndex].OutputValidation = updatedConfig.OutputValidation
 	c.MCPConfig.ClientConfigs[configIndex].AllowOnAllVirtualKeys = updatedConfig.AllowOnAllVirtualKeys
 	c.MCPConfig.ClientConfigs[configIndex].Disabled = updatedConfig.Disabled
 	c.MCPConfig.ClientConfigs[configIndex].PerUserHeaderKeys = updatedConfig.PerUserHeaderKeys
@@ -6586,8 +6797,11 @@ func ValidateCustomProvider(config configstore.ProviderConfig, provider schemas.
 
 	cpc := config.CustomProviderConfig
 
-	// Validate base provider type
+	// Validate base provider type; providers served by a translator need none
 	if cpc.BaseProviderType == "" {
+		if cpc.Translator != "" {
+			return nil
+		}
 		return fmt.Errorf("custom provider validation failed: base_provider_type is required")
 	}
 
@@ -6770,4 +6984,4 @@ func DeepCopy[T any](in T) (T, error) {
 	}
 	err = sonic.Unmarshal(b, &out)
 	return out, err
-}
\ No newline at end of file
+}
@@ -1150,6 +1150,91 @@ func (m *MockConfigStore) DeleteExpiredTempTokens(ctx context.Context, before ti
 	return 0, nil
 }
 
+// Session affinity
+func (m *MockConfigStore) GetSessionAffinity(ctx context.Context, sessionHash string) (*tables.TableSessionAffinity, error) {
+	return nil, nil
+}
+
+func (m *MockConfigStore) UpsertSessionAffinity(ctx context.Context, affinity *tables.TableSessionAffinity) error {
+	return nil
+}
+
+func (m *MockConfigStore) DeleteExpiredSessionAffinities(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// Replica config versions
+func (m *MockConfigStore) UpsertReplicaConfigVersion(ctx context.Context, version *tables.TableReplicaConfigVersion) error {
+	return nil
+}
+
+func (m *MockConfigStore) GetReplicaConfigVersions(ctx context.Context, seenSince time.Time) ([]tables.TableReplicaConfigVersion, error) {
+	return nil, nil
+}
+
+func (m *MockConfigStore) DeleteStaleReplicaConfigVersions(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// Config snapshots
+func (m *MockConfigStore) CaptureConfigSnapshot(ctx context.Context) (*tables.TableConfigSnapshot, bool, error) {
+	return nil, false, nil
+}
+
+func (m *MockConfigStore) GetConfigSnapshots(ctx context.Context, limit int) ([]tables.TableConfigSnapshot, error) {
+	return nil, nil
+}
+
+func (m *MockConfigStore) RestoreConfigSnapshot(ctx context.Context, hash string) error {
+	return nil
+}
+
+func (m *MockConfigStore) DeleteConfigSnapshotsBeyond(ctx context.Context, keep int) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockConfigStore) ConfigMutations() <-chan struct{} {
+	return nil
+}
+
+func (m *MockConfigStore) CreateGovernanceOverride(ctx context.Context, override *tables.TableGovernanceOverride) error {
+	return nil
+}
+
+func (m *MockConfigStore) GetGovernanceOverrides(ctx context.Context) ([]tables.TableGovernanceOverride, error) {
+	return nil, nil
+}
+
+func (m *MockConfigStore) GetGovernanceOverrideByHash(ctx context.Context, tokenHash string) (*tables.TableGovernanceOverride, error) {
+	return nil, nil
+}
+
+func (m *MockConfigStore) RevokeGovernanceOverride(ctx context.Context, id string, revokedAt time.Time) error {
+	return nil
+}
+
+func (m *MockConfigStore) RecordGovernanceOverrideUsage(ctx context.Context, id string, usedAt time.Time) error {
+	return nil
+}
+
+// Bulk operations
+func (m *MockConfigStore) CreateBulkOperation(ctx context.Context, operation *tables.TableBulkOperation) error {
+	return nil
+}
+
+func (m *MockConfigStore) GetBulkOperations(ctx context.Context, limit int) ([]tables.TableBulkOperation, error) {
+	return nil, nil
+}
+
+// Log access events
+func (m *MockConfigStore) CreateLogAccessEvents(ctx context.Context, events []tables.TableLogAccessEvent) error {
+	return nil
+}
+
+func (m *MockConfigStore) GetLogAccessEvents(ctx context.Context, logID string, limit int) ([]tables.TableLogAccessEvent, error) {
+	return nil, nil
+}
+
 // Model pricing
 func (m *MockConfigStore) GetModelPrices(ctx context.Context) ([]tables.TableModelPricing, error) {
 	return nil, nil
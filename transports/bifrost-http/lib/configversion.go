@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+)
+
+// ConfigVersionHash returns a hash of the config this replica serves: the client
+// config and every provider with its keys. Replicas that loaded, or were updated
+// to, the same config produce the same hash whatever order it was applied in;
+// see framework/configversion for how replicas compare it.
+func (c *Config) ConfigVersionHash() (string, error) {
+	c.Mu.RLock()
+	defer c.Mu.RUnlock()
+
+	hash := sha256.New()
+	if c.ClientConfig != nil {
+		clientHash, err := c.ClientConfig.GenerateClientConfigHash()
+		if err != nil {
+			return "", fmt.Errorf("failed to hash client config: %w", err)
+		}
+		hash.Write([]byte("client:" + clientHash))
+	}
+
+	providers := make([]schemas.ModelProvider, 0, len(c.Providers))
+	for provider := range c.Providers {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	for _, provider := range providers {
+		providerConfig := c.Providers[provider]
+		providerHash, err := providerConfig.GenerateConfigHash(string(provider))
+		if err != nil {
+			return "", fmt.Errorf("failed to hash provider %s: %w", provider, err)
+		}
+		hash.Write([]byte("provider:" + providerHash))
+		// Key IDs are generated per store, so keys are compared by content only.
+		keyHashes := make([]string, 0, len(providerConfig.Keys))
+		for _, key := range providerConfig.Keys {
+			keyHash, err := configstore.GenerateKeyHash(key)
+			if err != nil {
+				return "", fmt.Errorf("failed to hash key %s of provider %s: %w", key.Name, provider, err)
+			}
+			keyHashes = append(keyHashes, keyHash)
+		}
+		slices.Sort(keyHashes)
+		for _, keyHash := range keyHashes {
+			hash.Write([]byte("key:" + keyHash))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
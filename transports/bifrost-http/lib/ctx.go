@@ -124,6 +124,7 @@ func ParseSessionIDFromBaggage(header string) string {
 //
 // 4. Governance Headers:
 //   - x-bf-vk: Virtual key for governance (required for governance to work)
+//   - x-bf-governance-override: Admin-minted override token that lifts the budgets and/or rate limits it covers
 //
 // 5. API Key Headers:
 //   - Authorization: Bearer token format only (e.g., "Bearer sk-...") - OpenAI style
@@ -147,6 +148,19 @@ func ParseSessionIDFromBaggage(header string) string {
 //   - x-bf-session-id: Session identifier for key binding (reuse same key across requests)
 //   - x-bf-session-ttl: Per-request TTL override (duration string e.g. "30m" or seconds integer)
 //
+// 8a. Fallback Chain Header:
+//   - x-bf-fallbacks: Comma-separated provider/model pairs (e.g. "anthropic/claude-sonnet-4,openai/gpt-4o-mini")
+//     that replace the configured fallback chain for this request; entries the virtual key may not use are dropped
+//
+// 8b. Priority Header:
+//   - x-bf-priority: "interactive" (default) or "batch". When a provider's workers are saturated,
+//     queued interactive requests are served before queued batch ones. A priority set on the
+//     virtual key takes precedence.
+//
+// 8c. Structured Output Header:
+//   - x-bf-validate-structured-output: "true" checks chat completion responses against the
+//     response_format json_schema, retrying once when the JSON does not match.
+//
 // 9. Raw Capture Headers (per-request override of provider config; accepts "true" or "false"):
 //   - x-bf-send-back-raw-request: include raw provider request in the BifrostResponse returned to the caller
 //   - x-bf-send-back-raw-response: include raw provider response in the BifrostResponse returned to the caller
@@ -251,12 +265,13 @@ func ConvertToBifrostContext(ctx *fasthttp.RequestCtx, store HandlerStore) (*sch
 		"transfer-encoding":   true,
 
 		// prevent auth/key overrides via x-bf-eh-*
-		"x-api-key":       true,
-		"x-goog-api-key":  true,
-		"x-bf-api-key":    true,
-		"x-bf-api-key-id": true,
-		"x-bf-vk":         true,
-		"x-bf-direct-key": true,
+		"x-api-key":                true,
+		"x-goog-api-key":           true,
+		"x-bf-api-key":             true,
+		"x-bf-api-key-id":          true,
+		"x-bf-vk":                  true,
+		"x-bf-direct-key":          true,
+		"x-bf-governance-override": true,
 	}
 
 	// Debug: Log header matcher state
@@ -469,6 +484,74 @@ func ConvertToBifrostContext(ctx *fasthttp.RequestCtx, store HandlerStore) (*sch
 			}
 			return true
 		}
+		// Hedged requests: delay before the first fallback is raced against the primary (duration string or milliseconds integer)
+		if keyStr == "x-bf-hedge-delay" {
+			valueStr := strings.TrimSpace(string(value))
+			delay, err := time.ParseDuration(valueStr)
+			if err != nil {
+				if millis, parseErr := strconv.Atoi(valueStr); parseErr == nil {
+					delay, err = time.Duration(millis)*time.Millisecond, nil
+				}
+			}
+			if err == nil && delay > 0 {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyHedgeDelay, delay)
+			}
+			return true
+		}
+		// First-token timeout: how long a stream waits for its first chunk before restarting on the next fallback (duration string or milliseconds integer)
+		if keyStr == "x-bf-first-token-timeout" {
+			valueStr := strings.TrimSpace(string(value))
+			timeout, err := time.ParseDuration(valueStr)
+			if err != nil {
+				if millis, parseErr := strconv.Atoi(valueStr); parseErr == nil {
+					timeout, err = time.Duration(millis)*time.Millisecond, nil
+				}
+			}
+			if err == nil && timeout > 0 {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyFirstTokenTimeout, timeout)
+			}
+			return true
+		}
+		// Fallback chain override: comma-separated provider/model pairs replacing the configured chain
+		if keyStr == "x-bf-fallbacks" {
+			var fallbacks []schemas.Fallback
+			for _, entry := range strings.Split(string(value), ",") {
+				provider, model := schemas.ParseModelString(strings.TrimSpace(entry), "")
+				if provider != "" && model != "" {
+					fallbacks = append(fallbacks, schemas.Fallback{Provider: provider, Model: model})
+				}
+			}
+			if len(fallbacks) > 0 {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyFallbacksOverride, fallbacks)
+			}
+			return true
+		}
+		// Governance override token: lifts the budgets and/or rate limits it was minted for
+		if keyStr == "x-bf-governance-override" {
+			if valueStr := strings.TrimSpace(string(value)); valueStr != "" {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyGovernanceOverrideToken, valueStr)
+			}
+			return true
+		}
+		if keyStr == "x-bf-priority" {
+			if priority, ok := schemas.ParseRequestPriority(string(value)); ok {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyRequestPriority, priority)
+			}
+			return true
+		}
+		if keyStr == "x-bf-validate-structured-output" {
+			if b, err := strconv.ParseBool(string(value)); err == nil {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyValidateStructuredOutput, b)
+			}
+			return true
+		}
+		// Cost-based routing: per-request budget cap in US dollars
+		if keyStr == "x-bf-max-cost-usd" {
+			if maxCost, err := strconv.ParseFloat(strings.TrimSpace(string(value)), 64); err == nil && maxCost > 0 {
+				bifrostCtx.SetValue(schemas.BifrostContextKeyMaxCostUSD, maxCost)
+			}
+			return true
+		}
 		if labelName, ok := strings.CutPrefix(keyStr, "x-bf-eh-"); ok {
 			// Skip empty header names after prefix removal
 			if labelName == "" {
@@ -704,6 +787,8 @@ func ConvertToBifrostContext(ctx *fasthttp.RequestCtx, store HandlerStore) (*sch
 				Value:  schemas.SecretVar{Val: apiKey},
 				Models: []string{},
 				Weight: 1.0,
+				// The caller's own key, so its spend is tenant-owned.
+				BYOK: true,
 			}
 			bifrostCtx.SetValue(schemas.BifrostContextKeyDirectKey, key)
 		}
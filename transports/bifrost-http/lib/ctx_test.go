@@ -32,6 +32,8 @@ func (s testHandlerStore) ShouldAllowPerRequestRawOverride() bool     { return f
 func (s testHandlerStore) ShouldAllowDirectKeys() bool                { return s.allowDirectKeys }
 func (s testHandlerStore) GetMCPExternalServerURL() string            { return "" }
 func (s testHandlerStore) GetMCPExternalClientURL() string            { return "" }
+func (s testHandlerStore) StreamPacingFor(string) float64             { return 0 }
+func (s testHandlerStore) GetStreamBroadcasts() *StreamBroadcasts     { return nil }
 
 func TestParseSessionIDFromBaggage(t *testing.T) {
 	tests := []struct {
@@ -37,6 +37,14 @@ const (
 	// elapsed time to get what Bifrost cost. Distinct from the per-attempt
 	// latency in the response body's extra_fields, which only holds the last try.
 	HeaderBifrostUpstreamLatency = "x-bifrost-upstream-latency-ms"
+	// Set to the original "provider/model" when the circuit breaker degraded
+	// the request to a designated fallback model because that provider's
+	// circuit was open. Absent when the request was served normally.
+	HeaderBifrostDegradedFrom = "x-bifrost-degraded-from"
+	// Set on the 429 Bifrost returns when it sheds a request itself because a
+	// provider queue is saturated, naming why (see schemas.ShedReason). Its
+	// absence on a 429 means the rate limit came from the provider.
+	HeaderBifrostShedReason = "x-bifrost-shed-reason"
 )
 
 // Headers mirroring the non-deprecated ExtraFields.RoutingInfo fields 1:1.
@@ -55,6 +63,73 @@ const (
 	HeaderBifrostRoutingInfoServerSideFallbackModel = "x-bifrost-routing-info-server-side-fallback-model"
 )
 
+// Spend headers. Computed by the governance plugin from the model catalog and
+// the in-memory budget state once the final response is known, so clients can
+// show real-time spend without polling. Streams receive the same values in a
+// final SSE event (see StreamSpendEventType) because headers are already sent.
+const (
+	HeaderBifrostCost            = "x-bf-cost"
+	HeaderBifrostTokensIn        = "x-bf-tokens-in"
+	HeaderBifrostTokensOut       = "x-bf-tokens-out"
+	HeaderBifrostBudgetRemaining = "x-bf-budget-remaining"
+)
+
+// StreamSpendEventType is the SSE event name of the spend summary sent before
+// [DONE] on native streaming routes.
+const StreamSpendEventType = "bifrost.spend"
+
+// ResponseSpend is the per-request spend summary. Nil fields were not
+// computed (no pricing data, no usage, or no applicable budget).
+type ResponseSpend struct {
+	Cost            *float64 `json:"cost,omitempty"`
+	TokensIn        *int     `json:"tokens_in,omitempty"`
+	TokensOut       *int     `json:"tokens_out,omitempty"`
+	BudgetRemaining *float64 `json:"budget_remaining,omitempty"`
+}
+
+// GetResponseSpend reads the spend summary from the context. Returns false when
+// nothing was recorded for this request.
+func GetResponseSpend(bifrostCtx *schemas.BifrostContext) (ResponseSpend, bool) {
+	var spend ResponseSpend
+	if bifrostCtx == nil {
+		return spend, false
+	}
+	if cost, ok := bifrostCtx.Value(schemas.BifrostContextKeyResponseCost).(float64); ok {
+		spend.Cost = &cost
+	}
+	if tokensIn, ok := bifrostCtx.Value(schemas.BifrostContextKeyResponseTokensIn).(int); ok {
+		spend.TokensIn = &tokensIn
+	}
+	if tokensOut, ok := bifrostCtx.Value(schemas.BifrostContextKeyResponseTokensOut).(int); ok {
+		spend.TokensOut = &tokensOut
+	}
+	if remaining, ok := bifrostCtx.Value(schemas.BifrostContextKeyGovernanceBudgetRemaining).(float64); ok {
+		spend.BudgetRemaining = &remaining
+	}
+	return spend, spend != ResponseSpend{}
+}
+
+// applyResponseSpendHeaders writes the x-bf-* spend headers for whatever the
+// governance plugin recorded on the context.
+func applyResponseSpendHeaders(ctx *fasthttp.RequestCtx, bifrostCtx *schemas.BifrostContext) {
+	spend, ok := GetResponseSpend(bifrostCtx)
+	if !ok {
+		return
+	}
+	if spend.Cost != nil {
+		ctx.Response.Header.Set(HeaderBifrostCost, strconv.FormatFloat(*spend.Cost, 'f', -1, 64))
+	}
+	if spend.TokensIn != nil {
+		ctx.Response.Header.Set(HeaderBifrostTokensIn, strconv.Itoa(*spend.TokensIn))
+	}
+	if spend.TokensOut != nil {
+		ctx.Response.Header.Set(HeaderBifrostTokensOut, strconv.Itoa(*spend.TokensOut))
+	}
+	if spend.BudgetRemaining != nil {
+		ctx.Response.Header.Set(HeaderBifrostBudgetRemaining, strconv.FormatFloat(*spend.BudgetRemaining, 'f', -1, 64))
+	}
+}
+
 // ApplyBifrostStreamResponseHeaders emits the routed-identity headers for a
 // streaming response, before the first SSE write. Streams only carry
 // ExtraFields on chunks — none exist at header-write time — so the identity
@@ -79,7 +154,9 @@ func ApplyBifrostStreamResponseHeaders(ctx *fasthttp.RequestCtx, bifrostCtx *sch
 // whether a fallback fired. This exists only to bridge the error's
 // BifrostErrorExtraFields to the shared writer (a different type than the
 // success path's BifrostResponseExtraFields); provider response headers are
-// forwarded separately by the caller.
+// forwarded separately by the caller. A request Bifrost shed for saturation
+// also gets Retry-After and the shed reason, and a request turned away by a
+// rate limiting plugin gets Retry-After.
 func ApplyBifrostErrorResponseHeaders(ctx *fasthttp.RequestCtx, bifrostCtx *schemas.BifrostContext, extra schemas.BifrostErrorExtraFields) {
 	ApplyBifrostResponseHeaders(ctx, bifrostCtx, schemas.BifrostResponseExtraFields{
 		RequestType:            extra.RequestType,
@@ -88,6 +165,13 @@ func ApplyBifrostErrorResponseHeaders(ctx *fasthttp.RequestCtx, bifrostCtx *sche
 		OriginalModelRequested: extra.OriginalModelRequested,
 		ResolvedModelUsed:      extra.ResolvedModelUsed,
 	})
+	if saturation := extra.Saturation; saturation != nil {
+		ctx.Response.Header.Set("Retry-After", strconv.Itoa(saturation.RetryAfterSeconds))
+		ctx.Response.Header.Set(HeaderBifrostShedReason, string(saturation.Reason))
+	}
+	if rateLimit := extra.RateLimit; rateLimit != nil {
+		ctx.Response.Header.Set("Retry-After", strconv.Itoa(rateLimit.RetryAfterSeconds))
+	}
 }
 
 // ApplyBifrostResponseHeaders writes both the upstream provider response
@@ -158,5 +242,9 @@ func ApplyBifrostResponseHeaders(ctx *fasthttp.RequestCtx, bifrostCtx *schemas.B
 			ctx.Response.Header.Set(HeaderBifrostUpstreamLatency,
 				strconv.FormatFloat(float64(upstream)/float64(time.Millisecond), 'f', 3, 64))
 		}
+		if degradedFrom, ok := bifrostCtx.Value(schemas.BifrostContextKeyDegradedFrom).(string); ok && degradedFrom != "" {
+			ctx.Response.Header.Set(HeaderBifrostDegradedFrom, degradedFrom)
+		}
+		applyResponseSpendHeaders(ctx, bifrostCtx)
 	}
 }
@@ -173,6 +173,41 @@ func TestApplyBifrostResponseHeaders(t *testing.T) {
 		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostRoutingInfoPrimaryModel)))
 		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostRoutingInfoServerSideFallbackModel)))
 	})
+
+	t.Run("spend recorded by governance emits x-bf headers", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		bifrostCtx := newBifrostCtx()
+		bifrostCtx.SetValue(schemas.BifrostContextKeyResponseCost, 0.00125)
+		bifrostCtx.SetValue(schemas.BifrostContextKeyResponseTokensIn, 12)
+		bifrostCtx.SetValue(schemas.BifrostContextKeyResponseTokensOut, 30)
+		bifrostCtx.SetValue(schemas.BifrostContextKeyGovernanceBudgetRemaining, 4.5)
+
+		ApplyBifrostResponseHeaders(ctx, bifrostCtx, schemas.BifrostResponseExtraFields{Provider: schemas.OpenAI})
+
+		assert.Equal(t, "0.00125", string(ctx.Response.Header.Peek(HeaderBifrostCost)))
+		assert.Equal(t, "12", string(ctx.Response.Header.Peek(HeaderBifrostTokensIn)))
+		assert.Equal(t, "30", string(ctx.Response.Header.Peek(HeaderBifrostTokensOut)))
+		assert.Equal(t, "4.5", string(ctx.Response.Header.Peek(HeaderBifrostBudgetRemaining)))
+	})
+
+	t.Run("no spend recorded emits no spend headers", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+
+		ApplyBifrostResponseHeaders(ctx, newBifrostCtx(), schemas.BifrostResponseExtraFields{Provider: schemas.OpenAI})
+
+		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostCost)))
+		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostBudgetRemaining)))
+	})
+
+	t.Run("circuit breaker degradation emits degraded-from header", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		bifrostCtx := newBifrostCtx()
+		bifrostCtx.SetValue(schemas.BifrostContextKeyDegradedFrom, "openai/gpt-4o")
+
+		ApplyBifrostResponseHeaders(ctx, bifrostCtx, schemas.BifrostResponseExtraFields{Provider: schemas.Ollama})
+
+		assert.Equal(t, "openai/gpt-4o", string(ctx.Response.Header.Peek(HeaderBifrostDegradedFrom)))
+	})
 }
 
 // TestApplyBifrostStreamResponseHeaders covers the streaming variant: identity
@@ -312,5 +347,29 @@ func TestApplyBifrostErrorResponseHeaders(t *testing.T) {
 		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostProvider)))
 		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostRequestType)))
 		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostRoutingInfoProvider)))
+		assert.Empty(t, string(ctx.Response.Header.Peek("Retry-After")))
+	})
+
+	t.Run("shed request emits retry-after and shed reason", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+
+		ApplyBifrostErrorResponseHeaders(ctx, nil, schemas.BifrostErrorExtraFields{
+			Provider:   schemas.OpenAI,
+			Saturation: &schemas.GatewaySaturation{Reason: schemas.ShedReasonQueueFull, RetryAfterSeconds: 7, QueueDepth: 100},
+		})
+
+		assert.Equal(t, "7", string(ctx.Response.Header.Peek("Retry-After")))
+		assert.Equal(t, "queue_full", string(ctx.Response.Header.Peek(HeaderBifrostShedReason)))
+	})
+
+	t.Run("rate limited request emits retry-after", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+
+		ApplyBifrostErrorResponseHeaders(ctx, nil, schemas.BifrostErrorExtraFields{
+			RateLimit: &schemas.RateLimitExceeded{Scope: "virtual_key", Key: "vk-1", Limit: "rpm", RetryAfterSeconds: 3},
+		})
+
+		assert.Equal(t, "3", string(ctx.Response.Header.Peek("Retry-After")))
+		assert.Empty(t, string(ctx.Response.Header.Peek(HeaderBifrostShedReason)))
 	})
 }
@@ -0,0 +1,191 @@
+package lib
+
+// Signed response attestation. When enabled, every buffered inference response
+// carries an attestation (request ID, model, usage, SHA-256 of the body) and a
+// signature over it, so downstream services can check that the response really
+// transited Bifrost and that the usage figures were not altered on the way.
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// Response headers carrying the attestation. The signature covers the exact
+// bytes of the attestation header value.
+const (
+	HeaderBifrostAttestation        = "x-bifrost-attestation"
+	HeaderBifrostSignature          = "x-bifrost-signature"
+	HeaderBifrostSignatureKeyID     = "x-bifrost-signature-key-id"
+	HeaderBifrostSignatureAlgorithm = "x-bifrost-signature-alg"
+)
+
+// Supported response signing algorithms.
+const (
+	ResponseSigningAlgorithmHMACSHA256 = "hmac-sha256"
+	ResponseSigningAlgorithmEd25519    = "ed25519"
+)
+
+// ResponseSigningConfig configures signed response attestation.
+type ResponseSigningConfig struct {
+	Enabled bool `json:"enabled"`
+	// Algorithm is "ed25519" (default) or "hmac-sha256".
+	Algorithm string `json:"algorithm,omitempty"`
+	// KeyID is sent with every signature so verifiers can pick the right key
+	// during rotation. Defaults to a fingerprint of the key.
+	KeyID string `json:"key_id,omitempty"`
+	// Key is the shared secret for hmac-sha256, or a base64-encoded Ed25519 seed
+	// (32 bytes) or private key (64 bytes) for ed25519. When an ed25519 key is
+	// not set, an ephemeral key pair is generated at startup.
+	Key *schemas.SecretVar `json:"key,omitempty"`
+}
+
+// ResponseAttestation is the signed statement about one response. It is sent
+// base64url-encoded (unpadded) in the x-bifrost-attestation header.
+type ResponseAttestation struct {
+	RequestID     string `json:"request_id"`
+	Model         string `json:"model,omitempty"`
+	Usage         any    `json:"usage,omitempty"`
+	ContentSHA256 string `json:"content_sha256"`
+	IssuedAt      int64  `json:"iat"`
+}
+
+// ResponseSigningKey describes the verification key published at the
+// verification key endpoint. PublicKey is empty for hmac-sha256: the shared
+// secret is never published.
+type ResponseSigningKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key,omitempty"` // base64 (std) Ed25519 public key
+}
+
+// ResponseSigner signs response attestations. It is immutable after creation
+// and safe for concurrent use.
+type ResponseSigner struct {
+	algorithm  string
+	keyID      string
+	hmacKey    []byte
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewResponseSigner builds a signer from config. It returns nil, nil when
+// signing is disabled.
+func NewResponseSigner(config *ResponseSigningConfig) (*ResponseSigner, error) {
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+	signer := &ResponseSigner{algorithm: strings.ToLower(strings.TrimSpace(config.Algorithm))}
+	if signer.algorithm == "" {
+		signer.algorithm = ResponseSigningAlgorithmEd25519
+	}
+	key := strings.TrimSpace(config.Key.GetValue())
+	var fingerprint [sha256.Size]byte
+	switch signer.algorithm {
+	case ResponseSigningAlgorithmHMACSHA256:
+		if key == "" {
+			return nil, fmt.Errorf("response_signing.key is required for %s", ResponseSigningAlgorithmHMACSHA256)
+		}
+		signer.hmacKey = []byte(key)
+		fingerprint = sha256.Sum256(signer.hmacKey)
+	case ResponseSigningAlgorithmEd25519:
+		if key == "" {
+			publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate response signing key: %w", err)
+			}
+			signer.privateKey, signer.publicKey = privateKey, publicKey
+		} else {
+			raw, err := base64.StdEncoding.DecodeString(key)
+			if err != nil {
+				return nil, fmt.Errorf("response_signing.key must be base64-encoded: %w", err)
+			}
+			switch len(raw) {
+			case ed25519.SeedSize:
+				signer.privateKey = ed25519.NewKeyFromSeed(raw)
+			case ed25519.PrivateKeySize:
+				signer.privateKey = ed25519.PrivateKey(raw)
+			default:
+				return nil, fmt.Errorf("response_signing.key must decode to %d or %d bytes, got %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+			}
+			signer.publicKey = signer.privateKey.Public().(ed25519.PublicKey)
+		}
+		fingerprint = sha256.Sum256(signer.publicKey)
+	default:
+		return nil, fmt.Errorf("unsupported response_signing.algorithm %q (expected %s or %s)", config.Algorithm, ResponseSigningAlgorithmEd25519, ResponseSigningAlgorithmHMACSHA256)
+	}
+	signer.keyID = strings.TrimSpace(config.KeyID)
+	if signer.keyID == "" {
+		signer.keyID = hex.EncodeToString(fingerprint[:8])
+	}
+	return signer, nil
+}
+
+// Key returns the verification key description for this signer.
+func (s *ResponseSigner) Key() ResponseSigningKey {
+	key := ResponseSigningKey{Algorithm: s.algorithm, KeyID: s.keyID}
+	if s.publicKey != nil {
+		key.PublicKey = base64.StdEncoding.EncodeToString(s.publicKey)
+	}
+	return key
+}
+
+// Attest builds the attestation for a response body. Usage is taken from the
+// body's top-level "usage" object when present.
+func (s *ResponseSigner) Attest(requestID, model string, body []byte) ResponseAttestation {
+	digest := sha256.Sum256(body)
+	attestation := ResponseAttestation{
+		RequestID:     requestID,
+		Model:         model,
+		ContentSHA256: hex.EncodeToString(digest[:]),
+		IssuedAt:      time.Now().Unix(),
+	}
+	if node, err := sonic.Get(body, "usage"); err == nil {
+		if usage, err := node.Interface(); err == nil {
+			attestation.Usage = usage
+		}
+	}
+	return attestation
+}
+
+// Sign encodes attestation and signs it, returning the header value and its
+// signature (both base64url, unpadded).
+func (s *ResponseSigner) Sign(attestation ResponseAttestation) (string, string, error) {
+	payload, err := sonic.Marshal(attestation)
+	if err != nil {
+		return "", "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	var signature []byte
+	if s.hmacKey != nil {
+		mac := hmac.New(sha256.New, s.hmacKey)
+		mac.Write([]byte(encoded))
+		signature = mac.Sum(nil)
+	} else {
+		signature = ed25519.Sign(s.privateKey, []byte(encoded))
+	}
+	return encoded, base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks signature against the encoded attestation header value.
+func (s *ResponseSigner) Verify(encoded, signature string) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	if s.hmacKey != nil {
+		mac := hmac.New(sha256.New, s.hmacKey)
+		mac.Write([]byte(encoded))
+		return hmac.Equal(raw, mac.Sum(nil))
+	}
+	return ed25519.Verify(s.publicKey, []byte(encoded), raw)
+}
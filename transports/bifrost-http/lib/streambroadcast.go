@@ -0,0 +1,234 @@
+package lib
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// HeaderBifrostBroadcast opts a streaming request into broadcast: other clients
+// holding the same virtual key can then attach to the stream by request ID with
+// GET /v1/streams/{request_id} while it is being generated.
+const HeaderBifrostBroadcast = "x-bf-broadcast"
+
+const (
+	// streamBroadcastLinger is how long a finished stream stays attachable, so a
+	// viewer that arrives just after the last event still gets the whole stream.
+	streamBroadcastLinger = 30 * time.Second
+	// maxStreamBroadcastPrefixBytes caps the events kept for replay to late
+	// subscribers. Past it the stream keeps serving existing subscribers but
+	// refuses new ones, since they could no longer be given the full prefix.
+	maxStreamBroadcastPrefixBytes = 8 << 20
+	// streamSubscriberBuffer is how many live events a subscriber may fall behind
+	// before it is dropped, so one slow viewer never holds back the stream.
+	streamSubscriberBuffer = 256
+)
+
+var (
+	// ErrStreamBroadcastNotFound is returned for an unknown or expired request
+	// ID, and for a virtual key that does not match the originating request's.
+	ErrStreamBroadcastNotFound = errors.New("stream not found")
+	// ErrStreamBroadcastTooLong is returned when the stream's prefix outgrew the
+	// replay buffer before the subscriber attached.
+	ErrStreamBroadcastTooLong = errors.New("stream is too long to replay to new subscribers")
+)
+
+// StreamBroadcasts tracks the in-progress streams that opted into broadcast,
+// keyed by request ID.
+type StreamBroadcasts struct {
+	mu      sync.Mutex
+	streams map[string]*StreamBroadcast
+}
+
+// NewStreamBroadcasts creates an empty broadcast registry.
+func NewStreamBroadcasts() *StreamBroadcasts {
+	return &StreamBroadcasts{streams: make(map[string]*StreamBroadcast)}
+}
+
+// StreamBroadcast fans the events of one stream out to its subscribers and
+// keeps them for replay to subscribers that attach later.
+type StreamBroadcast struct {
+	owner       *StreamBroadcasts
+	requestID   string
+	virtualKey  string
+	contentType string
+
+	mu          sync.Mutex
+	events      [][]byte
+	size        int
+	overflowed  bool
+	done        bool
+	subscribers map[*StreamSubscription]struct{}
+}
+
+// StreamSubscription is one viewer of a broadcast stream. Events yields the
+// stream's prefix followed by its live events, and is closed when the stream
+// ends or the subscriber is dropped for falling behind.
+type StreamSubscription struct {
+	ContentType string
+
+	broadcast *StreamBroadcast
+	events    chan []byte
+	dropped   bool // guarded by broadcast.mu
+	closed    bool // guarded by broadcast.mu
+}
+
+// StartStreamBroadcast registers the stream of the request in ctx for broadcast
+// when the client asked for it with the x-bf-broadcast header, and echoes the
+// request ID subscribers attach with in x-request-id. Returns nil when broadcast
+// was not requested or is unavailable, or when another stream already uses the
+// request ID. Must be called after the response content type is set.
+func StartStreamBroadcast(ctx *fasthttp.RequestCtx, bifrostCtx *schemas.BifrostContext, broadcasts *StreamBroadcasts) *StreamBroadcast {
+	if broadcasts == nil || bifrostCtx == nil || !strings.EqualFold(string(ctx.Request.Header.Peek(HeaderBifrostBroadcast)), "true") {
+		return nil
+	}
+	requestID, _ := bifrostCtx.Value(schemas.BifrostContextKeyRequestID).(string)
+	if requestID == "" {
+		return nil
+	}
+	virtualKey, _ := bifrostCtx.Value(schemas.BifrostContextKeyVirtualKey).(string)
+	broadcast := broadcasts.start(requestID, virtualKey, string(ctx.Response.Header.ContentType()))
+	if broadcast != nil {
+		ctx.Response.Header.Set("x-request-id", requestID)
+	}
+	return broadcast
+}
+
+func (s *StreamBroadcasts) start(requestID, virtualKey, contentType string) *StreamBroadcast {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.streams[requestID]; exists {
+		return nil
+	}
+	broadcast := &StreamBroadcast{
+		owner:       s,
+		requestID:   requestID,
+		virtualKey:  virtualKey,
+		contentType: contentType,
+		subscribers: make(map[*StreamSubscription]struct{}),
+	}
+	s.streams[requestID] = broadcast
+	return broadcast
+}
+
+// Subscribe attaches to the stream of requestID. virtualKey must match the one
+// the originating request used, if it used one.
+func (s *StreamBroadcasts) Subscribe(requestID, virtualKey string) (*StreamSubscription, error) {
+	if s == nil {
+		return nil, ErrStreamBroadcastNotFound
+	}
+	s.mu.Lock()
+	broadcast, ok := s.streams[requestID]
+	s.mu.Unlock()
+	if !ok || subtle.ConstantTimeCompare([]byte(broadcast.virtualKey), []byte(virtualKey)) != 1 {
+		return nil, ErrStreamBroadcastNotFound
+	}
+
+	broadcast.mu.Lock()
+	defer broadcast.mu.Unlock()
+	if broadcast.overflowed {
+		return nil, ErrStreamBroadcastTooLong
+	}
+	// The prefix is queued under the same lock publish takes, so the subscriber
+	// sees every event exactly once whether it arrived before or after attaching.
+	subscription := &StreamSubscription{
+		ContentType: broadcast.contentType,
+		broadcast:   broadcast,
+		events:      make(chan []byte, len(broadcast.events)+streamSubscriberBuffer),
+	}
+	for _, event := range broadcast.events {
+		subscription.events <- event
+	}
+	if broadcast.done {
+		subscription.closed = true
+		close(subscription.events)
+		return subscription, nil
+	}
+	broadcast.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// publish records event for replay and hands it to every subscriber, dropping
+// the ones whose buffer is full.
+func (b *StreamBroadcast) publish(event []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return
+	}
+	if !b.overflowed {
+		if b.size+len(event) > maxStreamBroadcastPrefixBytes {
+			b.overflowed = true
+			b.events = nil
+		} else {
+			b.events = append(b.events, event)
+			b.size += len(event)
+		}
+	}
+	for subscription := range b.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.dropped = true
+			b.closeLocked(subscription)
+		}
+	}
+}
+
+// finish ends the stream for every subscriber and removes it from the registry
+// once late subscribers have had streamBroadcastLinger to attach.
+func (b *StreamBroadcast) finish() {
+	b.mu.Lock()
+	if b.done {
+		b.mu.Unlock()
+		return
+	}
+	b.done = true
+	for subscription := range b.subscribers {
+		b.closeLocked(subscription)
+	}
+	b.mu.Unlock()
+
+	time.AfterFunc(streamBroadcastLinger, func() {
+		b.owner.mu.Lock()
+		if b.owner.streams[b.requestID] == b {
+			delete(b.owner.streams, b.requestID)
+		}
+		b.owner.mu.Unlock()
+	})
+}
+
+func (b *StreamBroadcast) closeLocked(subscription *StreamSubscription) {
+	delete(b.subscribers, subscription)
+	if !subscription.closed {
+		subscription.closed = true
+		close(subscription.events)
+	}
+}
+
+// Events returns the subscription's event channel.
+func (s *StreamSubscription) Events() <-chan []byte {
+	return s.events
+}
+
+// Dropped reports whether the subscription ended because the subscriber fell
+// more than streamSubscriberBuffer events behind, rather than because the
+// stream finished. Only meaningful once Events is closed.
+func (s *StreamSubscription) Dropped() bool {
+	s.broadcast.mu.Lock()
+	defer s.broadcast.mu.Unlock()
+	return s.dropped
+}
+
+// Close detaches the subscriber, for example when its client disconnects.
+// Safe to call more than once.
+func (s *StreamSubscription) Close() {
+	s.broadcast.mu.Lock()
+	defer s.broadcast.mu.Unlock()
+	s.broadcast.closeLocked(s)
+}
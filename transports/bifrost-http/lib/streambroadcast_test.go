@@ -0,0 +1,112 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+func drainSubscription(sub *StreamSubscription) []string {
+	var events []string
+	for event := range sub.Events() {
+		events = append(events, string(event))
+	}
+	return events
+}
+
+func TestStartStreamBroadcastRequiresOptIn(t *testing.T) {
+	broadcasts := NewStreamBroadcasts()
+	bifrostCtx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	bifrostCtx.SetValue(schemas.BifrostContextKeyRequestID, "req-1")
+
+	var ctx fasthttp.RequestCtx
+	if broadcast := StartStreamBroadcast(&ctx, bifrostCtx, broadcasts); broadcast != nil {
+		t.Fatalf("expected no broadcast without %s", HeaderBifrostBroadcast)
+	}
+
+	ctx.Request.Header.Set(HeaderBifrostBroadcast, "true")
+	ctx.SetContentType("text/event-stream")
+	broadcast := StartStreamBroadcast(&ctx, bifrostCtx, broadcasts)
+	if broadcast == nil {
+		t.Fatalf("expected a broadcast once opted in")
+	}
+	if got := string(ctx.Response.Header.Peek("x-request-id")); got != "req-1" {
+		t.Fatalf("expected the request ID to be echoed, got %q", got)
+	}
+	if again := StartStreamBroadcast(&ctx, bifrostCtx, broadcasts); again != nil {
+		t.Fatalf("expected a second stream with the same request ID to be refused")
+	}
+}
+
+func TestStreamBroadcastReplaysPrefixThenLiveEvents(t *testing.T) {
+	broadcasts := NewStreamBroadcasts()
+	broadcast := broadcasts.start("req-1", "sk-bf-vk", "text/event-stream")
+	broadcast.publish([]byte("data: 1\n\n"))
+	broadcast.publish([]byte("data: 2\n\n"))
+
+	if _, err := broadcasts.Subscribe("req-1", "sk-bf-other"); !errors.Is(err, ErrStreamBroadcastNotFound) {
+		t.Fatalf("expected a different virtual key to be refused, got %v", err)
+	}
+	if _, err := broadcasts.Subscribe("req-unknown", "sk-bf-vk"); !errors.Is(err, ErrStreamBroadcastNotFound) {
+		t.Fatalf("expected an unknown request ID to be refused, got %v", err)
+	}
+	sub, err := broadcasts.Subscribe("req-1", "sk-bf-vk")
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	broadcast.publish([]byte("data: 3\n\n"))
+	broadcast.finish()
+
+	got := drainSubscription(sub)
+	want := []string{"data: 1\n\n", "data: 2\n\n", "data: 3\n\n"}
+	if fmt.Sprint(got) != fmt.Sprint(want) || sub.Dropped() {
+		t.Fatalf("expected %q, got %q (dropped=%v)", want, got, sub.Dropped())
+	}
+
+	// A finished stream stays attachable for late viewers.
+	late, err := broadcasts.Subscribe("req-1", "sk-bf-vk")
+	if err != nil {
+		t.Fatalf("late subscribe failed: %v", err)
+	}
+	if got := drainSubscription(late); len(got) != 3 {
+		t.Fatalf("expected the full stream for a late subscriber, got %q", got)
+	}
+}
+
+func TestStreamBroadcastDropsSlowSubscriber(t *testing.T) {
+	broadcasts := NewStreamBroadcasts()
+	broadcast := broadcasts.start("req-1", "", "text/event-stream")
+	slow, err := broadcasts.Subscribe("req-1", "")
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	for i := 0; i <= streamSubscriberBuffer; i++ {
+		broadcast.publish([]byte(fmt.Sprintf("data: %d\n\n", i)))
+	}
+	if got := drainSubscription(slow); len(got) != streamSubscriberBuffer || !slow.Dropped() {
+		t.Fatalf("expected the slow subscriber to be dropped after %d events, got %d (dropped=%v)", streamSubscriberBuffer, len(got), slow.Dropped())
+	}
+
+	// The stream itself carries on for new subscribers.
+	fresh, err := broadcasts.Subscribe("req-1", "")
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	broadcast.finish()
+	if got := drainSubscription(fresh); len(got) != streamSubscriberBuffer+1 {
+		t.Fatalf("expected the full prefix for a new subscriber, got %d events", len(got))
+	}
+}
+
+func TestStreamBroadcastRefusesSubscribersPastReplayLimit(t *testing.T) {
+	broadcasts := NewStreamBroadcasts()
+	broadcast := broadcasts.start("req-1", "", "text/event-stream")
+	broadcast.publish(make([]byte, maxStreamBroadcastPrefixBytes+1))
+	if _, err := broadcasts.Subscribe("req-1", ""); !errors.Is(err, ErrStreamBroadcastTooLong) {
+		t.Fatalf("expected ErrStreamBroadcastTooLong, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package lib
+
+import (
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// StreamPacingRule caps SSE output on a route to TokensPerSecond. Path is an
+// exact request path, or a prefix when it ends with "*" (e.g. "/openai/*").
+// Pacing only affects delivery to the client: the upstream stream is consumed
+// at full speed and usage accounting is unchanged.
+type StreamPacingRule struct {
+	Path            string  `json:"path"`
+	TokensPerSecond float64 `json:"tokens_per_second"`
+}
+
+// StreamPacingFor returns the tokens-per-second limit for path, or 0 when no
+// rule matches. Exact matches win over prefix rules; among prefix rules the
+// first listed match is used.
+func (c *Config) StreamPacingFor(path string) float64 {
+	if c == nil || c.ServerConfig == nil || len(c.ServerConfig.StreamPacing) == 0 {
+		return 0
+	}
+	rules := c.ServerConfig.StreamPacing
+	for _, rule := range rules {
+		if rule.Path == path {
+			return rule.TokensPerSecond
+		}
+	}
+	for _, rule := range rules {
+		if prefix, ok := strings.CutSuffix(rule.Path, "*"); ok && strings.HasPrefix(path, prefix) {
+			return rule.TokensPerSecond
+		}
+	}
+	return 0
+}
+
+// EstimateStreamChunkTokens approximates the output tokens carried by a stream
+// chunk (about four bytes per token of generated text). It is only used to
+// weight pacing delays, never for accounting.
+func EstimateStreamChunkTokens(chunk *schemas.BifrostStreamChunk) int {
+	if chunk == nil {
+		return 0
+	}
+	size := 0
+	if resp := chunk.BifrostChatResponse; resp != nil {
+		for _, choice := range resp.Choices {
+			if choice.ChatStreamResponseChoice != nil && choice.ChatStreamResponseChoice.Delta != nil {
+				delta := choice.ChatStreamResponseChoice.Delta
+				size += len(derefString(delta.Content)) + len(derefString(delta.Reasoning)) + len(derefString(delta.Refusal))
+				for _, toolCall := range delta.ToolCalls {
+					size += len(toolCall.Function.Arguments)
+				}
+			}
+		}
+	}
+	if resp := chunk.BifrostTextCompletionResponse; resp != nil {
+		for _, choice := range resp.Choices {
+			if choice.TextCompletionResponseChoice != nil {
+				size += len(derefString(choice.TextCompletionResponseChoice.Text))
+			}
+		}
+	}
+	if resp := chunk.BifrostResponsesStreamResponse; resp != nil {
+		size += len(derefString(resp.Delta)) + len(derefString(resp.Arguments))
+	}
+	return (size + 3) / 4
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -3,8 +3,19 @@ package lib
 import (
 	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// openSSEStreams counts SSEStreamReaders handed out and not yet closed.
+var openSSEStreams atomic.Int64
+
+// OpenSSEStreams returns how many SSE responses are currently being streamed to
+// clients, across native and integration routes.
+func OpenSSEStreams() int64 {
+	return openSSEStreams.Load()
+}
+
 // SSEStreamReader is an io.ReadCloser that delivers one event per Read call,
 // bypassing fasthttp's internal pipe mechanism (fasthttputil.PipeConns) which
 // batches multiple events into single TCP segments.
@@ -15,17 +26,41 @@ import (
 //  3. Start a producer goroutine that calls Send()/SendEvent()/SendError() for each event
 //  4. Producer calls Done() when finished (closes the event channel)
 //  5. fasthttp calls Close() on write errors (signals producer to stop)
+//
+// With SetPacing, events are queued without blocking the producer and released
+// to the client at a maximum tokens-per-second rate (see Pace).
 type SSEStreamReader struct {
 	eventCh   chan []byte
 	closeCh   chan struct{}
 	closeOnce sync.Once
 	current   []byte // remaining bytes from a partial read
+
+	pacing    *ssePacing       // nil unless SetPacing was called
+	broadcast *StreamBroadcast // nil unless SetBroadcast was called
+}
+
+// ssePacing holds the paced-mode state of an SSEStreamReader. The producer
+// appends to queue under mu and never blocks; Read releases events on schedule.
+type ssePacing struct {
+	interval      time.Duration // time per token
+	mu            sync.Mutex
+	queue         []pacedEvent
+	done          bool
+	notify        chan struct{}
+	pendingTokens int       // tokens for the next sent event (producer only)
+	nextRelease   time.Time // earliest time the next weighted event may be read (reader only)
+}
+
+type pacedEvent struct {
+	data   []byte
+	tokens int
 }
 
 // NewSSEStreamReader creates a new SSEStreamReader with a buffered event channel.
 // Channel capacity of 1 allows one event of pipeline parallelism between
 // the producer goroutine and fasthttp's writeBodyChunked loop.
 func NewSSEStreamReader() *SSEStreamReader {
+	openSSEStreams.Add(1)
 	return &SSEStreamReader{
 		eventCh: make(chan []byte, 1),
 		closeCh: make(chan struct{}),
@@ -37,6 +72,9 @@ func NewSSEStreamReader() *SSEStreamReader {
 // bytes are stored and returned on subsequent calls. Returns io.EOF when Done()
 // has been called and all events have been consumed.
 func (r *SSEStreamReader) Read(p []byte) (int, error) {
+	if r.pacing != nil {
+		return r.readPaced(p)
+	}
 	if len(r.current) == 0 {
 		event, ok := <-r.eventCh
 		if !ok {
@@ -49,12 +87,14 @@ func (r *SSEStreamReader) Read(p []byte) (int, error) {
 	return n, nil
 }
 
-// Close implements io.Closer. Called by fasthttp when writeBodyChunked encounters
-// a write error (client disconnect). Signals the producer goroutine to stop via closeCh.
-// Safe to call multiple times.
+// Close implements io.Closer. Called by fasthttp once the body has been written,
+// including when writeBodyChunked encounters a write error (client disconnect).
+// Signals the producer goroutine to stop via closeCh and ends the stream's count
+// in OpenSSEStreams. Safe to call multiple times.
 func (r *SSEStreamReader) Close() error {
 	r.closeOnce.Do(func() {
 		close(r.closeCh)
+		openSSEStreams.Add(-1)
 	})
 	return nil
 }
@@ -68,6 +108,18 @@ func (r *SSEStreamReader) Send(event []byte) bool {
 		return false
 	default:
 	}
+	if r.broadcast != nil {
+		r.broadcast.publish(event)
+	}
+	if r.pacing != nil {
+		select {
+		case <-r.closeCh:
+			return false
+		default:
+		}
+		r.pacing.enqueue(event)
+		return true
+	}
 	select {
 	case r.eventCh <- event:
 		return true
@@ -110,5 +162,113 @@ func (r *SSEStreamReader) SendDone() bool {
 // Done closes the event channel, signaling to Read that the stream is finished.
 // Must be called exactly once by the producer goroutine when streaming is complete.
 func (r *SSEStreamReader) Done() {
+	if r.broadcast != nil {
+		r.broadcast.finish()
+	}
+	if r.pacing != nil {
+		r.pacing.mu.Lock()
+		r.pacing.done = true
+		r.pacing.mu.Unlock()
+		r.pacing.signal()
+		return
+	}
 	close(r.eventCh)
 }
+
+// SetPacing limits delivery to tokensPerSecond, as weighted by Pace. Events are
+// queued without blocking Send, so upstream consumption and accounting run at
+// full speed while the client receives a smoothed stream. Must be called before
+// the first Send; tokensPerSecond <= 0 leaves the reader unpaced.
+func (r *SSEStreamReader) SetPacing(tokensPerSecond float64) {
+	if tokensPerSecond <= 0 {
+		return
+	}
+	r.pacing = &ssePacing{
+		interval: time.Duration(float64(time.Second) / tokensPerSecond),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// SetBroadcast also hands every event sent to broadcast's subscribers, and ends
+// the broadcast on Done. Subscribers are fed unpaced and never slow the stream.
+// Must be called before the first Send; a nil broadcast is ignored.
+func (r *SSEStreamReader) SetBroadcast(broadcast *StreamBroadcast) {
+	r.broadcast = broadcast
+}
+
+// Pace attributes tokens to the next event sent, delaying its delivery by the
+// time those tokens take at the configured rate. No-op when pacing is off.
+func (r *SSEStreamReader) Pace(tokens int) {
+	if r.pacing != nil && tokens > 0 {
+		r.pacing.pendingTokens += tokens
+	}
+}
+
+func (p *ssePacing) enqueue(data []byte) {
+	p.mu.Lock()
+	p.queue = append(p.queue, pacedEvent{data: data, tokens: p.pendingTokens})
+	p.mu.Unlock()
+	p.pendingTokens = 0
+	p.signal()
+}
+
+func (p *ssePacing) signal() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next blocks until an event is queued and returns it, or returns false once
+// the producer is done and the queue is drained (or the reader is closed).
+func (p *ssePacing) next(closeCh <-chan struct{}) (pacedEvent, bool) {
+	for {
+		p.mu.Lock()
+		if len(p.queue) > 0 {
+			event := p.queue[0]
+			p.queue[0] = pacedEvent{}
+			p.queue = p.queue[1:]
+			p.mu.Unlock()
+			return event, true
+		}
+		done := p.done
+		p.mu.Unlock()
+		if done {
+			return pacedEvent{}, false
+		}
+		select {
+		case <-p.notify:
+		case <-closeCh:
+			return pacedEvent{}, false
+		}
+	}
+}
+
+// readPaced is Read in paced mode: an event carrying tokens is released no
+// earlier than the previous weighted event's release plus its token time.
+func (r *SSEStreamReader) readPaced(p []byte) (int, error) {
+	if len(r.current) == 0 {
+		event, ok := r.pacing.next(r.closeCh)
+		if !ok {
+			return 0, io.EOF
+		}
+		if event.tokens > 0 {
+			now := time.Now()
+			if wait := r.pacing.nextRelease.Sub(now); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-r.closeCh:
+					timer.Stop()
+					return 0, io.EOF
+				}
+				now = r.pacing.nextRelease
+			}
+			r.pacing.nextRelease = now.Add(time.Duration(event.tokens) * r.pacing.interval)
+		}
+		r.current = event.data
+	}
+	n := copy(p, r.current)
+	r.current = r.current[n:]
+	return n, nil
+}
@@ -85,7 +85,7 @@ var server *bifrostServer.BifrostHTTPServer
 //   - port: Server port (default: 8080)
 //   - app-dir: Application data directory (default: current directory)
 //   - log-level: Logger level (debug, info, warn, error). Default is info.
-//   - log-style: Logger output type (json or pretty). Default is JSON.
+//   - log-style: Logger output type (json, pretty or structured). Default is JSON.
 
 func init() {
 	if Version == "" {
@@ -107,7 +107,7 @@ func init() {
 	flag.StringVar(&server.Host, "host", defaultHost, "Host to bind the server to (default: localhost, override with BIFROST_HOST env var)")
 	flag.StringVar(&server.AppDir, "app-dir", bifrostServer.DefaultAppDir, "Application data directory (contains config.json and logs)")
 	flag.StringVar(&server.LogLevel, "log-level", defaultLogLevel, "Logger level (debug, info, warn, error). Default is info.")
-	flag.StringVar(&server.LogOutputStyle, "log-style", bifrostServer.DefaultLogOutputStyle, "Logger output type (json or pretty). Default is JSON.")
+	flag.StringVar(&server.LogOutputStyle, "log-style", bifrostServer.DefaultLogOutputStyle, "Logger output type (json, pretty or structured). Default is JSON.")
 }
 
 // main is the entry point of the application.
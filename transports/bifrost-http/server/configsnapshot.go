@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/configstore"
+	"github.com/maximhq/bifrost/framework/configstore/tables"
+	dynamicPlugins "github.com/maximhq/bifrost/framework/plugins"
+	"github.com/maximhq/bifrost/transports/bifrost-http/handlers"
+	"github.com/maximhq/bifrost/transports/bifrost-http/lib"
+)
+
+// RollbackConfig restores the config store to the snapshot with the given hash
+// and re-applies the restored providers, keys, routing rules and plugins to
+// this server. The current state is captured first, so a rollback can itself
+// be rolled back.
+//
+// The restore is atomic in the config store. Re-applying it in memory is not:
+// when part of it fails, the error says so and a restart loads the restored
+// config.
+func (s *BifrostHTTPServer) RollbackConfig(ctx context.Context, hash string) error {
+	if s.Config == nil || s.Config.ConfigStore == nil {
+		return fmt.Errorf("config store not found")
+	}
+	store := s.Config.ConfigStore
+	if s.ConfigSnapshots != nil {
+		if _, err := s.ConfigSnapshots.Capture(ctx); err != nil {
+			return fmt.Errorf("failed to capture the current config before rollback: %w", err)
+		}
+	}
+	providersBefore, err := store.GetProvidersConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read providers: %w", err)
+	}
+	pluginsBefore, err := store.GetPlugins(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read plugins: %w", err)
+	}
+	if err := store.RestoreConfigSnapshot(ctx, hash); err != nil {
+		return err
+	}
+	logger.Info("config store rolled back to snapshot %s", hash)
+
+	if err := errors.Join(
+		s.reapplyRestoredProviders(ctx, providersBefore),
+		s.reapplyRestoredRoutingRules(ctx),
+		s.reapplyRestoredPlugins(ctx, pluginsBefore),
+	); err != nil {
+		return fmt.Errorf("config restored but not fully applied, restart bifrost to load it: %w", err)
+	}
+	return nil
+}
+
+// reapplyRestoredProviders brings the in-memory providers in line with the
+// restored config store. The config store already holds the restored rows, so
+// every config write here skips the database.
+func (s *BifrostHTTPServer) reapplyRestoredProviders(ctx context.Context, before map[schemas.ModelProvider]configstore.ProviderConfig) error {
+	after, err := s.Config.ConfigStore.GetProvidersConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read restored providers: %w", err)
+	}
+	skipDBCtx := context.WithValue(ctx, schemas.BifrostContextKeySkipDBUpdate, true)
+	var errs []error
+	for provider := range before {
+		if _, ok := after[provider]; ok {
+			continue
+		}
+		if err := s.RemoveProvider(skipDBCtx, provider); err != nil {
+			errs = append(errs, fmt.Errorf("remove provider %s: %w", provider, err))
+		}
+	}
+	for provider, config := range after {
+		if _, err := s.Config.GetProviderConfigRaw(provider); errors.Is(err, lib.ErrNotFound) {
+			if err := s.Config.AddProvider(skipDBCtx, provider, config); err != nil {
+				errs = append(errs, fmt.Errorf("add provider %s: %w", provider, err))
+				continue
+			}
+		} else if previous, ok := before[provider]; ok && reflect.DeepEqual(previous, config) {
+			continue
+		} else if err := s.Config.UpdateProviderConfig(skipDBCtx, provider, config); err != nil {
+			errs = append(errs, fmt.Errorf("update provider %s: %w", provider, err))
+			continue
+		}
+		if _, err := s.ReloadProvider(ctx, provider); err != nil {
+			errs = append(errs, fmt.Errorf("reload provider %s: %w", provider, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reapplyRestoredRoutingRules replaces the governance store's routing rules
+// with the restored ones. Without the governance plugin there is nothing to
+// apply them to.
+func (s *BifrostHTTPServer) reapplyRestoredRoutingRules(ctx context.Context) error {
+	governancePlugin, err := s.getGovernancePlugin()
+	if err != nil {
+		return nil
+	}
+	rules, err := s.Config.ConfigStore.GetRoutingRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read restored routing rules: %w", err)
+	}
+	restored := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		restored[rule.ID] = struct{}{}
+	}
+	var errs []error
+	for _, rule := range governancePlugin.GetGovernanceStore().GetAllRoutingRules(ctx) {
+		if _, ok := restored[rule.ID]; ok {
+			continue
+		}
+		if err := s.RemoveRoutingRule(ctx, rule.ID); err != nil {
+			errs = append(errs, fmt.Errorf("remove routing rule %s: %w", rule.ID, err))
+		}
+	}
+	for _, rule := range rules {
+		if err := s.ReloadRoutingRule(ctx, rule.ID); err != nil {
+			errs = append(errs, fmt.Errorf("reload routing rule %s: %w", rule.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reapplyRestoredPlugins reloads the plugins whose restored row differs from
+// the one they ran with, and unloads the ones the rollback disabled or removed.
+func (s *BifrostHTTPServer) reapplyRestoredPlugins(ctx context.Context, before []*tables.TablePlugin) error {
+	after, err := s.Config.ConfigStore.GetPlugins(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read restored plugins: %w", err)
+	}
+	previous := make(map[string]*tables.TablePlugin, len(before))
+	for _, plugin := range before {
+		previous[plugin.Name] = plugin
+	}
+	disabledCtx := context.WithValue(ctx, handlers.PluginDisabledKey, true)
+	var errs []error
+	for _, plugin := range after {
+		prev, existed := previous[plugin.Name]
+		delete(previous, plugin.Name)
+		if existed && pluginRowUnchanged(prev, plugin) {
+			continue
+		}
+		if plugin.Enabled {
+			if err := s.ReloadPlugin(ctx, plugin.Name, plugin.Path, plugin.Config, plugin.Placement, plugin.Order); err != nil {
+				errs = append(errs, fmt.Errorf("reload plugin %s: %w", plugin.Name, err))
+			}
+			continue
+		}
+		if err := s.RemovePlugin(disabledCtx, plugin.Name); err != nil && !errors.Is(err, dynamicPlugins.ErrPluginNotFound) {
+			errs = append(errs, fmt.Errorf("disable plugin %s: %w", plugin.Name, err))
+		}
+	}
+	for name := range previous {
+		if err := s.RemovePlugin(ctx, name); err != nil && !errors.Is(err, dynamicPlugins.ErrPluginNotFound) {
+			errs = append(errs, fmt.Errorf("remove plugin %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pluginRowUnchanged reports whether two rows of the same plugin load it the
+// same way.
+func pluginRowUnchanged(a, b *tables.TablePlugin) bool {
+	return a.Enabled == b.Enabled &&
+		reflect.DeepEqual(a.Path, b.Path) &&
+		reflect.DeepEqual(a.Placement, b.Placement) &&
+		reflect.DeepEqual(a.Order, b.Order) &&
+		reflect.DeepEqual(a.Config, b.Config)
+}
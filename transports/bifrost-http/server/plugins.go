@@ -7,10 +7,13 @@ import (
 	"slices"
 
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/chaos"
+	"github.com/maximhq/bifrost/plugins/circuitbreaker"
 	"github.com/maximhq/bifrost/plugins/compat"
 	"github.com/maximhq/bifrost/plugins/governance"
 	"github.com/maximhq/bifrost/plugins/logging"
 	"github.com/maximhq/bifrost/plugins/maxim"
+	"github.com/maximhq/bifrost/plugins/mocker"
 	"github.com/maximhq/bifrost/plugins/modelcatalogresolver"
 	"github.com/maximhq/bifrost/plugins/otel"
 	"github.com/maximhq/bifrost/plugins/prompts"
@@ -84,9 +87,17 @@ func loadBuiltinPlugin(ctx context.Context, name string, pluginConfig any, bifro
 		if loggingConfig != nil {
 			loggingConfig.ObjectStorageEnabled = bifrostConfig.LogsStoreConfig != nil &&
 				bifrostConfig.LogsStoreConfig.ObjectStorage != nil
+			if len(loggingConfig.ScrubRules) == 0 && bifrostConfig.LogsStoreConfig != nil {
+				loggingConfig.ScrubRules = bifrostConfig.LogsStoreConfig.ScrubRules
+			}
 		}
-		return logging.Init(ctx, loggingConfig, logger, bifrostConfig.LogsStore,
+		loggingPlugin, err := logging.Init(ctx, loggingConfig, logger, bifrostConfig.LogsStore,
 			bifrostConfig.ModelCatalog, bifrostConfig.MCPCatalog)
+		if err != nil {
+			return nil, err
+		}
+		loggingPlugin.SetDeploymentMetadata(bifrostConfig.Deployment)
+		return loggingPlugin, nil
 
 	case governance.PluginName:
 		governanceConfig, err := MarshalPluginConfig[governance.Config](pluginConfig)
@@ -126,6 +137,27 @@ func loadBuiltinPlugin(ctx context.Context, name string, pluginConfig any, bifro
 		}
 		return compat.Init(*compatConfig, logger, bifrostConfig.ModelCatalog)
 
+	case chaos.PluginName:
+		chaosConfig, err := MarshalPluginConfig[chaos.Config](pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal chaos plugin config: %w", err)
+		}
+		return chaos.Init(chaosConfig, logger)
+
+	case circuitbreaker.PluginName:
+		circuitBreakerConfig, err := MarshalPluginConfig[circuitbreaker.Config](pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal circuit breaker plugin config: %w", err)
+		}
+		return circuitbreaker.Init(*circuitBreakerConfig, logger)
+
+	case mocker.PluginName:
+		mockerConfig, err := MarshalPluginConfig[mocker.MockerConfig](pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal mocker plugin config: %w", err)
+		}
+		return mocker.Init(*mockerConfig)
+
 	case modelcatalogresolver.PluginName:
 		return modelcatalogresolver.Init(bifrostConfig.ModelCatalog, logger)
 
@@ -206,6 +238,7 @@ func (s *BifrostHTTPServer) loadBuiltinPlugins(ctx context.Context) error {
 		}
 		if s.Config.LogsStoreConfig != nil {
 			config.Writer = s.Config.LogsStoreConfig.Writer
+			config.ScrubRules = s.Config.LogsStoreConfig.ScrubRules
 		}
 		s.registerPluginWithStatus(ctx, logging.PluginName, nil, config, false)
 	} else {
@@ -221,6 +254,9 @@ func (s *BifrostHTTPServer) loadBuiltinPlugins(ctx context.Context) error {
 			DisableAutoToolInject: &s.Config.ClientConfig.MCPDisableAutoToolInject,
 			RoutingChainMaxDepth:  &s.Config.ClientConfig.RoutingChainMaxDepth,
 		}
+		if s.Config.GovernanceConfig != nil {
+			config.BudgetAlerts = s.Config.GovernanceConfig.BudgetAlerts
+		}
 		s.registerPluginWithStatus(ctx, governance.PluginName, nil, config, false)
 	} else {
 		s.markPluginDisabled(governance.PluginName)
@@ -252,6 +288,8 @@ func (s *BifrostHTTPServer) loadBuiltinPlugins(ctx context.Context) error {
 		ConvertChatToResponses: cc.ConvertChatToResponses,
 		ShouldDropParams:       cc.ShouldDropParams,
 		ShouldConvertParams:    cc.ShouldConvertParams,
+		TextToChatSystemPrompt: cc.TextToChatSystemPrompt,
+		TextToChatModels:       cc.TextToChatModels,
 	}
 	s.registerPluginWithStatus(ctx, compat.PluginName, nil, compatCfg, false)
 	s.Config.SetPluginOrderInfo(compat.PluginName, builtinPlacement, schemas.Ptr(7))
@@ -265,7 +303,37 @@ func (s *BifrostHTTPServer) loadBuiltinPlugins(ctx context.Context) error {
 	}
 	s.Config.SetPluginOrderInfo(maxim.PluginName, builtinPlacement, schemas.Ptr(8))
 
-	// 9. ModelCatalogResolver (last routing layer — fills req.Provider from catalog only when
+	// 9. Chaos fault injection (if configured in PluginConfigs). Runs after
+	// governance so faults can be scoped by the resolved virtual key.
+	chaosConfig := s.getPluginConfig(chaos.PluginName)
+	if chaosConfig != nil && chaosConfig.Enabled {
+		s.registerPluginWithStatus(ctx, chaos.PluginName, nil, chaosConfig.Config, false)
+	} else {
+		s.markPluginDisabled(chaos.PluginName)
+	}
+	s.Config.SetPluginOrderInfo(chaos.PluginName, builtinPlacement, schemas.Ptr(9))
+
+	// 10. Circuit breaker (if configured in PluginConfigs). Runs after governance
+	// routing so circuits are checked against the provider each attempt resolved to.
+	circuitBreakerConfig := s.getPluginConfig(circuitbreaker.PluginName)
+	if circuitBreakerConfig != nil && circuitBreakerConfig.Enabled {
+		s.registerPluginWithStatus(ctx, circuitbreaker.PluginName, nil, circuitBreakerConfig.Config, false)
+	} else {
+		s.markPluginDisabled(circuitbreaker.PluginName)
+	}
+	s.Config.SetPluginOrderInfo(circuitbreaker.PluginName, builtinPlacement, schemas.Ptr(10))
+
+	// 11. Mocker (if configured in PluginConfigs). Runs after governance and the
+	// circuit breaker so mocked traffic is still budgeted and routed like real traffic.
+	mockerConfig := s.getPluginConfig(mocker.PluginName)
+	if mockerConfig != nil && mockerConfig.Enabled {
+		s.registerPluginWithStatus(ctx, mocker.PluginName, nil, mockerConfig.Config, false)
+	} else {
+		s.markPluginDisabled(mocker.PluginName)
+	}
+	s.Config.SetPluginOrderInfo(mocker.PluginName, builtinPlacement, schemas.Ptr(11))
+
+	// 12. ModelCatalogResolver (last routing layer — fills req.Provider from catalog only when
 	// no earlier routing plugin (governance routing rules, governance VK LB, enterprise LB)
 	// already set one. CEL rules can still match on provider == "" because this runs last.
 	// Requires a model catalog; only register when one is configured.
@@ -19,18 +19,24 @@ import (
 	"github.com/google/uuid"
 	bifrost "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/maximhq/bifrost/framework/chaos"
+	"github.com/maximhq/bifrost/framework/configsnapshot"
 	"github.com/maximhq/bifrost/framework/configstore"
 	"github.com/maximhq/bifrost/framework/configstore/tables"
+	"github.com/maximhq/bifrost/framework/configversion"
 	"github.com/maximhq/bifrost/framework/encrypt"
 	"github.com/maximhq/bifrost/framework/logstore"
 	dynamicPlugins "github.com/maximhq/bifrost/framework/plugins"
+	"github.com/maximhq/bifrost/framework/sessionaffinity"
 	"github.com/maximhq/bifrost/framework/sidekiq"
 	"github.com/maximhq/bifrost/framework/temptoken"
 	"github.com/maximhq/bifrost/framework/tracing"
 	"github.com/maximhq/bifrost/framework/webhooks"
+	"github.com/maximhq/bifrost/plugins/circuitbreaker"
 	"github.com/maximhq/bifrost/plugins/governance"
 	"github.com/maximhq/bifrost/plugins/governance/complexity"
 	"github.com/maximhq/bifrost/plugins/logging"
+	"github.com/maximhq/bifrost/plugins/mocker"
 	"github.com/maximhq/bifrost/plugins/otel"
 	"github.com/maximhq/bifrost/plugins/prompts"
 	"github.com/maximhq/bifrost/plugins/semanticcache"
@@ -72,6 +78,9 @@ type ServerCallbacks interface {
 	GetLoadedPluginNames() []string
 	NormalizePluginConfig(name string, config map[string]any) (map[string]any, error)
 	ExpandPluginConfigForAPI(name string, config map[string]any) (map[string]any, error)
+	GetPluginConfigSchema(name string) []byte
+	GetPluginHealth() []schemas.PluginHealth
+	ResumePlugin(name string) error
 	// Auth related callbacks
 	UpdateAuthConfig(ctx context.Context, authConfig *configstore.AuthConfig) error
 	ReloadClientConfigFromConfigStore(ctx context.Context) error
@@ -130,6 +139,8 @@ type ServerCallbacks interface {
 	ReconnectMCPClient(ctx context.Context, id string) error
 	DisableMCPClient(ctx context.Context, id string) error
 	EnableMCPClient(ctx context.Context, id string) error
+	// Config snapshot related callbacks
+	RollbackConfig(ctx context.Context, hash string) error
 }
 
 // LogRedactionMappingResolverProvider is implemented by servers that can attach reveal data to log-detail responses.
@@ -166,13 +177,17 @@ type BifrostHTTPServer struct {
 	devPprofHandler    *handlers.DevPprofHandler
 	IntegrationHandler *handlers.IntegrationHandler
 
-	AuthMiddleware       *handlers.AuthMiddleware
-	CORSMiddleware       *handlers.CorsMiddleware
-	TracingMiddleware    *handlers.TracingMiddleware
-	WSTicketStore        *handlers.WSTicketStore
-	TempTokens           *temptoken.Service
-	TempTokenSweepWorker *temptoken.SweepWorker
-	OAuth2SweepWorker    *oauth2SweepWorker
+	AuthMiddleware        *handlers.AuthMiddleware
+	CORSMiddleware        *handlers.CorsMiddleware
+	TracingMiddleware     *handlers.TracingMiddleware
+	WSTicketStore         *handlers.WSTicketStore
+	TempTokens            *temptoken.Service
+	TempTokenSweepWorker  *temptoken.SweepWorker
+	SessionAffinity       *sessionaffinity.Store
+	SessionAffinityWorker *sessionaffinity.SweepWorker
+	ConfigVersions        *configversion.Tracker
+	ConfigSnapshots       *configsnapshot.Recorder
+	OAuth2SweepWorker     *oauth2SweepWorker
 	// OAuth2IdentityResolver scopes a user-mode /mcp request to the user's own
 	// tools. Optional; wired at server init when user-mode identity resolution
 	// is available, otherwise left nil (user-mode requests fall back to the
@@ -1298,6 +1313,33 @@ func (s *BifrostHTTPServer) ExpandPluginConfigForAPI(name string, config map[str
 	return nil, nil
 }
 
+// GetPluginConfigSchema implements handlers.PluginsLoader. It returns the config JSON
+// Schema of the loaded plugin, falling back to the ConfigMarshallers cache so disabled
+// built-ins still describe their config. Returns nil when the plugin has no schema.
+func (s *BifrostHTTPServer) GetPluginConfigSchema(name string) []byte {
+	if plugin, err := s.Config.FindPluginByName(name); err == nil {
+		if sp, ok := plugin.(schemas.ConfigSchemaPlugin); ok {
+			return sp.ConfigSchema()
+		}
+	}
+	if m := s.Config.ConfigMarshallers.Load(); m != nil {
+		if sp, ok := (*m)[name].(schemas.ConfigSchemaPlugin); ok {
+			return sp.ConfigSchema()
+		}
+	}
+	return nil
+}
+
+// GetPluginHealth implements handlers.PluginsLoader.
+func (s *BifrostHTTPServer) GetPluginHealth() []schemas.PluginHealth {
+	return s.Client.GetPluginHealth()
+}
+
+// ResumePlugin implements handlers.PluginsLoader.
+func (s *BifrostHTTPServer) ResumePlugin(name string) error {
+	return s.Client.ResumePlugin(name)
+}
+
 // Helper to update error status
 // Uses UpdatePluginOverallStatus to create the status entry if it doesn't exist,
 // ensuring plugins that were never loaded can still have their error status tracked.
@@ -1338,6 +1380,18 @@ func (s *BifrostHTTPServer) SyncLoadedPlugin(ctx context.Context, name string, p
 // to the appropriate arrays based on which interfaces it implements.
 func (s *BifrostHTTPServer) ReloadPlugin(ctx context.Context, name string, path *string, pluginConfig any, placement *schemas.PluginPlacement, order *int) error {
 	logger.Debug("reloading plugin %s", name)
+	// The circuit breaker reloads its config in place so open circuits and their
+	// windows survive a threshold change.
+	if existing := s.circuitBreakerPlugin(); name == circuitbreaker.PluginName && existing != nil {
+		config, err := MarshalPluginConfig[circuitbreaker.Config](pluginConfig)
+		if err != nil {
+			return s.updatePluginErrorStatus(name, "loading", err)
+		}
+		if err := existing.ReloadConfig(*config); err != nil {
+			return s.updatePluginErrorStatus(name, "loading", err)
+		}
+		return s.SyncLoadedPlugin(ctx, name, existing, placement, order)
+	}
 	// 1. Instantiate new version
 	plugin, err := InstantiatePlugin(ctx, name, path, pluginConfig, s.Config)
 	if err != nil {
@@ -1347,6 +1401,19 @@ func (s *BifrostHTTPServer) ReloadPlugin(ctx context.Context, name string, path
 	if semanticCachePlugin, ok := plugin.(*semanticcache.Plugin); ok {
 		semanticCachePlugin.SetEmbeddingRequestExecutor(s.Client.EmbeddingRequest)
 	}
+	if prometheusPlugin, ok := plugin.(*telemetry.PrometheusPlugin); ok {
+		prometheusPlugin.SetProviderQueueStatsSource(s.Client.GetProviderQueueStats)
+		prometheusPlugin.SetGatewayLoadSource(s.gatewayLoad)
+		prometheusPlugin.SetVirtualKeyInFlightSource(s.virtualKeyInFlight)
+		prometheusPlugin.SetLogStoreDualWriteSource(s.logStoreDualWrite)
+		prometheusPlugin.SetSemanticCacheStatsSource(s.semanticCacheStats)
+		prometheusPlugin.SetCircuitBreakerStatsSource(s.circuitBreakerStats)
+		prometheusPlugin.SetConfigVersionSource(s.configVersionStats)
+	}
+	if loggerPlugin, ok := plugin.(*logging.LoggerPlugin); ok && s.WebSocketHandler != nil {
+		loggerPlugin.SetLogCallback(s.WebSocketHandler.BroadcastLogUpdate)
+		s.WebSocketHandler.SetLogManager(loggerPlugin.GetPluginLogManager())
+	}
 	return s.SyncLoadedPlugin(ctx, name, plugin, placement, order)
 }
 
@@ -1468,6 +1535,9 @@ func (s *BifrostHTTPServer) RegisterAPIRoutes(ctx context.Context, callbacks Ser
 		if s.SidekiqRunner != nil && s.Config != nil && s.Config.ConfigStore != nil {
 			loggingHandler.SetSidekiqBackend(s.SidekiqRunner, s.Config.ConfigStore)
 		}
+		if s.Config != nil && s.Config.ConfigStore != nil {
+			loggingHandler.SetLogAccessAuditStore(s.Config.ConfigStore)
+		}
 		govLogManager = loggerPlugin.GetPluginLogManager()
 	}
 	var governanceHandler *handlers.GovernanceHandler
@@ -1503,12 +1573,18 @@ func (s *BifrostHTTPServer) RegisterAPIRoutes(ctx context.Context, callbacks Ser
 	if s.WebSocketHandler == nil {
 		s.WebSocketHandler = handlers.NewWebSocketHandler(s.Ctx, s.Config.ClientConfig.AllowedOrigins)
 	}
+	// Stream log updates to WebSocket subscribers and backfill from the log store
+	if loggerPlugin != nil {
+		loggerPlugin.SetLogCallback(s.WebSocketHandler.BroadcastLogUpdate)
+		s.WebSocketHandler.SetLogManager(loggerPlugin.GetPluginLogManager())
+	}
 	// Start WebSocket heartbeat
 	s.WebSocketHandler.StartHeartbeat()
 	// Adding telemetry middleware
 	// Chaining all middlewares
 	// lib.ChainMiddlewares chains multiple middlewares together
-	healthHandler := handlers.NewHealthHandler(s.Config)
+	healthHandler := handlers.NewHealthHandler(s.Config, s.ConfigVersions)
+	responseSigningHandler := handlers.NewResponseSigningHandler(s.Config.ResponseSigner)
 	providerHandler := handlers.NewProviderHandler(callbacks, s.Config, s.Client)
 	oauthHandler := handlers.NewOAuthHandler(s.Config.OAuthProvider, s.Client, s.Config)
 	mcpHandler := handlers.NewMCPHandler(callbacks, callbacks, s.Client, s.Config, oauthHandler)
@@ -1531,6 +1607,7 @@ func (s *BifrostHTTPServer) RegisterAPIRoutes(ctx context.Context, callbacks Ser
 	oauth2SessionsHandler.RegisterRoutes(s.Router, middlewares...)
 	oauth2ConsentHandler.RegisterRoutes(s.Router, middlewares...)
 	healthHandler.RegisterRoutes(s.Router, middlewares...)
+	responseSigningHandler.RegisterRoutes(s.Router, middlewares...)
 	providerHandler.RegisterRoutes(s.Router, middlewares...)
 	mcpHandler.RegisterRoutes(s.Router, middlewares...)
 	mcpPerUserHeadersHandler.RegisterRoutes(s.Router, middlewares...)
@@ -1540,6 +1617,15 @@ func (s *BifrostHTTPServer) RegisterAPIRoutes(ctx context.Context, callbacks Ser
 	if pluginsHandler != nil {
 		pluginsHandler.RegisterRoutes(s.Router, middlewares...)
 	}
+	if s.Config.ConfigStore != nil {
+		bulkHandler, err := handlers.NewBulkHandler(callbacks, s.Config, s.Config.ConfigStore)
+		if err != nil {
+			return fmt.Errorf("failed to initialize bulk handler: %v", err)
+		}
+		bulkHandler.RegisterRoutes(s.Router, middlewares...)
+		configSnapshotHandler := handlers.NewConfigSnapshotHandler(s.Config.ConfigStore, callbacks)
+		configSnapshotHandler.RegisterRoutes(s.Router, middlewares...)
+	}
 	if sessionHandler != nil {
 		sessionHandler.RegisterRoutes(s.Router, middlewares...)
 	}
@@ -1557,6 +1643,37 @@ func (s *BifrostHTTPServer) RegisterAPIRoutes(ctx context.Context, callbacks Ser
 		skillsServingHandler.RegisterRoutes(s.Router, middlewares...)
 	}
 	cacheHandler.RegisterRoutes(s.Router, middlewares...)
+	chaosHandler := handlers.NewChaosHandler(func() handlers.FaultInjector {
+		p, err := lib.FindPluginAs[*chaos.Plugin](s.Config, chaos.PluginName)
+		if err != nil || p == nil {
+			return nil
+		}
+		return p
+	})
+	chaosHandler.RegisterRoutes(s.Router, middlewares...)
+	circuitBreakerHandler := handlers.NewCircuitBreakerHandler(func() handlers.CircuitBreakerAdmin {
+		if p := s.circuitBreakerPlugin(); p != nil {
+			return p.Breaker()
+		}
+		return nil
+	})
+	circuitBreakerHandler.RegisterRoutes(s.Router, middlewares...)
+	mockerHandler := handlers.NewMockerHandler(func() handlers.MockerAdmin {
+		p, err := lib.FindPluginAs[*mocker.MockerPlugin](s.Config, mocker.PluginName)
+		if err != nil || p == nil {
+			return nil
+		}
+		return p
+	})
+	mockerHandler.RegisterRoutes(s.Router, middlewares...)
+	runtimeHandler := handlers.NewRuntimeHandler(s.Config, s.Client, func() handlers.WriteQueueStatsProvider {
+		p, err := lib.FindPluginAs[*logging.LoggerPlugin](s.Config, logging.PluginName)
+		if err != nil || p == nil {
+			return nil
+		}
+		return p
+	})
+	runtimeHandler.RegisterRoutes(s.Router, middlewares...)
 	if featureFlagsHandler != nil {
 		featureFlagsHandler.RegisterRoutes(s.Router, middlewares...)
 	}
@@ -1719,6 +1836,158 @@ func startSkillsOrphanCleanupWorker(ctx context.Context, config *lib.Config) {
 	}()
 }
 
+// gatewayLoad reports the gateway-wide load the telemetry plugin exports for autoscaling.
+func (s *BifrostHTTPServer) gatewayLoad() telemetry.GatewayLoad {
+	return telemetry.GatewayLoad{
+		InFlightRequests: s.Client.GetInFlightRequests(),
+		SSEConnections:   lib.OpenSSEStreams(),
+	}
+}
+
+// virtualKeyInFlight reports the requests in flight per virtual key from the
+// governance plugin. The plugin is looked up on every scrape so reloads and
+// enterprise governance plugins without in-flight tracking are handled.
+func (s *BifrostHTTPServer) virtualKeyInFlight() []telemetry.VirtualKeyInFlight {
+	governancePlugin, err := s.getGovernancePlugin()
+	if err != nil || governancePlugin == nil {
+		return nil
+	}
+	tracker, ok := governancePlugin.(interface {
+		InFlightRequestsByVirtualKey() []governance.VirtualKeyInFlight
+	})
+	if !ok {
+		return nil
+	}
+	counts := tracker.InFlightRequestsByVirtualKey()
+	result := make([]telemetry.VirtualKeyInFlight, 0, len(counts))
+	for _, vk := range counts {
+		result = append(result, telemetry.VirtualKeyInFlight(vk))
+	}
+	return result
+}
+
+// logStoreDualWrite reports how far the shadow log store has drifted from the
+// primary, or nil when log writes are not mirrored.
+func (s *BifrostHTTPServer) logStoreDualWrite() *telemetry.LogStoreDualWrite {
+	if s.Config == nil {
+		return nil
+	}
+	dualWrite, ok := s.Config.LogsStore.(*logstore.DualWriteLogStore)
+	if !ok {
+		return nil
+	}
+	stats := dualWrite.Stats()
+	return &telemetry.LogStoreDualWrite{
+		PrimaryType:       string(stats.PrimaryType),
+		ShadowType:        string(stats.ShadowType),
+		ShadowWrites:      stats.ShadowWrites,
+		ShadowWriteErrors: stats.ShadowWriteErrors,
+		Compared:          stats.Compared,
+		Missing:           stats.Missing,
+		Mismatched:        stats.Mismatched,
+	}
+}
+
+// semanticCacheStats reports the semantic cache's lookup counters, or nil when
+// the semantic cache plugin is not loaded.
+func (s *BifrostHTTPServer) semanticCacheStats() *telemetry.SemanticCacheStats {
+	if s.Config == nil {
+		return nil
+	}
+	plugin, err := lib.FindPluginAs[*semanticcache.Plugin](s.Config, semanticcache.PluginName)
+	if err != nil || plugin == nil {
+		return nil
+	}
+	stats := plugin.GetStats()
+	lookups := func(l semanticcache.LookupStats) telemetry.SemanticCacheLookups {
+		return telemetry.SemanticCacheLookups{
+			Lookups:       l.Lookups,
+			DirectHits:    l.DirectHits,
+			SemanticHits:  l.SemanticHits,
+			Misses:        l.Misses,
+			StoreFailures: l.StoreFailures,
+		}
+	}
+	result := &telemetry.SemanticCacheStats{
+		SemanticCacheLookups: lookups(stats.LookupStats),
+		ByCacheKey:           make(map[string]telemetry.SemanticCacheLookups, len(stats.ByCacheKey)),
+	}
+	for cacheKey, keyStats := range stats.ByCacheKey {
+		result.ByCacheKey[cacheKey] = lookups(keyStats)
+	}
+	return result
+}
+
+// configVersionStats reports how this replica's config compares with the
+// other replicas', or nil when there is no config store to compare through.
+func (s *BifrostHTTPServer) configVersionStats() *telemetry.ConfigVersionStats {
+	if s.ConfigVersions == nil {
+		return nil
+	}
+	status := s.ConfigVersions.Status()
+	stats := &telemetry.ConfigVersionStats{
+		State:          string(status.State),
+		ReplicasByHash: make(map[string]int, len(status.Replicas)),
+	}
+	for _, replica := range status.Replicas {
+		stats.ReplicasByHash[replica.ConfigHash]++
+	}
+	return stats
+}
+
+// circuitBreakerStats reports the circuit breaker's circuits, or nil when the
+// circuit breaker plugin is not loaded.
+func (s *BifrostHTTPServer) circuitBreakerStats() []telemetry.CircuitBreakerCircuit {
+	plugin := s.circuitBreakerPlugin()
+	if plugin == nil {
+		return nil
+	}
+	snapshots := plugin.Breaker().Snapshot()
+	circuits := make([]telemetry.CircuitBreakerCircuit, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		circuit := telemetry.CircuitBreakerCircuit{
+			Provider:    string(snapshot.Provider),
+			Model:       snapshot.Model,
+			KeyID:       snapshot.KeyID,
+			State:       string(snapshot.State),
+			FailureRate: snapshot.FailureRate,
+			Transitions: make([]telemetry.CircuitBreakerTransitions, 0, len(snapshot.TransitionCounts)),
+		}
+		for _, count := range snapshot.TransitionCounts {
+			circuit.Transitions = append(circuit.Transitions, telemetry.CircuitBreakerTransitions{
+				From:  string(count.From),
+				To:    string(count.To),
+				Count: count.Count,
+			})
+		}
+		circuits = append(circuits, circuit)
+	}
+	return circuits
+}
+
+// circuitBreakerKeyPoolFilter lets the circuit breaker plugin drop keys whose
+// circuits are open when it tracks circuits per key. All keys are eligible when
+// the plugin is not loaded.
+func (s *BifrostHTTPServer) circuitBreakerKeyPoolFilter(ctx *schemas.BifrostContext, provider schemas.ModelProvider, model string, keys []schemas.Key) ([]schemas.Key, error) {
+	plugin := s.circuitBreakerPlugin()
+	if plugin == nil {
+		return keys, nil
+	}
+	return plugin.KeyPoolFilter(ctx, provider, model, keys)
+}
+
+// circuitBreakerPlugin returns the loaded circuit breaker plugin, or nil.
+func (s *BifrostHTTPServer) circuitBreakerPlugin() *circuitbreaker.Plugin {
+	if s.Config == nil {
+		return nil
+	}
+	plugin, err := lib.FindPluginAs[*circuitbreaker.Plugin](s.Config, circuitbreaker.PluginName)
+	if err != nil {
+		return nil
+	}
+	return plugin
+}
+
 // Bootstrap initializes the Bifrost HTTP server with all necessary components.
 // It:
 // 1. Initializes Prometheus collectors for monitoring
@@ -1744,6 +2013,10 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config %v", err)
 	}
+	if s.Config.ServerConfig != nil && s.Config.ServerConfig.LogStyle != "" {
+		s.LogOutputStyle = s.Config.ServerConfig.LogStyle
+		logger.SetOutputType(schemas.LoggerOutputType(s.LogOutputStyle))
+	}
 	if s.Config.KVStore != nil {
 		integrations.RegisterKVDecoders(s.Config.KVStore)
 	}
@@ -1757,6 +2030,7 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	if s.Config.LogsStore != nil {
 		// If log retention days remains 0, then we wont be initializing the log retention cleaner
 		logRetentionDays := 0
+		var retentionConfig *tables.RetentionConfig
 		if s.Config.ConfigStore != nil {
 			// Get logs store config from config store
 			clientConfig, err := s.Config.ConfigStore.GetClientConfig(ctx)
@@ -1766,10 +2040,12 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 			}
 			if clientConfig != nil {
 				logRetentionDays = clientConfig.LogRetentionDays
+				retentionConfig = clientConfig.RetentionConfig
 			}
 		} else {
 			// We will check if the config file has the log retention days set
 			logRetentionDays = s.Config.ClientConfig.LogRetentionDays
+			retentionConfig = s.Config.ClientConfig.RetentionConfig
 		}
 		logger.Info("log retention days: %d", logRetentionDays)
 		if logRetentionDays > 0 {
@@ -1778,7 +2054,24 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 				cleanerConfig := logstore.CleanerConfig{
 					RetentionDays: logRetentionDays,
 				}
+				if retentionConfig != nil {
+					cleanerConfig.MCPToolLogRetentionDays = retentionConfig.MCPToolLogDays
+					cleanerConfig.RawPayloadRetentionDays = retentionConfig.RawPayloadDays
+					cleanerConfig.AuditRetentionDays = retentionConfig.AuditRecordDays
+					cleanerConfig.VirtualKeyOverrides = make(map[string]logstore.VirtualKeyRetention, len(retentionConfig.VirtualKeys))
+					for id, override := range retentionConfig.VirtualKeys {
+						cleanerConfig.VirtualKeyOverrides[id] = logstore.VirtualKeyRetention{
+							RetentionDays:           override.LogDays,
+							MCPToolLogRetentionDays: override.MCPToolLogDays,
+							RawPayloadRetentionDays: override.RawPayloadDays,
+						}
+					}
+				}
 				s.LogsCleaner = logstore.NewLogsCleaner(rdbStore, cleanerConfig, logger)
+				// Audit records live in the config store
+				if auditStore, ok := s.Config.ConfigStore.(logstore.AuditRetentionManager); ok {
+					s.LogsCleaner.SetAuditRetentionManager(auditStore)
+				}
 				s.LogsCleaner.StartCleanupRoutine()
 				logger.Info("log retention cleaner initialized with %d days retention",
 					logRetentionDays)
@@ -1832,22 +2125,50 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	// Create account backed by the high-performance store (all processing is done in LoadFromDatabase)
 	// The account interface now benefits from ultra-fast config access times via in-memory storage
 	account := lib.NewBaseAccount(s.Config)
+	// Session affinity pins x-bf-session-id conversations to the provider, model and
+	// key that served them; it needs the config store to survive restarts and be
+	// shared across nodes.
+	var sessionAffinityStore schemas.SessionAffinityStore
+	if s.Config.ConfigStore != nil {
+		s.SessionAffinity = sessionaffinity.NewStore(s.Config.ConfigStore)
+		sessionAffinityStore = s.SessionAffinity
+	}
 	s.Client, err = bifrost.Init(ctx, schemas.BifrostConfig{
-		Account:            account,
-		InitialPoolSize:    s.Config.ClientConfig.InitialPoolSize,
-		DropExcessRequests: s.Config.ClientConfig.DropExcessRequests,
-		LLMPlugins:         s.Config.GetLoadedLLMPlugins(),
-		MCPPlugins:         s.Config.GetLoadedMCPPlugins(),
-		MCPConfig:          mcpConfig,
-		OAuth2Provider:     s.Config.OAuthProvider,
-		MCPHeadersProvider: s.Config.MCPHeadersProvider,
-		Logger:             logger,
-		KVStore:            s.Config.KVStore,
+		Account:              account,
+		InitialPoolSize:      s.Config.ClientConfig.InitialPoolSize,
+		DropExcessRequests:   s.Config.ClientConfig.DropExcessRequests,
+		LLMPlugins:           s.Config.GetLoadedLLMPlugins(),
+		MCPPlugins:           s.Config.GetLoadedMCPPlugins(),
+		MCPConfig:            mcpConfig,
+		OAuth2Provider:       s.Config.OAuthProvider,
+		MCPHeadersProvider:   s.Config.MCPHeadersProvider,
+		Logger:               logger,
+		KVStore:              s.Config.KVStore,
+		SessionAffinityStore: sessionAffinityStore,
+		ModelGroups:          s.Config.ModelGroups,
+		RetryBudget:          s.Config.RetryBudget,
+		ImagePreprocessing:   s.Config.ImagePreprocessing,
+		ConfidentialFields:   s.Config.ConfidentialFields,
+		RequestFlags:         s.Config.RequestFlags,
+		Region:               s.Config.Deployment.Region,
+		KeyPoolFilter:        s.circuitBreakerKeyPoolFilter,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize bifrost: %v", err)
 	}
 	logger.Info("bifrost client initialized")
+	s.SessionAffinityWorker = sessionaffinity.NewSweepWorker(s.SessionAffinity, logger)
+	if s.SessionAffinityWorker != nil {
+		s.SessionAffinityWorker.Start(s.Ctx)
+	}
+	s.ConfigVersions = configversion.NewTracker(s.Config.ConfigStore, s.Config.Deployment.InstanceID, s.Version, s.Config.ConfigVersionHash, logger)
+	if s.ConfigVersions != nil {
+		s.ConfigVersions.Start(s.Ctx)
+	}
+	s.ConfigSnapshots = configsnapshot.NewRecorder(s.Config.ConfigStore, logger)
+	if s.ConfigSnapshots != nil {
+		s.ConfigSnapshots.Start(s.Ctx)
+	}
 	// Sync plugin execution order from config to core (defensive — Init receives sorted list,
 	// but this ensures order consistency if the loading path changes in the future)
 	s.Client.ReorderPlugins(s.Config.GetPluginOrder())
@@ -1941,6 +2262,16 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	if err == nil && semanticCachePlugin != nil {
 		semanticCachePlugin.SetEmbeddingRequestExecutor(s.Client.EmbeddingRequest)
 	}
+	// Export provider queue depths, gateway load and per virtual key in-flight requests through the telemetry plugin if it exists
+	if prometheusPlugin, err := lib.FindPluginAs[*telemetry.PrometheusPlugin](s.Config, telemetry.PluginName); err == nil && prometheusPlugin != nil {
+		prometheusPlugin.SetProviderQueueStatsSource(s.Client.GetProviderQueueStats)
+		prometheusPlugin.SetGatewayLoadSource(s.gatewayLoad)
+		prometheusPlugin.SetVirtualKeyInFlightSource(s.virtualKeyInFlight)
+		prometheusPlugin.SetLogStoreDualWriteSource(s.logStoreDualWrite)
+		prometheusPlugin.SetSemanticCacheStatsSource(s.semanticCacheStats)
+		prometheusPlugin.SetCircuitBreakerStatsSource(s.circuitBreakerStats)
+		prometheusPlugin.SetConfigVersionSource(s.configVersionStats)
+	}
 
 	// Initialize Sidekiq runner for background jobs
 	if s.Config != nil && s.Config.ConfigStore != nil {
@@ -1980,6 +2311,7 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	tracer.SetObservabilityPlugins(observabilityPlugins)
 	s.Client.SetTracer(tracer)
 	s.TracingMiddleware = handlers.NewTracingMiddleware(tracer)
+	s.TracingMiddleware.SetDeploymentMetadata(s.Config.Deployment)
 	// TransportInterceptor must be inside TracingMiddleware so that the tracing defer
 	// runs AFTER transport post-hooks (capturing HTTPTransportPostHook plugin logs).
 	// Order: Tracing.pre → TransportInterceptor.pre → handler → TransportInterceptor.post → Tracing.defer
@@ -2029,7 +2361,7 @@ func (s *BifrostHTTPServer) Bootstrap(ctx context.Context) error {
 	logger.Debug("server read buffer size: %d", s.Config.ServerConfig.ReadBufferSize)
 	// Create fasthttp server instance
 	s.Server = &fasthttp.Server{
-		Handler:            handlers.SecurityHeadersMiddleware()(s.CORSMiddleware.Middleware()(handlers.RequestDecompressionMiddleware(s.Config)(s.Router.Handler))),
+		Handler:            handlers.SecurityHeadersMiddleware()(handlers.DeploymentHeadersMiddleware(s.Config.Deployment)(handlers.ResponseSigningMiddleware(s.Config.ResponseSigner)(s.CORSMiddleware.Middleware()(handlers.RequestDecompressionMiddleware(s.Config)(s.Router.Handler))))),
 		MaxRequestBodySize: s.Config.ClientConfig.MaxRequestBodySizeMB * 1024 * 1024,
 		ReadBufferSize:     s.Config.ServerConfig.ReadBufferSize,
 	}
@@ -2111,6 +2443,18 @@ func (s *BifrostHTTPServer) Start() error {
 				logger.Info("stopping temp-token sweep worker...")
 				s.TempTokenSweepWorker.Stop()
 			}
+			if s.SessionAffinityWorker != nil {
+				logger.Info("stopping session affinity sweep worker...")
+				s.SessionAffinityWorker.Stop()
+			}
+			if s.ConfigVersions != nil {
+				logger.Info("stopping config version tracker...")
+				s.ConfigVersions.Stop()
+			}
+			if s.ConfigSnapshots != nil {
+				logger.Info("stopping config snapshot recorder...")
+				s.ConfigSnapshots.Stop()
+			}
 			if s.OAuth2SweepWorker != nil {
 				logger.Info("stopping oauth2 sweep worker...")
 				s.OAuth2SweepWorker.stop()
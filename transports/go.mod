@@ -17,10 +17,12 @@ require (
 	github.com/mark3labs/mcp-go v0.43.2
 	github.com/maximhq/bifrost/core v1.7.4
 	github.com/maximhq/bifrost/framework v1.5.4
+	github.com/maximhq/bifrost/plugins/circuitbreaker v1.0.0
 	github.com/maximhq/bifrost/plugins/compat v0.1.30
 	github.com/maximhq/bifrost/plugins/governance v1.6.8
 	github.com/maximhq/bifrost/plugins/logging v1.6.4
 	github.com/maximhq/bifrost/plugins/maxim v1.6.31
+	github.com/maximhq/bifrost/plugins/mocker v1.5.31
 	github.com/maximhq/bifrost/plugins/modelcatalogresolver v1.0.12
 	github.com/maximhq/bifrost/plugins/otel v1.4.3
 	github.com/maximhq/bifrost/plugins/prompts v1.0.31
@@ -152,7 +154,6 @@ require (
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-sqlite3 v1.14.32 // indirect
-	github.com/maximhq/bifrost/plugins/mocker v1.5.31 // indirect
 	github.com/maximhq/maxim-go v0.2.1 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/oapi-codegen/runtime v1.1.1 // indirect
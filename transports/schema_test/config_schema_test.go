@@ -249,7 +249,10 @@ func TestSchemaLogsStoreWriterConfig(t *testing.T) {
 				"batch_interval": "2s",
 				"max_batch_bytes": 1048576,
 				"write_queue_capacity": 2000,
-				"deferred_usage_concurrency": 3
+				"deferred_usage_concurrency": 3,
+				"overflow_policy": "spill",
+				"block_timeout": "500ms",
+				"spill_dir": "/var/lib/bifrost/spill"
 			}
 		}
 	}`
@@ -281,6 +284,10 @@ func TestSchemaLogsStoreWriterConfig(t *testing.T) {
 			name:   "rejects zero deferred usage concurrency",
 			writer: `"deferred_usage_concurrency": 0`,
 		},
+		{
+			name:   "rejects unknown overflow policy",
+			writer: `"overflow_policy": "retry"`,
+		},
 		{
 			name:   "rejects unknown writer field",
 			writer: `"unknown": 1`,
@@ -567,6 +574,28 @@ func TestSchemaKeyAliases(t *testing.T) {
 			t.Errorf("azure key with aliases should be valid, got: %v", err)
 		}
 	})
+
+	t.Run("azure entra auth types", func(t *testing.T) {
+		compiled := compileSchema(t)
+		tests := []struct {
+			name      string
+			keyConfig string
+			wantError bool
+		}{
+			{name: "user-assigned managed identity", keyConfig: `{"endpoint": "https://r.openai.azure.com", "auth_type": "managed_identity", "client_id": "cid"}`},
+			{name: "system-assigned managed identity", keyConfig: `{"endpoint": "https://r.openai.azure.com", "auth_type": "managed_identity"}`},
+			{name: "client secret", keyConfig: `{"endpoint": "https://r.openai.azure.com", "auth_type": "client_secret", "client_id": "cid", "client_secret": "s", "tenant_id": "t"}`},
+			{name: "client id alone without managed identity", keyConfig: `{"endpoint": "https://r.openai.azure.com", "client_id": "cid"}`, wantError: true},
+			{name: "unknown auth type", keyConfig: `{"endpoint": "https://r.openai.azure.com", "auth_type": "certificate"}`, wantError: true},
+		}
+		for _, tt := range tests {
+			config := `{"providers": {"azure": {"keys": [{"name": "k", "value": "", "weight": 1, "models": ["*"], "azure_key_config": ` + tt.keyConfig + `}]}}}`
+			err := validateConfig(t, compiled, config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("%s: wantError=%v, got %v", tt.name, tt.wantError, err)
+			}
+		}
+	})
 }
 
 func TestSchemaGovernanceModelConfigs(t *testing.T) {
@@ -813,6 +842,7 @@ func TestSchemaMCPClientConfigFields(t *testing.T) {
 		"tools_to_execute",
 		"tools_to_auto_execute",
 		"tool_sync_interval",
+		"output_schema_validation",
 	}
 	for _, field := range fields {
 		t.Run("mcp_client_config has "+field, func(t *testing.T) {
@@ -835,6 +865,7 @@ func TestSchemaMCPClientConfigFields(t *testing.T) {
 					"auth_type": "none",
 					"tools_to_execute": ["*"],
 					"tools_to_auto_execute": [],
+					"output_schema_validation": "coerce",
 					"stdio_config": {
 						"command": "npx",
 						"args": ["-y", "@modelcontextprotocol/server-filesystem"]
@@ -1031,3 +1062,163 @@ func TestSchemaBedrockKeyConfigSTSFields(t *testing.T) {
 		}
 	})
 }
+
+func TestSchemaKeyBetaFeatures(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name         string
+		betaFeatures string
+		wantError    bool
+	}{
+		{name: "allowlist and defaults", betaFeatures: `{"allowlist": {"anthropic-beta": ["prompt-caching-2024-07-31", "computer-use-*"]}, "defaults": {"anthropic-beta": ["prompt-caching-2024-07-31"]}}`},
+		{name: "empty allowlist blocks header", betaFeatures: `{"allowlist": {"openai-beta": []}}`},
+		{name: "bare wildcard", betaFeatures: `{"allowlist": {"anthropic-beta": ["*"]}}`, wantError: true},
+		{name: "empty flag", betaFeatures: `{"defaults": {"anthropic-beta": [""]}}`, wantError: true},
+		{name: "unknown field", betaFeatures: `{"blocklist": {"anthropic-beta": ["x"]}}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := `{"providers": {"anthropic": {"keys": [{"name": "k", "value": "sk-test", "weight": 1, "models": ["*"], "beta_features": ` + tt.betaFeatures + `}]}}}`
+			err := validateConfig(t, compiled, config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaDeploymentMetadata(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{name: "region and instance", config: `{"deployment": {"region": "eu-west-1", "instance_id": "bifrost-eu-1"}}`},
+		{name: "unknown deployment field", config: `{"deployment": {"zone": "eu-west-1a"}}`, wantError: true},
+		{name: "regional base urls", config: `{"providers": {"openai": {"keys": [{"name": "k", "value": "sk-test", "weight": 1, "models": ["*"]}], "network_config": {"base_url": "https://api.openai.com", "regional_base_urls": {"eu-west-1": "https://eu.api.openai.com"}}}}}`},
+		{name: "regional base url not a string", config: `{"providers": {"openai": {"keys": [{"name": "k", "value": "sk-test", "weight": 1, "models": ["*"]}], "network_config": {"regional_base_urls": {"eu-west-1": 1}}}}}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(t, compiled, tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaResponseSigning(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{name: "ed25519 with ephemeral key", config: `{"response_signing": {"enabled": true}}`},
+		{name: "hmac with env key", config: `{"response_signing": {"enabled": true, "algorithm": "hmac-sha256", "key_id": "k1", "key": "env.BIFROST_SIGNING_KEY"}}`},
+		{name: "unknown algorithm", config: `{"response_signing": {"enabled": true, "algorithm": "rsa"}}`, wantError: true},
+		{name: "unknown field", config: `{"response_signing": {"enabled": true, "secret": "x"}}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(t, compiled, tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaChaosPlugin(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{name: "startup faults", config: `{"plugins": [{"name": "chaos", "enabled": true, "config": {"faults": [{"kind": "error", "scope": {"provider": "openai"}, "error_class": "rate_limit", "percentage": 10, "ttl": "30m"}, {"kind": "abort_stream", "after_chunks": 5}]}}]}`},
+		{name: "no config", config: `{"plugins": [{"name": "chaos", "enabled": true}]}`},
+		{name: "unknown kind", config: `{"plugins": [{"name": "chaos", "enabled": true, "config": {"faults": [{"kind": "explode"}]}}]}`, wantError: true},
+		{name: "zero percentage", config: `{"plugins": [{"name": "chaos", "enabled": true, "config": {"faults": [{"kind": "error", "percentage": 0}]}}]}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(t, compiled, tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaServerStreamPacing(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{name: "exact and prefix rules", config: `{"server": {"read_buffer_size": 65536, "stream_pacing": [{"path": "/v1/chat/completions", "tokens_per_second": 40}, {"path": "/openai/*", "tokens_per_second": 25.5}]}}`},
+		{name: "zero rate", config: `{"server": {"read_buffer_size": 65536, "stream_pacing": [{"path": "/v1/chat/completions", "tokens_per_second": 0}]}}`, wantError: true},
+		{name: "missing path", config: `{"server": {"read_buffer_size": 65536, "stream_pacing": [{"tokens_per_second": 40}]}}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(t, compiled, tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaVirtualKeyDefaults(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		defaults  string
+		wantError bool
+	}{
+		{name: "fill presets", defaults: `{"model": "openai/gpt-4o-mini", "temperature": 0.2, "max_tokens": 512, "system_prompt": "Be brief."}`},
+		{name: "force mode", defaults: `{"mode": "force", "model": "gpt-4o-mini"}`},
+		{name: "unknown mode", defaults: `{"mode": "merge"}`, wantError: true},
+		{name: "temperature out of range", defaults: `{"temperature": 3}`, wantError: true},
+		{name: "unknown field", defaults: `{"top_k": 5}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := `{"governance": {"virtual_keys": [{"id": "vk-1", "name": "internal-tools", "value": "sk-bf-internal", "defaults": ` + tt.defaults + `}]}}`
+			err := validateConfig(t, compiled, config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSchemaCircuitBreakerPlugin(t *testing.T) {
+	compiled := compileSchema(t)
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{name: "per model with degradation", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"granularity": "provider+model", "failure_rate_threshold": 0.5, "minimum_requests": 10, "window_size": 20, "cooldown_period": 30000000000, "degradation": [{"provider": "openai", "models": ["gpt-4o"], "target_provider": "ollama", "target_model": "llama3"}]}}]}`},
+		{name: "no config", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true}]}`},
+		{name: "slow start and adaptive threshold", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"slow_start": {"steps": [0.1, 0.5], "duration": 60000000000}, "adaptive_threshold": {"multiplier": 3, "minimum_threshold": 0.05, "baseline_requests": 500}}}]}`},
+		{name: "slow start step of one", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"slow_start": {"steps": [1]}}}]}`, wantError: true},
+		{name: "unknown granularity", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"granularity": "region"}}]}`, wantError: true},
+		{name: "threshold above one", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"failure_rate_threshold": 1.5}}]}`, wantError: true},
+		{name: "rule without target", config: `{"plugins": [{"name": "circuit-breaker", "enabled": true, "config": {"degradation": [{"provider": "openai"}]}}]}`, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(t, compiled, tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("wantError=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}